@@ -0,0 +1,125 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// auditCommand is mie audit.
+var auditCommand = command{
+	name:    "audit",
+	summary: "Show the log of writes to the memory graph",
+	usage:   "[options]",
+	help: `  Show the audit log of writes to the memory graph, newest first. Every
+  store, update, invalidation, relationship change, merge, and import is
+  recorded with its time, tool, source agent, and affected node IDs.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.IntP("tail", "n", 50, "Number of most recent entries to show")
+		fs.String("node", "", "Only show writes that affected this node ID")
+		fs.String("agent", "", "Only show writes made by this source agent")
+		fs.String("tool", "", "Only show writes made through this tool (e.g. mie_update)")
+	},
+	examples: `  mie audit                       Last 50 writes
+  mie audit --tail 200            Last 200 writes
+  mie audit --node fact:abc123    History of one node
+  mie audit --agent claude --json Writes by one agent as JSON`,
+	run: runAudit,
+}
+
+// runAudit prints the most recent entries of the audit log.
+func runAudit(fs *flag.FlagSet, configPath string, globals GlobalFlags) {
+	tail, _ := fs.GetInt("tail")
+	nodeID, _ := fs.GetString("node")
+	agent, _ := fs.GetString("agent")
+	tool, _ := fs.GetString("tool")
+
+	if tail < 1 {
+		fmt.Fprintf(os.Stderr, "Error: --tail must be at least 1\n")
+		os.Exit(ExitGeneral)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		cfg = DefaultConfig()
+		cfg.applyEnvOverrides()
+	}
+
+	dataDir, err := ResolveDataDir(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+
+	if _, err := os.Stat(dataDir); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Error: no data found at %s\n", dataDir)
+		os.Exit(ExitDatabase)
+	}
+
+	client, err := memory.NewClient(memory.ClientConfig{
+		DataDir:       dataDir,
+		StorageEngine: cfg.Storage.Engine,
+		Namespace:     globals.resolveNamespace(cfg),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: cannot open database: %v\n", err)
+		printLockHint(dataDir)
+		os.Exit(ExitDatabase)
+	}
+	defer func() { _ = client.Close() }()
+
+	entries, err := client.GetAuditLog(context.Background(), tools.AuditOptions{
+		Limit:       tail,
+		NodeID:      nodeID,
+		SourceAgent: agent,
+		Tool:        tool,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitQuery)
+	}
+
+	if globals.JSON {
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitGeneral)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	if len(entries) == 0 {
+		if !globals.Quiet {
+			fmt.Println("No matching writes recorded.")
+		}
+		return
+	}
+	for _, e := range entries {
+		fmt.Printf("%s  %-11s  %-14s  %-12s  %s\n",
+			time.Unix(e.At, 0).Local().Format("2006-01-02 15:04:05"),
+			e.Op, dashIfEmpty(e.Tool), dashIfEmpty(e.SourceAgent), strings.Join(e.NodeIDs, ", "))
+	}
+}
+
+// dashIfEmpty returns s, or "-" when s is empty.
+func dashIfEmpty(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
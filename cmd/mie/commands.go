@@ -0,0 +1,158 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+)
+
+// command is a built-in mie subcommand. The dispatcher builds its flag set
+// from flags, parses the arguments with it, and passes it to run, which
+// reads the options with the Get methods of the flag set and the
+// positional arguments with Args. mie help <name> and --help print the
+// help assembled from usage, help, the options, and examples.
+type command struct {
+	name     string
+	summary  string                 // one line for the command list of mie --help
+	usage    string                 // arguments after "mie <name>", one line per form
+	help     string                 // the Description section of the help
+	flags    func(fs *flag.FlagSet) // defines the options, if the command has any
+	examples string                 // the Examples section of the help
+	run      func(fs *flag.FlagSet, configPath string, globals GlobalFlags)
+}
+
+// commands are the built-in subcommands, in the order mie --help lists
+// them. A command that is not built in runs the mie-<name> plugin on PATH
+// (see plugin.go).
+var commands = []command{
+	initCommand,
+	statusCommand,
+	statsCommand,
+	resetCommand,
+	exportCommand,
+	importCommand,
+	mergeCommand,
+	syncCommand,
+	queryCommand,
+	serveCommand,
+	embedCommand,
+	doctorCommand,
+	auditCommand,
+	snapshotCommand,
+	searchCommand,
+	gcCommand,
+	pruneCommand,
+	dedupeCommand,
+	tuiCommand,
+}
+
+// helpSummary is the command list line of mie help, which main dispatches
+// itself since its run reads commands.
+const helpSummary = "Show the help of a command or plugin"
+
+// findCommand returns the built-in command called name.
+func findCommand(name string) (command, bool) {
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+// isBuiltin reports whether name is a built-in command, including help.
+func isBuiltin(name string) bool {
+	_, ok := findCommand(name)
+	return ok || name == "help"
+}
+
+// flagSet returns a flag set with the options of c that prints the help
+// of c for --help.
+func (c command) flagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet(c.name, flag.ExitOnError)
+	if c.flags != nil {
+		c.flags(fs)
+	}
+	fs.Usage = func() { c.printHelp(os.Stderr, fs) }
+	return fs
+}
+
+// execute parses args with the flag set of c and runs c.
+func (c command) execute(args []string, configPath string, globals GlobalFlags) {
+	fs := c.flagSet()
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+	c.run(fs, configPath, globals)
+}
+
+// printHelp writes the help of c to w, with the options defined in fs.
+func (c command) printHelp(w io.Writer, fs *flag.FlagSet) {
+	for i, form := range strings.Split(c.usage, "\n") {
+		prefix := "Usage: "
+		if i > 0 {
+			prefix = "       "
+		}
+		fmt.Fprintf(w, "%smie %s %s\n", prefix, c.name, form)
+	}
+	fmt.Fprintf(w, "\nDescription:\n%s\n", c.help)
+	if fs.HasFlags() {
+		fmt.Fprintf(w, "\nOptions:\n%s", fs.FlagUsages())
+	}
+	if c.examples != "" {
+		fmt.Fprintf(w, "\nExamples:\n%s\n", c.examples)
+	}
+	fmt.Fprintln(w)
+}
+
+// runHelp implements mie help [COMMAND]: it prints the help of a built-in
+// command, runs a plugin with --help, or without COMMAND prints the usage
+// of mie with the command list.
+func runHelp(args []string, configPath string, globals GlobalFlags) {
+	if len(args) == 0 {
+		flag.Usage()
+		return
+	}
+	name := args[0]
+	if c, ok := findCommand(name); ok {
+		c.printHelp(os.Stdout, c.flagSet())
+		return
+	}
+	if name == "help" {
+		fmt.Println("Usage: mie help [COMMAND]\n\nShow the help of COMMAND, a built-in command or a plugin, or the list of\ncommands.")
+		return
+	}
+	if path, ok := pluginPath(name); ok {
+		runPlugin(path, []string{"--help"}, configPath, globals)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Unknown command: %s\n", name)
+	os.Exit(ExitGeneral)
+}
+
+// commandList renders the command list of mie --help: the built-in
+// commands, then the plugins found on PATH.
+func commandList() string {
+	var sb strings.Builder
+	sb.WriteString("Commands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(&sb, "  %-14s%s\n", c.name, c.summary)
+	}
+	fmt.Fprintf(&sb, "  %-14s%s\n", "help", helpSummary)
+	if plugins := findPlugins(); len(plugins) > 0 {
+		sb.WriteString("\nPlugins (mie-<name> on PATH):\n")
+		for _, p := range plugins {
+			fmt.Fprintf(&sb, "  %-14s%s\n", p.name, p.path)
+		}
+	}
+	return sb.String()
+}
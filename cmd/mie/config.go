@@ -8,11 +8,21 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/policy"
+	"github.com/kraklabs/mie/pkg/tools"
 )
 
 const (
@@ -24,8 +34,21 @@ const (
 // Config represents the .mie/config.yaml configuration file.
 type Config struct {
 	Version   string          `yaml:"version"`
+	Namespace string          `yaml:"namespace,omitempty"` // memory namespace for this project
 	Storage   StorageConfig   `yaml:"storage"`
 	Embedding EmbeddingConfig `yaml:"embedding"`
+	Dedup     DedupConfig     `yaml:"dedup,omitempty"`
+	Memory    MemoryConfig    `yaml:"memory,omitempty"`
+	// Categories sets per-category fact policies, keyed by fact category.
+	Categories map[string]CategoryConfig `yaml:"categories,omitempty"`
+	Server     ServerConfig              `yaml:"server,omitempty"`
+	Schema     SchemaConfig              `yaml:"schema,omitempty"`
+	Log        LogConfig                 `yaml:"log,omitempty"`
+	Hooks      HooksConfig               `yaml:"hooks,omitempty"`
+
+	// dir is the directory the config file was loaded from; empty for
+	// the default configuration.
+	dir string
 }
 
 // StorageConfig contains storage backend configuration.
@@ -37,12 +60,254 @@ type StorageConfig struct {
 // EmbeddingConfig contains embedding provider configuration.
 type EmbeddingConfig struct {
 	Enabled    bool   `yaml:"enabled"`
-	Provider   string `yaml:"provider"`   // ollama, openai, nomic, mock
+	Provider   string `yaml:"provider"`   // ollama, openai, nomic, local, mock
 	BaseURL    string `yaml:"base_url"`
 	Model      string `yaml:"model"`
 	Dimensions int    `yaml:"dimensions"` // 768 for nomic, 1536 for openai
 	APIKey     string `yaml:"api_key,omitempty"`
 	Workers    int    `yaml:"workers"`
+
+	// FallbackProvider embeds the texts Provider fails on, for example
+	// nomic behind a local ollama serving the same model. It has its own
+	// base URL, model, and API key, and must return vectors of Dimensions.
+	FallbackProvider string `yaml:"fallback_provider,omitempty"`
+	FallbackBaseURL  string `yaml:"fallback_base_url,omitempty"`
+	FallbackModel    string `yaml:"fallback_model,omitempty"`
+	FallbackAPIKey   string `yaml:"fallback_api_key,omitempty"`
+
+	// Index is the vector index semantic search uses: "hnsw" (the default)
+	// for CozoDB's HNSW indexes, or "flat" for an exact in-memory index
+	// loaded from the stored embeddings at startup.
+	Index string `yaml:"index,omitempty"`
+}
+
+// vectorIndex returns the value for memory.ClientConfig.VectorIndex.
+func (e EmbeddingConfig) vectorIndex() memory.VectorIndex {
+	if e.Index == "flat" {
+		return memory.NewFlatIndex()
+	}
+	return nil
+}
+
+// DedupConfig controls store-time deduplication of facts. The zero value
+// enables it with the default threshold.
+type DedupConfig struct {
+	Disabled  bool    `yaml:"disabled,omitempty"`
+	Threshold float64 `yaml:"threshold,omitempty"` // similarity 0-1; 0 uses the default (0.95)
+}
+
+// threshold returns the value for memory.ClientConfig.DedupThreshold.
+func (d DedupConfig) threshold() float64 {
+	if d.Disabled {
+		return -1
+	}
+	return d.Threshold
+}
+
+// MemoryConfig contains settings that affect how memory is stored and ranked.
+type MemoryConfig struct {
+	Decay   DecayConfig   `yaml:"decay,omitempty"`
+	Ranking RankingConfig `yaml:"ranking,omitempty"`
+	// MinSimilarity drops semantic search results less similar to the
+	// query (0-1). Zero keeps them all; mie_query min_similarity overrides it.
+	MinSimilarity float64 `yaml:"min_similarity,omitempty"`
+	// AutoLink links each stored fact to the existing entities whose names
+	// or aliases it mentions.
+	AutoLink    bool              `yaml:"auto_link,omitempty"`
+	SearchCache SearchCacheConfig `yaml:"search_cache,omitempty"`
+	Limits      LimitsConfig      `yaml:"limits,omitempty"`
+}
+
+// SearchCacheConfig controls the cache of recent semantic search results.
+// The zero value enables it with the default size and TTL.
+type SearchCacheConfig struct {
+	Disabled bool          `yaml:"disabled,omitempty"`
+	Size     int           `yaml:"size,omitempty"` // searches kept; 0 uses the default (256)
+	TTL      time.Duration `yaml:"ttl,omitempty"`  // 0 uses the default (5m)
+}
+
+// size returns the value for memory.ClientConfig.SearchCacheSize.
+func (c SearchCacheConfig) size() int {
+	if c.Disabled {
+		return -1
+	}
+	return c.Size
+}
+
+// LimitsConfig caps the size of the memory graph. Stores fail once a limit
+// is reached. Zero fields are unlimited.
+type LimitsConfig struct {
+	MaxFacts        int   `yaml:"max_facts,omitempty"`         // facts per namespace
+	MaxNodes        int   `yaml:"max_nodes,omitempty"`         // nodes of all types per namespace
+	MaxStorageBytes int64 `yaml:"max_storage_bytes,omitempty"` // size of the data directory
+}
+
+// limits returns the value for memory.ClientConfig.Limits.
+func (l LimitsConfig) limits() memory.Limits {
+	return memory.Limits{MaxFacts: l.MaxFacts, MaxNodes: l.MaxNodes, MaxStorageBytes: l.MaxStorageBytes}
+}
+
+// DecayConfig controls confidence decay of old facts in search ranking.
+// The zero value disables decay.
+type DecayConfig struct {
+	HalfLifeDays float64 `yaml:"half_life_days,omitempty"` // age at which a fact's confidence is halved
+}
+
+// RankingConfig weighs the signals semantic search blends into its ranking
+// score. Unset weights keep their memory.DefaultRankingWeights value.
+type RankingConfig struct {
+	Similarity *float64 `yaml:"similarity,omitempty"`
+	Confidence *float64 `yaml:"confidence,omitempty"`
+	Recency    *float64 `yaml:"recency,omitempty"`
+	Validity   *float64 `yaml:"validity,omitempty"`
+}
+
+// weights returns the value for memory.ClientConfig.RankingWeights.
+func (r RankingConfig) weights() memory.RankingWeights {
+	w := memory.DefaultRankingWeights
+	for _, f := range []struct {
+		src *float64
+		dst *float64
+	}{
+		{r.Similarity, &w.Similarity},
+		{r.Confidence, &w.Confidence},
+		{r.Recency, &w.Recency},
+		{r.Validity, &w.Validity},
+	} {
+		if f.src != nil {
+			*f.dst = *f.src
+		}
+	}
+	return w
+}
+
+// defaultMaxConcurrentCalls is the number of MCP tool calls that run at
+// once unless server.max_concurrent_calls says otherwise.
+const defaultMaxConcurrentCalls = 8
+
+// ServerConfig contains settings for the MCP server.
+type ServerConfig struct {
+	// ReadOnly exposes only tools that do not modify the memory graph.
+	ReadOnly bool `yaml:"read_only,omitempty"`
+	// NamespaceFromWorkspace derives the session's default namespace from
+	// the workspace root the MCP client sends on initialize.
+	NamespaceFromWorkspace bool `yaml:"namespace_from_workspace,omitempty"`
+	// RequestTimeout bounds each MCP tool call, for example "30s". Zero
+	// leaves them unbounded.
+	RequestTimeout time.Duration `yaml:"request_timeout,omitempty"`
+	// MaxConcurrentCalls is the number of MCP tool calls that run at once;
+	// further calls wait for one to finish. Zero uses
+	// defaultMaxConcurrentCalls.
+	MaxConcurrentCalls int `yaml:"max_concurrent_calls,omitempty"`
+	// StrictValidation rejects MCP tool calls whose arguments do not match
+	// the tool's input schema, naming each invalid field, instead of
+	// replacing invalid values with defaults.
+	StrictValidation bool `yaml:"strict_validation,omitempty"`
+	// Tenants switches mie serve to multi-tenant mode: each request names
+	// its tenant with an API key and reaches only that tenant's graph.
+	Tenants []TenantConfig `yaml:"tenants,omitempty"`
+	// AdminKey authorizes the admin endpoints of multi-tenant mode.
+	AdminKey string `yaml:"admin_key,omitempty"`
+	// Instructions is a Markdown file, rendered as a Go template, that
+	// adds to the instructions the MCP server sends on initialize.
+	// Relative paths are resolved against the config directory. Empty
+	// uses instructions.md there when it exists.
+	Instructions string `yaml:"instructions,omitempty"`
+	// InstructionsMode is "append" (the default) to send the file after
+	// the built-in instructions, or "replace" to send it instead.
+	InstructionsMode string `yaml:"instructions_mode,omitempty"`
+	// Clients restricts the MCP tools each client may call, keyed by the
+	// name it sends in clientInfo on initialize; "*" applies to clients
+	// without a rule of their own.
+	Clients map[string]policy.Rule `yaml:"clients,omitempty"`
+}
+
+// maxConcurrentCalls returns the number of MCP tool calls that run at
+// once.
+func (c ServerConfig) maxConcurrentCalls() int {
+	if c.MaxConcurrentCalls > 0 {
+		return c.MaxConcurrentCalls
+	}
+	return defaultMaxConcurrentCalls
+}
+
+// TenantConfig is one tenant of a multi-tenant mie serve.
+type TenantConfig struct {
+	Name   string `yaml:"name"`
+	APIKey string `yaml:"api_key"`
+	// DataDir holds the tenant's database. Empty uses tenants/NAME in the
+	// data directory.
+	DataDir string `yaml:"data_dir,omitempty"`
+}
+
+// LogConfig controls the diagnostic log that mie --mcp and mie serve write
+// to stderr.
+type LogConfig struct {
+	// Level is debug, info, warn, or error. Empty uses the command's
+	// default: info for mie --mcp, warn for mie serve (info with -v).
+	Level string `yaml:"level,omitempty"`
+	// Format is text (the default) or json.
+	Format string `yaml:"format,omitempty"`
+}
+
+// newLogger returns a logger writing to w at the configured level, or at
+// fallback when none is set.
+func (l LogConfig) newLogger(w io.Writer, fallback slog.Level) *slog.Logger {
+	level := fallback
+	if l.Level != "" {
+		_ = level.UnmarshalText([]byte(l.Level))
+	}
+	opts := &slog.HandlerOptions{Level: level}
+	if l.Format == "json" {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+// HooksConfig lists the webhooks the MCP server calls after writes to the
+// memory graph.
+type HooksConfig struct {
+	// OnStore is called for each node stored.
+	OnStore []WebhookConfig `yaml:"on_store,omitempty"`
+	// OnInvalidate is called for each fact invalidated, by mie_update or
+	// by expiry.
+	OnInvalidate []WebhookConfig `yaml:"on_invalidate,omitempty"`
+}
+
+// WebhookConfig is one webhook: a URL that receives a JSON POST.
+type WebhookConfig struct {
+	URL string `yaml:"url"`
+	// Headers are added to each request, for example Authorization.
+	Headers map[string]string `yaml:"headers,omitempty"`
+}
+
+// CategoryConfig is the policy of one fact category.
+type CategoryConfig struct {
+	// RetentionDays expires facts of the category this many days after
+	// they are stored. Zero keeps them.
+	RetentionDays int `yaml:"retention_days,omitempty"`
+	// DefaultConfidence is the confidence of facts stored without one. Zero
+	// uses the built-in default (0.8).
+	DefaultConfidence float64 `yaml:"default_confidence,omitempty"`
+}
+
+// categoryPolicies returns the value for memory.ClientConfig.CategoryPolicies
+// and tools.SetCategoryPolicies.
+func (c *Config) categoryPolicies() map[string]tools.CategoryPolicy {
+	if len(c.Categories) == 0 {
+		return nil
+	}
+	policies := make(map[string]tools.CategoryPolicy, len(c.Categories))
+	for category, cc := range c.Categories {
+		policies[category] = tools.CategoryPolicy{RetentionDays: cc.RetentionDays, DefaultConfidence: cc.DefaultConfidence}
+	}
+	return policies
+}
+
+// SchemaConfig extends the built-in entity kinds and fact categories.
+type SchemaConfig struct {
+	ExtraEntityKinds    []string `yaml:"extra_entity_kinds,omitempty"`
+	ExtraFactCategories []string `yaml:"extra_fact_categories,omitempty"`
 }
 
 // DefaultConfig returns a config with sensible defaults for local development.
@@ -97,6 +362,7 @@ func LoadConfig(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("unsupported config version %q (expected %q), run 'mie init --force' to regenerate", cfg.Version, configVersion)
 	}
 
+	cfg.dir = filepath.Dir(configPath)
 	cfg.applyEnvOverrides()
 
 	if err := ValidateConfig(&cfg); err != nil {
@@ -114,6 +380,111 @@ func ValidateConfig(cfg *Config) error {
 	default:
 		return fmt.Errorf("unsupported storage engine %q (supported: mem, sqlite, rocksdb)", cfg.Storage.Engine)
 	}
+	if cfg.Namespace != "" {
+		if err := tools.ValidateNamespace(cfg.Namespace); err != nil {
+			return err
+		}
+	}
+	switch cfg.Embedding.FallbackProvider {
+	case "", "local", "mock", "nomic", "ollama", "openai":
+	default:
+		return fmt.Errorf("unknown embedding fallback provider %q (supported: local, mock, nomic, ollama, openai)", cfg.Embedding.FallbackProvider)
+	}
+	if _, err := policy.New(cfg.Server.Clients); err != nil {
+		return fmt.Errorf("invalid server.clients: %w", err)
+	}
+	switch cfg.Server.InstructionsMode {
+	case "", "append", "replace":
+	default:
+		return fmt.Errorf("unknown server.instructions_mode %q (supported: append, replace)", cfg.Server.InstructionsMode)
+	}
+	switch cfg.Embedding.Index {
+	case "", "hnsw", "flat":
+	default:
+		return fmt.Errorf("unknown embedding index %q (supported: hnsw, flat)", cfg.Embedding.Index)
+	}
+	if cfg.Dedup.Threshold < 0 || cfg.Dedup.Threshold > 1 {
+		return fmt.Errorf("invalid dedup threshold %v (must be between 0 and 1)", cfg.Dedup.Threshold)
+	}
+	if cfg.Memory.Decay.HalfLifeDays < 0 {
+		return fmt.Errorf("invalid decay half-life %v (must not be negative)", cfg.Memory.Decay.HalfLifeDays)
+	}
+	if err := tools.ValidateMinSimilarity(cfg.Memory.MinSimilarity); err != nil {
+		return fmt.Errorf("invalid memory.min_similarity: %w", err)
+	}
+	if cfg.Memory.SearchCache.Size < 0 || cfg.Memory.SearchCache.TTL < 0 {
+		return fmt.Errorf("invalid memory.search_cache: size and ttl must not be negative")
+	}
+	if l := cfg.Memory.Limits; l.MaxFacts < 0 || l.MaxNodes < 0 || l.MaxStorageBytes < 0 {
+		return fmt.Errorf("invalid memory.limits: max_facts, max_nodes, and max_storage_bytes must not be negative")
+	}
+	if cfg.Log.Level != "" {
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(cfg.Log.Level)); err != nil {
+			return fmt.Errorf("invalid log level %q (supported: debug, info, warn, error)", cfg.Log.Level)
+		}
+	}
+	switch cfg.Log.Format {
+	case "", "text", "json":
+	default:
+		return fmt.Errorf("unknown log format %q (supported: text, json)", cfg.Log.Format)
+	}
+	if cfg.Server.RequestTimeout < 0 {
+		return fmt.Errorf("invalid server.request_timeout %v (must not be negative)", cfg.Server.RequestTimeout)
+	}
+	if cfg.Server.MaxConcurrentCalls < 0 {
+		return fmt.Errorf("invalid server.max_concurrent_calls %d (must not be negative)", cfg.Server.MaxConcurrentCalls)
+	}
+	for name, hooks := range map[string][]WebhookConfig{"on_store": cfg.Hooks.OnStore, "on_invalidate": cfg.Hooks.OnInvalidate} {
+		for _, h := range hooks {
+			if u, err := url.Parse(h.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("invalid hooks.%s url %q (must be an http or https URL)", name, h.URL)
+			}
+		}
+	}
+	w := cfg.Memory.Ranking.weights()
+	if w.Similarity <= 0 {
+		return fmt.Errorf("invalid ranking similarity weight %v (must be greater than 0)", w.Similarity)
+	}
+	if w.Confidence < 0 || w.Recency < 0 || w.Validity < 0 {
+		return fmt.Errorf("invalid ranking weights: confidence, recency, and validity must not be negative")
+	}
+	names := map[string]bool{}
+	keys := map[string]bool{}
+	for _, t := range cfg.Server.Tenants {
+		if err := tools.ValidateNamespace(t.Name); err != nil {
+			return fmt.Errorf("invalid tenant name: %w", err)
+		}
+		if t.APIKey == "" {
+			return fmt.Errorf("tenant %q has no api_key", t.Name)
+		}
+		if names[t.Name] {
+			return fmt.Errorf("tenant %q is configured twice", t.Name)
+		}
+		if keys[t.APIKey] || t.APIKey == cfg.Server.AdminKey {
+			return fmt.Errorf("tenant %q shares its api_key with another tenant or the admin key", t.Name)
+		}
+		names[t.Name] = true
+		keys[t.APIKey] = true
+	}
+	for _, kind := range cfg.Schema.ExtraEntityKinds {
+		if err := tools.ValidateSchemaName(kind); err != nil {
+			return fmt.Errorf("invalid extra entity kind: %w", err)
+		}
+	}
+	for _, category := range cfg.Schema.ExtraFactCategories {
+		if err := tools.ValidateSchemaName(category); err != nil {
+			return fmt.Errorf("invalid extra fact category: %w", err)
+		}
+	}
+	for category, p := range cfg.categoryPolicies() {
+		if !slices.Contains(memory.ValidFactCategories, category) && !slices.Contains(cfg.Schema.ExtraFactCategories, category) {
+			return fmt.Errorf("invalid categories.%s: unknown fact category (add it to schema.extra_fact_categories)", category)
+		}
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("invalid categories.%s: %w", category, err)
+		}
+	}
 	return nil
 }
 
@@ -208,6 +579,10 @@ func findConfigFile() (string, error) {
 
 // applyEnvOverrides applies environment variable overrides to the configuration.
 func (c *Config) applyEnvOverrides() {
+	if v := os.Getenv("MIE_NAMESPACE"); v != "" {
+		c.Namespace = v
+	}
+
 	// Storage overrides
 	if v := os.Getenv("MIE_STORAGE_ENGINE"); v != "" {
 		c.Storage.Engine = v
@@ -223,6 +598,12 @@ func (c *Config) applyEnvOverrides() {
 	if v := os.Getenv("MIE_EMBEDDING_PROVIDER"); v != "" {
 		c.Embedding.Provider = v
 	}
+	if v := os.Getenv("MIE_EMBEDDING_FALLBACK_PROVIDER"); v != "" {
+		c.Embedding.FallbackProvider = v
+	}
+	if v := os.Getenv("MIE_EMBEDDING_INDEX"); v != "" {
+		c.Embedding.Index = v
+	}
 	if v := os.Getenv("OLLAMA_HOST"); v != "" {
 		c.Embedding.BaseURL = v
 	}
@@ -242,6 +623,60 @@ func (c *Config) applyEnvOverrides() {
 		}
 	}
 
+	// Dedup overrides
+	if v := os.Getenv("MIE_DEDUP_THRESHOLD"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			c.Dedup.Threshold = f
+		}
+	}
+
+	// Memory overrides
+	if v := os.Getenv("MIE_DECAY_HALF_LIFE_DAYS"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			c.Memory.Decay.HalfLifeDays = f
+		}
+	}
+	if v := os.Getenv("MIE_MIN_SIMILARITY"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			c.Memory.MinSimilarity = f
+		}
+	}
+	if v := os.Getenv("MIE_AUTO_LINK"); v != "" {
+		c.Memory.AutoLink = strings.EqualFold(v, "true") || v == "1"
+	}
+
+	// Server overrides
+	if v := os.Getenv("MIE_READ_ONLY"); v != "" {
+		c.Server.ReadOnly = strings.EqualFold(v, "true") || v == "1"
+	}
+	if v := os.Getenv("MIE_NAMESPACE_FROM_WORKSPACE"); v != "" {
+		c.Server.NamespaceFromWorkspace = strings.EqualFold(v, "true") || v == "1"
+	}
+	if v := os.Getenv("MIE_REQUEST_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			c.Server.RequestTimeout = d
+		}
+	}
+	if v := os.Getenv("MIE_MAX_CONCURRENT_CALLS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			c.Server.MaxConcurrentCalls = n
+		}
+	}
+	if v := os.Getenv("MIE_STRICT_VALIDATION"); v != "" {
+		c.Server.StrictValidation = strings.EqualFold(v, "true") || v == "1"
+	}
+	if v := os.Getenv("MIE_ADMIN_KEY"); v != "" {
+		c.Server.AdminKey = v
+	}
+
+	// Log overrides
+	if v := os.Getenv("MIE_LOG_LEVEL"); v != "" {
+		c.Log.Level = v
+	}
+	if v := os.Getenv("MIE_LOG_FORMAT"); v != "" {
+		c.Log.Format = v
+	}
+
 }
 
 // getEnv retrieves an environment variable or returns a fallback value if not set.
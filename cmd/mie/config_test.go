@@ -7,12 +7,20 @@
 package main
 
 import (
+	"bytes"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/policy"
+	"github.com/kraklabs/mie/pkg/tools"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -51,6 +59,34 @@ func TestConfigEnvOverridesEmbedding(t *testing.T) {
 	assert.Equal(t, "openai", cfg.Embedding.Provider)
 }
 
+func TestConfigEmbeddingFallback(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	yaml := `version: "1"
+storage:
+  engine: mem
+embedding:
+  provider: ollama
+  fallback_provider: openai
+  fallback_model: nomic-embed-text
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(yaml), 0600))
+
+	t.Setenv("MIE_CONFIG_PATH", configPath)
+
+	cfg, err := LoadConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, "openai", cfg.Embedding.FallbackProvider)
+	assert.Equal(t, "nomic-embed-text", cfg.Embedding.FallbackModel)
+
+	t.Setenv("MIE_EMBEDDING_FALLBACK_PROVIDER", "nomic")
+	cfg.applyEnvOverrides()
+	assert.Equal(t, "nomic", cfg.Embedding.FallbackProvider)
+
+	cfg.Embedding.FallbackProvider = "cohere"
+	assert.Error(t, ValidateConfig(cfg))
+}
+
 func TestConfigEnvOverridesStoragePath(t *testing.T) {
 	t.Setenv("MIE_STORAGE_PATH", "/custom/path/data.db")
 
@@ -97,6 +133,306 @@ embedding:
 
 }
 
+func TestConfigDedup(t *testing.T) {
+	cfg := DefaultConfig()
+	assert.Equal(t, 0.0, cfg.Dedup.threshold(), "zero value uses the client default")
+
+	t.Setenv("MIE_DEDUP_THRESHOLD", "0.9")
+	cfg.applyEnvOverrides()
+	assert.Equal(t, 0.9, cfg.Dedup.threshold())
+
+	cfg.Dedup.Disabled = true
+	assert.Less(t, cfg.Dedup.threshold(), 0.0)
+
+	cfg.Dedup.Threshold = 1.5
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestConfigReadOnly(t *testing.T) {
+	cfg := DefaultConfig()
+	assert.False(t, cfg.Server.ReadOnly, "read-only is off by default")
+
+	t.Setenv("MIE_READ_ONLY", "true")
+	cfg.applyEnvOverrides()
+	assert.True(t, cfg.Server.ReadOnly)
+}
+
+func TestConfigNamespaceFromWorkspace(t *testing.T) {
+	cfg := DefaultConfig()
+	assert.False(t, cfg.Server.NamespaceFromWorkspace, "workspace namespaces are off by default")
+
+	t.Setenv("MIE_NAMESPACE_FROM_WORKSPACE", "1")
+	cfg.applyEnvOverrides()
+	assert.True(t, cfg.Server.NamespaceFromWorkspace)
+}
+
+func TestConfigTenants(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	yaml := `version: "1"
+storage:
+  engine: mem
+server:
+  admin_key: root
+  tenants:
+    - name: alice
+      api_key: key-a
+    - name: bob
+      api_key: key-b
+      data_dir: /srv/mie/bob
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(yaml), 0600))
+
+	t.Setenv("MIE_CONFIG_PATH", configPath)
+
+	cfg, err := LoadConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, "root", cfg.Server.AdminKey)
+	assert.Equal(t, []TenantConfig{{Name: "alice", APIKey: "key-a"}, {Name: "bob", APIKey: "key-b", DataDir: "/srv/mie/bob"}}, cfg.Server.Tenants)
+
+	t.Setenv("MIE_ADMIN_KEY", "other")
+	cfg.applyEnvOverrides()
+	assert.Equal(t, "other", cfg.Server.AdminKey)
+
+	cfg.Server.Tenants[1].APIKey = "key-a"
+	assert.Error(t, ValidateConfig(cfg), "tenants must not share a key")
+	cfg.Server.Tenants[1].APIKey = "other"
+	assert.Error(t, ValidateConfig(cfg), "a tenant must not use the admin key")
+	cfg.Server.Tenants[1] = TenantConfig{Name: "Bob", APIKey: "key-b"}
+	assert.Error(t, ValidateConfig(cfg), "tenant names must be valid directory names")
+}
+
+func TestConfigDecay(t *testing.T) {
+	cfg := DefaultConfig()
+	assert.Equal(t, 0.0, cfg.Memory.Decay.HalfLifeDays, "decay is disabled by default")
+
+	t.Setenv("MIE_DECAY_HALF_LIFE_DAYS", "90")
+	cfg.applyEnvOverrides()
+	assert.Equal(t, 90.0, cfg.Memory.Decay.HalfLifeDays)
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.Memory.Decay.HalfLifeDays = -1
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestConfigAutoLink(t *testing.T) {
+	cfg := DefaultConfig()
+	assert.False(t, cfg.Memory.AutoLink, "auto-linking is opt-in")
+
+	t.Setenv("MIE_AUTO_LINK", "true")
+	cfg.applyEnvOverrides()
+	assert.True(t, cfg.Memory.AutoLink)
+}
+
+func TestConfigEmbeddingIndex(t *testing.T) {
+	cfg := DefaultConfig()
+	assert.Nil(t, cfg.Embedding.vectorIndex(), "HNSW by default")
+
+	t.Setenv("MIE_EMBEDDING_INDEX", "flat")
+	cfg.applyEnvOverrides()
+	assert.Equal(t, "flat", cfg.Embedding.Index)
+	assert.NoError(t, ValidateConfig(cfg))
+	assert.IsType(t, &memory.FlatIndex{}, cfg.Embedding.vectorIndex())
+
+	cfg.Embedding.Index = "faiss"
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestConfigMinSimilarity(t *testing.T) {
+	cfg := DefaultConfig()
+	assert.Equal(t, 0.0, cfg.Memory.MinSimilarity, "no cutoff by default")
+
+	t.Setenv("MIE_MIN_SIMILARITY", "0.6")
+	cfg.applyEnvOverrides()
+	assert.Equal(t, 0.6, cfg.Memory.MinSimilarity)
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.Memory.MinSimilarity = 1.2
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestConfigRequestTimeout(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	yaml := `version: "1"
+storage:
+  engine: mem
+server:
+  request_timeout: 90s
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(yaml), 0600))
+	t.Setenv("MIE_CONFIG_PATH", configPath)
+
+	cfg, err := LoadConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, cfg.Server.RequestTimeout)
+
+	t.Setenv("MIE_REQUEST_TIMEOUT", "2m")
+	cfg.applyEnvOverrides()
+	assert.Equal(t, 2*time.Minute, cfg.Server.RequestTimeout)
+
+	cfg.Server.RequestTimeout = -time.Second
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestConfigMaxConcurrentCalls(t *testing.T) {
+	cfg := DefaultConfig()
+	assert.Equal(t, defaultMaxConcurrentCalls, cfg.Server.maxConcurrentCalls())
+
+	t.Setenv("MIE_MAX_CONCURRENT_CALLS", "2")
+	cfg.applyEnvOverrides()
+	assert.Equal(t, 2, cfg.Server.maxConcurrentCalls())
+
+	cfg.Server.MaxConcurrentCalls = -1
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestConfigStrictValidation(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	yaml := `version: "1"
+storage:
+  engine: mem
+server:
+  strict_validation: true
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(yaml), 0600))
+	t.Setenv("MIE_CONFIG_PATH", configPath)
+
+	cfg, err := LoadConfig("")
+	require.NoError(t, err)
+	assert.True(t, cfg.Server.StrictValidation)
+
+	t.Setenv("MIE_STRICT_VALIDATION", "false")
+	cfg.applyEnvOverrides()
+	assert.False(t, cfg.Server.StrictValidation)
+}
+
+func TestConfigLimits(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	yaml := `version: "1"
+storage:
+  engine: mem
+memory:
+  limits:
+    max_facts: 10000
+    max_storage_bytes: 1073741824
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(yaml), 0600))
+	t.Setenv("MIE_CONFIG_PATH", configPath)
+
+	cfg, err := LoadConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, memory.Limits{MaxFacts: 10000, MaxStorageBytes: 1 << 30}, cfg.Memory.Limits.limits())
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg.Memory.Limits.MaxNodes = -1
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestConfigHooks(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	yaml := `version: "1"
+storage:
+  engine: mem
+hooks:
+  on_store:
+    - url: https://hooks.example.com/mie
+      headers:
+        Authorization: Bearer secret
+  on_invalidate:
+    - url: http://localhost:8080/invalidated
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(yaml), 0600))
+	t.Setenv("MIE_CONFIG_PATH", configPath)
+
+	cfg, err := LoadConfig("")
+	require.NoError(t, err)
+	require.Len(t, cfg.Hooks.OnStore, 1)
+	assert.Equal(t, "Bearer secret", cfg.Hooks.OnStore[0].Headers["Authorization"])
+	require.Len(t, cfg.Hooks.OnInvalidate, 1)
+	require.NoError(t, ValidateConfig(cfg))
+
+	for _, u := range []string{"", "hooks.example.com/mie", "ftp://hooks.example.com", "https://"} {
+		cfg.Hooks.OnInvalidate[0].URL = u
+		assert.Error(t, ValidateConfig(cfg), "url %q", u)
+	}
+}
+
+func TestConfigLog(t *testing.T) {
+	cfg := DefaultConfig()
+	var out bytes.Buffer
+	logger := cfg.Log.newLogger(&out, slog.LevelWarn)
+	logger.Info("hidden")
+	logger.Warn("shown")
+	assert.NotContains(t, out.String(), "hidden", "the command's default level applies")
+	assert.Contains(t, out.String(), "level=WARN msg=shown")
+
+	t.Setenv("MIE_LOG_LEVEL", "debug")
+	t.Setenv("MIE_LOG_FORMAT", "json")
+	cfg.applyEnvOverrides()
+	require.NoError(t, ValidateConfig(cfg))
+	out.Reset()
+	cfg.Log.newLogger(&out, slog.LevelWarn).Debug("detail")
+	assert.Contains(t, out.String(), `"msg":"detail"`)
+
+	cfg.Log.Level = "verbose"
+	assert.Error(t, ValidateConfig(cfg))
+	cfg.Log = LogConfig{Format: "logfmt"}
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestConfigRanking(t *testing.T) {
+	cfg := DefaultConfig()
+	assert.Equal(t, memory.DefaultRankingWeights, cfg.Memory.Ranking.weights())
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	yaml := `version: "1"
+storage:
+  engine: mem
+memory:
+  ranking:
+    confidence: 0
+    recency: 0.5
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(yaml), 0600))
+
+	t.Setenv("MIE_CONFIG_PATH", configPath)
+
+	cfg, err := LoadConfig("")
+	require.NoError(t, err)
+	w := cfg.Memory.Ranking.weights()
+	assert.Equal(t, memory.DefaultRankingWeights.Similarity, w.Similarity)
+	assert.Equal(t, 0.0, w.Confidence)
+	assert.Equal(t, 0.5, w.Recency)
+
+	zero := 0.0
+	cfg.Memory.Ranking.Similarity = &zero
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestConfigSchemaExtensions(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	yaml := `version: "1"
+storage:
+  engine: mem
+schema:
+  extra_entity_kinds: [service]
+  extra_fact_categories: [incident]
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(yaml), 0600))
+
+	t.Setenv("MIE_CONFIG_PATH", configPath)
+
+	cfg, err := LoadConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"service"}, cfg.Schema.ExtraEntityKinds)
+	assert.Equal(t, []string{"incident"}, cfg.Schema.ExtraFactCategories)
+
+	cfg.Schema.ExtraEntityKinds = []string{"Micro Service"}
+	assert.Error(t, ValidateConfig(cfg))
+}
+
 func TestConfigYAMLInvalidVersion(t *testing.T) {
 	dir := t.TempDir()
 	configPath := filepath.Join(dir, "config.yaml")
@@ -136,4 +472,89 @@ func TestSaveConfig(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, cfg.Version, loaded.Version)
 	assert.Equal(t, cfg.Storage.Engine, loaded.Storage.Engine)
-}
\ No newline at end of file
+}
+
+func TestConfigCategories(t *testing.T) {
+	cfg := DefaultConfig()
+	assert.Nil(t, cfg.categoryPolicies(), "no policies by default")
+
+	cfg.Categories = map[string]CategoryConfig{
+		"personal":  {RetentionDays: 90},
+		"technical": {DefaultConfidence: 0.9},
+	}
+	assert.NoError(t, ValidateConfig(cfg))
+	assert.Equal(t, tools.CategoryPolicy{RetentionDays: 90}, cfg.categoryPolicies()["personal"])
+
+	cfg.Categories["incident"] = CategoryConfig{RetentionDays: 7}
+	assert.Error(t, ValidateConfig(cfg), "unknown category")
+	cfg.Schema.ExtraFactCategories = []string{"incident"}
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.Categories["personal"] = CategoryConfig{DefaultConfidence: 2}
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestConfigInstructions(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "billing", ".mie")
+	require.NoError(t, os.MkdirAll(dir, 0750))
+	configPath := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("version: \"1\"\nstorage:\n  engine: mem\n"), 0600))
+
+	cfg, err := LoadConfig(configPath)
+	require.NoError(t, err)
+	ci, err := loadInstructions(cfg)
+	require.NoError(t, err)
+	assert.Nil(t, ci, "without instructions.md the built-in text is used")
+
+	instructions := "Project {{.Project}} stores into {{.Namespace}}; categories: {{join .Categories \", \"}}."
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "instructions.md"), []byte(instructions), 0600))
+	ci, err = loadInstructions(cfg)
+	require.NoError(t, err)
+
+	s := &mcpServer{namespace: "billing", customInstructions: ci}
+	text := s.instructions()
+	assert.True(t, strings.HasPrefix(text, mieInstructions), "append keeps the built-in text first")
+	assert.Contains(t, text, "Project billing stores into billing; categories: personal,")
+
+	cfg.Server.InstructionsMode = "replace"
+	ci, err = loadInstructions(cfg)
+	require.NoError(t, err)
+	s.customInstructions = ci
+	assert.True(t, strings.HasPrefix(s.instructions(), "Project billing"), "replace drops the built-in text")
+
+	cfg.Server.Instructions = "missing.md"
+	_, err = loadInstructions(cfg)
+	assert.Error(t, err, "an explicit instructions file must exist")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bad.md"), []byte("{{.Team}}"), 0600))
+	cfg.Server.Instructions = "bad.md"
+	_, err = loadInstructions(cfg)
+	assert.Error(t, err, "unknown template fields fail at startup")
+
+	cfg.Server.InstructionsMode = "prepend"
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestConfigClients(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	yaml := `version: "1"
+storage:
+  engine: mem
+server:
+  clients:
+    cursor:
+      deny: [mie_merge, "*:delete"]
+    "*":
+      allow: [mie_query]
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(yaml), 0600))
+
+	cfg, err := LoadConfig(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, policy.Rule{Deny: []string{"mie_merge", "*:delete"}}, cfg.Server.Clients["cursor"])
+	assert.Equal(t, []string{"mie_query"}, cfg.Server.Clients["*"].Allow)
+
+	cfg.Server.Clients["cursor"] = policy.Rule{Deny: []string{"mie_[store"}}
+	assert.Error(t, ValidateConfig(cfg))
+}
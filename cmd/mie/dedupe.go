@@ -0,0 +1,157 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// dedupeCommand is mie dedupe.
+var dedupeCommand = command{
+	name:    "dedupe",
+	summary: "Report likely duplicate entities and topics",
+	usage:   "[options]",
+	help: `  Report entities and topics that likely name the same thing, such as
+  "Postgres" and "PostgreSQL". Pairs are scored from 0 to 1 by the
+  similarity of their names and aliases and, with embeddings, of their
+  embeddings, and pairs at --threshold or above are grouped into clusters.
+  The first member of each cluster is the suggested survivor, the one with
+  the most relationships. Merge entity clusters with the mie_merge tool.
+
+  Nothing is changed. The mie_dedupe MCP tool returns the same report.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.Bool("report", true, "Report the clusters (the default; dedupe never changes the graph)")
+		fs.StringSlice("node-type", nil, "Only compare these node types (entity, topic)")
+		fs.Float64("threshold", memory.DefaultDuplicateThreshold, "Score from which two nodes are reported (0-1)")
+		fs.Int("limit", 0, "Only report the best-scoring N clusters (0 for all)")
+		fs.Bool("include-archived", false, "Also compare archived nodes")
+	},
+	examples: `  mie dedupe                          Report likely duplicates
+  mie dedupe --node-type entity       Only entities
+  mie dedupe --threshold 0.9 --json   Only close matches, as JSON`,
+	run: runDedupe,
+}
+
+// runDedupe reports clusters of entities and topics that likely name the
+// same thing.
+func runDedupe(fs *flag.FlagSet, configPath string, globals GlobalFlags) {
+	nodeTypes, _ := fs.GetStringSlice("node-type")
+	threshold, _ := fs.GetFloat64("threshold")
+	limit, _ := fs.GetInt("limit")
+	includeArchived, _ := fs.GetBool("include-archived")
+
+	for _, nt := range nodeTypes {
+		if nt != "entity" && nt != "topic" {
+			fmt.Fprintf(os.Stderr, "Error: invalid node type %q (must be entity or topic)\n", nt)
+			os.Exit(1)
+		}
+	}
+	if threshold <= 0 || threshold > 1 {
+		fmt.Fprintf(os.Stderr, "Error: --threshold must be greater than 0 and at most 1\n")
+		os.Exit(1)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		cfg = DefaultConfig()
+		cfg.applyEnvOverrides()
+	}
+
+	dataDir, err := ResolveDataDir(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+
+	if _, err := os.Stat(dataDir); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Error: no data found at %s\n", dataDir)
+		os.Exit(ExitDatabase)
+	}
+
+	client, err := memory.NewClient(memory.ClientConfig{
+		DataDir:                   dataDir,
+		StorageEngine:             cfg.Storage.Engine,
+		Namespace:                 globals.resolveNamespace(cfg),
+		EmbeddingEnabled:          cfg.Embedding.Enabled,
+		EmbeddingProvider:         cfg.Embedding.Provider,
+		EmbeddingBaseURL:          cfg.Embedding.BaseURL,
+		EmbeddingModel:            cfg.Embedding.Model,
+		EmbeddingAPIKey:           cfg.Embedding.APIKey,
+		EmbeddingDimensions:       cfg.Embedding.Dimensions,
+		EmbeddingFallbackProvider: cfg.Embedding.FallbackProvider,
+		EmbeddingFallbackBaseURL:  cfg.Embedding.FallbackBaseURL,
+		EmbeddingFallbackModel:    cfg.Embedding.FallbackModel,
+		EmbeddingFallbackAPIKey:   cfg.Embedding.FallbackAPIKey,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: cannot open database: %v\n", err)
+		printLockHint(dataDir)
+		os.Exit(ExitDatabase)
+	}
+	defer func() { _ = client.Close() }()
+
+	ctx := tools.WithIncludeArchived(context.Background(), includeArchived)
+	clusters, err := client.FindDuplicates(ctx, tools.DuplicateOptions{
+		NodeTypes: nodeTypes,
+		Threshold: threshold,
+		Limit:     limit,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitQuery)
+	}
+
+	if globals.JSON {
+		if clusters == nil {
+			clusters = []tools.DuplicateCluster{}
+		}
+		data, err := json.MarshalIndent(map[string]any{"threshold": threshold, "clusters": clusters}, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitGeneral)
+		}
+		fmt.Println(string(data))
+		return
+	}
+	if globals.Quiet {
+		return
+	}
+	printDedupe(clusters)
+}
+
+func printDedupe(clusters []tools.DuplicateCluster) {
+	if len(clusters) == 0 {
+		fmt.Println("No likely duplicates found.")
+		return
+	}
+
+	fmt.Printf("%d clusters of likely duplicates:\n", len(clusters))
+	for i, c := range clusters {
+		fmt.Printf("\n%d. %s, score %.2f\n", i+1, c.NodeType, c.Score)
+		for j, m := range c.Members {
+			marker := " "
+			if j == 0 {
+				marker = "*"
+			}
+			name := m.Name
+			if len(m.Aliases) > 0 {
+				name += " (aliases: " + strings.Join(m.Aliases, ", ") + ")"
+			}
+			fmt.Printf("  %s %-24s  %3d edges  %s\n", marker, m.ID, m.Edges, name)
+		}
+	}
+	fmt.Println("\n* suggested survivor. Merge entities with the mie_merge tool after review.")
+}
@@ -0,0 +1,359 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/storage"
+)
+
+// Doctor check statuses.
+const (
+	checkOK   = "ok"
+	checkWarn = "warn"
+	checkFail = "fail"
+)
+
+// doctorEmbedTimeout bounds the embedding provider connectivity check.
+const doctorEmbedTimeout = 30 * time.Second
+
+// DoctorCheck is the outcome of a single mie doctor check.
+type DoctorCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"` // ok, warn, fail
+	Detail string `json:"detail"`
+	Fix    string `json:"fix,omitempty"`
+}
+
+// DoctorResult represents the mie doctor report for JSON output.
+type DoctorResult struct {
+	Checks   []DoctorCheck `json:"checks"`
+	Failures int           `json:"failures"`
+	Warnings int           `json:"warnings"`
+}
+
+func (r *DoctorResult) add(name, status, detail, fix string) {
+	r.Checks = append(r.Checks, DoctorCheck{Name: name, Status: status, Detail: detail, Fix: fix})
+	switch status {
+	case checkFail:
+		r.Failures++
+	case checkWarn:
+		r.Warnings++
+	}
+}
+
+// doctorCommand is mie doctor.
+var doctorCommand = command{
+	name:    "doctor",
+	summary: "Diagnose configuration and database problems",
+	usage:   "[options]",
+	help: `  Diagnose common problems: invalid configuration, an unavailable storage
+  engine, an outdated schema, missing HNSW indexes, an unreachable
+  embedding provider, embedding dimension mismatches, and edges that
+  point to deleted nodes. Each problem is printed with a suggested fix.
+  The database is inspected read-only unless --fix is given.
+
+  Exits with status 1 if any check fails.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.Bool("fix", false, "Remove orphaned edges")
+	},
+	examples: `  mie doctor              Run all checks
+  mie doctor --json       Output the report as JSON
+  mie doctor --fix        Also remove orphaned edges`,
+	run: runDoctor,
+}
+
+// runDoctor checks the configuration, database, and embedding provider and
+// prints a fix for every problem found.
+func runDoctor(fs *flag.FlagSet, configPath string, globals GlobalFlags) {
+	fix, _ := fs.GetBool("fix")
+
+	result := &DoctorResult{}
+	ctx := context.Background()
+
+	cfg := checkConfig(result, configPath)
+
+	if backend := checkStorage(result, cfg); backend != nil {
+		checkDatabase(ctx, result, cfg, backend, fix)
+		_ = backend.Close()
+	}
+
+	providerDim := checkEmbeddingProvider(ctx, result, cfg)
+	if providerDim > 0 && providerDim != cfg.Embedding.Dimensions {
+		result.add("Embedding dimensions", checkFail,
+			fmt.Sprintf("model %s returns %d-dimensional vectors but embedding.dimensions is %d", cfg.Embedding.Model, providerDim, cfg.Embedding.Dimensions),
+			fmt.Sprintf("Set embedding.dimensions: %d in the config", providerDim))
+	}
+	checkFallbackProvider(ctx, result, cfg, providerDim)
+
+	if globals.JSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(result)
+	} else {
+		printDoctor(result)
+	}
+
+	if result.Failures > 0 {
+		os.Exit(ExitGeneral)
+	}
+}
+
+// checkConfig loads and validates the configuration. Without a config file,
+// or when it is invalid, the remaining checks use the defaults that other
+// commands fall back to.
+func checkConfig(result *DoctorResult, configPath string) *Config {
+	path := configPath
+	if path == "" {
+		path = os.Getenv("MIE_CONFIG_PATH")
+	}
+	if path == "" {
+		path, _ = findConfigFile()
+	}
+
+	if path == "" {
+		cfg := DefaultConfig()
+		cfg.applyEnvOverrides()
+		if err := ValidateConfig(cfg); err != nil {
+			result.add("Config", checkFail, fmt.Sprintf("default config with environment overrides is invalid: %v", err),
+				"Fix the MIE_* environment variables")
+			return cfg
+		}
+		result.add("Config", checkWarn, "no .mie/config.yaml found; using defaults",
+			"Run 'mie init' to create a config file")
+		return cfg
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		result.add("Config", checkFail, err.Error(),
+			fmt.Sprintf("Correct %s, or run 'mie init --force' to regenerate it", path))
+		cfg = DefaultConfig()
+		cfg.applyEnvOverrides()
+		return cfg
+	}
+	result.add("Config", checkOK, path, "")
+	return cfg
+}
+
+// checkEmbeddingProvider generates a test embedding and returns its size,
+// or 0 if embeddings are disabled or the provider failed.
+func checkEmbeddingProvider(ctx context.Context, result *DoctorResult, cfg *Config) int {
+	emb := cfg.Embedding
+	if !emb.Enabled {
+		result.add("Embedding provider", checkOK, "embeddings disabled; semantic search is unavailable", "")
+		return 0
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	provider, err := memory.CreateEmbeddingProvider(emb.Provider, emb.APIKey, emb.BaseURL, emb.Model, logger)
+	if err != nil {
+		result.add("Embedding provider", checkFail, err.Error(), providerFix(emb))
+		return 0
+	}
+	defer func() { _ = memory.CloseEmbeddingProvider(provider) }()
+
+	ctx, cancel := context.WithTimeout(ctx, doctorEmbedTimeout)
+	defer cancel()
+	vec, err := provider.Embed(ctx, "mie doctor connectivity check")
+	if err != nil {
+		result.add("Embedding provider", checkFail, fmt.Sprintf("%s: %v", emb.Provider, err), providerFix(emb))
+		return 0
+	}
+
+	result.add("Embedding provider", checkOK, fmt.Sprintf("%s (%s) returned a %d-dimensional embedding", emb.Provider, emb.Model, len(vec)), "")
+	return len(vec)
+}
+
+// checkFallbackProvider generates a test embedding with
+// embedding.fallback_provider, if set, and checks that its vectors have the
+// size of primaryDim, the primary provider's, when that is known.
+func checkFallbackProvider(ctx context.Context, result *DoctorResult, cfg *Config, primaryDim int) {
+	emb := cfg.Embedding
+	if !emb.Enabled || emb.FallbackProvider == "" {
+		return
+	}
+	fix := "Check the embedding.fallback_* settings, or remove embedding.fallback_provider"
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	provider, err := memory.CreateEmbeddingProvider(emb.FallbackProvider, emb.FallbackAPIKey, emb.FallbackBaseURL, emb.FallbackModel, logger)
+	if err != nil {
+		result.add("Fallback embedding provider", checkFail, err.Error(), fix)
+		return
+	}
+	defer func() { _ = memory.CloseEmbeddingProvider(provider) }()
+
+	ctx, cancel := context.WithTimeout(ctx, doctorEmbedTimeout)
+	defer cancel()
+	vec, err := provider.Embed(ctx, "mie doctor connectivity check")
+	switch {
+	case err != nil:
+		result.add("Fallback embedding provider", checkWarn, fmt.Sprintf("%s: %v", emb.FallbackProvider, err), fix)
+	case primaryDim > 0 && len(vec) != primaryDim:
+		result.add("Fallback embedding provider", checkFail,
+			fmt.Sprintf("%s returns %d-dimensional vectors but %s returns %d", emb.FallbackProvider, len(vec), emb.Provider, primaryDim),
+			"Use a fallback model of the same size, ideally the same model as embedding.model")
+	default:
+		result.add("Fallback embedding provider", checkOK, fmt.Sprintf("%s returned a %d-dimensional embedding", emb.FallbackProvider, len(vec)), "")
+	}
+}
+
+// providerFix suggests how to repair a failing embedding provider.
+func providerFix(emb EmbeddingConfig) string {
+	switch emb.Provider {
+	case "ollama":
+		return fmt.Sprintf("Start Ollama ('ollama serve') and pull the model ('ollama pull %s'), or point embedding.base_url / OLLAMA_HOST at a running instance (currently %s)", emb.Model, emb.BaseURL)
+	case "openai":
+		return "Check embedding.api_key (or OPENAI_API_KEY), embedding.model, and network access to the OpenAI API"
+	case "nomic":
+		return "Check embedding.api_key (or NOMIC_API_KEY) and network access to the Nomic API"
+	case "local":
+		return "Set embedding.model to a GGUF model file and install llama.cpp's llama-server, or set embedding.base_url to its path"
+	default:
+		return "Set embedding.provider to ollama, openai, nomic, or local, or set embedding.enabled: false"
+	}
+}
+
+// checkStorage opens the database without applying the MIE schema. It
+// returns nil if there is nothing to inspect.
+func checkStorage(result *DoctorResult, cfg *Config) storage.Backend {
+	dataDir, err := ResolveDataDir(cfg)
+	if err != nil {
+		result.add("Storage", checkFail, err.Error(), "Set storage.path in the config or MIE_STORAGE_PATH")
+		return nil
+	}
+
+	if cfg.Storage.Engine == "mem" {
+		result.add("Storage", checkWarn, "mem engine: memory is lost when the process exits",
+			"Set storage.engine: rocksdb to keep memory between sessions")
+		return nil
+	}
+
+	if _, err := os.Stat(dataDir); os.IsNotExist(err) {
+		result.add("Storage", checkWarn, fmt.Sprintf("no database at %s yet", dataDir),
+			"Start the MCP server with 'mie --mcp' to create it")
+		return nil
+	}
+
+	backend, err := storage.NewEmbeddedBackend(storage.EmbeddedConfig{
+		DataDir:             dataDir,
+		Engine:              cfg.Storage.Engine,
+		EmbeddingDimensions: cfg.Embedding.Dimensions,
+	})
+	if err != nil {
+		result.add("Storage", checkFail, fmt.Sprintf("cannot open %s database at %s: %v", cfg.Storage.Engine, dataDir, err),
+			"Stop other mie processes holding the database (such as a running MCP server), and check that storage.engine matches the engine that created it")
+		return nil
+	}
+
+	result.add("Storage", checkOK, fmt.Sprintf("%s (%s)", cfg.Storage.Engine, dataDir), "")
+	return backend
+}
+
+// checkDatabase inspects the schema, indexes, stored embedding size, and
+// edges of an open database.
+func checkDatabase(ctx context.Context, result *DoctorResult, cfg *Config, backend storage.Backend, fix bool) {
+	d, err := memory.Diagnose(ctx, backend)
+	if err != nil {
+		result.add("Schema", checkFail, err.Error(), "Check that the data directory holds a MIE database")
+		return
+	}
+
+	switch {
+	case d.SchemaVersion > memory.SchemaVersion:
+		result.add("Schema", checkFail,
+			fmt.Sprintf("database schema version %d is newer than this mie supports (%d)", d.SchemaVersion, memory.SchemaVersion),
+			"Upgrade mie")
+	case d.SchemaVersion == 0 && len(d.MissingTables) > 0:
+		result.add("Schema", checkWarn, "database has no MIE schema yet",
+			"Start the MCP server with 'mie --mcp' to create it")
+	case d.SchemaVersion < memory.SchemaVersion || len(d.MissingTables) > 0:
+		result.add("Schema", checkWarn,
+			fmt.Sprintf("schema version %d, current is %d", d.SchemaVersion, memory.SchemaVersion),
+			"Run 'mie status' or start the MCP server; the schema is migrated when the database is opened")
+	default:
+		result.add("Schema", checkOK, fmt.Sprintf("version %d", d.SchemaVersion), "")
+	}
+
+	if cfg.Embedding.Enabled {
+		if d.EmbeddingDimensions > 0 && d.EmbeddingDimensions != cfg.Embedding.Dimensions {
+			result.add("Stored embeddings", checkFail,
+				fmt.Sprintf("database stores %d-dimensional embeddings but embedding.dimensions is %d", d.EmbeddingDimensions, cfg.Embedding.Dimensions),
+				fmt.Sprintf("Set embedding.dimensions: %d and use a model of that size, or export with 'mie export', delete the data directory, and re-import to switch models", d.EmbeddingDimensions))
+		}
+
+		switch {
+		case cfg.Embedding.Index == "flat":
+			// The flat index is built in memory; there are no HNSW indexes.
+		case len(d.MissingHNSWIndexes) > 0:
+			result.add("HNSW indexes", checkFail, "missing: "+strings.Join(d.MissingHNSWIndexes, ", "),
+				"Run 'mie embed' with embeddings enabled; opening the database creates the indexes")
+		case d.SchemaVersion > 0:
+			result.add("HNSW indexes", checkOK, "present", "")
+		}
+	}
+
+	if len(d.OrphanedEdges) == 0 {
+		result.add("Edges", checkOK, "no orphaned edges", "")
+		return
+	}
+
+	tables := make([]string, 0, len(d.OrphanedEdges))
+	total := 0
+	for table, n := range d.OrphanedEdges {
+		tables = append(tables, fmt.Sprintf("%s: %d", table, n))
+		total += n
+	}
+	sort.Strings(tables)
+	detail := fmt.Sprintf("%d edges point to deleted nodes (%s)", total, strings.Join(tables, ", "))
+
+	if !fix {
+		result.add("Edges", checkWarn, detail, "Run 'mie doctor --fix' to remove them")
+		return
+	}
+	removed, err := memory.RemoveOrphanedEdges(ctx, backend)
+	if err != nil {
+		result.add("Edges", checkFail, fmt.Sprintf("%s; removal failed: %v", detail, err), "")
+		return
+	}
+	result.add("Edges", checkOK, fmt.Sprintf("removed %d orphaned edges", removed), "")
+}
+
+func printDoctor(result *DoctorResult) {
+	fmt.Println("MIE Doctor")
+	fmt.Println()
+
+	labels := map[string]string{checkOK: "ok", checkWarn: "WARN", checkFail: "FAIL"}
+	for _, c := range result.Checks {
+		fmt.Printf("  %-5s %-20s %s\n", labels[c.Status], c.Name, c.Detail)
+		if c.Fix != "" {
+			fmt.Printf("        %-20s Fix: %s\n", "", c.Fix)
+		}
+	}
+	fmt.Println()
+
+	switch {
+	case result.Failures > 0:
+		fmt.Printf("%d of %d checks failed (warnings: %d)\n", result.Failures, len(result.Checks), result.Warnings)
+	case result.Warnings > 0:
+		fmt.Printf("All checks passed (warnings: %d)\n", result.Warnings)
+	default:
+		fmt.Println("All checks passed")
+	}
+}
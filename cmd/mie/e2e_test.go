@@ -8,12 +8,20 @@ package main
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
 	"io"
+	"log/slog"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/policy"
+	"github.com/kraklabs/mie/pkg/tools"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -21,7 +29,8 @@ import (
 // startTestServer creates an MCP server backed by an in-memory CozoDB and
 // returns a writer for sending requests and a reader for reading responses.
 // The server runs in a background goroutine and stops when the writer is closed.
-func startTestServer(t *testing.T) (io.WriteCloser, *bufio.Reader) {
+// Options adjust the server before it starts.
+func startTestServer(t *testing.T, opts ...func(*mcpServer)) (io.WriteCloser, *bufio.Reader) {
 	t.Helper()
 
 	dir := t.TempDir()
@@ -39,15 +48,19 @@ func startTestServer(t *testing.T) (io.WriteCloser, *bufio.Reader) {
 	cfg.Embedding.Enabled = false
 
 	server := &mcpServer{
-		client: client,
-		config: cfg,
+		client:  client,
+		config:  cfg,
+		changes: client.Changes(),
+	}
+	for _, opt := range opts {
+		opt(server)
 	}
 
 	stdinReader, stdinWriter := io.Pipe()
 	stdoutReader, stdoutWriter := io.Pipe()
 
 	go func() {
-		_ = server.serve(stdinReader, stdoutWriter)
+		_ = server.serve(context.Background(), stdinReader, stdoutWriter)
 		_ = stdoutWriter.Close()
 	}()
 
@@ -153,6 +166,9 @@ func TestMCPInitialize(t *testing.T) {
 	toolsCap, ok := caps["tools"].(map[string]any)
 	require.True(t, ok)
 	assert.Equal(t, true, toolsCap["listChanged"])
+	resourcesCap, ok := caps["resources"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, true, resourcesCap["subscribe"])
 }
 
 func TestMCPToolsList(t *testing.T) {
@@ -169,18 +185,37 @@ func TestMCPToolsList(t *testing.T) {
 
 	toolsList, ok := result["tools"].([]any)
 	require.True(t, ok)
-	assert.Len(t, toolsList, 9)
+	assert.Len(t, toolsList, 27)
 
 	expectedNames := map[string]bool{
-		"mie_analyze":    false,
-		"mie_store":      false,
-		"mie_bulk_store": false,
-		"mie_query":      false,
-		"mie_update":     false,
-		"mie_list":       false,
-		"mie_conflicts":  false,
-		"mie_export":     false,
-		"mie_status":     false,
+		"mie_analyze":               false,
+		"mie_store":                 false,
+		"mie_bulk_store":            false,
+		"mie_query":                 false,
+		"mie_bulk_query":            false,
+		"mie_context":               false,
+		"mie_ask":                   false,
+		"mie_update":                false,
+		"mie_relate":                false,
+		"mie_merge":                 false,
+		"mie_list":                  false,
+		"mie_conflicts":             false,
+		"mie_similar":               false,
+		"mie_dedupe":                false,
+		"mie_preferences":           false,
+		"mie_export":                false,
+		"mie_status":                false,
+		"mie_stats_by_topic":        false,
+		"mie_embedding_status":      false,
+		"mie_topics":                false,
+		"mie_visualize":             false,
+		"mie_audit":                 false,
+		"mie_history":               false,
+		"mie_suggest_relationships": false,
+		"mie_snapshot":              false,
+		"mie_review":                false,
+		"mie_session":               false,
+		"mie_attach":                false,
 	}
 
 	for _, tool := range toolsList {
@@ -199,6 +234,9 @@ func TestMCPToolsList(t *testing.T) {
 		schema, ok := toolMap["inputSchema"].(map[string]any)
 		require.True(t, ok, "tool %s should have inputSchema", name)
 		assert.Equal(t, "object", schema["type"], "tool %s schema type should be object", name)
+		props, ok := schema["properties"].(map[string]any)
+		require.True(t, ok, "tool %s should have properties", name)
+		assert.Contains(t, props, "namespace", "tool %s should accept a namespace", name)
 	}
 
 	// Verify all expected tools were found
@@ -268,6 +306,187 @@ func TestMCPStoreAndList(t *testing.T) {
 	assert.Contains(t, listResult, "The sky is blue")
 }
 
+func TestMCPNamespaces(t *testing.T) {
+	w, r := startTestServer(t)
+	defer w.Close()
+
+	initSession(t, w, r)
+
+	storeResp := callTool(t, w, r, 2, "mie_store", map[string]any{
+		"type":      "fact",
+		"content":   "Project A uses Rust",
+		"namespace": "project-a",
+	})
+	assert.Nil(t, storeResp["error"])
+	assert.Contains(t, extractToolText(t, storeResp), "Stored fact")
+
+	listResp := callTool(t, w, r, 3, "mie_list", map[string]any{
+		"node_type": "fact",
+		"namespace": "project-b",
+	})
+	assert.Contains(t, extractToolText(t, listResp), "No results found")
+
+	listResp = callTool(t, w, r, 4, "mie_list", map[string]any{
+		"node_type": "fact",
+		"namespace": "project-a",
+	})
+	assert.Contains(t, extractToolText(t, listResp), "Project A uses Rust")
+
+	badResp := callTool(t, w, r, 5, "mie_list", map[string]any{
+		"node_type": "fact",
+		"namespace": "Not Valid",
+	})
+	assert.Contains(t, extractToolText(t, badResp), "Invalid namespace")
+}
+
+func TestMCPProjectArgument(t *testing.T) {
+	w, r := startTestServer(t)
+	defer w.Close()
+
+	initSession(t, w, r)
+
+	storeResp := callTool(t, w, r, 2, "mie_store", map[string]any{
+		"type":    "fact",
+		"content": "The billing service uses Go",
+		"project": "Billing Service",
+	})
+	assert.Contains(t, extractToolText(t, storeResp), "Stored fact")
+
+	listResp := callTool(t, w, r, 3, "mie_list", map[string]any{
+		"node_type": "fact",
+		"namespace": "billing-service",
+	})
+	assert.Contains(t, extractToolText(t, listResp), "The billing service uses Go")
+
+	bothResp := callTool(t, w, r, 4, "mie_list", map[string]any{
+		"node_type": "fact",
+		"namespace": "billing-service",
+		"project":   "Billing Service",
+	})
+	assert.Contains(t, extractToolText(t, bothResp), "not both")
+}
+
+func TestMCPResponseFormatJSON(t *testing.T) {
+	w, r := startTestServer(t)
+	defer w.Close()
+
+	initSession(t, w, r)
+
+	storeResp := callTool(t, w, r, 2, "mie_store", map[string]any{
+		"type":            "fact",
+		"content":         "The API is written in Go",
+		"response_format": "json",
+	})
+	var stored struct {
+		ID       string `json:"id"`
+		NodeType string `json:"node_type"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(extractToolText(t, storeResp)), &stored))
+	assert.Equal(t, "fact", stored.NodeType)
+	assert.True(t, strings.HasPrefix(stored.ID, "fact:"))
+
+	listResp := callTool(t, w, r, 3, "mie_list", map[string]any{
+		"node_type":       "fact",
+		"response_format": "json",
+	})
+	var listed struct {
+		Total int              `json:"total"`
+		Nodes []map[string]any `json:"nodes"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(extractToolText(t, listResp)), &listed))
+	assert.Equal(t, 1, listed.Total)
+	require.Len(t, listed.Nodes, 1)
+	assert.Equal(t, stored.ID, listed.Nodes[0]["id"])
+
+	badResp := callTool(t, w, r, 4, "mie_status", map[string]any{"response_format": "yaml"})
+	assert.Contains(t, extractToolText(t, badResp), "invalid response_format")
+}
+
+func TestMCPAudit(t *testing.T) {
+	w, r := startTestServer(t)
+	defer w.Close()
+
+	initSession(t, w, r)
+
+	storeResp := callTool(t, w, r, 2, "mie_store", map[string]any{
+		"type":            "fact",
+		"content":         "Deploys run on Fridays",
+		"source_agent":    "claude",
+		"response_format": "json",
+	})
+	var stored struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(extractToolText(t, storeResp)), &stored))
+
+	callTool(t, w, r, 3, "mie_update", map[string]any{
+		"node_id": stored.ID,
+		"action":  "archive",
+		"reason":  "Outdated",
+	})
+
+	auditResp := callTool(t, w, r, 4, "mie_audit", map[string]any{
+		"node_id":         stored.ID,
+		"response_format": "json",
+	})
+	var audit struct {
+		Entries []struct {
+			Op          string   `json:"op"`
+			Tool        string   `json:"tool"`
+			SourceAgent string   `json:"source_agent"`
+			NodeIDs     []string `json:"node_ids"`
+		} `json:"entries"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(extractToolText(t, auditResp)), &audit))
+	require.Len(t, audit.Entries, 2)
+	assert.Equal(t, "archived", audit.Entries[0].Op)
+	assert.Equal(t, "mie_update", audit.Entries[0].Tool)
+	assert.Equal(t, "created", audit.Entries[1].Op)
+	assert.Equal(t, "mie_store", audit.Entries[1].Tool)
+	assert.Equal(t, "claude", audit.Entries[1].SourceAgent)
+	assert.Equal(t, []string{stored.ID}, audit.Entries[1].NodeIDs)
+}
+
+func TestMCPWorkspaceNamespace(t *testing.T) {
+	w, r := startTestServer(t, func(s *mcpServer) { s.namespaceFromWorkspace = true })
+	defer w.Close()
+
+	sendRequest(t, w, r, 1, "initialize", map[string]any{
+		"protocolVersion": "2024-11-05",
+		"capabilities":    map[string]any{},
+		"clientInfo":      map[string]any{"name": "test", "version": "0.0.1"},
+		"rootUri":         "file:///home/dev/My%20Repo/",
+	})
+	sendNotification(t, w, "notifications/initialized", nil)
+
+	storeResp := callTool(t, w, r, 2, "mie_store", map[string]any{
+		"type":    "fact",
+		"content": "My Repo deploys on Fridays",
+	})
+	assert.Contains(t, extractToolText(t, storeResp), "Stored fact")
+
+	listResp := callTool(t, w, r, 3, "mie_list", map[string]any{
+		"node_type": "fact",
+		"namespace": "my-repo",
+	})
+	assert.Contains(t, extractToolText(t, listResp), "My Repo deploys on Fridays")
+
+	listResp = callTool(t, w, r, 4, "mie_list", map[string]any{
+		"node_type": "fact",
+		"namespace": "default",
+	})
+	assert.Contains(t, extractToolText(t, listResp), "No results found")
+}
+
+func TestWorkspaceNamespace(t *testing.T) {
+	ns, err := workspaceNamespace("file:///home/dev/Acme.API")
+	require.NoError(t, err)
+	assert.Equal(t, "acme.api", ns)
+
+	_, err = workspaceNamespace("https://example.com/repo")
+	assert.Error(t, err)
+}
+
 func TestMCPStoreAndQuery(t *testing.T) {
 	w, r := startTestServer(t)
 	defer w.Close()
@@ -299,6 +518,211 @@ func TestMCPStoreAndQuery(t *testing.T) {
 	assert.Contains(t, queryResult, "Entities")
 }
 
+func TestMCPSavedSearch(t *testing.T) {
+	w, r := startTestServer(t)
+	defer w.Close()
+
+	initSession(t, w, r)
+
+	callTool(t, w, r, 2, "mie_store", map[string]any{
+		"type":         "entity",
+		"name":         "Acme Corp",
+		"kind":         "company",
+		"source_agent": "test",
+	})
+
+	saveResp := callTool(t, w, r, 3, "mie_query", map[string]any{
+		"action":     "save",
+		"name":       "companies",
+		"query":      "Acme",
+		"mode":       "exact",
+		"node_types": []string{"entity"},
+	})
+	assert.Contains(t, extractToolText(t, saveResp), `Saved search "companies"`)
+
+	listResp := callTool(t, w, r, 4, "mie_query", map[string]any{"action": "list"})
+	assert.Contains(t, extractToolText(t, listResp), "| companies |")
+
+	runResp := callTool(t, w, r, 5, "mie_query", map[string]any{"action": "run", "name": "companies"})
+	assert.Contains(t, extractToolText(t, runResp), "Acme Corp")
+}
+
+func TestMCPSession(t *testing.T) {
+	w, r := startTestServer(t)
+	defer w.Close()
+
+	initSession(t, w, r)
+
+	openResp := callTool(t, w, r, 2, "mie_session", map[string]any{"action": "open", "title": "Queue choice", "source_agent": "test"})
+	assert.Contains(t, extractToolText(t, openResp), "Opened session [ses:")
+
+	callTool(t, w, r, 3, "mie_store", map[string]any{
+		"type":         "decision",
+		"title":        "Use NATS for jobs",
+		"rationale":    "Already run in production",
+		"source_agent": "test",
+	})
+
+	closeResp := callTool(t, w, r, 4, "mie_session", map[string]any{"action": "close", "summary": "Chose NATS"})
+	assert.Contains(t, extractToolText(t, closeResp), "Closed session [ses:")
+
+	// Stores after the session closed are not part of it.
+	callTool(t, w, r, 5, "mie_store", map[string]any{
+		"type":         "fact",
+		"content":      "Deploys run on Fridays",
+		"category":     "technical",
+		"source_agent": "test",
+	})
+
+	listResp := callTool(t, w, r, 6, "mie_session", map[string]any{"action": "list", "response_format": "json"})
+	var list struct {
+		Sessions []tools.Session `json:"sessions"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(extractToolText(t, listResp)), &list))
+	require.Len(t, list.Sessions, 1)
+
+	showResp := callTool(t, w, r, 7, "mie_session", map[string]any{"action": "show", "session_id": list.Sessions[0].ID})
+	text := extractToolText(t, showResp)
+	assert.Contains(t, text, "Summary: Chose NATS")
+	assert.Contains(t, text, "Use NATS for jobs")
+	assert.NotContains(t, text, "Deploys run on Fridays")
+}
+
+func TestMCPAttachment(t *testing.T) {
+	w, r := startTestServer(t)
+	defer w.Close()
+
+	initSession(t, w, r)
+
+	storeResp := callTool(t, w, r, 2, "mie_store", map[string]any{
+		"type":            "decision",
+		"title":           "Use NATS for jobs",
+		"rationale":       "Already run in production",
+		"source_agent":    "test",
+		"response_format": "json",
+	})
+	var stored struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(extractToolText(t, storeResp)), &stored))
+	require.NotEmpty(t, stored.ID)
+
+	addResp := callTool(t, w, r, 3, "mie_attach", map[string]any{
+		"node_id": stored.ID, "name": "nats.conf", "content": "max_payload: 8MB\n", "mime_type": "text/plain", "response_format": "json",
+	})
+	var added struct {
+		URI string `json:"uri"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(extractToolText(t, addResp)), &added))
+	require.True(t, strings.HasPrefix(added.URI, tools.AttachmentURIPrefix), added.URI)
+
+	resp := sendRequest(t, w, r, 4, "resources/read", map[string]any{"uri": added.URI})
+	require.Nil(t, resp["error"])
+	contents := resp["result"].(map[string]any)["contents"].([]any)
+	require.Len(t, contents, 1)
+	assert.Equal(t, "max_payload: 8MB\n", contents[0].(map[string]any)["text"])
+
+	// Binary content comes back base64 encoded.
+	callTool(t, w, r, 5, "mie_attach", map[string]any{"node_id": stored.ID, "name": "flow.png", "content": "iVBORw0KGgo=", "encoding": "base64"})
+	listResp := callTool(t, w, r, 6, "mie_attach", map[string]any{"action": "list", "node_id": stored.ID, "response_format": "json"})
+	var list struct {
+		Attachments []tools.Attachment `json:"attachments"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(extractToolText(t, listResp)), &list))
+	require.Len(t, list.Attachments, 2)
+	png := list.Attachments[1]
+	assert.Equal(t, "image/png", png.MimeType)
+	resp = sendRequest(t, w, r, 7, "resources/read", map[string]any{"uri": png.URI()})
+	contents = resp["result"].(map[string]any)["contents"].([]any)
+	assert.Equal(t, "iVBORw0KGgo=", contents[0].(map[string]any)["blob"])
+
+	resp = sendRequest(t, w, r, 8, "resources/templates/list", nil)
+	templates := resp["result"].(map[string]any)["resourceTemplates"].([]any)
+	require.Len(t, templates, 4)
+	assert.Equal(t, "mie://attachment/{hash}", templates[0].(map[string]any)["uriTemplate"])
+	assert.Equal(t, "mie://topic/{name}/summary", templates[1].(map[string]any)["uriTemplate"])
+	assert.Equal(t, "mie://entity/{id}", templates[2].(map[string]any)["uriTemplate"])
+	assert.Equal(t, "mie://decision/{id}", templates[3].(map[string]any)["uriTemplate"])
+
+	callTool(t, w, r, 9, "mie_attach", map[string]any{"action": "remove", "node_id": stored.ID, "hash": png.Hash})
+	resp = sendRequest(t, w, r, 10, "resources/read", map[string]any{"uri": png.URI()})
+	assert.NotNil(t, resp["error"])
+}
+
+func TestMCPTopicSummary(t *testing.T) {
+	w, r := startTestServer(t)
+	defer w.Close()
+
+	initSession(t, w, r)
+
+	storeID := func(id int, args map[string]any) string {
+		args["source_agent"] = "test"
+		args["response_format"] = "json"
+		var stored struct {
+			ID string `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(extractToolText(t, callTool(t, w, r, id, "mie_store", args))), &stored))
+		require.NotEmpty(t, stored.ID)
+		return stored.ID
+	}
+	topicID := storeID(2, map[string]any{"type": "topic", "name": "Job Queue", "description": "Background job processing"})
+	storeID(3, map[string]any{
+		"type": "fact", "content": "Jobs are retried three times", "category": "technical",
+		"relationships": []any{map[string]any{"edge": "fact_topic", "target_id": topicID}},
+	})
+	decisionID := storeID(4, map[string]any{
+		"type": "decision", "title": "Use NATS for jobs", "rationale": "Already run in production",
+		"relationships": []any{map[string]any{"edge": "decision_topic", "target_id": topicID}},
+	})
+	storeID(5, map[string]any{
+		"type": "event", "title": "NATS rollout", "event_date": "2020-01-15",
+		"relationships": []any{map[string]any{"edge": "event_decision", "target_id": decisionID}},
+	})
+
+	resp := sendRequest(t, w, r, 6, "resources/read", map[string]any{"uri": "mie://topic/job%20queue/summary"})
+	require.Nil(t, resp["error"])
+	contents := resp["result"].(map[string]any)["contents"].([]any)
+	require.Len(t, contents, 1)
+	content := contents[0].(map[string]any)
+	assert.Equal(t, "text/markdown", content["mimeType"])
+	text := content["text"].(string)
+	assert.True(t, strings.HasPrefix(text, "# Job Queue\n"), text)
+	assert.Contains(t, text, "Jobs are retried three times")
+	assert.Contains(t, text, "**Use NATS for jobs**: Already run in production")
+	assert.Contains(t, text, "## Past Events\n\n- 2020-01-15 [")
+
+	resp = sendRequest(t, w, r, 7, "resources/read", map[string]any{"uri": "mie://topic/billing/summary"})
+	assert.NotNil(t, resp["error"])
+}
+
+func TestMCPNodeResources(t *testing.T) {
+	w, r := startTestServer(t)
+	defer w.Close()
+
+	initSession(t, w, r)
+
+	var stored struct {
+		ID string `json:"id"`
+	}
+	resp := callTool(t, w, r, 2, "mie_store", map[string]any{
+		"type": "decision", "title": "Use NATS for jobs", "rationale": "Already run in production",
+		"source_agent": "test", "response_format": "json",
+	})
+	require.NoError(t, json.Unmarshal([]byte(extractToolText(t, resp)), &stored))
+
+	resp = sendRequest(t, w, r, 3, "resources/read", map[string]any{"uri": "mie://decision/" + stored.ID})
+	require.Nil(t, resp["error"])
+	content := resp["result"].(map[string]any)["contents"].([]any)[0].(map[string]any)
+	assert.Equal(t, "text/markdown", content["mimeType"])
+	text := content["text"].(string)
+	assert.True(t, strings.HasPrefix(text, "# Use NATS for jobs\n"), text)
+	assert.Contains(t, text, "## Rationale\n\nAlready run in production")
+
+	// A decision is not readable as an entity.
+	resp = sendRequest(t, w, r, 4, "resources/read", map[string]any{"uri": "mie://entity/" + stored.ID})
+	assert.NotNil(t, resp["error"])
+}
+
 func TestMCPStoreAndUpdate(t *testing.T) {
 	w, r := startTestServer(t)
 	defer w.Close()
@@ -465,6 +889,393 @@ func TestMCPUnknownMethod(t *testing.T) {
 	assert.Equal(t, "Method not found", errObj["message"])
 }
 
+func TestMCPReadOnly(t *testing.T) {
+	w, r := startTestServer(t, func(s *mcpServer) { s.readOnly = true })
+	defer w.Close()
+
+	resp := initSession(t, w, r)
+	result, ok := resp["result"].(map[string]any)
+	require.True(t, ok)
+	assert.Contains(t, result["instructions"], "read-only")
+
+	resp = sendRequest(t, w, r, 2, "tools/list", nil)
+	result, ok = resp["result"].(map[string]any)
+	require.True(t, ok)
+	toolsList, ok := result["tools"].([]any)
+	require.True(t, ok)
+	var names []string
+	for _, tool := range toolsList {
+		names = append(names, tool.(map[string]any)["name"].(string))
+	}
+	assert.Contains(t, names, "mie_query")
+	assert.Contains(t, names, "mie_list")
+	assert.Contains(t, names, "mie_status")
+	assert.Contains(t, names, "mie_export")
+	for _, name := range []string{"mie_store", "mie_bulk_store", "mie_update", "mie_relate", "mie_merge", "mie_snapshot"} {
+		assert.NotContains(t, names, name)
+	}
+
+	// Write tools are rejected even when called directly.
+	storeResp := callTool(t, w, r, 3, "mie_store", map[string]any{
+		"type":         "fact",
+		"content":      "The sky is blue",
+		"category":     "general",
+		"source_agent": "test",
+	})
+	storeResult, ok := storeResp["result"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, true, storeResult["isError"])
+	assert.Contains(t, extractToolText(t, storeResp), "read-only")
+
+	listResp := callTool(t, w, r, 4, "mie_list", map[string]any{"node_type": "fact"})
+	assert.Contains(t, extractToolText(t, listResp), "0 total")
+
+	// Saving a search writes, so it is rejected; listing saved searches is not.
+	saveResp := callTool(t, w, r, 5, "mie_query", map[string]any{"action": "save", "name": "sky", "query": "sky"})
+	assert.Contains(t, extractToolText(t, saveResp), "read-only")
+	savedResp := callTool(t, w, r, 6, "mie_query", map[string]any{"action": "list"})
+	assert.Contains(t, extractToolText(t, savedResp), "No saved searches")
+
+	// mie_attach adds an attachment when the call leaves out action.
+	attachResp := callTool(t, w, r, 7, "mie_attach", map[string]any{"node_id": "fact:abc", "content": "graph TD"})
+	assert.Contains(t, extractToolText(t, attachResp), "Action add of mie_attach is disabled: this MIE server is read-only")
+}
+
+func TestMCPClientPolicy(t *testing.T) {
+	p, err := policy.New(map[string]policy.Rule{
+		"test": {Deny: []string{"mie_merge", "mie_query:delete", "mie_relate:create"}},
+	})
+	require.NoError(t, err)
+	w, r := startTestServer(t, func(s *mcpServer) { s.policy = p })
+	defer w.Close()
+
+	initSession(t, w, r)
+
+	resp := sendRequest(t, w, r, 2, "tools/list", nil)
+	result, ok := resp["result"].(map[string]any)
+	require.True(t, ok)
+	var names []string
+	for _, tool := range result["tools"].([]any) {
+		names = append(names, tool.(map[string]any)["name"].(string))
+	}
+	assert.NotContains(t, names, "mie_merge")
+	assert.Contains(t, names, "mie_query", "tools with a denied action stay listed")
+
+	mergeResp := callTool(t, w, r, 3, "mie_merge", map[string]any{"survivor_id": "ent:a", "duplicate_id": "ent:b"})
+	assert.Contains(t, extractToolText(t, mergeResp), "not permitted for client")
+
+	saveResp := callTool(t, w, r, 4, "mie_query", map[string]any{"action": "save", "name": "sky", "query": "sky"})
+	assert.NotContains(t, extractToolText(t, saveResp), "not permitted")
+	deleteResp := callTool(t, w, r, 5, "mie_query", map[string]any{"action": "delete", "name": "sky"})
+	assert.Contains(t, extractToolText(t, deleteResp), "Action delete of mie_query is not permitted")
+
+	// mie_relate creates an edge when the call leaves out action.
+	relateResp := callTool(t, w, r, 6, "mie_relate", map[string]any{
+		"edge_type": "fact_entity", "source_id": "fact:a", "target_id": "ent:b",
+	})
+	assert.Contains(t, extractToolText(t, relateResp), "Action create of mie_relate is not permitted")
+}
+
+func TestMCPResourceSubscribe(t *testing.T) {
+	w, r := startTestServer(t)
+	defer w.Close()
+
+	initSession(t, w, r)
+
+	resp := sendRequest(t, w, r, 2, "resources/subscribe", map[string]any{"uri": "mie://changes"})
+	assert.Nil(t, resp["error"])
+
+	resp = sendRequest(t, w, r, 3, "resources/subscribe", map[string]any{"uri": "mie://unknown"})
+	assert.NotNil(t, resp["error"])
+
+	// The store response and the change notification may arrive in either order.
+	data, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      4,
+		"method":  "tools/call",
+		"params": map[string]any{
+			"name": "mie_store",
+			"arguments": map[string]any{
+				"type":         "fact",
+				"content":      "The sky is blue",
+				"category":     "general",
+				"source_agent": "test",
+			},
+		},
+	})
+	require.NoError(t, err)
+	_, err = w.Write(append(data, '\n'))
+	require.NoError(t, err)
+
+	var gotResponse, gotNotification bool
+	for !gotResponse || !gotNotification {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		var msg map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &msg))
+		if msg["method"] == "notifications/resources/updated" {
+			params, _ := msg["params"].(map[string]any)
+			assert.Equal(t, "mie://changes", params["uri"])
+			gotNotification = true
+			continue
+		}
+		assert.Equal(t, float64(4), msg["id"])
+		gotResponse = true
+	}
+
+	resp = sendRequest(t, w, r, 5, "resources/read", map[string]any{"uri": "mie://changes"})
+	assert.Nil(t, resp["error"])
+	result, ok := resp["result"].(map[string]any)
+	require.True(t, ok)
+	contents, ok := result["contents"].([]any)
+	require.True(t, ok)
+	require.Len(t, contents, 1)
+	text, _ := contents[0].(map[string]any)["text"].(string)
+	assert.Contains(t, text, "created fact:")
+
+	resp = sendRequest(t, w, r, 6, "resources/unsubscribe", map[string]any{"uri": "mie://changes"})
+	assert.Nil(t, resp["error"])
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use, for logs written
+// by the server's goroutines.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestMCPToolCallLogging(t *testing.T) {
+	for _, level := range []string{"info", "debug"} {
+		t.Run(level, func(t *testing.T) {
+			var logs syncBuffer
+			w, r := startTestServer(t, func(s *mcpServer) {
+				s.logger = LogConfig{Level: level}.newLogger(&logs, slog.LevelInfo)
+			})
+			defer w.Close()
+			initSession(t, w, r)
+
+			callTool(t, w, r, 7, "mie_store", map[string]any{
+				"type":     "fact",
+				"content":  "The staging password rotates monthly",
+				"category": "technical",
+			})
+			out := logs.String()
+			assert.Contains(t, out, "msg=\"tool call\" request_id=7 tool=mie_store")
+			assert.Contains(t, out, "msg=\"tool call done\" request_id=7 tool=mie_store")
+			if level == "info" {
+				assert.Contains(t, out, "args=\"[category content type]\"")
+				assert.NotContains(t, out, "staging password", "argument values are redacted at info level")
+			} else {
+				assert.Contains(t, out, "staging password")
+			}
+		})
+	}
+}
+
+// blockingStats is a Querier whose GetStats, behind mie_status, blocks
+// until its context is done.
+type blockingStats struct {
+	tools.Querier
+	started chan struct{}
+}
+
+func (b blockingStats) GetStats(ctx context.Context) (*tools.GraphStats, error) {
+	b.started <- struct{}{}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestMCPCancelRequest(t *testing.T) {
+	started := make(chan struct{}, 1)
+	w, r := startTestServer(t, func(s *mcpServer) {
+		s.client = blockingStats{Querier: s.client, started: started}
+	})
+	defer w.Close()
+	initSession(t, w, r)
+
+	data, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      2,
+		"method":  "tools/call",
+		"params":  map[string]any{"name": "mie_status", "arguments": map[string]any{}},
+	})
+	require.NoError(t, err)
+	_, err = w.Write(append(data, '\n'))
+	require.NoError(t, err)
+
+	// Other requests are answered while the call runs.
+	<-started
+	resp := sendRequest(t, w, r, 3, "tools/list", nil)
+	assert.Equal(t, float64(3), resp["id"])
+
+	// The cancelled call gets no response, so the next line answers id 4.
+	sendNotification(t, w, "notifications/cancelled", map[string]any{"requestId": 2, "reason": "user aborted"})
+	resp = sendRequest(t, w, r, 4, "tools/list", nil)
+	assert.Equal(t, float64(4), resp["id"])
+}
+
+func TestMCPConcurrencyLimit(t *testing.T) {
+	started := make(chan struct{}, 2)
+	w, r := startTestServer(t, func(s *mcpServer) {
+		s.client = blockingStats{Querier: s.client, started: started}
+		s.callSlots = make(chan struct{}, 1)
+	})
+	defer w.Close()
+	initSession(t, w, r)
+
+	for _, id := range []int{2, 3} {
+		data, err := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      id,
+			"method":  "tools/call",
+			"params":  map[string]any{"name": "mie_status", "arguments": map[string]any{}},
+		})
+		require.NoError(t, err)
+		_, err = w.Write(append(data, '\n'))
+		require.NoError(t, err)
+	}
+
+	// The second call waits for the first, and the server keeps reading.
+	<-started
+	resp := sendRequest(t, w, r, 4, "tools/list", nil)
+	assert.Equal(t, float64(4), resp["id"])
+	select {
+	case <-started:
+		t.Fatal("second call started while the first held the only slot")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Cancelling the first call lets the second run.
+	sendNotification(t, w, "notifications/cancelled", map[string]any{"requestId": 2})
+	<-started
+	sendNotification(t, w, "notifications/cancelled", map[string]any{"requestId": 3})
+	resp = sendRequest(t, w, r, 5, "tools/list", nil)
+	assert.Equal(t, float64(5), resp["id"])
+}
+
+// panickingStats is a Querier whose GetStats, behind mie_status, panics
+// once and then delegates.
+type panickingStats struct {
+	tools.Querier
+	panicked *atomic.Bool
+}
+
+func (p panickingStats) GetStats(ctx context.Context) (*tools.GraphStats, error) {
+	if p.panicked.CompareAndSwap(false, true) {
+		panic("boom")
+	}
+	return p.Querier.GetStats(ctx)
+}
+
+func TestMCPPanickingHandlerFreesSlot(t *testing.T) {
+	w, r := startTestServer(t, func(s *mcpServer) {
+		s.client = panickingStats{Querier: s.client, panicked: new(atomic.Bool)}
+		s.callSlots = make(chan struct{}, 1)
+	})
+	defer w.Close()
+	initSession(t, w, r)
+
+	resp := callTool(t, w, r, 2, "mie_status", map[string]any{})
+	rpcErr, ok := resp["error"].(map[string]any)
+	require.True(t, ok, "a panicking tool call should answer with an error")
+	assert.Equal(t, float64(-32603), rpcErr["code"])
+	assert.Equal(t, "boom", rpcErr["data"])
+
+	// The server is still up and the panicking call freed its only slot.
+	resp = callTool(t, w, r, 3, "mie_status", map[string]any{})
+	assert.Nil(t, resp["error"])
+	assert.NotEmpty(t, extractToolText(t, resp))
+}
+
+func TestMCPRequestTimeout(t *testing.T) {
+	started := make(chan struct{}, 1)
+	w, r := startTestServer(t, func(s *mcpServer) {
+		s.client = blockingStats{Querier: s.client, started: started}
+		s.requestTimeout = 50 * time.Millisecond
+	})
+	defer w.Close()
+	initSession(t, w, r)
+
+	resp := callTool(t, w, r, 2, "mie_status", map[string]any{})
+	assert.Contains(t, extractToolText(t, resp), "timed out after 50ms")
+}
+
+func TestMCPStrictValidation(t *testing.T) {
+	args := map[string]any{"type": "fact", "content": "Uses Go", "confidence": 1.5}
+
+	// By default, the tool falls back to the default confidence.
+	w, r := startTestServer(t)
+	defer w.Close()
+	initSession(t, w, r)
+	resp := callTool(t, w, r, 2, "mie_store", args)
+	assert.NotContains(t, extractToolText(t, resp), "Invalid arguments")
+
+	w, r = startTestServer(t, func(s *mcpServer) { s.strictValidation = true })
+	defer w.Close()
+	initSession(t, w, r)
+	resp = callTool(t, w, r, 2, "mie_store", args)
+	assert.Contains(t, extractToolText(t, resp), "Invalid arguments for mie_store: confidence: must be at most 1, got 1.5")
+
+	resp = callTool(t, w, r, 3, "mie_list", map[string]any{"node_type": "fact", "limit": 0, "include_archived": "yes"})
+	text := extractToolText(t, resp)
+	assert.Contains(t, text, "limit: must be at least 1, got 0")
+	assert.Contains(t, text, `include_archived: must be true or false, got string "yes"`)
+
+	resp = callTool(t, w, r, 4, "mie_store", map[string]any{"type": "fact", "content": "Uses Go", "confidence": 0.9})
+	assert.Contains(t, extractToolText(t, resp), "Stored fact")
+}
+
+func TestMCPShutdown(t *testing.T) {
+	client, err := memory.NewClient(memory.ClientConfig{DataDir: t.TempDir(), StorageEngine: "mem", EmbeddingDimensions: 768})
+	require.NoError(t, err)
+	defer client.Close()
+	started := make(chan struct{}, 1)
+	server := &mcpServer{client: blockingStats{Querier: client, started: started}, config: DefaultConfig()}
+
+	stdinReader, stdinWriter := io.Pipe()
+	defer stdinWriter.Close()
+	stdoutReader, stdoutWriter := io.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- server.serve(ctx, stdinReader, stdoutWriter)
+		_ = stdoutWriter.Close()
+	}()
+	r := bufio.NewReader(stdoutReader)
+
+	initSession(t, stdinWriter, r)
+	data, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      2,
+		"method":  "tools/call",
+		"params":  map[string]any{"name": "mie_status", "arguments": map[string]any{}},
+	})
+	require.NoError(t, err)
+	_, err = stdinWriter.Write(append(data, '\n'))
+	require.NoError(t, err)
+	<-started
+
+	// Shutting down cancels the running call, which still answers.
+	cancel()
+	line, err := r.ReadString('\n')
+	require.NoError(t, err)
+	assert.Contains(t, line, `"id":2`)
+	assert.Contains(t, line, "context canceled")
+	assert.NoError(t, <-served)
+}
+
 func TestMCPConflicts(t *testing.T) {
 	w, r := startTestServer(t)
 	defer w.Close()
@@ -0,0 +1,298 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// EmbedResult represents the outcome of mie embed for JSON output.
+type EmbedResult struct {
+	Missing  map[string]int `json:"missing"`
+	Embedded int            `json:"embedded"`
+	Failed   int            `json:"failed"`
+}
+
+// embedCommand is mie embed.
+var embedCommand = command{
+	name:    "embed",
+	summary: "Report or backfill missing embeddings",
+	usage:   "[options]",
+	help: `  Find nodes without embeddings and optionally generate them. Nodes stored
+  while embeddings were disabled, or whose embedding call failed, are not
+  found by semantic search until they are backfilled. The MCP server also
+  backfills in the background on startup.
+
+  With --status, report embedding coverage per node type in the current
+  namespace, the vector index, the configured and stored vector sizes, and
+  the last embedding error, with how to fix each problem found. It exits
+  with status 1 when a problem is found.
+
+  With --migrate, re-embed every node with another model of the same
+  provider, --model, whose vectors have --dimensions values, and replace the
+  embeddings and vector indexes once all nodes are embedded. Until then the
+  old embeddings keep serving semantic search. An interrupted migration, or
+  one where some nodes failed, resumes when run again with the same model.
+  Afterwards set embedding.model and embedding.dimensions in the config to
+  the new values.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.Bool("backfill", false, "Generate missing embeddings")
+		fs.Bool("status", false, "Report embedding coverage, index health, dimensions, and the last error")
+		fs.StringSlice("types", nil, "Node types to process (default: fact,decision,entity,event)")
+		fs.Int("workers", 0, "Concurrent embedding workers (default: embedding.workers)")
+		fs.Bool("migrate", false, "Re-embed all nodes with --model and rebuild the vector indexes for --dimensions")
+		fs.String("model", "", "Embedding model to migrate to (with --migrate)")
+		fs.Int("dimensions", 0, "Vector size of the model to migrate to (with --migrate)")
+	},
+	examples: `  mie embed                               Count nodes missing embeddings
+  mie embed --backfill                    Generate all missing embeddings
+  mie embed --backfill --types fact       Only facts
+  mie embed --backfill --workers 8        Use 8 concurrent workers
+  mie embed --status                      Explain why semantic search misses nodes
+  mie embed --migrate --model mxbai-embed-large --dimensions 1024
+                                          Switch to a model with 1024-dimension vectors`,
+	run: runEmbed,
+}
+
+// runEmbed reports or backfills nodes that have no embedding.
+func runEmbed(fs *flag.FlagSet, configPath string, globals GlobalFlags) {
+	backfill, _ := fs.GetBool("backfill")
+	status, _ := fs.GetBool("status")
+	types, _ := fs.GetStringSlice("types")
+	workers, _ := fs.GetInt("workers")
+	migrate, _ := fs.GetBool("migrate")
+	model, _ := fs.GetString("model")
+	dimensions, _ := fs.GetInt("dimensions")
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		cfg = DefaultConfig()
+		cfg.applyEnvOverrides()
+	}
+
+	if migrate {
+		if model == "" || dimensions <= 0 {
+			fmt.Fprintf(os.Stderr, "Error: --migrate requires --model and --dimensions\n")
+			os.Exit(1)
+		}
+		cfg.Embedding.Model = model
+		cfg.Embedding.Dimensions = dimensions
+	}
+
+	if !cfg.Embedding.Enabled && !status {
+		fmt.Fprintf(os.Stderr, "Error: embeddings are disabled; set embedding.enabled: true in the config\n")
+		os.Exit(ExitConfig)
+	}
+
+	dataDir, err := ResolveDataDir(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+
+	if _, err := os.Stat(dataDir); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Error: no data found at %s\n", dataDir)
+		os.Exit(ExitDatabase)
+	}
+
+	client, err := memory.NewClient(memory.ClientConfig{
+		DataDir:                   dataDir,
+		StorageEngine:             cfg.Storage.Engine,
+		Namespace:                 globals.resolveNamespace(cfg),
+		EmbeddingEnabled:          cfg.Embedding.Enabled,
+		EmbeddingProvider:         cfg.Embedding.Provider,
+		EmbeddingBaseURL:          cfg.Embedding.BaseURL,
+		EmbeddingModel:            cfg.Embedding.Model,
+		EmbeddingAPIKey:           cfg.Embedding.APIKey,
+		EmbeddingDimensions:       cfg.Embedding.Dimensions,
+		EmbeddingWorkers:          cfg.Embedding.Workers,
+		EmbeddingFallbackProvider: cfg.Embedding.FallbackProvider,
+		EmbeddingFallbackBaseURL:  cfg.Embedding.FallbackBaseURL,
+		EmbeddingFallbackModel:    cfg.Embedding.FallbackModel,
+		EmbeddingFallbackAPIKey:   cfg.Embedding.FallbackAPIKey,
+		VectorIndex:               cfg.Embedding.vectorIndex(),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: cannot open database: %v\n", err)
+		printLockHint(dataDir)
+		os.Exit(ExitDatabase)
+	}
+	defer func() { _ = client.Close() }()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if status {
+		runEmbedStatus(ctx, client, globals)
+		return
+	}
+	if migrate {
+		if !client.EmbeddingsEnabled() {
+			fmt.Fprintf(os.Stderr, "Error: embedding provider %q is not available\n", cfg.Embedding.Provider)
+			os.Exit(ExitConfig)
+		}
+		runEmbedMigrate(ctx, client, cfg, workers, globals)
+		return
+	}
+
+	result := &EmbedResult{}
+	if backfill {
+		if !client.EmbeddingsEnabled() {
+			fmt.Fprintf(os.Stderr, "Error: embedding provider %q is not available\n", cfg.Embedding.Provider)
+			os.Exit(ExitConfig)
+		}
+		if !globals.Quiet {
+			fmt.Fprintf(os.Stderr, "Backfilling embeddings with %s (%s)...\n", cfg.Embedding.Provider, cfg.Embedding.Model)
+		}
+		res, err := client.BackfillEmbeddings(ctx, memory.BackfillOptions{NodeTypes: types, Workers: workers})
+		if res != nil {
+			result.Missing, result.Embedded, result.Failed = res.Missing, res.Embedded, res.Failed
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			if res == nil {
+				os.Exit(ExitDatabase)
+			}
+		}
+	} else {
+		result.Missing, err = client.MissingEmbeddings(ctx, types)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitDatabase)
+		}
+	}
+
+	if globals.JSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(result)
+	} else {
+		printEmbedResult(result, backfill)
+	}
+
+	if result.Failed > 0 {
+		os.Exit(ExitGeneral)
+	}
+}
+
+// runEmbedMigrate re-embeds all nodes with the model client is configured
+// with and reports the outcome.
+func runEmbedMigrate(ctx context.Context, client *memory.Client, cfg *Config, workers int, globals GlobalFlags) {
+	opts := memory.MigrateOptions{Workers: workers}
+	if !globals.Quiet {
+		fmt.Fprintf(os.Stderr, "Migrating embeddings to %s (%s, %d dimensions)...\n",
+			cfg.Embedding.Model, cfg.Embedding.Provider, cfg.Embedding.Dimensions)
+		opts.Progress = func(done, total int) {
+			if done == total || done%100 == 0 {
+				fmt.Fprintf(os.Stderr, "  %d/%d nodes\n", done, total)
+			}
+		}
+	}
+	result, err := client.MigrateEmbeddings(ctx, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if result == nil {
+			os.Exit(ExitDatabase)
+		}
+	}
+
+	if globals.JSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(result)
+	} else if !globals.Quiet {
+		if result.Resumed {
+			fmt.Println("Resumed an earlier migration to this model.")
+		}
+		fmt.Printf("Embedded %d of %d nodes (%d failed)\n", result.Embedded, result.Total, result.Failed)
+		if result.Complete {
+			fmt.Println("Migration complete. Set these in .mie/config.yaml before starting MIE again:")
+			fmt.Printf("  embedding.model: %s\n", result.Model)
+			fmt.Printf("  embedding.dimensions: %d\n", result.Dimensions)
+		} else {
+			fmt.Println("Migration incomplete; the old embeddings are still in use. Run the same command again to resume.")
+		}
+	}
+
+	if !result.Complete {
+		os.Exit(ExitGeneral)
+	}
+}
+
+// EmbedStatusResult is the output of mie embed --status for JSON output.
+type EmbedStatusResult struct {
+	*tools.EmbeddingReport
+	Problems []string `json:"problems"`
+}
+
+// runEmbedStatus prints the embedding status report of client.
+func runEmbedStatus(ctx context.Context, client *memory.Client, globals GlobalFlags) {
+	report, err := client.GetEmbeddingStatus(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitDatabase)
+	}
+	problems := tools.EmbeddingProblems(report)
+
+	if globals.JSON {
+		if problems == nil {
+			problems = []string{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(EmbedStatusResult{EmbeddingReport: report, Problems: problems})
+		return
+	}
+
+	if report.Enabled {
+		fmt.Printf("Embeddings:   enabled (%s, %s)\n", report.Provider, report.Model)
+	} else {
+		fmt.Println("Embeddings:   disabled")
+	}
+	fmt.Printf("Vector index: %s\n", report.Index)
+	fmt.Printf("Dimensions:   %d configured, %d stored\n\n", report.ConfiguredDimensions, report.StoredDimensions)
+	for _, c := range report.Coverage {
+		fmt.Printf("  %-10s %d of %d embedded (%d missing)\n", c.NodeType+":", c.Embedded, c.Nodes, c.Missing())
+	}
+	fmt.Println()
+	if len(problems) == 0 {
+		fmt.Println("No problems found.")
+		return
+	}
+	for _, p := range problems {
+		fmt.Printf("- %s\n", p)
+	}
+	os.Exit(ExitGeneral)
+}
+
+func printEmbedResult(result *EmbedResult, backfill bool) {
+	total := 0
+	for _, nt := range []string{"fact", "decision", "entity", "event"} {
+		if n := result.Missing[nt]; n > 0 {
+			fmt.Printf("  %-10s %d missing\n", nt+":", n)
+			total += n
+		}
+	}
+	switch {
+	case total == 0:
+		fmt.Println("All nodes have embeddings.")
+	case backfill:
+		fmt.Printf("Embedded %d of %d nodes (%d failed)\n", result.Embedded, total, result.Failed)
+	default:
+		fmt.Printf("%d nodes missing embeddings. Run 'mie embed --backfill' to generate them.\n", total)
+	}
+}
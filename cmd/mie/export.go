@@ -8,8 +8,10 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	flag "github.com/spf13/pflag"
 
@@ -17,34 +19,59 @@ import (
 	"github.com/kraklabs/mie/pkg/tools"
 )
 
-// runExport exports the memory graph to stdout or a file.
-func runExport(args []string, configPath string, globals GlobalFlags) {
-	fs := flag.NewFlagSet("export", flag.ExitOnError)
-	format := fs.String("format", "json", "Export format: json or datalog")
-	output := fs.StringP("output", "o", "", "Output file (default: stdout)")
-	includeEmbeddings := fs.Bool("include-embeddings", false, "Include embedding vectors (large)")
-
-	fs.Usage = func() {
-		fmt.Fprintf(os.Stderr, `Usage: mie export [options]
-
-Description:
-  Export the complete memory graph for backup or migration.
-
-Options:
-`)
-		fs.PrintDefaults()
-		fmt.Fprintf(os.Stderr, `
-Examples:
-  mie export                              JSON to stdout
+// exportCommand is mie export.
+var exportCommand = command{
+	name:    "export",
+	summary: "Export memory graph",
+	usage:   "[options]",
+	help: `  Export the complete memory graph for backup or migration. JSON exports
+  include relationships and aliases and can be restored with mie import.
+
+  With --since, only nodes created or updated, facts verified, and
+  relationships, aliases, and archive marks added at or after that time are
+  exported. Importing such an export on top of an earlier backup brings it
+  up to date; deletions are not carried over.
+
+  Cypher exports are CREATE statements that load the graph into an empty
+  Neo4j or Memgraph database, for example with cypher-shell or mgconsole.
+
+  Markdown exports write a folder of notes that Obsidian opens as a vault:
+  a note per entity, decision, and topic with links in both directions,
+  plus Facts.md, Events.md, and index.md. They need --output.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.String("format", "json", "Export format: json, datalog, cypher, or markdown")
+		fs.StringP("output", "o", "", "Output file, or folder for markdown (default: stdout)")
+		fs.Bool("include-embeddings", false, "Include embedding vectors (large)")
+		fs.String("since", "", "Only export changes at or after this time (RFC 3339 or YYYY-MM-DD)")
+	},
+	examples: `  mie export                              JSON to stdout
   mie export --output memory.json         JSON to file
   mie export --format datalog             Datalog format
+  mie export --format cypher -o mie.cypher
+                                          Cypher for Neo4j or Memgraph
+  mie export --format markdown -o ~/vault/mie
+                                          Obsidian vault
   mie export --include-embeddings         Include vectors (large)
+  mie export --since 2026-01-31T00:00:00Z -o delta.json
+                                          Changes since a time`,
+	run: runExport,
+}
 
-`)
-	}
-
-	if err := fs.Parse(args); err != nil {
-		os.Exit(1)
+// runExport exports the memory graph to stdout or a file.
+func runExport(fs *flag.FlagSet, configPath string, globals GlobalFlags) {
+	format, _ := fs.GetString("format")
+	output, _ := fs.GetString("output")
+	includeEmbeddings, _ := fs.GetBool("include-embeddings")
+	since, _ := fs.GetString("since")
+
+	var sinceUnix int64
+	if since != "" {
+		t, err := tools.ParseTimestamp(since)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid --since: %v\n", err)
+			os.Exit(ExitGeneral)
+		}
+		sinceUnix = t.Unix()
 	}
 
 	cfg, err := LoadConfig(configPath)
@@ -67,35 +94,111 @@ Examples:
 	client, err := memory.NewClient(memory.ClientConfig{
 		DataDir:       dataDir,
 		StorageEngine: cfg.Storage.Engine,
+		Namespace:     globals.resolveNamespace(cfg),
 	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: cannot open database: %v\n", err)
+		printLockHint(dataDir)
 		os.Exit(ExitDatabase)
 	}
 	defer func() { _ = client.Close() }()
 
 	ctx := context.Background()
 
-	exportArgs := map[string]any{
-		"format":             *format,
-		"include_embeddings": *includeEmbeddings,
+	if format == "markdown" {
+		exportVault(ctx, client, output, sinceUnix, globals)
+		return
 	}
 
-	result, err := tools.Export(ctx, client, exportArgs)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(ExitGeneral)
+	var text string
+	switch format {
+	case "json":
+		// Marshal directly: the mie_export tool truncates large output for
+		// agents, but a backup must be complete to be restorable.
+		data, err := client.ExportGraph(ctx, tools.ExportOptions{
+			Format:            "json",
+			IncludeEmbeddings: includeEmbeddings,
+			Since:             sinceUnix,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitDatabase)
+		}
+		jsonBytes, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitGeneral)
+		}
+		text = string(jsonBytes) + "\n"
+	case "cypher":
+		// Like JSON, a Cypher script is only loadable if it is complete.
+		data, err := client.ExportGraph(ctx, tools.ExportOptions{
+			Format: "cypher",
+			Since:  sinceUnix,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitDatabase)
+		}
+		text = tools.FormatCypher(data)
+	default:
+		exportArgs := map[string]any{
+			"format":             format,
+			"include_embeddings": includeEmbeddings,
+			"since":              since,
+		}
+
+		result, err := tools.Export(ctx, client, exportArgs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitGeneral)
+		}
+		if result.IsError {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", result.Text)
+			os.Exit(ExitGeneral)
+		}
+		text = result.Text
 	}
 
-	if *output != "" {
-		if err := os.WriteFile(*output, []byte(result.Text), 0600); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: cannot write to %s: %v\n", *output, err)
+	if output != "" {
+		if err := os.WriteFile(output, []byte(text), 0600); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: cannot write to %s: %v\n", output, err)
 			os.Exit(ExitGeneral)
 		}
 		if !globals.Quiet {
-			fmt.Fprintf(os.Stderr, "Exported to %s\n", *output)
+			fmt.Fprintf(os.Stderr, "Exported to %s\n", output)
 		}
 	} else {
-		fmt.Print(result.Text)
+		fmt.Print(text)
+	}
+}
+
+// exportVault writes the memory graph as a markdown vault into the folder
+// dir, creating it if needed. Notes already in the folder are overwritten;
+// other files are left alone.
+func exportVault(ctx context.Context, client *memory.Client, dir string, since int64, globals GlobalFlags) {
+	if dir == "" {
+		fmt.Fprintf(os.Stderr, "Error: --format markdown writes a folder of notes; pass it with --output\n")
+		os.Exit(ExitGeneral)
+	}
+	data, err := client.ExportGraph(ctx, tools.ExportOptions{Format: "markdown", Since: since})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitDatabase)
+	}
+	files := tools.FormatMarkdownVault(data)
+	for name, text := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: cannot create %s: %v\n", filepath.Dir(path), err)
+			os.Exit(ExitGeneral)
+		}
+		if err := os.WriteFile(path, []byte(text), 0600); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: cannot write to %s: %v\n", path, err)
+			os.Exit(ExitGeneral)
+		}
+	}
+	if !globals.Quiet {
+		fmt.Fprintf(os.Stderr, "Exported %d notes to %s\n", len(files), dir)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,134 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// gcCommand is mie gc.
+var gcCommand = command{
+	name:    "gc",
+	summary: "Remove dangling edges, orphaned embeddings, unused topics",
+	usage:   "[options]",
+	help: `  Garbage-collect the memory graph. Removes edges whose source or target
+  node does not exist, embeddings of deleted nodes, and topics that no
+  fact, decision, or entity links to. Edges and embeddings are collected
+  in every namespace, topics only in the current one.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.Bool("dry-run", false, "Report what would be removed without removing it")
+	},
+	examples: `  mie gc --dry-run    Show what would be removed
+  mie gc              Remove it
+  mie gc --json       Output the result as JSON`,
+	run: runGC,
+}
+
+// runGC removes dangling edges, orphaned embeddings, and unused topics.
+func runGC(fs *flag.FlagSet, configPath string, globals GlobalFlags) {
+	dryRun, _ := fs.GetBool("dry-run")
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		cfg = DefaultConfig()
+		cfg.applyEnvOverrides()
+	}
+
+	dataDir, err := ResolveDataDir(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+
+	if _, err := os.Stat(dataDir); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Error: no data found at %s\n", dataDir)
+		os.Exit(ExitDatabase)
+	}
+
+	client, err := memory.NewClient(memory.ClientConfig{
+		DataDir:       dataDir,
+		StorageEngine: cfg.Storage.Engine,
+		Namespace:     globals.resolveNamespace(cfg),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: cannot open database: %v\n", err)
+		printLockHint(dataDir)
+		os.Exit(ExitDatabase)
+	}
+	defer func() { _ = client.Close() }()
+
+	ctx := tools.WithAuditSource(context.Background(), "mie gc", "")
+	result, err := client.GC(ctx, memory.GCOptions{DryRun: dryRun})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitQuery)
+	}
+
+	if globals.JSON {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitGeneral)
+		}
+		fmt.Println(string(data))
+		return
+	}
+	if globals.Quiet {
+		return
+	}
+	printGC(result)
+}
+
+func printGC(result *memory.GCResult) {
+	if result.Total() == 0 {
+		fmt.Println("Nothing to collect.")
+		return
+	}
+
+	verb := "Removed"
+	if result.DryRun {
+		verb = "Would remove"
+	}
+	printGCCounts(verb, "dangling edges", result.DanglingEdges)
+	printGCCounts(verb, "orphaned embeddings", result.OrphanedEmbeddings)
+	if len(result.UnusedTopics) > 0 {
+		fmt.Printf("%s %d unused topics:\n", verb, len(result.UnusedTopics))
+		for _, t := range result.UnusedTopics {
+			fmt.Printf("  %-24s  %s\n", t.ID, t.Name)
+		}
+	}
+	if result.DryRun {
+		fmt.Println("\nRun 'mie gc' without --dry-run to remove them.")
+	}
+}
+
+// printGCCounts prints the total of counts and the count of each table.
+func printGCCounts(verb, label string, counts map[string]int) {
+	if len(counts) == 0 {
+		return
+	}
+	tables := make([]string, 0, len(counts))
+	total := 0
+	for table, n := range counts {
+		tables = append(tables, table)
+		total += n
+	}
+	sort.Strings(tables)
+	fmt.Printf("%s %d %s:\n", verb, total, label)
+	for _, table := range tables {
+		fmt.Printf("  %-24s  %d\n", table, counts[table])
+	}
+}
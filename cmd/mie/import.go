@@ -9,71 +9,161 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	flag "github.com/spf13/pflag"
 
+	"github.com/kraklabs/mie/pkg/ingest"
 	"github.com/kraklabs/mie/pkg/memory"
 	"github.com/kraklabs/mie/pkg/tools"
 )
 
-// runImport imports data from a JSON or Datalog export file into the memory graph.
-func runImport(args []string, configPath string, globals GlobalFlags) {
-	fs := flag.NewFlagSet("import", flag.ExitOnError)
-	format := fs.String("format", "json", "Import format: json or datalog")
-	input := fs.StringP("input", "i", "", "Input file path (default: stdin)")
-	dryRun := fs.Bool("dry-run", false, "Preview what would be imported without writing")
-
-	fs.Usage = func() {
-		fmt.Fprintf(os.Stderr, `Usage: mie import [options]
-
-Description:
-  Import data from a JSON or Datalog export file into the memory graph.
-
-Options:
-`)
-		fs.PrintDefaults()
-		fmt.Fprintf(os.Stderr, `
-Examples:
-  mie import --input memory.json              Import from JSON file
+// importCommand is mie import.
+var importCommand = command{
+	name:    "import",
+	summary: "Import memory graph",
+	usage:   "[options] [FILE...]",
+	help: `  Import data from a JSON or Datalog export file into the memory graph.
+  JSON imports keep node IDs, timestamps, relationships, and aliases. When
+  importing into a different namespace than the export came from, IDs are
+  remapped consistently so relationships stay intact. With --dry-run, a
+  JSON import is compared with the existing graph instead: it reports the
+  nodes the import would create, those it would rewrite because their ID
+  exists, the new nodes that duplicate an existing one by fact content,
+  decision title, event title and date, or entity and topic name (as
+  matched by mie merge), and potential conflicts.
+
+  With --format markdown, each FILE is parsed as an Architecture Decision
+  Record: the title and Decision section become a decision, considered
+  options and deciders become linked entities, and front matter tags become
+  topics. Files without ADR structure are skipped.
+
+  With --format git, the history of --repo is read with the git executable:
+  conventional-commit scopes become entities, merge commits become
+  decisions linked to the scopes they merged, and tags become release
+  events linked to the merges they include.
+
+  With --format obsidian, every note in the --input vault is imported.
+  Notes become facts, or events when dated (daily notes named YYYY-MM-DD, or
+  a date in the front matter). Wiki-links become entities linked to the
+  note's fact and to its front matter tags, which become topics.
+
+  With --format mem0, zep, or langchain, the input is another agent
+  memory's JSON export: mem0 memories (from get_all) and graph relations,
+  Zep sessions with their summaries and facts, or a serialized LangChain
+  summary or entity memory. Memories become facts, Zep session summaries
+  become events, users and LangChain entities become entities, and mem0
+  categories become topics. Chat messages are not imported.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.String("format", "json", "Import format: json, datalog, markdown, git, obsidian, mem0, zep, or langchain")
+		fs.StringP("input", "i", "", "Input file path (default: stdin), or vault directory with --format obsidian")
+		fs.Bool("dry-run", false, "Preview what would be imported without writing; JSON imports are compared with the existing graph")
+		fs.String("repo", ".", "Git repository to read (with --format git)")
+		fs.Int("max-commits", 1000, "Maximum number of commits to read, 0 for all (with --format git)")
+	},
+	examples: `  mie import --input memory.json              Import from JSON file
   mie import --input backup.json --dry-run    Preview import
   mie import --format datalog --input data.dl Import Datalog
   cat memory.json | mie import                Import from stdin
+  mie import --format markdown docs/adr/*.md  Import ADRs
+  mie import --format git --repo .            Import git history
+  mie import --format obsidian -i ~/vault     Import an Obsidian vault
+  mie import --format mem0 -i mem0.json       Import mem0 memories`,
+	run: runImport,
+}
 
-`)
+// runImport imports data from a JSON or Datalog export file, from Markdown
+// ADRs, from git history, from an Obsidian vault, or from another agent
+// memory's export into the memory graph.
+func runImport(fs *flag.FlagSet, configPath string, globals GlobalFlags) {
+	format, _ := fs.GetString("format")
+	input, _ := fs.GetString("input")
+	dryRun, _ := fs.GetBool("dry-run")
+	repo, _ := fs.GetString("repo")
+	maxCommits, _ := fs.GetInt("max-commits")
+
+	switch format {
+	case "json", "datalog", "markdown", "git", "obsidian", "mem0", "zep", "langchain":
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unsupported format %q (supported: json, datalog, markdown, git, obsidian, mem0, zep, langchain)\n", format)
+		os.Exit(ExitGeneral)
 	}
 
-	if err := fs.Parse(args); err != nil {
-		os.Exit(1)
+	// Markdown, git history, vaults, and memory exports are parsed before
+	// the database is opened, so a dry run needs no database.
+	var docs []*ingest.Document
+	var history *ingest.History
+	var notes []*ingest.Note
+	var memories []*ingest.Memory
+	if format == "markdown" {
+		paths := fs.Args()
+		if input != "" {
+			paths = append([]string{input}, paths...)
+		}
+		docs = parseMarkdownFiles(paths)
+		if dryRun {
+			printMarkdownDryRun(docs)
+			return
+		}
+		if len(docs) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: no ADRs found\n")
+			os.Exit(ExitGeneral)
+		}
 	}
-
-	if *format != "json" && *format != "datalog" {
-		fmt.Fprintf(os.Stderr, "Error: unsupported format %q (supported: json, datalog)\n", *format)
-		os.Exit(ExitGeneral)
+	if format == "git" {
+		commits, tags, err := ingest.ReadGitHistory(context.Background(), repo, maxCommits)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitGeneral)
+		}
+		history = ingest.ParseGitHistory(commits, tags)
+		if dryRun {
+			printGitDryRun(history)
+			return
+		}
 	}
-
-	// Read input data.
-	var data []byte
-	var err error
-	if *input != "" {
-		data, err = os.ReadFile(*input) //nolint:gosec // G304: Path comes from user flag
+	if format == "obsidian" {
+		if input == "" {
+			fmt.Fprintf(os.Stderr, "Error: --input must name the vault directory\n")
+			os.Exit(ExitGeneral)
+		}
+		var err error
+		notes, err = ingest.ReadObsidianVault(input)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: cannot read %s: %v\n", *input, err)
+			fmt.Fprintf(os.Stderr, "Error: cannot read vault: %v\n", err)
 			os.Exit(ExitGeneral)
 		}
-	} else {
-		data, err = io.ReadAll(os.Stdin)
+		if dryRun {
+			printObsidianDryRun(notes)
+			return
+		}
+		if len(notes) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: no notes found in %s\n", input)
+			os.Exit(ExitGeneral)
+		}
+	}
+
+	if parse, ok := memoryParsers[format]; ok {
+		var err error
+		memories, err = parse(readImportInput(input))
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: cannot read stdin: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(ExitGeneral)
 		}
+		if dryRun {
+			printMemoriesDryRun(memories)
+			return
+		}
 	}
 
-	if len(data) == 0 {
-		fmt.Fprintf(os.Stderr, "Error: no input data\n")
-		os.Exit(ExitGeneral)
+	// Read input data.
+	var data []byte
+	if format == "json" || format == "datalog" {
+		data = readImportInput(input)
 	}
 
 	cfg, err := LoadConfig(configPath)
@@ -96,114 +186,145 @@ Examples:
 	client, err := memory.NewClient(memory.ClientConfig{
 		DataDir:       dataDir,
 		StorageEngine: cfg.Storage.Engine,
+		Namespace:     globals.resolveNamespace(cfg),
 	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: cannot open database: %v\n", err)
+		printLockHint(dataDir)
 		os.Exit(ExitDatabase)
 	}
 	defer func() { _ = client.Close() }()
 
-	ctx := context.Background()
+	ctx := tools.WithAuditSource(context.Background(), "mie import", "")
 
-	switch *format {
+	switch format {
 	case "json":
-		importJSON(ctx, client, data, *dryRun, globals)
+		importJSON(ctx, os.Stdout, client, data, dryRun, globals)
 	case "datalog":
-		importDatalog(ctx, client, data, *dryRun, globals)
+		importDatalog(ctx, client, data, dryRun, globals)
+	case "markdown":
+		importMarkdown(ctx, client, docs, globals)
+	case "git":
+		importGit(ctx, client, history, globals)
+	case "obsidian":
+		importObsidian(ctx, client, notes, globals)
+	case "mem0", "zep", "langchain":
+		importMemories(ctx, client, memories, globals)
 	}
 }
 
-func importJSON(ctx context.Context, client *memory.Client, data []byte, dryRun bool, globals GlobalFlags) {
+// readImportInput reads the export to import from path, or from stdin when
+// path is empty, and exits if there is nothing to import.
+func readImportInput(path string) []byte {
+	var data []byte
+	var err error
+	if path != "" {
+		data, err = os.ReadFile(path) //nolint:gosec // G304: Path comes from user flag
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: cannot read %s: %v\n", path, err)
+			os.Exit(ExitGeneral)
+		}
+	} else {
+		data, err = io.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: cannot read stdin: %v\n", err)
+			os.Exit(ExitGeneral)
+		}
+	}
+
+	if len(data) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: no input data\n")
+		os.Exit(ExitGeneral)
+	}
+	return data
+}
+
+// importJSON imports the JSON export data, or with dryRun compares it with
+// the existing graph, and reports the result to w.
+func importJSON(ctx context.Context, w io.Writer, client *memory.Client, data []byte, dryRun bool, globals GlobalFlags) {
 	var export tools.ExportData
 	if err := json.Unmarshal(data, &export); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: invalid JSON: %v\n", err)
 		os.Exit(ExitGeneral)
 	}
 
+	relationships := 0
+	for _, rows := range export.Edges {
+		relationships += len(rows)
+	}
 	counts := map[string]int{
-		"facts":     len(export.Facts),
-		"decisions": len(export.Decisions),
-		"entities":  len(export.Entities),
-		"events":    len(export.Events),
-		"topics":    len(export.Topics),
+		"facts":         len(export.Facts),
+		"decisions":     len(export.Decisions),
+		"entities":      len(export.Entities),
+		"events":        len(export.Events),
+		"topics":        len(export.Topics),
+		"relationships": relationships,
+		"aliases":       len(export.Aliases),
+		"archived":      len(export.Archived),
 	}
 
 	if dryRun {
-		fmt.Println("Dry run — would import:")
-		for kind, n := range counts {
-			if n > 0 {
-				fmt.Printf("  %d %s\n", n, kind)
-			}
+		diff, err := client.DiffImport(ctx, &export)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: cannot compare with the existing graph: %v\n", err)
+			os.Exit(ExitDatabase)
 		}
+		printImportDiff(w, diff, counts)
 		return
 	}
 
-	for _, f := range export.Facts {
-		_, err := client.StoreFact(ctx, tools.StoreFactRequest{
-			Content:            f.Content,
-			Category:           f.Category,
-			Confidence:         f.Confidence,
-			SourceAgent:        f.SourceAgent,
-			SourceConversation: f.SourceConversation,
-		})
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to import fact: %v\n", err)
-		}
+	imported, err := client.ImportGraph(ctx, &export)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: import failed after %s: %v\n", formatImportCounts(imported), err)
+		os.Exit(ExitDatabase)
 	}
 
-	for _, d := range export.Decisions {
-		_, err := client.StoreDecision(ctx, tools.StoreDecisionRequest{
-			Title:              d.Title,
-			Rationale:          d.Rationale,
-			Alternatives:       d.Alternatives,
-			Context:            d.Context,
-			SourceAgent:        d.SourceAgent,
-			SourceConversation: d.SourceConversation,
-		})
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to import decision %q: %v\n", d.Title, err)
-		}
+	if !globals.Quiet {
+		fmt.Fprintf(w, "Imported %s\n", formatImportCounts(imported))
 	}
+}
 
-	for _, e := range export.Entities {
-		_, err := client.StoreEntity(ctx, tools.StoreEntityRequest{
-			Name:        e.Name,
-			Kind:        e.Kind,
-			Description: e.Description,
-			SourceAgent: e.SourceAgent,
-		})
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to import entity %q: %v\n", e.Name, err)
+// printImportDiff writes to w what a JSON import would change, from diff:
+// the nodes it would create, the nodes it would rewrite in place, the new
+// nodes that duplicate an existing one, and the potential conflicts.
+// counts holds the import counts of the export.
+func printImportDiff(w io.Writer, diff *tools.ImportDiff, counts map[string]int) {
+	total := func(counts map[string]int) int {
+		n := 0
+		for _, kind := range mergeKinds {
+			n += counts[kind]
 		}
+		return n
 	}
 
-	for _, ev := range export.Events {
-		_, err := client.StoreEvent(ctx, tools.StoreEventRequest{
-			Title:              ev.Title,
-			Description:        ev.Description,
-			EventDate:          ev.EventDate,
-			SourceAgent:        ev.SourceAgent,
-			SourceConversation: ev.SourceConversation,
-		})
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to import event %q: %v\n", ev.Title, err)
-		}
-	}
+	fmt.Fprintln(w, "Dry run — compared with the existing graph, nothing written:")
+	fmt.Fprintf(w, "  %d new:        %s\n", total(diff.New), formatMergeCounts(diff.New))
+	fmt.Fprintf(w, "  %d updated:    %s\n", total(diff.Updated), formatMergeCounts(diff.Updated))
+	fmt.Fprintf(w, "  %d duplicates: %s\n", total(diff.Duplicates), formatMergeCounts(diff.Duplicates))
+	fmt.Fprintf(w, "  %d potential conflicts\n", len(diff.Conflicts))
+	fmt.Fprintf(w, "  %d relationships, %d aliases, %d archived\n", counts["relationships"], counts["aliases"], counts["archived"])
 
-	for _, tp := range export.Topics {
-		_, err := client.StoreTopic(ctx, tools.StoreTopicRequest{
-			Name:        tp.Name,
-			Description: tp.Description,
-		})
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to import topic %q: %v\n", tp.Name, err)
+	if n := total(diff.Duplicates); n > 0 {
+		fmt.Fprintf(w, "\n%d new nodes match an existing node by content or name and would be stored again; use mie merge to skip them.\n", n)
+	}
+	if len(diff.Conflicts) > 0 {
+		fmt.Fprintf(w, "\n%d potential conflicts:\n", len(diff.Conflicts))
+		for _, c := range diff.Conflicts {
+			fmt.Fprintf(w, "  %s / %s: %s\n", c.LocalID, c.IncomingID, c.Reason)
 		}
 	}
+}
 
-	if !globals.Quiet {
-		fmt.Printf("Imported %d facts, %d decisions, %d entities, %d events, %d topics\n",
-			counts["facts"], counts["decisions"], counts["entities"], counts["events"], counts["topics"])
+// importKinds is the order in which import counts are reported.
+var importKinds = []string{"facts", "decisions", "entities", "events", "topics", "relationships", "aliases", "archived"}
+
+// formatImportCounts renders counts as "3 facts, 1 decisions, ...".
+func formatImportCounts(counts map[string]int) string {
+	parts := make([]string, 0, len(importKinds))
+	for _, kind := range importKinds {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[kind], kind))
 	}
+	return strings.Join(parts, ", ")
 }
 
 func importDatalog(ctx context.Context, client *memory.Client, data []byte, dryRun bool, globals GlobalFlags) {
@@ -224,4 +345,164 @@ func importDatalog(ctx context.Context, client *memory.Client, data []byte, dryR
 	if !globals.Quiet {
 		fmt.Println("Datalog import completed successfully")
 	}
-}
\ No newline at end of file
+}
+
+// parseMarkdownFiles parses each path as an ADR, or stdin when paths is
+// empty. Files without ADR structure are skipped with a warning.
+func parseMarkdownFiles(paths []string) []*ingest.Document {
+	type source struct {
+		name string
+		data []byte
+	}
+	var sources []source
+	if len(paths) == 0 {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: cannot read stdin: %v\n", err)
+			os.Exit(ExitGeneral)
+		}
+		sources = append(sources, source{name: "stdin", data: data})
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path) //nolint:gosec // G304: Path comes from user argument
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: cannot read %s: %v\n", path, err)
+			os.Exit(ExitGeneral)
+		}
+		sources = append(sources, source{name: path, data: data})
+	}
+
+	var docs []*ingest.Document
+	for _, src := range sources {
+		doc, err := ingest.ParseMarkdown(src.name, src.data)
+		if errors.Is(err, ingest.ErrNotADR) {
+			fmt.Fprintf(os.Stderr, "Warning: skipping %s: %v\n", src.name, err)
+			continue
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: cannot parse %s: %v\n", src.name, err)
+			os.Exit(ExitGeneral)
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+func printMarkdownDryRun(docs []*ingest.Document) {
+	fmt.Printf("Dry run — would import %d decisions:\n", len(docs))
+	for _, doc := range docs {
+		fmt.Printf("  %s: %q (%s)\n", doc.Source, doc.Decision.Title, doc.Status)
+		for _, ref := range doc.Entities {
+			fmt.Printf("    entity %q (%s)\n", ref.Entity.Name, ref.Role)
+		}
+		for _, topic := range doc.Topics {
+			fmt.Printf("    topic %q\n", topic.Name)
+		}
+	}
+}
+
+func importMarkdown(ctx context.Context, client *memory.Client, docs []*ingest.Document, globals GlobalFlags) {
+	imported, err := ingest.Apply(ctx, client, docs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: import failed after %s: %v\n", formatImportCounts(imported), err)
+		os.Exit(ExitDatabase)
+	}
+
+	if !globals.Quiet {
+		fmt.Printf("Imported %s\n", formatImportCounts(imported))
+	}
+}
+
+func printGitDryRun(h *ingest.History) {
+	fmt.Printf("Dry run — would import %d entities, %d decisions, %d events:\n", len(h.Entities), len(h.Decisions), len(h.Releases))
+	for _, e := range h.Entities {
+		fmt.Printf("  entity %q: %s\n", e.Name, e.Description)
+	}
+	for _, doc := range h.Decisions {
+		fmt.Printf("  decision %q (%s)\n", doc.Decision.Title, doc.Source)
+	}
+	for _, r := range h.Releases {
+		fmt.Printf("  event %q on %s (%d merges)\n", r.Event.Title, r.Event.EventDate, len(r.Decisions))
+	}
+}
+
+func importGit(ctx context.Context, client *memory.Client, h *ingest.History, globals GlobalFlags) {
+	imported, err := ingest.ApplyHistory(ctx, client, h)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: import failed after %s: %v\n", formatImportCounts(imported), err)
+		os.Exit(ExitDatabase)
+	}
+
+	if !globals.Quiet {
+		fmt.Printf("Imported %s\n", formatImportCounts(imported))
+	}
+}
+
+func printObsidianDryRun(notes []*ingest.Note) {
+	fmt.Printf("Dry run — would import %d notes:\n", len(notes))
+	for _, note := range notes {
+		if note.Event != nil {
+			fmt.Printf("  %s: event %q on %s\n", note.Source, note.Title, note.Event.EventDate)
+		} else {
+			fmt.Printf("  %s: fact %q\n", note.Source, note.Title)
+		}
+		for _, e := range note.Entities {
+			fmt.Printf("    entity %q\n", e.Name)
+		}
+		for _, topic := range note.Topics {
+			fmt.Printf("    topic %q\n", topic.Name)
+		}
+	}
+}
+
+func importObsidian(ctx context.Context, client *memory.Client, notes []*ingest.Note, globals GlobalFlags) {
+	imported, err := ingest.ApplyNotes(ctx, client, notes)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: import failed after %s: %v\n", formatImportCounts(imported), err)
+		os.Exit(ExitDatabase)
+	}
+
+	if !globals.Quiet {
+		fmt.Printf("Imported %s\n", formatImportCounts(imported))
+	}
+}
+
+// memoryParsers are the parsers of the --format values that read another
+// agent memory's export.
+var memoryParsers = map[string]func([]byte) ([]*ingest.Memory, error){
+	"mem0":      ingest.ParseMem0,
+	"zep":       ingest.ParseZep,
+	"langchain": ingest.ParseLangChain,
+}
+
+func printMemoriesDryRun(memories []*ingest.Memory) {
+	fmt.Printf("Dry run — would import %d memories:\n", len(memories))
+	for _, mem := range memories {
+		switch {
+		case mem.Event != nil:
+			fmt.Printf("  %s: event %q on %s\n", mem.Source, mem.Event.Title, mem.Event.EventDate)
+		case mem.Fact != nil:
+			fmt.Printf("  %s: fact %q (%s)\n", mem.Source, tools.Truncate(mem.Fact.Content, 80), mem.Fact.Category)
+		default:
+			fmt.Printf("  %s: entities only\n", mem.Source)
+		}
+		for _, e := range mem.Entities {
+			fmt.Printf("    entity %q (%s)\n", e.Name, e.Kind)
+		}
+		for _, topic := range mem.Topics {
+			fmt.Printf("    topic %q\n", topic.Name)
+		}
+	}
+}
+
+func importMemories(ctx context.Context, client *memory.Client, memories []*ingest.Memory, globals GlobalFlags) {
+	imported, err := ingest.ApplyMemories(ctx, client, memories)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: import failed after %s: %v\n", formatImportCounts(imported), err)
+		os.Exit(ExitDatabase)
+	}
+
+	if !globals.Quiet {
+		fmt.Printf("Imported %s\n", formatImportCounts(imported))
+	}
+}
@@ -0,0 +1,62 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestImportJSONDryRun(t *testing.T) {
+	client, err := memory.NewClient(memory.ClientConfig{DataDir: t.TempDir(), StorageEngine: "mem", EmbeddingDimensions: 384})
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+	ctx := context.Background()
+
+	_, err = client.StoreFact(ctx, tools.StoreFactRequest{Content: "Uses Go", Category: "technical"})
+	require.NoError(t, err)
+	_, err = client.StoreFact(ctx, tools.StoreFactRequest{Content: "Billing runs on Postgres 15", Category: "technical"})
+	require.NoError(t, err)
+
+	export, err := client.ExportGraph(ctx, tools.ExportOptions{Format: "json"})
+	require.NoError(t, err)
+	export.Facts = append(export.Facts,
+		tools.Fact{ID: "fact:copy", Content: "uses go", Category: "general", Valid: true},
+		tools.Fact{ID: "fact:new", Content: "Deploys on Fridays", Category: "general", Valid: true},
+		tools.Fact{ID: "fact:pg16", Content: "Billing runs on Postgres 16", Category: "technical", Valid: true})
+	data, err := json.Marshal(export)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	importJSON(ctx, &out, client, data, true, GlobalFlags{})
+	report := out.String()
+	assert.Contains(t, report, "3 new:        3 facts, 0 decisions")
+	assert.Contains(t, report, "2 updated:    2 facts, 0 decisions")
+	assert.Contains(t, report, "1 duplicates: 1 facts, 0 decisions")
+	assert.Contains(t, report, "1 potential conflicts\n")
+	assert.Contains(t, report, "1 new nodes match an existing node")
+
+	stats, err := client.GetStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.TotalFacts, "the dry run wrote facts")
+
+	// The import does what the dry run reported: 3 facts more, the
+	// duplicate included.
+	out.Reset()
+	importJSON(ctx, &out, client, data, false, GlobalFlags{})
+	stats, err = client.GetStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 5, stats.TotalFacts)
+}
@@ -19,34 +19,28 @@ import (
 	"github.com/kraklabs/mie/pkg/tools"
 )
 
-// runInit creates a new .mie/config.yaml configuration file.
-func runInit(args []string, globals GlobalFlags) {
-	fs := flag.NewFlagSet("init", flag.ExitOnError)
-	force := fs.Bool("force", false, "Overwrite existing configuration")
-	interview := fs.Bool("interview", false, "Run interactive onboarding to pre-populate memory")
-
-	fs.Usage = func() {
-		fmt.Fprintf(os.Stderr, `Usage: mie init [options]
-
-Description:
-  Create a new .mie/config.yaml configuration file in the current directory
-  with sensible defaults.
-
-Options:
-`)
-		fs.PrintDefaults()
-		fmt.Fprintf(os.Stderr, `
-Examples:
-  mie init                  Create configuration with defaults
+// initCommand is mie init.
+var initCommand = command{
+	name:    "init",
+	summary: "Create .mie/config.yaml configuration",
+	usage:   "[options]",
+	help: `  Create a new .mie/config.yaml configuration file in the current directory
+  with sensible defaults.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.Bool("force", false, "Overwrite existing configuration")
+		fs.Bool("interview", false, "Run interactive onboarding to pre-populate memory")
+	},
+	examples: `  mie init                  Create configuration with defaults
   mie init --force          Overwrite existing configuration
   mie init --interview      Create config and pre-populate memory
+  mie --namespace acme init Create config scoped to the "acme" namespace`,
+	run: func(fs *flag.FlagSet, _ string, globals GlobalFlags) { runInit(fs, globals) },
+}
 
-`)
-	}
-
-	if err := fs.Parse(args); err != nil {
-		os.Exit(1)
-	}
+// runInit creates a new .mie/config.yaml configuration file.
+func runInit(fs *flag.FlagSet, globals GlobalFlags) {
+	force, _ := fs.GetBool("force")
+	interview, _ := fs.GetBool("interview")
 
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -56,13 +50,14 @@ Examples:
 
 	configPath := ConfigPath(cwd)
 
-	if _, err := os.Stat(configPath); err == nil && !*force {
+	if _, err := os.Stat(configPath); err == nil && !force {
 		fmt.Fprintf(os.Stderr, "Error: %s already exists\n", configPath)
 		fmt.Fprintf(os.Stderr, "Use --force to overwrite\n")
 		os.Exit(1)
 	}
 
 	cfg := DefaultConfig()
+	cfg.Namespace = globals.Namespace
 	if err := SaveConfig(cfg, configPath); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(ExitConfig)
@@ -72,7 +67,7 @@ Examples:
 		fmt.Printf("Created %s\n", configPath)
 	}
 
-	if *interview {
+	if interview {
 		runInterview(cfg, globals)
 	} else if !globals.Quiet {
 		fmt.Println()
@@ -93,9 +88,11 @@ func runInterview(cfg *Config, globals GlobalFlags) {
 	client, err := memory.NewClient(memory.ClientConfig{
 		DataDir:       dataDir,
 		StorageEngine: cfg.Storage.Engine,
+		Namespace:     globals.resolveNamespace(cfg),
 	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: cannot open database: %v\n", err)
+		printLockHint(dataDir)
 		os.Exit(ExitDatabase)
 	}
 	defer func() { _ = client.Close() }()
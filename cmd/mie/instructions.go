@@ -0,0 +1,142 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// defaultInstructionsFile is looked up next to config.yaml when
+// server.instructions is not set.
+const defaultInstructionsFile = "instructions.md"
+
+// instructionsData is the data an instructions template is rendered with.
+type instructionsData struct {
+	// Project is the name of the directory that holds .mie.
+	Project string
+	// Namespace is the session's default namespace.
+	Namespace   string
+	Categories  []string
+	EntityKinds []string
+	ReadOnly    bool
+	// Default is the built-in instructions text.
+	Default string
+}
+
+// customInstructions is a team's instructions template, loaded from
+// .mie/instructions.md or server.instructions.
+type customInstructions struct {
+	tmpl    *template.Template
+	replace bool
+	project string
+}
+
+// loadInstructions parses the instructions template configured for cfg. It
+// returns nil when server.instructions is not set and the config directory
+// has no instructions.md.
+func loadInstructions(cfg *Config) (*customInstructions, error) {
+	file := cfg.Server.Instructions
+	if file == "" {
+		if cfg.dir == "" {
+			return nil, nil
+		}
+		file = filepath.Join(cfg.dir, defaultInstructionsFile)
+		if _, err := os.Stat(file); errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+	} else if !filepath.IsAbs(file) && cfg.dir != "" {
+		file = filepath.Join(cfg.dir, file)
+	}
+
+	data, err := os.ReadFile(file) //nolint:gosec // G304: Path comes from user config
+	if err != nil {
+		return nil, fmt.Errorf("cannot read instructions: %w", err)
+	}
+	tmpl, err := template.New(filepath.Base(file)).
+		Funcs(template.FuncMap{"join": strings.Join}).
+		Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid instructions template: %w", err)
+	}
+	ci := &customInstructions{
+		tmpl:    tmpl,
+		replace: cfg.Server.InstructionsMode == "replace",
+		project: projectName(cfg.dir),
+	}
+	// Render once so a reference to an unknown field fails at startup
+	// rather than on every initialize.
+	if _, err := ci.render(instructionsData{Project: ci.project, Namespace: tools.DefaultNamespace}); err != nil {
+		return nil, err
+	}
+	return ci, nil
+}
+
+// render executes the template with data.
+func (ci *customInstructions) render(data instructionsData) (string, error) {
+	var b strings.Builder
+	if err := ci.tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("invalid instructions template: %w", err)
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+// projectName returns the name of the project a config directory belongs
+// to: the directory holding .mie, or the working directory for a config
+// file kept elsewhere.
+func projectName(configDir string) string {
+	if filepath.Base(configDir) == defaultConfigDir {
+		return filepath.Base(filepath.Dir(configDir))
+	}
+	if wd, err := os.Getwd(); err == nil {
+		return filepath.Base(wd)
+	}
+	return ""
+}
+
+// instructions returns the instructions text for initialize: the built-in
+// text, the read-only note when it applies, and the team's template either
+// appended to them or in their place.
+func (s *mcpServer) instructions() string {
+	text := mieInstructions
+	if s.readOnly {
+		text += readOnlyInstructions
+	}
+	if s.customInstructions == nil {
+		return text
+	}
+	namespace := s.workspaceNamespace
+	if namespace == "" {
+		namespace = s.namespace
+	}
+	if namespace == "" {
+		namespace = tools.DefaultNamespace
+	}
+	custom, err := s.customInstructions.render(instructionsData{
+		Project:     s.customInstructions.project,
+		Namespace:   namespace,
+		Categories:  tools.FactCategories(),
+		EntityKinds: tools.EntityKinds(),
+		ReadOnly:    s.readOnly,
+		Default:     text,
+	})
+	if err != nil {
+		s.log().Warn("using the built-in instructions", "error", err)
+		return text
+	}
+	if s.customInstructions.replace {
+		return custom
+	}
+	return text + "\n\n" + custom
+}
@@ -0,0 +1,107 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/api"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// localSocketName is the Unix socket, in the data directory, on which a
+// running server answers CLI commands. The storage engine locks the database
+// for the process that opened it, so commands such as mie status ask the
+// server instead of opening the database themselves.
+const localSocketName = "mie.sock"
+
+// localSocketPath returns the path of the local socket for dataDir.
+func localSocketPath(dataDir string) string {
+	return filepath.Join(dataDir, localSocketName)
+}
+
+// serveLocal serves the REST API for client on the local socket of dataDir,
+// logging to logger. When readOnly, requests other than GET are rejected. It
+// returns a function that stops serving and removes the socket. If another
+// live server already answers on the socket, serveLocal leaves it alone and
+// returns an error.
+func serveLocal(dataDir string, client tools.Querier, readOnly bool, logger *slog.Logger) (func(), error) {
+	path := localSocketPath(dataDir)
+	if _, err := os.Stat(path); err == nil {
+		if dialLocal(context.Background(), dataDir) != nil {
+			return nil, fmt.Errorf("another MIE server is listening on %s", path)
+		}
+		// Left behind by a server that did not shut down cleanly.
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("remove stale socket: %w", err)
+		}
+	}
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		_ = ln.Close()
+		return nil, err
+	}
+
+	var handler http.Handler = api.NewServer(client, logger)
+	if readOnly {
+		next := handler
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "server is read-only"})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	srv := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Warn("local socket stopped", "error", err)
+		}
+	}()
+	return func() { _ = srv.Close() }, nil
+}
+
+// dialLocal returns a client for the server running on dataDir, or nil if
+// none answers on its local socket.
+func dialLocal(ctx context.Context, dataDir string) *api.Client {
+	path := localSocketPath(dataDir)
+	if _, err := os.Stat(path); err != nil {
+		return nil
+	}
+	c := api.NewSocketClient(path)
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	if _, err := c.Health(ctx); err != nil {
+		return nil
+	}
+	return c
+}
+
+// printLockHint explains a failure to open the database in dataDir when a
+// running server holds it.
+func printLockHint(dataDir string) {
+	if dialLocal(context.Background(), dataDir) != nil {
+		fmt.Fprintf(os.Stderr, "Hint: a running MIE server holds the database in %s. Stop it and retry; 'mie status' works while it runs.\n", dataDir)
+	}
+}
@@ -8,13 +8,19 @@
 //
 // Usage:
 //
-//	mie --mcp                     Start as MCP server (JSON-RPC over stdio)
+//	mie --mcp [--read-only]       Start as MCP server (JSON-RPC over stdio)
 //	mie init                      Create .mie/config.yaml configuration
 //	mie status [--json]           Show memory graph status
+//	mie stats [--period 30d]      Chart growth and usage over recent days
 //	mie reset --yes               Delete all memory data
 //	mie export [--format json]    Export memory graph
 //	mie import [--format json]    Import memory graph
 //	mie query <script>            Execute CozoScript query
+//	mie serve [--http addr]       Serve the REST API
+//	mie embed [--backfill]        Report or generate missing embeddings
+//	mie doctor [--fix]            Diagnose configuration and database problems
+//	mie <plugin> [args]           Run the mie-<plugin> executable found on PATH
+//	mie help <command>            Show the help of a command or plugin
 package main
 
 import (
@@ -22,6 +28,8 @@ import (
 	"os"
 
 	flag "github.com/spf13/pflag"
+
+	"github.com/kraklabs/mie/pkg/tools"
 )
 
 // Exit codes for the MIE CLI.
@@ -42,19 +50,31 @@ var (
 
 // GlobalFlags holds the global CLI flags that apply to all commands.
 type GlobalFlags struct {
-	JSON    bool
-	Verbose int
-	Quiet   bool
+	JSON      bool
+	Verbose   int
+	Quiet     bool
+	Namespace string
+}
+
+// resolveNamespace returns the memory namespace to use with cfg.
+// The --namespace flag takes precedence over the config file and MIE_NAMESPACE.
+func (g GlobalFlags) resolveNamespace(cfg *Config) string {
+	if g.Namespace != "" {
+		return g.Namespace
+	}
+	return cfg.Namespace
 }
 
 func main() {
 	var (
 		showVersion = flag.BoolP("version", "V", false, "Show version and exit")
 		mcpMode     = flag.Bool("mcp", false, "Start as MCP server (JSON-RPC over stdio)")
+		readOnly    = flag.Bool("read-only", false, "With --mcp, expose only tools that do not modify memory")
 		configPath  = flag.StringP("config", "c", "", "Path to .mie/config.yaml")
 		jsonOutput  = flag.Bool("json", false, "Output in JSON format")
 		verbose     = flag.CountP("verbose", "v", "Increase verbosity (-v info, -vv debug)")
 		quiet       = flag.BoolP("quiet", "q", false, "Suppress non-essential output")
+		namespace   = flag.String("namespace", "", "Memory namespace to use (default from config)")
 	)
 
 	flag.SetInterspersed(false)
@@ -68,31 +88,43 @@ and their relationships, accessible via MCP (Model Context Protocol).
 
 Usage:
   mie <command> [options]
+  mie <plugin> [args]     Run the mie-<plugin> executable found on PATH
+  mie help <command>      Show the help of a command or plugin
 
-Commands:
-  init          Create .mie/config.yaml configuration
-  status        Show memory graph status
-  reset         Delete all memory data (destructive!)
-  export        Export memory graph
-  import        Import memory graph
-  query         Execute CozoScript query (debugging)
-
+%s
 Global Options:
   --json            Output in JSON format
   -v, --verbose     Increase verbosity (-v info, -vv debug)
   -q, --quiet       Suppress non-essential output
+  --namespace       Memory namespace to use (partitions the graph per project)
   --mcp             Start as MCP server (JSON-RPC over stdio)
+  --read-only       With --mcp, expose only tools that do not modify memory
   -c, --config      Path to .mie/config.yaml
   -V, --version     Show version and exit
 
 Examples:
   mie init                         Create configuration
   mie --mcp                        Start MCP server
+  mie --mcp --read-only            Start MCP server without write tools
   mie status                       Show memory stats
   mie status --json                Output as JSON
+  mie stats --period 30d           Chart activity over the last 30 days
   mie export --format json         Export all data
   mie import --input backup.json   Import from file
+  mie merge --input laptop.json    Merge another machine's export
+  mie sync --to http://laptop:8080 Send changes since the last sync
   mie query "?[name] := *mie_entity{name} :limit 10"
+  mie query -i                     Interactive query session
+  mie serve --http :8080           Start REST API server
+  mie embed --backfill             Generate missing embeddings
+  mie doctor                       Check for common problems
+  mie audit --tail 50              Show the latest writes
+  mie snapshot create "backup"     Checkpoint the graph
+  mie search run open-decisions    Run a saved search
+  mie gc --dry-run                 Show what garbage collection removes
+  mie prune --min-score 0.7        Suggest nodes that are safe to delete
+  mie dedupe                       Report entities and topics to merge
+  mie tui                          Browse stats, recent nodes, and conflicts
 
 Getting Started:
   1. Initialize configuration:  mie init
@@ -101,13 +133,15 @@ Getting Started:
 
 Environment Variables:
   MIE_CONFIG_PATH       Path to config file
+  MIE_NAMESPACE         Memory namespace (default: default)
   MIE_STORAGE_ENGINE    Storage engine (sqlite, rocksdb, mem)
   MIE_STORAGE_PATH      Database file path
   MIE_EMBEDDING_ENABLED Enable embeddings (true/false)
+  MIE_READ_ONLY         Start the MCP server in read-only mode (true/false)
   OLLAMA_HOST           Ollama URL (default: http://localhost:11434)
   OLLAMA_EMBED_MODEL    Embedding model (default: nomic-embed-text)
 
-`)
+`, commandList())
 	}
 
 	flag.Parse()
@@ -128,14 +162,22 @@ Environment Variables:
 		*quiet = true
 	}
 
+	if *namespace != "" {
+		if err := tools.ValidateNamespace(*namespace); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitConfig)
+		}
+	}
+
 	globals := GlobalFlags{
-		JSON:    *jsonOutput,
-		Verbose: *verbose,
-		Quiet:   *quiet,
+		JSON:      *jsonOutput,
+		Verbose:   *verbose,
+		Quiet:     *quiet,
+		Namespace: *namespace,
 	}
 
 	if *mcpMode {
-		runMCPServer(*configPath)
+		runMCPServer(*configPath, globals, *readOnly)
 		return
 	}
 
@@ -145,25 +187,22 @@ Environment Variables:
 		os.Exit(1)
 	}
 
-	command := args[0]
+	name := args[0]
 	cmdArgs := args[1:]
 
-	switch command {
-	case "init":
-		runInit(cmdArgs, globals)
-	case "status":
-		runStatus(cmdArgs, *configPath, globals)
-	case "reset":
-		runReset(cmdArgs, *configPath, globals)
-	case "export":
-		runExport(cmdArgs, *configPath, globals)
-	case "import":
-		runImport(cmdArgs, *configPath, globals)
-	case "query":
-		runQuery(cmdArgs, *configPath, globals)
-	default:
-		fmt.Fprintf(os.Stderr, "Unknown command: %s\n", command)
-		flag.Usage()
-		os.Exit(1)
+	if name == "help" {
+		runHelp(cmdArgs, *configPath, globals)
+		return
+	}
+	if c, ok := findCommand(name); ok {
+		c.execute(cmdArgs, *configPath, globals)
+		return
+	}
+	if path, ok := pluginPath(name); ok {
+		runPlugin(path, cmdArgs, *configPath, globals)
+		return
 	}
+	fmt.Fprintf(os.Stderr, "Unknown command: %s\n", name)
+	flag.Usage()
+	os.Exit(1)
 }
@@ -9,13 +9,26 @@ package main
 import (
 	"bufio"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"net/url"
 	"os"
+	"os/signal"
+	"path"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"unicode/utf8"
 
 	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/policy"
 	"github.com/kraklabs/mie/pkg/tools"
 )
 
@@ -24,17 +37,23 @@ const (
 	mcpServerName = "mie"
 )
 
+// Resource URIs served by the MCP server.
+const (
+	recentContextURI = "mie://context/recent"
+	changesURI       = "mie://changes"
+)
+
 // mieInstructions is the MCP instructions text sent to agents on initialize.
 // It guides AI agents on how to use MIE effectively.
 const mieInstructions = `MIE (Memory Intelligence Engine) gives you persistent memory across conversations. Use it to remember facts, decisions, entities, events, and topics about the user and their projects.
 
 ## When to capture memory
 
-At the end of meaningful conversations, call mie_analyze with a summary of what was discussed. It will identify what is worth storing and return related existing memories. Then use mie_store or mie_bulk_store to persist the information.
+At the end of meaningful conversations, call mie_analyze with a summary of what was discussed. It will identify what is worth storing, propose candidate items, and return related existing memories. Review the candidates, then pass the ones worth keeping to mie_bulk_store (or use mie_store) to persist the information.
 
 ## When to query memory
 
-Before answering questions about past decisions, user preferences, project context, or previously discussed topics, query MIE first using mie_query. This lets you give informed, consistent responses grounded in what you actually know about the user.
+Before answering questions about past decisions, user preferences, project context, or previously discussed topics, query MIE first using mie_query. This lets you give informed, consistent responses grounded in what you actually know about the user. When you need several lookups (for example, a person, a project, and a past decision), send them together in one mie_bulk_query call instead of calling mie_query repeatedly. To prime yourself on a subject before a longer task, use mie_context to get a compact briefing of what MIE knows about it. To answer a specific question from memory, call mie_ask and cite the node IDs of the evidence it returns.
 
 ## What to store
 
@@ -68,7 +87,7 @@ Map ADR fields to MIE decision nodes:
 
 ### Importing general markdown
 
-- Headings suggest topics — create topic nodes for major themes
+- Headings suggest topics — create topic nodes for major themes, after checking mie_topics for an existing topic that fits
 - "We decided" / "We chose" / "We use" language suggests decisions
 - Technical tool and framework names suggest entities (kind: technology)
 - People and team names suggest entities (kind: person, company)
@@ -122,7 +141,51 @@ Run 'git tag -l --sort=-creatordate --format="%(creatordate:short) %(refname:sho
 
 ### Cross-referencing
 
-Use mie_bulk_store with the target_ref field in relationships to link items within the same batch by their array index (0-based). This avoids needing to know IDs ahead of time.`
+Use mie_bulk_store with the target_ref field in relationships to link items within the same batch by their array index (0-based). This avoids needing to know IDs ahead of time.
+
+To link nodes that already exist (for example, after a mie_query reveals a missing connection), use mie_relate with the two node IDs. mie_relate with action "delete" removes a wrong edge.
+
+When you change a decision's status or a node's description with mie_update, pass a reason; mie_history with the node ID shows every such change with its reason.
+
+To find the links a stored node is missing, call mie_suggest_relationships with its ID and confirm the suggestions you agree with through mie_relate.
+
+To check whether a node duplicates or echoes what is already stored, call mie_similar with its ID. Merge duplicate entities with mie_merge, invalidate duplicate facts with mie_update, and link related nodes with mie_relate.
+
+When you run the same search again and again (for example "open decisions about auth"), save it with mie_query action "save" and a name, then repeat it with action "run" and the name.
+
+At the start of a conversation worth remembering, call mie_session with action "open" and a title; what you store afterwards is grouped under the session until you call it with action "close" and a summary. mie_session with action "show" returns everything learned in a session.
+
+To keep a small artifact with a memory, such as a diagram or a config snippet, call mie_attach with the node ID, a file name, and the content (base64 with encoding "base64" for binary files). Read it back through the mie://attachment/<hash> resource.
+
+For an overview of everything remembered about a topic, read the mie://topic/<name>/summary resource. A single entity or decision can be read as the mie://entity/<id> or mie://decision/<id> resource, for example to attach it as context.
+
+Before a large mie_bulk_store or import, call mie_snapshot with action "create" and a label. If the result is wrong, mie_snapshot with action "restore" and the snapshot ID reverts the graph.
+
+### Aliases
+
+When the same thing appears under different names (e.g. "Postgres" and "PostgreSQL"), keep one entity and call mie_update with action "alias" on it, passing the other name as new_value. Storing an entity whose name is a known alias returns the existing entity instead of creating a duplicate. To correct an entity's name, use action "rename" with the new name as new_value; the old name becomes an alias.
+
+If duplicates already exist as separate entities, call mie_merge with the entity to keep as survivor_id and the other as duplicate_id. Relationships move to the survivor and the duplicate's name becomes an alias. To find such duplicates across the whole graph, call mie_dedupe; it lists clusters of similar entities and topics with the mie_merge calls to review.
+
+### Duplicate facts
+
+Storing a fact that matches an existing valid fact (same text, or nearly identical meaning when embeddings are enabled) returns the existing fact instead of creating a copy; the output starts with "Not stored" and includes a duplicate_of line. Relationships in the same call attach to the existing fact. A fact that sets invalidates is always stored.
+
+### Anti-facts
+
+To record that something is NOT true, store the positive statement with negated=true, e.g. content "User uses Docker" with negated true for a user who does not use Docker. Search results mark anti-facts with "Negated: this does NOT hold", and mie_conflicts reports an anti-fact and a matching asserted fact as a negation conflict.
+
+### Preferences
+
+Store a user preference with a key naming the setting, e.g. mie_store with type "fact", content "User prefers tabs for indentation" and key "indentation". Each key keeps one current value: storing a new value invalidates the previous one, so do not pass invalidates yourself. Call mie_preferences to read the current values before acting on a preference.
+
+### Temporary facts
+
+For facts that are only true for a while ("the user is traveling until March"), set expires_at on the fact. Once it passes, the server invalidates the fact, so it drops out of valid-only results.
+
+### Archiving
+
+When an entity, topic, or other node is stale but worth keeping (a retired service, a finished project), call mie_update with action "archive". Archived nodes are hidden from mie_query and mie_list unless include_archived is true; action "unarchive" restores them. Prefer invalidation for facts that turned out to be wrong.`
 
 // JSON-RPC 2.0 types for MCP protocol.
 
@@ -133,6 +196,13 @@ type jsonRPCRequest struct {
 	Params  json.RawMessage `json:"params,omitempty"`
 }
 
+// jsonRPCNotification is a server-initiated message that expects no response.
+type jsonRPCNotification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
 type jsonRPCResponse struct {
 	JSONRPC string    `json:"jsonrpc"`
 	ID      any       `json:"id,omitempty"`
@@ -156,6 +226,18 @@ type mcpCapabilities struct {
 	Resources map[string]any `json:"resources,omitempty"`
 }
 
+// mcpInitializeParams holds the initialize params MIE uses.
+type mcpInitializeParams struct {
+	RootURI    string        `json:"rootUri"`
+	ClientInfo mcpClientInfo `json:"clientInfo"`
+}
+
+// mcpClientInfo identifies the MCP client, such as "cursor".
+type mcpClientInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
 type mcpInitializeResult struct {
 	ProtocolVersion string          `json:"protocolVersion"`
 	Capabilities    mcpCapabilities `json:"capabilities"`
@@ -201,14 +283,36 @@ type mcpResourcesListResult struct {
 	Resources []mcpResource `json:"resources"`
 }
 
+type mcpResourceTemplate struct {
+	URITemplate string `json:"uriTemplate"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+type mcpResourceTemplatesListResult struct {
+	ResourceTemplates []mcpResourceTemplate `json:"resourceTemplates"`
+}
+
 type mcpResourceReadParams struct {
 	URI string `json:"uri"`
 }
 
+type mcpResourceSubscribeParams struct {
+	URI string `json:"uri"`
+}
+
+type mcpResourceUpdatedParams struct {
+	URI string `json:"uri"`
+}
+
+// mcpResourceContent holds text content in Text, or binary content base64
+// encoded in Blob.
 type mcpResourceContent struct {
 	URI      string `json:"uri"`
 	MimeType string `json:"mimeType"`
-	Text     string `json:"text"`
+	Text     string `json:"text,omitempty"`
+	Blob     string `json:"blob,omitempty"`
 }
 
 type mcpResourceReadResult struct {
@@ -219,6 +323,53 @@ type mcpResourceReadResult struct {
 type mcpServer struct {
 	client tools.Querier
 	config *Config
+	// changes is the client's write feed; nil disables subscriptions.
+	changes *memory.ChangeFeed
+	// readOnly hides and rejects the tools in writeTools.
+	readOnly bool
+	// namespaceFromWorkspace makes initialize set workspaceNamespace from
+	// the client's rootUri. workspaceNamespace then scopes every request
+	// that does not pass namespace or project itself.
+	namespaceFromWorkspace bool
+	workspaceNamespace     string
+	// namespace is the configured default namespace, shown to agents
+	// through the instructions template.
+	namespace string
+	// customInstructions extends or replaces the built-in instructions;
+	// nil sends them unchanged.
+	customInstructions *customInstructions
+	// policy restricts the tools of each client, named by clientName
+	// from the initialize params; nil allows every tool.
+	policy     *policy.Policy
+	clientName string
+	// requestTimeout bounds each tool call; 0 leaves them unbounded.
+	requestTimeout time.Duration
+	// callSlots holds a token for each running tool call, so at most
+	// cap(callSlots) run at once; nil leaves them unlimited.
+	callSlots chan struct{}
+	// strictValidation rejects tool calls whose arguments do not match the
+	// tool's input schema instead of letting the tool fall back to
+	// defaults.
+	strictValidation bool
+	// logger receives the server's diagnostics; nil discards them.
+	logger *slog.Logger
+
+	// callsMu guards calls, the cancel functions of the running tool calls
+	// by JSON-encoded request ID, for notifications/cancelled.
+	callsMu sync.Mutex
+	calls   map[string]context.CancelCauseFunc
+
+	// outMu serializes writes of responses and notifications.
+	outMu sync.Mutex
+	// subMu guards subscribed, the resource URIs the client subscribed to.
+	subMu      sync.Mutex
+	subscribed map[string]bool
+
+	// sessionMu guards session, the ID of the session opened with
+	// mie_session. Stores made while it is set record it as their
+	// source_conversation.
+	sessionMu sync.Mutex
+	session   string
 }
 
 // toolHandler is the signature for MCP tool handlers.
@@ -226,19 +377,65 @@ type toolHandler func(ctx context.Context, s *mcpServer, args map[string]any) (*
 
 // toolHandlers maps tool names to their handler functions.
 var toolHandlers = map[string]toolHandler{
-	"mie_analyze":    handleAnalyze,
-	"mie_store":      handleStore,
-	"mie_bulk_store": handleBulkStore,
-	"mie_query":      handleQuery,
-	"mie_update":     handleUpdate,
-	"mie_list":       handleList,
-	"mie_conflicts":  handleConflicts,
-	"mie_export":     handleExport,
-	"mie_status":     handleMIEStatus,
-}
-
-// runMCPServer starts the MIE MCP server on stdin/stdout.
-func runMCPServer(configPath string) {
+	"mie_analyze":               handleAnalyze,
+	"mie_store":                 handleStore,
+	"mie_bulk_store":            handleBulkStore,
+	"mie_query":                 handleQuery,
+	"mie_bulk_query":            handleBulkQuery,
+	"mie_context":               handleContext,
+	"mie_ask":                   handleAsk,
+	"mie_update":                handleUpdate,
+	"mie_relate":                handleRelate,
+	"mie_merge":                 handleMerge,
+	"mie_list":                  handleList,
+	"mie_conflicts":             handleConflicts,
+	"mie_similar":               handleSimilar,
+	"mie_dedupe":                handleDedupe,
+	"mie_preferences":           handlePreferences,
+	"mie_export":                handleExport,
+	"mie_status":                handleMIEStatus,
+	"mie_stats_by_topic":        handleStatsByTopic,
+	"mie_embedding_status":      handleEmbeddingStatus,
+	"mie_topics":                handleTopics,
+	"mie_visualize":             handleVisualize,
+	"mie_audit":                 handleAudit,
+	"mie_history":               handleHistory,
+	"mie_suggest_relationships": handleSuggestRelationships,
+	"mie_snapshot":              handleSnapshot,
+	"mie_review":                handleReview,
+	"mie_session":               handleSession,
+	"mie_attach":                handleAttach,
+}
+
+// writeTools are the tools that modify the memory graph. A read-only server
+// neither lists nor dispatches them.
+var writeTools = map[string]bool{
+	"mie_store":      true,
+	"mie_bulk_store": true,
+	"mie_update":     true,
+	"mie_relate":     true,
+	"mie_merge":      true,
+	"mie_snapshot":   true,
+}
+
+// writeActions are the actions of tools outside writeTools that modify the
+// memory graph. A read-only server rejects them.
+var writeActions = map[string]map[string]bool{
+	"mie_query":   {"save": true, "delete": true},
+	"mie_session": {"open": true, "close": true},
+	"mie_attach":  {"add": true, "remove": true},
+}
+
+// readOnlyInstructions is appended to mieInstructions in read-only mode.
+const readOnlyInstructions = `
+
+## Read-only mode
+
+This MIE server is read-only. The storing, updating, relating, merging, and snapshot tools are unavailable, mie_query cannot save or delete searches, mie_session cannot open or close sessions, and mie_attach cannot add or remove attachments; use MIE only to look things up.`
+
+// runMCPServer starts the MIE MCP server on stdin/stdout. readOnly, or
+// server.read_only in the config, disables the tools that write memory.
+func runMCPServer(configPath string, globals GlobalFlags, readOnly bool) {
 	var cfg *Config
 	var err error
 
@@ -250,8 +447,29 @@ func runMCPServer(configPath string) {
 		cfg.applyEnvOverrides()
 	}
 
+	if err := tools.ExtendSchema(cfg.Schema.ExtraEntityKinds, cfg.Schema.ExtraFactCategories); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+	if err := tools.SetCategoryPolicies(cfg.categoryPolicies()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+
+	instructions, err := loadInstructions(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+	clientPolicy, err := policy.New(cfg.Server.Clients)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid server.clients: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+
+	logger := cfg.Log.newLogger(os.Stderr, slog.LevelInfo)
 	if cfg.Storage.Engine == "sqlite" {
-		fmt.Fprintf(os.Stderr, "Warning: sqlite engine may not be available in pre-built binaries; consider using \"rocksdb\"\n")
+		logger.Warn("sqlite engine may not be available in pre-built binaries; consider using \"rocksdb\"")
 	}
 
 	// Resolve storage path
@@ -269,9 +487,10 @@ func runMCPServer(configPath string) {
 
 	// Create the memory client (implements tools.Querier)
 	// This opens CozoDB, ensures schema, and sets up embeddings.
-	client, err := memory.NewClient(memory.ClientConfig{
+	client, err := memory.NewClientWithLogger(memory.ClientConfig{
 		DataDir:            dataDir,
 		StorageEngine:      cfg.Storage.Engine,
+		Namespace:          globals.resolveNamespace(cfg),
 		EmbeddingEnabled:   cfg.Embedding.Enabled,
 		EmbeddingProvider:  cfg.Embedding.Provider,
 		EmbeddingBaseURL:   cfg.Embedding.BaseURL,
@@ -279,74 +498,444 @@ func runMCPServer(configPath string) {
 		EmbeddingAPIKey:    cfg.Embedding.APIKey,
 		EmbeddingDimensions: cfg.Embedding.Dimensions,
 		EmbeddingWorkers:   cfg.Embedding.Workers,
-	})
+		EmbeddingFallbackProvider: cfg.Embedding.FallbackProvider,
+		EmbeddingFallbackBaseURL: cfg.Embedding.FallbackBaseURL,
+		EmbeddingFallbackModel: cfg.Embedding.FallbackModel,
+		EmbeddingFallbackAPIKey: cfg.Embedding.FallbackAPIKey,
+		VectorIndex: cfg.Embedding.vectorIndex(),
+		DedupThreshold:     cfg.Dedup.threshold(),
+		DecayHalfLifeDays:  cfg.Memory.Decay.HalfLifeDays,
+		RankingWeights:     cfg.Memory.Ranking.weights(),
+		MinSimilarity:      cfg.Memory.MinSimilarity,
+		SearchCacheSize:    cfg.Memory.SearchCache.size(),
+		SearchCacheTTL:     cfg.Memory.SearchCache.TTL,
+		AutoLink:           cfg.Memory.AutoLink,
+		CategoryPolicies:   cfg.categoryPolicies(),
+		ExtraEntityKinds:   cfg.Schema.ExtraEntityKinds,
+		ExtraFactCategories: cfg.Schema.ExtraFactCategories,
+		Limits:             cfg.Memory.Limits.limits(),
+	}, logger)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: cannot initialize MIE: %v\n", err)
 		os.Exit(ExitDatabase)
 	}
 	defer func() { _ = client.Close() }()
+	client.StartBackfill()
+	client.StartExpirySweep(memory.DefaultExpirySweepInterval)
+	replayIntents(client, logger)
 
 	server := &mcpServer{
-		client: client,
-		config: cfg,
+		client:   client,
+		config:   cfg,
+		changes:  client.Changes(),
+		readOnly: readOnly || cfg.Server.ReadOnly,
+		// An explicit --namespace wins over the client's workspace.
+		namespaceFromWorkspace: cfg.Server.NamespaceFromWorkspace && globals.Namespace == "",
+		namespace:              globals.resolveNamespace(cfg),
+		customInstructions:     instructions,
+		policy:                 clientPolicy,
+		requestTimeout:         cfg.Server.RequestTimeout,
+		callSlots:              make(chan struct{}, cfg.Server.maxConcurrentCalls()),
+		strictValidation:       cfg.Server.StrictValidation,
+		logger:                 logger,
+	}
+
+	// Stopped before the client closes, so pending webhooks can still read
+	// their nodes.
+	stopWebhooks := startWebhooks(cfg.Hooks, client.Changes(), client.GetNodeByID, logger)
+	defer stopWebhooks()
+
+	if stopLocal, err := serveLocal(dataDir, client, server.readOnly, logger); err != nil {
+		logger.Warn("CLI commands cannot reach this server", "error", err)
+	} else {
+		defer stopLocal()
 	}
 
-	fmt.Fprintf(os.Stderr, "MIE MCP Server v%s starting...\n", mcpVersion)
-	fmt.Fprintf(os.Stderr, "  Storage: %s (%s)\n", cfg.Storage.Engine, dataDir)
+	logger.Info("MIE MCP server starting", "version", mcpVersion,
+		"storage", cfg.Storage.Engine, "data_dir", dataDir, "read_only", server.readOnly)
 	if cfg.Embedding.Enabled {
-		fmt.Fprintf(os.Stderr, "  Embeddings: %s (%s, %dd)\n", cfg.Embedding.Provider, cfg.Embedding.Model, cfg.Embedding.Dimensions)
+		logger.Info("embeddings enabled", "provider", cfg.Embedding.Provider,
+			"model", cfg.Embedding.Model, "dimensions", cfg.Embedding.Dimensions)
+		if cfg.Embedding.FallbackProvider != "" {
+			logger.Info("embedding fallback enabled", "provider", cfg.Embedding.FallbackProvider, "model", cfg.Embedding.FallbackModel)
+		}
+		// Probe in the background so a hanging provider does not delay
+		// the handshake; mie_status reports the result.
+		go func() {
+			for _, w := range client.CheckEmbeddings(context.Background()) {
+				logger.Warn(w)
+			}
+		}()
 	}
 
-	if err := server.serve(os.Stdin, os.Stdout); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: stdin read error: %v\n", err)
+	// SIGINT and SIGTERM cancel the running tool calls; the server exits
+	// once they have answered, closing the database cleanly.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := server.serve(ctx, os.Stdin, os.Stdout); err != nil {
+		logger.Error("stdin read error", "error", err)
 		os.Exit(ExitGeneral)
 	}
 }
 
-// serve runs the JSON-RPC read loop, reading requests from r and writing responses to w.
-func (s *mcpServer) serve(r io.Reader, w io.Writer) error {
-	scanner := bufio.NewScanner(r)
-	scanner.Buffer(make([]byte, 1024*1024), 10*1024*1024)
+// replayIntents finishes the multi-step writes that a previous run left
+// pending, such as a bulk store interrupted by a crash, and logs them.
+func replayIntents(client tools.Querier, logger *slog.Logger) {
+	ctx := tools.WithAuditSource(context.Background(), "intent replay", "")
+	replays, err := tools.ReplayIntents(ctx, client)
+	if err != nil {
+		logger.Warn("cannot read pending intents", "error", err)
+		return
+	}
+	for _, r := range replays {
+		switch {
+		case !r.Completed:
+			logger.Warn("intent left pending", "intent_id", r.Intent.ID, "errors", strings.Join(r.Errors, "; "))
+		case len(r.Errors) > 0:
+			logger.Warn("finished interrupted intent with errors", "intent_id", r.Intent.ID, "kind", r.Intent.Kind, "errors", r.Errors)
+		default:
+			logger.Info("finished interrupted intent", "intent_id", r.Intent.ID, "kind", r.Intent.Kind)
+		}
+	}
+}
+
+// errCallCancelled is the cause of a tool call cancelled by the client.
+var errCallCancelled = errors.New("cancelled by the client")
+
+// serve runs the JSON-RPC read loop, reading requests from r and writing
+// responses to w. Tool calls run concurrently, up to cap(s.callSlots) at
+// once, so a slow call neither blocks the others nor keeps the client from
+// cancelling it; their responses are sent as they finish and matched to
+// requests by ID. Other requests are handled in order. serve returns at
+// the end of r or when ctx is done, after the running tool calls have
+// answered.
+func (s *mcpServer) serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	lines := make(chan string)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 1024*1024), 10*1024*1024)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-ctx.Done():
+				return
+			}
+		}
+		readErr <- scanner.Err()
+	}()
+
+	if s.changes != nil {
+		changes, unsubscribe := s.changes.Subscribe(64)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			s.notifyChanges(changes, w)
+		}()
+		defer func() {
+			unsubscribe()
+			<-done
+		}()
+	}
 
-	for scanner.Scan() {
-		line := scanner.Text()
+	var running sync.WaitGroup
+	defer running.Wait()
+
+	for {
+		var line string
+		select {
+		case <-ctx.Done():
+			s.log().Info("shutting down", "reason", context.Cause(ctx))
+			return nil
+		case l, ok := <-lines:
+			if !ok {
+				select {
+				case err := <-readErr:
+					return err
+				default:
+					return nil
+				}
+			}
+			line = l
+		}
 		if line == "" {
 			continue
 		}
 
 		var req jsonRPCRequest
 		if err := json.Unmarshal([]byte(line), &req); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: invalid JSON-RPC request: %v\n", err)
+			s.log().Warn("invalid JSON-RPC request", "error", err)
 			continue
 		}
 
-		fmt.Fprintf(os.Stderr, "-> %s\n", req.Method)
-
-		ctx := context.Background()
-		resp := s.handleRequest(ctx, req)
+		s.log().Debug("request", "method", req.Method, "request_id", requestKey(req.ID))
 
-		if resp.ID == nil && resp.Result == nil && resp.Error == nil {
+		reqCtx := tools.WithNamespace(ctx, s.workspaceNamespace)
+		if req.Method != "tools/call" || req.ID == nil {
+			s.respond(w, req, s.handleRequest(reqCtx, req))
 			continue
 		}
 
-		respBytes, err := json.Marshal(resp)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: cannot encode response: %v\n", err)
-			continue
+		callCtx, done := s.startCall(reqCtx, req.ID)
+		running.Add(1)
+		go func() {
+			defer running.Done()
+			defer done()
+			// A panicking tool answers with an internal error instead of
+			// taking the server down; runHandler has freed its slot.
+			defer func() {
+				if p := recover(); p != nil {
+					s.log().Error("tool call panicked", "method", req.Method, "request_id", requestKey(req.ID), "panic", p)
+					s.respond(w, req, jsonRPCResponse{
+						JSONRPC: "2.0",
+						ID:      req.ID,
+						Error: &rpcError{
+							Code:    -32603,
+							Message: "Internal error",
+							Data:    fmt.Sprint(p),
+						},
+					})
+				}
+			}()
+			resp := s.handleRequest(callCtx, req)
+			// A cancelled request gets no response.
+			if errors.Is(context.Cause(callCtx), errCallCancelled) {
+				s.log().Info("request cancelled", "method", req.Method, "request_id", requestKey(req.ID))
+				return
+			}
+			s.respond(w, req, resp)
+		}()
+	}
+}
+
+// respond writes resp to w unless it is the empty response of a
+// notification.
+func (s *mcpServer) respond(w io.Writer, req jsonRPCRequest, resp jsonRPCResponse) {
+	if resp.ID == nil && resp.Result == nil && resp.Error == nil {
+		return
+	}
+	if err := s.writeMessage(w, resp); err != nil {
+		s.log().Error("cannot encode response", "method", req.Method, "request_id", requestKey(req.ID), "error", err)
+		return
+	}
+	s.log().Debug("response sent", "method", req.Method, "request_id", requestKey(req.ID))
+}
+
+// toolArgsAttr describes the arguments of a tool call for logger. They hold
+// memory contents, so only their names are logged unless debug logging is
+// enabled.
+func toolArgsAttr(ctx context.Context, logger *slog.Logger, args map[string]any) slog.Attr {
+	if logger.Enabled(ctx, slog.LevelDebug) {
+		return slog.Any("args", args)
+	}
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return slog.Any("args", names)
+}
+
+// log returns the server's logger, or one that discards everything.
+func (s *mcpServer) log() *slog.Logger {
+	if s.logger == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	return s.logger
+}
+
+// startCall returns the context of the tool call with request ID id,
+// bounded by the request timeout and cancellable by the client, and a
+// function to call when the call is done.
+func (s *mcpServer) startCall(ctx context.Context, id any) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	stopTimer := func() {}
+	if s.requestTimeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, s.requestTimeout)
+		stopTimer = cancelTimeout
+	}
+
+	key := requestKey(id)
+	s.callsMu.Lock()
+	if s.calls == nil {
+		s.calls = map[string]context.CancelCauseFunc{}
+	}
+	s.calls[key] = cancel
+	s.callsMu.Unlock()
+
+	return ctx, func() {
+		s.callsMu.Lock()
+		delete(s.calls, key)
+		s.callsMu.Unlock()
+		stopTimer()
+		cancel(nil)
+	}
+}
+
+// acquireCallSlot waits until fewer than cap(s.callSlots) tool calls run,
+// and returns the function that frees the slot again. It fails when ctx
+// is done first.
+func (s *mcpServer) acquireCallSlot(ctx context.Context) (func(), error) {
+	if s.callSlots == nil {
+		return func() {}, nil
+	}
+	select {
+	case s.callSlots <- struct{}{}:
+		return func() { <-s.callSlots }, nil
+	case <-ctx.Done():
+		return nil, context.Cause(ctx)
+	}
+}
+
+// runHandler runs handler in a call slot. Waiting for the slot counts
+// towards the request timeout. The slot is freed even if handler panics.
+func (s *mcpServer) runHandler(ctx context.Context, handler toolHandler, args map[string]any) (*tools.ToolResult, error) {
+	release, err := s.acquireCallSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return handler(ctx, s, args)
+}
+
+// cancelCall cancels the running tool call with request ID id, if any,
+// for notifications/cancelled.
+func (s *mcpServer) cancelCall(id any, reason string) {
+	s.callsMu.Lock()
+	cancel, ok := s.calls[requestKey(id)]
+	s.callsMu.Unlock()
+	if !ok {
+		return
+	}
+	s.log().Info("cancelling request", "request_id", requestKey(id), "reason", reason)
+	cancel(errCallCancelled)
+}
+
+// requestKey identifies a JSON-RPC request ID, keeping the number 1 and
+// the string "1" apart.
+func requestKey(id any) string {
+	data, _ := json.Marshal(id)
+	return string(data)
+}
+
+// writeMessage encodes msg as one JSON line on w. Responses and change
+// notifications share w, so writes are serialized.
+func (s *mcpServer) writeMessage(w io.Writer, msg any) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	s.outMu.Lock()
+	defer s.outMu.Unlock()
+	_, _ = fmt.Fprintf(w, "%s\n", data)
+	return nil
+}
+
+// notifyChanges sends notifications/resources/updated for every subscribed
+// resource when the memory graph changes. Changes that arrive together, such
+// as the nodes of one mie_bulk_store call, produce a single notification.
+func (s *mcpServer) notifyChanges(changes <-chan memory.Change, w io.Writer) {
+	for range changes {
+		for drained := false; !drained; {
+			select {
+			case _, ok := <-changes:
+				if !ok {
+					return
+				}
+			default:
+				drained = true
+			}
+		}
+		for _, uri := range s.subscriptions() {
+			_ = s.writeMessage(w, jsonRPCNotification{
+				JSONRPC: "2.0",
+				Method:  "notifications/resources/updated",
+				Params:  mcpResourceUpdatedParams{URI: uri},
+			})
 		}
+	}
+}
+
+// subscriptions returns the subscribed resource URIs in sorted order.
+func (s *mcpServer) subscriptions() []string {
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+	uris := make([]string, 0, len(s.subscribed))
+	for uri := range s.subscribed {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	return uris
+}
+
+// setSubscribed adds or removes a resource subscription.
+func (s *mcpServer) setSubscribed(uri string, on bool) {
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+	if !on {
+		delete(s.subscribed, uri)
+		return
+	}
+	if s.subscribed == nil {
+		s.subscribed = make(map[string]bool)
+	}
+	s.subscribed[uri] = true
+}
 
-		_, _ = fmt.Fprintf(w, "%s\n", respBytes)
+// setWorkspaceNamespace sets workspaceNamespace from the rootUri in the
+// initialize params. Without a usable file URI the configured namespace stays
+// in effect.
+func (s *mcpServer) setWorkspaceNamespace(params json.RawMessage) {
+	var p mcpInitializeParams
+	if err := json.Unmarshal(params, &p); err != nil || p.RootURI == "" {
+		return
+	}
+	ns, err := workspaceNamespace(p.RootURI)
+	if err != nil {
+		s.log().Warn("cannot derive namespace from workspace", "root_uri", p.RootURI, "error", err)
+		return
+	}
+	s.workspaceNamespace = ns
+	s.log().Info("namespace from workspace", "namespace", ns, "root_uri", p.RootURI)
+}
 
-		fmt.Fprintf(os.Stderr, "<- response sent for %s\n", req.Method)
+// setClient sets clientName from the clientInfo in the initialize params,
+// selecting the client's tool policy.
+func (s *mcpServer) setClient(params json.RawMessage) {
+	var p mcpInitializeParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return
 	}
+	s.clientName = p.ClientInfo.Name
+	if _, ok := s.policy.Rule(s.clientName); ok {
+		s.log().Info("tool policy applies to client", "client", s.clientName)
+	}
+}
 
-	return scanner.Err()
+// workspaceNamespace returns the namespace for a workspace root file URI,
+// named after its last path element.
+func workspaceNamespace(rootURI string) (string, error) {
+	u, err := url.Parse(rootURI)
+	if err != nil {
+		return "", fmt.Errorf("invalid rootUri %q: %w", rootURI, err)
+	}
+	if u.Scheme != "file" {
+		return "", fmt.Errorf("rootUri %q is not a file URI", rootURI)
+	}
+	return tools.ProjectNamespace(path.Base(strings.TrimRight(u.Path, "/")))
 }
 
 // handleRequest dispatches a JSON-RPC request to the appropriate handler.
 func (s *mcpServer) handleRequest(ctx context.Context, req jsonRPCRequest) jsonRPCResponse {
 	switch req.Method {
 	case "initialize":
+		if s.namespaceFromWorkspace {
+			s.setWorkspaceNamespace(req.Params)
+		}
+		s.setClient(req.Params)
 		return jsonRPCResponse{
 			JSONRPC: "2.0",
 			ID:      req.ID,
@@ -354,19 +943,29 @@ func (s *mcpServer) handleRequest(ctx context.Context, req jsonRPCRequest) jsonR
 				ProtocolVersion: "2024-11-05",
 				Capabilities: mcpCapabilities{
 					Tools:     map[string]any{"listChanged": true},
-					Resources: map[string]any{"listChanged": false},
+					Resources: map[string]any{"subscribe": s.changes != nil, "listChanged": false},
 				},
 				ServerInfo: mcpServerInfo{
 					Name:    mcpServerName,
 					Version: mcpVersion,
 				},
-				Instructions: mieInstructions,
+				Instructions: s.instructions(),
 			},
 		}
 
 	case "notifications/initialized":
 		return jsonRPCResponse{}
 
+	case "notifications/cancelled":
+		var params struct {
+			RequestID any    `json:"requestId"`
+			Reason    string `json:"reason"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err == nil {
+			s.cancelCall(params.RequestID, params.Reason)
+		}
+		return jsonRPCResponse{}
+
 	case "tools/list":
 		return jsonRPCResponse{
 			JSONRPC: "2.0",
@@ -390,7 +989,11 @@ func (s *mcpServer) handleRequest(ctx context.Context, req jsonRPCRequest) jsonR
 			}
 		}
 
+		logger := s.log().With("request_id", requestKey(req.ID), "tool", params.Name)
+		logger.Info("tool call", toolArgsAttr(ctx, logger, params.Arguments))
+		start := time.Now()
 		result, err := s.handleToolCall(ctx, params)
+		logger.Info("tool call done", "duration", time.Since(start), "is_error", err != nil || result.IsError)
 		if err != nil {
 			return jsonRPCResponse{
 				JSONRPC: "2.0",
@@ -416,11 +1019,50 @@ func (s *mcpServer) handleRequest(ctx context.Context, req jsonRPCRequest) jsonR
 			Result: mcpResourcesListResult{
 				Resources: []mcpResource{
 					{
-						URI:         "mie://context/recent",
+						URI:         recentContextURI,
 						Name:        "Recent memory context",
 						Description: "Latest facts, decisions, and entities from the memory graph",
 						MimeType:    "text/plain",
 					},
+					{
+						URI:         changesURI,
+						Name:        "Memory change feed",
+						Description: "Latest writes to the memory graph; subscribe to be notified of new changes",
+						MimeType:    "text/plain",
+					},
+				},
+			},
+		}
+
+	case "resources/templates/list":
+		return jsonRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Result: mcpResourceTemplatesListResult{
+				ResourceTemplates: []mcpResourceTemplate{
+					{
+						URITemplate: tools.AttachmentURIPrefix + "{hash}",
+						Name:        "Attachment",
+						Description: "Content attached to a node with mie_attach, by its SHA-256 hash",
+					},
+					{
+						URITemplate: topicSummaryURIPrefix + "{name}" + topicSummaryURISuffix,
+						Name:        "Topic summary",
+						Description: "A page of what memory holds about a topic: its facts, active decisions, entities, and upcoming and past events",
+						MimeType:    "text/markdown",
+					},
+					{
+						URITemplate: entityURIPrefix + "{id}",
+						Name:        "Entity",
+						Description: "An entity by ID, with its description, valid facts, and decisions",
+						MimeType:    "text/markdown",
+					},
+					{
+						URITemplate: decisionURIPrefix + "{id}",
+						Name:        "Decision",
+						Description: "A decision by ID, with its status, rationale, alternatives, context, and entities",
+						MimeType:    "text/markdown",
+					},
 				},
 			},
 		}
@@ -439,7 +1081,23 @@ func (s *mcpServer) handleRequest(ctx context.Context, req jsonRPCRequest) jsonR
 			}
 		}
 
-		if params.URI != "mie://context/recent" {
+		if hash, ok := strings.CutPrefix(params.URI, tools.AttachmentURIPrefix); ok {
+			return s.readAttachment(ctx, req.ID, params.URI, hash)
+		}
+		if name, ok := parseTopicSummaryURI(params.URI); ok {
+			return s.readTopicSummary(ctx, req.ID, params.URI, name)
+		}
+		if nodeID, ok := parseNodeURI(params.URI); ok {
+			return s.readNode(ctx, req.ID, params.URI, nodeID)
+		}
+
+		var text string
+		switch params.URI {
+		case recentContextURI:
+			text = s.buildRecentContext(ctx)
+		case changesURI:
+			text = s.buildRecentChanges()
+		default:
 			return jsonRPCResponse{
 				JSONRPC: "2.0",
 				ID:      req.ID,
@@ -451,7 +1109,6 @@ func (s *mcpServer) handleRequest(ctx context.Context, req jsonRPCRequest) jsonR
 			}
 		}
 
-		text := s.buildRecentContext(ctx)
 		return jsonRPCResponse{
 			JSONRPC: "2.0",
 			ID:      req.ID,
@@ -466,6 +1123,50 @@ func (s *mcpServer) handleRequest(ctx context.Context, req jsonRPCRequest) jsonR
 			},
 		}
 
+	case "resources/subscribe", "resources/unsubscribe":
+		var params mcpResourceSubscribeParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return jsonRPCResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Error: &rpcError{
+					Code:    -32602,
+					Message: "Invalid params",
+					Data:    err.Error(),
+				},
+			}
+		}
+
+		if params.URI != recentContextURI && params.URI != changesURI {
+			return jsonRPCResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Error: &rpcError{
+					Code:    -32602,
+					Message: "Unknown resource",
+					Data:    params.URI,
+				},
+			}
+		}
+		if s.changes == nil {
+			return jsonRPCResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Error: &rpcError{
+					Code:    -32601,
+					Message: "Subscriptions not supported",
+					Data:    req.Method,
+				},
+			}
+		}
+
+		s.setSubscribed(params.URI, req.Method == "resources/subscribe")
+		return jsonRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Result:  map[string]any{},
+		}
+
 	default:
 		return jsonRPCResponse{
 			JSONRPC: "2.0",
@@ -488,8 +1189,67 @@ func (s *mcpServer) handleToolCall(ctx context.Context, params mcpToolCallParams
 			IsError: true,
 		}, nil
 	}
+	if s.readOnly && writeTools[params.Name] {
+		return &mcpToolResult{
+			Content: []mcpContent{{Type: "text", Text: fmt.Sprintf("Tool %s is disabled: this MIE server is read-only", params.Name)}},
+			IsError: true,
+		}, nil
+	}
+	// A call without action runs the tool's default action, which is
+	// checked like an explicit one.
+	action := tools.GetStringArg(params.Arguments, "action", tools.DefaultActions[params.Name])
+	if s.readOnly && writeActions[params.Name][action] {
+		return &mcpToolResult{
+			Content: []mcpContent{{Type: "text", Text: fmt.Sprintf("Action %s of %s is disabled: this MIE server is read-only", action, params.Name)}},
+			IsError: true,
+		}, nil
+	}
+	if !s.policy.Allows(s.clientName, params.Name, "") {
+		return &mcpToolResult{
+			Content: []mcpContent{{Type: "text", Text: fmt.Sprintf("Tool %s is not permitted for client %q (server.clients)", params.Name, s.clientName)}},
+			IsError: true,
+		}, nil
+	}
+	if action != "" && !s.policy.Allows(s.clientName, params.Name, action) {
+		return &mcpToolResult{
+			Content: []mcpContent{{Type: "text", Text: fmt.Sprintf("Action %s of %s is not permitted for client %q (server.clients)", action, params.Name, s.clientName)}},
+			IsError: true,
+		}, nil
+	}
+
+	ctx, err := tools.WithNamespaceArg(ctx, params.Arguments)
+	if err != nil {
+		return &mcpToolResult{
+			Content: []mcpContent{{Type: "text", Text: fmt.Sprintf("Invalid namespace: %v", err)}},
+			IsError: true,
+		}, nil
+	}
+
+	if err := tools.ValidateResponseFormat(params.Arguments); err != nil {
+		return &mcpToolResult{
+			Content: []mcpContent{{Type: "text", Text: fmt.Sprintf("Invalid arguments: %v", err)}},
+			IsError: true,
+		}, nil
+	}
 
-	result, err := handler(ctx, s, params.Arguments)
+	if s.strictValidation {
+		if err := tools.ValidateArgs(s.inputSchema(params.Name), params.Arguments); err != nil {
+			return &mcpToolResult{
+				Content: []mcpContent{{Type: "text", Text: fmt.Sprintf("Invalid arguments for %s: %v", params.Name, err)}},
+				IsError: true,
+			}, nil
+		}
+	}
+
+	ctx = tools.WithAuditSource(ctx, params.Name, tools.GetStringArg(params.Arguments, "source_agent", ""))
+	ctx = tools.WithSession(ctx, s.activeSession())
+	result, err := s.runHandler(ctx, handler, params.Arguments)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &mcpToolResult{
+			Content: []mcpContent{{Type: "text", Text: fmt.Sprintf("Error in %s: timed out after %s (server.request_timeout)", params.Name, s.requestTimeout)}},
+			IsError: true,
+		}, nil
+	}
 	if err != nil {
 		return &mcpToolResult{
 			Content: []mcpContent{{Type: "text", Text: fmt.Sprintf("Error in %s: %v", params.Name, err)}},
@@ -505,10 +1265,10 @@ func (s *mcpServer) handleToolCall(ctx context.Context, params mcpToolCallParams
 
 // getTools returns the list of all MIE MCP tool definitions.
 func (s *mcpServer) getTools() []mcpTool {
-	return []mcpTool{
+	toolList := []mcpTool{
 		{
 			Name:        "mie_analyze",
-			Description: "Analyze a conversation fragment for potential memory storage. Returns related existing memory and an evaluation guide for the agent to decide what to persist. Call this at the end of meaningful conversations or when noticing something worth remembering.",
+			Description: "Analyze a conversation fragment for potential memory storage. Returns related existing memory, candidate nodes extracted from the content (with proposed fields, likely duplicates, and relationships to existing entities and topics, ready to pass to mie_bulk_store), and an evaluation guide for the agent to decide what to persist. Call this at the end of meaningful conversations or when noticing something worth remembering.",
 			InputSchema: map[string]any{
 				"type": "object",
 				"properties": map[string]any{
@@ -519,7 +1279,7 @@ func (s *mcpServer) getTools() []mcpTool {
 					"content_type": map[string]any{
 						"type":        "string",
 						"enum":        []string{"conversation", "statement", "decision", "event"},
-						"description": "Type of content being analyzed. Helps focus the search.",
+						"description": "Type of content being analyzed. With decision or event, the whole content becomes one candidate of that type.",
 						"default":     "conversation",
 					},
 				},
@@ -543,7 +1303,7 @@ func (s *mcpServer) getTools() []mcpTool {
 					},
 					"category": map[string]any{
 						"type":        "string",
-						"enum":        []string{"personal", "professional", "preference", "technical", "relationship", "general"},
+						"enum":        tools.FactCategories(),
 						"description": "Fact category",
 						"default":     "general",
 					},
@@ -554,15 +1314,28 @@ func (s *mcpServer) getTools() []mcpTool {
 						"description": "Confidence level (0.0-1.0)",
 						"default":     0.8,
 					},
-					"title": map[string]any{
+					"expires_at": map[string]any{
 						"type":        "string",
-						"description": "Decision or event title (required for type=decision, type=event)",
+						"description": "For temporary facts, when the fact stops being true (RFC 3339 or YYYY-MM-DD). The fact is invalidated automatically then.",
 					},
-					"rationale": map[string]any{
+					"key": map[string]any{
 						"type":        "string",
-						"description": "Decision rationale (required for type=decision)",
+						"description": "For preference facts, the setting the fact records (e.g. 'editor'). Category defaults to preference. Storing a new value invalidates the previous value of the key",
 					},
-					"alternatives": map[string]any{
+					"negated": map[string]any{
+						"type":        "boolean",
+						"description": "Store the fact as an anti-fact: content states what is NOT true (e.g. content 'User uses Docker' with negated=true means the user does not use Docker)",
+						"default":     false,
+					},
+					"title": map[string]any{
+						"type":        "string",
+						"description": "Decision or event title (required for type=decision, type=event)",
+					},
+					"rationale": map[string]any{
+						"type":        "string",
+						"description": "Decision rationale (required for type=decision)",
+					},
+					"alternatives": map[string]any{
 						"type":        "string",
 						"description": "JSON array of alternatives considered (for decisions)",
 					},
@@ -570,13 +1343,18 @@ func (s *mcpServer) getTools() []mcpTool {
 						"type":        "string",
 						"description": "Decision context",
 					},
+					"status": map[string]any{
+						"type":        "string",
+						"enum":        []string{"active", "proposed"},
+						"description": "Initial decision status (default: active). Use proposed for a decision not yet agreed on",
+					},
 					"name": map[string]any{
 						"type":        "string",
 						"description": "Entity or topic name (required for type=entity, type=topic)",
 					},
 					"kind": map[string]any{
 						"type":        "string",
-						"enum":        []string{"person", "company", "project", "product", "technology", "place", "other"},
+						"enum":        tools.EntityKinds(),
 						"description": "Entity kind (required for type=entity)",
 					},
 					"description": map[string]any{
@@ -585,7 +1363,11 @@ func (s *mcpServer) getTools() []mcpTool {
 					},
 					"event_date": map[string]any{
 						"type":        "string",
-						"description": "Event date in ISO format (e.g., 2026-02-05). Required for type=event.",
+						"description": "Event date, e.g. 2026-02-05. A month (2025-06, June 2025), quarter (2025-Q3), or year (2025) covers that whole period. Required for type=event.",
+					},
+					"end_date": map[string]any{
+						"type":        "string",
+						"description": "Last day of an event that spans several days, in the same forms as event_date. Optional, for type=event.",
 					},
 					"source_agent": map[string]any{
 						"type":        "string",
@@ -594,7 +1376,7 @@ func (s *mcpServer) getTools() []mcpTool {
 					},
 					"source_conversation": map[string]any{
 						"type":        "string",
-						"description": "Conversation reference or identifier",
+						"description": "Conversation reference or identifier. Defaults to the session opened with mie_session",
 					},
 					"relationships": map[string]any{
 						"type": "array",
@@ -614,6 +1396,12 @@ func (s *mcpServer) getTools() []mcpTool {
 									"type":        "string",
 									"description": "Role description (for decision_entity edges)",
 								},
+								"weight": map[string]any{
+									"type":             "number",
+									"exclusiveMinimum": 0,
+									"maximum":          1,
+									"description":      "Strength of the relationship, greater than 0 and at most 1 (default 1). Stronger edges rank first in graph traversals",
+								},
 							},
 							"required": []string{"edge", "target_id"},
 						},
@@ -649,7 +1437,7 @@ func (s *mcpServer) getTools() []mcpTool {
 								},
 								"category": map[string]any{
 									"type":        "string",
-									"enum":        []string{"personal", "professional", "preference", "technical", "relationship", "general"},
+									"enum":        tools.FactCategories(),
 									"description": "Fact category",
 									"default":     "general",
 								},
@@ -660,6 +1448,18 @@ func (s *mcpServer) getTools() []mcpTool {
 									"description": "Confidence level (0.0-1.0)",
 									"default":     0.8,
 								},
+								"expires_at": map[string]any{
+									"type":        "string",
+									"description": "For temporary facts, when the fact stops being true (RFC 3339 or YYYY-MM-DD)",
+								},
+								"key": map[string]any{
+									"type":        "string",
+									"description": "For preference facts, the setting the fact records; replaces its previous value",
+								},
+								"negated": map[string]any{
+									"type":        "boolean",
+									"description": "Store the fact as an anti-fact: content states what is NOT true",
+								},
 								"title": map[string]any{
 									"type":        "string",
 									"description": "Decision or event title (required for type=decision, type=event)",
@@ -676,13 +1476,18 @@ func (s *mcpServer) getTools() []mcpTool {
 									"type":        "string",
 									"description": "Decision context",
 								},
+								"status": map[string]any{
+									"type":        "string",
+									"enum":        []string{"active", "proposed"},
+									"description": "Initial decision status (default: active)",
+								},
 								"name": map[string]any{
 									"type":        "string",
 									"description": "Entity or topic name (required for type=entity, type=topic)",
 								},
 								"kind": map[string]any{
 									"type":        "string",
-									"enum":        []string{"person", "company", "project", "product", "technology", "place", "other"},
+									"enum":        tools.EntityKinds(),
 									"description": "Entity kind (required for type=entity)",
 								},
 								"description": map[string]any{
@@ -691,7 +1496,11 @@ func (s *mcpServer) getTools() []mcpTool {
 								},
 								"event_date": map[string]any{
 									"type":        "string",
-									"description": "Event date in ISO format (e.g., 2026-02-05). Required for type=event.",
+									"description": "Event date, e.g. 2026-02-05. A month (2025-06, June 2025), quarter (2025-Q3), or year (2025) covers that whole period. Required for type=event.",
+								},
+								"end_date": map[string]any{
+									"type":        "string",
+									"description": "Last day of an event that spans several days, in the same forms as event_date. Optional, for type=event.",
 								},
 								"source_agent": map[string]any{
 									"type":        "string",
@@ -717,13 +1526,20 @@ func (s *mcpServer) getTools() []mcpTool {
 												"description": "Target node ID (use target_ref for cross-batch references)",
 											},
 											"target_ref": map[string]any{
-												"type":        "number",
+												"type":        "integer",
+												"minimum":     0,
 												"description": "0-based index of another item in this batch to link to (alternative to target_id)",
 											},
 											"role": map[string]any{
 												"type":        "string",
 												"description": "Role description (for decision_entity edges)",
 											},
+											"weight": map[string]any{
+												"type":             "number",
+												"exclusiveMinimum": 0,
+												"maximum":          1,
+												"description":      "Strength of the relationship, greater than 0 and at most 1 (default 1)",
+											},
 										},
 										"required": []string{"edge"},
 									},
@@ -738,65 +1554,95 @@ func (s *mcpServer) getTools() []mcpTool {
 						},
 						"description": "Array of memory nodes to store (max 50)",
 					},
+					"atomic": map[string]any{
+						"type":        "boolean",
+						"description": "Store all items in one transaction, or nothing if any item or relationship fails",
+						"default":     false,
+					},
 				},
 				"required": []string{"items"},
 			},
 		},
 		{
 			Name:        "mie_query",
-			Description: "Search the memory graph. Supports three modes: 'semantic' (natural language similarity search), 'exact' (substring match), and 'graph' (traverse relationships from a node).",
+			Description: "Search the memory graph. Supports five modes: 'semantic' (natural language similarity search), 'exact' (substring match ignoring case and accents), 'fulltext' (ranked keyword search with stemming, no embeddings needed), 'hybrid' (semantic and exact combined with reciprocal-rank fusion), and 'graph' (traverse relationships from a node). Action 'save' stores the search under a name; 'run' repeats a saved search, 'list' shows them, and 'delete' removes one.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": savedSearchProperties(queryToolProperties()),
+				"required": []string{},
+			},
+		},
+		{
+			Name:        "mie_bulk_query",
+			Description: "Run up to 10 mie_query searches concurrently and return their results grouped in one response. Each query takes the same arguments as mie_query, including its own mode and filters. Prefer this over several mie_query calls when you need multiple lookups before answering.",
 			InputSchema: map[string]any{
 				"type": "object",
 				"properties": map[string]any{
-					"query": map[string]any{
-						"type":        "string",
-						"description": "Search query. Natural language for semantic mode, exact text for exact mode, or node ID for graph mode.",
+					"queries": map[string]any{
+						"type":     "array",
+						"minItems": 1,
+						"maxItems": 10,
+						"items": map[string]any{
+							"type":       "object",
+							"properties": queryToolProperties(),
+							"required":   []string{"query"},
+						},
+						"description": "Queries to run, each with the same arguments as mie_query",
 					},
-					"mode": map[string]any{
+				},
+				"required": []string{"queries"},
+			},
+		},
+		{
+			Name:        "mie_context",
+			Description: "Build a compact markdown briefing about a topic, entity, or free-text focus, ready to paste into a system prompt. Combines search hits with facts and decisions linked to the matching entities, keeping the most relevant items that fit within max_tokens.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"focus": map[string]any{
 						"type":        "string",
-						"enum":        []string{"semantic", "exact", "graph"},
-						"description": "Search mode",
-						"default":     "semantic",
-					},
-					"node_types": map[string]any{
-						"type":        "array",
-						"items":       map[string]any{"type": "string", "enum": []string{"fact", "decision", "entity", "event"}},
-						"description": "Node types to search (default: all)",
-					},
-					"limit": map[string]any{
-						"type":    "number",
-						"minimum": 1,
-						"maximum": 50,
-						"default": 10,
+						"description": "Topic, entity name, or free-text description to brief on",
 					},
-					"category": map[string]any{
-						"type":        "string",
-						"description": "Filter facts by category",
+					"max_tokens": map[string]any{
+						"type":        "integer",
+						"default":     1500,
+						"minimum":     100,
+						"maximum":     8000,
+						"description": "Approximate token budget for the briefing",
 					},
-					"kind": map[string]any{
+				},
+				"required": []string{"focus"},
+			},
+		},
+		{
+			Name:        "mie_ask",
+			Description: "Gather the evidence for answering a question from memory. Runs hybrid search on the question, adds the nodes linked to the top hits, and returns the most relevant facts, decisions, entities, and events, each labeled with its ID, relevance, and confidence. Answer from this evidence and cite the IDs, e.g. [fact:abc123]; if it does not answer the question, say so.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"question": map[string]any{
 						"type":        "string",
-						"description": "Filter entities by kind",
-					},
-					"valid_only": map[string]any{
-						"type":    "boolean",
-						"default": true,
+						"description": "The question to answer, in natural language",
 					},
-					"node_id": map[string]any{
-						"type":        "string",
-						"description": "Node ID for graph traversal mode",
+					"limit": map[string]any{
+						"type":        "integer",
+						"default":     10,
+						"minimum":     1,
+						"maximum":     50,
+						"description": "Maximum pieces of evidence to return",
 					},
-					"traversal": map[string]any{
-						"type":        "string",
-						"enum":        []string{"related_entities", "related_facts", "invalidation_chain", "decision_entities", "facts_about_entity", "entity_decisions"},
-						"description": "Traversal type for graph mode",
+					"expand": map[string]any{
+						"type":        "boolean",
+						"default":     true,
+						"description": "Also return the nodes linked to the top search hits",
 					},
 				},
-				"required": []string{"query"},
+				"required": []string{"question"},
 			},
 		},
 		{
 			Name:        "mie_update",
-			Description: "Update or invalidate existing memory nodes. For facts, invalidation creates a chain (old fact marked invalid, linked to new). For entities, update description. For decisions, change status.",
+			Description: "Update or invalidate existing memory nodes. For facts, invalidation creates a chain (old fact marked invalid, linked to new). For entities, update description, add an alias (alternative name), or rename (the old name becomes an alias). For decisions, change status. Any node can be archived to hide it from search and list results without deleting it. A fact a person has confirmed can be marked verified so it outranks unverified facts in search.",
 			InputSchema: map[string]any{
 				"type": "object",
 				"properties": map[string]any{
@@ -806,12 +1652,12 @@ func (s *mcpServer) getTools() []mcpTool {
 					},
 					"action": map[string]any{
 						"type":        "string",
-						"enum":        []string{"invalidate", "update_description", "update_status"},
-						"description": "Action: invalidate a fact, update an entity description, or change a decision status",
+						"enum":        []string{"invalidate", "update_description", "update_status", "alias", "rename", "archive", "unarchive", "verify", "unverify"},
+						"description": "Action: invalidate a fact, update an entity description, change a decision status, add an alias to an entity, rename an entity, archive/unarchive any node, or verify/unverify a fact",
 					},
 					"reason": map[string]any{
 						"type":        "string",
-						"description": "Why this change is being made (required for invalidation)",
+						"description": "Why this change is being made (required for invalidation). Recorded in the node's history for invalidations, description updates, renames, and status changes",
 					},
 					"replacement_id": map[string]any{
 						"type":        "string",
@@ -819,12 +1665,78 @@ func (s *mcpServer) getTools() []mcpTool {
 					},
 					"new_value": map[string]any{
 						"type":        "string",
-						"description": "New value for update_description or update_status actions",
+						"description": "New value for update_description or update_status actions, the alternative name for the alias action, or the new name for the rename action. Decision statuses change proposed -> active or reversed, and active -> superseded or reversed",
+					},
+					"verified_by": map[string]any{
+						"type":        "string",
+						"description": "Who confirmed the fact (required for verify). Only verify facts the user has explicitly confirmed.",
 					},
 				},
 				"required": []string{"node_id", "action"},
 			},
 		},
+		{
+			Name:        "mie_relate",
+			Description: "Create or delete a relationship edge between two existing memory nodes. The edge type must match the node ID prefixes (e.g. fact_entity links a fact: ID to an ent: ID).",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"action": map[string]any{
+						"type":        "string",
+						"enum":        []string{"create", "delete"},
+						"description": "Whether to create or delete the edge",
+						"default":     "create",
+					},
+					"edge": map[string]any{
+						"type":        "string",
+						"enum":        []string{"fact_entity", "fact_topic", "decision_topic", "decision_entity", "event_decision", "entity_topic"},
+						"description": "Relationship type, named source_target",
+					},
+					"source_id": map[string]any{
+						"type":        "string",
+						"description": "ID of the source node (first part of the edge name)",
+					},
+					"target_id": map[string]any{
+						"type":        "string",
+						"description": "ID of the target node (second part of the edge name)",
+					},
+					"role": map[string]any{
+						"type":        "string",
+						"description": "Role of the entity in a decision (only for decision_entity)",
+					},
+					"weight": map[string]any{
+						"type":             "number",
+						"exclusiveMinimum": 0,
+						"maximum":          1,
+						"description":      "Strength of the relationship, greater than 0 and at most 1 (default 1). Stronger edges rank first in graph traversals",
+					},
+					"source_agent": map[string]any{
+						"type":        "string",
+						"description": "Agent identifier (e.g., 'claude', 'cursor')",
+						"default":     "unknown",
+					},
+				},
+				"required": []string{"edge", "source_id", "target_id"},
+			},
+		},
+		{
+			Name:        "mie_merge",
+			Description: "Merge a duplicate entity into another entity. All fact, decision, and topic relationships move to the surviving entity, the duplicate's name becomes an alias, and the duplicate is deleted.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"survivor_id": map[string]any{
+						"type":        "string",
+						"description": "ID of the entity to keep (prefix 'ent:')",
+					},
+					"duplicate_id": map[string]any{
+						"type":        "string",
+						"description": "ID of the duplicate entity to fold into the survivor and delete (prefix 'ent:')",
+					},
+				},
+				"required": []string{"survivor_id", "duplicate_id"},
+			},
+		},
 		{
 			Name:        "mie_list",
 			Description: "List memory nodes with filtering, pagination, and sorting. Returns a formatted table of results.",
@@ -846,7 +1758,7 @@ func (s *mcpServer) getTools() []mcpTool {
 					},
 					"status": map[string]any{
 						"type":        "string",
-						"description": "Filter decisions by status (active, superseded, reversed)",
+						"description": "Filter decisions by status (proposed, active, superseded, reversed)",
 					},
 					"topic": map[string]any{
 						"type":        "string",
@@ -867,6 +1779,10 @@ func (s *mcpServer) getTools() []mcpTool {
 						"minimum": 0,
 						"default": 0,
 					},
+					"cursor": map[string]any{
+						"type":        "string",
+						"description": "next_cursor from the previous page, to continue after it. Stable when nodes are added or removed between pages. Cannot be combined with offset.",
+					},
 					"sort_by": map[string]any{
 						"type":        "string",
 						"description": "Sort field (created_at, updated_at, name)",
@@ -877,13 +1793,43 @@ func (s *mcpServer) getTools() []mcpTool {
 						"enum":        []string{"asc", "desc"},
 						"default":     "desc",
 					},
+					"created_after": map[string]any{
+						"type":        "string",
+						"description": "Only include nodes created at or after this time (RFC 3339 or YYYY-MM-DD)",
+					},
+					"created_before": map[string]any{
+						"type":        "string",
+						"description": "Only include nodes created before this time (RFC 3339 or YYYY-MM-DD)",
+					},
+					"event_date_range": map[string]any{
+						"type":        "string",
+						"description": "Only include events that overlap this inclusive range, as FROM..TO (YYYY-MM-DD, YYYY-MM, YYYY-Qn, or YYYY; either side optional). Requires node_type=event.",
+					},
+					"include_archived": map[string]any{
+						"type":        "boolean",
+						"description": "Also list nodes hidden with mie_update action=archive",
+						"default":     false,
+					},
+					"source_agent": map[string]any{
+						"type":        "string",
+						"description": "Only list nodes written by this agent (e.g. 'claude', 'cursor'). Not valid for node_type=topic",
+					},
+					"source_conversation": map[string]any{
+						"type":        "string",
+						"description": "Only list nodes stored in this conversation or mie_session session (e.g. 'ses:abc123'). Valid for facts, decisions, and events",
+					},
+					"include_degree": map[string]any{
+						"type":        "boolean",
+						"description": "Add the number of relationships of each node, to tell richly connected nodes from orphaned ones",
+						"default":     false,
+					},
 				},
 				"required": []string{"node_type"},
 			},
 		},
 		{
 			Name:        "mie_conflicts",
-			Description: "Detect potentially contradicting facts in the memory graph. Returns pairs of facts that are semantically similar but may contain conflicting information. Use this to maintain memory consistency.",
+			Description: "Detect potentially contradicting facts in the memory graph. Returns pairs of facts that are semantically similar but may contain conflicting information, with the words that differ between them and the kind of difference (numeric mismatch, temporal supersession, negation). Use this to maintain memory consistency.",
 			InputSchema: map[string]any{
 				"type": "object",
 				"properties": map[string]any{
@@ -908,6 +1854,99 @@ func (s *mcpServer) getTools() []mcpTool {
 				"required": []string{},
 			},
 		},
+		{
+			Name:        "mie_similar",
+			Description: "Find the nodes most similar to an existing node, by its stored embedding, without writing a query. Results span node types unless node_types narrows them and are ordered by similarity; those at 95% or more are flagged as likely duplicates. Use this to spot duplicates before storing or to discover related memories.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"node_id": map[string]any{
+						"type":        "string",
+						"description": "ID of the node to compare against (fact:, dec:, ent:, or evt:). Topics have no embeddings",
+					},
+					"node_types": map[string]any{
+						"type":        "array",
+						"items":       map[string]any{"type": "string", "enum": []string{"fact", "decision", "entity", "event"}},
+						"description": "Node types to return (default: all)",
+					},
+					"limit": map[string]any{
+						"type":    "number",
+						"minimum": 1,
+						"maximum": 50,
+						"default": 10,
+					},
+					"min_similarity": map[string]any{
+						"type":        "number",
+						"minimum":     0,
+						"maximum":     1,
+						"description": "Drop nodes less similar than this (0-1, e.g. 0.8). Overrides the server's memory.min_similarity",
+					},
+					"include_archived": map[string]any{
+						"type":        "boolean",
+						"description": "Also return nodes hidden with mie_update action=archive",
+						"default":     false,
+					},
+					"source_agent": map[string]any{
+						"type":        "string",
+						"description": "Only return nodes written by this agent (e.g. 'claude', 'cursor')",
+					},
+				},
+				"required": []string{"node_id"},
+			},
+		},
+		{
+			Name:        "mie_dedupe",
+			Description: "Report entities and topics that likely name the same thing, clustered by the similarity of their names, aliases, and embeddings. Each cluster lists a suggested survivor (the member with the most relationships) and the mie_merge calls that would fold the others into it. Nothing is changed; review each cluster before merging.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"node_types": map[string]any{
+						"type":        "array",
+						"items":       map[string]any{"type": "string", "enum": []string{"entity", "topic"}},
+						"description": "Node types to compare (default: both). Entities and topics are never paired with each other",
+					},
+					"threshold": map[string]any{
+						"type":        "number",
+						"minimum":     0,
+						"maximum":     1,
+						"description": "Score from which two nodes are reported as duplicates (0-1). Names alone score 0.8 for 'Postgres' and 'PostgreSQL'",
+						"default":     0.8,
+					},
+					"limit": map[string]any{
+						"type":        "number",
+						"minimum":     1,
+						"maximum":     50,
+						"description": "Maximum number of clusters, best first",
+						"default":     10,
+					},
+					"include_archived": map[string]any{
+						"type":        "boolean",
+						"description": "Also compare nodes hidden with mie_update action=archive",
+						"default":     false,
+					},
+				},
+				"required": []string{},
+			},
+		},
+		{
+			Name:        "mie_preferences",
+			Description: "List the current value of each preference key: the newest valid preference fact stored with that key. Use it to check a user's settings (editor, language, tone) before acting on them.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"key": map[string]any{
+						"type":        "string",
+						"description": "Only show this key (case-insensitive)",
+					},
+					"include_archived": map[string]any{
+						"type":        "boolean",
+						"description": "Also list preferences hidden with mie_update action=archive",
+						"default":     false,
+					},
+				},
+				"required": []string{},
+			},
+		},
 		{
 			Name:        "mie_export",
 			Description: "Export the complete memory graph for backup or migration. Returns all nodes and relationships in structured format.",
@@ -916,8 +1955,8 @@ func (s *mcpServer) getTools() []mcpTool {
 				"properties": map[string]any{
 					"format": map[string]any{
 						"type":        "string",
-						"enum":        []string{"json", "datalog"},
-						"description": "Export format",
+						"enum":        []string{"json", "datalog", "cypher"},
+						"description": "Export format. cypher produces CREATE statements for loading into Neo4j or Memgraph",
 						"default":     "json",
 					},
 					"include_embeddings": map[string]any{
@@ -930,6 +1969,10 @@ func (s *mcpServer) getTools() []mcpTool {
 						"items":       map[string]any{"type": "string", "enum": []string{"fact", "decision", "entity", "event", "topic"}},
 						"description": "Types to export (default: all)",
 					},
+					"since": map[string]any{
+						"type":        "string",
+						"description": "Only export what was created or changed at or after this time (RFC 3339 or YYYY-MM-DD), for incremental backups",
+					},
 				},
 				"required": []string{},
 			},
@@ -943,6 +1986,468 @@ func (s *mcpServer) getTools() []mcpTool {
 				"required":   []string{},
 			},
 		},
+		{
+			Name:        "mie_stats_by_topic",
+			Description: "Break memory down per topic: valid facts, decisions, events (through linked decisions), and entities linked to each topic, plus its last activity. Use this to see which project areas have rich memory coverage and which are sparse.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"sort_by": map[string]any{
+						"type":        "string",
+						"enum":        []string{"coverage", "activity", "name"},
+						"description": "Order topics by number of linked nodes, most recent activity, or name",
+						"default":     "coverage",
+					},
+					"limit": map[string]any{
+						"type":        "integer",
+						"description": "Maximum topics to show",
+						"default":     25,
+						"minimum":     1,
+						"maximum":     100,
+					},
+					"include_archived": map[string]any{
+						"type":        "boolean",
+						"description": "Include archived topics and count archived nodes",
+						"default":     false,
+					},
+				},
+				"required": []string{},
+			},
+		},
+		{
+			Name:        "mie_topics",
+			Description: "List the existing topics with the number of nodes linked to each, or pass text to get the existing topics that best fit it (embedding similarity over topic names and descriptions). Call this before storing a new topic, and reuse a suggested topic instead of inventing a near-duplicate name.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"text": map[string]any{
+						"type":        "string",
+						"description": "Content to suggest topics for, such as a fact or decision about to be stored. Omit to list all topics",
+					},
+					"limit": map[string]any{
+						"type":        "integer",
+						"description": "Maximum topics to list or suggest",
+						"default":     25,
+						"minimum":     1,
+						"maximum":     100,
+					},
+					"min_score": map[string]any{
+						"type":        "number",
+						"description": "Minimum score (0-1) for a topic to be suggested for text",
+						"default":     0.5,
+						"minimum":     0,
+						"maximum":     1,
+					},
+					"include_archived": map[string]any{
+						"type":        "boolean",
+						"description": "Include archived topics",
+						"default":     false,
+					},
+				},
+				"required": []string{},
+			},
+		},
+		{
+			Name:        "mie_embedding_status",
+			Description: "Report embedding coverage per node type (how many facts, decisions, entities, and events lack vectors), vector index health, configured versus stored embedding dimensions, and the last embedding error. Use this when semantic search returns nothing or misses nodes you know are stored.",
+			InputSchema: map[string]any{
+				"type":       "object",
+				"properties": map[string]any{},
+				"required":   []string{},
+			},
+		},
+		{
+			Name:        "mie_visualize",
+			Description: "Render the neighborhood of a node or topic as a Mermaid flowchart. Returns a ```mermaid block that chat UIs supporting Mermaid can draw as a memory map. Pass node_id or topic.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"node_id": map[string]any{
+						"type":        "string",
+						"description": "ID of the node to center the map on (e.g. 'ent:abc123')",
+					},
+					"topic": map[string]any{
+						"type":        "string",
+						"description": "Name of the topic to center the map on, instead of node_id",
+					},
+					"depth": map[string]any{
+						"type":        "integer",
+						"description": "Number of hops to walk from the center node",
+						"default":     1,
+						"minimum":     1,
+						"maximum":     3,
+					},
+					"max_nodes": map[string]any{
+						"type":        "integer",
+						"description": "Maximum nodes to draw",
+						"default":     30,
+						"minimum":     2,
+						"maximum":     100,
+					},
+					"include_archived": map[string]any{
+						"type":        "boolean",
+						"description": "Include archived nodes",
+						"default":     false,
+					},
+				},
+				"required": []string{},
+			},
+		},
+		{
+			Name:        "mie_audit",
+			Description: "Review the audit log of writes to the memory graph, newest first: every store, update, invalidation, relationship change, merge, and import, with its time, tool, source agent, and affected node IDs. Use this to check what you or other agents changed.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"limit": map[string]any{
+						"type":        "integer",
+						"description": "Maximum entries to return",
+						"default":     50,
+						"minimum":     1,
+						"maximum":     500,
+					},
+					"node_id": map[string]any{
+						"type":        "string",
+						"description": "Only show writes that affected this node",
+					},
+					"source_agent": map[string]any{
+						"type":        "string",
+						"description": "Only show writes made by this agent",
+					},
+					"tool": map[string]any{
+						"type":        "string",
+						"description": "Only show writes made through this tool (e.g. 'mie_update')",
+					},
+				},
+				"required": []string{},
+			},
+		},
+		{
+			Name:        "mie_history",
+			Description: "Show the change timeline of one node, oldest first: when it was stored, then every description change, decision status change, and fact invalidation (including expiry), with the old and new values, the reason given, and the tool and agent that made it. Use this to answer questions like 'when did this decision change status and why'.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"node_id": map[string]any{
+						"type":        "string",
+						"description": "ID of the node (e.g. 'dec:abc123')",
+					},
+					"field": map[string]any{
+						"type":        "string",
+						"enum":        []string{"description", "status", "valid"},
+						"description": "Only show changes to this field, e.g. status for a decision's status transitions",
+					},
+				},
+				"required": []string{"node_id"},
+			},
+		},
+		{
+			Name:        "mie_suggest_relationships",
+			Description: "Suggest edges a node is not linked by yet: entities and topics its text names, nodes whose text names it, and (with embeddings) semantically similar nodes, each with a confidence score. Read-only; confirm the suggestions you agree with by passing their edge, source_id, and target_id to mie_relate.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"node_id": map[string]any{
+						"type":        "string",
+						"description": "ID of the node to suggest relationships for (e.g. 'fact:abc123')",
+					},
+					"limit": map[string]any{
+						"type":        "integer",
+						"description": "Maximum suggestions to return",
+						"default":     10,
+						"minimum":     1,
+						"maximum":     50,
+					},
+					"min_confidence": map[string]any{
+						"type":        "number",
+						"description": "Only suggest edges with at least this confidence (0.0-1.0)",
+						"default":     0.5,
+						"minimum":     0,
+						"maximum":     1,
+					},
+				},
+				"required": []string{"node_id"},
+			},
+		},
+		{
+			Name:        "mie_snapshot",
+			Description: "Checkpoint and roll back the memory graph. 'create' records a labeled snapshot of every node and relationship; 'list' shows the snapshots; 'restore' replaces the graph with a snapshot, saving the current graph as a new snapshot first; 'delete' removes a snapshot. Create one before large bulk stores or imports.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"action": map[string]any{
+						"type":        "string",
+						"enum":        []string{"create", "list", "restore", "delete"},
+						"description": "Operation to perform",
+						"default":     "list",
+					},
+					"label": map[string]any{
+						"type":        "string",
+						"description": "Label of the new snapshot, for 'create' (e.g. 'before import')",
+					},
+					"snapshot_id": map[string]any{
+						"type":        "string",
+						"description": "ID of the snapshot, for 'restore' and 'delete' (e.g. 'snap:abc123')",
+					},
+				},
+				"required": []string{},
+			},
+		},
+		{
+			Name:        "mie_session",
+			Description: "Group the memories of one conversation. 'open' starts a session: facts, decisions, and events stored afterwards without a source_conversation record the session ID as theirs. 'close' ends it with an optional summary; 'list' shows past sessions; 'show' returns everything stored in a session.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"action": map[string]any{
+						"type":        "string",
+						"enum":        []string{"open", "close", "list", "show"},
+						"description": "Operation to perform. Opening a session closes the one that is open.",
+					},
+					"title": map[string]any{
+						"type":        "string",
+						"description": "What the conversation is about, for 'open'",
+					},
+					"source_agent": map[string]any{
+						"type":        "string",
+						"description": "Agent holding the conversation, for 'open' (e.g. 'claude', 'cursor')",
+					},
+					"session_id": map[string]any{
+						"type":        "string",
+						"description": "Session to close or show (e.g. 'ses:abc123'). Defaults to the open session.",
+					},
+					"summary": map[string]any{
+						"type":        "string",
+						"description": "What was learned in the conversation, for 'close'",
+					},
+					"limit": map[string]any{
+						"type":        "integer",
+						"description": "Maximum facts, decisions, and events each to return, for 'show'",
+						"default":     50,
+						"minimum":     1,
+						"maximum":     100,
+					},
+				},
+				"required": []string{"action"},
+			},
+		},
+		{
+			Name:        "mie_attach",
+			Description: "Attach small artifacts, such as a diagram or a config snippet, to a node. 'add' stores the content under its SHA-256 hash and links it to the node; 'list' shows the node's attachments; 'remove' unlinks one. Read an attachment's content through the mie://attachment/<hash> resource. At most 1 MiB each.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"action": map[string]any{
+						"type":        "string",
+						"enum":        []string{"add", "list", "remove"},
+						"description": "Operation to perform",
+						"default":     "add",
+					},
+					"node_id": map[string]any{
+						"type":        "string",
+						"description": "Node the attachment belongs to (e.g. 'dec:abc123')",
+					},
+					"name": map[string]any{
+						"type":        "string",
+						"description": "File name of the attachment, for 'add' (e.g. 'queue-flow.png'). Its extension sets the MIME type when mime_type is not given.",
+					},
+					"content": map[string]any{
+						"type":        "string",
+						"description": "Content to attach, for 'add'",
+					},
+					"encoding": map[string]any{
+						"type":        "string",
+						"enum":        []string{"text", "base64"},
+						"description": "How content is encoded, for 'add'. Use base64 for binary files.",
+						"default":     "text",
+					},
+					"mime_type": map[string]any{
+						"type":        "string",
+						"description": "MIME type of the content, for 'add' (e.g. 'image/png'). Guessed from the name or content when omitted.",
+					},
+					"hash": map[string]any{
+						"type":        "string",
+						"description": "Hash or mie://attachment/ URI of the attachment to remove, for 'remove'",
+					},
+				},
+				"required": []string{"node_id"},
+			},
+		},
+		{
+			Name:        "mie_review",
+			Description: "Assemble a memory review digest: valid facts stored in the last days, potential conflicts, active decisions missing a rationale or links, and entities without a description. Opens with a maintenance checklist to work through with the user, e.g. in a weekly review.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"days": map[string]any{
+						"type":        "integer",
+						"description": "Review facts stored in this many past days",
+						"default":     7,
+						"minimum":     1,
+						"maximum":     365,
+					},
+					"limit": map[string]any{
+						"type":        "integer",
+						"description": "Maximum items to show per section",
+						"default":     10,
+						"minimum":     1,
+						"maximum":     50,
+					},
+				},
+				"required": []string{},
+			},
+		},
+	}
+
+	for _, t := range toolList {
+		addNamespaceProperty(t.InputSchema)
+		addResponseFormatProperty(t.InputSchema)
+	}
+	if s.readOnly {
+		toolList = slices.DeleteFunc(toolList, func(t mcpTool) bool {
+			return writeTools[t.Name]
+		})
+	}
+	// Tools the client may not call at all are hidden; ones with denied
+	// actions stay listed and reject those actions.
+	toolList = slices.DeleteFunc(toolList, func(t mcpTool) bool {
+		return !s.policy.Allows(s.clientName, t.Name, "")
+	})
+	return toolList
+}
+
+// inputSchema returns the input schema of the named tool, or nil if the
+// tool is not listed.
+func (s *mcpServer) inputSchema(name string) map[string]any {
+	for _, t := range s.getTools() {
+		if t.Name == name {
+			return t.InputSchema
+		}
+	}
+	return nil
+}
+
+// queryToolProperties returns the argument schema shared by mie_query and
+// each entry of mie_bulk_query.
+func queryToolProperties() map[string]any {
+	return map[string]any{
+		"query": map[string]any{
+			"type":        "string",
+			"description": "Search query. Natural language for semantic or hybrid mode, keywords for fulltext mode, exact text for exact mode, or node ID for graph mode.",
+		},
+		"mode": map[string]any{
+			"type":        "string",
+			"enum":        []string{"semantic", "exact", "fulltext", "hybrid", "graph"},
+			"description": "Search mode",
+			"default":     "semantic",
+		},
+		"node_types": map[string]any{
+			"type":        "array",
+			"items":       map[string]any{"type": "string", "enum": []string{"fact", "decision", "entity", "event"}},
+			"description": "Node types to search (default: all)",
+		},
+		"limit": map[string]any{
+			"type":    "number",
+			"minimum": 1,
+			"maximum": 50,
+			"default": 10,
+		},
+		"category": map[string]any{
+			"type":        "string",
+			"description": "Filter facts by category",
+		},
+		"kind": map[string]any{
+			"type":        "string",
+			"description": "Filter entities by kind",
+		},
+		"valid_only": map[string]any{
+			"type":    "boolean",
+			"default": true,
+		},
+		"created_after": map[string]any{
+			"type":        "string",
+			"description": "Only include nodes created at or after this time (RFC 3339 or YYYY-MM-DD)",
+		},
+		"created_before": map[string]any{
+			"type":        "string",
+			"description": "Only include nodes created before this time (RFC 3339 or YYYY-MM-DD)",
+		},
+		"event_date_range": map[string]any{
+			"type":        "string",
+			"description": "Only include events that overlap this inclusive range, as FROM..TO (YYYY-MM-DD, YYYY-MM, YYYY-Qn, or YYYY; either side optional). Limits results to events. Ignored in graph mode.",
+		},
+		"include_archived": map[string]any{
+			"type":        "boolean",
+			"description": "Also return nodes hidden with mie_update action=archive",
+			"default":     false,
+		},
+		"source_agent": map[string]any{
+			"type":        "string",
+			"description": "Only return nodes written by this agent (e.g. 'claude', 'cursor'). Topics are skipped. Ignored in graph mode",
+		},
+		"min_similarity": map[string]any{
+			"type":        "number",
+			"minimum":     0,
+			"maximum":     1,
+			"description": "Drop semantic results less similar to the query than this (0-1, e.g. 0.6). In hybrid mode it applies to the semantic results before fusion. Overrides the server's memory.min_similarity",
+		},
+		"node_id": map[string]any{
+			"type":        "string",
+			"description": "Node ID for graph traversal mode. For traversals that start from an entity, 'name:<entity name>' finds the entity by name or alias, ignoring case and tolerating typos",
+		},
+		"traversal": map[string]any{
+			"type":        "string",
+			"enum":        []string{"related_entities", "related_facts", "invalidation_chain", "decision_entities", "facts_about_entity", "entity_decisions"},
+			"description": "Traversal type for graph mode",
+		},
+	}
+}
+
+// savedSearchProperties adds the mie_query arguments that manage saved
+// searches to props.
+func savedSearchProperties(props map[string]any) map[string]any {
+	props["query"].(map[string]any)["description"] = "Search query. Natural language for semantic or hybrid mode, keywords for fulltext mode, exact text for exact mode, or node ID for graph mode. Required to search or save."
+	props["action"] = map[string]any{
+		"type":        "string",
+		"enum":        []string{"search", "save", "list", "run", "delete"},
+		"description": "'search' runs the query; 'save' stores its arguments under name; 'run' repeats the saved search name, with any other arguments given replacing the saved ones; 'list' shows saved searches; 'delete' removes one",
+		"default":     "search",
+	}
+	props["name"] = map[string]any{
+		"type":        "string",
+		"description": "Name of the saved search, for 'save', 'run', and 'delete'",
+	}
+	return props
+}
+
+// addNamespaceProperty adds the optional "namespace" argument that every tool accepts.
+func addNamespaceProperty(schema map[string]any) {
+	props, ok := schema["properties"].(map[string]any)
+	if !ok {
+		return
+	}
+	props["namespace"] = map[string]any{
+		"type":        "string",
+		"description": "Memory namespace to operate on (e.g. a project name). Defaults to the server's configured namespace.",
+	}
+	props["project"] = map[string]any{
+		"type":        "string",
+		"description": "Project name to scope the call to, as an alternative to namespace. Lowercased, with other characters than letters, digits, '-', '_' and '.' replaced by '-'.",
+	}
+}
+
+// addResponseFormatProperty adds the optional "response_format" argument that
+// every tool accepts.
+func addResponseFormatProperty(schema map[string]any) {
+	props, ok := schema["properties"].(map[string]any)
+	if !ok {
+		return
+	}
+	props["response_format"] = map[string]any{
+		"type":        "string",
+		"enum":        []string{tools.FormatMarkdown, tools.FormatJSON},
+		"description": "Output format: markdown for people, or json for machine-readable IDs, fields, and scores. Errors are always plain text.",
+		"default":     tools.FormatMarkdown,
 	}
 }
 
@@ -961,10 +2466,30 @@ func handleQuery(ctx context.Context, s *mcpServer, args map[string]any) (*tools
 	return tools.Query(ctx, s.client, args)
 }
 
+func handleBulkQuery(ctx context.Context, s *mcpServer, args map[string]any) (*tools.ToolResult, error) {
+	return tools.BulkQuery(ctx, s.client, args)
+}
+
+func handleContext(ctx context.Context, s *mcpServer, args map[string]any) (*tools.ToolResult, error) {
+	return tools.Context(ctx, s.client, args)
+}
+
+func handleAsk(ctx context.Context, s *mcpServer, args map[string]any) (*tools.ToolResult, error) {
+	return tools.Ask(ctx, s.client, args)
+}
+
 func handleUpdate(ctx context.Context, s *mcpServer, args map[string]any) (*tools.ToolResult, error) {
 	return tools.Update(ctx, s.client, args)
 }
 
+func handleRelate(ctx context.Context, s *mcpServer, args map[string]any) (*tools.ToolResult, error) {
+	return tools.Relate(ctx, s.client, args)
+}
+
+func handleMerge(ctx context.Context, s *mcpServer, args map[string]any) (*tools.ToolResult, error) {
+	return tools.Merge(ctx, s.client, args)
+}
+
 func handleList(ctx context.Context, s *mcpServer, args map[string]any) (*tools.ToolResult, error) {
 	return tools.List(ctx, s.client, args)
 }
@@ -973,6 +2498,18 @@ func handleConflicts(ctx context.Context, s *mcpServer, args map[string]any) (*t
 	return tools.Conflicts(ctx, s.client, args)
 }
 
+func handleSimilar(ctx context.Context, s *mcpServer, args map[string]any) (*tools.ToolResult, error) {
+	return tools.Similar(ctx, s.client, args)
+}
+
+func handleDedupe(ctx context.Context, s *mcpServer, args map[string]any) (*tools.ToolResult, error) {
+	return tools.Dedupe(ctx, s.client, args)
+}
+
+func handlePreferences(ctx context.Context, s *mcpServer, args map[string]any) (*tools.ToolResult, error) {
+	return tools.Preferences(ctx, s.client, args)
+}
+
 func handleExport(ctx context.Context, s *mcpServer, args map[string]any) (*tools.ToolResult, error) {
 	return tools.Export(ctx, s.client, args)
 }
@@ -985,6 +2522,144 @@ func handleMIEStatus(ctx context.Context, s *mcpServer, args map[string]any) (*t
 	return tools.Status(ctx, s.client, args)
 }
 
+func handleStatsByTopic(ctx context.Context, s *mcpServer, args map[string]any) (*tools.ToolResult, error) {
+	return tools.StatsByTopic(ctx, s.client, args)
+}
+
+func handleEmbeddingStatus(ctx context.Context, s *mcpServer, args map[string]any) (*tools.ToolResult, error) {
+	return tools.EmbeddingStatus(ctx, s.client, args)
+}
+
+func handleTopics(ctx context.Context, s *mcpServer, args map[string]any) (*tools.ToolResult, error) {
+	return tools.Topics(ctx, s.client, args)
+}
+
+func handleVisualize(ctx context.Context, s *mcpServer, args map[string]any) (*tools.ToolResult, error) {
+	return tools.Visualize(ctx, s.client, args)
+}
+
+func handleAudit(ctx context.Context, s *mcpServer, args map[string]any) (*tools.ToolResult, error) {
+	return tools.Audit(ctx, s.client, args)
+}
+
+func handleHistory(ctx context.Context, s *mcpServer, args map[string]any) (*tools.ToolResult, error) {
+	return tools.History(ctx, s.client, args)
+}
+
+func handleSuggestRelationships(ctx context.Context, s *mcpServer, args map[string]any) (*tools.ToolResult, error) {
+	return tools.SuggestRelationships(ctx, s.client, args)
+}
+
+func handleSnapshot(ctx context.Context, s *mcpServer, args map[string]any) (*tools.ToolResult, error) {
+	return tools.Snapshots(ctx, s.client, args)
+}
+
+func handleReview(ctx context.Context, s *mcpServer, args map[string]any) (*tools.ToolResult, error) {
+	return tools.Review(ctx, s.client, args)
+}
+
+func handleAttach(ctx context.Context, s *mcpServer, args map[string]any) (*tools.ToolResult, error) {
+	return tools.Attachments(ctx, s.client, args)
+}
+
+func handleSession(ctx context.Context, s *mcpServer, args map[string]any) (*tools.ToolResult, error) {
+	result, active, err := tools.Sessions(ctx, s.client, args)
+	if err == nil {
+		s.sessionMu.Lock()
+		s.session = active
+		s.sessionMu.Unlock()
+	}
+	return result, err
+}
+
+// readAttachment answers resources/read for the attachment URI uri. Text
+// content is returned as text and anything else base64 encoded as a blob.
+func (s *mcpServer) readAttachment(ctx context.Context, id any, uri, hash string) jsonRPCResponse {
+	att, data, err := s.client.ReadAttachment(ctx, hash)
+	if err != nil {
+		return jsonRPCResponse{
+			JSONRPC: "2.0",
+			ID:      id,
+			Error: &rpcError{
+				Code:    -32002,
+				Message: "Resource not found",
+				Data:    err.Error(),
+			},
+		}
+	}
+	content := mcpResourceContent{URI: uri, MimeType: att.MimeType}
+	if isTextMimeType(att.MimeType) && utf8.Valid(data) {
+		content.Text = string(data)
+	} else {
+		content.Blob = base64.StdEncoding.EncodeToString(data)
+	}
+	return jsonRPCResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Result:  mcpResourceReadResult{Contents: []mcpResourceContent{content}},
+	}
+}
+
+// readTopicSummary answers resources/read for the summary URI uri of the
+// topic named name.
+func (s *mcpServer) readTopicSummary(ctx context.Context, id any, uri, name string) jsonRPCResponse {
+	text, err := buildTopicSummary(ctx, s.client, name, time.Now())
+	if err != nil {
+		return jsonRPCResponse{
+			JSONRPC: "2.0",
+			ID:      id,
+			Error: &rpcError{
+				Code:    -32002,
+				Message: "Resource not found",
+				Data:    err.Error(),
+			},
+		}
+	}
+	return jsonRPCResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Result:  mcpResourceReadResult{Contents: []mcpResourceContent{{URI: uri, MimeType: "text/markdown", Text: text}}},
+	}
+}
+
+// readNode answers resources/read for the entity or decision URI uri of
+// the node nodeID.
+func (s *mcpServer) readNode(ctx context.Context, id any, uri, nodeID string) jsonRPCResponse {
+	text, err := buildNodeResource(ctx, s.client, nodeID)
+	if err != nil {
+		return jsonRPCResponse{
+			JSONRPC: "2.0",
+			ID:      id,
+			Error: &rpcError{
+				Code:    -32002,
+				Message: "Resource not found",
+				Data:    err.Error(),
+			},
+		}
+	}
+	return jsonRPCResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Result:  mcpResourceReadResult{Contents: []mcpResourceContent{{URI: uri, MimeType: "text/markdown", Text: text}}},
+	}
+}
+
+// isTextMimeType reports whether content of mimeType is text.
+func isTextMimeType(mimeType string) bool {
+	mimeType, _, _ = strings.Cut(mimeType, ";")
+	mimeType = strings.TrimSpace(mimeType)
+	return strings.HasPrefix(mimeType, "text/") || strings.HasSuffix(mimeType, "+xml") || strings.HasSuffix(mimeType, "+json") ||
+		mimeType == "application/json" || mimeType == "application/xml" || mimeType == "application/yaml"
+}
+
+// activeSession returns the ID of the session opened with mie_session, or
+// "" if none is open.
+func (s *mcpServer) activeSession() string {
+	s.sessionMu.Lock()
+	defer s.sessionMu.Unlock()
+	return s.session
+}
+
 // buildRecentContext queries the memory graph for recent facts, decisions, and entities,
 // and formats them as a concise markdown summary for the mie://context/recent resource.
 func (s *mcpServer) buildRecentContext(ctx context.Context) string {
@@ -1052,4 +2727,27 @@ func (s *mcpServer) buildRecentContext(ctx context.Context) string {
 	}
 
 	return sb.String()
-}
\ No newline at end of file
+}
+
+// buildRecentChanges formats the change feed for the mie://changes resource.
+func (s *mcpServer) buildRecentChanges() string {
+	var sb strings.Builder
+	sb.WriteString("# Recent Memory Changes\n\n")
+	if s.changes == nil {
+		sb.WriteString("Change feed unavailable.\n")
+		return sb.String()
+	}
+	changes := s.changes.Recent(20)
+	if len(changes) == 0 {
+		sb.WriteString("No changes since the server started.\n")
+		return sb.String()
+	}
+	for _, c := range changes {
+		fmt.Fprintf(&sb, "- %s %s", time.Unix(c.At, 0).UTC().Format("2006-01-02 15:04:05"), c.Op)
+		if c.NodeID != "" {
+			fmt.Fprintf(&sb, " %s", c.NodeID)
+		}
+		fmt.Fprintf(&sb, " (namespace %s)\n", c.Namespace)
+	}
+	return sb.String()
+}
@@ -0,0 +1,136 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// mergeKinds is the order in which merge counts are reported.
+var mergeKinds = []string{"facts", "decisions", "entities", "events", "topics"}
+
+// mergeCommand is mie merge.
+var mergeCommand = command{
+	name:    "merge",
+	summary: "Merge another machine's export without duplicates",
+	usage:   "[options]",
+	help: `  Merge a JSON export of the memory graph taken on another machine into
+  this one. Unlike mie import, nodes both graphs hold are not duplicated:
+  they match by ID, or else by fact content, decision title, event title
+  and date, or entity and topic name. The newer version of a matched node
+  wins, and relationships, aliases, and archive marks from both graphs are
+  kept.
+
+  Conflicts that need a person to decide are listed: matched nodes that
+  disagree on fact validity or decision status, similar facts with
+  different content, and entities with the same name but another kind.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.StringP("input", "i", "", "JSON export to merge (default: stdin)")
+		fs.Bool("dry-run", false, "Report what the merge would do without writing")
+	},
+	examples: `  mie merge --input laptop.json               Merge another machine's export
+  mie merge --input laptop.json --dry-run     Preview the merge
+  ssh laptop mie export | mie merge           Merge straight from another machine`,
+	run: runMerge,
+}
+
+// runMerge merges a JSON export from another copy of the memory graph into
+// this one.
+func runMerge(fs *flag.FlagSet, configPath string, globals GlobalFlags) {
+	input, _ := fs.GetString("input")
+	dryRun, _ := fs.GetBool("dry-run")
+
+	var export tools.ExportData
+	if err := json.Unmarshal(readImportInput(input), &export); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid JSON: %v\n", err)
+		os.Exit(ExitGeneral)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		cfg = DefaultConfig()
+		cfg.applyEnvOverrides()
+	}
+
+	dataDir, err := ResolveDataDir(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+
+	if _, err := os.Stat(dataDir); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Error: no data found at %s\n", dataDir)
+		os.Exit(ExitDatabase)
+	}
+
+	client, err := memory.NewClient(memory.ClientConfig{
+		DataDir:       dataDir,
+		StorageEngine: cfg.Storage.Engine,
+		Namespace:     globals.resolveNamespace(cfg),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: cannot open database: %v\n", err)
+		printLockHint(dataDir)
+		os.Exit(ExitDatabase)
+	}
+	defer func() { _ = client.Close() }()
+
+	ctx := tools.WithAuditSource(context.Background(), "mie merge", "")
+	merge, err := client.MergeGraph(ctx, &export, dryRun)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: merge failed: %v\n", err)
+		os.Exit(ExitDatabase)
+	}
+
+	if globals.JSON {
+		out, err := json.MarshalIndent(merge, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitGeneral)
+		}
+		fmt.Println(string(out))
+		return
+	}
+
+	if dryRun {
+		fmt.Println("Dry run — would merge:")
+	} else if !globals.Quiet {
+		fmt.Println("Merged:")
+	}
+	if dryRun || !globals.Quiet {
+		fmt.Printf("  Added:     %s\n", formatMergeCounts(merge.Added))
+		fmt.Printf("  Updated:   %s\n", formatMergeCounts(merge.Updated))
+		fmt.Printf("  Unchanged: %s\n", formatMergeCounts(merge.Unchanged))
+		fmt.Printf("  %d relationships, %d nodes matched by content or name\n", merge.Relationships, len(merge.Matched))
+	}
+
+	if len(merge.Conflicts) > 0 {
+		fmt.Printf("\n%d conflicts need manual resolution:\n", len(merge.Conflicts))
+		for _, c := range merge.Conflicts {
+			fmt.Printf("  %s / %s: %s\n", c.LocalID, c.IncomingID, c.Reason)
+		}
+		fmt.Println("\nResolve them with mie_update (invalidate, update_status) or mie_merge.")
+	}
+}
+
+// formatMergeCounts renders counts as "3 facts, 1 decisions, ...".
+func formatMergeCounts(counts map[string]int) string {
+	parts := make([]string, 0, len(mergeKinds))
+	for _, kind := range mergeKinds {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[kind], kind))
+	}
+	return strings.Join(parts, ", ")
+}
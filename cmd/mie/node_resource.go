@@ -0,0 +1,167 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// Node resources are mie://entity/<id> and mie://decision/<id>, with the
+// node ID path-escaped. The ID prefix may be left out, as in
+// mie://entity/a1b2c3d4.
+const (
+	entityURIPrefix   = "mie://entity/"
+	decisionURIPrefix = "mie://decision/"
+)
+
+// nodeResources maps the URI prefix of each node resource to the ID prefix
+// of its nodes.
+var nodeResources = map[string]string{
+	entityURIPrefix:   "ent:",
+	decisionURIPrefix: "dec:",
+}
+
+// nodeResourceData is the data a node page is rendered with.
+type nodeResourceData struct {
+	Entity    *tools.Entity
+	Decision  *tools.Decision
+	Facts     []tools.Fact           // valid, newest first
+	Decisions []tools.Decision       // newest first
+	Entities  []tools.EntityWithRole // by name
+	Created   string
+}
+
+// nodeResourceTemplate renders an entity or decision as markdown.
+var nodeResourceTemplate = template.Must(template.New("node-resource").Funcs(template.FuncMap{
+	"oneLine": func(s string) string { return strings.Join(strings.Fields(s), " ") },
+}).Parse(`{{with .Entity -}}
+# {{.Name}}
+
+_Entity [{{.ID}}] ({{.Kind}}), created {{$.Created}}{{if .SourceAgent}} by {{.SourceAgent}}{{end}}._
+{{- if .Description}}
+
+{{.Description}}
+{{- end}}
+{{- end}}
+{{- with .Decision -}}
+# {{oneLine .Title}}
+
+_Decision [{{.ID}}], {{.Status}}, created {{$.Created}}{{if .SourceAgent}} by {{.SourceAgent}}{{end}}._
+
+## Rationale
+
+{{.Rationale}}
+{{- if and .Alternatives (ne .Alternatives "[]")}}
+
+## Alternatives
+
+{{.Alternatives}}
+{{- end}}
+{{- if .Context}}
+
+## Context
+
+{{.Context}}
+{{- end}}
+{{- end}}
+{{- if .Facts}}
+
+## Facts
+{{range .Facts}}
+- [{{.ID}}] {{oneLine .Content}} ({{.Category}}, confidence {{printf "%.1f" .Confidence}})
+{{- end}}
+{{- end}}
+{{- if .Decisions}}
+
+## Decisions
+{{range .Decisions}}
+- [{{.ID}}] **{{oneLine .Title}}** ({{.Status}}): {{oneLine .Rationale}}
+{{- end}}
+{{- end}}
+{{- if .Entities}}
+
+## Entities
+{{range .Entities}}
+- [{{.ID}}] {{.Name}} ({{.Kind}}){{if .Role}}, {{.Role}}{{end}}
+{{- end}}
+{{- end}}
+`))
+
+// parseNodeURI returns the node ID of an entity or decision resource URI,
+// and whether uri is one.
+func parseNodeURI(uri string) (string, bool) {
+	for uriPrefix, idPrefix := range nodeResources {
+		escaped, ok := strings.CutPrefix(uri, uriPrefix)
+		if !ok {
+			continue
+		}
+		id, err := url.PathUnescape(escaped)
+		if err != nil || strings.TrimPrefix(id, idPrefix) == "" || strings.Contains(id, "/") {
+			return "", false
+		}
+		if !strings.HasPrefix(id, idPrefix) {
+			id = idPrefix + id
+		}
+		return id, true
+	}
+	return "", false
+}
+
+// buildNodeResource renders the page of the entity or decision nodeID: an
+// entity with its valid facts and its decisions, or a decision with its
+// rationale, alternatives, context, and entities.
+func buildNodeResource(ctx context.Context, client tools.Querier, nodeID string) (string, error) {
+	node, err := client.GetNodeByID(ctx, nodeID)
+	if err != nil {
+		return "", err
+	}
+
+	var data nodeResourceData
+	switch n := node.(type) {
+	case *tools.Entity:
+		data.Entity = n
+		data.Created = time.Unix(n.CreatedAt, 0).UTC().Format("2006-01-02")
+		facts, err := client.GetFactsAboutEntity(ctx, n.ID)
+		if err != nil {
+			return "", err
+		}
+		for _, f := range facts {
+			if f.Valid {
+				data.Facts = append(data.Facts, f)
+			}
+		}
+		if data.Decisions, err = client.GetEntityDecisions(ctx, n.ID); err != nil {
+			return "", err
+		}
+	case *tools.Decision:
+		data.Decision = n
+		data.Created = time.Unix(n.CreatedAt, 0).UTC().Format("2006-01-02")
+		if data.Entities, err = client.GetDecisionEntities(ctx, n.ID); err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("node %s is not an entity or decision", nodeID)
+	}
+
+	sort.SliceStable(data.Facts, func(i, j int) bool { return data.Facts[i].CreatedAt > data.Facts[j].CreatedAt })
+	sort.SliceStable(data.Decisions, func(i, j int) bool { return data.Decisions[i].CreatedAt > data.Decisions[j].CreatedAt })
+	sort.SliceStable(data.Entities, func(i, j int) bool { return data.Entities[i].Name < data.Entities[j].Name })
+
+	var sb strings.Builder
+	if err := nodeResourceTemplate.Execute(&sb, data); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
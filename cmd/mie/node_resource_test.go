@@ -0,0 +1,123 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// nodeGraph is a tools.Querier over a fixed entity and decision.
+type nodeGraph struct {
+	tools.Querier
+	nodes map[string]any
+}
+
+func (g *nodeGraph) GetNodeByID(ctx context.Context, nodeID string) (any, error) {
+	if n, ok := g.nodes[nodeID]; ok {
+		return n, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (g *nodeGraph) GetFactsAboutEntity(ctx context.Context, entityID string) ([]tools.Fact, error) {
+	return []tools.Fact{
+		{ID: "fact:1", Content: "Postgres runs\nbilling", Category: "technical", Confidence: 0.9, Valid: true, CreatedAt: 1},
+		{ID: "fact:2", Content: "Postgres 16 is deployed", Category: "technical", Confidence: 0.8, Valid: true, CreatedAt: 2},
+		{ID: "fact:3", Content: "Postgres 15 is deployed", Valid: false},
+	}, nil
+}
+
+func (g *nodeGraph) GetEntityDecisions(ctx context.Context, entityID string) ([]tools.Decision, error) {
+	return []tools.Decision{*g.nodes["dec:d"].(*tools.Decision)}, nil
+}
+
+func (g *nodeGraph) GetDecisionEntities(ctx context.Context, decisionID string) ([]tools.EntityWithRole, error) {
+	return []tools.EntityWithRole{{Entity: *g.nodes["ent:p"].(*tools.Entity), Role: "subject"}}, nil
+}
+
+func TestBuildNodeResource(t *testing.T) {
+	g := &nodeGraph{nodes: map[string]any{
+		"ent:p": &tools.Entity{ID: "ent:p", Name: "Postgres", Kind: "technology", Description: "Primary database", CreatedAt: 1767225600},
+		"dec:d": &tools.Decision{
+			ID: "dec:d", Title: "Use Postgres", Rationale: "Team knows it", Alternatives: `["MySQL"]`,
+			Status: "active", SourceAgent: "claude", CreatedAt: 1767225600,
+		},
+		"top:t": &tools.Topic{ID: "top:t", Name: "Billing"},
+	}}
+	ctx := context.Background()
+
+	text, err := buildNodeResource(ctx, g, "ent:p")
+	require.NoError(t, err)
+	assert.Equal(t, `# Postgres
+
+_Entity [ent:p] (technology), created 2026-01-01._
+
+Primary database
+
+## Facts
+
+- [fact:2] Postgres 16 is deployed (technical, confidence 0.8)
+- [fact:1] Postgres runs billing (technical, confidence 0.9)
+
+## Decisions
+
+- [dec:d] **Use Postgres** (active): Team knows it
+`, text)
+
+	text, err = buildNodeResource(ctx, g, "dec:d")
+	require.NoError(t, err)
+	assert.Equal(t, `# Use Postgres
+
+_Decision [dec:d], active, created 2026-01-01 by claude._
+
+## Rationale
+
+Team knows it
+
+## Alternatives
+
+["MySQL"]
+
+## Entities
+
+- [ent:p] Postgres (technology), subject
+`, text)
+
+	_, err = buildNodeResource(ctx, g, "top:t")
+	assert.Error(t, err)
+	_, err = buildNodeResource(ctx, g, "ent:missing")
+	assert.Error(t, err)
+}
+
+func TestParseNodeURI(t *testing.T) {
+	tests := []struct {
+		uri string
+		id  string
+		ok  bool
+	}{
+		{"mie://entity/ent:a1b2c3d4", "ent:a1b2c3d4", true},
+		{"mie://entity/a1b2c3d4", "ent:a1b2c3d4", true},
+		{"mie://decision/dec%3Aa1b2c3d4", "dec:a1b2c3d4", true},
+		{"mie://decision/", "", false},
+		{"mie://entity/ent:", "", false},
+		{"mie://entity/a/b", "", false},
+		{"mie://topic/billing/summary", "", false},
+	}
+	for _, tt := range tests {
+		id, ok := parseNodeURI(tt.uri)
+		if id != tt.id || ok != tt.ok {
+			t.Errorf("parseNodeURI(%q) = %q, %v, want %q, %v", tt.uri, id, ok, tt.id, tt.ok)
+		}
+	}
+}
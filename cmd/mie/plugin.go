@@ -0,0 +1,145 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// pluginPrefix starts the file name of plugin executables: mie <name> runs
+// mie-<name> from PATH when <name> is not a built-in command, so the CLI
+// can be extended without changing MIE.
+const pluginPrefix = "mie-"
+
+// plugin is a mie-<name> executable found on PATH.
+type plugin struct {
+	name string
+	path string
+}
+
+// findPlugins returns the plugins on PATH, by name. A plugin named like a
+// built-in command is left out, since it can never run. When several PATH
+// directories hold a plugin, the first one wins, as with exec.LookPath.
+func findPlugins() []plugin {
+	seen := make(map[string]bool)
+	var plugins []plugin
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := strings.CutPrefix(entry.Name(), pluginPrefix)
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, ".exe")
+			}
+			if !ok || name == "" || seen[name] {
+				continue
+			}
+			if isBuiltin(name) {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			seen[name] = true
+			plugins = append(plugins, plugin{name: name, path: path})
+		}
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].name < plugins[j].name })
+	return plugins
+}
+
+// isExecutable reports whether path is a regular file that can be run.
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	return runtime.GOOS == "windows" || info.Mode().Perm()&0o111 != 0
+}
+
+// pluginPath returns the path of the plugin executable for command name,
+// and whether there is one. Names that could reach outside PATH, such as
+// "../x", never match.
+func pluginPath(name string) (string, bool) {
+	if name == "" || strings.HasPrefix(name, "-") || strings.ContainsAny(name, `/\`) {
+		return "", false
+	}
+	path, err := exec.LookPath(pluginPrefix + name)
+	if err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+// runPlugin runs the plugin at path with args on mie's standard streams and
+// exits with its exit code. The global flags reach the plugin through its
+// environment (see pluginEnv).
+func runPlugin(path string, args []string, configPath string, globals GlobalFlags) {
+	// The plugin shares the terminal, so Ctrl-C reaches it directly. Catch
+	// the interrupt here so mie waits for the plugin to exit instead of
+	// exiting first.
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+
+	cmd := exec.Command(path, args...) //nolint:gosec // G204: Plugin path comes from PATH lookup
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.Env = pluginEnv(os.Environ(), configPath, globals)
+	err := cmd.Run()
+	if err == nil {
+		return
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+		os.Exit(exitErr.ExitCode())
+	}
+	fmt.Fprintf(os.Stderr, "Error: plugin %s failed: %v\n", path, err)
+	os.Exit(ExitGeneral)
+}
+
+// pluginEnv returns environ with the global flags added for a plugin:
+// --config and --namespace as MIE_CONFIG_PATH and MIE_NAMESPACE, which mie
+// itself reads, --json, --quiet, and --verbose as MIE_JSON, MIE_QUIET, and
+// MIE_VERBOSE, and the path of this mie binary as MIE_EXECUTABLE, for
+// plugins that run mie commands.
+func pluginEnv(environ []string, configPath string, globals GlobalFlags) []string {
+	env := append([]string(nil), environ...)
+	if configPath != "" {
+		env = append(env, "MIE_CONFIG_PATH="+configPath)
+	}
+	if globals.Namespace != "" {
+		env = append(env, "MIE_NAMESPACE="+globals.Namespace)
+	}
+	if globals.JSON {
+		env = append(env, "MIE_JSON=true")
+	}
+	if globals.Quiet {
+		env = append(env, "MIE_QUIET=true")
+	}
+	if globals.Verbose > 0 {
+		env = append(env, "MIE_VERBOSE="+strconv.Itoa(globals.Verbose))
+	}
+	if exe, err := os.Executable(); err == nil {
+		env = append(env, "MIE_EXECUTABLE="+exe)
+	}
+	return env
+}
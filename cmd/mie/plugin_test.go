@@ -0,0 +1,146 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCommandsUnique(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, c := range commands {
+		if c.name == "" || c.summary == "" || c.usage == "" || c.help == "" || c.run == nil {
+			t.Errorf("command %+v is incomplete", c)
+		}
+		if seen[c.name] {
+			t.Errorf("command %q is registered twice", c.name)
+		}
+		seen[c.name] = true
+	}
+	if _, ok := findCommand("import"); !ok {
+		t.Error(`findCommand("import") found nothing`)
+	}
+	if _, ok := findCommand("hello"); ok {
+		t.Error(`findCommand("hello") found a built-in command`)
+	}
+	if !isBuiltin("help") || !isBuiltin("import") || isBuiltin("hello") {
+		t.Error("isBuiltin does not match the built-in commands and help")
+	}
+}
+
+func TestCommandHelp(t *testing.T) {
+	for _, c := range commands {
+		// flagSet panics if a command defines an option twice.
+		var buf bytes.Buffer
+		c.printHelp(&buf, c.flagSet())
+		help := buf.String()
+		if !strings.HasPrefix(help, "Usage: mie "+c.name+" ") || !strings.Contains(help, "\nDescription:\n") {
+			t.Errorf("help of %s lacks its usage or description:\n%s", c.name, help)
+		}
+		if c.flags != nil && !strings.Contains(help, "\nOptions:\n") {
+			t.Errorf("help of %s lacks its options:\n%s", c.name, help)
+		}
+	}
+
+	c, _ := findCommand("import")
+	var buf bytes.Buffer
+	c.printHelp(&buf, c.flagSet())
+	for _, want := range []string{
+		"Usage: mie import [options] [FILE...]\n",
+		"      --dry-run ",
+		"  -i, --input string ",
+		"\nExamples:\n  mie import --input memory.json ",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("help of import lacks %q:\n%s", want, buf.String())
+		}
+	}
+
+	c, _ = findCommand("query")
+	buf.Reset()
+	c.printHelp(&buf, c.flagSet())
+	if !strings.HasPrefix(buf.String(), "Usage: mie query <cozoscript> [options]\n       mie query -i [options]\n") {
+		t.Errorf("help of query lacks its two forms:\n%s", buf.String())
+	}
+
+	fs := c.flagSet()
+	if err := fs.Parse([]string{"--allow-write", "?[x] <- [[1]]"}); err != nil {
+		t.Fatal(err)
+	}
+	if allow, _ := fs.GetBool("allow-write"); !allow || fs.Arg(0) != "?[x] <- [[1]]" {
+		t.Errorf("parsed query flags: allow-write %v, args %v", allow, fs.Args())
+	}
+}
+
+func writePlugin(t *testing.T, dir, name string, mode os.FileMode) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\necho hello\n"), mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindPlugins(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+	writePlugin(t, first, "mie-hello", 0o755)
+	writePlugin(t, second, "mie-hello", 0o755)
+	writePlugin(t, second, "mie-backup", 0o755)
+	writePlugin(t, second, "mie-status", 0o755) // shadowed by the built-in command
+	writePlugin(t, second, "mie-help", 0o755)   // shadowed by mie help
+	writePlugin(t, second, "mie-notes", 0o644)  // not executable
+	writePlugin(t, second, "mie-", 0o755)
+	t.Setenv("PATH", strings.Join([]string{first, second}, string(os.PathListSeparator)))
+
+	var got []string
+	for _, p := range findPlugins() {
+		got = append(got, p.name+"="+p.path)
+	}
+	want := []string{
+		"backup=" + filepath.Join(second, "mie-backup"),
+		"hello=" + filepath.Join(first, "mie-hello"),
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("findPlugins() = %v, want %v", got, want)
+	}
+
+	if path, ok := pluginPath("hello"); !ok || path != filepath.Join(first, "mie-hello") {
+		t.Errorf(`pluginPath("hello") = %q, %v`, path, ok)
+	}
+	for _, name := range []string{"notes", "missing", "", "-x", "../hello"} {
+		if path, ok := pluginPath(name); ok {
+			t.Errorf("pluginPath(%q) = %q, want no plugin", name, path)
+		}
+	}
+	if list := commandList(); !strings.Contains(list, "Plugins (mie-<name> on PATH):") || !strings.Contains(list, "  backup") || !strings.Contains(list, "  help") {
+		t.Errorf("commandList() does not list the plugins:\n%s", list)
+	}
+}
+
+func TestPluginEnv(t *testing.T) {
+	env := pluginEnv([]string{"HOME=/home/me", "MIE_NAMESPACE=default"}, "/tmp/config.yaml",
+		GlobalFlags{JSON: true, Quiet: true, Verbose: 2, Namespace: "work"})
+	for _, want := range []string{"HOME=/home/me", "MIE_CONFIG_PATH=/tmp/config.yaml", "MIE_NAMESPACE=work", "MIE_JSON=true", "MIE_QUIET=true", "MIE_VERBOSE=2"} {
+		if !slices.Contains(env, want) {
+			t.Errorf("pluginEnv() lacks %s: %v", want, env)
+		}
+	}
+	// The --namespace value must come after the inherited one, so it wins.
+	if slices.Index(env, "MIE_NAMESPACE=work") < slices.Index(env, "MIE_NAMESPACE=default") {
+		t.Errorf("pluginEnv() puts the inherited namespace last: %v", env)
+	}
+
+	env = pluginEnv(nil, "", GlobalFlags{})
+	for _, e := range env {
+		if !strings.HasPrefix(e, "MIE_EXECUTABLE=") {
+			t.Errorf("pluginEnv() without flags added %s", e)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// pruneCommand is mie prune.
+var pruneCommand = command{
+	name:    "prune",
+	summary: "Suggest or delete low-value nodes",
+	usage:   "[options]",
+	help: `  Suggest nodes of the memory graph that are likely safe to delete, and
+  delete them with --apply. Candidates are:
+
+    invalidated     facts that were invalidated
+    low_confidence  valid facts below --low-confidence, older than
+                    --min-age-days, that nobody verified
+    orphan_entity   entities older than --min-age-days without
+                    relationships
+    unused_topic    topics no fact, decision, or entity links to
+
+  Each candidate is scored from 0 to 1 by its reason and its age; the
+  higher the score, the safer the deletion. Deleted nodes are removed
+  with their edges, embeddings, and aliases, as by mie reset.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.Bool("suggest", true, "List the candidates without deleting them (the default)")
+		fs.Bool("apply", false, "Delete the candidates")
+		fs.Float64("min-score", 0, "Only consider candidates scoring at least this (0-1)")
+		fs.Int("limit", 0, "Only consider the best-scoring N candidates (0 for all)")
+		fs.Int("min-age-days", memory.DefaultPruneMinAgeDays, "How old a valid fact or an entity must be to be a candidate")
+		fs.Float64("low-confidence", memory.DefaultPruneLowConfidence, "Confidence below which old, unverified facts are candidates")
+		fs.String("namespace", "", "Prune this namespace instead of the current one")
+	},
+	examples: `  mie prune                              List the candidates
+  mie prune --min-score 0.7 --json       Report the safest ones as JSON
+  mie prune --min-score 0.7 --apply      Delete them
+  mie prune --limit 100 --apply          Delete the best-scoring 100`,
+	run: runPrune,
+}
+
+// runPrune ranks the nodes likely safe to delete, and deletes them with
+// --apply.
+func runPrune(fs *flag.FlagSet, configPath string, globals GlobalFlags) {
+	apply, _ := fs.GetBool("apply")
+	minScore, _ := fs.GetFloat64("min-score")
+	limit, _ := fs.GetInt("limit")
+	minAgeDays, _ := fs.GetInt("min-age-days")
+	lowConfidence, _ := fs.GetFloat64("low-confidence")
+	namespace, _ := fs.GetString("namespace")
+
+	if namespace != "" {
+		if err := tools.ValidateNamespace(namespace); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitConfig)
+		}
+		globals.Namespace = namespace
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		cfg = DefaultConfig()
+		cfg.applyEnvOverrides()
+	}
+
+	dataDir, err := ResolveDataDir(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+
+	if _, err := os.Stat(dataDir); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Error: no data found at %s\n", dataDir)
+		os.Exit(ExitDatabase)
+	}
+
+	client, err := memory.NewClient(memory.ClientConfig{
+		DataDir:       dataDir,
+		StorageEngine: cfg.Storage.Engine,
+		Namespace:     globals.resolveNamespace(cfg),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: cannot open database: %v\n", err)
+		printLockHint(dataDir)
+		os.Exit(ExitDatabase)
+	}
+	defer func() { _ = client.Close() }()
+
+	ctx := tools.WithAuditSource(context.Background(), "mie prune", "")
+	result, err := client.Prune(ctx, memory.PruneOptions{
+		LowConfidence: lowConfidence,
+		MinAgeDays:    minAgeDays,
+		MinScore:      minScore,
+		Limit:         limit,
+		Apply:         apply,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitQuery)
+	}
+
+	if globals.JSON {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitGeneral)
+		}
+		fmt.Println(string(data))
+		return
+	}
+	if globals.Quiet {
+		return
+	}
+	printPrune(result)
+}
+
+func printPrune(result *memory.PruneResult) {
+	if len(result.Candidates) == 0 {
+		fmt.Printf("Nothing to prune in namespace %s.\n", result.Namespace)
+		return
+	}
+
+	verb := "Would prune"
+	if result.Applied {
+		verb = "Pruned"
+	}
+	fmt.Printf("%s %d nodes from namespace %s:\n", verb, len(result.Candidates), result.Namespace)
+	for _, c := range result.Candidates {
+		fmt.Printf("  %.2f  %-14s  %-24s  %s\n", c.Score, c.Reason, c.ID, tools.Truncate(c.Summary, 60))
+	}
+	if !result.Applied {
+		fmt.Println("\nRun again with --apply to delete them.")
+	}
+}
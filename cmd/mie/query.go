@@ -11,48 +11,56 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	flag "github.com/spf13/pflag"
 
 	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/storage"
 )
 
-// runQuery executes a raw CozoScript query for debugging.
-func runQuery(args []string, configPath string, globals GlobalFlags) {
-	fs := flag.NewFlagSet("query", flag.ExitOnError)
-
-	fs.Usage = func() {
-		fmt.Fprintf(os.Stderr, `Usage: mie query <cozoscript> [options]
-
-Description:
-  Execute a raw CozoScript query against the MIE database.
+// queryCommand is mie query.
+var queryCommand = command{
+	name:    "query",
+	summary: "Execute CozoScript query (debugging)",
+	usage:   "<cozoscript> [options]\n-i [options]",
+	help: `  Execute a raw CozoScript query against the MIE database.
   This is a debugging tool for inspecting the underlying data.
 
-Options (inherited):
-  --json    Output as JSON
-
-Examples:
-  mie query "?[name] := *mie_entity { name } :limit 10"
+  With -i, start an interactive session that reads scripts (which may
+  span several lines) and prints each result as a table. Type :help in
+  the session for its commands.
+
+  Scripts are read-only by default: a script that would modify the
+  database, with :put, :rm, :create, ::remove, ::index, or another write
+  operation, is rejected unless --allow-write is given. Writing relations
+  directly bypasses MIE's bookkeeping and can corrupt the graph; take a
+  snapshot first.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.BoolP("interactive", "i", false, "Start an interactive query session")
+		fs.Bool("allow-write", false, "Allow scripts that modify the database (:put, :rm, ::remove, ...)")
+	},
+	examples: `  mie query "?[name] := *mie_entity { name } :limit 10"
   mie query "?[count(id)] := *mie_fact { id }"
   mie query "?[id, content] := *mie_fact { id, content, valid }, valid = true :limit 5"
+  mie query -i
+  mie query --allow-write "?[key, value] <- [['note', 'x']] :put mie_meta { key => value }"`,
+	run: runQuery,
+}
 
-`)
-	}
-
-	if err := fs.Parse(args); err != nil {
-		os.Exit(1)
-	}
+// runQuery executes a raw CozoScript query for debugging.
+func runQuery(fs *flag.FlagSet, configPath string, globals GlobalFlags) {
+	interactive, _ := fs.GetBool("interactive")
+	allowWrite, _ := fs.GetBool("allow-write")
 
 	remaining := fs.Args()
-	if len(remaining) == 0 {
+	if len(remaining) == 0 && !interactive {
 		fmt.Fprintf(os.Stderr, "Error: query argument required\n")
 		fmt.Fprintf(os.Stderr, "Usage: mie query \"<cozoscript>\"\n")
 		os.Exit(ExitQuery)
 	}
 
-	script := strings.Join(remaining, " ")
-
 	cfg, err := LoadConfig(configPath)
 	if err != nil {
 		cfg = DefaultConfig()
@@ -74,15 +82,33 @@ Examples:
 	client, err := memory.NewClient(memory.ClientConfig{
 		DataDir:       dataDir,
 		StorageEngine: cfg.Storage.Engine,
+		Namespace:     globals.resolveNamespace(cfg),
 	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: cannot open database: %v\n", err)
+		printLockHint(dataDir)
 		os.Exit(ExitDatabase)
 	}
 	defer func() { _ = client.Close() }()
 
 	ctx := context.Background()
-	result, err := client.RawQuery(ctx, script)
+	query := guardedQuery(client.RawQuery, client.RawExecute, allowWrite)
+	if interactive {
+		repl := &queryREPL{
+			query:       query,
+			out:         os.Stdout,
+			json:        globals.JSON,
+			historyPath: filepath.Join(dataDir, "query_history"),
+		}
+		repl.loadHistory()
+		if err := repl.run(ctx, os.Stdin); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitQuery)
+		}
+		return
+	}
+
+	result, err := query(ctx, strings.Join(remaining, " "))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Query error: %v\n", err)
 		os.Exit(ExitQuery)
@@ -95,29 +121,24 @@ Examples:
 		return
 	}
 
-	// Human-readable output
-	fmt.Printf("Found %d results\n\n", len(result.Rows))
+	writeQueryTable(os.Stdout, result)
+}
 
-	if len(result.Rows) == 0 {
-		fmt.Println("No results.")
-		return
-	}
-
-	// Print headers
-	if len(result.Headers) > 0 {
-		fmt.Println(strings.Join(result.Headers, "\t"))
-		fmt.Println(strings.Repeat("-", 60))
-	}
+// queryFunc runs a CozoScript script and returns its result.
+type queryFunc func(ctx context.Context, script string) (*storage.QueryResult, error)
 
-	// Print rows
-	for _, row := range result.Rows {
-		vals := make([]string, len(row))
-		for i, v := range row {
-			vals[i] = fmt.Sprintf("%v", v)
-			if len(vals[i]) > 80 {
-				vals[i] = vals[i][:80] + "..."
-			}
+// guardedQuery returns a queryFunc that runs read-only scripts with query.
+// Scripts that modify the database run with execute if allowWrite is set,
+// and are rejected otherwise.
+func guardedQuery(query, execute queryFunc, allowWrite bool) queryFunc {
+	return func(ctx context.Context, script string) (*storage.QueryResult, error) {
+		ops := storage.WriteOps(script)
+		if len(ops) == 0 {
+			return query(ctx, script)
+		}
+		if !allowWrite {
+			return nil, fmt.Errorf("script modifies the database (%s); rerun with --allow-write to allow it", strings.Join(ops, ", "))
 		}
-		fmt.Println(strings.Join(vals, "\t"))
+		return execute(ctx, script)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,346 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/kraklabs/mie/pkg/storage"
+)
+
+// maxQueryHistory is the number of scripts kept in the REPL history file.
+const maxQueryHistory = 500
+
+// maxCellWidth is the number of characters of a value shown in a table cell.
+const maxCellWidth = 80
+
+var cellReplacer = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ", "\t", " ")
+
+var relationNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+const replHelp = `Enter CozoScript to run it. A script spans several lines until its
+brackets are closed; end a line with ',' or ':=' to keep going, or enter
+an empty line to run what has been typed so far.
+
+Commands:
+  :tables             List the stored relations
+  :schema <relation>  Show the columns of a relation
+  :history [N]        Show the last N scripts (default 20)
+  :!N                 Run script N from the history again
+  :help               Show this help
+  :quit               Exit (or press Ctrl-D)
+`
+
+// queryREPL is the interactive loop behind 'mie query -i'.
+type queryREPL struct {
+	query   func(ctx context.Context, script string) (*storage.QueryResult, error)
+	out     io.Writer
+	json    bool
+	history []string
+	// historyPath is the file history is loaded from and appended to. Empty
+	// keeps history in memory only.
+	historyPath string
+}
+
+// run reads scripts and commands from in until EOF or :quit.
+func (r *queryREPL) run(ctx context.Context, in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	fmt.Fprintln(r.out, "MIE query REPL. Type :help for commands, :quit to exit.")
+	var buf []string
+	for {
+		if len(buf) == 0 {
+			fmt.Fprint(r.out, "mie> ")
+		} else {
+			fmt.Fprint(r.out, "...> ")
+		}
+		if !scanner.Scan() {
+			fmt.Fprintln(r.out)
+			return scanner.Err()
+		}
+		line := scanner.Text()
+
+		if len(buf) == 0 {
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" {
+				continue
+			}
+			if isREPLCommand(trimmed) {
+				if quit := r.command(ctx, trimmed); quit {
+					return nil
+				}
+				continue
+			}
+		}
+
+		if strings.TrimSpace(line) != "" {
+			buf = append(buf, line)
+			if !scriptComplete(strings.Join(buf, "\n")) {
+				continue
+			}
+		}
+		if len(buf) == 0 {
+			continue
+		}
+		script := strings.Join(buf, "\n")
+		buf = nil
+		r.addHistory(script)
+		r.execute(ctx, script)
+	}
+}
+
+// isREPLCommand reports whether line is a REPL command rather than the
+// start of a script. CozoScript query options such as ':limit' and system
+// ops such as '::relations' are left to the script.
+func isREPLCommand(line string) bool {
+	if strings.HasPrefix(line, ":!") {
+		return true
+	}
+	name, _, _ := strings.Cut(line, " ")
+	switch name {
+	case ":help", ":tables", ":schema", ":history", ":quit", ":exit":
+		return true
+	}
+	return false
+}
+
+// command runs a REPL command and reports whether the REPL should exit.
+func (r *queryREPL) command(ctx context.Context, line string) bool {
+	name, arg, _ := strings.Cut(line, " ")
+	arg = strings.TrimSpace(arg)
+
+	switch {
+	case name == ":quit" || name == ":exit":
+		return true
+	case name == ":help":
+		fmt.Fprint(r.out, replHelp)
+	case name == ":tables":
+		r.execute(ctx, "::relations")
+	case name == ":schema":
+		if !relationNamePattern.MatchString(arg) {
+			fmt.Fprintln(r.out, "Usage: :schema <relation>")
+			return false
+		}
+		r.execute(ctx, "::columns "+arg)
+	case name == ":history":
+		n := 20
+		if arg != "" {
+			v, err := strconv.Atoi(arg)
+			if err != nil || v < 1 {
+				fmt.Fprintln(r.out, "Usage: :history [N]")
+				return false
+			}
+			n = v
+		}
+		start := max(len(r.history)-n, 0)
+		for i := start; i < len(r.history); i++ {
+			fmt.Fprintf(r.out, "%4d  %s\n", i+1, strings.ReplaceAll(r.history[i], "\n", "\n      "))
+		}
+	case strings.HasPrefix(name, ":!"):
+		n, err := strconv.Atoi(strings.TrimPrefix(name, ":!"))
+		if err != nil || n < 1 || n > len(r.history) {
+			fmt.Fprintf(r.out, "No history entry %s\n", strings.TrimPrefix(name, ":!"))
+			return false
+		}
+		script := r.history[n-1]
+		fmt.Fprintln(r.out, script)
+		r.addHistory(script)
+		r.execute(ctx, script)
+	}
+	return false
+}
+
+// execute runs script and prints its result or error.
+func (r *queryREPL) execute(ctx context.Context, script string) {
+	result, err := r.query(ctx, script)
+	if err != nil {
+		fmt.Fprintf(r.out, "Query error: %v\n", err)
+		return
+	}
+	if r.json {
+		enc := json.NewEncoder(r.out)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(result)
+		return
+	}
+	writeQueryTable(r.out, result)
+}
+
+// addHistory records script in memory and in the history file.
+func (r *queryREPL) addHistory(script string) {
+	if n := len(r.history); n > 0 && r.history[n-1] == script {
+		return
+	}
+	r.history = append(r.history, script)
+	if len(r.history) > maxQueryHistory {
+		r.history = r.history[len(r.history)-maxQueryHistory:]
+	}
+	if r.historyPath == "" {
+		return
+	}
+	f, err := os.OpenFile(r.historyPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer func() { _ = f.Close() }()
+	line, _ := json.Marshal(script)
+	_, _ = fmt.Fprintf(f, "%s\n", line)
+}
+
+// loadHistory reads the history file, keeping the most recent entries and
+// rewriting the file if it has grown past maxQueryHistory.
+func (r *queryREPL) loadHistory() {
+	if r.historyPath == "" {
+		return
+	}
+	data, err := os.ReadFile(r.historyPath)
+	if err != nil {
+		return
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		var script string
+		if line == "" || json.Unmarshal([]byte(line), &script) != nil {
+			continue
+		}
+		r.history = append(r.history, script)
+	}
+	if len(r.history) <= maxQueryHistory {
+		return
+	}
+	r.history = r.history[len(r.history)-maxQueryHistory:]
+	var sb strings.Builder
+	for _, script := range r.history {
+		line, _ := json.Marshal(script)
+		sb.Write(line)
+		sb.WriteByte('\n')
+	}
+	_ = os.WriteFile(r.historyPath, []byte(sb.String()), 0600)
+}
+
+// scriptComplete reports whether script is ready to run: every bracket
+// outside string literals is closed and the last line does not end with ','
+// or ':='.
+func scriptComplete(script string) bool {
+	depth := 0
+	var quote rune
+	escaped := false
+	for _, c := range script {
+		switch {
+		case escaped:
+			escaped = false
+		case quote != 0:
+			if c == '\\' {
+				escaped = true
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+		}
+	}
+	if depth > 0 || quote != 0 {
+		return false
+	}
+	trimmed := strings.TrimSpace(script)
+	return !strings.HasSuffix(trimmed, ",") && !strings.HasSuffix(trimmed, ":=")
+}
+
+// writeQueryTable prints result as a table with aligned columns.
+func writeQueryTable(w io.Writer, result *storage.QueryResult) {
+	fmt.Fprintf(w, "Found %d results\n\n", len(result.Rows))
+	if len(result.Rows) == 0 {
+		fmt.Fprintln(w, "No results.")
+		return
+	}
+
+	cols := len(result.Headers)
+	for _, row := range result.Rows {
+		cols = max(cols, len(row))
+	}
+	widths := make([]int, cols)
+	header := make([]string, cols)
+	for i := range header {
+		if i < len(result.Headers) {
+			header[i] = result.Headers[i]
+		}
+		widths[i] = utf8.RuneCountInString(header[i])
+	}
+	cells := make([][]string, len(result.Rows))
+	for r, row := range result.Rows {
+		cells[r] = make([]string, cols)
+		for i, v := range row {
+			cells[r][i] = formatCell(v)
+			widths[i] = max(widths[i], utf8.RuneCountInString(cells[r][i]))
+		}
+	}
+
+	writeRow := func(vals []string) {
+		var sb strings.Builder
+		for i, v := range vals {
+			if i > 0 {
+				sb.WriteString("  ")
+			}
+			sb.WriteString(v)
+			if i < len(vals)-1 {
+				sb.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(v)))
+			}
+		}
+		fmt.Fprintln(w, sb.String())
+	}
+
+	if len(result.Headers) > 0 {
+		writeRow(header)
+		rule := make([]string, cols)
+		for i, width := range widths {
+			rule[i] = strings.Repeat("-", width)
+		}
+		writeRow(rule)
+	}
+	for _, row := range cells {
+		writeRow(row)
+	}
+}
+
+// formatCell renders a value for a table cell on a single line, cut to
+// maxCellWidth characters.
+func formatCell(v any) string {
+	var s string
+	switch v := v.(type) {
+	case nil:
+		s = "null"
+	case string:
+		s = v
+	case []any, map[string]any:
+		data, err := json.Marshal(v)
+		if err != nil {
+			s = fmt.Sprintf("%v", v)
+		} else {
+			s = string(data)
+		}
+	default:
+		s = fmt.Sprintf("%v", v)
+	}
+	s = cellReplacer.Replace(s)
+	if utf8.RuneCountInString(s) > maxCellWidth {
+		s = string([]rune(s)[:maxCellWidth]) + "..."
+	}
+	return s
+}
@@ -0,0 +1,131 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kraklabs/mie/pkg/storage"
+)
+
+func TestScriptComplete(t *testing.T) {
+	assert.True(t, scriptComplete("?[name] := *mie_entity { name }"))
+	assert.False(t, scriptComplete("?[name] := *mie_entity {"))
+	assert.False(t, scriptComplete("?[id, content] :="))
+	assert.False(t, scriptComplete("?[id] := *mie_fact { id, valid },"))
+	assert.True(t, scriptComplete(`?[x] := x = "a { b"`))
+	assert.False(t, scriptComplete(`?[x] := x = "unterminated`))
+}
+
+func TestWriteQueryTable(t *testing.T) {
+	var out bytes.Buffer
+	writeQueryTable(&out, &storage.QueryResult{
+		Headers: []string{"name", "kind"},
+		Rows: [][]any{
+			{"Kraklabs", "company"},
+			{"Bun", "technology"},
+			{"line\nbreak", nil},
+		},
+	})
+	assert.Equal(t, `Found 3 results
+
+name        kind
+----------  ----------
+Kraklabs    company
+Bun         technology
+line break  null
+`, out.String())
+}
+
+func TestGuardedQuery(t *testing.T) {
+	var ran []string
+	record := func(kind string) queryFunc {
+		return func(ctx context.Context, script string) (*storage.QueryResult, error) {
+			ran = append(ran, kind)
+			return &storage.QueryResult{}, nil
+		}
+	}
+	ctx := context.Background()
+
+	readOnly := guardedQuery(record("query"), record("execute"), false)
+	_, err := readOnly(ctx, "?[name] := *mie_entity { name }")
+	require.NoError(t, err)
+	_, err = readOnly(ctx, "::remove mie_fact")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "::remove")
+	assert.Contains(t, err.Error(), "--allow-write")
+	assert.Equal(t, []string{"query"}, ran)
+
+	ran = nil
+	writable := guardedQuery(record("query"), record("execute"), true)
+	_, err = writable(ctx, "?[id] <- [['fact:1']] :rm mie_fact { id }")
+	require.NoError(t, err)
+	_, err = writable(ctx, "?[id] := *mie_fact { id }")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"execute", "query"}, ran)
+}
+
+func TestQueryREPL(t *testing.T) {
+	historyPath := filepath.Join(t.TempDir(), "query_history")
+	var scripts []string
+	var out bytes.Buffer
+	repl := &queryREPL{
+		query: func(ctx context.Context, script string) (*storage.QueryResult, error) {
+			scripts = append(scripts, script)
+			if strings.HasPrefix(script, "bad") {
+				return nil, errors.New("parse error")
+			}
+			return &storage.QueryResult{Headers: []string{"n"}, Rows: [][]any{{float64(1)}}}, nil
+		},
+		out:         &out,
+		historyPath: historyPath,
+	}
+
+	input := strings.Join([]string{
+		"?[n] := *mie_fact {",
+		"  id },",
+		"  n = 1",
+		":tables",
+		":schema mie_fact",
+		":schema bad name",
+		"bad script",
+		"",
+		":!1",
+		":history",
+		":quit",
+		"?[n] := n = 2",
+	}, "\n")
+	require.NoError(t, repl.run(context.Background(), strings.NewReader(input)))
+
+	assert.Equal(t, []string{
+		"?[n] := *mie_fact {\n  id },\n  n = 1",
+		"::relations",
+		"::columns mie_fact",
+		"bad script",
+		"?[n] := *mie_fact {\n  id },\n  n = 1",
+	}, scripts)
+	assert.Contains(t, out.String(), "Usage: :schema <relation>")
+	assert.Contains(t, out.String(), "Query error: parse error")
+	assert.Contains(t, out.String(), "   2  bad script")
+
+	data, err := os.ReadFile(historyPath)
+	require.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(string(data), "\n"), "history file: %s", data)
+
+	reloaded := &queryREPL{historyPath: historyPath}
+	reloaded.loadHistory()
+	assert.Equal(t, repl.history, reloaded.history)
+}
@@ -7,51 +7,80 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 
 	flag "github.com/spf13/pflag"
-)
-
-// runReset deletes all local memory data for the current MIE instance.
-func runReset(args []string, configPath string, globals GlobalFlags) {
-	fs := flag.NewFlagSet("reset", flag.ExitOnError)
-	confirm := fs.Bool("yes", false, "Confirm the reset (required)")
-
-	fs.Usage = func() {
-		fmt.Fprintf(os.Stderr, `Usage: mie reset [options]
-
-Description:
-  WARNING: This is a destructive operation that deletes all memory data.
-
-  Removes the MIE database file. This deletes all stored facts, decisions,
-  entities, events, topics, and relationships.
 
-  Configuration (.mie/config.yaml) is NOT deleted.
-
-Options:
-`)
-		fs.PrintDefaults()
-		fmt.Fprintf(os.Stderr, `
-Examples:
-  mie reset --yes       Delete all memory data
-
-Notes:
-  After resetting, the database will be recreated automatically when
-  the MCP server starts again.
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
 
-`)
-	}
+// resetCommand is mie reset.
+var resetCommand = command{
+	name:    "reset",
+	summary: "Delete all or selected memory data (destructive!)",
+	usage:   "[options]",
+	help: `  WARNING: This is a destructive operation that deletes memory data.
+
+  Without filters, removes the MIE database file. This deletes all stored
+  facts, decisions, entities, events, topics, and relationships in every
+  namespace.
+
+  With --node-type, --category, --source-agent, or --namespace, deletes
+  only the matching nodes of one namespace, together with their edges,
+  embeddings, and aliases. The namespace defaults to the current one.
+  Snapshots and the audit log are kept.
+
+  Configuration (.mie/config.yaml) is NOT deleted. After a full reset,
+  the database will be recreated automatically when the MCP server starts
+  again.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.Bool("yes", false, "Confirm the reset (required unless --dry-run)")
+		fs.StringSlice("node-type", nil, "Only delete nodes of these types (fact, decision, entity, event, topic)")
+		fs.String("category", "", "Only delete facts of this category")
+		fs.String("source-agent", "", "Only delete nodes stored by this agent")
+		fs.String("namespace", "", "Only delete nodes of this namespace")
+		fs.Bool("dry-run", false, "With filters, report what would be deleted without deleting it")
+	},
+	examples: `  mie reset --yes                                  Delete all memory data
+  mie reset --source-agent rogue-bot --dry-run     Show what rogue-bot stored
+  mie reset --source-agent rogue-bot --yes         Delete it
+  mie reset --category preference --yes            Delete preference facts
+  mie reset --node-type event,topic --yes          Delete all events and topics
+  mie reset --namespace old-project --yes          Empty one namespace`,
+	run: runReset,
+}
 
-	if err := fs.Parse(args); err != nil {
+// runReset deletes all local memory data for the current MIE instance, or
+// only the nodes selected by its filters.
+func runReset(fs *flag.FlagSet, configPath string, globals GlobalFlags) {
+	confirm, _ := fs.GetBool("yes")
+	nodeTypes, _ := fs.GetStringSlice("node-type")
+	category, _ := fs.GetString("category")
+	sourceAgent, _ := fs.GetString("source-agent")
+	namespace, _ := fs.GetString("namespace")
+	dryRun, _ := fs.GetBool("dry-run")
+
+	selective := len(nodeTypes) > 0 || category != "" || sourceAgent != "" || namespace != ""
+	if dryRun && !selective {
+		fmt.Fprintf(os.Stderr, "Error: --dry-run needs a filter (--node-type, --category, --source-agent, or --namespace)\n")
 		os.Exit(1)
 	}
-
-	if !*confirm {
+	if !confirm && !dryRun {
 		fmt.Fprintf(os.Stderr, "Error: the --yes flag is required to confirm this destructive operation\n")
 		fmt.Fprintf(os.Stderr, "Run 'mie reset --yes' to confirm\n")
 		os.Exit(1)
 	}
+	if namespace != "" {
+		if err := tools.ValidateNamespace(namespace); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitConfig)
+		}
+		globals.Namespace = namespace
+	}
 
 	cfg, err := LoadConfig(configPath)
 	if err != nil {
@@ -72,6 +101,16 @@ Notes:
 		return
 	}
 
+	if selective {
+		resetSelected(cfg, dataDir, globals, memory.ResetOptions{
+			NodeTypes:   nodeTypes,
+			Category:    category,
+			SourceAgent: sourceAgent,
+			DryRun:      dryRun,
+		})
+		return
+	}
+
 	if !globals.Quiet {
 		fmt.Printf("Deleting memory data at %s...\n", dataDir)
 	}
@@ -88,3 +127,55 @@ Notes:
 		fmt.Println("  mie --mcp    Start MCP server (database will be recreated)")
 	}
 }
+
+// resetSelected deletes the nodes opts selects from the database in dataDir.
+func resetSelected(cfg *Config, dataDir string, globals GlobalFlags, opts memory.ResetOptions) {
+	client, err := memory.NewClient(memory.ClientConfig{
+		DataDir:       dataDir,
+		StorageEngine: cfg.Storage.Engine,
+		Namespace:     globals.resolveNamespace(cfg),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: cannot open database: %v\n", err)
+		printLockHint(dataDir)
+		os.Exit(ExitDatabase)
+	}
+	defer func() { _ = client.Close() }()
+
+	ctx := tools.WithAuditSource(context.Background(), "mie reset", "")
+	result, err := client.Reset(ctx, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitQuery)
+	}
+
+	if globals.JSON {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitGeneral)
+		}
+		fmt.Println(string(data))
+		return
+	}
+	if globals.Quiet {
+		return
+	}
+	if result.Total() == 0 {
+		fmt.Printf("No matching nodes in namespace %s.\n", result.Namespace)
+		return
+	}
+	verb := "Deleted"
+	if result.DryRun {
+		verb = "Would delete"
+	}
+	fmt.Printf("%s %d nodes from namespace %s:\n", verb, result.Total(), result.Namespace)
+	for _, nt := range []string{"fact", "decision", "entity", "event", "topic"} {
+		if n := result.Deleted[nt]; n > 0 {
+			fmt.Printf("  %-10s  %d\n", nt, n)
+		}
+	}
+	if result.DryRun {
+		fmt.Println("\nRun again with --yes instead of --dry-run to delete them.")
+	}
+}
@@ -0,0 +1,172 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// searchCommand is mie search.
+var searchCommand = command{
+	name:    "search",
+	summary: "List, run, or delete saved searches",
+	usage:   "<list | run NAME | delete NAME> [options]",
+	help: `  Work with searches that agents saved through mie_query with
+  action=save. 'run' repeats a saved search with its mode, filters,
+  and node types and prints the results.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.Int("limit", 0, "With run, return at most this many results instead of the saved limit")
+	},
+	examples: `  mie search list                      Show saved searches
+  mie search run open-auth-decisions   Run a saved search
+  mie search run db --limit 50 --json  Run with more results, as JSON
+  mie search delete db                 Remove a saved search`,
+	run: runSearch,
+}
+
+// runSearch lists, runs, or deletes searches saved with mie_query
+// action=save.
+func runSearch(fs *flag.FlagSet, configPath string, globals GlobalFlags) {
+	limit, _ := fs.GetInt("limit")
+
+	rest := fs.Args()
+	if len(rest) == 0 {
+		rest = []string{"list"}
+	}
+	action := rest[0]
+	var name string
+	switch action {
+	case "list":
+		if len(rest) != 1 {
+			fs.Usage()
+			os.Exit(ExitGeneral)
+		}
+	case "run", "delete":
+		if len(rest) != 2 || rest[1] == "" {
+			fs.Usage()
+			os.Exit(ExitGeneral)
+		}
+		name = rest[1]
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown search action %q (want list, run, or delete)\n", action)
+		os.Exit(ExitGeneral)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		cfg = DefaultConfig()
+		cfg.applyEnvOverrides()
+	}
+
+	dataDir, err := ResolveDataDir(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+
+	if _, err := os.Stat(dataDir); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Error: no data found at %s\n", dataDir)
+		os.Exit(ExitDatabase)
+	}
+
+	client, err := memory.NewClient(memory.ClientConfig{
+		DataDir:                   dataDir,
+		StorageEngine:             cfg.Storage.Engine,
+		Namespace:                 globals.resolveNamespace(cfg),
+		EmbeddingEnabled:          cfg.Embedding.Enabled,
+		EmbeddingProvider:         cfg.Embedding.Provider,
+		EmbeddingBaseURL:          cfg.Embedding.BaseURL,
+		EmbeddingModel:            cfg.Embedding.Model,
+		EmbeddingAPIKey:           cfg.Embedding.APIKey,
+		EmbeddingDimensions:       cfg.Embedding.Dimensions,
+		EmbeddingWorkers:          cfg.Embedding.Workers,
+		EmbeddingFallbackProvider: cfg.Embedding.FallbackProvider,
+		EmbeddingFallbackBaseURL:  cfg.Embedding.FallbackBaseURL,
+		EmbeddingFallbackModel:    cfg.Embedding.FallbackModel,
+		EmbeddingFallbackAPIKey:   cfg.Embedding.FallbackAPIKey,
+		VectorIndex:               cfg.Embedding.vectorIndex(),
+		DecayHalfLifeDays:         cfg.Memory.Decay.HalfLifeDays,
+		RankingWeights:            cfg.Memory.Ranking.weights(),
+		MinSimilarity:             cfg.Memory.MinSimilarity,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: cannot open database: %v\n", err)
+		printLockHint(dataDir)
+		os.Exit(ExitDatabase)
+	}
+	defer func() { _ = client.Close() }()
+
+	ctx := context.Background()
+	switch action {
+	case "list":
+		searches, err := client.ListSavedSearches(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitQuery)
+		}
+		if globals.JSON {
+			if searches == nil {
+				searches = []tools.SavedSearch{}
+			}
+			data, err := json.MarshalIndent(searches, "", "  ")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(ExitGeneral)
+			}
+			fmt.Println(string(data))
+			return
+		}
+		if len(searches) == 0 {
+			if !globals.Quiet {
+				fmt.Println("No saved searches.")
+			}
+			return
+		}
+		fmt.Print(tools.FormatSavedSearchTable(searches))
+
+	case "run":
+		search, err := client.GetSavedSearch(ctx, name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitQuery)
+		}
+		overrides := map[string]any{}
+		if limit > 0 {
+			overrides["limit"] = limit
+		}
+		if globals.JSON {
+			overrides["response_format"] = tools.FormatJSON
+		}
+		result, err := tools.Query(ctx, client, tools.SavedSearchArgs(search, overrides))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitQuery)
+		}
+		if result.IsError {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", result.Text)
+			os.Exit(ExitQuery)
+		}
+		fmt.Println(result.Text)
+
+	case "delete":
+		if err := client.DeleteSavedSearch(ctx, name); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitQuery)
+		}
+		if !globals.Quiet && !globals.JSON {
+			fmt.Printf("Deleted saved search %q\n", name)
+		}
+	}
+}
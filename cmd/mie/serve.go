@@ -0,0 +1,216 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/kraklabs/mie/pkg/api"
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// serveCommand is mie serve.
+var serveCommand = command{
+	name:    "serve",
+	summary: "Serve the memory graph over a REST API",
+	usage:   "[options]",
+	help: `  Serve the memory graph over a REST API for dashboards and scripts.
+  The API has no authentication; bind to a non-loopback address only
+  on trusted networks.
+
+  With server.tenants in the config, one process serves a separate
+  memory graph per tenant. Each request sends its tenant's API key as
+  "Authorization: Bearer KEY" or "X-MIE-API-Key: KEY", and a tenant's
+  graph is opened on its first request. GET /admin/tenants, with
+  server.admin_key (or MIE_ADMIN_KEY), lists the tenants.
+
+Endpoints:
+  GET  /health            Liveness check
+  GET  /stats             Graph statistics
+  GET  /search?q=...      Search (mode, types, limit)
+  GET  /facts             List facts (also /decisions, /entities, /events, /topics)
+  POST /facts             Store a fact
+  POST /sync              Import changes sent by mie sync
+  GET  /nodes/{id}        Fetch a node by ID
+  GET  /admin/tenants     List tenants (multi-tenant mode, admin key)`,
+	flags: func(fs *flag.FlagSet) {
+		fs.String("http", "127.0.0.1:8080", "Address to listen on")
+	},
+	examples: `  mie serve                               Listen on 127.0.0.1:8080
+  mie serve --http :9090                  Listen on all interfaces, port 9090
+  curl 'localhost:8080/search?q=postgres'`,
+	run: runServe,
+}
+
+// runServe serves the memory graph over a plain HTTP/JSON API.
+func runServe(fs *flag.FlagSet, configPath string, globals GlobalFlags) {
+	addr, _ := fs.GetString("http")
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		cfg = DefaultConfig()
+		cfg.applyEnvOverrides()
+	}
+
+	dataDir, err := ResolveDataDir(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+
+	if err := os.MkdirAll(dataDir, 0750); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: cannot create data directory %s: %v\n", dataDir, err)
+		os.Exit(ExitDatabase)
+	}
+
+	level := slog.LevelWarn
+	if globals.Verbose > 0 {
+		level = slog.LevelInfo
+	}
+	logger := cfg.Log.newLogger(os.Stderr, level)
+	namespace := globals.resolveNamespace(cfg)
+
+	var handler http.Handler
+	if len(cfg.Server.Tenants) > 0 {
+		tenantDirs := make(map[string]string, len(cfg.Server.Tenants))
+		keys := make(map[string]string, len(cfg.Server.Tenants))
+		for _, t := range cfg.Server.Tenants {
+			tenantDirs[t.Name] = t.DataDir
+			if tenantDirs[t.Name] == "" {
+				tenantDirs[t.Name] = filepath.Join(dataDir, "tenants", t.Name)
+			}
+			keys[t.APIKey] = t.Name
+		}
+		tenants := api.NewTenantServer(keys, cfg.Server.AdminKey, func(name string) (tools.Querier, error) {
+			dir := tenantDirs[name]
+			if err := os.MkdirAll(dir, 0750); err != nil {
+				return nil, fmt.Errorf("create data directory %s: %w", dir, err)
+			}
+			client, err := openServeClient(cfg, dir, namespace, logger)
+			if err != nil {
+				return nil, err
+			}
+			if cfg.Embedding.Enabled {
+				for _, w := range client.CheckEmbeddings(context.Background()) {
+					logger.Warn("embedding provider check", "tenant", name, "problem", w)
+				}
+			}
+			return client, nil
+		}, logger)
+		defer func() { _ = tenants.Close() }()
+		handler = tenants
+	} else {
+		client, err := openServeClient(cfg, dataDir, namespace, logger)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: cannot initialize MIE: %v\n", err)
+			os.Exit(ExitDatabase)
+		}
+		defer func() { _ = client.Close() }()
+
+		if stopLocal, err := serveLocal(dataDir, client, false, logger); err != nil {
+			logger.Warn("CLI commands cannot reach this server", "error", err)
+		} else {
+			defer stopLocal()
+		}
+
+		if cfg.Embedding.Enabled {
+			go func() {
+				for _, w := range client.CheckEmbeddings(context.Background()) {
+					logger.Warn("embedding provider check", "problem", w)
+				}
+			}()
+		}
+		handler = api.NewServer(client, logger)
+	}
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.ListenAndServe() }()
+
+	if !globals.Quiet {
+		fmt.Fprintf(os.Stderr, "MIE REST API listening on http://%s\n", addr)
+		fmt.Fprintf(os.Stderr, "  Storage: %s (%s)\n", cfg.Storage.Engine, dataDir)
+		if len(cfg.Server.Tenants) > 0 {
+			fmt.Fprintf(os.Stderr, "  Tenants: %d (opened on first request)\n", len(cfg.Server.Tenants))
+		}
+	}
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitGeneral)
+		}
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: shutdown: %v\n", err)
+		}
+	}
+}
+
+// openServeClient opens the memory graph in dataDir for mie serve and starts
+// its background work: embedding backfill, the fact expiry sweep, and the
+// replay of writes a previous run left pending.
+func openServeClient(cfg *Config, dataDir, namespace string, logger *slog.Logger) (*memory.Client, error) {
+	client, err := memory.NewClientWithLogger(memory.ClientConfig{
+		DataDir:                   dataDir,
+		StorageEngine:             cfg.Storage.Engine,
+		Namespace:                 namespace,
+		EmbeddingEnabled:          cfg.Embedding.Enabled,
+		EmbeddingProvider:         cfg.Embedding.Provider,
+		EmbeddingBaseURL:          cfg.Embedding.BaseURL,
+		EmbeddingModel:            cfg.Embedding.Model,
+		EmbeddingAPIKey:           cfg.Embedding.APIKey,
+		EmbeddingDimensions:       cfg.Embedding.Dimensions,
+		EmbeddingWorkers:          cfg.Embedding.Workers,
+		EmbeddingFallbackProvider: cfg.Embedding.FallbackProvider,
+		EmbeddingFallbackBaseURL:  cfg.Embedding.FallbackBaseURL,
+		EmbeddingFallbackModel:    cfg.Embedding.FallbackModel,
+		EmbeddingFallbackAPIKey:   cfg.Embedding.FallbackAPIKey,
+		VectorIndex:               cfg.Embedding.vectorIndex(),
+		DedupThreshold:            cfg.Dedup.threshold(),
+		DecayHalfLifeDays:         cfg.Memory.Decay.HalfLifeDays,
+		RankingWeights:            cfg.Memory.Ranking.weights(),
+		MinSimilarity:             cfg.Memory.MinSimilarity,
+		SearchCacheSize:           cfg.Memory.SearchCache.size(),
+		SearchCacheTTL:            cfg.Memory.SearchCache.TTL,
+		AutoLink:                  cfg.Memory.AutoLink,
+		CategoryPolicies:          cfg.categoryPolicies(),
+		ExtraEntityKinds:          cfg.Schema.ExtraEntityKinds,
+		ExtraFactCategories:       cfg.Schema.ExtraFactCategories,
+		Limits:                    cfg.Memory.Limits.limits(),
+	}, logger)
+	if err != nil {
+		return nil, err
+	}
+	client.StartBackfill()
+	client.StartExpirySweep(memory.DefaultExpirySweepInterval)
+	replayIntents(client, logger)
+	return client, nil
+}
@@ -0,0 +1,164 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// snapshotCommand is mie snapshot.
+var snapshotCommand = command{
+	name:    "snapshot",
+	summary: "Create, list, or restore snapshots of the graph",
+	usage:   "<create LABEL | list | restore ID | delete ID>",
+	help: `  Record labeled snapshots of the memory graph and revert to them. A
+  snapshot holds every node and relationship of the namespace. Restoring
+  first snapshots the current graph, so a restore can itself be undone.
+  Run 'mie embed --backfill' after a restore to regenerate embeddings.`,
+	examples: `  mie snapshot create "before import"   Checkpoint the graph
+  mie snapshot list                     Show snapshots, newest first
+  mie snapshot restore snap:abc123      Revert the graph to a snapshot
+  mie snapshot delete snap:abc123       Remove a snapshot`,
+	run: runSnapshot,
+}
+
+// runSnapshot creates, lists, restores, or deletes snapshots of the memory
+// graph.
+func runSnapshot(fs *flag.FlagSet, configPath string, globals GlobalFlags) {
+	rest := fs.Args()
+	if len(rest) == 0 {
+		rest = []string{"list"}
+	}
+	action := rest[0]
+	var arg string
+	switch action {
+	case "list":
+		if len(rest) != 1 {
+			fs.Usage()
+			os.Exit(ExitGeneral)
+		}
+	case "create", "restore", "delete":
+		if len(rest) != 2 || strings.TrimSpace(rest[1]) == "" {
+			fs.Usage()
+			os.Exit(ExitGeneral)
+		}
+		arg = strings.TrimSpace(rest[1])
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown snapshot action %q (want create, list, restore, or delete)\n", action)
+		os.Exit(ExitGeneral)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		cfg = DefaultConfig()
+		cfg.applyEnvOverrides()
+	}
+
+	dataDir, err := ResolveDataDir(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+
+	if _, err := os.Stat(dataDir); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Error: no data found at %s\n", dataDir)
+		os.Exit(ExitDatabase)
+	}
+
+	client, err := memory.NewClient(memory.ClientConfig{
+		DataDir:       dataDir,
+		StorageEngine: cfg.Storage.Engine,
+		Namespace:     globals.resolveNamespace(cfg),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: cannot open database: %v\n", err)
+		printLockHint(dataDir)
+		os.Exit(ExitDatabase)
+	}
+	defer func() { _ = client.Close() }()
+
+	ctx := tools.WithAuditSource(context.Background(), "mie snapshot", "")
+	var result any
+	switch action {
+	case "create":
+		snap, err := client.CreateSnapshot(ctx, arg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitQuery)
+		}
+		result = snap
+		if !globals.JSON && !globals.Quiet {
+			fmt.Printf("Created snapshot %s %q (%s)\n", snap.ID, snap.Label, tools.FormatSnapshotStats(snap.Stats))
+		}
+
+	case "list":
+		snapshots, err := client.ListSnapshots(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitQuery)
+		}
+		if snapshots == nil {
+			snapshots = []tools.Snapshot{}
+		}
+		result = snapshots
+		if !globals.JSON {
+			if len(snapshots) == 0 && !globals.Quiet {
+				fmt.Println("No snapshots.")
+			}
+			for _, s := range snapshots {
+				fmt.Printf("%s  %-24s  %s  %s\n",
+					time.Unix(s.CreatedAt, 0).Local().Format("2006-01-02 15:04:05"),
+					s.ID, s.Label, tools.FormatSnapshotStats(s.Stats))
+			}
+		}
+
+	case "restore":
+		checkpoint, err := client.RestoreSnapshot(ctx, arg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			if checkpoint != nil {
+				fmt.Fprintf(os.Stderr, "The graph before the restore was saved as %s.\n", checkpoint.ID)
+			}
+			os.Exit(ExitQuery)
+		}
+		result = map[string]any{"snapshot_id": arg, "checkpoint": checkpoint}
+		if !globals.JSON && !globals.Quiet {
+			fmt.Printf("Restored snapshot %s\n", arg)
+			fmt.Printf("The previous graph was saved as %s; restore it to undo.\n", checkpoint.ID)
+			fmt.Println("Run 'mie embed --backfill' to regenerate embeddings.")
+		}
+
+	case "delete":
+		if err := client.DeleteSnapshot(ctx, arg); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitQuery)
+		}
+		result = map[string]any{"deleted": arg}
+		if !globals.JSON && !globals.Quiet {
+			fmt.Printf("Deleted snapshot %s\n", arg)
+		}
+	}
+
+	if globals.JSON {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitGeneral)
+		}
+		fmt.Println(string(data))
+	}
+}
@@ -0,0 +1,186 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/kraklabs/mie/pkg/memory"
+)
+
+// maxStatsDays bounds --period.
+const maxStatsDays = 365
+
+// sparkLevels are the bars of a sparkline, lowest first.
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// statsCommand is mie stats.
+var statsCommand = command{
+	name:    "stats",
+	summary: "Chart growth and usage over recent days",
+	usage:   "[options]",
+	help: `  Chart how the memory graph grew and was used over recent days: nodes per
+  day, writes per day from the audit log, queries per day, and the topics
+  that gained the most links. Days are UTC. Queries are counted per day
+  from schema version 16 on, across all namespaces.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.String("period", "30d", "Days to chart, as Nd or Nw (e.g. 7d, 4w)")
+		fs.Int("top", 5, "Number of most active topics to list")
+	},
+	examples: `  mie stats                      Last 30 days
+  mie stats --period 7d          Last week
+  mie stats --period 12w --json  Last 12 weeks, day by day, as JSON`,
+	run: runStats,
+}
+
+// runStats charts the activity of the memory graph over recent days.
+func runStats(fs *flag.FlagSet, configPath string, globals GlobalFlags) {
+	period, _ := fs.GetString("period")
+	top, _ := fs.GetInt("top")
+
+	days, err := parseStatsPeriod(period)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid --period: %v\n", err)
+		os.Exit(ExitGeneral)
+	}
+	if top < 0 {
+		fmt.Fprintf(os.Stderr, "Error: --top must not be negative\n")
+		os.Exit(ExitGeneral)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		cfg = DefaultConfig()
+		cfg.applyEnvOverrides()
+	}
+
+	dataDir, err := ResolveDataDir(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+
+	if _, err := os.Stat(dataDir); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Error: no data found at %s\n", dataDir)
+		os.Exit(ExitDatabase)
+	}
+
+	client, err := memory.NewClient(memory.ClientConfig{
+		DataDir:       dataDir,
+		StorageEngine: cfg.Storage.Engine,
+		Namespace:     globals.resolveNamespace(cfg),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: cannot open database: %v\n", err)
+		printLockHint(dataDir)
+		os.Exit(ExitDatabase)
+	}
+	defer func() { _ = client.Close() }()
+
+	trend, err := client.UsageTrend(context.Background(), days, top)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitQuery)
+	}
+
+	if globals.JSON {
+		data, err := json.MarshalIndent(trend, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitGeneral)
+		}
+		fmt.Println(string(data))
+		return
+	}
+	printStats(trend)
+}
+
+// parseStatsPeriod returns the days of a period such as "30d" or "4w".
+func parseStatsPeriod(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 {
+		return 0, fmt.Errorf("%q is not a number of days (Nd) or weeks (Nw)", s)
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%q is not a number of days (Nd) or weeks (Nw)", s)
+	}
+	switch s[len(s)-1] {
+	case 'd':
+	case 'w':
+		n *= 7
+	default:
+		return 0, fmt.Errorf("%q is not a number of days (Nd) or weeks (Nw)", s)
+	}
+	if n > maxStatsDays {
+		return 0, fmt.Errorf("%q is longer than %d days", s, maxStatsDays)
+	}
+	return n, nil
+}
+
+func printStats(trend *memory.UsageTrend) {
+	days := trend.Days
+	fmt.Printf("MIE Activity, %s to %s (UTC)\n\n", days[0].Date, days[len(days)-1].Date)
+
+	var nodes, created, writes, queries []int
+	for _, d := range days {
+		nodes = append(nodes, d.Nodes)
+		created = append(created, d.Created)
+		writes = append(writes, d.Writes)
+		queries = append(queries, d.Queries)
+	}
+	last := days[len(days)-1]
+	fmt.Printf("  Nodes:    %s  %d (+%d)\n", sparkline(nodes), last.Nodes, sum(created))
+	fmt.Printf("  Created:  %s  %d total, %d today\n", sparkline(created), sum(created), last.Created)
+	fmt.Printf("  Writes:   %s  %d total, %d today\n", sparkline(writes), sum(writes), last.Writes)
+	fmt.Printf("  Queries:  %s  %d total, %d today\n", sparkline(queries), sum(queries), last.Queries)
+
+	if len(trend.TopTopics) > 0 {
+		fmt.Println()
+		fmt.Println("Most active topics:")
+		for _, t := range trend.TopTopics {
+			fmt.Printf("  %-24s %d new links\n", t.Name, t.Links)
+		}
+	}
+}
+
+// sparkline draws values as one bar per value, scaled between the lowest
+// and the highest value.
+func sparkline(values []int) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	var sb strings.Builder
+	for _, v := range values {
+		level := 0
+		if hi > lo {
+			level = (v - lo) * (len(sparkLevels) - 1) / (hi - lo)
+		}
+		sb.WriteRune(sparkLevels[level])
+	}
+	return sb.String()
+}
+
+// sum adds up values.
+func sum(values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
@@ -0,0 +1,31 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStatsPeriod(t *testing.T) {
+	for in, want := range map[string]int{"30d": 30, "1d": 1, "4w": 28, " 7d ": 7} {
+		got, err := parseStatsPeriod(in)
+		assert.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	for _, in := range []string{"", "d", "30", "0d", "-2d", "3m", "53w"} {
+		_, err := parseStatsPeriod(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "▁▄█", sparkline([]int{0, 5, 10}))
+	assert.Equal(t, "▁▁▁", sparkline([]int{2, 2, 2}))
+	assert.Equal(t, "", sparkline(nil))
+}
@@ -15,7 +15,9 @@ import (
 
 	flag "github.com/spf13/pflag"
 
+	"github.com/kraklabs/mie/pkg/api"
 	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
 )
 
 // StatusResult represents the memory graph status for JSON output.
@@ -23,6 +25,7 @@ type StatusResult struct {
 	StorageEngine    string    `json:"storage_engine"`
 	DataDir          string    `json:"data_dir"`
 	Connected        bool      `json:"connected"`
+	ViaServer        bool      `json:"via_server,omitempty"`
 	Facts            int       `json:"facts"`
 	ValidFacts       int       `json:"valid_facts"`
 	InvalidatedFacts int       `json:"invalidated_facts"`
@@ -32,36 +35,31 @@ type StatusResult struct {
 	Events           int       `json:"events"`
 	Topics           int       `json:"topics"`
 	Edges            int       `json:"edges"`
+	Agents           []tools.AgentStats `json:"agents,omitempty"`
 	EmbeddingsEnabled bool    `json:"embeddings_enabled"`
+	EmbeddingWarnings []string `json:"embedding_warnings,omitempty"`
+	EmbeddingQueue   *tools.EmbeddingQueueStats `json:"embedding_queue,omitempty"`
 	Timestamp        time.Time `json:"timestamp"`
 	Error            string    `json:"error,omitempty"`
 }
 
-// runStatus displays memory graph statistics.
-func runStatus(args []string, configPath string, globals GlobalFlags) {
-	fs := flag.NewFlagSet("status", flag.ExitOnError)
-
-	fs.Usage = func() {
-		fmt.Fprintf(os.Stderr, `Usage: mie status [options]
-
-Description:
-  Display the current status of the MIE memory graph including
+// statusCommand is mie status.
+var statusCommand = command{
+	name:    "status",
+	summary: "Show memory graph status",
+	usage:   "[options]",
+	help: `  Display the current status of the MIE memory graph including
   node counts, configuration, and health information.
 
 Options (inherited):
-  --json    Output as JSON
-
-Examples:
-  mie status            Show human-readable status
-  mie status --json     Output as JSON
-
-`)
-	}
-
-	if err := fs.Parse(args); err != nil {
-		os.Exit(1)
-	}
+  --json    Output as JSON`,
+	examples: `  mie status            Show human-readable status
+  mie status --json     Output as JSON`,
+	run: runStatus,
+}
 
+// runStatus displays memory graph statistics.
+func runStatus(_ *flag.FlagSet, configPath string, globals GlobalFlags) {
 	cfg, err := LoadConfig(configPath)
 	if err != nil {
 		cfg = DefaultConfig()
@@ -96,10 +94,19 @@ Examples:
 		return
 	}
 
+	ctx := context.Background()
+
+	// A running server holds the database lock; ask it instead.
+	if server := dialLocal(ctx, dataDir); server != nil {
+		statusFromServer(ctx, server, result, cfg, globals)
+		return
+	}
+
 	// Open memory client
 	client, err := memory.NewClient(memory.ClientConfig{
 		DataDir:       dataDir,
 		StorageEngine: cfg.Storage.Engine,
+		Namespace:     globals.resolveNamespace(cfg),
 	})
 	if err != nil {
 		result.Connected = false
@@ -114,7 +121,6 @@ Examples:
 	defer func() { _ = client.Close() }()
 
 	result.Connected = true
-	ctx := context.Background()
 
 	stats, err := client.GetStats(ctx)
 	if err != nil {
@@ -127,15 +133,32 @@ Examples:
 		os.Exit(ExitDatabase)
 	}
 
-	result.Facts = stats.TotalFacts
-	result.ValidFacts = stats.ValidFacts
-	result.InvalidatedFacts = stats.InvalidatedFacts
-	result.Decisions = stats.TotalDecisions
-	result.ActiveDecisions = stats.ActiveDecisions
-	result.Entities = stats.TotalEntities
-	result.Events = stats.TotalEvents
-	result.Topics = stats.TotalTopics
-	result.Edges = stats.TotalEdges
+	result.setStats(stats)
+
+	if globals.JSON {
+		outputStatusJSON(result)
+	} else {
+		printStatus(result, cfg)
+	}
+}
+
+// statusFromServer reports the status read from a server running on the
+// data directory, through its local socket.
+func statusFromServer(ctx context.Context, server *api.Client, result *StatusResult, cfg *Config, globals GlobalFlags) {
+	result.Connected = true
+	result.ViaServer = true
+
+	stats, err := server.Stats(tools.WithNamespace(ctx, globals.resolveNamespace(cfg)))
+	if err != nil {
+		result.Error = fmt.Sprintf("Cannot read stats from the running server: %v", err)
+		if globals.JSON {
+			outputStatusJSON(result)
+		} else {
+			fmt.Fprintf(os.Stderr, "Error: cannot read stats from the running server: %v\n", err)
+		}
+		os.Exit(ExitDatabase)
+	}
+	result.setStats(stats)
 
 	if globals.JSON {
 		outputStatusJSON(result)
@@ -144,6 +167,22 @@ Examples:
 	}
 }
 
+// setStats copies the graph statistics into r.
+func (r *StatusResult) setStats(stats *tools.GraphStats) {
+	r.Facts = stats.TotalFacts
+	r.ValidFacts = stats.ValidFacts
+	r.InvalidatedFacts = stats.InvalidatedFacts
+	r.Decisions = stats.TotalDecisions
+	r.ActiveDecisions = stats.ActiveDecisions
+	r.Entities = stats.TotalEntities
+	r.Events = stats.TotalEvents
+	r.Topics = stats.TotalTopics
+	r.Edges = stats.TotalEdges
+	r.Agents = stats.Agents
+	r.EmbeddingWarnings = stats.EmbeddingWarnings
+	r.EmbeddingQueue = stats.EmbeddingQueue
+}
+
 func outputStatusJSON(result *StatusResult) {
 	enc := json.NewEncoder(os.Stdout)
 	enc.SetIndent("", "  ")
@@ -163,12 +202,38 @@ func printStatus(result *StatusResult, cfg *Config) {
 	fmt.Printf("  Edges:       %d total\n", result.Edges)
 	fmt.Println()
 
+	if len(result.Agents) > 0 {
+		fmt.Println("Sources by Agent:")
+		for _, a := range result.Agents {
+			fmt.Printf("  %-12s %d nodes (%d facts, %d decisions, %d entities, %d events)\n",
+				a.Agent+":", a.Total(), a.Facts, a.Decisions, a.Entities, a.Events)
+		}
+		fmt.Println()
+	}
+
 	fmt.Println("Configuration:")
 	fmt.Printf("  Storage:     %s (%s)\n", cfg.Storage.Engine, result.DataDir)
+	if result.ViaServer {
+		fmt.Printf("  Server:      running (read through %s)\n", localSocketName)
+	}
 	if cfg.Embedding.Enabled {
 		fmt.Printf("  Embeddings:  enabled (%s, %dd)\n", cfg.Embedding.Model, cfg.Embedding.Dimensions)
+		if cfg.Embedding.FallbackProvider != "" {
+			fmt.Printf("  Fallback:    %s (%s)\n", cfg.Embedding.FallbackProvider, cfg.Embedding.FallbackModel)
+		}
+		if q := result.EmbeddingQueue; q != nil && q.Pending > 0 {
+			fmt.Printf("  Queue:       %d pending embeddings (%d retrying)\n", q.Pending, q.Retrying)
+		}
 	} else {
 		fmt.Printf("  Embeddings:  disabled\n")
 	}
 	fmt.Printf("  Schema:      v%s\n", configVersion)
+
+	if len(result.EmbeddingWarnings) > 0 {
+		fmt.Println()
+		fmt.Println("Warnings:")
+		for _, w := range result.EmbeddingWarnings {
+			fmt.Printf("  %s\n", w)
+		}
+	}
 }
\ No newline at end of file
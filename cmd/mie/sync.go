@@ -0,0 +1,198 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/kraklabs/mie/pkg/api"
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// syncStateFile records, in the data directory, when each sync target last
+// received this graph's changes.
+const syncStateFile = "sync.json"
+
+// syncCommand is mie sync.
+var syncCommand = command{
+	name:    "sync",
+	summary: "Send changes to another MIE instance",
+	usage:   "--to URL [options]",
+	help: `  Send the changes made to the memory graph since the last sync to another
+  MIE instance running 'mie serve', which imports them with POST /sync.
+  The first sync to a URL sends the whole graph; later ones send only nodes
+  created or updated, facts verified, and relationships, aliases, and
+  archive marks added since. Deletions are not carried over.
+
+  Changes are sent from and imported into the current namespace. To keep
+  two machines converged, run mie sync on each, pointing at the other.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.String("to", "", "Base URL of the receiving 'mie serve' (required)")
+		fs.String("since", "", "Send changes at or after this time instead of since the last sync (RFC 3339 or YYYY-MM-DD)")
+		fs.Bool("full", false, "Send the whole graph")
+		fs.String("api-key", os.Getenv("MIE_SYNC_API_KEY"), "API key of a multi-tenant receiver (or MIE_SYNC_API_KEY)")
+	},
+	examples: `  mie sync --to http://laptop.local:8080  Send changes since the last sync
+  mie sync --to http://laptop.local:8080 --full
+                                          Send the whole graph again`,
+	run: runSync,
+}
+
+// runSync ships the changes since the last sync to another MIE instance.
+func runSync(fs *flag.FlagSet, configPath string, globals GlobalFlags) {
+	to, _ := fs.GetString("to")
+	since, _ := fs.GetString("since")
+	full, _ := fs.GetBool("full")
+	apiKey, _ := fs.GetString("api-key")
+
+	target := strings.TrimRight(strings.TrimSpace(to), "/")
+	if target == "" {
+		fs.Usage()
+		os.Exit(ExitGeneral)
+	}
+	if full && since != "" {
+		fmt.Fprintf(os.Stderr, "Error: --full and --since are mutually exclusive\n")
+		os.Exit(ExitGeneral)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		cfg = DefaultConfig()
+		cfg.applyEnvOverrides()
+	}
+
+	dataDir, err := ResolveDataDir(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+
+	if _, err := os.Stat(dataDir); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Error: no data found at %s\n", dataDir)
+		os.Exit(ExitDatabase)
+	}
+
+	namespace := globals.resolveNamespace(cfg)
+	statePath := filepath.Join(dataDir, syncStateFile)
+	state, err := loadSyncState(statePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitGeneral)
+	}
+	key := target + " " + namespace
+
+	var sinceUnix int64
+	switch {
+	case since != "":
+		t, err := tools.ParseTimestamp(since)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid --since: %v\n", err)
+			os.Exit(ExitGeneral)
+		}
+		sinceUnix = t.Unix()
+	case !full:
+		sinceUnix = state[key]
+	}
+
+	client, err := memory.NewClient(memory.ClientConfig{
+		DataDir:       dataDir,
+		StorageEngine: cfg.Storage.Engine,
+		Namespace:     namespace,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: cannot open database: %v\n", err)
+		printLockHint(dataDir)
+		os.Exit(ExitDatabase)
+	}
+	defer func() { _ = client.Close() }()
+
+	// Start the next sync from before this export, so changes made while it
+	// runs are sent again rather than missed.
+	started := time.Now().Unix()
+	ctx := tools.WithNamespace(context.Background(), namespace)
+	data, err := client.ExportGraph(ctx, tools.ExportOptions{Format: "json", Since: sinceUnix})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitDatabase)
+	}
+
+	remote := api.NewClient(target, &http.Client{Timeout: 5 * time.Minute})
+	remote.SetAPIKey(apiKey)
+	resp, err := remote.Sync(ctx, data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: sync to %s failed: %v\n", target, err)
+		os.Exit(ExitGeneral)
+	}
+
+	state[key] = started
+	if err := saveSyncState(statePath, state); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; the next sync will resend these changes\n", err)
+	}
+
+	if globals.JSON {
+		out, err := json.MarshalIndent(map[string]any{
+			"to":        target,
+			"namespace": namespace,
+			"since":     sinceUnix,
+			"imported":  resp.Imported,
+		}, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(ExitGeneral)
+		}
+		fmt.Println(string(out))
+		return
+	}
+	if !globals.Quiet {
+		from := "the beginning"
+		if sinceUnix > 0 {
+			from = time.Unix(sinceUnix, 0).Local().Format(time.RFC3339)
+		}
+		fmt.Printf("Synced changes since %s to %s\n", from, target)
+		fmt.Printf("Imported %s\n", formatImportCounts(resp.Imported))
+	}
+}
+
+// loadSyncState reads the last sync time (Unix seconds) per target and
+// namespace. A missing file is an empty state.
+func loadSyncState(path string) (map[string]int64, error) {
+	state := map[string]int64{}
+	data, err := os.ReadFile(path) //nolint:gosec // G304: Path is in the data directory
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read sync state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parse sync state %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// saveSyncState writes the last sync times to path.
+func saveSyncState(path string, state map[string]int64) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode sync state: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("write sync state: %w", err)
+	}
+	return nil
+}
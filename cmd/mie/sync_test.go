@@ -0,0 +1,76 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kraklabs/mie/pkg/api"
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestSyncState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), syncStateFile)
+
+	state, err := loadSyncState(path)
+	require.NoError(t, err)
+	assert.Empty(t, state)
+
+	state["http://laptop:8080 default"] = 1772323200
+	require.NoError(t, saveSyncState(path, state))
+	loaded, err := loadSyncState(path)
+	require.NoError(t, err)
+	assert.Equal(t, state, loaded)
+}
+
+func TestSyncBetweenInstances(t *testing.T) {
+	open := func() *memory.Client {
+		client, err := memory.NewClient(memory.ClientConfig{DataDir: t.TempDir(), StorageEngine: "mem", EmbeddingDimensions: 384})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = client.Close() })
+		return client
+	}
+	desktop, laptop := open(), open()
+	ctx := context.Background()
+
+	ts := httptest.NewServer(api.NewServer(laptop, nil))
+	defer ts.Close()
+	remote := api.NewClient(ts.URL, nil)
+
+	old, err := desktop.StoreFact(ctx, tools.StoreFactRequest{Content: "Billing runs on Postgres", Category: "technical"})
+	require.NoError(t, err)
+	data, err := desktop.ExportGraph(ctx, tools.ExportOptions{Format: "json"})
+	require.NoError(t, err)
+	resp, err := remote.Sync(ctx, data)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.Imported["facts"])
+
+	// An incremental sync sends only what changed since the last one.
+	since := time.Now().Unix()
+	time.Sleep(1100 * time.Millisecond)
+	fresh, err := desktop.StoreFact(ctx, tools.StoreFactRequest{Content: "Alice owns billing", Category: "relationship"})
+	require.NoError(t, err)
+	data, err = desktop.ExportGraph(ctx, tools.ExportOptions{Format: "json", Since: since})
+	require.NoError(t, err)
+	resp, err = remote.Sync(ctx, data)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.Imported["facts"])
+
+	for _, id := range []string{old.ID, fresh.ID} {
+		node, err := laptop.GetNodeByID(ctx, id)
+		require.NoError(t, err, id)
+		assert.NotNil(t, node, id)
+	}
+}
@@ -0,0 +1,206 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// Topic summary resources are mie://topic/<name>/summary, with the topic
+// name path-escaped.
+const (
+	topicSummaryURIPrefix = "mie://topic/"
+	topicSummaryURISuffix = "/summary"
+)
+
+// topicSummaryData is the data the topic summary page is rendered with.
+type topicSummaryData struct {
+	Topic     *tools.Topic
+	Facts     []*tools.Fact     // valid, newest first
+	Decisions []*tools.Decision // active, newest first
+	Entities  []*tools.Entity   // by name
+	Upcoming  []*tools.Event    // not over yet, soonest first
+	Past      []*tools.Event    // most recent first
+	Today     string
+}
+
+// topicSummaryTemplate renders a topic summary as markdown.
+var topicSummaryTemplate = template.Must(template.New("topic-summary").Funcs(template.FuncMap{
+	"oneLine": func(s string) string { return strings.Join(strings.Fields(s), " ") },
+}).Parse(`# {{.Topic.Name}}
+{{if .Topic.Description}}
+{{oneLine .Topic.Description}}
+{{end}}
+_{{len .Facts}} facts, {{len .Decisions}} active decisions, {{len .Entities}} entities, {{len .Upcoming}} upcoming and {{len .Past}} past events, as of {{.Today}}._
+{{- if .Facts}}
+
+## Facts
+{{range .Facts}}
+- [{{.ID}}] {{oneLine .Content}} ({{.Category}}, confidence {{printf "%.1f" .Confidence}})
+{{- end}}
+{{- end}}
+{{- if .Decisions}}
+
+## Active Decisions
+{{range .Decisions}}
+- [{{.ID}}] **{{oneLine .Title}}**: {{oneLine .Rationale}}
+{{- end}}
+{{- end}}
+{{- if .Entities}}
+
+## Entities
+{{range .Entities}}
+- [{{.ID}}] {{.Name}} ({{.Kind}}){{if .Description}}: {{oneLine .Description}}{{end}}
+{{- end}}
+{{- end}}
+{{- if .Upcoming}}
+
+## Upcoming Events
+{{range .Upcoming}}
+- {{.Dates}} [{{.ID}}] {{oneLine .Title}}
+{{- end}}
+{{- end}}
+{{- if .Past}}
+
+## Past Events
+{{range .Past}}
+- {{.Dates}} [{{.ID}}] {{oneLine .Title}}
+{{- end}}
+{{- end}}
+`))
+
+// parseTopicSummaryURI returns the topic name of a topic summary URI, and
+// whether uri is one.
+func parseTopicSummaryURI(uri string) (string, bool) {
+	rest, ok := strings.CutPrefix(uri, topicSummaryURIPrefix)
+	if !ok {
+		return "", false
+	}
+	escaped, ok := strings.CutSuffix(rest, topicSummaryURISuffix)
+	if !ok || escaped == "" {
+		return "", false
+	}
+	name, err := url.PathUnescape(escaped)
+	if err != nil || name == "" {
+		return "", false
+	}
+	return name, true
+}
+
+// buildTopicSummary assembles the memory page of the topic named name,
+// matched case-insensitively: its valid facts, active decisions, and
+// entities, and the upcoming and past events of its decisions. It is
+// rendered from a template; no language model is involved.
+func buildTopicSummary(ctx context.Context, client tools.Querier, name string, now time.Time) (string, error) {
+	stats, err := client.GetTopicStats(ctx)
+	if err != nil {
+		return "", err
+	}
+	topicID := ""
+	for _, t := range stats {
+		if strings.EqualFold(t.Name, strings.TrimSpace(name)) {
+			topicID = t.TopicID
+		}
+	}
+	if topicID == "" {
+		return "", fmt.Errorf("topic %q not found", name)
+	}
+	node, err := client.GetNodeByID(ctx, topicID)
+	if err != nil {
+		return "", err
+	}
+	topic, ok := node.(*tools.Topic)
+	if !ok {
+		return "", fmt.Errorf("node %s is not a topic", topicID)
+	}
+
+	data := topicSummaryData{Topic: topic, Today: now.UTC().Format("2006-01-02")}
+	edges, err := client.GetNodeEdges(ctx, topicID)
+	if err != nil {
+		return "", err
+	}
+	seen := map[string]bool{}
+	for _, e := range edges {
+		if e.TargetID != topicID || seen[e.SourceID] {
+			continue
+		}
+		seen[e.SourceID] = true
+		node, err := client.GetNodeByID(ctx, e.SourceID)
+		if err != nil {
+			continue // a dangling edge
+		}
+		switch n := node.(type) {
+		case *tools.Fact:
+			if n.Valid {
+				data.Facts = append(data.Facts, n)
+			}
+		case *tools.Decision:
+			if n.Status == "active" {
+				data.Decisions = append(data.Decisions, n)
+			}
+			if err := addTopicEvents(ctx, client, n.ID, seen, &data); err != nil {
+				return "", err
+			}
+		case *tools.Entity:
+			data.Entities = append(data.Entities, n)
+		}
+	}
+
+	sort.Slice(data.Facts, func(i, j int) bool { return data.Facts[i].CreatedAt > data.Facts[j].CreatedAt })
+	sort.Slice(data.Decisions, func(i, j int) bool { return data.Decisions[i].CreatedAt > data.Decisions[j].CreatedAt })
+	sort.Slice(data.Entities, func(i, j int) bool { return data.Entities[i].Name < data.Entities[j].Name })
+	sort.Slice(data.Upcoming, func(i, j int) bool { return data.Upcoming[i].EventDate < data.Upcoming[j].EventDate })
+	sort.Slice(data.Past, func(i, j int) bool { return data.Past[i].EventDate > data.Past[j].EventDate })
+
+	var sb strings.Builder
+	if err := topicSummaryTemplate.Execute(&sb, data); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// addTopicEvents adds the events linked to the decision decisionID to the
+// upcoming or past events of data. An event is upcoming until its last day
+// has passed.
+func addTopicEvents(ctx context.Context, client tools.Querier, decisionID string, seen map[string]bool, data *topicSummaryData) error {
+	edges, err := client.GetNodeEdges(ctx, decisionID)
+	if err != nil {
+		return err
+	}
+	for _, e := range edges {
+		if e.Type != "event_decision" || seen[e.SourceID] {
+			continue
+		}
+		seen[e.SourceID] = true
+		node, err := client.GetNodeByID(ctx, e.SourceID)
+		if err != nil {
+			continue
+		}
+		evt, ok := node.(*tools.Event)
+		if !ok {
+			continue
+		}
+		last := evt.EventDate
+		if evt.EndDate != "" {
+			last = evt.EndDate
+		}
+		if last >= data.Today {
+			data.Upcoming = append(data.Upcoming, evt)
+		} else {
+			data.Past = append(data.Past, evt)
+		}
+	}
+	return nil
+}
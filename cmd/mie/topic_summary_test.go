@@ -0,0 +1,135 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// topicGraph is a tools.Querier over a fixed set of nodes and edges.
+type topicGraph struct {
+	tools.Querier
+	nodes map[string]any
+	edges []tools.GraphEdge
+}
+
+func (g *topicGraph) GetTopicStats(ctx context.Context) ([]tools.TopicStats, error) {
+	var stats []tools.TopicStats
+	for _, n := range g.nodes {
+		if t, ok := n.(*tools.Topic); ok {
+			stats = append(stats, tools.TopicStats{TopicID: t.ID, Name: t.Name})
+		}
+	}
+	return stats, nil
+}
+
+func (g *topicGraph) GetNodeByID(ctx context.Context, nodeID string) (any, error) {
+	if n, ok := g.nodes[nodeID]; ok {
+		return n, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (g *topicGraph) GetNodeEdges(ctx context.Context, nodeID string) ([]tools.GraphEdge, error) {
+	var edges []tools.GraphEdge
+	for _, e := range g.edges {
+		if e.SourceID == nodeID || e.TargetID == nodeID {
+			edges = append(edges, e)
+		}
+	}
+	return edges, nil
+}
+
+func TestBuildTopicSummary(t *testing.T) {
+	g := &topicGraph{nodes: map[string]any{
+		"top:b":  &tools.Topic{ID: "top:b", Name: "Billing", Description: "Invoices and\npayments"},
+		"fact:1": &tools.Fact{ID: "fact:1", Content: "Billing runs on Postgres", Category: "technical", Confidence: 0.9, Valid: true, CreatedAt: 1},
+		"fact:2": &tools.Fact{ID: "fact:2", Content: "Invoices are sent monthly", Category: "general", Confidence: 0.8, Valid: true, CreatedAt: 2},
+		"fact:3": &tools.Fact{ID: "fact:3", Content: "Billing runs on MySQL", Valid: false},
+		"dec:1":  &tools.Decision{ID: "dec:1", Title: "Use Stripe", Rationale: "Fewer PCI concerns", Status: "active"},
+		"dec:2":  &tools.Decision{ID: "dec:2", Title: "Build in-house", Status: "reversed"},
+		"ent:1":  &tools.Entity{ID: "ent:1", Name: "Stripe", Kind: "company"},
+		"evt:1":  &tools.Event{ID: "evt:1", Title: "Stripe launch", EventDate: "2026-03-01", EndDate: "2026-03-20"},
+		"evt:2":  &tools.Event{ID: "evt:2", Title: "Stripe contract signed", EventDate: "2026-01-10"},
+		"evt:3":  &tools.Event{ID: "evt:3", Title: "In-house prototype", EventDate: "2025-11-02"},
+	}, edges: []tools.GraphEdge{
+		{Type: "fact_topic", SourceID: "fact:1", TargetID: "top:b"},
+		{Type: "fact_topic", SourceID: "fact:2", TargetID: "top:b"},
+		{Type: "fact_topic", SourceID: "fact:3", TargetID: "top:b"},
+		{Type: "fact_topic", SourceID: "fact:gone", TargetID: "top:b"},
+		{Type: "decision_topic", SourceID: "dec:1", TargetID: "top:b"},
+		{Type: "decision_topic", SourceID: "dec:2", TargetID: "top:b"},
+		{Type: "entity_topic", SourceID: "ent:1", TargetID: "top:b"},
+		{Type: "event_decision", SourceID: "evt:1", TargetID: "dec:1"},
+		{Type: "event_decision", SourceID: "evt:2", TargetID: "dec:1"},
+		{Type: "event_decision", SourceID: "evt:3", TargetID: "dec:2"},
+	}}
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	text, err := buildTopicSummary(context.Background(), g, "billing", now)
+	require.NoError(t, err)
+	assert.Equal(t, `# Billing
+
+Invoices and payments
+
+_2 facts, 1 active decisions, 1 entities, 1 upcoming and 2 past events, as of 2026-03-15._
+
+## Facts
+
+- [fact:2] Invoices are sent monthly (general, confidence 0.8)
+- [fact:1] Billing runs on Postgres (technical, confidence 0.9)
+
+## Active Decisions
+
+- [dec:1] **Use Stripe**: Fewer PCI concerns
+
+## Entities
+
+- [ent:1] Stripe (company)
+
+## Upcoming Events
+
+- 2026-03-01..2026-03-20 [evt:1] Stripe launch
+
+## Past Events
+
+- 2026-01-10 [evt:2] Stripe contract signed
+- 2025-11-02 [evt:3] In-house prototype
+`, text)
+
+	_, err = buildTopicSummary(context.Background(), g, "hiring", now)
+	assert.ErrorContains(t, err, `topic "hiring" not found`)
+}
+
+func TestParseTopicSummaryURI(t *testing.T) {
+	tests := []struct {
+		uri  string
+		name string
+		ok   bool
+	}{
+		{"mie://topic/billing/summary", "billing", true},
+		{"mie://topic/on%20call/summary", "on call", true},
+		{"mie://topic/a%2Fb/summary", "a/b", true},
+		{"mie://topic//summary", "", false},
+		{"mie://topic/billing", "", false},
+		{"mie://changes", "", false},
+	}
+	for _, tt := range tests {
+		name, ok := parseTopicSummaryURI(tt.uri)
+		if name != tt.name || ok != tt.ok {
+			t.Errorf("parseTopicSummaryURI(%q) = %q, %v, want %q, %v", tt.uri, name, ok, tt.name, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,419 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	flag "github.com/spf13/pflag"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// tuiRecentLimit is the number of recently stored nodes the TUI shows.
+const tuiRecentLimit = 8
+
+// tuiConflictLimit is the number of conflicts the TUI shows.
+const tuiConflictLimit = 5
+
+// tuiSearchLimit is the number of search results the TUI shows.
+const tuiSearchLimit = 10
+
+// tuiCommand is mie tui.
+var tuiCommand = command{
+	name:    "tui",
+	summary: "Open a terminal dashboard of the memory graph",
+	usage:   "[options]",
+	help: `  Open a terminal dashboard of the memory graph: node and edge counts,
+  the most recently stored nodes, conflicting facts, and a search box.
+  The dashboard refreshes every --interval. Conflicts and semantic
+  search need embeddings; without them the search box runs exact search.
+
+  Keys: / search, enter run search, esc clear, r refresh, q quit.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.Duration("interval", 5*time.Second, "How often to refresh stats, recent nodes, and conflicts")
+	},
+	examples: `  mie tui                 Open the dashboard
+  mie tui --interval 30s  Refresh every 30 seconds`,
+	run: runTUI,
+}
+
+// runTUI starts the interactive terminal dashboard.
+func runTUI(fs *flag.FlagSet, configPath string, globals GlobalFlags) {
+	interval, _ := fs.GetDuration("interval")
+
+	if interval <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: --interval must be positive\n")
+		os.Exit(ExitGeneral)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		cfg = DefaultConfig()
+		cfg.applyEnvOverrides()
+	}
+
+	dataDir, err := ResolveDataDir(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+
+	if _, err := os.Stat(dataDir); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Error: no data found at %s\n", dataDir)
+		os.Exit(ExitDatabase)
+	}
+
+	client, err := memory.NewClient(memory.ClientConfig{
+		DataDir:                   dataDir,
+		StorageEngine:             cfg.Storage.Engine,
+		Namespace:                 globals.resolveNamespace(cfg),
+		EmbeddingEnabled:          cfg.Embedding.Enabled,
+		EmbeddingProvider:         cfg.Embedding.Provider,
+		EmbeddingBaseURL:          cfg.Embedding.BaseURL,
+		EmbeddingModel:            cfg.Embedding.Model,
+		EmbeddingAPIKey:           cfg.Embedding.APIKey,
+		EmbeddingDimensions:       cfg.Embedding.Dimensions,
+		EmbeddingWorkers:          cfg.Embedding.Workers,
+		EmbeddingFallbackProvider: cfg.Embedding.FallbackProvider,
+		EmbeddingFallbackBaseURL:  cfg.Embedding.FallbackBaseURL,
+		EmbeddingFallbackModel:    cfg.Embedding.FallbackModel,
+		EmbeddingFallbackAPIKey:   cfg.Embedding.FallbackAPIKey,
+		VectorIndex:               cfg.Embedding.vectorIndex(),
+		DecayHalfLifeDays:         cfg.Memory.Decay.HalfLifeDays,
+		RankingWeights:            cfg.Memory.Ranking.weights(),
+		MinSimilarity:             cfg.Memory.MinSimilarity,
+		SearchCacheSize:           cfg.Memory.SearchCache.size(),
+		SearchCacheTTL:            cfg.Memory.SearchCache.TTL,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: cannot open database: %v\n", err)
+		printLockHint(dataDir)
+		os.Exit(ExitDatabase)
+	}
+	defer func() { _ = client.Close() }()
+
+	model := newTUIModel(client, interval)
+	if _, err := tea.NewProgram(model, tea.WithAltScreen()).Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitGeneral)
+	}
+}
+
+// tuiNode is a recently stored node as the TUI lists it.
+type tuiNode struct {
+	id        string
+	nodeType  string
+	text      string
+	createdAt int64
+}
+
+// tuiRefreshMsg carries the data of one dashboard refresh.
+type tuiRefreshMsg struct {
+	at        time.Time
+	stats     *tools.GraphStats
+	recent    []tuiNode
+	conflicts []tools.Conflict
+	err       error
+}
+
+// tuiTickMsg triggers a refresh.
+type tuiTickMsg time.Time
+
+// tuiSearchMsg carries the results of a search box query.
+type tuiSearchMsg struct {
+	query   string
+	results []tools.SearchResult
+	err     error
+}
+
+// tuiModel is the bubbletea model behind 'mie tui'.
+type tuiModel struct {
+	client   tools.Querier
+	interval time.Duration
+	width    int
+
+	refreshed time.Time
+	stats     *tools.GraphStats
+	recent    []tuiNode
+	conflicts []tools.Conflict
+	err       error
+
+	// searching is true while the search box has focus.
+	searching   bool
+	query       string
+	searchedFor string
+	results     []tools.SearchResult
+	searchErr   error
+}
+
+func newTUIModel(client tools.Querier, interval time.Duration) *tuiModel {
+	return &tuiModel{client: client, interval: interval}
+}
+
+func (m *tuiModel) Init() tea.Cmd {
+	return tea.Batch(m.refresh(), m.tick())
+}
+
+func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+	case tuiTickMsg:
+		return m, tea.Batch(m.refresh(), m.tick())
+	case tuiRefreshMsg:
+		m.refreshed = msg.at
+		m.err = msg.err
+		if msg.err == nil {
+			m.stats = msg.stats
+			m.recent = msg.recent
+			m.conflicts = msg.conflicts
+		}
+	case tuiSearchMsg:
+		m.searchedFor = msg.query
+		m.results = msg.results
+		m.searchErr = msg.err
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+// handleKey edits the search box while it has focus and otherwise runs
+// the dashboard commands.
+func (m *tuiModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.Type == tea.KeyCtrlC {
+		return m, tea.Quit
+	}
+	if m.searching {
+		switch msg.Type {
+		case tea.KeyEnter:
+			m.searching = false
+			if strings.TrimSpace(m.query) == "" {
+				return m, nil
+			}
+			return m, m.search(m.query)
+		case tea.KeyEsc:
+			m.searching = false
+			m.query = ""
+		case tea.KeyBackspace:
+			if r := []rune(m.query); len(r) > 0 {
+				m.query = string(r[:len(r)-1])
+			}
+		case tea.KeySpace:
+			m.query += " "
+		case tea.KeyRunes:
+			m.query += string(msg.Runes)
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "/":
+		m.searching = true
+	case "r":
+		return m, m.refresh()
+	case "esc":
+		m.query, m.searchedFor, m.results, m.searchErr = "", "", nil, nil
+	}
+	return m, nil
+}
+
+// tick schedules the next refresh.
+func (m *tuiModel) tick() tea.Cmd {
+	return tea.Tick(m.interval, func(t time.Time) tea.Msg { return tuiTickMsg(t) })
+}
+
+// refresh reads stats, recent nodes, and conflicts.
+func (m *tuiModel) refresh() tea.Cmd {
+	client := m.client
+	return func() tea.Msg {
+		ctx := context.Background()
+		msg := tuiRefreshMsg{at: time.Now()}
+		msg.stats, msg.err = client.GetStats(ctx)
+		if msg.err != nil {
+			return msg
+		}
+		msg.recent, msg.err = recentNodes(ctx, client, tuiRecentLimit)
+		if msg.err != nil {
+			return msg
+		}
+		if client.EmbeddingsEnabled() {
+			msg.conflicts, msg.err = client.DetectConflicts(ctx, tools.ConflictOptions{Limit: tuiConflictLimit})
+		}
+		return msg
+	}
+}
+
+// search runs query through hybrid search, or exact search without
+// embeddings.
+func (m *tuiModel) search(query string) tea.Cmd {
+	client := m.client
+	return func() tea.Msg {
+		ctx := context.Background()
+		msg := tuiSearchMsg{query: query}
+		if client.EmbeddingsEnabled() {
+			msg.results, msg.err = client.HybridSearch(ctx, query, nil, tuiSearchLimit)
+		} else {
+			msg.results, msg.err = client.ExactSearch(ctx, query, nil, tuiSearchLimit)
+		}
+		return msg
+	}
+}
+
+// recentNodes returns the limit most recently stored facts, decisions,
+// entities, and events, newest first.
+func recentNodes(ctx context.Context, client tools.Querier, limit int) ([]tuiNode, error) {
+	var nodes []tuiNode
+	for _, nodeType := range []string{"fact", "decision", "entity", "event"} {
+		listed, _, err := client.ListNodes(ctx, tools.ListOptions{
+			NodeType:  nodeType,
+			Limit:     limit,
+			SortBy:    "created_at",
+			SortOrder: "desc",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list %s nodes: %w", nodeType, err)
+		}
+		for _, n := range listed {
+			nodes = append(nodes, newTUINode(n))
+		}
+	}
+	sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].createdAt > nodes[j].createdAt })
+	if len(nodes) > limit {
+		nodes = nodes[:limit]
+	}
+	return nodes, nil
+}
+
+// newTUINode converts a node returned by ListNodes.
+func newTUINode(node any) tuiNode {
+	switch n := node.(type) {
+	case *tools.Fact:
+		return tuiNode{id: n.ID, nodeType: "fact", text: n.Content, createdAt: n.CreatedAt}
+	case *tools.Decision:
+		return tuiNode{id: n.ID, nodeType: "decision", text: n.Title, createdAt: n.CreatedAt}
+	case *tools.Entity:
+		return tuiNode{id: n.ID, nodeType: "entity", text: n.Name, createdAt: n.CreatedAt}
+	case *tools.Event:
+		return tuiNode{id: n.ID, nodeType: "event", text: n.Title, createdAt: n.CreatedAt}
+	default:
+		return tuiNode{nodeType: "node", text: fmt.Sprintf("%v", node)}
+	}
+}
+
+func (m *tuiModel) View() string {
+	var sb strings.Builder
+	line := func(format string, args ...any) {
+		sb.WriteString(m.fit(fmt.Sprintf(format, args...)))
+		sb.WriteString("\n")
+	}
+
+	header := "MIE memory graph"
+	if m.stats != nil && m.stats.Namespace != "" {
+		header += " — " + m.stats.Namespace
+	}
+	if !m.refreshed.IsZero() {
+		header += fmt.Sprintf("    refreshed %s, every %s", m.refreshed.Format("15:04:05"), m.interval)
+	}
+	line("%s", header)
+	if m.err != nil {
+		line("Error: %v", m.err)
+	}
+	sb.WriteString("\n")
+
+	line("Graph")
+	if s := m.stats; s != nil {
+		line("  Facts %d (%d valid)   Decisions %d (%d active)   Entities %d   Events %d   Topics %d   Edges %d",
+			s.TotalFacts, s.ValidFacts, s.TotalDecisions, s.ActiveDecisions, s.TotalEntities, s.TotalEvents, s.TotalTopics, s.TotalEdges)
+		line("  Queries %d   Stores %d   Last store %s", s.TotalQueries, s.TotalStores, tuiTime(s.LastStoreAt))
+		for _, w := range s.EmbeddingWarnings {
+			line("  Warning: %s", w)
+		}
+	} else {
+		line("  Loading...")
+	}
+	sb.WriteString("\n")
+
+	line("Recent")
+	if len(m.recent) == 0 {
+		line("  No nodes yet.")
+	}
+	for _, n := range m.recent {
+		line("  %-8s  %-20s  %s  %s", n.nodeType, n.id, tuiTime(n.createdAt), oneLine(n.text))
+	}
+	sb.WriteString("\n")
+
+	line("Conflicts")
+	switch {
+	case !m.client.EmbeddingsEnabled():
+		line("  Conflict detection needs embeddings.")
+	case len(m.conflicts) == 0:
+		line("  No conflicting facts found.")
+	}
+	for _, c := range m.conflicts {
+		line("  %3.0f%%  %s %q  vs  %s %q", c.Similarity*100, c.FactA.ID, oneLine(c.FactA.Content), c.FactB.ID, oneLine(c.FactB.Content))
+	}
+	sb.WriteString("\n")
+
+	if m.searching {
+		line("Search: %s_", m.query)
+	} else if m.searchedFor != "" {
+		line("Search: %s", m.searchedFor)
+	} else {
+		line("Search: press / to search")
+	}
+	if m.searchErr != nil {
+		line("  Error: %v", m.searchErr)
+	} else if m.searchedFor != "" && len(m.results) == 0 {
+		line("  No results.")
+	}
+	for _, r := range m.results {
+		line("  %-8s  %-20s  %s", r.NodeType, r.ID, oneLine(r.Content))
+	}
+	sb.WriteString("\n")
+
+	if m.searching {
+		line("enter search · esc cancel · ctrl+c quit")
+	} else {
+		line("/ search · r refresh · esc clear search · q quit")
+	}
+	return sb.String()
+}
+
+// fit cuts s to the terminal width.
+func (m *tuiModel) fit(s string) string {
+	if m.width <= 0 {
+		return s
+	}
+	if r := []rune(s); len(r) > m.width {
+		return string(r[:m.width])
+	}
+	return s
+}
+
+// tuiTime formats Unix seconds as a local date and time, or "-" for 0.
+func tuiTime(at int64) string {
+	if at == 0 {
+		return "-"
+	}
+	return time.Unix(at, 0).Format("2006-01-02 15:04")
+}
+
+// oneLine collapses whitespace so multi-line text fits one row.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
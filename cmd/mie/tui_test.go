@@ -0,0 +1,102 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// tuiQuerier serves the reads of the TUI. Calling any other method panics
+// via the nil embedded interface.
+type tuiQuerier struct {
+	tools.Querier
+	nodes    map[string][]any
+	searched string
+}
+
+func (q *tuiQuerier) GetStats(ctx context.Context) (*tools.GraphStats, error) {
+	return &tools.GraphStats{TotalFacts: 3, ValidFacts: 2, TotalDecisions: 1, ActiveDecisions: 1, Namespace: "default"}, nil
+}
+
+func (q *tuiQuerier) ListNodes(ctx context.Context, opts tools.ListOptions) ([]any, int, error) {
+	return q.nodes[opts.NodeType], len(q.nodes[opts.NodeType]), nil
+}
+
+func (q *tuiQuerier) ExactSearch(ctx context.Context, query string, nodeTypes []string, limit int) ([]tools.SearchResult, error) {
+	q.searched = query
+	return []tools.SearchResult{{NodeType: "fact", ID: "fact:go", Content: "Uses Go"}}, nil
+}
+
+func (q *tuiQuerier) EmbeddingsEnabled() bool { return false }
+
+func TestRecentNodes(t *testing.T) {
+	q := &tuiQuerier{nodes: map[string][]any{
+		"fact":     {&tools.Fact{ID: "fact:new", Content: "Uses Go", CreatedAt: 300}, &tools.Fact{ID: "fact:old", CreatedAt: 100}},
+		"decision": {&tools.Decision{ID: "dec:mid", Title: "Use CozoDB", CreatedAt: 200}},
+	}}
+
+	nodes, err := recentNodes(context.Background(), q, 2)
+	require.NoError(t, err)
+	require.Len(t, nodes, 2)
+	assert.Equal(t, tuiNode{id: "fact:new", nodeType: "fact", text: "Uses Go", createdAt: 300}, nodes[0])
+	assert.Equal(t, "dec:mid", nodes[1].id)
+}
+
+func TestTUIModel(t *testing.T) {
+	q := &tuiQuerier{nodes: map[string][]any{
+		"fact": {&tools.Fact{ID: "fact:go", Content: "Uses\nGo", CreatedAt: 300}},
+	}}
+	m := newTUIModel(q, time.Minute)
+
+	m.Update(m.refresh()())
+	view := m.View()
+	assert.Contains(t, view, "MIE memory graph — default")
+	assert.Contains(t, view, "Facts 3 (2 valid)   Decisions 1 (1 active)")
+	assert.Contains(t, view, "fact:go")
+	assert.Contains(t, view, "Uses Go")
+	assert.Contains(t, view, "Conflict detection needs embeddings.")
+
+	// Typing after / goes to the search box, including "q".
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	for _, k := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("go")},
+		{Type: tea.KeySpace},
+		{Type: tea.KeyRunes, Runes: []rune("q")},
+		{Type: tea.KeyBackspace},
+	} {
+		_, cmd := m.Update(k)
+		assert.Nil(t, cmd)
+	}
+	assert.Contains(t, m.View(), "Search: go _")
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	m.Update(cmd())
+	assert.Equal(t, "go ", q.searched)
+	assert.Contains(t, m.View(), "fact      fact:go")
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Contains(t, m.View(), "Search: press / to search")
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	require.NotNil(t, cmd)
+	assert.IsType(t, tea.QuitMsg{}, cmd())
+}
+
+func TestTUIModelFit(t *testing.T) {
+	m := newTUIModel(&tuiQuerier{}, time.Minute)
+	m.Update(tea.WindowSizeMsg{Width: 10, Height: 5})
+	assert.Equal(t, "MIE memory", m.fit("MIE memory graph"))
+}
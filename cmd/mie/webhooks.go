@@ -0,0 +1,147 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// webhookTimeout bounds each webhook request, including reading the node.
+const webhookTimeout = 10 * time.Second
+
+// webhookBuffer is how many changes may wait for delivery before new ones
+// are dropped.
+const webhookBuffer = 256
+
+// webhookPayload is the JSON body POSTed to a webhook.
+type webhookPayload struct {
+	Hook      string `json:"hook"` // on_store or on_invalidate
+	Op        string `json:"op"`
+	NodeID    string `json:"node_id"`
+	NodeType  string `json:"node_type,omitempty"`
+	Namespace string `json:"namespace"`
+	At        int64  `json:"at"`
+	// Node is the node as stored after the change, omitted if it could not
+	// be read.
+	Node any `json:"node,omitempty"`
+}
+
+// webhookNodeTypes maps node ID prefixes to node types.
+var webhookNodeTypes = map[string]string{
+	"fact:": "fact",
+	"dec:":  "decision",
+	"ent:":  "entity",
+	"evt:":  "event",
+	"top:":  "topic",
+}
+
+// webhooks delivers the changes of a ChangeFeed to the configured hooks.
+type webhooks struct {
+	hooks   HooksConfig
+	getNode func(ctx context.Context, nodeID string) (any, error)
+	http    *http.Client
+	logger  *slog.Logger
+}
+
+// startWebhooks delivers the stores and invalidations published on feed to
+// the hooks of cfg, one at a time and in order, reading each node with
+// getNode. Delivery is best effort: failed requests are logged, not
+// retried. The returned function stops delivery once the changes already
+// published are sent. Without hooks, startWebhooks does nothing.
+func startWebhooks(cfg HooksConfig, feed *memory.ChangeFeed, getNode func(ctx context.Context, nodeID string) (any, error), logger *slog.Logger) func() {
+	if len(cfg.OnStore) == 0 && len(cfg.OnInvalidate) == 0 {
+		return func() {}
+	}
+	h := &webhooks{hooks: cfg, getNode: getNode, http: &http.Client{Timeout: webhookTimeout}, logger: logger}
+	changes, unsubscribe := feed.Subscribe(webhookBuffer)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for c := range changes {
+			h.deliver(c)
+		}
+	}()
+	return func() {
+		unsubscribe()
+		<-done
+	}
+}
+
+// deliver POSTs one change to the hooks that want it.
+func (h *webhooks) deliver(c memory.Change) {
+	var hook string
+	var targets []WebhookConfig
+	switch c.Op {
+	case memory.ChangeCreated:
+		hook, targets = "on_store", h.hooks.OnStore
+	case memory.ChangeInvalidated:
+		hook, targets = "on_invalidate", h.hooks.OnInvalidate
+	}
+	if len(targets) == 0 {
+		return
+	}
+
+	payload := webhookPayload{Hook: hook, Op: c.Op, NodeID: c.NodeID, Namespace: c.Namespace, At: c.At}
+	for prefix, nodeType := range webhookNodeTypes {
+		if strings.HasPrefix(c.NodeID, prefix) {
+			payload.NodeType = nodeType
+		}
+	}
+	ctx, cancel := context.WithTimeout(tools.WithNamespace(context.Background(), c.Namespace), webhookTimeout)
+	node, err := h.getNode(ctx, c.NodeID)
+	cancel()
+	if err != nil {
+		h.logger.Warn("webhook cannot read node", "hook", hook, "node_id", c.NodeID, "error", err)
+	} else {
+		payload.Node = node
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		h.logger.Warn("webhook cannot encode payload", "hook", hook, "node_id", c.NodeID, "error", err)
+		return
+	}
+
+	for _, target := range targets {
+		if err := h.post(target, body); err != nil {
+			h.logger.Warn("webhook failed", "hook", hook, "url", target.URL, "node_id", c.NodeID, "error", err)
+		}
+	}
+}
+
+// post sends body to the webhook target.
+func (h *webhooks) post(target WebhookConfig, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, target.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "mie/"+mcpVersion)
+	for k, v := range target.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := h.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("status %s", resp.Status)
+	}
+	return nil
+}
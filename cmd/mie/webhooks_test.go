@@ -0,0 +1,86 @@
+//go:build cozodb
+
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestWebhooks(t *testing.T) {
+	var mu sync.Mutex
+	var got []webhookPayload
+	var auth []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p webhookPayload
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&p))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		mu.Lock()
+		got = append(got, p)
+		auth = append(auth, r.Header.Get("Authorization"))
+		mu.Unlock()
+	}))
+	defer srv.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	getNode := func(ctx context.Context, nodeID string) (any, error) {
+		if nodeID == "fact:gone" {
+			return nil, errors.New("not found")
+		}
+		assert.Equal(t, "billing", tools.NamespaceFromContext(ctx))
+		return &tools.Fact{ID: nodeID, Content: "Billing runs on Postgres"}, nil
+	}
+	feed := memory.NewChangeFeed()
+	stop := startWebhooks(HooksConfig{
+		OnStore: []WebhookConfig{
+			{URL: failing.URL},
+			{URL: srv.URL, Headers: map[string]string{"Authorization": "Bearer s3cret"}},
+		},
+		OnInvalidate: []WebhookConfig{{URL: srv.URL}},
+	}, feed, getNode, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	feed.Publish(memory.Change{Op: memory.ChangeCreated, NodeID: "fact:a", Namespace: "billing", At: 100})
+	feed.Publish(memory.Change{Op: memory.ChangeUpdated, NodeID: "fact:a", Namespace: "billing", At: 101})
+	feed.Publish(memory.Change{Op: memory.ChangeInvalidated, NodeID: "fact:gone", Namespace: "billing", At: 102})
+	stop()
+
+	require.Len(t, got, 2, "the update has no hook")
+	assert.Equal(t, "on_store", got[0].Hook)
+	assert.Equal(t, "fact:a", got[0].NodeID)
+	assert.Equal(t, "fact", got[0].NodeType)
+	assert.Equal(t, int64(100), got[0].At)
+	assert.Equal(t, "Billing runs on Postgres", got[0].Node.(map[string]any)["content"])
+	assert.Equal(t, "Bearer s3cret", auth[0])
+
+	assert.Equal(t, "on_invalidate", got[1].Hook)
+	assert.Equal(t, memory.ChangeInvalidated, got[1].Op)
+	assert.Nil(t, got[1].Node, "a node that cannot be read is left out")
+	assert.Empty(t, auth[1])
+}
+
+func TestWebhooksDisabled(t *testing.T) {
+	feed := memory.NewChangeFeed()
+	stop := startWebhooks(HooksConfig{}, feed, nil, slog.Default())
+	feed.Publish(memory.Change{Op: memory.ChangeCreated, NodeID: "fact:a"})
+	stop()
+}
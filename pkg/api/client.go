@@ -0,0 +1,125 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// Client calls the endpoints of an API server. Requests are scoped to the
+// namespace of their context, set with tools.WithNamespace.
+type Client struct {
+	baseURL string
+	http    *http.Client
+	apiKey  string
+}
+
+// NewClient returns a client for the API at baseURL, such as
+// "http://127.0.0.1:8080". If httpClient is nil, http.DefaultClient is used.
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{baseURL: baseURL, http: httpClient}
+}
+
+// NewSocketClient returns a client for an API served on the Unix socket at
+// path, as a running MIE server does for CLI commands.
+func NewSocketClient(path string) *Client {
+	var dialer net.Dialer
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", path)
+		},
+	}
+	return NewClient("http://mie", &http.Client{Transport: transport, Timeout: 30 * time.Second})
+}
+
+// SetAPIKey makes the client send key as a bearer token, as a multi-tenant
+// server requires.
+func (c *Client) SetAPIKey(key string) {
+	c.apiKey = key
+}
+
+// Health calls GET /health.
+func (c *Client) Health(ctx context.Context) (*HealthResponse, error) {
+	var resp HealthResponse
+	if err := c.get(ctx, "/health", &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Stats calls GET /stats.
+func (c *Client) Stats(ctx context.Context) (*tools.GraphStats, error) {
+	var stats tools.GraphStats
+	if err := c.get(ctx, "/stats", &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// Sync calls POST /sync with an export to import.
+func (c *Client) Sync(ctx context.Context, data *tools.ExportData) (*SyncResponse, error) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("encode export: %w", err)
+	}
+	var resp SyncResponse
+	if err := c.do(ctx, http.MethodPost, "/sync", bytes.NewReader(body), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// get fetches path and decodes the JSON response into v.
+func (c *Client) get(ctx context.Context, path string, v any) error {
+	return c.do(ctx, http.MethodGet, path, nil, v)
+}
+
+// do sends a request and decodes the JSON response into v. Non-2xx
+// responses are returned as errors carrying the server's message.
+func (c *Client) do(ctx context.Context, method, path string, body io.Reader, v any) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if ns := tools.NamespaceFromContext(ctx); ns != "" {
+		req.Header.Set("X-MIE-Namespace", ns)
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr ErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil || apiErr.Error == "" {
+			return fmt.Errorf("%s %s: %s", method, path, resp.Status)
+		}
+		return fmt.Errorf("%s %s: %s", method, path, apiErr.Error)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("%s %s: decode response: %w", method, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestClient_HealthAndStats(t *testing.T) {
+	ts := httptest.NewServer(NewServer(&fakeQuerier{embeddings: true}, nil))
+	defer ts.Close()
+	c := NewClient(ts.URL, nil)
+
+	health, err := c.Health(context.Background())
+	if err != nil {
+		t.Fatalf("Health() error = %v", err)
+	}
+	if health.Status != "ok" || !health.EmbeddingsEnabled {
+		t.Errorf("Health() = %+v", health)
+	}
+
+	stats, err := c.Stats(context.Background())
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	if stats.TotalFacts != 3 {
+		t.Errorf("TotalFacts = %d, want 3", stats.TotalFacts)
+	}
+}
+
+func TestClient_SendsNamespace(t *testing.T) {
+	var got string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("X-MIE-Namespace")
+		writeJSON(w, http.StatusOK, tools.GraphStats{})
+	}))
+	defer ts.Close()
+
+	ctx := tools.WithNamespace(context.Background(), "billing")
+	if _, err := NewClient(ts.URL, nil).Stats(ctx); err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	if got != "billing" {
+		t.Errorf("X-MIE-Namespace = %q, want billing", got)
+	}
+}
+
+func TestClient_Error(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusInternalServerError, "get stats failed")
+	}))
+	defer ts.Close()
+
+	_, err := NewClient(ts.URL, nil).Stats(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "get stats failed") {
+		t.Errorf("Stats() error = %v, want the server's message", err)
+	}
+}
+
+func TestClient_Sync(t *testing.T) {
+	var auth string
+	fake := &importingQuerier{}
+	srv := NewServer(fake, nil)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		srv.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, nil)
+	c.SetAPIKey("secret")
+	resp, err := c.Sync(context.Background(), &tools.ExportData{Facts: []tools.Fact{{ID: "fact:abc", Content: "Uses Go"}}})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if resp.Imported["facts"] != 1 || fake.imported == nil || len(fake.imported.Facts) != 1 {
+		t.Errorf("Sync() = %+v, imported %+v", resp, fake.imported)
+	}
+	if auth != "Bearer secret" {
+		t.Errorf("Authorization = %q, want the API key", auth)
+	}
+}
+
+func TestSocketClient(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mie.sock")
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	srv := &http.Server{Handler: NewServer(&fakeQuerier{}, nil)}
+	go func() { _ = srv.Serve(ln) }()
+	defer func() { _ = srv.Close() }()
+
+	stats, err := NewSocketClient(path).Stats(context.Background())
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	if stats.TotalFacts != 3 {
+		t.Errorf("TotalFacts = %d, want 3", stats.TotalFacts)
+	}
+
+	if _, err := NewSocketClient(path + ".missing").Health(context.Background()); err == nil {
+		t.Error("Health() on a missing socket should fail")
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+// Package api exposes the MIE memory graph over a plain HTTP/JSON API.
+//
+// The API is intended for dashboards and scripts that do not speak MCP. It is
+// backed by the same tools.Querier used by the MCP server, so it works with
+// memory.Client or any other Querier implementation.
+//
+// # Endpoints
+//
+//	GET  /health            Liveness check
+//	GET  /stats             Graph statistics (tools.GraphStats)
+//	GET  /search?q=...      Search (mode=semantic|exact|fulltext|hybrid, types=fact,entity, limit=N)
+//	GET  /facts             List facts (also /decisions, /entities, /events, /topics)
+//	POST /facts             Store a fact (body: tools.StoreFactRequest)
+//	POST /sync              Import changes from another instance (body: tools.ExportData)
+//	GET  /nodes/{id}        Fetch a single node by ID
+//
+// List endpoints accept limit, offset, sort_by, sort_order, created_after,
+// created_before, and the type-specific filters category, valid_only, kind,
+// and status. /events also accepts event_date_range.
+//
+// POST /sync needs a Querier that also implements Importer. mie sync uses
+// it to ship incremental exports to another instance.
+//
+// Every endpoint accepts a namespace query parameter or X-MIE-Namespace
+// header that scopes the request like the namespace argument of MCP tools.
+//
+// # Quick Start
+//
+//	server := api.NewServer(client, logger)
+//	log.Fatal(http.ListenAndServe("127.0.0.1:8080", server))
+//
+// Errors are returned as ErrorResponse with a matching HTTP status code.
+//
+// # Tenants
+//
+// TenantServer serves several isolated graphs from one process. Each
+// request sends an API key as a bearer token or X-MIE-API-Key header; the
+// key selects the tenant, whose graph is opened by a TenantOpener on first
+// use. GET /admin/tenants, with the admin key, lists the tenants.
+//
+// # Client
+//
+// Client calls the API from Go. A running MIE server also serves the API on
+// a Unix socket in its data directory, which NewSocketClient connects to, so
+// CLI commands can read a database the server holds locked.
+package api
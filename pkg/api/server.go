@@ -0,0 +1,381 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// maxBodyBytes bounds request bodies accepted by POST endpoints.
+const maxBodyBytes = 1 << 20
+
+// maxSyncBodyBytes bounds the exports accepted by POST /sync.
+const maxSyncBodyBytes = 64 << 20
+
+// listPaths maps list endpoint paths to node types.
+var listPaths = map[string]string{
+	"/facts":     "fact",
+	"/decisions": "decision",
+	"/entities":  "entity",
+	"/events":    "event",
+	"/topics":    "topic",
+}
+
+// Importer is implemented by Queriers that can write an export into their
+// graph, such as memory.Client. POST /sync requires it.
+type Importer interface {
+	ImportGraph(ctx context.Context, data *tools.ExportData) (map[string]int, error)
+}
+
+// Server serves the HTTP API. It implements http.Handler.
+type Server struct {
+	client tools.Querier
+	logger *slog.Logger
+	mux    *http.ServeMux
+}
+
+// NewServer creates an API server backed by client.
+// If logger is nil, slog.Default() is used.
+func NewServer(client tools.Querier, logger *slog.Logger) *Server {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	s := &Server{client: client, logger: logger, mux: http.NewServeMux()}
+
+	s.mux.HandleFunc("GET /health", s.handleHealth)
+	s.mux.HandleFunc("GET /stats", s.handleStats)
+	s.mux.HandleFunc("GET /search", s.handleSearch)
+	s.mux.HandleFunc("GET /nodes/{id}", s.handleGetNode)
+	s.mux.HandleFunc("POST /facts", s.handleStoreFact)
+	s.mux.HandleFunc("POST /sync", s.handleSync)
+	for path, nodeType := range listPaths {
+		s.mux.HandleFunc("GET "+path, s.listHandler(nodeType))
+	}
+	return s
+}
+
+// ServeHTTP scopes the request to its namespace and dispatches it.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ns := r.URL.Query().Get("namespace")
+	if ns == "" {
+		ns = r.Header.Get("X-MIE-Namespace")
+	}
+	if ns != "" {
+		if err := tools.ValidateNamespace(ns); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		r = r.WithContext(tools.WithNamespace(r.Context(), ns))
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, HealthResponse{Status: "ok", EmbeddingsEnabled: s.client.EmbeddingsEnabled()})
+}
+
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := s.client.GetStats(r.Context())
+	if err != nil {
+		s.internalError(w, "get stats", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, stats)
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	query := q.Get("q")
+	if query == "" {
+		writeError(w, http.StatusBadRequest, "missing required query parameter: q")
+		return
+	}
+
+	mode := q.Get("mode")
+	if mode == "" {
+		mode = "hybrid"
+	}
+	nodeTypes := []string{"fact", "decision", "entity", "event"}
+	if types := q.Get("types"); types != "" {
+		nodeTypes = strings.Split(types, ",")
+	}
+	limit, err := intParam(q.Get("limit"), 10, 1, 50)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid limit: %v", err))
+		return
+	}
+
+	timeRange, err := tools.ParseTimeRangeArgs(queryArgs(r))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	includeArchived, err := boolParam(q.Get("include_archived"), false)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid include_archived: %v", err))
+		return
+	}
+	ctx := tools.WithTimeRange(r.Context(), timeRange)
+	ctx = tools.WithIncludeArchived(ctx, includeArchived)
+	if raw := q.Get("min_similarity"); raw != "" {
+		minSimilarity, err := strconv.ParseFloat(raw, 64)
+		if err == nil {
+			err = tools.ValidateMinSimilarity(minSimilarity)
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid min_similarity: %v", err))
+			return
+		}
+		ctx = tools.WithMinSimilarity(ctx, minSimilarity)
+	}
+
+	var search func(context.Context, string, []string, int) ([]tools.SearchResult, error)
+	switch mode {
+	case "semantic":
+		if !s.client.EmbeddingsEnabled() {
+			writeError(w, http.StatusBadRequest, "semantic search requires embeddings to be enabled; use mode=exact or mode=hybrid")
+			return
+		}
+		search = s.client.SemanticSearch
+	case "exact":
+		search = s.client.ExactSearch
+	case "fulltext":
+		search = s.client.FullTextSearch
+	case "hybrid":
+		search = s.client.HybridSearch
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid mode %q: must be one of semantic, exact, fulltext, hybrid", mode))
+		return
+	}
+
+	results, err := search(ctx, query, nodeTypes, limit)
+	if err != nil {
+		s.internalError(w, "search", err)
+		return
+	}
+	if results == nil {
+		results = []tools.SearchResult{}
+	}
+	_ = s.client.IncrementCounter(r.Context(), "total_queries")
+
+	writeJSON(w, http.StatusOK, SearchResponse{Query: query, Mode: mode, Results: results})
+}
+
+func (s *Server) handleGetNode(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	node, err := s.client.GetNodeByID(r.Context(), id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("node %q not found", id))
+			return
+		}
+		s.internalError(w, "get node", err)
+		return
+	}
+	if node == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("node %q not found", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, node)
+}
+
+func (s *Server) handleStoreFact(w http.ResponseWriter, r *http.Request) {
+	var req tools.StoreFactRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if strings.TrimSpace(req.Content) == "" {
+		writeError(w, http.StatusBadRequest, "content is required")
+		return
+	}
+	// Same defaults as the mie_store tool.
+	if req.Category == "" {
+		req.Category = "general"
+	}
+	if req.Confidence == 0 {
+		req.Confidence = 0.8
+	}
+	if req.Confidence < 0 || req.Confidence > 1.0 {
+		writeError(w, http.StatusBadRequest, "confidence must be between 0 and 1")
+		return
+	}
+
+	ctx := tools.WithAuditSource(r.Context(), "POST /facts", "")
+	fact, err := s.client.StoreFact(ctx, req)
+	if err != nil {
+		s.internalError(w, "store fact", err)
+		return
+	}
+	if fact.DuplicateSimilarity > 0 {
+		// An existing fact was returned; nothing new was created.
+		writeJSON(w, http.StatusOK, fact)
+		return
+	}
+	_ = s.client.IncrementCounter(ctx, "total_stores")
+
+	writeJSON(w, http.StatusCreated, fact)
+}
+
+// handleSync imports an export sent by another MIE instance, typically an
+// incremental one from mie sync. Nodes and edges already present are
+// overwritten with the sent version.
+func (s *Server) handleSync(w http.ResponseWriter, r *http.Request) {
+	importer, ok := s.client.(Importer)
+	if !ok {
+		writeError(w, http.StatusNotImplemented, "this server cannot import")
+		return
+	}
+	var data tools.ExportData
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSyncBodyBytes)).Decode(&data); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	ctx := tools.WithAuditSource(r.Context(), "POST /sync", "")
+	counts, err := importer.ImportGraph(ctx, &data)
+	if err != nil {
+		s.internalError(w, "sync", err)
+		return
+	}
+	if counts == nil {
+		counts = map[string]int{}
+	}
+	writeJSON(w, http.StatusOK, SyncResponse{Imported: counts})
+}
+
+// listHandler returns a handler that lists nodes of nodeType.
+func (s *Server) listHandler(nodeType string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		limit, err := intParam(q.Get("limit"), 20, 1, 100)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid limit: %v", err))
+			return
+		}
+		offset, err := intParam(q.Get("offset"), 0, 0, -1)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid offset: %v", err))
+			return
+		}
+		validOnly, err := boolParam(q.Get("valid_only"), true)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid valid_only: %v", err))
+			return
+		}
+		includeArchived, err := boolParam(q.Get("include_archived"), false)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid include_archived: %v", err))
+			return
+		}
+
+		timeRange, err := tools.ParseTimeRangeArgs(queryArgs(r))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if timeRange.HasEventDate() && nodeType != "event" {
+			writeError(w, http.StatusBadRequest, "event_date_range only applies to /events")
+			return
+		}
+
+		sortBy := q.Get("sort_by")
+		if sortBy == "" {
+			sortBy = "created_at"
+		}
+		sortOrder := q.Get("sort_order")
+		if sortOrder == "" {
+			sortOrder = "desc"
+		}
+
+		nodes, total, err := s.client.ListNodes(r.Context(), tools.ListOptions{
+			NodeType:        nodeType,
+			Category:        q.Get("category"),
+			Kind:            q.Get("kind"),
+			Status:          q.Get("status"),
+			ValidOnly:       validOnly,
+			Limit:           limit,
+			Offset:          offset,
+			SortBy:          sortBy,
+			SortOrder:       sortOrder,
+			TimeRange:       timeRange,
+			IncludeArchived: includeArchived,
+		})
+		if err != nil {
+			s.internalError(w, "list nodes", err)
+			return
+		}
+		if nodes == nil {
+			nodes = []any{}
+		}
+
+		writeJSON(w, http.StatusOK, ListResponse{NodeType: nodeType, Total: total, Limit: limit, Offset: offset, Nodes: nodes})
+	}
+}
+
+// internalError logs err and writes a 500 response without leaking details.
+func (s *Server) internalError(w http.ResponseWriter, op string, err error) {
+	s.logger.Error("api request failed", "op", op, "error", err)
+	writeError(w, http.StatusInternalServerError, op+" failed")
+}
+
+// queryArgs converts single-valued query parameters to tool arguments.
+func queryArgs(r *http.Request) map[string]any {
+	args := map[string]any{}
+	for key, values := range r.URL.Query() {
+		if len(values) > 0 {
+			args[key] = values[0]
+		}
+	}
+	return args
+}
+
+// intParam parses an integer query parameter, returning def when empty.
+// The value is clamped to [lo, hi]; a negative hi means no upper bound.
+func intParam(raw string, def, lo, hi int) (int, error) {
+	if raw == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, err
+	}
+	if n < lo {
+		n = lo
+	}
+	if hi >= 0 && n > hi {
+		n = hi
+	}
+	return n, nil
+}
+
+// boolParam parses a boolean query parameter, returning def when empty.
+func boolParam(raw string, def bool) (bool, error) {
+	if raw == "" {
+		return def, nil
+	}
+	return strconv.ParseBool(raw)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, ErrorResponse{Error: msg})
+}
@@ -0,0 +1,273 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// fakeQuerier overrides the Querier methods used by the API.
+// Calling any other method panics via the nil embedded interface.
+type fakeQuerier struct {
+	tools.Querier
+	embeddings bool
+	listOpts   tools.ListOptions
+	listNS     string
+	stored     tools.StoreFactRequest
+	searchMode string
+	minSim     float64
+}
+
+func (f *fakeQuerier) EmbeddingsEnabled() bool { return f.embeddings }
+
+func (f *fakeQuerier) IncrementCounter(ctx context.Context, key string) error { return nil }
+
+func (f *fakeQuerier) GetStats(ctx context.Context) (*tools.GraphStats, error) {
+	return &tools.GraphStats{TotalFacts: 3}, nil
+}
+
+func (f *fakeQuerier) ListNodes(ctx context.Context, opts tools.ListOptions) ([]any, int, error) {
+	f.listOpts = opts
+	f.listNS = tools.NamespaceFromContext(ctx)
+	return []any{&tools.Fact{ID: "fact:abc", Content: "Uses Go"}}, 1, nil
+}
+
+func (f *fakeQuerier) StoreFact(ctx context.Context, req tools.StoreFactRequest) (*tools.Fact, error) {
+	f.stored = req
+	if req.SourceAgent == "dashboard" {
+		return &tools.Fact{ID: "fact:existing", Content: req.Content, DuplicateSimilarity: 1.0}, nil
+	}
+	return &tools.Fact{ID: "fact:new", Content: req.Content, Category: req.Category, Confidence: req.Confidence, Valid: true}, nil
+}
+
+func (f *fakeQuerier) search(mode string) func(context.Context, string, []string, int) ([]tools.SearchResult, error) {
+	return func(ctx context.Context, query string, nodeTypes []string, limit int) ([]tools.SearchResult, error) {
+		f.searchMode = mode
+		f.minSim, _ = tools.MinSimilarityFromContext(ctx)
+		return []tools.SearchResult{{ID: "fact:abc", NodeType: "fact", Content: "Uses Go"}}, nil
+	}
+}
+
+func (f *fakeQuerier) ExactSearch(ctx context.Context, query string, nodeTypes []string, limit int) ([]tools.SearchResult, error) {
+	return f.search("exact")(ctx, query, nodeTypes, limit)
+}
+
+func (f *fakeQuerier) HybridSearch(ctx context.Context, query string, nodeTypes []string, limit int) ([]tools.SearchResult, error) {
+	return f.search("hybrid")(ctx, query, nodeTypes, limit)
+}
+
+func (f *fakeQuerier) GetNodeByID(ctx context.Context, nodeID string) (any, error) {
+	if nodeID == "fact:abc" {
+		return &tools.Fact{ID: nodeID, Content: "Uses Go"}, nil
+	}
+	return nil, errors.New("node not found")
+}
+
+func do(t *testing.T, h http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_ListFacts(t *testing.T) {
+	fake := &fakeQuerier{}
+	srv := NewServer(fake, nil)
+
+	rec := do(t, srv, http.MethodGet, "/facts?category=technical&limit=500&namespace=proj&created_after=2026-01-01", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+
+	var resp ListResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Total != 1 || len(resp.Nodes) != 1 || resp.NodeType != "fact" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+	if fake.listOpts.Category != "technical" || fake.listOpts.Limit != 100 || !fake.listOpts.ValidOnly {
+		t.Errorf("unexpected list options: %+v", fake.listOpts)
+	}
+	if fake.listOpts.CreatedAfter == 0 {
+		t.Error("expected created_after to be parsed")
+	}
+	if fake.listOpts.IncludeArchived {
+		t.Error("archived nodes should be hidden by default")
+	}
+	if fake.listNS != "proj" {
+		t.Errorf("namespace = %q, want proj", fake.listNS)
+	}
+}
+
+func TestServer_ListIncludeArchived(t *testing.T) {
+	fake := &fakeQuerier{}
+	srv := NewServer(fake, nil)
+
+	rec := do(t, srv, http.MethodGet, "/topics?include_archived=true", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	if fake.listOpts.NodeType != "topic" || !fake.listOpts.IncludeArchived {
+		t.Errorf("unexpected list options: %+v", fake.listOpts)
+	}
+}
+
+func TestServer_ListInvalidParams(t *testing.T) {
+	srv := NewServer(&fakeQuerier{}, nil)
+
+	for _, target := range []string{
+		"/facts?limit=abc",
+		"/facts?valid_only=maybe",
+		"/topics?include_archived=maybe",
+		"/facts?event_date_range=2026-01-01",
+		"/facts?namespace=bad%20ns",
+	} {
+		rec := do(t, srv, http.MethodGet, target, "")
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", target, rec.Code)
+		}
+		var resp ErrorResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || resp.Error == "" {
+			t.Errorf("%s: expected error body, got %s", target, rec.Body.String())
+		}
+	}
+}
+
+func TestServer_StoreFact(t *testing.T) {
+	fake := &fakeQuerier{}
+	srv := NewServer(fake, nil)
+
+	rec := do(t, srv, http.MethodPost, "/facts", `{"content":"Prefers dark mode"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	if fake.stored.Category != "general" || fake.stored.Confidence != 0.8 {
+		t.Errorf("expected mie_store defaults, got %+v", fake.stored)
+	}
+
+	var fact tools.Fact
+	if err := json.Unmarshal(rec.Body.Bytes(), &fact); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if fact.ID != "fact:new" {
+		t.Errorf("fact.ID = %q, want fact:new", fact.ID)
+	}
+
+	// A duplicate returns the existing fact with 200 instead of 201.
+	rec = do(t, srv, http.MethodPost, "/facts", `{"content":"Prefers dark mode","source_agent":"dashboard"}`)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"duplicate_similarity":1`) {
+		t.Errorf("duplicate: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+
+	for _, body := range []string{`{}`, `not json`, `{"content":"x","confidence":2}`, `{"content":"x","extra":1}`} {
+		rec := do(t, srv, http.MethodPost, "/facts", body)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("body %s: status = %d, want 400", body, rec.Code)
+		}
+	}
+}
+
+func TestServer_Search(t *testing.T) {
+	fake := &fakeQuerier{}
+	srv := NewServer(fake, nil)
+
+	rec := do(t, srv, http.MethodGet, "/search?q=go", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	var resp SearchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Mode != "hybrid" || fake.searchMode != "hybrid" || len(resp.Results) != 1 {
+		t.Errorf("unexpected response: %+v (mode called %q)", resp, fake.searchMode)
+	}
+
+	if rec := do(t, srv, http.MethodGet, "/search?q=go&mode=exact", ""); rec.Code != http.StatusOK || fake.searchMode != "exact" {
+		t.Errorf("exact: status = %d, mode called %q", rec.Code, fake.searchMode)
+	}
+
+	if rec := do(t, srv, http.MethodGet, "/search?q=go&min_similarity=0.6", ""); rec.Code != http.StatusOK || fake.minSim != 0.6 {
+		t.Errorf("min_similarity: status = %d, passed %v", rec.Code, fake.minSim)
+	}
+
+	for _, target := range []string{"/search", "/search?q=go&mode=semantic", "/search?q=go&mode=fuzzy", "/search?q=go&min_similarity=2"} {
+		if rec := do(t, srv, http.MethodGet, target, ""); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", target, rec.Code)
+		}
+	}
+}
+
+func TestServer_StatsNodesAndHealth(t *testing.T) {
+	srv := NewServer(&fakeQuerier{embeddings: true}, nil)
+
+	rec := do(t, srv, http.MethodGet, "/stats", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"total_facts":3`) {
+		t.Errorf("stats: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := do(t, srv, http.MethodGet, "/nodes/fact:abc", ""); rec.Code != http.StatusOK {
+		t.Errorf("node: status = %d", rec.Code)
+	}
+	if rec := do(t, srv, http.MethodGet, "/nodes/fact:missing", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("missing node: status = %d, want 404", rec.Code)
+	}
+
+	rec = do(t, srv, http.MethodGet, "/health", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"embeddings_enabled":true`) {
+		t.Errorf("health: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := do(t, srv, http.MethodDelete, "/facts", ""); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("DELETE /facts: status = %d, want 405", rec.Code)
+	}
+}
+
+// importingQuerier is a fakeQuerier that implements Importer.
+type importingQuerier struct {
+	fakeQuerier
+	imported *tools.ExportData
+	ns       string
+}
+
+func (f *importingQuerier) ImportGraph(ctx context.Context, data *tools.ExportData) (map[string]int, error) {
+	f.imported = data
+	f.ns = tools.NamespaceFromContext(ctx)
+	return map[string]int{"facts": len(data.Facts)}, nil
+}
+
+func TestServer_Sync(t *testing.T) {
+	fake := &importingQuerier{}
+	srv := NewServer(fake, nil)
+
+	rec := do(t, srv, http.MethodPost, "/sync?namespace=billing", `{"version":"1","facts":[{"id":"fact:abc","content":"Uses Go"}]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	var resp SyncResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Imported["facts"] != 1 || fake.imported.Facts[0].ID != "fact:abc" || fake.ns != "billing" {
+		t.Errorf("imported %+v into %q, response %+v", fake.imported, fake.ns, resp)
+	}
+
+	if rec := do(t, srv, http.MethodPost, "/sync", `not json`); rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid body: status = %d, want 400", rec.Code)
+	}
+	if rec := do(t, NewServer(&fakeQuerier{}, nil), http.MethodPost, "/sync", `{}`); rec.Code != http.StatusNotImplemented {
+		t.Errorf("without Importer: status = %d, want 501", rec.Code)
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// TenantOpener opens the memory graph of a tenant. TenantServer calls it on
+// the first request of the tenant, and again after a failed open.
+type TenantOpener func(tenant string) (tools.Querier, error)
+
+// tenant is the memory graph of one tenant, opened on first use.
+type tenant struct {
+	mu       sync.Mutex
+	server   *Server
+	client   tools.Querier
+	openedAt time.Time
+}
+
+// tenantKey is an API key of a tenant, kept as its SHA-256 hash so that
+// keys can be compared in constant time.
+type tenantKey struct {
+	hash   [sha256.Size]byte
+	tenant string
+}
+
+// TenantServer serves the API for several tenants, each with its own memory
+// graph. Requests name their tenant with an API key, sent as a bearer token
+// or in the X-MIE-API-Key header, and are served by the Server of that
+// tenant. It implements http.Handler.
+type TenantServer struct {
+	keys     []tenantKey
+	adminKey string
+	open     TenantOpener
+	logger   *slog.Logger
+
+	mu      sync.Mutex
+	tenants map[string]*tenant
+	closed  bool
+}
+
+// NewTenantServer creates a server that routes each request to the tenant
+// its API key maps to in keys, opening the tenant's graph with open on
+// first use. Requests with the admin key reach GET /admin/tenants; with an
+// empty adminKey the admin endpoint is disabled. If logger is nil,
+// slog.Default() is used.
+func NewTenantServer(keys map[string]string, adminKey string, open TenantOpener, logger *slog.Logger) *TenantServer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	s := &TenantServer{
+		keys:     make([]tenantKey, 0, len(keys)),
+		adminKey: adminKey,
+		open:     open,
+		logger:   logger,
+		tenants:  make(map[string]*tenant),
+	}
+	for key, name := range keys {
+		s.keys = append(s.keys, tenantKey{hash: sha256.Sum256([]byte(key)), tenant: name})
+		if _, ok := s.tenants[name]; !ok {
+			s.tenants[name] = &tenant{}
+		}
+	}
+	return s
+}
+
+// ServeHTTP authenticates the request and dispatches it to its tenant or to
+// the admin endpoint.
+func (s *TenantServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := apiKey(r)
+	if key == "" {
+		writeError(w, http.StatusUnauthorized, "missing API key")
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/admin/") {
+		if s.adminKey == "" || subtle.ConstantTimeCompare([]byte(key), []byte(s.adminKey)) != 1 {
+			writeError(w, http.StatusForbidden, "admin key required")
+			return
+		}
+		if r.Method == http.MethodGet && r.URL.Path == "/admin/tenants" {
+			writeJSON(w, http.StatusOK, TenantsResponse{Tenants: s.Tenants()})
+			return
+		}
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	name, ok := s.tenantOf(key)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "invalid API key")
+		return
+	}
+	srv, err := s.server(name)
+	if err != nil {
+		s.logger.Error("open tenant failed", "tenant", name, "error", err)
+		writeError(w, http.StatusServiceUnavailable, fmt.Sprintf("tenant %q is unavailable", name))
+		return
+	}
+	srv.ServeHTTP(w, r)
+}
+
+// tenantOf returns the tenant the API key key belongs to, and whether there
+// is one. It compares the hash of key with every configured key in constant
+// time, so the response time does not reveal how much of a key was right.
+func (s *TenantServer) tenantOf(key string) (string, bool) {
+	hash := sha256.Sum256([]byte(key))
+	var name string
+	found := false
+	for _, k := range s.keys {
+		if subtle.ConstantTimeCompare(hash[:], k.hash[:]) == 1 {
+			name, found = k.tenant, true
+		}
+	}
+	return name, found
+}
+
+// errTenantServerClosed is returned for requests that arrive after Close.
+var errTenantServerClosed = errors.New("tenant server closed")
+
+// server returns the Server of the tenant name, opening its graph if this
+// is the tenant's first request.
+func (s *TenantServer) server(name string) (*Server, error) {
+	s.mu.Lock()
+	t := s.tenants[name]
+	closed := s.closed
+	s.mu.Unlock()
+	if closed {
+		return nil, errTenantServerClosed
+	}
+
+	// Each tenant opens under its own lock, so a slow open does not hold
+	// up requests of other tenants.
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.server != nil {
+		return t.server, nil
+	}
+	client, err := s.open(name)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("opened tenant", "tenant", name)
+	t.client = client
+	t.server = NewServer(client, s.logger.With("tenant", name))
+	t.openedAt = time.Now()
+	return t.server, nil
+}
+
+// Tenants returns the configured tenants by name and whether their graphs
+// are open.
+func (s *TenantServer) Tenants() []TenantInfo {
+	s.mu.Lock()
+	names := make([]string, 0, len(s.tenants))
+	for name := range s.tenants {
+		names = append(names, name)
+	}
+	s.mu.Unlock()
+	sort.Strings(names)
+
+	infos := make([]TenantInfo, 0, len(names))
+	for _, name := range names {
+		t := s.tenants[name]
+		t.mu.Lock()
+		info := TenantInfo{Name: name, Open: t.server != nil}
+		if info.Open {
+			info.OpenedAt = t.openedAt.Unix()
+		}
+		t.mu.Unlock()
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// Close closes the graphs of the tenants opened so far whose clients
+// implement io.Closer, and makes later requests fail. It returns the first
+// error.
+func (s *TenantServer) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	var first error
+	for name, t := range s.tenants {
+		t.mu.Lock()
+		if c, ok := t.client.(io.Closer); ok {
+			if err := c.Close(); err != nil && first == nil {
+				first = fmt.Errorf("close tenant %q: %w", name, err)
+			}
+		}
+		t.client, t.server = nil, nil
+		t.mu.Unlock()
+	}
+	return first
+}
+
+// apiKey returns the API key of r from a bearer Authorization header or the
+// X-MIE-API-Key header.
+func apiKey(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		if token, ok := strings.CutPrefix(auth, "Bearer "); ok {
+			return strings.TrimSpace(token)
+		}
+	}
+	return r.Header.Get("X-MIE-API-Key")
+}
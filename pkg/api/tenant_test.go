@@ -0,0 +1,142 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// closingQuerier records whether Close was called.
+type closingQuerier struct {
+	fakeQuerier
+	closed bool
+}
+
+func (c *closingQuerier) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestTenantServer(t *testing.T) {
+	var mu sync.Mutex
+	opened := map[string]int{}
+	clients := map[string]*closingQuerier{}
+	open := func(name string) (tools.Querier, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if name == "broken" {
+			return nil, errors.New("disk full")
+		}
+		opened[name]++
+		clients[name] = &closingQuerier{fakeQuerier: fakeQuerier{embeddings: name == "alice"}}
+		return clients[name], nil
+	}
+	s := NewTenantServer(map[string]string{"key-a": "alice", "key-b": "bob", "key-x": "broken"}, "admin", open, nil)
+
+	get := func(path, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if key != "" {
+			req.Header.Set("Authorization", "Bearer "+key)
+		}
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := get("/health", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("no key: status = %d, want 401", rec.Code)
+	}
+	if rec := get("/health", "nope"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("unknown key: status = %d, want 401", rec.Code)
+	}
+
+	for range 2 {
+		rec := get("/health", "key-a")
+		var health HealthResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &health); err != nil || !health.EmbeddingsEnabled {
+			t.Errorf("alice /health = %d %s, want her own graph", rec.Code, rec.Body)
+		}
+	}
+	if opened["alice"] != 1 {
+		t.Errorf("alice opened %d times, want once", opened["alice"])
+	}
+	if opened["bob"] != 0 {
+		t.Error("bob's graph was opened before his first request")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.Header.Set("X-MIE-API-Key", "key-b")
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || opened["bob"] != 1 {
+		t.Errorf("bob via X-MIE-API-Key: status = %d, opened %d", rec.Code, opened["bob"])
+	}
+
+	if rec := get("/health", "key-x"); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("failed open: status = %d, want 503", rec.Code)
+	}
+
+	if rec := get("/admin/tenants", "key-a"); rec.Code != http.StatusForbidden {
+		t.Errorf("admin endpoint with a tenant key: status = %d, want 403", rec.Code)
+	}
+	rec = get("/admin/tenants", "admin")
+	var tenants TenantsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &tenants); err != nil {
+		t.Fatalf("decode tenants: %v (%s)", err, rec.Body)
+	}
+	want := []TenantInfo{{Name: "alice", Open: true}, {Name: "bob", Open: true}, {Name: "broken"}}
+	if len(tenants.Tenants) != len(want) {
+		t.Fatalf("tenants = %+v, want %+v", tenants.Tenants, want)
+	}
+	for i, w := range want {
+		if got := tenants.Tenants[i]; got.Name != w.Name || got.Open != w.Open || (got.Open && got.OpenedAt == 0) {
+			t.Errorf("tenant %d = %+v, want %+v", i, got, w)
+		}
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if !clients["alice"].closed || !clients["bob"].closed {
+		t.Error("Close did not close the opened graphs")
+	}
+	if rec := get("/health", "key-a"); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("after Close: status = %d, want 503", rec.Code)
+	}
+}
+
+func TestTenantServerNoAdminKey(t *testing.T) {
+	s := NewTenantServer(map[string]string{"key-a": "alice"}, "", nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/admin/tenants", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("empty bearer token: status = %d, want 401", rec.Code)
+	}
+
+	req.Header.Set("Authorization", "Bearer key-a")
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("admin endpoint disabled: status = %d, want 403", rec.Code)
+	}
+}
+
+func TestTenantServerKeyHashes(t *testing.T) {
+	s := NewTenantServer(map[string]string{"key-a": "alice", "key-b": "bob"}, "", nil, nil)
+	for key, want := range map[string]string{"key-a": "alice", "key-b": "bob", "key-": "", "key-ab": "", "": ""} {
+		if name, ok := s.tenantOf(key); name != want || ok != (want != "") {
+			t.Errorf("tenantOf(%q) = %q, %v, want %q", key, name, ok, want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package api
+
+import "github.com/kraklabs/mie/pkg/tools"
+
+// ErrorResponse is the body of every non-2xx response.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// HealthResponse is returned by GET /health.
+type HealthResponse struct {
+	Status            string `json:"status"`
+	EmbeddingsEnabled bool   `json:"embeddings_enabled"`
+}
+
+// ListResponse is returned by the list endpoints such as GET /facts.
+type ListResponse struct {
+	NodeType string `json:"node_type"`
+	Total    int    `json:"total"`
+	Limit    int    `json:"limit"`
+	Offset   int    `json:"offset"`
+	Nodes    []any  `json:"nodes"`
+}
+
+// SearchResponse is returned by GET /search.
+type SearchResponse struct {
+	Query   string               `json:"query"`
+	Mode    string               `json:"mode"`
+	Results []tools.SearchResult `json:"results"`
+}
+
+// SyncResponse is returned by POST /sync.
+type SyncResponse struct {
+	// Imported counts the rows written per kind, keyed like
+	// tools.ExportData.Stats.
+	Imported map[string]int `json:"imported"`
+}
+
+// TenantInfo describes one tenant in a TenantsResponse.
+type TenantInfo struct {
+	Name string `json:"name"`
+	// Open reports whether the tenant's graph has been opened by a request.
+	Open     bool  `json:"open"`
+	OpenedAt int64 `json:"opened_at,omitempty"`
+}
+
+// TenantsResponse is returned by GET /admin/tenants.
+type TenantsResponse struct {
+	Tenants []TenantInfo `json:"tenants"`
+}
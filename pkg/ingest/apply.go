@@ -0,0 +1,172 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// Apply stores documents through client and returns the number of
+// decisions, entities, topics, and relationships written, keyed like the
+// counts of memory.Client.ImportGraph.
+//
+// Entities and topics that already exist under the same name (ignoring case)
+// are linked instead of stored again, so an import never overwrites their
+// descriptions. Decisions use deterministic IDs, so importing the same file
+// twice updates the decision in place.
+func Apply(ctx context.Context, client tools.Querier, docs []*Document) (map[string]int, error) {
+	a := newApplier(client)
+	for _, doc := range docs {
+		if _, err := a.document(ctx, doc); err != nil {
+			return a.counts, err
+		}
+	}
+	return a.counts, nil
+}
+
+// applier stores ingested nodes and remembers the IDs of the entities and
+// topics it has resolved, so documents sharing a name link the same node.
+type applier struct {
+	client    tools.Querier
+	counts    map[string]int
+	entityIDs map[string]string
+	topicIDs  map[string]string
+	linked    map[string]bool // edges added by linkOnce
+}
+
+func newApplier(client tools.Querier) *applier {
+	return &applier{
+		client:    client,
+		counts:    map[string]int{},
+		entityIDs: map[string]string{},
+		topicIDs:  map[string]string{},
+		linked:    map[string]bool{},
+	}
+}
+
+// document stores one document's decision with its entities and topics and
+// returns the decision ID.
+func (a *applier) document(ctx context.Context, doc *Document) (string, error) {
+	req := doc.Decision
+	if doc.Status == "proposed" {
+		req.Status = doc.Status
+	}
+	decision, err := a.client.StoreDecision(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("%s: store decision: %w", doc.Source, err)
+	}
+	a.counts["decisions"]++
+	if doc.Status != "" && doc.Status != decision.Status {
+		if err := a.client.UpdateStatus(ctx, decision.ID, doc.Status); err != nil {
+			return "", fmt.Errorf("%s: set status: %w", doc.Source, err)
+		}
+	}
+
+	for _, ref := range doc.Entities {
+		id, err := a.entity(ctx, ref.Entity)
+		if err != nil {
+			return "", fmt.Errorf("%s: store entity %q: %w", doc.Source, ref.Entity.Name, err)
+		}
+		fields := map[string]string{"decision_id": decision.ID, "entity_id": id, "role": ref.Role}
+		if err := a.link(ctx, "mie_decision_entity", fields); err != nil {
+			return "", fmt.Errorf("%s: link entity %q: %w", doc.Source, ref.Entity.Name, err)
+		}
+	}
+
+	for _, req := range doc.Topics {
+		id, err := a.topic(ctx, req)
+		if err != nil {
+			return "", fmt.Errorf("%s: store topic %q: %w", doc.Source, req.Name, err)
+		}
+		fields := map[string]string{"decision_id": decision.ID, "topic_id": id}
+		if err := a.link(ctx, "mie_decision_topic", fields); err != nil {
+			return "", fmt.Errorf("%s: link topic %q: %w", doc.Source, req.Name, err)
+		}
+	}
+
+	return decision.ID, nil
+}
+
+// entity returns the ID of the entity named req.Name, storing it if needed.
+func (a *applier) entity(ctx context.Context, req tools.StoreEntityRequest) (string, error) {
+	return a.resolve(ctx, "entity", req.Name, a.entityIDs, func() (string, error) {
+		entity, err := a.client.StoreEntity(ctx, req)
+		if err != nil {
+			return "", err
+		}
+		a.counts["entities"]++
+		return entity.ID, nil
+	})
+}
+
+// topic returns the ID of the topic named req.Name, storing it if needed.
+func (a *applier) topic(ctx context.Context, req tools.StoreTopicRequest) (string, error) {
+	return a.resolve(ctx, "topic", req.Name, a.topicIDs, func() (string, error) {
+		topic, err := a.client.StoreTopic(ctx, req)
+		if err != nil {
+			return "", err
+		}
+		a.counts["topics"]++
+		return topic.ID, nil
+	})
+}
+
+// link adds an edge attributed to SourceAgent and counts it.
+func (a *applier) link(ctx context.Context, table string, fields map[string]string) error {
+	fields["source_agent"] = SourceAgent
+	if err := a.client.AddRelationship(ctx, table, fields); err != nil {
+		return err
+	}
+	a.counts["relationships"]++
+	return nil
+}
+
+// linkOnce is link for importers whose sources may repeat an edge: it skips
+// edges it has already added.
+func (a *applier) linkOnce(ctx context.Context, table string, fields map[string]string) error {
+	keys := make([]string, 0, len(fields))
+	for k, v := range fields {
+		keys = append(keys, k+"="+v)
+	}
+	sort.Strings(keys)
+	key := table + " " + strings.Join(keys, " ")
+	if a.linked[key] {
+		return nil
+	}
+	a.linked[key] = true
+	return a.link(ctx, table, fields)
+}
+
+// resolve returns the ID of the node of nodeType named name: from seen,
+// from an existing node with that name, or by calling store.
+func (a *applier) resolve(ctx context.Context, nodeType, name string, seen map[string]string, store func() (string, error)) (string, error) {
+	key := strings.ToLower(name)
+	if id, ok := seen[key]; ok {
+		return id, nil
+	}
+
+	results, err := a.client.ExactSearch(ctx, name, []string{nodeType}, 20)
+	if err != nil {
+		return "", err
+	}
+	for _, r := range results {
+		if strings.EqualFold(r.Content, name) {
+			seen[key] = r.ID
+			return r.ID, nil
+		}
+	}
+
+	id, err := store()
+	if err != nil {
+		return "", err
+	}
+	seen[key] = id
+	return id, nil
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// fakeQuerier records the writes made by Apply.
+// Calling any other method panics via the nil embedded interface.
+type fakeQuerier struct {
+	tools.Querier
+	existing map[string]tools.SearchResult // lowercased name -> node
+	entities []string
+	topics   []string
+	statuses map[string]string
+	edges    []string
+}
+
+func (f *fakeQuerier) StoreDecision(ctx context.Context, req tools.StoreDecisionRequest) (*tools.Decision, error) {
+	status := req.Status
+	if status == "" {
+		status = "active"
+	}
+	return &tools.Decision{ID: "dec:" + req.Title, Title: req.Title, Status: status}, nil
+}
+
+func (f *fakeQuerier) UpdateStatus(ctx context.Context, nodeID, newStatus string) error {
+	f.statuses[nodeID] = newStatus
+	return nil
+}
+
+func (f *fakeQuerier) StoreEntity(ctx context.Context, req tools.StoreEntityRequest) (*tools.Entity, error) {
+	f.entities = append(f.entities, req.Name)
+	return &tools.Entity{ID: "ent:" + req.Name, Name: req.Name, Kind: req.Kind}, nil
+}
+
+func (f *fakeQuerier) StoreTopic(ctx context.Context, req tools.StoreTopicRequest) (*tools.Topic, error) {
+	f.topics = append(f.topics, req.Name)
+	return &tools.Topic{ID: "top:" + req.Name, Name: req.Name}, nil
+}
+
+func (f *fakeQuerier) ExactSearch(ctx context.Context, query string, nodeTypes []string, limit int) ([]tools.SearchResult, error) {
+	if r, ok := f.existing[strings.ToLower(query)]; ok && r.NodeType == nodeTypes[0] {
+		return []tools.SearchResult{r}, nil
+	}
+	return nil, nil
+}
+
+func (f *fakeQuerier) StoreFact(ctx context.Context, req tools.StoreFactRequest) (*tools.Fact, error) {
+	return &tools.Fact{ID: "fact:" + strings.SplitN(req.Content, "\n", 2)[0], Content: req.Content}, nil
+}
+
+func (f *fakeQuerier) StoreEvent(ctx context.Context, req tools.StoreEventRequest) (*tools.Event, error) {
+	return &tools.Event{ID: "evt:" + req.Title, Title: req.Title}, nil
+}
+
+func (f *fakeQuerier) AddRelationship(ctx context.Context, edgeType string, fields map[string]string) error {
+	f.edges = append(f.edges, fmt.Sprint(edgeType, " ", fields))
+	return nil
+}
+
+func TestApply(t *testing.T) {
+	fake := &fakeQuerier{
+		existing: map[string]tools.SearchResult{
+			"kafka": {NodeType: "entity", ID: "ent:existing-kafka", Content: "Kafka"},
+		},
+		statuses: map[string]string{},
+	}
+	docs := []*Document{
+		{
+			Source:   "a.md",
+			Decision: tools.StoreDecisionRequest{Title: "Choose a queue"},
+			Status:   "active",
+			Entities: []EntityRef{
+				{Entity: tools.StoreEntityRequest{Name: "Kafka"}, Role: "chosen"},
+				{Entity: tools.StoreEntityRequest{Name: "RabbitMQ"}, Role: "alternative"},
+			},
+			Topics: []tools.StoreTopicRequest{{Name: "messaging"}},
+		},
+		{
+			Source:   "b.md",
+			Decision: tools.StoreDecisionRequest{Title: "Drop RabbitMQ"},
+			Status:   "superseded",
+			Entities: []EntityRef{{Entity: tools.StoreEntityRequest{Name: "rabbitmq"}, Role: "chosen"}},
+		},
+		{
+			Source:   "c.md",
+			Decision: tools.StoreDecisionRequest{Title: "Try NATS"},
+			Status:   "proposed",
+		},
+	}
+
+	counts, err := Apply(context.Background(), fake, docs)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	want := map[string]int{"decisions": 3, "entities": 1, "topics": 1, "relationships": 4}
+	for kind, n := range want {
+		if counts[kind] != n {
+			t.Errorf("counts[%s] = %d, want %d", kind, counts[kind], n)
+		}
+	}
+	if len(fake.entities) != 1 || fake.entities[0] != "RabbitMQ" {
+		t.Errorf("stored entities = %v, want only RabbitMQ", fake.entities)
+	}
+	if fake.statuses["dec:Drop RabbitMQ"] != "superseded" || len(fake.statuses) != 1 {
+		t.Errorf("statuses = %v", fake.statuses)
+	}
+
+	wantEdges := []string{
+		"mie_decision_entity map[decision_id:dec:Choose a queue entity_id:ent:existing-kafka role:chosen source_agent:mie-import]",
+		"mie_decision_entity map[decision_id:dec:Choose a queue entity_id:ent:RabbitMQ role:alternative source_agent:mie-import]",
+		"mie_decision_topic map[decision_id:dec:Choose a queue source_agent:mie-import topic_id:top:messaging]",
+		"mie_decision_entity map[decision_id:dec:Drop RabbitMQ entity_id:ent:RabbitMQ role:chosen source_agent:mie-import]",
+	}
+	if strings.Join(fake.edges, "\n") != strings.Join(wantEdges, "\n") {
+		t.Errorf("edges =\n%s\nwant\n%s", strings.Join(fake.edges, "\n"), strings.Join(wantEdges, "\n"))
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+// Package ingest extracts memory nodes from existing documents without an LLM.
+//
+// Parsers in this package use deterministic heuristics, so the same input
+// always produces the same nodes and re-importing a file updates the nodes it
+// created instead of duplicating them.
+//
+// # Architecture Decision Records
+//
+// ParseMarkdown reads an ADR in the common Nygard or MADR layouts:
+//
+//	# 3. Use PostgreSQL for payments
+//
+//	Status: accepted
+//	Deciders: Alice, Bob
+//
+//	## Context
+//	We need ACID transactions.
+//
+//	## Considered Options
+//	- PostgreSQL
+//	- DynamoDB
+//
+//	## Decision
+//	Chosen option: "PostgreSQL", because ...
+//
+// The title becomes a decision, the Decision section its rationale, and the
+// Context and Consequences sections its context. Deciders become person
+// entities, short considered options become entities linked with the role
+// "chosen" or "alternative", and front matter tags become topics.
+//
+// # Git history
+//
+// ReadGitHistory reads commits and tags with the git executable, and
+// ParseGitHistory turns them into nodes: conventional-commit scopes
+// ("feat(auth): ...") become entities, merge commits become decisions linked
+// to the scopes they merged, and tags become release events linked to the
+// merge decisions they include. ApplyHistory stores the result.
+//
+// # Obsidian vaults
+//
+// ReadObsidianVault parses every note of a vault with ParseObsidianNote:
+// notes become facts, and dated notes (daily notes named YYYY-MM-DD, or a
+// front matter date) become events. Wiki-link targets become entities and
+// front matter tags become topics. ApplyNotes stores the notes, linking each
+// fact to its entities and topics and each entity to the note's topics.
+//
+// # Agent memory exports
+//
+// ParseMem0, ParseZep, and ParseLangChain read the JSON exports of other
+// agent-memory systems into Memory records: mem0 memories and graph
+// relations, Zep session summaries and facts, and LangChain summary and
+// entity memories. Memories become facts, Zep summaries become events, and
+// users and named entities become entities. ApplyMemories stores them.
+//
+// # Storing
+//
+// Apply stores parsed documents through a tools.Querier:
+//
+//	doc, err := ingest.ParseMarkdown("docs/adr/0003-postgres.md", data)
+//	if err != nil {
+//	    return err
+//	}
+//	counts, err := ingest.Apply(ctx, client, []*ingest.Document{doc})
+package ingest
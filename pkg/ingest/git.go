@@ -0,0 +1,407 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// Commit is a commit read from git history.
+type Commit struct {
+	Hash    string
+	Parents []string
+	Author  string
+	Date    time.Time
+	Subject string
+	Body    string
+	// Merged lists the commits a merge brought in (reachable from its second
+	// parent but not its first). Empty for non-merge commits.
+	Merged []string
+}
+
+// Tag is a git tag and the commits it adds since the previous tag.
+type Tag struct {
+	Name    string
+	Date    time.Time
+	Message string
+	// Commits lists the commits reachable from the tag but not from the
+	// previous tag by date.
+	Commits []string
+}
+
+// History is the knowledge extracted from a git repository.
+type History struct {
+	// Entities are the conventional-commit scopes found in the history.
+	Entities []tools.StoreEntityRequest
+	// Decisions are created from merge commits. Their entities are the
+	// scopes of the merged commits.
+	Decisions []*Document
+	// Releases are events created from tags.
+	Releases []Release
+}
+
+// Release is an event created from a tag, linked to the merge decisions it
+// includes (as indexes into History.Decisions).
+type Release struct {
+	Event     tools.StoreEventRequest
+	Decisions []int
+}
+
+var (
+	// conventionalPattern matches "type(scope)!: description".
+	conventionalPattern = regexp.MustCompile(`^(\w+)(?:\(([^)]+)\))?!?:\s+\S`)
+	versionPattern      = regexp.MustCompile(`^v?\d+(\.\d+)*`)
+	pullRequestPattern  = regexp.MustCompile(`^Merge pull request #\d+`)
+)
+
+// Field and record separators for git's --format output.
+const (
+	gitFieldSep  = "\x00"
+	gitRecordSep = "\x1e"
+)
+
+// ReadGitHistory reads up to maxCommits commits (0 for all) and all tags of
+// the repository at dir with the git executable, which must be installed
+// (git 2.24 or later).
+func ReadGitHistory(ctx context.Context, dir string, maxCommits int) ([]Commit, []Tag, error) {
+	args := []string{"log", "--topo-order", "--format=%H%x00%P%x00%an%x00%aI%x00%s%x00%b%x1e"}
+	if maxCommits > 0 {
+		args = append(args, fmt.Sprintf("--max-count=%d", maxCommits))
+	}
+	out, err := runGit(ctx, dir, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var commits []Commit
+	for _, record := range splitRecords(out) {
+		f := strings.SplitN(record, gitFieldSep, 6)
+		if len(f) < 6 {
+			continue
+		}
+		date, _ := time.Parse(time.RFC3339, f[3])
+		c := Commit{
+			Hash:    f[0],
+			Parents: strings.Fields(f[1]),
+			Author:  f[2],
+			Date:    date,
+			Subject: f[4],
+			Body:    strings.TrimSpace(f[5]),
+		}
+		if len(c.Parents) > 1 {
+			merged, err := runGit(ctx, dir, "rev-list", "--end-of-options", c.Parents[0]+".."+c.Parents[1])
+			if err != nil {
+				return nil, nil, err
+			}
+			c.Merged = strings.Fields(merged)
+		}
+		commits = append(commits, c)
+	}
+
+	out, err = runGit(ctx, dir, "for-each-ref", "--sort=creatordate",
+		"--format=%(refname:short)%00%(refname)%00%(creatordate:iso-strict)%00%(if)%(taggername)%(then)%(contents)%(end)%1e", "refs/tags")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var tags []Tag
+	previous := ""
+	for _, record := range splitRecords(out) {
+		f := strings.SplitN(record, gitFieldSep, 4)
+		if len(f) < 4 {
+			continue
+		}
+		date, _ := time.Parse(time.RFC3339, f[2])
+		// Tag names come from the repository, so they are passed as full
+		// refs after --end-of-options: a tag named "-x" is not an option.
+		revs := f[1]
+		if previous != "" {
+			revs = previous + ".." + f[1]
+		}
+		included, err := runGit(ctx, dir, "rev-list", "--end-of-options", revs)
+		if err != nil {
+			return nil, nil, err
+		}
+		tags = append(tags, Tag{
+			Name:    f[0],
+			Date:    date,
+			Message: strings.TrimSpace(f[3]),
+			Commits: strings.Fields(included),
+		})
+		previous = f[1]
+	}
+
+	return commits, tags, nil
+}
+
+// runGit runs git in dir and returns its standard output.
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s (is %s a git repository?): %w: %s", args[0], dir, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// splitRecords splits git output on the record separator, dropping the
+// newline git prints between records.
+func splitRecords(out string) []string {
+	var records []string
+	for _, r := range strings.Split(out, gitRecordSep) {
+		if r = strings.TrimPrefix(r, "\n"); r != "" {
+			records = append(records, r)
+		}
+	}
+	return records
+}
+
+// ParseGitHistory turns commits and tags into memory nodes:
+//
+//   - each conventional-commit scope ("feat(auth): ...") becomes an entity;
+//   - each merge commit becomes a decision linked to the scopes it merged;
+//   - each tag becomes a release event linked to the merge decisions it
+//     includes.
+func ParseGitHistory(commits []Commit, tags []Tag) *History {
+	h := &History{}
+	byHash := map[string]Commit{}
+	for _, c := range commits {
+		byHash[c.Hash] = c
+	}
+
+	// Scopes, with the commit types seen for each.
+	scopeTypes := map[string]map[string]int{}
+	var scopeNames []string
+	for _, c := range commits {
+		typ, scopes := conventionalPrefix(c.Subject)
+		for _, scope := range scopes {
+			key := strings.ToLower(scope)
+			if scopeTypes[key] == nil {
+				scopeTypes[key] = map[string]int{}
+				scopeNames = append(scopeNames, scope)
+			}
+			scopeTypes[key][typ]++
+		}
+	}
+	sort.Slice(scopeNames, func(i, j int) bool { return strings.ToLower(scopeNames[i]) < strings.ToLower(scopeNames[j]) })
+	for _, scope := range scopeNames {
+		h.Entities = append(h.Entities, tools.StoreEntityRequest{
+			Name:        scope,
+			Kind:        "other",
+			Description: "Conventional commit scope (" + formatTypeCounts(scopeTypes[strings.ToLower(scope)]) + ")",
+			SourceAgent: SourceAgent,
+		})
+	}
+
+	// Merge decisions, oldest first.
+	decisionOf := map[string]int{}
+	for i := len(commits) - 1; i >= 0; i-- {
+		c := commits[i]
+		if len(c.Parents) < 2 {
+			continue
+		}
+		decisionOf[c.Hash] = len(h.Decisions)
+		h.Decisions = append(h.Decisions, mergeDecision(c, byHash))
+	}
+
+	for _, tag := range tags {
+		release := Release{Event: releaseEvent(tag, byHash)}
+		for _, hash := range tag.Commits {
+			if i, ok := decisionOf[hash]; ok {
+				release.Decisions = append(release.Decisions, i)
+			}
+		}
+		sort.Ints(release.Decisions)
+		h.Releases = append(h.Releases, release)
+	}
+
+	return h
+}
+
+// conventionalPrefix returns the type and scopes of a conventional commit
+// subject, or "" and nil.
+func conventionalPrefix(subject string) (string, []string) {
+	m := conventionalPattern.FindStringSubmatch(subject)
+	if m == nil {
+		return "", nil
+	}
+	var scopes []string
+	for _, s := range strings.Split(m[2], ",") {
+		if s = strings.TrimSpace(s); s != "" && s != "*" {
+			scopes = append(scopes, s)
+		}
+	}
+	return strings.ToLower(m[1]), scopes
+}
+
+// mergeDecision builds the decision recorded by a merge commit.
+func mergeDecision(c Commit, byHash map[string]Commit) *Document {
+	title := c.Subject
+	body := c.Body
+	// GitHub puts the pull request title on the first body line.
+	if pullRequestPattern.MatchString(c.Subject) && body != "" {
+		first, rest, _ := strings.Cut(body, "\n")
+		title = strings.TrimSpace(first)
+		body = strings.TrimSpace(rest)
+	}
+
+	var rationale []string
+	if body != "" {
+		rationale = append(rationale, body)
+	}
+	doc := &Document{
+		Source: "git:" + shortHash(c.Hash),
+		Status: "active",
+	}
+	var merged []string
+	for _, hash := range c.Merged {
+		mc, ok := byHash[hash]
+		if !ok {
+			continue
+		}
+		merged = append(merged, "- "+mc.Subject)
+		_, scopes := conventionalPrefix(mc.Subject)
+		for _, scope := range scopes {
+			doc.addScope(scope)
+		}
+	}
+	if len(merged) > 0 {
+		rationale = append(rationale, "Merged commits:\n"+strings.Join(merged, "\n"))
+	}
+	if len(rationale) == 0 {
+		rationale = append(rationale, c.Subject)
+	}
+
+	doc.Decision = tools.StoreDecisionRequest{
+		Title:              title,
+		Rationale:          strings.Join(rationale, "\n\n"),
+		Context:            fmt.Sprintf("Merge commit %s by %s on %s", shortHash(c.Hash), c.Author, c.Date.Format("2006-01-02")),
+		SourceAgent:        SourceAgent,
+		SourceConversation: doc.Source,
+	}
+	return doc
+}
+
+// addScope links a conventional-commit scope entity to the decision.
+func (d *Document) addScope(scope string) {
+	for _, ref := range d.Entities {
+		if strings.EqualFold(ref.Entity.Name, scope) {
+			return
+		}
+	}
+	d.Entities = append(d.Entities, EntityRef{
+		Entity: tools.StoreEntityRequest{Name: scope, Kind: "other", SourceAgent: SourceAgent},
+		Role:   "scope",
+	})
+}
+
+// releaseEvent builds the event recorded by a tag.
+func releaseEvent(tag Tag, byHash map[string]Commit) tools.StoreEventRequest {
+	title := "Tag " + tag.Name
+	if versionPattern.MatchString(tag.Name) {
+		title = "Release " + tag.Name
+	}
+
+	types := map[string]int{}
+	for _, hash := range tag.Commits {
+		if typ, _ := conventionalPrefix(byHash[hash].Subject); typ != "" {
+			types[typ]++
+		}
+	}
+	summary := fmt.Sprintf("%d commits", len(tag.Commits))
+	if len(types) > 0 {
+		summary += " (" + formatTypeCounts(types) + ")"
+	}
+
+	description := summary
+	if tag.Message != "" && tag.Message != tag.Name {
+		description = tag.Message + "\n\n" + summary
+	}
+
+	return tools.StoreEventRequest{
+		Title:              title,
+		Description:        description,
+		EventDate:          tag.Date.Format("2006-01-02"),
+		SourceAgent:        SourceAgent,
+		SourceConversation: "git:" + tag.Name,
+	}
+}
+
+// formatTypeCounts renders commit type counts as "3 feat, 1 fix", most
+// frequent first.
+func formatTypeCounts(counts map[string]int) string {
+	types := make([]string, 0, len(counts))
+	for typ := range counts {
+		types = append(types, typ)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if counts[types[i]] != counts[types[j]] {
+			return counts[types[i]] > counts[types[j]]
+		}
+		return types[i] < types[j]
+	})
+	parts := make([]string, len(types))
+	for i, typ := range types {
+		parts[i] = fmt.Sprintf("%d %s", counts[typ], typ)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// shortHash abbreviates a commit hash for display.
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+// ApplyHistory stores the nodes of h through client, linking decisions to
+// their scope entities and release events to the decisions they include.
+// Counts are keyed like those of Apply.
+func ApplyHistory(ctx context.Context, client tools.Querier, h *History) (map[string]int, error) {
+	a := newApplier(client)
+
+	for _, req := range h.Entities {
+		if _, err := a.entity(ctx, req); err != nil {
+			return a.counts, fmt.Errorf("store entity %q: %w", req.Name, err)
+		}
+	}
+
+	decisionIDs := make([]string, len(h.Decisions))
+	for i, doc := range h.Decisions {
+		id, err := a.document(ctx, doc)
+		if err != nil {
+			return a.counts, err
+		}
+		decisionIDs[i] = id
+	}
+
+	for _, release := range h.Releases {
+		event, err := client.StoreEvent(ctx, release.Event)
+		if err != nil {
+			return a.counts, fmt.Errorf("store event %q: %w", release.Event.Title, err)
+		}
+		a.counts["events"]++
+		for _, i := range release.Decisions {
+			fields := map[string]string{"event_id": event.ID, "decision_id": decisionIDs[i]}
+			if err := a.link(ctx, "mie_event_decision", fields); err != nil {
+				return a.counts, fmt.Errorf("link event %q: %w", release.Event.Title, err)
+			}
+		}
+	}
+
+	return a.counts, nil
+}
@@ -0,0 +1,193 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package ingest
+
+import (
+	"context"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestParseGitHistory(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 12, 0, 0, 0, time.UTC) }
+	// Newest first, as git log prints them.
+	commits := []Commit{
+		{Hash: "m2", Parents: []string{"c3", "c4"}, Author: "Bob", Date: day(5), Subject: "Merge branch 'cli'", Merged: []string{"c4"}},
+		{Hash: "c4", Parents: []string{"m1"}, Date: day(4), Subject: "feat(cli): add --json"},
+		{Hash: "c3", Parents: []string{"m1"}, Date: day(4), Subject: "docs: update README"},
+		{Hash: "m1", Parents: []string{"c1", "c2"}, Author: "Alice", Date: day(3), Subject: "Merge pull request #7 from alice/auth",
+			Body: "Add token auth\n\nReplaces basic auth.", Merged: []string{"c2"}},
+		{Hash: "c2", Parents: []string{"c1"}, Date: day(2), Subject: "feat(auth,API): token login"},
+		{Hash: "c1", Date: day(1), Subject: "fix(auth): reject empty passwords"},
+	}
+	tags := []Tag{
+		{Name: "v0.1.0", Date: day(3), Message: "First release", Commits: []string{"m1", "c2", "c1"}},
+		{Name: "nightly", Date: day(5), Commits: []string{"m2", "c4", "c3"}},
+	}
+
+	h := ParseGitHistory(commits, tags)
+
+	var names []string
+	for _, e := range h.Entities {
+		names = append(names, e.Name+": "+e.Description)
+	}
+	want := "API: Conventional commit scope (1 feat)|auth: Conventional commit scope (1 feat, 1 fix)|cli: Conventional commit scope (1 feat)"
+	if got := strings.Join(names, "|"); got != want {
+		t.Errorf("Entities = %s\nwant %s", got, want)
+	}
+
+	if len(h.Decisions) != 2 {
+		t.Fatalf("Decisions = %d, want 2", len(h.Decisions))
+	}
+	pr := h.Decisions[0]
+	if pr.Decision.Title != "Add token auth" {
+		t.Errorf("pull request title = %q", pr.Decision.Title)
+	}
+	if pr.Decision.Rationale != "Replaces basic auth.\n\nMerged commits:\n- feat(auth,API): token login" {
+		t.Errorf("Rationale = %q", pr.Decision.Rationale)
+	}
+	if pr.Decision.Context != "Merge commit m1 by Alice on 2026-03-03" || pr.Source != "git:m1" {
+		t.Errorf("Context = %q, Source = %q", pr.Decision.Context, pr.Source)
+	}
+	if len(pr.Entities) != 2 || pr.Entities[0].Entity.Name != "auth" || pr.Entities[1].Entity.Name != "API" || pr.Entities[0].Role != "scope" {
+		t.Errorf("Entities = %+v", pr.Entities)
+	}
+	if h.Decisions[1].Decision.Title != "Merge branch 'cli'" {
+		t.Errorf("branch merge title = %q", h.Decisions[1].Decision.Title)
+	}
+
+	if len(h.Releases) != 2 {
+		t.Fatalf("Releases = %d, want 2", len(h.Releases))
+	}
+	r := h.Releases[0]
+	if r.Event.Title != "Release v0.1.0" || r.Event.EventDate != "2026-03-03" {
+		t.Errorf("Event = %+v", r.Event)
+	}
+	if r.Event.Description != "First release\n\n3 commits (1 feat, 1 fix)" {
+		t.Errorf("Description = %q", r.Event.Description)
+	}
+	if len(r.Decisions) != 1 || r.Decisions[0] != 0 {
+		t.Errorf("release decisions = %v, want [0]", r.Decisions)
+	}
+	if h.Releases[1].Event.Title != "Tag nightly" || len(h.Releases[1].Decisions) != 1 || h.Releases[1].Decisions[0] != 1 {
+		t.Errorf("second release = %+v", h.Releases[1])
+	}
+}
+
+func TestConventionalPrefix(t *testing.T) {
+	tests := []struct {
+		subject string
+		typ     string
+		scopes  string
+	}{
+		{"feat(auth): add login", "feat", "auth"},
+		{"fix(api, cli)!: breaking", "fix", "api|cli"},
+		{"chore: bump deps", "chore", ""},
+		{"Merge branch 'main'", "", ""},
+		{"WIP:", "", ""},
+	}
+	for _, tt := range tests {
+		typ, scopes := conventionalPrefix(tt.subject)
+		if typ != tt.typ || strings.Join(scopes, "|") != tt.scopes {
+			t.Errorf("conventionalPrefix(%q) = %q, %v", tt.subject, typ, scopes)
+		}
+	}
+}
+
+func TestReadGitHistory(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q", "-b", "main")
+	git("commit", "-q", "--allow-empty", "-m", "fix(core): first")
+	git("tag", "-a", "v1.0.0", "-m", "First release")
+	git("checkout", "-q", "-b", "feature")
+	git("commit", "-q", "--allow-empty", "-m", "feat(cli): second", "-m", "With a body.")
+	git("checkout", "-q", "main")
+	git("merge", "-q", "--no-ff", "-m", "Merge branch 'feature'", "feature")
+	git("tag", "v1.1.0")
+
+	commits, tags, err := ReadGitHistory(context.Background(), dir, 0)
+	if err != nil {
+		t.Fatalf("ReadGitHistory() error = %v", err)
+	}
+	if len(commits) != 3 {
+		t.Fatalf("commits = %d, want 3", len(commits))
+	}
+	merge := commits[0]
+	if merge.Subject != "Merge branch 'feature'" || len(merge.Parents) != 2 || len(merge.Merged) != 1 || merge.Author != "Test" {
+		t.Errorf("merge = %+v", merge)
+	}
+	if commits[1].Subject != "feat(cli): second" || commits[1].Body != "With a body." {
+		t.Errorf("commit = %+v", commits[1])
+	}
+
+	if len(tags) != 2 {
+		t.Fatalf("tags = %+v, want 2", tags)
+	}
+	if tags[0].Name != "v1.0.0" || tags[0].Message != "First release" || len(tags[0].Commits) != 1 {
+		t.Errorf("annotated tag = %+v", tags[0])
+	}
+	if tags[1].Name != "v1.1.0" || tags[1].Message != "" || len(tags[1].Commits) != 2 {
+		t.Errorf("lightweight tag = %+v", tags[1])
+	}
+
+	// A tag name starting with "-" is not read as a git option.
+	git("update-ref", "refs/tags/-rc2", "HEAD")
+	_, tags, err = ReadGitHistory(context.Background(), dir, 0)
+	if err != nil {
+		t.Fatalf("ReadGitHistory() with tag -rc2 error = %v", err)
+	}
+	if !slices.ContainsFunc(tags, func(tag Tag) bool { return tag.Name == "-rc2" }) {
+		t.Errorf("tags = %+v, want -rc2", tags)
+	}
+
+	if _, _, err := ReadGitHistory(context.Background(), t.TempDir(), 0); err == nil {
+		t.Error("expected an error outside a git repository")
+	}
+}
+
+func TestApplyHistory(t *testing.T) {
+	fake := &fakeQuerier{statuses: map[string]string{}}
+
+	h := &History{
+		Entities: []tools.StoreEntityRequest{{Name: "auth", Description: "Conventional commit scope (1 feat)"}},
+		Decisions: []*Document{{
+			Source:   "git:m1",
+			Decision: tools.StoreDecisionRequest{Title: "Add token auth"},
+			Entities: []EntityRef{{Entity: tools.StoreEntityRequest{Name: "auth"}, Role: "scope"}},
+		}},
+		Releases: []Release{{Event: tools.StoreEventRequest{Title: "Release v1"}, Decisions: []int{0}}},
+	}
+
+	counts, err := ApplyHistory(context.Background(), fake, h)
+	if err != nil {
+		t.Fatalf("ApplyHistory() error = %v", err)
+	}
+	want := map[string]int{"entities": 1, "decisions": 1, "events": 1, "relationships": 2}
+	for kind, n := range want {
+		if counts[kind] != n {
+			t.Errorf("counts[%s] = %d, want %d", kind, counts[kind], n)
+		}
+	}
+	wantEdges := "mie_decision_entity map[decision_id:dec:Add token auth entity_id:ent:auth role:scope source_agent:mie-import]\n" +
+		"mie_event_decision map[decision_id:dec:Add token auth event_id:evt:Release v1 source_agent:mie-import]"
+	if got := strings.Join(fake.edges, "\n"); got != wantEdges {
+		t.Errorf("edges =\n%s\nwant\n%s", got, wantEdges)
+	}
+}
@@ -0,0 +1,408 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package ingest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// SourceAgent is recorded as the source_agent of every node created by ingest.
+const SourceAgent = "mie-import"
+
+// ErrNotADR is returned by ParseMarkdown when a file has no title or no
+// Decision section.
+var ErrNotADR = errors.New("no ADR structure found (expected a title and a Decision section)")
+
+// Document is the knowledge extracted from one source file.
+type Document struct {
+	// Source identifies the file the document was parsed from.
+	Source   string
+	Decision tools.StoreDecisionRequest
+	// Status is the decision status: proposed, active, superseded, or reversed.
+	Status   string
+	Entities []EntityRef
+	Topics   []tools.StoreTopicRequest
+}
+
+// EntityRef is an entity mentioned by a document and its role in the decision.
+type EntityRef struct {
+	Entity tools.StoreEntityRequest
+	Role   string
+}
+
+// Section headings recognized in ADRs, normalized to lowercase.
+var (
+	decisionHeadings     = []string{"decision", "decision outcome", "the decision"}
+	contextHeadings      = []string{"context", "context and problem statement", "problem statement", "background"}
+	consequencesHeadings = []string{"consequences", "positive consequences", "negative consequences"}
+	optionsHeadings      = []string{"considered options", "options", "options considered", "alternatives", "alternatives considered"}
+	statusHeadings       = []string{"status"}
+)
+
+var (
+	headingPattern  = regexp.MustCompile(`^(#{1,6})\s+(.*?)[\s#]*$`)
+	titlePrefix     = regexp.MustCompile(`(?i)^(?:adr)?[\s-]*\d+\s*[.:)\-–]?\s+`)
+	metadataPattern = regexp.MustCompile(`(?i)^\s*(?:[-*+]\s+)?(status|deciders|tags)\s*:\s*(.+)$`)
+	listItemPattern = regexp.MustCompile(`^(?:[-*+]|\d+[.)])\s+(.+)$`)
+	linkPattern     = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	chosenPattern   = regexp.MustCompile(`(?i)chosen option:?\s*["“]?([^"”,\n]+)`)
+	listSeparator   = regexp.MustCompile(`\s*(?:,|;|\band\b)\s*`)
+)
+
+// maxEntityWords keeps long option descriptions ("Use a message queue for
+// retries") from becoming entities; names like "PostgreSQL" or "AWS Lambda"
+// pass.
+const maxEntityWords = 3
+
+// frontMatter holds the YAML front matter fields used by ADR templates.
+type frontMatter struct {
+	Title    string     `yaml:"title"`
+	Status   string     `yaml:"status"`
+	Deciders stringList `yaml:"deciders"`
+	Tags     stringList `yaml:"tags"`
+	Topics   stringList `yaml:"topics"`
+}
+
+// stringList accepts either a YAML sequence or a comma-separated string.
+type stringList []string
+
+func (l *stringList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = splitList(node.Value)
+		return nil
+	}
+	var items []string
+	if err := node.Decode(&items); err != nil {
+		return err
+	}
+	*l = items
+	return nil
+}
+
+// ParseMarkdown extracts a decision and the entities and topics around it
+// from an ADR-structured markdown file. source identifies the file and is
+// recorded as the decision's source conversation. Files without a title and
+// a Decision section return ErrNotADR.
+func ParseMarkdown(source string, data []byte) (*Document, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+
+	var meta frontMatter
+	if fm, body, ok := splitFrontMatter(text); ok {
+		if err := yaml.Unmarshal([]byte(fm), &meta); err != nil {
+			return nil, fmt.Errorf("invalid front matter: %w", err)
+		}
+		text = body
+	}
+
+	title, preamble, sections := splitSections(text)
+	if title == "" {
+		title = meta.Title
+	}
+	title = cleanTitle(title)
+	rationale := sectionText(sections, decisionHeadings)
+	if title == "" || rationale == "" {
+		return nil, ErrNotADR
+	}
+
+	// Metadata lines before the first section, as in "Status: accepted".
+	status := meta.Status
+	deciders := []string(meta.Deciders)
+	tags := append([]string(meta.Tags), meta.Topics...)
+	for _, line := range strings.Split(preamble, "\n") {
+		m := metadataPattern.FindStringSubmatch(stripEmphasis(line))
+		if m == nil {
+			continue
+		}
+		switch strings.ToLower(m[1]) {
+		case "status":
+			if status == "" {
+				status = m[2]
+			}
+		case "deciders":
+			deciders = append(deciders, splitList(m[2])...)
+		case "tags":
+			tags = append(tags, splitList(m[2])...)
+		}
+	}
+	if s := sectionText(sections, statusHeadings); s != "" {
+		status = s
+	}
+
+	doc := &Document{
+		Source: source,
+		Status: decisionStatus(status),
+		Decision: tools.StoreDecisionRequest{
+			Title:              title,
+			Rationale:          rationale,
+			Context:            decisionContext(sections),
+			SourceAgent:        SourceAgent,
+			SourceConversation: source,
+		},
+	}
+
+	options := listItems(sectionText(sections, optionsHeadings))
+	chosen := chosenOption(rationale, options)
+	var alternatives []string
+	for i, opt := range options {
+		role := "alternative"
+		if i == chosen {
+			role = "chosen"
+		} else {
+			alternatives = append(alternatives, opt)
+		}
+		if name := optionName(opt); len(strings.Fields(name)) <= maxEntityWords {
+			doc.addEntity(name, "other", role)
+		}
+	}
+	if len(alternatives) > 0 {
+		encoded, _ := json.Marshal(alternatives)
+		doc.Decision.Alternatives = string(encoded)
+	}
+
+	for _, name := range deciders {
+		doc.addEntity(cleanInline(name), "person", "decider")
+	}
+
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag = cleanInline(tag)
+		if tag == "" || seen[strings.ToLower(tag)] {
+			continue
+		}
+		seen[strings.ToLower(tag)] = true
+		doc.Topics = append(doc.Topics, tools.StoreTopicRequest{Name: tag})
+	}
+
+	return doc, nil
+}
+
+// addEntity records an entity unless the document already mentions it.
+func (d *Document) addEntity(name, kind, role string) {
+	if name == "" {
+		return
+	}
+	for _, ref := range d.Entities {
+		if strings.EqualFold(ref.Entity.Name, name) {
+			return
+		}
+	}
+	d.Entities = append(d.Entities, EntityRef{
+		Entity: tools.StoreEntityRequest{
+			Name:        name,
+			Kind:        kind,
+			Description: fmt.Sprintf("Mentioned in %q", d.Decision.Title),
+			SourceAgent: SourceAgent,
+		},
+		Role: role,
+	})
+}
+
+// splitFrontMatter separates a leading "---" YAML block from the body.
+func splitFrontMatter(text string) (string, string, bool) {
+	if !strings.HasPrefix(text, "---\n") {
+		return "", text, false
+	}
+	rest := text[len("---\n"):]
+	end := strings.Index(rest, "\n---")
+	if end < 0 {
+		return "", text, false
+	}
+	body := rest[end+len("\n---"):]
+	if i := strings.IndexByte(body, '\n'); i >= 0 {
+		body = body[i+1:]
+	} else {
+		body = ""
+	}
+	return rest[:end], body, true
+}
+
+// splitSections returns the first level-1 heading, the text between it and
+// the next heading, and the text under every other heading keyed by its
+// lowercased title. Headings inside fenced code blocks are ignored.
+func splitSections(text string) (string, string, map[string]string) {
+	var title string
+	var preamble strings.Builder
+	sections := map[string]string{}
+	current := ""
+	inSection := false
+	inFence := false
+
+	var body strings.Builder
+	flush := func() {
+		if inSection {
+			if prev, ok := sections[current]; ok {
+				sections[current] = prev + "\n\n" + strings.TrimSpace(body.String())
+			} else {
+				sections[current] = strings.TrimSpace(body.String())
+			}
+		}
+		body.Reset()
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		}
+		if !inFence {
+			if m := headingPattern.FindStringSubmatch(line); m != nil {
+				if len(m[1]) == 1 && title == "" && !inSection {
+					title = m[2]
+					continue
+				}
+				flush()
+				current = normalizeHeading(m[2])
+				inSection = true
+				continue
+			}
+		}
+		if inSection {
+			body.WriteString(line + "\n")
+		} else {
+			preamble.WriteString(line + "\n")
+		}
+	}
+	flush()
+
+	return title, preamble.String(), sections
+}
+
+// normalizeHeading lowercases a heading and drops numbering and emphasis.
+func normalizeHeading(heading string) string {
+	heading = strings.ToLower(cleanInline(heading))
+	heading = strings.TrimLeft(heading, "0123456789. ")
+	return strings.TrimRight(heading, ": ")
+}
+
+// sectionText returns the text of the first non-empty section among headings.
+func sectionText(sections map[string]string, headings []string) string {
+	for _, h := range headings {
+		if text := sections[h]; text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// decisionContext combines the Context and Consequences sections.
+func decisionContext(sections map[string]string) string {
+	context := sectionText(sections, contextHeadings)
+	var consequences []string
+	for _, h := range consequencesHeadings {
+		if text := sections[h]; text != "" {
+			consequences = append(consequences, text)
+		}
+	}
+	if len(consequences) == 0 {
+		return context
+	}
+	joined := "Consequences:\n" + strings.Join(consequences, "\n\n")
+	if context == "" {
+		return joined
+	}
+	return context + "\n\n" + joined
+}
+
+// decisionStatus maps an ADR status to a MIE decision status.
+func decisionStatus(status string) string {
+	fields := strings.Fields(strings.ToLower(cleanInline(status)))
+	if len(fields) == 0 {
+		return "active"
+	}
+	switch strings.Trim(fields[0], ".,;:") {
+	case "superseded", "deprecated", "replaced":
+		return "superseded"
+	case "rejected", "reversed", "withdrawn", "revoked":
+		return "reversed"
+	case "proposed", "draft":
+		return "proposed"
+	default:
+		return "active"
+	}
+}
+
+// listItems returns the cleaned top-level list items of a section.
+func listItems(text string) []string {
+	var items []string
+	for _, line := range strings.Split(text, "\n") {
+		if m := listItemPattern.FindStringSubmatch(line); m != nil {
+			if item := cleanInline(m[1]); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+// optionName returns the name part of an option such as
+// "PostgreSQL - relational database" or "DynamoDB (managed)".
+func optionName(option string) string {
+	for _, sep := range []string{" - ", " – ", " — ", ": ", " ("} {
+		if i := strings.Index(option, sep); i > 0 {
+			option = option[:i]
+		}
+	}
+	return strings.TrimSpace(option)
+}
+
+// chosenOption returns the index of the option selected by the decision
+// text, or -1. An explicit MADR "Chosen option:" line wins; otherwise the
+// option mentioned first in the decision text is chosen.
+func chosenOption(decision string, options []string) int {
+	if m := chosenPattern.FindStringSubmatch(stripEmphasis(decision)); m != nil && strings.TrimSpace(m[1]) != "" {
+		picked := strings.ToLower(strings.TrimSpace(m[1]))
+		for i, opt := range options {
+			name := strings.ToLower(optionName(opt))
+			if strings.HasPrefix(picked, name) || strings.HasPrefix(name, picked) {
+				return i
+			}
+		}
+	}
+	lower := strings.ToLower(decision)
+	chosen, first := -1, len(lower)
+	for i, opt := range options {
+		if pos := strings.Index(lower, strings.ToLower(optionName(opt))); pos >= 0 && pos < first {
+			chosen, first = i, pos
+		}
+	}
+	return chosen
+}
+
+// cleanTitle strips ADR numbering such as "3.", "ADR-003:" or "0003 -".
+func cleanTitle(title string) string {
+	return strings.TrimSpace(titlePrefix.ReplaceAllString(cleanInline(title), ""))
+}
+
+// cleanInline removes inline markdown: links keep their text, and code and
+// emphasis markers are dropped.
+func cleanInline(s string) string {
+	s = linkPattern.ReplaceAllString(s, "$1")
+	s = strings.ReplaceAll(s, "`", "")
+	return strings.TrimSpace(stripEmphasis(s))
+}
+
+// stripEmphasis removes bold and italic markers.
+func stripEmphasis(s string) string {
+	return strings.NewReplacer("**", "", "__", "").Replace(s)
+}
+
+// splitList splits "Alice, Bob and Carol" into names.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range listSeparator.Split(s, -1) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,211 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package ingest
+
+import (
+	"errors"
+	"testing"
+)
+
+const nygardADR = `# 3. Use PostgreSQL for payments
+
+Date: 2026-01-12
+
+## Status
+
+Superseded by [ADR 7](0007-use-aurora.md)
+
+## Context
+
+The payments module needs ACID transactions.
+
+` + "```" + `
+# not a heading
+` + "```" + `
+
+## Options
+
+- DynamoDB - managed key-value store
+- PostgreSQL (self-hosted)
+- Build our own ledger on top of S3 objects
+
+## Decision
+
+We will use PostgreSQL instead of DynamoDB.
+
+## Consequences
+
+We must run database migrations.
+`
+
+const madrADR = `---
+status: accepted
+deciders: Alice, Bob
+tags: [databases, Payments]
+---
+# ADR-0004: Choose a queue
+
+## Context and Problem Statement
+
+Retries are lost on deploy.
+
+## Considered Options
+
+* ` + "`RabbitMQ`" + `
+* **Kafka**
+
+## Decision Outcome
+
+Chosen option: "Kafka", because it keeps a replayable log.
+`
+
+func TestParseMarkdown_Nygard(t *testing.T) {
+	doc, err := ParseMarkdown("docs/adr/0003-postgres.md", []byte(nygardADR))
+	if err != nil {
+		t.Fatalf("ParseMarkdown() error = %v", err)
+	}
+
+	d := doc.Decision
+	if d.Title != "Use PostgreSQL for payments" {
+		t.Errorf("Title = %q", d.Title)
+	}
+	if d.Rationale != "We will use PostgreSQL instead of DynamoDB." {
+		t.Errorf("Rationale = %q", d.Rationale)
+	}
+	want := "The payments module needs ACID transactions.\n\n```\n# not a heading\n```\n\nConsequences:\nWe must run database migrations."
+	if d.Context != want {
+		t.Errorf("Context = %q, want %q", d.Context, want)
+	}
+	if d.Alternatives != `["DynamoDB - managed key-value store","Build our own ledger on top of S3 objects"]` {
+		t.Errorf("Alternatives = %s", d.Alternatives)
+	}
+	if d.SourceAgent != SourceAgent || d.SourceConversation != "docs/adr/0003-postgres.md" {
+		t.Errorf("source = %q/%q", d.SourceAgent, d.SourceConversation)
+	}
+	if doc.Status != "superseded" {
+		t.Errorf("Status = %q, want superseded", doc.Status)
+	}
+
+	// The long third option is not an entity.
+	if len(doc.Entities) != 2 {
+		t.Fatalf("Entities = %+v, want 2", doc.Entities)
+	}
+	if e := doc.Entities[0]; e.Entity.Name != "DynamoDB" || e.Role != "alternative" {
+		t.Errorf("Entities[0] = %+v", e)
+	}
+	if e := doc.Entities[1]; e.Entity.Name != "PostgreSQL" || e.Role != "chosen" {
+		t.Errorf("Entities[1] = %+v", e)
+	}
+}
+
+func TestParseMarkdown_MADR(t *testing.T) {
+	doc, err := ParseMarkdown("0004-queue.md", []byte(madrADR))
+	if err != nil {
+		t.Fatalf("ParseMarkdown() error = %v", err)
+	}
+
+	if doc.Decision.Title != "Choose a queue" {
+		t.Errorf("Title = %q", doc.Decision.Title)
+	}
+	if doc.Status != "active" {
+		t.Errorf("Status = %q, want active", doc.Status)
+	}
+	if doc.Decision.Alternatives != `["RabbitMQ"]` {
+		t.Errorf("Alternatives = %s", doc.Decision.Alternatives)
+	}
+
+	roles := map[string]string{}
+	kinds := map[string]string{}
+	for _, ref := range doc.Entities {
+		roles[ref.Entity.Name] = ref.Role
+		kinds[ref.Entity.Name] = ref.Entity.Kind
+	}
+	wantRoles := map[string]string{"RabbitMQ": "alternative", "Kafka": "chosen", "Alice": "decider", "Bob": "decider"}
+	for name, role := range wantRoles {
+		if roles[name] != role {
+			t.Errorf("role of %s = %q, want %q", name, roles[name], role)
+		}
+	}
+	if kinds["Alice"] != "person" {
+		t.Errorf("kind of Alice = %q, want person", kinds["Alice"])
+	}
+
+	if len(doc.Topics) != 2 || doc.Topics[0].Name != "databases" || doc.Topics[1].Name != "Payments" {
+		t.Errorf("Topics = %+v", doc.Topics)
+	}
+}
+
+func TestParseMarkdown_PreambleMetadata(t *testing.T) {
+	src := `# Adopt Go
+
+* **Status:** rejected
+* Deciders: Carol and Dan
+* Tags: languages
+
+## Decision
+
+Stay on Python.
+`
+	doc, err := ParseMarkdown("adopt-go.md", []byte(src))
+	if err != nil {
+		t.Fatalf("ParseMarkdown() error = %v", err)
+	}
+	if doc.Status != "reversed" {
+		t.Errorf("Status = %q, want reversed", doc.Status)
+	}
+	if len(doc.Entities) != 2 || doc.Entities[0].Entity.Name != "Carol" || doc.Entities[1].Entity.Name != "Dan" {
+		t.Errorf("Entities = %+v", doc.Entities)
+	}
+	if len(doc.Topics) != 1 || doc.Topics[0].Name != "languages" {
+		t.Errorf("Topics = %+v", doc.Topics)
+	}
+}
+
+func TestParseMarkdown_Proposed(t *testing.T) {
+	src := `# Adopt Rust
+
+Status: Proposed
+
+## Decision
+
+Rewrite the parser in Rust.
+`
+	doc, err := ParseMarkdown("adopt-rust.md", []byte(src))
+	if err != nil {
+		t.Fatalf("ParseMarkdown() error = %v", err)
+	}
+	if doc.Status != "proposed" {
+		t.Errorf("Status = %q, want proposed", doc.Status)
+	}
+}
+
+func TestParseMarkdown_NotADR(t *testing.T) {
+	inputs := map[string]string{
+		"no decision": "# README\n\n## Install\n\nRun make.\n",
+		"no title":    "## Decision\n\nUse Go.\n",
+	}
+	for name, src := range inputs {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseMarkdown("x.md", []byte(src))
+			if !errors.Is(err, ErrNotADR) {
+				t.Errorf("ParseMarkdown() error = %v, want ErrNotADR", err)
+			}
+		})
+	}
+}
+
+func TestCleanTitle(t *testing.T) {
+	tests := map[string]string{
+		"1. Record architecture decisions": "Record architecture decisions",
+		"ADR-003: Use Postgres":            "Use Postgres",
+		"0007 - Use Aurora":                "Use Aurora",
+		"Use `gRPC` for [internal](x) RPC": "Use gRPC for internal RPC",
+	}
+	for in, want := range tests {
+		if got := cleanTitle(in); got != want {
+			t.Errorf("cleanTitle(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,459 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// ErrNoMemories is returned by the memory export parsers when an export
+// holds nothing to import.
+var ErrNoMemories = errors.New("no memories found")
+
+// Memory is one record of another agent-memory system's export. At most one
+// of Fact and Event is set; a memory with neither only stores its entities.
+type Memory struct {
+	// Source identifies the record, such as "mem0:<id>", and is recorded as
+	// the source conversation of its fact or event.
+	Source string
+	Fact   *tools.StoreFactRequest
+	Event  *tools.StoreEventRequest
+	// Entities are the people and things the memory is about.
+	Entities []tools.StoreEntityRequest
+	Topics   []tools.StoreTopicRequest
+}
+
+// mem0Categories maps mem0's default memory categories to fact categories.
+// Other categories only become topics.
+var mem0Categories = map[string]string{
+	"personal_details":     "personal",
+	"health":               "personal",
+	"hobbies":              "personal",
+	"travel":               "personal",
+	"sports":               "personal",
+	"milestones":           "personal",
+	"family":               "relationship",
+	"professional_details": "professional",
+	"technology":           "technical",
+	"user_preferences":     "preference",
+	"food":                 "preference",
+	"music":                "preference",
+	"fashion":              "preference",
+	"entertainment":        "preference",
+}
+
+// mem0Memory is a memory as returned by mem0's get_all and export APIs.
+type mem0Memory struct {
+	ID         string   `json:"id"`
+	Memory     string   `json:"memory"`
+	Text       string   `json:"text"` // older exports
+	Categories []string `json:"categories"`
+	UserID     string   `json:"user_id"`
+}
+
+// mem0Relation is an edge of mem0's graph memory.
+type mem0Relation struct {
+	Source       string `json:"source"`
+	Relationship string `json:"relationship"`
+	Target       string `json:"target"`
+	Destination  string `json:"destination"` // older exports
+}
+
+// ParseMem0 reads a mem0 export: either the list of memories returned by
+// get_all, or an object with "results" (or "memories") and, with graph
+// memory enabled, "relations".
+//
+// Each memory becomes a fact linked to its user as a person entity, with
+// its categories as topics; the default categories also pick the fact's
+// category. Each relation becomes a relationship fact such as "alice works
+// at acme" linked to both ends.
+func ParseMem0(data []byte) ([]*Memory, error) {
+	var export struct {
+		Results   []mem0Memory   `json:"results"`
+		Memories  []mem0Memory   `json:"memories"`
+		Relations []mem0Relation `json:"relations"`
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &export.Results); err != nil {
+			return nil, fmt.Errorf("invalid mem0 export: %w", err)
+		}
+	} else if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("invalid mem0 export: %w", err)
+	}
+
+	var memories []*Memory
+	for _, m := range append(export.Results, export.Memories...) {
+		text := strings.TrimSpace(m.Memory)
+		if text == "" {
+			text = strings.TrimSpace(m.Text)
+		}
+		if text == "" {
+			continue
+		}
+		mem := &Memory{
+			Source: "mem0:" + m.ID,
+			Fact: &tools.StoreFactRequest{
+				Content:            text,
+				Category:           "general",
+				SourceAgent:        SourceAgent,
+				SourceConversation: "mem0:" + m.ID,
+			},
+		}
+		for _, c := range m.Categories {
+			if category, ok := mem0Categories[c]; ok && mem.Fact.Category == "general" {
+				mem.Fact.Category = category
+			}
+		}
+		mem.Topics = topicRequests(m.Categories)
+		if m.UserID != "" {
+			mem.Entities = append(mem.Entities, userEntity(m.UserID, "mem0"))
+		}
+		memories = append(memories, mem)
+	}
+
+	for _, r := range export.Relations {
+		target := r.Target
+		if target == "" {
+			target = r.Destination
+		}
+		relationship := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(r.Relationship), "_", " "))
+		if r.Source == "" || target == "" || relationship == "" {
+			continue
+		}
+		memories = append(memories, &Memory{
+			Source: "mem0:relations",
+			Fact: &tools.StoreFactRequest{
+				Content:            fmt.Sprintf("%s %s %s", r.Source, relationship, target),
+				Category:           "relationship",
+				SourceAgent:        SourceAgent,
+				SourceConversation: "mem0:relations",
+			},
+			Entities: []tools.StoreEntityRequest{
+				{Name: r.Source, Kind: "other", SourceAgent: SourceAgent},
+				{Name: target, Kind: "other", SourceAgent: SourceAgent},
+			},
+		})
+	}
+
+	if len(memories) == 0 {
+		return nil, ErrNoMemories
+	}
+	return memories, nil
+}
+
+// zepSession is a session of a Zep memory export.
+type zepSession struct {
+	SessionID     string     `json:"session_id"`
+	UserID        string     `json:"user_id"`
+	CreatedAt     string     `json:"created_at"`
+	Summary       zepSummary `json:"summary"`
+	Facts         []zepFact  `json:"facts"`
+	RelevantFacts []zepFact  `json:"relevant_facts"`
+}
+
+// zepSummary is a session summary, exported as a string or as an object
+// with content and created_at.
+type zepSummary struct {
+	Content   string `json:"content"`
+	CreatedAt string `json:"created_at"`
+}
+
+func (s *zepSummary) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &s.Content)
+	}
+	type plain zepSummary
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+// zepFact is a fact Zep extracted from a session, exported as a string or
+// as an object. Facts with an invalid_at or expired_at are no longer true.
+type zepFact struct {
+	Fact      string `json:"fact"`
+	InvalidAt string `json:"invalid_at"`
+	ExpiredAt string `json:"expired_at"`
+}
+
+func (f *zepFact) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &f.Fact)
+	}
+	type plain zepFact
+	return json.Unmarshal(data, (*plain)(f))
+}
+
+// ParseZep reads Zep sessions: one session with its memory, a list of
+// them, or an object with "sessions".
+//
+// Each session summary becomes an event dated by the summary or session
+// creation time, or a fact when neither is known. Each fact Zep extracted
+// becomes a fact, except those Zep has since invalidated, and is linked to
+// the session's user as a person entity. Messages are not imported.
+func ParseZep(data []byte) ([]*Memory, error) {
+	var sessions []zepSession
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case len(trimmed) > 0 && trimmed[0] == '[':
+		if err := json.Unmarshal(trimmed, &sessions); err != nil {
+			return nil, fmt.Errorf("invalid Zep export: %w", err)
+		}
+	default:
+		var export struct {
+			Sessions []zepSession `json:"sessions"`
+		}
+		if err := json.Unmarshal(trimmed, &export); err != nil {
+			return nil, fmt.Errorf("invalid Zep export: %w", err)
+		}
+		sessions = export.Sessions
+		if sessions == nil {
+			var session zepSession
+			if err := json.Unmarshal(trimmed, &session); err != nil {
+				return nil, fmt.Errorf("invalid Zep export: %w", err)
+			}
+			sessions = []zepSession{session}
+		}
+	}
+
+	var memories []*Memory
+	for _, s := range sessions {
+		source := "zep:" + s.SessionID
+		var entities []tools.StoreEntityRequest
+		if s.UserID != "" {
+			entities = append(entities, userEntity(s.UserID, "Zep"))
+		}
+
+		if summary := strings.TrimSpace(s.Summary.Content); summary != "" {
+			mem := &Memory{Source: source, Entities: entities}
+			date := datePattern.FindString(s.Summary.CreatedAt)
+			if date == "" {
+				date = datePattern.FindString(s.CreatedAt)
+			}
+			if date != "" {
+				mem.Event = &tools.StoreEventRequest{
+					Title:              "Zep session " + s.SessionID,
+					Description:        summary,
+					EventDate:          date,
+					SourceAgent:        SourceAgent,
+					SourceConversation: source,
+				}
+			} else {
+				mem.Fact = &tools.StoreFactRequest{
+					Content:            summary,
+					Category:           "general",
+					SourceAgent:        SourceAgent,
+					SourceConversation: source,
+				}
+			}
+			memories = append(memories, mem)
+		}
+
+		for _, f := range append(s.Facts, s.RelevantFacts...) {
+			text := strings.TrimSpace(f.Fact)
+			if text == "" || f.InvalidAt != "" || f.ExpiredAt != "" {
+				continue
+			}
+			memories = append(memories, &Memory{
+				Source: source,
+				Fact: &tools.StoreFactRequest{
+					Content:            text,
+					Category:           "general",
+					SourceAgent:        SourceAgent,
+					SourceConversation: source,
+				},
+				Entities: entities,
+			})
+		}
+	}
+
+	if len(memories) == 0 {
+		return nil, ErrNoMemories
+	}
+	return memories, nil
+}
+
+// langChainMemory is the serialized state of a LangChain memory class, such
+// as ConversationSummaryMemory, ConversationSummaryBufferMemory, or
+// ConversationEntityMemory.
+type langChainMemory struct {
+	Buffer              string `json:"buffer"`
+	MovingSummaryBuffer string `json:"moving_summary_buffer"`
+	EntityStore         struct {
+		Store map[string]string `json:"store"`
+	} `json:"entity_store"`
+}
+
+// ParseLangChain reads LangChain memory dumps: one serialized memory or a
+// list of them. The running summary of ConversationSummaryMemory ("buffer")
+// or ConversationSummaryBufferMemory ("moving_summary_buffer") becomes a
+// fact, and each entity summary of ConversationEntityMemory's entity store
+// becomes an entity described by it. The summary fact is linked to the
+// stored entities it names. Chat messages are not imported.
+func ParseLangChain(data []byte) ([]*Memory, error) {
+	var dumps []langChainMemory
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &dumps); err != nil {
+			return nil, fmt.Errorf("invalid LangChain memory: %w", err)
+		}
+	} else {
+		var dump langChainMemory
+		if err := json.Unmarshal(data, &dump); err != nil {
+			return nil, fmt.Errorf("invalid LangChain memory: %w", err)
+		}
+		dumps = []langChainMemory{dump}
+	}
+
+	var memories []*Memory
+	for i, d := range dumps {
+		source := fmt.Sprintf("langchain:%d", i)
+
+		names := make([]string, 0, len(d.EntityStore.Store))
+		for name := range d.EntityStore.Store {
+			if strings.TrimSpace(name) != "" {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		var entities []tools.StoreEntityRequest
+		for _, name := range names {
+			entities = append(entities, tools.StoreEntityRequest{
+				Name:        strings.TrimSpace(name),
+				Kind:        "other",
+				Description: strings.TrimSpace(d.EntityStore.Store[name]),
+				SourceAgent: SourceAgent,
+			})
+		}
+
+		summary := strings.TrimSpace(d.MovingSummaryBuffer)
+		if summary == "" {
+			summary = strings.TrimSpace(d.Buffer)
+		}
+		if summary == "" {
+			if len(entities) > 0 {
+				memories = append(memories, &Memory{Source: source, Entities: entities})
+			}
+			continue
+		}
+
+		mem := &Memory{
+			Source: source,
+			Fact: &tools.StoreFactRequest{
+				Content:            summary,
+				Category:           "general",
+				SourceAgent:        SourceAgent,
+				SourceConversation: source,
+			},
+		}
+		var unnamed []tools.StoreEntityRequest
+		for _, e := range entities {
+			if tools.MentionsWord(summary, e.Name, true) {
+				mem.Entities = append(mem.Entities, e)
+			} else {
+				unnamed = append(unnamed, e)
+			}
+		}
+		memories = append(memories, mem)
+		if len(unnamed) > 0 {
+			memories = append(memories, &Memory{Source: source, Entities: unnamed})
+		}
+	}
+
+	if len(memories) == 0 {
+		return nil, ErrNoMemories
+	}
+	return memories, nil
+}
+
+// ApplyMemories stores memories through client. Each fact is linked to the
+// memory's entities (fact_entity) and topics (fact_topic), and each entity
+// to its topics (entity_topic). Events have no entity or topic edges, so an
+// event's entities are stored unlinked. Counts are keyed like those of
+// Apply.
+func ApplyMemories(ctx context.Context, client tools.Querier, memories []*Memory) (map[string]int, error) {
+	a := newApplier(client)
+	for _, mem := range memories {
+		entityIDs := make([]string, len(mem.Entities))
+		for i, req := range mem.Entities {
+			id, err := a.entity(ctx, req)
+			if err != nil {
+				return a.counts, fmt.Errorf("%s: store entity %q: %w", mem.Source, req.Name, err)
+			}
+			entityIDs[i] = id
+		}
+		topicIDs := make([]string, len(mem.Topics))
+		for i, req := range mem.Topics {
+			id, err := a.topic(ctx, req)
+			if err != nil {
+				return a.counts, fmt.Errorf("%s: store topic %q: %w", mem.Source, req.Name, err)
+			}
+			topicIDs[i] = id
+		}
+
+		if mem.Event != nil {
+			if _, err := client.StoreEvent(ctx, *mem.Event); err != nil {
+				return a.counts, fmt.Errorf("%s: store event: %w", mem.Source, err)
+			}
+			a.counts["events"]++
+		}
+		if mem.Fact != nil {
+			fact, err := client.StoreFact(ctx, *mem.Fact)
+			if err != nil {
+				return a.counts, fmt.Errorf("%s: store fact: %w", mem.Source, err)
+			}
+			a.counts["facts"]++
+			for _, id := range entityIDs {
+				if err := a.linkOnce(ctx, "mie_fact_entity", map[string]string{"fact_id": fact.ID, "entity_id": id}); err != nil {
+					return a.counts, fmt.Errorf("%s: link entity: %w", mem.Source, err)
+				}
+			}
+			for _, id := range topicIDs {
+				if err := a.linkOnce(ctx, "mie_fact_topic", map[string]string{"fact_id": fact.ID, "topic_id": id}); err != nil {
+					return a.counts, fmt.Errorf("%s: link topic: %w", mem.Source, err)
+				}
+			}
+		}
+
+		for _, entityID := range entityIDs {
+			for _, topicID := range topicIDs {
+				if err := a.linkOnce(ctx, "mie_entity_topic", map[string]string{"entity_id": entityID, "topic_id": topicID}); err != nil {
+					return a.counts, fmt.Errorf("%s: link entity to topic: %w", mem.Source, err)
+				}
+			}
+		}
+	}
+	return a.counts, nil
+}
+
+// userEntity is the person entity of a user ID of another memory system.
+func userEntity(userID, system string) tools.StoreEntityRequest {
+	return tools.StoreEntityRequest{
+		Name:        userID,
+		Kind:        "person",
+		Description: fmt.Sprintf("%s user", system),
+		SourceAgent: SourceAgent,
+	}
+}
+
+// topicRequests returns a topic per distinct non-empty name, ignoring case.
+func topicRequests(names []string) []tools.StoreTopicRequest {
+	var topics []tools.StoreTopicRequest
+	seen := map[string]bool{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[strings.ToLower(name)] {
+			continue
+		}
+		seen[strings.ToLower(name)] = true
+		topics = append(topics, tools.StoreTopicRequest{Name: name})
+	}
+	return topics
+}
@@ -0,0 +1,179 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package ingest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseMem0(t *testing.T) {
+	data := `{
+  "results": [
+    {"id": "m1", "memory": "Prefers dark roast coffee", "categories": ["food", "user_preferences"], "user_id": "alice"},
+    {"id": "m2", "memory": "  ", "user_id": "alice"},
+    {"id": "m3", "memory": "Works on the billing service", "categories": ["work"]}
+  ],
+  "relations": [
+    {"source": "alice", "relationship": "WORKS_AT", "target": "acme"},
+    {"source": "alice", "relationship": "", "target": "bob"}
+  ]
+}`
+	memories, err := ParseMem0([]byte(data))
+	if err != nil {
+		t.Fatalf("ParseMem0() error = %v", err)
+	}
+	if len(memories) != 3 {
+		t.Fatalf("got %d memories, want 3", len(memories))
+	}
+
+	m := memories[0]
+	if m.Fact.Content != "Prefers dark roast coffee" || m.Fact.Category != "preference" || m.Fact.SourceConversation != "mem0:m1" {
+		t.Errorf("fact = %+v", m.Fact)
+	}
+	if len(m.Entities) != 1 || m.Entities[0].Name != "alice" || m.Entities[0].Kind != "person" {
+		t.Errorf("entities = %+v, want user alice", m.Entities)
+	}
+	if len(m.Topics) != 2 || m.Topics[0].Name != "food" {
+		t.Errorf("topics = %+v", m.Topics)
+	}
+	if memories[1].Fact.Category != "general" || len(memories[1].Entities) != 0 {
+		t.Errorf("uncategorized memory = %+v", memories[1])
+	}
+
+	rel := memories[2]
+	if rel.Fact.Content != "alice works at acme" || rel.Fact.Category != "relationship" {
+		t.Errorf("relation fact = %+v", rel.Fact)
+	}
+	if len(rel.Entities) != 2 || rel.Entities[1].Name != "acme" {
+		t.Errorf("relation entities = %+v", rel.Entities)
+	}
+
+	list, err := ParseMem0([]byte(`[{"id": "m1", "text": "Lives in Lisbon"}]`))
+	if err != nil || len(list) != 1 || list[0].Fact.Content != "Lives in Lisbon" {
+		t.Errorf("ParseMem0(list) = %v, %v", list, err)
+	}
+	if _, err := ParseMem0([]byte(`{"results": []}`)); !errors.Is(err, ErrNoMemories) {
+		t.Errorf("ParseMem0(empty) error = %v, want ErrNoMemories", err)
+	}
+}
+
+func TestParseZep(t *testing.T) {
+	data := `[
+  {
+    "session_id": "s1",
+    "user_id": "alice",
+    "created_at": "2026-03-01T09:00:00Z",
+    "summary": {"content": "Planned the Q2 roadmap.", "created_at": "2026-03-02T10:00:00.123456Z"},
+    "facts": ["Alice leads the platform team"],
+    "relevant_facts": [
+      {"fact": "Alice uses Vim", "created_at": "2026-03-01T09:00:00Z"},
+      {"fact": "Alice uses Emacs", "invalid_at": "2026-02-01T00:00:00Z"}
+    ],
+    "messages": [{"role": "user", "content": "hi"}]
+  },
+  {"session_id": "s2", "summary": "Undated summary"}
+]`
+	memories, err := ParseZep([]byte(data))
+	if err != nil {
+		t.Fatalf("ParseZep() error = %v", err)
+	}
+	if len(memories) != 4 {
+		t.Fatalf("got %d memories, want 4", len(memories))
+	}
+
+	ev := memories[0].Event
+	if ev == nil || ev.Title != "Zep session s1" || ev.EventDate != "2026-03-02" || ev.Description != "Planned the Q2 roadmap." {
+		t.Errorf("summary event = %+v", ev)
+	}
+	if memories[1].Fact.Content != "Alice leads the platform team" || memories[2].Fact.Content != "Alice uses Vim" {
+		t.Errorf("facts = %q, %q", memories[1].Fact.Content, memories[2].Fact.Content)
+	}
+	if len(memories[1].Entities) != 1 || memories[1].Entities[0].Name != "alice" {
+		t.Errorf("fact entities = %+v, want user alice", memories[1].Entities)
+	}
+	if memories[3].Fact == nil || memories[3].Fact.Content != "Undated summary" || memories[3].Fact.SourceConversation != "zep:s2" {
+		t.Errorf("undated summary = %+v", memories[3])
+	}
+
+	one, err := ParseZep([]byte(`{"session_id": "s3", "facts": ["Likes tea"]}`))
+	if err != nil || len(one) != 1 || one[0].Fact.Content != "Likes tea" {
+		t.Errorf("ParseZep(session) = %v, %v", one, err)
+	}
+	wrapped, err := ParseZep([]byte(`{"sessions": [{"session_id": "s4", "facts": ["Likes tea"]}]}`))
+	if err != nil || len(wrapped) != 1 || wrapped[0].Source != "zep:s4" {
+		t.Errorf("ParseZep(sessions) = %v, %v", wrapped, err)
+	}
+}
+
+func TestParseLangChain(t *testing.T) {
+	data := `{
+  "moving_summary_buffer": "The human asked about deploying Billing to Kubernetes.",
+  "entity_store": {"store": {"Kubernetes": "Container orchestrator used in prod.", "Dana": "Billing team lead."}},
+  "chat_memory": {"messages": [{"type": "human", "content": "hi"}]}
+}`
+	memories, err := ParseLangChain([]byte(data))
+	if err != nil {
+		t.Fatalf("ParseLangChain() error = %v", err)
+	}
+	if len(memories) != 2 {
+		t.Fatalf("got %d memories, want 2", len(memories))
+	}
+	summary := memories[0]
+	if summary.Fact == nil || !strings.HasPrefix(summary.Fact.Content, "The human asked") {
+		t.Fatalf("summary = %+v", summary)
+	}
+	if len(summary.Entities) != 1 || summary.Entities[0].Name != "Kubernetes" || summary.Entities[0].Description != "Container orchestrator used in prod." {
+		t.Errorf("summary entities = %+v, want Kubernetes", summary.Entities)
+	}
+	if rest := memories[1]; rest.Fact != nil || len(rest.Entities) != 1 || rest.Entities[0].Name != "Dana" {
+		t.Errorf("unnamed entities = %+v", rest)
+	}
+
+	if _, err := ParseLangChain([]byte(`{"buffer": ""}`)); !errors.Is(err, ErrNoMemories) {
+		t.Errorf("ParseLangChain(empty) error = %v, want ErrNoMemories", err)
+	}
+}
+
+func TestApplyMemories(t *testing.T) {
+	fake := &fakeQuerier{statuses: map[string]string{}}
+	memories, err := ParseMem0([]byte(`[
+  {"id": "m1", "memory": "Prefers tea", "categories": ["food"], "user_id": "alice"},
+  {"id": "m2", "memory": "Drinks oat milk", "categories": ["food"], "user_id": "alice"}
+]`))
+	if err != nil {
+		t.Fatalf("ParseMem0() error = %v", err)
+	}
+	zep, err := ParseZep([]byte(`{"session_id": "s1", "user_id": "alice", "summary": {"content": "Chatted.", "created_at": "2026-03-02T10:00:00Z"}}`))
+	if err != nil {
+		t.Fatalf("ParseZep() error = %v", err)
+	}
+
+	counts, err := ApplyMemories(context.Background(), fake, append(memories, zep...))
+	if err != nil {
+		t.Fatalf("ApplyMemories() error = %v", err)
+	}
+
+	// The entity-topic edge is shared by both memories and added once.
+	want := map[string]int{"facts": 2, "events": 1, "entities": 1, "topics": 1, "relationships": 5}
+	for kind, n := range want {
+		if counts[kind] != n {
+			t.Errorf("counts[%s] = %d, want %d", kind, counts[kind], n)
+		}
+	}
+
+	edges := strings.Join(fake.edges, "\n")
+	for _, want := range []string{
+		"mie_fact_entity map[entity_id:ent:alice fact_id:fact:Prefers tea source_agent:mie-import]",
+		"mie_fact_topic map[fact_id:fact:Drinks oat milk source_agent:mie-import topic_id:top:food]",
+		"mie_entity_topic map[entity_id:ent:alice source_agent:mie-import topic_id:top:food]",
+	} {
+		if !strings.Contains(edges, want) {
+			t.Errorf("missing edge %q in\n%s", want, edges)
+		}
+	}
+}
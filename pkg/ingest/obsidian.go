@@ -0,0 +1,330 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// ErrEmptyNote is returned by ParseObsidianNote when a note has no text.
+var ErrEmptyNote = errors.New("note has no text")
+
+// Note is the knowledge extracted from one note of an Obsidian vault.
+// Exactly one of Fact and Event is set.
+type Note struct {
+	// Source is the note's path relative to the vault, with forward slashes.
+	Source string
+	Title  string
+	Fact   *tools.StoreFactRequest
+	// Event is set instead of Fact for dated notes.
+	Event *tools.StoreEventRequest
+	// Entities are the notes the note links to with [[wiki-links]].
+	Entities []tools.StoreEntityRequest
+	Topics   []tools.StoreTopicRequest
+}
+
+var (
+	// wikiLinkPattern matches "[[target]]", "[[target#heading|alias]]", and
+	// their "![[embed]]" forms.
+	wikiLinkPattern = regexp.MustCompile(`(!?)\[\[([^\[\]|#^]*)((?:#|\^)[^\[\]|]*)?(?:\|([^\[\]]*))?\]\]`)
+	inlineCode      = regexp.MustCompile("`[^`\n]*`")
+	datePattern     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}`)
+)
+
+// attachmentExtensions are the file types Obsidian links and embeds besides
+// notes. Other extensions are part of note names, as in [[Go 1.24]].
+var attachmentExtensions = map[string]bool{
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".svg": true, ".webp": true, ".bmp": true,
+	".pdf": true, ".mp3": true, ".wav": true, ".m4a": true, ".ogg": true, ".mp4": true, ".webm": true,
+	".mov": true, ".canvas": true,
+}
+
+// obsidianFrontMatter holds the YAML front matter fields read from notes.
+type obsidianFrontMatter struct {
+	Title string     `yaml:"title"`
+	Date  string     `yaml:"date"`
+	Tags  stringList `yaml:"tags"`
+	Tag   stringList `yaml:"tag"`
+}
+
+// ReadObsidianVault parses every markdown note under dir, in path order.
+// Hidden directories such as .obsidian and .trash are skipped, and so are
+// notes without text.
+func ReadObsidianVault(dir string) ([]*Note, error) {
+	var notes []*Note
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.EqualFold(filepath.Ext(p), ".md") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(p) //nolint:gosec // G304: Path comes from walking the user's vault
+		if err != nil {
+			return err
+		}
+		note, err := ParseObsidianNote(filepath.ToSlash(rel), data)
+		if errors.Is(err, ErrEmptyNote) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", rel, err)
+		}
+		notes = append(notes, note)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
+
+// ParseObsidianNote extracts a note from an Obsidian markdown file. source
+// is the path of the note within the vault; its base name is the note's
+// title unless the front matter sets one.
+//
+// Dated notes, whose file name starts with YYYY-MM-DD (as daily notes do)
+// or whose front matter has a date, become events; other notes become
+// facts. Wiki-link targets become entities and front matter tags become
+// topics. Notes with no text return ErrEmptyNote.
+func ParseObsidianNote(source string, data []byte) (*Note, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+
+	var meta obsidianFrontMatter
+	if fm, body, ok := splitFrontMatter(text); ok {
+		if err := yaml.Unmarshal([]byte(fm), &meta); err != nil {
+			return nil, fmt.Errorf("invalid front matter: %w", err)
+		}
+		text = body
+	}
+
+	name := strings.TrimSuffix(path.Base(source), path.Ext(source))
+	title := strings.TrimSpace(meta.Title)
+	if title == "" {
+		title = name
+	}
+
+	body, links := resolveWikiLinks(text)
+	if body == "" {
+		return nil, ErrEmptyNote
+	}
+
+	note := &Note{Source: source, Title: title}
+	conversation := "obsidian:" + source
+	if date := noteDate(meta.Date, name); date != "" {
+		note.Event = &tools.StoreEventRequest{
+			Title:              title,
+			Description:        body,
+			EventDate:          date,
+			SourceAgent:        SourceAgent,
+			SourceConversation: conversation,
+		}
+	} else {
+		note.Fact = &tools.StoreFactRequest{
+			Content:            title + "\n\n" + body,
+			Category:           "general",
+			SourceAgent:        SourceAgent,
+			SourceConversation: conversation,
+		}
+	}
+
+	seen := map[string]bool{strings.ToLower(title): true}
+	for _, target := range links {
+		if seen[strings.ToLower(target)] {
+			continue
+		}
+		seen[strings.ToLower(target)] = true
+		note.Entities = append(note.Entities, tools.StoreEntityRequest{
+			Name:        target,
+			Kind:        "other",
+			Description: fmt.Sprintf("Linked from %q", title),
+			SourceAgent: SourceAgent,
+		})
+	}
+
+	seen = map[string]bool{}
+	for _, tag := range append(meta.Tags, meta.Tag...) {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "#")
+		if tag == "" || seen[strings.ToLower(tag)] {
+			continue
+		}
+		seen[strings.ToLower(tag)] = true
+		note.Topics = append(note.Topics, tools.StoreTopicRequest{Name: tag})
+	}
+
+	return note, nil
+}
+
+// resolveWikiLinks replaces the wiki-links in text with their display text
+// and returns the trimmed text and the link targets, in order. Links in
+// code and embedded attachments ("![[diagram.png]]") are not targets.
+func resolveWikiLinks(text string) (string, []string) {
+	var links []string
+	var out strings.Builder
+	inFence := false
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		}
+		if !inFence {
+			// Mask inline code so links inside it stay as written.
+			masked := inlineCode.ReplaceAllStringFunc(line, func(s string) string { return strings.Repeat(" ", len(s)) })
+			var b strings.Builder
+			last := 0
+			for _, m := range wikiLinkPattern.FindAllStringSubmatchIndex(masked, -1) {
+				b.WriteString(line[last:m[0]])
+				last = m[1]
+				embed := m[3] > m[2]
+				target := strings.TrimSpace(line[m[4]:m[5]])
+				if target == "" {
+					// A link to a heading or block in the same note.
+					if m[6] >= 0 && !embed {
+						b.WriteString(strings.TrimLeft(line[m[6]:m[7]], "#^"))
+					}
+					continue
+				}
+				if attachmentExtensions[strings.ToLower(path.Ext(target))] {
+					// Embedded attachments vanish; links keep the file name.
+					if !embed {
+						b.WriteString(path.Base(target))
+					}
+					continue
+				}
+				target = path.Base(strings.TrimSuffix(target, ".md"))
+				display := target
+				if m[8] >= 0 && strings.TrimSpace(line[m[8]:m[9]]) != "" {
+					display = strings.TrimSpace(line[m[8]:m[9]])
+				}
+				links = append(links, target)
+				b.WriteString(display)
+			}
+			b.WriteString(line[last:])
+			line = b.String()
+		}
+		out.WriteString(line + "\n")
+	}
+	return strings.TrimSpace(out.String()), links
+}
+
+// noteDate returns the date of a dated note as YYYY-MM-DD: from the front
+// matter date, else from the start of its file name. It returns "" for
+// undated notes.
+func noteDate(frontMatterDate, name string) string {
+	for _, s := range []string{strings.TrimSpace(frontMatterDate), name} {
+		if d := datePattern.FindString(s); d != "" {
+			if _, err := time.Parse("2006-01-02", d); err == nil {
+				return d
+			}
+		}
+	}
+	return ""
+}
+
+// ApplyNotes stores notes through client. Each fact is linked to the
+// entities of its wiki-links (fact_entity) and to its topics (fact_topic),
+// and every linked entity to the note's topics (entity_topic). A note that
+// other notes link to is also linked to the entity of its own name. Events
+// have no entity or topic edges, so a dated note's topics are only stored
+// when it has links to attach them to. Counts are keyed like those of Apply.
+func ApplyNotes(ctx context.Context, client tools.Querier, notes []*Note) (map[string]int, error) {
+	a := newApplier(client)
+
+	targets := map[string]bool{}
+	for _, note := range notes {
+		for _, e := range note.Entities {
+			targets[strings.ToLower(e.Name)] = true
+		}
+	}
+
+	for _, note := range notes {
+		entities := note.Entities
+		if note.Fact != nil && targets[strings.ToLower(note.Title)] {
+			entities = append([]tools.StoreEntityRequest{{
+				Name:        note.Title,
+				Kind:        "other",
+				Description: fmt.Sprintf("Obsidian note %s", note.Source),
+				SourceAgent: SourceAgent,
+			}}, entities...)
+		}
+
+		entityIDs := make([]string, len(entities))
+		for i, req := range entities {
+			id, err := a.entity(ctx, req)
+			if err != nil {
+				return a.counts, fmt.Errorf("%s: store entity %q: %w", note.Source, req.Name, err)
+			}
+			entityIDs[i] = id
+		}
+
+		var topicIDs []string
+		if note.Fact != nil || len(entityIDs) > 0 {
+			for _, req := range note.Topics {
+				id, err := a.topic(ctx, req)
+				if err != nil {
+					return a.counts, fmt.Errorf("%s: store topic %q: %w", note.Source, req.Name, err)
+				}
+				topicIDs = append(topicIDs, id)
+			}
+		}
+
+		if note.Event != nil {
+			if _, err := client.StoreEvent(ctx, *note.Event); err != nil {
+				return a.counts, fmt.Errorf("%s: store event: %w", note.Source, err)
+			}
+			a.counts["events"]++
+		}
+		if note.Fact != nil {
+			fact, err := client.StoreFact(ctx, *note.Fact)
+			if err != nil {
+				return a.counts, fmt.Errorf("%s: store fact: %w", note.Source, err)
+			}
+			a.counts["facts"]++
+			for _, id := range entityIDs {
+				if err := a.linkOnce(ctx, "mie_fact_entity", map[string]string{"fact_id": fact.ID, "entity_id": id}); err != nil {
+					return a.counts, fmt.Errorf("%s: link entity: %w", note.Source, err)
+				}
+			}
+			for _, id := range topicIDs {
+				if err := a.linkOnce(ctx, "mie_fact_topic", map[string]string{"fact_id": fact.ID, "topic_id": id}); err != nil {
+					return a.counts, fmt.Errorf("%s: link topic: %w", note.Source, err)
+				}
+			}
+		}
+
+		for _, entityID := range entityIDs {
+			for _, topicID := range topicIDs {
+				if err := a.linkOnce(ctx, "mie_entity_topic", map[string]string{"entity_id": entityID, "topic_id": topicID}); err != nil {
+					return a.counts, fmt.Errorf("%s: link entity to topic: %w", note.Source, err)
+				}
+			}
+		}
+	}
+
+	return a.counts, nil
+}
@@ -0,0 +1,173 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package ingest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseObsidianNote(t *testing.T) {
+	data := []byte("---\ntags: [infra, \"#k8s\", infra]\n---\n" +
+		"We run [[Kubernetes]] on [[Cloud/AWS|Amazon]], see [[Runbook#Deploys]].\n\n" +
+		"![[diagram.png]] and [[spec.pdf]]. Back to [[#Setup]].\n" +
+		"Inline `[[NotALink]]` and [[kubernetes]] again.\n\n" +
+		"```\n[[AlsoNotALink]]\n```\n")
+
+	note, err := ParseObsidianNote("Infra/Cluster.md", data)
+	if err != nil {
+		t.Fatalf("ParseObsidianNote() error = %v", err)
+	}
+	if note.Title != "Cluster" || note.Event != nil || note.Fact == nil {
+		t.Fatalf("note = %+v, want a fact titled Cluster", note)
+	}
+	wantContent := "Cluster\n\nWe run Kubernetes on Amazon, see Runbook.\n\n" +
+		" and spec.pdf. Back to Setup.\nInline `[[NotALink]]` and kubernetes again.\n\n```\n[[AlsoNotALink]]\n```"
+	if note.Fact.Content != wantContent {
+		t.Errorf("Content = %q\nwant %q", note.Fact.Content, wantContent)
+	}
+	if note.Fact.SourceConversation != "obsidian:Infra/Cluster.md" {
+		t.Errorf("SourceConversation = %q", note.Fact.SourceConversation)
+	}
+
+	var entities []string
+	for _, e := range note.Entities {
+		entities = append(entities, e.Name)
+	}
+	if got := strings.Join(entities, ","); got != "Kubernetes,AWS,Runbook" {
+		t.Errorf("Entities = %s, want Kubernetes,AWS,Runbook", got)
+	}
+	var topics []string
+	for _, topic := range note.Topics {
+		topics = append(topics, topic.Name)
+	}
+	if got := strings.Join(topics, ","); got != "infra,k8s" {
+		t.Errorf("Topics = %s, want infra,k8s", got)
+	}
+}
+
+func TestParseObsidianNote_Dated(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		data     string
+		wantDate string
+	}{
+		{"daily note", "Daily/2026-03-14.md", "Met with [[Alice]].", "2026-03-14"},
+		{"front matter date", "Launch.md", "---\ndate: 2026-04-01\n---\nWe launched.", "2026-04-01"},
+		{"invalid date", "2026-13-40 ideas.md", "Some ideas.", ""},
+		{"undated", "Ideas.md", "Some ideas.", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			note, err := ParseObsidianNote(tt.source, []byte(tt.data))
+			if err != nil {
+				t.Fatalf("ParseObsidianNote() error = %v", err)
+			}
+			if tt.wantDate == "" {
+				if note.Event != nil || note.Fact == nil {
+					t.Errorf("note = %+v, want a fact", note)
+				}
+				return
+			}
+			if note.Event == nil || note.Fact != nil {
+				t.Fatalf("note = %+v, want an event", note)
+			}
+			if note.Event.EventDate != tt.wantDate {
+				t.Errorf("EventDate = %q, want %q", note.Event.EventDate, tt.wantDate)
+			}
+		})
+	}
+}
+
+func TestParseObsidianNote_Empty(t *testing.T) {
+	_, err := ParseObsidianNote("Empty.md", []byte("---\ntags: [x]\n---\n\n"))
+	if !errors.Is(err, ErrEmptyNote) {
+		t.Errorf("error = %v, want ErrEmptyNote", err)
+	}
+}
+
+func TestReadObsidianVault(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"Kubernetes.md":          "Container orchestrator.",
+		"Projects/Cluster.md":    "Runs on [[Kubernetes]].",
+		"Empty.md":               "",
+		"image.png":              "PNG",
+		".obsidian/workspace.md": "ignored",
+		".trash/Deleted note.md": "ignored",
+		"Daily/2026-03-14.md":    "Upgraded [[Kubernetes]].",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	notes, err := ReadObsidianVault(dir)
+	if err != nil {
+		t.Fatalf("ReadObsidianVault() error = %v", err)
+	}
+	var sources []string
+	for _, n := range notes {
+		sources = append(sources, n.Source)
+	}
+	if got := strings.Join(sources, ","); got != "Daily/2026-03-14.md,Kubernetes.md,Projects/Cluster.md" {
+		t.Errorf("notes = %s", got)
+	}
+}
+
+func TestApplyNotes(t *testing.T) {
+	fake := &fakeQuerier{statuses: map[string]string{}}
+	notes := []*Note{}
+	for source, text := range map[string]string{
+		"Kubernetes.md":       "Container orchestrator.",
+		"Cluster.md":          "---\ntags: infra\n---\nRuns on [[Kubernetes]] in [[AWS]].",
+		"Daily/2026-03-14.md": "---\ntags: [journal]\n---\nUpgraded [[Kubernetes]].",
+		"Daily/2026-03-15.md": "---\ntags: [journal]\n---\nQuiet day.",
+	} {
+		note, err := ParseObsidianNote(source, []byte(text))
+		if err != nil {
+			t.Fatalf("ParseObsidianNote(%s) error = %v", source, err)
+		}
+		notes = append(notes, note)
+	}
+
+	counts, err := ApplyNotes(context.Background(), fake, notes)
+	if err != nil {
+		t.Fatalf("ApplyNotes() error = %v", err)
+	}
+
+	// Topic "journal" is stored once: the quiet day has no links to carry it.
+	want := map[string]int{"facts": 2, "events": 2, "entities": 2, "topics": 2, "relationships": 7}
+	for kind, n := range want {
+		if counts[kind] != n {
+			t.Errorf("counts[%s] = %d, want %d", kind, counts[kind], n)
+		}
+	}
+
+	edges := strings.Join(fake.edges, "\n")
+	for _, want := range []string{
+		"mie_fact_entity map[entity_id:ent:Kubernetes fact_id:fact:Kubernetes",
+		"mie_fact_entity map[entity_id:ent:Kubernetes fact_id:fact:Cluster",
+		"mie_fact_entity map[entity_id:ent:AWS fact_id:fact:Cluster",
+		"mie_fact_topic map[fact_id:fact:Cluster source_agent:mie-import topic_id:top:infra]",
+		"mie_entity_topic map[entity_id:ent:Kubernetes source_agent:mie-import topic_id:top:infra]",
+		"mie_entity_topic map[entity_id:ent:AWS source_agent:mie-import topic_id:top:infra]",
+		"mie_entity_topic map[entity_id:ent:Kubernetes source_agent:mie-import topic_id:top:journal]",
+	} {
+		if !strings.Contains(edges, want) {
+			t.Errorf("missing edge %q in\n%s", want, edges)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// attachmentDir is the directory of the data dir that holds attachment
+// content, one file per hash.
+const attachmentDir = "attachments"
+
+// Attach stores data under its content hash and links it to the node
+// nodeID of the namespace. Attaching the same content to the node again
+// replaces the link's name and MIME type. An empty mimeType is guessed from
+// the extension of name, or else from the content.
+func (c *Client) Attach(ctx context.Context, nodeID, name, mimeType string, data []byte) (*tools.Attachment, error) {
+	if len(data) == 0 {
+		return nil, errors.New("attachment is empty")
+	}
+	if len(data) > MaxAttachmentSize {
+		return nil, fmt.Errorf("attachment is %d bytes, more than the limit of %d", len(data), MaxAttachmentSize)
+	}
+	if _, err := c.reader.GetNodeByID(ctx, nodeID); err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	if name == "" {
+		name = hash[:12]
+	}
+	if mimeType == "" {
+		mimeType = mime.TypeByExtension(filepath.Ext(name))
+	}
+	if mimeType == "" {
+		mimeType = http.DetectContentType(data)
+	}
+	att := &tools.Attachment{
+		Hash:      hash,
+		NodeID:    nodeID,
+		Name:      name,
+		MimeType:  mimeType,
+		Size:      int64(len(data)),
+		CreatedAt: time.Now().Unix(),
+	}
+
+	c.attachMu.Lock()
+	defer c.attachMu.Unlock()
+	if err := c.writeAttachmentFile(hash, data); err != nil {
+		return nil, err
+	}
+	err := c.backend.Execute(ctx, `?[node_id, hash, name, mime_type, size, namespace, created_at] <- [[$node_id, $hash, $name, $mime_type, $size, $ns, $created_at]]
+:put mie_attachment { node_id, hash => name, mime_type, size, namespace, created_at }`, map[string]any{
+		"node_id": nodeID, "hash": hash, "name": name, "mime_type": mimeType, "size": att.Size,
+		"ns": resolveNamespace(ctx, c.config.Namespace), "created_at": att.CreatedAt,
+	})
+	if err != nil {
+		err = fmt.Errorf("link attachment: %w", err)
+	}
+	c.publish(ctx, ChangeUpdated, nodeID, err)
+	c.audit(ctx, ChangeUpdated, "", err, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	return att, nil
+}
+
+// ListAttachments returns the attachments of the node nodeID of the
+// namespace, oldest first.
+func (c *Client) ListAttachments(ctx context.Context, nodeID string) ([]tools.Attachment, error) {
+	return c.attachments(ctx, "node_id = $node_id", map[string]any{"node_id": nodeID}, 0)
+}
+
+// ReadAttachment returns the content with the given hash and one of its
+// links to a node of the namespace. Content attached only in other
+// namespaces is not found.
+func (c *Client) ReadAttachment(ctx context.Context, hash string) (*tools.Attachment, []byte, error) {
+	if !ValidAttachmentHash(hash) {
+		return nil, nil, fmt.Errorf("invalid attachment hash %q", hash)
+	}
+	links, err := c.attachments(ctx, "hash = $hash", map[string]any{"hash": hash}, 1)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(links) == 0 {
+		return nil, nil, fmt.Errorf("attachment %s not found", hash)
+	}
+	path, err := c.attachmentPath(hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is built from a validated hash
+	if err != nil {
+		return nil, nil, fmt.Errorf("read attachment %s: %w", hash, err)
+	}
+	return &links[0], data, nil
+}
+
+// Detach removes the link between the node nodeID and the content with the
+// given hash. The content is deleted once no node of any namespace links to
+// it.
+func (c *Client) Detach(ctx context.Context, nodeID, hash string) error {
+	if !ValidAttachmentHash(hash) {
+		return fmt.Errorf("invalid attachment hash %q", hash)
+	}
+	c.attachMu.Lock()
+	defer c.attachMu.Unlock()
+
+	links, err := c.attachments(ctx, "node_id = $node_id, hash = $hash", map[string]any{"node_id": nodeID, "hash": hash}, 1)
+	if err != nil {
+		return err
+	}
+	if len(links) == 0 {
+		return fmt.Errorf("attachment %s is not attached to %s", hash, nodeID)
+	}
+	err = c.backend.Execute(ctx, `?[node_id, hash] <- [[$node_id, $hash]] :rm mie_attachment { node_id, hash }`,
+		map[string]any{"node_id": nodeID, "hash": hash})
+	if err != nil {
+		err = fmt.Errorf("unlink attachment: %w", err)
+	}
+	c.publish(ctx, ChangeUpdated, nodeID, err)
+	c.audit(ctx, ChangeUpdated, "", err, nodeID)
+	if err != nil {
+		return err
+	}
+
+	qr, err := c.backend.Query(ctx, `?[node_id] := *mie_attachment { node_id, hash }, hash = $hash
+:limit 1`, map[string]any{"hash": hash})
+	if err != nil || len(qr.Rows) > 0 {
+		// Still linked, or unknown: keep the content.
+		return nil
+	}
+	if path, err := c.attachmentPath(hash); err == nil {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			c.logger.Warn("failed to remove attachment content", "hash", hash, "error", err)
+		}
+	}
+	return nil
+}
+
+// attachments returns the attachment links of the namespace that satisfy
+// cond, oldest first, at most limit of them unless limit is 0.
+func (c *Client) attachments(ctx context.Context, cond string, params map[string]any, limit int) ([]tools.Attachment, error) {
+	params["ns"] = resolveNamespace(ctx, c.config.Namespace)
+	script := fmt.Sprintf(`?[node_id, hash, name, mime_type, size, created_at] := *mie_attachment { node_id, hash, name, mime_type, size, namespace, created_at }, namespace = $ns, %s
+:order created_at, name`, cond)
+	if limit > 0 {
+		script += fmt.Sprintf("\n:limit %d", limit)
+	}
+	qr, err := c.backend.Query(ctx, script, params)
+	if err != nil {
+		return nil, fmt.Errorf("read attachments: %w", err)
+	}
+	links := make([]tools.Attachment, 0, len(qr.Rows))
+	for _, row := range qr.Rows {
+		links = append(links, tools.Attachment{
+			NodeID:    toString(row[0]),
+			Hash:      toString(row[1]),
+			Name:      toString(row[2]),
+			MimeType:  toString(row[3]),
+			Size:      toInt64(row[4]),
+			CreatedAt: toInt64(row[5]),
+		})
+	}
+	return links, nil
+}
+
+// attachmentPath returns the file that holds the content with the given
+// hash, sharded by the first two hex digits.
+func (c *Client) attachmentPath(hash string) (string, error) {
+	if c.config.DataDir == "" {
+		return "", errors.New("attachments need a data directory")
+	}
+	return filepath.Join(c.config.DataDir, attachmentDir, hash[:2], hash), nil
+}
+
+// writeAttachmentFile stores data as the content with the given hash unless
+// it is stored already. The file is written under a temporary name and
+// renamed, so a crash never leaves partial content under the hash.
+func (c *Client) writeAttachmentFile(hash string, data []byte) error {
+	path, err := c.attachmentPath(hash)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("create attachment directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), hash+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write attachment: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write attachment: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write attachment: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("write attachment: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestClientAttachments(t *testing.T) {
+	dataDir := t.TempDir()
+	client, err := NewClient(ClientConfig{DataDir: dataDir, StorageEngine: "mem", EmbeddingDimensions: 384})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	dec, _ := client.StoreDecision(ctx, tools.StoreDecisionRequest{Title: "Use NATS", Rationale: "Simple ops"})
+	ent, _ := client.StoreEntity(ctx, tools.StoreEntityRequest{Name: "NATS", Kind: "technology"})
+	config := []byte("max_payload: 8MB\n")
+
+	att, err := client.Attach(ctx, dec.ID, "nats.yaml", "", config)
+	if err != nil {
+		t.Fatalf("Attach: %v", err)
+	}
+	if len(att.Hash) != 64 || att.Size != int64(len(config)) || att.MimeType == "" {
+		t.Errorf("unexpected attachment: %+v", att)
+	}
+	if _, err := client.Attach(ctx, ent.ID, "", "text/yaml", config); err != nil {
+		t.Fatalf("Attach to entity: %v", err)
+	}
+	if _, err := client.Attach(ctx, "dec:missing", "x.txt", "", config); err == nil {
+		t.Error("expected an error attaching to a missing node")
+	}
+	if _, err := client.Attach(ctx, dec.ID, "big.bin", "", make([]byte, MaxAttachmentSize+1)); err == nil {
+		t.Error("expected an error for an oversized attachment")
+	}
+
+	list, err := client.ListAttachments(ctx, dec.ID)
+	if err != nil || len(list) != 1 || list[0].Name != "nats.yaml" || list[0].Hash != att.Hash {
+		t.Fatalf("ListAttachments = %+v, %v", list, err)
+	}
+	got, data, err := client.ReadAttachment(ctx, att.Hash)
+	if err != nil || string(data) != string(config) || got.Hash != att.Hash {
+		t.Fatalf("ReadAttachment = %+v, %q, %v", got, data, err)
+	}
+	if _, _, err := client.ReadAttachment(tools.WithNamespace(ctx, "other"), att.Hash); err == nil {
+		t.Error("attachment readable from another namespace")
+	}
+	if _, _, err := client.ReadAttachment(ctx, "../../etc/passwd"); err == nil {
+		t.Error("expected an error for an invalid hash")
+	}
+
+	path, _ := client.attachmentPath(att.Hash)
+	if !strings.HasPrefix(path, dataDir) {
+		t.Errorf("attachment stored outside the data dir: %s", path)
+	}
+
+	// The content stays while another node links to it.
+	if err := client.Detach(ctx, dec.ID, att.Hash); err != nil {
+		t.Fatalf("Detach: %v", err)
+	}
+	if err := client.Detach(ctx, dec.ID, att.Hash); err == nil {
+		t.Error("expected an error detaching twice")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("content removed while still attached: %v", err)
+	}
+	if err := client.Detach(ctx, ent.ID, att.Hash); err != nil {
+		t.Fatalf("Detach: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("content kept after the last detach: %v", err)
+	}
+}
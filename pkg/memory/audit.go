@@ -0,0 +1,90 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// auditSeq disambiguates audit entries recorded in the same nanosecond.
+var auditSeq atomic.Uint64
+
+// auditID returns an ID for an audit entry recorded at now. IDs sort in
+// recording order.
+func auditID(now time.Time) string {
+	return fmt.Sprintf("aud:%019d:%06d", now.UnixNano(), auditSeq.Add(1)%1000000)
+}
+
+// recordAudit appends an entry to mie_audit. Inside Atomic, the entry is
+// committed together with the write it records.
+func (w *Writer) recordAudit(ctx context.Context, op, tool, agent string, nodeIDs []string) error {
+	now := time.Now()
+	if nodeIDs == nil {
+		nodeIDs = []string{}
+	}
+	return w.execute(ctx,
+		`?[id, at, op, tool, source_agent, node_ids, namespace] <- [[$id, $at, $op, $tool, $agent, $node_ids, $ns]] :put mie_audit { id => at, op, tool, source_agent, node_ids, namespace }`,
+		map[string]any{
+			"id": auditID(now), "at": now.Unix(), "op": op, "tool": tool, "agent": agent,
+			"node_ids": nodeIDs, "ns": resolveNamespace(ctx, w.namespace),
+		})
+}
+
+// GetAuditLog returns the latest audit entries of the namespace that match
+// opts, newest first. A zero limit returns 50 entries.
+func (r *Reader) GetAuditLog(ctx context.Context, opts tools.AuditOptions) ([]tools.AuditEntry, error) {
+	if opts.Limit <= 0 {
+		opts.Limit = 50
+	}
+	conditions := `namespace = $ns`
+	params := map[string]any{"ns": resolveNamespace(ctx, r.namespace)}
+	if opts.NodeID != "" {
+		conditions += `, is_in($node_id, node_ids)`
+		params["node_id"] = opts.NodeID
+	}
+	if opts.SourceAgent != "" {
+		conditions += `, source_agent = $agent`
+		params["agent"] = opts.SourceAgent
+	}
+	if opts.Tool != "" {
+		conditions += `, tool = $tool`
+		params["tool"] = opts.Tool
+	}
+
+	result, err := r.backend.Query(ctx, fmt.Sprintf(
+		`?[id, at, op, tool, source_agent, node_ids] := *mie_audit { id, at, op, tool, source_agent, node_ids, namespace }, %s
+:order -id
+:limit %d`,
+		conditions, opts.Limit), params)
+	if err != nil {
+		return nil, fmt.Errorf("read audit log: %w", err)
+	}
+
+	entries := make([]tools.AuditEntry, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		entry := tools.AuditEntry{
+			ID:          toString(row[0]),
+			At:          toInt64(row[1]),
+			Op:          toString(row[2]),
+			Tool:        toString(row[3]),
+			SourceAgent: toString(row[4]),
+			NodeIDs:     []string{},
+		}
+		if ids, ok := row[5].([]any); ok {
+			for _, id := range ids {
+				entry.NodeIDs = append(entry.NodeIDs, toString(id))
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
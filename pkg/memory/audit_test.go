@@ -0,0 +1,105 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestClientAuditLog(t *testing.T) {
+	client, err := NewClient(ClientConfig{DataDir: t.TempDir(), StorageEngine: "mem", EmbeddingDimensions: 384})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+
+	ctx := tools.WithAuditSource(context.Background(), "mie_store", "fallback-agent")
+	fact, err := client.StoreFact(ctx, tools.StoreFactRequest{Content: "Go is fast", Category: "technical", SourceAgent: "claude"})
+	if err != nil {
+		t.Fatalf("StoreFact: %v", err)
+	}
+	ent, err := client.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Go", Kind: "technology"})
+	if err != nil {
+		t.Fatalf("StoreEntity: %v", err)
+	}
+	if err := client.AddRelationship(ctx, "mie_fact_entity", map[string]string{"fact_id": fact.ID, "entity_id": ent.ID}); err != nil {
+		t.Fatalf("AddRelationship: %v", err)
+	}
+
+	// Writes of a discarded batch are not audited.
+	errDiscard := errors.New("discard")
+	err = client.Atomic(ctx, func(ctx context.Context) error {
+		if _, err := client.StoreTopic(ctx, tools.StoreTopicRequest{Name: "languages"}); err != nil {
+			return err
+		}
+		return errDiscard
+	})
+	if !errors.Is(err, errDiscard) {
+		t.Fatalf("Atomic error = %v, want errDiscard", err)
+	}
+
+	updateCtx := tools.WithAuditSource(context.Background(), "mie_update", "")
+	if err := client.SetArchived(updateCtx, fact.ID, true); err != nil {
+		t.Fatalf("SetArchived: %v", err)
+	}
+
+	entries, err := client.GetAuditLog(context.Background(), tools.AuditOptions{})
+	if err != nil {
+		t.Fatalf("GetAuditLog: %v", err)
+	}
+	type row struct {
+		op, tool, agent string
+		ids             []string
+	}
+	want := []row{
+		{ChangeArchived, "mie_update", "", []string{fact.ID}},
+		{ChangeRelated, "mie_store", "fallback-agent", []string{fact.ID, ent.ID}},
+		{ChangeCreated, "mie_store", "fallback-agent", []string{ent.ID}},
+		{ChangeCreated, "mie_store", "claude", []string{fact.ID}},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(entries), len(want), entries)
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.Op != w.op || e.Tool != w.tool || e.SourceAgent != w.agent || !reflect.DeepEqual(e.NodeIDs, w.ids) {
+			t.Errorf("entry %d = %+v, want %+v", i, e, w)
+		}
+		if e.At == 0 || e.ID == "" {
+			t.Errorf("entry %d has no time or ID: %+v", i, e)
+		}
+	}
+
+	filtered, err := client.GetAuditLog(context.Background(), tools.AuditOptions{NodeID: ent.ID})
+	if err != nil {
+		t.Fatalf("GetAuditLog(node): %v", err)
+	}
+	if len(filtered) != 2 {
+		t.Errorf("entries for %s = %d, want 2", ent.ID, len(filtered))
+	}
+	filtered, err = client.GetAuditLog(context.Background(), tools.AuditOptions{SourceAgent: "claude", Limit: 5})
+	if err != nil {
+		t.Fatalf("GetAuditLog(agent): %v", err)
+	}
+	if len(filtered) != 1 || filtered[0].NodeIDs[0] != fact.ID {
+		t.Errorf("entries by claude = %+v, want the fact store", filtered)
+	}
+
+	// Other namespaces have their own log.
+	other, err := client.GetAuditLog(tools.WithNamespace(context.Background(), "other"), tools.AuditOptions{})
+	if err != nil {
+		t.Fatalf("GetAuditLog(other): %v", err)
+	}
+	if len(other) != 0 {
+		t.Errorf("other namespace entries = %+v, want none", other)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// autoLinkExactCaseLen is the length up to which entity names must match
+// with their exact case, so "Go" links but "go to lunch" does not.
+const autoLinkExactCaseLen = 3
+
+// autoLinkFact links a newly stored fact to the entities of ns whose names
+// or aliases its content mentions as whole words, ignoring case. Archived
+// entities are skipped. Auto-linking never fails a store: errors are
+// logged and the fact keeps the links made so far.
+func (w *Writer) autoLinkFact(ctx context.Context, fact *tools.Fact, ns string) {
+	content := strings.ToLower(fact.Content)
+	script := `?[id, name, alias] := *mie_entity { id, name, namespace },
+    namespace = $ns,
+    str_includes($content, lowercase(name)),
+    not *mie_archived { node_id: id },
+    alias = false
+?[id, name, alias] := *mie_entity_alias { alias: name, namespace, entity_id: id },
+    namespace = $ns,
+    str_includes($content, lowercase(name)),
+    not *mie_archived { node_id: id },
+    alias = true`
+	qr, err := w.backend.Query(ctx, script, map[string]any{"ns": ns, "content": content})
+	if err != nil {
+		w.logger.Warn("auto-link lookup failed", "fact_id", fact.ID, "error", err)
+		return
+	}
+
+	linked := map[string]bool{}
+	for _, row := range qr.Rows {
+		id, name, alias := toString(row[0]), toString(row[1]), toBool(row[2])
+		// Short names must match case exactly unless they are aliases,
+		// which are stored lowercased.
+		foldCase := alias || utf8.RuneCountInString(name) > autoLinkExactCaseLen
+		if linked[id] || !tools.MentionsWord(fact.Content, name, foldCase) {
+			continue
+		}
+		linked[id] = true
+		fields := map[string]string{"fact_id": fact.ID, "entity_id": id, "source_agent": fact.SourceAgent}
+		if err := w.AddRelationship(ctx, "mie_fact_entity", fields); err != nil {
+			w.logger.Warn("auto-link failed", "fact_id", fact.ID, "entity_id", id, "error", err)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestStoreFactAutoLink(t *testing.T) {
+	client, err := NewClient(ClientConfig{DataDir: t.TempDir(), StorageEngine: "mem", EmbeddingDimensions: 384, AutoLink: true})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	var ids []string
+	for _, name := range []string{"Kubernetes", "Go", "Legacy CI"} {
+		e, err := client.StoreEntity(ctx, tools.StoreEntityRequest{Name: name, Kind: "technology"})
+		if err != nil {
+			t.Fatalf("StoreEntity(%s): %v", name, err)
+		}
+		ids = append(ids, e.ID)
+	}
+	if err := client.AddAlias(ctx, ids[0], "k8s"); err != nil {
+		t.Fatalf("AddAlias: %v", err)
+	}
+	if err := client.SetArchived(ctx, ids[2], true); err != nil {
+		t.Fatalf("SetArchived: %v", err)
+	}
+
+	fact, err := client.StoreFact(ctx, tools.StoreFactRequest{
+		Content:     "The API is written in Go and runs on K8s, replacing legacy CI jobs; we go live Friday",
+		Category:    "technical",
+		SourceAgent: "claude",
+	})
+	if err != nil {
+		t.Fatalf("StoreFact: %v", err)
+	}
+
+	entities, err := client.GetRelatedEntities(ctx, fact.ID)
+	if err != nil {
+		t.Fatalf("GetRelatedEntities: %v", err)
+	}
+	var names []string
+	for _, e := range entities {
+		names = append(names, e.Name)
+	}
+	sort.Strings(names)
+	if got := strings.Join(names, ","); got != "Go,Kubernetes" {
+		t.Errorf("auto-linked entities = %s, want Go,Kubernetes", got)
+	}
+
+	// Without AutoLink, nothing is linked.
+	plain, err := NewClient(ClientConfig{DataDir: t.TempDir(), StorageEngine: "mem", EmbeddingDimensions: 384})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer plain.Close()
+	if _, err := plain.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Kubernetes", Kind: "technology"}); err != nil {
+		t.Fatalf("StoreEntity: %v", err)
+	}
+	fact, err = plain.StoreFact(ctx, tools.StoreFactRequest{Content: "Runs on Kubernetes", Category: "technical"})
+	if err != nil {
+		t.Fatalf("StoreFact: %v", err)
+	}
+	if entities, _ := plain.GetRelatedEntities(ctx, fact.ID); len(entities) != 0 {
+		t.Errorf("entities linked without auto_link: %+v", entities)
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// DefaultBackfillWorkers is the number of concurrent embedding workers used
+// when BackfillOptions.Workers is not set.
+const DefaultBackfillWorkers = 4
+
+// embeddingTextRules bind each node type's id and the text its embedding
+// is generated from. The text matches what the Store methods embed.
+var embeddingTextRules = map[string]string{
+	"fact":     `*mie_fact { id, content }, text = content`,
+	"decision": `*mie_decision { id, title, rationale }, text = concat(title, '. ', rationale)`,
+	"entity":   `*mie_entity { id, name, description }, text = concat(name, ': ', description)`,
+	"event":    `*mie_event { id, title, description }, text = concat(title, '. ', description)`,
+}
+
+// missingEmbeddingsScript selects the ID and embedding text of the nodes of
+// nodeType that have no row in the embedding table table. It covers every
+// namespace because embedding tables are shared.
+func missingEmbeddingsScript(nodeType, table string) (string, bool) {
+	rule, ok := embeddingTextRules[nodeType]
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf(`?[id, text] := %s, not *%s { %s_id: id }`, rule, table, nodeType), true
+}
+
+// backfillNodeTypes lists the node types that have embeddings, in scan order.
+var backfillNodeTypes = []string{"fact", "decision", "entity", "event"}
+
+// BackfillOptions configures an embedding backfill.
+type BackfillOptions struct {
+	NodeTypes []string // node types to scan (default: fact, decision, entity, event)
+	Workers   int      // concurrent embedding workers (default: DefaultBackfillWorkers)
+}
+
+// BackfillResult summarizes an embedding backfill.
+type BackfillResult struct {
+	Missing  map[string]int // nodes without embeddings per type when the scan ran
+	Embedded int            // embeddings generated and stored
+	Failed   int            // nodes whose embedding could not be generated or stored
+}
+
+// backfillJob is one node waiting for an embedding.
+type backfillJob struct {
+	nodeType string
+	id       string
+	text     string
+}
+
+// MissingEmbeddings counts nodes without embeddings per node type.
+func (w *Writer) MissingEmbeddings(ctx context.Context, nodeTypes []string) (map[string]int, error) {
+	jobs, err := w.scanMissingEmbeddings(ctx, nodeTypes)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int)
+	for _, j := range jobs {
+		counts[j.nodeType]++
+	}
+	return counts, nil
+}
+
+// BackfillEmbeddings generates embeddings for nodes that are missing them,
+// for example because embeddings were enabled after the data was stored or
+// an embedding call failed. Failures are logged and counted, not returned;
+// an error is returned only if the scan fails or ctx is cancelled.
+func (w *Writer) BackfillEmbeddings(ctx context.Context, opts BackfillOptions) (*BackfillResult, error) {
+	if w.embedder == nil {
+		return nil, fmt.Errorf("embedding backfill requires embeddings to be enabled")
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = DefaultBackfillWorkers
+	}
+
+	missing, err := w.scanMissingEmbeddings(ctx, opts.NodeTypes)
+	if err != nil {
+		return nil, err
+	}
+	// Queued nodes are embedded by the queue workers.
+	queued, err := w.queuedNodeIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BackfillResult{Missing: make(map[string]int)}
+	var pending []backfillJob
+	for _, j := range missing {
+		result.Missing[j.nodeType]++
+		if !queued[j.id] {
+			pending = append(pending, j)
+		}
+	}
+	if len(pending) == 0 {
+		return result, nil
+	}
+	w.logger.Info("backfilling embeddings", "nodes", len(pending), "workers", workers)
+
+	jobs := make(chan backfillJob)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				err := w.embedNode(ctx, j)
+				mu.Lock()
+				if err != nil {
+					result.Failed++
+				} else {
+					result.Embedded++
+				}
+				mu.Unlock()
+				if err != nil {
+					w.logger.Warn("failed to backfill embedding", "node_id", j.id, "error", err)
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, j := range pending {
+		select {
+		case jobs <- j:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return result, fmt.Errorf("embedding backfill interrupted: %w", err)
+	}
+	w.logger.Info("embedding backfill finished", "embedded", result.Embedded, "failed", result.Failed)
+	return result, nil
+}
+
+// scanMissingEmbeddings returns the nodes of the given types that have no
+// embedding.
+func (w *Writer) scanMissingEmbeddings(ctx context.Context, nodeTypes []string) ([]backfillJob, error) {
+	if len(nodeTypes) == 0 {
+		nodeTypes = backfillNodeTypes
+	}
+
+	var jobs []backfillJob
+	for _, nt := range nodeTypes {
+		script, ok := missingEmbeddingsScript(nt, nodeTypeToEmbeddingTable(nt))
+		if !ok {
+			return nil, fmt.Errorf("node type %q has no embeddings (valid: fact, decision, entity, event)", nt)
+		}
+		qr, err := w.backend.Query(ctx, script, nil)
+		if err != nil {
+			return nil, fmt.Errorf("scan %s nodes without embeddings: %w", nt, err)
+		}
+		for _, row := range qr.Rows {
+			jobs = append(jobs, backfillJob{nodeType: nt, id: toString(row[0]), text: toString(row[1])})
+		}
+	}
+	return jobs, nil
+}
+
+// embedNode generates and stores the embedding for one backfill job.
+func (w *Writer) embedNode(ctx context.Context, j backfillJob) error {
+	embedding, err := w.embedder.Generate(ctx, j.text)
+	if err != nil {
+		return fmt.Errorf("generate embedding: %w", err)
+	}
+	if err := w.putEmbedding(ctx, j.nodeType, j.id, embedding); err != nil {
+		return fmt.Errorf("store embedding: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestWriterBackfillEmbeddings(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	ctx := context.Background()
+
+	// Store nodes while embeddings are disabled.
+	plain := NewWriter(backend, nil, nil)
+	if _, err := plain.StoreFact(ctx, tools.StoreFactRequest{Content: "Uses Go", Category: "technical"}); err != nil {
+		t.Fatalf("StoreFact failed: %v", err)
+	}
+	if _, err := plain.StoreFact(tools.WithNamespace(ctx, "other"), tools.StoreFactRequest{Content: "Uses Rust", Category: "technical"}); err != nil {
+		t.Fatalf("StoreFact failed: %v", err)
+	}
+	if _, err := plain.StoreDecision(ctx, tools.StoreDecisionRequest{Title: "Use CozoDB", Rationale: "Embedded Datalog"}); err != nil {
+		t.Fatalf("StoreDecision failed: %v", err)
+	}
+	if _, err := plain.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Go", Kind: "technology"}); err != nil {
+		t.Fatalf("StoreEntity failed: %v", err)
+	}
+
+	if _, err := plain.BackfillEmbeddings(ctx, BackfillOptions{}); err == nil {
+		t.Error("expected error when embeddings are disabled")
+	}
+
+	w := NewWriter(backend, NewEmbeddingGenerator(NewMockEmbeddingProvider(384, nil), nil), nil)
+
+	missing, err := w.MissingEmbeddings(ctx, nil)
+	if err != nil {
+		t.Fatalf("MissingEmbeddings failed: %v", err)
+	}
+	if missing["fact"] != 2 || missing["decision"] != 1 || missing["entity"] != 1 || missing["event"] != 0 {
+		t.Errorf("unexpected missing counts: %v", missing)
+	}
+
+	// Restricting node types only scans those types.
+	result, err := w.BackfillEmbeddings(ctx, BackfillOptions{NodeTypes: []string{"fact"}, Workers: 2})
+	if err != nil {
+		t.Fatalf("BackfillEmbeddings failed: %v", err)
+	}
+	if result.Embedded != 2 || result.Failed != 0 || result.Missing["decision"] != 0 {
+		t.Errorf("unexpected fact backfill result: %+v", result)
+	}
+
+	result, err = w.BackfillEmbeddings(ctx, BackfillOptions{})
+	if err != nil {
+		t.Fatalf("BackfillEmbeddings failed: %v", err)
+	}
+	if result.Embedded != 2 {
+		t.Errorf("expected decision and entity to be backfilled, got %+v", result)
+	}
+
+	missing, err = w.MissingEmbeddings(ctx, nil)
+	if err != nil {
+		t.Fatalf("MissingEmbeddings failed: %v", err)
+	}
+	if len(missing) != 0 {
+		t.Errorf("expected no missing embeddings after backfill, got %v", missing)
+	}
+
+	if _, err := w.MissingEmbeddings(ctx, []string{"topic"}); err == nil {
+		t.Error("expected error for node type without embeddings")
+	}
+}
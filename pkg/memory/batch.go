@@ -0,0 +1,104 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// writeBatch collects the mutations made inside Writer.Atomic so they can
+// run as one transaction, and the work to do once they have committed.
+type writeBatch struct {
+	mu         sync.Mutex
+	statements []string
+	params     map[string]any
+	onCommit   []func()
+}
+
+type writeBatchKey struct{}
+
+// batchFromContext returns the write batch ctx carries, or nil.
+func batchFromContext(ctx context.Context) *writeBatch {
+	b, _ := ctx.Value(writeBatchKey{}).(*writeBatch)
+	return b
+}
+
+// afterCommit runs fn now, or once the write batch in ctx has committed. A
+// batch that is discarded never runs fn.
+func afterCommit(ctx context.Context, fn func()) {
+	b := batchFromContext(ctx)
+	if b == nil {
+		fn()
+		return
+	}
+	b.mu.Lock()
+	b.onCommit = append(b.onCommit, fn)
+	b.mu.Unlock()
+}
+
+// paramRef matches a $name parameter placeholder in a script.
+var paramRef = regexp.MustCompile(`\$([A-Za-z_][A-Za-z0-9_]*)`)
+
+// execute runs mutation with params, or stages it when ctx carries a write
+// batch. Staged mutations share one parameter map, so their placeholders
+// are renamed with a per-statement prefix.
+func (w *Writer) execute(ctx context.Context, mutation string, params map[string]any) error {
+	b := batchFromContext(ctx)
+	if b == nil {
+		return w.backend.Execute(ctx, mutation, params)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(params) > 0 {
+		prefix := fmt.Sprintf("s%d_", len(b.statements))
+		mutation = paramRef.ReplaceAllString(mutation, "$$"+prefix+"$1")
+		if b.params == nil {
+			b.params = make(map[string]any)
+		}
+		for name, v := range params {
+			b.params[prefix+name] = v
+		}
+	}
+	b.statements = append(b.statements, mutation)
+	return nil
+}
+
+// Atomic calls fn with a context in which the store methods, InvalidateFact,
+// and AddRelationship stage their mutations instead of running them. If fn
+// succeeds, the staged mutations run as one chained CozoScript query, which
+// CozoDB executes in a single transaction; if fn or the commit fails,
+// nothing is written. Embeddings are generated only after the commit.
+//
+// Reads inside fn do not see staged writes. Atomic calls nested inside fn
+// join the outer batch.
+func (w *Writer) Atomic(ctx context.Context, fn func(ctx context.Context) error) error {
+	if batchFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	b := &writeBatch{}
+	if err := fn(context.WithValue(ctx, writeBatchKey{}, b)); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.statements) > 0 {
+		script := "{\n" + strings.Join(b.statements, "\n}\n{\n") + "\n}"
+		if err := w.backend.Execute(ctx, script, b.params); err != nil {
+			return fmt.Errorf("commit atomic write: %w", err)
+		}
+	}
+	for _, fn := range b.onCommit {
+		fn()
+	}
+	return nil
+}
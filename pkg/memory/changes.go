@@ -0,0 +1,108 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package memory
+
+import (
+	"sync"
+	"time"
+)
+
+// Change operations published on a ChangeFeed.
+const (
+	ChangeCreated     = "created"
+	ChangeUpdated     = "updated"
+	ChangeInvalidated = "invalidated"
+	ChangeRelated     = "related"
+	ChangeUnrelated   = "unrelated"
+	ChangeArchived    = "archived"
+	ChangeUnarchived  = "unarchived"
+	ChangeMerged      = "merged"
+	ChangeImported    = "imported"
+	ChangeRestored    = "restored"
+	ChangeDeleted     = "deleted"
+)
+
+// maxRecentChanges is how many changes a ChangeFeed keeps for Recent.
+const maxRecentChanges = 50
+
+// Change describes one write to the memory graph.
+type Change struct {
+	Op        string `json:"op"`
+	NodeID    string `json:"node_id,omitempty"`
+	Namespace string `json:"namespace"`
+	At        int64  `json:"at"`
+}
+
+// ChangeFeed fans out graph writes to subscribers and remembers the most
+// recent ones. It is safe for concurrent use.
+type ChangeFeed struct {
+	mu     sync.Mutex
+	subs   map[chan Change]struct{}
+	recent []Change
+}
+
+// NewChangeFeed creates an empty change feed.
+func NewChangeFeed() *ChangeFeed {
+	return &ChangeFeed{subs: make(map[chan Change]struct{})}
+}
+
+// Subscribe returns a channel that receives changes published after the
+// call, and a function that unsubscribes and closes the channel. A
+// subscriber that falls more than buffer changes behind misses changes
+// rather than blocking writers.
+func (f *ChangeFeed) Subscribe(buffer int) (<-chan Change, func()) {
+	ch := make(chan Change, buffer)
+	f.mu.Lock()
+	f.subs[ch] = struct{}{}
+	f.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			f.mu.Lock()
+			delete(f.subs, ch)
+			f.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// Publish records a change and delivers it to every subscriber. A zero At
+// is set to the current time.
+func (f *ChangeFeed) Publish(c Change) {
+	if c.At == 0 {
+		c.At = time.Now().Unix()
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.recent = append(f.recent, c)
+	if len(f.recent) > maxRecentChanges {
+		f.recent = f.recent[len(f.recent)-maxRecentChanges:]
+	}
+	for ch := range f.subs {
+		select {
+		case ch <- c:
+		default:
+		}
+	}
+}
+
+// Recent returns up to limit of the latest changes, newest first. A limit
+// of zero or less returns all retained changes.
+func (f *ChangeFeed) Recent(limit int) []Change {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	n := len(f.recent)
+	if limit > 0 && limit < n {
+		n = limit
+	}
+	out := make([]Change, n)
+	for i := range out {
+		out[i] = f.recent[len(f.recent)-1-i]
+	}
+	return out
+}
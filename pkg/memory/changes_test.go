@@ -0,0 +1,67 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package memory
+
+import (
+	"testing"
+)
+
+func TestChangeFeedSubscribe(t *testing.T) {
+	feed := NewChangeFeed()
+	ch, unsubscribe := feed.Subscribe(4)
+
+	feed.Publish(Change{Op: ChangeCreated, NodeID: "fact:1", Namespace: "default"})
+	got := <-ch
+	if got.Op != ChangeCreated || got.NodeID != "fact:1" {
+		t.Errorf("received %+v, want created fact:1", got)
+	}
+	if got.At == 0 {
+		t.Error("Publish should set At")
+	}
+
+	unsubscribe()
+	unsubscribe()
+	if _, ok := <-ch; ok {
+		t.Error("channel should be closed after unsubscribe")
+	}
+	feed.Publish(Change{Op: ChangeUpdated, NodeID: "fact:1"})
+}
+
+func TestChangeFeedSlowSubscriber(t *testing.T) {
+	feed := NewChangeFeed()
+	ch, unsubscribe := feed.Subscribe(1)
+	defer unsubscribe()
+
+	// A full buffer drops changes instead of blocking the writer.
+	feed.Publish(Change{Op: ChangeCreated, NodeID: "fact:1"})
+	feed.Publish(Change{Op: ChangeCreated, NodeID: "fact:2"})
+	if got := <-ch; got.NodeID != "fact:1" {
+		t.Errorf("received %s, want fact:1", got.NodeID)
+	}
+	select {
+	case c := <-ch:
+		t.Errorf("unexpected change %+v", c)
+	default:
+	}
+}
+
+func TestChangeFeedRecent(t *testing.T) {
+	feed := NewChangeFeed()
+	for i := 0; i < maxRecentChanges+5; i++ {
+		feed.Publish(Change{Op: ChangeCreated, At: int64(i + 1)})
+	}
+
+	all := feed.Recent(0)
+	if len(all) != maxRecentChanges {
+		t.Fatalf("Recent(0) returned %d changes, want %d", len(all), maxRecentChanges)
+	}
+	if all[0].At != maxRecentChanges+5 {
+		t.Errorf("newest change first: got At=%d", all[0].At)
+	}
+
+	if got := feed.Recent(3); len(got) != 3 || got[2].At != maxRecentChanges+3 {
+		t.Errorf("Recent(3) = %+v", got)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/kraklabs/mie/pkg/storage"
@@ -21,6 +22,7 @@ import (
 type ClientConfig struct {
 	DataDir             string
 	StorageEngine       string
+	Namespace           string // default namespace; tools may override it per call
 	EmbeddingEnabled    bool
 	EmbeddingProvider   string
 	EmbeddingBaseURL    string
@@ -28,6 +30,53 @@ type ClientConfig struct {
 	EmbeddingAPIKey     string
 	EmbeddingDimensions int
 	EmbeddingWorkers    int
+	// EmbeddingFallbackProvider, if set, embeds the texts EmbeddingProvider
+	// fails on. It takes its own base URL, model, and API key, and must
+	// return vectors of EmbeddingDimensions.
+	EmbeddingFallbackProvider string
+	EmbeddingFallbackBaseURL  string
+	EmbeddingFallbackModel    string
+	EmbeddingFallbackAPIKey   string
+	// VectorIndex, if set, is searched for semantic search, deduplication,
+	// and conflict detection instead of CozoDB's HNSW indexes, which are
+	// then not created. The stored embeddings are loaded into it when the
+	// Client opens. Use it with storage engines or deployments without
+	// HNSW support.
+	VectorIndex VectorIndex
+	// DedupThreshold is the similarity at or above which StoreFact returns an
+	// existing fact instead of storing a new one. Zero uses
+	// DefaultDedupThreshold; a negative value disables deduplication.
+	DedupThreshold float64
+	// DecayHalfLifeDays is the age in days at which a fact's effective
+	// confidence halves in search results. Zero disables decay.
+	DecayHalfLifeDays float64
+	// RankingWeights blends the signals semantic search ranks by. The zero
+	// value uses DefaultRankingWeights.
+	RankingWeights RankingWeights
+	// MinSimilarity drops semantic search results less similar to the
+	// query than it (0-1) unless a search sets its own. Zero keeps them all.
+	MinSimilarity float64
+	// SearchCacheSize is how many semantic searches keep their results
+	// cached, and SearchCacheTTL for how long. Zero uses
+	// DefaultSearchCacheSize and DefaultSearchCacheTTL; a negative size
+	// disables the cache. Every write through the Client and every stored
+	// embedding clears it.
+	SearchCacheSize int
+	SearchCacheTTL  time.Duration
+	// AutoLink makes StoreFact link each new fact to the existing entities
+	// whose names or aliases its content mentions.
+	AutoLink bool
+	// CategoryPolicies set the default confidence and retention of facts by
+	// category. Facts past their category's retention are invalidated by
+	// the expiry sweep, including those stored before the policy was set.
+	CategoryPolicies map[string]tools.CategoryPolicy
+	// ExtraEntityKinds and ExtraFactCategories are accepted in addition to
+	// ValidEntityKinds and ValidFactCategories.
+	ExtraEntityKinds    []string
+	ExtraFactCategories []string
+	// Limits caps the size of the graph; stores fail once it is reached.
+	// The zero value is unlimited.
+	Limits Limits
 }
 
 // Client provides access to the MIE memory graph.
@@ -40,6 +89,30 @@ type Client struct {
 	detector *ConflictDetector
 	embedder *EmbeddingGenerator
 	logger   *slog.Logger
+	changes  *ChangeFeed
+
+	// providers wraps the embedding providers, or is nil without
+	// embeddings; providerErr is why the provider could not be created.
+	providers   *FallbackEmbeddingProvider
+	providerErr error
+
+	// Background backfill started by StartBackfill; stopped by Close.
+	backfillCancel context.CancelFunc
+	backfillDone   chan struct{}
+
+	// Expiry sweep started by StartExpirySweep; stopped by Close.
+	sweepCancel context.CancelFunc
+	sweepDone   chan struct{}
+
+	// attachMu keeps Detach from removing an attachment file that Attach
+	// is linking again.
+	attachMu sync.Mutex
+
+	// sizeBytes is the size of the data directory measured at sizeAt, for
+	// Limits.MaxStorageBytes.
+	sizeMu    sync.Mutex
+	sizeAt    time.Time
+	sizeBytes int64
 }
 
 // Ensure Client implements tools.Querier at compile time.
@@ -81,16 +154,31 @@ func NewClientWithLogger(cfg ClientConfig, logger *slog.Logger) (*Client, error)
 		return nil, err
 	}
 
-	// Create HNSW indexes for semantic search if embeddings are enabled
-	if cfg.EmbeddingEnabled {
+	// Create full-text indexes for fulltext search
+	if err := EnsureFTSIndexes(backend); err != nil {
+		_ = backend.Close()
+		return nil, err
+	}
+
+	// Create HNSW indexes for semantic search if embeddings are enabled,
+	// unless another vector index replaces them.
+	switch {
+	case cfg.EmbeddingEnabled && cfg.VectorIndex == nil:
 		if err := EnsureHNSWIndexes(backend, dim); err != nil {
 			_ = backend.Close()
 			return nil, err
 		}
+	case cfg.VectorIndex != nil:
+		if err := loadVectorIndex(context.Background(), backend, cfg.VectorIndex); err != nil {
+			_ = backend.Close()
+			return nil, err
+		}
 	}
 
 	// Set up embedding provider if enabled
 	var embedder *EmbeddingGenerator
+	var providers *FallbackEmbeddingProvider
+	var providerErr error
 	if cfg.EmbeddingEnabled && cfg.EmbeddingProvider != "" {
 		provider, err := CreateEmbeddingProvider(
 			cfg.EmbeddingProvider,
@@ -101,14 +189,64 @@ func NewClientWithLogger(cfg ClientConfig, logger *slog.Logger) (*Client, error)
 		)
 		if err != nil {
 			logger.Warn("failed to create embedding provider, continuing without embeddings", "error", err)
+			providerErr = err
 		} else {
-			embedder = NewEmbeddingGenerator(provider, logger)
+			var fallback EmbeddingProvider
+			if cfg.EmbeddingFallbackProvider != "" {
+				fallback, err = CreateEmbeddingProvider(
+					cfg.EmbeddingFallbackProvider,
+					cfg.EmbeddingFallbackAPIKey,
+					cfg.EmbeddingFallbackBaseURL,
+					cfg.EmbeddingFallbackModel,
+					logger,
+				)
+				if err != nil {
+					logger.Warn("failed to create fallback embedding provider, continuing without it", "error", err)
+					fallback = nil
+				}
+			}
+			providers = NewFallbackEmbeddingProvider(cfg.EmbeddingProvider, provider, cfg.EmbeddingFallbackProvider, fallback, logger)
+			embedder = NewEmbeddingGenerator(providers, logger)
 		}
 	}
 
 	writer := NewWriter(backend, embedder, logger)
 	reader := NewReader(backend, embedder, logger)
 	detector := NewConflictDetector(backend, embedder, logger)
+	writer.namespace = cfg.Namespace
+	writer.extraKinds = cfg.ExtraEntityKinds
+	writer.extraCategories = cfg.ExtraFactCategories
+	writer.autoLink = cfg.AutoLink
+	writer.vectors = cfg.VectorIndex
+	writer.categoryPolicies = cfg.CategoryPolicies
+	reader.namespace = cfg.Namespace
+	reader.halfLifeDays = cfg.DecayHalfLifeDays
+	reader.minSimilarity = cfg.MinSimilarity
+	reader.vectors = cfg.VectorIndex
+	if cfg.SearchCacheSize >= 0 {
+		size, ttl := cfg.SearchCacheSize, cfg.SearchCacheTTL
+		if size == 0 {
+			size = DefaultSearchCacheSize
+		}
+		if ttl <= 0 {
+			ttl = DefaultSearchCacheTTL
+		}
+		reader.searchCache = newSearchCache(size, ttl)
+		writer.searchCache = reader.searchCache
+	}
+	if cfg.RankingWeights != (RankingWeights{}) {
+		reader.ranking = cfg.RankingWeights
+	}
+	detector.namespace = cfg.Namespace
+	detector.vectors = cfg.VectorIndex
+	switch {
+	case cfg.DedupThreshold == 0:
+		writer.dedupThreshold = DefaultDedupThreshold
+	case cfg.DedupThreshold > 0:
+		writer.dedupThreshold = cfg.DedupThreshold
+	}
+
+	writer.StartEmbeddingQueue(cfg.EmbeddingWorkers)
 
 	return &Client{
 		backend:  backend,
@@ -118,17 +256,116 @@ func NewClientWithLogger(cfg ClientConfig, logger *slog.Logger) (*Client, error)
 		detector: detector,
 		embedder: embedder,
 		logger:   logger,
+		changes:  NewChangeFeed(),
+
+		providers:   providers,
+		providerErr: providerErr,
 	}, nil
 }
 
 // Close releases resources held by the Client.
+// A background backfill, expiry sweep, and the embedding queue workers are
+// stopped and waited for first; queued embeddings resume on the next start.
 func (c *Client) Close() error {
+	if c.backfillCancel != nil {
+		c.backfillCancel()
+		<-c.backfillDone
+	}
+	if c.sweepCancel != nil {
+		c.sweepCancel()
+		<-c.sweepDone
+	}
+	c.writer.StopEmbeddingQueue()
+	if c.providers != nil {
+		if err := c.providers.Close(); err != nil {
+			c.logger.Warn("failed to close embedding provider", "error", err)
+		}
+	}
 	return c.backend.Close()
 }
 
+// MissingEmbeddings counts nodes without embeddings per node type.
+func (c *Client) MissingEmbeddings(ctx context.Context, nodeTypes []string) (map[string]int, error) {
+	return c.writer.MissingEmbeddings(ctx, nodeTypes)
+}
+
+// BackfillEmbeddings generates missing embeddings. See Writer.BackfillEmbeddings.
+// Workers defaults to ClientConfig.EmbeddingWorkers.
+func (c *Client) BackfillEmbeddings(ctx context.Context, opts BackfillOptions) (*BackfillResult, error) {
+	if opts.Workers <= 0 {
+		opts.Workers = c.config.EmbeddingWorkers
+	}
+	return c.writer.BackfillEmbeddings(ctx, opts)
+}
+
+// GC removes dangling edges, orphaned embeddings, and unused topics. See
+// Writer.GC. Removed topics are recorded as deleted.
+func (c *Client) GC(ctx context.Context, opts GCOptions) (*GCResult, error) {
+	result, err := c.writer.GC(ctx, opts)
+	if err != nil || opts.DryRun || len(result.UnusedTopics) == 0 {
+		return result, err
+	}
+	ids := make([]string, len(result.UnusedTopics))
+	for i, t := range result.UnusedTopics {
+		ids[i] = t.ID
+		c.publish(ctx, ChangeDeleted, t.ID, nil)
+	}
+	c.audit(ctx, ChangeDeleted, "", nil, ids...)
+	return result, nil
+}
+
+// StartBackfill runs BackfillEmbeddings in the background so nodes stored
+// before embeddings were enabled become searchable semantically. It does
+// nothing if embeddings are disabled or a backfill was already started.
+func (c *Client) StartBackfill() {
+	if c.embedder == nil || c.backfillCancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	c.backfillCancel = cancel
+	c.backfillDone = make(chan struct{})
+	go func() {
+		defer close(c.backfillDone)
+		if _, err := c.BackfillEmbeddings(ctx, BackfillOptions{}); err != nil && ctx.Err() == nil {
+			c.logger.Warn("background embedding backfill failed", "error", err)
+		}
+	}()
+}
+
 // RawQuery executes a raw CozoScript query against the database.
 func (c *Client) RawQuery(ctx context.Context, script string) (*storage.QueryResult, error) {
-	return c.backend.Query(ctx, script)
+	return c.backend.Query(ctx, script, nil)
+}
+
+// RawExecute runs a raw CozoScript script that may modify the database, such
+// as one with :put or ::remove. It returns a single "status" row on success.
+func (c *Client) RawExecute(ctx context.Context, script string) (*storage.QueryResult, error) {
+	if err := c.backend.Execute(ctx, script, nil); err != nil {
+		return nil, err
+	}
+	c.invalidateSearchCache()
+	return &storage.QueryResult{Headers: []string{"status"}, Rows: [][]any{{"OK"}}}, nil
+}
+
+// CheckEmbeddings embeds a test text with the embedding provider and the
+// fallback provider, if configured, and returns the problems found. They are
+// also reported by GetStats until the next check.
+func (c *Client) CheckEmbeddings(ctx context.Context) []string {
+	if c.providers == nil {
+		return c.embeddingWarnings()
+	}
+	return c.providers.Check(ctx)
+}
+
+// embeddingWarnings returns the embedding problems GetStats reports.
+func (c *Client) embeddingWarnings() []string {
+	if c.providerErr != nil {
+		return []string{fmt.Sprintf("embedding provider %s could not be set up: %v", c.config.EmbeddingProvider, c.providerErr)}
+	}
+	if c.providers == nil {
+		return nil
+	}
+	return c.providers.Warnings()
 }
 
 // EmbeddingsEnabled reports whether embedding support is configured.
@@ -136,34 +373,298 @@ func (c *Client) EmbeddingsEnabled() bool {
 	return c.config.EmbeddingEnabled && c.embedder != nil
 }
 
+// Changes returns the feed that every successful write through the Client
+// publishes to.
+func (c *Client) Changes() *ChangeFeed {
+	return c.changes
+}
+
+// publish records a write on the change feed when err is nil. Inside
+// Atomic, the change is published once the batch commits.
+func (c *Client) publish(ctx context.Context, op, nodeID string, err error) {
+	if err != nil {
+		return
+	}
+	change := Change{
+		Op:        op,
+		NodeID:    nodeID,
+		Namespace: resolveNamespace(ctx, c.config.Namespace),
+	}
+	afterCommit(ctx, func() {
+		c.invalidateSearchCache()
+		c.changes.Publish(change)
+	})
+}
+
+// invalidateSearchCache drops cached search results after a write.
+func (c *Client) invalidateSearchCache() {
+	if c.reader.searchCache != nil {
+		c.reader.searchCache.invalidate()
+	}
+}
+
+// audit records a successful write in the audit log, attributed to the
+// tool and agent set with tools.WithAuditSource. agent, when set, is the
+// source_agent the write itself names. A failure to record is logged, not
+// returned, so auditing never fails a write.
+func (c *Client) audit(ctx context.Context, op, agent string, err error, nodeIDs ...string) {
+	if err != nil {
+		return
+	}
+	tool, ctxAgent := tools.AuditSourceFromContext(ctx)
+	if agent == "" {
+		agent = ctxAgent
+	}
+	if err := c.writer.recordAudit(ctx, op, tool, agent, nodeIDs); err != nil {
+		c.logger.Warn("failed to record audit entry", "op", op, "error", err)
+	}
+}
+
+// edgeNodeIDs returns the IDs of the nodes an edge in table connects.
+func edgeNodeIDs(table string, fields map[string]string) []string {
+	var ids []string
+	for _, col := range ValidEdgeTables[table] {
+		if id := fields[col]; id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// GetAuditLog returns recorded writes, newest first. See Reader.GetAuditLog.
+func (c *Client) GetAuditLog(ctx context.Context, opts tools.AuditOptions) ([]tools.AuditEntry, error) {
+	return c.reader.GetAuditLog(ctx, opts)
+}
+
+// CreateSnapshot records a full export of the namespace under label, so
+// that RestoreSnapshot can revert the graph to its current state.
+func (c *Client) CreateSnapshot(ctx context.Context, label string) (*tools.Snapshot, error) {
+	data, err := c.reader.ExportGraph(ctx, tools.ExportOptions{Format: "json"})
+	if err != nil {
+		return nil, fmt.Errorf("export graph: %w", err)
+	}
+	return c.writer.CreateSnapshot(ctx, label, data)
+}
+
+// ListSnapshots returns the snapshots of the namespace, newest first.
+func (c *Client) ListSnapshots(ctx context.Context) ([]tools.Snapshot, error) {
+	return c.reader.ListSnapshots(ctx)
+}
+
+// RestoreSnapshot reverts the namespace to a snapshot. It first snapshots
+// the current graph, and returns that checkpoint so the restore can be
+// undone, then deletes the namespace's nodes, edges, aliases, and archive
+// marks and imports the snapshot. Embeddings are recomputed in the
+// background. Snapshots and the audit log are not affected.
+func (c *Client) RestoreSnapshot(ctx context.Context, snapshotID string) (*tools.Snapshot, error) {
+	data, err := c.reader.snapshotData(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint, err := c.CreateSnapshot(ctx, fmt.Sprintf("before restoring %s", snapshotID))
+	if err != nil {
+		return nil, fmt.Errorf("checkpoint before restore: %w", err)
+	}
+	if err := c.writer.clearNamespace(ctx); err != nil {
+		return checkpoint, err
+	}
+	if _, err := c.writer.ImportGraph(ctx, data); err != nil {
+		return checkpoint, fmt.Errorf("restore snapshot %s: %w", snapshotID, err)
+	}
+	c.publish(ctx, ChangeRestored, "", nil)
+	c.audit(ctx, ChangeRestored, "", nil, snapshotID)
+	return checkpoint, nil
+}
+
+// DeleteSnapshot removes a snapshot of the namespace.
+func (c *Client) DeleteSnapshot(ctx context.Context, snapshotID string) error {
+	return c.writer.DeleteSnapshot(ctx, snapshotID)
+}
+
+// SaveSearch stores mie_query arguments under name in the namespace,
+// replacing a saved search of the same name.
+func (c *Client) SaveSearch(ctx context.Context, name string, args map[string]any) (*tools.SavedSearch, error) {
+	now := time.Now().Unix()
+	search := &tools.SavedSearch{Name: name, Args: args, CreatedAt: now, UpdatedAt: now}
+	if prev, err := c.reader.GetSavedSearch(ctx, name); err == nil {
+		search.CreatedAt = prev.CreatedAt
+	}
+	if err := c.writer.SaveSearch(ctx, search); err != nil {
+		return nil, err
+	}
+	return search, nil
+}
+
+// GetSavedSearch returns the saved search named name.
+func (c *Client) GetSavedSearch(ctx context.Context, name string) (*tools.SavedSearch, error) {
+	return c.reader.GetSavedSearch(ctx, name)
+}
+
+// ListSavedSearches returns the saved searches of the namespace by name.
+func (c *Client) ListSavedSearches(ctx context.Context) ([]tools.SavedSearch, error) {
+	return c.reader.ListSavedSearches(ctx)
+}
+
+// DeleteSavedSearch removes the saved search named name.
+func (c *Client) DeleteSavedSearch(ctx context.Context, name string) error {
+	return c.writer.DeleteSavedSearch(ctx, name)
+}
+
+// OpenSession starts a session in the namespace. Stores that pass its ID
+// as their source conversation are grouped under it.
+func (c *Client) OpenSession(ctx context.Context, title, sourceAgent string) (*tools.Session, error) {
+	now := time.Now()
+	session := &tools.Session{
+		ID:          GenerateID("ses", resolveNamespace(ctx, c.config.Namespace), title, strconv.FormatInt(now.UnixNano(), 10)),
+		Title:       title,
+		SourceAgent: sourceAgent,
+		StartedAt:   now.Unix(),
+	}
+	if err := c.writer.PutSession(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// CloseSession ends an open session of the namespace and records summary
+// with it.
+func (c *Client) CloseSession(ctx context.Context, sessionID, summary string) (*tools.Session, error) {
+	session, err := c.reader.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.EndedAt != 0 {
+		return nil, fmt.Errorf("session %s is already closed", sessionID)
+	}
+	session.EndedAt = time.Now().Unix()
+	session.Summary = summary
+	if err := c.writer.PutSession(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// GetSession returns the session of the namespace with the given ID.
+func (c *Client) GetSession(ctx context.Context, sessionID string) (*tools.Session, error) {
+	return c.reader.GetSession(ctx, sessionID)
+}
+
+// ListSessions returns the sessions of the namespace, most recently started
+// first.
+func (c *Client) ListSessions(ctx context.Context) ([]tools.Session, error) {
+	return c.reader.ListSessions(ctx)
+}
+
+// Atomic runs fn so that the writes it makes through the Client are
+// committed together or not at all. See Writer.Atomic.
+func (c *Client) Atomic(ctx context.Context, fn func(ctx context.Context) error) error {
+	return c.writer.Atomic(ctx, fn)
+}
+
 // --- tools.Querier write operations ---
 
 func (c *Client) StoreFact(ctx context.Context, req tools.StoreFactRequest) (*tools.Fact, error) {
-	return c.writer.StoreFact(ctx, req)
+	if err := c.checkLimits(ctx, "fact"); err != nil {
+		return nil, err
+	}
+	fact, err := c.writer.StoreFact(ctx, req)
+	if err == nil && fact.DuplicateSimilarity == 0 {
+		c.publish(ctx, ChangeCreated, fact.ID, nil)
+		c.audit(ctx, ChangeCreated, req.SourceAgent, nil, fact.ID)
+		if len(fact.Replaces) > 0 {
+			for _, id := range fact.Replaces {
+				c.publish(ctx, ChangeInvalidated, id, nil)
+			}
+			c.audit(ctx, ChangeInvalidated, req.SourceAgent, nil, fact.Replaces...)
+		}
+	}
+	return fact, err
 }
 
 func (c *Client) StoreDecision(ctx context.Context, req tools.StoreDecisionRequest) (*tools.Decision, error) {
-	return c.writer.StoreDecision(ctx, req)
+	if err := c.checkLimits(ctx, "decision"); err != nil {
+		return nil, err
+	}
+	dec, err := c.writer.StoreDecision(ctx, req)
+	if err == nil {
+		c.publish(ctx, ChangeCreated, dec.ID, nil)
+		c.audit(ctx, ChangeCreated, req.SourceAgent, nil, dec.ID)
+	}
+	return dec, err
 }
 
+// StoreEntity stores an entity, or returns the canonical entity unchanged
+// when req.Name is a known alias.
 func (c *Client) StoreEntity(ctx context.Context, req tools.StoreEntityRequest) (*tools.Entity, error) {
-	return c.writer.StoreEntity(ctx, req)
+	if req.Name != "" {
+		entityID, err := c.reader.ResolveAlias(ctx, req.Name)
+		if err != nil {
+			return nil, fmt.Errorf("resolve alias: %w", err)
+		}
+		if entityID != "" {
+			node, err := c.reader.GetNodeByID(ctx, entityID)
+			if err != nil {
+				return nil, err
+			}
+			if ent, ok := node.(*tools.Entity); ok {
+				return ent, nil
+			}
+		}
+	}
+	if err := c.checkLimits(ctx, "entity"); err != nil {
+		return nil, err
+	}
+	ent, err := c.writer.StoreEntity(ctx, req)
+	if err == nil {
+		c.publish(ctx, ChangeCreated, ent.ID, nil)
+		c.audit(ctx, ChangeCreated, req.SourceAgent, nil, ent.ID)
+	}
+	return ent, err
 }
 
 func (c *Client) StoreEvent(ctx context.Context, req tools.StoreEventRequest) (*tools.Event, error) {
-	return c.writer.StoreEvent(ctx, req)
+	if err := c.checkLimits(ctx, "event"); err != nil {
+		return nil, err
+	}
+	evt, err := c.writer.StoreEvent(ctx, req)
+	if err == nil {
+		c.publish(ctx, ChangeCreated, evt.ID, nil)
+		c.audit(ctx, ChangeCreated, req.SourceAgent, nil, evt.ID)
+	}
+	return evt, err
 }
 
 func (c *Client) StoreTopic(ctx context.Context, req tools.StoreTopicRequest) (*tools.Topic, error) {
-	return c.writer.StoreTopic(ctx, req)
+	if err := c.checkLimits(ctx, "topic"); err != nil {
+		return nil, err
+	}
+	topic, err := c.writer.StoreTopic(ctx, req)
+	if err == nil {
+		c.publish(ctx, ChangeCreated, topic.ID, nil)
+		c.audit(ctx, ChangeCreated, "", nil, topic.ID)
+	}
+	return topic, err
 }
 
 func (c *Client) InvalidateFact(ctx context.Context, oldFactID, newFactID, reason string) error {
-	return c.writer.InvalidateFact(ctx, oldFactID, newFactID, reason)
+	err := c.writer.InvalidateFact(ctx, oldFactID, newFactID, reason)
+	c.publish(ctx, ChangeInvalidated, oldFactID, err)
+	c.audit(ctx, ChangeInvalidated, "", err, oldFactID, newFactID)
+	return err
 }
 
 func (c *Client) AddRelationship(ctx context.Context, edgeType string, fields map[string]string) error {
-	return c.writer.AddRelationship(ctx, edgeType, fields)
+	err := c.writer.AddRelationship(ctx, edgeType, fields)
+	c.publish(ctx, ChangeRelated, "", err)
+	c.audit(ctx, ChangeRelated, fields["source_agent"], err, edgeNodeIDs(edgeType, fields)...)
+	return err
+}
+
+func (c *Client) RemoveRelationship(ctx context.Context, edgeType string, fields map[string]string) error {
+	err := c.writer.RemoveRelationship(ctx, edgeType, fields)
+	c.publish(ctx, ChangeUnrelated, "", err)
+	c.audit(ctx, ChangeUnrelated, "", err, edgeNodeIDs(edgeType, fields)...)
+	return err
 }
 
 // --- tools.Querier read operations ---
@@ -176,6 +677,18 @@ func (c *Client) ExactSearch(ctx context.Context, query string, nodeTypes []stri
 	return c.reader.ExactSearch(ctx, query, nodeTypes, limit)
 }
 
+func (c *Client) FullTextSearch(ctx context.Context, query string, nodeTypes []string, limit int) ([]tools.SearchResult, error) {
+	return c.reader.FullTextSearch(ctx, query, nodeTypes, limit)
+}
+
+func (c *Client) HybridSearch(ctx context.Context, query string, nodeTypes []string, limit int) ([]tools.SearchResult, error) {
+	return c.reader.HybridSearch(ctx, query, nodeTypes, limit)
+}
+
+func (c *Client) SimilarNodes(ctx context.Context, nodeID string, nodeTypes []string, limit int) ([]tools.SearchResult, error) {
+	return c.reader.SimilarNodes(ctx, nodeID, nodeTypes, limit)
+}
+
 func (c *Client) GetNodeByID(ctx context.Context, nodeID string) (any, error) {
 	return c.reader.GetNodeByID(ctx, nodeID)
 }
@@ -184,6 +697,10 @@ func (c *Client) ListNodes(ctx context.Context, opts tools.ListOptions) ([]any,
 	return c.reader.ListNodes(ctx, opts)
 }
 
+func (c *Client) FindEntityByName(ctx context.Context, name string) (*tools.Entity, error) {
+	return c.reader.FindEntityByName(ctx, name)
+}
+
 // --- tools.Querier graph traversal ---
 
 func (c *Client) GetRelatedEntities(ctx context.Context, factID string) ([]tools.Entity, error) {
@@ -210,14 +727,71 @@ func (c *Client) GetEntityDecisions(ctx context.Context, entityID string) ([]too
 	return c.reader.GetEntityDecisions(ctx, entityID)
 }
 
+func (c *Client) GetNodeEdges(ctx context.Context, nodeID string) ([]tools.GraphEdge, error) {
+	return c.reader.GetNodeEdges(ctx, nodeID)
+}
+
 // --- tools.Querier update operations ---
 
 func (c *Client) UpdateDescription(ctx context.Context, nodeID, newDescription string) error {
-	return c.writer.UpdateDescription(ctx, nodeID, newDescription)
+	err := c.writer.UpdateDescription(ctx, nodeID, newDescription)
+	c.publish(ctx, ChangeUpdated, nodeID, err)
+	c.audit(ctx, ChangeUpdated, "", err, nodeID)
+	return err
+}
+
+func (c *Client) RenameEntity(ctx context.Context, entityID, newName string) error {
+	err := c.writer.RenameEntity(ctx, entityID, newName)
+	c.publish(ctx, ChangeUpdated, entityID, err)
+	c.audit(ctx, ChangeUpdated, "", err, entityID)
+	return err
+}
+
+func (c *Client) AddAlias(ctx context.Context, entityID, alias string) error {
+	err := c.writer.AddAlias(ctx, entityID, alias)
+	c.publish(ctx, ChangeUpdated, entityID, err)
+	c.audit(ctx, ChangeUpdated, "", err, entityID)
+	return err
+}
+
+func (c *Client) SetArchived(ctx context.Context, nodeID string, archived bool) error {
+	err := c.writer.SetArchived(ctx, nodeID, archived)
+	op := ChangeArchived
+	if !archived {
+		op = ChangeUnarchived
+	}
+	c.publish(ctx, op, nodeID, err)
+	c.audit(ctx, op, "", err, nodeID)
+	return err
+}
+
+func (c *Client) SetVerified(ctx context.Context, factID, verifiedBy string, verified bool) error {
+	err := c.writer.SetVerified(ctx, factID, verifiedBy, verified)
+	c.publish(ctx, ChangeUpdated, factID, err)
+	c.audit(ctx, ChangeUpdated, "", err, factID)
+	return err
+}
+
+// ImportGraph restores an export into the client's namespace. See Writer.ImportGraph.
+func (c *Client) ImportGraph(ctx context.Context, data *tools.ExportData) (map[string]int, error) {
+	counts, err := c.writer.ImportGraph(ctx, data)
+	c.publish(ctx, ChangeImported, "", err)
+	c.audit(ctx, ChangeImported, "", err)
+	return counts, err
+}
+
+func (c *Client) MergeEntities(ctx context.Context, survivorID, duplicateID string) error {
+	err := c.writer.MergeEntities(ctx, survivorID, duplicateID)
+	c.publish(ctx, ChangeMerged, survivorID, err)
+	c.audit(ctx, ChangeMerged, "", err, survivorID, duplicateID)
+	return err
 }
 
 func (c *Client) UpdateStatus(ctx context.Context, nodeID, newStatus string) error {
-	return c.writer.UpdateStatus(ctx, nodeID, newStatus)
+	err := c.writer.UpdateStatus(ctx, nodeID, newStatus)
+	c.publish(ctx, ChangeUpdated, nodeID, err)
+	c.audit(ctx, ChangeUpdated, "", err, nodeID)
+	return err
 }
 
 // --- tools.Querier conflict detection ---
@@ -230,6 +804,10 @@ func (c *Client) CheckNewFactConflicts(ctx context.Context, content, category st
 	return c.detector.CheckNewFactConflicts(ctx, content, category)
 }
 
+func (c *Client) FindDuplicates(ctx context.Context, opts tools.DuplicateOptions) ([]tools.DuplicateCluster, error) {
+	return c.reader.FindDuplicates(ctx, opts)
+}
+
 // --- tools.Querier stats and export ---
 
 func (c *Client) GetStats(ctx context.Context) (*tools.GraphStats, error) {
@@ -239,19 +817,39 @@ func (c *Client) GetStats(ctx context.Context) (*tools.GraphStats, error) {
 	}
 	stats.StorageEngine = c.config.StorageEngine
 	stats.StoragePath = c.config.DataDir
+	stats.EmbeddingWarnings = c.embeddingWarnings()
+	if c.reader.searchCache != nil {
+		stats.SearchCache = c.reader.searchCache.stats()
+	}
+	if c.embedder != nil {
+		if stats.EmbeddingQueue, err = c.writer.EmbeddingQueueStats(ctx); err != nil {
+			return nil, err
+		}
+	}
+	stats.Limits = c.limitStats(stats)
 	return stats, nil
 }
 
+func (c *Client) GetTopicStats(ctx context.Context) ([]tools.TopicStats, error) {
+	return c.reader.GetTopicStats(ctx)
+}
+
+func (c *Client) SuggestTopics(ctx context.Context, text string, limit int) ([]tools.TopicSuggestion, error) {
+	return c.reader.SuggestTopics(ctx, text, limit)
+}
+
 func (c *Client) ExportGraph(ctx context.Context, opts tools.ExportOptions) (*tools.ExportData, error) {
 	return c.reader.ExportGraph(ctx, opts)
 }
 
+// metaPutScript sets the mie_meta value of $key to $value.
+const metaPutScript = `?[key, value] <- [[$key, $value]] :put mie_meta {key => value}`
+
 // IncrementCounter atomically increments a counter in mie_meta and updates
 // the corresponding last_*_at timestamp.
 func (c *Client) IncrementCounter(ctx context.Context, key string) error {
 	// Read current value.
-	readScript := fmt.Sprintf(`?[value] := *mie_meta{key: '%s', value}`, escapeDatalog(key))
-	result, err := c.backend.Query(ctx, readScript)
+	result, err := c.backend.Query(ctx, `?[value] := *mie_meta{key: $key, value}`, map[string]any{"key": key})
 
 	current := 0
 	if err == nil && len(result.Rows) > 0 {
@@ -262,11 +860,7 @@ func (c *Client) IncrementCounter(ctx context.Context, key string) error {
 
 	// Write incremented value.
 	next := strconv.Itoa(current + 1)
-	writeScript := fmt.Sprintf(
-		`?[key, value] <- [['%s', '%s']] :put mie_meta {key => value}`,
-		escapeDatalog(key), next,
-	)
-	if err := c.backend.Execute(ctx, writeScript); err != nil {
+	if err := c.backend.Execute(ctx, metaPutScript, map[string]any{"key": key, "value": next}); err != nil {
 		return fmt.Errorf("increment counter %s: %w", key, err)
 	}
 
@@ -280,13 +874,12 @@ func (c *Client) IncrementCounter(ctx context.Context, key string) error {
 	}
 	if tsKey != "" {
 		now := strconv.FormatInt(time.Now().Unix(), 10)
-		tsScript := fmt.Sprintf(
-			`?[key, value] <- [['%s', '%s']] :put mie_meta {key => value}`,
-			tsKey, now,
-		)
 		// Best-effort: ignore timestamp write errors.
-		_ = c.backend.Execute(ctx, tsScript)
+		_ = c.backend.Execute(ctx, metaPutScript, map[string]any{"key": tsKey, "value": now})
 	}
 
+	// Count the day too, for mie stats. Best-effort like the timestamp.
+	_ = c.backend.Execute(ctx, dailyCounterScript, map[string]any{"counter": key, "day": usageDay(time.Now().Unix())})
+
 	return nil
 }
\ No newline at end of file
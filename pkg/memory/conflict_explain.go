@@ -0,0 +1,167 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package memory
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// maxConflictDiffWords bounds the words of each fact that explainConflict
+// compares; words past it are ignored.
+const maxConflictDiffWords = 256
+
+// temporalMarkers are the words and phrases that say a fact replaced an
+// earlier state of affairs.
+var temporalMarkers = []string{
+	"now", "currently", "anymore", "no longer", "used to", "previously", "formerly",
+	"moved", "switched", "migrated", "replaced", "instead", "as of",
+}
+
+// negationWords are the words that negate a statement. Contractions ending
+// in "n't" count as well.
+var negationWords = map[string]bool{
+	"no": true, "not": true, "never": true, "none": true, "nobody": true, "nothing": true,
+	"neither": true, "nor": true, "cannot": true, "without": true,
+}
+
+// explainConflict sets the differing spans and the kind of c from the
+// contents of its facts: the words each has that the other lacks, by a
+// word-level diff, and whether they differ in a number, in a marker of
+// change over time, or in a negation. An anti-fact paired with a fact is
+// a negation, unless their words differ in a negation as well, which
+// cancels it out.
+func explainConflict(c *tools.Conflict) {
+	a, b := strings.Fields(c.FactA.Content), strings.Fields(c.FactB.Content)
+	a, b = a[:min(len(a), maxConflictDiffWords)], b[:min(len(b), maxConflictDiffWords)]
+	c.OnlyInA, c.OnlyInB = diffWords(a, b)
+	c.Kind = classifyConflict(c.OnlyInA, c.OnlyInB)
+	if c.FactA.Negated != c.FactB.Negated {
+		if c.Kind == tools.ConflictNegation {
+			c.Kind = tools.ConflictOther
+		} else {
+			c.Kind = tools.ConflictNegation
+		}
+	}
+}
+
+// diffWords returns the runs of words of a missing from b and of b missing
+// from a, along a longest common subsequence of the two. Words compare
+// case-insensitively and without surrounding punctuation.
+func diffWords(a, b []string) (onlyInA, onlyInB []string) {
+	na, nb := make([]string, len(a)), make([]string, len(b))
+	for i, w := range a {
+		na[i] = normalizeDiffWord(w)
+	}
+	for j, w := range b {
+		nb[j] = normalizeDiffWord(w)
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of na[i:]
+	// and nb[j:].
+	lcs := make([][]int, len(na)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(nb)+1)
+	}
+	for i := len(na) - 1; i >= 0; i-- {
+		for j := len(nb) - 1; j >= 0; j-- {
+			if na[i] == nb[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var runA, runB []string
+	flush := func() {
+		if len(runA) > 0 {
+			onlyInA = append(onlyInA, strings.Join(runA, " "))
+			runA = nil
+		}
+		if len(runB) > 0 {
+			onlyInB = append(onlyInB, strings.Join(runB, " "))
+			runB = nil
+		}
+	}
+	i, j := 0, 0
+	for i < len(na) || j < len(nb) {
+		switch {
+		case i < len(na) && j < len(nb) && na[i] == nb[j]:
+			flush()
+			i++
+			j++
+		case j == len(nb) || (i < len(na) && lcs[i+1][j] >= lcs[i][j+1]):
+			runA = append(runA, a[i])
+			i++
+		default:
+			runB = append(runB, b[j])
+			j++
+		}
+	}
+	flush()
+	return onlyInA, onlyInB
+}
+
+// classifyConflict returns the kind of a conflict from the spans each fact
+// has that the other lacks.
+func classifyConflict(onlyInA, onlyInB []string) string {
+	switch {
+	case hasDigit(onlyInA) && hasDigit(onlyInB):
+		return tools.ConflictNumericMismatch
+	case hasTemporalMarker(onlyInA) || hasTemporalMarker(onlyInB):
+		return tools.ConflictTemporalSupersession
+	case hasNegation(onlyInA) != hasNegation(onlyInB):
+		return tools.ConflictNegation
+	default:
+		return tools.ConflictOther
+	}
+}
+
+// normalizeDiffWord lowercases w and trims its leading and trailing
+// punctuation, so "Redis," and "redis" compare equal.
+func normalizeDiffWord(w string) string {
+	return strings.TrimFunc(strings.ToLower(w), func(r rune) bool {
+		return unicode.IsPunct(r) || unicode.IsSymbol(r)
+	})
+}
+
+func hasDigit(spans []string) bool {
+	for _, s := range spans {
+		if strings.ContainsFunc(s, unicode.IsDigit) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTemporalMarker(spans []string) bool {
+	for _, s := range spans {
+		words := strings.Fields(s)
+		for i := range words {
+			words[i] = normalizeDiffWord(words[i])
+		}
+		text := " " + strings.Join(words, " ") + " "
+		for _, m := range temporalMarkers {
+			if strings.Contains(text, " "+m+" ") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasNegation(spans []string) bool {
+	for _, s := range spans {
+		for _, w := range strings.Fields(strings.ReplaceAll(strings.ToLower(s), "’", "'")) {
+			if w = strings.TrimFunc(w, unicode.IsPunct); negationWords[w] || strings.HasSuffix(w, "n't") {
+				return true
+			}
+		}
+	}
+	return false
+}
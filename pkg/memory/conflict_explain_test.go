@@ -0,0 +1,67 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package memory
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestExplainConflict(t *testing.T) {
+	tests := []struct {
+		a, b             string
+		kind             string
+		onlyInA, onlyInB []string
+	}{
+		{"The team has 5 engineers", "The team has 7 engineers.", tools.ConflictNumericMismatch, []string{"5"}, []string{"7"}},
+		{"User lives in Buenos Aires", "User moved to New York", tools.ConflictTemporalSupersession, []string{"lives in Buenos Aires"}, []string{"moved to New York"}},
+		{"Billing uses Redis", "Billing no longer uses Redis", tools.ConflictTemporalSupersession, nil, []string{"no longer"}},
+		{"I prefer dark mode", "I don't prefer dark mode", tools.ConflictNegation, nil, []string{"don't"}},
+		{"Deploys are not automated", "Deploys are automated", tools.ConflictNegation, []string{"not"}, nil},
+		{"I prefer dark mode", "I prefer light mode", tools.ConflictOther, []string{"dark"}, []string{"light"}},
+		{"Uses Postgres", "uses postgres", tools.ConflictOther, nil, nil},
+	}
+	for _, tt := range tests {
+		c := tools.Conflict{FactA: tools.Fact{Content: tt.a}, FactB: tools.Fact{Content: tt.b}}
+		explainConflict(&c)
+		if c.Kind != tt.kind {
+			t.Errorf("%q vs %q: kind = %q, want %q", tt.a, tt.b, c.Kind, tt.kind)
+		}
+		if !slices.Equal(c.OnlyInA, tt.onlyInA) || !slices.Equal(c.OnlyInB, tt.onlyInB) {
+			t.Errorf("%q vs %q: spans = %q / %q, want %q / %q", tt.a, tt.b, c.OnlyInA, c.OnlyInB, tt.onlyInA, tt.onlyInB)
+		}
+	}
+}
+
+func TestExplainConflictAntiFact(t *testing.T) {
+	tests := []struct {
+		a, b string
+		kind string
+	}{
+		{"User uses Docker", "User uses Docker", tools.ConflictNegation},
+		{"User lives in Lisbon", "User moved to Porto", tools.ConflictNegation},
+		// Denying "does not use Docker" agrees with "uses Docker".
+		{"User uses Docker", "User does not use Docker", tools.ConflictOther},
+	}
+	for _, tt := range tests {
+		c := tools.Conflict{FactA: tools.Fact{Content: tt.a}, FactB: tools.Fact{Content: tt.b, Negated: true}}
+		explainConflict(&c)
+		if c.Kind != tt.kind {
+			t.Errorf("%q vs NOT %q: kind = %q, want %q", tt.a, tt.b, c.Kind, tt.kind)
+		}
+	}
+}
+
+func TestDiffWordsRuns(t *testing.T) {
+	onlyInA, onlyInB := diffWords(
+		[]string{"Ana", "owns", "billing", "and", "search"},
+		[]string{"Ben", "owns", "billing", "but", "not", "search"},
+	)
+	if !slices.Equal(onlyInA, []string{"Ana", "and"}) || !slices.Equal(onlyInB, []string{"Ben", "but not"}) {
+		t.Errorf("diffWords = %q / %q", onlyInA, onlyInB)
+	}
+}
@@ -17,9 +17,12 @@ import (
 
 // ConflictDetector identifies facts that may contradict each other.
 type ConflictDetector struct {
-	backend  storage.Backend
-	embedder *EmbeddingGenerator
-	logger   *slog.Logger
+	backend   storage.Backend
+	embedder  *EmbeddingGenerator
+	logger    *slog.Logger
+	namespace string // default namespace when the context carries none
+	// vectors, if set, is searched instead of the HNSW indexes.
+	vectors VectorIndex
 }
 
 // NewConflictDetector creates a new ConflictDetector.
@@ -30,7 +33,9 @@ func NewConflictDetector(backend storage.Backend, embedder *EmbeddingGenerator,
 	return &ConflictDetector{backend: backend, embedder: embedder, logger: logger}
 }
 
-// DetectConflicts scans for potentially contradicting facts using HNSW neighbor search.
+// DetectConflicts scans for potentially contradicting facts using nearest
+// neighbor search. Each conflict carries the words that differ between the
+// facts and the kind of the difference.
 func (cd *ConflictDetector) DetectConflicts(ctx context.Context, opts tools.ConflictOptions) ([]tools.Conflict, error) {
 	if cd.embedder == nil {
 		return nil, fmt.Errorf("conflict detection requires embeddings to be enabled")
@@ -45,19 +50,24 @@ func (cd *ConflictDetector) DetectConflicts(ctx context.Context, opts tools.Conf
 		limit = 20
 	}
 
+	ns := resolveNamespace(ctx, cd.namespace)
+
 	// Get all valid facts
+	factsParams := map[string]any{"ns": ns}
 	categoryFilter := ""
 	if opts.Category != "" {
-		categoryFilter = fmt.Sprintf(`, category = '%s'`, escapeDatalog(opts.Category))
+		categoryFilter = `, category = $category`
+		factsParams["category"] = opts.Category
 	}
 
 	factsQuery := fmt.Sprintf(
 		`?[id, content, category, confidence, source_agent, source_conversation, created_at, updated_at] :=
-    *mie_fact { id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at },
-    valid = true%s`, categoryFilter,
+    *mie_fact { id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at, namespace },
+    valid = true,
+    namespace = $ns%s`, categoryFilter,
 	)
 
-	qr, err := cd.backend.Query(ctx, factsQuery)
+	qr, err := cd.backend.Query(ctx, factsQuery, factsParams)
 	if err != nil {
 		return nil, fmt.Errorf("query facts: %w", err)
 	}
@@ -66,7 +76,12 @@ func (cd *ConflictDetector) DetectConflicts(ctx context.Context, opts tools.Conf
 		return nil, nil // Need at least 2 facts to find conflicts
 	}
 
-	// For each fact, find its nearest neighbors via HNSW
+	negated, err := cd.negatedFacts(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	// For each fact, find its nearest neighbors
 	var conflicts []tools.Conflict
 	seen := make(map[string]bool) // Track pairs to avoid duplicates
 
@@ -81,25 +96,30 @@ func (cd *ConflictDetector) DetectConflicts(ctx context.Context, opts tools.Conf
 			continue
 		}
 
-		vecStr := formatVector(queryEmb)
+		params := map[string]any{"ns": ns, "fact_id": factID, "threshold": threshold}
+		nearest, err := nearestAtom(ctx, cd.vectors, "fact", queryEmb, 10, params)
+		if err != nil {
+			cd.logger.Warn("neighbor search failed", "fact_id", factID, "error", err)
+			continue
+		}
 
 		// Search for nearest neighbors
 		script := fmt.Sprintf(
 			`?[neighbor_id, content, category, confidence, source_agent, source_conversation, created_at, updated_at, distance] :=
-    ~mie_fact_embedding:fact_embedding_idx { fact_id | query: q, k: 10, ef: 200, bind_distance: distance },
-    q = vec(%s),
-    *mie_fact { id: fact_id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at },
+    %s,
+    *mie_fact { id: fact_id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at, namespace },
     valid = true,
+    namespace = $ns,
     neighbor_id = fact_id,
-    neighbor_id != '%s',
-    distance < %f
+    neighbor_id != $fact_id,
+    distance < $threshold
     :order distance
-    :limit 5`, vecStr, escapeDatalog(factID), threshold,
+    :limit 5`, nearest,
 		)
 
-		neighbors, err := cd.backend.Query(ctx, script)
+		neighbors, err := cd.backend.Query(ctx, script, params)
 		if err != nil {
-			cd.logger.Warn("hnsw neighbor search failed", "fact_id", factID, "error", err)
+			cd.logger.Warn("neighbor search failed", "fact_id", factID, "error", err)
 			continue
 		}
 
@@ -113,6 +133,7 @@ func (cd *ConflictDetector) DetectConflicts(ctx context.Context, opts tools.Conf
 			Valid:              true,
 			CreatedAt:          toInt64(row[6]),
 			UpdatedAt:          toInt64(row[7]),
+			Negated:            negated[factID],
 		}
 
 		for _, nRow := range neighbors.Rows {
@@ -139,13 +160,16 @@ func (cd *ConflictDetector) DetectConflicts(ctx context.Context, opts tools.Conf
 				Valid:              true,
 				CreatedAt:          toInt64(nRow[6]),
 				UpdatedAt:          toInt64(nRow[7]),
+				Negated:            negated[neighborID],
 			}
 
-			conflicts = append(conflicts, tools.Conflict{
+			conflict := tools.Conflict{
 				FactA:      factA,
 				FactB:      factB,
 				Similarity: similarity,
-			})
+			}
+			explainConflict(&conflict)
+			conflicts = append(conflicts, conflict)
 		}
 
 		if len(conflicts) >= limit {
@@ -181,32 +205,45 @@ func (cd *ConflictDetector) CheckNewFactConflicts(ctx context.Context, content,
 		return nil, fmt.Errorf("generate query embedding: %w", err)
 	}
 
-	vecStr := formatVector(queryEmb)
-	threshold := 0.15 // cosine distance threshold
+	ns := resolveNamespace(ctx, cd.namespace)
+	params := map[string]any{
+		"ns":        ns,
+		"threshold": 0.15, // cosine distance threshold
+	}
+	nearest, err := nearestAtom(ctx, cd.vectors, "fact", queryEmb, 10, params)
+	if err != nil {
+		return nil, fmt.Errorf("check conflicts: %w", err)
+	}
 
 	categoryFilter := ""
 	if category != "" {
-		categoryFilter = fmt.Sprintf(`,
-    category = '%s'`, escapeDatalog(category))
+		categoryFilter = `,
+    category = $category`
+		params["category"] = category
 	}
 
 	script := fmt.Sprintf(
 		`?[id, fact_content, category, confidence, source_agent, source_conversation, created_at, updated_at, distance] :=
-    ~mie_fact_embedding:fact_embedding_idx { fact_id | query: q, k: 10, ef: 200, bind_distance: distance },
-    q = vec(%s),
-    *mie_fact { id: fact_id, content: fact_content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at },
+    %s,
+    *mie_fact { id: fact_id, content: fact_content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at, namespace },
     valid = true,
+    namespace = $ns,
     id = fact_id,
-    distance < %f%s
+    distance < $threshold%s
     :order distance
-    :limit 10`, vecStr, threshold, categoryFilter,
+    :limit 10`, nearest, categoryFilter,
 	)
 
-	qr, err := cd.backend.Query(ctx, script)
+	qr, err := cd.backend.Query(ctx, script, params)
 	if err != nil {
 		return nil, fmt.Errorf("check conflicts: %w", err)
 	}
 
+	negated, err := cd.negatedFacts(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+
 	proposedFact := tools.Fact{
 		Content:  content,
 		Category: category,
@@ -228,13 +265,16 @@ func (cd *ConflictDetector) CheckNewFactConflicts(ctx context.Context, content,
 			Valid:              true,
 			CreatedAt:          toInt64(row[6]),
 			UpdatedAt:          toInt64(row[7]),
+			Negated:            negated[toString(row[0])],
 		}
 
-		conflicts = append(conflicts, tools.Conflict{
+		conflict := tools.Conflict{
 			FactA:      proposedFact,
 			FactB:      existingFact,
 			Similarity: similarity,
-		})
+		}
+		explainConflict(&conflict)
+		conflicts = append(conflicts, conflict)
 	}
 
 	return conflicts, nil
@@ -88,12 +88,11 @@ func storeEmbeddingSync(t *testing.T, backend *storage.EmbeddedBackend, embedder
 	if err != nil {
 		t.Fatalf("generate embedding: %v", err)
 	}
-	vecStr := formatVector(embedding)
 	mutation := fmt.Sprintf(
-		`?[%s, embedding] <- [["%s", vec(%s)]] :put %s { %s => embedding }`,
-		idCol, escapeDatalog(nodeID), vecStr, table, idCol,
+		`?[%s, embedding] <- [[$id, vec($embedding)]] :put %s { %s => embedding }`,
+		idCol, table, idCol,
 	)
-	if err := backend.Execute(ctx, mutation); err != nil {
+	if err := backend.Execute(ctx, mutation, map[string]any{"id": nodeID, "embedding": embedding}); err != nil {
 		t.Fatalf("store embedding: %v", err)
 	}
 }
\ No newline at end of file
@@ -0,0 +1,162 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// dedupeNode is an entity or topic compared by FindDuplicates.
+type dedupeNode struct {
+	member    tools.DuplicateMember
+	names     []string
+	embedding []float32
+}
+
+// FindDuplicates clusters the entities and topics of the namespace that
+// likely name the same thing. Two nodes are paired when their score, from
+// the similarity of their names and aliases and, where both have one, of
+// their embeddings, reaches opts.Threshold; paired nodes form a cluster.
+// Entities and topics are never paired with each other. Archived nodes are
+// left out unless ctx asks for them with tools.WithIncludeArchived.
+func (r *Reader) FindDuplicates(ctx context.Context, opts tools.DuplicateOptions) ([]tools.DuplicateCluster, error) {
+	threshold := opts.Threshold
+	if threshold <= 0 {
+		threshold = DefaultDuplicateThreshold
+	}
+	if threshold > 1 {
+		return nil, fmt.Errorf("invalid threshold %v (must be between 0 and 1)", threshold)
+	}
+	nodeTypes := opts.NodeTypes
+	if len(nodeTypes) == 0 {
+		nodeTypes = []string{"entity", "topic"}
+	}
+
+	var clusters []tools.DuplicateCluster
+	for _, nt := range nodeTypes {
+		if nt != "entity" && nt != "topic" {
+			return nil, fmt.Errorf("invalid node type %q: duplicates are found among entities and topics", nt)
+		}
+		nodes, err := r.dedupeNodes(ctx, nt)
+		if err != nil {
+			return nil, err
+		}
+
+		var pairs []tools.DuplicatePair
+		members := make([]tools.DuplicateMember, len(nodes))
+		for i, a := range nodes {
+			members[i] = a.member
+			for _, b := range nodes[i+1:] {
+				p := tools.DuplicatePair{A: a.member.ID, B: b.member.ID, NameSimilarity: namesSimilarity(a.names, b.names)}
+				hasEmbeddings := a.embedding != nil && b.embedding != nil
+				if hasEmbeddings {
+					p.EmbeddingSimilarity = 1 - cosineDistance(a.embedding, b.embedding)
+				}
+				if p.Score = duplicateScore(p.NameSimilarity, p.EmbeddingSimilarity, hasEmbeddings); p.Score >= threshold {
+					pairs = append(pairs, p)
+				}
+			}
+		}
+		clusters = append(clusters, clusterDuplicates(nt, members, pairs)...)
+	}
+
+	sort.SliceStable(clusters, func(i, j int) bool { return clusters[i].Score > clusters[j].Score })
+	if opts.Limit > 0 && len(clusters) > opts.Limit {
+		clusters = clusters[:opts.Limit]
+	}
+	return clusters, nil
+}
+
+// dedupeNodes reads the entities or topics of the namespace with their
+// names, aliases, relationship counts, and embeddings. Entities use their
+// stored embeddings; topics are embedded from their name and description
+// as in SuggestTopics.
+func (r *Reader) dedupeNodes(ctx context.Context, nodeType string) ([]dedupeNode, error) {
+	ns := resolveNamespace(ctx, r.namespace)
+	params := map[string]any{"ns": ns}
+	var script string
+	if nodeType == "entity" {
+		script = `?[id, name, kind, created_at, updated_at] := *mie_entity { id, name, kind, created_at, updated_at, namespace }, namespace = $ns`
+	} else {
+		script = `?[id, name, description, created_at, updated_at] := *mie_topic { id, name, description, created_at, updated_at, namespace }, namespace = $ns`
+	}
+	qr, err := r.backend.Query(ctx, script+archivedFilter(ctx, "id")+"\n:order id", params)
+	if err != nil {
+		return nil, fmt.Errorf("list %s nodes: %w", nodeType, err)
+	}
+	nodes := make([]dedupeNode, 0, len(qr.Rows))
+	index := make(map[string]int, len(qr.Rows))
+	for _, row := range qr.Rows {
+		n := dedupeNode{member: tools.DuplicateMember{ID: toString(row[0]), Name: toString(row[1]), CreatedAt: toInt64(row[3])}}
+		n.names = []string{n.member.Name}
+		if nodeType == "entity" {
+			n.member.Kind = toString(row[2])
+		} else if r.embedder != nil {
+			vec, err := r.topicEmbedding(ctx, tools.TopicSuggestion{TopicID: n.member.ID, Name: n.member.Name, Description: toString(row[2])}, toInt64(row[4]))
+			if err != nil {
+				return nil, err
+			}
+			n.embedding = vec
+		}
+		index[n.member.ID] = len(nodes)
+		nodes = append(nodes, n)
+	}
+	if len(nodes) < 2 {
+		return nodes, nil
+	}
+
+	var rules []string
+	for _, table := range sortedEdgeTables() {
+		keyCols := ValidEdgeTables[table]
+		for i, nt := range edgeTableEndpoints[table] {
+			if nt == nodeType {
+				rules = append(rules, fmt.Sprintf(`edge[id, other] := *%s { %s: id, %s: other }`, table, keyCols[i], keyCols[1-i]))
+			}
+		}
+	}
+	if len(rules) > 0 {
+		qr, err = r.backend.Query(ctx, strings.Join(rules, "\n")+"\n?[id, count(other)] := edge[id, other]", nil)
+		if err != nil {
+			return nil, fmt.Errorf("count %s relationships: %w", nodeType, err)
+		}
+		for _, row := range qr.Rows {
+			if i, ok := index[toString(row[0])]; ok {
+				nodes[i].member.Edges = toInt(row[1])
+			}
+		}
+	}
+
+	if nodeType != "entity" {
+		return nodes, nil
+	}
+	qr, err = r.backend.Query(ctx, `?[entity_id, alias] := *mie_entity_alias { alias, namespace, entity_id }, namespace = $ns
+:order alias`, params)
+	if err != nil {
+		return nil, fmt.Errorf("list entity aliases: %w", err)
+	}
+	for _, row := range qr.Rows {
+		if i, ok := index[toString(row[0])]; ok {
+			nodes[i].member.Aliases = append(nodes[i].member.Aliases, toString(row[1]))
+			nodes[i].names = append(nodes[i].names, toString(row[1]))
+		}
+	}
+	qr, err = r.backend.Query(ctx, `?[id, embedding] := *mie_entity { id, namespace }, namespace = $ns, *mie_entity_embedding { entity_id: id, embedding }`, params)
+	if err != nil {
+		return nil, fmt.Errorf("read entity embeddings: %w", err)
+	}
+	for _, row := range qr.Rows {
+		if i, ok := index[toString(row[0])]; ok {
+			nodes[i].embedding = toVector(row[1])
+		}
+	}
+	return nodes, nil
+}
@@ -0,0 +1,197 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package memory
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// DefaultDuplicateThreshold is the score from which two entities or topics
+// are reported as likely duplicates.
+const DefaultDuplicateThreshold = 0.8
+
+// nameTokens returns the lowercased words of a name.
+func nameTokens(name string) []string {
+	return strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// nameSimilarity compares two names from 0 to 1, ignoring case,
+// punctuation, and word order. It is the higher of the edit similarity of
+// the names without separators ("Postgres" and "PostgreSQL" score 0.8) and
+// the share of words they have in common ("Smith, Alice" and "Alice Smith"
+// score 1).
+func nameSimilarity(a, b string) float64 {
+	ta, tb := nameTokens(a), nameTokens(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	ra, rb := []rune(strings.Join(ta, "")), []rune(strings.Join(tb, ""))
+	edit := 1 - float64(levenshtein(ra, rb))/float64(max(len(ra), len(rb)))
+
+	words := make(map[string]bool, len(ta))
+	for _, w := range ta {
+		words[w] = true
+	}
+	union, shared := len(words), 0
+	seen := make(map[string]bool, len(tb))
+	for _, w := range tb {
+		if seen[w] {
+			continue
+		}
+		seen[w] = true
+		if words[w] {
+			shared++
+		} else {
+			union++
+		}
+	}
+	return max(edit, float64(shared)/float64(union))
+}
+
+// namesSimilarity returns the best nameSimilarity of any name of a with any
+// name of b, so an alias of one entity matches the name of another.
+func namesSimilarity(a, b []string) float64 {
+	best := 0.0
+	for _, x := range a {
+		for _, y := range b {
+			best = max(best, nameSimilarity(x, y))
+		}
+	}
+	return best
+}
+
+// levenshtein returns the edit distance of a and b.
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j-1] + cost
+			if d := prev[j] + 1; d < cur[j] {
+				cur[j] = d
+			}
+			if d := cur[j-1] + 1; d < cur[j] {
+				cur[j] = d
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// duplicateScore combines the similarities of a pair as DuplicatePair.Score
+// describes. Embeddings can raise the score of a pair, not lower it, since
+// they tell little about short names.
+func duplicateScore(nameSim, embeddingSim float64, hasEmbeddings bool) float64 {
+	if !hasEmbeddings {
+		return nameSim
+	}
+	return max(nameSim, (nameSim+embeddingSim)/2)
+}
+
+// clusterDuplicates groups the members linked by pairs into clusters, each
+// with its survivor first and its pairs best first. Clusters are sorted by
+// score, best first.
+func clusterDuplicates(nodeType string, members []tools.DuplicateMember, pairs []tools.DuplicatePair) []tools.DuplicateCluster {
+	parent := make(map[string]string, len(members))
+	var find func(id string) string
+	find = func(id string) string {
+		if p, ok := parent[id]; ok && p != id {
+			parent[id] = find(p)
+			return parent[id]
+		}
+		return id
+	}
+	for _, p := range pairs {
+		if ra, rb := find(p.A), find(p.B); ra != rb {
+			parent[rb] = ra
+		}
+	}
+
+	byRoot := map[string]*tools.DuplicateCluster{}
+	var roots []string
+	for _, p := range pairs {
+		root := find(p.A)
+		c, ok := byRoot[root]
+		if !ok {
+			c = &tools.DuplicateCluster{NodeType: nodeType}
+			byRoot[root] = c
+			roots = append(roots, root)
+		}
+		c.Pairs = append(c.Pairs, p)
+		c.Score = max(c.Score, p.Score)
+	}
+	for _, m := range members {
+		if c, ok := byRoot[find(m.ID)]; ok && len(c.Pairs) > 0 {
+			c.Members = append(c.Members, m)
+		}
+	}
+
+	clusters := make([]tools.DuplicateCluster, 0, len(roots))
+	for _, root := range roots {
+		c := byRoot[root]
+		sort.SliceStable(c.Members, func(i, j int) bool {
+			if c.Members[i].Edges != c.Members[j].Edges {
+				return c.Members[i].Edges > c.Members[j].Edges
+			}
+			return c.Members[i].CreatedAt < c.Members[j].CreatedAt
+		})
+		sort.SliceStable(c.Pairs, func(i, j int) bool { return c.Pairs[i].Score > c.Pairs[j].Score })
+		clusters = append(clusters, *c)
+	}
+	sort.SliceStable(clusters, func(i, j int) bool {
+		if clusters[i].Score != clusters[j].Score {
+			return clusters[i].Score > clusters[j].Score
+		}
+		return clusters[i].Members[0].Name < clusters[j].Members[0].Name
+	})
+	return clusters
+}
+
+// ClusterByName groups members that likely name the same thing as
+// Reader.FindDuplicates does, but from the similarity of their names and
+// aliases alone, for graphs without embeddings. Pairs below threshold are
+// dropped.
+func ClusterByName(nodeType string, members []tools.DuplicateMember, threshold float64) []tools.DuplicateCluster {
+	var pairs []tools.DuplicatePair
+	for i, a := range members {
+		for _, b := range members[i+1:] {
+			sim := namesSimilarity(append([]string{a.Name}, a.Aliases...), append([]string{b.Name}, b.Aliases...))
+			if sim >= threshold {
+				pairs = append(pairs, tools.DuplicatePair{A: a.ID, B: b.ID, NameSimilarity: sim, Score: sim})
+			}
+		}
+	}
+	return clusterDuplicates(nodeType, members, pairs)
+}
+
+// BestNameMatch returns the member whose name or one of whose aliases is
+// most similar to name, for lookups that tolerate typos and spelling
+// variants. Members below threshold never match; ties go to the member
+// listed first.
+func BestNameMatch(name string, members []tools.DuplicateMember, threshold float64) (tools.DuplicateMember, bool) {
+	var best tools.DuplicateMember
+	bestSim := 0.0
+	for _, m := range members {
+		if sim := namesSimilarity([]string{name}, append([]string{m.Name}, m.Aliases...)); sim >= threshold && sim > bestSim {
+			best, bestSim = m, sim
+		}
+	}
+	return best, bestSim > 0
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package memory
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestNameSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"Postgres", "postgres", 1},
+		{"Postgres", "PostgreSQL", 0.8},
+		{"Smith, Alice", "Alice Smith", 1},
+		{"API-Gateway", "api gateway", 1},
+		{"billing service", "billing", 0.5},
+		{"Go", "Rust", 0},
+		{"", "Rust", 0},
+	}
+	for _, tt := range tests {
+		if got := nameSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("nameSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+	if got := namesSimilarity([]string{"Kubernetes", "k8s"}, []string{"K8s"}); got != 1 {
+		t.Errorf("namesSimilarity with a matching alias = %v, want 1", got)
+	}
+}
+
+func TestDuplicateScore(t *testing.T) {
+	if got := duplicateScore(0.6, 0.9, false); got != 0.6 {
+		t.Errorf("score without embeddings = %v, want the name similarity", got)
+	}
+	if got := duplicateScore(0.6, 0.9, true); math.Abs(got-0.75) > 1e-9 {
+		t.Errorf("score with embeddings = %v, want 0.75", got)
+	}
+	if got := duplicateScore(1, 0.4, true); got != 1 {
+		t.Errorf("score of identical names = %v, embeddings must not lower it", got)
+	}
+}
+
+func TestClusterDuplicates(t *testing.T) {
+	members := []tools.DuplicateMember{
+		{ID: "ent:a", Name: "Postgres", Edges: 1, CreatedAt: 10},
+		{ID: "ent:b", Name: "PostgreSQL", Edges: 4, CreatedAt: 20},
+		{ID: "ent:c", Name: "postgres db", Edges: 1, CreatedAt: 5},
+		{ID: "ent:d", Name: "Redis", Edges: 2, CreatedAt: 1},
+		{ID: "ent:e", Name: "Alice Smith"},
+		{ID: "ent:f", Name: "Smith, Alice"},
+	}
+	pairs := []tools.DuplicatePair{
+		{A: "ent:a", B: "ent:b", Score: 0.8},
+		{A: "ent:e", B: "ent:f", Score: 1},
+		{A: "ent:c", B: "ent:a", Score: 0.85},
+	}
+	clusters := clusterDuplicates("entity", members, pairs)
+	if len(clusters) != 2 {
+		t.Fatalf("clusters = %+v, want 2", clusters)
+	}
+	if clusters[0].Score != 1 || len(clusters[0].Members) != 2 {
+		t.Errorf("best cluster = %+v, want Alice Smith", clusters[0])
+	}
+	postgres := clusters[1]
+	var ids []string
+	for _, m := range postgres.Members {
+		ids = append(ids, m.ID)
+	}
+	// The most connected member survives, then the oldest.
+	if len(ids) != 3 || ids[0] != "ent:b" || ids[1] != "ent:c" || ids[2] != "ent:a" {
+		t.Errorf("members = %v, want ent:b, ent:c, ent:a", ids)
+	}
+	if postgres.NodeType != "entity" || postgres.Score != 0.85 || postgres.Pairs[0].Score != 0.85 {
+		t.Errorf("cluster = %+v, want pairs best first", postgres)
+	}
+}
+
+func TestClusterByName(t *testing.T) {
+	members := []tools.DuplicateMember{
+		{ID: "ent:a", Name: "Kubernetes", Aliases: []string{"k8s"}},
+		{ID: "ent:b", Name: "K8s", Edges: 2},
+		{ID: "ent:c", Name: "Redis"},
+	}
+	clusters := ClusterByName("entity", members, DefaultDuplicateThreshold)
+	if len(clusters) != 1 || len(clusters[0].Members) != 2 {
+		t.Fatalf("clusters = %+v, want Kubernetes and K8s", clusters)
+	}
+	if clusters[0].Members[0].ID != "ent:b" || clusters[0].Pairs[0].Score != 1 {
+		t.Errorf("cluster = %+v, want ent:b first and a matching alias scoring 1", clusters[0])
+	}
+	if got := ClusterByName("entity", members, 1.01); len(got) != 0 {
+		t.Errorf("clusters above any score = %+v, want none", got)
+	}
+}
+
+func TestBestNameMatch(t *testing.T) {
+	members := []tools.DuplicateMember{
+		{ID: "ent:pg", Name: "PostgreSQL", Aliases: []string{"pg"}},
+		{ID: "ent:redis", Name: "Redis"},
+	}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Postgre SQL", "ent:pg"},
+		{"postgressql", "ent:pg"},
+		{"PG", "ent:pg"},
+		{"Rediss", "ent:redis"},
+		{"MySQL", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		m, ok := BestNameMatch(tt.name, members, DefaultDuplicateThreshold)
+		if got := m.ID; ok != (tt.want != "") || got != tt.want {
+			t.Errorf("BestNameMatch(%q) = %q, %v; want %q", tt.name, got, ok, tt.want)
+		}
+	}
+}
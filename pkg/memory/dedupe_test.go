@@ -0,0 +1,73 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestClientFindDuplicates(t *testing.T) {
+	client := setupIntegrationClient(t, false)
+	ctx := context.Background()
+
+	store := func(name, kind string) string {
+		ent, err := client.StoreEntity(ctx, tools.StoreEntityRequest{Name: name, Kind: kind})
+		require.NoError(t, err)
+		return ent.ID
+	}
+	postgres := store("Postgres", "technology")
+	postgreSQL := store("PostgreSQL", "technology")
+	store("Redis", "technology")
+	kubernetes := store("Kubernetes", "technology")
+	k8s := store("K8S", "project")
+	require.NoError(t, client.AddAlias(ctx, kubernetes, "k8s"))
+	fact, err := client.StoreFact(ctx, tools.StoreFactRequest{Content: "Billing runs on PostgreSQL", Category: "technical"})
+	require.NoError(t, err)
+	require.NoError(t, client.AddRelationship(ctx, "mie_fact_entity", map[string]string{"fact_id": fact.ID, "entity_id": postgreSQL}))
+	for _, name := range []string{"on-call", "oncall", "hiring"} {
+		_, err := client.StoreTopic(ctx, tools.StoreTopicRequest{Name: name})
+		require.NoError(t, err)
+	}
+
+	clusters, err := client.FindDuplicates(ctx, tools.DuplicateOptions{})
+	require.NoError(t, err)
+	require.Len(t, clusters, 3, "%+v", clusters)
+
+	byType := map[string][]tools.DuplicateCluster{}
+	for _, c := range clusters {
+		byType[c.NodeType] = append(byType[c.NodeType], c)
+	}
+	require.Len(t, byType["entity"], 2)
+	require.Len(t, byType["topic"], 1)
+	assert.Equal(t, 1.0, byType["topic"][0].Score)
+
+	aliased := byType["entity"][0]
+	assert.Equal(t, 1.0, aliased.Score, "the alias k8s matches K8S")
+	assert.ElementsMatch(t, []string{kubernetes, k8s}, []string{aliased.Members[0].ID, aliased.Members[1].ID})
+
+	pg := byType["entity"][1]
+	require.Len(t, pg.Members, 2)
+	assert.Equal(t, postgreSQL, pg.Members[0].ID, "the entity with relationships survives")
+	assert.Equal(t, 1, pg.Members[0].Edges)
+	assert.Equal(t, postgres, pg.Members[1].ID)
+	assert.InDelta(t, 0.8, pg.Score, 1e-9)
+	assert.Zero(t, pg.Pairs[0].EmbeddingSimilarity)
+
+	strict, err := client.FindDuplicates(ctx, tools.DuplicateOptions{NodeTypes: []string{"entity"}, Threshold: 0.9, Limit: 5})
+	require.NoError(t, err)
+	require.Len(t, strict, 1)
+	assert.Equal(t, "entity", strict[0].NodeType)
+
+	_, err = client.FindDuplicates(ctx, tools.DuplicateOptions{NodeTypes: []string{"fact"}})
+	assert.Error(t, err)
+}
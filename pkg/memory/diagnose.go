@@ -0,0 +1,200 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/kraklabs/mie/pkg/storage"
+)
+
+// createTablePattern extracts the table name from a :create statement.
+var createTablePattern = regexp.MustCompile(`^:create (\w+)`)
+
+// vectorDimPattern extracts the dimension from a column type like "<F32;768>".
+var vectorDimPattern = regexp.MustCompile(`;\s*(\d+)\s*>`)
+
+// Diagnosis is a read-only inspection of a MIE database, used by mie doctor.
+// Unlike NewClient, Diagnose never creates tables or indexes or migrates
+// data, so it reports the database as it is on disk.
+type Diagnosis struct {
+	// SchemaVersion is the version recorded in mie_meta, or 0 if none.
+	SchemaVersion int
+	// MissingTables lists schema tables that do not exist yet.
+	MissingTables []string
+	// MissingHNSWIndexes lists "table:index" names of absent HNSW indexes.
+	MissingHNSWIndexes []string
+	// EmbeddingDimensions is the vector size of the embedding tables, or 0
+	// if it could not be read.
+	EmbeddingDimensions int
+	// OrphanedEdges counts edges per edge table whose source or target node
+	// no longer exists. Tables without orphans are omitted.
+	OrphanedEdges map[string]int
+}
+
+// Diagnose inspects the database behind backend without modifying it.
+func Diagnose(ctx context.Context, backend storage.Backend) (*Diagnosis, error) {
+	relations, err := backend.Query(ctx, `::relations`, nil)
+	if err != nil {
+		return nil, fmt.Errorf("list relations: %w", err)
+	}
+	existing := map[string]bool{}
+	for _, row := range relations.Rows {
+		existing[toString(row[0])] = true
+	}
+
+	d := &Diagnosis{
+		SchemaVersion: readSchemaVersion(ctx, backend),
+		OrphanedEdges: countOrphanedEdges(ctx, backend, existing),
+	}
+
+	for _, table := range schemaTableNames() {
+		if !existing[table] {
+			d.MissingTables = append(d.MissingTables, table)
+		}
+	}
+
+	for _, nt := range []string{"fact", "decision", "entity", "event"} {
+		table := nodeTypeToEmbeddingTable(nt)
+		if !existing[table] {
+			continue
+		}
+		index := nodeTypeToHNSWIndex(nt)
+		found, err := hasIndex(ctx, backend, table, index)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			d.MissingHNSWIndexes = append(d.MissingHNSWIndexes, table+":"+index)
+		}
+		if d.EmbeddingDimensions == 0 {
+			d.EmbeddingDimensions = vectorDimensions(ctx, backend, table)
+		}
+	}
+
+	return d, nil
+}
+
+// RemoveOrphanedEdges deletes edges whose source or target node no longer
+// exists and returns the number of edges removed.
+func RemoveOrphanedEdges(ctx context.Context, backend storage.Backend) (int, error) {
+	removed := 0
+	for _, table := range sortedEdgeTables() {
+		count, err := backend.Query(ctx, orphanedEdgesRule(table)+"\n?[count(k0)] := orphan[k0, k1]", nil)
+		if err != nil {
+			return removed, fmt.Errorf("count orphaned %s edges: %w", table, err)
+		}
+		if len(count.Rows) == 0 || toInt(count.Rows[0][0]) == 0 {
+			continue
+		}
+
+		keyCols := ValidEdgeTables[table]
+		script := orphanedEdgesRule(table) + fmt.Sprintf(`
+?[%[1]s, %[2]s] := orphan[%[1]s, %[2]s]
+:rm %[3]s { %[1]s, %[2]s }`, keyCols[0], keyCols[1], table)
+		if err := backend.Execute(ctx, script, nil); err != nil {
+			return removed, fmt.Errorf("remove orphaned %s edges: %w", table, err)
+		}
+		removed += toInt(count.Rows[0][0])
+	}
+	return removed, nil
+}
+
+// countOrphanedEdges returns the number of orphaned edges per edge table,
+// skipping tables that do not exist.
+func countOrphanedEdges(ctx context.Context, backend storage.Backend, existing map[string]bool) map[string]int {
+	counts := map[string]int{}
+	for _, table := range sortedEdgeTables() {
+		if !existing[table] {
+			continue
+		}
+		result, err := backend.Query(ctx, orphanedEdgesRule(table)+"\n?[count(k0)] := orphan[k0, k1]", nil)
+		if err != nil || len(result.Rows) == 0 {
+			continue
+		}
+		if n := toInt(result.Rows[0][0]); n > 0 {
+			counts[table] = n
+		}
+	}
+	return counts
+}
+
+// orphanedEdgesRule defines orphan[k0, k1] as the keys of edges in table
+// whose source or target node is missing.
+func orphanedEdgesRule(table string) string {
+	keyCols := ValidEdgeTables[table]
+	endpoints := edgeTableEndpoints[table]
+	return fmt.Sprintf(`orphan[k0, k1] := *%[1]s { %[2]s: k0, %[3]s: k1 }, not *%[4]s { id: k0 }
+orphan[k0, k1] := *%[1]s { %[2]s: k0, %[3]s: k1 }, not *%[5]s { id: k1 }`,
+		table, keyCols[0], keyCols[1], nodeTypeToTable(endpoints[0]), nodeTypeToTable(endpoints[1]))
+}
+
+// hasIndex reports whether table has an index named index.
+func hasIndex(ctx context.Context, backend storage.Backend, table, index string) (bool, error) {
+	result, err := backend.Query(ctx, fmt.Sprintf(`::indices %s`, table), nil)
+	if err != nil {
+		return false, fmt.Errorf("list indices of %s: %w", table, err)
+	}
+	for _, row := range result.Rows {
+		if len(row) > 0 && toString(row[0]) == index {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// vectorDimensions returns the size of the embedding column of table, or 0.
+func vectorDimensions(ctx context.Context, backend storage.Backend, table string) int {
+	result, err := backend.Query(ctx, fmt.Sprintf(`::columns %s`, table), nil)
+	if err != nil {
+		return 0
+	}
+	typeCol := -1
+	for i, h := range result.Headers {
+		if h == "type" {
+			typeCol = i
+		}
+	}
+	if typeCol < 0 {
+		return 0
+	}
+	for _, row := range result.Rows {
+		if toString(row[0]) != "embedding" {
+			continue
+		}
+		if m := vectorDimPattern.FindStringSubmatch(toString(row[typeCol])); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			return n
+		}
+	}
+	return 0
+}
+
+// schemaTableNames returns the names of the tables created by SchemaStatements.
+func schemaTableNames() []string {
+	var names []string
+	for _, stmt := range SchemaStatements(1) {
+		if m := createTablePattern.FindStringSubmatch(stmt); m != nil {
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
+// sortedEdgeTables returns the ValidEdgeTables names in a stable order.
+func sortedEdgeTables() []string {
+	tables := make([]string, 0, len(ValidEdgeTables))
+	for table := range ValidEdgeTables {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	return tables
+}
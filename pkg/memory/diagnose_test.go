@@ -0,0 +1,101 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestDiagnose(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	ctx := context.Background()
+
+	// An empty database has no schema yet.
+	d, err := Diagnose(ctx, backend)
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+	if d.SchemaVersion != 0 || len(d.MissingTables) == 0 {
+		t.Errorf("expected an uninitialized database, got %+v", d)
+	}
+
+	setupSchema(t, backend)
+	d, err = Diagnose(ctx, backend)
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+	if d.SchemaVersion != SchemaVersion {
+		t.Errorf("SchemaVersion = %d, want %d", d.SchemaVersion, SchemaVersion)
+	}
+	if len(d.MissingTables) != 0 {
+		t.Errorf("unexpected missing tables: %v", d.MissingTables)
+	}
+	if len(d.MissingHNSWIndexes) != 4 {
+		t.Errorf("expected all HNSW indexes missing, got %v", d.MissingHNSWIndexes)
+	}
+	if d.EmbeddingDimensions != 384 {
+		t.Errorf("EmbeddingDimensions = %d, want 384", d.EmbeddingDimensions)
+	}
+
+	if err := EnsureHNSWIndexes(backend, 384); err != nil {
+		t.Fatalf("EnsureHNSWIndexes failed: %v", err)
+	}
+	d, _ = Diagnose(ctx, backend)
+	if len(d.MissingHNSWIndexes) != 0 {
+		t.Errorf("unexpected missing HNSW indexes: %v", d.MissingHNSWIndexes)
+	}
+}
+
+func TestRemoveOrphanedEdges(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	ctx := context.Background()
+
+	fact, _ := w.StoreFact(ctx, tools.StoreFactRequest{Content: "Uses Go", Category: "technical"})
+	entity, _ := w.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Go", Kind: "technology"})
+	if err := w.AddRelationship(ctx, "mie_fact_entity", map[string]string{"fact_id": fact.ID, "entity_id": entity.ID}); err != nil {
+		t.Fatalf("AddRelationship failed: %v", err)
+	}
+	if err := backend.Execute(ctx, `?[fact_id, entity_id] <- [['fact:gone', 'ent:gone'], ['fact:gone2', '`+entity.ID+`']] :put mie_fact_entity { fact_id, entity_id }`, nil); err != nil {
+		t.Fatalf("insert orphaned edges: %v", err)
+	}
+
+	d, err := Diagnose(ctx, backend)
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+	if d.OrphanedEdges["mie_fact_entity"] != 2 {
+		t.Errorf("orphaned edges = %v, want 2 in mie_fact_entity", d.OrphanedEdges)
+	}
+
+	removed, err := RemoveOrphanedEdges(ctx, backend)
+	if err != nil {
+		t.Fatalf("RemoveOrphanedEdges failed: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("removed = %d, want 2", removed)
+	}
+
+	d, _ = Diagnose(ctx, backend)
+	if len(d.OrphanedEdges) != 0 {
+		t.Errorf("orphaned edges remain: %v", d.OrphanedEdges)
+	}
+	edges, err := backend.Query(ctx, `?[fact_id] := *mie_fact_entity { fact_id }`, nil)
+	if err != nil {
+		t.Fatalf("query edges: %v", err)
+	}
+	if len(edges.Rows) != 1 {
+		t.Errorf("expected the valid edge to survive, got %v", edges.Rows)
+	}
+}
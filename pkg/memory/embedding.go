@@ -12,7 +12,10 @@ import (
 	"io"
 	"log/slog"
 	"math"
+	"net"
 	"net/http"
+	"os/exec"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -253,6 +256,270 @@ func (o *OllamaEmbeddingProvider) embed(ctx context.Context, prompt string) ([]f
 	return normalizeEmbedding(embedding), nil
 }
 
+// =============================================================================
+// LOCAL (LLAMA.CPP) EMBEDDING PROVIDER
+// =============================================================================
+
+// LocalEmbeddingProvider generates embeddings fully offline with a GGUF model
+// served by llama.cpp's llama-server in embedding mode. No API key or
+// network access is needed. The server is started on 127.0.0.1 at the first
+// call and kept running, so the model is loaded once rather than per
+// embedding; it is started again if it exits. Close stops it.
+type LocalEmbeddingProvider struct {
+	binary       string
+	modelPath    string
+	httpClient   *http.Client
+	logger       *slog.Logger
+	startTimeout time.Duration
+
+	mu      sync.Mutex
+	server  *exec.Cmd
+	exited  chan struct{} // closed when server exits
+	baseURL string
+}
+
+type localEmbedRequest struct {
+	Input string `json:"input"`
+}
+
+type localEmbedResponse struct {
+	Data []struct {
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+}
+
+// localServerStartTimeout bounds how long llama-server may take to load the
+// model and report healthy.
+const localServerStartTimeout = 2 * time.Minute
+
+// NewLocalEmbeddingProvider creates a provider that serves modelPath (a GGUF
+// file) with the llama-server executable at binary.
+func NewLocalEmbeddingProvider(binary, modelPath string, logger *slog.Logger) *LocalEmbeddingProvider {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &LocalEmbeddingProvider{
+		binary:    binary,
+		modelPath: modelPath,
+		httpClient: &http.Client{
+			Timeout: 120 * time.Second,
+		},
+		logger:       logger,
+		startTimeout: localServerStartTimeout,
+	}
+}
+
+// Embed generates an embedding for document text using the local model.
+func (l *LocalEmbeddingProvider) Embed(ctx context.Context, text string) ([]float32, error) {
+	prompt := text
+	if isNomicModel(l.modelPath) {
+		prompt = "search_document: " + text
+	}
+	return l.embed(ctx, prompt)
+}
+
+// EmbedQuery generates an embedding for a search query using the local model.
+func (l *LocalEmbeddingProvider) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
+	prompt := text
+	if isNomicModel(l.modelPath) {
+		prompt = "search_query: " + text
+	}
+	return l.embed(ctx, prompt)
+}
+
+// Close stops the llama-server process, if one is running.
+func (l *LocalEmbeddingProvider) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.server == nil {
+		return nil
+	}
+	_ = l.server.Process.Kill()
+	<-l.exited
+	l.server = nil
+	return nil
+}
+
+func (l *LocalEmbeddingProvider) embed(ctx context.Context, prompt string) ([]float32, error) {
+	baseURL, err := l.start(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonBody, err := json.Marshal(localEmbedRequest{Input: prompt})
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/v1/embeddings", bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := l.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http request to %s: %w", l.binary, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s error (status %d): %s", l.binary, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var embedResp localEmbedResponse
+	if err := json.Unmarshal(body, &embedResp); err != nil {
+		return nil, fmt.Errorf("parse %s response: %w", l.binary, err)
+	}
+	if len(embedResp.Data) == 0 || len(embedResp.Data[0].Embedding) == 0 {
+		return nil, fmt.Errorf("local model returned empty embedding")
+	}
+
+	embedding := make([]float32, len(embedResp.Data[0].Embedding))
+	for i, v := range embedResp.Data[0].Embedding {
+		embedding[i] = float32(v)
+	}
+
+	return normalizeEmbedding(embedding), nil
+}
+
+// start returns the URL of the running llama-server, starting it first if
+// it is not running. Concurrent callers wait for the same start.
+func (l *LocalEmbeddingProvider) start(ctx context.Context) (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.server != nil {
+		select {
+		case <-l.exited:
+			l.logger.Warn("llama-server exited, restarting it", "binary", l.binary)
+			l.server = nil
+		default:
+			return l.baseURL, nil
+		}
+	}
+
+	port, err := freeLocalPort()
+	if err != nil {
+		return "", fmt.Errorf("find a port for %s: %w", l.binary, err)
+	}
+	cmd := exec.Command(l.binary, //nolint:gosec // G204: Binary and model come from the config file
+		"-m", l.modelPath,
+		"--embedding",
+		"--host", "127.0.0.1",
+		"--port", strconv.Itoa(port),
+	)
+	output := &lastLineWriter{}
+	cmd.Stdout = output
+	cmd.Stderr = output
+	if err := cmd.Start(); err != nil {
+		return "", fmt.Errorf("start %s (is llama.cpp installed?): %w", l.binary, err)
+	}
+	exited := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(exited)
+	}()
+
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d", port)
+	if err := l.waitHealthy(ctx, baseURL, exited); err != nil {
+		_ = cmd.Process.Kill()
+		<-exited
+		return "", fmt.Errorf("start %s: %w: %s", l.binary, err, output.String())
+	}
+	l.logger.Info("llama-server started", "model", l.modelPath, "url", baseURL)
+	l.server, l.exited, l.baseURL = cmd, exited, baseURL
+	return baseURL, nil
+}
+
+// waitHealthy polls the /health endpoint of a starting llama-server until
+// it has loaded the model.
+func (l *LocalEmbeddingProvider) waitHealthy(ctx context.Context, baseURL string, exited <-chan struct{}) error {
+	ctx, cancel := context.WithTimeout(ctx, l.startTimeout)
+	defer cancel()
+	tick := time.NewTicker(100 * time.Millisecond)
+	defer tick.Stop()
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/health", nil)
+		if err != nil {
+			return err
+		}
+		if resp, err := l.httpClient.Do(req); err == nil {
+			_ = resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		select {
+		case <-exited:
+			return fmt.Errorf("server exited")
+		case <-ctx.Done():
+			return fmt.Errorf("server not ready: %w", ctx.Err())
+		case <-tick.C:
+		}
+	}
+}
+
+// freeLocalPort returns a TCP port on 127.0.0.1 that is free now.
+func freeLocalPort() (int, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = ln.Close() }()
+	addr, ok := ln.Addr().(*net.TCPAddr)
+	if !ok {
+		return 0, fmt.Errorf("unexpected listener address %s", ln.Addr())
+	}
+	return addr.Port, nil
+}
+
+// lastLineWriter keeps the last non-empty line written to it, so a failed
+// server start can report why without buffering all its logs.
+type lastLineWriter struct {
+	mu      sync.Mutex
+	partial []byte
+	last    string
+}
+
+func (w *lastLineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		if line := strings.TrimSpace(string(w.partial[:i])); line != "" {
+			w.last = line
+		}
+		w.partial = w.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+func (w *lastLineWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if line := strings.TrimSpace(string(w.partial)); line != "" {
+		return line
+	}
+	return w.last
+}
+
+// CloseEmbeddingProvider releases what p holds, such as the llama-server
+// process of a LocalEmbeddingProvider. Providers without resources are left
+// alone.
+func CloseEmbeddingProvider(p EmbeddingProvider) error {
+	if c, ok := p.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
 // =============================================================================
 // OPENAI-COMPATIBLE EMBEDDING PROVIDER
 // =============================================================================
@@ -569,6 +836,8 @@ func randInt63n(n int64) int64 {
 }
 
 // CreateEmbeddingProvider creates an embedding provider based on config.
+// For the local provider, model is the path to a GGUF file and baseURL is
+// the path to the llama-server executable.
 func CreateEmbeddingProvider(providerType, apiKey, baseURL, model string, logger *slog.Logger) (EmbeddingProvider, error) {
 	switch providerType {
 	case "mock":
@@ -595,6 +864,17 @@ func CreateEmbeddingProvider(providerType, apiKey, baseURL, model string, logger
 		}
 		return NewOllamaEmbeddingProvider(baseURL, model, logger), nil
 
+	case "local":
+		if model == "" {
+			return nil, fmt.Errorf("model is required for local provider (path to a GGUF embedding model)")
+		}
+		// base_url optionally points at the llama-server executable; an
+		// HTTP URL left over from an Ollama config is ignored.
+		if baseURL == "" || strings.Contains(baseURL, "://") {
+			baseURL = "llama-server"
+		}
+		return NewLocalEmbeddingProvider(baseURL, model, logger), nil
+
 	case "openai":
 		if apiKey == "" {
 			return nil, fmt.Errorf("api_key is required for openai provider")
@@ -608,6 +888,6 @@ func CreateEmbeddingProvider(providerType, apiKey, baseURL, model string, logger
 		return NewOpenAIEmbeddingProvider(apiKey, baseURL, model, logger), nil
 
 	default:
-		return nil, fmt.Errorf("unknown embedding provider: %s (supported: mock, nomic, ollama, openai)", providerType)
+		return nil, fmt.Errorf("unknown embedding provider: %s (supported: local, mock, nomic, ollama, openai)", providerType)
 	}
 }
\ No newline at end of file
@@ -0,0 +1,184 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// embeddingCheckTimeout bounds each provider probe of CheckEmbeddings.
+const embeddingCheckTimeout = 10 * time.Second
+
+// FallbackEmbeddingProvider embeds with a primary provider and, when a call
+// fails, retries it with an optional fallback provider. It records the
+// failures of both so that they can be reported instead of only logged.
+//
+// The fallback must produce vectors of the same size as the primary, and
+// should use the same model: vectors of different models are not
+// comparable, so search quality drops for nodes embedded by the fallback.
+type FallbackEmbeddingProvider struct {
+	primary      EmbeddingProvider
+	primaryName  string
+	fallback     EmbeddingProvider
+	fallbackName string
+	logger       *slog.Logger
+
+	mu sync.Mutex
+	// startup holds the problems found by Check.
+	startup []string
+	// failures counts failed calls to the primary, and served the calls
+	// the fallback answered instead.
+	failures int
+	served   int
+	lastErr  error
+	lastAt   time.Time
+	// fallbackErr is the latest failure of the fallback.
+	fallbackErr error
+}
+
+// NewFallbackEmbeddingProvider wraps primary. fallback may be nil, in which
+// case failures are only recorded.
+func NewFallbackEmbeddingProvider(primaryName string, primary EmbeddingProvider, fallbackName string, fallback EmbeddingProvider, logger *slog.Logger) *FallbackEmbeddingProvider {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &FallbackEmbeddingProvider{
+		primary:      primary,
+		primaryName:  primaryName,
+		fallback:     fallback,
+		fallbackName: fallbackName,
+		logger:       logger,
+	}
+}
+
+// Embed implements EmbeddingProvider.
+func (f *FallbackEmbeddingProvider) Embed(ctx context.Context, text string) ([]float32, error) {
+	return f.embed(ctx, text, false)
+}
+
+// EmbedQuery implements EmbeddingProvider.
+func (f *FallbackEmbeddingProvider) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
+	return f.embed(ctx, text, true)
+}
+
+// Close closes the primary and fallback providers. See
+// CloseEmbeddingProvider.
+func (f *FallbackEmbeddingProvider) Close() error {
+	return errors.Join(CloseEmbeddingProvider(f.primary), CloseEmbeddingProvider(f.fallback))
+}
+
+func (f *FallbackEmbeddingProvider) embed(ctx context.Context, text string, isQuery bool) ([]float32, error) {
+	vec, err := callProvider(ctx, f.primary, text, isQuery)
+	if err == nil || ctx.Err() != nil {
+		return vec, err
+	}
+
+	f.mu.Lock()
+	f.failures++
+	f.lastErr = err
+	f.lastAt = time.Now()
+	fallback := f.fallback
+	f.mu.Unlock()
+
+	if fallback == nil {
+		return nil, err
+	}
+	f.logger.Warn("embedding.fallback", "provider", f.primaryName, "fallback", f.fallbackName, "err", err)
+	vec, ferr := callProvider(ctx, fallback, text, isQuery)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if ferr != nil {
+		f.fallbackErr = ferr
+		return nil, fmt.Errorf("%s: %w (fallback %s: %v)", f.primaryName, err, f.fallbackName, ferr)
+	}
+	f.served++
+	return vec, nil
+}
+
+func callProvider(ctx context.Context, p EmbeddingProvider, text string, isQuery bool) ([]float32, error) {
+	if isQuery {
+		return p.EmbedQuery(ctx, text)
+	}
+	return p.Embed(ctx, text)
+}
+
+// Check embeds a short text with the primary and the fallback provider and
+// returns the problems found. A fallback whose vectors differ in size from
+// the primary's is disabled.
+func (f *FallbackEmbeddingProvider) Check(ctx context.Context) []string {
+	var problems []string
+	primaryDim := 0
+	if vec, err := probeProvider(ctx, f.primary); err != nil {
+		msg := fmt.Sprintf("embedding provider %s is unavailable: %v", f.primaryName, err)
+		if f.fallback == nil {
+			msg += "; semantic search and new embeddings will fail until it is back"
+		} else {
+			msg += fmt.Sprintf("; using fallback provider %s", f.fallbackName)
+		}
+		problems = append(problems, msg)
+	} else {
+		primaryDim = len(vec)
+	}
+
+	if f.fallback != nil {
+		vec, err := probeProvider(ctx, f.fallback)
+		switch {
+		case err != nil:
+			problems = append(problems, fmt.Sprintf("fallback embedding provider %s is unavailable: %v", f.fallbackName, err))
+		case primaryDim > 0 && len(vec) != primaryDim:
+			problems = append(problems, fmt.Sprintf("fallback embedding provider %s returns %d-dimensional vectors but %s returns %d; the fallback is disabled",
+				f.fallbackName, len(vec), f.primaryName, primaryDim))
+			f.mu.Lock()
+			f.fallback = nil
+			f.mu.Unlock()
+		}
+	}
+
+	f.mu.Lock()
+	f.startup = problems
+	f.mu.Unlock()
+	return problems
+}
+
+func probeProvider(ctx context.Context, p EmbeddingProvider) ([]float32, error) {
+	ctx, cancel := context.WithTimeout(ctx, embeddingCheckTimeout)
+	defer cancel()
+	return p.Embed(ctx, "mie embedding health check")
+}
+
+// LastError returns when the latest failed call to the primary provider
+// happened and its error, which is nil if no call has failed.
+func (f *FallbackEmbeddingProvider) LastError() (at time.Time, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.lastAt, f.lastErr
+}
+
+// Warnings returns the problems found by Check and a summary of the calls
+// that failed since.
+func (f *FallbackEmbeddingProvider) Warnings() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	warnings := append([]string(nil), f.startup...)
+	if f.failures > 0 {
+		msg := fmt.Sprintf("embedding provider %s failed %d call(s), last at %s: %v",
+			f.primaryName, f.failures, f.lastAt.UTC().Format("2006-01-02 15:04:05"), f.lastErr)
+		if f.served > 0 {
+			msg += fmt.Sprintf("; fallback provider %s answered %d of them", f.fallbackName, f.served)
+		}
+		warnings = append(warnings, msg)
+	}
+	if f.fallbackErr != nil {
+		warnings = append(warnings, fmt.Sprintf("fallback embedding provider %s also failed: %v", f.fallbackName, f.fallbackErr))
+	}
+	return warnings
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package memory
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// failingEmbeddingProvider fails every call with err.
+type failingEmbeddingProvider struct {
+	err   error
+	calls int
+}
+
+func (f *failingEmbeddingProvider) Embed(context.Context, string) ([]float32, error) {
+	f.calls++
+	return nil, f.err
+}
+
+func (f *failingEmbeddingProvider) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
+	return f.Embed(ctx, text)
+}
+
+func TestFallbackEmbeddingProviderServesFailures(t *testing.T) {
+	primary := &failingEmbeddingProvider{err: errors.New("connection refused")}
+	f := NewFallbackEmbeddingProvider("ollama", primary, "openai", NewMockEmbeddingProvider(8, nil), nil)
+
+	ctx := context.Background()
+	vec, err := f.Embed(ctx, "hello")
+	if err != nil {
+		t.Fatalf("Embed() error = %v", err)
+	}
+	if len(vec) != 8 {
+		t.Errorf("len(vec) = %d, want the fallback's 8", len(vec))
+	}
+	if _, err := f.EmbedQuery(ctx, "hello"); err != nil {
+		t.Fatalf("EmbedQuery() error = %v", err)
+	}
+
+	warnings := f.Warnings()
+	if len(warnings) != 1 {
+		t.Fatalf("Warnings() = %v, want one summary", warnings)
+	}
+	for _, want := range []string{"ollama failed 2 call(s)", "connection refused", "openai answered 2"} {
+		if !strings.Contains(warnings[0], want) {
+			t.Errorf("warning %q should contain %q", warnings[0], want)
+		}
+	}
+}
+
+func TestFallbackEmbeddingProviderBothFail(t *testing.T) {
+	f := NewFallbackEmbeddingProvider(
+		"ollama", &failingEmbeddingProvider{err: errors.New("connection refused")},
+		"openai", &failingEmbeddingProvider{err: errors.New("invalid api key")}, nil)
+
+	_, err := f.Embed(context.Background(), "hello")
+	if err == nil || !strings.Contains(err.Error(), "connection refused") || !strings.Contains(err.Error(), "invalid api key") {
+		t.Fatalf("Embed() error = %v, want both failures", err)
+	}
+	if warnings := f.Warnings(); len(warnings) != 2 || !strings.Contains(warnings[1], "invalid api key") {
+		t.Errorf("Warnings() = %v, want the fallback failure too", warnings)
+	}
+}
+
+func TestFallbackEmbeddingProviderCheck(t *testing.T) {
+	f := NewFallbackEmbeddingProvider("ollama", NewMockEmbeddingProvider(8, nil), "openai", NewMockEmbeddingProvider(8, nil), nil)
+	if problems := f.Check(context.Background()); len(problems) != 0 {
+		t.Fatalf("Check() of healthy providers = %v", problems)
+	}
+	if problems := f.Warnings(); len(problems) != 0 {
+		t.Fatalf("Warnings() of healthy providers = %v", problems)
+	}
+
+	f = NewFallbackEmbeddingProvider("ollama", NewMockEmbeddingProvider(8, nil), "openai", NewMockEmbeddingProvider(4, nil), nil)
+	problems := f.Check(context.Background())
+	if len(problems) != 1 || !strings.Contains(problems[0], "4-dimensional") || !strings.Contains(problems[0], "disabled") {
+		t.Fatalf("Check() = %v, want the dimension mismatch", problems)
+	}
+	if f.fallback != nil {
+		t.Error("a fallback of the wrong size should be disabled")
+	}
+	if warnings := f.Warnings(); len(warnings) != 1 {
+		t.Errorf("Warnings() = %v, want the startup problem", warnings)
+	}
+}
+
+func TestFallbackEmbeddingProviderCheckUnavailable(t *testing.T) {
+	f := NewFallbackEmbeddingProvider("ollama", &failingEmbeddingProvider{err: errors.New("connection refused")}, "", nil, nil)
+	problems := f.Check(context.Background())
+	if len(problems) != 1 || !strings.Contains(problems[0], "ollama is unavailable") || !strings.Contains(problems[0], "semantic search") {
+		t.Errorf("Check() = %v, want the unavailable primary", problems)
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+const (
+	// maxEmbeddingAttempts is how often a queued embedding is tried before
+	// the node is dropped from the queue. The startup backfill picks it up
+	// again.
+	maxEmbeddingAttempts = 5
+	// embeddingRetryDelay is the wait before the first retry of a failed
+	// embedding; each further retry waits twice as long.
+	embeddingRetryDelay = 10 * time.Second
+	// embeddingQueuePoll is how often idle workers look for retries that
+	// came due.
+	embeddingQueuePoll = 5 * time.Second
+)
+
+// enqueueEmbeddingScript adds a node to mie_embedding_queue, replacing an
+// earlier entry for it.
+const enqueueEmbeddingScript = `?[node_id, node_type, text, enqueued_at, attempts, retry_at, last_error] <- [[$node_id, $node_type, $text, $enqueued_at, 0, 0, '']]
+:put mie_embedding_queue { node_id => node_type, text, enqueued_at, attempts, retry_at, last_error }`
+
+// dequeueEmbeddingScript removes a node from mie_embedding_queue unless it
+// was queued again after $enqueued_at.
+const dequeueEmbeddingScript = `?[node_id] := *mie_embedding_queue { node_id, enqueued_at }, node_id = $node_id, enqueued_at = $enqueued_at
+:rm mie_embedding_queue { node_id }`
+
+// embeddingQueue runs the workers started by Writer.StartEmbeddingQueue.
+type embeddingQueue struct {
+	wake   chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// queuedEmbedding is a node read from mie_embedding_queue.
+type queuedEmbedding struct {
+	backfillJob
+	enqueuedAt int64
+	attempts   int
+}
+
+// enqueueEmbedding records in mie_embedding_queue that nodeID needs an
+// embedding of text. When ctx carries a write batch, the entry is committed
+// with the node. The queue workers are woken once it is.
+func (w *Writer) enqueueEmbedding(ctx context.Context, nodeType, nodeID, text string) error {
+	params := map[string]any{
+		"node_id": nodeID, "node_type": nodeType, "text": text,
+		"enqueued_at": time.Now().UnixNano(),
+	}
+	if err := w.execute(ctx, enqueueEmbeddingScript, params); err != nil {
+		return fmt.Errorf("queue embedding: %w", err)
+	}
+	afterCommit(ctx, w.wakeEmbeddingQueue)
+	return nil
+}
+
+// StartEmbeddingQueue starts workers that embed the nodes in
+// mie_embedding_queue, beginning with those an earlier process left queued.
+// At most workers embeddings are generated at once, however fast nodes are
+// stored; stores only add to the queue. It does nothing if embeddings are
+// disabled or the queue was already started.
+func (w *Writer) StartEmbeddingQueue(workers int) {
+	if w.embedder == nil || w.queue != nil {
+		return
+	}
+	if workers <= 0 {
+		workers = DefaultBackfillWorkers
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &embeddingQueue{wake: make(chan struct{}, 1), cancel: cancel, done: make(chan struct{})}
+	w.queue = q
+	go func() {
+		defer close(q.done)
+		w.runEmbeddingQueue(ctx, q, workers)
+	}()
+}
+
+// StopEmbeddingQueue stops the queue workers and waits for the embeddings
+// in progress. Nodes not embedded yet stay queued for the next start.
+func (w *Writer) StopEmbeddingQueue() {
+	if w.queue == nil {
+		return
+	}
+	w.queue.cancel()
+	<-w.queue.done
+}
+
+// wakeEmbeddingQueue tells idle queue workers that a node was queued.
+func (w *Writer) wakeEmbeddingQueue() {
+	if w.queue == nil {
+		return
+	}
+	select {
+	case w.queue.wake <- struct{}{}:
+	default:
+	}
+}
+
+// runEmbeddingQueue embeds the due queue entries in batches until ctx is
+// cancelled, waiting for a wake-up or the next poll when none are due.
+func (w *Writer) runEmbeddingQueue(ctx context.Context, q *embeddingQueue, workers int) {
+	ticker := time.NewTicker(embeddingQueuePoll)
+	defer ticker.Stop()
+	for {
+		jobs, err := w.dueEmbeddings(ctx, workers*4)
+		if err != nil && ctx.Err() == nil {
+			w.logger.Warn("failed to read the embedding queue", "error", err)
+		}
+		if len(jobs) > 0 {
+			w.embedQueued(ctx, jobs, workers)
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// dueEmbeddings returns up to limit queue entries that are not waiting for
+// a retry, oldest first.
+func (w *Writer) dueEmbeddings(ctx context.Context, limit int) ([]queuedEmbedding, error) {
+	script := fmt.Sprintf(`?[node_id, node_type, text, enqueued_at, attempts] := *mie_embedding_queue { node_id, node_type, text, enqueued_at, attempts, retry_at }, retry_at <= $now
+:order enqueued_at
+:limit %d`, limit)
+	qr, err := w.backend.Query(ctx, script, map[string]any{"now": time.Now().Unix()})
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]queuedEmbedding, 0, len(qr.Rows))
+	for _, row := range qr.Rows {
+		jobs = append(jobs, queuedEmbedding{
+			backfillJob: backfillJob{id: toString(row[0]), nodeType: toString(row[1]), text: toString(row[2])},
+			enqueuedAt:  toInt64(row[3]),
+			attempts:    toInt(row[4]),
+		})
+	}
+	return jobs, nil
+}
+
+// embedQueued embeds jobs with up to workers concurrent workers.
+func (w *Writer) embedQueued(ctx context.Context, jobs []queuedEmbedding, workers int) {
+	ch := make(chan queuedEmbedding)
+	var wg sync.WaitGroup
+	for range min(workers, len(jobs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range ch {
+				w.embedQueuedNode(ctx, j)
+			}
+		}()
+	}
+
+feed:
+	for _, j := range jobs {
+		select {
+		case ch <- j:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(ch)
+	wg.Wait()
+}
+
+// embedQueuedNode embeds one queued node and removes it from the queue. A
+// failure schedules a retry, or drops the node after maxEmbeddingAttempts.
+func (w *Writer) embedQueuedNode(ctx context.Context, j queuedEmbedding) {
+	err := w.embedNode(ctx, j.backfillJob)
+	if err == nil {
+		w.dequeueEmbedding(ctx, j)
+		return
+	}
+	if ctx.Err() != nil {
+		// Stopped part way; the node stays queued.
+		return
+	}
+
+	attempts := j.attempts + 1
+	if attempts >= maxEmbeddingAttempts {
+		w.logger.Warn("giving up on embedding until the next backfill", "node_id", j.id, "type", j.nodeType, "attempts", attempts, "error", err)
+		w.dequeueEmbedding(ctx, j)
+		return
+	}
+	w.logger.Warn("failed to embed node, will retry", "node_id", j.id, "type", j.nodeType, "attempts", attempts, "error", err)
+	retry := `?[node_id, node_type, text, enqueued_at, attempts, retry_at, last_error] :=
+    *mie_embedding_queue { node_id, node_type, text, enqueued_at },
+    node_id = $node_id, enqueued_at = $enqueued_at,
+    attempts = $attempts, retry_at = $retry_at, last_error = $last_error
+:put mie_embedding_queue { node_id => node_type, text, enqueued_at, attempts, retry_at, last_error }`
+	params := map[string]any{
+		"node_id": j.id, "enqueued_at": j.enqueuedAt, "attempts": attempts,
+		"retry_at":   time.Now().Add(embeddingRetryDelay << (attempts - 1)).Unix(),
+		"last_error": err.Error(),
+	}
+	if err := w.backend.Execute(ctx, retry, params); err != nil {
+		w.logger.Warn("failed to schedule embedding retry", "node_id", j.id, "error", err)
+	}
+}
+
+// dequeueEmbedding removes j from the queue.
+func (w *Writer) dequeueEmbedding(ctx context.Context, j queuedEmbedding) {
+	params := map[string]any{"node_id": j.id, "enqueued_at": j.enqueuedAt}
+	if err := w.backend.Execute(ctx, dequeueEmbeddingScript, params); err != nil {
+		w.logger.Warn("failed to remove node from the embedding queue", "node_id", j.id, "error", err)
+	}
+}
+
+// queuedNodeIDs returns the IDs of the nodes in the embedding queue.
+func (w *Writer) queuedNodeIDs(ctx context.Context) (map[string]bool, error) {
+	qr, err := w.backend.Query(ctx, `?[node_id] := *mie_embedding_queue { node_id }`, nil)
+	if err != nil {
+		return nil, fmt.Errorf("read embedding queue: %w", err)
+	}
+	ids := make(map[string]bool, len(qr.Rows))
+	for _, row := range qr.Rows {
+		ids[toString(row[0])] = true
+	}
+	return ids, nil
+}
+
+// EmbeddingQueueStats counts the nodes waiting in the embedding queue.
+func (w *Writer) EmbeddingQueueStats(ctx context.Context) (*tools.EmbeddingQueueStats, error) {
+	stats := &tools.EmbeddingQueueStats{}
+	qr, err := w.backend.Query(ctx, `?[count(node_id), min(enqueued_at)] := *mie_embedding_queue { node_id, enqueued_at }`, nil)
+	if err != nil {
+		return nil, fmt.Errorf("count embedding queue: %w", err)
+	}
+	if len(qr.Rows) > 0 {
+		stats.Pending = toInt(qr.Rows[0][0])
+		if stats.Pending > 0 {
+			stats.OldestQueuedAt = toInt64(qr.Rows[0][1]) / int64(time.Second)
+		}
+	}
+	qr, err = w.backend.Query(ctx, `?[count(node_id)] := *mie_embedding_queue { node_id, attempts }, attempts > 0`, nil)
+	if err != nil {
+		return nil, fmt.Errorf("count embedding queue: %w", err)
+	}
+	if len(qr.Rows) > 0 {
+		stats.Retrying = toInt(qr.Rows[0][0])
+	}
+	return stats, nil
+}
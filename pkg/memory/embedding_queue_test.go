@@ -0,0 +1,76 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestWriterEmbeddingQueue(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	ctx := context.Background()
+	w := NewWriter(backend, NewEmbeddingGenerator(NewMockEmbeddingProvider(384, nil), nil), nil)
+
+	// Without running workers, stored nodes wait in the queue.
+	if _, err := w.StoreFact(ctx, tools.StoreFactRequest{Content: "Uses Go", Category: "technical"}); err != nil {
+		t.Fatalf("StoreFact failed: %v", err)
+	}
+	if _, err := w.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Go", Kind: "technology"}); err != nil {
+		t.Fatalf("StoreEntity failed: %v", err)
+	}
+	stats, err := w.EmbeddingQueueStats(ctx)
+	if err != nil {
+		t.Fatalf("EmbeddingQueueStats failed: %v", err)
+	}
+	if stats.Pending != 2 || stats.Retrying != 0 || stats.OldestQueuedAt == 0 {
+		t.Errorf("unexpected queue stats: %+v", stats)
+	}
+
+	// A backfill leaves queued nodes to the workers.
+	result, err := w.BackfillEmbeddings(ctx, BackfillOptions{})
+	if err != nil {
+		t.Fatalf("BackfillEmbeddings failed: %v", err)
+	}
+	if result.Embedded != 0 || result.Missing["fact"] != 1 {
+		t.Errorf("unexpected backfill result: %+v", result)
+	}
+
+	w.StartEmbeddingQueue(2)
+	defer w.StopEmbeddingQueue()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		stats, err = w.EmbeddingQueueStats(ctx)
+		if err != nil {
+			t.Fatalf("EmbeddingQueueStats failed: %v", err)
+		}
+		if stats.Pending == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("queue not drained: %+v", stats)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if stats.OldestQueuedAt != 0 {
+		t.Errorf("expected no oldest entry for an empty queue, got %d", stats.OldestQueuedAt)
+	}
+
+	missing, err := w.MissingEmbeddings(ctx, nil)
+	if err != nil {
+		t.Fatalf("MissingEmbeddings failed: %v", err)
+	}
+	if len(missing) != 0 {
+		t.Errorf("expected the queue to embed every node, got missing %v", missing)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// lastError remembers the latest of a series of errors. The zero value is
+// ready to use.
+type lastError struct {
+	mu  sync.Mutex
+	err error
+	at  time.Time
+}
+
+func (l *lastError) set(err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.err, l.at = err, time.Now()
+}
+
+func (l *lastError) get() (at time.Time, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.at, l.err
+}
+
+// embeddingCoverage counts the nodes of each embedded type in the namespace
+// and how many of them have an embedding.
+func (r *Reader) embeddingCoverage(ctx context.Context) ([]tools.EmbeddingCoverage, error) {
+	params := map[string]any{"ns": resolveNamespace(ctx, r.namespace)}
+	coverage := make([]tools.EmbeddingCoverage, 0, len(backfillNodeTypes))
+	for _, nt := range backfillNodeTypes {
+		table := nodeTypeToTable(nt)
+		nodes, err := r.backend.Query(ctx, fmt.Sprintf(`?[count(id)] := *%s { id, namespace }, namespace = $ns`, table), params)
+		if err != nil {
+			return nil, fmt.Errorf("count %s nodes: %w", nt, err)
+		}
+		embedded, err := r.backend.Query(ctx, fmt.Sprintf(`?[count(id)] := *%s { id, namespace }, namespace = $ns, *%s { %s_id: id }`,
+			table, nodeTypeToEmbeddingTable(nt), nt), params)
+		if err != nil {
+			return nil, fmt.Errorf("count %s embeddings: %w", nt, err)
+		}
+		c := tools.EmbeddingCoverage{NodeType: nt}
+		if len(nodes.Rows) > 0 {
+			c.Nodes = toInt(nodes.Rows[0][0])
+		}
+		if len(embedded.Rows) > 0 {
+			c.Embedded = toInt(embedded.Rows[0][0])
+		}
+		coverage = append(coverage, c)
+	}
+	return coverage, nil
+}
+
+// GetEmbeddingStatus reports embedding coverage per node type in the
+// namespace, the health of the vector index, the configured and stored
+// vector sizes, and the latest embedding failure.
+func (c *Client) GetEmbeddingStatus(ctx context.Context) (*tools.EmbeddingReport, error) {
+	dim := c.config.EmbeddingDimensions
+	if dim <= 0 {
+		dim = 768
+	}
+	report := &tools.EmbeddingReport{
+		Enabled:              c.EmbeddingsEnabled(),
+		Provider:             c.config.EmbeddingProvider,
+		Model:                c.config.EmbeddingModel,
+		ConfiguredDimensions: dim,
+		Index:                vectorIndexName(c.config.VectorIndex),
+		Warnings:             c.embeddingWarnings(),
+	}
+
+	coverage, err := c.reader.embeddingCoverage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report.Coverage = coverage
+
+	for _, nt := range backfillNodeTypes {
+		table := nodeTypeToEmbeddingTable(nt)
+		if report.StoredDimensions == 0 {
+			report.StoredDimensions = vectorDimensions(ctx, c.backend, table)
+		}
+		if c.config.VectorIndex != nil || !c.config.EmbeddingEnabled {
+			continue
+		}
+		index := nodeTypeToHNSWIndex(nt)
+		found, err := hasIndex(ctx, c.backend, table, index)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			report.MissingIndexes = append(report.MissingIndexes, table+":"+index)
+		}
+	}
+
+	at, lastErr := c.writer.storeErr.get()
+	if c.providers != nil {
+		if pAt, pErr := c.providers.LastError(); pErr != nil && pAt.After(at) {
+			at, lastErr = pAt, pErr
+		}
+	}
+	if lastErr != nil {
+		report.LastError = lastErr.Error()
+		report.LastErrorAt = at.Unix()
+	}
+	return report, nil
+}
+
+// vectorIndexName names the vector index of a ClientConfig for reports.
+func vectorIndexName(index VectorIndex) string {
+	switch index.(type) {
+	case nil:
+		return "hnsw"
+	case *FlatIndex:
+		return "flat"
+	default:
+		return "custom"
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestClientGetEmbeddingStatus(t *testing.T) {
+	client, err := NewClient(ClientConfig{DataDir: t.TempDir(), StorageEngine: "mem", EmbeddingDimensions: 384})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer func() { _ = client.Close() }()
+	ctx := context.Background()
+
+	if _, err := client.StoreFact(ctx, tools.StoreFactRequest{Content: "Uses Go", Category: "technical"}); err != nil {
+		t.Fatalf("StoreFact failed: %v", err)
+	}
+	if _, err := client.StoreFact(tools.WithNamespace(ctx, "other"), tools.StoreFactRequest{Content: "Uses Rust", Category: "technical"}); err != nil {
+		t.Fatalf("StoreFact failed: %v", err)
+	}
+	if _, err := client.StoreDecision(ctx, tools.StoreDecisionRequest{Title: "Use CozoDB", Rationale: "Embedded Datalog"}); err != nil {
+		t.Fatalf("StoreDecision failed: %v", err)
+	}
+
+	report, err := client.GetEmbeddingStatus(ctx)
+	if err != nil {
+		t.Fatalf("GetEmbeddingStatus failed: %v", err)
+	}
+	if report.Enabled || report.Index != "hnsw" || report.ConfiguredDimensions != 384 || report.StoredDimensions != 384 {
+		t.Errorf("report = %+v", report)
+	}
+	counts := map[string]tools.EmbeddingCoverage{}
+	for _, c := range report.Coverage {
+		counts[c.NodeType] = c
+	}
+	if counts["fact"].Nodes != 1 || counts["fact"].Missing() != 1 || counts["decision"].Nodes != 1 || counts["event"].Nodes != 0 {
+		t.Errorf("coverage = %+v, want one fact and one decision without embeddings", report.Coverage)
+	}
+	if report.LastError != "" || len(report.MissingIndexes) != 0 {
+		t.Errorf("report = %+v, want no error and no index checks while disabled", report)
+	}
+
+	client.writer.storeErr.set(errors.New("vector length mismatch"))
+	report, err = client.GetEmbeddingStatus(ctx)
+	if err != nil {
+		t.Fatalf("GetEmbeddingStatus failed: %v", err)
+	}
+	if report.LastError != "vector length mismatch" || report.LastErrorAt == 0 {
+		t.Errorf("last error = %q at %d", report.LastError, report.LastErrorAt)
+	}
+}
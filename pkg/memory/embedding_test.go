@@ -6,7 +6,17 @@ package memory
 
 import (
 	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
 	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -162,6 +172,21 @@ func TestCreateEmbeddingProvider(t *testing.T) {
 		t.Error("expected error for openai without API key")
 	}
 
+	// Local without a model path
+	_, err = CreateEmbeddingProvider("local", "", "", "", nil)
+	if err == nil {
+		t.Error("expected error for local without model")
+	}
+
+	// Local ignores an Ollama URL left in base_url
+	p, err = CreateEmbeddingProvider("local", "", "http://localhost:11434", "/models/minilm.gguf", nil)
+	if err != nil {
+		t.Fatalf("failed to create local provider: %v", err)
+	}
+	if lp, ok := p.(*LocalEmbeddingProvider); !ok || lp.binary != "llama-server" {
+		t.Errorf("expected local provider with default binary, got %#v", p)
+	}
+
 	// Ollama with defaults
 	p, err = CreateEmbeddingProvider("ollama", "", "", "", nil)
 	if err != nil {
@@ -194,6 +219,113 @@ func TestEmbeddingGenerator(t *testing.T) {
 	}
 }
 
+// TestFakeLlamaServer is not a test: run with MIE_FAKE_LLAMA_SERVER set, it
+// serves llama-server's health and embeddings endpoints on the --port
+// given after "--", recording each start and input in that directory.
+func TestFakeLlamaServer(t *testing.T) {
+	dir := os.Getenv("MIE_FAKE_LLAMA_SERVER")
+	if dir == "" {
+		t.Skip("helper process for TestLocalEmbeddingProvider")
+	}
+	args := flag.Args()
+	port := ""
+	for i, arg := range args {
+		if arg == "--port" && i+1 < len(args) {
+			port = args[i+1]
+		}
+	}
+	appendLine := func(name, line string) {
+		f, err := os.OpenFile(filepath.Join(dir, name), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = fmt.Fprintln(f, line)
+		_ = f.Close()
+	}
+	appendLine("starts", strings.Join(args, " "))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/v1/embeddings", func(w http.ResponseWriter, r *http.Request) {
+		var req localEmbedRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		appendLine("inputs", strconv.Quote(req.Input))
+		_, _ = io.WriteString(w, `{"object":"list","data":[{"object":"embedding","index":0,"embedding":[3,4]}]}`)
+	})
+	_ = http.ListenAndServe("127.0.0.1:"+port, mux) //nolint:gosec // G114: test helper
+	os.Exit(0)
+}
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func TestLocalEmbeddingProvider(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake llama-server is started through a shell script")
+	}
+
+	// The fake binary runs TestFakeLlamaServer in this test binary.
+	dir := t.TempDir()
+	t.Setenv("MIE_FAKE_LLAMA_SERVER", dir)
+	script := "#!/bin/sh\nexec '" + os.Args[0] + "' -test.run='^TestFakeLlamaServer$' -- \"$@\"\n"
+	binary := filepath.Join(dir, "llama-server")
+	if err := os.WriteFile(binary, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewLocalEmbeddingProvider(binary, "/models/nomic-embed-text-v1.5.gguf", nil)
+	defer func() { _ = p.Close() }()
+	ctx := context.Background()
+	emb, err := p.EmbedQuery(ctx, "which database?")
+	if err != nil {
+		t.Fatalf("EmbedQuery failed: %v", err)
+	}
+	if len(emb) != 2 || math.Abs(float64(emb[0])-0.6) > 1e-6 || math.Abs(float64(emb[1])-0.8) > 1e-6 {
+		t.Errorf("expected normalized [0.6 0.8], got %v", emb)
+	}
+	if _, err := p.Embed(ctx, "uses Go"); err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+
+	// Both calls go to one server, which loaded the model once.
+	starts := readLines(t, filepath.Join(dir, "starts"))
+	if len(starts) != 1 || !strings.Contains(starts[0], "-m /models/nomic-embed-text-v1.5.gguf --embedding --host 127.0.0.1") {
+		t.Errorf("server starts = %q, want one embedding server", starts)
+	}
+	inputs := readLines(t, filepath.Join(dir, "inputs"))
+	want := []string{`"search_query: which database?"`, `"search_document: uses Go"`}
+	if strings.Join(inputs, "|") != strings.Join(want, "|") {
+		t.Errorf("inputs = %q, want %q", inputs, want)
+	}
+
+	// After Close, the next call starts a new server.
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if _, err := p.Embed(ctx, "again"); err != nil {
+		t.Fatalf("Embed after Close failed: %v", err)
+	}
+	if starts := readLines(t, filepath.Join(dir, "starts")); len(starts) != 2 {
+		t.Errorf("server started %d times, want 2", len(starts))
+	}
+
+	// A server that fails to start surfaces its last output line.
+	failing := filepath.Join(dir, "failing")
+	if err := os.WriteFile(failing, []byte("#!/bin/sh\necho 'loading model' >&2\necho 'failed to load model' >&2\nexit 1\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	_, err = NewLocalEmbeddingProvider(failing, "/models/missing.gguf", nil).Embed(ctx, "text")
+	if err == nil || !strings.Contains(err.Error(), "failed to load model") {
+		t.Errorf("expected output detail in error, got %v", err)
+	}
+}
+
 type testError struct {
 	msg string
 }
@@ -0,0 +1,74 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// putEventEnd records the last day of an event: ev.EndDate, or its
+// event_date for a one-day event. Every event has a row, so date range
+// filters can join on it.
+func (w *Writer) putEventEnd(ctx context.Context, ev *tools.Event) error {
+	end := ev.EndDate
+	if end == "" {
+		end = ev.EventDate
+	}
+	mutation := `?[event_id, end_date] <- [[$event_id, $end_date]] :put mie_event_end { event_id => end_date }`
+	if err := w.execute(ctx, mutation, map[string]any{"event_id": ev.ID, "end_date": end}); err != nil {
+		return fmt.Errorf("set end date of event %s: %w", ev.ID, err)
+	}
+	return nil
+}
+
+// attachEventEnds sets the EndDate field of events that span several days
+// from mie_event_end.
+func (r *Reader) attachEventEnds(ctx context.Context, events []*tools.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	ids := make([]string, len(events))
+	for i, ev := range events {
+		ids[i] = ev.ID
+	}
+	qr, err := r.backend.Query(ctx, `ids[event_id] <- $ids
+?[event_id, end_date] := ids[event_id], *mie_event_end { event_id, end_date }`,
+		map[string]any{"ids": idRows(ids)})
+	if err != nil {
+		return fmt.Errorf("get event end dates: %w", err)
+	}
+	ends := make(map[string]string, len(qr.Rows))
+	for _, row := range qr.Rows {
+		ends[toString(row[0])] = toString(row[1])
+	}
+	for _, ev := range events {
+		if end := ends[ev.ID]; end != ev.EventDate {
+			ev.EndDate = end
+		}
+	}
+	return nil
+}
+
+// eventOverlapConditions keeps the events, with ID idVar and event_date
+// bound, that overlap the event date bounds of tr, and adds the bounds to
+// params. An event overlaps when it starts on or before EventDateTo and
+// ends on or after EventDateFrom.
+func eventOverlapConditions(tr tools.TimeRange, idVar string, params map[string]any) []string {
+	var conditions []string
+	if tr.EventDateFrom != "" {
+		conditions = append(conditions, fmt.Sprintf(`*mie_event_end { event_id: %s, end_date: event_end }, event_end >= $event_date_from`, idVar))
+		params["event_date_from"] = tr.EventDateFrom
+	}
+	if tr.EventDateTo != "" {
+		conditions = append(conditions, `event_date <= $event_date_to`)
+		params["event_date_to"] = tr.EventDateTo
+	}
+	return conditions
+}
@@ -0,0 +1,176 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// DefaultExpirySweepInterval is how often StartExpirySweep invalidates
+// expired facts.
+const DefaultExpirySweepInterval = time.Minute
+
+// secondsPerDay converts retention periods in days to Unix time.
+const secondsPerDay = 24 * 60 * 60
+
+// putExpiry records when a fact expires.
+func (w *Writer) putExpiry(ctx context.Context, factID string, expiresAt int64) error {
+	mutation := `?[fact_id, expires_at] <- [[$fact_id, $expires_at]] :put mie_fact_expiry { fact_id => expires_at }`
+	if err := w.execute(ctx, mutation, map[string]any{"fact_id": factID, "expires_at": expiresAt}); err != nil {
+		return fmt.Errorf("set expiry of fact %s: %w", factID, err)
+	}
+	return nil
+}
+
+// ExpireFacts invalidates the valid facts whose expiry is at or before now,
+// and those older than the retention of their category, and returns their
+// IDs grouped by namespace. It covers every namespace, so one sweep serves
+// a server that handles several.
+func (w *Writer) ExpireFacts(ctx context.Context, now time.Time) (map[string][]string, error) {
+	script := fmt.Sprintf(
+		`?[id, namespace, reason] := *mie_fact_expiry { fact_id: id, expires_at }, expires_at <= %d,
+    *mie_fact { id, valid, namespace }, valid = true, reason = 'expired'`, now.Unix())
+	categories := make([]string, 0, len(w.categoryPolicies))
+	for category, p := range w.categoryPolicies {
+		if p.RetentionDays > 0 {
+			categories = append(categories, category)
+		}
+	}
+	sort.Strings(categories)
+	params := map[string]any{}
+	for i, category := range categories {
+		cutoff := now.Unix() - int64(w.categoryPolicies[category].RetentionDays)*secondsPerDay
+		script += fmt.Sprintf(`
+?[id, namespace, reason] := *mie_fact { id, category, valid, created_at, namespace },
+    category = $category_%d, valid = true, created_at <= %d, reason = 'retention'`, i, cutoff)
+		params[fmt.Sprintf("category_%d", i)] = category
+	}
+	result, err := w.backend.Query(ctx, script, params)
+	if err != nil {
+		return nil, fmt.Errorf("find expired facts: %w", err)
+	}
+	if len(result.Rows) == 0 {
+		return nil, nil
+	}
+
+	expired := map[string][]string{}
+	seen := make(map[string]bool, len(result.Rows))
+	ids := make([]string, 0, len(result.Rows))
+	changes := make([]historyChange, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		id := toString(row[0])
+		ns := toString(row[1])
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		expired[ns] = append(expired[ns], id)
+		ids = append(ids, id)
+		changes = append(changes, historyChange{
+			nodeID: id, field: "valid", oldValue: "true", newValue: "false", reason: toString(row[2]), namespace: ns,
+		})
+	}
+
+	mutation := fmt.Sprintf(
+		`expired[id] <- $ids
+?[id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at, namespace] :=
+    expired[id],
+    *mie_fact { id, content, category, confidence, source_agent, source_conversation, created_at, namespace },
+    valid = false,
+    updated_at = %d
+:put mie_fact { id => content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at, namespace }`,
+		now.Unix())
+	if err := w.backend.Execute(ctx, mutation, map[string]any{"ids": idRows(ids)}); err != nil {
+		return nil, fmt.Errorf("invalidate expired facts: %w", err)
+	}
+	if err := w.recordHistory(ctx, changes...); err != nil {
+		return nil, err
+	}
+	return expired, nil
+}
+
+// attachExpiry sets the ExpiresAt field of facts from mie_fact_expiry.
+func (r *Reader) attachExpiry(ctx context.Context, facts []*tools.Fact) error {
+	if len(facts) == 0 {
+		return nil
+	}
+	ids := make([]string, len(facts))
+	for i, f := range facts {
+		ids[i] = f.ID
+	}
+	qr, err := r.backend.Query(ctx, `ids[fact_id] <- $ids
+?[fact_id, expires_at] := ids[fact_id], *mie_fact_expiry { fact_id, expires_at }`,
+		map[string]any{"ids": idRows(ids)})
+	if err != nil {
+		return fmt.Errorf("get fact expiry: %w", err)
+	}
+	expiry := make(map[string]int64, len(qr.Rows))
+	for _, row := range qr.Rows {
+		expiry[toString(row[0])] = toInt64(row[1])
+	}
+	for _, f := range facts {
+		f.ExpiresAt = expiry[f.ID]
+	}
+	return nil
+}
+
+// ExpireFacts invalidates expired facts. See Writer.ExpireFacts. Each
+// invalidation is published and recorded in the audit log of its
+// namespace.
+func (c *Client) ExpireFacts(ctx context.Context) (int, error) {
+	expired, err := c.writer.ExpireFacts(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for ns, ids := range expired {
+		nsCtx := tools.WithNamespace(ctx, ns)
+		for _, id := range ids {
+			c.publish(nsCtx, ChangeInvalidated, id, nil)
+		}
+		c.audit(nsCtx, ChangeInvalidated, "", nil, ids...)
+		total += len(ids)
+	}
+	return total, nil
+}
+
+// StartExpirySweep calls ExpireFacts now and then every interval until
+// Close, so temporary facts and facts past their category's retention drop
+// out of valid-only results. It does nothing if a sweep was already started.
+func (c *Client) StartExpirySweep(interval time.Duration) {
+	if c.sweepCancel != nil {
+		return
+	}
+	if interval <= 0 {
+		interval = DefaultExpirySweepInterval
+	}
+	ctx, cancel := context.WithCancel(tools.WithAuditSource(context.Background(), "expiry sweep", ""))
+	c.sweepCancel = cancel
+	c.sweepDone = make(chan struct{})
+	go func() {
+		defer close(c.sweepDone)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if n, err := c.ExpireFacts(ctx); err != nil && ctx.Err() == nil {
+				c.logger.Warn("fact expiry sweep failed", "error", err)
+			} else if n > 0 {
+				c.logger.Info("invalidated expired facts", "count", n)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
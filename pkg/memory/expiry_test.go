@@ -0,0 +1,132 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestClientExpireFacts(t *testing.T) {
+	client, err := NewClient(ClientConfig{DataDir: t.TempDir(), StorageEngine: "mem", EmbeddingDimensions: 384})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	soon := time.Now().Add(time.Hour)
+	temporary, err := client.StoreFact(ctx, tools.StoreFactRequest{
+		Content: "User is traveling in Japan", Category: "personal", ExpiresAt: soon.Unix(),
+	})
+	if err != nil {
+		t.Fatalf("StoreFact: %v", err)
+	}
+	lasting, _ := client.StoreFact(ctx, tools.StoreFactRequest{Content: "User lives in Madrid", Category: "personal"})
+
+	got, err := client.GetNodeByID(ctx, temporary.ID)
+	if err != nil {
+		t.Fatalf("GetNodeByID: %v", err)
+	}
+	if f := got.(*tools.Fact); f.ExpiresAt != soon.Unix() {
+		t.Errorf("ExpiresAt = %d, want %d", f.ExpiresAt, soon.Unix())
+	}
+
+	// Nothing has expired yet.
+	if n, err := client.ExpireFacts(ctx); err != nil || n != 0 {
+		t.Fatalf("ExpireFacts() = %d, %v before expiry", n, err)
+	}
+
+	expired, err := client.writer.ExpireFacts(ctx, soon.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("ExpireFacts: %v", err)
+	}
+	if ids := expired["default"]; len(ids) != 1 || ids[0] != temporary.ID {
+		t.Errorf("expired = %v, want only %s", expired, temporary.ID)
+	}
+
+	nodes, _, err := client.ListNodes(ctx, tools.ListOptions{NodeType: "fact", ValidOnly: true, Limit: 10})
+	if err != nil {
+		t.Fatalf("ListNodes: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].(*tools.Fact).ID != lasting.ID {
+		t.Errorf("valid facts = %+v, want only %s", nodes, lasting.ID)
+	}
+
+	// An invalidated fact is not expired again.
+	if again, _ := client.writer.ExpireFacts(ctx, soon.Add(time.Hour)); len(again) != 0 {
+		t.Errorf("second sweep expired %v", again)
+	}
+}
+
+func TestClientCategoryPolicies(t *testing.T) {
+	client, err := NewClient(ClientConfig{
+		DataDir: t.TempDir(), StorageEngine: "mem", EmbeddingDimensions: 384,
+		CategoryPolicies: map[string]tools.CategoryPolicy{
+			"personal":  {RetentionDays: 30},
+			"technical": {DefaultConfidence: 0.95},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	now := time.Now()
+	personal, err := client.StoreFact(ctx, tools.StoreFactRequest{Content: "User is vegetarian", Category: "personal"})
+	if err != nil {
+		t.Fatalf("StoreFact: %v", err)
+	}
+	limit := now.Unix() + 30*secondsPerDay
+	if personal.ExpiresAt < limit || personal.ExpiresAt > limit+60 {
+		t.Errorf("ExpiresAt = %d, want about %d", personal.ExpiresAt, limit)
+	}
+	// An earlier expiry is kept.
+	soon := now.Add(time.Hour).Unix()
+	trip, _ := client.StoreFact(ctx, tools.StoreFactRequest{Content: "User is in Japan", Category: "personal", ExpiresAt: soon})
+	if trip.ExpiresAt != soon {
+		t.Errorf("ExpiresAt = %d, want the requested %d", trip.ExpiresAt, soon)
+	}
+
+	technical, _ := client.StoreFact(ctx, tools.StoreFactRequest{Content: "API is written in Go", Category: "technical"})
+	if technical.Confidence != 0.95 || technical.ExpiresAt != 0 {
+		t.Errorf("technical fact = %+v, want confidence 0.95 and no expiry", technical)
+	}
+	explicit, _ := client.StoreFact(ctx, tools.StoreFactRequest{Content: "Uses Postgres 16", Category: "technical", Confidence: 0.6})
+	if explicit.Confidence != 0.6 {
+		t.Errorf("explicit confidence = %v, want 0.6", explicit.Confidence)
+	}
+
+	// A personal fact stored before the policy has no expiry row; the
+	// sweep still retires it once it is past the retention.
+	old := "fact:old-personal"
+	if _, err := client.RawQuery(ctx, fmt.Sprintf(
+		`?[id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at, namespace] <- [['%s', 'User lived in Rome', 'personal', 0.8, '', '', true, %d, %d, 'default']] :put mie_fact { id => content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at, namespace }`,
+		old, now.Unix()-40*secondsPerDay, now.Unix()-40*secondsPerDay)); err != nil {
+		t.Fatalf("insert old fact: %v", err)
+	}
+
+	expired, err := client.writer.ExpireFacts(ctx, now)
+	if err != nil {
+		t.Fatalf("ExpireFacts: %v", err)
+	}
+	if ids := expired["default"]; len(ids) != 1 || ids[0] != old {
+		t.Errorf("expired = %v, want only %s", expired, old)
+	}
+	history, err := client.GetNodeHistory(ctx, old)
+	if err != nil {
+		t.Fatalf("GetNodeHistory: %v", err)
+	}
+	if len(history) != 1 || history[0].Reason != "retention" {
+		t.Errorf("history = %+v, want one retention change", history)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// GCOptions configures a garbage collection run.
+type GCOptions struct {
+	DryRun bool // report what would be removed without removing it
+}
+
+// GCResult lists what a garbage collection run found and, unless DryRun,
+// removed.
+type GCResult struct {
+	DryRun bool `json:"dry_run"`
+	// DanglingEdges counts edges per edge table whose source or target node
+	// does not exist.
+	DanglingEdges map[string]int `json:"dangling_edges"`
+	// OrphanedEmbeddings counts rows per embedding table whose node does not
+	// exist.
+	OrphanedEmbeddings map[string]int `json:"orphaned_embeddings"`
+	// UnusedTopics lists the topics of the namespace that no existing fact,
+	// decision, or entity links to.
+	UnusedTopics []tools.Topic `json:"unused_topics"`
+}
+
+// Total returns the number of records found.
+func (r *GCResult) Total() int {
+	total := len(r.UnusedTopics)
+	for _, n := range r.DanglingEdges {
+		total += n
+	}
+	for _, n := range r.OrphanedEmbeddings {
+		total += n
+	}
+	return total
+}
+
+// GC finds dangling edges, orphaned embeddings, and unused topics, and
+// removes them in one transaction unless opts.DryRun is set. Edge and
+// embedding tables are shared, so those are collected in every namespace;
+// unused topics only in the Writer's.
+func (w *Writer) GC(ctx context.Context, opts GCOptions) (*GCResult, error) {
+	result := &GCResult{
+		DryRun:             opts.DryRun,
+		DanglingEdges:      map[string]int{},
+		OrphanedEmbeddings: map[string]int{},
+	}
+	var blocks []string
+	var forget []func()
+
+	for _, table := range sortedEdgeTables() {
+		n, err := w.count(ctx, orphanedEdgesRule(table)+"\n?[count(k0)] := orphan[k0, k1]")
+		if err != nil {
+			return nil, fmt.Errorf("count dangling %s edges: %w", table, err)
+		}
+		if n == 0 {
+			continue
+		}
+		result.DanglingEdges[table] = n
+		keyCols := ValidEdgeTables[table]
+		blocks = append(blocks, fmt.Sprintf(`{
+    %[4]s
+    ?[%[1]s, %[2]s] := orphan[%[1]s, %[2]s]
+    :rm %[3]s { %[1]s, %[2]s }
+}`, keyCols[0], keyCols[1], table, orphanedEdgesRule(table)))
+	}
+
+	for _, nodeType := range backfillNodeTypes {
+		table := nodeTypeToEmbeddingTable(nodeType)
+		rule := fmt.Sprintf(`orphan[%[2]s] := *%[1]s { %[2]s }, not *%[3]s { id: %[2]s }`,
+			table, nodeType+"_id", nodeTypeToTable(nodeType))
+		n, err := w.count(ctx, rule+fmt.Sprintf("\n?[count(%[1]s)] := orphan[%[1]s]", nodeType+"_id"))
+		if err != nil {
+			return nil, fmt.Errorf("count orphaned %s rows: %w", table, err)
+		}
+		if n == 0 {
+			continue
+		}
+		result.OrphanedEmbeddings[table] = n
+		if !opts.DryRun {
+			forget = append(forget, w.forgetVectors(ctx, nodeType, rule+fmt.Sprintf("\n?[%[1]s] := orphan[%[1]s]", nodeType+"_id"), nil))
+		}
+		blocks = append(blocks, fmt.Sprintf(`{
+    %[3]s
+    ?[%[2]s] := orphan[%[2]s]
+    :rm %[1]s { %[2]s }
+}`, table, nodeType+"_id", rule))
+	}
+
+	topics, err := w.unusedTopics(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result.UnusedTopics = topics
+	var params map[string]any
+	if len(topics) > 0 {
+		ids := make([]string, len(topics))
+		for i, t := range topics {
+			ids[i] = t.ID
+		}
+		params = map[string]any{"topic_ids": idRows(ids)}
+		blocks = append(blocks, `{
+    ?[node_id] <- $topic_ids
+    :rm mie_archived { node_id }
+}`, `{
+    ?[id] <- $topic_ids
+    :rm mie_topic { id }
+}`)
+	}
+
+	if opts.DryRun || len(blocks) == 0 {
+		return result, nil
+	}
+	if err := w.backend.Execute(ctx, strings.Join(blocks, "\n"), params); err != nil {
+		return nil, fmt.Errorf("garbage collect: %w", err)
+	}
+	for _, f := range forget {
+		f()
+	}
+	return result, nil
+}
+
+// unusedTopics returns the topics of the namespace without an edge from an
+// existing node. Dangling edges do not keep a topic in use.
+func (w *Writer) unusedTopics(ctx context.Context) ([]tools.Topic, error) {
+	var rules []string
+	for _, table := range sortedEdgeTables() {
+		endpoints := edgeTableEndpoints[table]
+		if endpoints[1] != "topic" {
+			continue
+		}
+		keyCols := ValidEdgeTables[table]
+		rules = append(rules, fmt.Sprintf(`used[topic_id] := *%s { %s: node_id, topic_id }, *%s { id: node_id }`,
+			table, keyCols[0], nodeTypeToTable(endpoints[0])))
+	}
+	script := fmt.Sprintf(`%s
+?[id, name, description, created_at, updated_at] := *mie_topic { id, name, description, created_at, updated_at, namespace },
+    namespace = $ns, not used[id]
+:order name`, strings.Join(rules, "\n"))
+
+	qr, err := w.backend.Query(ctx, script, map[string]any{"ns": resolveNamespace(ctx, w.namespace)})
+	if err != nil {
+		return nil, fmt.Errorf("find unused topics: %w", err)
+	}
+	topics := make([]tools.Topic, 0, len(qr.Rows))
+	for _, row := range qr.Rows {
+		topics = append(topics, tools.Topic{
+			ID:          toString(row[0]),
+			Name:        toString(row[1]),
+			Description: toString(row[2]),
+			CreatedAt:   toInt64(row[3]),
+			UpdatedAt:   toInt64(row[4]),
+		})
+	}
+	return topics, nil
+}
+
+// count runs a script that returns a single count.
+func (w *Writer) count(ctx context.Context, script string) (int, error) {
+	qr, err := w.backend.Query(ctx, script, nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(qr.Rows) == 0 {
+		return 0, nil
+	}
+	return toInt(qr.Rows[0][0]), nil
+}
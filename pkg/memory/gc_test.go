@@ -0,0 +1,81 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestWriterGC(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	ctx := context.Background()
+
+	fact, _ := w.StoreFact(ctx, tools.StoreFactRequest{Content: "Uses Go", Category: "technical"})
+	used, _ := w.StoreTopic(ctx, tools.StoreTopicRequest{Name: "languages"})
+	unused, _ := w.StoreTopic(ctx, tools.StoreTopicRequest{Name: "hiring"})
+	stale, _ := w.StoreTopic(ctx, tools.StoreTopicRequest{Name: "legacy"})
+	if err := w.AddRelationship(ctx, "mie_fact_topic", map[string]string{"fact_id": fact.ID, "topic_id": used.ID}); err != nil {
+		t.Fatalf("AddRelationship failed: %v", err)
+	}
+	// An edge from a deleted fact does not keep "legacy" in use.
+	if err := backend.Execute(ctx, `?[fact_id, topic_id] <- [['fact:gone', '`+stale.ID+`']] :put mie_fact_topic { fact_id, topic_id }`, nil); err != nil {
+		t.Fatalf("insert dangling edge: %v", err)
+	}
+	vec := NewMockEmbeddingProvider(384, nil).generateDeterministic("Uses Go")
+	if err := w.putEmbedding(ctx, "fact", "fact:gone", vec); err != nil {
+		t.Fatalf("insert orphaned embedding: %v", err)
+	}
+	if err := w.putEmbedding(ctx, "fact", fact.ID, vec); err != nil {
+		t.Fatalf("insert embedding: %v", err)
+	}
+
+	result, err := w.GC(ctx, GCOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("GC dry run failed: %v", err)
+	}
+	if result.DanglingEdges["mie_fact_topic"] != 1 || result.OrphanedEmbeddings["mie_fact_embedding"] != 1 {
+		t.Errorf("dry run found edges %v, embeddings %v", result.DanglingEdges, result.OrphanedEmbeddings)
+	}
+	if len(result.UnusedTopics) != 2 || result.UnusedTopics[0].ID != unused.ID || result.UnusedTopics[1].ID != stale.ID {
+		t.Errorf("unused topics = %+v, want hiring and legacy", result.UnusedTopics)
+	}
+	if result.Total() != 4 {
+		t.Errorf("Total() = %d, want 4", result.Total())
+	}
+
+	// A dry run removes nothing.
+	again, _ := w.GC(ctx, GCOptions{DryRun: true})
+	if again.Total() != 4 {
+		t.Fatalf("dry run removed records: %+v", again)
+	}
+
+	if _, err := w.GC(ctx, GCOptions{}); err != nil {
+		t.Fatalf("GC failed: %v", err)
+	}
+	after, _ := w.GC(ctx, GCOptions{DryRun: true})
+	if after.Total() != 0 {
+		t.Errorf("records remain after GC: %+v", after)
+	}
+	topics, err := backend.Query(ctx, `?[id] := *mie_topic { id }`, nil)
+	if err != nil {
+		t.Fatalf("query topics: %v", err)
+	}
+	if len(topics.Rows) != 1 || toString(topics.Rows[0][0]) != used.ID {
+		t.Errorf("topics after GC = %v, want only %s", topics.Rows, used.ID)
+	}
+	embeddings, _ := backend.Query(ctx, `?[fact_id] := *mie_fact_embedding { fact_id }`, nil)
+	if len(embeddings.Rows) != 1 {
+		t.Errorf("expected the valid embedding to survive, got %v", embeddings.Rows)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// MergeGraph merges an export taken from another copy of the memory, such as
+// one kept on another machine, into the client's namespace without
+// duplicating the nodes both copies hold. See tools.MergeExports for how
+// nodes are matched and reconciled. With dryRun, nothing is written and the
+// returned merge reports what would be.
+func (c *Client) MergeGraph(ctx context.Context, data *tools.ExportData, dryRun bool) (*tools.GraphMerge, error) {
+	local, err := c.ExportGraph(ctx, tools.ExportOptions{Format: "json"})
+	if err != nil {
+		return nil, fmt.Errorf("export local graph: %w", err)
+	}
+	merge := tools.MergeExports(local, exportInNamespace(data, local.Namespace))
+	if dryRun {
+		return merge, nil
+	}
+	if _, err := c.ImportGraph(ctx, merge.Data); err != nil {
+		return merge, fmt.Errorf("write merged graph: %w", err)
+	}
+	return merge, nil
+}
+
+// DiffImport reports what ImportGraph would change when importing data into
+// the client's namespace, without writing anything. See tools.DiffImport.
+func (c *Client) DiffImport(ctx context.Context, data *tools.ExportData) (*tools.ImportDiff, error) {
+	local, err := c.ExportGraph(ctx, tools.ExportOptions{Format: "json"})
+	if err != nil {
+		return nil, fmt.Errorf("export local graph: %w", err)
+	}
+	return tools.DiffImport(local, exportInNamespace(data, local.Namespace)), nil
+}
+
+// exportInNamespace returns a copy of data with node IDs scoped to ns the
+// way ImportGraph scopes them, and nodes without an ID given the ID their
+// Store method would assign, so they can be matched against a graph in ns.
+func exportInNamespace(data *tools.ExportData, ns string) *tools.ExportData {
+	source := data.Namespace
+	if source == "" {
+		source = tools.DefaultNamespace
+	}
+	remap := func(id string) string {
+		if id == "" || source == ns {
+			return id
+		}
+		return NamespacedID(id, ns)
+	}
+
+	out := *data
+	out.Namespace = ns
+	out.Facts = make([]tools.Fact, len(data.Facts))
+	for i, f := range data.Facts {
+		if f.ID == "" {
+			f.ID = importedFactID(f)
+		}
+		f.ID = remap(f.ID)
+		out.Facts[i] = f
+	}
+	out.Decisions = make([]tools.Decision, len(data.Decisions))
+	for i, d := range data.Decisions {
+		if d.ID == "" {
+			d.ID = DecisionID(d.Title, d.Rationale)
+		}
+		d.ID = remap(d.ID)
+		out.Decisions[i] = d
+	}
+	out.Entities = make([]tools.Entity, len(data.Entities))
+	for i, e := range data.Entities {
+		if e.ID == "" {
+			e.ID = EntityID(e.Name, e.Kind)
+		}
+		e.ID = remap(e.ID)
+		out.Entities[i] = e
+	}
+	out.Events = make([]tools.Event, len(data.Events))
+	for i, e := range data.Events {
+		if e.ID == "" {
+			e.ID = EventID(e.Title, e.EventDate)
+		}
+		e.ID = remap(e.ID)
+		out.Events[i] = e
+	}
+	out.Topics = make([]tools.Topic, len(data.Topics))
+	for i, t := range data.Topics {
+		if t.ID == "" {
+			t.ID = TopicID(t.Name)
+		}
+		t.ID = remap(t.ID)
+		out.Topics[i] = t
+	}
+
+	out.Edges = make(map[string][]map[string]string, len(data.Edges))
+	for edgeType, rows := range data.Edges {
+		keyCols := ValidEdgeTables["mie_"+edgeType]
+		for _, row := range rows {
+			fields := make(map[string]string, len(row))
+			for col, val := range row {
+				fields[col] = val
+			}
+			for _, col := range keyCols {
+				fields[col] = remap(fields[col])
+			}
+			out.Edges[edgeType] = append(out.Edges[edgeType], fields)
+		}
+	}
+	out.Aliases = make([]tools.EntityAlias, len(data.Aliases))
+	for i, a := range data.Aliases {
+		out.Aliases[i] = tools.EntityAlias{Alias: a.Alias, EntityID: remap(a.EntityID)}
+	}
+	out.Archived = make([]string, len(data.Archived))
+	for i, id := range data.Archived {
+		out.Archived[i] = remap(id)
+	}
+	return &out
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestMergeGraph(t *testing.T) {
+	open := func() *Client {
+		client, err := NewClient(ClientConfig{DataDir: t.TempDir(), StorageEngine: "mem", EmbeddingDimensions: 384})
+		if err != nil {
+			t.Fatalf("NewClient failed: %v", err)
+		}
+		t.Cleanup(func() { _ = client.Close() })
+		return client
+	}
+	desktop, laptop := open(), open()
+	ctx := context.Background()
+
+	// Both machines know Alice; the laptop also stored a fact about her.
+	alice, err := desktop.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Alice", Kind: "person"})
+	if err != nil {
+		t.Fatalf("StoreEntity: %v", err)
+	}
+	if _, err := laptop.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Alice", Kind: "person"}); err != nil {
+		t.Fatalf("StoreEntity: %v", err)
+	}
+	fact, err := laptop.StoreFact(ctx, tools.StoreFactRequest{Content: "Alice owns billing", Category: "relationship"})
+	if err != nil {
+		t.Fatalf("StoreFact: %v", err)
+	}
+	if err := laptop.AddRelationship(ctx, "mie_fact_entity", map[string]string{"fact_id": fact.ID, "entity_id": alice.ID}); err != nil {
+		t.Fatalf("AddRelationship: %v", err)
+	}
+
+	data, err := laptop.ExportGraph(ctx, tools.ExportOptions{Format: "json"})
+	if err != nil {
+		t.Fatalf("ExportGraph: %v", err)
+	}
+
+	preview, err := desktop.MergeGraph(ctx, data, true)
+	if err != nil {
+		t.Fatalf("MergeGraph dry run: %v", err)
+	}
+	if preview.Added["facts"] != 1 || preview.Unchanged["entities"] != 1 {
+		t.Errorf("dry run = %+v, want 1 new fact and Alice unchanged", preview)
+	}
+	if node, _ := desktop.GetNodeByID(ctx, fact.ID); node != nil {
+		t.Fatal("dry run wrote the fact")
+	}
+
+	if _, err := desktop.MergeGraph(ctx, data, false); err != nil {
+		t.Fatalf("MergeGraph: %v", err)
+	}
+	stats, err := desktop.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("GetStats: %v", err)
+	}
+	if stats.TotalEntities != 1 || stats.TotalFacts != 1 {
+		t.Errorf("after merge: %d entities, %d facts; want 1 and 1", stats.TotalEntities, stats.TotalFacts)
+	}
+	entities, err := desktop.GetRelatedEntities(ctx, fact.ID)
+	if err != nil || len(entities) != 1 || entities[0].ID != alice.ID {
+		t.Errorf("related entities = %+v (%v), want Alice", entities, err)
+	}
+}
+
+func TestDiffImport(t *testing.T) {
+	client, err := NewClient(ClientConfig{DataDir: t.TempDir(), StorageEngine: "mem", EmbeddingDimensions: 384})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	if _, err := client.StoreFact(ctx, tools.StoreFactRequest{Content: "Uses Go", Category: "technical"}); err != nil {
+		t.Fatalf("StoreFact: %v", err)
+	}
+	data, err := client.ExportGraph(ctx, tools.ExportOptions{Format: "json"})
+	if err != nil {
+		t.Fatalf("ExportGraph: %v", err)
+	}
+	data.Facts = append(data.Facts,
+		tools.Fact{ID: "fact:copy", Content: "uses go", Category: "general", Valid: true},
+		tools.Fact{ID: "fact:new", Content: "Deploys on Fridays", Category: "general", Valid: true})
+
+	diff, err := client.DiffImport(ctx, data)
+	if err != nil {
+		t.Fatalf("DiffImport: %v", err)
+	}
+	if diff.New["facts"] != 2 || diff.Updated["facts"] != 1 || diff.Duplicates["facts"] != 1 {
+		t.Errorf("diff = %+v, want 2 new facts, 1 of them a duplicate, and 1 updated", diff)
+	}
+	stats, err := client.GetStats(ctx)
+	if err != nil || stats.TotalFacts != 1 {
+		t.Errorf("DiffImport wrote facts: %+v (%v)", stats, err)
+	}
+}
@@ -5,8 +5,15 @@
 package memory
 
 import (
+	"context"
+	"crypto/sha256"
 	"fmt"
+	"math"
+	"slices"
 	"strings"
+	"unicode"
+
+	"github.com/kraklabs/mie/pkg/tools"
 )
 
 // ValidFactCategories lists valid categories for facts.
@@ -32,11 +39,22 @@ var ValidEntityKinds = []string{
 
 // ValidDecisionStatuses lists valid statuses for decisions.
 var ValidDecisionStatuses = []string{
+	"proposed",
 	"active",
 	"superseded",
 	"reversed",
 }
 
+// DecisionStatusTransitions maps each decision status to the statuses it
+// can change to. A proposed decision is adopted or rejected; an active one
+// is superseded or reversed. Superseded and reversed are final.
+var DecisionStatusTransitions = map[string][]string{
+	"proposed":   {"active", "reversed"},
+	"active":     {"superseded", "reversed"},
+	"superseded": nil,
+	"reversed":   nil,
+}
+
 // ValidEntityRoles lists valid roles for decision-entity relationships.
 var ValidEntityRoles = []string{
 	"subject",
@@ -56,6 +74,32 @@ var ValidEdgeTables = map[string][]string{
 	"mie_entity_topic":    {"entity_id", "topic_id"},
 }
 
+// edgeTableEndpoints maps edge table names to the node types of their key
+// columns, in ValidEdgeTables order.
+var edgeTableEndpoints = map[string][2]string{
+	"mie_invalidates":     {"fact", "fact"},
+	"mie_decision_topic":  {"decision", "topic"},
+	"mie_decision_entity": {"decision", "entity"},
+	"mie_event_decision":  {"event", "decision"},
+	"mie_fact_entity":     {"fact", "entity"},
+	"mie_fact_topic":      {"fact", "topic"},
+	"mie_entity_topic":    {"entity", "topic"},
+}
+
+// edgeValueColumns lists the non-key columns of edge tables that have them,
+// other than edgeMetadataColumns.
+var edgeValueColumns = map[string][]string{
+	"mie_invalidates":     {"reason"},
+	"mie_decision_entity": {"role"},
+}
+
+// edgeMetadataColumns are the value columns every edge table has.
+var edgeMetadataColumns = []string{"weight", "source_agent", "created_at"}
+
+// MaxAttachmentSize is the largest attachment Attach accepts. Attachments
+// are meant for small artifacts such as diagrams and config snippets.
+const MaxAttachmentSize = 1 << 20
+
 func isValidCategory(cat string) bool {
 	for _, c := range ValidFactCategories {
 		if c == cat {
@@ -83,6 +127,37 @@ func isValidDecisionStatus(status string) bool {
 	return false
 }
 
+// CheckDecisionTransition returns an error if a decision cannot change
+// from status from to status to. Keeping the same status is allowed.
+func CheckDecisionTransition(from, to string) error {
+	if from == to {
+		return nil
+	}
+	allowed, known := DecisionStatusTransitions[from]
+	if !known || slices.Contains(allowed, to) {
+		return nil
+	}
+	if len(allowed) == 0 {
+		return fmt.Errorf("cannot change status from %s to %s: %s is a final status", from, to, from)
+	}
+	return fmt.Errorf("cannot change status from %s to %s; %s decisions can become: %s", from, to, from, strings.Join(allowed, ", "))
+}
+
+// PreferenceKey normalizes the key of a preference fact. Keys are trimmed
+// and compared without case, so "Editor" and "editor" name one setting.
+func PreferenceKey(key string) string {
+	return strings.ToLower(strings.TrimSpace(key))
+}
+
+// CheckFactKey returns an error if a fact of the given category cannot
+// have key: only preference facts have keys.
+func CheckFactKey(category, key string) error {
+	if key != "" && category != "preference" {
+		return fmt.Errorf("key %q requires category preference, got %q", key, category)
+	}
+	return nil
+}
+
 func isValidEntityRole(role string) bool {
 	for _, r := range ValidEntityRoles {
 		if r == role {
@@ -92,31 +167,108 @@ func isValidEntityRole(role string) bool {
 	return false
 }
 
-// formatVector converts a float32 slice to CozoDB vec() format.
-// Example output: "[0.123000, -0.456000, 0.789000]"
-func formatVector(v []float32) string {
-	if len(v) == 0 {
-		return "[]"
+// columnsForNodeType returns the column list for a given node type.
+func columnsForNodeType(nodeType string) string {
+	switch nodeType {
+	case "fact":
+		return "id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at"
+	case "decision":
+		return "id, title, rationale, alternatives, context, source_agent, source_conversation, status, created_at, updated_at"
+	case "entity":
+		return "id, name, kind, description, source_agent, created_at, updated_at"
+	case "event":
+		return "id, title, description, event_date, source_agent, source_conversation, created_at, updated_at"
+	case "topic":
+		return "id, name, description, created_at, updated_at"
+	default:
+		return "id"
 	}
-	var sb strings.Builder
-	sb.WriteString("[")
-	for i, f := range v {
-		if i > 0 {
-			sb.WriteString(", ")
+}
+
+// CheckSortColumn returns an error unless sortBy is one of the columns of
+// nodeType that ListNodes returns, the only values it may sort by.
+func CheckSortColumn(nodeType, sortBy string) error {
+	columns := columnsForNodeType(nodeType)
+	if !slices.Contains(strings.Split(columns, ", "), sortBy) {
+		return fmt.Errorf("invalid sort_by %q for %s: must be one of %s", sortBy, nodeType, columns)
+	}
+	return nil
+}
+
+// ValidAttachmentHash reports whether hash is a lower-case hex SHA-256.
+func ValidAttachmentHash(hash string) bool {
+	if len(hash) != sha256.Size*2 {
+		return false
+	}
+	for _, r := range hash {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// paramList returns the placeholders "$a, $b" of the parameters named
+// names, for a row of a constant rule.
+func paramList(names []string) string {
+	return "$" + strings.Join(names, ", $")
+}
+
+// idRows returns ids as the rows of a one-column relation, to bind as the
+// parameter of a constant rule such as "ids[id] <- $ids".
+func idRows(ids []string) [][]string {
+	rows := make([][]string, len(ids))
+	for i, id := range ids {
+		rows[i] = []string{id}
+	}
+	return rows
+}
+
+// foldExpr returns a Datalog expression for the string expression expr
+// lowercased and with its accents removed, so that "Reunión" and "reunion"
+// compare equal. Accents are the combining diacritical marks left by NFD
+// normalization; marks of other scripts are kept.
+func foldExpr(expr string) string {
+	return fmt.Sprintf(`regex_replace_all(unicode_normalize(lowercase(%s), 'nfd'), '[\\x{0300}-\\x{036f}]', '')`, expr)
+}
+
+// sinceCondition returns a Datalog condition, with a leading comma, keeping
+// rows whose col is at or after since (Unix seconds), or "" if since is 0.
+func sinceCondition(col string, since int64) string {
+	if since <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(", %s >= %d", col, since)
+}
+
+// ftsQuery turns free text into a CozoDB full-text query that matches any of
+// its words. Punctuation and FTS operators in user input are dropped so the
+// query always parses; words are lowercased so AND, OR, and NOT are searched
+// as terms. Returns "" if the text has no words.
+func ftsQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	seen := make(map[string]bool, len(words))
+	terms := words[:0]
+	for _, w := range words {
+		if !seen[w] {
+			seen[w] = true
+			terms = append(terms, w)
 		}
-		sb.WriteString(fmt.Sprintf("%f", f))
 	}
-	sb.WriteString("]")
-	return sb.String()
+	return strings.Join(terms, " OR ")
 }
 
-// escapeDatalog escapes a string for safe embedding in single-quoted Datalog queries.
-// CozoDB single-quoted strings support \' for literal single quotes and \\ for backslashes.
-// Double quotes do not need escaping inside single-quoted strings.
-func escapeDatalog(s string) string {
-	s = strings.ReplaceAll(s, `\`, `\\`)
-	s = strings.ReplaceAll(s, `'`, `\'`)
-	return s
+// decayFactor returns the weight of a fact created at createdAt, as of now
+// (both Unix seconds): 1 for a new fact, halving every halfLifeDays. A
+// non-positive halfLifeDays disables decay.
+func decayFactor(createdAt, now int64, halfLifeDays float64) float64 {
+	if halfLifeDays <= 0 || createdAt <= 0 || now <= createdAt {
+		return 1
+	}
+	ageDays := float64(now-createdAt) / (24 * 60 * 60)
+	return math.Pow(0.5, ageDays/halfLifeDays)
 }
 
 // nodeTypeToTable maps a node type string to its CozoDB table name.
@@ -167,4 +319,16 @@ func nodeTypeToHNSWIndex(nodeType string) string {
 	default:
 		return ""
 	}
-}
\ No newline at end of file
+}
+
+// resolveNamespace returns the namespace carried by ctx, falling back to the
+// configured namespace and then to tools.DefaultNamespace.
+func resolveNamespace(ctx context.Context, fallback string) string {
+	if ns := tools.NamespaceFromContext(ctx); ns != "" {
+		return ns
+	}
+	if fallback != "" {
+		return fallback
+	}
+	return tools.DefaultNamespace
+}
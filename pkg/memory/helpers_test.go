@@ -5,7 +5,11 @@
 package memory
 
 import (
+	"context"
+	"math"
 	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
 )
 
 func TestIsValidCategory(t *testing.T) {
@@ -29,6 +33,28 @@ func TestIsValidCategory(t *testing.T) {
 	}
 }
 
+func TestCheckSortColumn(t *testing.T) {
+	for _, tt := range []struct {
+		nodeType, sortBy string
+		ok               bool
+	}{
+		{"fact", "created_at", true},
+		{"fact", "content", true},
+		{"entity", "name", true},
+		{"fact", "name", false},
+		{"topic", "event_date", false},
+		{"fact", "created_at :limit 1000 } ?[id] := *mie_fact { id", false},
+		{"fact", "created_at, -content", false},
+		{"fact", "", false},
+		{"widget", "created_at", false},
+	} {
+		err := CheckSortColumn(tt.nodeType, tt.sortBy)
+		if (err == nil) != tt.ok {
+			t.Errorf("CheckSortColumn(%q, %q) = %v, want ok %v", tt.nodeType, tt.sortBy, err, tt.ok)
+		}
+	}
+}
+
 func TestIsValidEntityKind(t *testing.T) {
 	if !isValidEntityKind("person") {
 		t.Error("'person' should be valid")
@@ -47,6 +73,44 @@ func TestIsValidDecisionStatus(t *testing.T) {
 	}
 }
 
+func TestCheckDecisionTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		ok       bool
+	}{
+		{"proposed", "active", true},
+		{"proposed", "reversed", true},
+		{"proposed", "superseded", false},
+		{"active", "superseded", true},
+		{"active", "reversed", true},
+		{"active", "proposed", false},
+		{"active", "active", true},
+		{"superseded", "active", false},
+		{"reversed", "superseded", false},
+	}
+	for _, tt := range tests {
+		err := CheckDecisionTransition(tt.from, tt.to)
+		if (err == nil) != tt.ok {
+			t.Errorf("CheckDecisionTransition(%q, %q) = %v, want ok %v", tt.from, tt.to, err, tt.ok)
+		}
+	}
+}
+
+func TestPreferenceKey(t *testing.T) {
+	if got := PreferenceKey("  Editor "); got != "editor" {
+		t.Errorf("PreferenceKey = %q, want editor", got)
+	}
+	if err := CheckFactKey("preference", "editor"); err != nil {
+		t.Errorf("CheckFactKey(preference) = %v", err)
+	}
+	if err := CheckFactKey("general", ""); err != nil {
+		t.Errorf("CheckFactKey without key = %v", err)
+	}
+	if err := CheckFactKey("technical", "editor"); err == nil {
+		t.Error("CheckFactKey(technical, editor) should fail")
+	}
+}
+
 func TestIsValidEntityRole(t *testing.T) {
 	if !isValidEntityRole("subject") {
 		t.Error("'subject' should be valid")
@@ -56,35 +120,73 @@ func TestIsValidEntityRole(t *testing.T) {
 	}
 }
 
-func TestFormatVector(t *testing.T) {
-	vec := []float32{0.1, -0.5, 0.9}
-	result := formatVector(vec)
-	if result == "" {
-		t.Error("formatVector returned empty string")
+func TestParamList(t *testing.T) {
+	if got := paramList([]string{"fact_id", "entity_id"}); got != "$fact_id, $entity_id" {
+		t.Errorf("paramList = %q", got)
 	}
-	if result[0] != '[' || result[len(result)-1] != ']' {
-		t.Errorf("formatVector should wrap in brackets: %s", result)
+}
+
+func TestIDRows(t *testing.T) {
+	got := idRows([]string{"fact:1", "it's"})
+	if len(got) != 2 || len(got[0]) != 1 || got[0][0] != "fact:1" || got[1][0] != "it's" {
+		t.Errorf("idRows = %v", got)
 	}
+}
 
-	empty := formatVector(nil)
-	if empty != "[]" {
-		t.Errorf("formatVector(nil) should return '[]': %s", empty)
+func TestFoldExpr(t *testing.T) {
+	want := `regex_replace_all(unicode_normalize(lowercase(name), 'nfd'), '[\\x{0300}-\\x{036f}]', '')`
+	if got := foldExpr("name"); got != want {
+		t.Errorf("foldExpr(name) = %s, want %s", got, want)
 	}
 }
 
-func TestEscapeDatalog(t *testing.T) {
+func TestSinceCondition(t *testing.T) {
+	if got := sinceCondition("updated_at", 0); got != "" {
+		t.Errorf("sinceCondition(0) = %q, want empty", got)
+	}
+	if got := sinceCondition("updated_at", 1700000000); got != ", updated_at >= 1700000000" {
+		t.Errorf("sinceCondition(1700000000) = %q", got)
+	}
+}
+
+func TestDecayFactor(t *testing.T) {
+	const day = 24 * 60 * 60
+	now := int64(1_800_000_000)
+	tests := []struct {
+		name      string
+		createdAt int64
+		halfLife  float64
+		want      float64
+	}{
+		{"disabled", now - 100*day, 0, 1},
+		{"new fact", now, 30, 1},
+		{"one half-life", now - 30*day, 30, 0.5},
+		{"two half-lives", now - 60*day, 30, 0.25},
+		{"future timestamp", now + day, 30, 1},
+		{"unknown creation time", 0, 30, 1},
+	}
+	for _, tt := range tests {
+		if got := decayFactor(tt.createdAt, now, tt.halfLife); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: decayFactor = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFTSQuery(t *testing.T) {
 	tests := []struct {
 		input string
 		want  string
 	}{
-		{"hello", "hello"},
-		{`say "hi"`, `say "hi"`},
-		{`it's fine`, `it\'s fine`},
-		{`path\to\file`, `path\\to\\file`},
+		{"postgres", "postgres"},
+		{"Why Postgres, not MySQL?", "why OR postgres OR not OR mysql"},
+		{`"quoted" AND title:* -x`, "quoted OR and OR title OR x"},
+		{"go go Go", "go"},
+		{"café 2026", "café OR 2026"},
+		{"?!", ""},
 	}
 	for _, tt := range tests {
-		if got := escapeDatalog(tt.input); got != tt.want {
-			t.Errorf("escapeDatalog(%q) = %q, want %q", tt.input, got, tt.want)
+		if got := ftsQuery(tt.input); got != tt.want {
+			t.Errorf("ftsQuery(%q) = %q, want %q", tt.input, got, tt.want)
 		}
 	}
 }
@@ -124,4 +226,36 @@ func TestNodeTypeToHNSWIndex(t *testing.T) {
 	if got := nodeTypeToHNSWIndex("topic"); got != "" {
 		t.Errorf("topic should not have HNSW index: %s", got)
 	}
-}
\ No newline at end of file
+}
+
+func TestResolveNamespace(t *testing.T) {
+	ctx := context.Background()
+	if got := resolveNamespace(ctx, ""); got != "default" {
+		t.Errorf("resolveNamespace(no ctx, no fallback) = %q, want %q", got, "default")
+	}
+	if got := resolveNamespace(ctx, "work"); got != "work" {
+		t.Errorf("resolveNamespace(fallback) = %q, want %q", got, "work")
+	}
+	scoped := tools.WithNamespace(ctx, "personal")
+	if got := resolveNamespace(scoped, "work"); got != "personal" {
+		t.Errorf("resolveNamespace(ctx) = %q, want %q", got, "personal")
+	}
+}
+
+func TestValidAttachmentHash(t *testing.T) {
+	tests := []struct {
+		hash string
+		want bool
+	}{
+		{"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", true},
+		{"2CF24DBA5FB0A30E26E83B2AC5B9E29E1B161E5C1FA7425E73043362938B9824", false},
+		{"2cf24dba5fb0a30e", false},
+		{"../../../../etc/passwd", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := ValidAttachmentHash(tt.hash); got != tt.want {
+			t.Errorf("ValidAttachmentHash(%q) = %v, want %v", tt.hash, got, tt.want)
+		}
+	}
+}
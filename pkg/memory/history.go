@@ -0,0 +1,109 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// historySeq disambiguates history entries recorded in the same nanosecond.
+var historySeq atomic.Uint64
+
+// historyChange is a change to one field of a node.
+type historyChange struct {
+	nodeID    string
+	field     string
+	oldValue  string
+	newValue  string
+	reason    string
+	relatedID string
+	namespace string
+}
+
+// recordHistory appends changes to mie_history, attributed to the tool and
+// agent set with tools.WithAuditSource. Inside Atomic, the entries are
+// committed together with the write they record.
+func (w *Writer) recordHistory(ctx context.Context, changes ...historyChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	tool, agent := tools.AuditSourceFromContext(ctx)
+	now := time.Now()
+	rows := make([][]any, len(changes))
+	for i, c := range changes {
+		if c.namespace == "" {
+			c.namespace = resolveNamespace(ctx, w.namespace)
+		}
+		rows[i] = []any{
+			fmt.Sprintf("hist:%019d:%06d", now.UnixNano(), historySeq.Add(1)%1000000), c.nodeID, now.Unix(),
+			c.field, c.oldValue, c.newValue, c.reason, c.relatedID, tool, agent, c.namespace,
+		}
+	}
+	mutation := `?[id, node_id, at, field, old_value, new_value, reason, related_id, tool, source_agent, namespace] <- $rows
+:put mie_history { id => node_id, at, field, old_value, new_value, reason, related_id, tool, source_agent, namespace }`
+	if err := w.execute(ctx, mutation, map[string]any{"rows": rows}); err != nil {
+		return fmt.Errorf("record history: %w", err)
+	}
+	return nil
+}
+
+// currentValue returns the value of column in the row of table with id in
+// the Writer's namespace, and whether the row exists.
+func (w *Writer) currentValue(ctx context.Context, table, column, id string) (string, bool, error) {
+	qr, err := w.backend.Query(ctx, fmt.Sprintf(
+		`?[v] := *%s { id, %s: v, namespace }, id = $id, namespace = $ns`, table, column),
+		map[string]any{"id": id, "ns": resolveNamespace(ctx, w.namespace)})
+	if err != nil {
+		return "", false, fmt.Errorf("read %s of %s: %w", column, id, err)
+	}
+	if len(qr.Rows) == 0 {
+		return "", false, nil
+	}
+	return fmt.Sprint(qr.Rows[0][0]), true, nil
+}
+
+// GetNodeHistory returns the recorded changes of a node in the namespace,
+// oldest first.
+func (r *Reader) GetNodeHistory(ctx context.Context, nodeID string) ([]tools.HistoryEntry, error) {
+	result, err := r.backend.Query(ctx,
+		`?[id, at, field, old_value, new_value, reason, related_id, tool, source_agent] :=
+    *mie_history { id, node_id, at, field, old_value, new_value, reason, related_id, tool, source_agent, namespace },
+    node_id = $node_id, namespace = $ns
+:order id`,
+		map[string]any{"node_id": nodeID, "ns": resolveNamespace(ctx, r.namespace)})
+	if err != nil {
+		return nil, fmt.Errorf("read history of %s: %w", nodeID, err)
+	}
+
+	entries := make([]tools.HistoryEntry, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		entries = append(entries, tools.HistoryEntry{
+			ID:          toString(row[0]),
+			NodeID:      nodeID,
+			At:          toInt64(row[1]),
+			Field:       toString(row[2]),
+			OldValue:    toString(row[3]),
+			NewValue:    toString(row[4]),
+			Reason:      toString(row[5]),
+			RelatedID:   toString(row[6]),
+			Tool:        toString(row[7]),
+			SourceAgent: toString(row[8]),
+		})
+	}
+	return entries, nil
+}
+
+// GetNodeHistory returns the recorded changes of a node, oldest first. See
+// Reader.GetNodeHistory.
+func (c *Client) GetNodeHistory(ctx context.Context, nodeID string) ([]tools.HistoryEntry, error) {
+	return c.reader.GetNodeHistory(ctx, nodeID)
+}
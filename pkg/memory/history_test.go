@@ -0,0 +1,85 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestClientNodeHistory(t *testing.T) {
+	client, err := NewClient(ClientConfig{DataDir: t.TempDir(), StorageEngine: "mem", EmbeddingDimensions: 384})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	dec, err := client.StoreDecision(ctx, tools.StoreDecisionRequest{Title: "Use PostgreSQL", Rationale: "Mature", Status: "proposed"})
+	if err != nil {
+		t.Fatalf("StoreDecision: %v", err)
+	}
+	updateCtx := tools.WithAuditSource(context.Background(), "mie_update", "claude")
+	if err := client.UpdateStatus(tools.WithChangeReason(updateCtx, "Team agreed"), dec.ID, "active"); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+	// Setting the current status again is not a change.
+	if err := client.UpdateStatus(updateCtx, dec.ID, "active"); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+	if err := client.UpdateStatus(updateCtx, dec.ID, "superseded"); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+	// A rejected transition is not recorded.
+	if err := client.UpdateStatus(updateCtx, dec.ID, "active"); err == nil {
+		t.Fatal("expected superseded -> active to be rejected")
+	}
+
+	history, err := client.GetNodeHistory(ctx, dec.ID)
+	if err != nil {
+		t.Fatalf("GetNodeHistory: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("history = %+v, want 2 entries", history)
+	}
+	first := history[0]
+	if first.Field != "status" || first.OldValue != "proposed" || first.NewValue != "active" ||
+		first.Reason != "Team agreed" || first.Tool != "mie_update" || first.SourceAgent != "claude" {
+		t.Errorf("first entry = %+v", first)
+	}
+	if history[1].OldValue != "active" || history[1].NewValue != "superseded" || history[1].Reason != "" {
+		t.Errorf("second entry = %+v", history[1])
+	}
+
+	ent, _ := client.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Go", Kind: "technology", Description: "A language"})
+	if err := client.UpdateDescription(ctx, ent.ID, "A compiled language"); err != nil {
+		t.Fatalf("UpdateDescription: %v", err)
+	}
+	history, _ = client.GetNodeHistory(ctx, ent.ID)
+	if len(history) != 1 || history[0].Field != "description" || history[0].OldValue != "A language" || history[0].NewValue != "A compiled language" {
+		t.Errorf("entity history = %+v", history)
+	}
+
+	old, _ := client.StoreFact(ctx, tools.StoreFactRequest{Content: "User lives in Madrid", Category: "personal"})
+	replacement, _ := client.StoreFact(ctx, tools.StoreFactRequest{Content: "User lives in Lisbon", Category: "personal"})
+	if err := client.InvalidateFact(ctx, old.ID, replacement.ID, "Moved"); err != nil {
+		t.Fatalf("InvalidateFact: %v", err)
+	}
+	history, _ = client.GetNodeHistory(ctx, old.ID)
+	if len(history) != 1 || history[0].Field != "valid" || history[0].NewValue != "false" ||
+		history[0].Reason != "Moved" || history[0].RelatedID != replacement.ID {
+		t.Errorf("fact history = %+v", history)
+	}
+
+	// History is kept per namespace.
+	other, err := client.GetNodeHistory(tools.WithNamespace(ctx, "other"), dec.ID)
+	if err != nil || len(other) != 0 {
+		t.Errorf("history in other namespace = %v, %v", other, err)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"strings"
+
+	"github.com/kraklabs/mie/pkg/tools"
 )
 
 // GenerateID creates a deterministic ID from input fields.
@@ -24,6 +26,21 @@ func FactID(content, category string) string {
 	return GenerateID("fact", content, category)
 }
 
+// NegatedFactID generates a deterministic ID for an anti-fact, which must
+// not collide with the fact of the same content that it denies.
+func NegatedFactID(content, category string) string {
+	return GenerateID("fact", content, category, "negated")
+}
+
+// importedFactID returns the ID of an imported fact that has none: the ID
+// StoreFact would give it in the default namespace.
+func importedFactID(f tools.Fact) string {
+	if f.Negated {
+		return NegatedFactID(f.Content, f.Category)
+	}
+	return FactID(f.Content, f.Category)
+}
+
 // DecisionID generates a deterministic ID for a decision.
 func DecisionID(title, rationale string) string {
 	return GenerateID("dec", title, rationale)
@@ -44,4 +61,18 @@ func EventID(title, eventDate string) string {
 // Name is lowercased for case-insensitive deduplication.
 func TopicID(name string) string {
 	return GenerateID("top", strings.ToLower(name))
-}
\ No newline at end of file
+}
+
+// NamespacedID scopes a node ID to a namespace so identical content stored in
+// different namespaces does not collide. IDs in the default namespace are
+// returned unchanged, which keeps databases created before namespaces valid.
+func NamespacedID(id, namespace string) string {
+	if namespace == "" || namespace == tools.DefaultNamespace {
+		return id
+	}
+	prefix, rest, ok := strings.Cut(id, ":")
+	if !ok {
+		return GenerateID(namespace, id)
+	}
+	return GenerateID(prefix, namespace, rest)
+}
@@ -77,4 +77,29 @@ func TestTopicID(t *testing.T) {
 	if id != id2 {
 		t.Error("TopicID should be case-insensitive")
 	}
-}
\ No newline at end of file
+}
+
+func TestNamespacedID(t *testing.T) {
+	id := FactID("Uses Go", "technical")
+
+	if got := NamespacedID(id, ""); got != id {
+		t.Errorf("NamespacedID(empty) = %s, want %s", got, id)
+	}
+	if got := NamespacedID(id, "default"); got != id {
+		t.Errorf("NamespacedID(default) = %s, want %s", got, id)
+	}
+
+	scoped := NamespacedID(id, "project-a")
+	if scoped == id {
+		t.Error("NamespacedID should change IDs outside the default namespace")
+	}
+	if scoped[:5] != "fact:" {
+		t.Errorf("NamespacedID should keep the prefix, got %s", scoped)
+	}
+	if scoped != NamespacedID(id, "project-a") {
+		t.Error("NamespacedID should be deterministic")
+	}
+	if scoped == NamespacedID(id, "project-b") {
+		t.Error("NamespacedID should differ between namespaces")
+	}
+}
@@ -9,6 +9,7 @@ package memory
 import (
 	"context"
 	"fmt"
+	"math"
 	"strings"
 	"sync"
 	"testing"
@@ -278,6 +279,18 @@ func TestIntegrationSemanticSearch(t *testing.T) {
 		assert.LessOrEqual(t, results[i-1].Distance, results[i].Distance,
 			"results should be sorted by distance ascending")
 	}
+
+	// A minimum similarity drops the results beyond its distance.
+	closest := results[0].Distance
+	for _, r := range results {
+		closest = math.Min(closest, r.Distance)
+	}
+	near, err := client.SemanticSearch(tools.WithMinSimilarity(ctx, 1-closest-1e-6), "concurrency programming", []string{"fact"}, 10)
+	require.NoError(t, err)
+	require.NotEmpty(t, near)
+	for _, r := range near {
+		assert.LessOrEqual(t, r.Distance, closest+1e-6)
+	}
 }
 
 // ---------------------------------------------------------------------------
@@ -951,4 +964,95 @@ func TestIntegrationListNodesFilters(t *testing.T) {
 				"ascending sort should have earliest first")
 		}
 	})
-}
\ No newline at end of file
+}
+
+// ---------------------------------------------------------------------------
+// TestIntegrationNamespaces
+// ---------------------------------------------------------------------------
+
+func TestIntegrationNamespaces(t *testing.T) {
+	t.Parallel()
+	client := setupIntegrationClient(t, false)
+	ctx := context.Background()
+	projectA := tools.WithNamespace(ctx, "project-a")
+	projectB := tools.WithNamespace(ctx, "project-b")
+
+	factA, err := client.StoreFact(projectA, tools.StoreFactRequest{Content: "Uses PostgreSQL", Category: "technical"})
+	require.NoError(t, err)
+	factB, err := client.StoreFact(projectB, tools.StoreFactRequest{Content: "Uses PostgreSQL", Category: "technical"})
+	require.NoError(t, err)
+	assert.NotEqual(t, factA.ID, factB.ID, "identical facts in different namespaces must not collide")
+
+	_, err = client.StoreEntity(projectA, tools.StoreEntityRequest{Name: "Only in A", Kind: "project"})
+	require.NoError(t, err)
+
+	t.Run("ListIsScoped", func(t *testing.T) {
+		nodes, total, err := client.ListNodes(projectB, tools.ListOptions{NodeType: "entity", Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 0, total)
+		assert.Empty(t, nodes)
+
+		nodes, total, err = client.ListNodes(projectA, tools.ListOptions{NodeType: "entity", Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, nodes, 1)
+	})
+
+	t.Run("SearchIsScoped", func(t *testing.T) {
+		results, err := client.ExactSearch(projectA, "PostgreSQL", []string{"fact"}, 10)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, factA.ID, results[0].ID)
+
+		results, err = client.ExactSearch(ctx, "PostgreSQL", []string{"fact"}, 10)
+		require.NoError(t, err)
+		assert.Empty(t, results, "default namespace should not see project facts")
+	})
+
+	t.Run("StatsAreScoped", func(t *testing.T) {
+		stats, err := client.GetStats(projectA)
+		require.NoError(t, err)
+		assert.Equal(t, "project-a", stats.Namespace)
+		assert.Equal(t, 1, stats.TotalFacts)
+		assert.Equal(t, 1, stats.TotalEntities)
+
+		stats, err = client.GetStats(projectB)
+		require.NoError(t, err)
+		assert.Equal(t, 1, stats.TotalFacts)
+		assert.Equal(t, 0, stats.TotalEntities)
+	})
+
+	t.Run("InvalidationKeepsNamespace", func(t *testing.T) {
+		newFact, err := client.StoreFact(projectA, tools.StoreFactRequest{Content: "Uses MySQL", Category: "technical"})
+		require.NoError(t, err)
+		require.NoError(t, client.InvalidateFact(projectA, factA.ID, newFact.ID, "migrated"))
+
+		nodes, total, err := client.ListNodes(projectA, tools.ListOptions{NodeType: "fact", Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 2, total, "invalidated fact should stay in its namespace")
+		assert.Len(t, nodes, 2)
+	})
+}
+
+func TestIntegrationConfiguredNamespace(t *testing.T) {
+	t.Parallel()
+	client, err := NewClient(ClientConfig{
+		DataDir:       t.TempDir(),
+		StorageEngine: "mem",
+		Namespace:     "work",
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	ctx := context.Background()
+
+	_, err = client.StoreFact(ctx, tools.StoreFactRequest{Content: "Configured namespace fact"})
+	require.NoError(t, err)
+
+	_, total, err := client.ListNodes(tools.WithNamespace(ctx, "work"), tools.ListOptions{NodeType: "fact", Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total, "facts stored without an explicit namespace use the configured one")
+
+	_, total, err = client.ListNodes(tools.WithNamespace(ctx, "default"), tools.ListOptions{NodeType: "fact", Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+}
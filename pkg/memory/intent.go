@@ -0,0 +1,83 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// intentSeq disambiguates intents recorded in the same nanosecond.
+var intentSeq atomic.Uint64
+
+// RecordIntent adds an intent of kind with payload to mie_intent in the
+// namespace and returns its ID. Inside Atomic, the intent is committed
+// together with the writes it describes.
+func (w *Writer) RecordIntent(ctx context.Context, kind, payload string) (string, error) {
+	now := time.Now()
+	id := fmt.Sprintf("int:%019d:%06d", now.UnixNano(), intentSeq.Add(1)%1000000)
+	mutation := `?[id, kind, payload, namespace, created_at] <- [[$id, $kind, $payload, $ns, $at]] :put mie_intent { id => kind, payload, namespace, created_at }`
+	params := map[string]any{"id": id, "kind": kind, "payload": payload, "ns": resolveNamespace(ctx, w.namespace), "at": now.Unix()}
+	if err := w.execute(ctx, mutation, params); err != nil {
+		return "", fmt.Errorf("record intent: %w", err)
+	}
+	return id, nil
+}
+
+// CompleteIntent removes a finished intent from mie_intent.
+func (w *Writer) CompleteIntent(ctx context.Context, intentID string) error {
+	mutation := `?[id] <- [[$id]] :rm mie_intent { id }`
+	if err := w.execute(ctx, mutation, map[string]any{"id": intentID}); err != nil {
+		return fmt.Errorf("complete intent %s: %w", intentID, err)
+	}
+	return nil
+}
+
+// PendingIntents returns the intents in mie_intent, oldest first. It covers
+// every namespace, so a server that handles several can finish all of them
+// on startup.
+func (r *Reader) PendingIntents(ctx context.Context) ([]tools.Intent, error) {
+	result, err := r.backend.Query(ctx,
+		`?[id, kind, payload, namespace, created_at] := *mie_intent { id, kind, payload, namespace, created_at }
+:order id`, nil)
+	if err != nil {
+		return nil, fmt.Errorf("read pending intents: %w", err)
+	}
+
+	intents := make([]tools.Intent, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		intents = append(intents, tools.Intent{
+			ID:        toString(row[0]),
+			Kind:      toString(row[1]),
+			Payload:   toString(row[2]),
+			Namespace: toString(row[3]),
+			CreatedAt: toInt64(row[4]),
+		})
+	}
+	return intents, nil
+}
+
+// RecordIntent logs a multi-step write before its steps run. See
+// Writer.RecordIntent.
+func (c *Client) RecordIntent(ctx context.Context, kind, payload string) (string, error) {
+	return c.writer.RecordIntent(ctx, kind, payload)
+}
+
+// CompleteIntent removes an intent whose steps all ran.
+func (c *Client) CompleteIntent(ctx context.Context, intentID string) error {
+	return c.writer.CompleteIntent(ctx, intentID)
+}
+
+// PendingIntents returns the intents of every namespace that were recorded
+// but never completed, oldest first.
+func (c *Client) PendingIntents(ctx context.Context) ([]tools.Intent, error) {
+	return c.reader.PendingIntents(ctx)
+}
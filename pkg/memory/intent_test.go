@@ -0,0 +1,65 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestClientIntents(t *testing.T) {
+	client, err := NewClient(ClientConfig{DataDir: t.TempDir(), StorageEngine: "mem", EmbeddingDimensions: 384})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	first, err := client.RecordIntent(ctx, tools.IntentBulkLinks, `[{"node_id": "fact:a", "reason": "it's"}]`)
+	if err != nil {
+		t.Fatalf("RecordIntent: %v", err)
+	}
+	second, err := client.RecordIntent(tools.WithNamespace(ctx, "work"), tools.IntentBulkLinks, "[]")
+	if err != nil {
+		t.Fatalf("RecordIntent in another namespace: %v", err)
+	}
+
+	pending, err := client.PendingIntents(ctx)
+	if err != nil {
+		t.Fatalf("PendingIntents: %v", err)
+	}
+	if len(pending) != 2 || pending[0].ID != first || pending[1].ID != second {
+		t.Fatalf("PendingIntents = %+v, want both intents oldest first", pending)
+	}
+	if pending[0].Payload != `[{"node_id": "fact:a", "reason": "it's"}]` || pending[0].Namespace != "default" {
+		t.Errorf("first intent = %+v", pending[0])
+	}
+	if pending[1].Namespace != "work" {
+		t.Errorf("second intent namespace = %q, want work", pending[1].Namespace)
+	}
+
+	if err := client.CompleteIntent(ctx, first); err != nil {
+		t.Fatalf("CompleteIntent: %v", err)
+	}
+	pending, _ = client.PendingIntents(ctx)
+	if len(pending) != 1 || pending[0].ID != second {
+		t.Errorf("PendingIntents after completing one = %+v", pending)
+	}
+
+	// An atomic write that fails takes its intent with it.
+	_ = client.Atomic(ctx, func(ctx context.Context) error {
+		if _, err := client.RecordIntent(ctx, tools.IntentBulkLinks, "[]"); err != nil {
+			return err
+		}
+		return context.Canceled
+	})
+	if pending, _ = client.PendingIntents(ctx); len(pending) != 1 {
+		t.Errorf("a rolled back intent was recorded: %+v", pending)
+	}
+}
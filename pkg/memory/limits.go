@@ -0,0 +1,154 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// storageSizeTTL is how long the measured size of the data directory is
+// reused before it is measured again.
+const storageSizeTTL = 30 * time.Second
+
+// Limits caps the size of the memory graph. A store that finds the graph at
+// a limit fails with a LimitError. A zero field is unlimited.
+type Limits struct {
+	// MaxFacts caps the facts of a namespace, valid or invalidated.
+	MaxFacts int
+	// MaxNodes caps the facts, decisions, entities, events, and topics of
+	// a namespace together.
+	MaxNodes int
+	// MaxStorageBytes caps the size of the data directory, which all
+	// namespaces share.
+	MaxStorageBytes int64
+}
+
+// enabled reports whether any limit is set.
+func (l Limits) enabled() bool {
+	return l.MaxFacts > 0 || l.MaxNodes > 0 || l.MaxStorageBytes > 0
+}
+
+// LimitError is the error of a store refused because the memory graph
+// reached one of its Limits.
+type LimitError struct {
+	// Limit is max_facts, max_nodes, or max_storage_bytes.
+	Limit     string
+	Namespace string
+	Usage     int64
+	Max       int64
+}
+
+func (e *LimitError) Error() string {
+	var usage string
+	switch e.Limit {
+	case "max_facts":
+		usage = fmt.Sprintf("namespace %q has %d facts, the limit is %d", e.Namespace, e.Usage, e.Max)
+	case "max_nodes":
+		usage = fmt.Sprintf("namespace %q has %d nodes, the limit is %d", e.Namespace, e.Usage, e.Max)
+	default:
+		usage = fmt.Sprintf("the database uses %d bytes, the limit is %d", e.Usage, e.Max)
+	}
+	return fmt.Sprintf("memory graph is full (%s): %s. Prune memories you no longer need with mie prune, mie reset (by node type, category, or source agent), and mie gc, or raise the limit", e.Limit, usage)
+}
+
+// checkLimits returns a LimitError if storing a node of nodeType would go
+// past the configured limits. Nodes staged in the same Atomic call are not
+// counted yet, so a batch may go past a limit by its own size.
+func (c *Client) checkLimits(ctx context.Context, nodeType string) error {
+	limits := c.config.Limits
+	if !limits.enabled() {
+		return nil
+	}
+	ns := resolveNamespace(ctx, c.config.Namespace)
+	if limits.MaxFacts > 0 && nodeType == "fact" {
+		facts, err := c.countNodes(ctx, ns, "fact")
+		if err != nil {
+			return err
+		}
+		if facts >= limits.MaxFacts {
+			return &LimitError{Limit: "max_facts", Namespace: ns, Usage: int64(facts), Max: int64(limits.MaxFacts)}
+		}
+	}
+	if limits.MaxNodes > 0 {
+		nodes, err := c.countNodes(ctx, ns, snapshotNodeTypes...)
+		if err != nil {
+			return err
+		}
+		if nodes >= limits.MaxNodes {
+			return &LimitError{Limit: "max_nodes", Namespace: ns, Usage: int64(nodes), Max: int64(limits.MaxNodes)}
+		}
+	}
+	if limits.MaxStorageBytes > 0 {
+		if size := c.storageBytes(); size >= limits.MaxStorageBytes {
+			return &LimitError{Limit: "max_storage_bytes", Namespace: ns, Usage: size, Max: limits.MaxStorageBytes}
+		}
+	}
+	return nil
+}
+
+// limitStats returns the usage of the graph against the configured limits,
+// or nil when none is set.
+func (c *Client) limitStats(stats *tools.GraphStats) *tools.LimitStats {
+	limits := c.config.Limits
+	if !limits.enabled() {
+		return nil
+	}
+	return &tools.LimitStats{
+		Facts:           stats.TotalFacts,
+		MaxFacts:        limits.MaxFacts,
+		Nodes:           stats.TotalFacts + stats.TotalDecisions + stats.TotalEntities + stats.TotalEvents + stats.TotalTopics,
+		MaxNodes:        limits.MaxNodes,
+		StorageBytes:    c.storageBytes(),
+		MaxStorageBytes: limits.MaxStorageBytes,
+	}
+}
+
+// countNodes counts the nodes of the namespace of the given types.
+func (c *Client) countNodes(ctx context.Context, ns string, nodeTypes ...string) (int, error) {
+	total := 0
+	for _, nt := range nodeTypes {
+		qr, err := c.backend.Query(ctx, fmt.Sprintf(`?[count(id)] := *%s { id, namespace }, namespace = $ns`, nodeTypeToTable(nt)),
+			map[string]any{"ns": ns})
+		if err != nil {
+			return 0, fmt.Errorf("count %s nodes: %w", nt, err)
+		}
+		if len(qr.Rows) > 0 {
+			total += toInt(qr.Rows[0][0])
+		}
+	}
+	return total, nil
+}
+
+// storageBytes returns the size of the data directory, measured at most
+// every storageSizeTTL. Files that cannot be read are not counted.
+func (c *Client) storageBytes() int64 {
+	c.sizeMu.Lock()
+	defer c.sizeMu.Unlock()
+	if !c.sizeAt.IsZero() && time.Since(c.sizeAt) < storageSizeTTL {
+		return c.sizeBytes
+	}
+	var size int64
+	if c.config.DataDir != "" {
+		_ = filepath.WalkDir(c.config.DataDir, func(_ string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+			}
+			return nil
+		})
+	}
+	c.sizeBytes, c.sizeAt = size, time.Now()
+	return size
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestClientLimits(t *testing.T) {
+	client, err := NewClient(ClientConfig{
+		DataDir:       t.TempDir(),
+		StorageEngine: "mem",
+		Limits:        Limits{MaxFacts: 2, MaxNodes: 3},
+	})
+	require.NoError(t, err)
+	defer client.Close()
+	ctx := context.Background()
+
+	for _, content := range []string{"Alice leads the billing team", "Billing runs on Postgres"} {
+		_, err := client.StoreFact(ctx, tools.StoreFactRequest{Content: content, Category: "general"})
+		require.NoError(t, err)
+	}
+	_, err = client.StoreFact(ctx, tools.StoreFactRequest{Content: "Bob joined in May", Category: "general"})
+	var limitErr *LimitError
+	require.True(t, errors.As(err, &limitErr), "third fact: %v", err)
+	assert.Equal(t, "max_facts", limitErr.Limit)
+	assert.Contains(t, err.Error(), `namespace "default" has 2 facts, the limit is 2`)
+	assert.Contains(t, err.Error(), "mie reset")
+
+	_, err = client.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Postgres", Kind: "technology"})
+	require.NoError(t, err)
+	_, err = client.StoreDecision(ctx, tools.StoreDecisionRequest{Title: "Use Postgres", Rationale: "It is already run"})
+	require.True(t, errors.As(err, &limitErr), "fourth node: %v", err)
+	assert.Equal(t, "max_nodes", limitErr.Limit)
+
+	// Limits are per namespace.
+	_, err = client.StoreFact(tools.WithNamespace(ctx, "other"), tools.StoreFactRequest{Content: "Bob joined in May", Category: "general"})
+	require.NoError(t, err)
+
+	stats, err := client.GetStats(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, stats.Limits)
+	assert.Equal(t, tools.LimitStats{Facts: 2, MaxFacts: 2, Nodes: 3, MaxNodes: 3, StorageBytes: stats.Limits.StorageBytes}, *stats.Limits)
+}
+
+func TestClientStorageLimit(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "blob"), []byte(strings.Repeat("x", 2048)), 0o600))
+	client, err := NewClient(ClientConfig{DataDir: dir, StorageEngine: "mem", Limits: Limits{MaxStorageBytes: 1024}})
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.StoreTopic(context.Background(), tools.StoreTopicRequest{Name: "billing"})
+	var limitErr *LimitError
+	require.True(t, errors.As(err, &limitErr), "store over the storage limit: %v", err)
+	assert.Equal(t, "max_storage_bytes", limitErr.Limit)
+	assert.GreaterOrEqual(t, limitErr.Usage, int64(2048))
+
+	unlimited := setupIntegrationClient(t, false)
+	stats, err := unlimited.GetStats(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, stats.Limits)
+}
@@ -0,0 +1,244 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// migrationMetaKey is the mie_meta key recording the embedding model and
+// vector size an unfinished migration targets.
+const migrationMetaKey = "embedding_migration"
+
+// MigrateOptions configures an embedding migration.
+type MigrateOptions struct {
+	Workers int // concurrent embedding workers (default: ClientConfig.EmbeddingWorkers)
+	// Progress, if set, is called after each node is embedded or fails,
+	// with the nodes done so far and the nodes the run has to embed.
+	Progress func(done, total int)
+}
+
+// MigrateResult summarizes an embedding migration.
+type MigrateResult struct {
+	Model      string `json:"model"`
+	Dimensions int    `json:"dimensions"`
+	// Total is the number of nodes this run had to embed. Nodes embedded
+	// by an earlier, interrupted run are not counted.
+	Total    int  `json:"total"`
+	Embedded int  `json:"embedded"`
+	Failed   int  `json:"failed"`
+	Resumed  bool `json:"resumed"`  // an earlier run for the same model was continued
+	Complete bool `json:"complete"` // the new embeddings replaced the old ones
+}
+
+// migrationTable returns the staging table new embeddings of nodeType are
+// written to until the migration completes.
+func migrationTable(nodeType string) string {
+	return nodeTypeToEmbeddingTable(nodeType) + "_migration"
+}
+
+// MigrateEmbeddings re-embeds every fact, decision, entity, and event with
+// the Client's embedding model into tables of ClientConfig.EmbeddingDimensions,
+// then replaces the embedding tables and their vector indexes with them.
+// Until then the old embeddings stay in place, so semantic search keeps
+// working with the old model.
+//
+// The new embeddings are kept across runs: if the migration is interrupted
+// or some nodes fail, running it again with the same model embeds only the
+// nodes still missing. Running it with another model starts over. The
+// result has Complete set once the tables were replaced.
+func (c *Client) MigrateEmbeddings(ctx context.Context, opts MigrateOptions) (*MigrateResult, error) {
+	if c.embedder == nil {
+		return nil, fmt.Errorf("embedding migration requires embeddings to be enabled")
+	}
+	dim := c.config.EmbeddingDimensions
+	if dim <= 0 {
+		return nil, fmt.Errorf("embedding migration requires the embedding dimensions")
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = c.config.EmbeddingWorkers
+	}
+	if workers <= 0 {
+		workers = DefaultBackfillWorkers
+	}
+	result := &MigrateResult{Model: c.config.EmbeddingModel, Dimensions: dim}
+	target := fmt.Sprintf("%s/%s/%d", c.config.EmbeddingProvider, c.config.EmbeddingModel, dim)
+
+	previous, err := c.metaValue(ctx, migrationMetaKey)
+	if err != nil {
+		return nil, err
+	}
+	if previous == target {
+		result.Resumed = true
+	} else {
+		// A migration to another model left embeddings of the wrong kind.
+		for _, nt := range backfillNodeTypes {
+			if err := c.dropTable(ctx, migrationTable(nt)); err != nil {
+				return nil, err
+			}
+		}
+		if err := c.backend.Execute(ctx, metaPutScript, map[string]any{"key": migrationMetaKey, "value": target}); err != nil {
+			return nil, fmt.Errorf("record embedding migration: %w", err)
+		}
+	}
+
+	var pending []backfillJob
+	for _, nt := range backfillNodeTypes {
+		table := migrationTable(nt)
+		err := c.backend.Execute(ctx, fmt.Sprintf(`:create %s {
+    %s_id: String =>
+    embedding: <F32; %d>
+}`, table, nt, dim), nil)
+		if err != nil && !strings.Contains(err.Error(), "already exists") &&
+			!strings.Contains(err.Error(), "conflicts with an existing one") {
+			return nil, fmt.Errorf("create %s: %w", table, err)
+		}
+		script, _ := missingEmbeddingsScript(nt, table)
+		qr, err := c.backend.Query(ctx, script, nil)
+		if err != nil {
+			return nil, fmt.Errorf("scan %s nodes to migrate: %w", nt, err)
+		}
+		for _, row := range qr.Rows {
+			pending = append(pending, backfillJob{nodeType: nt, id: toString(row[0]), text: toString(row[1])})
+		}
+	}
+	result.Total = len(pending)
+	c.logger.Info("migrating embeddings", "model", result.Model, "dimensions", dim, "nodes", len(pending), "resumed", result.Resumed)
+
+	jobs := make(chan backfillJob)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				err := c.migrateNode(ctx, j, dim)
+				mu.Lock()
+				if err != nil {
+					result.Failed++
+				} else {
+					result.Embedded++
+				}
+				if opts.Progress != nil {
+					opts.Progress(result.Embedded+result.Failed, result.Total)
+				}
+				mu.Unlock()
+				if err != nil {
+					c.logger.Warn("failed to migrate embedding", "node_id", j.id, "error", err)
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, j := range pending {
+		select {
+		case jobs <- j:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return result, fmt.Errorf("embedding migration interrupted: %w", err)
+	}
+	if result.Failed > 0 {
+		return result, nil
+	}
+
+	if err := c.swapEmbeddingTables(ctx, dim); err != nil {
+		return result, err
+	}
+	if err := c.backend.Execute(ctx, `?[key] <- [[$key]] :rm mie_meta { key }`, map[string]any{"key": migrationMetaKey}); err != nil {
+		return result, fmt.Errorf("clear embedding migration: %w", err)
+	}
+	c.invalidateSearchCache()
+	result.Complete = true
+	c.logger.Info("embedding migration finished", "model", result.Model, "dimensions", dim)
+	return result, nil
+}
+
+// migrateNode embeds one node into its staging table.
+func (c *Client) migrateNode(ctx context.Context, j backfillJob, dim int) error {
+	embedding, err := c.embedder.Generate(ctx, j.text)
+	if err != nil {
+		return fmt.Errorf("generate embedding: %w", err)
+	}
+	if len(embedding) != dim {
+		return fmt.Errorf("model returned %d dimensions, expected %d", len(embedding), dim)
+	}
+	mutation := fmt.Sprintf(`?[%[1]s, embedding] <- [[$id, vec($embedding)]] :put %[2]s { %[1]s => embedding }`, j.nodeType+"_id", migrationTable(j.nodeType))
+	if err := c.backend.Execute(ctx, mutation, map[string]any{"id": j.id, "embedding": embedding}); err != nil {
+		return fmt.Errorf("store embedding: %w", err)
+	}
+	return nil
+}
+
+// swapEmbeddingTables replaces each embedding table with its staging table
+// and rebuilds the vector index. A table already replaced by an earlier,
+// interrupted swap is skipped.
+func (c *Client) swapEmbeddingTables(ctx context.Context, dim int) error {
+	for _, nt := range backfillNodeTypes {
+		table, staging := nodeTypeToEmbeddingTable(nt), migrationTable(nt)
+		if vectorDimensions(ctx, c.backend, staging) == 0 {
+			continue
+		}
+		if vectorDimensions(ctx, c.backend, table) > 0 {
+			indexed, err := hasIndex(ctx, c.backend, table, nodeTypeToHNSWIndex(nt))
+			if err != nil {
+				return err
+			}
+			if indexed {
+				if err := c.backend.Execute(ctx, fmt.Sprintf(`::hnsw drop %s:%s`, table, nodeTypeToHNSWIndex(nt)), nil); err != nil {
+					return fmt.Errorf("drop %s index: %w", nt, err)
+				}
+			}
+			if err := c.dropTable(ctx, table); err != nil {
+				return err
+			}
+		}
+		if err := c.backend.Execute(ctx, fmt.Sprintf(`::rename %s -> %s`, staging, table), nil); err != nil {
+			return fmt.Errorf("rename %s: %w", staging, err)
+		}
+	}
+
+	if c.config.VectorIndex == nil {
+		return EnsureHNSWIndexes(c.backend, dim)
+	}
+	// Adding a node replaces its old embedding in the index.
+	return loadVectorIndex(ctx, c.backend, c.config.VectorIndex)
+}
+
+// dropTable removes table if it exists.
+func (c *Client) dropTable(ctx context.Context, table string) error {
+	if vectorDimensions(ctx, c.backend, table) == 0 {
+		return nil
+	}
+	if err := c.backend.Execute(ctx, `::remove `+table, nil); err != nil {
+		return fmt.Errorf("remove %s: %w", table, err)
+	}
+	return nil
+}
+
+// metaValue returns the mie_meta value of key, or "" if it is not set.
+func (c *Client) metaValue(ctx context.Context, key string) (string, error) {
+	qr, err := c.backend.Query(ctx, `?[value] := *mie_meta { key, value }, key = $key`, map[string]any{"key": key})
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", key, err)
+	}
+	if len(qr.Rows) == 0 {
+		return "", nil
+	}
+	return toString(qr.Rows[0][0]), nil
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestClientMigrateEmbeddings(t *testing.T) {
+	client, err := NewClient(ClientConfig{DataDir: t.TempDir(), StorageEngine: "mem", EmbeddingDimensions: 384})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	fact, _ := client.StoreFact(ctx, tools.StoreFactRequest{Content: "Deploys run on Fridays", Category: "technical"})
+	_, _ = client.StoreDecision(ctx, tools.StoreDecisionRequest{Title: "Use CozoDB", Rationale: "Embedded Datalog"})
+
+	if _, err := client.MigrateEmbeddings(ctx, MigrateOptions{}); err == nil {
+		t.Error("expected error when embeddings are disabled")
+	}
+
+	// A model returning vectors of the wrong size fails every node, and
+	// the old tables are kept.
+	client.embedder = NewEmbeddingGenerator(NewMockEmbeddingProvider(384, nil), nil)
+	client.config.EmbeddingModel = "mock-768"
+	client.config.EmbeddingDimensions = 768
+	result, err := client.MigrateEmbeddings(ctx, MigrateOptions{Workers: 2})
+	if err != nil {
+		t.Fatalf("MigrateEmbeddings failed: %v", err)
+	}
+	if result.Complete || result.Failed != 2 || result.Resumed {
+		t.Fatalf("expected an incomplete migration with 2 failures, got %+v", result)
+	}
+	if dim := vectorDimensions(ctx, client.backend, "mie_fact_embedding"); dim != 384 {
+		t.Errorf("expected the old 384-dimension table to be kept, got %d", dim)
+	}
+
+	client.embedder = NewEmbeddingGenerator(NewMockEmbeddingProvider(768, nil), nil)
+	var progress int
+	result, err = client.MigrateEmbeddings(ctx, MigrateOptions{Progress: func(done, total int) { progress = done }})
+	if err != nil {
+		t.Fatalf("MigrateEmbeddings failed: %v", err)
+	}
+	if !result.Complete || !result.Resumed || result.Embedded != 2 || progress != 2 {
+		t.Fatalf("expected a resumed, complete migration of 2 nodes, got %+v (progress %d)", result, progress)
+	}
+	for _, nt := range backfillNodeTypes {
+		if dim := vectorDimensions(ctx, client.backend, nodeTypeToEmbeddingTable(nt)); dim != 768 {
+			t.Errorf("expected %s embeddings of 768 dimensions, got %d", nt, dim)
+		}
+		if dim := vectorDimensions(ctx, client.backend, migrationTable(nt)); dim != 0 {
+			t.Errorf("expected the %s staging table to be removed", nt)
+		}
+	}
+	if pending, _ := client.metaValue(ctx, migrationMetaKey); pending != "" {
+		t.Errorf("expected the migration record to be cleared, got %q", pending)
+	}
+
+	qr, err := client.RawQuery(ctx, `?[id] := *mie_fact_embedding { fact_id: id }`)
+	if err != nil {
+		t.Fatalf("RawQuery failed: %v", err)
+	}
+	if len(qr.Rows) != 1 || toString(qr.Rows[0][0]) != fact.ID {
+		t.Errorf("expected the embedding of %s, got %v", fact.ID, qr.Rows)
+	}
+	if ok, _ := hasIndex(ctx, client.backend, "mie_fact_embedding", "fact_embedding_idx"); !ok {
+		t.Error("expected the HNSW index to be rebuilt")
+	}
+}
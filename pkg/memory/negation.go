@@ -0,0 +1,72 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// putNegation records that a fact is an anti-fact.
+func (w *Writer) putNegation(ctx context.Context, factID string) error {
+	mutation := `?[fact_id, created_at] <- [[$fact_id, $now]] :put mie_fact_negated { fact_id => created_at }`
+	if err := w.execute(ctx, mutation, map[string]any{"fact_id": factID, "now": time.Now().Unix()}); err != nil {
+		return fmt.Errorf("negate fact %s: %w", factID, err)
+	}
+	return nil
+}
+
+// isNegated reports whether the fact factID is an anti-fact.
+func (w *Writer) isNegated(ctx context.Context, factID string) (bool, error) {
+	qr, err := w.backend.Query(ctx, `?[fact_id] := *mie_fact_negated { fact_id }, fact_id = $id`, map[string]any{"id": factID})
+	if err != nil {
+		return false, fmt.Errorf("get negation of fact %s: %w", factID, err)
+	}
+	return len(qr.Rows) > 0, nil
+}
+
+// attachNegation sets the Negated field of facts from mie_fact_negated.
+func (r *Reader) attachNegation(ctx context.Context, facts []*tools.Fact) error {
+	if len(facts) == 0 {
+		return nil
+	}
+	ids := make([]string, len(facts))
+	for i, f := range facts {
+		ids[i] = f.ID
+	}
+	qr, err := r.backend.Query(ctx, `ids[fact_id] <- $ids
+?[fact_id] := ids[fact_id], *mie_fact_negated { fact_id }`,
+		map[string]any{"ids": idRows(ids)})
+	if err != nil {
+		return fmt.Errorf("get fact negation: %w", err)
+	}
+	negated := make(map[string]bool, len(qr.Rows))
+	for _, row := range qr.Rows {
+		negated[toString(row[0])] = true
+	}
+	for _, f := range facts {
+		f.Negated = negated[f.ID]
+	}
+	return nil
+}
+
+// negatedFacts returns the IDs of the anti-facts of namespace ns.
+func (cd *ConflictDetector) negatedFacts(ctx context.Context, ns string) (map[string]bool, error) {
+	qr, err := cd.backend.Query(ctx, `?[fact_id] := *mie_fact_negated { fact_id }, *mie_fact { id: fact_id, namespace }, namespace = $ns`,
+		map[string]any{"ns": ns})
+	if err != nil {
+		return nil, fmt.Errorf("get negated facts: %w", err)
+	}
+	negated := make(map[string]bool, len(qr.Rows))
+	for _, row := range qr.Rows {
+		negated[toString(row[0])] = true
+	}
+	return negated, nil
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestClientAntiFacts(t *testing.T) {
+	client, err := NewClient(ClientConfig{DataDir: t.TempDir(), StorageEngine: "mem", EmbeddingDimensions: 384})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	asserted, err := client.StoreFact(ctx, tools.StoreFactRequest{Content: "User uses Docker", Category: "technical"})
+	if err != nil {
+		t.Fatalf("StoreFact: %v", err)
+	}
+	negated, err := client.StoreFact(ctx, tools.StoreFactRequest{Content: "User uses Docker", Category: "technical", Negated: true})
+	if err != nil {
+		t.Fatalf("StoreFact negated: %v", err)
+	}
+	if negated.ID == asserted.ID || negated.ID != NegatedFactID("User uses Docker", "technical") {
+		t.Errorf("anti-fact ID = %s, want %s", negated.ID, NegatedFactID("User uses Docker", "technical"))
+	}
+	if negated.DuplicateSimilarity != 0 || !negated.Negated {
+		t.Errorf("anti-fact duplicate=%v negated=%v, want a new anti-fact", negated.DuplicateSimilarity, negated.Negated)
+	}
+
+	again, err := client.StoreFact(ctx, tools.StoreFactRequest{Content: "User uses Docker", Category: "technical", Negated: true})
+	if err != nil {
+		t.Fatalf("StoreFact negated again: %v", err)
+	}
+	if again.ID != negated.ID {
+		t.Errorf("storing the anti-fact again gave %s, want %s", again.ID, negated.ID)
+	}
+
+	got, err := client.GetNodeByID(ctx, negated.ID)
+	if err != nil {
+		t.Fatalf("GetNodeByID: %v", err)
+	}
+	if !got.(*tools.Fact).Negated {
+		t.Error("GetNodeByID lost the Negated flag")
+	}
+	got, err = client.GetNodeByID(ctx, asserted.ID)
+	if err != nil {
+		t.Fatalf("GetNodeByID: %v", err)
+	}
+	if got.(*tools.Fact).Negated {
+		t.Error("asserted fact reads as negated")
+	}
+}
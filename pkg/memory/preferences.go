@@ -0,0 +1,100 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// putKey records the preference key of a fact.
+func (w *Writer) putKey(ctx context.Context, factID, key string) error {
+	mutation := `?[fact_id, key] <- [[$fact_id, $key]] :put mie_fact_key { fact_id => key }`
+	if err := w.execute(ctx, mutation, map[string]any{"fact_id": factID, "key": key}); err != nil {
+		return fmt.Errorf("set key of fact %s: %w", factID, err)
+	}
+	return nil
+}
+
+// currentValues returns the IDs of the valid facts of ns other than factID
+// that record key.
+func (w *Writer) currentValues(ctx context.Context, key, factID, ns string) ([]string, error) {
+	qr, err := w.backend.Query(ctx, `?[id] := *mie_fact_key { fact_id: id, key }, key = $key, id != $id,
+    *mie_fact { id, valid, namespace }, valid = true, namespace = $ns
+:order id`, map[string]any{"key": key, "id": factID, "ns": ns})
+	if err != nil {
+		return nil, fmt.Errorf("find current value of %q: %w", key, err)
+	}
+	ids := make([]string, len(qr.Rows))
+	for i, row := range qr.Rows {
+		ids[i] = toString(row[0])
+	}
+	return ids, nil
+}
+
+// attachKeys sets the Key field of facts from mie_fact_key.
+func (r *Reader) attachKeys(ctx context.Context, facts []*tools.Fact) error {
+	if len(facts) == 0 {
+		return nil
+	}
+	ids := make([]string, len(facts))
+	for i, f := range facts {
+		ids[i] = f.ID
+	}
+	qr, err := r.backend.Query(ctx, `ids[fact_id] <- $ids
+?[fact_id, key] := ids[fact_id], *mie_fact_key { fact_id, key }`,
+		map[string]any{"ids": idRows(ids)})
+	if err != nil {
+		return fmt.Errorf("get fact keys: %w", err)
+	}
+	keys := make(map[string]string, len(qr.Rows))
+	for _, row := range qr.Rows {
+		keys[toString(row[0])] = toString(row[1])
+	}
+	for _, f := range facts {
+		f.Key = keys[f.ID]
+	}
+	return nil
+}
+
+// ListPreferences returns the current value of each preference key of the
+// namespace: the valid preference facts stored with a key, ordered by key.
+// Archived facts are left out unless ctx asks for them with
+// tools.WithIncludeArchived.
+func (r *Reader) ListPreferences(ctx context.Context) ([]tools.Fact, error) {
+	columns := columnsForNodeType("fact")
+	script := fmt.Sprintf(`?[%s] := *mie_fact { %s, namespace }, namespace = $ns, valid = true,
+    *mie_fact_key { fact_id: id }`, columns, columns) + archivedFilter(ctx, "id")
+	qr, err := r.backend.Query(ctx, script, map[string]any{"ns": resolveNamespace(ctx, r.namespace)})
+	if err != nil {
+		return nil, fmt.Errorf("list preferences: %w", err)
+	}
+	facts := make([]tools.Fact, len(qr.Rows))
+	for i, row := range qr.Rows {
+		facts[i] = *factFromRow(row)
+	}
+	if err := r.attachFactMetadata(ctx, factPointers(facts)); err != nil {
+		return nil, err
+	}
+	// A key has one valid value unless an import brought in several; the
+	// newest is listed first.
+	slices.SortFunc(facts, func(a, b tools.Fact) int {
+		return cmp.Or(strings.Compare(a.Key, b.Key), cmp.Compare(b.CreatedAt, a.CreatedAt), strings.Compare(a.ID, b.ID))
+	})
+	return facts, nil
+}
+
+// ListPreferences returns the current preference values. See
+// Reader.ListPreferences.
+func (c *Client) ListPreferences(ctx context.Context) ([]tools.Fact, error) {
+	return c.reader.ListPreferences(ctx)
+}
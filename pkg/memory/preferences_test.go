@@ -0,0 +1,59 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestClientPreferences(t *testing.T) {
+	client, err := NewClient(ClientConfig{DataDir: t.TempDir(), StorageEngine: "mem", EmbeddingDimensions: 384})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	first, err := client.StoreFact(ctx, tools.StoreFactRequest{Content: "User edits in Vim", Key: "Editor"})
+	if err != nil {
+		t.Fatalf("StoreFact: %v", err)
+	}
+	if first.Category != "preference" || first.Key != "editor" {
+		t.Errorf("stored category %q key %q, want preference editor", first.Category, first.Key)
+	}
+	if _, err := client.StoreFact(ctx, tools.StoreFactRequest{Content: "User uses tabs", Category: "technical", Key: "indent"}); err == nil {
+		t.Error("StoreFact with a key and category technical should fail")
+	}
+	theme, _ := client.StoreFact(ctx, tools.StoreFactRequest{Content: "User prefers a dark theme", Key: "theme"})
+
+	second, err := client.StoreFact(ctx, tools.StoreFactRequest{Content: "User edits in Helix", Key: "editor"})
+	if err != nil {
+		t.Fatalf("StoreFact: %v", err)
+	}
+	if len(second.Replaces) != 1 || second.Replaces[0] != first.ID {
+		t.Errorf("Replaces = %v, want [%s]", second.Replaces, first.ID)
+	}
+
+	got, err := client.GetNodeByID(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("GetNodeByID: %v", err)
+	}
+	if f := got.(*tools.Fact); f.Valid || f.Key != "editor" {
+		t.Errorf("old value valid=%v key=%q, want invalid with key editor", f.Valid, f.Key)
+	}
+
+	prefs, err := client.ListPreferences(ctx)
+	if err != nil {
+		t.Fatalf("ListPreferences: %v", err)
+	}
+	if len(prefs) != 2 || prefs[0].ID != second.ID || prefs[1].ID != theme.ID {
+		t.Errorf("preferences = %+v, want %s then %s", prefs, second.ID, theme.ID)
+	}
+}
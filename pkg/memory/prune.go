@@ -0,0 +1,225 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Reasons a node is a prune candidate.
+const (
+	PruneInvalidated   = "invalidated"    // an invalidated fact
+	PruneLowConfidence = "low_confidence" // an old, unverified fact of low confidence
+	PruneOrphanEntity  = "orphan_entity"  // an old entity without relationships
+	PruneUnusedTopic   = "unused_topic"   // a topic nothing links to
+)
+
+// Defaults of PruneOptions.
+const (
+	DefaultPruneLowConfidence = 0.5
+	DefaultPruneMinAgeDays    = 90
+)
+
+// pruneAgeHorizon is the age at which a candidate's age adds its full
+// weight to its score.
+const pruneAgeHorizon = 365 * 24 * time.Hour
+
+// PruneOptions selects and bounds the candidates of Prune.
+type PruneOptions struct {
+	// LowConfidence is the confidence below which old, unverified, valid
+	// facts are candidates. Zero uses DefaultPruneLowConfidence.
+	LowConfidence float64
+	// MinAgeDays is how old a valid fact or an entity must be to be a
+	// candidate. Zero uses DefaultPruneMinAgeDays.
+	MinAgeDays int
+	// MinScore drops candidates that score lower (0-1).
+	MinScore float64
+	// Limit keeps only the best-scoring candidates. Zero keeps them all.
+	Limit int
+	// Apply deletes the candidates. Without it, Prune only ranks them.
+	Apply bool
+}
+
+// PruneCandidate is a node Prune proposes to delete.
+type PruneCandidate struct {
+	ID       string `json:"id"`
+	NodeType string `json:"node_type"`
+	// Summary is the fact's content, or the entity's or topic's name.
+	Summary string `json:"summary"`
+	// Reason is one of PruneInvalidated, PruneLowConfidence,
+	// PruneOrphanEntity, and PruneUnusedTopic.
+	Reason string `json:"reason"`
+	// Score says how safe the node is to delete, from 0 to 1.
+	Score     float64 `json:"score"`
+	CreatedAt int64   `json:"created_at"`
+}
+
+// PruneResult lists the candidates of Prune, best first.
+type PruneResult struct {
+	Applied    bool             `json:"applied"`
+	Namespace  string           `json:"namespace"`
+	Candidates []PruneCandidate `json:"candidates"`
+	// Pruned counts the deleted candidates per node type when Applied.
+	Pruned map[string]int `json:"pruned,omitempty"`
+}
+
+// Prune ranks the nodes of the namespace that are likely safe to delete:
+// invalidated facts, old unverified facts of low confidence, old entities
+// without relationships, and topics nothing links to. Each candidate is
+// scored from 0 to 1 by its reason and age. With opts.Apply, the candidates
+// are deleted as by Reset.
+func (w *Writer) Prune(ctx context.Context, opts PruneOptions) (*PruneResult, error) {
+	if opts.LowConfidence == 0 {
+		opts.LowConfidence = DefaultPruneLowConfidence
+	}
+	if opts.MinAgeDays == 0 {
+		opts.MinAgeDays = DefaultPruneMinAgeDays
+	}
+	if opts.LowConfidence < 0 || opts.LowConfidence > 1 {
+		return nil, fmt.Errorf("invalid low confidence %v (must be between 0 and 1)", opts.LowConfidence)
+	}
+	if opts.MinAgeDays < 0 || opts.MinScore < 0 || opts.MinScore > 1 || opts.Limit < 0 {
+		return nil, fmt.Errorf("invalid prune options: min age and limit must not be negative, min score must be between 0 and 1")
+	}
+
+	ns := resolveNamespace(ctx, w.namespace)
+	now := time.Now()
+	cutoff := now.Add(-time.Duration(opts.MinAgeDays) * 24 * time.Hour).Unix()
+	params := map[string]any{"ns": ns, "cutoff": cutoff, "low_confidence": opts.LowConfidence}
+	ageFactor := func(createdAt int64) float64 {
+		return math.Min(now.Sub(time.Unix(createdAt, 0)).Seconds()/pruneAgeHorizon.Seconds(), 1)
+	}
+	var candidates []PruneCandidate
+
+	qr, err := w.backend.Query(ctx, `?[id, content, created_at] := *mie_fact { id, content, valid, created_at, namespace },
+    namespace = $ns, valid = false`, params)
+	if err != nil {
+		return nil, fmt.Errorf("find invalidated facts: %w", err)
+	}
+	for _, row := range qr.Rows {
+		createdAt := toInt64(row[2])
+		candidates = append(candidates, PruneCandidate{
+			ID: toString(row[0]), NodeType: "fact", Summary: toString(row[1]), Reason: PruneInvalidated,
+			Score: 0.7 + 0.3*ageFactor(createdAt), CreatedAt: createdAt,
+		})
+	}
+
+	qr, err = w.backend.Query(ctx, `?[id, content, confidence, created_at] := *mie_fact { id, content, confidence, valid, created_at, namespace },
+    namespace = $ns, valid = true, confidence < $low_confidence, created_at <= $cutoff,
+    not *mie_fact_verification { fact_id: id }`, params)
+	if err != nil {
+		return nil, fmt.Errorf("find low-confidence facts: %w", err)
+	}
+	for _, row := range qr.Rows {
+		confidence, createdAt := toFloat64(row[2]), toInt64(row[3])
+		candidates = append(candidates, PruneCandidate{
+			ID: toString(row[0]), NodeType: "fact", Summary: toString(row[1]), Reason: PruneLowConfidence,
+			Score: 0.2 + 0.3*(1-confidence/opts.LowConfidence) + 0.3*ageFactor(createdAt), CreatedAt: createdAt,
+		})
+	}
+
+	var rules []string
+	for _, table := range sortedEdgeTables() {
+		keyCols := ValidEdgeTables[table]
+		for i, nt := range edgeTableEndpoints[table] {
+			if nt != "entity" {
+				continue
+			}
+			other := edgeTableEndpoints[table][1-i]
+			rules = append(rules, fmt.Sprintf(`linked[entity_id] := *%s { %s: entity_id, %s: other_id }, *%s { id: other_id }`,
+				table, keyCols[i], keyCols[1-i], nodeTypeToTable(other)))
+		}
+	}
+	qr, err = w.backend.Query(ctx, fmt.Sprintf(`%s
+?[id, name, description, created_at] := *mie_entity { id, name, description, created_at, namespace },
+    namespace = $ns, created_at <= $cutoff, not linked[id]`, strings.Join(rules, "\n")), params)
+	if err != nil {
+		return nil, fmt.Errorf("find orphan entities: %w", err)
+	}
+	for _, row := range qr.Rows {
+		createdAt := toInt64(row[3])
+		score := 0.4 + 0.2*ageFactor(createdAt)
+		if toString(row[2]) == "" {
+			// Nothing would be lost but the name.
+			score += 0.1
+		}
+		candidates = append(candidates, PruneCandidate{
+			ID: toString(row[0]), NodeType: "entity", Summary: toString(row[1]), Reason: PruneOrphanEntity,
+			Score: score, CreatedAt: createdAt,
+		})
+	}
+
+	topics, err := w.unusedTopics(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range topics {
+		candidates = append(candidates, PruneCandidate{
+			ID: t.ID, NodeType: "topic", Summary: t.Name, Reason: PruneUnusedTopic,
+			Score: 0.8 + 0.2*ageFactor(t.CreatedAt), CreatedAt: t.CreatedAt,
+		})
+	}
+
+	kept := candidates[:0]
+	for _, c := range candidates {
+		if c.Score >= opts.MinScore {
+			kept = append(kept, c)
+		}
+	}
+	candidates = kept
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].CreatedAt < candidates[j].CreatedAt
+	})
+	if opts.Limit > 0 && len(candidates) > opts.Limit {
+		candidates = candidates[:opts.Limit]
+	}
+	if candidates == nil {
+		candidates = []PruneCandidate{}
+	}
+
+	result := &PruneResult{Namespace: ns, Candidates: candidates}
+	if !opts.Apply || len(candidates) == 0 {
+		return result, nil
+	}
+	ids := map[string][]string{}
+	for _, c := range candidates {
+		ids[c.NodeType] = append(ids[c.NodeType], c.ID)
+	}
+	if err := w.deleteNodes(ctx, ids); err != nil {
+		return nil, fmt.Errorf("prune: %w", err)
+	}
+	result.Applied = true
+	result.Pruned = make(map[string]int, len(ids))
+	for nt, nodeIDs := range ids {
+		result.Pruned[nt] = len(nodeIDs)
+	}
+	return result, nil
+}
+
+// Prune ranks, and with opts.Apply deletes, the nodes likely safe to
+// delete. See Writer.Prune. Deleted nodes are recorded as deleted.
+func (c *Client) Prune(ctx context.Context, opts PruneOptions) (*PruneResult, error) {
+	result, err := c.writer.Prune(ctx, opts)
+	if err != nil || !result.Applied {
+		return result, err
+	}
+	deleted := make([]string, len(result.Candidates))
+	for i, cand := range result.Candidates {
+		deleted[i] = cand.ID
+		c.publish(ctx, ChangeDeleted, cand.ID, nil)
+	}
+	c.audit(ctx, ChangeDeleted, "", nil, deleted...)
+	return result, nil
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestWriterPrune(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	ctx := context.Background()
+	old := time.Now().AddDate(0, 0, -200).Unix()
+
+	stale, _ := w.StoreFact(ctx, tools.StoreFactRequest{Content: "Billing runs on MySQL", Category: "technical"})
+	current, _ := w.StoreFact(ctx, tools.StoreFactRequest{Content: "Billing runs on Postgres", Category: "technical"})
+	if err := w.InvalidateFact(ctx, stale.ID, current.ID, "migrated"); err != nil {
+		t.Fatalf("InvalidateFact failed: %v", err)
+	}
+	doubtful, _ := w.StoreFact(ctx, tools.StoreFactRequest{Content: "Bob may prefer tabs", Category: "preference", Confidence: 0.2})
+	verified, _ := w.StoreFact(ctx, tools.StoreFactRequest{Content: "Alice may prefer spaces", Category: "preference", Confidence: 0.2})
+	recent, _ := w.StoreFact(ctx, tools.StoreFactRequest{Content: "Carol may use vim", Category: "preference", Confidence: 0.2})
+	orphan, _ := w.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Legacy API", Kind: "technology"})
+	linked, _ := w.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Postgres", Kind: "technology"})
+	newcomer, _ := w.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Redis", Kind: "technology"})
+	topic, _ := w.StoreTopic(ctx, tools.StoreTopicRequest{Name: "hiring"})
+	if err := w.AddRelationship(ctx, "mie_fact_entity", map[string]string{"fact_id": current.ID, "entity_id": linked.ID}); err != nil {
+		t.Fatalf("AddRelationship failed: %v", err)
+	}
+	backdate := fmt.Sprintf(`?[id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at, namespace] :=
+    *mie_fact { id, content, category, confidence, source_agent, source_conversation, valid, namespace },
+    is_in(id, ['%s', '%s']), created_at = %d, updated_at = %[3]d
+:put mie_fact { id => content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at, namespace }`,
+		doubtful.ID, verified.ID, old)
+	if err := backend.Execute(ctx, backdate, nil); err != nil {
+		t.Fatalf("backdate facts: %v", err)
+	}
+	backdate = fmt.Sprintf(`?[id, name, kind, description, source_agent, created_at, updated_at, namespace] :=
+    *mie_entity { id, name, kind, description, source_agent, namespace },
+    is_in(id, ['%s', '%s']), created_at = %d, updated_at = %[3]d
+:put mie_entity { id => name, kind, description, source_agent, created_at, updated_at, namespace }`,
+		orphan.ID, linked.ID, old)
+	if err := backend.Execute(ctx, backdate, nil); err != nil {
+		t.Fatalf("backdate entities: %v", err)
+	}
+	if err := backend.Execute(ctx, `?[fact_id, verified_by, verified_at] <- [[$id, 'alice', 1]] :put mie_fact_verification { fact_id => verified_by, verified_at }`,
+		map[string]any{"id": verified.ID}); err != nil {
+		t.Fatalf("verify fact: %v", err)
+	}
+
+	result, err := w.Prune(ctx, PruneOptions{})
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if result.Applied || result.Namespace != "default" {
+		t.Errorf("suggestion = applied %v in %q", result.Applied, result.Namespace)
+	}
+	got := map[string]string{}
+	for i, c := range result.Candidates {
+		got[c.ID] = c.Reason
+		if i > 0 && c.Score > result.Candidates[i-1].Score {
+			t.Errorf("candidates not sorted by score: %+v", result.Candidates)
+		}
+	}
+	want := map[string]string{
+		stale.ID:    PruneInvalidated,
+		doubtful.ID: PruneLowConfidence,
+		orphan.ID:   PruneOrphanEntity,
+		topic.ID:    PruneUnusedTopic,
+	}
+	if len(got) != len(want) {
+		t.Errorf("candidates = %v, want %v (not %s, %s, %s, %s)", got, want, verified.ID, recent.ID, linked.ID, newcomer.ID)
+	}
+	for id, reason := range want {
+		if got[id] != reason {
+			t.Errorf("candidate %s reason = %q, want %q", id, got[id], reason)
+		}
+	}
+
+	limited, err := w.Prune(ctx, PruneOptions{MinScore: 0.65, Limit: 1})
+	if err != nil {
+		t.Fatalf("Prune with limit failed: %v", err)
+	}
+	if len(limited.Candidates) != 1 || limited.Candidates[0].ID != topic.ID {
+		t.Errorf("best candidate = %+v, want the unused topic", limited.Candidates)
+	}
+
+	applied, err := w.Prune(ctx, PruneOptions{MinScore: 0.65, Apply: true})
+	if err != nil {
+		t.Fatalf("Prune apply failed: %v", err)
+	}
+	if !applied.Applied || applied.Pruned["fact"] != 1 || applied.Pruned["topic"] != 1 {
+		t.Errorf("applied = %+v, want the invalidated fact and the topic pruned", applied)
+	}
+	if n, _ := w.count(ctx, `?[count(id)] := *mie_fact { id }`); n != 4 {
+		t.Errorf("facts after prune = %d, want 4", n)
+	}
+	again, _ := w.Prune(ctx, PruneOptions{})
+	if len(again.Candidates) != 2 {
+		t.Errorf("candidates after prune = %+v, want the low-confidence fact and the orphan entity", again.Candidates)
+	}
+
+	if _, err := w.Prune(ctx, PruneOptions{MinScore: 2}); err == nil {
+		t.Error("Prune accepted a min score above 1")
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package memory
+
+import "github.com/kraklabs/mie/pkg/tools"
+
+// verifiedBoost multiplies the search ranking weight of verified facts.
+const verifiedBoost = 1.5
+
+// defaultRecencyHalfLifeDays is the age at which a fact's recency signal
+// halves when no decay half-life is configured.
+const defaultRecencyHalfLifeDays = 90
+
+// RankingWeights sets how semantic search blends its ranking signals, each
+// between 0 and 1: similarity to the query, a fact's confidence (after
+// decay), a fact's recency, and validity (whether a decision is still
+// active). Node types without a signal are scored on the others, so facts,
+// decisions, and entities stay comparable.
+type RankingWeights struct {
+	Similarity float64
+	Confidence float64
+	Recency    float64
+	Validity   float64
+}
+
+// DefaultRankingWeights are used when ClientConfig.RankingWeights is zero.
+var DefaultRankingWeights = RankingWeights{
+	Similarity: 1,
+	Confidence: 0.2,
+	Recency:    0.1,
+	Validity:   0.2,
+}
+
+// score returns the composite ranking score of a semantic search result:
+// the weighted mean of its signals, multiplied by verifiedBoost for verified
+// facts. halfLifeDays is the configured decay half-life, 0 if disabled.
+func (w RankingWeights) score(sr tools.SearchResult, now int64, halfLifeDays float64) float64 {
+	similarity := 1 - sr.Distance
+	if similarity < 0 {
+		similarity = 0
+	}
+	sum := w.Similarity * similarity
+	total := w.Similarity
+	add := func(weight, signal float64) {
+		sum += weight * signal
+		total += weight
+	}
+
+	boost := 1.0
+	switch n := sr.Metadata.(type) {
+	case *tools.Fact:
+		recencyHalfLife := halfLifeDays
+		if recencyHalfLife <= 0 {
+			recencyHalfLife = defaultRecencyHalfLifeDays
+		}
+		add(w.Confidence, n.Confidence*decayFactor(n.CreatedAt, now, halfLifeDays))
+		add(w.Recency, decayFactor(n.CreatedAt, now, recencyHalfLife))
+		// Semantic search only returns valid facts.
+		add(w.Validity, 1)
+		if n.Verified {
+			boost = verifiedBoost
+		}
+	case *tools.Decision:
+		validity := 0.0
+		if n.Status == "" || n.Status == "active" {
+			validity = 1
+		}
+		add(w.Validity, validity)
+	}
+
+	if total <= 0 {
+		return 0
+	}
+	return sum / total * boost
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package memory
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestRankingWeightsScore(t *testing.T) {
+	now := int64(1_000_000_000)
+	day := int64(24 * 60 * 60)
+	w := RankingWeights{Similarity: 1, Confidence: 1, Recency: 1, Validity: 1}
+
+	tests := []struct {
+		name     string
+		sr       tools.SearchResult
+		halfLife float64
+		want     float64
+	}{
+		{
+			name: "similarity only",
+			sr:   tools.SearchResult{Distance: 0.2, Metadata: &tools.Entity{}},
+			want: 0.8,
+		},
+		{
+			name: "fresh fact",
+			sr:   tools.SearchResult{Distance: 0.2, Metadata: &tools.Fact{Confidence: 0.6, CreatedAt: now}},
+			want: (0.8 + 0.6 + 1 + 1) / 4,
+		},
+		{
+			name: "fact one recency half-life old",
+			sr:   tools.SearchResult{Distance: 0.2, Metadata: &tools.Fact{Confidence: 0.6, CreatedAt: now - defaultRecencyHalfLifeDays*day}},
+			want: (0.8 + 0.6 + 0.5 + 1) / 4,
+		},
+		{
+			name:     "decay lowers confidence and recency",
+			sr:       tools.SearchResult{Distance: 0.2, Metadata: &tools.Fact{Confidence: 0.6, CreatedAt: now - 30*day}},
+			halfLife: 30,
+			want:     (0.8 + 0.3 + 0.5 + 1) / 4,
+		},
+		{
+			name: "verified fact",
+			sr:   tools.SearchResult{Distance: 0.2, Metadata: &tools.Fact{Confidence: 0.6, CreatedAt: now, Verified: true}},
+			want: (0.8 + 0.6 + 1 + 1) / 4 * verifiedBoost,
+		},
+		{
+			name: "superseded decision",
+			sr:   tools.SearchResult{Distance: 0.2, Metadata: &tools.Decision{Status: "superseded"}},
+			want: 0.8 / 2,
+		},
+		{
+			name: "distance beyond 1",
+			sr:   tools.SearchResult{Distance: 1.4, Metadata: &tools.Decision{Status: "active"}},
+			want: 0.5,
+		},
+	}
+	for _, tt := range tests {
+		if got := w.score(tt.sr, now, tt.halfLife); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: score = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRankingWeightsSimilarityOnly(t *testing.T) {
+	w := RankingWeights{Similarity: 1}
+	sr := tools.SearchResult{Distance: 0.3, Metadata: &tools.Fact{Confidence: 0.1}}
+	if got := w.score(sr, 0, 0); math.Abs(got-0.7) > 1e-9 {
+		t.Errorf("score = %v, want 0.7", got)
+	}
+}
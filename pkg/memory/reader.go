@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -21,9 +22,24 @@ import (
 
 // Reader handles all queries against the memory graph.
 type Reader struct {
-	backend  storage.Backend
-	embedder *EmbeddingGenerator
-	logger   *slog.Logger
+	backend   storage.Backend
+	embedder  *EmbeddingGenerator
+	logger    *slog.Logger
+	namespace string // default namespace when the context carries none
+	// halfLifeDays is the age at which a fact's effective confidence halves
+	// in search results; 0 disables decay.
+	halfLifeDays float64
+	// ranking weighs the signals semantic search ranks by.
+	ranking RankingWeights
+	// minSimilarity drops semantic results less similar than it, unless the
+	// context sets its own with tools.WithMinSimilarity; 0 keeps them all.
+	minSimilarity float64
+	// vectors, if set, is searched instead of the HNSW indexes.
+	vectors VectorIndex
+	// topicVectors caches the embeddings SuggestTopics compares text to.
+	topicVectors topicVectorCache
+	// searchCache, if set, holds recent SemanticSearch results.
+	searchCache *searchCache
 }
 
 // NewReader creates a new Reader.
@@ -31,10 +47,13 @@ func NewReader(backend storage.Backend, embedder *EmbeddingGenerator, logger *sl
 	if logger == nil {
 		logger = slog.Default()
 	}
-	return &Reader{backend: backend, embedder: embedder, logger: logger}
+	return &Reader{backend: backend, embedder: embedder, logger: logger, ranking: DefaultRankingWeights}
 }
 
 // SemanticSearch performs vector similarity search across the memory graph.
+// Results less similar to the query than the minimum similarity, from the
+// context or the Reader's default, are dropped. Results are served from the
+// search cache, if set, when the same search ran recently.
 func (r *Reader) SemanticSearch(ctx context.Context, query string, nodeTypes []string, limit int) ([]tools.SearchResult, error) {
 	if r.embedder == nil {
 		return nil, fmt.Errorf("semantic search requires embeddings to be enabled")
@@ -43,59 +62,134 @@ func (r *Reader) SemanticSearch(ctx context.Context, query string, nodeTypes []s
 		limit = 10
 	}
 
+	ns := resolveNamespace(ctx, r.namespace)
+	minSimilarity := r.minSimilarity
+	if v, ok := tools.MinSimilarityFromContext(ctx); ok {
+		minSimilarity = v
+	}
+	if r.searchCache == nil {
+		return r.semanticSearch(ctx, ns, query, nodeTypes, limit, minSimilarity)
+	}
+	key := newSearchKey(ctx, ns, query, nodeTypes, limit, minSimilarity)
+	results, gen, ok := r.searchCache.get(key)
+	if ok {
+		return results, nil
+	}
+	results, err := r.semanticSearch(ctx, ns, query, nodeTypes, limit, minSimilarity)
+	if err != nil {
+		return nil, err
+	}
+	r.searchCache.put(key, gen, results)
+	return results, nil
+}
+
+// semanticSearch runs SemanticSearch against the database.
+func (r *Reader) semanticSearch(ctx context.Context, ns, query string, nodeTypes []string, limit int, minSimilarity float64) ([]tools.SearchResult, error) {
 	queryEmb, err := r.embedder.GenerateQuery(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("generate query embedding: %w", err)
 	}
+	results := r.nearestNodes(ctx, ns, queryEmb, nodeTypes, limit, minSimilarity)
+
+	// Rank by a blend of similarity, confidence, recency, and validity,
+	// so weak, old, or superseded memories sink and verified facts rise.
+	r.attachSearchVerification(ctx, results)
+	now := time.Now().Unix()
+	r.applyDecay(results, now)
+	for i := range results {
+		results[i].Score = r.ranking.score(results[i], now, r.halfLifeDays)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
 
-	vecStr := formatVector(queryEmb)
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
+
+// nearestNodes returns up to limit nodes of each of nodeTypes nearest to
+// emb, no less similar than minSimilarity and unordered across types. The
+// time range, source agent, and archive filters of the context apply.
+func (r *Reader) nearestNodes(ctx context.Context, ns string, emb []float32, nodeTypes []string, limit int, minSimilarity float64) []tools.SearchResult {
+	tr := tools.TimeRangeFromContext(ctx)
+	agent := tools.SourceAgentFromContext(ctx)
 	var results []tools.SearchResult
 
+	var maxDistance string
+	if minSimilarity > 0 {
+		maxDistance = ",\n    distance <= $max_distance"
+	}
+
 	if len(nodeTypes) == 0 {
 		nodeTypes = []string{"fact", "decision", "entity", "event"}
 	}
 
 	for _, nt := range nodeTypes {
+		if tr.HasEventDate() && nt != "event" {
+			continue
+		}
+		// Topics have no source_agent.
+		if agent != "" && nt == "topic" {
+			continue
+		}
+		params := map[string]any{"ns": ns}
+		if minSimilarity > 0 {
+			// Cosine distance is 1 - similarity.
+			params["max_distance"] = 1 - minSimilarity
+		}
+		filter := timeRangeFilter(tr, nt, nt+"_id", params) + sourceAgentFilter(agent, params) + archivedFilter(ctx, nt+"_id") + maxDistance
+		if nodeTypeToEmbeddingTable(nt) == "" {
+			continue
+		}
+		nearest, err := nearestAtom(ctx, r.vectors, nt, emb, limit*5, params)
+		if err != nil {
+			r.logger.Warn("semantic search failed for type", "type", nt, "error", err)
+			continue
+		}
+
 		var script string
 		switch nt {
 		case "fact":
-			script = fmt.Sprintf(`?[id, content, category, confidence, distance] :=
-    ~mie_fact_embedding:fact_embedding_idx { fact_id | query: q, k: %d, ef: 200, bind_distance: distance },
-    q = vec(%s),
-    *mie_fact { id: fact_id, content, category, confidence, valid },
+			script = fmt.Sprintf(`?[id, content, category, confidence, created_at, distance] :=
+    %s,
+    *mie_fact { id: fact_id, content, category, confidence, valid, source_agent, created_at, namespace },
     valid = true,
+    namespace = $ns%s,
     id = fact_id
     :order distance
-    :limit %d`, limit*5, vecStr, limit)
+    :limit %d`, nearest, filter, limit)
 		case "decision":
 			script = fmt.Sprintf(`?[id, title, rationale, status, distance] :=
-    ~mie_decision_embedding:decision_embedding_idx { decision_id | query: q, k: %d, ef: 200, bind_distance: distance },
-    q = vec(%s),
-    *mie_decision { id: decision_id, title, rationale, status },
+    %s,
+    *mie_decision { id: decision_id, title, rationale, status, source_agent, created_at, namespace },
+    namespace = $ns%s,
     id = decision_id
     :order distance
-    :limit %d`, limit*5, vecStr, limit)
+    :limit %d`, nearest, filter, limit)
 		case "entity":
 			script = fmt.Sprintf(`?[id, name, kind, description, distance] :=
-    ~mie_entity_embedding:entity_embedding_idx { entity_id | query: q, k: %d, ef: 200, bind_distance: distance },
-    q = vec(%s),
-    *mie_entity { id: entity_id, name, kind, description },
+    %s,
+    *mie_entity { id: entity_id, name, kind, description, source_agent, created_at, namespace },
+    namespace = $ns%s,
     id = entity_id
     :order distance
-    :limit %d`, limit*5, vecStr, limit)
+    :limit %d`, nearest, filter, limit)
 		case "event":
 			script = fmt.Sprintf(`?[id, title, description, event_date, distance] :=
-    ~mie_event_embedding:event_embedding_idx { event_id | query: q, k: %d, ef: 200, bind_distance: distance },
-    q = vec(%s),
-    *mie_event { id: event_id, title, description, event_date },
+    %s,
+    *mie_event { id: event_id, title, description, event_date, source_agent, created_at, namespace },
+    namespace = $ns%s,
     id = event_id
     :order distance
-    :limit %d`, limit*5, vecStr, limit)
+    :limit %d`, nearest, filter, limit)
 		default:
 			continue
 		}
 
-		qr, err := r.backend.Query(ctx, script)
+		qr, err := r.backend.Query(ctx, script, params)
 		if err != nil {
 			r.logger.Warn("semantic search failed for type", "type", nt, "error", err)
 			continue
@@ -106,9 +200,95 @@ func (r *Reader) SemanticSearch(ctx context.Context, query string, nodeTypes []s
 			results = append(results, sr)
 		}
 	}
+	return results
+}
+
+// ExactSearch performs substring matching across the memory graph.
+func (r *Reader) ExactSearch(ctx context.Context, query string, nodeTypes []string, limit int) ([]tools.SearchResult, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	// Matching ignores case and accents, so "reunion" finds "Reunión".
+	folded := foldExpr("$query")
+	includes := func(col string) string {
+		return fmt.Sprintf("str_includes(%s, %s)", foldExpr(col), folded)
+	}
+	ns := resolveNamespace(ctx, r.namespace)
+	tr := tools.TimeRangeFromContext(ctx)
+	agent := tools.SourceAgentFromContext(ctx)
+	var results []tools.SearchResult
+
+	if len(nodeTypes) == 0 {
+		nodeTypes = []string{"fact", "decision", "entity", "event", "topic"}
+	}
+
+	for _, nt := range nodeTypes {
+		if tr.HasEventDate() && nt != "event" {
+			continue
+		}
+		// Topics have no source_agent.
+		if agent != "" && nt == "topic" {
+			continue
+		}
+		params := map[string]any{"ns": ns, "query": query}
+		filter := timeRangeFilter(tr, nt, "id", params) + sourceAgentFilter(agent, params) + archivedFilter(ctx, "id")
+
+		var script string
+		switch nt {
+		case "fact":
+			script = fmt.Sprintf(`?[id, content, category, confidence, created_at] :=
+    *mie_fact { id, content, category, confidence, valid, source_agent, created_at, namespace },
+    valid = true,
+    namespace = $ns%s,
+    %s
+    :limit %d`, filter, includes("content"), limit)
+		case "decision":
+			script = fmt.Sprintf(`?[id, title, rationale, status] :=
+    *mie_decision { id, title, rationale, status, source_agent, created_at, namespace },
+    namespace = $ns%s,
+    or(%s, %s)
+    :limit %d`, filter, includes("title"), includes("rationale"), limit)
+		case "entity":
+			script = fmt.Sprintf(`?[id, name, kind, description] :=
+    *mie_entity { id, name, kind, description, source_agent, created_at, namespace },
+    namespace = $ns%s,
+    or(%s, %s)
+    :limit %d`, filter, includes("name"), includes("description"), limit)
+		case "event":
+			script = fmt.Sprintf(`?[id, title, description, event_date] :=
+    *mie_event { id, title, description, event_date, source_agent, created_at, namespace },
+    namespace = $ns%s,
+    or(%s, %s)
+    :limit %d`, filter, includes("title"), includes("description"), limit)
+		case "topic":
+			script = fmt.Sprintf(`?[id, name, description] :=
+    *mie_topic { id, name, description, created_at, namespace },
+    namespace = $ns%s,
+    or(%s, %s)
+    :limit %d`, filter, includes("name"), includes("description"), limit)
+		default:
+			continue
+		}
+
+		qr, err := r.backend.Query(ctx, script, params)
+		if err != nil {
+			r.logger.Warn("exact search failed for type", "type", nt, "error", err)
+			continue
+		}
+
+		for _, row := range qr.Rows {
+			sr := r.parseSearchResult(nt, row, qr.Headers)
+			results = append(results, sr)
+		}
+	}
 
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Distance < results[j].Distance
+	// Matches are unranked, so only age decay and verification order them.
+	r.attachSearchVerification(ctx, results)
+	now := time.Now().Unix()
+	r.applyDecay(results, now)
+	sort.SliceStable(results, func(i, j int) bool {
+		return r.rankWeight(results[i], now) > r.rankWeight(results[j], now)
 	})
 
 	if len(results) > limit {
@@ -118,13 +298,108 @@ func (r *Reader) SemanticSearch(ctx context.Context, query string, nodeTypes []s
 	return results, nil
 }
 
-// ExactSearch performs substring matching across the memory graph.
-func (r *Reader) ExactSearch(ctx context.Context, query string, nodeTypes []string, limit int) ([]tools.SearchResult, error) {
+// applyDecay sets EffectiveConfidence on fact results when a decay
+// half-life is configured.
+func (r *Reader) applyDecay(results []tools.SearchResult, now int64) {
+	if r.halfLifeDays <= 0 {
+		return
+	}
+	for i := range results {
+		if f, ok := results[i].Metadata.(*tools.Fact); ok {
+			results[i].EffectiveConfidence = f.Confidence * decayFactor(f.CreatedAt, now, r.halfLifeDays)
+		}
+	}
+}
+
+// rankWeight returns the ranking weight of sr: for facts, its age decay
+// factor, multiplied by verifiedBoost when verified; 1 for other node types.
+func (r *Reader) rankWeight(sr tools.SearchResult, now int64) float64 {
+	f, ok := sr.Metadata.(*tools.Fact)
+	if !ok {
+		return 1
+	}
+	weight := decayFactor(f.CreatedAt, now, r.halfLifeDays)
+	if f.Verified {
+		weight *= verifiedBoost
+	}
+	return weight
+}
+
+// attachSearchVerification sets the verification fields of fact results.
+// A failed lookup is logged and leaves the results unboosted.
+func (r *Reader) attachSearchVerification(ctx context.Context, results []tools.SearchResult) {
+	var facts []*tools.Fact
+	for _, sr := range results {
+		if f, ok := sr.Metadata.(*tools.Fact); ok {
+			facts = append(facts, f)
+		}
+	}
+	if err := r.attachFactMetadata(ctx, facts); err != nil {
+		r.logger.Warn("search: fact verification lookup failed", "error", err)
+	}
+}
+
+// attachFactMetadata sets the verification, expiry, key and negation
+// fields of facts.
+func (r *Reader) attachFactMetadata(ctx context.Context, facts []*tools.Fact) error {
+	if err := r.attachVerification(ctx, facts); err != nil {
+		return err
+	}
+	if err := r.attachExpiry(ctx, facts); err != nil {
+		return err
+	}
+	if err := r.attachKeys(ctx, facts); err != nil {
+		return err
+	}
+	return r.attachNegation(ctx, facts)
+}
+
+// attachVerification sets the verification fields of facts from
+// mie_fact_verification.
+func (r *Reader) attachVerification(ctx context.Context, facts []*tools.Fact) error {
+	if len(facts) == 0 {
+		return nil
+	}
+	ids := make([]string, len(facts))
+	for i, f := range facts {
+		ids[i] = f.ID
+	}
+	script := `ids[fact_id] <- $ids
+?[fact_id, verified_by, verified_at] := ids[fact_id], *mie_fact_verification { fact_id, verified_by, verified_at }`
+
+	qr, err := r.backend.Query(ctx, script, map[string]any{"ids": idRows(ids)})
+	if err != nil {
+		return fmt.Errorf("get fact verification: %w", err)
+	}
+	verified := make(map[string][]any, len(qr.Rows))
+	for _, row := range qr.Rows {
+		verified[toString(row[0])] = row
+	}
+	for _, f := range facts {
+		if row, ok := verified[f.ID]; ok {
+			f.Verified = true
+			f.VerifiedBy = toString(row[1])
+			f.VerifiedAt = toInt64(row[2])
+		}
+	}
+	return nil
+}
+
+// FullTextSearch performs ranked keyword search using the full-text indexes.
+// Words are stemmed and stopwords dropped, so "deploying" matches "deploy";
+// results are ordered by TF-IDF score, highest first.
+func (r *Reader) FullTextSearch(ctx context.Context, query string, nodeTypes []string, limit int) ([]tools.SearchResult, error) {
 	if limit <= 0 {
 		limit = 10
 	}
 
-	escaped := escapeDatalog(query)
+	q := ftsQuery(query)
+	if q == "" {
+		return nil, nil
+	}
+	ns := resolveNamespace(ctx, r.namespace)
+	tr := tools.TimeRangeFromContext(ctx)
+	agent := tools.SourceAgentFromContext(ctx)
 	var results []tools.SearchResult
 
 	if len(nodeTypes) == 0 {
@@ -132,50 +407,75 @@ func (r *Reader) ExactSearch(ctx context.Context, query string, nodeTypes []stri
 	}
 
 	for _, nt := range nodeTypes {
+		if tr.HasEventDate() && nt != "event" {
+			continue
+		}
+		// Topics have no source_agent.
+		if agent != "" && nt == "topic" {
+			continue
+		}
+		params := map[string]any{"ns": ns, "query": q}
+		filter := timeRangeFilter(tr, nt, "id", params) + sourceAgentFilter(agent, params) + archivedFilter(ctx, "id")
+
 		var script string
 		switch nt {
 		case "fact":
-			script = fmt.Sprintf(`?[id, content, category, confidence] :=
-    *mie_fact { id, content, category, confidence, valid },
+			script = fmt.Sprintf(`?[id, content, category, confidence, created_at, score] :=
+    ~mie_fact:fact_fts { id, content, category, confidence, valid, source_agent, created_at, namespace | query: $query, k: %d, bind_score: score },
     valid = true,
-    str_includes(content, '%s')
-    :limit %d`, escaped, limit)
+    namespace = $ns%s
+    :order -score
+    :limit %d`, limit*5, filter, limit)
 		case "decision":
-			script = fmt.Sprintf(`?[id, title, rationale, status] :=
-    *mie_decision { id, title, rationale, status },
-    or(str_includes(title, '%s'), str_includes(rationale, '%s'))
-    :limit %d`, escaped, escaped, limit)
+			script = fmt.Sprintf(`?[id, title, rationale, status, score] :=
+    ~mie_decision:decision_fts { id, title, rationale, status, source_agent, created_at, namespace | query: $query, k: %d, bind_score: score },
+    namespace = $ns%s
+    :order -score
+    :limit %d`, limit*5, filter, limit)
 		case "entity":
-			script = fmt.Sprintf(`?[id, name, kind, description] :=
-    *mie_entity { id, name, kind, description },
-    or(str_includes(name, '%s'), str_includes(description, '%s'))
-    :limit %d`, escaped, escaped, limit)
+			script = fmt.Sprintf(`?[id, name, kind, description, score] :=
+    ~mie_entity:entity_fts { id, name, kind, description, source_agent, created_at, namespace | query: $query, k: %d, bind_score: score },
+    namespace = $ns%s
+    :order -score
+    :limit %d`, limit*5, filter, limit)
 		case "event":
-			script = fmt.Sprintf(`?[id, title, description, event_date] :=
-    *mie_event { id, title, description, event_date },
-    or(str_includes(title, '%s'), str_includes(description, '%s'))
-    :limit %d`, escaped, escaped, limit)
+			script = fmt.Sprintf(`?[id, title, description, event_date, score] :=
+    ~mie_event:event_fts { id, title, description, event_date, source_agent, created_at, namespace | query: $query, k: %d, bind_score: score },
+    namespace = $ns%s
+    :order -score
+    :limit %d`, limit*5, filter, limit)
 		case "topic":
-			script = fmt.Sprintf(`?[id, name, description] :=
-    *mie_topic { id, name, description },
-    or(str_includes(name, '%s'), str_includes(description, '%s'))
-    :limit %d`, escaped, escaped, limit)
+			script = fmt.Sprintf(`?[id, name, description, score] :=
+    ~mie_topic:topic_fts { id, name, description, created_at, namespace | query: $query, k: %d, bind_score: score },
+    namespace = $ns%s
+    :order -score
+    :limit %d`, limit*5, filter, limit)
 		default:
 			continue
 		}
 
-		qr, err := r.backend.Query(ctx, script)
+		qr, err := r.backend.Query(ctx, script, params)
 		if err != nil {
-			r.logger.Warn("exact search failed for type", "type", nt, "error", err)
+			r.logger.Warn("fulltext search failed for type", "type", nt, "error", err)
 			continue
 		}
 
 		for _, row := range qr.Rows {
 			sr := r.parseSearchResult(nt, row, qr.Headers)
+			// The score column is last; it is a relevance score, not a distance.
+			sr.Distance = 0
+			sr.Score = toFloat64(row[len(row)-1])
 			results = append(results, sr)
 		}
 	}
 
+	r.attachSearchVerification(ctx, results)
+	now := time.Now().Unix()
+	r.applyDecay(results, now)
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score*r.rankWeight(results[i], now) > results[j].Score*r.rankWeight(results[j], now)
+	})
+
 	if len(results) > limit {
 		results = results[:limit]
 	}
@@ -183,6 +483,40 @@ func (r *Reader) ExactSearch(ctx context.Context, query string, nodeTypes []stri
 	return results, nil
 }
 
+// HybridSearch runs semantic and exact search and merges both result lists
+// with reciprocal-rank fusion, so paraphrases and literal identifiers are both
+// found. Without embeddings it degrades to exact search alone.
+func (r *Reader) HybridSearch(ctx context.Context, query string, nodeTypes []string, limit int) ([]tools.SearchResult, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	// Fetch a deeper pool from each leg so fusion can promote results that
+	// rank moderately in both.
+	pool := limit * 2
+
+	var lists []tools.RankedList
+	if r.embedder != nil {
+		semantic, err := r.SemanticSearch(ctx, query, nodeTypes, pool)
+		if err != nil {
+			r.logger.Warn("hybrid search: semantic leg failed, using exact results only", "error", err)
+		} else {
+			lists = append(lists, tools.RankedList{Source: "semantic", Results: semantic})
+		}
+	}
+
+	exact, err := r.ExactSearch(ctx, query, nodeTypes, pool)
+	if err != nil {
+		return nil, err
+	}
+	lists = append(lists, tools.RankedList{Source: "exact", Results: exact})
+
+	results := tools.FuseRanked(tools.DefaultRRFK, lists...)
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
 // ListNodes returns a paginated list of nodes matching the given options.
 func (r *Reader) ListNodes(ctx context.Context, opts tools.ListOptions) ([]any, int, error) {
 	if opts.Limit <= 0 {
@@ -194,7 +528,9 @@ func (r *Reader) ListNodes(ctx context.Context, opts tools.ListOptions) ([]any,
 		return nil, 0, fmt.Errorf("unknown node type: %s", opts.NodeType)
 	}
 
-	conditions := buildListConditions(opts)
+	params := map[string]any{"ns": resolveNamespace(ctx, r.namespace)}
+	conditions := buildListConditions(opts, params)
+	conditions = append(conditions, `namespace = $ns`)
 	columns := columnsForNodeType(opts.NodeType)
 
 	condStr := ""
@@ -206,90 +542,244 @@ func (r *Reader) ListNodes(ctx context.Context, opts tools.ListOptions) ([]any,
 	if sortBy == "" {
 		sortBy = "created_at"
 	}
-	sortOrder := sortBy
+	// sortBy is pasted into the script, so it must name a column.
+	if err := CheckSortColumn(opts.NodeType, sortBy); err != nil {
+		return nil, 0, err
+	}
+	// Order ties by ID so pages, and cursors into them, are stable.
+	sortOrder := sortBy + ", id"
 	if opts.SortOrder != "asc" {
-		sortOrder = "-" + sortBy
+		sortOrder = "-" + sortBy + ", -id"
 	}
 
-	script := fmt.Sprintf(`?[%s] := *%s { %s }%s :order %s :limit %d :offset %d`,
-		columns, table, columns, condStr, sortOrder, opts.Limit, opts.Offset,
+	pageCondStr := condStr
+	if opts.After != nil {
+		cmp := ">"
+		if opts.SortOrder != "asc" {
+			cmp = "<"
+		}
+		pageCondStr += fmt.Sprintf(", or(%[1]s %[2]s $after_value, and(%[1]s == $after_value, id %[2]s $after_id))", sortBy, cmp)
+		params["after_value"] = opts.After.Value
+		params["after_id"] = opts.After.ID
+	}
+
+	script := fmt.Sprintf(`?[%s] := *%s { %s, namespace }%s :order %s :limit %d :offset %d`,
+		columns, table, columns, pageCondStr, sortOrder, opts.Limit, opts.Offset,
 	)
 
-	qr, err := r.backend.Query(ctx, script)
+	qr, err := r.backend.Query(ctx, script, params)
 	if err != nil {
 		return nil, 0, fmt.Errorf("list nodes: %w", err)
 	}
 
-	totalCount, err := r.countNodes(ctx, table, conditions, condStr)
+	totalCount, err := r.countNodes(ctx, table, conditions, condStr, params)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	var nodes []any
+	var facts []*tools.Fact
+	var events []*tools.Event
 	for _, row := range qr.Rows {
 		node := r.parseNode(opts.NodeType, row, qr.Headers)
 		if node != nil {
 			nodes = append(nodes, node)
 		}
+		switch n := node.(type) {
+		case *tools.Fact:
+			facts = append(facts, n)
+		case *tools.Event:
+			events = append(events, n)
+		}
+	}
+	if err := r.attachFactMetadata(ctx, facts); err != nil {
+		return nil, 0, err
+	}
+	if err := r.attachEventEnds(ctx, events); err != nil {
+		return nil, 0, err
+	}
+	if opts.IncludeDegree {
+		if err := r.attachDegrees(ctx, opts.NodeType, nodes); err != nil {
+			return nil, 0, err
+		}
 	}
 
 	return nodes, totalCount, nil
 }
 
-// buildListConditions builds filter conditions for a ListNodes query.
-func buildListConditions(opts tools.ListOptions) []string {
+// attachDegrees sets the Degree of nodes, all of type nodeType, to the
+// number of edges they have in either direction.
+func (r *Reader) attachDegrees(ctx context.Context, nodeType string, nodes []any) error {
+	fields := make(map[string]**int, len(nodes))
+	ids := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		var id string
+		var degree **int
+		switch n := node.(type) {
+		case *tools.Fact:
+			id, degree = n.ID, &n.Degree
+		case *tools.Decision:
+			id, degree = n.ID, &n.Degree
+		case *tools.Entity:
+			id, degree = n.ID, &n.Degree
+		case *tools.Event:
+			id, degree = n.ID, &n.Degree
+		case *tools.Topic:
+			id, degree = n.ID, &n.Degree
+		default:
+			continue
+		}
+		zero := 0
+		*degree = &zero
+		fields[id] = degree
+		ids = append(ids, id)
+	}
+
+	// edge[id, other, dir] holds one row per edge of a listed node; dir
+	// tells apart the two sides of edges between nodes of the same type.
+	var rules []string
+	for _, table := range sortedEdgeTables() {
+		keyCols := ValidEdgeTables[table]
+		endpoints := edgeTableEndpoints[table]
+		for side := 0; side < 2; side++ {
+			if endpoints[side] != nodeType {
+				continue
+			}
+			rules = append(rules, fmt.Sprintf(`edge[id, other, dir] := ids[id], *%s { %s: id, %s: other }, dir = '%s:%d'`,
+				table, keyCols[side], keyCols[1-side], table, side))
+		}
+	}
+	if len(ids) == 0 || len(rules) == 0 {
+		return nil
+	}
+	script := fmt.Sprintf("ids[id] <- $ids\n%s\n?[id, count(other)] := edge[id, other, dir]",
+		strings.Join(rules, "\n"))
+
+	qr, err := r.backend.Query(ctx, script, map[string]any{"ids": idRows(ids)})
+	if err != nil {
+		return fmt.Errorf("count node edges: %w", err)
+	}
+	for _, row := range qr.Rows {
+		if degree, ok := fields[toString(row[0])]; ok {
+			n := toInt(row[1])
+			*degree = &n
+		}
+	}
+	return nil
+}
+
+// buildListConditions builds filter conditions for a ListNodes query and
+// adds the values they compare against to params.
+func buildListConditions(opts tools.ListOptions, params map[string]any) []string {
 	var conditions []string
 	switch opts.NodeType {
 	case "fact":
 		if opts.Category != "" {
-			conditions = append(conditions, fmt.Sprintf(`category = '%s'`, escapeDatalog(opts.Category)))
+			conditions = append(conditions, `category = $category`)
+			params["category"] = opts.Category
 		}
 		if opts.ValidOnly {
 			conditions = append(conditions, `valid = true`)
 		}
 	case "decision":
 		if opts.Status != "" {
-			conditions = append(conditions, fmt.Sprintf(`status = '%s'`, escapeDatalog(opts.Status)))
+			conditions = append(conditions, `status = $status`)
+			params["status"] = opts.Status
 		}
 	case "entity":
 		if opts.Kind != "" {
-			conditions = append(conditions, fmt.Sprintf(`kind = '%s'`, escapeDatalog(opts.Kind)))
+			conditions = append(conditions, `kind = $kind`)
+			params["kind"] = opts.Kind
 		}
 	}
+	if opts.SourceAgent != "" && opts.NodeType != "topic" {
+		conditions = append(conditions, `source_agent = $source_agent`)
+		params["source_agent"] = opts.SourceAgent
+	}
+	if opts.SourceConversation != "" {
+		switch opts.NodeType {
+		case "fact", "decision", "event":
+			conditions = append(conditions, `source_conversation = $source_conversation`)
+			params["source_conversation"] = opts.SourceConversation
+		}
+	}
+	if !opts.IncludeArchived {
+		conditions = append(conditions, `not *mie_archived { node_id: id }`)
+	}
+	return append(conditions, timeRangeConditions(opts.TimeRange, opts.NodeType, "id", params)...)
+}
+
+// timeRangeConditions builds created_at conditions for tr, plus the
+// conditions of eventOverlapConditions when nodeType is "event", and adds
+// the event dates to params. The bound columns, and the node ID as idVar,
+// must be in scope.
+func timeRangeConditions(tr tools.TimeRange, nodeType, idVar string, params map[string]any) []string {
+	var conditions []string
+	if tr.CreatedAfter != 0 {
+		conditions = append(conditions, fmt.Sprintf(`created_at >= %d`, tr.CreatedAfter))
+	}
+	if tr.CreatedBefore != 0 {
+		conditions = append(conditions, fmt.Sprintf(`created_at < %d`, tr.CreatedBefore))
+	}
+	if nodeType == "event" {
+		conditions = append(conditions, eventOverlapConditions(tr, idVar, params)...)
+	}
 	return conditions
 }
 
-// columnsForNodeType returns the column list for a given node type.
-func columnsForNodeType(nodeType string) string {
-	switch nodeType {
-	case "fact":
-		return "id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at"
-	case "decision":
-		return "id, title, rationale, alternatives, context, source_agent, source_conversation, status, created_at, updated_at"
-	case "entity":
-		return "id, name, kind, description, source_agent, created_at, updated_at"
-	case "event":
-		return "id, title, description, event_date, source_agent, source_conversation, created_at, updated_at"
-	case "topic":
-		return "id, name, description, created_at, updated_at"
-	default:
-		return "id"
+// timeRangeFilter renders timeRangeConditions as a suffix for a rule body
+// that already ends with a condition, e.g. ", created_at >= 1700000000".
+func timeRangeFilter(tr tools.TimeRange, nodeType, idVar string, params map[string]any) string {
+	conditions := timeRangeConditions(tr, nodeType, idVar, params)
+	if len(conditions) == 0 {
+		return ""
 	}
+	return ",\n    " + strings.Join(conditions, ",\n    ")
 }
 
-// countNodes executes a count query for the given table and conditions.
-func (r *Reader) countNodes(ctx context.Context, table string, conditions []string, condStr string) (int, error) {
+// sourceAgentFilter returns a rule body suffix that keeps nodes written by
+// agent, bound in params, or "" when agent is empty. source_agent must be
+// bound.
+func sourceAgentFilter(agent string, params map[string]any) string {
+	if agent == "" {
+		return ""
+	}
+	params["source_agent"] = agent
+	return ",\n    source_agent = $source_agent"
+}
+
+// archivedFilter returns a rule body suffix that drops archived nodes, whose
+// ID is bound to idVar, unless ctx asks for them with WithIncludeArchived.
+func archivedFilter(ctx context.Context, idVar string) string {
+	if tools.IncludeArchivedFromContext(ctx) {
+		return ""
+	}
+	return fmt.Sprintf(",\n    not *mie_archived { node_id: %s }", idVar)
+}
+
+// countNodes executes a count query for the given table and conditions,
+// with params bound.
+func (r *Reader) countNodes(ctx context.Context, table string, conditions []string, condStr string, params map[string]any) (int, error) {
 	var countCols []string
 	countCols = append(countCols, "id")
+	bound := map[string]bool{"id": true}
 	for _, cond := range conditions {
-		if eqIdx := strings.Index(cond, " = "); eqIdx > 0 {
-			col := strings.TrimSpace(cond[:eqIdx])
-			countCols = append(countCols, col)
+		// Every condition except a negation or a join starts with the
+		// column it filters on.
+		if strings.HasPrefix(cond, "not ") || strings.HasPrefix(cond, "*") {
+			continue
+		}
+		if spIdx := strings.Index(cond, " "); spIdx > 0 {
+			col := cond[:spIdx]
+			if !bound[col] {
+				bound[col] = true
+				countCols = append(countCols, col)
+			}
 		}
 	}
 	countScript := fmt.Sprintf(`?[count(id)] := *%s { %s }%s`,
 		table, strings.Join(countCols, ", "), condStr)
-	countResult, err := r.backend.Query(ctx, countScript)
+	countResult, err := r.backend.Query(ctx, countScript, params)
 	if err != nil {
 		return 0, fmt.Errorf("count nodes: %w", err)
 	}
@@ -367,9 +857,9 @@ func (r *Reader) getNodeByType(ctx context.Context, nodeID, nodeType string) (an
 		columns = "id, name, description, created_at, updated_at"
 	}
 
-	script := fmt.Sprintf(`?[%s] := *%s { %s }, id = '%s'`, columns, table, columns, escapeDatalog(nodeID))
+	script := fmt.Sprintf(`?[%s] := *%s { %s }, id = $id`, columns, table, columns)
 
-	qr, err := r.backend.Query(ctx, script)
+	qr, err := r.backend.Query(ctx, script, map[string]any{"id": nodeID})
 	if err != nil {
 		return nil, err
 	}
@@ -378,27 +868,54 @@ func (r *Reader) getNodeByType(ctx context.Context, nodeID, nodeType string) (an
 		return nil, nil
 	}
 
-	return r.parseNode(nodeType, qr.Rows[0], qr.Headers), nil
+	node := r.parseNode(nodeType, qr.Rows[0], qr.Headers)
+	switch n := node.(type) {
+	case *tools.Fact:
+		if err := r.attachFactMetadata(ctx, []*tools.Fact{n}); err != nil {
+			return nil, err
+		}
+	case *tools.Event:
+		if err := r.attachEventEnds(ctx, []*tools.Event{n}); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
 }
 
-// FindEntityByName finds an entity by its name (case-insensitive).
+// FindEntityByName finds an entity by its name, ignoring case and accents.
+// When no entity has that name, aliases recorded with Writer.AddAlias are
+// resolved, and then the entity whose name or alias is most like name, so
+// "Postgress" finds "PostgreSQL". It returns nil when nothing is close
+// enough.
 func (r *Reader) FindEntityByName(ctx context.Context, name string) (*tools.Entity, error) {
-	escaped := escapeDatalog(strings.ToLower(name))
 	script := fmt.Sprintf(
 		`?[id, name, kind, description, source_agent, created_at, updated_at] :=
-    *mie_entity { id, name, kind, description, source_agent, created_at, updated_at },
-    lname = lowercase(name),
-    lname = '%s'
-    :limit 1`, escaped,
+    *mie_entity { id, name, kind, description, source_agent, created_at, updated_at, namespace },
+    namespace = $ns,
+    lname = %s,
+    lname = %s
+    :limit 1`, foldExpr("name"), foldExpr("$name"),
 	)
 
-	qr, err := r.backend.Query(ctx, script)
+	qr, err := r.backend.Query(ctx, script, map[string]any{"ns": resolveNamespace(ctx, r.namespace), "name": name})
 	if err != nil {
 		return nil, err
 	}
 
 	if len(qr.Rows) == 0 {
-		return nil, nil
+		entityID, err := r.ResolveAlias(ctx, name)
+		if err == nil && entityID == "" {
+			entityID, err = r.closestEntity(ctx, name)
+		}
+		if err != nil || entityID == "" {
+			return nil, err
+		}
+		node, err := r.getNodeByType(ctx, entityID, "entity")
+		if err != nil {
+			return nil, err
+		}
+		ent, _ := node.(*tools.Entity)
+		return ent, nil
 	}
 
 	node := r.parseNode("entity", qr.Rows[0], qr.Headers)
@@ -408,17 +925,67 @@ func (r *Reader) FindEntityByName(ctx context.Context, name string) (*tools.Enti
 	return nil, nil
 }
 
+// closestEntity returns the ID of the entity of the namespace whose name or
+// alias is most similar to name, or "" when none reaches
+// DefaultDuplicateThreshold.
+func (r *Reader) closestEntity(ctx context.Context, name string) (string, error) {
+	params := map[string]any{"ns": resolveNamespace(ctx, r.namespace)}
+	qr, err := r.backend.Query(ctx, `?[id, name] := *mie_entity { id, name, namespace }, namespace = $ns
+:order id`, params)
+	if err != nil {
+		return "", fmt.Errorf("list entities: %w", err)
+	}
+	members := make([]tools.DuplicateMember, 0, len(qr.Rows))
+	index := make(map[string]int, len(qr.Rows))
+	for _, row := range qr.Rows {
+		index[toString(row[0])] = len(members)
+		members = append(members, tools.DuplicateMember{ID: toString(row[0]), Name: toString(row[1])})
+	}
+	qr, err = r.backend.Query(ctx, `?[entity_id, alias] := *mie_entity_alias { alias, namespace, entity_id }, namespace = $ns
+:order alias`, params)
+	if err != nil {
+		return "", fmt.Errorf("list entity aliases: %w", err)
+	}
+	for _, row := range qr.Rows {
+		if i, ok := index[toString(row[0])]; ok {
+			members[i].Aliases = append(members[i].Aliases, toString(row[1]))
+		}
+	}
+	m, _ := BestNameMatch(name, members, DefaultDuplicateThreshold)
+	return m.ID, nil
+}
+
+// ResolveAlias returns the ID of the entity that alias refers to in the
+// current namespace, or "" if the alias is unknown. Accents are ignored, so
+// "Pena" resolves the alias "Peña".
+func (r *Reader) ResolveAlias(ctx context.Context, alias string) (string, error) {
+	script := fmt.Sprintf(
+		`?[entity_id] := *mie_entity_alias { alias, namespace, entity_id }, namespace = $ns, %s == %s
+:limit 1`,
+		foldExpr("alias"), foldExpr("$alias"),
+	)
+
+	qr, err := r.backend.Query(ctx, script, map[string]any{"ns": resolveNamespace(ctx, r.namespace), "alias": strings.TrimSpace(alias)})
+	if err != nil {
+		return "", err
+	}
+	if len(qr.Rows) == 0 {
+		return "", nil
+	}
+	return toString(qr.Rows[0][0]), nil
+}
+
 // FindFactByContent finds a fact by matching content.
 func (r *Reader) FindFactByContent(ctx context.Context, content string) (*tools.Fact, error) {
-	escaped := escapeDatalog(content)
 	script := fmt.Sprintf(
 		`?[id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at] :=
-    *mie_fact { id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at },
-    str_includes(content, '%s')
-    :limit 1`, escaped,
+    *mie_fact { id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at, namespace },
+    namespace = $ns,
+    str_includes(content, $content)
+    :limit 1`,
 	)
 
-	qr, err := r.backend.Query(ctx, script)
+	qr, err := r.backend.Query(ctx, script, map[string]any{"ns": resolveNamespace(ctx, r.namespace), "content": content})
 	if err != nil {
 		return nil, err
 	}
@@ -436,15 +1003,15 @@ func (r *Reader) FindFactByContent(ctx context.Context, content string) (*tools.
 
 // FindDecisionByTitle finds a decision by matching title.
 func (r *Reader) FindDecisionByTitle(ctx context.Context, title string) (*tools.Decision, error) {
-	escaped := escapeDatalog(title)
 	script := fmt.Sprintf(
 		`?[id, title, rationale, alternatives, context, source_agent, source_conversation, status, created_at, updated_at] :=
-    *mie_decision { id, title, rationale, alternatives, context, source_agent, source_conversation, status, created_at, updated_at },
-    str_includes(title, '%s')
-    :limit 1`, escaped,
+    *mie_decision { id, title, rationale, alternatives, context, source_agent, source_conversation, status, created_at, updated_at, namespace },
+    namespace = $ns,
+    str_includes(title, $title)
+    :limit 1`,
 	)
 
-	qr, err := r.backend.Query(ctx, script)
+	qr, err := r.backend.Query(ctx, script, map[string]any{"ns": resolveNamespace(ctx, r.namespace), "title": title})
 	if err != nil {
 		return nil, err
 	}
@@ -460,17 +1027,41 @@ func (r *Reader) FindDecisionByTitle(ctx context.Context, title string) (*tools.
 	return nil, nil
 }
 
-// GetRelatedEntities returns entities related to a given fact.
+// Traversals bind the metadata of the edge they follow under these names so
+// it does not clash with the node's own source_agent and created_at.
+const (
+	edgeMetaColumns  = "weight, edge_agent, edge_created_at"
+	edgeMetaBindings = "weight, source_agent: edge_agent, created_at: edge_created_at"
+	// edgeMetaOrder ranks traversal results by edge weight, newest first
+	// among equal weights.
+	edgeMetaOrder = "-weight, -edge_created_at"
+)
+
+// edgeMetaFromRow reads the edgeMetaColumns at the start of row.
+func edgeMetaFromRow(row []any) *tools.EdgeMeta {
+	if len(row) < 3 {
+		return nil
+	}
+	return &tools.EdgeMeta{
+		Weight:      toFloat64(row[0]),
+		SourceAgent: toString(row[1]),
+		CreatedAt:   toInt64(row[2]),
+	}
+}
+
+// GetRelatedEntities returns entities related to a given fact, strongest
+// edges first.
 func (r *Reader) GetRelatedEntities(ctx context.Context, factID string) ([]tools.Entity, error) {
 	script := fmt.Sprintf(
-		`?[id, name, kind, description, source_agent, created_at, updated_at] :=
-    *mie_fact_entity { fact_id, entity_id },
-    fact_id = '%s',
+		`?[id, name, kind, description, source_agent, created_at, updated_at, %s] :=
+    *mie_fact_entity { fact_id, entity_id, %s },
+    fact_id = $id,
     *mie_entity { id: entity_id, name, kind, description, source_agent, created_at, updated_at },
-    id = entity_id`, escapeDatalog(factID),
+    id = entity_id
+    :order %s`, edgeMetaColumns, edgeMetaBindings, edgeMetaOrder,
 	)
 
-	qr, err := r.backend.Query(ctx, script)
+	qr, err := r.backend.Query(ctx, script, map[string]any{"id": factID})
 	if err != nil {
 		return nil, fmt.Errorf("get related entities: %w", err)
 	}
@@ -479,6 +1070,7 @@ func (r *Reader) GetRelatedEntities(ctx context.Context, factID string) ([]tools
 	for _, row := range qr.Rows {
 		node := r.parseNode("entity", row, qr.Headers)
 		if ent, ok := node.(*tools.Entity); ok {
+			ent.Edge = edgeMetaFromRow(row[7:])
 			entities = append(entities, *ent)
 		}
 	}
@@ -486,17 +1078,19 @@ func (r *Reader) GetRelatedEntities(ctx context.Context, factID string) ([]tools
 	return entities, nil
 }
 
-// GetFactsAboutEntity returns facts associated with a given entity.
+// GetFactsAboutEntity returns facts associated with a given entity,
+// strongest edges first.
 func (r *Reader) GetFactsAboutEntity(ctx context.Context, entityID string) ([]tools.Fact, error) {
 	script := fmt.Sprintf(
-		`?[id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at] :=
-    *mie_fact_entity { fact_id, entity_id },
-    entity_id = '%s',
+		`?[id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at, %s] :=
+    *mie_fact_entity { fact_id, entity_id, %s },
+    entity_id = $id,
     *mie_fact { id: fact_id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at },
-    id = fact_id`, escapeDatalog(entityID),
+    id = fact_id
+    :order %s`, edgeMetaColumns, edgeMetaBindings, edgeMetaOrder,
 	)
 
-	qr, err := r.backend.Query(ctx, script)
+	qr, err := r.backend.Query(ctx, script, map[string]any{"id": entityID})
 	if err != nil {
 		return nil, fmt.Errorf("get facts about entity: %w", err)
 	}
@@ -505,24 +1099,30 @@ func (r *Reader) GetFactsAboutEntity(ctx context.Context, entityID string) ([]to
 	for _, row := range qr.Rows {
 		node := r.parseNode("fact", row, qr.Headers)
 		if fact, ok := node.(*tools.Fact); ok {
+			fact.Edge = edgeMetaFromRow(row[9:])
 			facts = append(facts, *fact)
 		}
 	}
+	if err := r.attachFactMetadata(ctx, factPointers(facts)); err != nil {
+		return nil, err
+	}
 
 	return facts, nil
 }
 
-// GetDecisionEntities returns entities involved in a given decision.
+// GetDecisionEntities returns entities involved in a given decision,
+// strongest edges first.
 func (r *Reader) GetDecisionEntities(ctx context.Context, decisionID string) ([]tools.EntityWithRole, error) {
 	script := fmt.Sprintf(
-		`?[id, name, kind, description, source_agent, created_at, updated_at, role] :=
-    *mie_decision_entity { decision_id, entity_id, role },
-    decision_id = '%s',
+		`?[id, name, kind, description, source_agent, created_at, updated_at, role, %s] :=
+    *mie_decision_entity { decision_id, entity_id, role, %s },
+    decision_id = $id,
     *mie_entity { id: entity_id, name, kind, description, source_agent, created_at, updated_at },
-    id = entity_id`, escapeDatalog(decisionID),
+    id = entity_id
+    :order %s`, edgeMetaColumns, edgeMetaBindings, edgeMetaOrder,
 	)
 
-	qr, err := r.backend.Query(ctx, script)
+	qr, err := r.backend.Query(ctx, script, map[string]any{"id": decisionID})
 	if err != nil {
 		return nil, fmt.Errorf("get decision entities: %w", err)
 	}
@@ -538,6 +1138,7 @@ func (r *Reader) GetDecisionEntities(ctx context.Context, decisionID string) ([]
 		ent.CreatedAt = toInt64(row[5])
 		ent.UpdatedAt = toInt64(row[6])
 		ent.Role = toString(row[7])
+		ent.Edge = edgeMetaFromRow(row[8:])
 		entities = append(entities, ent)
 	}
 
@@ -546,23 +1147,22 @@ func (r *Reader) GetDecisionEntities(ctx context.Context, decisionID string) ([]
 
 // GetInvalidationChain returns the chain of fact invalidations for a given fact.
 func (r *Reader) GetInvalidationChain(ctx context.Context, factID string) ([]tools.Invalidation, error) {
-	escaped := escapeDatalog(factID)
 	// CozoDB or() doesn't work with = comparisons; use rule union (;) instead
 	script := fmt.Sprintf(
-		`?[new_fact_id, old_fact_id, reason, old_content, new_content] :=
-    *mie_invalidates { new_fact_id, old_fact_id, reason },
-    new_fact_id = '%s',
+		`?[new_fact_id, old_fact_id, reason, old_content, new_content, %[1]s] :=
+    *mie_invalidates { new_fact_id, old_fact_id, reason, %[2]s },
+    new_fact_id = $id,
     *mie_fact { id: old_fact_id, content: old_content },
     *mie_fact { id: new_fact_id, content: new_content };
-?[new_fact_id, old_fact_id, reason, old_content, new_content] :=
-    *mie_invalidates { new_fact_id, old_fact_id, reason },
-    old_fact_id = '%s',
+?[new_fact_id, old_fact_id, reason, old_content, new_content, %[1]s] :=
+    *mie_invalidates { new_fact_id, old_fact_id, reason, %[2]s },
+    old_fact_id = $id,
     *mie_fact { id: old_fact_id, content: old_content },
     *mie_fact { id: new_fact_id, content: new_content }`,
-		escaped, escaped,
+		edgeMetaColumns, edgeMetaBindings,
 	)
 
-	qr, err := r.backend.Query(ctx, script)
+	qr, err := r.backend.Query(ctx, script, map[string]any{"id": factID})
 	if err != nil {
 		return nil, fmt.Errorf("get invalidation chain: %w", err)
 	}
@@ -575,6 +1175,7 @@ func (r *Reader) GetInvalidationChain(ctx context.Context, factID string) ([]too
 			Reason:     toString(row[2]),
 			OldContent: toString(row[3]),
 			NewContent: toString(row[4]),
+			Edge:       edgeMetaFromRow(row[5:]),
 		}
 		chain = append(chain, inv)
 	}
@@ -587,17 +1188,19 @@ func (r *Reader) GetRelatedFacts(ctx context.Context, entityID string) ([]tools.
 	return r.GetFactsAboutEntity(ctx, entityID)
 }
 
-// GetEntityDecisions returns decisions involving a given entity.
+// GetEntityDecisions returns decisions involving a given entity, strongest
+// edges first.
 func (r *Reader) GetEntityDecisions(ctx context.Context, entityID string) ([]tools.Decision, error) {
 	script := fmt.Sprintf(
-		`?[id, title, rationale, alternatives, context, source_agent, source_conversation, status, created_at, updated_at] :=
-    *mie_decision_entity { decision_id, entity_id },
-    entity_id = '%s',
+		`?[id, title, rationale, alternatives, context, source_agent, source_conversation, status, created_at, updated_at, %s] :=
+    *mie_decision_entity { decision_id, entity_id, %s },
+    entity_id = $id,
     *mie_decision { id: decision_id, title, rationale, alternatives, context, source_agent, source_conversation, status, created_at, updated_at },
-    id = decision_id`, escapeDatalog(entityID),
+    id = decision_id
+    :order %s`, edgeMetaColumns, edgeMetaBindings, edgeMetaOrder,
 	)
 
-	qr, err := r.backend.Query(ctx, script)
+	qr, err := r.backend.Query(ctx, script, map[string]any{"id": entityID})
 	if err != nil {
 		return nil, fmt.Errorf("get entity decisions: %w", err)
 	}
@@ -606,6 +1209,7 @@ func (r *Reader) GetEntityDecisions(ctx context.Context, entityID string) ([]too
 	for _, row := range qr.Rows {
 		node := r.parseNode("decision", row, qr.Headers)
 		if dec, ok := node.(*tools.Decision); ok {
+			dec.Edge = edgeMetaFromRow(row[10:])
 			decisions = append(decisions, *dec)
 		}
 	}
@@ -613,26 +1217,67 @@ func (r *Reader) GetEntityDecisions(ctx context.Context, entityID string) ([]too
 	return decisions, nil
 }
 
+// GetNodeEdges returns every edge that starts or ends at nodeID, across all
+// edge tables. Edges to archived nodes are left out unless ctx includes
+// archived nodes.
+func (r *Reader) GetNodeEdges(ctx context.Context, nodeID string) ([]tools.GraphEdge, error) {
+	var edges []tools.GraphEdge
+	for _, table := range sortedEdgeTables() {
+		keyCols := ValidEdgeTables[table]
+		bindings := fmt.Sprintf("%s: src, %s: dst, weight", keyCols[0], keyCols[1])
+		label := "label = ''"
+		if valueCols := edgeValueColumns[table]; len(valueCols) > 0 {
+			bindings += fmt.Sprintf(", %s: label", valueCols[0])
+			label = ""
+		}
+		rule := func(match, other string) string {
+			conds := []string{fmt.Sprintf("*%s { %s }", table, bindings), match + " = $id"}
+			if label != "" {
+				conds = append(conds, label)
+			}
+			return "?[src, dst, label, weight] := " + strings.Join(conds, ", ") + archivedFilter(ctx, other)
+		}
+		script := rule("src", "dst") + "\n" + rule("dst", "src")
+
+		qr, err := r.backend.Query(ctx, script, map[string]any{"id": nodeID})
+		if err != nil {
+			return nil, fmt.Errorf("get node edges from %s: %w", table, err)
+		}
+		for _, row := range qr.Rows {
+			edges = append(edges, tools.GraphEdge{
+				Type:     strings.TrimPrefix(table, "mie_"),
+				SourceID: toString(row[0]),
+				TargetID: toString(row[1]),
+				Label:    toString(row[2]),
+				Weight:   toFloat64(row[3]),
+			})
+		}
+	}
+	return edges, nil
+}
+
 // GetStats returns memory graph statistics.
 func (r *Reader) GetStats(ctx context.Context) (*tools.GraphStats, error) {
-	stats := &tools.GraphStats{}
+	ns := resolveNamespace(ctx, r.namespace)
+	stats := &tools.GraphStats{Namespace: ns}
+	params := map[string]any{"ns": ns}
 
 	queries := []struct {
 		query string
 		dest  *int
 	}{
-		{`?[count(id)] := *mie_fact { id }`, &stats.TotalFacts},
-		{`?[count(id)] := *mie_fact { id, valid }, valid = true`, &stats.ValidFacts},
-		{`?[count(id)] := *mie_fact { id, valid }, valid = false`, &stats.InvalidatedFacts},
-		{`?[count(id)] := *mie_decision { id }`, &stats.TotalDecisions},
-		{`?[count(id)] := *mie_decision { id, status }, status = 'active'`, &stats.ActiveDecisions},
-		{`?[count(id)] := *mie_entity { id }`, &stats.TotalEntities},
-		{`?[count(id)] := *mie_event { id }`, &stats.TotalEvents},
-		{`?[count(id)] := *mie_topic { id }`, &stats.TotalTopics},
+		{`?[count(id)] := *mie_fact { id, namespace }, namespace = $ns`, &stats.TotalFacts},
+		{`?[count(id)] := *mie_fact { id, valid, namespace }, valid = true, namespace = $ns`, &stats.ValidFacts},
+		{`?[count(id)] := *mie_fact { id, valid, namespace }, valid = false, namespace = $ns`, &stats.InvalidatedFacts},
+		{`?[count(id)] := *mie_decision { id, namespace }, namespace = $ns`, &stats.TotalDecisions},
+		{`?[count(id)] := *mie_decision { id, status, namespace }, status = 'active', namespace = $ns`, &stats.ActiveDecisions},
+		{`?[count(id)] := *mie_entity { id, namespace }, namespace = $ns`, &stats.TotalEntities},
+		{`?[count(id)] := *mie_event { id, namespace }, namespace = $ns`, &stats.TotalEvents},
+		{`?[count(id)] := *mie_topic { id, namespace }, namespace = $ns`, &stats.TotalTopics},
 	}
 
 	for _, q := range queries {
-		result, err := r.backend.Query(ctx, q.query)
+		result, err := r.backend.Query(ctx, q.query, params)
 		if err != nil {
 			r.logger.Warn("stats query failed", "query", q.query, "error", err)
 			continue
@@ -642,19 +1287,31 @@ func (r *Reader) GetStats(ctx context.Context) (*tools.GraphStats, error) {
 		}
 	}
 
-	// Count total edges across all edge tables
-	edgeTables := []string{
-		"mie_invalidates", "mie_decision_topic", "mie_decision_entity",
-		"mie_event_decision", "mie_fact_entity", "mie_fact_topic", "mie_entity_topic",
+	stats.Agents = r.agentStats(ctx, params)
+
+	// Count total edges across all edge tables. Edges are scoped to the
+	// namespace of their source node.
+	edgeTables := []struct {
+		table      string
+		sourceNode string
+	}{
+		{"mie_invalidates", "mie_fact"},
+		{"mie_decision_topic", "mie_decision"},
+		{"mie_decision_entity", "mie_decision"},
+		{"mie_event_decision", "mie_event"},
+		{"mie_fact_entity", "mie_fact"},
+		{"mie_fact_topic", "mie_fact"},
+		{"mie_entity_topic", "mie_entity"},
 	}
 	totalEdges := 0
 	for _, et := range edgeTables {
-		cols := ValidEdgeTables[et]
+		cols := ValidEdgeTables[et.table]
 		if len(cols) < 2 {
 			continue
 		}
-		query := fmt.Sprintf(`?[count(%s)] := *%s { %s }`, cols[0], et, strings.Join(cols, ", "))
-		result, err := r.backend.Query(ctx, query)
+		query := fmt.Sprintf(`?[count(%s)] := *%s { %s }, *%s { id: %s, namespace }, namespace = $ns`,
+			cols[0], et.table, strings.Join(cols, ", "), et.sourceNode, cols[0])
+		result, err := r.backend.Query(ctx, query, params)
 		if err != nil {
 			continue
 		}
@@ -693,8 +1350,7 @@ func (r *Reader) GetStats(ctx context.Context) (*tools.GraphStats, error) {
 	}
 
 	for _, mk := range metaKeys {
-		q := fmt.Sprintf(`?[value] := *mie_meta { key, value }, key = '%s'`, mk.key)
-		result, err := r.backend.Query(ctx, q)
+		result, err := r.backend.Query(ctx, `?[value] := *mie_meta { key, value }, key = $key`, map[string]any{"key": mk.key})
 		if err == nil && len(result.Rows) > 0 {
 			mk.setter(toString(result.Rows[0][0]))
 		}
@@ -703,13 +1359,134 @@ func (r *Reader) GetStats(ctx context.Context) (*tools.GraphStats, error) {
 	return stats, nil
 }
 
+// agentStats counts the nodes each source agent has written in the namespace
+// bound as $ns in params, most active agent first.
+func (r *Reader) agentStats(ctx context.Context, params map[string]any) []tools.AgentStats {
+	byAgent := map[string]*tools.AgentStats{}
+	counts := []struct {
+		table string
+		field func(*tools.AgentStats) *int
+	}{
+		{"mie_fact", func(a *tools.AgentStats) *int { return &a.Facts }},
+		{"mie_decision", func(a *tools.AgentStats) *int { return &a.Decisions }},
+		{"mie_entity", func(a *tools.AgentStats) *int { return &a.Entities }},
+		{"mie_event", func(a *tools.AgentStats) *int { return &a.Events }},
+	}
+	for _, c := range counts {
+		query := fmt.Sprintf(`?[source_agent, count(id)] := *%s { id, source_agent, namespace }, namespace = $ns`, c.table)
+		result, err := r.backend.Query(ctx, query, params)
+		if err != nil {
+			r.logger.Warn("agent stats query failed", "table", c.table, "error", err)
+			continue
+		}
+		for _, row := range result.Rows {
+			agent := toString(row[0])
+			a, ok := byAgent[agent]
+			if !ok {
+				a = &tools.AgentStats{Agent: agent}
+				byAgent[agent] = a
+			}
+			*c.field(a) = toInt(row[1])
+		}
+	}
+
+	agents := make([]tools.AgentStats, 0, len(byAgent))
+	for _, a := range byAgent {
+		agents = append(agents, *a)
+	}
+	sort.Slice(agents, func(i, j int) bool {
+		if agents[i].Total() != agents[j].Total() {
+			return agents[i].Total() > agents[j].Total()
+		}
+		return agents[i].Agent < agents[j].Agent
+	})
+	return agents
+}
+
+// GetTopicStats counts the valid facts, decisions, entities, and events
+// linked to each topic, sorted by topic name. Events are reached through the
+// decisions they are linked to. Archived nodes are left out unless ctx asks
+// for them with tools.WithIncludeArchived.
+func (r *Reader) GetTopicStats(ctx context.Context) ([]tools.TopicStats, error) {
+	params := map[string]any{"ns": resolveNamespace(ctx, r.namespace)}
+
+	result, err := r.backend.Query(ctx, fmt.Sprintf(
+		`?[id, name, updated_at] := *mie_topic { id, name, updated_at, namespace }, namespace = $ns%s`,
+		archivedFilter(ctx, "id")), params)
+	if err != nil {
+		return nil, fmt.Errorf("list topics: %w", err)
+	}
+	byTopic := make(map[string]*tools.TopicStats, len(result.Rows))
+	for _, row := range result.Rows {
+		byTopic[toString(row[0])] = &tools.TopicStats{
+			TopicID:      toString(row[0]),
+			Name:         toString(row[1]),
+			LastActivity: toInt64(row[2]),
+		}
+	}
+
+	counts := []struct {
+		name  string
+		body  string
+		field func(*tools.TopicStats) *int
+	}{
+		{"facts", `*mie_fact_topic { fact_id: node_id, topic_id },
+    *mie_fact { id: node_id, valid, updated_at, namespace }, valid = true`,
+			func(t *tools.TopicStats) *int { return &t.Facts }},
+		{"decisions", `*mie_decision_topic { decision_id: node_id, topic_id },
+    *mie_decision { id: node_id, updated_at, namespace }`,
+			func(t *tools.TopicStats) *int { return &t.Decisions }},
+		{"entities", `*mie_entity_topic { entity_id: node_id, topic_id },
+    *mie_entity { id: node_id, updated_at, namespace }`,
+			func(t *tools.TopicStats) *int { return &t.Entities }},
+		{"events", `*mie_decision_topic { decision_id, topic_id },
+    *mie_event_decision { event_id: node_id, decision_id },
+    *mie_event { id: node_id, updated_at, namespace }`,
+			func(t *tools.TopicStats) *int { return &t.Events }},
+	}
+	for _, c := range counts {
+		query := fmt.Sprintf("?[topic_id, count_unique(node_id), max(updated_at)] :=\n    %s,\n    namespace = $ns%s",
+			c.body, archivedFilter(ctx, "node_id"))
+		result, err := r.backend.Query(ctx, query, params)
+		if err != nil {
+			return nil, fmt.Errorf("count topic %s: %w", c.name, err)
+		}
+		for _, row := range result.Rows {
+			t, ok := byTopic[toString(row[0])]
+			if !ok {
+				continue
+			}
+			*c.field(t) = toInt(row[1])
+			t.LastActivity = max(t.LastActivity, toInt64(row[2]))
+		}
+	}
+
+	stats := make([]tools.TopicStats, 0, len(byTopic))
+	for _, t := range byTopic {
+		stats = append(stats, *t)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Name != stats[j].Name {
+			return stats[i].Name < stats[j].Name
+		}
+		return stats[i].TopicID < stats[j].TopicID
+	})
+	return stats, nil
+}
+
 // ExportGraph exports the complete memory graph.
 func (r *Reader) ExportGraph(ctx context.Context, opts tools.ExportOptions) (*tools.ExportData, error) {
+	ns := resolveNamespace(ctx, r.namespace)
 	export := &tools.ExportData{
-		Version:    "1",
+		Version:    "2",
 		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+		Namespace:  ns,
 		Stats:      make(map[string]int),
 	}
+	since := opts.Since
+	if since > 0 {
+		export.Since = time.Unix(since, 0).UTC().Format(time.RFC3339)
+	}
 
 	nodeTypes := opts.NodeTypes
 	if len(nodeTypes) == 0 {
@@ -719,7 +1496,7 @@ func (r *Reader) ExportGraph(ctx context.Context, opts tools.ExportOptions) (*to
 	for _, nt := range nodeTypes {
 		switch nt {
 		case "fact":
-			facts, err := r.exportFacts(ctx)
+			facts, err := r.exportFacts(ctx, ns, since)
 			if err != nil {
 				return nil, err
 			}
@@ -727,7 +1504,7 @@ func (r *Reader) ExportGraph(ctx context.Context, opts tools.ExportOptions) (*to
 			export.Stats["facts"] = len(facts)
 
 		case "decision":
-			decisions, err := r.exportDecisions(ctx)
+			decisions, err := r.exportDecisions(ctx, ns, since)
 			if err != nil {
 				return nil, err
 			}
@@ -735,7 +1512,7 @@ func (r *Reader) ExportGraph(ctx context.Context, opts tools.ExportOptions) (*to
 			export.Stats["decisions"] = len(decisions)
 
 		case "entity":
-			entities, err := r.exportEntities(ctx)
+			entities, err := r.exportEntities(ctx, ns, since)
 			if err != nil {
 				return nil, err
 			}
@@ -743,7 +1520,7 @@ func (r *Reader) ExportGraph(ctx context.Context, opts tools.ExportOptions) (*to
 			export.Stats["entities"] = len(entities)
 
 		case "event":
-			events, err := r.exportEvents(ctx)
+			events, err := r.exportEvents(ctx, ns, since)
 			if err != nil {
 				return nil, err
 			}
@@ -751,7 +1528,7 @@ func (r *Reader) ExportGraph(ctx context.Context, opts tools.ExportOptions) (*to
 			export.Stats["events"] = len(events)
 
 		case "topic":
-			topics, err := r.exportTopics(ctx)
+			topics, err := r.exportTopics(ctx, ns, since)
 			if err != nil {
 				return nil, err
 			}
@@ -760,14 +1537,136 @@ func (r *Reader) ExportGraph(ctx context.Context, opts tools.ExportOptions) (*to
 		}
 	}
 
+	edges, err := r.exportEdges(ctx, ns, nodeTypes, since)
+	if err != nil {
+		return nil, err
+	}
+	if len(edges) > 0 {
+		export.Edges = edges
+		total := 0
+		for _, rows := range edges {
+			total += len(rows)
+		}
+		export.Stats["relationships"] = total
+	}
+
+	if slices.Contains(nodeTypes, "entity") {
+		aliases, err := r.exportAliases(ctx, ns, since)
+		if err != nil {
+			return nil, err
+		}
+		export.Aliases = aliases
+		if len(aliases) > 0 {
+			export.Stats["aliases"] = len(aliases)
+		}
+	}
+
+	archived, err := r.exportArchived(ctx, ns, nodeTypes, since)
+	if err != nil {
+		return nil, err
+	}
+	export.Archived = archived
+	if len(archived) > 0 {
+		export.Stats["archived"] = len(archived)
+	}
+
 	return export, nil
 }
 
 // --- Export helpers ---
 
-func (r *Reader) exportFacts(ctx context.Context) ([]tools.Fact, error) {
-	script := `?[id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at] := *mie_fact { id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at }`
-	qr, err := r.backend.Query(ctx, script)
+// exportEdges returns the edges whose endpoints are both among nodeTypes,
+// keyed by edge type without the "mie_" prefix, created at or after since
+// if it is set. Edges belong to the namespace of their source node.
+func (r *Reader) exportEdges(ctx context.Context, namespace string, nodeTypes []string, since int64) (map[string][]map[string]string, error) {
+	tables := make([]string, 0, len(ValidEdgeTables))
+	for table := range ValidEdgeTables {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	edges := map[string][]map[string]string{}
+	for _, table := range tables {
+		endpoints := edgeTableEndpoints[table]
+		if !slices.Contains(nodeTypes, endpoints[0]) || !slices.Contains(nodeTypes, endpoints[1]) {
+			continue
+		}
+		keyCols := ValidEdgeTables[table]
+		cols := append(append([]string{}, keyCols...), edgeValueColumns[table]...)
+		cols = append(cols, edgeMetadataColumns...)
+		colList := strings.Join(cols, ", ")
+
+		script := fmt.Sprintf(`?[%s] := *%s { %s }, *%s { id: %s, namespace }, namespace = $ns%s`,
+			colList, table, colList, nodeTypeToTable(endpoints[0]), keyCols[0], sinceCondition("created_at", since))
+		qr, err := r.backend.Query(ctx, script, map[string]any{"ns": namespace})
+		if err != nil {
+			return nil, fmt.Errorf("export %s: %w", table, err)
+		}
+
+		for _, row := range qr.Rows {
+			fields := make(map[string]string, len(cols))
+			for i, col := range qr.Headers {
+				switch col {
+				case "weight":
+					fields[col] = strconv.FormatFloat(toFloat64(row[i]), 'g', -1, 64)
+				case "created_at":
+					fields[col] = strconv.FormatInt(toInt64(row[i]), 10)
+				default:
+					fields[col] = toString(row[i])
+				}
+			}
+			name := strings.TrimPrefix(table, "mie_")
+			edges[name] = append(edges[name], fields)
+		}
+	}
+	return edges, nil
+}
+
+func (r *Reader) exportAliases(ctx context.Context, namespace string, since int64) ([]tools.EntityAlias, error) {
+	script := fmt.Sprintf(`?[alias, entity_id] := *mie_entity_alias { alias, namespace, entity_id, created_at }, namespace = $ns%s`,
+		sinceCondition("created_at", since))
+	qr, err := r.backend.Query(ctx, script, map[string]any{"ns": namespace})
+	if err != nil {
+		return nil, err
+	}
+	var aliases []tools.EntityAlias
+	for _, row := range qr.Rows {
+		aliases = append(aliases, tools.EntityAlias{Alias: toString(row[0]), EntityID: toString(row[1])})
+	}
+	return aliases, nil
+}
+
+// exportArchived returns the IDs of archived nodes among nodeTypes, archived
+// at or after since if it is set.
+func (r *Reader) exportArchived(ctx context.Context, namespace string, nodeTypes []string, since int64) ([]string, error) {
+	var ids []string
+	for _, nt := range nodeTypes {
+		table := nodeTypeToTable(nt)
+		if table == "" {
+			continue
+		}
+		script := fmt.Sprintf(`?[node_id] := *mie_archived { node_id, archived_at }, *%s { id: node_id, namespace }, namespace = $ns%s`,
+			table, sinceCondition("archived_at", since))
+		qr, err := r.backend.Query(ctx, script, map[string]any{"ns": namespace})
+		if err != nil {
+			return nil, fmt.Errorf("export archived %s: %w", nt, err)
+		}
+		for _, row := range qr.Rows {
+			ids = append(ids, toString(row[0]))
+		}
+	}
+	return ids, nil
+}
+
+func (r *Reader) exportFacts(ctx context.Context, namespace string, since int64) ([]tools.Fact, error) {
+	head := `?[id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at]`
+	body := `*mie_fact { id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at, namespace }, namespace = $ns`
+	script := head + " := " + body + sinceCondition("updated_at", since)
+	if since > 0 {
+		// Verifying a fact does not touch updated_at.
+		script += "\n" + head + " := " + body + ", *mie_fact_verification { fact_id: id, verified_at }" + sinceCondition("verified_at", since)
+	}
+	qr, err := r.backend.Query(ctx, script, map[string]any{"ns": namespace})
 	if err != nil {
 		return nil, err
 	}
@@ -778,12 +1677,15 @@ func (r *Reader) exportFacts(ctx context.Context) ([]tools.Fact, error) {
 			facts = append(facts, *f)
 		}
 	}
+	if err := r.attachFactMetadata(ctx, factPointers(facts)); err != nil {
+		return nil, err
+	}
 	return facts, nil
 }
 
-func (r *Reader) exportDecisions(ctx context.Context) ([]tools.Decision, error) {
-	script := `?[id, title, rationale, alternatives, context, source_agent, source_conversation, status, created_at, updated_at] := *mie_decision { id, title, rationale, alternatives, context, source_agent, source_conversation, status, created_at, updated_at }`
-	qr, err := r.backend.Query(ctx, script)
+func (r *Reader) exportDecisions(ctx context.Context, namespace string, since int64) ([]tools.Decision, error) {
+	script := fmt.Sprintf(`?[id, title, rationale, alternatives, context, source_agent, source_conversation, status, created_at, updated_at] := *mie_decision { id, title, rationale, alternatives, context, source_agent, source_conversation, status, created_at, updated_at, namespace }, namespace = $ns%s`, sinceCondition("updated_at", since))
+	qr, err := r.backend.Query(ctx, script, map[string]any{"ns": namespace})
 	if err != nil {
 		return nil, err
 	}
@@ -797,9 +1699,9 @@ func (r *Reader) exportDecisions(ctx context.Context) ([]tools.Decision, error)
 	return decisions, nil
 }
 
-func (r *Reader) exportEntities(ctx context.Context) ([]tools.Entity, error) {
-	script := `?[id, name, kind, description, source_agent, created_at, updated_at] := *mie_entity { id, name, kind, description, source_agent, created_at, updated_at }`
-	qr, err := r.backend.Query(ctx, script)
+func (r *Reader) exportEntities(ctx context.Context, namespace string, since int64) ([]tools.Entity, error) {
+	script := fmt.Sprintf(`?[id, name, kind, description, source_agent, created_at, updated_at] := *mie_entity { id, name, kind, description, source_agent, created_at, updated_at, namespace }, namespace = $ns%s`, sinceCondition("updated_at", since))
+	qr, err := r.backend.Query(ctx, script, map[string]any{"ns": namespace})
 	if err != nil {
 		return nil, err
 	}
@@ -813,25 +1715,32 @@ func (r *Reader) exportEntities(ctx context.Context) ([]tools.Entity, error) {
 	return entities, nil
 }
 
-func (r *Reader) exportEvents(ctx context.Context) ([]tools.Event, error) {
-	script := `?[id, title, description, event_date, source_agent, source_conversation, created_at, updated_at] := *mie_event { id, title, description, event_date, source_agent, source_conversation, created_at, updated_at }`
-	qr, err := r.backend.Query(ctx, script)
+func (r *Reader) exportEvents(ctx context.Context, namespace string, since int64) ([]tools.Event, error) {
+	script := fmt.Sprintf(`?[id, title, description, event_date, source_agent, source_conversation, created_at, updated_at] := *mie_event { id, title, description, event_date, source_agent, source_conversation, created_at, updated_at, namespace }, namespace = $ns%s`, sinceCondition("updated_at", since))
+	qr, err := r.backend.Query(ctx, script, map[string]any{"ns": namespace})
 	if err != nil {
 		return nil, err
 	}
-	var events []tools.Event
+	var events []*tools.Event
 	for _, row := range qr.Rows {
 		node := r.parseNode("event", row, qr.Headers)
 		if e, ok := node.(*tools.Event); ok {
-			events = append(events, *e)
+			events = append(events, e)
 		}
 	}
-	return events, nil
+	if err := r.attachEventEnds(ctx, events); err != nil {
+		return nil, err
+	}
+	out := make([]tools.Event, len(events))
+	for i, e := range events {
+		out[i] = *e
+	}
+	return out, nil
 }
 
-func (r *Reader) exportTopics(ctx context.Context) ([]tools.Topic, error) {
-	script := `?[id, name, description, created_at, updated_at] := *mie_topic { id, name, description, created_at, updated_at }`
-	qr, err := r.backend.Query(ctx, script)
+func (r *Reader) exportTopics(ctx context.Context, namespace string, since int64) ([]tools.Topic, error) {
+	script := fmt.Sprintf(`?[id, name, description, created_at, updated_at] := *mie_topic { id, name, description, created_at, updated_at, namespace }, namespace = $ns%s`, sinceCondition("updated_at", since))
+	qr, err := r.backend.Query(ctx, script, map[string]any{"ns": namespace})
 	if err != nil {
 		return nil, err
 	}
@@ -854,17 +1763,19 @@ func (r *Reader) parseSearchResult(nodeType string, row []any, headers []string)
 
 	switch nodeType {
 	case "fact":
-		// id, content, category, confidence, distance
+		// id, content, category, confidence, created_at, then distance or score
 		sr.ID = toString(row[0])
 		sr.Content = toString(row[1])
 		sr.Detail = toString(row[2])
-		if len(row) > 4 {
-			sr.Distance = toFloat64(row[4])
+		if len(row) > 5 {
+			sr.Distance = toFloat64(row[5])
 		}
 		sr.Metadata = &tools.Fact{
-			ID:       sr.ID,
-			Content:  sr.Content,
-			Category: toString(row[2]),
+			ID:         sr.ID,
+			Content:    sr.Content,
+			Category:   toString(row[2]),
+			Confidence: toFloat64(row[3]),
+			CreatedAt:  toInt64(row[4]),
 		}
 	case "decision":
 		// id, title, rationale, status, distance
@@ -875,8 +1786,10 @@ func (r *Reader) parseSearchResult(nodeType string, row []any, headers []string)
 			sr.Distance = toFloat64(row[4])
 		}
 		sr.Metadata = &tools.Decision{
-			ID:    sr.ID,
-			Title: sr.Content,
+			ID:        sr.ID,
+			Title:     sr.Content,
+			Rationale: sr.Detail,
+			Status:    toString(row[3]),
 		}
 	case "entity":
 		// id, name, kind, description, distance
@@ -900,8 +1813,10 @@ func (r *Reader) parseSearchResult(nodeType string, row []any, headers []string)
 			sr.Distance = toFloat64(row[4])
 		}
 		sr.Metadata = &tools.Event{
-			ID:    sr.ID,
-			Title: sr.Content,
+			ID:          sr.ID,
+			Title:       sr.Content,
+			Description: sr.Detail,
+			EventDate:   toString(row[3]),
 		}
 	case "topic":
 		// id, name, description
@@ -929,17 +1844,7 @@ func (r *Reader) parseNode(nodeType string, row []any, headers []string) any {
 		if len(row) < 9 {
 			return nil
 		}
-		return &tools.Fact{
-			ID:                 toString(row[0]),
-			Content:            toString(row[1]),
-			Category:           toString(row[2]),
-			Confidence:         toFloat64(row[3]),
-			SourceAgent:        toString(row[4]),
-			SourceConversation: toString(row[5]),
-			Valid:              toBool(row[6]),
-			CreatedAt:          toInt64(row[7]),
-			UpdatedAt:          toInt64(row[8]),
-		}
+		return factFromRow(row)
 	case "decision":
 		if len(row) < 10 {
 			return nil
@@ -998,6 +1903,31 @@ func (r *Reader) parseNode(nodeType string, row []any, headers []string) any {
 	return nil
 }
 
+// factPointers returns pointers to the elements of facts, so helpers that
+// take []*tools.Fact can fill them in place.
+func factPointers(facts []tools.Fact) []*tools.Fact {
+	ptrs := make([]*tools.Fact, len(facts))
+	for i := range facts {
+		ptrs[i] = &facts[i]
+	}
+	return ptrs
+}
+
+// factFromRow builds a Fact from a row in columnsForNodeType("fact") order.
+func factFromRow(row []any) *tools.Fact {
+	return &tools.Fact{
+		ID:                 toString(row[0]),
+		Content:            toString(row[1]),
+		Category:           toString(row[2]),
+		Confidence:         toFloat64(row[3]),
+		SourceAgent:        toString(row[4]),
+		SourceConversation: toString(row[5]),
+		Valid:              toBool(row[6]),
+		CreatedAt:          toInt64(row[7]),
+		UpdatedAt:          toInt64(row[8]),
+	}
+}
+
 // --- Type conversion helpers ---
 
 func toString(v any) string {
@@ -8,7 +8,10 @@ package memory
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/kraklabs/mie/pkg/tools"
 )
@@ -59,6 +62,147 @@ func TestReaderListNodes(t *testing.T) {
 	}
 }
 
+func TestReaderListNodesRejectsSortBy(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	r := NewReader(backend, nil, nil)
+	ctx := context.Background()
+	w.StoreFact(ctx, tools.StoreFactRequest{Content: "Fact 1", Category: "personal"})
+
+	// A sort_by that would rewrite the query when pasted into :order.
+	malicious := "created_at :limit 1000 } ?[id] := *mie_fact { id"
+	_, _, err := r.ListNodes(ctx, tools.ListOptions{NodeType: "fact", SortBy: malicious, Limit: 10})
+	if err == nil || !strings.Contains(err.Error(), "invalid sort_by") {
+		t.Errorf("ListNodes with malicious sort_by error = %v, want invalid sort_by", err)
+	}
+	_, _, err = r.ListNodes(ctx, tools.ListOptions{
+		NodeType: "fact",
+		SortBy:   "created_at, -content",
+		After:    &tools.ListCursor{Value: int64(0), ID: "fact:x"},
+		Limit:    10,
+	})
+	if err == nil {
+		t.Error("ListNodes with an injected cursor sort_by should fail")
+	}
+
+	if _, _, err := r.ListNodes(ctx, tools.ListOptions{NodeType: "fact", SortBy: "updated_at", Limit: 10}); err != nil {
+		t.Errorf("ListNodes sorted by updated_at: %v", err)
+	}
+}
+
+func TestReaderListNodesDegree(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	r := NewReader(backend, nil, nil)
+	ctx := context.Background()
+
+	hub, _ := w.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Postgres", Kind: "technology"})
+	orphan, _ := w.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Redis", Kind: "technology"})
+	f1, _ := w.StoreFact(ctx, tools.StoreFactRequest{Content: "We use Postgres", Category: "technical"})
+	f2, _ := w.StoreFact(ctx, tools.StoreFactRequest{Content: "Postgres runs on RDS", Category: "technical"})
+	topic, _ := w.StoreTopic(ctx, tools.StoreTopicRequest{Name: "databases"})
+	for _, f := range []*tools.Fact{f1, f2} {
+		if err := w.AddRelationship(ctx, "mie_fact_entity", map[string]string{"fact_id": f.ID, "entity_id": hub.ID}); err != nil {
+			t.Fatalf("AddRelationship: %v", err)
+		}
+	}
+	if err := w.AddRelationship(ctx, "mie_entity_topic", map[string]string{"entity_id": hub.ID, "topic_id": topic.ID}); err != nil {
+		t.Fatalf("AddRelationship: %v", err)
+	}
+	if err := w.AddRelationship(ctx, "mie_invalidates", map[string]string{"new_fact_id": f2.ID, "old_fact_id": f1.ID, "reason": "moved"}); err != nil {
+		t.Fatalf("AddRelationship: %v", err)
+	}
+
+	nodes, _, err := r.ListNodes(ctx, tools.ListOptions{NodeType: "entity", Limit: 10, IncludeDegree: true})
+	if err != nil {
+		t.Fatalf("ListNodes failed: %v", err)
+	}
+	degrees := map[string]int{}
+	for _, node := range nodes {
+		e := node.(*tools.Entity)
+		if e.Degree == nil {
+			t.Fatalf("entity %s has no degree", e.ID)
+		}
+		degrees[e.ID] = *e.Degree
+	}
+	if degrees[hub.ID] != 3 || degrees[orphan.ID] != 0 {
+		t.Errorf("entity degrees = %v, want %s: 3, %s: 0", degrees, hub.ID, orphan.ID)
+	}
+
+	// Invalidation edges count for both facts.
+	nodes, _, err = r.ListNodes(ctx, tools.ListOptions{NodeType: "fact", Limit: 10, IncludeDegree: true})
+	if err != nil {
+		t.Fatalf("ListNodes failed: %v", err)
+	}
+	for _, node := range nodes {
+		if f := node.(*tools.Fact); f.Degree == nil || *f.Degree != 2 {
+			t.Errorf("fact %s degree = %v, want 2", f.ID, f.Degree)
+		}
+	}
+
+	nodes, _, _ = r.ListNodes(ctx, tools.ListOptions{NodeType: "topic", Limit: 10})
+	if len(nodes) != 1 || nodes[0].(*tools.Topic).Degree != nil {
+		t.Errorf("degree should only be set with IncludeDegree: %+v", nodes)
+	}
+}
+
+func TestReaderListNodesCursor(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	r := NewReader(backend, nil, nil)
+	ctx := context.Background()
+
+	// Facts stored within the same second share created_at, so paging
+	// relies on the ID tie-break.
+	for i := 1; i <= 5; i++ {
+		if _, err := w.StoreFact(ctx, tools.StoreFactRequest{Content: fmt.Sprintf("Cursor fact %d", i), Category: "general"}); err != nil {
+			t.Fatalf("StoreFact failed: %v", err)
+		}
+	}
+
+	seen := map[string]bool{}
+	var after *tools.ListCursor
+	for page := 0; page < 3; page++ {
+		nodes, total, err := r.ListNodes(ctx, tools.ListOptions{
+			NodeType:  "fact",
+			Limit:     2,
+			SortBy:    "created_at",
+			SortOrder: "desc",
+			After:     after,
+		})
+		if err != nil {
+			t.Fatalf("ListNodes page %d failed: %v", page, err)
+		}
+		if total != 5 {
+			t.Errorf("page %d: expected total 5, got %d", page, total)
+		}
+		for _, n := range nodes {
+			f := n.(*tools.Fact)
+			if seen[f.ID] {
+				t.Errorf("page %d repeats %s", page, f.ID)
+			}
+			seen[f.ID] = true
+		}
+		if len(nodes) == 0 {
+			break
+		}
+		last := nodes[len(nodes)-1].(*tools.Fact)
+		after = &tools.ListCursor{NodeType: "fact", SortBy: "created_at", SortOrder: "desc", Value: float64(last.CreatedAt), ID: last.ID}
+	}
+	if len(seen) != 5 {
+		t.Errorf("expected to page through 5 facts, saw %d", len(seen))
+	}
+}
+
 func TestReaderGetNodeByID(t *testing.T) {
 	backend := newTestBackend(t)
 	defer backend.Close()
@@ -198,6 +342,49 @@ func TestReaderGetDecisionEntities(t *testing.T) {
 	}
 }
 
+func TestReaderTraversalEdgeMetadata(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	r := NewReader(backend, nil, nil)
+	ctx := context.Background()
+
+	entity, _ := w.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Kafka", Kind: "technology"})
+	weak, _ := w.StoreFact(ctx, tools.StoreFactRequest{Content: "Kafka was evaluated once", Category: "technical"})
+	strong, _ := w.StoreFact(ctx, tools.StoreFactRequest{Content: "Kafka carries all events", Category: "technical"})
+
+	w.AddRelationship(ctx, "mie_fact_entity", map[string]string{
+		"fact_id": weak.ID, "entity_id": entity.ID, "weight": "0.2", "source_agent": "cursor",
+	})
+	w.AddRelationship(ctx, "mie_fact_entity", map[string]string{
+		"fact_id": strong.ID, "entity_id": entity.ID, "weight": "0.9", "source_agent": "claude", "created_at": "1772323200",
+	})
+
+	facts, err := r.GetFactsAboutEntity(ctx, entity.ID)
+	if err != nil {
+		t.Fatalf("GetFactsAboutEntity failed: %v", err)
+	}
+	if len(facts) != 2 {
+		t.Fatalf("expected 2 facts, got %d", len(facts))
+	}
+	if facts[0].ID != strong.ID || facts[1].ID != weak.ID {
+		t.Errorf("expected the stronger edge first, got %s then %s", facts[0].ID, facts[1].ID)
+	}
+	want := tools.EdgeMeta{Weight: 0.9, SourceAgent: "claude", CreatedAt: 1772323200}
+	if facts[0].Edge == nil || *facts[0].Edge != want {
+		t.Errorf("edge = %+v, want %+v", facts[0].Edge, want)
+	}
+	if e := facts[1].Edge; e == nil || e.SourceAgent != "cursor" || e.CreatedAt == 0 {
+		t.Errorf("expected the weak edge to be stamped, got %+v", e)
+	}
+	// The fact's own attribution is unchanged by the edge's.
+	if facts[0].SourceAgent != "" {
+		t.Errorf("fact source_agent = %q, want empty", facts[0].SourceAgent)
+	}
+}
+
 func TestReaderGetInvalidationChain(t *testing.T) {
 	backend := newTestBackend(t)
 	defer backend.Close()
@@ -260,6 +447,59 @@ func TestReaderGetStats(t *testing.T) {
 	}
 }
 
+func TestReaderGetTopicStats(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	r := NewReader(backend, nil, nil)
+	ctx := context.Background()
+
+	infra, _ := w.StoreTopic(ctx, tools.StoreTopicRequest{Name: "infra"})
+	_, _ = w.StoreTopic(ctx, tools.StoreTopicRequest{Name: "hiring"})
+	fact, _ := w.StoreFact(ctx, tools.StoreFactRequest{Content: "Deploys run on Fridays", Category: "technical"})
+	old, _ := w.StoreFact(ctx, tools.StoreFactRequest{Content: "Deploys run on Mondays", Category: "technical"})
+	dec, _ := w.StoreDecision(ctx, tools.StoreDecisionRequest{Title: "Use Kubernetes", Rationale: "Scale"})
+	ev, _ := w.StoreEvent(ctx, tools.StoreEventRequest{Title: "Cluster launch", EventDate: "2026-01-10"})
+
+	links := []struct {
+		edge   string
+		fields map[string]string
+	}{
+		{"mie_fact_topic", map[string]string{"fact_id": fact.ID, "topic_id": infra.ID}},
+		{"mie_fact_topic", map[string]string{"fact_id": old.ID, "topic_id": infra.ID}},
+		{"mie_decision_topic", map[string]string{"decision_id": dec.ID, "topic_id": infra.ID}},
+		{"mie_event_decision", map[string]string{"event_id": ev.ID, "decision_id": dec.ID}},
+	}
+	for _, l := range links {
+		if err := w.AddRelationship(ctx, l.edge, l.fields); err != nil {
+			t.Fatalf("AddRelationship(%s) failed: %v", l.edge, err)
+		}
+	}
+	if err := w.InvalidateFact(ctx, old.ID, fact.ID, "moved"); err != nil {
+		t.Fatalf("InvalidateFact failed: %v", err)
+	}
+
+	stats, err := r.GetTopicStats(ctx)
+	if err != nil {
+		t.Fatalf("GetTopicStats failed: %v", err)
+	}
+	if len(stats) != 2 || stats[0].Name != "hiring" || stats[1].Name != "infra" {
+		t.Fatalf("expected hiring and infra sorted by name, got %+v", stats)
+	}
+	if stats[0].Total() != 0 {
+		t.Errorf("expected hiring to have no linked nodes, got %+v", stats[0])
+	}
+	got := stats[1]
+	if got.Facts != 1 || got.Decisions != 1 || got.Events != 1 || got.Entities != 0 {
+		t.Errorf("expected 1 valid fact, 1 decision, 1 event for infra, got %+v", got)
+	}
+	if got.LastActivity == 0 {
+		t.Error("expected infra to have a last activity time")
+	}
+}
+
 func TestReaderExportGraph(t *testing.T) {
 	backend := newTestBackend(t)
 	defer backend.Close()
@@ -277,8 +517,8 @@ func TestReaderExportGraph(t *testing.T) {
 		t.Fatalf("ExportGraph failed: %v", err)
 	}
 
-	if export.Version != "1" {
-		t.Errorf("expected version '1', got %q", export.Version)
+	if export.Version != "2" {
+		t.Errorf("expected version '2', got %q", export.Version)
 	}
 	if len(export.Facts) != 1 {
 		t.Errorf("expected 1 fact in export, got %d", len(export.Facts))
@@ -288,6 +528,49 @@ func TestReaderExportGraph(t *testing.T) {
 	}
 }
 
+func TestReaderExportGraphSince(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	r := NewReader(backend, nil, nil)
+	ctx := context.Background()
+
+	old, _ := w.StoreFact(ctx, tools.StoreFactRequest{Content: "Old fact", Category: "general"})
+	fresh, _ := w.StoreFact(ctx, tools.StoreFactRequest{Content: "New fact", Category: "general"})
+	backdate := fmt.Sprintf(`?[id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at, namespace] :=
+    *mie_fact { id, content, category, confidence, source_agent, source_conversation, valid, namespace },
+    id = '%s', created_at = 1000, updated_at = 1000
+:put mie_fact { id => content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at, namespace }`, old.ID)
+	if err := backend.Execute(ctx, backdate, nil); err != nil {
+		t.Fatalf("backdate failed: %v", err)
+	}
+
+	export, err := r.ExportGraph(ctx, tools.ExportOptions{NodeTypes: []string{"fact"}, Since: 2000})
+	if err != nil {
+		t.Fatalf("ExportGraph failed: %v", err)
+	}
+	if export.Since != "1970-01-01T00:33:20Z" {
+		t.Errorf("expected since 1970-01-01T00:33:20Z, got %q", export.Since)
+	}
+	if len(export.Facts) != 1 || export.Facts[0].ID != fresh.ID {
+		t.Errorf("expected only %s, got %+v", fresh.ID, export.Facts)
+	}
+
+	// Verifying the old fact makes it part of the next incremental export.
+	if err := w.SetVerified(ctx, old.ID, "alice", true); err != nil {
+		t.Fatalf("SetVerified failed: %v", err)
+	}
+	export, err = r.ExportGraph(ctx, tools.ExportOptions{NodeTypes: []string{"fact"}, Since: 2000})
+	if err != nil {
+		t.Fatalf("ExportGraph failed: %v", err)
+	}
+	if len(export.Facts) != 2 {
+		t.Errorf("expected 2 facts after verifying the old one, got %d", len(export.Facts))
+	}
+}
+
 func TestReaderExactSearch(t *testing.T) {
 	backend := newTestBackend(t)
 	defer backend.Close()
@@ -320,6 +603,83 @@ func TestReaderExactSearch(t *testing.T) {
 	}
 }
 
+func TestReaderExactSearchDecay(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	r := NewReader(backend, nil, nil)
+	r.halfLifeDays = 30
+	ctx := context.Background()
+
+	old, _ := w.StoreFact(ctx, tools.StoreFactRequest{Content: "Deploys with Heroku", Category: "technical", Confidence: 0.9})
+	fresh, _ := w.StoreFact(ctx, tools.StoreFactRequest{Content: "Deploys with Fly.io", Category: "technical", Confidence: 0.9})
+
+	// Age the first fact by one half-life.
+	aged := time.Now().Add(-30 * 24 * time.Hour).Unix()
+	script := fmt.Sprintf(`?[id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at, namespace] :=
+    *mie_fact { id, content, category, confidence, source_agent, source_conversation, valid, updated_at, namespace },
+    id = '%s', created_at = %d
+    :put mie_fact { id => content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at, namespace }`, old.ID, aged)
+	if err := backend.Execute(ctx, script, nil); err != nil {
+		t.Fatalf("age fact: %v", err)
+	}
+
+	results, err := r.ExactSearch(ctx, "Deploys", []string{"fact"}, 10)
+	if err != nil {
+		t.Fatalf("ExactSearch failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].ID != fresh.ID {
+		t.Errorf("expected the recent fact first, got %s", results[0].ID)
+	}
+	if ec := results[1].EffectiveConfidence; ec < 0.44 || ec > 0.46 {
+		t.Errorf("EffectiveConfidence = %v, want about 0.45", ec)
+	}
+}
+
+func TestReaderFullTextSearch(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	r := NewReader(backend, nil, nil)
+	ctx := context.Background()
+
+	w.StoreFact(ctx, tools.StoreFactRequest{Content: "Deploys run on Kubernetes every Friday", Category: "technical"})
+	w.StoreFact(ctx, tools.StoreFactRequest{Content: "I prefer tea", Category: "preference"})
+	w.StoreDecision(ctx, tools.StoreDecisionRequest{Title: "Use Postgres", Rationale: "Deploying managed databases is simpler"})
+
+	// Stemming matches "deploying" against "Deploys" and "Deploying".
+	results, err := r.FullTextSearch(ctx, "deploying", []string{"fact", "decision"}, 10)
+	if err != nil {
+		t.Fatalf("FullTextSearch failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for _, res := range results {
+		if res.Score <= 0 {
+			t.Errorf("expected positive score for %s, got %f", res.ID, res.Score)
+		}
+	}
+
+	// Operators and punctuation in user input do not break the query.
+	if _, err := r.FullTextSearch(ctx, `tea" AND (`, []string{"fact"}, 10); err != nil {
+		t.Errorf("FullTextSearch with punctuation failed: %v", err)
+	}
+
+	// Other namespaces are not searched.
+	results, _ = r.FullTextSearch(tools.WithNamespace(ctx, "other"), "deploying", []string{"fact"}, 10)
+	if len(results) != 0 {
+		t.Errorf("expected 0 results in other namespace, got %d", len(results))
+	}
+}
+
 func TestReaderFindEntityByName(t *testing.T) {
 	backend := newTestBackend(t)
 	defer backend.Close()
@@ -347,6 +707,112 @@ func TestReaderFindEntityByName(t *testing.T) {
 	}
 }
 
+func TestReaderFindEntityByAlias(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	r := NewReader(backend, nil, nil)
+	ctx := context.Background()
+
+	pg, _ := w.StoreEntity(ctx, tools.StoreEntityRequest{Name: "PostgreSQL", Kind: "technology"})
+	if err := w.AddAlias(ctx, pg.ID, "Postgres"); err != nil {
+		t.Fatalf("AddAlias failed: %v", err)
+	}
+
+	entity, err := r.FindEntityByName(ctx, "postgres")
+	if err != nil {
+		t.Fatalf("FindEntityByName failed: %v", err)
+	}
+	if entity == nil || entity.ID != pg.ID {
+		t.Fatalf("expected alias to resolve to %s, got %+v", pg.ID, entity)
+	}
+
+	id, err := r.ResolveAlias(ctx, "unknown")
+	if err != nil {
+		t.Fatalf("ResolveAlias failed: %v", err)
+	}
+	if id != "" {
+		t.Errorf("expected unknown alias to resolve to empty ID, got %q", id)
+	}
+}
+
+func TestReaderFindEntityByFuzzyName(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	r := NewReader(backend, nil, nil)
+	ctx := context.Background()
+
+	pg, _ := w.StoreEntity(ctx, tools.StoreEntityRequest{Name: "PostgreSQL", Kind: "technology"})
+	k8s, _ := w.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Kubernetes", Kind: "technology"})
+	if err := w.AddAlias(ctx, k8s.ID, "k8s cluster"); err != nil {
+		t.Fatalf("AddAlias failed: %v", err)
+	}
+
+	for name, want := range map[string]string{"Postgress QL": pg.ID, "kubernets": k8s.ID, "K8s-Cluster": k8s.ID, "Redis": ""} {
+		entity, err := r.FindEntityByName(ctx, name)
+		if err != nil {
+			t.Fatalf("FindEntityByName(%q) failed: %v", name, err)
+		}
+		got := ""
+		if entity != nil {
+			got = entity.ID
+		}
+		if got != want {
+			t.Errorf("FindEntityByName(%q) = %q, want %q", name, got, want)
+		}
+	}
+
+	if entity, _ := r.FindEntityByName(tools.WithNamespace(ctx, "other"), "PostgreSQL"); entity != nil {
+		t.Errorf("expected no match in other namespace, got %+v", entity)
+	}
+}
+
+func TestReaderSearchIgnoresCaseAndAccents(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	r := NewReader(backend, nil, nil)
+	ctx := context.Background()
+
+	fact, _ := w.StoreFact(ctx, tools.StoreFactRequest{Content: "La reunión de diseño es los martes", Category: "professional"})
+	jose, _ := w.StoreEntity(ctx, tools.StoreEntityRequest{Name: "José García", Kind: "person"})
+	if err := w.AddAlias(ctx, jose.ID, "Pepe Peña"); err != nil {
+		t.Fatalf("AddAlias failed: %v", err)
+	}
+
+	for _, query := range []string{"REUNION DE DISENO", "Reunión", "diseño"} {
+		results, err := r.ExactSearch(ctx, query, []string{"fact"}, 10)
+		if err != nil {
+			t.Fatalf("ExactSearch(%q) failed: %v", query, err)
+		}
+		if len(results) != 1 || results[0].ID != fact.ID {
+			t.Errorf("ExactSearch(%q) = %+v, want %s", query, results, fact.ID)
+		}
+	}
+
+	results, _ := r.ExactSearch(ctx, "garcia", []string{"entity"}, 10)
+	if len(results) != 1 {
+		t.Errorf("expected 1 entity result for 'garcia', got %d", len(results))
+	}
+
+	for _, name := range []string{"jose garcia", "JOSÉ GARCÍA", "pepe pena"} {
+		entity, err := r.FindEntityByName(ctx, name)
+		if err != nil {
+			t.Fatalf("FindEntityByName(%q) failed: %v", name, err)
+		}
+		if entity == nil || entity.ID != jose.ID {
+			t.Errorf("FindEntityByName(%q) = %+v, want %s", name, entity, jose.ID)
+		}
+	}
+}
+
 func TestReaderGetEntityDecisions(t *testing.T) {
 	backend := newTestBackend(t)
 	defer backend.Close()
@@ -379,3 +845,141 @@ func TestReaderGetEntityDecisions(t *testing.T) {
 		t.Errorf("expected title 'Use Go', got %q", decisions[0].Title)
 	}
 }
+
+func TestReaderTimeRangeFilters(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	r := NewReader(backend, nil, nil)
+	ctx := context.Background()
+
+	w.StoreFact(ctx, tools.StoreFactRequest{Content: "Deploys run on Fridays", Category: "professional"})
+	w.StoreEvent(ctx, tools.StoreEventRequest{Title: "Launch", EventDate: "2026-01-15"})
+	w.StoreEvent(ctx, tools.StoreEventRequest{Title: "Retro", EventDate: "2026-03-02"})
+
+	hourAgo := time.Now().Add(-time.Hour).Unix()
+
+	nodes, total, err := r.ListNodes(ctx, tools.ListOptions{NodeType: "fact", TimeRange: tools.TimeRange{CreatedAfter: hourAgo}})
+	if err != nil {
+		t.Fatalf("ListNodes failed: %v", err)
+	}
+	if len(nodes) != 1 || total != 1 {
+		t.Errorf("expected 1 recent fact, got %d (total %d)", len(nodes), total)
+	}
+
+	nodes, total, err = r.ListNodes(ctx, tools.ListOptions{NodeType: "fact", TimeRange: tools.TimeRange{CreatedBefore: hourAgo}})
+	if err != nil {
+		t.Fatalf("ListNodes failed: %v", err)
+	}
+	if len(nodes) != 0 || total != 0 {
+		t.Errorf("expected no facts created before an hour ago, got %d (total %d)", len(nodes), total)
+	}
+
+	nodes, _, err = r.ListNodes(ctx, tools.ListOptions{NodeType: "event", TimeRange: tools.TimeRange{EventDateFrom: "2026-01-01", EventDateTo: "2026-01-31"}})
+	if err != nil {
+		t.Fatalf("ListNodes failed: %v", err)
+	}
+	if len(nodes) != 1 {
+		t.Fatalf("expected 1 event in January, got %d", len(nodes))
+	}
+	if evt, ok := nodes[0].(*tools.Event); !ok || evt.Title != "Launch" {
+		t.Errorf("expected the Launch event, got %+v", nodes[0])
+	}
+
+	// An event_date range restricts search results to events.
+	rangeCtx := tools.WithTimeRange(ctx, tools.TimeRange{EventDateFrom: "2026-03-01"})
+	results, err := r.ExactSearch(rangeCtx, "r", []string{"fact", "event"}, 10)
+	if err != nil {
+		t.Fatalf("ExactSearch failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Content != "Retro" {
+		t.Errorf("expected only the Retro event, got %+v", results)
+	}
+}
+
+func TestReaderEventRangeOverlap(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	r := NewReader(backend, nil, nil)
+	ctx := context.Background()
+
+	w.StoreEvent(ctx, tools.StoreEventRequest{Title: "Migration", EventDate: "2025-07-01", EndDate: "2025-09-30"})
+	w.StoreEvent(ctx, tools.StoreEventRequest{Title: "Kickoff", EventDate: "2025-06-10"})
+
+	// The migration started before August but was still running then.
+	nodes, total, err := r.ListNodes(ctx, tools.ListOptions{NodeType: "event", TimeRange: tools.TimeRange{EventDateFrom: "2025-08-01", EventDateTo: "2025-08-31"}})
+	if err != nil {
+		t.Fatalf("ListNodes failed: %v", err)
+	}
+	if len(nodes) != 1 || total != 1 {
+		t.Fatalf("expected 1 event overlapping August, got %d (total %d)", len(nodes), total)
+	}
+	if evt, ok := nodes[0].(*tools.Event); !ok || evt.Title != "Migration" || evt.EndDate != "2025-09-30" {
+		t.Errorf("expected the Migration event with its end date, got %+v", nodes[0])
+	}
+
+	nodes, _, err = r.ListNodes(ctx, tools.ListOptions{NodeType: "event", TimeRange: tools.TimeRange{EventDateFrom: "2025-06-01", EventDateTo: "2025-06-30"}})
+	if err != nil {
+		t.Fatalf("ListNodes failed: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].(*tools.Event).Title != "Kickoff" {
+		t.Errorf("expected only the Kickoff event in June, got %+v", nodes)
+	}
+	if evt := nodes[0].(*tools.Event); evt.EndDate != "" {
+		t.Errorf("one-day event has end date %q", evt.EndDate)
+	}
+}
+
+func TestReaderSourceAgentFilters(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	r := NewReader(backend, nil, nil)
+	ctx := context.Background()
+
+	w.StoreFact(ctx, tools.StoreFactRequest{Content: "Deploys use Helm", Category: "technical", SourceAgent: "claude"})
+	w.StoreFact(ctx, tools.StoreFactRequest{Content: "Deploys use Argo", Category: "technical", SourceAgent: "cursor"})
+	w.StoreDecision(ctx, tools.StoreDecisionRequest{Title: "Deploy with Argo", Rationale: "GitOps", SourceAgent: "cursor"})
+	w.StoreTopic(ctx, tools.StoreTopicRequest{Name: "deploys"})
+
+	nodes, total, err := r.ListNodes(ctx, tools.ListOptions{NodeType: "fact", SourceAgent: "cursor"})
+	if err != nil {
+		t.Fatalf("ListNodes failed: %v", err)
+	}
+	if total != 1 || len(nodes) != 1 || nodes[0].(*tools.Fact).SourceAgent != "cursor" {
+		t.Errorf("expected only cursor's fact, got %d (total %d)", len(nodes), total)
+	}
+
+	agentCtx := tools.WithSourceAgent(ctx, "claude")
+	results, err := r.ExactSearch(agentCtx, "eploy", nil, 10)
+	if err != nil {
+		t.Fatalf("ExactSearch failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Content != "Deploys use Helm" {
+		t.Errorf("expected only claude's fact, got %+v", results)
+	}
+
+	stats, err := r.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	want := []tools.AgentStats{
+		{Agent: "cursor", Facts: 1, Decisions: 1},
+		{Agent: "claude", Facts: 1},
+	}
+	if len(stats.Agents) != len(want) {
+		t.Fatalf("expected %d agents, got %+v", len(want), stats.Agents)
+	}
+	for i := range want {
+		if stats.Agents[i] != want[i] {
+			t.Errorf("agent %d = %+v, want %+v", i, stats.Agents[i], want[i])
+		}
+	}
+}
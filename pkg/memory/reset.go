@@ -0,0 +1,231 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ResetOptions selects the nodes of the namespace a reset deletes. The
+// zero value selects every node.
+type ResetOptions struct {
+	// NodeTypes limits the reset to these node types. Empty selects all of
+	// them, or only facts when Category is set.
+	NodeTypes []string
+	// Category limits the reset to facts of this category.
+	Category string
+	// SourceAgent limits the reset to nodes stored by this agent. Topics
+	// have no source agent, so they are kept.
+	SourceAgent string
+	DryRun      bool // report what would be deleted without deleting it
+}
+
+// ResetResult counts the nodes a reset deleted or, with DryRun, would
+// delete.
+type ResetResult struct {
+	DryRun    bool   `json:"dry_run"`
+	Namespace string `json:"namespace"`
+	// Deleted counts the selected nodes per node type.
+	Deleted map[string]int `json:"deleted"`
+
+	ids map[string][]string // selected node IDs per node type
+}
+
+// Total returns the number of nodes selected.
+func (r *ResetResult) Total() int {
+	total := 0
+	for _, n := range r.Deleted {
+		total += n
+	}
+	return total
+}
+
+// resetNodeTypes returns the node types opts selects.
+func (opts ResetOptions) resetNodeTypes() ([]string, error) {
+	nodeTypes := opts.NodeTypes
+	if len(nodeTypes) == 0 {
+		switch {
+		case opts.Category != "":
+			nodeTypes = []string{"fact"}
+		case opts.SourceAgent != "":
+			nodeTypes = backfillNodeTypes
+		default:
+			nodeTypes = snapshotNodeTypes
+		}
+	}
+	for _, nt := range nodeTypes {
+		if !slices.Contains(snapshotNodeTypes, nt) {
+			return nil, fmt.Errorf("invalid node type %q (must be one of: %s)", nt, strings.Join(snapshotNodeTypes, ", "))
+		}
+		if opts.Category != "" && nt != "fact" {
+			return nil, fmt.Errorf("category applies only to facts, not to %s nodes", nt)
+		}
+		if opts.SourceAgent != "" && nt == "topic" {
+			return nil, fmt.Errorf("topics have no source agent")
+		}
+	}
+	return nodeTypes, nil
+}
+
+// Reset deletes the nodes of the namespace selected by opts together with
+// their edges, embeddings, verification, expiry, event end dates, archive
+// marks, and aliases, in one transaction. Edges from nodes that are kept
+// to deleted nodes are removed too. Snapshots, the audit log, and node
+// history are kept.
+func (w *Writer) Reset(ctx context.Context, opts ResetOptions) (*ResetResult, error) {
+	nodeTypes, err := opts.resetNodeTypes()
+	if err != nil {
+		return nil, err
+	}
+	ns := resolveNamespace(ctx, w.namespace)
+	result := &ResetResult{DryRun: opts.DryRun, Namespace: ns, Deleted: map[string]int{}, ids: map[string][]string{}}
+
+	selectParams := map[string]any{"ns": ns}
+	conditions := []string{"namespace = $ns"}
+	if opts.SourceAgent != "" {
+		conditions = append(conditions, "source_agent = $source_agent")
+		selectParams["source_agent"] = opts.SourceAgent
+	}
+	if opts.Category != "" {
+		conditions = append(conditions, "category = $category")
+		selectParams["category"] = opts.Category
+	}
+	for _, nt := range nodeTypes {
+		columns := []string{"id", "namespace"}
+		if opts.SourceAgent != "" {
+			columns = append(columns, "source_agent")
+		}
+		if opts.Category != "" {
+			columns = append(columns, "category")
+		}
+		qr, err := w.backend.Query(ctx, fmt.Sprintf(`?[id] := *%s { %s }, %s`,
+			nodeTypeToTable(nt), strings.Join(columns, ", "), strings.Join(conditions, ", ")), selectParams)
+		if err != nil {
+			return nil, fmt.Errorf("select %s nodes: %w", nt, err)
+		}
+		if len(qr.Rows) == 0 {
+			continue
+		}
+		ids := make([]string, len(qr.Rows))
+		for i, row := range qr.Rows {
+			ids[i] = toString(row[0])
+		}
+		result.ids[nt] = ids
+		result.Deleted[nt] = len(ids)
+	}
+	if opts.DryRun || result.Total() == 0 {
+		return result, nil
+	}
+	if err := w.deleteNodes(ctx, result.ids); err != nil {
+		return nil, fmt.Errorf("reset: %w", err)
+	}
+	return result, nil
+}
+
+// deleteNodes deletes the nodes with the given IDs, keyed by node type,
+// together with their edges, embeddings, verification, expiry, event end
+// dates, archive marks, and aliases, in one transaction. Edges from other
+// nodes to them are removed too.
+func (w *Writer) deleteNodes(ctx context.Context, ids map[string][]string) error {
+	params := make(map[string]any, len(ids))
+	for nt, nodeIDs := range ids {
+		params[nt+"_ids"] = idRows(nodeIDs)
+	}
+	var blocks []string
+	var forget []func()
+
+	for _, table := range sortedEdgeTables() {
+		keys := ValidEdgeTables[table]
+		for i, nt := range edgeTableEndpoints[table] {
+			if len(ids[nt]) == 0 {
+				continue
+			}
+			blocks = append(blocks, fmt.Sprintf(`{
+    ids[id] <- $%[4]s_ids
+    ?[%[2]s, %[3]s] := *%[1]s { %[2]s, %[3]s }, ids[%[5]s]
+    :rm %[1]s { %[2]s, %[3]s }
+}`, table, keys[0], keys[1], nt, keys[i]))
+		}
+	}
+
+	for _, nt := range snapshotNodeTypes {
+		if len(ids[nt]) == 0 {
+			continue
+		}
+		if embeddings := nodeTypeToEmbeddingTable(nt); embeddings != "" {
+			forget = append(forget, w.forgetVectors(ctx, nt, fmt.Sprintf(`?[id] <- $%s_ids`, nt), params))
+			blocks = append(blocks, fmt.Sprintf(`{
+    ?[%[2]s] <- $%[3]s_ids
+    :rm %[1]s { %[2]s }
+}`, embeddings, nt+"_id", nt))
+		}
+		blocks = append(blocks, fmt.Sprintf(`{
+    ?[node_id] <- $%s_ids
+    :rm mie_archived { node_id }
+}`, nt))
+		switch nt {
+		case "fact":
+			blocks = append(blocks, `{
+    ?[fact_id] <- $fact_ids
+    :rm mie_fact_verification { fact_id }
+}`, `{
+    ?[fact_id] <- $fact_ids
+    :rm mie_fact_expiry { fact_id }
+}`, `{
+    ?[fact_id] <- $fact_ids
+    :rm mie_fact_key { fact_id }
+}`, `{
+    ?[fact_id] <- $fact_ids
+    :rm mie_fact_negated { fact_id }
+}`)
+		case "event":
+			blocks = append(blocks, `{
+    ?[event_id] <- $event_ids
+    :rm mie_event_end { event_id }
+}`)
+		case "entity":
+			blocks = append(blocks, `{
+    ids[entity_id] <- $entity_ids
+    ?[alias, namespace] := *mie_entity_alias { alias, namespace, entity_id }, ids[entity_id]
+    :rm mie_entity_alias { alias, namespace }
+}`)
+		}
+		blocks = append(blocks, fmt.Sprintf(`{
+    ?[id] <- $%s_ids
+    :rm %s { id }
+}`, nt, nodeTypeToTable(nt)))
+	}
+
+	if err := w.backend.Execute(ctx, strings.Join(blocks, "\n"), params); err != nil {
+		return err
+	}
+	for _, f := range forget {
+		f()
+	}
+	return nil
+}
+
+// Reset deletes the nodes selected by opts. See Writer.Reset. Deleted
+// nodes are recorded as deleted.
+func (c *Client) Reset(ctx context.Context, opts ResetOptions) (*ResetResult, error) {
+	result, err := c.writer.Reset(ctx, opts)
+	if err != nil || opts.DryRun || result.Total() == 0 {
+		return result, err
+	}
+	var deleted []string
+	for _, nt := range snapshotNodeTypes {
+		for _, id := range result.ids[nt] {
+			deleted = append(deleted, id)
+			c.publish(ctx, ChangeDeleted, id, nil)
+		}
+	}
+	c.audit(ctx, ChangeDeleted, "", nil, deleted...)
+	return result, nil
+}
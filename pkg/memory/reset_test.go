@@ -0,0 +1,107 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestClientResetBySourceAgent(t *testing.T) {
+	client, err := NewClient(ClientConfig{DataDir: t.TempDir(), StorageEngine: "mem", EmbeddingDimensions: 384})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	bad, _ := client.StoreFact(ctx, tools.StoreFactRequest{Content: "The moon is made of cheese", Category: "general", SourceAgent: "rogue"})
+	good, _ := client.StoreFact(ctx, tools.StoreFactRequest{Content: "Deploys run on Fridays", Category: "technical", SourceAgent: "claude"})
+	ent, _ := client.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Cheese Corp", Kind: "company", SourceAgent: "rogue"})
+	topic, _ := client.StoreTopic(ctx, tools.StoreTopicRequest{Name: "astronomy"})
+	if err := client.AddRelationship(ctx, "mie_fact_entity", map[string]string{"fact_id": good.ID, "entity_id": ent.ID}); err != nil {
+		t.Fatalf("AddRelationship failed: %v", err)
+	}
+	if err := client.AddAlias(ctx, ent.ID, "CheeseCo"); err != nil {
+		t.Fatalf("AddAlias failed: %v", err)
+	}
+
+	preview, err := client.Reset(ctx, ResetOptions{SourceAgent: "rogue", DryRun: true})
+	if err != nil {
+		t.Fatalf("Reset dry run failed: %v", err)
+	}
+	if preview.Deleted["fact"] != 1 || preview.Deleted["entity"] != 1 || preview.Total() != 2 {
+		t.Fatalf("expected 1 fact and 1 entity selected, got %+v", preview.Deleted)
+	}
+	if _, err := client.GetNodeByID(ctx, bad.ID); err != nil {
+		t.Fatalf("dry run should keep %s: %v", bad.ID, err)
+	}
+
+	if _, err := client.Reset(ctx, ResetOptions{SourceAgent: "rogue"}); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	for _, id := range []string{bad.ID, ent.ID} {
+		if _, err := client.GetNodeByID(ctx, id); err == nil {
+			t.Errorf("expected %s to be deleted", id)
+		}
+	}
+	for _, id := range []string{good.ID, topic.ID} {
+		if _, err := client.GetNodeByID(ctx, id); err != nil {
+			t.Errorf("expected %s to be kept: %v", id, err)
+		}
+	}
+	edges, err := client.GetNodeEdges(ctx, good.ID)
+	if err != nil {
+		t.Fatalf("GetNodeEdges failed: %v", err)
+	}
+	if len(edges) != 0 {
+		t.Errorf("expected the edge to the deleted entity to be removed, got %+v", edges)
+	}
+	qr, err := client.RawQuery(ctx, `?[alias] := *mie_entity_alias { alias }`)
+	if err != nil {
+		t.Fatalf("RawQuery failed: %v", err)
+	}
+	if len(qr.Rows) != 0 {
+		t.Errorf("expected the alias of the deleted entity to be removed, got %v", qr.Rows)
+	}
+}
+
+func TestClientResetByCategory(t *testing.T) {
+	client, err := NewClient(ClientConfig{DataDir: t.TempDir(), StorageEngine: "mem", EmbeddingDimensions: 384})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	_, _ = client.StoreFact(ctx, tools.StoreFactRequest{Content: "User likes tea", Category: "preference"})
+	kept, _ := client.StoreFact(ctx, tools.StoreFactRequest{Content: "API uses REST", Category: "technical"})
+
+	result, err := client.Reset(ctx, ResetOptions{Category: "preference"})
+	if err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if result.Total() != 1 {
+		t.Errorf("expected 1 node deleted, got %+v", result.Deleted)
+	}
+	nodes, _, err := client.ListNodes(ctx, tools.ListOptions{NodeType: "fact", Limit: 10})
+	if err != nil {
+		t.Fatalf("ListNodes failed: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].(*tools.Fact).ID != kept.ID {
+		t.Errorf("expected only %s left, got %+v", kept.ID, nodes)
+	}
+
+	if _, err := client.Reset(ctx, ResetOptions{Category: "preference", NodeTypes: []string{"entity"}}); err == nil {
+		t.Error("expected error for category with entity nodes")
+	}
+	if _, err := client.Reset(ctx, ResetOptions{SourceAgent: "rogue", NodeTypes: []string{"topic"}}); err == nil {
+		t.Error("expected error for source agent with topics")
+	}
+}
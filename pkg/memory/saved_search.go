@@ -0,0 +1,90 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// SaveSearch records search in mie_saved_search, replacing a saved search of
+// the same name in the namespace.
+func (w *Writer) SaveSearch(ctx context.Context, search *tools.SavedSearch) error {
+	args, err := json.Marshal(search.Args)
+	if err != nil {
+		return fmt.Errorf("encode saved search: %w", err)
+	}
+	mutation := `?[name, namespace, args, created_at, updated_at] <- [[$name, $ns, $args, $created_at, $updated_at]] :put mie_saved_search { name, namespace => args, created_at, updated_at }`
+	params := map[string]any{
+		"name": search.Name, "ns": resolveNamespace(ctx, w.namespace), "args": string(args),
+		"created_at": search.CreatedAt, "updated_at": search.UpdatedAt,
+	}
+	if err := w.backend.Execute(ctx, mutation, params); err != nil {
+		return fmt.Errorf("save search: %w", err)
+	}
+	return nil
+}
+
+// DeleteSavedSearch removes a saved search of the namespace.
+func (w *Writer) DeleteSavedSearch(ctx context.Context, name string) error {
+	mutation := `?[name, namespace] <- [[$name, $ns]] :rm mie_saved_search { name, namespace }`
+	if err := w.backend.Execute(ctx, mutation, map[string]any{"name": name, "ns": resolveNamespace(ctx, w.namespace)}); err != nil {
+		return fmt.Errorf("delete saved search: %w", err)
+	}
+	return nil
+}
+
+// GetSavedSearch returns the saved search of the namespace named name.
+func (r *Reader) GetSavedSearch(ctx context.Context, name string) (*tools.SavedSearch, error) {
+	searches, err := r.savedSearches(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(searches) == 0 {
+		return nil, fmt.Errorf("saved search %q not found", name)
+	}
+	return &searches[0], nil
+}
+
+// ListSavedSearches returns the saved searches of the namespace by name.
+func (r *Reader) ListSavedSearches(ctx context.Context) ([]tools.SavedSearch, error) {
+	return r.savedSearches(ctx, "")
+}
+
+// savedSearches returns the saved searches of the namespace, or only the
+// one called name if name is not empty.
+func (r *Reader) savedSearches(ctx context.Context, name string) ([]tools.SavedSearch, error) {
+	cond := ""
+	params := map[string]any{"ns": resolveNamespace(ctx, r.namespace)}
+	if name != "" {
+		cond = ", name = $name"
+		params["name"] = name
+	}
+	result, err := r.backend.Query(ctx, fmt.Sprintf(
+		`?[name, args, created_at, updated_at] := *mie_saved_search { name, namespace, args, created_at, updated_at }, namespace = $ns%s
+:order name`, cond), params)
+	if err != nil {
+		return nil, fmt.Errorf("read saved searches: %w", err)
+	}
+
+	searches := make([]tools.SavedSearch, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		search := tools.SavedSearch{
+			Name:      toString(row[0]),
+			CreatedAt: toInt64(row[2]),
+			UpdatedAt: toInt64(row[3]),
+		}
+		if err := json.Unmarshal([]byte(toString(row[1])), &search.Args); err != nil {
+			return nil, fmt.Errorf("decode saved search %q: %w", search.Name, err)
+		}
+		searches = append(searches, search)
+	}
+	return searches, nil
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestClientSavedSearches(t *testing.T) {
+	client, err := NewClient(ClientConfig{DataDir: t.TempDir(), StorageEngine: "mem", EmbeddingDimensions: 384})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	args := map[string]any{"query": "postgres", "mode": "exact", "node_types": []any{"fact"}, "limit": float64(5)}
+	if _, err := client.SaveSearch(ctx, "db", args); err != nil {
+		t.Fatalf("SaveSearch: %v", err)
+	}
+	if _, err := client.SaveSearch(ctx, "auth's flow", map[string]any{"query": "login"}); err != nil {
+		t.Fatalf("SaveSearch with a quote in the name: %v", err)
+	}
+
+	got, err := client.GetSavedSearch(ctx, "db")
+	if err != nil {
+		t.Fatalf("GetSavedSearch: %v", err)
+	}
+	if !reflect.DeepEqual(got.Args, args) {
+		t.Errorf("Args = %v, want %v", got.Args, args)
+	}
+
+	// Saving under the same name replaces the search.
+	if _, err := client.SaveSearch(ctx, "db", map[string]any{"query": "mysql"}); err != nil {
+		t.Fatalf("SaveSearch again: %v", err)
+	}
+	list, err := client.ListSavedSearches(ctx)
+	if err != nil {
+		t.Fatalf("ListSavedSearches: %v", err)
+	}
+	if len(list) != 2 || list[0].Name != "auth's flow" || list[1].Name != "db" {
+		t.Fatalf("ListSavedSearches = %+v, want both searches by name", list)
+	}
+	if list[1].Args["query"] != "mysql" || list[1].CreatedAt != got.CreatedAt {
+		t.Errorf("replaced search = %+v, want new args and the original created_at", list[1])
+	}
+
+	if others, _ := client.ListSavedSearches(tools.WithNamespace(ctx, "other")); len(others) != 0 {
+		t.Errorf("saved searches leaked into another namespace: %v", others)
+	}
+
+	if err := client.DeleteSavedSearch(ctx, "db"); err != nil {
+		t.Fatalf("DeleteSavedSearch: %v", err)
+	}
+	if _, err := client.GetSavedSearch(ctx, "db"); err == nil {
+		t.Error("GetSavedSearch of a deleted search should fail")
+	}
+}
@@ -9,6 +9,7 @@ package memory
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/kraklabs/mie/pkg/storage"
@@ -28,7 +29,8 @@ func SchemaStatements(dim int) []string {
     source_conversation: String,
     valid: Bool,
     created_at: Int,
-    updated_at: Int
+    updated_at: Int,
+    namespace: String default 'default'
 }`,
 
 		fmt.Sprintf(`:create mie_fact_embedding {
@@ -46,7 +48,8 @@ func SchemaStatements(dim int) []string {
     source_conversation: String,
     status: String,
     created_at: Int,
-    updated_at: Int
+    updated_at: Int,
+    namespace: String default 'default'
 }`,
 
 		fmt.Sprintf(`:create mie_decision_embedding {
@@ -61,7 +64,8 @@ func SchemaStatements(dim int) []string {
     description: String,
     source_agent: String,
     created_at: Int,
-    updated_at: Int
+    updated_at: Int,
+    namespace: String default 'default'
 }`,
 
 		fmt.Sprintf(`:create mie_entity_embedding {
@@ -77,7 +81,8 @@ func SchemaStatements(dim int) []string {
     source_agent: String,
     source_conversation: String,
     created_at: Int,
-    updated_at: Int
+    updated_at: Int,
+    namespace: String default 'default'
 }`,
 
 		fmt.Sprintf(`:create mie_event_embedding {
@@ -90,45 +95,208 @@ func SchemaStatements(dim int) []string {
     name: String,
     description: String,
     created_at: Int,
-    updated_at: Int
+    updated_at: Int,
+    namespace: String default 'default'
 }`,
 
-		// Edge tables
+		// Edge tables. Every edge records its strength, the agent that
+		// created it, and when.
 		`:create mie_invalidates {
     new_fact_id: String,
     old_fact_id: String =>
-    reason: String
+    reason: String,
+    weight: Float default 1.0,
+    source_agent: String default '',
+    created_at: Int default 0
 }`,
 
 		`:create mie_decision_topic {
     decision_id: String,
     topic_id: String =>
+    weight: Float default 1.0,
+    source_agent: String default '',
+    created_at: Int default 0
 }`,
 
 		`:create mie_decision_entity {
     decision_id: String,
     entity_id: String =>
-    role: String
+    role: String,
+    weight: Float default 1.0,
+    source_agent: String default '',
+    created_at: Int default 0
 }`,
 
 		`:create mie_event_decision {
     event_id: String,
     decision_id: String =>
+    weight: Float default 1.0,
+    source_agent: String default '',
+    created_at: Int default 0
 }`,
 
 		`:create mie_fact_entity {
     fact_id: String,
     entity_id: String =>
+    weight: Float default 1.0,
+    source_agent: String default '',
+    created_at: Int default 0
 }`,
 
 		`:create mie_fact_topic {
     fact_id: String,
     topic_id: String =>
+    weight: Float default 1.0,
+    source_agent: String default '',
+    created_at: Int default 0
 }`,
 
 		`:create mie_entity_topic {
     entity_id: String,
     topic_id: String =>
+    weight: Float default 1.0,
+    source_agent: String default '',
+    created_at: Int default 0
+}`,
+
+		// Alias table: maps a lowercased alternative name to its canonical entity
+		`:create mie_entity_alias {
+    alias: String,
+    namespace: String =>
+    entity_id: String,
+    created_at: Int
+}`,
+
+		// Archive table: nodes hidden from search and list results
+		`:create mie_archived {
+    node_id: String =>
+    archived_at: Int
+}`,
+
+		// Verification table: facts confirmed by a person
+		`:create mie_fact_verification {
+    fact_id: String =>
+    verified_by: String,
+    verified_at: Int
+}`,
+
+		// Expiry table: facts that stop being true at a known time
+		`:create mie_fact_expiry {
+    fact_id: String =>
+    expires_at: Int
+}`,
+
+		// Event end table: the last day of every event, which is its
+		// event_date unless the event spans several days
+		`:create mie_event_end {
+    event_id: String =>
+    end_date: String
+}`,
+
+		// Audit table: append-only log of writes
+		`:create mie_audit {
+    id: String =>
+    at: Int,
+    op: String,
+    tool: String,
+    source_agent: String,
+    node_ids: [String],
+    namespace: String
+}`,
+
+		// History table: append-only log of changed node fields
+		`:create mie_history {
+    id: String =>
+    node_id: String,
+    at: Int,
+    field: String,
+    old_value: String,
+    new_value: String,
+    reason: String,
+    related_id: String,
+    tool: String,
+    source_agent: String,
+    namespace: String
+}`,
+
+		// Intent table: multi-step writes that have not finished yet
+		`:create mie_intent {
+    id: String =>
+    kind: String,
+    payload: String,
+    namespace: String,
+    created_at: Int
+}`,
+
+		// Snapshot table: labeled full exports of a namespace
+		`:create mie_snapshot {
+    id: String =>
+    label: String,
+    created_at: Int,
+    namespace: String,
+    stats: String,
+    data: String
+}`,
+
+		// Saved search table: named mie_query arguments
+		`:create mie_saved_search {
+    name: String,
+    namespace: String =>
+    args: String,
+    created_at: Int,
+    updated_at: Int
+}`,
+
+		// Session table: conversations whose stores are grouped together
+		`:create mie_session {
+    id: String =>
+    title: String,
+    source_agent: String,
+    namespace: String,
+    started_at: Int,
+    ended_at: Int,
+    summary: String
+}`,
+
+		// Embedding queue: nodes waiting for their embedding
+		`:create mie_embedding_queue {
+    node_id: String =>
+    node_type: String,
+    text: String,
+    enqueued_at: Int,
+    attempts: Int,
+    retry_at: Int,
+    last_error: String
+}`,
+
+		// Daily counters: per-day values of the mie_meta usage counters
+		`:create mie_counter_daily {
+    counter: String,
+    day: String =>
+    total: Int
+}`,
+
+		// Attachments: content stored under its hash, linked to nodes
+		`:create mie_attachment {
+    node_id: String,
+    hash: String =>
+    name: String,
+    mime_type: String,
+    size: Int,
+    namespace: String,
+    created_at: Int
+}`,
+
+		// Preference keys: the setting each keyed preference fact records
+		`:create mie_fact_key {
+    fact_id: String =>
+    key: String
+}`,
+
+		// Anti-facts: facts stored as not holding
+		`:create mie_fact_negated {
+    fact_id: String =>
+    created_at: Int
 }`,
 
 		// Metadata table
@@ -184,13 +352,151 @@ func HNSWIndexStatements(dim int) []string {
 	}
 }
 
+// FTSIndexStatements returns the full-text index creation statements.
+// Decisions, entities, events, and topics index their title or name together
+// with their descriptive text. A relation with an FTS index cannot be
+// rewritten with :replace, so future migrations of these tables must drop
+// the index first.
+func FTSIndexStatements() []string {
+	const filters = `[Lowercase, AlphaNumOnly, AsciiFolding, Stemmer('english'), Stopwords('en')]`
+	return []string{
+		`::fts create mie_fact:fact_fts {
+    extractor: content,
+    tokenizer: Simple,
+    filters: ` + filters + `
+}`,
+
+		`::fts create mie_decision:decision_fts {
+    extractor: concat(title, ' ', rationale),
+    tokenizer: Simple,
+    filters: ` + filters + `
+}`,
+
+		`::fts create mie_entity:entity_fts {
+    extractor: concat(name, ' ', description),
+    tokenizer: Simple,
+    filters: ` + filters + `
+}`,
+
+		`::fts create mie_event:event_fts {
+    extractor: concat(title, ' ', description),
+    tokenizer: Simple,
+    filters: ` + filters + `
+}`,
+
+		`::fts create mie_topic:topic_fts {
+    extractor: concat(name, ' ', description),
+    tokenizer: Simple,
+    filters: ` + filters + `
+}`,
+	}
+}
+
+// SchemaVersion is the version of the schema created by SchemaStatements.
+// It is recorded in mie_meta and drives the migrations in schemaMigrations.
+// New tables need no migration because EnsureSchema creates missing tables
+// on every start; version 3 added mie_entity_alias, version 4 added
+// mie_archived, version 6 added mie_fact_verification, version 7 added
+// mie_audit, version 8 added mie_snapshot, version 9 added
+// mie_saved_search, version 10 added mie_fact_expiry, version 11 added
+// mie_history, and version 12 added mie_intent this way. Version 13 added
+// mie_event_end and fills it for existing events. Version 14 added
+// mie_session, version 15 mie_embedding_queue, version 16
+// mie_counter_daily, version 17 mie_attachment, version 18
+// mie_fact_key, and version 19 mie_fact_negated.
+const SchemaVersion = 19
+
+// schemaMigration upgrades a database created with an older schema version.
+type schemaMigration struct {
+	version     int
+	description string
+	statements  []string
+}
+
+// schemaMigrations lists the upgrades applied, in order, to databases whose
+// recorded schema version is lower than the migration version.
+var schemaMigrations = []schemaMigration{
+	{
+		version:     2,
+		description: "add namespace column to node tables",
+		statements: []string{
+			`?[id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at, namespace] :=
+    *mie_fact { id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at },
+    namespace = 'default'
+:replace mie_fact { id: String => content: String, category: String, confidence: Float, source_agent: String, source_conversation: String, valid: Bool, created_at: Int, updated_at: Int, namespace: String default 'default' }`,
+			`?[id, title, rationale, alternatives, context, source_agent, source_conversation, status, created_at, updated_at, namespace] :=
+    *mie_decision { id, title, rationale, alternatives, context, source_agent, source_conversation, status, created_at, updated_at },
+    namespace = 'default'
+:replace mie_decision { id: String => title: String, rationale: String, alternatives: String, context: String, source_agent: String, source_conversation: String, status: String, created_at: Int, updated_at: Int, namespace: String default 'default' }`,
+			`?[id, name, kind, description, source_agent, created_at, updated_at, namespace] :=
+    *mie_entity { id, name, kind, description, source_agent, created_at, updated_at },
+    namespace = 'default'
+:replace mie_entity { id: String => name: String, kind: String, description: String, source_agent: String, created_at: Int, updated_at: Int, namespace: String default 'default' }`,
+			`?[id, title, description, event_date, source_agent, source_conversation, created_at, updated_at, namespace] :=
+    *mie_event { id, title, description, event_date, source_agent, source_conversation, created_at, updated_at },
+    namespace = 'default'
+:replace mie_event { id: String => title: String, description: String, event_date: String, source_agent: String, source_conversation: String, created_at: Int, updated_at: Int, namespace: String default 'default' }`,
+			`?[id, name, description, created_at, updated_at, namespace] :=
+    *mie_topic { id, name, description, created_at, updated_at },
+    namespace = 'default'
+:replace mie_topic { id: String => name: String, description: String, created_at: Int, updated_at: Int, namespace: String default 'default' }`,
+		},
+	},
+	{
+		version:     5,
+		description: "add weight, source_agent, and created_at to edge tables",
+		statements: []string{
+			`?[new_fact_id, old_fact_id, reason, weight, source_agent, created_at] :=
+    *mie_invalidates { new_fact_id, old_fact_id, reason },
+    weight = 1.0, source_agent = '', created_at = 0
+:replace mie_invalidates { new_fact_id: String, old_fact_id: String => reason: String, weight: Float default 1.0, source_agent: String default '', created_at: Int default 0 }`,
+			`?[decision_id, topic_id, weight, source_agent, created_at] :=
+    *mie_decision_topic { decision_id, topic_id },
+    weight = 1.0, source_agent = '', created_at = 0
+:replace mie_decision_topic { decision_id: String, topic_id: String => weight: Float default 1.0, source_agent: String default '', created_at: Int default 0 }`,
+			`?[decision_id, entity_id, role, weight, source_agent, created_at] :=
+    *mie_decision_entity { decision_id, entity_id, role },
+    weight = 1.0, source_agent = '', created_at = 0
+:replace mie_decision_entity { decision_id: String, entity_id: String => role: String, weight: Float default 1.0, source_agent: String default '', created_at: Int default 0 }`,
+			`?[event_id, decision_id, weight, source_agent, created_at] :=
+    *mie_event_decision { event_id, decision_id },
+    weight = 1.0, source_agent = '', created_at = 0
+:replace mie_event_decision { event_id: String, decision_id: String => weight: Float default 1.0, source_agent: String default '', created_at: Int default 0 }`,
+			`?[fact_id, entity_id, weight, source_agent, created_at] :=
+    *mie_fact_entity { fact_id, entity_id },
+    weight = 1.0, source_agent = '', created_at = 0
+:replace mie_fact_entity { fact_id: String, entity_id: String => weight: Float default 1.0, source_agent: String default '', created_at: Int default 0 }`,
+			`?[fact_id, topic_id, weight, source_agent, created_at] :=
+    *mie_fact_topic { fact_id, topic_id },
+    weight = 1.0, source_agent = '', created_at = 0
+:replace mie_fact_topic { fact_id: String, topic_id: String => weight: Float default 1.0, source_agent: String default '', created_at: Int default 0 }`,
+			`?[entity_id, topic_id, weight, source_agent, created_at] :=
+    *mie_entity_topic { entity_id, topic_id },
+    weight = 1.0, source_agent = '', created_at = 0
+:replace mie_entity_topic { entity_id: String, topic_id: String => weight: Float default 1.0, source_agent: String default '', created_at: Int default 0 }`,
+		},
+	},
+	{
+		version:     13,
+		description: "record the end date of existing events",
+		statements: []string{
+			`?[event_id, end_date] := *mie_event { id: event_id, event_date: end_date }
+:put mie_event_end { event_id => end_date }`,
+		},
+	},
+}
+
 // EnsureSchema creates all MIE schema tables, ignoring "already exists" errors.
 // Each :create statement is executed as a separate Run() call as required by CozoDB.
+// Databases created with an older schema version are upgraded in place.
 func EnsureSchema(backend storage.Backend, dim int) error {
 	ctx := context.Background()
 
+	// Read the version before creating tables: a missing version means a fresh database.
+	current := readSchemaVersion(ctx, backend)
+
 	for _, stmt := range SchemaStatements(dim) {
-		if err := backend.Execute(ctx, stmt); err != nil {
+		if err := backend.Execute(ctx, stmt, nil); err != nil {
 			errStr := err.Error()
 			if strings.Contains(errStr, "already exists") ||
 				strings.Contains(errStr, "conflicts with an existing one") {
@@ -200,22 +506,49 @@ func EnsureSchema(backend storage.Backend, dim int) error {
 		}
 	}
 
+	if current > 0 {
+		for _, m := range schemaMigrations {
+			if m.version <= current {
+				continue
+			}
+			for _, stmt := range m.statements {
+				if err := backend.Execute(ctx, stmt, nil); err != nil {
+					return fmt.Errorf("migrate schema to version %d (%s): %w", m.version, m.description, err)
+				}
+			}
+		}
+	}
+
 	// Set schema version
-	versionStmt := `?[key, value] <- [['schema_version', '1']] :put mie_meta { key => value }`
-	if err := backend.Execute(ctx, versionStmt); err != nil {
+	versionStmt := `?[key, value] <- [['schema_version', $version]] :put mie_meta { key => value }`
+	if err := backend.Execute(ctx, versionStmt, map[string]any{"version": strconv.Itoa(SchemaVersion)}); err != nil {
 		return fmt.Errorf("set schema version: %w", err)
 	}
 
 	return nil
 }
 
+// readSchemaVersion returns the schema version recorded in mie_meta, or 0 if
+// none is recorded yet.
+func readSchemaVersion(ctx context.Context, backend storage.Backend) int {
+	result, err := backend.Query(ctx, `?[value] := *mie_meta { key, value }, key = 'schema_version'`, nil)
+	if err != nil || len(result.Rows) == 0 {
+		return 0
+	}
+	v, err := strconv.Atoi(toString(result.Rows[0][0]))
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
 // EnsureHNSWIndexes creates HNSW indexes for semantic search.
 // Ignores "already exists" errors so it can be called idempotently.
 func EnsureHNSWIndexes(backend storage.Backend, dim int) error {
 	ctx := context.Background()
 
 	for _, stmt := range HNSWIndexStatements(dim) {
-		if err := backend.Execute(ctx, stmt); err != nil {
+		if err := backend.Execute(ctx, stmt, nil); err != nil {
 			errStr := err.Error()
 			if strings.Contains(errStr, "already exists") ||
 				strings.Contains(errStr, "conflicts with an existing one") ||
@@ -228,3 +561,23 @@ func EnsureHNSWIndexes(backend storage.Backend, dim int) error {
 
 	return nil
 }
+
+// EnsureFTSIndexes creates full-text indexes for fulltext search.
+// Ignores "already exists" errors so it can be called idempotently.
+func EnsureFTSIndexes(backend storage.Backend) error {
+	ctx := context.Background()
+
+	for _, stmt := range FTSIndexStatements() {
+		if err := backend.Execute(ctx, stmt, nil); err != nil {
+			errStr := err.Error()
+			if strings.Contains(errStr, "already exists") ||
+				strings.Contains(errStr, "conflicts with an existing one") ||
+				strings.Contains(errStr, "index already exists") {
+				continue
+			}
+			return fmt.Errorf("create fts index: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -12,8 +12,8 @@ import (
 
 func TestSchemaStatements(t *testing.T) {
 	stmts := SchemaStatements(768)
-	if len(stmts) != 17 {
-		t.Errorf("expected 17 schema statements, got %d", len(stmts))
+	if len(stmts) != 23 {
+		t.Errorf("expected 23 schema statements, got %d", len(stmts))
 	}
 
 	// Verify each statement starts with :create
@@ -65,15 +65,90 @@ func TestEnsureSchema(t *testing.T) {
 	}
 
 	// Verify schema version was set
-	result, err := backend.Query(t.Context(), `?[value] := *mie_meta { key, value }, key = "schema_version"`)
+	result, err := backend.Query(t.Context(), `?[value] := *mie_meta { key, value }, key = "schema_version"`, nil)
 	if err != nil {
 		t.Fatalf("query schema version: %v", err)
 	}
 	if len(result.Rows) == 0 {
 		t.Fatal("schema version not set")
 	}
-	if toString(result.Rows[0][0]) != "1" {
-		t.Errorf("expected schema version '1', got %v", result.Rows[0][0])
+	if toString(result.Rows[0][0]) != "12" {
+		t.Errorf("expected schema version '12', got %v", result.Rows[0][0])
+	}
+}
+
+func TestEnsureSchemaMigratesNamespace(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	ctx := t.Context()
+
+	if err := backend.EnsureSchema(); err != nil {
+		t.Fatalf("ensure storage schema: %v", err)
+	}
+
+	// Simulate a version 1 database: mie_fact without the namespace column.
+	v1Stmts := []string{
+		`:create mie_fact { id: String => content: String, category: String, confidence: Float, source_agent: String, source_conversation: String, valid: Bool, created_at: Int, updated_at: Int }`,
+		`?[id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at] <- [['fact:old', 'Legacy fact', 'general', 0.8, '', '', true, 1, 1]] :put mie_fact { id => content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at }`,
+		`?[key, value] <- [['schema_version', '1']] :put mie_meta { key => value }`,
+	}
+	for _, stmt := range v1Stmts {
+		if err := backend.Execute(ctx, stmt, nil); err != nil {
+			t.Fatalf("set up v1 schema: %v", err)
+		}
+	}
+
+	if err := EnsureSchema(backend, 384); err != nil {
+		t.Fatalf("EnsureSchema (migration) failed: %v", err)
+	}
+
+	result, err := backend.Query(ctx, `?[namespace] := *mie_fact { id, namespace }, id = 'fact:old'`, nil)
+	if err != nil {
+		t.Fatalf("query migrated fact: %v", err)
+	}
+	if len(result.Rows) != 1 {
+		t.Fatalf("expected migrated fact to survive, got %d rows", len(result.Rows))
+	}
+	if toString(result.Rows[0][0]) != "default" {
+		t.Errorf("expected migrated fact in 'default' namespace, got %v", result.Rows[0][0])
+	}
+}
+
+func TestEnsureSchemaMigratesEdgeMetadata(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	ctx := t.Context()
+
+	if err := backend.EnsureSchema(); err != nil {
+		t.Fatalf("ensure storage schema: %v", err)
+	}
+
+	// Simulate a version 4 database: edge tables without metadata columns.
+	v4Stmts := []string{
+		`:create mie_decision_entity { decision_id: String, entity_id: String => role: String }`,
+		`?[decision_id, entity_id, role] <- [['dec:old', 'ent:old', 'subject']] :put mie_decision_entity { decision_id, entity_id => role }`,
+		`?[key, value] <- [['schema_version', '4']] :put mie_meta { key => value }`,
+	}
+	for _, stmt := range v4Stmts {
+		if err := backend.Execute(ctx, stmt, nil); err != nil {
+			t.Fatalf("set up v4 schema: %v", err)
+		}
+	}
+
+	if err := EnsureSchema(backend, 384); err != nil {
+		t.Fatalf("EnsureSchema (migration) failed: %v", err)
+	}
+
+	result, err := backend.Query(ctx, `?[role, weight, source_agent, created_at] := *mie_decision_entity { decision_id, role, weight, source_agent, created_at }, decision_id = 'dec:old'`, nil)
+	if err != nil {
+		t.Fatalf("query migrated edge: %v", err)
+	}
+	if len(result.Rows) != 1 {
+		t.Fatalf("expected migrated edge to survive, got %d rows", len(result.Rows))
+	}
+	row := result.Rows[0]
+	if toString(row[0]) != "subject" || toFloat64(row[1]) != 1 || toString(row[2]) != "" || toInt64(row[3]) != 0 {
+		t.Errorf("unexpected migrated edge %v", row)
 	}
 }
 
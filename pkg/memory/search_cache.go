@@ -0,0 +1,155 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package memory
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// Defaults for ClientConfig.SearchCacheSize and SearchCacheTTL.
+const (
+	DefaultSearchCacheSize = 256
+	DefaultSearchCacheTTL  = 5 * time.Minute
+)
+
+// searchKey identifies a semantic search: the query and everything that
+// filters its results.
+type searchKey struct {
+	query           string
+	nodeTypes       string
+	limit           int
+	namespace       string
+	timeRange       tools.TimeRange
+	sourceAgent     string
+	includeArchived bool
+	minSimilarity   float64
+}
+
+type searchEntry struct {
+	key     searchKey
+	results []tools.SearchResult
+	expires time.Time
+}
+
+// searchCache is a least-recently-used cache of semantic search results
+// whose entries expire after a TTL. It is safe for concurrent use.
+type searchCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	order   *list.List // of *searchEntry, most recently used first
+	entries map[searchKey]*list.Element
+	hits    int64
+	misses  int64
+	// gen counts invalidations, so a search that raced with a write is not
+	// cached.
+	gen uint64
+	now func() time.Time
+}
+
+// newSearchCache creates a cache holding up to size searches for ttl.
+func newSearchCache(size int, ttl time.Duration) *searchCache {
+	return &searchCache{
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: make(map[searchKey]*list.Element),
+		now:     time.Now,
+	}
+}
+
+// get returns a copy of the results cached for key, if they have not
+// expired. On a miss it returns the generation to pass to put.
+func (c *searchCache) get(key searchKey) ([]tools.SearchResult, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if ok && c.now().After(el.Value.(*searchEntry).expires) {
+		c.remove(el)
+		ok = false
+	}
+	if !ok {
+		c.misses++
+		return nil, c.gen, false
+	}
+	c.hits++
+	c.order.MoveToFront(el)
+	return cloneResults(el.Value.(*searchEntry).results), c.gen, true
+}
+
+// put caches a copy of results for key, evicting the least recently used
+// search when the cache is full. Results are dropped when the cache was
+// invalidated since get returned gen.
+func (c *searchCache) put(key searchKey, gen uint64, results []tools.SearchResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if gen != c.gen {
+		return
+	}
+	entry := &searchEntry{key: key, results: cloneResults(results), expires: c.now().Add(c.ttl)}
+	if el, ok := c.entries[key]; ok {
+		el.Value = entry
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+// invalidate drops every cached search. The hit and miss counts are kept.
+func (c *searchCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	clear(c.entries)
+	c.gen++
+}
+
+// stats returns the cache's counters.
+func (c *searchCache) stats() *tools.SearchCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &tools.SearchCacheStats{Hits: c.hits, Misses: c.misses, Entries: c.order.Len()}
+}
+
+func (c *searchCache) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*searchEntry).key)
+}
+
+// cloneResults copies results so callers cannot change cached entries.
+func cloneResults(results []tools.SearchResult) []tools.SearchResult {
+	out := make([]tools.SearchResult, len(results))
+	copy(out, results)
+	for i := range out {
+		if out[i].MatchedBy != nil {
+			out[i].MatchedBy = append([]string(nil), out[i].MatchedBy...)
+		}
+	}
+	return out
+}
+
+// newSearchKey builds the key of a semantic search of query under ctx.
+// minSimilarity is the threshold the search applies.
+func newSearchKey(ctx context.Context, namespace, query string, nodeTypes []string, limit int, minSimilarity float64) searchKey {
+	return searchKey{
+		query:           query,
+		nodeTypes:       strings.Join(nodeTypes, ","),
+		limit:           limit,
+		namespace:       namespace,
+		timeRange:       tools.TimeRangeFromContext(ctx),
+		sourceAgent:     tools.SourceAgentFromContext(ctx),
+		includeArchived: tools.IncludeArchivedFromContext(ctx),
+		minSimilarity:   minSimilarity,
+	}
+}
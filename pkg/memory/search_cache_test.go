@@ -0,0 +1,97 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestSearchCacheGetPut(t *testing.T) {
+	c := newSearchCache(2, time.Minute)
+	key := newSearchKey(context.Background(), "default", "deploys", nil, 10, 0)
+
+	_, gen, ok := c.get(key)
+	if ok {
+		t.Fatal("empty cache should miss")
+	}
+	c.put(key, gen, []tools.SearchResult{{ID: "fact:1", MatchedBy: []string{"semantic"}}})
+
+	got, _, ok := c.get(key)
+	if !ok || len(got) != 1 || got[0].ID != "fact:1" {
+		t.Fatalf("expected cached fact:1, got %+v (hit %v)", got, ok)
+	}
+	got[0].ID = "changed"
+	got[0].MatchedBy[0] = "changed"
+	again, _, _ := c.get(key)
+	if again[0].ID != "fact:1" || again[0].MatchedBy[0] != "semantic" {
+		t.Errorf("changing returned results should not change the cache, got %+v", again[0])
+	}
+
+	other := newSearchKey(tools.WithIncludeArchived(context.Background(), true), "default", "deploys", nil, 10, 0)
+	if _, _, ok := c.get(other); ok {
+		t.Error("a search with other filters should miss")
+	}
+
+	if s := c.stats(); s.Hits != 2 || s.Misses != 2 || s.Entries != 1 {
+		t.Errorf("expected 2 hits, 2 misses, 1 entry, got %+v", s)
+	}
+}
+
+func TestSearchCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := newSearchCache(2, time.Minute)
+	keys := make([]searchKey, 3)
+	for i, q := range []string{"a", "b", "c"} {
+		keys[i] = newSearchKey(context.Background(), "default", q, nil, 10, 0)
+	}
+	c.put(keys[0], 0, nil)
+	c.put(keys[1], 0, nil)
+	c.get(keys[0])
+	c.put(keys[2], 0, nil)
+
+	if _, _, ok := c.get(keys[1]); ok {
+		t.Error("b was least recently used and should be evicted")
+	}
+	if _, _, ok := c.get(keys[0]); !ok {
+		t.Error("a should still be cached")
+	}
+}
+
+func TestSearchCacheExpiry(t *testing.T) {
+	c := newSearchCache(2, time.Minute)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	key := newSearchKey(context.Background(), "default", "deploys", nil, 10, 0)
+	c.put(key, 0, nil)
+
+	now = now.Add(2 * time.Minute)
+	if _, _, ok := c.get(key); ok {
+		t.Error("expired search should miss")
+	}
+	if s := c.stats(); s.Entries != 0 {
+		t.Errorf("expired search should be removed, got %d entries", s.Entries)
+	}
+}
+
+func TestSearchCacheInvalidate(t *testing.T) {
+	c := newSearchCache(2, time.Minute)
+	key := newSearchKey(context.Background(), "default", "deploys", nil, 10, 0)
+	_, gen, _ := c.get(key)
+	c.put(key, gen, nil)
+
+	c.invalidate()
+	if _, _, ok := c.get(key); ok {
+		t.Error("invalidated search should miss")
+	}
+
+	// A search that started before a write must not be cached.
+	c.put(key, gen, nil)
+	if _, _, ok := c.get(key); ok {
+		t.Error("results from before the invalidation should not be cached")
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// PutSession records session in mie_session, replacing an earlier record of
+// the same session.
+func (w *Writer) PutSession(ctx context.Context, session *tools.Session) error {
+	mutation := `?[id, title, source_agent, namespace, started_at, ended_at, summary] <- [[$id, $title, $source_agent, $ns, $started_at, $ended_at, $summary]]
+:put mie_session { id => title, source_agent, namespace, started_at, ended_at, summary }`
+	params := map[string]any{
+		"id": session.ID, "title": session.Title, "source_agent": session.SourceAgent,
+		"ns": resolveNamespace(ctx, w.namespace), "started_at": session.StartedAt,
+		"ended_at": session.EndedAt, "summary": session.Summary,
+	}
+	if err := w.backend.Execute(ctx, mutation, params); err != nil {
+		return fmt.Errorf("record session: %w", err)
+	}
+	return nil
+}
+
+// GetSession returns the session of the namespace with the given ID.
+func (r *Reader) GetSession(ctx context.Context, sessionID string) (*tools.Session, error) {
+	sessions, err := r.sessions(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, fmt.Errorf("session %q not found", sessionID)
+	}
+	return &sessions[0], nil
+}
+
+// ListSessions returns the sessions of the namespace, most recently started
+// first.
+func (r *Reader) ListSessions(ctx context.Context) ([]tools.Session, error) {
+	return r.sessions(ctx, "")
+}
+
+// sessions returns the sessions of the namespace, or only the one with ID
+// sessionID if it is not empty.
+func (r *Reader) sessions(ctx context.Context, sessionID string) ([]tools.Session, error) {
+	cond := ""
+	params := map[string]any{"ns": resolveNamespace(ctx, r.namespace)}
+	if sessionID != "" {
+		cond = ", id = $id"
+		params["id"] = sessionID
+	}
+	result, err := r.backend.Query(ctx, fmt.Sprintf(
+		`?[id, title, source_agent, started_at, ended_at, summary] := *mie_session { id, title, source_agent, namespace, started_at, ended_at, summary }, namespace = $ns%s
+:order -started_at, id`, cond), params)
+	if err != nil {
+		return nil, fmt.Errorf("read sessions: %w", err)
+	}
+
+	sessions := make([]tools.Session, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		sessions = append(sessions, tools.Session{
+			ID:          toString(row[0]),
+			Title:       toString(row[1]),
+			SourceAgent: toString(row[2]),
+			StartedAt:   toInt64(row[3]),
+			EndedAt:     toInt64(row[4]),
+			Summary:     toString(row[5]),
+		})
+	}
+	return sessions, nil
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestClientSessions(t *testing.T) {
+	client, err := NewClient(ClientConfig{DataDir: t.TempDir(), StorageEngine: "mem", EmbeddingDimensions: 384})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	session, err := client.OpenSession(ctx, "Pick a queue", "claude")
+	if err != nil {
+		t.Fatalf("OpenSession: %v", err)
+	}
+	if session.ID == "" || session.StartedAt == 0 || session.EndedAt != 0 {
+		t.Fatalf("unexpected new session: %+v", session)
+	}
+
+	fact, _ := client.StoreFact(ctx, tools.StoreFactRequest{Content: "Jobs retry 3 times", Category: "technical", SourceConversation: session.ID})
+	_, _ = client.StoreFact(ctx, tools.StoreFactRequest{Content: "Deploys run on Fridays", Category: "technical"})
+	_, _ = client.StoreEntity(ctx, tools.StoreEntityRequest{Name: "NATS", Kind: "technology"})
+
+	nodes, total, err := client.ListNodes(ctx, tools.ListOptions{NodeType: "fact", SourceConversation: session.ID})
+	if err != nil {
+		t.Fatalf("ListNodes: %v", err)
+	}
+	if total != 1 || len(nodes) != 1 || nodes[0].(*tools.Fact).ID != fact.ID {
+		t.Errorf("expected only the session's fact, got %d: %v", total, nodes)
+	}
+
+	closed, err := client.CloseSession(ctx, session.ID, "Chose NATS")
+	if err != nil {
+		t.Fatalf("CloseSession: %v", err)
+	}
+	if closed.EndedAt == 0 || closed.Summary != "Chose NATS" || closed.Title != "Pick a queue" {
+		t.Errorf("unexpected closed session: %+v", closed)
+	}
+	if _, err := client.CloseSession(ctx, session.ID, ""); err == nil {
+		t.Error("expected an error closing a closed session")
+	}
+
+	got, err := client.GetSession(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if *got != *closed {
+		t.Errorf("GetSession = %+v, want %+v", got, closed)
+	}
+	list, err := client.ListSessions(ctx)
+	if err != nil || len(list) != 1 {
+		t.Fatalf("ListSessions = %v, %v", list, err)
+	}
+	if others, _ := client.ListSessions(tools.WithNamespace(ctx, "other")); len(others) != 0 {
+		t.Errorf("sessions leaked into another namespace: %v", others)
+	}
+}
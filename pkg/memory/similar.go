@@ -0,0 +1,84 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// similarNodePrefixes maps the ID prefixes of the node types that have
+// embeddings to those types.
+var similarNodePrefixes = map[string]string{
+	"fact:": "fact",
+	"dec:":  "decision",
+	"ent:":  "entity",
+	"evt:":  "event",
+}
+
+// SimilarNodes returns the nodes of nodeTypes, all embedded types if empty,
+// nearest to the stored embedding of the node nodeID, most similar first.
+// The node itself is left out. Like SemanticSearch it drops results less
+// similar than the minimum similarity and honors the time range, source
+// agent, and archive filters of the context, but it orders purely by
+// similarity, which is what finding duplicates calls for.
+func (r *Reader) SimilarNodes(ctx context.Context, nodeID string, nodeTypes []string, limit int) ([]tools.SearchResult, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if strings.HasPrefix(nodeID, "top:") {
+		return nil, fmt.Errorf("topics have no embeddings; %s cannot be compared", nodeID)
+	}
+	nodeType := ""
+	for prefix, nt := range similarNodePrefixes {
+		if strings.HasPrefix(nodeID, prefix) {
+			nodeType = nt
+		}
+	}
+	if nodeType == "" {
+		return nil, fmt.Errorf("invalid node ID %q: must start with fact:, dec:, ent:, or evt:", nodeID)
+	}
+
+	ns := resolveNamespace(ctx, r.namespace)
+	script := fmt.Sprintf(`?[embedding] := *%s { id, namespace }, id = $id, namespace = $ns, *%s { %s: id, embedding }`,
+		nodeTypeToTable(nodeType), nodeTypeToEmbeddingTable(nodeType), nodeType+"_id")
+	qr, err := r.backend.Query(ctx, script, map[string]any{"id": nodeID, "ns": ns})
+	if err != nil {
+		return nil, fmt.Errorf("read embedding of %s: %w", nodeID, err)
+	}
+	if len(qr.Rows) == 0 {
+		if node, err := r.getNodeByType(ctx, nodeID, nodeType); err != nil || node == nil {
+			return nil, fmt.Errorf("node %q not found", nodeID)
+		}
+		return nil, fmt.Errorf("node %s has no embedding yet; it is embedded when embeddings are enabled", nodeID)
+	}
+	emb := toVector(qr.Rows[0][0])
+
+	minSimilarity := r.minSimilarity
+	if v, ok := tools.MinSimilarityFromContext(ctx); ok {
+		minSimilarity = v
+	}
+	// One more than asked for, as the node itself is usually the nearest.
+	results := r.nearestNodes(ctx, ns, emb, nodeTypes, limit+1, minSimilarity)
+	kept := results[:0]
+	for _, sr := range results {
+		if sr.ID != nodeID {
+			kept = append(kept, sr)
+		}
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		return kept[i].Distance < kept[j].Distance
+	})
+	if len(kept) > limit {
+		kept = kept[:limit]
+	}
+	return kept, nil
+}
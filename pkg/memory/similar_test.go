@@ -0,0 +1,76 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestClientSimilarNodes(t *testing.T) {
+	client, err := NewClient(ClientConfig{
+		DataDir:             t.TempDir(),
+		StorageEngine:       "mem",
+		EmbeddingEnabled:    true,
+		EmbeddingProvider:   "mock",
+		EmbeddingDimensions: 384,
+		VectorIndex:         NewFlatIndex(),
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	var ids []string
+	for _, content := range []string{
+		"The billing service runs on Postgres",
+		"The billing service runs on Postgres 16",
+		"Alice prefers dark roast coffee",
+	} {
+		fact, err := client.StoreFact(ctx, tools.StoreFactRequest{Content: content, Category: "technical"})
+		if err != nil {
+			t.Fatalf("StoreFact: %v", err)
+		}
+		ids = append(ids, fact.ID)
+	}
+	if _, err := client.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Postgres", Kind: "technology"}); err != nil {
+		t.Fatalf("StoreEntity: %v", err)
+	}
+
+	results, err := client.SimilarNodes(ctx, ids[0], nil, 10)
+	if err != nil {
+		t.Fatalf("SimilarNodes: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("SimilarNodes returned %d nodes, want the other 3: %+v", len(results), results)
+	}
+	for i, r := range results {
+		if r.ID == ids[0] {
+			t.Errorf("SimilarNodes returned the node itself")
+		}
+		if i > 0 && r.Distance < results[i-1].Distance {
+			t.Errorf("results not ordered by distance: %+v", results)
+		}
+	}
+
+	results, err = client.SimilarNodes(ctx, ids[0], []string{"fact"}, 1)
+	if err != nil {
+		t.Fatalf("SimilarNodes: %v", err)
+	}
+	if len(results) != 1 || results[0].NodeType != "fact" {
+		t.Errorf("SimilarNodes(fact, 1) = %+v", results)
+	}
+
+	for _, id := range []string{"top:abc", "fact:missing", "nope"} {
+		if _, err := client.SimilarNodes(ctx, id, nil, 10); err == nil {
+			t.Errorf("SimilarNodes(%q) succeeded", id)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// snapshotNodeTypes lists the node types a namespace is cleared of before a
+// snapshot is restored.
+var snapshotNodeTypes = []string{"fact", "decision", "entity", "event", "topic"}
+
+// CreateSnapshot records a full export of the namespace in mie_snapshot
+// under label.
+func (w *Writer) CreateSnapshot(ctx context.Context, label string, data *tools.ExportData) (*tools.Snapshot, error) {
+	ns := resolveNamespace(ctx, w.namespace)
+	now := time.Now()
+	snap := &tools.Snapshot{
+		ID:        GenerateID("snap", ns, label, strconv.FormatInt(now.UnixNano(), 10)),
+		Label:     label,
+		CreatedAt: now.Unix(),
+		Stats:     data.Stats,
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("encode snapshot: %w", err)
+	}
+	stats, err := json.Marshal(snap.Stats)
+	if err != nil {
+		return nil, fmt.Errorf("encode snapshot stats: %w", err)
+	}
+
+	mutation := `?[id, label, created_at, namespace, stats, data] <- [[$id, $label, $created_at, $ns, $stats, $data]] :put mie_snapshot { id => label, created_at, namespace, stats, data }`
+	params := map[string]any{
+		"id": snap.ID, "label": label, "created_at": snap.CreatedAt, "ns": ns,
+		"stats": string(stats), "data": string(payload),
+	}
+	if err := w.backend.Execute(ctx, mutation, params); err != nil {
+		return nil, fmt.Errorf("create snapshot: %w", err)
+	}
+	return snap, nil
+}
+
+// DeleteSnapshot removes a snapshot of the namespace.
+func (w *Writer) DeleteSnapshot(ctx context.Context, snapshotID string) error {
+	mutation := `?[id] := *mie_snapshot { id, namespace }, id = $id, namespace = $ns :rm mie_snapshot { id }`
+	if err := w.backend.Execute(ctx, mutation, map[string]any{"id": snapshotID, "ns": resolveNamespace(ctx, w.namespace)}); err != nil {
+		return fmt.Errorf("delete snapshot: %w", err)
+	}
+	return nil
+}
+
+// clearNamespace deletes every node of the namespace together with its
+// edges, embeddings, verification, expiry, event end dates, archive marks,
+// and aliases, in one transaction. Snapshots and the audit log are kept.
+func (w *Writer) clearNamespace(ctx context.Context) error {
+	params := map[string]any{"ns": resolveNamespace(ctx, w.namespace)}
+	var blocks []string
+	var forget []func()
+
+	// Edges belong to the namespace of their source node.
+	tables := make([]string, 0, len(edgeTableEndpoints))
+	for table := range edgeTableEndpoints {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	for _, table := range tables {
+		keys := ValidEdgeTables[table]
+		blocks = append(blocks, fmt.Sprintf(`{
+    ?[%[2]s, %[3]s] := *%[1]s { %[2]s, %[3]s }, *%[4]s { id: %[2]s, namespace }, namespace = $ns
+    :rm %[1]s { %[2]s, %[3]s }
+}`, table, keys[0], keys[1], nodeTypeToTable(edgeTableEndpoints[table][0])))
+	}
+
+	for _, nodeType := range snapshotNodeTypes {
+		table := nodeTypeToTable(nodeType)
+		if embeddings := nodeTypeToEmbeddingTable(nodeType); embeddings != "" {
+			forget = append(forget, w.forgetVectors(ctx, nodeType, fmt.Sprintf(
+				`?[id] := *%s { id, namespace }, namespace = $ns`, table), params))
+			blocks = append(blocks, fmt.Sprintf(`{
+    ?[%[2]s] := *%[1]s { %[2]s }, *%[3]s { id: %[2]s, namespace }, namespace = $ns
+    :rm %[1]s { %[2]s }
+}`, embeddings, nodeType+"_id", table))
+		}
+		blocks = append(blocks, fmt.Sprintf(`{
+    ?[node_id] := *mie_archived { node_id }, *%[1]s { id: node_id, namespace }, namespace = $ns
+    :rm mie_archived { node_id }
+}`, table))
+	}
+
+	blocks = append(blocks, `{
+    ?[fact_id] := *mie_fact_verification { fact_id }, *mie_fact { id: fact_id, namespace }, namespace = $ns
+    :rm mie_fact_verification { fact_id }
+}`, `{
+    ?[fact_id] := *mie_fact_expiry { fact_id }, *mie_fact { id: fact_id, namespace }, namespace = $ns
+    :rm mie_fact_expiry { fact_id }
+}`, `{
+    ?[fact_id] := *mie_fact_key { fact_id }, *mie_fact { id: fact_id, namespace }, namespace = $ns
+    :rm mie_fact_key { fact_id }
+}`, `{
+    ?[fact_id] := *mie_fact_negated { fact_id }, *mie_fact { id: fact_id, namespace }, namespace = $ns
+    :rm mie_fact_negated { fact_id }
+}`, `{
+    ?[event_id] := *mie_event_end { event_id }, *mie_event { id: event_id, namespace }, namespace = $ns
+    :rm mie_event_end { event_id }
+}`, `{
+    ?[alias, namespace] := *mie_entity_alias { alias, namespace }, namespace = $ns
+    :rm mie_entity_alias { alias, namespace }
+}`)
+
+	for _, nodeType := range snapshotNodeTypes {
+		blocks = append(blocks, fmt.Sprintf(`{
+    ?[id] := *%[1]s { id, namespace }, namespace = $ns
+    :rm %[1]s { id }
+}`, nodeTypeToTable(nodeType)))
+	}
+
+	if err := w.backend.Execute(ctx, strings.Join(blocks, "\n"), params); err != nil {
+		return fmt.Errorf("clear namespace: %w", err)
+	}
+	for _, f := range forget {
+		f()
+	}
+	return nil
+}
+
+// ListSnapshots returns the snapshots of the namespace, newest first.
+func (r *Reader) ListSnapshots(ctx context.Context) ([]tools.Snapshot, error) {
+	result, err := r.backend.Query(ctx,
+		`?[id, label, created_at, stats] := *mie_snapshot { id, label, created_at, stats, namespace }, namespace = $ns
+:order -created_at, id`,
+		map[string]any{"ns": resolveNamespace(ctx, r.namespace)})
+	if err != nil {
+		return nil, fmt.Errorf("list snapshots: %w", err)
+	}
+
+	snapshots := make([]tools.Snapshot, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		snap := tools.Snapshot{
+			ID:        toString(row[0]),
+			Label:     toString(row[1]),
+			CreatedAt: toInt64(row[2]),
+		}
+		if err := json.Unmarshal([]byte(toString(row[3])), &snap.Stats); err != nil {
+			return nil, fmt.Errorf("decode stats of snapshot %s: %w", snap.ID, err)
+		}
+		snapshots = append(snapshots, snap)
+	}
+	return snapshots, nil
+}
+
+// snapshotData returns the export recorded by a snapshot of the namespace.
+func (r *Reader) snapshotData(ctx context.Context, snapshotID string) (*tools.ExportData, error) {
+	result, err := r.backend.Query(ctx,
+		`?[data] := *mie_snapshot { id, data, namespace }, id = $id, namespace = $ns`,
+		map[string]any{"id": snapshotID, "ns": resolveNamespace(ctx, r.namespace)})
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot: %w", err)
+	}
+	if len(result.Rows) == 0 {
+		return nil, fmt.Errorf("snapshot %s not found", snapshotID)
+	}
+
+	var data tools.ExportData
+	if err := json.Unmarshal([]byte(toString(result.Rows[0][0])), &data); err != nil {
+		return nil, fmt.Errorf("decode snapshot %s: %w", snapshotID, err)
+	}
+	return &data, nil
+}
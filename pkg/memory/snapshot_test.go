@@ -0,0 +1,101 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestClientSnapshotRestore(t *testing.T) {
+	client, err := NewClient(ClientConfig{DataDir: t.TempDir(), StorageEngine: "mem", EmbeddingDimensions: 384})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	fact, err := client.StoreFact(ctx, tools.StoreFactRequest{Content: "Go is fast", Category: "technical"})
+	if err != nil {
+		t.Fatalf("StoreFact: %v", err)
+	}
+	ent, err := client.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Go", Kind: "technology"})
+	if err != nil {
+		t.Fatalf("StoreEntity: %v", err)
+	}
+	if err := client.AddRelationship(ctx, "mie_fact_entity", map[string]string{"fact_id": fact.ID, "entity_id": ent.ID}); err != nil {
+		t.Fatalf("AddRelationship: %v", err)
+	}
+	other, err := client.StoreFact(tools.WithNamespace(ctx, "other"), tools.StoreFactRequest{Content: "Uses Rust", Category: "technical"})
+	if err != nil {
+		t.Fatalf("StoreFact in other namespace: %v", err)
+	}
+
+	snap, err := client.CreateSnapshot(ctx, "before import")
+	if err != nil {
+		t.Fatalf("CreateSnapshot: %v", err)
+	}
+	if snap.Stats["facts"] != 1 || snap.Stats["entities"] != 1 || snap.Stats["relationships"] != 1 {
+		t.Errorf("snapshot stats = %v", snap.Stats)
+	}
+
+	// Changes after the snapshot are reverted by the restore.
+	added, err := client.StoreFact(ctx, tools.StoreFactRequest{Content: "Go has generics", Category: "technical"})
+	if err != nil {
+		t.Fatalf("StoreFact: %v", err)
+	}
+	if err := client.RemoveRelationship(ctx, "mie_fact_entity", map[string]string{"fact_id": fact.ID, "entity_id": ent.ID}); err != nil {
+		t.Fatalf("RemoveRelationship: %v", err)
+	}
+
+	checkpoint, err := client.RestoreSnapshot(ctx, snap.ID)
+	if err != nil {
+		t.Fatalf("RestoreSnapshot: %v", err)
+	}
+	if checkpoint.Stats["facts"] != 2 {
+		t.Errorf("checkpoint stats = %v, want the pre-restore graph", checkpoint.Stats)
+	}
+
+	if _, err := client.GetNodeByID(ctx, added.ID); err == nil {
+		t.Errorf("fact %s stored after the snapshot survived the restore", added.ID)
+	}
+	if _, err := client.GetNodeByID(ctx, fact.ID); err != nil {
+		t.Errorf("fact %s from the snapshot is missing: %v", fact.ID, err)
+	}
+	related, err := client.GetRelatedEntities(ctx, fact.ID)
+	if err != nil || len(related) != 1 {
+		t.Errorf("GetRelatedEntities = %v, %v; want the restored edge", related, err)
+	}
+	if _, err := client.GetNodeByID(ctx, other.ID); err != nil {
+		t.Errorf("fact %s of another namespace was removed: %v", other.ID, err)
+	}
+
+	snapshots, err := client.ListSnapshots(ctx)
+	if err != nil {
+		t.Fatalf("ListSnapshots: %v", err)
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("ListSnapshots returned %d snapshots, want the snapshot and its checkpoint", len(snapshots))
+	}
+	if others, _ := client.ListSnapshots(tools.WithNamespace(ctx, "other")); len(others) != 0 {
+		t.Errorf("snapshots leaked into another namespace: %v", others)
+	}
+
+	entries, err := client.GetAuditLog(ctx, tools.AuditOptions{Limit: 1})
+	if err != nil || len(entries) != 1 || entries[0].Op != ChangeRestored {
+		t.Errorf("latest audit entry = %v, %v; want %q", entries, err, ChangeRestored)
+	}
+
+	if err := client.DeleteSnapshot(ctx, checkpoint.ID); err != nil {
+		t.Fatalf("DeleteSnapshot: %v", err)
+	}
+	if _, err := client.RestoreSnapshot(ctx, checkpoint.ID); err == nil {
+		t.Error("RestoreSnapshot of a deleted snapshot should fail")
+	}
+}
@@ -35,4 +35,7 @@ func setupSchema(t *testing.T, backend *storage.EmbeddedBackend) {
 	if err := EnsureSchema(backend, 384); err != nil {
 		t.Fatalf("ensure mie schema: %v", err)
 	}
+	if err := EnsureFTSIndexes(backend); err != nil {
+		t.Fatalf("ensure fts indexes: %v", err)
+	}
 }
\ No newline at end of file
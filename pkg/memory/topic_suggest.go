@@ -0,0 +1,131 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// topicVectorCache holds topic embeddings by topic ID, so SuggestTopics
+// embeds a topic again only after it changes.
+type topicVectorCache struct {
+	mu      sync.Mutex
+	vectors map[string]topicVector
+}
+
+type topicVector struct {
+	updatedAt int64
+	embedding []float32
+}
+
+// get returns the embedding of topic id cached at updatedAt.
+func (c *topicVectorCache) get(id string, updatedAt int64) ([]float32, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.vectors[id]
+	if !ok || v.updatedAt != updatedAt {
+		return nil, false
+	}
+	return v.embedding, true
+}
+
+func (c *topicVectorCache) put(id string, updatedAt int64, embedding []float32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.vectors == nil {
+		c.vectors = make(map[string]topicVector)
+	}
+	c.vectors[id] = topicVector{updatedAt: updatedAt, embedding: embedding}
+}
+
+// SuggestTopics scores the topics of the namespace against text and returns
+// up to limit of them, best first. With embeddings enabled the score is the
+// cosine similarity of text to the topic's name and description; without,
+// it is the share of the topic name's words that appear in text. Archived
+// topics are left out unless ctx asks for them with
+// tools.WithIncludeArchived.
+func (r *Reader) SuggestTopics(ctx context.Context, text string, limit int) ([]tools.TopicSuggestion, error) {
+	if limit <= 0 {
+		limit = 5
+	}
+	result, err := r.backend.Query(ctx, fmt.Sprintf(
+		`?[id, name, description, updated_at] := *mie_topic { id, name, description, updated_at, namespace }, namespace = $ns%s`,
+		archivedFilter(ctx, "id")), map[string]any{"ns": resolveNamespace(ctx, r.namespace)})
+	if err != nil {
+		return nil, fmt.Errorf("list topics: %w", err)
+	}
+
+	var query []float32
+	if r.embedder != nil {
+		if query, err = r.embedder.GenerateQuery(ctx, text); err != nil {
+			return nil, fmt.Errorf("embed text: %w", err)
+		}
+	}
+	textWords := tools.WordSet(text)
+
+	suggestions := make([]tools.TopicSuggestion, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		s := tools.TopicSuggestion{
+			TopicID:     toString(row[0]),
+			Name:        toString(row[1]),
+			Description: toString(row[2]),
+		}
+		if query == nil {
+			s.Score = nameOverlap(s.Name, textWords)
+		} else {
+			vec, err := r.topicEmbedding(ctx, s, toInt64(row[3]))
+			if err != nil {
+				return nil, err
+			}
+			s.Score = 1 - cosineDistance(query, vec)
+		}
+		suggestions = append(suggestions, s)
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].Name < suggestions[j].Name
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
+
+// topicEmbedding returns the embedding of a topic's name and description,
+// from the cache when the topic is unchanged since it was embedded.
+func (r *Reader) topicEmbedding(ctx context.Context, t tools.TopicSuggestion, updatedAt int64) ([]float32, error) {
+	if vec, ok := r.topicVectors.get(t.TopicID, updatedAt); ok {
+		return vec, nil
+	}
+	content := t.Name
+	if t.Description != "" {
+		content += ": " + t.Description
+	}
+	vec, err := r.embedder.Generate(ctx, content)
+	if err != nil {
+		return nil, fmt.Errorf("embed topic %s: %w", t.TopicID, err)
+	}
+	r.topicVectors.put(t.TopicID, updatedAt, vec)
+	return vec, nil
+}
+
+// nameOverlap returns the share of the words of name found in words.
+func nameOverlap(name string, words map[string]bool) float64 {
+	nameWords := tools.WordSet(name)
+	if len(nameWords) == 0 {
+		return 0
+	}
+	return float64(tools.SharedWords(nameWords, words)) / float64(len(nameWords))
+}
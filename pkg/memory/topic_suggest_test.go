@@ -0,0 +1,77 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestReaderSuggestTopicsByWords(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	r := NewReader(backend, nil, nil)
+	ctx := context.Background()
+
+	for _, name := range []string{"payment processing", "hiring", "infra"} {
+		if _, err := w.StoreTopic(ctx, tools.StoreTopicRequest{Name: name}); err != nil {
+			t.Fatalf("StoreTopic(%s) failed: %v", name, err)
+		}
+	}
+
+	got, err := r.SuggestTopics(ctx, "Payment retries now back off exponentially", 2)
+	if err != nil {
+		t.Fatalf("SuggestTopics failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 suggestions, got %+v", got)
+	}
+	if got[0].Name != "payment processing" || got[0].Score != 0.5 {
+		t.Errorf("expected payment processing first with score 0.5, got %+v", got[0])
+	}
+	if got[1].Score != 0 {
+		t.Errorf("expected no overlap for the other topics, got %+v", got[1])
+	}
+}
+
+func TestReaderSuggestTopicsByEmbedding(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	embedder := NewEmbeddingGenerator(NewMockEmbeddingProvider(384, nil), nil)
+	w := NewWriter(backend, embedder, nil)
+	r := NewReader(backend, embedder, nil)
+	ctx := context.Background()
+
+	billing, err := w.StoreTopic(ctx, tools.StoreTopicRequest{Name: "billing", Description: "Invoices and payments"})
+	if err != nil {
+		t.Fatalf("StoreTopic failed: %v", err)
+	}
+	if _, err := w.StoreTopic(ctx, tools.StoreTopicRequest{Name: "hiring", Description: "Recruiting"}); err != nil {
+		t.Fatalf("StoreTopic failed: %v", err)
+	}
+
+	got, err := r.SuggestTopics(ctx, "billing: Invoices and payments", 5)
+	if err != nil {
+		t.Fatalf("SuggestTopics failed: %v", err)
+	}
+	if len(got) != 2 || got[0].TopicID != billing.ID {
+		t.Fatalf("expected billing first, got %+v", got)
+	}
+	if got[0].Score < 0.999 {
+		t.Errorf("expected identical text to score 1, got %f", got[0].Score)
+	}
+	if _, ok := r.topicVectors.get(billing.ID, billing.UpdatedAt); !ok {
+		t.Error("expected the billing embedding to be cached")
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// dailyCounterScript adds one to the mie_counter_daily total of $counter on
+// $day.
+const dailyCounterScript = `?[counter, day, total] := counter = $counter, day = $day, *mie_counter_daily { counter, day, total: prev }, total = prev + 1
+?[counter, day, total] := counter = $counter, day = $day, not *mie_counter_daily { counter, day }, total = 1
+:put mie_counter_daily { counter, day => total }`
+
+// usageDayLayout formats the days of mie_counter_daily and UsageDay.
+const usageDayLayout = "2006-01-02"
+
+// usageDay returns the UTC day of the Unix time at.
+func usageDay(at int64) string {
+	return time.Unix(at, 0).UTC().Format(usageDayLayout)
+}
+
+// UsageTrend is the day-by-day activity of a namespace, returned by
+// Client.UsageTrend.
+type UsageTrend struct {
+	// Since is the start of the first day, as Unix seconds.
+	Since     int64           `json:"since"`
+	Days      []UsageDay      `json:"days"`
+	TopTopics []TopicActivity `json:"top_topics"`
+}
+
+// UsageDay is the activity of one UTC day.
+type UsageDay struct {
+	Date string `json:"date"`
+	// Nodes counts the nodes that existed at the end of the day.
+	Nodes int `json:"nodes"`
+	// Created counts the nodes created that day.
+	Created int `json:"created"`
+	// Writes counts the audit log entries of the day.
+	Writes int `json:"writes"`
+	// Queries counts the read tool calls of the day. Queries are counted
+	// for every namespace together, and only since schema version 16.
+	Queries int `json:"queries"`
+}
+
+// TopicActivity counts the nodes linked to a topic during a period.
+type TopicActivity struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Links int    `json:"links"`
+}
+
+// UsageTrend returns the activity of the namespace over the last days UTC
+// days, today included, and the topics with the most nodes linked to them in
+// that time, at most topTopics of them.
+func (c *Client) UsageTrend(ctx context.Context, days, topTopics int) (*UsageTrend, error) {
+	if days < 1 {
+		return nil, fmt.Errorf("days must be at least 1")
+	}
+	ns := resolveNamespace(ctx, c.reader.namespace)
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1-days)
+	trend := &UsageTrend{Since: start.Unix(), Days: make([]UsageDay, days), TopTopics: []TopicActivity{}}
+	index := make(map[string]int, days)
+	for i := range trend.Days {
+		trend.Days[i].Date = start.AddDate(0, 0, i).Format(usageDayLayout)
+		index[trend.Days[i].Date] = i
+	}
+	params := map[string]any{"ns": ns, "since": trend.Since}
+
+	// Node growth: the nodes created before the period, plus each day's.
+	existing := 0
+	for _, nt := range []string{"fact", "decision", "entity", "event", "topic"} {
+		qr, err := c.backend.Query(ctx, fmt.Sprintf(
+			`?[count(id)] := *%s { id, namespace, created_at }, namespace = $ns, created_at < $since`, nodeTypeToTable(nt)), params)
+		if err != nil {
+			return nil, fmt.Errorf("count %s nodes: %w", nt, err)
+		}
+		if len(qr.Rows) > 0 {
+			existing += toInt(qr.Rows[0][0])
+		}
+		qr, err = c.backend.Query(ctx, fmt.Sprintf(
+			`?[id, created_at] := *%s { id, namespace, created_at }, namespace = $ns, created_at >= $since`, nodeTypeToTable(nt)), params)
+		if err != nil {
+			return nil, fmt.Errorf("read %s nodes: %w", nt, err)
+		}
+		for _, row := range qr.Rows {
+			if i, ok := index[usageDay(toInt64(row[1]))]; ok {
+				trend.Days[i].Created++
+			}
+		}
+	}
+	for i := range trend.Days {
+		existing += trend.Days[i].Created
+		trend.Days[i].Nodes = existing
+	}
+
+	qr, err := c.backend.Query(ctx, `?[id, at] := *mie_audit { id, at, namespace }, namespace = $ns, at >= $since`, params)
+	if err != nil {
+		return nil, fmt.Errorf("read audit log: %w", err)
+	}
+	for _, row := range qr.Rows {
+		if i, ok := index[usageDay(toInt64(row[1]))]; ok {
+			trend.Days[i].Writes++
+		}
+	}
+
+	qr, err = c.backend.Query(ctx, `?[day, total] := *mie_counter_daily { counter, day, total }, counter = 'total_queries', day >= $day`,
+		map[string]any{"day": trend.Days[0].Date})
+	if err != nil {
+		return nil, fmt.Errorf("read daily counters: %w", err)
+	}
+	for _, row := range qr.Rows {
+		if i, ok := index[toString(row[0])]; ok {
+			trend.Days[i].Queries = toInt(row[1])
+		}
+	}
+
+	if topTopics > 0 {
+		if trend.TopTopics, err = c.topTopicActivity(ctx, params, topTopics); err != nil {
+			return nil, err
+		}
+	}
+	return trend, nil
+}
+
+// topTopicActivity returns the limit topics of namespace $ns with the most
+// nodes linked to them at or after $since, most first.
+func (c *Client) topTopicActivity(ctx context.Context, params map[string]any, limit int) ([]TopicActivity, error) {
+	qr, err := c.backend.Query(ctx, `linked[topic_id, node_id] := *mie_fact_topic { fact_id: node_id, topic_id, created_at }, created_at >= $since
+linked[topic_id, node_id] := *mie_decision_topic { decision_id: node_id, topic_id, created_at }, created_at >= $since
+linked[topic_id, node_id] := *mie_entity_topic { entity_id: node_id, topic_id, created_at }, created_at >= $since
+?[topic_id, name, count(node_id)] := linked[topic_id, node_id], *mie_topic { id: topic_id, name, namespace }, namespace = $ns`, params)
+	if err != nil {
+		return nil, fmt.Errorf("count topic links: %w", err)
+	}
+	topics := make([]TopicActivity, 0, len(qr.Rows))
+	for _, row := range qr.Rows {
+		topics = append(topics, TopicActivity{ID: toString(row[0]), Name: toString(row[1]), Links: toInt(row[2])})
+	}
+	sort.Slice(topics, func(i, j int) bool {
+		if topics[i].Links != topics[j].Links {
+			return topics[i].Links > topics[j].Links
+		}
+		return topics[i].Name < topics[j].Name
+	})
+	if len(topics) > limit {
+		topics = topics[:limit]
+	}
+	return topics, nil
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestClientUsageTrend(t *testing.T) {
+	client, err := NewClient(ClientConfig{DataDir: t.TempDir(), StorageEngine: "mem", EmbeddingDimensions: 384})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	fact, _ := client.StoreFact(ctx, tools.StoreFactRequest{Content: "Jobs retry 3 times", Category: "technical"})
+	ent, _ := client.StoreEntity(ctx, tools.StoreEntityRequest{Name: "NATS", Kind: "technology"})
+	topic, _ := client.StoreTopic(ctx, tools.StoreTopicRequest{Name: "messaging"})
+	_, _ = client.StoreTopic(ctx, tools.StoreTopicRequest{Name: "billing"})
+	for _, link := range []struct {
+		table  string
+		fields map[string]string
+	}{
+		{"mie_fact_topic", map[string]string{"fact_id": fact.ID, "topic_id": topic.ID}},
+		{"mie_entity_topic", map[string]string{"entity_id": ent.ID, "topic_id": topic.ID}},
+	} {
+		if err := client.AddRelationship(ctx, link.table, link.fields); err != nil {
+			t.Fatalf("AddRelationship(%s): %v", link.table, err)
+		}
+	}
+	for range 3 {
+		_ = client.IncrementCounter(ctx, "total_queries")
+	}
+
+	trend, err := client.UsageTrend(ctx, 7, 5)
+	if err != nil {
+		t.Fatalf("UsageTrend: %v", err)
+	}
+	if len(trend.Days) != 7 {
+		t.Fatalf("got %d days, want 7", len(trend.Days))
+	}
+	today := trend.Days[6]
+	if today.Date != time.Now().UTC().Format("2006-01-02") {
+		t.Errorf("last day = %s, want today", today.Date)
+	}
+	if today.Nodes != 4 || today.Created != 4 || today.Queries != 3 || today.Writes != 6 {
+		t.Errorf("unexpected today: %+v", today)
+	}
+	if trend.Days[0].Nodes != 0 {
+		t.Errorf("expected no nodes on the first day, got %+v", trend.Days[0])
+	}
+	if len(trend.TopTopics) != 1 || trend.TopTopics[0].ID != topic.ID || trend.TopTopics[0].Links != 2 {
+		t.Errorf("unexpected top topics: %+v", trend.TopTopics)
+	}
+
+	other, err := client.UsageTrend(tools.WithNamespace(ctx, "other"), 1, 5)
+	if err != nil {
+		t.Fatalf("UsageTrend(other): %v", err)
+	}
+	if other.Days[0].Nodes != 0 || other.Days[0].Writes != 0 {
+		t.Errorf("activity leaked into another namespace: %+v", other.Days[0])
+	}
+	if _, err := client.UsageTrend(ctx, 0, 5); err == nil {
+		t.Error("expected an error for zero days")
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/kraklabs/mie/pkg/storage"
+)
+
+// VectorMatch is a node found by a VectorIndex search.
+type VectorMatch struct {
+	ID string
+	// Distance is the cosine distance to the query, 1 - similarity.
+	Distance float64
+}
+
+// VectorIndex finds nodes by the similarity of their embeddings. Node types
+// are "fact", "decision", "entity", and "event".
+//
+// Embeddings are always stored in the per-type embedding tables, which
+// CozoDB indexes with HNSW by default. A Client configured with another
+// VectorIndex skips the HNSW indexes, loads the stored embeddings into the
+// index when it opens, and keeps the index in step as embeddings are
+// written and nodes removed. Implementations must be safe for concurrent
+// use.
+type VectorIndex interface {
+	// Add stores or replaces the embedding of a node.
+	Add(ctx context.Context, nodeType, id string, embedding []float32) error
+	// Delete removes the embedding of a node. Deleting a node that is not
+	// in the index is not an error.
+	Delete(ctx context.Context, nodeType, id string) error
+	// Search returns up to k nodes of nodeType nearest to query, nearest
+	// first.
+	Search(ctx context.Context, nodeType string, query []float32, k int) ([]VectorMatch, error)
+}
+
+// hnswIndex is the built-in VectorIndex over the embedding tables and their
+// CozoDB HNSW indexes.
+type hnswIndex struct {
+	backend storage.Backend
+}
+
+// Ensure both indexes implement VectorIndex at compile time.
+var (
+	_ VectorIndex = (*hnswIndex)(nil)
+	_ VectorIndex = (*FlatIndex)(nil)
+)
+
+func (h *hnswIndex) Add(ctx context.Context, nodeType, id string, embedding []float32) error {
+	table := nodeTypeToEmbeddingTable(nodeType)
+	if table == "" {
+		return fmt.Errorf("no embeddings for node type %q", nodeType)
+	}
+	return h.backend.Execute(ctx, putEmbeddingScript(nodeType, table), map[string]any{"id": id, "embedding": embedding})
+}
+
+// putEmbeddingScript returns the mutation that writes $embedding as the
+// embedding of the node $id of nodeType to its embedding table table.
+func putEmbeddingScript(nodeType, table string) string {
+	return fmt.Sprintf(`?[%[1]s, embedding] <- [[$id, vec($embedding)]] :put %[2]s { %[1]s => embedding }`, nodeType+"_id", table)
+}
+
+func (h *hnswIndex) Delete(ctx context.Context, nodeType, id string) error {
+	table := nodeTypeToEmbeddingTable(nodeType)
+	if table == "" {
+		return fmt.Errorf("no embeddings for node type %q", nodeType)
+	}
+	mutation := fmt.Sprintf(`?[%[1]s] <- [[$id]] :rm %[2]s { %[1]s }`, nodeType+"_id", table)
+	return h.backend.Execute(ctx, mutation, map[string]any{"id": id})
+}
+
+func (h *hnswIndex) Search(ctx context.Context, nodeType string, query []float32, k int) ([]VectorMatch, error) {
+	if nodeTypeToEmbeddingTable(nodeType) == "" {
+		return nil, fmt.Errorf("no embeddings for node type %q", nodeType)
+	}
+	params := map[string]any{}
+	qr, err := h.backend.Query(ctx, fmt.Sprintf("?[id, distance] := %s,\n    id = %s\n    :order distance",
+		hnswAtom(nodeType, query, k, params), nodeType+"_id"), params)
+	if err != nil {
+		return nil, err
+	}
+	matches := make([]VectorMatch, len(qr.Rows))
+	for i, row := range qr.Rows {
+		matches[i] = VectorMatch{ID: toString(row[0]), Distance: toFloat64(row[1])}
+	}
+	return matches, nil
+}
+
+// hnswAtom returns the Datalog atoms that bind NODETYPE_id and distance to
+// the k nodes nearest to query in the HNSW index of nodeType. The query
+// vector is added to params as $query_vector.
+func hnswAtom(nodeType string, query []float32, k int, params map[string]any) string {
+	params["query_vector"] = query
+	return fmt.Sprintf(`~%s:%s { %s | query: q, k: %d, ef: 200, bind_distance: distance },
+    q = vec($query_vector)`, nodeTypeToEmbeddingTable(nodeType), nodeTypeToHNSWIndex(nodeType), nodeType+"_id", k)
+}
+
+// nearestAtom returns the Datalog atoms that bind NODETYPE_id and distance
+// to the k nodes of nodeType nearest to query, and adds the values they
+// refer to to params. A nil index stands for the built-in HNSW index,
+// searched inside the query; any other index is searched first and its
+// matches are bound as $matches.
+func nearestAtom(ctx context.Context, index VectorIndex, nodeType string, query []float32, k int, params map[string]any) (string, error) {
+	if index == nil {
+		return hnswAtom(nodeType, query, k, params), nil
+	}
+	matches, err := index.Search(ctx, nodeType, query, k)
+	if err != nil {
+		return "", fmt.Errorf("search %s vectors: %w", nodeType, err)
+	}
+	pairs := make([][]any, len(matches))
+	for i, m := range matches {
+		pairs[i] = []any{m.ID, m.Distance}
+	}
+	params["matches"] = pairs
+	return fmt.Sprintf(`match in $matches,
+    %s = get(match, 0),
+    distance = get(match, 1)`, nodeType+"_id"), nil
+}
+
+// loadVectorIndex adds every stored embedding to index.
+func loadVectorIndex(ctx context.Context, backend storage.Backend, index VectorIndex) error {
+	for _, nodeType := range backfillNodeTypes {
+		qr, err := backend.Query(ctx, fmt.Sprintf(`?[id, embedding] := *%s { %s: id, embedding }`,
+			nodeTypeToEmbeddingTable(nodeType), nodeType+"_id"), nil)
+		if err != nil {
+			return fmt.Errorf("read %s embeddings: %w", nodeType, err)
+		}
+		for _, row := range qr.Rows {
+			if err := index.Add(ctx, nodeType, toString(row[0]), toVector(row[1])); err != nil {
+				return fmt.Errorf("index %s embedding: %w", nodeType, err)
+			}
+		}
+	}
+	return nil
+}
+
+// toVector converts a vector read from CozoDB to []float32.
+func toVector(v any) []float32 {
+	switch vec := v.(type) {
+	case []float32:
+		return vec
+	case []any:
+		out := make([]float32, len(vec))
+		for i, x := range vec {
+			out[i] = float32(toFloat64(x))
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// FlatIndex is an in-memory VectorIndex that compares the query with every
+// embedding of the node type. It needs no index support from the storage
+// backend and is exact, but each search takes time linear in the number of
+// embeddings, so it suits small graphs.
+type FlatIndex struct {
+	mu      sync.RWMutex
+	vectors map[string]map[string][]float32 // node type -> node ID -> embedding
+}
+
+// NewFlatIndex returns an empty FlatIndex.
+func NewFlatIndex() *FlatIndex {
+	return &FlatIndex{vectors: map[string]map[string][]float32{}}
+}
+
+// Add stores or replaces the embedding of a node.
+func (f *FlatIndex) Add(_ context.Context, nodeType, id string, embedding []float32) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.vectors[nodeType] == nil {
+		f.vectors[nodeType] = map[string][]float32{}
+	}
+	f.vectors[nodeType][id] = embedding
+	return nil
+}
+
+// Delete removes the embedding of a node.
+func (f *FlatIndex) Delete(_ context.Context, nodeType, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.vectors[nodeType], id)
+	return nil
+}
+
+// Search returns up to k nodes of nodeType nearest to query by cosine
+// distance, nearest first.
+func (f *FlatIndex) Search(_ context.Context, nodeType string, query []float32, k int) ([]VectorMatch, error) {
+	f.mu.RLock()
+	matches := make([]VectorMatch, 0, len(f.vectors[nodeType]))
+	for id, vec := range f.vectors[nodeType] {
+		matches = append(matches, VectorMatch{ID: id, Distance: cosineDistance(query, vec)})
+	}
+	f.mu.RUnlock()
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Distance != matches[j].Distance {
+			return matches[i].Distance < matches[j].Distance
+		}
+		return matches[i].ID < matches[j].ID
+	})
+	if k >= 0 && len(matches) > k {
+		matches = matches[:k]
+	}
+	return matches, nil
+}
+
+// cosineDistance returns 1 - the cosine similarity of a and b, or 1 if
+// either is zero or their lengths differ.
+func cosineDistance(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 1
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 1
+	}
+	return 1 - dot/(math.Sqrt(na)*math.Sqrt(nb))
+}
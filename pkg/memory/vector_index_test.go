@@ -0,0 +1,113 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package memory
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestFlatIndex(t *testing.T) {
+	ctx := context.Background()
+	idx := NewFlatIndex()
+	for id, vec := range map[string][]float32{
+		"fact:a": {1, 0},
+		"fact:b": {1, 1},
+		"fact:c": {0, 1},
+	} {
+		if err := idx.Add(ctx, "fact", id, vec); err != nil {
+			t.Fatalf("Add(%s): %v", id, err)
+		}
+	}
+
+	matches, err := idx.Search(ctx, "fact", []float32{2, 0}, 2)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(matches) != 2 || matches[0].ID != "fact:a" || matches[1].ID != "fact:b" {
+		t.Fatalf("Search = %+v, want fact:a then fact:b", matches)
+	}
+	if matches[0].Distance > 1e-9 {
+		t.Errorf("distance to identical direction = %v, want 0", matches[0].Distance)
+	}
+
+	if err := idx.Delete(ctx, "fact", "fact:a"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	matches, _ = idx.Search(ctx, "fact", []float32{2, 0}, 2)
+	if len(matches) != 2 || matches[0].ID != "fact:b" {
+		t.Errorf("Search after Delete = %+v, want fact:b first", matches)
+	}
+	if matches, _ := idx.Search(ctx, "entity", []float32{2, 0}, 2); len(matches) != 0 {
+		t.Errorf("Search(entity) = %+v, want none", matches)
+	}
+}
+
+func TestClientFlatVectorIndex(t *testing.T) {
+	idx := NewFlatIndex()
+	client, err := NewClient(ClientConfig{
+		DataDir:             t.TempDir(),
+		StorageEngine:       "mem",
+		EmbeddingEnabled:    true,
+		EmbeddingProvider:   "mock",
+		EmbeddingDimensions: 384,
+		VectorIndex:         idx,
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	// The flat index replaces the HNSW indexes.
+	d, err := Diagnose(ctx, client.backend)
+	if err != nil {
+		t.Fatalf("Diagnose: %v", err)
+	}
+	if len(d.MissingHNSWIndexes) != 4 {
+		t.Errorf("HNSW indexes created with a flat index: missing only %v", d.MissingHNSWIndexes)
+	}
+
+	var ids []string
+	for _, content := range []string{"The billing service runs on Postgres", "Alice prefers dark roast coffee"} {
+		fact, err := client.StoreFact(ctx, tools.StoreFactRequest{Content: content, Category: "technical"})
+		if err != nil {
+			t.Fatalf("StoreFact: %v", err)
+		}
+		ids = append(ids, fact.ID)
+	}
+
+	results, err := client.SemanticSearch(ctx, "The billing service runs on Postgres", []string{"fact"}, 1)
+	if err != nil {
+		t.Fatalf("SemanticSearch: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != ids[0] {
+		t.Fatalf("SemanticSearch = %+v, want %s", results, ids[0])
+	}
+
+	// A new index opened on the same data is loaded from the stored embeddings.
+	reloaded := NewFlatIndex()
+	if err := loadVectorIndex(ctx, client.backend, reloaded); err != nil {
+		t.Fatalf("loadVectorIndex: %v", err)
+	}
+	matches, err := reloaded.Search(ctx, "fact", make([]float32, 384), 10)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	var found []string
+	for _, m := range matches {
+		found = append(found, m.ID)
+	}
+	for _, id := range ids {
+		if !strings.Contains(strings.Join(found, ","), id) {
+			t.Errorf("reloaded index lacks %s: %v", id, found)
+		}
+	}
+}
@@ -10,6 +10,9 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/kraklabs/mie/pkg/storage"
@@ -18,11 +21,46 @@ import (
 
 // Writer handles all mutations to the memory graph.
 type Writer struct {
-	backend  storage.Backend
-	embedder *EmbeddingGenerator
-	logger   *slog.Logger
+	backend        storage.Backend
+	embedder       *EmbeddingGenerator
+	logger         *slog.Logger
+	namespace      string  // default namespace when the context carries none
+	dedupThreshold float64 // fact similarity that counts as a duplicate; 0 disables
+	autoLink       bool    // link new facts to the entities they name
+	// vectors, if set, is searched instead of the HNSW indexes and kept in
+	// step with the embedding tables.
+	vectors VectorIndex
+	// categoryPolicies set the default confidence and retention of facts
+	// by category.
+	categoryPolicies map[string]tools.CategoryPolicy
+	// Configured entity kinds and fact categories beyond the built-in ones.
+	extraKinds      []string
+	extraCategories []string
+	// storeErr is the latest failure to store an embedding.
+	storeErr lastError
+	// searchCache, if set, is the Reader's search cache, cleared when an
+	// embedding is stored.
+	searchCache *searchCache
+	// queue runs the embedding queue workers; nil until
+	// StartEmbeddingQueue.
+	queue *embeddingQueue
 }
 
+// DefaultDedupThreshold is the cosine similarity at or above which a new fact
+// is treated as a duplicate of an existing one.
+const DefaultDedupThreshold = 0.95
+
+// Mutations that write one node, with each column bound to the parameter
+// of the same name.
+const (
+	putFactScript     = `?[id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at, namespace] <- [[$id, $content, $category, $confidence, $source_agent, $source_conversation, $valid, $created_at, $updated_at, $namespace]] :put mie_fact { id => content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at, namespace }`
+	putDecisionScript = `?[id, title, rationale, alternatives, context, source_agent, source_conversation, status, created_at, updated_at, namespace] <- [[$id, $title, $rationale, $alternatives, $context, $source_agent, $source_conversation, $status, $created_at, $updated_at, $namespace]] :put mie_decision { id => title, rationale, alternatives, context, source_agent, source_conversation, status, created_at, updated_at, namespace }`
+	putEntityScript   = `?[id, name, kind, description, source_agent, created_at, updated_at, namespace] <- [[$id, $name, $kind, $description, $source_agent, $created_at, $updated_at, $namespace]] :put mie_entity { id => name, kind, description, source_agent, created_at, updated_at, namespace }`
+	putEventScript    = `?[id, title, description, event_date, source_agent, source_conversation, created_at, updated_at, namespace] <- [[$id, $title, $description, $event_date, $source_agent, $source_conversation, $created_at, $updated_at, $namespace]] :put mie_event { id => title, description, event_date, source_agent, source_conversation, created_at, updated_at, namespace }`
+	putTopicScript    = `?[id, name, description, created_at, updated_at, namespace] <- [[$id, $name, $description, $created_at, $updated_at, $namespace]] :put mie_topic { id => name, description, created_at, updated_at, namespace }`
+	putAliasScript    = `?[alias, namespace, entity_id, created_at] <- [[$alias, $namespace, $entity_id, $created_at]] :put mie_entity_alias { alias, namespace => entity_id, created_at }`
+)
+
 // NewWriter creates a new Writer.
 func NewWriter(backend storage.Backend, embedder *EmbeddingGenerator, logger *slog.Logger) *Writer {
 	if logger == nil {
@@ -36,15 +74,59 @@ func (w *Writer) StoreFact(ctx context.Context, req tools.StoreFactRequest) (*to
 	if req.Content == "" {
 		return nil, fmt.Errorf("fact content is required")
 	}
-	if !isValidCategory(req.Category) {
+	if req.Key = PreferenceKey(req.Key); req.Key != "" {
+		if req.Category == "" {
+			req.Category = "preference"
+		}
+		if err := CheckFactKey(req.Category, req.Key); err != nil {
+			return nil, err
+		}
+	}
+	if !isValidCategory(req.Category) && !slices.Contains(w.extraCategories, req.Category) {
 		req.Category = "general"
 	}
+	policy := w.categoryPolicies[req.Category]
 	if req.Confidence <= 0 || req.Confidence > 1.0 {
 		req.Confidence = 0.8
+		if policy.DefaultConfidence > 0 {
+			req.Confidence = policy.DefaultConfidence
+		}
 	}
 
-	id := FactID(req.Content, req.Category)
+	ns := resolveNamespace(ctx, w.namespace)
+
+	var embedding []float32
+	// A keyed preference replaces the current value of its key, so it is
+	// stored even when an older value reads the same.
+	if w.dedupThreshold > 0 && !req.SkipDedup && req.Key == "" {
+		var existing *tools.Fact
+		existing, embedding = w.findDuplicateFact(ctx, req.Content, ns)
+		if existing != nil {
+			// A fact and an anti-fact of the same content are opposites,
+			// not duplicates.
+			negated, err := w.isNegated(ctx, existing.ID)
+			if err != nil {
+				w.logger.Warn("duplicate polarity check failed", "error", err)
+			} else if negated == req.Negated {
+				existing.Negated = negated
+				return existing, nil
+			}
+		}
+	}
+
+	factID := FactID
+	if req.Negated {
+		factID = NegatedFactID
+	}
+	id := NamespacedID(factID(req.Content, req.Category), ns)
 	now := time.Now().Unix()
+	if policy.RetentionDays > 0 {
+		// Retention caps the expiry; an earlier one is kept.
+		limit := now + int64(policy.RetentionDays)*secondsPerDay
+		if req.ExpiresAt == 0 || req.ExpiresAt > limit {
+			req.ExpiresAt = limit
+		}
+	}
 
 	fact := &tools.Fact{
 		ID:                 id,
@@ -56,22 +138,129 @@ func (w *Writer) StoreFact(ctx context.Context, req tools.StoreFactRequest) (*to
 		Valid:              true,
 		CreatedAt:          now,
 		UpdatedAt:          now,
+		ExpiresAt:          req.ExpiresAt,
+		Key:                req.Key,
+		Negated:            req.Negated,
+	}
+	if fact.Key != "" {
+		previous, err := w.currentValues(ctx, fact.Key, fact.ID, ns)
+		if err != nil {
+			return nil, err
+		}
+		fact.Replaces = previous
 	}
 
-	mutation := fmt.Sprintf(
-		`?[id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at] <- [['%s', '%s', '%s', %f, '%s', '%s', true, %d, %d]] :put mie_fact { id => content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at }`,
-		escapeDatalog(fact.ID), escapeDatalog(fact.Content), escapeDatalog(fact.Category),
-		fact.Confidence, escapeDatalog(fact.SourceAgent), escapeDatalog(fact.SourceConversation),
-		fact.CreatedAt, fact.UpdatedAt,
-	)
-	if err := w.backend.Execute(ctx, mutation); err != nil {
-		return nil, fmt.Errorf("store fact: %w", err)
+	// The fact, its metadata and the invalidation of the values it replaces
+	// are written together.
+	err := w.Atomic(ctx, func(ctx context.Context) error {
+		if err := w.execute(ctx, putFactScript, factParams(fact, ns)); err != nil {
+			return fmt.Errorf("store fact: %w", err)
+		}
+		if fact.ExpiresAt > 0 {
+			if err := w.putExpiry(ctx, fact.ID, fact.ExpiresAt); err != nil {
+				return err
+			}
+		}
+		if fact.Negated {
+			if err := w.putNegation(ctx, fact.ID); err != nil {
+				return err
+			}
+		}
+		if fact.Key == "" {
+			return nil
+		}
+		if err := w.putKey(ctx, fact.ID, fact.Key); err != nil {
+			return err
+		}
+		for _, old := range fact.Replaces {
+			if err := w.InvalidateFact(ctx, old, fact.ID, "new value of preference "+fact.Key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if w.autoLink {
+		w.autoLinkFact(ctx, fact, ns)
 	}
 
-	if w.embedder != nil {
-		go w.storeEmbeddingAsync("mie_fact_embedding", "fact_id", fact.ID, fact.Content)
+	switch {
+	case embedding != nil:
+		// Reuse the embedding generated for the duplicate check.
+		afterCommit(ctx, func() {
+			if err := w.putEmbedding(ctx, "fact", fact.ID, embedding); err != nil {
+				w.logger.Warn("failed to store embedding", "node_id", fact.ID, "type", "fact", "error", err)
+			}
+		})
+	case w.embedder != nil:
+		if err := w.enqueueEmbedding(ctx, "fact", fact.ID, fact.Content); err != nil {
+			return nil, err
+		}
+	}
+
+	return fact, nil
+}
+
+// findDuplicateFact looks for a valid fact in ns that duplicates content:
+// first an exact match ignoring case and surrounding whitespace, then, with
+// embeddings enabled, the nearest fact at or above w.dedupThreshold
+// similarity. The duplicate check never fails a store; errors are logged and
+// treated as no match. The generated embedding is returned for reuse.
+func (w *Writer) findDuplicateFact(ctx context.Context, content, ns string) (*tools.Fact, []float32) {
+	columns := columnsForNodeType("fact")
+
+	exact := fmt.Sprintf(`?[%s] := *mie_fact { %s, namespace },
+    valid = true,
+    namespace = $ns,
+    lowercase(trim(content)) = $content
+    :limit 1`, columns, columns)
+	qr, err := w.backend.Query(ctx, exact, map[string]any{"ns": ns, "content": strings.ToLower(strings.TrimSpace(content))})
+	if err != nil {
+		w.logger.Warn("exact duplicate check failed", "error", err)
+	} else if len(qr.Rows) > 0 {
+		fact := factFromRow(qr.Rows[0])
+		fact.DuplicateSimilarity = 1.0
+		return fact, nil
 	}
 
+	if w.embedder == nil {
+		return nil, nil
+	}
+	embedding, err := w.embedder.Generate(ctx, content)
+	if err != nil {
+		w.logger.Warn("failed to generate embedding for duplicate check", "error", err)
+		return nil, nil
+	}
+
+	params := map[string]any{"ns": ns, "max_distance": 1 - w.dedupThreshold}
+	nearest, err := nearestAtom(ctx, w.vectors, "fact", embedding, 5, params)
+	if err != nil {
+		w.logger.Warn("semantic duplicate check failed", "error", err)
+		return nil, embedding
+	}
+	semantic := fmt.Sprintf(`?[%s, distance] :=
+    %s,
+    *mie_fact { %s, namespace },
+    id = fact_id,
+    valid = true,
+    namespace = $ns,
+    distance <= $max_distance
+    :order distance
+    :limit 1`, columns, nearest, columns)
+	qr, err = w.backend.Query(ctx, semantic, params)
+	if err != nil {
+		w.logger.Warn("semantic duplicate check failed", "error", err)
+		return nil, embedding
+	}
+	if len(qr.Rows) == 0 {
+		return nil, embedding
+	}
+
+	row := qr.Rows[0]
+	fact := factFromRow(row)
+	fact.DuplicateSimilarity = 1 - toFloat64(row[len(row)-1])
 	return fact, nil
 }
 
@@ -84,7 +273,16 @@ func (w *Writer) StoreDecision(ctx context.Context, req tools.StoreDecisionReque
 		return nil, fmt.Errorf("decision rationale is required")
 	}
 
-	id := DecisionID(req.Title, req.Rationale)
+	switch req.Status {
+	case "":
+		req.Status = "active"
+	case "active", "proposed":
+	default:
+		return nil, fmt.Errorf("invalid initial status %q; must be active or proposed", req.Status)
+	}
+
+	ns := resolveNamespace(ctx, w.namespace)
+	id := NamespacedID(DecisionID(req.Title, req.Rationale), ns)
 	now := time.Now().Unix()
 
 	decision := &tools.Decision{
@@ -95,25 +293,19 @@ func (w *Writer) StoreDecision(ctx context.Context, req tools.StoreDecisionReque
 		Context:            req.Context,
 		SourceAgent:        req.SourceAgent,
 		SourceConversation: req.SourceConversation,
-		Status:             "active",
+		Status:             req.Status,
 		CreatedAt:          now,
 		UpdatedAt:          now,
 	}
 
-	mutation := fmt.Sprintf(
-		`?[id, title, rationale, alternatives, context, source_agent, source_conversation, status, created_at, updated_at] <- [['%s', '%s', '%s', '%s', '%s', '%s', '%s', '%s', %d, %d]] :put mie_decision { id => title, rationale, alternatives, context, source_agent, source_conversation, status, created_at, updated_at }`,
-		escapeDatalog(decision.ID), escapeDatalog(decision.Title), escapeDatalog(decision.Rationale),
-		escapeDatalog(decision.Alternatives), escapeDatalog(decision.Context),
-		escapeDatalog(decision.SourceAgent), escapeDatalog(decision.SourceConversation),
-		escapeDatalog(decision.Status), decision.CreatedAt, decision.UpdatedAt,
-	)
-	if err := w.backend.Execute(ctx, mutation); err != nil {
+	if err := w.execute(ctx, putDecisionScript, decisionParams(decision, ns)); err != nil {
 		return nil, fmt.Errorf("store decision: %w", err)
 	}
 
 	if w.embedder != nil {
-		text := decision.Title + ". " + decision.Rationale
-		go w.storeEmbeddingAsync("mie_decision_embedding", "decision_id", decision.ID, text)
+		if err := w.enqueueEmbedding(ctx, "decision", decision.ID, decision.Title+". "+decision.Rationale); err != nil {
+			return nil, err
+		}
 	}
 
 	return decision, nil
@@ -124,11 +316,12 @@ func (w *Writer) StoreEntity(ctx context.Context, req tools.StoreEntityRequest)
 	if req.Name == "" {
 		return nil, fmt.Errorf("entity name is required")
 	}
-	if !isValidEntityKind(req.Kind) {
+	if !isValidEntityKind(req.Kind) && !slices.Contains(w.extraKinds, req.Kind) {
 		req.Kind = "other"
 	}
 
-	id := EntityID(req.Name, req.Kind)
+	ns := resolveNamespace(ctx, w.namespace)
+	id := NamespacedID(EntityID(req.Name, req.Kind), ns)
 	now := time.Now().Unix()
 
 	entity := &tools.Entity{
@@ -141,19 +334,14 @@ func (w *Writer) StoreEntity(ctx context.Context, req tools.StoreEntityRequest)
 		UpdatedAt:   now,
 	}
 
-	mutation := fmt.Sprintf(
-		`?[id, name, kind, description, source_agent, created_at, updated_at] <- [['%s', '%s', '%s', '%s', '%s', %d, %d]] :put mie_entity { id => name, kind, description, source_agent, created_at, updated_at }`,
-		escapeDatalog(entity.ID), escapeDatalog(entity.Name), escapeDatalog(entity.Kind),
-		escapeDatalog(entity.Description), escapeDatalog(entity.SourceAgent),
-		entity.CreatedAt, entity.UpdatedAt,
-	)
-	if err := w.backend.Execute(ctx, mutation); err != nil {
+	if err := w.execute(ctx, putEntityScript, entityParams(entity, ns)); err != nil {
 		return nil, fmt.Errorf("store entity: %w", err)
 	}
 
 	if w.embedder != nil {
-		text := entity.Name + ": " + entity.Description
-		go w.storeEmbeddingAsync("mie_entity_embedding", "entity_id", entity.ID, text)
+		if err := w.enqueueEmbedding(ctx, "entity", entity.ID, entity.Name+": "+entity.Description); err != nil {
+			return nil, err
+		}
 	}
 
 	return entity, nil
@@ -165,7 +353,8 @@ func (w *Writer) StoreEvent(ctx context.Context, req tools.StoreEventRequest) (*
 		return nil, fmt.Errorf("event title is required")
 	}
 
-	id := EventID(req.Title, req.EventDate)
+	ns := resolveNamespace(ctx, w.namespace)
+	id := NamespacedID(EventID(req.Title, req.EventDate), ns)
 	now := time.Now().Unix()
 
 	event := &tools.Event{
@@ -173,25 +362,24 @@ func (w *Writer) StoreEvent(ctx context.Context, req tools.StoreEventRequest) (*
 		Title:              req.Title,
 		Description:        req.Description,
 		EventDate:          req.EventDate,
+		EndDate:            req.EndDate,
 		SourceAgent:        req.SourceAgent,
 		SourceConversation: req.SourceConversation,
 		CreatedAt:          now,
 		UpdatedAt:          now,
 	}
 
-	mutation := fmt.Sprintf(
-		`?[id, title, description, event_date, source_agent, source_conversation, created_at, updated_at] <- [['%s', '%s', '%s', '%s', '%s', '%s', %d, %d]] :put mie_event { id => title, description, event_date, source_agent, source_conversation, created_at, updated_at }`,
-		escapeDatalog(event.ID), escapeDatalog(event.Title), escapeDatalog(event.Description),
-		escapeDatalog(event.EventDate), escapeDatalog(event.SourceAgent),
-		escapeDatalog(event.SourceConversation), event.CreatedAt, event.UpdatedAt,
-	)
-	if err := w.backend.Execute(ctx, mutation); err != nil {
+	if err := w.execute(ctx, putEventScript, eventParams(event, ns)); err != nil {
 		return nil, fmt.Errorf("store event: %w", err)
 	}
+	if err := w.putEventEnd(ctx, event); err != nil {
+		return nil, err
+	}
 
 	if w.embedder != nil {
-		text := event.Title + ". " + event.Description
-		go w.storeEmbeddingAsync("mie_event_embedding", "event_id", event.ID, text)
+		if err := w.enqueueEmbedding(ctx, "event", event.ID, event.Title+". "+event.Description); err != nil {
+			return nil, err
+		}
 	}
 
 	return event, nil
@@ -203,7 +391,8 @@ func (w *Writer) StoreTopic(ctx context.Context, req tools.StoreTopicRequest) (*
 		return nil, fmt.Errorf("topic name is required")
 	}
 
-	id := TopicID(req.Name)
+	ns := resolveNamespace(ctx, w.namespace)
+	id := NamespacedID(TopicID(req.Name), ns)
 	now := time.Now().Unix()
 
 	topic := &tools.Topic{
@@ -214,12 +403,7 @@ func (w *Writer) StoreTopic(ctx context.Context, req tools.StoreTopicRequest) (*
 		UpdatedAt:   now,
 	}
 
-	mutation := fmt.Sprintf(
-		`?[id, name, description, created_at, updated_at] <- [['%s', '%s', '%s', %d, %d]] :put mie_topic { id => name, description, created_at, updated_at }`,
-		escapeDatalog(topic.ID), escapeDatalog(topic.Name), escapeDatalog(topic.Description),
-		topic.CreatedAt, topic.UpdatedAt,
-	)
-	if err := w.backend.Execute(ctx, mutation); err != nil {
+	if err := w.execute(ctx, putTopicScript, topicParams(topic, ns)); err != nil {
 		return nil, fmt.Errorf("store topic: %w", err)
 	}
 
@@ -232,84 +416,608 @@ func (w *Writer) InvalidateFact(ctx context.Context, oldFactID, newFactID, reaso
 		return fmt.Errorf("both old and new fact IDs are required")
 	}
 
+	wasValid, exists, err := w.currentValue(ctx, "mie_fact", "valid", oldFactID)
+	if err != nil {
+		return err
+	}
 	now := time.Now().Unix()
 
 	// Mark the old fact as invalid by reading its current data and updating
-	mutation := fmt.Sprintf(
-		`?[id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at] :=
-    *mie_fact { id, content, category, confidence, source_agent, source_conversation, created_at },
-    id = '%s',
+	mutation := `?[id, content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at, namespace] :=
+    *mie_fact { id, content, category, confidence, source_agent, source_conversation, created_at, namespace },
+    id = $id,
     valid = false,
-    updated_at = %d
-:put mie_fact { id => content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at }`,
-		escapeDatalog(oldFactID), now,
-	)
-	if err := w.backend.Execute(ctx, mutation); err != nil {
+    updated_at = $now
+:put mie_fact { id => content, category, confidence, source_agent, source_conversation, valid, created_at, updated_at, namespace }`
+	if err := w.execute(ctx, mutation, map[string]any{"id": oldFactID, "now": now}); err != nil {
 		return fmt.Errorf("invalidate fact %s: %w", oldFactID, err)
 	}
 
 	// Record the invalidation edge
-	edgeMutation := fmt.Sprintf(
-		`?[new_fact_id, old_fact_id, reason] <- [['%s', '%s', '%s']] :put mie_invalidates { new_fact_id, old_fact_id => reason }`,
-		escapeDatalog(newFactID), escapeDatalog(oldFactID), escapeDatalog(reason),
-	)
-	if err := w.backend.Execute(ctx, edgeMutation); err != nil {
+	edgeMutation := `?[new_fact_id, old_fact_id, reason, created_at] <- [[$new_fact_id, $old_fact_id, $reason, $now]] :put mie_invalidates { new_fact_id, old_fact_id => reason, created_at }`
+	edgeParams := map[string]any{"new_fact_id": newFactID, "old_fact_id": oldFactID, "reason": reason, "now": now}
+	if err := w.execute(ctx, edgeMutation, edgeParams); err != nil {
 		return fmt.Errorf("record invalidation edge: %w", err)
 	}
 
+	if exists && wasValid == "true" {
+		return w.recordHistory(ctx, historyChange{
+			nodeID: oldFactID, field: "valid", oldValue: "true", newValue: "false",
+			reason: reason, relatedID: newFactID,
+		})
+	}
+
 	return nil
 }
 
 // AddRelationship creates an edge between two nodes in the memory graph.
+// Besides the key columns and value columns such as role, fields may carry
+// "weight" (greater than 0 and at most 1, default 1), "source_agent", and
+// "created_at" (Unix seconds, default now). Re-adding an edge overwrites it.
 func (w *Writer) AddRelationship(ctx context.Context, edgeType string, fields map[string]string) error {
 	cols, ok := ValidEdgeTables[edgeType]
 	if !ok {
 		return fmt.Errorf("unknown edge type: %s", edgeType)
 	}
 
-	// Build column values
+	// Build column values, each bound to the parameter named after its column
 	var colNames []string
-	var colValues []string
+	params := map[string]any{}
 	for _, col := range cols {
 		val, exists := fields[col]
 		if !exists {
 			return fmt.Errorf("missing required field %q for edge type %s", col, edgeType)
 		}
 		colNames = append(colNames, col)
-		colValues = append(colValues, fmt.Sprintf(`'%s'`, escapeDatalog(val)))
+		params[col] = val
+	}
+
+	weight := 1.0
+	if v := fields["weight"]; v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f <= 0 || f > 1 {
+			return fmt.Errorf("invalid weight %q: must be greater than 0 and at most 1", v)
+		}
+		weight = f
 	}
+	createdAt := time.Now().Unix()
+	if v := fields["created_at"]; v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid created_at %q: %w", v, err)
+		}
+		createdAt = n
+	}
+	colNames = append(colNames, "weight", "source_agent", "created_at")
+	params["weight"] = weight
+	params["source_agent"] = fields["source_agent"]
+	params["created_at"] = createdAt
 
 	// Handle optional value columns (like role for mie_decision_entity, reason for mie_invalidates)
 	for k, v := range fields {
-		found := false
-		for _, col := range cols {
-			if col == k {
-				found = true
-				break
-			}
-		}
-		if !found {
-			colNames = append(colNames, k)
-			colValues = append(colValues, fmt.Sprintf(`'%s'`, escapeDatalog(v)))
+		if slices.Contains(cols, k) || slices.Contains(edgeMetadataColumns, k) {
+			continue
 		}
+		colNames = append(colNames, k)
+		params[k] = v
 	}
 
 	mutation := fmt.Sprintf(
 		`?[%s] <- [[%s]] :put %s { %s }`,
 		joinStrings(colNames, ", "),
-		joinStrings(colValues, ", "),
+		paramList(colNames),
 		edgeType,
 		joinStrings(colNames, ", "),
 	)
 
-	if err := w.backend.Execute(ctx, mutation); err != nil {
+	if err := w.execute(ctx, mutation, params); err != nil {
 		return fmt.Errorf("add relationship %s: %w", edgeType, err)
 	}
 
 	return nil
 }
 
-// UpdateDescription updates the description of a node.
+// RemoveRelationship deletes an edge between two nodes. Only the key columns
+// of the edge table are used; value columns such as role are ignored.
+func (w *Writer) RemoveRelationship(ctx context.Context, edgeType string, fields map[string]string) error {
+	cols, ok := ValidEdgeTables[edgeType]
+	if !ok {
+		return fmt.Errorf("unknown edge type: %s", edgeType)
+	}
+
+	params := map[string]any{}
+	for _, col := range cols {
+		val, exists := fields[col]
+		if !exists {
+			return fmt.Errorf("missing required field %q for edge type %s", col, edgeType)
+		}
+		params[col] = val
+	}
+
+	mutation := fmt.Sprintf(
+		`?[%s] <- [[%s]] :rm %s { %s }`,
+		joinStrings(cols, ", "),
+		paramList(cols),
+		edgeType,
+		joinStrings(cols, ", "),
+	)
+
+	if err := w.backend.Execute(ctx, mutation, params); err != nil {
+		return fmt.Errorf("remove relationship %s: %w", edgeType, err)
+	}
+
+	return nil
+}
+
+// AddAlias records alias as an alternative name for an existing entity.
+// Aliases are matched case-insensitively and are scoped to the namespace.
+// An alias that already points to a different entity is rejected.
+func (w *Writer) AddAlias(ctx context.Context, entityID, alias string) error {
+	alias = strings.ToLower(strings.TrimSpace(alias))
+	if alias == "" {
+		return fmt.Errorf("alias is required")
+	}
+	if !strings.HasPrefix(entityID, "ent:") {
+		return fmt.Errorf("alias requires an entity ID (prefix 'ent:'), got %q", entityID)
+	}
+
+	exists, err := w.backend.Query(ctx, `?[id] := *mie_entity { id }, id = $id`, map[string]any{"id": entityID})
+	if err != nil {
+		return fmt.Errorf("look up entity: %w", err)
+	}
+	if len(exists.Rows) == 0 {
+		return fmt.Errorf("entity %q not found", entityID)
+	}
+
+	ns := resolveNamespace(ctx, w.namespace)
+	params := map[string]any{"alias": alias, "namespace": ns, "entity_id": entityID, "created_at": time.Now().Unix()}
+	current, err := w.backend.Query(ctx,
+		`?[entity_id] := *mie_entity_alias { alias, namespace, entity_id }, alias = $alias, namespace = $namespace`, params)
+	if err != nil {
+		return fmt.Errorf("look up alias: %w", err)
+	}
+	if len(current.Rows) > 0 {
+		if existing := toString(current.Rows[0][0]); existing != entityID {
+			return fmt.Errorf("alias %q already refers to %s", alias, existing)
+		}
+	}
+
+	if err := w.backend.Execute(ctx, putAliasScript, params); err != nil {
+		return fmt.Errorf("add alias: %w", err)
+	}
+
+	return nil
+}
+
+// RenameEntity changes the name of an entity. The entity keeps its ID, its
+// previous name becomes an alias so lookups by it still resolve, and the
+// entity is re-embedded from its new name, all in one transaction. The
+// change is recorded in the node history with the reason set by
+// tools.WithChangeReason. A name another entity of the namespace has, as
+// name or alias, is rejected.
+func (w *Writer) RenameEntity(ctx context.Context, entityID, newName string) error {
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return fmt.Errorf("new name is required")
+	}
+	if !strings.HasPrefix(entityID, "ent:") {
+		return fmt.Errorf("rename requires an entity ID (prefix 'ent:'), got %q", entityID)
+	}
+	oldName, exists, err := w.currentValue(ctx, "mie_entity", "name", entityID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("entity %q not found", entityID)
+	}
+	if oldName == newName {
+		return nil
+	}
+
+	ns := resolveNamespace(ctx, w.namespace)
+	params := map[string]any{"id": entityID, "name": newName, "ns": ns}
+	taken, err := w.backend.Query(ctx, fmt.Sprintf(
+		`?[id] := *mie_entity { id, name, namespace }, namespace = $ns, id != $id, %s == %s
+?[id] := *mie_entity_alias { alias, namespace, entity_id: id }, namespace = $ns, id != $id, %s == %s
+:limit 1`, foldExpr("name"), foldExpr("$name"), foldExpr("alias"), foldExpr("$name")), params)
+	if err != nil {
+		return fmt.Errorf("look up name: %w", err)
+	}
+	if len(taken.Rows) > 0 {
+		return fmt.Errorf("%q already names %s; merge the entities instead", newName, toString(taken.Rows[0][0]))
+	}
+	alias := strings.ToLower(oldName)
+	aliased, err := w.backend.Query(ctx,
+		`?[entity_id] := *mie_entity_alias { alias, namespace, entity_id }, alias = $alias, namespace = $ns, entity_id != $id`,
+		map[string]any{"alias": alias, "ns": ns, "id": entityID})
+	if err != nil {
+		return fmt.Errorf("look up alias: %w", err)
+	}
+	if len(aliased.Rows) > 0 {
+		return fmt.Errorf("alias %q already refers to %s", alias, toString(aliased.Rows[0][0]))
+	}
+
+	mutation := `?[id, name, kind, description, source_agent, created_at, updated_at, namespace] :=
+    *mie_entity { id, kind, description, source_agent, created_at, namespace },
+    id = $id,
+    name = $name,
+    updated_at = $now
+:put mie_entity { id => name, kind, description, source_agent, created_at, updated_at, namespace }`
+	now := time.Now().Unix()
+	return w.Atomic(ctx, func(ctx context.Context) error {
+		if err := w.execute(ctx, mutation, map[string]any{"id": entityID, "name": newName, "now": now}); err != nil {
+			return fmt.Errorf("rename entity: %w", err)
+		}
+		// A change of case only needs no alias: names match case-insensitively.
+		if !strings.EqualFold(oldName, newName) {
+			aliasParams := map[string]any{"alias": alias, "namespace": ns, "entity_id": entityID, "created_at": now}
+			if err := w.execute(ctx, putAliasScript, aliasParams); err != nil {
+				return fmt.Errorf("add alias: %w", err)
+			}
+		}
+		if err := w.recordHistory(ctx, historyChange{
+			nodeID: entityID, field: "name", oldValue: oldName, newValue: newName,
+			reason: tools.ChangeReasonFromContext(ctx),
+		}); err != nil {
+			return err
+		}
+		return w.reembed(ctx, "entity", entityID, map[string]string{"name": newName})
+	})
+}
+
+// SetArchived archives or unarchives a node. Archived nodes keep their data
+// and relationships but are left out of search and list results unless the
+// caller asks for them.
+func (w *Writer) SetArchived(ctx context.Context, nodeID string, archived bool) error {
+	nodeType, err := w.detectNodeType(ctx, nodeID)
+	if err != nil {
+		return err
+	}
+
+	params := map[string]any{"id": nodeID, "ns": resolveNamespace(ctx, w.namespace)}
+	exists, err := w.backend.Query(ctx, fmt.Sprintf(
+		`?[id] := *%s { id, namespace }, id = $id, namespace = $ns`, nodeTypeToTable(nodeType)), params)
+	if err != nil {
+		return fmt.Errorf("look up node: %w", err)
+	}
+	if len(exists.Rows) == 0 {
+		return fmt.Errorf("node %q not found", nodeID)
+	}
+
+	mutation := `?[node_id] <- [[$id]] :rm mie_archived { node_id }`
+	if archived {
+		mutation = `?[node_id, archived_at] <- [[$id, $now]] :put mie_archived { node_id => archived_at }`
+		params["now"] = time.Now().Unix()
+	}
+	if err := w.backend.Execute(ctx, mutation, params); err != nil {
+		return fmt.Errorf("set archived: %w", err)
+	}
+
+	return nil
+}
+
+// SetVerified marks a fact as confirmed by verifiedBy, or clears the mark.
+// Verifying an already verified fact records the new verifier and time.
+func (w *Writer) SetVerified(ctx context.Context, factID, verifiedBy string, verified bool) error {
+	params := map[string]any{"id": factID, "ns": resolveNamespace(ctx, w.namespace)}
+	exists, err := w.backend.Query(ctx, `?[id] := *mie_fact { id, namespace }, id = $id, namespace = $ns`, params)
+	if err != nil {
+		return fmt.Errorf("look up fact: %w", err)
+	}
+	if len(exists.Rows) == 0 {
+		return fmt.Errorf("fact %q not found", factID)
+	}
+
+	mutation := `?[fact_id] <- [[$id]] :rm mie_fact_verification { fact_id }`
+	if verified {
+		if verifiedBy == "" {
+			return fmt.Errorf("verified_by is required to verify a fact")
+		}
+		mutation = `?[fact_id, verified_by, verified_at] <- [[$id, $verified_by, $now]] :put mie_fact_verification { fact_id => verified_by, verified_at }`
+		params["verified_by"] = verifiedBy
+		params["now"] = time.Now().Unix()
+	}
+	if err := w.backend.Execute(ctx, mutation, params); err != nil {
+		return fmt.Errorf("set verified: %w", err)
+	}
+
+	return nil
+}
+
+// MergeEntities folds the duplicate entity into the survivor. Fact, decision
+// and topic edges of the duplicate are moved to the survivor, aliases that
+// pointed at the duplicate are repointed, the duplicate's name is recorded as
+// an alias of the survivor, and the duplicate, its embedding and its archive
+// state are deleted.
+// All changes run as one CozoDB transaction.
+func (w *Writer) MergeEntities(ctx context.Context, survivorID, duplicateID string) error {
+	if survivorID == duplicateID {
+		return fmt.Errorf("cannot merge entity %q into itself", survivorID)
+	}
+	for _, id := range []string{survivorID, duplicateID} {
+		if !strings.HasPrefix(id, "ent:") {
+			return fmt.Errorf("merge requires entity IDs (prefix 'ent:'), got %q", id)
+		}
+	}
+
+	names := map[string]string{}
+	for _, id := range []string{survivorID, duplicateID} {
+		result, err := w.backend.Query(ctx, `?[name] := *mie_entity { id, name }, id = $id`, map[string]any{"id": id})
+		if err != nil {
+			return fmt.Errorf("look up entity: %w", err)
+		}
+		if len(result.Rows) == 0 {
+			return fmt.Errorf("entity %q not found", id)
+		}
+		names[id] = toString(result.Rows[0][0])
+	}
+
+	params := map[string]any{
+		"survivor":  survivorID,
+		"duplicate": duplicateID,
+		"alias":     strings.ToLower(strings.TrimSpace(names[duplicateID])),
+		"ns":        resolveNamespace(ctx, w.namespace),
+		"now":       time.Now().Unix(),
+	}
+
+	script := `{
+    ?[fact_id, entity_id, weight, source_agent, created_at] := *mie_fact_entity { fact_id, entity_id: old, weight, source_agent, created_at }, old = $duplicate, entity_id = $survivor
+    :put mie_fact_entity { fact_id, entity_id => weight, source_agent, created_at }
+}
+{
+    ?[fact_id, entity_id] := *mie_fact_entity { fact_id, entity_id }, entity_id = $duplicate
+    :rm mie_fact_entity { fact_id, entity_id }
+}
+{
+    ?[decision_id, entity_id, role, weight, source_agent, created_at] := *mie_decision_entity { decision_id, entity_id: old, role, weight, source_agent, created_at }, old = $duplicate, entity_id = $survivor
+    :put mie_decision_entity { decision_id, entity_id => role, weight, source_agent, created_at }
+}
+{
+    ?[decision_id, entity_id] := *mie_decision_entity { decision_id, entity_id }, entity_id = $duplicate
+    :rm mie_decision_entity { decision_id, entity_id }
+}
+{
+    ?[entity_id, topic_id, weight, source_agent, created_at] := *mie_entity_topic { entity_id: old, topic_id, weight, source_agent, created_at }, old = $duplicate, entity_id = $survivor
+    :put mie_entity_topic { entity_id, topic_id => weight, source_agent, created_at }
+}
+{
+    ?[entity_id, topic_id] := *mie_entity_topic { entity_id, topic_id }, entity_id = $duplicate
+    :rm mie_entity_topic { entity_id, topic_id }
+}
+{
+    ?[alias, namespace, entity_id, created_at] := *mie_entity_alias { alias, namespace, entity_id: old, created_at }, old = $duplicate, entity_id = $survivor
+    :put mie_entity_alias { alias, namespace => entity_id, created_at }
+}
+{
+    ?[alias, namespace, entity_id, created_at] <- [[$alias, $ns, $survivor, $now]]
+    :put mie_entity_alias { alias, namespace => entity_id, created_at }
+}
+{
+    ?[entity_id] <- [[$duplicate]]
+    :rm mie_entity_embedding { entity_id }
+}
+{
+    ?[node_id] <- [[$duplicate]]
+    :rm mie_archived { node_id }
+}
+{
+    ?[id] <- [[$duplicate]]
+    :rm mie_entity { id }
+}`
+
+	if err := w.backend.Execute(ctx, script, params); err != nil {
+		return fmt.Errorf("merge entities: %w", err)
+	}
+	if w.vectors != nil {
+		if err := w.vectors.Delete(ctx, "entity", duplicateID); err != nil {
+			w.logger.Warn("failed to remove vector", "node_id", duplicateID, "type", "entity", "error", err)
+		}
+	}
+
+	return nil
+}
+
+// ImportGraph writes exported nodes, relationships, aliases, and archive
+// state into the current namespace, preserving node IDs, timestamps, fact
+// validity, and decision status. When the export comes from a different
+// namespace, IDs are remapped with NamespacedID so they match what storing
+// the same content in this namespace would produce, and edges are remapped
+// consistently. Nodes without an ID get the ID their Store method would
+// assign. It returns the
+// number of rows written per kind, keyed like ExportData.Stats.
+func (w *Writer) ImportGraph(ctx context.Context, data *tools.ExportData) (map[string]int, error) {
+	ns := resolveNamespace(ctx, w.namespace)
+	source := data.Namespace
+	if source == "" {
+		source = tools.DefaultNamespace
+	}
+	remap := func(id string) string {
+		if source == ns {
+			return id
+		}
+		return NamespacedID(id, ns)
+	}
+	stamps := func(created, updated int64) (int64, int64) {
+		if created == 0 {
+			created = time.Now().Unix()
+		}
+		if updated == 0 {
+			updated = created
+		}
+		return created, updated
+	}
+
+	counts := map[string]int{}
+
+	for _, f := range data.Facts {
+		id := f.ID
+		if id == "" {
+			id = importedFactID(f)
+		}
+		id = remap(id)
+		f.ID = id
+		f.CreatedAt, f.UpdatedAt = stamps(f.CreatedAt, f.UpdatedAt)
+		if err := w.backend.Execute(ctx, putFactScript, factParams(&f, ns)); err != nil {
+			return counts, fmt.Errorf("import fact %s: %w", id, err)
+		}
+		counts["facts"]++
+		if f.Verified {
+			verification := `?[fact_id, verified_by, verified_at] <- [[$fact_id, $verified_by, $verified_at]] :put mie_fact_verification { fact_id => verified_by, verified_at }`
+			params := map[string]any{"fact_id": id, "verified_by": f.VerifiedBy, "verified_at": f.VerifiedAt}
+			if err := w.backend.Execute(ctx, verification, params); err != nil {
+				return counts, fmt.Errorf("import fact verification %s: %w", id, err)
+			}
+			counts["verified"]++
+		}
+		if f.ExpiresAt > 0 {
+			if err := w.putExpiry(ctx, id, f.ExpiresAt); err != nil {
+				return counts, err
+			}
+		}
+		if f.Key != "" {
+			if err := w.putKey(ctx, id, PreferenceKey(f.Key)); err != nil {
+				return counts, err
+			}
+		}
+		if f.Negated {
+			if err := w.putNegation(ctx, id); err != nil {
+				return counts, err
+			}
+		}
+		if w.embedder != nil {
+			if err := w.enqueueEmbedding(ctx, "fact", id, f.Content); err != nil {
+				return counts, err
+			}
+		}
+	}
+
+	for _, d := range data.Decisions {
+		id := d.ID
+		if id == "" {
+			id = DecisionID(d.Title, d.Rationale)
+		}
+		id = remap(id)
+		d.ID = id
+		if !isValidDecisionStatus(d.Status) {
+			d.Status = "active"
+		}
+		d.CreatedAt, d.UpdatedAt = stamps(d.CreatedAt, d.UpdatedAt)
+		if err := w.backend.Execute(ctx, putDecisionScript, decisionParams(&d, ns)); err != nil {
+			return counts, fmt.Errorf("import decision %s: %w", id, err)
+		}
+		counts["decisions"]++
+		if w.embedder != nil {
+			if err := w.enqueueEmbedding(ctx, "decision", id, d.Title+". "+d.Rationale); err != nil {
+				return counts, err
+			}
+		}
+	}
+
+	for _, e := range data.Entities {
+		id := e.ID
+		if id == "" {
+			id = EntityID(e.Name, e.Kind)
+		}
+		id = remap(id)
+		e.ID = id
+		e.CreatedAt, e.UpdatedAt = stamps(e.CreatedAt, e.UpdatedAt)
+		if err := w.backend.Execute(ctx, putEntityScript, entityParams(&e, ns)); err != nil {
+			return counts, fmt.Errorf("import entity %s: %w", id, err)
+		}
+		counts["entities"]++
+		if w.embedder != nil {
+			if err := w.enqueueEmbedding(ctx, "entity", id, e.Name+": "+e.Description); err != nil {
+				return counts, err
+			}
+		}
+	}
+
+	for _, ev := range data.Events {
+		id := ev.ID
+		if id == "" {
+			id = EventID(ev.Title, ev.EventDate)
+		}
+		id = remap(id)
+		ev.ID = id
+		ev.CreatedAt, ev.UpdatedAt = stamps(ev.CreatedAt, ev.UpdatedAt)
+		if err := w.backend.Execute(ctx, putEventScript, eventParams(&ev, ns)); err != nil {
+			return counts, fmt.Errorf("import event %s: %w", id, err)
+		}
+		if err := w.putEventEnd(ctx, &ev); err != nil {
+			return counts, err
+		}
+		counts["events"]++
+		if w.embedder != nil {
+			if err := w.enqueueEmbedding(ctx, "event", id, ev.Title+". "+ev.Description); err != nil {
+				return counts, err
+			}
+		}
+	}
+
+	for _, tp := range data.Topics {
+		id := tp.ID
+		if id == "" {
+			id = TopicID(tp.Name)
+		}
+		id = remap(id)
+		tp.ID = id
+		tp.CreatedAt, tp.UpdatedAt = stamps(tp.CreatedAt, tp.UpdatedAt)
+		if err := w.backend.Execute(ctx, putTopicScript, topicParams(&tp, ns)); err != nil {
+			return counts, fmt.Errorf("import topic %s: %w", id, err)
+		}
+		counts["topics"]++
+	}
+
+	for edgeType, rows := range data.Edges {
+		table := "mie_" + edgeType
+		keyCols, ok := ValidEdgeTables[table]
+		if !ok {
+			return counts, fmt.Errorf("import: unknown relationship type %q", edgeType)
+		}
+		for _, row := range rows {
+			fields := make(map[string]string, len(row))
+			for col, val := range row {
+				fields[col] = val
+			}
+			for _, col := range keyCols {
+				fields[col] = remap(fields[col])
+			}
+			if err := w.AddRelationship(ctx, table, fields); err != nil {
+				return counts, fmt.Errorf("import relationship: %w", err)
+			}
+			counts["relationships"]++
+		}
+	}
+
+	for _, a := range data.Aliases {
+		alias := strings.ToLower(strings.TrimSpace(a.Alias))
+		if alias == "" || a.EntityID == "" {
+			continue
+		}
+		params := map[string]any{"alias": alias, "namespace": ns, "entity_id": remap(a.EntityID), "created_at": time.Now().Unix()}
+		if err := w.backend.Execute(ctx, putAliasScript, params); err != nil {
+			return counts, fmt.Errorf("import alias %q: %w", alias, err)
+		}
+		counts["aliases"]++
+	}
+
+	for _, nodeID := range data.Archived {
+		if nodeID == "" {
+			continue
+		}
+		mutation := `?[node_id, archived_at] <- [[$node_id, $archived_at]] :put mie_archived { node_id => archived_at }`
+		params := map[string]any{"node_id": remap(nodeID), "archived_at": time.Now().Unix()}
+		if err := w.backend.Execute(ctx, mutation, params); err != nil {
+			return counts, fmt.Errorf("import archived node %s: %w", nodeID, err)
+		}
+		counts["archived"]++
+	}
+
+	return counts, nil
+}
+
+// UpdateDescription updates the description of a node. Entities and events
+// are re-embedded from their new text in the same transaction.
 func (w *Writer) UpdateDescription(ctx context.Context, nodeID, newDescription string) error {
 	nodeType, err := w.detectNodeType(ctx, nodeID)
 	if err != nil {
@@ -322,86 +1030,200 @@ func (w *Writer) UpdateDescription(ctx context.Context, nodeID, newDescription s
 	switch nodeType {
 	case "entity":
 		mutation = fmt.Sprintf(
-			`?[id, name, kind, description, source_agent, created_at, updated_at] :=
-    *mie_entity { id, name, kind, source_agent, created_at },
-    id = '%s',
-    description = '%s',
-    updated_at = %d
-:put mie_entity { id => name, kind, description, source_agent, created_at, updated_at }`,
-			escapeDatalog(nodeID), escapeDatalog(newDescription), now,
+			`?[id, name, kind, description, source_agent, created_at, updated_at, namespace] :=
+    *mie_entity { id, name, kind, source_agent, created_at, namespace },
+    id = $id,
+    description = $description,
+    updated_at = $now
+:put mie_entity { id => name, kind, description, source_agent, created_at, updated_at, namespace }`,
 		)
 	case "event":
 		mutation = fmt.Sprintf(
-			`?[id, title, description, event_date, source_agent, source_conversation, created_at, updated_at] :=
-    *mie_event { id, title, event_date, source_agent, source_conversation, created_at },
-    id = '%s',
-    description = '%s',
-    updated_at = %d
-:put mie_event { id => title, description, event_date, source_agent, source_conversation, created_at, updated_at }`,
-			escapeDatalog(nodeID), escapeDatalog(newDescription), now,
+			`?[id, title, description, event_date, source_agent, source_conversation, created_at, updated_at, namespace] :=
+    *mie_event { id, title, event_date, source_agent, source_conversation, created_at, namespace },
+    id = $id,
+    description = $description,
+    updated_at = $now
+:put mie_event { id => title, description, event_date, source_agent, source_conversation, created_at, updated_at, namespace }`,
 		)
 	case "topic":
 		mutation = fmt.Sprintf(
-			`?[id, name, description, created_at, updated_at] :=
-    *mie_topic { id, name, created_at },
-    id = '%s',
-    description = '%s',
-    updated_at = %d
-:put mie_topic { id => name, description, created_at, updated_at }`,
-			escapeDatalog(nodeID), escapeDatalog(newDescription), now,
+			`?[id, name, description, created_at, updated_at, namespace] :=
+    *mie_topic { id, name, created_at, namespace },
+    id = $id,
+    description = $description,
+    updated_at = $now
+:put mie_topic { id => name, description, created_at, updated_at, namespace }`,
 		)
 	default:
 		return fmt.Errorf("node type %q does not support description update", nodeType)
 	}
 
-	if err := w.backend.Execute(ctx, mutation); err != nil {
-		return fmt.Errorf("update description: %w", err)
+	oldDescription, exists, err := w.currentValue(ctx, nodeTypeToTable(nodeType), "description", nodeID)
+	if err != nil {
+		return err
+	}
+	params := map[string]any{"id": nodeID, "description": newDescription, "now": now}
+	err = w.Atomic(ctx, func(ctx context.Context) error {
+		if err := w.execute(ctx, mutation, params); err != nil {
+			return fmt.Errorf("update description: %w", err)
+		}
+		if !exists {
+			return nil
+		}
+		return w.reembed(ctx, nodeType, nodeID, map[string]string{"description": newDescription})
+	})
+	if err != nil {
+		return err
+	}
+	if exists && oldDescription != newDescription {
+		return w.recordHistory(ctx, historyChange{
+			nodeID: nodeID, field: "description", oldValue: oldDescription, newValue: newDescription,
+			reason: tools.ChangeReasonFromContext(ctx),
+		})
 	}
 
 	return nil
 }
 
-// UpdateStatus updates the status of a decision node.
+// UpdateStatus updates the status of a decision node. The change must be
+// allowed by DecisionStatusTransitions. It is recorded in the node history
+// with the reason set by tools.WithChangeReason.
 func (w *Writer) UpdateStatus(ctx context.Context, nodeID, newStatus string) error {
 	if !isValidDecisionStatus(newStatus) {
-		return fmt.Errorf("invalid status %q; must be one of: active, superseded, reversed", newStatus)
+		return fmt.Errorf("invalid status %q; must be one of: %s", newStatus, strings.Join(ValidDecisionStatuses, ", "))
 	}
 
 	now := time.Now().Unix()
 
-	mutation := fmt.Sprintf(
-		`?[id, title, rationale, alternatives, context, source_agent, source_conversation, status, created_at, updated_at] :=
-    *mie_decision { id, title, rationale, alternatives, context, source_agent, source_conversation, created_at },
-    id = '%s',
-    status = '%s',
-    updated_at = %d
-:put mie_decision { id => title, rationale, alternatives, context, source_agent, source_conversation, status, created_at, updated_at }`,
-		escapeDatalog(nodeID), escapeDatalog(newStatus), now,
-	)
+	mutation := `?[id, title, rationale, alternatives, context, source_agent, source_conversation, status, created_at, updated_at, namespace] :=
+    *mie_decision { id, title, rationale, alternatives, context, source_agent, source_conversation, created_at, namespace },
+    id = $id,
+    status = $status,
+    updated_at = $now
+:put mie_decision { id => title, rationale, alternatives, context, source_agent, source_conversation, status, created_at, updated_at, namespace }`
 
-	if err := w.backend.Execute(ctx, mutation); err != nil {
+	oldStatus, exists, err := w.currentValue(ctx, "mie_decision", "status", nodeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		if err := CheckDecisionTransition(oldStatus, newStatus); err != nil {
+			return err
+		}
+	}
+	params := map[string]any{"id": nodeID, "status": newStatus, "now": now}
+	if err := w.backend.Execute(ctx, mutation, params); err != nil {
 		return fmt.Errorf("update status: %w", err)
 	}
+	if exists && oldStatus != newStatus {
+		return w.recordHistory(ctx, historyChange{
+			nodeID: nodeID, field: "status", oldValue: oldStatus, newValue: newStatus,
+			reason: tools.ChangeReasonFromContext(ctx),
+		})
+	}
 
 	return nil
 }
 
-// storeEmbeddingAsync generates and stores an embedding in the background.
-func (w *Writer) storeEmbeddingAsync(table, idCol, nodeID, text string) {
-	ctx := context.Background()
+// putEmbedding writes a node's embedding vector to its embedding table and
+// to the configured vector index, if any. Failures are remembered for
+// Client.GetEmbeddingStatus.
+func (w *Writer) putEmbedding(ctx context.Context, nodeType, nodeID string, embedding []float32) error {
+	err := (&hnswIndex{backend: w.backend}).Add(ctx, nodeType, nodeID, embedding)
+	if err == nil && w.vectors != nil {
+		err = w.vectors.Add(ctx, nodeType, nodeID, embedding)
+	}
+	if err != nil {
+		w.storeErr.set(err)
+		return err
+	}
+	// The node can now be found semantically.
+	if w.searchCache != nil {
+		w.searchCache.invalidate()
+	}
+	return nil
+}
+
+// embeddingLabelColumns maps the node types whose embedding text is a label
+// followed by the description to the label column and the separator, as
+// in embeddingTextRules.
+var embeddingLabelColumns = map[string][2]string{
+	"entity": {"name", ": "},
+	"event":  {"title", ". "},
+}
+
+// reembed replaces the embedding of a node whose columns change to the
+// values in changed, in the same transaction as the change when ctx carries
+// a write batch. The new embedding is generated right away, so the old one
+// never outlives the old text; if that fails, the node is queued for
+// embedding instead. Node types without embeddings are left alone.
+func (w *Writer) reembed(ctx context.Context, nodeType, nodeID string, changed map[string]string) error {
+	label, ok := embeddingLabelColumns[nodeType]
+	if !ok || w.embedder == nil {
+		return nil
+	}
+	var parts [2]string
+	for i, column := range []string{label[0], "description"} {
+		if v, ok := changed[column]; ok {
+			parts[i] = v
+			continue
+		}
+		v, _, err := w.currentValue(ctx, nodeTypeToTable(nodeType), column, nodeID)
+		if err != nil {
+			return err
+		}
+		parts[i] = v
+	}
+	text := parts[0] + label[1] + parts[1]
+
 	embedding, err := w.embedder.Generate(ctx, text)
 	if err != nil {
-		w.logger.Warn("failed to generate embedding", "node_id", nodeID, "table", table, "error", err)
-		return
+		w.logger.Warn("failed to re-embed node, queuing it", "node_id", nodeID, "type", nodeType, "error", err)
+		return w.enqueueEmbedding(ctx, nodeType, nodeID, text)
+	}
+	params := map[string]any{"id": nodeID, "embedding": embedding}
+	if err := w.execute(ctx, putEmbeddingScript(nodeType, nodeTypeToEmbeddingTable(nodeType)), params); err != nil {
+		return fmt.Errorf("store embedding: %w", err)
+	}
+	// A queued embedding of the old text would overwrite the new one.
+	if err := w.execute(ctx, `?[node_id] <- [[$id]] :rm mie_embedding_queue { node_id }`, map[string]any{"id": nodeID}); err != nil {
+		return fmt.Errorf("update embedding queue: %w", err)
 	}
+	afterCommit(ctx, func() {
+		if w.vectors != nil {
+			if err := w.vectors.Add(ctx, nodeType, nodeID, embedding); err != nil {
+				w.storeErr.set(err)
+				w.logger.Warn("failed to update vector", "node_id", nodeID, "type", nodeType, "error", err)
+			}
+		}
+		if w.searchCache != nil {
+			w.searchCache.invalidate()
+		}
+	})
+	return nil
+}
 
-	vecStr := formatVector(embedding)
-	mutation := fmt.Sprintf(
-		`?[%s, embedding] <- [['%s', vec(%s)]] :put %s { %s => embedding }`,
-		idCol, escapeDatalog(nodeID), vecStr, table, idCol,
-	)
-	if err := w.backend.Execute(ctx, mutation); err != nil {
-		w.logger.Warn("failed to store embedding", "node_id", nodeID, "table", table, "error", err)
+// forgetVectors lists the nodes of nodeType returned by script, a query
+// with the node ID as its first column run with params, and returns a
+// function that removes them from the configured vector index. Call it
+// before the nodes are deleted and the returned function after. Without a
+// configured index the function does nothing.
+func (w *Writer) forgetVectors(ctx context.Context, nodeType, script string, params map[string]any) func() {
+	if w.vectors == nil {
+		return func() {}
+	}
+	qr, err := w.backend.Query(ctx, script, params)
+	if err != nil {
+		w.logger.Warn("failed to list vectors to remove", "type", nodeType, "error", err)
+		return func() {}
+	}
+	return func() {
+		for _, row := range qr.Rows {
+			if err := w.vectors.Delete(ctx, nodeType, toString(row[0])); err != nil {
+				w.logger.Warn("failed to remove vector", "node_id", toString(row[0]), "type", nodeType, "error", err)
+			}
+		}
 	}
 }
 
@@ -437,8 +1259,8 @@ func (w *Writer) detectNodeType(ctx context.Context, nodeID string) (string, err
 	}
 
 	for _, t := range tables {
-		query := fmt.Sprintf(`?[id] := *%s { id }, id = '%s'`, t.name, escapeDatalog(nodeID))
-		result, err := w.backend.Query(ctx, query)
+		query := fmt.Sprintf(`?[id] := *%s { id }, id = $id`, t.name)
+		result, err := w.backend.Query(ctx, query, map[string]any{"id": nodeID})
 		if err != nil {
 			continue
 		}
@@ -450,6 +1272,52 @@ func (w *Writer) detectNodeType(ctx context.Context, nodeID string) (string, err
 	return "", fmt.Errorf("node %q not found", nodeID)
 }
 
+// factParams returns the parameters of putFactScript for f in namespace ns.
+func factParams(f *tools.Fact, ns string) map[string]any {
+	return map[string]any{
+		"id": f.ID, "content": f.Content, "category": f.Category, "confidence": f.Confidence,
+		"source_agent": f.SourceAgent, "source_conversation": f.SourceConversation, "valid": f.Valid,
+		"created_at": f.CreatedAt, "updated_at": f.UpdatedAt, "namespace": ns,
+	}
+}
+
+// decisionParams returns the parameters of putDecisionScript for d in
+// namespace ns.
+func decisionParams(d *tools.Decision, ns string) map[string]any {
+	return map[string]any{
+		"id": d.ID, "title": d.Title, "rationale": d.Rationale, "alternatives": d.Alternatives,
+		"context": d.Context, "source_agent": d.SourceAgent, "source_conversation": d.SourceConversation,
+		"status": d.Status, "created_at": d.CreatedAt, "updated_at": d.UpdatedAt, "namespace": ns,
+	}
+}
+
+// entityParams returns the parameters of putEntityScript for e in namespace
+// ns.
+func entityParams(e *tools.Entity, ns string) map[string]any {
+	return map[string]any{
+		"id": e.ID, "name": e.Name, "kind": e.Kind, "description": e.Description,
+		"source_agent": e.SourceAgent, "created_at": e.CreatedAt, "updated_at": e.UpdatedAt, "namespace": ns,
+	}
+}
+
+// eventParams returns the parameters of putEventScript for ev in namespace
+// ns.
+func eventParams(ev *tools.Event, ns string) map[string]any {
+	return map[string]any{
+		"id": ev.ID, "title": ev.Title, "description": ev.Description, "event_date": ev.EventDate,
+		"source_agent": ev.SourceAgent, "source_conversation": ev.SourceConversation,
+		"created_at": ev.CreatedAt, "updated_at": ev.UpdatedAt, "namespace": ns,
+	}
+}
+
+// topicParams returns the parameters of putTopicScript for t in namespace ns.
+func topicParams(t *tools.Topic, ns string) map[string]any {
+	return map[string]any{
+		"id": t.ID, "name": t.Name, "description": t.Description,
+		"created_at": t.CreatedAt, "updated_at": t.UpdatedAt, "namespace": ns,
+	}
+}
+
 func joinStrings(ss []string, sep string) string {
 	result := ""
 	for i, s := range ss {
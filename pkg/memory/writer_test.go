@@ -8,6 +8,7 @@ package memory
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/kraklabs/mie/pkg/tools"
@@ -48,7 +49,7 @@ func TestWriterStoreFact(t *testing.T) {
 	}
 
 	// Verify it was written to DB
-	result, err := backend.Query(ctx, `?[id, content] := *mie_fact { id, content }`)
+	result, err := backend.Query(ctx, `?[id, content] := *mie_fact { id, content }`, nil)
 	if err != nil {
 		t.Fatalf("query failed: %v", err)
 	}
@@ -57,6 +58,57 @@ func TestWriterStoreFact(t *testing.T) {
 	}
 }
 
+func TestWriterStoreFactDedup(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	w.dedupThreshold = DefaultDedupThreshold
+	ctx := context.Background()
+
+	first, err := w.StoreFact(ctx, tools.StoreFactRequest{Content: "I live in Buenos Aires", Category: "personal"})
+	if err != nil {
+		t.Fatalf("StoreFact failed: %v", err)
+	}
+	if first.DuplicateSimilarity != 0 {
+		t.Errorf("first store should not be a duplicate, got similarity %f", first.DuplicateSimilarity)
+	}
+
+	// Case and surrounding whitespace are ignored, even across categories.
+	dup, err := w.StoreFact(ctx, tools.StoreFactRequest{Content: "  i live in buenos aires ", Category: "general"})
+	if err != nil {
+		t.Fatalf("StoreFact failed: %v", err)
+	}
+	if dup.ID != first.ID || dup.DuplicateSimilarity != 1.0 {
+		t.Errorf("expected duplicate of %s with similarity 1, got %s (%f)", first.ID, dup.ID, dup.DuplicateSimilarity)
+	}
+
+	// SkipDedup stores a new fact anyway.
+	forced, err := w.StoreFact(ctx, tools.StoreFactRequest{Content: "I LIVE IN BUENOS AIRES", Category: "general", SkipDedup: true})
+	if err != nil {
+		t.Fatalf("StoreFact failed: %v", err)
+	}
+	if forced.ID == first.ID || forced.DuplicateSimilarity != 0 {
+		t.Errorf("SkipDedup should store a new fact, got %s (%f)", forced.ID, forced.DuplicateSimilarity)
+	}
+
+	// Invalidated facts are not duplicates.
+	if err := w.InvalidateFact(ctx, first.ID, forced.ID, "test"); err != nil {
+		t.Fatalf("InvalidateFact failed: %v", err)
+	}
+	if err := w.InvalidateFact(ctx, forced.ID, first.ID, "test"); err != nil {
+		t.Fatalf("InvalidateFact failed: %v", err)
+	}
+	again, err := w.StoreFact(ctx, tools.StoreFactRequest{Content: "I live in Buenos Aires", Category: "general"})
+	if err != nil {
+		t.Fatalf("StoreFact failed: %v", err)
+	}
+	if again.DuplicateSimilarity != 0 {
+		t.Errorf("expected a new fact after invalidation, got duplicate %s", again.ID)
+	}
+}
+
 func TestWriterStoreFactValidation(t *testing.T) {
 	backend := newTestBackend(t)
 	defer backend.Close()
@@ -157,6 +209,33 @@ func TestWriterStoreEntity(t *testing.T) {
 	}
 }
 
+func TestWriterExtraKindsAndCategories(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	w.extraKinds = []string{"service"}
+	w.extraCategories = []string{"incident"}
+	ctx := context.Background()
+
+	entity, err := w.StoreEntity(ctx, tools.StoreEntityRequest{Name: "billing-api", Kind: "service"})
+	if err != nil {
+		t.Fatalf("StoreEntity failed: %v", err)
+	}
+	if entity.Kind != "service" {
+		t.Errorf("expected kind 'service', got %q", entity.Kind)
+	}
+
+	fact, err := w.StoreFact(ctx, tools.StoreFactRequest{Content: "Billing was down for an hour", Category: "incident"})
+	if err != nil {
+		t.Fatalf("StoreFact failed: %v", err)
+	}
+	if fact.Category != "incident" {
+		t.Errorf("expected category 'incident', got %q", fact.Category)
+	}
+}
+
 func TestWriterStoreEvent(t *testing.T) {
 	backend := newTestBackend(t)
 	defer backend.Close()
@@ -231,7 +310,7 @@ func TestWriterInvalidateFact(t *testing.T) {
 	}
 
 	// Verify old fact is now invalid
-	result, err := backend.Query(ctx, `?[valid] := *mie_fact { id, valid }, id = "`+escapeDatalog(oldFact.ID)+`"`)
+	result, err := backend.Query(ctx, `?[valid] := *mie_fact { id, valid }, id = $id`, map[string]any{"id": oldFact.ID})
 	if err != nil {
 		t.Fatalf("query failed: %v", err)
 	}
@@ -243,7 +322,7 @@ func TestWriterInvalidateFact(t *testing.T) {
 	}
 
 	// Verify invalidation edge exists
-	result, err = backend.Query(ctx, `?[reason] := *mie_invalidates { new_fact_id, old_fact_id, reason }`)
+	result, err = backend.Query(ctx, `?[reason] := *mie_invalidates { new_fact_id, old_fact_id, reason }`, nil)
 	if err != nil {
 		t.Fatalf("query failed: %v", err)
 	}
@@ -284,6 +363,488 @@ func TestWriterAddRelationship(t *testing.T) {
 	if err == nil {
 		t.Error("expected error for unknown edge type")
 	}
+
+	for _, weight := range []string{"0", "1.5", "heavy"} {
+		err = w.AddRelationship(ctx, "mie_fact_entity", map[string]string{
+			"fact_id":   fact.ID,
+			"entity_id": entity.ID,
+			"weight":    weight,
+		})
+		if err == nil {
+			t.Errorf("expected error for weight %q", weight)
+		}
+	}
+}
+
+func TestWriterRemoveRelationship(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	ctx := context.Background()
+
+	decision, _ := w.StoreDecision(ctx, tools.StoreDecisionRequest{
+		Title:     "Use CozoDB",
+		Rationale: "Datalog and vectors in one engine",
+	})
+	entity, _ := w.StoreEntity(ctx, tools.StoreEntityRequest{
+		Name: "CozoDB",
+		Kind: "technology",
+	})
+
+	fields := map[string]string{
+		"decision_id": decision.ID,
+		"entity_id":   entity.ID,
+		"role":        "subject",
+	}
+	if err := w.AddRelationship(ctx, "mie_decision_entity", fields); err != nil {
+		t.Fatalf("AddRelationship failed: %v", err)
+	}
+
+	if err := w.RemoveRelationship(ctx, "mie_decision_entity", fields); err != nil {
+		t.Fatalf("RemoveRelationship failed: %v", err)
+	}
+
+	result, err := backend.Query(ctx, `?[decision_id] := *mie_decision_entity { decision_id, entity_id }`, nil)
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	if len(result.Rows) != 0 {
+		t.Errorf("expected edge to be removed, got %d rows", len(result.Rows))
+	}
+
+	// Missing key column
+	err = w.RemoveRelationship(ctx, "mie_decision_entity", map[string]string{"decision_id": decision.ID})
+	if err == nil {
+		t.Error("expected error for missing key column")
+	}
+}
+
+func TestWriterAddAlias(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	ctx := context.Background()
+
+	pg, _ := w.StoreEntity(ctx, tools.StoreEntityRequest{Name: "PostgreSQL", Kind: "technology"})
+	mysql, _ := w.StoreEntity(ctx, tools.StoreEntityRequest{Name: "MySQL", Kind: "technology"})
+
+	if err := w.AddAlias(ctx, pg.ID, "  Postgres DB "); err != nil {
+		t.Fatalf("AddAlias failed: %v", err)
+	}
+	// Re-adding the same alias to the same entity is a no-op.
+	if err := w.AddAlias(ctx, pg.ID, "postgres db"); err != nil {
+		t.Errorf("AddAlias (repeat) failed: %v", err)
+	}
+
+	result, err := backend.Query(ctx, `?[alias, entity_id] := *mie_entity_alias { alias, entity_id }`, nil)
+	if err != nil {
+		t.Fatalf("query aliases: %v", err)
+	}
+	if len(result.Rows) != 1 || toString(result.Rows[0][0]) != "postgres db" {
+		t.Errorf("expected one normalized alias, got %v", result.Rows)
+	}
+
+	if err := w.AddAlias(ctx, mysql.ID, "Postgres DB"); err == nil {
+		t.Error("expected error when alias already refers to another entity")
+	}
+	if err := w.AddAlias(ctx, "ent:missing", "x"); err == nil {
+		t.Error("expected error for missing entity")
+	}
+	if err := w.AddAlias(ctx, "fact:abc", "x"); err == nil {
+		t.Error("expected error for non-entity ID")
+	}
+	if err := w.AddAlias(ctx, pg.ID, "  "); err == nil {
+		t.Error("expected error for empty alias")
+	}
+}
+
+func TestWriterRenameEntity(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	embedder := NewEmbeddingGenerator(NewMockEmbeddingProvider(384, nil), nil)
+	w := NewWriter(backend, embedder, nil)
+	r := NewReader(backend, nil, nil)
+	ctx := tools.WithChangeReason(context.Background(), "official name")
+
+	pg, _ := w.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Postgres", Kind: "technology", Description: "Main database"})
+	mysql, _ := w.StoreEntity(ctx, tools.StoreEntityRequest{Name: "MySQL", Kind: "technology"})
+
+	if err := w.RenameEntity(ctx, pg.ID, "PostgreSQL"); err != nil {
+		t.Fatalf("RenameEntity failed: %v", err)
+	}
+	node, err := r.GetNodeByID(ctx, pg.ID)
+	if err != nil {
+		t.Fatalf("GetNodeByID failed: %v", err)
+	}
+	if ent := node.(*tools.Entity); ent.Name != "PostgreSQL" || ent.Description != "Main database" {
+		t.Errorf("unexpected entity after rename: %+v", ent)
+	}
+	for _, name := range []string{"postgresql", "Postgres"} {
+		if ent, err := r.FindEntityByName(ctx, name); err != nil || ent == nil || ent.ID != pg.ID {
+			t.Errorf("FindEntityByName(%q) = %+v, %v; want %s", name, ent, err, pg.ID)
+		}
+	}
+
+	history, err := r.GetNodeHistory(ctx, pg.ID)
+	if err != nil {
+		t.Fatalf("GetNodeHistory failed: %v", err)
+	}
+	if len(history) != 1 || history[0].Field != "name" || history[0].OldValue != "Postgres" || history[0].Reason != "official name" {
+		t.Errorf("unexpected history: %+v", history)
+	}
+
+	want, _ := embedder.Generate(ctx, "PostgreSQL: Main database")
+	stored := NewFlatIndex()
+	if err := loadVectorIndex(ctx, backend, stored); err != nil {
+		t.Fatalf("loadVectorIndex failed: %v", err)
+	}
+	if matches, _ := stored.Search(ctx, "entity", want, 1); len(matches) != 1 || matches[0].ID != pg.ID || matches[0].Distance > 1e-4 {
+		t.Errorf("expected %s embedded from its new name, got %+v", pg.ID, matches)
+	}
+
+	if err := w.RenameEntity(ctx, mysql.ID, "postgres"); err == nil {
+		t.Error("expected error renaming to another entity's alias")
+	}
+	if err := w.RenameEntity(ctx, mysql.ID, "PostgreSQL"); err == nil {
+		t.Error("expected error renaming to another entity's name")
+	}
+	if err := w.RenameEntity(ctx, "ent:missing", "x"); err == nil {
+		t.Error("expected error for missing entity")
+	}
+	if err := w.RenameEntity(ctx, "fact:abc", "x"); err == nil {
+		t.Error("expected error for non-entity ID")
+	}
+}
+
+func TestWriterSetArchived(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	r := NewReader(backend, nil, nil)
+	ctx := context.Background()
+
+	old, _ := w.StoreTopic(ctx, tools.StoreTopicRequest{Name: "legacy-api", Description: "Old API"})
+	w.StoreTopic(ctx, tools.StoreTopicRequest{Name: "new-api", Description: "New API"})
+
+	if err := w.SetArchived(ctx, old.ID, true); err != nil {
+		t.Fatalf("SetArchived failed: %v", err)
+	}
+
+	nodes, total, err := r.ListNodes(ctx, tools.ListOptions{NodeType: "topic", Limit: 10})
+	if err != nil {
+		t.Fatalf("ListNodes failed: %v", err)
+	}
+	if total != 1 || len(nodes) != 1 {
+		t.Errorf("expected archived topic to be hidden, got total %d, %d nodes", total, len(nodes))
+	}
+	_, total, _ = r.ListNodes(ctx, tools.ListOptions{NodeType: "topic", Limit: 10, IncludeArchived: true})
+	if total != 2 {
+		t.Errorf("expected 2 topics with include_archived, got %d", total)
+	}
+
+	results, _ := r.ExactSearch(ctx, "API", []string{"topic"}, 10)
+	if len(results) != 1 {
+		t.Errorf("expected exact search to skip archived topic, got %d results", len(results))
+	}
+	results, _ = r.ExactSearch(tools.WithIncludeArchived(ctx, true), "API", []string{"topic"}, 10)
+	if len(results) != 2 {
+		t.Errorf("expected 2 results with include_archived, got %d", len(results))
+	}
+
+	if err := w.SetArchived(ctx, old.ID, false); err != nil {
+		t.Fatalf("SetArchived (unarchive) failed: %v", err)
+	}
+	_, total, _ = r.ListNodes(ctx, tools.ListOptions{NodeType: "topic", Limit: 10})
+	if total != 2 {
+		t.Errorf("expected unarchived topic to be listed, got total %d", total)
+	}
+
+	if err := w.SetArchived(ctx, "ent:missing", true); err == nil {
+		t.Error("expected error for missing node")
+	}
+}
+
+func TestWriterSetVerified(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	r := NewReader(backend, nil, nil)
+	ctx := context.Background()
+
+	w.StoreFact(ctx, tools.StoreFactRequest{Content: "Deploys use Postgres 15", Category: "technical"})
+	confirmed, _ := w.StoreFact(ctx, tools.StoreFactRequest{Content: "Deploys use Postgres 16", Category: "technical"})
+
+	if err := w.SetVerified(ctx, confirmed.ID, "alice", true); err != nil {
+		t.Fatalf("SetVerified failed: %v", err)
+	}
+
+	node, err := r.GetNodeByID(ctx, confirmed.ID)
+	if err != nil {
+		t.Fatalf("GetNodeByID failed: %v", err)
+	}
+	if f := node.(*tools.Fact); !f.Verified || f.VerifiedBy != "alice" || f.VerifiedAt == 0 {
+		t.Errorf("expected fact verified by alice, got %+v", f)
+	}
+
+	results, err := r.ExactSearch(ctx, "Deploys use", []string{"fact"}, 10)
+	if err != nil {
+		t.Fatalf("ExactSearch failed: %v", err)
+	}
+	if len(results) != 2 || results[0].ID != confirmed.ID {
+		t.Errorf("expected verified fact to rank first, got %+v", results)
+	}
+
+	export, err := r.ExportGraph(ctx, tools.ExportOptions{})
+	if err != nil {
+		t.Fatalf("ExportGraph failed: %v", err)
+	}
+	verified := 0
+	for _, f := range export.Facts {
+		if f.Verified {
+			verified++
+		}
+	}
+	if verified != 1 {
+		t.Errorf("expected 1 verified fact in export, got %d", verified)
+	}
+
+	if err := w.SetVerified(ctx, confirmed.ID, "", false); err != nil {
+		t.Fatalf("SetVerified (unverify) failed: %v", err)
+	}
+	nodes, _, _ := r.ListNodes(ctx, tools.ListOptions{NodeType: "fact", Limit: 10})
+	for _, n := range nodes {
+		if n.(*tools.Fact).Verified {
+			t.Errorf("expected no verified facts after unverify, got %+v", n)
+		}
+	}
+
+	if err := w.SetVerified(ctx, confirmed.ID, "", true); err == nil {
+		t.Error("expected error when verified_by is empty")
+	}
+	if err := w.SetVerified(ctx, "fact:missing", "alice", true); err == nil {
+		t.Error("expected error for missing fact")
+	}
+}
+
+func TestWriterMergeEntities(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	r := NewReader(backend, nil, nil)
+	ctx := context.Background()
+
+	pg, _ := w.StoreEntity(ctx, tools.StoreEntityRequest{Name: "PostgreSQL", Kind: "technology"})
+	dup, _ := w.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Postgres DB", Kind: "technology"})
+	fact, _ := w.StoreFact(ctx, tools.StoreFactRequest{Content: "Orders live in Postgres", Category: "technical"})
+	dec, _ := w.StoreDecision(ctx, tools.StoreDecisionRequest{Title: "Use Postgres", Rationale: "Mature"})
+	topic, _ := w.StoreTopic(ctx, tools.StoreTopicRequest{Name: "databases"})
+
+	w.AddRelationship(ctx, "mie_fact_entity", map[string]string{"fact_id": fact.ID, "entity_id": dup.ID})
+	w.AddRelationship(ctx, "mie_decision_entity", map[string]string{"decision_id": dec.ID, "entity_id": dup.ID, "role": "subject"})
+	w.AddRelationship(ctx, "mie_entity_topic", map[string]string{"entity_id": dup.ID, "topic_id": topic.ID})
+	if err := w.AddAlias(ctx, dup.ID, "pg"); err != nil {
+		t.Fatalf("AddAlias failed: %v", err)
+	}
+
+	if err := w.MergeEntities(ctx, pg.ID, dup.ID); err != nil {
+		t.Fatalf("MergeEntities failed: %v", err)
+	}
+
+	facts, _ := r.GetFactsAboutEntity(ctx, pg.ID)
+	if len(facts) != 1 {
+		t.Errorf("expected fact edge moved to survivor, got %d facts", len(facts))
+	}
+	ents, _ := r.GetDecisionEntities(ctx, dec.ID)
+	if len(ents) != 1 || ents[0].ID != pg.ID || ents[0].Role != "subject" {
+		t.Errorf("expected decision edge moved to survivor with role, got %+v", ents)
+	}
+	topics, _ := backend.Query(ctx, fmt.Sprintf(`?[topic_id] := *mie_entity_topic { entity_id, topic_id }, entity_id = '%s'`, pg.ID), nil)
+	if len(topics.Rows) != 1 {
+		t.Errorf("expected topic edge moved to survivor, got %d", len(topics.Rows))
+	}
+
+	if node, _ := r.GetNodeByID(ctx, dup.ID); node != nil {
+		t.Error("expected duplicate entity to be deleted")
+	}
+	for _, name := range []string{"postgres db", "pg"} {
+		id, _ := r.ResolveAlias(ctx, name)
+		if id != pg.ID {
+			t.Errorf("expected alias %q to resolve to survivor, got %q", name, id)
+		}
+	}
+
+	if err := w.MergeEntities(ctx, pg.ID, pg.ID); err == nil {
+		t.Error("expected error merging an entity into itself")
+	}
+	if err := w.MergeEntities(ctx, pg.ID, dup.ID); err == nil {
+		t.Error("expected error merging a deleted entity")
+	}
+}
+
+func TestWriterImportGraphRoundTrip(t *testing.T) {
+	src := newTestBackend(t)
+	defer src.Close()
+	setupSchema(t, src)
+
+	w := NewWriter(src, nil, nil)
+	r := NewReader(src, nil, nil)
+	ctx := context.Background()
+
+	oldFact, _ := w.StoreFact(ctx, tools.StoreFactRequest{Content: "Lives in Berlin", Category: "personal"})
+	newFact, _ := w.StoreFact(ctx, tools.StoreFactRequest{Content: "Lives in Madrid", Category: "personal"})
+	ent, _ := w.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Madrid", Kind: "place"})
+	dec, _ := w.StoreDecision(ctx, tools.StoreDecisionRequest{Title: "Move to Madrid", Rationale: "Weather"})
+	w.InvalidateFact(ctx, oldFact.ID, newFact.ID, "Moved")
+	w.UpdateStatus(ctx, dec.ID, "superseded")
+	w.AddRelationship(ctx, "mie_fact_entity", map[string]string{"fact_id": newFact.ID, "entity_id": ent.ID})
+	w.AddRelationship(ctx, "mie_decision_entity", map[string]string{"decision_id": dec.ID, "entity_id": ent.ID, "role": "subject"})
+	w.AddAlias(ctx, ent.ID, "MAD")
+
+	export, err := r.ExportGraph(ctx, tools.ExportOptions{})
+	if err != nil {
+		t.Fatalf("ExportGraph failed: %v", err)
+	}
+	if export.Stats["relationships"] != 3 {
+		t.Errorf("expected 3 exported relationships, got %d (%v)", export.Stats["relationships"], export.Edges)
+	}
+	if len(export.Aliases) != 1 {
+		t.Errorf("expected 1 exported alias, got %d", len(export.Aliases))
+	}
+
+	dst := newTestBackend(t)
+	defer dst.Close()
+	setupSchema(t, dst)
+
+	dw := NewWriter(dst, nil, nil)
+	dr := NewReader(dst, nil, nil)
+	counts, err := dw.ImportGraph(ctx, export)
+	if err != nil {
+		t.Fatalf("ImportGraph failed: %v", err)
+	}
+	if counts["facts"] != 2 || counts["relationships"] != 3 || counts["aliases"] != 1 {
+		t.Errorf("unexpected import counts: %v", counts)
+	}
+
+	node, _ := dr.GetNodeByID(ctx, oldFact.ID)
+	if f, ok := node.(*tools.Fact); !ok || f.Valid || f.CreatedAt != oldFact.CreatedAt {
+		t.Errorf("expected invalidated fact with original ID and timestamp, got %+v", node)
+	}
+	node, _ = dr.GetNodeByID(ctx, dec.ID)
+	if d, ok := node.(*tools.Decision); !ok || d.Status != "superseded" {
+		t.Errorf("expected decision status preserved, got %+v", node)
+	}
+	chain, _ := dr.GetInvalidationChain(ctx, oldFact.ID)
+	if len(chain) != 1 || chain[0].NewFactID != newFact.ID {
+		t.Errorf("expected invalidation chain preserved, got %+v", chain)
+	}
+	ents, _ := dr.GetDecisionEntities(ctx, dec.ID)
+	if len(ents) != 1 || ents[0].ID != ent.ID || ents[0].Role != "subject" {
+		t.Errorf("expected decision_entity edge preserved, got %+v", ents)
+	}
+	if id, _ := dr.ResolveAlias(ctx, "mad"); id != ent.ID {
+		t.Errorf("expected alias preserved, got %q", id)
+	}
+}
+
+func TestWriterImportGraphRemapsNamespace(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	r := NewReader(backend, nil, nil)
+	ctx := context.Background()
+
+	fact, _ := w.StoreFact(ctx, tools.StoreFactRequest{Content: "Uses Go", Category: "technical"})
+	ent, _ := w.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Go", Kind: "technology"})
+	w.AddRelationship(ctx, "mie_fact_entity", map[string]string{"fact_id": fact.ID, "entity_id": ent.ID})
+
+	export, err := r.ExportGraph(ctx, tools.ExportOptions{})
+	if err != nil {
+		t.Fatalf("ExportGraph failed: %v", err)
+	}
+
+	workCtx := tools.WithNamespace(ctx, "work")
+	if _, err := w.ImportGraph(workCtx, export); err != nil {
+		t.Fatalf("ImportGraph failed: %v", err)
+	}
+
+	// The copy gets the IDs that storing the same content in "work" yields.
+	stored, _ := w.StoreFact(workCtx, tools.StoreFactRequest{Content: "Uses Go", Category: "technical"})
+	if stored.ID == fact.ID {
+		t.Fatal("expected a namespaced ID different from the default namespace")
+	}
+	ents, _ := r.GetRelatedEntities(workCtx, stored.ID)
+	if len(ents) != 1 || ents[0].ID != NamespacedID(ent.ID, "work") {
+		t.Errorf("expected remapped fact_entity edge, got %+v", ents)
+	}
+
+	// The original namespace is untouched.
+	if node, _ := r.GetNodeByID(ctx, fact.ID); node == nil {
+		t.Error("expected original fact to remain in the default namespace")
+	}
+	stats, _ := r.GetStats(ctx)
+	if stats.TotalFacts != 1 {
+		t.Errorf("expected 1 fact in default namespace, got %d", stats.TotalFacts)
+	}
+}
+
+func TestWriterUpdateDescriptionReembeds(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	embedder := NewEmbeddingGenerator(NewMockEmbeddingProvider(384, nil), nil)
+	w := NewWriter(backend, embedder, nil)
+	w.vectors = NewFlatIndex()
+	ctx := context.Background()
+
+	entity, err := w.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Go", Kind: "technology", Description: "A board game"})
+	if err != nil {
+		t.Fatalf("StoreEntity failed: %v", err)
+	}
+	if err := w.UpdateDescription(ctx, entity.ID, "A compiled language"); err != nil {
+		t.Fatalf("UpdateDescription failed: %v", err)
+	}
+
+	// The queued embedding of the old text is replaced, not left to run.
+	stats, err := w.EmbeddingQueueStats(ctx)
+	if err != nil {
+		t.Fatalf("EmbeddingQueueStats failed: %v", err)
+	}
+	if stats.Pending != 0 {
+		t.Errorf("expected an empty queue, got %+v", stats)
+	}
+
+	want, err := embedder.Generate(ctx, "Go: A compiled language")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	stored := NewFlatIndex()
+	if err := loadVectorIndex(ctx, backend, stored); err != nil {
+		t.Fatalf("loadVectorIndex failed: %v", err)
+	}
+	for name, idx := range map[string]VectorIndex{"embedding table": stored, "vector index": w.vectors} {
+		matches, err := idx.Search(ctx, "entity", want, 1)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(matches) != 1 || matches[0].ID != entity.ID || matches[0].Distance > 1e-4 {
+			t.Errorf("%s: expected %s embedded from its new description, got %+v", name, entity.ID, matches)
+		}
+	}
 }
 
 func TestWriterUpdateStatus(t *testing.T) {
@@ -308,7 +869,7 @@ func TestWriterUpdateStatus(t *testing.T) {
 	}
 
 	// Verify status changed
-	result, err := backend.Query(ctx, `?[status] := *mie_decision { id, status }, id = "`+escapeDatalog(decision.ID)+`"`)
+	result, err := backend.Query(ctx, `?[status] := *mie_decision { id, status }, id = $id`, map[string]any{"id": decision.ID})
 	if err != nil {
 		t.Fatalf("query failed: %v", err)
 	}
@@ -321,4 +882,88 @@ func TestWriterUpdateStatus(t *testing.T) {
 	if err == nil {
 		t.Error("expected error for invalid status")
 	}
-}
\ No newline at end of file
+
+	// Superseded is final.
+	if err := w.UpdateStatus(ctx, decision.ID, "active"); err == nil {
+		t.Error("expected error for superseded -> active")
+	}
+
+	proposal, err := w.StoreDecision(ctx, tools.StoreDecisionRequest{Title: "Proposal", Rationale: "Maybe", Status: "proposed"})
+	if err != nil {
+		t.Fatalf("StoreDecision failed: %v", err)
+	}
+	if proposal.Status != "proposed" {
+		t.Errorf("expected status 'proposed', got %q", proposal.Status)
+	}
+	if err := w.UpdateStatus(ctx, proposal.ID, "superseded"); err == nil {
+		t.Error("expected error for proposed -> superseded")
+	}
+	if err := w.UpdateStatus(ctx, proposal.ID, "active"); err != nil {
+		t.Errorf("proposed -> active failed: %v", err)
+	}
+	if _, err := w.StoreDecision(ctx, tools.StoreDecisionRequest{Title: "Done", Rationale: "Old", Status: "superseded"}); err == nil {
+		t.Error("expected error for initial status superseded")
+	}
+}
+
+func TestWriterAtomic(t *testing.T) {
+	backend := newTestBackend(t)
+	defer backend.Close()
+	setupSchema(t, backend)
+
+	w := NewWriter(backend, nil, nil)
+	r := NewReader(backend, nil, nil)
+	ctx := context.Background()
+
+	countFacts := func() int {
+		result, err := backend.Query(ctx, `?[count(id)] := *mie_fact { id }`, nil)
+		if err != nil {
+			t.Fatalf("count facts: %v", err)
+		}
+		return toInt(result.Rows[0][0])
+	}
+
+	// A failing function discards everything it staged.
+	err := w.Atomic(ctx, func(ctx context.Context) error {
+		if _, err := w.StoreFact(ctx, tools.StoreFactRequest{Content: "Staged fact", Category: "general"}); err != nil {
+			return err
+		}
+		return fmt.Errorf("abort")
+	})
+	if err == nil || err.Error() != "abort" {
+		t.Fatalf("expected abort error, got %v", err)
+	}
+	if n := countFacts(); n != 0 {
+		t.Fatalf("expected no facts after rollback, got %d", n)
+	}
+
+	// A successful function commits nodes and edges together.
+	var fact *tools.Fact
+	var topic *tools.Topic
+	err = w.Atomic(ctx, func(ctx context.Context) error {
+		var err error
+		if fact, err = w.StoreFact(ctx, tools.StoreFactRequest{Content: "Committed fact", Category: "general"}); err != nil {
+			return err
+		}
+		if topic, err = w.StoreTopic(ctx, tools.StoreTopicRequest{Name: "testing"}); err != nil {
+			return err
+		}
+		if n := countFacts(); n != 0 {
+			t.Errorf("staged fact visible before commit: %d facts", n)
+		}
+		return w.AddRelationship(ctx, "mie_fact_topic", map[string]string{"fact_id": fact.ID, "topic_id": topic.ID})
+	})
+	if err != nil {
+		t.Fatalf("Atomic failed: %v", err)
+	}
+	if n := countFacts(); n != 1 {
+		t.Fatalf("expected 1 fact after commit, got %d", n)
+	}
+	stats, err := r.GetTopicStats(ctx)
+	if err != nil {
+		t.Fatalf("GetTopicStats failed: %v", err)
+	}
+	if len(stats) != 1 || stats[0].Facts != 1 {
+		t.Errorf("expected the committed edge to link the fact to the topic, got %+v", stats)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package memorytest
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// Attach stores data under its content hash and links it to the node
+// nodeID. Attaching the same content to the node again replaces the link's
+// name and MIME type. An empty mimeType is guessed from the name's
+// extension, then from the content.
+func (q *Querier) Attach(ctx context.Context, nodeID, name, mimeType string, data []byte) (*tools.Attachment, error) {
+	if len(data) == 0 {
+		return nil, errors.New("attachment is empty")
+	}
+	if len(data) > memory.MaxAttachmentSize {
+		return nil, fmt.Errorf("attachment is %d bytes, more than the limit of %d", len(data), memory.MaxAttachmentSize)
+	}
+	if _, err := q.GetNodeByID(ctx, nodeID); err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	if name == "" {
+		name = hash[:12]
+	}
+	if mimeType == "" {
+		mimeType = mime.TypeByExtension(filepath.Ext(name))
+	}
+	if mimeType == "" {
+		mimeType = http.DetectContentType(data)
+	}
+	att := &tools.Attachment{
+		Hash:      hash,
+		NodeID:    nodeID,
+		Name:      name,
+		MimeType:  mimeType,
+		Size:      int64(len(data)),
+		CreatedAt: time.Now().Unix(),
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.g.contents[hash] = slices.Clone(data)
+	q.g.attachments[attachmentKey{nodeID: nodeID, hash: hash}] = attachmentRow{Attachment: *att, ns: q.resolveNamespace(ctx)}
+	q.publish(ctx, memory.ChangeUpdated, nodeID, nil)
+	q.audit(ctx, memory.ChangeUpdated, "", nil, nodeID)
+	return att, nil
+}
+
+// attachments returns the links of the namespace that keep reports true
+// for, oldest first. q.mu must be held.
+func (q *Querier) attachments(ctx context.Context, keep func(tools.Attachment) bool) []tools.Attachment {
+	ns := q.resolveNamespace(ctx)
+	links := []tools.Attachment{}
+	for _, a := range q.g.attachments {
+		if a.ns == ns && keep(a.Attachment) {
+			links = append(links, a.Attachment)
+		}
+	}
+	sort.Slice(links, func(i, j int) bool {
+		a, b := links[i], links[j]
+		return cmp.Or(cmp.Compare(a.CreatedAt, b.CreatedAt), strings.Compare(a.Name, b.Name), strings.Compare(a.NodeID, b.NodeID)) < 0
+	})
+	return links
+}
+
+// ListAttachments returns the attachments of the node nodeID of the
+// namespace, oldest first.
+func (q *Querier) ListAttachments(ctx context.Context, nodeID string) ([]tools.Attachment, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.attachments(ctx, func(a tools.Attachment) bool { return a.NodeID == nodeID }), nil
+}
+
+// ReadAttachment returns the content with the given hash and one of its
+// links to a node of the namespace. Content attached only in other
+// namespaces is not found.
+func (q *Querier) ReadAttachment(ctx context.Context, hash string) (*tools.Attachment, []byte, error) {
+	if !memory.ValidAttachmentHash(hash) {
+		return nil, nil, fmt.Errorf("invalid attachment hash %q", hash)
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	links := q.attachments(ctx, func(a tools.Attachment) bool { return a.Hash == hash })
+	if len(links) == 0 {
+		return nil, nil, fmt.Errorf("attachment %s not found", hash)
+	}
+	return &links[0], slices.Clone(q.g.contents[hash]), nil
+}
+
+// Detach removes the link between the node nodeID and the content with the
+// given hash. The content is deleted once no node of any namespace links to
+// it.
+func (q *Querier) Detach(ctx context.Context, nodeID, hash string) error {
+	if !memory.ValidAttachmentHash(hash) {
+		return fmt.Errorf("invalid attachment hash %q", hash)
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	key := attachmentKey{nodeID: nodeID, hash: hash}
+	if a, ok := q.g.attachments[key]; !ok || a.ns != q.resolveNamespace(ctx) {
+		return fmt.Errorf("attachment %s is not attached to %s", hash, nodeID)
+	}
+	delete(q.g.attachments, key)
+	q.publish(ctx, memory.ChangeUpdated, nodeID, nil)
+	q.audit(ctx, memory.ChangeUpdated, "", nil, nodeID)
+	for k := range q.g.attachments {
+		if k.hash == hash {
+			return nil
+		}
+	}
+	delete(q.g.contents, hash)
+	return nil
+}
@@ -0,0 +1,35 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+// Package memorytest provides an in-memory tools.Querier for integration
+// tests of code built on pkg/tools.
+//
+// Querier is a reference implementation, not a mock: it keeps a real memory
+// graph in process memory and applies the same rules as the CozoDB-backed
+// memory.Client. Node IDs are the deterministic IDs of memory.FactID and its
+// siblings, scoped by namespace with memory.NamespacedID, so storing the same
+// content twice returns the same node; required fields, category and kind
+// defaults, decision status transitions, alias conflicts, and edge columns
+// are checked as the client checks them, with the same error messages.
+//
+//	q := memorytest.New()
+//	fact, err := q.StoreFact(ctx, tools.StoreFactRequest{Content: "The user prefers Go", Category: "preference"})
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	result, err := tools.Query(ctx, q, map[string]any{"query": "Go", "mode": "exact"})
+//
+// It needs no CGO and no build tags.
+//
+// # Differences From memory.Client
+//
+// Querier has no embeddings: EmbeddingsEnabled reports false, and
+// SemanticSearch, SimilarNodes, DetectConflicts, and CheckNewFactConflicts
+// fail as they do on a client with embeddings disabled. HybridSearch falls
+// back to exact search, as the client does. Exact search ignores case and
+// accents, as the client does, but entity name lookups ignore only case,
+// and full-text search matches whole words without stemming.
+// Graph size limits, extra fact categories and entity kinds, and category
+// policies cannot be configured.
+package memorytest
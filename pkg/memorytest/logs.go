@@ -0,0 +1,88 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package memorytest
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// GetAuditLog returns the writes made in the namespace that match opts,
+// newest first.
+func (q *Querier) GetAuditLog(ctx context.Context, opts tools.AuditOptions) ([]tools.AuditEntry, error) {
+	if opts.Limit <= 0 {
+		opts.Limit = 50
+	}
+	ns := q.resolveNamespace(ctx)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	entries := []tools.AuditEntry{}
+	for i := len(q.g.audit) - 1; i >= 0 && len(entries) < opts.Limit; i-- {
+		e := q.g.audit[i]
+		if e.ns != ns ||
+			(opts.NodeID != "" && !slices.Contains(e.NodeIDs, opts.NodeID)) ||
+			(opts.SourceAgent != "" && e.SourceAgent != opts.SourceAgent) ||
+			(opts.Tool != "" && e.Tool != opts.Tool) {
+			continue
+		}
+		entry := e.AuditEntry
+		entry.NodeIDs = slices.Clone(e.NodeIDs)
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// GetNodeHistory returns the changes to the fields of a node of the
+// namespace, oldest first.
+func (q *Querier) GetNodeHistory(ctx context.Context, nodeID string) ([]tools.HistoryEntry, error) {
+	ns := q.resolveNamespace(ctx)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	entries := []tools.HistoryEntry{}
+	for _, h := range q.g.history {
+		if h.ns == ns && h.NodeID == nodeID {
+			entries = append(entries, h.HistoryEntry)
+		}
+	}
+	return entries, nil
+}
+
+// RecordIntent records a multi-step write before its steps run and returns
+// its ID.
+func (q *Querier) RecordIntent(ctx context.Context, kind, payload string) (string, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	now := time.Now()
+	id := q.logID("int", now)
+	q.g.intents[id] = tools.Intent{ID: id, Kind: kind, Payload: payload, Namespace: q.resolveNamespace(ctx), CreatedAt: now.Unix()}
+	return id, nil
+}
+
+// CompleteIntent removes an intent whose steps all ran.
+func (q *Querier) CompleteIntent(ctx context.Context, intentID string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.g.intents, intentID)
+	return nil
+}
+
+// PendingIntents returns the intents of every namespace that were recorded
+// but never completed, oldest first.
+func (q *Querier) PendingIntents(ctx context.Context) ([]tools.Intent, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	intents := make([]tools.Intent, 0, len(q.g.intents))
+	for _, in := range q.g.intents {
+		intents = append(intents, in)
+	}
+	sort.Slice(intents, func(i, j int) bool { return intents[i].ID < intents[j].ID })
+	return intents, nil
+}
@@ -0,0 +1,403 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package memorytest
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// Querier is an in-memory memory graph that implements tools.Querier. It is
+// safe for concurrent use. Create one with New.
+type Querier struct {
+	mu        sync.Mutex
+	namespace string // default namespace when the context carries none
+	g         *graph
+	changes   *memory.ChangeFeed
+	seq       atomic.Uint64 // disambiguates log IDs recorded in the same nanosecond
+}
+
+var _ tools.Querier = (*Querier)(nil)
+
+// Option configures a Querier created with New.
+type Option func(*Querier)
+
+// WithNamespace stores and reads nodes in namespace when the context
+// carries none, instead of tools.DefaultNamespace.
+func WithNamespace(namespace string) Option {
+	return func(q *Querier) { q.namespace = namespace }
+}
+
+// New creates an empty in-memory memory graph.
+func New(opts ...Option) *Querier {
+	q := &Querier{g: newGraph(), changes: memory.NewChangeFeed()}
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
+}
+
+// Changes returns the feed that every successful write is published on,
+// as memory.Client.Changes does.
+func (q *Querier) Changes() *memory.ChangeFeed {
+	return q.changes
+}
+
+// EmbeddingsEnabled reports false: Querier has no embeddings.
+func (q *Querier) EmbeddingsEnabled() bool {
+	return false
+}
+
+// graph is the state of a Querier. Atomic and snapshots copy it whole.
+type graph struct {
+	nodes       map[string]node
+	edges       map[string]map[edgeKey]edge // by edge table
+	aliases     map[aliasKey]aliasRow
+	archived    map[string]int64 // node ID to archived_at
+	audit       []auditRow
+	history     []historyRow
+	intents     map[string]tools.Intent
+	snapshots   map[string]snapshot
+	searches    map[searchKey]tools.SavedSearch
+	sessions    map[string]session
+	attachments map[attachmentKey]attachmentRow
+	contents    map[string][]byte // attachment content by hash
+	meta        map[string]string // counters and their timestamps
+}
+
+// node is a stored node: a *tools.Fact, *tools.Decision, *tools.Entity,
+// *tools.Event, or *tools.Topic, and its namespace.
+type node struct {
+	ns    string
+	value any
+}
+
+// edgeKey holds the key columns of an edge, in memory.ValidEdgeTables
+// order.
+type edgeKey [2]string
+
+// edge holds the value columns of an edge. label is its role or reason, for
+// the tables in edgeLabelColumns.
+type edge struct {
+	label       string
+	weight      float64
+	sourceAgent string
+	createdAt   int64
+}
+
+// aliasKey identifies an alias: aliases are scoped to a namespace.
+type aliasKey struct {
+	ns    string
+	alias string
+}
+
+type aliasRow struct {
+	entityID  string
+	createdAt int64
+}
+
+type auditRow struct {
+	tools.AuditEntry
+	ns string
+}
+
+type historyRow struct {
+	tools.HistoryEntry
+	ns string
+}
+
+type snapshot struct {
+	tools.Snapshot
+	ns string
+	g  *graph
+}
+
+type searchKey struct {
+	ns   string
+	name string
+}
+
+type session struct {
+	tools.Session
+	ns string
+}
+
+type attachmentKey struct {
+	nodeID string
+	hash   string
+}
+
+type attachmentRow struct {
+	tools.Attachment
+	ns string
+}
+
+// edgeLabelColumns maps the edge tables with a value column to it. The
+// column is required when the edge is added.
+var edgeLabelColumns = map[string]string{
+	"mie_invalidates":     "reason",
+	"mie_decision_entity": "role",
+}
+
+func newGraph() *graph {
+	g := &graph{
+		nodes:       map[string]node{},
+		edges:       map[string]map[edgeKey]edge{},
+		aliases:     map[aliasKey]aliasRow{},
+		archived:    map[string]int64{},
+		intents:     map[string]tools.Intent{},
+		snapshots:   map[string]snapshot{},
+		searches:    map[searchKey]tools.SavedSearch{},
+		sessions:    map[string]session{},
+		attachments: map[attachmentKey]attachmentRow{},
+		contents:    map[string][]byte{},
+		meta:        map[string]string{},
+	}
+	for table := range memory.ValidEdgeTables {
+		g.edges[table] = map[edgeKey]edge{}
+	}
+	return g
+}
+
+// clone returns a copy of g that shares nothing it could change.
+func (g *graph) clone() *graph {
+	c := &graph{
+		nodes:       make(map[string]node, len(g.nodes)),
+		edges:       make(map[string]map[edgeKey]edge, len(g.edges)),
+		aliases:     maps.Clone(g.aliases),
+		archived:    maps.Clone(g.archived),
+		audit:       append([]auditRow(nil), g.audit...),
+		history:     append([]historyRow(nil), g.history...),
+		intents:     maps.Clone(g.intents),
+		snapshots:   maps.Clone(g.snapshots),
+		searches:    maps.Clone(g.searches),
+		sessions:    maps.Clone(g.sessions),
+		attachments: maps.Clone(g.attachments),
+		contents:    maps.Clone(g.contents),
+		meta:        maps.Clone(g.meta),
+	}
+	for id, n := range g.nodes {
+		c.nodes[id] = node{ns: n.ns, value: copyNode(n.value)}
+	}
+	for table, rows := range g.edges {
+		c.edges[table] = maps.Clone(rows)
+	}
+	return c
+}
+
+// copyNode returns a copy of a stored node value.
+func copyNode(v any) any {
+	switch n := v.(type) {
+	case *tools.Fact:
+		c := *n
+		return &c
+	case *tools.Decision:
+		c := *n
+		return &c
+	case *tools.Entity:
+		c := *n
+		return &c
+	case *tools.Event:
+		c := *n
+		return &c
+	case *tools.Topic:
+		c := *n
+		return &c
+	}
+	return v
+}
+
+// nodeType returns the type of a stored node value.
+func nodeType(v any) string {
+	switch v.(type) {
+	case *tools.Fact:
+		return "fact"
+	case *tools.Decision:
+		return "decision"
+	case *tools.Entity:
+		return "entity"
+	case *tools.Event:
+		return "event"
+	case *tools.Topic:
+		return "topic"
+	}
+	return ""
+}
+
+// nodeTypeOfID returns the node type an ID's prefix names, or "".
+func nodeTypeOfID(id string) string {
+	switch {
+	case strings.HasPrefix(id, "fact:"):
+		return "fact"
+	case strings.HasPrefix(id, "dec:"):
+		return "decision"
+	case strings.HasPrefix(id, "ent:"):
+		return "entity"
+	case strings.HasPrefix(id, "evt:"):
+		return "event"
+	case strings.HasPrefix(id, "top:"):
+		return "topic"
+	}
+	return ""
+}
+
+// endpointType returns the node type of an edge key column, such as
+// "fact" for "new_fact_id".
+func endpointType(column string) string {
+	column = strings.TrimSuffix(column, "_id")
+	return column[strings.LastIndex(column, "_")+1:]
+}
+
+// nodeID returns the ID of a stored node value.
+func nodeID(v any) string {
+	switch n := v.(type) {
+	case *tools.Fact:
+		return n.ID
+	case *tools.Decision:
+		return n.ID
+	case *tools.Entity:
+		return n.ID
+	case *tools.Event:
+		return n.ID
+	case *tools.Topic:
+		return n.ID
+	}
+	return ""
+}
+
+// createdAt returns the creation time of a stored node value.
+func createdAt(v any) int64 {
+	switch n := v.(type) {
+	case *tools.Fact:
+		return n.CreatedAt
+	case *tools.Decision:
+		return n.CreatedAt
+	case *tools.Entity:
+		return n.CreatedAt
+	case *tools.Event:
+		return n.CreatedAt
+	case *tools.Topic:
+		return n.CreatedAt
+	}
+	return 0
+}
+
+// read returns a copy of a stored node value as readers see it: an event's
+// EndDate is empty unless it differs from its EventDate.
+func read(v any) any {
+	c := copyNode(v)
+	if ev, ok := c.(*tools.Event); ok && ev.EndDate == ev.EventDate {
+		ev.EndDate = ""
+	}
+	return c
+}
+
+// resolveNamespace returns the namespace carried by ctx, falling back to
+// the Querier's namespace and then to tools.DefaultNamespace.
+func (q *Querier) resolveNamespace(ctx context.Context) string {
+	if ns := tools.NamespaceFromContext(ctx); ns != "" {
+		return ns
+	}
+	if q.namespace != "" {
+		return q.namespace
+	}
+	return tools.DefaultNamespace
+}
+
+// hidden reports whether the node id is archived and ctx does not ask for
+// archived nodes.
+func (q *Querier) hidden(ctx context.Context, id string) bool {
+	_, archived := q.g.archived[id]
+	return archived && !tools.IncludeArchivedFromContext(ctx)
+}
+
+// logID returns a new ID for a log entry with prefix, such as "aud". IDs
+// sort in recording order.
+func (q *Querier) logID(prefix string, now time.Time) string {
+	return fmt.Sprintf("%s:%019d:%06d", prefix, now.UnixNano(), q.seq.Add(1)%1000000)
+}
+
+type pendingKey struct{}
+
+// Atomic calls fn with a context in which the writes it makes through q are
+// kept when fn returns nil and discarded when it returns an error. Changes
+// are published once fn succeeds. Writes other goroutines make through q
+// while fn runs are discarded with them.
+func (q *Querier) Atomic(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, nested := ctx.Value(pendingKey{}).(*[]memory.Change); nested {
+		return fn(ctx)
+	}
+	q.mu.Lock()
+	saved := q.g.clone()
+	q.mu.Unlock()
+
+	var pending []memory.Change
+	if err := fn(context.WithValue(ctx, pendingKey{}, &pending)); err != nil {
+		q.mu.Lock()
+		q.g = saved
+		q.mu.Unlock()
+		return err
+	}
+	for _, c := range pending {
+		q.changes.Publish(c)
+	}
+	return nil
+}
+
+// publish records a successful write on the change feed, once the
+// enclosing Atomic call, if any, succeeds.
+func (q *Querier) publish(ctx context.Context, op, nodeID string, err error) {
+	if err != nil {
+		return
+	}
+	c := memory.Change{Op: op, NodeID: nodeID, Namespace: q.resolveNamespace(ctx), At: time.Now().Unix()}
+	if pending, ok := ctx.Value(pendingKey{}).(*[]memory.Change); ok {
+		*pending = append(*pending, c)
+		return
+	}
+	q.changes.Publish(c)
+}
+
+// audit records a successful write in the audit log, as memory.Client does.
+// q.mu must be held.
+func (q *Querier) audit(ctx context.Context, op, agent string, err error, nodeIDs ...string) {
+	if err != nil {
+		return
+	}
+	tool, ctxAgent := tools.AuditSourceFromContext(ctx)
+	if agent == "" {
+		agent = ctxAgent
+	}
+	if nodeIDs == nil {
+		nodeIDs = []string{}
+	}
+	now := time.Now()
+	q.g.audit = append(q.g.audit, auditRow{
+		AuditEntry: tools.AuditEntry{ID: q.logID("aud", now), At: now.Unix(), Op: op, Tool: tool, SourceAgent: agent, NodeIDs: nodeIDs},
+		ns:         q.resolveNamespace(ctx),
+	})
+}
+
+// recordHistory records a change to one field of a node. q.mu must be
+// held.
+func (q *Querier) recordHistory(ctx context.Context, nodeID, field, oldValue, newValue, reason, relatedID string) {
+	tool, agent := tools.AuditSourceFromContext(ctx)
+	now := time.Now()
+	q.g.history = append(q.g.history, historyRow{
+		HistoryEntry: tools.HistoryEntry{
+			ID: q.logID("hist", now), NodeID: nodeID, At: now.Unix(), Field: field, OldValue: oldValue, NewValue: newValue,
+			Reason: reason, RelatedID: relatedID, Tool: tool, SourceAgent: agent,
+		},
+		ns: q.resolveNamespace(ctx),
+	})
+}
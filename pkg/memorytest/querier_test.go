@@ -0,0 +1,434 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package memorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+func TestStoreUsesClientIDs(t *testing.T) {
+	ctx := context.Background()
+	q := New()
+
+	fact, err := q.StoreFact(ctx, tools.StoreFactRequest{Content: "Prefers Go", Category: "preference"})
+	require.NoError(t, err)
+	assert.Equal(t, memory.FactID("Prefers Go", "preference"), fact.ID)
+	assert.Equal(t, 0.8, fact.Confidence)
+
+	dec, err := q.StoreDecision(ctx, tools.StoreDecisionRequest{Title: "Use Cozo", Rationale: "Embedded"})
+	require.NoError(t, err)
+	assert.Equal(t, memory.DecisionID("Use Cozo", "Embedded"), dec.ID)
+	assert.Equal(t, "active", dec.Status)
+
+	ent, err := q.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Kraklabs", Kind: "spaceship"})
+	require.NoError(t, err)
+	assert.Equal(t, memory.EntityID("Kraklabs", "other"), ent.ID)
+
+	evt, err := q.StoreEvent(ctx, tools.StoreEventRequest{Title: "Launch", EventDate: "2026-01-10"})
+	require.NoError(t, err)
+	assert.Equal(t, memory.EventID("Launch", "2026-01-10"), evt.ID)
+
+	top, err := q.StoreTopic(ctx, tools.StoreTopicRequest{Name: "Billing"})
+	require.NoError(t, err)
+	assert.Equal(t, memory.TopicID("Billing"), top.ID)
+
+	other, err := q.StoreFact(tools.WithNamespace(ctx, "work"), tools.StoreFactRequest{Content: "Prefers Go", Category: "preference"})
+	require.NoError(t, err)
+	assert.Equal(t, memory.NamespacedID(memory.FactID("Prefers Go", "preference"), "work"), other.ID)
+	assert.NotEqual(t, fact.ID, other.ID)
+}
+
+func TestStoreValidation(t *testing.T) {
+	ctx := context.Background()
+	q := New()
+
+	_, err := q.StoreFact(ctx, tools.StoreFactRequest{})
+	assert.EqualError(t, err, "fact content is required")
+	_, err = q.StoreDecision(ctx, tools.StoreDecisionRequest{Title: "Use Cozo"})
+	assert.EqualError(t, err, "decision rationale is required")
+	_, err = q.StoreDecision(ctx, tools.StoreDecisionRequest{Title: "Use Cozo", Rationale: "Embedded", Status: "reversed"})
+	assert.EqualError(t, err, `invalid initial status "reversed"; must be active or proposed`)
+	_, err = q.StoreEntity(ctx, tools.StoreEntityRequest{})
+	assert.EqualError(t, err, "entity name is required")
+	_, err = q.StoreEvent(ctx, tools.StoreEventRequest{})
+	assert.EqualError(t, err, "event title is required")
+	_, err = q.StoreTopic(ctx, tools.StoreTopicRequest{})
+	assert.EqualError(t, err, "topic name is required")
+}
+
+func TestStoreFactDedup(t *testing.T) {
+	ctx := context.Background()
+	q := New()
+
+	first, err := q.StoreFact(ctx, tools.StoreFactRequest{Content: "Lives in Lisbon", Category: "personal"})
+	require.NoError(t, err)
+	dup, err := q.StoreFact(ctx, tools.StoreFactRequest{Content: "  lives in lisbon ", Category: "general"})
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, dup.ID)
+	assert.Equal(t, 1.0, dup.DuplicateSimilarity)
+
+	forced, err := q.StoreFact(ctx, tools.StoreFactRequest{Content: "lives in lisbon", Category: "general", SkipDedup: true})
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, forced.ID)
+}
+
+func TestUpdateStatusTransitions(t *testing.T) {
+	ctx := context.Background()
+	q := New()
+	dec, err := q.StoreDecision(ctx, tools.StoreDecisionRequest{Title: "Use Cozo", Rationale: "Embedded"})
+	require.NoError(t, err)
+
+	require.NoError(t, q.UpdateStatus(tools.WithChangeReason(ctx, "replaced"), dec.ID, "superseded"))
+	assert.Equal(t, memory.CheckDecisionTransition("superseded", "proposed"), q.UpdateStatus(ctx, dec.ID, "proposed"))
+	assert.ErrorContains(t, q.UpdateStatus(ctx, dec.ID, "done"), `invalid status "done"`)
+
+	history, err := q.GetNodeHistory(ctx, dec.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "status", history[0].Field)
+	assert.Equal(t, "active", history[0].OldValue)
+	assert.Equal(t, "superseded", history[0].NewValue)
+	assert.Equal(t, "replaced", history[0].Reason)
+}
+
+func TestAliasesAndRename(t *testing.T) {
+	ctx := context.Background()
+	q := New()
+	pg, err := q.StoreEntity(ctx, tools.StoreEntityRequest{Name: "PostgreSQL", Kind: "technology"})
+	require.NoError(t, err)
+	my, err := q.StoreEntity(ctx, tools.StoreEntityRequest{Name: "MySQL", Kind: "technology"})
+	require.NoError(t, err)
+
+	require.NoError(t, q.AddAlias(ctx, pg.ID, " Postgres "))
+	found, err := q.StoreEntity(ctx, tools.StoreEntityRequest{Name: "POSTGRES", Kind: "company"})
+	require.NoError(t, err)
+	assert.Equal(t, pg.ID, found.ID)
+
+	assert.EqualError(t, q.AddAlias(ctx, my.ID, "postgres"), `alias "postgres" already refers to `+pg.ID)
+	assert.EqualError(t, q.AddAlias(ctx, "fact:1", "x"), `alias requires an entity ID (prefix 'ent:'), got "fact:1"`)
+	assert.EqualError(t, q.RenameEntity(ctx, my.ID, "postgres"), `"postgres" already names `+pg.ID+"; merge the entities instead")
+
+	require.NoError(t, q.RenameEntity(ctx, my.ID, "MariaDB"))
+	node, err := q.GetNodeByID(ctx, my.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "MariaDB", node.(*tools.Entity).Name)
+	found, err = q.StoreEntity(ctx, tools.StoreEntityRequest{Name: "mysql"})
+	require.NoError(t, err)
+	assert.Equal(t, my.ID, found.ID)
+}
+
+func TestFindEntityByName(t *testing.T) {
+	ctx := context.Background()
+	q := New()
+	pg, err := q.StoreEntity(ctx, tools.StoreEntityRequest{Name: "PostgreSQL", Kind: "technology"})
+	require.NoError(t, err)
+	require.NoError(t, q.AddAlias(ctx, pg.ID, "pg"))
+	fact, err := q.StoreFact(ctx, tools.StoreFactRequest{Content: "Billing runs on Postgres", Category: "technical"})
+	require.NoError(t, err)
+	require.NoError(t, q.AddRelationship(ctx, "mie_fact_entity", map[string]string{"fact_id": fact.ID, "entity_id": pg.ID}))
+
+	for _, name := range []string{"postgresql", "PG", "Postgress QL"} {
+		ent, err := q.FindEntityByName(ctx, name)
+		require.NoError(t, err)
+		if assert.NotNil(t, ent, name) {
+			assert.Equal(t, pg.ID, ent.ID, name)
+		}
+	}
+	ent, err := q.FindEntityByName(ctx, "Redis")
+	require.NoError(t, err)
+	assert.Nil(t, ent)
+
+	result, err := tools.Query(ctx, q, map[string]any{"query": "postgres", "mode": "graph", "node_id": "name:postgress", "traversal": "related_facts"})
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Text)
+	assert.Contains(t, result.Text, fact.ID)
+}
+
+func TestRelationshipsAndTraversal(t *testing.T) {
+	ctx := context.Background()
+	q := New()
+	fact, err := q.StoreFact(ctx, tools.StoreFactRequest{Content: "Billing runs on Postgres", Category: "technical"})
+	require.NoError(t, err)
+	pg, err := q.StoreEntity(ctx, tools.StoreEntityRequest{Name: "PostgreSQL", Kind: "technology"})
+	require.NoError(t, err)
+	stripe, err := q.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Stripe", Kind: "company"})
+	require.NoError(t, err)
+
+	require.NoError(t, q.AddRelationship(ctx, "mie_fact_entity", map[string]string{"fact_id": fact.ID, "entity_id": stripe.ID, "weight": "0.4"}))
+	require.NoError(t, q.AddRelationship(ctx, "mie_fact_entity", map[string]string{"fact_id": fact.ID, "entity_id": pg.ID, "source_agent": "claude"}))
+	assert.EqualError(t, q.AddRelationship(ctx, "mie_fact_entity", map[string]string{"fact_id": fact.ID}),
+		`missing required field "entity_id" for edge type mie_fact_entity`)
+	assert.EqualError(t, q.AddRelationship(ctx, "mie_fact_entity", map[string]string{"fact_id": fact.ID, "entity_id": pg.ID, "weight": "2"}),
+		`invalid weight "2": must be greater than 0 and at most 1`)
+	assert.EqualError(t, q.AddRelationship(ctx, "mie_nope", nil), "unknown edge type: mie_nope")
+
+	entities, err := q.GetRelatedEntities(ctx, fact.ID)
+	require.NoError(t, err)
+	require.Len(t, entities, 2)
+	assert.Equal(t, pg.ID, entities[0].ID)
+	assert.Equal(t, "claude", entities[0].Edge.SourceAgent)
+	assert.Equal(t, 0.4, entities[1].Edge.Weight)
+
+	facts, err := q.GetFactsAboutEntity(ctx, pg.ID)
+	require.NoError(t, err)
+	require.Len(t, facts, 1)
+	assert.Equal(t, fact.ID, facts[0].ID)
+
+	require.NoError(t, q.SetArchived(ctx, stripe.ID, true))
+	edges, err := q.GetNodeEdges(ctx, fact.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []tools.GraphEdge{{Type: "fact_entity", SourceID: fact.ID, TargetID: pg.ID, Weight: 1}}, edges)
+
+	require.NoError(t, q.RemoveRelationship(ctx, "mie_fact_entity", map[string]string{"fact_id": fact.ID, "entity_id": pg.ID}))
+	edges, err = q.GetNodeEdges(tools.WithIncludeArchived(ctx, true), fact.ID)
+	require.NoError(t, err)
+	require.Len(t, edges, 1)
+	assert.Equal(t, stripe.ID, edges[0].TargetID)
+}
+
+func TestInvalidateFact(t *testing.T) {
+	ctx := context.Background()
+	q := New()
+	old, err := q.StoreFact(ctx, tools.StoreFactRequest{Content: "Lives in Lisbon", Category: "personal"})
+	require.NoError(t, err)
+	repl, err := q.StoreFact(ctx, tools.StoreFactRequest{Content: "Lives in Porto", Category: "personal"})
+	require.NoError(t, err)
+
+	require.NoError(t, q.InvalidateFact(ctx, old.ID, repl.ID, "moved"))
+	chain, err := q.GetInvalidationChain(ctx, repl.ID)
+	require.NoError(t, err)
+	require.Len(t, chain, 1)
+	assert.Equal(t, "moved", chain[0].Reason)
+	assert.Equal(t, "Lives in Lisbon", chain[0].OldContent)
+
+	results, err := q.ExactSearch(ctx, "lives in", nil, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, repl.ID, results[0].ID)
+}
+
+func TestPreferenceKeys(t *testing.T) {
+	ctx := context.Background()
+	q := New()
+	first, err := q.StoreFact(ctx, tools.StoreFactRequest{Content: "Edits in Vim", Key: " Editor"})
+	require.NoError(t, err)
+	assert.Equal(t, "preference", first.Category)
+	assert.Equal(t, "editor", first.Key)
+	_, err = q.StoreFact(ctx, tools.StoreFactRequest{Content: "Uses tabs", Category: "technical", Key: "indent"})
+	assert.Error(t, err)
+
+	second, err := q.StoreFact(ctx, tools.StoreFactRequest{Content: "Edits in Helix", Key: "editor"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{first.ID}, second.Replaces)
+
+	chain, err := q.GetInvalidationChain(ctx, second.ID)
+	require.NoError(t, err)
+	require.Len(t, chain, 1)
+	assert.Equal(t, first.ID, chain[0].OldFactID)
+
+	prefs, err := q.ListPreferences(ctx)
+	require.NoError(t, err)
+	require.Len(t, prefs, 1)
+	assert.Equal(t, second.ID, prefs[0].ID)
+	assert.Empty(t, prefs[0].Replaces)
+}
+
+func TestAntiFacts(t *testing.T) {
+	ctx := context.Background()
+	q := New()
+	asserted, err := q.StoreFact(ctx, tools.StoreFactRequest{Content: "Uses Docker", Category: "technical"})
+	require.NoError(t, err)
+	negated, err := q.StoreFact(ctx, tools.StoreFactRequest{Content: "Uses Docker", Category: "technical", Negated: true})
+	require.NoError(t, err)
+	assert.Equal(t, memory.NegatedFactID("Uses Docker", "technical"), negated.ID)
+	assert.NotEqual(t, asserted.ID, negated.ID)
+	assert.Zero(t, negated.DuplicateSimilarity)
+	assert.True(t, negated.Negated)
+
+	again, err := q.StoreFact(ctx, tools.StoreFactRequest{Content: "uses docker", Negated: true})
+	require.NoError(t, err)
+	assert.Equal(t, negated.ID, again.ID)
+	assert.Equal(t, 1.0, again.DuplicateSimilarity)
+
+	node, err := q.GetNodeByID(ctx, negated.ID)
+	require.NoError(t, err)
+	assert.True(t, node.(*tools.Fact).Negated)
+}
+
+func TestMergeEntities(t *testing.T) {
+	ctx := context.Background()
+	q := New()
+	fact, err := q.StoreFact(ctx, tools.StoreFactRequest{Content: "Uses Postgres", Category: "technical"})
+	require.NoError(t, err)
+	pg, err := q.StoreEntity(ctx, tools.StoreEntityRequest{Name: "PostgreSQL", Kind: "technology"})
+	require.NoError(t, err)
+	dup, err := q.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Postgres", Kind: "technology"})
+	require.NoError(t, err)
+	require.NoError(t, q.AddRelationship(ctx, "mie_fact_entity", map[string]string{"fact_id": fact.ID, "entity_id": dup.ID}))
+
+	assert.EqualError(t, q.MergeEntities(ctx, pg.ID, pg.ID), `cannot merge entity "`+pg.ID+`" into itself`)
+	require.NoError(t, q.MergeEntities(ctx, pg.ID, dup.ID))
+
+	_, err = q.GetNodeByID(ctx, dup.ID)
+	assert.EqualError(t, err, `node "`+dup.ID+`" not found`)
+	entities, err := q.GetRelatedEntities(ctx, fact.ID)
+	require.NoError(t, err)
+	require.Len(t, entities, 1)
+	assert.Equal(t, pg.ID, entities[0].ID)
+	found, err := q.StoreEntity(ctx, tools.StoreEntityRequest{Name: "postgres"})
+	require.NoError(t, err)
+	assert.Equal(t, pg.ID, found.ID)
+}
+
+func TestAtomicRollsBack(t *testing.T) {
+	ctx := context.Background()
+	q := New()
+	changes, cancel := q.Changes().Subscribe(8)
+	defer cancel()
+
+	errBoom := errors.New("boom")
+	err := q.Atomic(ctx, func(ctx context.Context) error {
+		if _, err := q.StoreFact(ctx, tools.StoreFactRequest{Content: "Never kept"}); err != nil {
+			return err
+		}
+		return errBoom
+	})
+	assert.ErrorIs(t, err, errBoom)
+	stats, err := q.GetStats(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, stats.TotalFacts)
+	select {
+	case c := <-changes:
+		t.Fatalf("change published for a discarded write: %+v", c)
+	default:
+	}
+
+	require.NoError(t, q.Atomic(ctx, func(ctx context.Context) error {
+		_, err := q.StoreFact(ctx, tools.StoreFactRequest{Content: "Kept"})
+		return err
+	}))
+	assert.Equal(t, memory.ChangeCreated, (<-changes).Op)
+}
+
+func TestListNodesPages(t *testing.T) {
+	ctx := context.Background()
+	q := New()
+	for _, name := range []string{"Alpha", "Bravo", "Charlie", "Delta", "Echo"} {
+		_, err := q.StoreTopic(ctx, tools.StoreTopicRequest{Name: name})
+		require.NoError(t, err)
+	}
+	require.NoError(t, q.SetArchived(ctx, memory.TopicID("Echo"), true))
+
+	opts := tools.ListOptions{NodeType: "topic", SortBy: "name", SortOrder: "asc", Limit: 2}
+	var names []string
+	for {
+		nodes, total, err := q.ListNodes(ctx, opts)
+		require.NoError(t, err)
+		assert.Equal(t, 4, total)
+		for _, n := range nodes {
+			names = append(names, n.(*tools.Topic).Name)
+		}
+		if len(nodes) < opts.Limit {
+			break
+		}
+		last := nodes[len(nodes)-1].(*tools.Topic)
+		opts.After = &tools.ListCursor{Value: last.Name, ID: last.ID}
+	}
+	assert.Equal(t, []string{"Alpha", "Bravo", "Charlie", "Delta"}, names)
+
+	_, _, err := q.ListNodes(ctx, tools.ListOptions{NodeType: "widget"})
+	assert.EqualError(t, err, "unknown node type: widget")
+	_, _, err = q.ListNodes(ctx, tools.ListOptions{NodeType: "topic", SortBy: "name :limit 1000"})
+	assert.ErrorContains(t, err, "invalid sort_by")
+}
+
+func TestSearch(t *testing.T) {
+	ctx := context.Background()
+	q := New()
+	_, err := q.StoreFact(ctx, tools.StoreFactRequest{Content: "The API is written in Go", Category: "technical"})
+	require.NoError(t, err)
+	verified, err := q.StoreFact(ctx, tools.StoreFactRequest{Content: "Go services deploy on Fridays", Category: "technical"})
+	require.NoError(t, err)
+	require.NoError(t, q.SetVerified(ctx, verified.ID, "ana", true))
+	_, err = q.StoreEntity(ctx, tools.StoreEntityRequest{Name: "Golang", Kind: "technology"})
+	require.NoError(t, err)
+
+	exact, err := q.ExactSearch(ctx, "GO", []string{"fact"}, 0)
+	require.NoError(t, err)
+	require.Len(t, exact, 2)
+	assert.Equal(t, verified.ID, exact[0].ID)
+
+	meeting, err := q.StoreFact(ctx, tools.StoreFactRequest{Content: "Weekly Reunión on Mondays", Category: "general"})
+	require.NoError(t, err)
+	for _, query := range []string{"reunion", "REUNIÓN"} {
+		folded, err := q.ExactSearch(ctx, query, []string{"fact"}, 0)
+		require.NoError(t, err)
+		require.Len(t, folded, 1, query)
+		assert.Equal(t, meeting.ID, folded[0].ID, query)
+	}
+
+	fts, err := q.FullTextSearch(ctx, "go api", nil, 0)
+	require.NoError(t, err)
+	require.Len(t, fts, 2)
+	assert.Equal(t, 1.0, fts[0].Score)
+
+	_, err = q.SemanticSearch(ctx, "go", nil, 0)
+	assert.EqualError(t, err, "semantic search requires embeddings to be enabled")
+
+	result, err := tools.Query(ctx, q, map[string]any{"query": "golang", "mode": "exact"})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Contains(t, result.Text, "Golang")
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	ctx := context.Background()
+	q := New()
+	_, err := q.StoreFact(ctx, tools.StoreFactRequest{Content: "Before"})
+	require.NoError(t, err)
+	snap, err := q.CreateSnapshot(ctx, "v1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, snap.Stats["facts"])
+	_, err = q.StoreFact(ctx, tools.StoreFactRequest{Content: "After"})
+	require.NoError(t, err)
+
+	checkpoint, err := q.RestoreSnapshot(ctx, snap.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, checkpoint.Stats["facts"])
+	stats, err := q.GetStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.TotalFacts)
+
+	_, err = q.RestoreSnapshot(tools.WithNamespace(ctx, "work"), snap.ID)
+	assert.EqualError(t, err, "snapshot "+snap.ID+" not found")
+}
+
+func TestAttachments(t *testing.T) {
+	ctx := context.Background()
+	q := New()
+	fact, err := q.StoreFact(ctx, tools.StoreFactRequest{Content: "Has a diagram"})
+	require.NoError(t, err)
+
+	att, err := q.Attach(ctx, fact.ID, "notes.txt", "", []byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, "text/plain; charset=utf-8", att.MimeType)
+	_, data, err := q.ReadAttachment(ctx, att.Hash)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+
+	_, err = q.Attach(ctx, "fact:missing", "", "", []byte("x"))
+	assert.EqualError(t, err, `node "fact:missing" not found`)
+	require.NoError(t, q.Detach(ctx, fact.ID, att.Hash))
+	_, _, err = q.ReadAttachment(ctx, att.Hash)
+	assert.EqualError(t, err, "attachment "+att.Hash+" not found")
+}
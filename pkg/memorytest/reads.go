@@ -0,0 +1,770 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package memorytest
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// GetNodeByID returns the node with the given ID, in any namespace.
+func (q *Querier) GetNodeByID(ctx context.Context, nodeID string) (any, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	n, ok := q.g.nodes[nodeID]
+	if !ok {
+		return nil, fmt.Errorf("node %q not found", nodeID)
+	}
+	return read(n.value), nil
+}
+
+// FindEntityByName returns the entity of the namespace with the given name
+// or alias, ignoring case, or else the entity whose name or alias is most
+// like it by memory.BestNameMatch. It returns nil when nothing is close
+// enough.
+func (q *Querier) FindEntityByName(ctx context.Context, name string) (*tools.Entity, error) {
+	ns := q.resolveNamespace(ctx)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	id := q.entityNamed(ns, strings.TrimSpace(name), "")
+	if id == "" {
+		var members []tools.DuplicateMember
+		index := map[string]int{}
+		for _, n := range q.nodesOf(ns, "entity") {
+			ent := n.(*tools.Entity)
+			index[ent.ID] = len(members)
+			members = append(members, tools.DuplicateMember{ID: ent.ID, Name: ent.Name})
+		}
+		for key, a := range q.g.aliases {
+			if i, ok := index[a.entityID]; ok && key.ns == ns {
+				members[i].Aliases = append(members[i].Aliases, key.alias)
+			}
+		}
+		m, _ := memory.BestNameMatch(name, members, memory.DefaultDuplicateThreshold)
+		id = m.ID
+	}
+	if id == "" {
+		return nil, nil
+	}
+	ent, _ := read(q.g.nodes[id].value).(*tools.Entity)
+	return ent, nil
+}
+
+// ListPreferences returns the valid facts of the namespace that have a
+// key, ordered by key and then newest first. Archived facts are left out
+// unless ctx includes archived nodes.
+func (q *Querier) ListPreferences(ctx context.Context) ([]tools.Fact, error) {
+	ns := q.resolveNamespace(ctx)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var facts []tools.Fact
+	for _, n := range q.nodesOf(ns, "fact") {
+		f := n.(*tools.Fact)
+		if f.Valid && f.Key != "" && !q.hidden(ctx, f.ID) {
+			facts = append(facts, *read(f).(*tools.Fact))
+		}
+	}
+	slices.SortFunc(facts, func(a, b tools.Fact) int {
+		return cmp.Or(strings.Compare(a.Key, b.Key), cmp.Compare(b.CreatedAt, a.CreatedAt), strings.Compare(a.ID, b.ID))
+	})
+	return facts, nil
+}
+
+// nodesOf returns the nodes of type typ in namespace ns, archived or
+// not, ordered by ID. q.mu must be held.
+func (q *Querier) nodesOf(ns, typ string) []any {
+	var nodes []any
+	for _, n := range q.g.nodes {
+		if n.ns == ns && nodeType(n.value) == typ {
+			nodes = append(nodes, n.value)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodeID(nodes[i]) < nodeID(nodes[j]) })
+	return nodes
+}
+
+// ListNodes returns a page of the nodes of opts.NodeType in the namespace
+// and the number of nodes that match opts on all pages. opts.TopicName is
+// ignored, as it is by the client.
+func (q *Querier) ListNodes(ctx context.Context, opts tools.ListOptions) ([]any, int, error) {
+	if opts.Limit <= 0 {
+		opts.Limit = 20
+	}
+	switch opts.NodeType {
+	case "fact", "decision", "entity", "event", "topic":
+	default:
+		return nil, 0, fmt.Errorf("unknown node type: %s", opts.NodeType)
+	}
+	sortBy := opts.SortBy
+	if sortBy == "" {
+		sortBy = "created_at"
+	}
+	if err := memory.CheckSortColumn(opts.NodeType, sortBy); err != nil {
+		return nil, 0, err
+	}
+	desc := opts.SortOrder != "asc"
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	type row struct {
+		node  any
+		id    string
+		value any
+	}
+	var rows []row
+	for _, n := range q.nodesOf(q.resolveNamespace(ctx), opts.NodeType) {
+		if !q.listed(n, opts) {
+			continue
+		}
+		rows = append(rows, row{node: n, id: nodeID(n), value: fieldValue(n, sortBy)})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		c := cmp.Or(compareValues(rows[i].value, rows[j].value), strings.Compare(rows[i].id, rows[j].id))
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
+	total := len(rows)
+
+	if opts.After != nil {
+		rows = slices.DeleteFunc(rows, func(r row) bool {
+			c := cmp.Or(compareValues(r.value, opts.After.Value), strings.Compare(r.id, opts.After.ID))
+			if desc {
+				return c >= 0
+			}
+			return c <= 0
+		})
+	}
+	rows = rows[min(opts.Offset, len(rows)):]
+	rows = rows[:min(opts.Limit, len(rows))]
+
+	var nodes []any
+	for _, r := range rows {
+		n := read(r.node)
+		if opts.IncludeDegree {
+			q.setDegree(n)
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, total, nil
+}
+
+// listed reports whether the stored node n matches the filters of opts.
+// q.mu must be held.
+func (q *Querier) listed(n any, opts tools.ListOptions) bool {
+	switch n := n.(type) {
+	case *tools.Fact:
+		if (opts.Category != "" && n.Category != opts.Category) || (opts.ValidOnly && !n.Valid) {
+			return false
+		}
+	case *tools.Decision:
+		if opts.Status != "" && n.Status != opts.Status {
+			return false
+		}
+	case *tools.Entity:
+		if opts.Kind != "" && n.Kind != opts.Kind {
+			return false
+		}
+	}
+	if opts.SourceAgent != "" && opts.NodeType != "topic" && sourceAgent(n) != opts.SourceAgent {
+		return false
+	}
+	if opts.SourceConversation != "" && sourceConversation(n) != opts.SourceConversation {
+		switch opts.NodeType {
+		case "fact", "decision", "event":
+			return false
+		}
+	}
+	if _, archived := q.g.archived[nodeID(n)]; archived && !opts.IncludeArchived {
+		return false
+	}
+	return inTimeRange(n, opts.TimeRange)
+}
+
+// inTimeRange reports whether the stored node n was created within tr and,
+// for an event, overlaps its event dates.
+func inTimeRange(n any, tr tools.TimeRange) bool {
+	created := createdAt(n)
+	if (tr.CreatedAfter != 0 && created < tr.CreatedAfter) || (tr.CreatedBefore != 0 && created >= tr.CreatedBefore) {
+		return false
+	}
+	ev, ok := n.(*tools.Event)
+	if !ok {
+		return true
+	}
+	end := ev.EndDate
+	if end == "" {
+		end = ev.EventDate
+	}
+	return (tr.EventDateTo == "" || ev.EventDate <= tr.EventDateTo) && (tr.EventDateFrom == "" || end >= tr.EventDateFrom)
+}
+
+func sourceAgent(n any) string {
+	switch n := n.(type) {
+	case *tools.Fact:
+		return n.SourceAgent
+	case *tools.Decision:
+		return n.SourceAgent
+	case *tools.Entity:
+		return n.SourceAgent
+	case *tools.Event:
+		return n.SourceAgent
+	}
+	return ""
+}
+
+func sourceConversation(n any) string {
+	switch n := n.(type) {
+	case *tools.Fact:
+		return n.SourceConversation
+	case *tools.Decision:
+		return n.SourceConversation
+	case *tools.Event:
+		return n.SourceConversation
+	}
+	return ""
+}
+
+// fieldValue returns the JSON value of the field of node named field, the
+// form tools.ListCursor carries sort keys in, or nil.
+func fieldValue(node any, field string) any {
+	data, err := json.Marshal(node)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+	return fields[field]
+}
+
+// compareValues orders JSON values: nil first, then booleans, numbers, and
+// strings, each in their natural order.
+func compareValues(a, b any) int {
+	rank := func(v any) int {
+		switch v.(type) {
+		case nil:
+			return 0
+		case bool:
+			return 1
+		case float64:
+			return 2
+		}
+		return 3
+	}
+	if c := cmp.Compare(rank(a), rank(b)); c != 0 {
+		return c
+	}
+	switch a := a.(type) {
+	case bool:
+		return cmp.Compare(boolRank(a), boolRank(b.(bool)))
+	case float64:
+		return cmp.Compare(a, b.(float64))
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// degree returns the number of edges of node id, in either direction.
+// q.mu must be held.
+func (q *Querier) degree(id string) int {
+	n := 0
+	for _, rows := range q.g.edges {
+		for key := range rows {
+			if key[0] == id {
+				n++
+			}
+			if key[1] == id {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// setDegree sets the Degree of the node value n. q.mu must be held.
+func (q *Querier) setDegree(n any) {
+	d := q.degree(nodeID(n))
+	switch n := n.(type) {
+	case *tools.Fact:
+		n.Degree = &d
+	case *tools.Decision:
+		n.Degree = &d
+	case *tools.Entity:
+		n.Degree = &d
+	case *tools.Event:
+		n.Degree = &d
+	case *tools.Topic:
+		n.Degree = &d
+	}
+}
+
+// --- Graph traversal ---
+
+// edgesOf returns the edges of table whose key column side is id, with the
+// node at the other end, strongest and then newest first. Edges whose other
+// node does not exist are left out. q.mu must be held.
+func (q *Querier) edgesOf(table string, side int, id string) []linked {
+	var out []linked
+	for key, e := range q.g.edges[table] {
+		if key[side] != id {
+			continue
+		}
+		if n, ok := q.g.nodes[key[1-side]]; ok {
+			out = append(out, linked{value: read(n.value), edge: e})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		return cmp.Or(
+			cmp.Compare(b.edge.weight, a.edge.weight),
+			cmp.Compare(b.edge.createdAt, a.edge.createdAt),
+			strings.Compare(nodeID(a.value), nodeID(b.value)),
+		) < 0
+	})
+	return out
+}
+
+// linked is a node reached through an edge.
+type linked struct {
+	value any
+	edge  edge
+}
+
+func (e edge) meta() *tools.EdgeMeta {
+	return &tools.EdgeMeta{Weight: e.weight, SourceAgent: e.sourceAgent, CreatedAt: e.createdAt}
+}
+
+// GetRelatedEntities returns the entities linked to a fact, strongest edges
+// first.
+func (q *Querier) GetRelatedEntities(ctx context.Context, factID string) ([]tools.Entity, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var entities []tools.Entity
+	for _, l := range q.edgesOf("mie_fact_entity", 0, factID) {
+		if ent, ok := l.value.(*tools.Entity); ok {
+			ent.Edge = l.edge.meta()
+			entities = append(entities, *ent)
+		}
+	}
+	return entities, nil
+}
+
+// GetFactsAboutEntity returns the facts linked to an entity, strongest
+// edges first.
+func (q *Querier) GetFactsAboutEntity(ctx context.Context, entityID string) ([]tools.Fact, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var facts []tools.Fact
+	for _, l := range q.edgesOf("mie_fact_entity", 1, entityID) {
+		if f, ok := l.value.(*tools.Fact); ok {
+			f.Edge = l.edge.meta()
+			facts = append(facts, *f)
+		}
+	}
+	return facts, nil
+}
+
+// GetRelatedFacts is GetFactsAboutEntity.
+func (q *Querier) GetRelatedFacts(ctx context.Context, entityID string) ([]tools.Fact, error) {
+	return q.GetFactsAboutEntity(ctx, entityID)
+}
+
+// GetDecisionEntities returns the entities involved in a decision, with
+// their roles, strongest edges first.
+func (q *Querier) GetDecisionEntities(ctx context.Context, decisionID string) ([]tools.EntityWithRole, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var entities []tools.EntityWithRole
+	for _, l := range q.edgesOf("mie_decision_entity", 0, decisionID) {
+		if ent, ok := l.value.(*tools.Entity); ok {
+			ent.Edge = l.edge.meta()
+			entities = append(entities, tools.EntityWithRole{Entity: *ent, Role: l.edge.label})
+		}
+	}
+	return entities, nil
+}
+
+// GetEntityDecisions returns the decisions an entity is involved in,
+// strongest edges first.
+func (q *Querier) GetEntityDecisions(ctx context.Context, entityID string) ([]tools.Decision, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var decisions []tools.Decision
+	for _, l := range q.edgesOf("mie_decision_entity", 1, entityID) {
+		if d, ok := l.value.(*tools.Decision); ok {
+			d.Edge = l.edge.meta()
+			decisions = append(decisions, *d)
+		}
+	}
+	return decisions, nil
+}
+
+// GetInvalidationChain returns the invalidations that replaced factID or
+// that it replaced. Both facts must exist.
+func (q *Querier) GetInvalidationChain(ctx context.Context, factID string) ([]tools.Invalidation, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var chain []tools.Invalidation
+	for key, e := range q.g.edges["mie_invalidates"] {
+		if key[0] != factID && key[1] != factID {
+			continue
+		}
+		newFact, okNew := q.g.nodes[key[0]].value.(*tools.Fact)
+		oldFact, okOld := q.g.nodes[key[1]].value.(*tools.Fact)
+		if !okNew || !okOld {
+			continue
+		}
+		chain = append(chain, tools.Invalidation{
+			NewFactID:  key[0],
+			OldFactID:  key[1],
+			Reason:     e.label,
+			OldContent: oldFact.Content,
+			NewContent: newFact.Content,
+			Edge:       e.meta(),
+		})
+	}
+	sort.Slice(chain, func(i, j int) bool {
+		return cmp.Or(strings.Compare(chain[i].NewFactID, chain[j].NewFactID), strings.Compare(chain[i].OldFactID, chain[j].OldFactID)) < 0
+	})
+	return chain, nil
+}
+
+// GetNodeEdges returns every edge that starts or ends at nodeID, by edge
+// table. Edges to archived nodes are left out unless ctx includes archived
+// nodes.
+func (q *Querier) GetNodeEdges(ctx context.Context, nodeID string) ([]tools.GraphEdge, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	tables := slices.Sorted(maps.Keys(q.g.edges))
+	var edges []tools.GraphEdge
+	for _, table := range tables {
+		var rows []tools.GraphEdge
+		for key, e := range q.g.edges[table] {
+			var other string
+			switch nodeID {
+			case key[0]:
+				other = key[1]
+			case key[1]:
+				other = key[0]
+			default:
+				continue
+			}
+			if q.hidden(ctx, other) {
+				continue
+			}
+			rows = append(rows, tools.GraphEdge{
+				Type:     strings.TrimPrefix(table, "mie_"),
+				SourceID: key[0],
+				TargetID: key[1],
+				Label:    e.label,
+				Weight:   e.weight,
+			})
+		}
+		sort.Slice(rows, func(i, j int) bool {
+			return cmp.Or(strings.Compare(rows[i].SourceID, rows[j].SourceID), strings.Compare(rows[i].TargetID, rows[j].TargetID)) < 0
+		})
+		edges = append(edges, rows...)
+	}
+	return edges, nil
+}
+
+// --- Stats ---
+
+// GetStats counts the nodes and edges of the namespace. Edges belong to the
+// namespace of their source node.
+func (q *Querier) GetStats(ctx context.Context) (*tools.GraphStats, error) {
+	ns := q.resolveNamespace(ctx)
+	stats := &tools.GraphStats{Namespace: ns, StorageEngine: "memory"}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	byAgent := map[string]*tools.AgentStats{}
+	agentStats := func(n any) *tools.AgentStats {
+		agent := sourceAgent(n)
+		a, ok := byAgent[agent]
+		if !ok {
+			a = &tools.AgentStats{Agent: agent}
+			byAgent[agent] = a
+		}
+		return a
+	}
+	for _, n := range q.g.nodes {
+		if n.ns != ns {
+			continue
+		}
+		switch v := n.value.(type) {
+		case *tools.Fact:
+			stats.TotalFacts++
+			if v.Valid {
+				stats.ValidFacts++
+			} else {
+				stats.InvalidatedFacts++
+			}
+			agentStats(v).Facts++
+		case *tools.Decision:
+			stats.TotalDecisions++
+			if v.Status == "active" {
+				stats.ActiveDecisions++
+			}
+			agentStats(v).Decisions++
+		case *tools.Entity:
+			stats.TotalEntities++
+			agentStats(v).Entities++
+		case *tools.Event:
+			stats.TotalEvents++
+			agentStats(v).Events++
+		case *tools.Topic:
+			stats.TotalTopics++
+		}
+	}
+	for table, rows := range q.g.edges {
+		source := endpointType(memory.ValidEdgeTables[table][0])
+		for key := range rows {
+			if n, ok := q.g.nodes[key[0]]; ok && n.ns == ns && nodeType(n.value) == source {
+				stats.TotalEdges++
+			}
+		}
+	}
+
+	fmt.Sscan(q.g.meta["total_queries"], &stats.TotalQueries)
+	fmt.Sscan(q.g.meta["total_stores"], &stats.TotalStores)
+	fmt.Sscan(q.g.meta["last_query_at"], &stats.LastQueryAt)
+	fmt.Sscan(q.g.meta["last_store_at"], &stats.LastStoreAt)
+
+	for _, a := range byAgent {
+		stats.Agents = append(stats.Agents, *a)
+	}
+	sort.Slice(stats.Agents, func(i, j int) bool {
+		a, b := stats.Agents[i], stats.Agents[j]
+		return cmp.Or(cmp.Compare(b.Total(), a.Total()), strings.Compare(a.Agent, b.Agent)) < 0
+	})
+	return stats, nil
+}
+
+// GetTopicStats counts the valid facts, decisions, entities, and events
+// linked to each topic of the namespace, sorted by topic name. Events are
+// reached through the decisions they are linked to. Archived nodes are left
+// out unless ctx asks for them.
+func (q *Querier) GetTopicStats(ctx context.Context) ([]tools.TopicStats, error) {
+	ns := q.resolveNamespace(ctx)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	// linked returns the nodes of ns, counted once each, whose edge in
+	// table reaches topicID.
+	linked := func(table, topicID string) map[string]any {
+		found := map[string]any{}
+		for key := range q.g.edges[table] {
+			if key[1] != topicID || q.hidden(ctx, key[0]) {
+				continue
+			}
+			if n, ok := q.g.nodes[key[0]]; ok && n.ns == ns {
+				found[key[0]] = n.value
+			}
+		}
+		return found
+	}
+
+	var stats []tools.TopicStats
+	for _, n := range q.nodesOf(ns, "topic") {
+		topic := n.(*tools.Topic)
+		if q.hidden(ctx, topic.ID) {
+			continue
+		}
+		t := tools.TopicStats{TopicID: topic.ID, Name: topic.Name, LastActivity: topic.UpdatedAt}
+		for _, f := range linked("mie_fact_topic", topic.ID) {
+			if f, ok := f.(*tools.Fact); ok && f.Valid {
+				t.Facts++
+				t.LastActivity = max(t.LastActivity, f.UpdatedAt)
+			}
+		}
+		decisions := linked("mie_decision_topic", topic.ID)
+		for _, d := range decisions {
+			t.Decisions++
+			t.LastActivity = max(t.LastActivity, d.(*tools.Decision).UpdatedAt)
+		}
+		for _, e := range linked("mie_entity_topic", topic.ID) {
+			t.Entities++
+			t.LastActivity = max(t.LastActivity, e.(*tools.Entity).UpdatedAt)
+		}
+		events := map[string]*tools.Event{}
+		for key := range q.g.edges["mie_event_decision"] {
+			if _, ok := decisions[key[1]]; !ok || q.hidden(ctx, key[0]) {
+				continue
+			}
+			if n, ok := q.g.nodes[key[0]]; ok && n.ns == ns {
+				events[key[0]] = n.value.(*tools.Event)
+			}
+		}
+		for _, e := range events {
+			t.Events++
+			t.LastActivity = max(t.LastActivity, e.UpdatedAt)
+		}
+		stats = append(stats, t)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return cmp.Or(strings.Compare(stats[i].Name, stats[j].Name), strings.Compare(stats[i].TopicID, stats[j].TopicID)) < 0
+	})
+	return stats, nil
+}
+
+// SuggestTopics scores the topics of the namespace against text by the
+// share of the topic name's words that appear in text, as the client does
+// without embeddings, and returns up to limit of them, best first.
+func (q *Querier) SuggestTopics(ctx context.Context, text string, limit int) ([]tools.TopicSuggestion, error) {
+	if limit <= 0 {
+		limit = 5
+	}
+	textWords := map[string]bool{}
+	for _, w := range words(text) {
+		textWords[w] = true
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var suggestions []tools.TopicSuggestion
+	for _, n := range q.nodesOf(q.resolveNamespace(ctx), "topic") {
+		topic := n.(*tools.Topic)
+		if q.hidden(ctx, topic.ID) {
+			continue
+		}
+		s := tools.TopicSuggestion{TopicID: topic.ID, Name: topic.Name, Description: topic.Description}
+		if nameWords := words(topic.Name); len(nameWords) > 0 {
+			found := 0
+			for _, w := range nameWords {
+				if textWords[w] {
+					found++
+				}
+			}
+			s.Score = float64(found) / float64(len(nameWords))
+		}
+		suggestions = append(suggestions, s)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		return cmp.Or(cmp.Compare(b.Score, a.Score), strings.Compare(a.Name, b.Name)) < 0
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
+
+// FindDuplicates clusters the entities and topics of the namespace whose
+// names and aliases are similar, with memory.ClusterByName. Archived nodes
+// are left out unless ctx asks for them.
+func (q *Querier) FindDuplicates(ctx context.Context, opts tools.DuplicateOptions) ([]tools.DuplicateCluster, error) {
+	threshold := opts.Threshold
+	if threshold <= 0 {
+		threshold = memory.DefaultDuplicateThreshold
+	}
+	if threshold > 1 {
+		return nil, fmt.Errorf("invalid threshold %v (must be between 0 and 1)", threshold)
+	}
+	nodeTypes := opts.NodeTypes
+	if len(nodeTypes) == 0 {
+		nodeTypes = []string{"entity", "topic"}
+	}
+	ns := q.resolveNamespace(ctx)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var clusters []tools.DuplicateCluster
+	for _, nt := range nodeTypes {
+		if nt != "entity" && nt != "topic" {
+			return nil, fmt.Errorf("invalid node type %q: duplicates are found among entities and topics", nt)
+		}
+		var members []tools.DuplicateMember
+		for _, n := range q.nodesOf(ns, nt) {
+			id := nodeID(n)
+			if q.hidden(ctx, id) {
+				continue
+			}
+			m := tools.DuplicateMember{ID: id, CreatedAt: createdAt(n), Edges: q.degree(id)}
+			switch n := n.(type) {
+			case *tools.Entity:
+				m.Name, m.Kind = n.Name, n.Kind
+				for key, a := range q.g.aliases {
+					if key.ns == ns && a.entityID == id {
+						m.Aliases = append(m.Aliases, key.alias)
+					}
+				}
+				slices.Sort(m.Aliases)
+			case *tools.Topic:
+				m.Name = n.Name
+			}
+			members = append(members, m)
+		}
+		clusters = append(clusters, memory.ClusterByName(nt, members, threshold)...)
+	}
+
+	sort.SliceStable(clusters, func(i, j int) bool { return clusters[i].Score > clusters[j].Score })
+	if opts.Limit > 0 && len(clusters) > opts.Limit {
+		clusters = clusters[:opts.Limit]
+	}
+	return clusters, nil
+}
+
+// GetEmbeddingStatus reports embeddings as disabled, with no node of the
+// namespace embedded.
+func (q *Querier) GetEmbeddingStatus(ctx context.Context) (*tools.EmbeddingReport, error) {
+	ns := q.resolveNamespace(ctx)
+	report := &tools.EmbeddingReport{Index: "hnsw"}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, nt := range []string{"fact", "decision", "entity", "event"} {
+		report.Coverage = append(report.Coverage, tools.EmbeddingCoverage{NodeType: nt, Nodes: len(q.nodesOf(ns, nt))})
+	}
+	return report, nil
+}
+
+// IncrementCounter adds one to the counter key. Counting "total_queries"
+// or "total_stores" also records the time of the last query or store.
+func (q *Querier) IncrementCounter(ctx context.Context, key string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var n int
+	fmt.Sscan(q.g.meta[key], &n)
+	q.g.meta[key] = fmt.Sprint(n + 1)
+	now := fmt.Sprint(time.Now().Unix())
+	switch key {
+	case "total_queries":
+		q.g.meta["last_query_at"] = now
+	case "total_stores":
+		q.g.meta["last_store_at"] = now
+	}
+	return nil
+}
+
+// words returns the lowercase words of s, split at anything but letters
+// and digits.
+func words(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
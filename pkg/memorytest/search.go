@@ -0,0 +1,363 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package memorytest
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// verifiedBoost is the ranking weight of verified facts, as in the client.
+const verifiedBoost = 1.5
+
+// searchable returns the nodes of the namespace that a search of ctx over
+// nodeTypes considers: nodes matching its time range and source agent, and
+// not archived unless ctx asks for them. As in the client, only facts that
+// are valid are searched, a time range with event dates searches only
+// events, and a source agent leaves out topics. q.mu must be held.
+func (q *Querier) searchable(ctx context.Context, nodeTypes []string) []any {
+	if len(nodeTypes) == 0 {
+		nodeTypes = []string{"fact", "decision", "entity", "event", "topic"}
+	}
+	ns := q.resolveNamespace(ctx)
+	tr := tools.TimeRangeFromContext(ctx)
+	agent := tools.SourceAgentFromContext(ctx)
+	var nodes []any
+	for _, nt := range nodeTypes {
+		if (tr.HasEventDate() && nt != "event") || (agent != "" && nt == "topic") {
+			continue
+		}
+		for _, n := range q.nodesOf(ns, nt) {
+			if f, ok := n.(*tools.Fact); ok && !f.Valid {
+				continue
+			}
+			if q.hidden(ctx, nodeID(n)) || !inTimeRange(n, tr) || (agent != "" && sourceAgent(n) != agent) {
+				continue
+			}
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// searchText returns the searched fields of the stored node n.
+func searchText(n any) []string {
+	switch n := n.(type) {
+	case *tools.Fact:
+		return []string{n.Content}
+	case *tools.Decision:
+		return []string{n.Title, n.Rationale}
+	case *tools.Entity:
+		return []string{n.Name, n.Description}
+	case *tools.Event:
+		return []string{n.Title, n.Description}
+	case *tools.Topic:
+		return []string{n.Name, n.Description}
+	}
+	return nil
+}
+
+// searchResult returns the search result for the stored node n, filled in
+// as the client fills it in.
+func searchResult(n any) tools.SearchResult {
+	switch n := n.(type) {
+	case *tools.Fact:
+		return tools.SearchResult{NodeType: "fact", ID: n.ID, Content: n.Content, Detail: n.Category, Metadata: &tools.Fact{
+			ID: n.ID, Content: n.Content, Category: n.Category, Confidence: n.Confidence, CreatedAt: n.CreatedAt,
+			Verified: n.Verified, VerifiedBy: n.VerifiedBy, VerifiedAt: n.VerifiedAt, ExpiresAt: n.ExpiresAt,
+		}}
+	case *tools.Decision:
+		return tools.SearchResult{NodeType: "decision", ID: n.ID, Content: n.Title, Detail: n.Rationale, Metadata: &tools.Decision{
+			ID: n.ID, Title: n.Title, Rationale: n.Rationale, Status: n.Status,
+		}}
+	case *tools.Entity:
+		return tools.SearchResult{NodeType: "entity", ID: n.ID, Content: n.Name, Detail: n.Description, Metadata: &tools.Entity{
+			ID: n.ID, Name: n.Name, Kind: n.Kind,
+		}}
+	case *tools.Event:
+		return tools.SearchResult{NodeType: "event", ID: n.ID, Content: n.Title, Detail: n.Description, Metadata: &tools.Event{
+			ID: n.ID, Title: n.Title, Description: n.Description, EventDate: n.EventDate,
+		}}
+	case *tools.Topic:
+		return tools.SearchResult{NodeType: "topic", ID: n.ID, Content: n.Name, Detail: n.Description, Metadata: &tools.Topic{
+			ID: n.ID, Name: n.Name,
+		}}
+	}
+	return tools.SearchResult{}
+}
+
+// rankWeight returns the ranking weight of sr: verifiedBoost for verified
+// facts and 1 otherwise.
+func rankWeight(sr tools.SearchResult) float64 {
+	if f, ok := sr.Metadata.(*tools.Fact); ok && f.Verified {
+		return verifiedBoost
+	}
+	return 1
+}
+
+// fold returns s lowercased and with its accents removed, as the client
+// folds text for exact search: accents are the combining diacritical marks
+// (U+0300 to U+036F) left by NFD normalization.
+func fold(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 0x300 && r <= 0x36f {
+			return -1
+		}
+		return r
+	}, norm.NFD.String(strings.ToLower(s)))
+}
+
+// ExactSearch returns the nodes with a searched field that contains query,
+// ignoring case and accents, verified facts first.
+func (q *Querier) ExactSearch(ctx context.Context, query string, nodeTypes []string, limit int) ([]tools.SearchResult, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	query = fold(query)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var results []tools.SearchResult
+	for _, n := range q.searchable(ctx, nodeTypes) {
+		if slices.ContainsFunc(searchText(n), func(s string) bool { return strings.Contains(fold(s), query) }) {
+			results = append(results, searchResult(n))
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool { return rankWeight(results[i]) > rankWeight(results[j]) })
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// FullTextSearch returns the nodes whose searched fields contain any of the
+// words of query, scored by the share of those words they contain, best
+// first.
+func (q *Querier) FullTextSearch(ctx context.Context, query string, nodeTypes []string, limit int) ([]tools.SearchResult, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	terms := slices.Compact(slices.Sorted(slices.Values(words(query))))
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var results []tools.SearchResult
+	for _, n := range q.searchable(ctx, nodeTypes) {
+		nodeWords := map[string]bool{}
+		for _, s := range searchText(n) {
+			for _, w := range words(s) {
+				nodeWords[w] = true
+			}
+		}
+		found := 0
+		for _, t := range terms {
+			if nodeWords[t] {
+				found++
+			}
+		}
+		if found == 0 {
+			continue
+		}
+		sr := searchResult(n)
+		sr.Score = float64(found) / float64(len(terms))
+		results = append(results, sr)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score*rankWeight(results[i]) > results[j].Score*rankWeight(results[j])
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// HybridSearch is exact search alone, fused as the client fuses it without
+// embeddings.
+func (q *Querier) HybridSearch(ctx context.Context, query string, nodeTypes []string, limit int) ([]tools.SearchResult, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	exact, err := q.ExactSearch(ctx, query, nodeTypes, limit*2)
+	if err != nil {
+		return nil, err
+	}
+	results := tools.FuseRanked(tools.DefaultRRFK, tools.RankedList{Source: "exact", Results: exact})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// SemanticSearch fails: Querier has no embeddings.
+func (q *Querier) SemanticSearch(ctx context.Context, query string, nodeTypes []string, limit int) ([]tools.SearchResult, error) {
+	return nil, fmt.Errorf("semantic search requires embeddings to be enabled")
+}
+
+// SimilarNodes fails as the client fails for a node without an embedding.
+func (q *Querier) SimilarNodes(ctx context.Context, nodeID string, nodeTypes []string, limit int) ([]tools.SearchResult, error) {
+	nt := nodeTypeOfID(nodeID)
+	switch nt {
+	case "topic":
+		return nil, fmt.Errorf("topics have no embeddings; %s cannot be compared", nodeID)
+	case "":
+		return nil, fmt.Errorf("invalid node ID %q: must start with fact:, dec:, ent:, or evt:", nodeID)
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if n, ok := q.g.nodes[nodeID]; !ok || nodeType(n.value) != nt {
+		return nil, fmt.Errorf("node %q not found", nodeID)
+	}
+	return nil, fmt.Errorf("node %s has no embedding yet; it is embedded when embeddings are enabled", nodeID)
+}
+
+// DetectConflicts fails: Querier has no embeddings.
+func (q *Querier) DetectConflicts(ctx context.Context, opts tools.ConflictOptions) ([]tools.Conflict, error) {
+	return nil, fmt.Errorf("conflict detection requires embeddings to be enabled")
+}
+
+// CheckNewFactConflicts fails: Querier has no embeddings.
+func (q *Querier) CheckNewFactConflicts(ctx context.Context, content, category string) ([]tools.Conflict, error) {
+	return nil, fmt.Errorf("conflict detection requires embeddings to be enabled")
+}
+
+// ExportGraph exports the nodes of opts.NodeTypes in the namespace, the
+// edges between them, and, with entities, the aliases, in the format of
+// memory.Client.ExportGraph. Embeddings are never included.
+func (q *Querier) ExportGraph(ctx context.Context, opts tools.ExportOptions) (*tools.ExportData, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.export(q.resolveNamespace(ctx), opts), nil
+}
+
+// export implements ExportGraph for namespace ns. q.mu must be held.
+func (q *Querier) export(ns string, opts tools.ExportOptions) *tools.ExportData {
+	export := &tools.ExportData{
+		Version:    "2",
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+		Namespace:  ns,
+		Stats:      make(map[string]int),
+	}
+	since := opts.Since
+	if since > 0 {
+		export.Since = time.Unix(since, 0).UTC().Format(time.RFC3339)
+	}
+	nodeTypes := opts.NodeTypes
+	if len(nodeTypes) == 0 {
+		nodeTypes = []string{"fact", "decision", "entity", "event", "topic"}
+	}
+
+	for _, nt := range nodeTypes {
+		for _, n := range q.nodesOf(ns, nt) {
+			switch n := read(n).(type) {
+			case *tools.Fact:
+				if n.UpdatedAt >= since || (n.Verified && n.VerifiedAt >= since) {
+					export.Facts = append(export.Facts, *n)
+				}
+			case *tools.Decision:
+				if n.UpdatedAt >= since {
+					export.Decisions = append(export.Decisions, *n)
+				}
+			case *tools.Entity:
+				if n.UpdatedAt >= since {
+					export.Entities = append(export.Entities, *n)
+				}
+			case *tools.Event:
+				if n.UpdatedAt >= since {
+					export.Events = append(export.Events, *n)
+				}
+			case *tools.Topic:
+				if n.UpdatedAt >= since {
+					export.Topics = append(export.Topics, *n)
+				}
+			}
+		}
+		switch nt {
+		case "fact":
+			export.Stats["facts"] = len(export.Facts)
+		case "decision":
+			export.Stats["decisions"] = len(export.Decisions)
+		case "entity":
+			export.Stats["entities"] = len(export.Entities)
+		case "event":
+			export.Stats["events"] = len(export.Events)
+		case "topic":
+			export.Stats["topics"] = len(export.Topics)
+		}
+	}
+
+	edges := map[string][]map[string]string{}
+	total := 0
+	for _, table := range slices.Sorted(maps.Keys(q.g.edges)) {
+		keyCols := memory.ValidEdgeTables[table]
+		if !slices.Contains(nodeTypes, endpointType(keyCols[0])) || !slices.Contains(nodeTypes, endpointType(keyCols[1])) {
+			continue
+		}
+		keys := slices.SortedFunc(maps.Keys(q.g.edges[table]), func(a, b edgeKey) int {
+			return cmp.Or(strings.Compare(a[0], b[0]), strings.Compare(a[1], b[1]))
+		})
+		for _, key := range keys {
+			e := q.g.edges[table][key]
+			if n, ok := q.g.nodes[key[0]]; !ok || n.ns != ns || e.createdAt < since {
+				continue
+			}
+			fields := map[string]string{
+				keyCols[0]:     key[0],
+				keyCols[1]:     key[1],
+				"weight":       strconv.FormatFloat(e.weight, 'g', -1, 64),
+				"source_agent": e.sourceAgent,
+				"created_at":   strconv.FormatInt(e.createdAt, 10),
+			}
+			if label, ok := edgeLabelColumns[table]; ok {
+				fields[label] = e.label
+			}
+			name := strings.TrimPrefix(table, "mie_")
+			edges[name] = append(edges[name], fields)
+			total++
+		}
+	}
+	if total > 0 {
+		export.Edges = edges
+		export.Stats["relationships"] = total
+	}
+
+	if slices.Contains(nodeTypes, "entity") {
+		for key, a := range q.g.aliases {
+			if key.ns == ns && a.createdAt >= since {
+				export.Aliases = append(export.Aliases, tools.EntityAlias{Alias: key.alias, EntityID: a.entityID})
+			}
+		}
+		sort.Slice(export.Aliases, func(i, j int) bool { return export.Aliases[i].Alias < export.Aliases[j].Alias })
+		if len(export.Aliases) > 0 {
+			export.Stats["aliases"] = len(export.Aliases)
+		}
+	}
+
+	for _, nt := range nodeTypes {
+		for _, n := range q.nodesOf(ns, nt) {
+			if at, ok := q.g.archived[nodeID(n)]; ok && at >= since {
+				export.Archived = append(export.Archived, nodeID(n))
+			}
+		}
+	}
+	if len(export.Archived) > 0 {
+		export.Stats["archived"] = len(export.Archived)
+	}
+	return export
+}
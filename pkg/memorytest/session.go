@@ -0,0 +1,141 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package memorytest
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// SaveSearch stores mie_query arguments under name in the namespace,
+// replacing a saved search of the same name.
+func (q *Querier) SaveSearch(ctx context.Context, name string, args map[string]any) (*tools.SavedSearch, error) {
+	key := searchKey{ns: q.resolveNamespace(ctx), name: name}
+	now := time.Now().Unix()
+	search := tools.SavedSearch{Name: name, Args: maps.Clone(args), CreatedAt: now, UpdatedAt: now}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if prev, ok := q.g.searches[key]; ok {
+		search.CreatedAt = prev.CreatedAt
+	}
+	q.g.searches[key] = search
+	return &search, nil
+}
+
+// GetSavedSearch returns the saved search of the namespace named name.
+func (q *Querier) GetSavedSearch(ctx context.Context, name string) (*tools.SavedSearch, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	search, ok := q.g.searches[searchKey{ns: q.resolveNamespace(ctx), name: name}]
+	if !ok {
+		return nil, fmt.Errorf("saved search %q not found", name)
+	}
+	search.Args = maps.Clone(search.Args)
+	return &search, nil
+}
+
+// ListSavedSearches returns the saved searches of the namespace by name.
+func (q *Querier) ListSavedSearches(ctx context.Context) ([]tools.SavedSearch, error) {
+	ns := q.resolveNamespace(ctx)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	searches := []tools.SavedSearch{}
+	for key, s := range q.g.searches {
+		if key.ns == ns {
+			s.Args = maps.Clone(s.Args)
+			searches = append(searches, s)
+		}
+	}
+	sort.Slice(searches, func(i, j int) bool { return searches[i].Name < searches[j].Name })
+	return searches, nil
+}
+
+// DeleteSavedSearch removes the saved search named name.
+func (q *Querier) DeleteSavedSearch(ctx context.Context, name string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.g.searches, searchKey{ns: q.resolveNamespace(ctx), name: name})
+	return nil
+}
+
+// OpenSession starts a session in the namespace.
+func (q *Querier) OpenSession(ctx context.Context, title, sourceAgent string) (*tools.Session, error) {
+	ns := q.resolveNamespace(ctx)
+	now := time.Now()
+	s := session{
+		Session: tools.Session{
+			ID:          memory.GenerateID("ses", ns, title, strconv.FormatInt(now.UnixNano(), 10)),
+			Title:       title,
+			SourceAgent: sourceAgent,
+			StartedAt:   now.Unix(),
+		},
+		ns: ns,
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.g.sessions[s.ID] = s
+	return &s.Session, nil
+}
+
+// CloseSession ends an open session of the namespace and records summary
+// with it.
+func (q *Querier) CloseSession(ctx context.Context, sessionID, summary string) (*tools.Session, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	s, ok := q.g.sessions[sessionID]
+	if !ok || s.ns != q.resolveNamespace(ctx) {
+		return nil, fmt.Errorf("session %q not found", sessionID)
+	}
+	if s.EndedAt != 0 {
+		return nil, fmt.Errorf("session %s is already closed", sessionID)
+	}
+	s.EndedAt = time.Now().Unix()
+	s.Summary = summary
+	q.g.sessions[sessionID] = s
+	return &s.Session, nil
+}
+
+// GetSession returns the session of the namespace with the given ID.
+func (q *Querier) GetSession(ctx context.Context, sessionID string) (*tools.Session, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	s, ok := q.g.sessions[sessionID]
+	if !ok || s.ns != q.resolveNamespace(ctx) {
+		return nil, fmt.Errorf("session %q not found", sessionID)
+	}
+	return &s.Session, nil
+}
+
+// ListSessions returns the sessions of the namespace, most recently started
+// first.
+func (q *Querier) ListSessions(ctx context.Context) ([]tools.Session, error) {
+	ns := q.resolveNamespace(ctx)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	sessions := []tools.Session{}
+	for _, s := range q.g.sessions {
+		if s.ns == ns {
+			sessions = append(sessions, s.Session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		a, b := sessions[i], sessions[j]
+		return cmp.Or(cmp.Compare(b.StartedAt, a.StartedAt), strings.Compare(a.ID, b.ID)) < 0
+	})
+	return sessions, nil
+}
@@ -0,0 +1,146 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package memorytest
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// CreateSnapshot records a copy of the namespace's nodes, edges, aliases,
+// and archive marks under label, so that RestoreSnapshot can revert the
+// graph to its current state.
+func (q *Querier) CreateSnapshot(ctx context.Context, label string) (*tools.Snapshot, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.createSnapshot(q.resolveNamespace(ctx), label), nil
+}
+
+// createSnapshot implements CreateSnapshot for namespace ns. q.mu must be
+// held.
+func (q *Querier) createSnapshot(ns, label string) *tools.Snapshot {
+	now := time.Now()
+	snap := snapshot{
+		Snapshot: tools.Snapshot{
+			ID:        memory.GenerateID("snap", ns, label, strconv.FormatInt(now.UnixNano(), 10)),
+			Label:     label,
+			CreatedAt: now.Unix(),
+			Stats:     q.export(ns, tools.ExportOptions{Format: "json"}).Stats,
+		},
+		ns: ns,
+		g:  newGraph(),
+	}
+	copyNamespace(snap.g, q.g, ns)
+	q.g.snapshots[snap.ID] = snap
+	s := snap.Snapshot
+	return &s
+}
+
+// copyNamespace copies the nodes of namespace ns in src, with their edges,
+// aliases, and archive marks, into dst. Edges belong to the namespace of
+// their source node.
+func copyNamespace(dst, src *graph, ns string) {
+	for id, n := range src.nodes {
+		if n.ns != ns {
+			continue
+		}
+		dst.nodes[id] = node{ns: ns, value: copyNode(n.value)}
+		if at, ok := src.archived[id]; ok {
+			dst.archived[id] = at
+		}
+	}
+	for table, rows := range src.edges {
+		for key, e := range rows {
+			if n, ok := src.nodes[key[0]]; ok && n.ns == ns {
+				dst.edges[table][key] = e
+			}
+		}
+	}
+	for key, a := range src.aliases {
+		if key.ns == ns {
+			dst.aliases[key] = a
+		}
+	}
+}
+
+// ListSnapshots returns the snapshots of the namespace, newest first.
+func (q *Querier) ListSnapshots(ctx context.Context) ([]tools.Snapshot, error) {
+	ns := q.resolveNamespace(ctx)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	snapshots := []tools.Snapshot{}
+	for _, s := range q.g.snapshots {
+		if s.ns == ns {
+			snapshots = append(snapshots, s.Snapshot)
+		}
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		a, b := snapshots[i], snapshots[j]
+		return cmp.Or(cmp.Compare(b.CreatedAt, a.CreatedAt), strings.Compare(a.ID, b.ID)) < 0
+	})
+	return snapshots, nil
+}
+
+// RestoreSnapshot reverts the namespace to a snapshot. It first snapshots
+// the current graph and returns that checkpoint, so the restore can be
+// undone. Snapshots and the audit log are not affected.
+func (q *Querier) RestoreSnapshot(ctx context.Context, snapshotID string) (*tools.Snapshot, error) {
+	ns := q.resolveNamespace(ctx)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	snap, ok := q.g.snapshots[snapshotID]
+	if !ok || snap.ns != ns {
+		return nil, fmt.Errorf("snapshot %s not found", snapshotID)
+	}
+	checkpoint := q.createSnapshot(ns, fmt.Sprintf("before restoring %s", snapshotID))
+
+	for id, n := range q.g.nodes {
+		if n.ns != ns {
+			continue
+		}
+		for _, rows := range q.g.edges {
+			for key := range rows {
+				if key[0] == id {
+					delete(rows, key)
+				}
+			}
+		}
+		delete(q.g.archived, id)
+		delete(q.g.nodes, id)
+	}
+	for key := range q.g.aliases {
+		if key.ns == ns {
+			delete(q.g.aliases, key)
+		}
+	}
+	copyNamespace(q.g, snap.g, ns)
+
+	q.publish(ctx, memory.ChangeRestored, "", nil)
+	q.audit(ctx, memory.ChangeRestored, "", nil, snapshotID)
+	return checkpoint, nil
+}
+
+// DeleteSnapshot removes a snapshot of the namespace. Deleting a snapshot
+// that does not exist is not an error.
+func (q *Querier) DeleteSnapshot(ctx context.Context, snapshotID string) error {
+	ns := q.resolveNamespace(ctx)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if s, ok := q.g.snapshots[snapshotID]; ok && s.ns == ns {
+		delete(q.g.snapshots, snapshotID)
+	}
+	return nil
+}
@@ -0,0 +1,689 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package memorytest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// StoreFact stores a fact. An invalid category becomes "general" and a
+// confidence outside (0, 1] becomes 0.8. Unless req.SkipDedup is set, a
+// valid fact of the namespace with the same content, ignoring case and
+// surrounding whitespace, is returned instead with DuplicateSimilarity 1.
+// A fact with a key is a preference: it is never deduplicated, and it
+// invalidates the valid facts of the namespace with the same key. An
+// anti-fact (req.Negated) only deduplicates against anti-facts, and an
+// asserted fact only against asserted ones.
+func (q *Querier) StoreFact(ctx context.Context, req tools.StoreFactRequest) (*tools.Fact, error) {
+	if req.Content == "" {
+		return nil, fmt.Errorf("fact content is required")
+	}
+	if req.Key = memory.PreferenceKey(req.Key); req.Key != "" {
+		if req.Category == "" {
+			req.Category = "preference"
+		}
+		if err := memory.CheckFactKey(req.Category, req.Key); err != nil {
+			return nil, err
+		}
+	}
+	if !slices.Contains(memory.ValidFactCategories, req.Category) {
+		req.Category = "general"
+	}
+	if req.Confidence <= 0 || req.Confidence > 1.0 {
+		req.Confidence = 0.8
+	}
+	ns := q.resolveNamespace(ctx)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !req.SkipDedup && req.Key == "" {
+		if existing := q.findFact(ns, req.Content, req.Negated); existing != nil {
+			fact := read(existing).(*tools.Fact)
+			fact.DuplicateSimilarity = 1.0
+			return fact, nil
+		}
+	}
+
+	id := memory.FactID(req.Content, req.Category)
+	if req.Negated {
+		id = memory.NegatedFactID(req.Content, req.Category)
+	}
+	now := time.Now().Unix()
+	fact := &tools.Fact{
+		ID:                 memory.NamespacedID(id, ns),
+		Content:            req.Content,
+		Category:           req.Category,
+		Confidence:         req.Confidence,
+		SourceAgent:        req.SourceAgent,
+		SourceConversation: req.SourceConversation,
+		Valid:              true,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+		ExpiresAt:          req.ExpiresAt,
+		Key:                req.Key,
+		Negated:            req.Negated,
+	}
+	if fact.Key != "" {
+		fact.Replaces = q.currentValues(ns, fact.Key, fact.ID)
+	}
+	stored := copyNode(fact).(*tools.Fact)
+	stored.Replaces = nil
+	// Verification, expiry and key are kept apart from the fact row, so
+	// storing the fact again keeps them.
+	if prev, ok := q.g.nodes[fact.ID].value.(*tools.Fact); ok {
+		stored.Verified, stored.VerifiedBy, stored.VerifiedAt = prev.Verified, prev.VerifiedBy, prev.VerifiedAt
+		if stored.ExpiresAt == 0 {
+			stored.ExpiresAt = prev.ExpiresAt
+		}
+		if stored.Key == "" {
+			stored.Key = prev.Key
+		}
+	}
+	q.g.nodes[fact.ID] = node{ns: ns, value: stored}
+	for _, old := range fact.Replaces {
+		f := q.g.nodes[old].value.(*tools.Fact)
+		f.Valid = false
+		f.UpdatedAt = now
+		reason := "new value of preference " + fact.Key
+		q.recordHistory(ctx, old, "valid", "true", "false", reason, fact.ID)
+		q.g.edges["mie_invalidates"][edgeKey{fact.ID, old}] = edge{label: reason, weight: 1, createdAt: now}
+	}
+	q.publish(ctx, memory.ChangeCreated, fact.ID, nil)
+	q.audit(ctx, memory.ChangeCreated, req.SourceAgent, nil, fact.ID)
+	if len(fact.Replaces) > 0 {
+		for _, id := range fact.Replaces {
+			q.publish(ctx, memory.ChangeInvalidated, id, nil)
+		}
+		q.audit(ctx, memory.ChangeInvalidated, req.SourceAgent, nil, fact.Replaces...)
+	}
+	return fact, nil
+}
+
+// currentValues returns the sorted IDs of the valid facts of ns other than
+// factID whose key is key. q.mu must be held.
+func (q *Querier) currentValues(ns, key, factID string) []string {
+	var ids []string
+	for id, n := range q.g.nodes {
+		if f, ok := n.value.(*tools.Fact); ok && n.ns == ns && f.Valid && f.Key == key && id != factID {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// findFact returns the valid fact of ns whose content matches content,
+// ignoring case and surrounding whitespace, and whose polarity is negated,
+// or nil. q.mu must be held.
+func (q *Querier) findFact(ns, content string, negated bool) *tools.Fact {
+	content = strings.ToLower(strings.TrimSpace(content))
+	var found *tools.Fact
+	for _, n := range q.g.nodes {
+		f, ok := n.value.(*tools.Fact)
+		if !ok || n.ns != ns || !f.Valid || f.Negated != negated || strings.ToLower(strings.TrimSpace(f.Content)) != content {
+			continue
+		}
+		if found == nil || f.ID < found.ID {
+			found = f
+		}
+	}
+	return found
+}
+
+// StoreDecision stores a decision. Its status is "active" unless req asks
+// for "proposed".
+func (q *Querier) StoreDecision(ctx context.Context, req tools.StoreDecisionRequest) (*tools.Decision, error) {
+	if req.Title == "" {
+		return nil, fmt.Errorf("decision title is required")
+	}
+	if req.Rationale == "" {
+		return nil, fmt.Errorf("decision rationale is required")
+	}
+	switch req.Status {
+	case "":
+		req.Status = "active"
+	case "active", "proposed":
+	default:
+		return nil, fmt.Errorf("invalid initial status %q; must be active or proposed", req.Status)
+	}
+	ns := q.resolveNamespace(ctx)
+	now := time.Now().Unix()
+	decision := &tools.Decision{
+		ID:                 memory.NamespacedID(memory.DecisionID(req.Title, req.Rationale), ns),
+		Title:              req.Title,
+		Rationale:          req.Rationale,
+		Alternatives:       req.Alternatives,
+		Context:            req.Context,
+		SourceAgent:        req.SourceAgent,
+		SourceConversation: req.SourceConversation,
+		Status:             req.Status,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.g.nodes[decision.ID] = node{ns: ns, value: copyNode(decision)}
+	q.publish(ctx, memory.ChangeCreated, decision.ID, nil)
+	q.audit(ctx, memory.ChangeCreated, req.SourceAgent, nil, decision.ID)
+	return decision, nil
+}
+
+// StoreEntity stores an entity, or returns the entity unchanged when
+// req.Name is one of its aliases. An invalid kind becomes "other".
+func (q *Querier) StoreEntity(ctx context.Context, req tools.StoreEntityRequest) (*tools.Entity, error) {
+	ns := q.resolveNamespace(ctx)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if req.Name != "" {
+		if a, ok := q.g.aliases[aliasKey{ns: ns, alias: strings.ToLower(strings.TrimSpace(req.Name))}]; ok {
+			if ent, ok := q.g.nodes[a.entityID].value.(*tools.Entity); ok {
+				return read(ent).(*tools.Entity), nil
+			}
+		}
+	}
+	if req.Name == "" {
+		return nil, fmt.Errorf("entity name is required")
+	}
+	if !slices.Contains(memory.ValidEntityKinds, req.Kind) {
+		req.Kind = "other"
+	}
+	now := time.Now().Unix()
+	entity := &tools.Entity{
+		ID:          memory.NamespacedID(memory.EntityID(req.Name, req.Kind), ns),
+		Name:        req.Name,
+		Kind:        req.Kind,
+		Description: req.Description,
+		SourceAgent: req.SourceAgent,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	q.g.nodes[entity.ID] = node{ns: ns, value: copyNode(entity)}
+	q.publish(ctx, memory.ChangeCreated, entity.ID, nil)
+	q.audit(ctx, memory.ChangeCreated, req.SourceAgent, nil, entity.ID)
+	return entity, nil
+}
+
+// StoreEvent stores an event.
+func (q *Querier) StoreEvent(ctx context.Context, req tools.StoreEventRequest) (*tools.Event, error) {
+	if req.Title == "" {
+		return nil, fmt.Errorf("event title is required")
+	}
+	ns := q.resolveNamespace(ctx)
+	now := time.Now().Unix()
+	event := &tools.Event{
+		ID:                 memory.NamespacedID(memory.EventID(req.Title, req.EventDate), ns),
+		Title:              req.Title,
+		Description:        req.Description,
+		EventDate:          req.EventDate,
+		EndDate:            req.EndDate,
+		SourceAgent:        req.SourceAgent,
+		SourceConversation: req.SourceConversation,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.g.nodes[event.ID] = node{ns: ns, value: copyNode(event)}
+	q.publish(ctx, memory.ChangeCreated, event.ID, nil)
+	q.audit(ctx, memory.ChangeCreated, req.SourceAgent, nil, event.ID)
+	return event, nil
+}
+
+// StoreTopic stores a topic.
+func (q *Querier) StoreTopic(ctx context.Context, req tools.StoreTopicRequest) (*tools.Topic, error) {
+	if req.Name == "" {
+		return nil, fmt.Errorf("topic name is required")
+	}
+	ns := q.resolveNamespace(ctx)
+	now := time.Now().Unix()
+	topic := &tools.Topic{
+		ID:          memory.NamespacedID(memory.TopicID(req.Name), ns),
+		Name:        req.Name,
+		Description: req.Description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.g.nodes[topic.ID] = node{ns: ns, value: copyNode(topic)}
+	q.publish(ctx, memory.ChangeCreated, topic.ID, nil)
+	q.audit(ctx, memory.ChangeCreated, "", nil, topic.ID)
+	return topic, nil
+}
+
+// InvalidateFact marks the fact oldFactID as invalid and records that
+// newFactID replaced it. Neither fact has to exist, as with the client.
+func (q *Querier) InvalidateFact(ctx context.Context, oldFactID, newFactID, reason string) error {
+	if oldFactID == "" || newFactID == "" {
+		return fmt.Errorf("both old and new fact IDs are required")
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	now := time.Now().Unix()
+	if f, ok := q.g.nodes[oldFactID].value.(*tools.Fact); ok {
+		wasValid := f.Valid
+		f.Valid = false
+		f.UpdatedAt = now
+		if wasValid {
+			q.recordHistory(ctx, oldFactID, "valid", "true", "false", reason, newFactID)
+		}
+	}
+	q.g.edges["mie_invalidates"][edgeKey{newFactID, oldFactID}] = edge{label: reason, weight: 1, createdAt: now}
+	q.publish(ctx, memory.ChangeInvalidated, oldFactID, nil)
+	q.audit(ctx, memory.ChangeInvalidated, "", nil, oldFactID, newFactID)
+	return nil
+}
+
+// AddRelationship creates an edge, as memory.Writer.AddRelationship
+// describes: fields carries the key columns of the edge table, its value
+// column (role or reason) if it has one, and optionally "weight",
+// "source_agent", and "created_at". Re-adding an edge overwrites it. The
+// nodes it connects do not have to exist.
+func (q *Querier) AddRelationship(ctx context.Context, edgeType string, fields map[string]string) error {
+	e, key, err := parseEdge(edgeType, fields)
+	if err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.g.edges[edgeType][key] = e
+	q.publish(ctx, memory.ChangeRelated, "", nil)
+	q.audit(ctx, memory.ChangeRelated, fields["source_agent"], nil, key[0], key[1])
+	return nil
+}
+
+// parseEdge checks fields against the columns of the edge table edgeType
+// and returns the edge and its key.
+func parseEdge(edgeType string, fields map[string]string) (edge, edgeKey, error) {
+	key, err := parseEdgeKey(edgeType, fields)
+	if err != nil {
+		return edge{}, key, err
+	}
+	e := edge{weight: 1.0, sourceAgent: fields["source_agent"], createdAt: time.Now().Unix()}
+	if v := fields["weight"]; v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f <= 0 || f > 1 {
+			return e, key, fmt.Errorf("invalid weight %q: must be greater than 0 and at most 1", v)
+		}
+		e.weight = f
+	}
+	if v := fields["created_at"]; v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return e, key, fmt.Errorf("invalid created_at %q: %w", v, err)
+		}
+		e.createdAt = n
+	}
+	label, hasLabel := edgeLabelColumns[edgeType]
+	for k, v := range fields {
+		switch {
+		case slices.Contains(memory.ValidEdgeTables[edgeType], k), k == "weight", k == "source_agent", k == "created_at":
+		case hasLabel && k == label:
+			e.label = v
+		default:
+			return e, key, fmt.Errorf("add relationship %s: unknown column %q", edgeType, k)
+		}
+	}
+	if _, ok := fields[label]; hasLabel && !ok {
+		return e, key, fmt.Errorf("add relationship %s: missing column %q", edgeType, label)
+	}
+	return e, key, nil
+}
+
+// parseEdgeKey returns the key columns of the edge table edgeType in
+// fields.
+func parseEdgeKey(edgeType string, fields map[string]string) (edgeKey, error) {
+	cols, ok := memory.ValidEdgeTables[edgeType]
+	if !ok {
+		return edgeKey{}, fmt.Errorf("unknown edge type: %s", edgeType)
+	}
+	var key edgeKey
+	for i, col := range cols {
+		val, exists := fields[col]
+		if !exists {
+			return key, fmt.Errorf("missing required field %q for edge type %s", col, edgeType)
+		}
+		key[i] = val
+	}
+	return key, nil
+}
+
+// RemoveRelationship deletes an edge. Only the key columns of fields are
+// used; removing an edge that does not exist is not an error.
+func (q *Querier) RemoveRelationship(ctx context.Context, edgeType string, fields map[string]string) error {
+	key, err := parseEdgeKey(edgeType, fields)
+	if err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.g.edges[edgeType], key)
+	q.publish(ctx, memory.ChangeUnrelated, "", nil)
+	q.audit(ctx, memory.ChangeUnrelated, "", nil, key[0], key[1])
+	return nil
+}
+
+// detectNodeType returns the type of a node from its ID prefix, or else
+// from the node stored under it. q.mu must be held.
+func (q *Querier) detectNodeType(nodeID string) (string, error) {
+	if nt := nodeTypeOfID(nodeID); nt != "" {
+		return nt, nil
+	}
+	if n, ok := q.g.nodes[nodeID]; ok {
+		return nodeType(n.value), nil
+	}
+	return "", fmt.Errorf("node %q not found", nodeID)
+}
+
+// current returns the node nodeID of the namespace of ctx, or nil. q.mu
+// must be held.
+func (q *Querier) current(ctx context.Context, nodeID string) any {
+	n, ok := q.g.nodes[nodeID]
+	if !ok || n.ns != q.resolveNamespace(ctx) {
+		return nil
+	}
+	return n.value
+}
+
+// UpdateDescription updates the description of an entity, event, or topic.
+// Updating a node that does not exist changes nothing, as with the client.
+func (q *Querier) UpdateDescription(ctx context.Context, nodeID, newDescription string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	err := q.updateDescription(ctx, nodeID, newDescription)
+	q.publish(ctx, memory.ChangeUpdated, nodeID, err)
+	q.audit(ctx, memory.ChangeUpdated, "", err, nodeID)
+	return err
+}
+
+func (q *Querier) updateDescription(ctx context.Context, nodeID, newDescription string) error {
+	nt, err := q.detectNodeType(nodeID)
+	if err != nil {
+		return err
+	}
+	var description *string
+	var updatedAt *int64
+	switch n := q.current(ctx, nodeID).(type) {
+	case *tools.Entity:
+		description, updatedAt = &n.Description, &n.UpdatedAt
+	case *tools.Event:
+		description, updatedAt = &n.Description, &n.UpdatedAt
+	case *tools.Topic:
+		description, updatedAt = &n.Description, &n.UpdatedAt
+	default:
+		if nt != "entity" && nt != "event" && nt != "topic" {
+			return fmt.Errorf("node type %q does not support description update", nt)
+		}
+		return nil
+	}
+	old := *description
+	*description = newDescription
+	*updatedAt = time.Now().Unix()
+	if old != newDescription {
+		q.recordHistory(ctx, nodeID, "description", old, newDescription, tools.ChangeReasonFromContext(ctx), "")
+	}
+	return nil
+}
+
+// UpdateStatus changes the status of a decision. The change must be
+// allowed by memory.DecisionStatusTransitions. Updating a decision that
+// does not exist changes nothing, as with the client.
+func (q *Querier) UpdateStatus(ctx context.Context, nodeID, newStatus string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	err := q.updateStatus(ctx, nodeID, newStatus)
+	q.publish(ctx, memory.ChangeUpdated, nodeID, err)
+	q.audit(ctx, memory.ChangeUpdated, "", err, nodeID)
+	return err
+}
+
+func (q *Querier) updateStatus(ctx context.Context, nodeID, newStatus string) error {
+	if !slices.Contains(memory.ValidDecisionStatuses, newStatus) {
+		return fmt.Errorf("invalid status %q; must be one of: %s", newStatus, strings.Join(memory.ValidDecisionStatuses, ", "))
+	}
+	d, ok := q.current(ctx, nodeID).(*tools.Decision)
+	if !ok {
+		return nil
+	}
+	if err := memory.CheckDecisionTransition(d.Status, newStatus); err != nil {
+		return err
+	}
+	old := d.Status
+	d.Status = newStatus
+	d.UpdatedAt = time.Now().Unix()
+	if old != newStatus {
+		q.recordHistory(ctx, nodeID, "status", old, newStatus, tools.ChangeReasonFromContext(ctx), "")
+	}
+	return nil
+}
+
+// AddAlias records alias as an alternative name for an existing entity.
+// Aliases are lowercased and scoped to the namespace; an alias that
+// already refers to a different entity is rejected.
+func (q *Querier) AddAlias(ctx context.Context, entityID, alias string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	err := q.addAlias(ctx, entityID, alias)
+	q.publish(ctx, memory.ChangeUpdated, entityID, err)
+	q.audit(ctx, memory.ChangeUpdated, "", err, entityID)
+	return err
+}
+
+func (q *Querier) addAlias(ctx context.Context, entityID, alias string) error {
+	alias = strings.ToLower(strings.TrimSpace(alias))
+	if alias == "" {
+		return fmt.Errorf("alias is required")
+	}
+	if !strings.HasPrefix(entityID, "ent:") {
+		return fmt.Errorf("alias requires an entity ID (prefix 'ent:'), got %q", entityID)
+	}
+	if _, ok := q.g.nodes[entityID].value.(*tools.Entity); !ok {
+		return fmt.Errorf("entity %q not found", entityID)
+	}
+	key := aliasKey{ns: q.resolveNamespace(ctx), alias: alias}
+	if existing, ok := q.g.aliases[key]; ok && existing.entityID != entityID {
+		return fmt.Errorf("alias %q already refers to %s", alias, existing.entityID)
+	}
+	q.g.aliases[key] = aliasRow{entityID: entityID, createdAt: time.Now().Unix()}
+	return nil
+}
+
+// RenameEntity changes the name of an entity. The entity keeps its ID and
+// its previous name becomes an alias. A name another entity of the
+// namespace has, as name or alias, is rejected.
+func (q *Querier) RenameEntity(ctx context.Context, entityID, newName string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	err := q.renameEntity(ctx, entityID, newName)
+	q.publish(ctx, memory.ChangeUpdated, entityID, err)
+	q.audit(ctx, memory.ChangeUpdated, "", err, entityID)
+	return err
+}
+
+func (q *Querier) renameEntity(ctx context.Context, entityID, newName string) error {
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return fmt.Errorf("new name is required")
+	}
+	if !strings.HasPrefix(entityID, "ent:") {
+		return fmt.Errorf("rename requires an entity ID (prefix 'ent:'), got %q", entityID)
+	}
+	ent, ok := q.current(ctx, entityID).(*tools.Entity)
+	if !ok {
+		return fmt.Errorf("entity %q not found", entityID)
+	}
+	oldName := ent.Name
+	if oldName == newName {
+		return nil
+	}
+
+	ns := q.resolveNamespace(ctx)
+	if taken := q.entityNamed(ns, newName, entityID); taken != "" {
+		return fmt.Errorf("%q already names %s; merge the entities instead", newName, taken)
+	}
+	alias := strings.ToLower(oldName)
+	if existing, ok := q.g.aliases[aliasKey{ns: ns, alias: alias}]; ok && existing.entityID != entityID {
+		return fmt.Errorf("alias %q already refers to %s", alias, existing.entityID)
+	}
+
+	now := time.Now().Unix()
+	ent.Name = newName
+	ent.UpdatedAt = now
+	// A change of case only needs no alias: names match case-insensitively.
+	if !strings.EqualFold(oldName, newName) {
+		q.g.aliases[aliasKey{ns: ns, alias: alias}] = aliasRow{entityID: entityID, createdAt: now}
+	}
+	q.recordHistory(ctx, entityID, "name", oldName, newName, tools.ChangeReasonFromContext(ctx), "")
+	return nil
+}
+
+// entityNamed returns the ID of an entity of ns other than except that has
+// name as its name or as an alias, ignoring case, or "". q.mu must be held.
+func (q *Querier) entityNamed(ns, name, except string) string {
+	name = strings.ToLower(name)
+	var found string
+	for id, n := range q.g.nodes {
+		if ent, ok := n.value.(*tools.Entity); ok && n.ns == ns && id != except && strings.ToLower(ent.Name) == name {
+			if found == "" || id < found {
+				found = id
+			}
+		}
+	}
+	if found != "" {
+		return found
+	}
+	for key, a := range q.g.aliases {
+		if key.ns == ns && a.entityID != except && key.alias == name {
+			return a.entityID
+		}
+	}
+	return ""
+}
+
+// SetArchived archives or unarchives a node of the namespace. Archived
+// nodes are left out of search and list results unless the context asks
+// for them with tools.WithIncludeArchived.
+func (q *Querier) SetArchived(ctx context.Context, nodeID string, archived bool) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	err := q.setArchived(ctx, nodeID, archived)
+	op := memory.ChangeArchived
+	if !archived {
+		op = memory.ChangeUnarchived
+	}
+	q.publish(ctx, op, nodeID, err)
+	q.audit(ctx, op, "", err, nodeID)
+	return err
+}
+
+func (q *Querier) setArchived(ctx context.Context, nodeID string, archived bool) error {
+	if _, err := q.detectNodeType(nodeID); err != nil {
+		return err
+	}
+	if q.current(ctx, nodeID) == nil {
+		return fmt.Errorf("node %q not found", nodeID)
+	}
+	if archived {
+		q.g.archived[nodeID] = time.Now().Unix()
+	} else {
+		delete(q.g.archived, nodeID)
+	}
+	return nil
+}
+
+// SetVerified marks a fact of the namespace as confirmed by verifiedBy, or
+// clears the mark.
+func (q *Querier) SetVerified(ctx context.Context, factID, verifiedBy string, verified bool) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	err := q.setVerified(ctx, factID, verifiedBy, verified)
+	q.publish(ctx, memory.ChangeUpdated, factID, err)
+	q.audit(ctx, memory.ChangeUpdated, "", err, factID)
+	return err
+}
+
+func (q *Querier) setVerified(ctx context.Context, factID, verifiedBy string, verified bool) error {
+	f, ok := q.current(ctx, factID).(*tools.Fact)
+	if !ok {
+		return fmt.Errorf("fact %q not found", factID)
+	}
+	if !verified {
+		f.Verified, f.VerifiedBy, f.VerifiedAt = false, "", 0
+		return nil
+	}
+	if verifiedBy == "" {
+		return fmt.Errorf("verified_by is required to verify a fact")
+	}
+	f.Verified, f.VerifiedBy, f.VerifiedAt = true, verifiedBy, time.Now().Unix()
+	return nil
+}
+
+// MergeEntities folds the duplicate entity into the survivor: its edges
+// and aliases move to the survivor, its name becomes an alias of the
+// survivor, and it is deleted.
+func (q *Querier) MergeEntities(ctx context.Context, survivorID, duplicateID string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	err := q.mergeEntities(ctx, survivorID, duplicateID)
+	q.publish(ctx, memory.ChangeMerged, survivorID, err)
+	q.audit(ctx, memory.ChangeMerged, "", err, survivorID, duplicateID)
+	return err
+}
+
+func (q *Querier) mergeEntities(ctx context.Context, survivorID, duplicateID string) error {
+	if survivorID == duplicateID {
+		return fmt.Errorf("cannot merge entity %q into itself", survivorID)
+	}
+	for _, id := range []string{survivorID, duplicateID} {
+		if !strings.HasPrefix(id, "ent:") {
+			return fmt.Errorf("merge requires entity IDs (prefix 'ent:'), got %q", id)
+		}
+	}
+	for _, id := range []string{survivorID, duplicateID} {
+		if _, ok := q.g.nodes[id].value.(*tools.Entity); !ok {
+			return fmt.Errorf("entity %q not found", id)
+		}
+	}
+	duplicate := q.g.nodes[duplicateID].value.(*tools.Entity)
+
+	for _, table := range []string{"mie_fact_entity", "mie_decision_entity", "mie_entity_topic"} {
+		side := slices.Index(memory.ValidEdgeTables[table], "entity_id")
+		rows := q.g.edges[table]
+		for key, e := range rows {
+			if key[side] != duplicateID {
+				continue
+			}
+			delete(rows, key)
+			key[side] = survivorID
+			rows[key] = e
+		}
+	}
+	for key, a := range q.g.aliases {
+		if a.entityID == duplicateID {
+			a.entityID = survivorID
+			q.g.aliases[key] = a
+		}
+	}
+	key := aliasKey{ns: q.resolveNamespace(ctx), alias: strings.ToLower(strings.TrimSpace(duplicate.Name))}
+	q.g.aliases[key] = aliasRow{entityID: survivorID, createdAt: time.Now().Unix()}
+	delete(q.g.archived, duplicateID)
+	delete(q.g.nodes, duplicateID)
+	return nil
+}
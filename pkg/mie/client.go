@@ -0,0 +1,134 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package mie
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kraklabs/mie/pkg/memory"
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// Client is an open memory graph. It is safe for concurrent use.
+type Client struct {
+	memory *memory.Client
+}
+
+// Open opens the memory graph stored in dataDir, creating it if needed,
+// and applies any pending schema migrations.
+func Open(dataDir string, opts ...Option) (*Client, error) {
+	if dataDir == "" {
+		return nil, fmt.Errorf("data directory is required")
+	}
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	cfg := memory.ClientConfig{
+		DataDir:        dataDir,
+		StorageEngine:  o.engine,
+		Namespace:      o.namespace,
+		DedupThreshold: o.dedupThreshold,
+	}
+	if e := o.embeddings; e != nil {
+		cfg.EmbeddingEnabled = true
+		cfg.EmbeddingProvider = e.Provider
+		cfg.EmbeddingBaseURL = e.BaseURL
+		cfg.EmbeddingModel = e.Model
+		cfg.EmbeddingAPIKey = e.APIKey
+		cfg.EmbeddingDimensions = e.Dimensions
+		cfg.EmbeddingWorkers = e.Workers
+		if cfg.EmbeddingDimensions <= 0 {
+			cfg.EmbeddingDimensions = 768
+		}
+		if cfg.EmbeddingWorkers <= 0 {
+			cfg.EmbeddingWorkers = 4
+		}
+	}
+
+	client, err := memory.NewClientWithLogger(cfg, o.logger)
+	if err != nil {
+		return nil, fmt.Errorf("open memory graph: %w", err)
+	}
+	return &Client{memory: client}, nil
+}
+
+// Store saves node and returns its ID. Storing a node with the same
+// identifying fields again updates it in place, and storing a fact that
+// duplicates an existing one returns the existing fact's ID.
+func (c *Client) Store(ctx context.Context, node Node) (string, error) {
+	switch n := node.(type) {
+	case Fact:
+		fact, err := c.memory.StoreFact(ctx, tools.StoreFactRequest(n))
+		if err != nil {
+			return "", err
+		}
+		return fact.ID, nil
+	case Decision:
+		decision, err := c.memory.StoreDecision(ctx, tools.StoreDecisionRequest(n))
+		if err != nil {
+			return "", err
+		}
+		return decision.ID, nil
+	case Entity:
+		entity, err := c.memory.StoreEntity(ctx, tools.StoreEntityRequest(n))
+		if err != nil {
+			return "", err
+		}
+		return entity.ID, nil
+	case Event:
+		event, err := c.memory.StoreEvent(ctx, tools.StoreEventRequest(n))
+		if err != nil {
+			return "", err
+		}
+		return event.ID, nil
+	case Topic:
+		topic, err := c.memory.StoreTopic(ctx, tools.StoreTopicRequest(n))
+		if err != nil {
+			return "", err
+		}
+		return topic.ID, nil
+	default:
+		return "", fmt.Errorf("unsupported node type %T", node)
+	}
+}
+
+// Search returns the nodes matching query, best first. Without options it
+// runs a hybrid search over facts, decisions, entities, and events and
+// returns up to 10 results.
+func (c *Client) Search(ctx context.Context, query string, opts ...SearchOption) ([]Result, error) {
+	o, err := newSearchOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	switch o.mode {
+	case ModeSemantic:
+		if !c.memory.EmbeddingsEnabled() {
+			return nil, fmt.Errorf("semantic search requires embeddings (see WithEmbeddings)")
+		}
+		return c.memory.SemanticSearch(ctx, query, o.nodeTypes, o.limit)
+	case ModeExact:
+		return c.memory.ExactSearch(ctx, query, o.nodeTypes, o.limit)
+	case ModeFullText:
+		return c.memory.FullTextSearch(ctx, query, o.nodeTypes, o.limit)
+	default:
+		return c.memory.HybridSearch(ctx, query, o.nodeTypes, o.limit)
+	}
+}
+
+// Querier returns the underlying memory graph for operations the facade
+// does not cover, such as graph traversal, updates, and export.
+func (c *Client) Querier() tools.Querier {
+	return c.memory
+}
+
+// Close releases the database. The Client must not be used afterwards.
+func (c *Client) Close() error {
+	return c.memory.Close()
+}
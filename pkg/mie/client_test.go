@@ -0,0 +1,61 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+//go:build cozodb
+
+package mie
+
+import (
+	"context"
+	"testing"
+)
+
+func TestClient_StoreAndSearch(t *testing.T) {
+	ctx := context.Background()
+	client, err := Open(t.TempDir(), WithStorageEngine("mem"), WithNamespace("sdk"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	factID, err := client.Store(ctx, Fact{Content: "The user prefers Go for backend services", Category: "preference"})
+	if err != nil {
+		t.Fatalf("Store(Fact) error = %v", err)
+	}
+	if _, err := client.Store(ctx, Entity{Name: "Go", Kind: "technology"}); err != nil {
+		t.Fatalf("Store(Entity) error = %v", err)
+	}
+	if _, err := client.Store(ctx, Topic{Name: "languages"}); err != nil {
+		t.Fatalf("Store(Topic) error = %v", err)
+	}
+
+	results, err := client.Search(ctx, "backend", SearchMode(ModeExact))
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(results) != 1 || results[0].ID != factID {
+		t.Errorf("Search() = %+v, want the stored fact %s", results, factID)
+	}
+
+	results, err = client.Search(ctx, "languages", SearchNodeTypes("topic"))
+	if err != nil {
+		t.Fatalf("Search(topic) error = %v", err)
+	}
+	if len(results) != 1 || results[0].NodeType != "topic" {
+		t.Errorf("Search(topic) = %+v, want the topic", results)
+	}
+
+	if _, err := client.Search(ctx, "backend", SearchMode(ModeSemantic)); err == nil {
+		t.Error("semantic search without embeddings: expected error")
+	}
+	if node, err := client.Querier().GetNodeByID(ctx, factID); err != nil || node == nil {
+		t.Errorf("Querier().GetNodeByID() = %v, %v", node, err)
+	}
+}
+
+func TestOpen_RequiresDataDir(t *testing.T) {
+	if _, err := Open(""); err == nil {
+		t.Error("Open(\"\"): expected error")
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+// Package mie embeds the Memory Intelligence Engine in Go programs.
+//
+// It is a small, stable facade over pkg/memory for agents that want a
+// memory graph without running the mie CLI or an MCP server: open a data
+// directory, store nodes, search them, and close it.
+//
+// # Quick Start
+//
+//	client, err := mie.Open("/path/to/data",
+//	    mie.WithNamespace("my-agent"),
+//	    mie.WithEmbeddings(mie.Embeddings{Provider: "ollama", Model: "nomic-embed-text"}),
+//	)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer client.Close()
+//
+//	id, err := client.Store(ctx, mie.Fact{Content: "The user prefers Go", Category: "preference"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	results, err := client.Search(ctx, "programming language", mie.SearchLimit(5))
+//
+// # Build Requirements
+//
+// MIE stores its graph in CozoDB, which is linked through CGO. Open and
+// Client are only compiled with the cozodb build tag; see
+// docs/contributing.md for how to build the CozoDB C library. The option
+// and node types in this package are always available.
+//
+// # Beyond the Facade
+//
+// Client.Querier returns the underlying tools.Querier, which offers graph
+// traversal, updates, conflict detection, and export.
+package mie
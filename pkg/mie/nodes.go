@@ -0,0 +1,112 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package mie
+
+import (
+	"fmt"
+
+	"github.com/kraklabs/mie/pkg/tools"
+)
+
+// Node is a memory node to store: a Fact, Decision, Entity, Event, or Topic.
+type Node interface {
+	isNode()
+}
+
+// Fact is a piece of knowledge, such as a preference or a technical detail.
+type Fact tools.StoreFactRequest
+
+// Decision is a choice with its rationale.
+type Decision tools.StoreDecisionRequest
+
+// Entity is a person, project, technology, or other named thing.
+type Entity tools.StoreEntityRequest
+
+// Event is something that happened on a date (YYYY-MM-DD).
+type Event tools.StoreEventRequest
+
+// Topic is a subject that groups other nodes.
+type Topic tools.StoreTopicRequest
+
+func (Fact) isNode()     {}
+func (Decision) isNode() {}
+func (Entity) isNode()   {}
+func (Event) isNode()    {}
+func (Topic) isNode()    {}
+
+// Result is a node found by Search.
+type Result = tools.SearchResult
+
+// Search modes accepted by SearchMode.
+const (
+	// ModeHybrid fuses semantic and keyword matches. It is the default and
+	// falls back to keyword matches when embeddings are disabled.
+	ModeHybrid = "hybrid"
+	// ModeSemantic ranks nodes by embedding similarity and requires
+	// WithEmbeddings.
+	ModeSemantic = "semantic"
+	// ModeExact matches nodes containing the query as a substring.
+	ModeExact = "exact"
+	// ModeFullText ranks keyword matches with stemming and stopword removal.
+	ModeFullText = "fulltext"
+)
+
+// nodeTypes are the node types SearchNodeTypes accepts.
+var nodeTypes = map[string]bool{
+	"fact": true, "decision": true, "entity": true, "event": true, "topic": true,
+}
+
+// SearchOption configures a Search call.
+type SearchOption func(*searchOptions)
+
+// searchOptions collects the settings applied by SearchOption functions.
+type searchOptions struct {
+	mode      string
+	nodeTypes []string
+	limit     int
+}
+
+// newSearchOptions applies opts to the defaults: hybrid mode, all node
+// types, and 10 results.
+func newSearchOptions(opts []SearchOption) (searchOptions, error) {
+	o := searchOptions{
+		mode:      ModeHybrid,
+		nodeTypes: []string{"fact", "decision", "entity", "event"},
+		limit:     10,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	switch o.mode {
+	case ModeHybrid, ModeSemantic, ModeExact, ModeFullText:
+	default:
+		return o, fmt.Errorf("invalid search mode %q: must be %s, %s, %s, or %s", o.mode, ModeHybrid, ModeSemantic, ModeExact, ModeFullText)
+	}
+	for _, t := range o.nodeTypes {
+		if !nodeTypes[t] {
+			return o, fmt.Errorf("invalid node type %q", t)
+		}
+	}
+	if o.limit <= 0 {
+		return o, fmt.Errorf("invalid search limit %d: must be positive", o.limit)
+	}
+	return o, nil
+}
+
+// SearchMode selects how Search matches nodes.
+func SearchMode(mode string) SearchOption {
+	return func(o *searchOptions) { o.mode = mode }
+}
+
+// SearchNodeTypes restricts Search to the given node types: "fact",
+// "decision", "entity", "event", or "topic".
+func SearchNodeTypes(nodeTypes ...string) SearchOption {
+	return func(o *searchOptions) { o.nodeTypes = nodeTypes }
+}
+
+// SearchLimit sets the maximum number of results. It defaults to 10.
+func SearchLimit(limit int) SearchOption {
+	return func(o *searchOptions) { o.limit = limit }
+}
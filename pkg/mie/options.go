@@ -0,0 +1,67 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package mie
+
+import "log/slog"
+
+// Option configures a Client opened with Open.
+type Option func(*options)
+
+// options collects the settings applied by Option functions.
+type options struct {
+	engine         string
+	namespace      string
+	embeddings     *Embeddings
+	dedupThreshold float64
+	logger         *slog.Logger
+}
+
+// Embeddings configures the provider that generates embeddings for
+// semantic search. Provider is one of "ollama", "openai", "nomic", or
+// "local"; empty fields use the provider's defaults.
+type Embeddings struct {
+	Provider   string
+	BaseURL    string
+	Model      string
+	APIKey     string
+	Dimensions int // defaults to 768
+	Workers    int // concurrent requests when backfilling; defaults to 4
+}
+
+// defaultOptions returns the settings used when no Option overrides them:
+// the RocksDB engine, the default namespace, and no embeddings.
+func defaultOptions() options {
+	return options{engine: "rocksdb"}
+}
+
+// WithStorageEngine selects the CozoDB storage engine: "rocksdb" (the
+// default), "sqlite", or "mem" for a memory graph that is discarded on Close.
+func WithStorageEngine(engine string) Option {
+	return func(o *options) { o.engine = engine }
+}
+
+// WithNamespace stores and searches nodes in namespace instead of the
+// default namespace.
+func WithNamespace(namespace string) Option {
+	return func(o *options) { o.namespace = namespace }
+}
+
+// WithEmbeddings enables semantic search with the given provider. Without
+// it, Search matches nodes by keyword only.
+func WithEmbeddings(e Embeddings) Option {
+	return func(o *options) { o.embeddings = &e }
+}
+
+// WithDedupThreshold sets the similarity at or above which storing a fact
+// returns an existing fact instead. A negative value disables deduplication.
+func WithDedupThreshold(threshold float64) Option {
+	return func(o *options) { o.dedupThreshold = threshold }
+}
+
+// WithLogger sets the logger for warnings such as an unreachable embedding
+// provider. It defaults to slog.Default().
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) { o.logger = logger }
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package mie
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOptions(t *testing.T) {
+	o := defaultOptions()
+	for _, opt := range []Option{
+		WithStorageEngine("mem"),
+		WithNamespace("agent"),
+		WithEmbeddings(Embeddings{Provider: "ollama", Model: "nomic-embed-text"}),
+		WithDedupThreshold(-1),
+	} {
+		opt(&o)
+	}
+
+	if o.engine != "mem" || o.namespace != "agent" || o.dedupThreshold != -1 {
+		t.Errorf("options = %+v", o)
+	}
+	if o.embeddings == nil || o.embeddings.Provider != "ollama" || o.embeddings.Model != "nomic-embed-text" {
+		t.Errorf("embeddings = %+v", o.embeddings)
+	}
+	if d := defaultOptions(); d.engine != "rocksdb" || d.embeddings != nil {
+		t.Errorf("defaultOptions() = %+v, want rocksdb without embeddings", d)
+	}
+}
+
+func TestNewSearchOptions(t *testing.T) {
+	o, err := newSearchOptions(nil)
+	if err != nil {
+		t.Fatalf("newSearchOptions(nil) error = %v", err)
+	}
+	want := searchOptions{mode: ModeHybrid, nodeTypes: []string{"fact", "decision", "entity", "event"}, limit: 10}
+	if !reflect.DeepEqual(o, want) {
+		t.Errorf("defaults = %+v, want %+v", o, want)
+	}
+
+	o, err = newSearchOptions([]SearchOption{SearchMode(ModeFullText), SearchNodeTypes("topic"), SearchLimit(3)})
+	if err != nil {
+		t.Fatalf("newSearchOptions() error = %v", err)
+	}
+	want = searchOptions{mode: ModeFullText, nodeTypes: []string{"topic"}, limit: 3}
+	if !reflect.DeepEqual(o, want) {
+		t.Errorf("options = %+v, want %+v", o, want)
+	}
+
+	for name, opt := range map[string]SearchOption{
+		"mode":      SearchMode("fuzzy"),
+		"node type": SearchNodeTypes("fact", "note"),
+		"limit":     SearchLimit(0),
+	} {
+		if _, err := newSearchOptions([]SearchOption{opt}); err == nil {
+			t.Errorf("invalid %s: expected error", name)
+		}
+	}
+}
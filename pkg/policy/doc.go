@@ -0,0 +1,33 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+// Package policy decides which MCP tools a client may call.
+//
+// The MCP server identifies each client by the name it sends in the
+// clientInfo of initialize, such as "cursor" or "claude-code", and looks up
+// the Rule configured for that name. A rule allows or denies tools by name,
+// optionally narrowed to one action of the tool:
+//
+//	rules := map[string]policy.Rule{
+//	    "cursor": {Deny: []string{"mie_merge", "*:delete"}},
+//	    "*":      {Allow: []string{"mie_query", "mie_context"}},
+//	}
+//	p, err := policy.New(rules)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	p.Allows("cursor", "mie_relate", "delete") // false
+//	p.Allows("cursor", "mie_store", "")        // true
+//
+// Patterns use path.Match syntax, so "mie_*" matches every tool. A pattern
+// without an action matches every action of the tool; "mie_relate:delete"
+// matches only calls that run the "delete" action. Callers pass the action
+// the call runs, which is the tool's default action when the call leaves
+// out the action argument (see tools.DefaultActions), so that
+// "mie_relate:create" also covers calls to mie_relate without action.
+//
+// A client without a rule of its own gets the "*" rule, and a client with
+// neither may call every tool. Deny patterns win over allow patterns, and a
+// rule with allow patterns denies the tools they do not match.
+package policy
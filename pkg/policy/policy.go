@@ -0,0 +1,112 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package policy
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// DefaultClient is the key of the rule for clients without a rule of their
+// own.
+const DefaultClient = "*"
+
+// Rule lists the tools a client may and may not call. Each entry is a tool
+// pattern, optionally followed by ":" and an action pattern.
+type Rule struct {
+	// Allow, when not empty, limits the client to the matching tools.
+	Allow []string `yaml:"allow,omitempty" json:"allow,omitempty"`
+	// Deny forbids the matching tools even when Allow matches them.
+	Deny []string `yaml:"deny,omitempty" json:"deny,omitempty"`
+}
+
+// Validate checks that every pattern of the rule is well formed.
+func (r Rule) Validate() error {
+	for _, patterns := range [][]string{r.Allow, r.Deny} {
+		for _, p := range patterns {
+			tool, action, _ := strings.Cut(p, ":")
+			if tool == "" {
+				return fmt.Errorf("invalid tool pattern %q: empty tool name", p)
+			}
+			for _, part := range []string{tool, action} {
+				if _, err := path.Match(part, ""); err != nil {
+					return fmt.Errorf("invalid tool pattern %q: %w", p, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// allows reports whether the rule lets a client call tool with action.
+func (r Rule) allows(tool, action string) bool {
+	if matchAny(r.Deny, tool, action) {
+		return false
+	}
+	return len(r.Allow) == 0 || matchAny(r.Allow, tool, action)
+}
+
+// Policy holds the rules of each client. The zero value, and a nil
+// *Policy, allow everything.
+type Policy struct {
+	rules map[string]Rule
+}
+
+// New returns a policy with rules keyed by client name, after validating
+// them. Client names are matched case-insensitively.
+func New(rules map[string]Rule) (*Policy, error) {
+	p := &Policy{rules: make(map[string]Rule, len(rules))}
+	for client, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, fmt.Errorf("client %q: %w", client, err)
+		}
+		p.rules[strings.ToLower(client)] = rule
+	}
+	return p, nil
+}
+
+// Rule returns the rule that applies to client and whether one is
+// configured.
+func (p *Policy) Rule(client string) (Rule, bool) {
+	if p == nil {
+		return Rule{}, false
+	}
+	if r, ok := p.rules[strings.ToLower(client)]; ok {
+		return r, true
+	}
+	r, ok := p.rules[DefaultClient]
+	return r, ok
+}
+
+// Allows reports whether client may call tool with action, the action the
+// call runs, including a default action when the call leaves it out, or ""
+// for the tool itself. A tool that is denied with
+// action "" is denied outright and should not be listed to the client.
+func (p *Policy) Allows(client, tool, action string) bool {
+	r, ok := p.Rule(client)
+	return !ok || r.allows(tool, action)
+}
+
+// matchAny reports whether one of patterns matches tool and action. A
+// pattern without an action matches every action; one with an action does
+// not match calls without one.
+func matchAny(patterns []string, tool, action string) bool {
+	for _, p := range patterns {
+		toolPattern, actionPattern, hasAction := strings.Cut(p, ":")
+		if ok, _ := path.Match(toolPattern, tool); !ok {
+			continue
+		}
+		if !hasAction {
+			return true
+		}
+		if action != "" {
+			if ok, _ := path.Match(actionPattern, action); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
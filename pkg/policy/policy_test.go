@@ -0,0 +1,79 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package policy
+
+import "testing"
+
+func TestPolicyAllows(t *testing.T) {
+	p, err := New(map[string]Rule{
+		"cursor":  {Deny: []string{"mie_merge", "*:delete"}},
+		"Claude":  {},
+		"reviews": {Allow: []string{"mie_query", "mie_context"}, Deny: []string{"mie_query:save"}},
+		"*":       {Allow: []string{"mie_query"}},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	tests := []struct {
+		client, tool, action string
+		want                 bool
+	}{
+		{"cursor", "mie_store", "", true},
+		{"cursor", "mie_merge", "", false},
+		{"cursor", "mie_relate", "", true},
+		{"cursor", "mie_relate", "delete", false},
+		{"cursor", "mie_query", "delete", false},
+		{"claude", "mie_merge", "", true},
+		{"reviews", "mie_query", "", true},
+		{"reviews", "mie_query", "save", false},
+		{"reviews", "mie_store", "", false},
+		{"windsurf", "mie_query", "", true},
+		{"windsurf", "mie_store", "", false},
+	}
+	for _, tt := range tests {
+		if got := p.Allows(tt.client, tt.tool, tt.action); got != tt.want {
+			t.Errorf("Allows(%q, %q, %q) = %v, want %v", tt.client, tt.tool, tt.action, got, tt.want)
+		}
+	}
+}
+
+func TestPolicyWithoutRules(t *testing.T) {
+	var p *Policy
+	if !p.Allows("cursor", "mie_merge", "") {
+		t.Error("a nil policy should allow every tool")
+	}
+	p, _ = New(map[string]Rule{"cursor": {Deny: []string{"mie_merge"}}})
+	if !p.Allows("claude", "mie_merge", "") {
+		t.Error("a client without a rule and no default should be allowed")
+	}
+}
+
+func TestRuleValidate(t *testing.T) {
+	if _, err := New(map[string]Rule{"cursor": {Deny: []string{"mie_[store"}}}); err == nil {
+		t.Error("New should reject a malformed pattern")
+	}
+	if _, err := New(map[string]Rule{"cursor": {Allow: []string{":delete"}}}); err == nil {
+		t.Error("New should reject a pattern without a tool")
+	}
+}
+
+func TestPolicyDefaultAction(t *testing.T) {
+	p, err := New(map[string]Rule{"cursor": {Deny: []string{"mie_relate:create"}}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	// The tool stays callable; a call without action runs create, which
+	// the server passes as the action.
+	if !p.Allows("cursor", "mie_relate", "") {
+		t.Error("mie_relate itself should be allowed")
+	}
+	if p.Allows("cursor", "mie_relate", "create") {
+		t.Error("mie_relate without action, which runs create, should be denied")
+	}
+	if !p.Allows("cursor", "mie_relate", "delete") {
+		t.Error("mie_relate delete should be allowed")
+	}
+}
@@ -16,10 +16,13 @@ import (
 // It provides methods for executing queries and mutations on the memory graph.
 type Backend interface {
 	// Query executes a read-only Datalog query and returns the results.
-	Query(ctx context.Context, datalog string) (*QueryResult, error)
+	// Values in params are bound to the $name placeholders in datalog;
+	// params may be nil.
+	Query(ctx context.Context, datalog string, params map[string]any) (*QueryResult, error)
 
-	// Execute runs a Datalog mutation (insert, update, delete).
-	Execute(ctx context.Context, datalog string) error
+	// Execute runs a Datalog mutation (insert, update, delete), binding
+	// params like Query.
+	Execute(ctx context.Context, datalog string, params map[string]any) error
 
 	// Close releases any resources held by the backend.
 	Close() error
@@ -39,7 +39,7 @@
 //	result, err := backend.Query(ctx, `
 //	    ?[id, content] := *mie_fact{id, content}
 //	    :limit 10
-//	`)
+//	`, nil)
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
@@ -62,10 +62,22 @@
 // Use Query for read operations and Execute for mutations:
 //
 //	// Read-only query (uses RunReadOnly internally)
-//	result, err := backend.Query(ctx, `?[count(f)] := *mie_fact{id: f}`)
+//	result, err := backend.Query(ctx, `?[count(f)] := *mie_fact{id: f}`, nil)
 //
 //	// Mutation (uses Run internally)
-//	err := backend.Execute(ctx, `:rm mie_fact { id: "fact123" }`)
+//	err := backend.Execute(ctx, `?[id] <- [[$id]] :rm mie_fact { id }`, map[string]any{"id": "fact123"})
+//
+// # Parameters
+//
+// Pass values through params rather than formatting them into the script.
+// Each key binds to a $name placeholder, so strings never need escaping and
+// cannot change the shape of the query:
+//
+//	result, err := backend.Query(ctx,
+//	    `?[content] := *mie_fact{id, content}, id = $id`,
+//	    map[string]any{"id": factID})
+//
+// Relation and column names cannot be bound and must come from constants.
 //
 // # Configuration
 //
@@ -84,8 +84,9 @@ func NewEmbeddedBackend(config EmbeddedConfig) (*EmbeddedBackend, error) {
 	}, nil
 }
 
-// Query executes a read-only Datalog query.
-func (b *EmbeddedBackend) Query(ctx context.Context, datalog string) (*QueryResult, error) {
+// Query executes a read-only Datalog query with params bound to its
+// $name placeholders.
+func (b *EmbeddedBackend) Query(ctx context.Context, datalog string, params map[string]any) (*QueryResult, error) {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 
@@ -100,7 +101,7 @@ func (b *EmbeddedBackend) Query(ctx context.Context, datalog string) (*QueryResu
 	default:
 	}
 
-	result, err := b.db.RunReadOnly(datalog, nil)
+	result, err := b.db.RunReadOnly(datalog, params)
 	if err != nil {
 		return nil, fmt.Errorf("query failed: %w", err)
 	}
@@ -108,8 +109,9 @@ func (b *EmbeddedBackend) Query(ctx context.Context, datalog string) (*QueryResu
 	return FromNamedRows(result), nil
 }
 
-// Execute runs a Datalog mutation.
-func (b *EmbeddedBackend) Execute(ctx context.Context, datalog string) error {
+// Execute runs a Datalog mutation with params bound to its $name
+// placeholders.
+func (b *EmbeddedBackend) Execute(ctx context.Context, datalog string, params map[string]any) error {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
@@ -124,7 +126,7 @@ func (b *EmbeddedBackend) Execute(ctx context.Context, datalog string) error {
 	default:
 	}
 
-	_, err := b.db.Run(datalog, nil)
+	_, err := b.db.Run(datalog, params)
 	if err != nil {
 		return fmt.Errorf("execute failed: %w", err)
 	}
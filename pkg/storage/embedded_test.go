@@ -130,7 +130,7 @@ func TestEmbeddedBackend_Query_Success(t *testing.T) {
 	ctx := context.Background()
 
 	// Simple query that should always work
-	result, err := backend.Query(ctx, "?[x] := x = 1")
+	result, err := backend.Query(ctx, "?[x] := x = 1", nil)
 	if err != nil {
 		t.Fatalf("Query failed: %v", err)
 	}
@@ -143,6 +143,35 @@ func TestEmbeddedBackend_Query_Success(t *testing.T) {
 	}
 }
 
+// TestEmbeddedBackend_Query_Params tests that params bind to placeholders
+// without being parsed as script.
+func TestEmbeddedBackend_Query_Params(t *testing.T) {
+	backend := setupTestStorage(t)
+	defer func() {
+		_ = backend.Close()
+	}()
+
+	ctx := context.Background()
+	if err := backend.Execute(ctx, ":create param_test { id: String => name: String }", nil); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	name := `O'Brien \ "quoted" ] :rm param_test { id }`
+	err := backend.Execute(ctx, `?[id, name] <- [[$id, $name]] :put param_test { id => name }`,
+		map[string]any{"id": "a", "name": name})
+	if err != nil {
+		t.Fatalf("Execute with params failed: %v", err)
+	}
+
+	result, err := backend.Query(ctx, `?[name] := *param_test { id, name }, id = $id`, map[string]any{"id": "a"})
+	if err != nil {
+		t.Fatalf("Query with params failed: %v", err)
+	}
+	if len(result.Rows) != 1 || result.Rows[0][0] != name {
+		t.Errorf("rows = %v, want [[%q]]", result.Rows, name)
+	}
+}
+
 // TestEmbeddedBackend_Query_ContextCanceled tests query with canceled context.
 func TestEmbeddedBackend_Query_ContextCanceled(t *testing.T) {
 	backend := setupTestStorage(t)
@@ -153,7 +182,7 @@ func TestEmbeddedBackend_Query_ContextCanceled(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel() // Cancel immediately
 
-	_, err := backend.Query(ctx, "?[x] := x = 1")
+	_, err := backend.Query(ctx, "?[x] := x = 1", nil)
 	if err == nil {
 		t.Error("expected error with canceled context")
 	}
@@ -168,7 +197,7 @@ func TestEmbeddedBackend_Query_AfterClose(t *testing.T) {
 	_ = backend.Close()
 
 	ctx := context.Background()
-	_, err := backend.Query(ctx, "?[x] := x = 1")
+	_, err := backend.Query(ctx, "?[x] := x = 1", nil)
 	if err == nil {
 		t.Error("expected error when querying closed backend")
 	}
@@ -187,7 +216,7 @@ func TestEmbeddedBackend_Execute_Success(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a simple table
-	err := backend.Execute(ctx, ":create test_table { id: Int => name: String }")
+	err := backend.Execute(ctx, ":create test_table { id: Int => name: String }", nil)
 	if err != nil {
 		// Table might already exist, ignore that error
 		if !strings.Contains(err.Error(), "already exists") {
@@ -206,7 +235,7 @@ func TestEmbeddedBackend_Execute_ContextCanceled(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel() // Cancel immediately
 
-	err := backend.Execute(ctx, ":create test_table2 { id: Int }")
+	err := backend.Execute(ctx, ":create test_table2 { id: Int }", nil)
 	if err == nil {
 		t.Error("expected error with canceled context")
 	}
@@ -221,7 +250,7 @@ func TestEmbeddedBackend_Execute_AfterClose(t *testing.T) {
 	_ = backend.Close()
 
 	ctx := context.Background()
-	err := backend.Execute(ctx, ":create test_table3 { id: Int }")
+	err := backend.Execute(ctx, ":create test_table3 { id: Int }", nil)
 	if err == nil {
 		t.Error("expected error when executing on closed backend")
 	}
@@ -260,13 +289,13 @@ func TestEmbeddedBackend_Close_PreventsOperations(t *testing.T) {
 	ctx := context.Background()
 
 	// Try Query
-	_, err := backend.Query(ctx, "?[x] := x = 1")
+	_, err := backend.Query(ctx, "?[x] := x = 1", nil)
 	if err == nil {
 		t.Error("Query should fail after Close()")
 	}
 
 	// Try Execute
-	err = backend.Execute(ctx, ":create test { id: Int }")
+	err = backend.Execute(ctx, ":create test { id: Int }", nil)
 	if err == nil {
 		t.Error("Execute should fail after Close()")
 	}
@@ -286,7 +315,7 @@ func TestEmbeddedBackend_EnsureSchema(t *testing.T) {
 
 	// Verify mie_meta table was created by querying it
 	ctx := context.Background()
-	result, err := backend.Query(ctx, "?[key, value] := *mie_meta{key, value} :limit 1")
+	result, err := backend.Query(ctx, "?[key, value] := *mie_meta{key, value} :limit 1", nil)
 	if err != nil {
 		t.Fatalf("Query after EnsureSchema failed: %v", err)
 	}
@@ -347,7 +376,7 @@ func TestEmbeddedBackend_ConcurrentReads(t *testing.T) {
 	for range numReaders {
 		go func() {
 			defer wg.Done()
-			_, err := backend.Query(ctx, "?[x] := x = 1")
+			_, err := backend.Query(ctx, "?[x] := x = 1", nil)
 			if err != nil {
 				t.Errorf("concurrent Query failed: %v", err)
 			}
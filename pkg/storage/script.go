@@ -0,0 +1,90 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package storage
+
+import "strings"
+
+// writeOps are the query options that modify a stored relation.
+var writeOps = map[string]bool{
+	"put": true, "rm": true, "insert": true, "update": true, "delete": true,
+	"create": true, "replace": true, "ensure": true, "ensure_not": true,
+}
+
+// readOnlySysOps are the system operations that only inspect the database.
+// Every other system operation, such as ::remove or ::index, is a write.
+var readOnlySysOps = map[string]bool{
+	"relations": true, "columns": true, "indices": true, "explain": true,
+	"running": true, "show_triggers": true, "fixed_rules": true,
+}
+
+// WriteOps returns the operations in a CozoScript script that modify the
+// database, such as ":put" or "::remove", in the order they appear. String
+// literals and comments are skipped. A script without any only reads.
+//
+// The classification is conservative: a write option nested in a read-only
+// system operation, as in "::explain { ... :put ... }", still counts.
+func WriteOps(script string) []string {
+	var ops []string
+	for i := 0; i < len(script); {
+		switch c := script[i]; {
+		case c == '"' || c == '\'':
+			i = skipString(script, i)
+		case c == '#':
+			i = skipTo(script, i, "\n")
+		case strings.HasPrefix(script[i:], "/*"):
+			i = skipTo(script, i+2, "*/")
+		case c == ':' && (i == 0 || !isIdentByte(script[i-1])):
+			sys := strings.HasPrefix(script[i:], "::")
+			start := i + 1
+			if sys {
+				start++
+			}
+			end := start
+			for end < len(script) && isIdentByte(script[end]) {
+				end++
+			}
+			name := script[start:end]
+			switch {
+			case name == "":
+			case sys && !readOnlySysOps[name]:
+				ops = append(ops, "::"+name)
+			case !sys && writeOps[name]:
+				ops = append(ops, ":"+name)
+			}
+			i = end
+		default:
+			i++
+		}
+	}
+	return ops
+}
+
+// skipString returns the index just past the string literal opening at i,
+// honoring backslash escapes.
+func skipString(s string, i int) int {
+	quote := s[i]
+	for i++; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// skipTo returns the index just past the next occurrence of end at or after
+// i, or len(s).
+func skipTo(s string, i int, end string) int {
+	if j := strings.Index(s[i:], end); j >= 0 {
+		return i + j + len(end)
+	}
+	return len(s)
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
@@ -0,0 +1,36 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package storage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteOps(t *testing.T) {
+	tests := []struct {
+		script string
+		want   string
+	}{
+		{`?[name] := *mie_entity { name } :limit 10`, ""},
+		{`?[id, content] := *mie_fact { id, content } :order -created_at :offset 5`, ""},
+		{`?[id] := *mie_fact{id:x}, id = x`, ""},
+		{`::relations`, ""},
+		{`::columns mie_fact`, ""},
+		{`?[c] := c = ':put' # :rm in a comment`, ""},
+		{`?[c] := c = "say \":rm\"" /* ::remove */`, ""},
+		{`?[id] <- [['fact:1']] :rm mie_fact { id }`, ":rm"},
+		{`?[k, v] <- [['a', 1]] :put mie_meta { key: k => value: v }`, ":put"},
+		{`:create scratch { k: String }`, ":create"},
+		{`::remove mie_fact`, "::remove"},
+		{`::index create mie_fact:by_cat { category }`, "::index"},
+		{"{?[a] <- [[1]] :replace t { a }}\n{::remove t}", ":replace,::remove"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(WriteOps(tt.script), ","); got != tt.want {
+			t.Errorf("WriteOps(%q) = %q, want %q", tt.script, got, tt.want)
+		}
+	}
+}
@@ -6,6 +6,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -14,26 +15,27 @@ import (
 var allSearchableNodeTypes = []string{"fact", "decision", "entity", "event"}
 
 // Analyze provides context for agent self-evaluation before storing new memory.
-// It searches the existing memory graph for related nodes and returns a structured
-// evaluation prompt for the agent to decide what to persist.
+// It searches the existing memory graph for related nodes, proposes candidate
+// nodes extracted from the content, and returns a structured evaluation
+// prompt for the agent to decide what to persist.
 func Analyze(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
 	content := GetStringArg(args, "content", "")
 	if content == "" {
 		return NewError("Missing required parameter: content"), nil
 	}
 
-	_ = GetStringArg(args, "content_type", "conversation")
+	contentType := GetStringArg(args, "content_type", "conversation")
 
 	var sb strings.Builder
 
 	// Search for related nodes across all types
 	var results []SearchResult
+	var searchErr error
 	if client.EmbeddingsEnabled() {
-		var err error
-		results, err = client.SemanticSearch(ctx, content, allSearchableNodeTypes, 10)
-		if err != nil {
+		results, searchErr = client.SemanticSearch(ctx, content, allSearchableNodeTypes, 10)
+		if searchErr != nil {
 			// Non-fatal: continue without search results
-			fmt.Fprintf(&sb, "_Note: Semantic search failed: %v_\n\n", err)
+			fmt.Fprintf(&sb, "_Note: Semantic search failed: %v_\n\n", searchErr)
 		}
 	}
 
@@ -44,6 +46,24 @@ func Analyze(ctx context.Context, client Querier, args map[string]any) (*ToolRes
 		_ = err
 	}
 
+	candidates := extractCandidates(ctx, client, content, contentType)
+
+	if WantsJSON(args) {
+		out := analyzeJSON{
+			EmbeddingsEnabled: client.EmbeddingsEnabled(),
+			Related:           results,
+			Conflicts:         conflicts,
+			Candidates:        candidates,
+		}
+		if out.Candidates == nil {
+			out.Candidates = []analyzeCandidate{}
+		}
+		if searchErr != nil {
+			out.SearchError = searchErr.Error()
+		}
+		return NewJSONResult(out), nil
+	}
+
 	// Build response
 	sb.WriteString("## Existing Memory Context\n\n")
 
@@ -66,6 +86,11 @@ func Analyze(ctx context.Context, client Querier, args map[string]any) (*ToolRes
 		sb.WriteString("\n")
 	}
 
+	// Candidates section
+	if len(candidates) > 0 {
+		formatAnalyzeCandidates(&sb, candidates)
+	}
+
 	// Evaluation guide
 	sb.WriteString("---\n\n")
 	sb.WriteString("## Evaluation Guide\n\n")
@@ -75,13 +100,14 @@ func Analyze(ctx context.Context, client Querier, args map[string]any) (*ToolRes
 	sb.WriteString("3. **DECISION**: A choice with clear rationale and alternatives considered\n")
 	sb.WriteString("4. **NEW ENTITY**: A person, company, project, or technology not yet in the graph\n")
 	sb.WriteString("5. **EVENT**: A timestamped occurrence worth recording\n\n")
-	sb.WriteString("If you identify something to persist, call `mie_store` with the appropriate type.\n")
+	sb.WriteString("If you identify something to persist, call `mie_store` with the appropriate type, or pass the reviewed candidates to `mie_bulk_store`.\n")
 	sb.WriteString("If an existing fact needs correction, call `mie_update` to invalidate the old fact first.\n")
+	sb.WriteString("If a fact is only true until a known time (\"traveling until March\"), store it with `expires_at`; it is invalidated automatically once it expires.\n")
 	sb.WriteString("If nothing is worth persisting, do nothing.\n\n")
 
 	// Store schema reference
 	sb.WriteString("### Store Schema Reference\n\n")
-	sb.WriteString("For facts: `{\"type\": \"fact\", \"content\": \"...\", \"category\": \"personal|professional|preference|technical|relationship|general\", \"confidence\": 0.0-1.0}`\n")
+	sb.WriteString("For facts: `{\"type\": \"fact\", \"content\": \"...\", \"category\": \"personal|professional|preference|technical|relationship|general\", \"confidence\": 0.0-1.0, \"expires_at\": \"YYYY-MM-DD (optional)\"}`\n")
 	sb.WriteString("For decisions: `{\"type\": \"decision\", \"title\": \"...\", \"rationale\": \"...\", \"alternatives\": \"[...]\", \"context\": \"...\"}`\n")
 	sb.WriteString("For entities: `{\"type\": \"entity\", \"name\": \"...\", \"kind\": \"person|company|project|product|technology|place\", \"description\": \"...\"}`\n")
 	sb.WriteString("For events: `{\"type\": \"event\", \"title\": \"...\", \"description\": \"...\", \"event_date\": \"YYYY-MM-DD\"}`\n\n")
@@ -90,6 +116,39 @@ func Analyze(ctx context.Context, client Querier, args map[string]any) (*ToolRes
 	return NewResult(sb.String()), nil
 }
 
+// analyzeJSON is the JSON response of Analyze.
+type analyzeJSON struct {
+	EmbeddingsEnabled bool           `json:"embeddings_enabled"`
+	SearchError       string         `json:"search_error,omitempty"`
+	Related           []SearchResult `json:"related"`
+	Conflicts         []Conflict     `json:"conflicts"`
+	// Candidates are proposed nodes whose items can be passed to
+	// mie_bulk_store.
+	Candidates []analyzeCandidate `json:"candidates"`
+}
+
+// formatAnalyzeCandidates writes the candidates as a mie_bulk_store items
+// array, followed by the candidates that look like duplicates.
+func formatAnalyzeCandidates(sb *strings.Builder, candidates []analyzeCandidate) {
+	items := make([]map[string]any, len(candidates))
+	for i, c := range candidates {
+		items[i] = c.Item
+	}
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(sb, "### Candidates (%d)\n", len(candidates))
+	sb.WriteString("Extracted from the content. Review, edit, or drop items, then pass the rest as `items` to `mie_bulk_store`:\n\n")
+	fmt.Fprintf(sb, "```json\n%s\n```\n\n", data)
+	for i, c := range candidates {
+		if c.DuplicateOf != "" {
+			fmt.Fprintf(sb, "- Item [%d] may duplicate [%s] (similarity: %.0f%%)\n", i, c.DuplicateOf, c.DuplicateSimilarity*100)
+		}
+	}
+	sb.WriteString("\n")
+}
+
 func formatAnalyzeResults(sb *strings.Builder, results []SearchResult) {
 	// Group results by node type
 	grouped := map[string][]SearchResult{}
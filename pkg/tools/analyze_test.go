@@ -6,6 +6,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"strings"
 	"testing"
 )
@@ -152,4 +153,32 @@ func TestAnalyze_EmbeddingsDisabled(t *testing.T) {
 	if !strings.Contains(result.Text, "Evaluation Guide") {
 		t.Error("Analyze() should always include evaluation guide")
 	}
-}
\ No newline at end of file
+}
+
+func TestAnalyze_JSONCandidates(t *testing.T) {
+	mock := &MockQuerier{}
+	result, err := Analyze(context.Background(), mock, map[string]any{
+		"content":         "The user prefers TypeScript over JavaScript for new projects.",
+		"response_format": "json",
+	})
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	var out analyzeJSON
+	if err := json.Unmarshal([]byte(result.Text), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result.Text)
+	}
+	if len(out.Candidates) != 1 {
+		t.Fatalf("candidates = %+v, want 1", out.Candidates)
+	}
+	item := out.Candidates[0].Item
+	if item["type"] != "fact" || item["category"] != "preference" {
+		t.Errorf("candidate item = %v", item)
+	}
+
+	// The item is accepted as is by mie_bulk_store.
+	stored, _ := BulkStore(context.Background(), mock, map[string]any{"items": []any{item}})
+	if stored.IsError || !strings.Contains(stored.Text, "Stored 1 items") {
+		t.Errorf("BulkStore of the candidate = %s", stored.Text)
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import "context"
+
+type includeArchivedKey struct{}
+
+// WithIncludeArchived returns a copy of ctx whose searches also return
+// archived nodes. Searches skip archived nodes by default.
+func WithIncludeArchived(ctx context.Context, include bool) context.Context {
+	if !include {
+		return ctx
+	}
+	return context.WithValue(ctx, includeArchivedKey{}, true)
+}
+
+// IncludeArchivedFromContext reports whether searches under ctx should
+// return archived nodes.
+func IncludeArchivedFromContext(ctx context.Context) bool {
+	include, _ := ctx.Value(includeArchivedKey{}).(bool)
+	return include
+}
@@ -0,0 +1,220 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	defaultAskLimit = 10
+	maxAskLimit     = 50
+	// askSearchLimit is how many hybrid search hits are retrieved before
+	// graph expansion.
+	askSearchLimit = 20
+	// askExpandSeeds is how many of the top hits have their neighbours
+	// pulled in, and askNeighborsPerSeed how many neighbours each.
+	askExpandSeeds      = 5
+	askNeighborsPerSeed = 10
+	// askNeighborDecay scales the relevance of a neighbour relative to the
+	// hit it was reached from.
+	askNeighborDecay = 0.5
+)
+
+// askEvidence is one piece of evidence returned by Ask.
+type askEvidence struct {
+	ID       string `json:"id"`
+	NodeType string `json:"node_type"`
+	Text     string `json:"text"`
+	// Relevance is how well the node matches the question, relative to the
+	// best search hit (0-1).
+	Relevance float64 `json:"relevance"`
+	// Confidence is a fact's confidence after age decay, and 1 for other
+	// node types.
+	Confidence float64 `json:"confidence"`
+	Status     string  `json:"status,omitempty"` // decision status
+	// Source is "search" for search hits and "graph" for their neighbours,
+	// which name the hit (Via) and edge type (Edge) that reached them.
+	Source string `json:"source"`
+	Via    string `json:"via,omitempty"`
+	Edge   string `json:"edge,omitempty"`
+}
+
+// askJSON is the JSON response of Ask. Evidence is most relevant first.
+type askJSON struct {
+	Question string        `json:"question"`
+	Evidence []askEvidence `json:"evidence"`
+}
+
+// Ask gathers the evidence an agent needs to answer a question from memory:
+// hybrid search hits plus the nodes linked to the top hits, ranked by
+// relevance and labeled with their IDs and confidence so the answer can
+// cite them.
+func Ask(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
+	question := strings.TrimSpace(GetStringArg(args, "question", ""))
+	if question == "" {
+		return NewError("Missing required parameter: question"), nil
+	}
+	limit := GetIntArg(args, "limit", defaultAskLimit)
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > maxAskLimit {
+		limit = maxAskLimit
+	}
+	expand := GetBoolArg(args, "expand", true)
+
+	results, err := client.HybridSearch(ctx, question, []string{"fact", "decision", "entity", "event"}, max(limit, askSearchLimit))
+	if err != nil {
+		return NewError(fmt.Sprintf("Search failed: %v", err)), nil
+	}
+	_ = client.IncrementCounter(ctx, "total_queries")
+
+	var top float64
+	for _, r := range results {
+		top = max(top, r.Score)
+	}
+	evidence := make(map[string]*askEvidence)
+	var order []string
+	for i, r := range results {
+		relevance := 1.0
+		if top > 0 {
+			relevance = r.Score / top
+		} else if len(results) > 1 {
+			relevance = 1 - float64(i)/float64(len(results))
+		}
+		e := searchEvidence(r)
+		if e == nil || evidence[e.ID] != nil {
+			continue
+		}
+		e.Relevance = relevance
+		evidence[e.ID] = e
+		order = append(order, e.ID)
+	}
+
+	if expand {
+		seeds := order[:min(len(order), askExpandSeeds)]
+		for _, seedID := range seeds {
+			seed := evidence[seedID]
+			edges, err := client.GetNodeEdges(ctx, seedID)
+			if err != nil {
+				return NewError(fmt.Sprintf("Graph expansion failed: %v", err)), nil
+			}
+			sort.SliceStable(edges, func(i, j int) bool { return edges[i].Weight > edges[j].Weight })
+			added := 0
+			for _, edge := range edges {
+				if added >= askNeighborsPerSeed {
+					break
+				}
+				neighborID := edge.TargetID
+				if neighborID == seedID {
+					neighborID = edge.SourceID
+				}
+				weight := edge.Weight
+				if weight <= 0 || weight > 1 {
+					weight = 1
+				}
+				relevance := seed.Relevance * askNeighborDecay * weight
+				if existing := evidence[neighborID]; existing != nil {
+					if existing.Source == "graph" && relevance > existing.Relevance {
+						existing.Relevance, existing.Via, existing.Edge = relevance, seedID, edge.Type
+					}
+					continue
+				}
+				node, err := client.GetNodeByID(ctx, neighborID)
+				if err != nil {
+					continue
+				}
+				e := nodeEvidence(node)
+				if e == nil {
+					continue
+				}
+				e.Relevance, e.Source, e.Via, e.Edge = relevance, "graph", seedID, edge.Type
+				evidence[e.ID] = e
+				order = append(order, e.ID)
+				added++
+			}
+		}
+	}
+
+	ranked := make([]askEvidence, 0, len(order))
+	for _, id := range order {
+		ranked = append(ranked, *evidence[id])
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].Relevance > ranked[j].Relevance })
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	if WantsJSON(args) {
+		return NewJSONResult(askJSON{Question: question, Evidence: ranked}), nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Evidence for: %s\n\n", question)
+	if len(ranked) == 0 {
+		sb.WriteString("_No memories found for this question._ Say that memory has no answer instead of guessing.\n")
+		return NewResult(sb.String()), nil
+	}
+	sb.WriteString("Answer from this evidence and cite each claim with the ID in brackets, e.g. [" + ranked[0].ID + "]. Prefer higher relevance and confidence; if the evidence does not answer the question, say so.\n\n")
+	for i, e := range ranked {
+		fmt.Fprintf(&sb, "%d. [%s] %s | relevance %.2f | confidence %.2f", i+1, e.ID, e.NodeType, e.Relevance, e.Confidence)
+		if e.Status != "" && e.Status != "active" {
+			fmt.Fprintf(&sb, " | %s", e.Status)
+		}
+		if e.Source == "graph" {
+			fmt.Fprintf(&sb, " | via [%s] (%s)", e.Via, e.Edge)
+		}
+		fmt.Fprintf(&sb, "\n   %s\n", e.Text)
+	}
+	return NewResult(sb.String()), nil
+}
+
+// searchEvidence returns the evidence for a search hit, or nil if it has
+// nothing to show.
+func searchEvidence(r SearchResult) *askEvidence {
+	item := searchResultItem(r)
+	if item.line == "" {
+		return nil
+	}
+	e := &askEvidence{ID: r.ID, NodeType: r.NodeType, Text: strings.TrimPrefix(item.line, "- "), Confidence: 1, Source: "search"}
+	switch m := r.Metadata.(type) {
+	case *Fact:
+		e.Confidence = m.Confidence
+	case *Decision:
+		e.Status = m.Status
+	}
+	if r.EffectiveConfidence > 0 {
+		e.Confidence = r.EffectiveConfidence
+	}
+	return e
+}
+
+// nodeEvidence returns the evidence for a node returned by GetNodeByID, or
+// nil for invalid facts and topics, which only group other nodes.
+func nodeEvidence(node any) *askEvidence {
+	var item briefingItem
+	e := &askEvidence{Confidence: 1}
+	switch n := node.(type) {
+	case *Fact:
+		if !n.Valid {
+			return nil
+		}
+		item, e.NodeType, e.Confidence = factItem(*n), "fact", n.Confidence
+	case *Decision:
+		item, e.NodeType, e.Status = decisionItem(*n), "decision", n.Status
+	case *Entity:
+		item, e.NodeType = entityItem(*n), "entity"
+	case *Event:
+		item, e.NodeType = eventItem(*n), "event"
+	default:
+		return nil
+	}
+	e.ID, e.Text = item.id, strings.TrimPrefix(item.line, "- ")
+	return e
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func askMock() *MockQuerier {
+	return &MockQuerier{
+		HybridSearchFunc: func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
+			return []SearchResult{
+				{NodeType: "decision", ID: "dec:pg", Content: "Use PostgreSQL", Detail: "ACID", Score: 0.04,
+					Metadata: &Decision{ID: "dec:pg", Title: "Use PostgreSQL", Rationale: "ACID", Status: "active"}},
+				{NodeType: "fact", ID: "fact:load", Content: "Load tests peak at 2k writes/s", Detail: "technical", Score: 0.02,
+					Metadata: &Fact{ID: "fact:load", Content: "Load tests peak at 2k writes/s", Category: "technical", Confidence: 0.7}},
+			}, nil
+		},
+		GetNodeEdgesFunc: func(ctx context.Context, nodeID string) ([]GraphEdge, error) {
+			if nodeID != "dec:pg" {
+				return nil, nil
+			}
+			return []GraphEdge{
+				{Type: "decision_entity", SourceID: "dec:pg", TargetID: "ent:pg", Label: "subject", Weight: 1},
+				{Type: "decision_topic", SourceID: "dec:pg", TargetID: "top:db", Weight: 1},
+				{Type: "event_decision", SourceID: "evt:old", TargetID: "dec:pg", Weight: 1},
+			}, nil
+		},
+		GetNodeByIDFunc: func(ctx context.Context, nodeID string) (any, error) {
+			switch nodeID {
+			case "ent:pg":
+				return &Entity{ID: nodeID, Name: "PostgreSQL", Kind: "technology"}, nil
+			case "top:db":
+				return &Topic{ID: nodeID, Name: "databases"}, nil
+			}
+			return nil, errors.New("not found")
+		},
+	}
+}
+
+func TestAsk(t *testing.T) {
+	result, err := Ask(context.Background(), askMock(), map[string]any{"question": "Why PostgreSQL?"})
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Ask() returned error: %s", result.Text)
+	}
+	for _, want := range []string{
+		"## Evidence for: Why PostgreSQL?",
+		"1. [dec:pg] decision | relevance 1.00 | confidence 1.00\n   **Use PostgreSQL**: ACID",
+		"2. [fact:load] fact | relevance 0.50 | confidence 0.70\n   Load tests peak at 2k writes/s (technical)",
+		"3. [ent:pg] entity | relevance 0.50 | confidence 1.00 | via [dec:pg] (decision_entity)\n   PostgreSQL (technology)",
+	} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("missing %q in:\n%s", want, result.Text)
+		}
+	}
+	if strings.Contains(result.Text, "top:db") {
+		t.Errorf("topics should not be evidence:\n%s", result.Text)
+	}
+}
+
+func TestAsk_NoExpand(t *testing.T) {
+	result, _ := Ask(context.Background(), askMock(), map[string]any{"question": "Why PostgreSQL?", "expand": false, "limit": float64(1)})
+	if strings.Contains(result.Text, "ent:pg") || strings.Contains(result.Text, "fact:load") {
+		t.Errorf("expected only the top search hit:\n%s", result.Text)
+	}
+}
+
+func TestAsk_JSON(t *testing.T) {
+	result, _ := Ask(context.Background(), askMock(), map[string]any{"question": "Why PostgreSQL?", "response_format": "json"})
+	var out askJSON
+	if err := json.Unmarshal([]byte(result.Text), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result.Text)
+	}
+	if len(out.Evidence) != 3 {
+		t.Fatalf("expected 3 pieces of evidence, got %+v", out.Evidence)
+	}
+	last := out.Evidence[2]
+	if last.ID != "ent:pg" || last.Source != "graph" || last.Via != "dec:pg" || last.Edge != "decision_entity" {
+		t.Errorf("unexpected graph evidence: %+v", last)
+	}
+}
+
+func TestAsk_Errors(t *testing.T) {
+	result, _ := Ask(context.Background(), &MockQuerier{}, map[string]any{})
+	if !result.IsError {
+		t.Error("expected error for missing question")
+	}
+
+	result, _ = Ask(context.Background(), &MockQuerier{}, map[string]any{"question": "Anything?"})
+	if result.IsError || !strings.Contains(result.Text, "No memories found") {
+		t.Errorf("expected an empty result, got:\n%s", result.Text)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// attachmentJSON is the JSON response of Attachments. Which fields are set
+// depends on the action.
+type attachmentJSON struct {
+	Action      string       `json:"action"`
+	Attachment  *Attachment  `json:"attachment,omitempty"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+	URI         string       `json:"uri,omitempty"`
+}
+
+// Attachments adds, lists, or removes the attachments of a node: small
+// artifacts such as diagrams or config snippets, stored under their content
+// hash and read back through the mie://attachment/<hash> resource.
+func Attachments(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
+	action := GetStringArg(args, "action", DefaultActions["mie_attach"])
+	nodeID := strings.TrimSpace(GetStringArg(args, "node_id", ""))
+	if nodeID == "" {
+		return NewError("Missing required parameter: node_id"), nil
+	}
+
+	switch action {
+	case "add":
+		content := GetStringArg(args, "content", "")
+		if content == "" {
+			return NewError("Missing required parameter: content"), nil
+		}
+		data := []byte(content)
+		switch encoding := GetStringArg(args, "encoding", "text"); encoding {
+		case "text":
+		case "base64":
+			decoded, err := base64.StdEncoding.DecodeString(content)
+			if err != nil {
+				return NewError(fmt.Sprintf("Invalid base64 content: %v", err)), nil
+			}
+			data = decoded
+		default:
+			return NewError(fmt.Sprintf("Invalid encoding %q. Must be text or base64", encoding)), nil
+		}
+		att, err := client.Attach(ctx, nodeID, strings.TrimSpace(GetStringArg(args, "name", "")), GetStringArg(args, "mime_type", ""), data)
+		if err != nil {
+			return NewError(fmt.Sprintf("Failed to attach to [%s]: %v", nodeID, err)), nil
+		}
+		if WantsJSON(args) {
+			return NewJSONResult(attachmentJSON{Action: action, Attachment: att, URI: att.URI()}), nil
+		}
+		return NewResult(fmt.Sprintf("Attached %q (%s, %s) to [%s]\nRead it with the resource %s",
+			att.Name, att.MimeType, formatAttachmentSize(att.Size), nodeID, att.URI())), nil
+
+	case "list":
+		attachments, err := client.ListAttachments(ctx, nodeID)
+		if err != nil {
+			return NewError(fmt.Sprintf("Failed to list attachments of [%s]: %v", nodeID, err)), nil
+		}
+		_ = client.IncrementCounter(ctx, "total_queries")
+		if WantsJSON(args) {
+			if attachments == nil {
+				attachments = []Attachment{}
+			}
+			return NewJSONResult(attachmentJSON{Action: action, Attachments: attachments}), nil
+		}
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "## Attachments of [%s]\n\n", nodeID)
+		if len(attachments) == 0 {
+			sb.WriteString("_No attachments. Add one with action=\"add\"._\n")
+			return NewResult(sb.String()), nil
+		}
+		sb.WriteString("| Name | Type | Size | Attached (UTC) | Resource |\n")
+		sb.WriteString("|------|------|------|----------------|----------|\n")
+		for _, a := range attachments {
+			fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s |\n", a.Name, a.MimeType, formatAttachmentSize(a.Size),
+				time.Unix(a.CreatedAt, 0).UTC().Format("2006-01-02 15:04:05"), a.URI())
+		}
+		return NewResult(sb.String()), nil
+
+	case "remove":
+		hash := strings.TrimPrefix(GetStringArg(args, "hash", ""), AttachmentURIPrefix)
+		if hash == "" {
+			return NewError("Missing required parameter: hash"), nil
+		}
+		if err := client.Detach(ctx, nodeID, hash); err != nil {
+			return NewError(fmt.Sprintf("Failed to remove attachment from [%s]: %v", nodeID, err)), nil
+		}
+		if WantsJSON(args) {
+			return NewJSONResult(attachmentJSON{Action: action, URI: AttachmentURIPrefix + hash}), nil
+		}
+		return NewResult(fmt.Sprintf("Removed attachment %s from [%s]", hash, nodeID)), nil
+
+	default:
+		return NewError(fmt.Sprintf("Invalid action %q. Must be one of: add, list, remove", action)), nil
+	}
+}
+
+// formatAttachmentSize renders a byte count as "512 B" or "3.2 KiB".
+func formatAttachmentSize(size int64) string {
+	switch {
+	case size < 1024:
+		return fmt.Sprintf("%d B", size)
+	case size < 1024*1024:
+		return fmt.Sprintf("%.1f KiB", float64(size)/1024)
+	default:
+		return fmt.Sprintf("%.1f MiB", float64(size)/(1024*1024))
+	}
+}
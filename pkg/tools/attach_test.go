@@ -0,0 +1,82 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAttachments_Add(t *testing.T) {
+	var got []byte
+	mock := &MockQuerier{
+		AttachFunc: func(ctx context.Context, nodeID, name, mimeType string, data []byte) (*Attachment, error) {
+			got = data
+			return &Attachment{Hash: "ab12", NodeID: nodeID, Name: name, MimeType: "image/png", Size: 2048}, nil
+		},
+	}
+	ctx := context.Background()
+
+	result, _ := Attachments(ctx, mock, map[string]any{"node_id": "dec:q", "name": "flow.png", "content": "iVBORw0=", "encoding": "base64"})
+	if result.IsError {
+		t.Fatalf("add failed: %s", result.Text)
+	}
+	if string(got) != "\x89PNG\r" {
+		t.Errorf("content not decoded: %q", got)
+	}
+	if !strings.Contains(result.Text, `Attached "flow.png" (image/png, 2.0 KiB) to [dec:q]`) || !strings.Contains(result.Text, "mie://attachment/ab12") {
+		t.Errorf("unexpected result:\n%s", result.Text)
+	}
+
+	result, _ = Attachments(ctx, mock, map[string]any{"node_id": "dec:q", "content": "a: 1", "response_format": "json"})
+	var out attachmentJSON
+	if err := json.Unmarshal([]byte(result.Text), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result.Text)
+	}
+	if out.URI != "mie://attachment/ab12" || string(got) != "a: 1" {
+		t.Errorf("unexpected JSON: %+v", out)
+	}
+
+	for _, args := range []map[string]any{
+		{"content": "x"},
+		{"node_id": "dec:q"},
+		{"node_id": "dec:q", "content": "%%", "encoding": "base64"},
+		{"node_id": "dec:q", "content": "x", "encoding": "hex"},
+		{"node_id": "dec:q", "action": "rename"},
+	} {
+		if result, _ := Attachments(ctx, mock, args); !result.IsError {
+			t.Errorf("expected an error for %v", args)
+		}
+	}
+}
+
+func TestAttachments_ListRemove(t *testing.T) {
+	var removed string
+	mock := &MockQuerier{
+		ListAttachmentsFunc: func(ctx context.Context, nodeID string) ([]Attachment, error) {
+			return []Attachment{{Hash: "ab12", NodeID: nodeID, Name: "nats.yaml", MimeType: "text/yaml", Size: 17, CreatedAt: 2000}}, nil
+		},
+		DetachFunc: func(ctx context.Context, nodeID, hash string) error {
+			removed = hash
+			return nil
+		},
+	}
+	ctx := context.Background()
+
+	result, _ := Attachments(ctx, mock, map[string]any{"action": "list", "node_id": "dec:q"})
+	if !strings.Contains(result.Text, "| nats.yaml | text/yaml | 17 B | 1970-01-01 00:33:20 | mie://attachment/ab12 |") {
+		t.Errorf("unexpected list:\n%s", result.Text)
+	}
+
+	result, _ = Attachments(ctx, mock, map[string]any{"action": "remove", "node_id": "dec:q", "hash": "mie://attachment/ab12"})
+	if result.IsError || removed != "ab12" {
+		t.Errorf("remove: removed %q, result:\n%s", removed, result.Text)
+	}
+	if result, _ := Attachments(ctx, mock, map[string]any{"action": "remove", "node_id": "dec:q"}); !result.IsError {
+		t.Error("expected an error removing without a hash")
+	}
+}
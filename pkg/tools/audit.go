@@ -0,0 +1,103 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type auditSourceKey struct{}
+
+// auditSource is the origin of the writes made under a context.
+type auditSource struct {
+	tool  string
+	agent string
+}
+
+// WithAuditSource returns a copy of ctx whose writes are recorded in the
+// audit log as made by tool on behalf of agent. The agent is only used for
+// writes that do not name their own source_agent.
+func WithAuditSource(ctx context.Context, tool, agent string) context.Context {
+	return context.WithValue(ctx, auditSourceKey{}, auditSource{tool: tool, agent: agent})
+}
+
+// AuditSourceFromContext returns the tool and agent set by WithAuditSource,
+// or empty strings.
+func AuditSourceFromContext(ctx context.Context) (tool, agent string) {
+	src, _ := ctx.Value(auditSourceKey{}).(auditSource)
+	return src.tool, src.agent
+}
+
+// Audit lists the most recent writes to the memory graph, newest first, so
+// agents can review what they changed.
+func Audit(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
+	limit := GetIntArg(args, "limit", 50)
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > 500 {
+		limit = 500
+	}
+	opts := AuditOptions{
+		Limit:       limit,
+		NodeID:      GetStringArg(args, "node_id", ""),
+		SourceAgent: GetStringArg(args, "source_agent", ""),
+		Tool:        GetStringArg(args, "tool", ""),
+	}
+
+	entries, err := client.GetAuditLog(ctx, opts)
+	if err != nil {
+		return NewError(fmt.Sprintf("Failed to read audit log: %v", err)), nil
+	}
+	_ = client.IncrementCounter(ctx, "total_queries")
+
+	if WantsJSON(args) {
+		if entries == nil {
+			entries = []AuditEntry{}
+		}
+		return NewJSONResult(auditJSON{Entries: entries}), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Audit Log\n\n")
+	if len(entries) == 0 {
+		sb.WriteString("_No matching writes recorded._\n")
+		return NewResult(sb.String()), nil
+	}
+	sb.WriteString(FormatAuditTable(entries))
+	if len(entries) == limit {
+		fmt.Fprintf(&sb, "\nShowing the latest %d writes. Raise limit to see more.\n", limit)
+	}
+	return NewResult(sb.String()), nil
+}
+
+// auditJSON is the JSON response of Audit.
+type auditJSON struct {
+	Entries []AuditEntry `json:"entries"`
+}
+
+// FormatAuditTable renders audit entries as a markdown table.
+func FormatAuditTable(entries []AuditEntry) string {
+	var sb strings.Builder
+	sb.WriteString("| Time (UTC) | Op | Tool | Agent | Nodes |\n")
+	sb.WriteString("|------------|----|------|-------|-------|\n")
+	for _, e := range entries {
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s |\n",
+			time.Unix(e.At, 0).UTC().Format("2006-01-02 15:04:05"),
+			e.Op, orDash(e.Tool), orDash(e.SourceAgent), orDash(strings.Join(e.NodeIDs, ", ")))
+	}
+	return sb.String()
+}
+
+// orDash returns s, or "-" when s is empty.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
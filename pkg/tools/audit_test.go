@@ -0,0 +1,70 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAuditSourceFromContext(t *testing.T) {
+	if tool, agent := AuditSourceFromContext(context.Background()); tool != "" || agent != "" {
+		t.Errorf("empty context = %q, %q", tool, agent)
+	}
+	ctx := WithAuditSource(context.Background(), "mie_store", "claude")
+	if tool, agent := AuditSourceFromContext(ctx); tool != "mie_store" || agent != "claude" {
+		t.Errorf("AuditSourceFromContext() = %q, %q", tool, agent)
+	}
+}
+
+func TestAudit(t *testing.T) {
+	var got AuditOptions
+	mock := &MockQuerier{
+		GetAuditLogFunc: func(ctx context.Context, opts AuditOptions) ([]AuditEntry, error) {
+			got = opts
+			return []AuditEntry{
+				{ID: "aud:2", At: 1772323200, Op: "invalidated", Tool: "mie_update", NodeIDs: []string{"fact:old", "fact:new"}},
+				{ID: "aud:1", At: 1772323100, Op: "created", Tool: "mie_store", SourceAgent: "claude", NodeIDs: []string{"fact:new"}},
+			}, nil
+		},
+	}
+
+	result, err := Audit(context.Background(), mock, map[string]any{"limit": 1000.0, "node_id": "fact:new", "source_agent": "claude"})
+	if err != nil {
+		t.Fatalf("Audit() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Audit() returned error: %s", result.Text)
+	}
+	if got.Limit != 500 || got.NodeID != "fact:new" || got.SourceAgent != "claude" {
+		t.Errorf("options = %+v", got)
+	}
+	for _, want := range []string{
+		"| 2026-03-01 00:00:00 | invalidated | mie_update | - | fact:old, fact:new |",
+		"| created | mie_store | claude | fact:new |",
+	} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("missing %q in:\n%s", want, result.Text)
+		}
+	}
+}
+
+func TestAudit_JSONEmpty(t *testing.T) {
+	result, err := Audit(context.Background(), &MockQuerier{}, map[string]any{"response_format": "json"})
+	if err != nil {
+		t.Fatalf("Audit() error = %v", err)
+	}
+	var out struct {
+		Entries []AuditEntry `json:"entries"`
+	}
+	if err := json.Unmarshal([]byte(result.Text), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result.Text)
+	}
+	if out.Entries == nil || len(out.Entries) != 0 {
+		t.Errorf("entries = %v, want empty list", out.Entries)
+	}
+}
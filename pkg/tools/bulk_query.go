@@ -0,0 +1,136 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+)
+
+const maxBulkQueries = 10
+
+// BulkQuery runs several queries concurrently and returns their results grouped in one response.
+// Each entry in the queries array accepts the same arguments as Query.
+func BulkQuery(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
+	rawQueries, ok := args["queries"]
+	if !ok || rawQueries == nil {
+		return NewError("Missing required parameter: queries"), nil
+	}
+	querySlice, ok := rawQueries.([]any)
+	if !ok || len(querySlice) == 0 {
+		return NewError("queries must be a non-empty array"), nil
+	}
+	if len(querySlice) > maxBulkQueries {
+		return NewError(fmt.Sprintf("Too many queries: %d (max %d)", len(querySlice), maxBulkQueries)), nil
+	}
+
+	asJSON := WantsJSON(args)
+	results := make([]*ToolResult, len(querySlice))
+	var wg sync.WaitGroup
+	for i, raw := range querySlice {
+		queryArgs, ok := raw.(map[string]any)
+		if !ok {
+			results[i] = NewError(fmt.Sprintf("queries[%d]: not a valid object", i))
+			continue
+		}
+		if action := GetStringArg(queryArgs, "action", "search"); action != "search" && action != "run" {
+			results[i] = NewError(fmt.Sprintf("queries[%d]: action %q is not supported in bulk queries", i, action))
+			continue
+		}
+		if asJSON {
+			queryArgs = maps.Clone(queryArgs)
+			queryArgs["response_format"] = FormatJSON
+		}
+		wg.Add(1)
+		go func(i int, queryArgs map[string]any) {
+			defer wg.Done()
+			result, err := Query(ctx, client, queryArgs)
+			if err != nil {
+				result = NewError(err.Error())
+			}
+			results[i] = result
+		}(i, queryArgs)
+	}
+	wg.Wait()
+
+	if asJSON {
+		return bulkQueryJSONResult(querySlice, results), nil
+	}
+
+	failed := 0
+	var sb strings.Builder
+	for i, result := range results {
+		queryArgs, _ := querySlice[i].(map[string]any)
+		fmt.Fprintf(&sb, "### Query %d: %q (%s)\n\n", i+1, GetStringArg(queryArgs, "query", ""), GetStringArg(queryArgs, "mode", "semantic"))
+		if result.IsError {
+			failed++
+			fmt.Fprintf(&sb, "_Error: %s_\n\n", result.Text)
+			continue
+		}
+		sb.WriteString(demoteHeadings(result.Text))
+		sb.WriteString("\n")
+	}
+
+	header := fmt.Sprintf("## Bulk Query Results (%d queries", len(results))
+	if failed > 0 {
+		header += fmt.Sprintf(", %d failed", failed)
+	}
+	header += ")\n\n"
+
+	return NewResult(header + sb.String()), nil
+}
+
+// bulkQueryJSON is the JSON response of BulkQuery.
+type bulkQueryJSON struct {
+	Failed  int                  `json:"failed"`
+	Queries []bulkQueryEntryJSON `json:"queries"`
+}
+
+// bulkQueryEntryJSON is one query of a bulkQueryJSON. Result is the JSON
+// response of Query; Error is set instead if the query failed.
+type bulkQueryEntryJSON struct {
+	Index  int             `json:"index"`
+	Query  string          `json:"query"`
+	Mode   string          `json:"mode"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// bulkQueryJSONResult combines the JSON responses of each query.
+func bulkQueryJSONResult(querySlice []any, results []*ToolResult) *ToolResult {
+	out := bulkQueryJSON{Queries: make([]bulkQueryEntryJSON, len(results))}
+	for i, result := range results {
+		queryArgs, _ := querySlice[i].(map[string]any)
+		entry := bulkQueryEntryJSON{
+			Index: i,
+			Query: GetStringArg(queryArgs, "query", ""),
+			Mode:  GetStringArg(queryArgs, "mode", "semantic"),
+		}
+		if result.IsError {
+			out.Failed++
+			entry.Error = result.Text
+		} else {
+			entry.Result = json.RawMessage(result.Text)
+		}
+		out.Queries[i] = entry
+	}
+	return NewJSONResult(out)
+}
+
+// demoteHeadings nests the markdown headings of a single query result under
+// its bulk query section.
+func demoteHeadings(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "#") {
+			lines[i] = "##" + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,156 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestBulkQuery_MixedModes(t *testing.T) {
+	var mu sync.Mutex
+	var queries []string
+	mock := &MockQuerier{
+		ExactSearchFunc: func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
+			mu.Lock()
+			queries = append(queries, query)
+			mu.Unlock()
+			return []SearchResult{{NodeType: "entity", ID: "ent:pg", Content: "PostgreSQL"}}, nil
+		},
+		FullTextSearchFunc: func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
+			mu.Lock()
+			queries = append(queries, query)
+			mu.Unlock()
+			return []SearchResult{{NodeType: "fact", ID: "fact:deploy", Content: "Deploys on Fridays", Score: 1.5}}, nil
+		},
+	}
+
+	result, err := BulkQuery(context.Background(), mock, map[string]any{
+		"queries": []any{
+			map[string]any{"query": "PostgreSQL", "mode": "exact", "node_types": []any{"entity"}},
+			map[string]any{"query": "deploy", "mode": "fulltext"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("BulkQuery() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("BulkQuery() returned error: %s", result.Text)
+	}
+	if len(queries) != 2 {
+		t.Errorf("expected 2 searches, got %v", queries)
+	}
+	if !strings.Contains(result.Text, "Bulk Query Results (2 queries)") {
+		t.Errorf("missing summary header, got:\n%s", result.Text)
+	}
+	first := strings.Index(result.Text, `### Query 1: "PostgreSQL" (exact)`)
+	second := strings.Index(result.Text, `### Query 2: "deploy" (fulltext)`)
+	if first < 0 || second < first {
+		t.Errorf("results should be grouped in request order, got:\n%s", result.Text)
+	}
+	if !strings.Contains(result.Text, "#### Exact Search Results") || !strings.Contains(result.Text, "ent:pg") || !strings.Contains(result.Text, "fact:deploy") {
+		t.Errorf("expected nested results for both queries, got:\n%s", result.Text)
+	}
+}
+
+func TestBulkQuery_PartialFailure(t *testing.T) {
+	mock := &MockQuerier{
+		ExactSearchFunc: func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
+			return nil, nil
+		},
+	}
+
+	result, err := BulkQuery(context.Background(), mock, map[string]any{
+		"queries": []any{
+			map[string]any{"query": "Go", "mode": "exact"},
+			map[string]any{"query": "Go", "mode": "bogus"},
+			"not an object",
+		},
+	})
+	if err != nil {
+		t.Fatalf("BulkQuery() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("one failed query should not fail the batch: %s", result.Text)
+	}
+	if !strings.Contains(result.Text, "3 queries, 2 failed") {
+		t.Errorf("expected failure count in header, got:\n%s", result.Text)
+	}
+	if !strings.Contains(result.Text, `Invalid mode "bogus"`) || !strings.Contains(result.Text, "queries[2]: not a valid object") {
+		t.Errorf("expected per-query errors, got:\n%s", result.Text)
+	}
+}
+
+func TestBulkQuery_Validation(t *testing.T) {
+	tooMany := make([]any, maxBulkQueries+1)
+	for i := range tooMany {
+		tooMany[i] = map[string]any{"query": fmt.Sprintf("q%d", i)}
+	}
+
+	tests := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"missing", map[string]any{}, "Missing required parameter: queries"},
+		{"empty", map[string]any{"queries": []any{}}, "non-empty array"},
+		{"too many", map[string]any{"queries": tooMany}, "Too many queries: 11 (max 10)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := BulkQuery(context.Background(), &MockQuerier{}, tt.args)
+			if err != nil {
+				t.Fatalf("BulkQuery() error = %v", err)
+			}
+			if !result.IsError || !strings.Contains(result.Text, tt.want) {
+				t.Errorf("BulkQuery() = %q, want error containing %q", result.Text, tt.want)
+			}
+		})
+	}
+}
+
+func TestBulkQuery_JSON(t *testing.T) {
+	mock := &MockQuerier{
+		ExactSearchFunc: func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
+			return []SearchResult{{NodeType: "entity", ID: "ent:pg", Content: "PostgreSQL"}}, nil
+		},
+	}
+	queries := []any{
+		map[string]any{"query": "PostgreSQL", "mode": "exact"},
+		map[string]any{"query": "x", "mode": "bogus"},
+	}
+
+	result, _ := BulkQuery(context.Background(), mock, map[string]any{"queries": queries, "response_format": "json"})
+	var got struct {
+		Failed  int `json:"failed"`
+		Queries []struct {
+			Index  int    `json:"index"`
+			Mode   string `json:"mode"`
+			Error  string `json:"error"`
+			Result struct {
+				Results []SearchResult `json:"results"`
+			} `json:"result"`
+		} `json:"queries"`
+	}
+	if err := json.Unmarshal([]byte(result.Text), &got); err != nil {
+		t.Fatalf("BulkQuery() output is not JSON: %v\n%s", err, result.Text)
+	}
+	if got.Failed != 1 || len(got.Queries) != 2 {
+		t.Fatalf("BulkQuery() JSON = %+v", got)
+	}
+	if q := got.Queries[0]; q.Mode != "exact" || len(q.Result.Results) != 1 || q.Result.Results[0].ID != "ent:pg" {
+		t.Errorf("queries[0] = %+v", q)
+	}
+	if q := got.Queries[1]; q.Index != 1 || !strings.Contains(q.Error, "Invalid mode") {
+		t.Errorf("queries[1] = %+v, want an error", q)
+	}
+	if _, ok := queries[0].(map[string]any)["response_format"]; ok {
+		t.Error("BulkQuery() should not modify the caller's query arguments")
+	}
+}
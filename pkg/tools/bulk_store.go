@@ -6,6 +6,8 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -14,9 +16,32 @@ const maxBulkItems = 50
 
 // bulkItem tracks the result of storing a single item in a bulk operation.
 type bulkItem struct {
-	nodeID   string
-	nodeType string
-	summary  string
+	nodeID      string
+	nodeType    string
+	summary     string
+	duplicate   bool // an existing fact was returned instead of storing
+	node        any
+	rels        []relationshipResult
+	invalidated string // ID of the fact this item invalidated
+}
+
+// bulkStoreJSON is the JSON response of BulkStore.
+type bulkStoreJSON struct {
+	Stored     int            `json:"stored"`
+	Duplicates int            `json:"duplicates"`
+	Items      []bulkItemJSON `json:"items"`
+	Errors     []string       `json:"errors,omitempty"`
+}
+
+// bulkItemJSON is one stored item in a bulkStoreJSON.
+type bulkItemJSON struct {
+	Index         int                  `json:"index"`
+	ID            string               `json:"id"`
+	NodeType      string               `json:"node_type"`
+	Duplicate     bool                 `json:"duplicate"`
+	Node          any                  `json:"node"`
+	Relationships []relationshipResult `json:"relationships,omitempty"`
+	Invalidated   string               `json:"invalidated,omitempty"`
 }
 
 // BulkStore writes multiple nodes and optional relationships to the memory graph in a single call.
@@ -33,103 +58,99 @@ func BulkStore(ctx context.Context, client Querier, args map[string]any) (*ToolR
 		return NewError(fmt.Sprintf("Too many items: %d (max %d)", len(itemSlice), maxBulkItems)), nil
 	}
 
-	// Phase 1: Store all nodes and collect their IDs.
-	stored := make([]bulkItem, len(itemSlice))
-	var errors []string
-	typeCounts := map[string]int{}
-
-	for i, raw := range itemSlice {
-		itemArgs, ok := raw.(map[string]any)
-		if !ok {
-			errors = append(errors, fmt.Sprintf("item[%d]: not a valid object", i))
-			continue
-		}
-		nodeType := GetStringArg(itemArgs, "type", "")
-		if nodeType == "" {
-			errors = append(errors, fmt.Sprintf("item[%d]: missing required parameter: type", i))
-			continue
+	var out *bulkOutcome
+	if GetBoolArg(args, "atomic", false) {
+		err := client.Atomic(ctx, func(ctx context.Context) error {
+			out = storeBulkItems(ctx, client, itemSlice, true)
+			if len(out.errors) > 0 {
+				return errBulkRolledBack
+			}
+			return nil
+		})
+		if errors.Is(err, errBulkRolledBack) {
+			var sb strings.Builder
+			sb.WriteString(fmt.Sprintf("Nothing stored: atomic bulk store rolled back after %d errors:\n", len(out.errors)))
+			for _, e := range out.errors {
+				sb.WriteString(fmt.Sprintf("  - %s\n", e))
+			}
+			return NewError(sb.String()), nil
 		}
-
-		nodeID, summary, err := storeNode(ctx, client, itemArgs, nodeType)
 		if err != nil {
-			errors = append(errors, fmt.Sprintf("item[%d] (%s): %v", i, nodeType, err))
-			continue
+			return NewError(fmt.Sprintf("Nothing stored: atomic bulk store failed: %v", err)), nil
 		}
-		if nodeID == "" {
-			errors = append(errors, fmt.Sprintf("item[%d]: invalid type %q", i, nodeType))
-			continue
-		}
-
-		stored[i] = bulkItem{nodeID: nodeID, nodeType: nodeType, summary: summary}
-		typeCounts[nodeType]++
+	} else {
+		out = storeBulkItems(ctx, client, itemSlice, false)
 	}
 
-	// Phase 2: Handle invalidations and relationships for successfully stored items.
-	var relMessages []string
-	for i, item := range stored {
-		if item.nodeID == "" {
-			continue
-		}
-		itemArgs, _ := itemSlice[i].(map[string]any)
+	totalStored := 0
+	for _, c := range out.typeCounts {
+		totalStored += c
+	}
 
-		// Handle invalidation.
-		toolErr, invalidationMsg := handleInvalidation(ctx, client, itemArgs, item.nodeID)
-		if toolErr != nil {
-			errors = append(errors, fmt.Sprintf("item[%d] invalidation: %s", i, toolErr.Text))
-		} else if invalidationMsg != "" {
-			relMessages = append(relMessages, fmt.Sprintf("item[%d]%s", i, invalidationMsg))
-		}
+	// Increment usage counters (never fail the main operation).
+	for range totalStored {
+		_ = client.IncrementCounter(ctx, "total_stores")
+	}
 
-		// Handle relationships, resolving cross-batch references.
-		if rels, ok := itemArgs["relationships"]; ok && rels != nil {
-			resolved := resolveBatchRefs(rels, stored)
-			if msg := storeRelationships(ctx, client, item.nodeID, resolved); msg != "" {
-				relMessages = append(relMessages, fmt.Sprintf("item[%d]:\n%s", i, msg))
+	if WantsJSON(args) {
+		resp := bulkStoreJSON{Stored: totalStored, Duplicates: out.duplicates, Items: []bulkItemJSON{}, Errors: out.errors}
+		for i, item := range out.stored {
+			if item.nodeID == "" {
+				continue
 			}
+			resp.Items = append(resp.Items, bulkItemJSON{
+				Index:         i,
+				ID:            item.nodeID,
+				NodeType:      item.nodeType,
+				Duplicate:     item.duplicate,
+				Node:          item.node,
+				Relationships: item.rels,
+				Invalidated:   item.invalidated,
+			})
 		}
+		return NewJSONResult(resp), nil
 	}
 
-	// Phase 3: Build output.
+	// Build output.
 	var sb strings.Builder
 
 	// Summary line.
 	var parts []string
 	for _, nt := range []string{"fact", "decision", "entity", "event", "topic"} {
-		if c := typeCounts[nt]; c > 0 {
+		if c := out.typeCounts[nt]; c > 0 {
 			parts = append(parts, fmt.Sprintf("%d %ss", c, nt))
 		}
 	}
-	totalStored := 0
-	for _, c := range typeCounts {
-		totalStored += c
-	}
 	sb.WriteString(fmt.Sprintf("Stored %d items: %s\n", totalStored, strings.Join(parts, ", ")))
-
-	// Increment usage counters (never fail the main operation).
-	for range totalStored {
-		_ = client.IncrementCounter(ctx, "total_stores")
+	if out.duplicates > 0 {
+		sb.WriteString(fmt.Sprintf("Skipped %d duplicate facts (existing IDs returned)\n", out.duplicates))
 	}
 
 	// Per-item IDs.
 	sb.WriteString("\nIDs:\n")
-	for i, item := range stored {
-		if item.nodeID != "" {
+	for i, item := range out.stored {
+		if item.nodeID == "" {
+			continue
+		}
+		if item.duplicate {
+			sb.WriteString(fmt.Sprintf("  [%d] %s [%s] duplicate_of existing\n", i, item.nodeType, item.nodeID))
+		} else {
 			sb.WriteString(fmt.Sprintf("  [%d] %s [%s]\n", i, item.nodeType, item.nodeID))
 		}
 	}
 
 	// Relationships.
-	if len(relMessages) > 0 {
+	if len(out.relMessages) > 0 {
 		sb.WriteString("\nRelationships:\n")
-		for _, msg := range relMessages {
+		for _, msg := range out.relMessages {
 			sb.WriteString(msg)
 		}
 	}
 
 	// Errors.
-	if len(errors) > 0 {
-		sb.WriteString(fmt.Sprintf("\nErrors (%d):\n", len(errors)))
-		for _, e := range errors {
+	if len(out.errors) > 0 {
+		sb.WriteString(fmt.Sprintf("\nErrors (%d):\n", len(out.errors)))
+		for _, e := range out.errors {
 			sb.WriteString(fmt.Sprintf("  - %s\n", e))
 		}
 	}
@@ -137,13 +158,154 @@ func BulkStore(ctx context.Context, client Querier, args map[string]any) (*ToolR
 	return NewResult(sb.String()), nil
 }
 
+// errBulkRolledBack aborts an atomic bulk store that had item errors.
+var errBulkRolledBack = errors.New("atomic bulk store rolled back")
+
+// bulkOutcome is what storing the items of a bulk operation did.
+type bulkOutcome struct {
+	stored      []bulkItem
+	typeCounts  map[string]int
+	duplicates  int
+	relMessages []string
+	errors      []string
+}
+
+// storeBulkItems stores the nodes in items, then their invalidations and
+// relationships. With strict set, relationships that are skipped, fail, or
+// reference a batch item that was not stored are reported as errors too.
+func storeBulkItems(ctx context.Context, client Querier, items []any, strict bool) *bulkOutcome {
+	out := &bulkOutcome{
+		stored:     make([]bulkItem, len(items)),
+		typeCounts: map[string]int{},
+	}
+
+	// Phase 1: Store all nodes and collect their IDs.
+	for i, raw := range items {
+		itemArgs, ok := raw.(map[string]any)
+		if !ok {
+			out.errors = append(out.errors, fmt.Sprintf("item[%d]: not a valid object", i))
+			continue
+		}
+		nodeType := GetStringArg(itemArgs, "type", "")
+		if nodeType == "" {
+			out.errors = append(out.errors, fmt.Sprintf("item[%d]: missing required parameter: type", i))
+			continue
+		}
+
+		stored, err := storeNode(ctx, client, itemArgs, nodeType)
+		if err != nil {
+			out.errors = append(out.errors, fmt.Sprintf("item[%d] (%s): %v", i, nodeType, err))
+			continue
+		}
+		if stored.ID == "" {
+			out.errors = append(out.errors, fmt.Sprintf("item[%d]: invalid type %q", i, nodeType))
+			continue
+		}
+
+		out.stored[i] = bulkItem{nodeID: stored.ID, nodeType: nodeType, summary: stored.Summary, duplicate: stored.Duplicate, node: stored.Node}
+		if stored.Duplicate {
+			out.duplicates++
+			continue
+		}
+		out.typeCounts[nodeType]++
+	}
+
+	// Phase 2: Handle invalidations and relationships for successfully stored items,
+	// resolving cross-batch references.
+	var plan []bulkLinks
+	unresolved := map[int]int{}
+	for i := range out.stored {
+		if out.stored[i].nodeID == "" {
+			continue
+		}
+		itemArgs, _ := items[i].(map[string]any)
+		links := bulkLinks{
+			Index:       i,
+			NodeID:      out.stored[i].nodeID,
+			SourceAgent: GetStringArg(itemArgs, "source_agent", "unknown"),
+			Invalidates: GetStringArg(itemArgs, "invalidates", ""),
+		}
+		if rels, ok := itemArgs["relationships"]; ok && rels != nil {
+			links.Relationships, unresolved[i] = resolveBatchRefs(rels, out.stored)
+			if links.Relationships == nil {
+				links.Relationships = []any{}
+			}
+		}
+		plan = append(plan, links)
+	}
+
+	// Log phase 2 before running it, so that ReplayIntents can finish it
+	// if the process dies part way through. The log is a safety net: if it
+	// cannot be written, phase 2 still runs.
+	intentID := recordBulkIntent(ctx, client, plan)
+
+	for _, links := range plan {
+		i := links.Index
+		item := &out.stored[i]
+
+		toolErr, invalidationMsg, rels := applyBulkLinks(ctx, client, links)
+		if toolErr != nil {
+			out.errors = append(out.errors, fmt.Sprintf("item[%d] invalidation: %s", i, toolErr.Text))
+		} else if invalidationMsg != "" {
+			out.relMessages = append(out.relMessages, fmt.Sprintf("item[%d]%s", i, invalidationMsg))
+			item.invalidated = links.Invalidates
+		}
+
+		if links.Relationships != nil {
+			item.rels = rels
+			if msg := relationshipLines(item.rels); msg != "" {
+				out.relMessages = append(out.relMessages, fmt.Sprintf("item[%d]:\n%s", i, msg))
+			}
+			if strict && unresolved[i] > 0 {
+				out.errors = append(out.errors, fmt.Sprintf("item[%d]: %d target_ref values do not reference a stored item", i, unresolved[i]))
+			}
+			if failed := relationshipFailures(item.rels); strict && failed > 0 {
+				out.errors = append(out.errors, fmt.Sprintf("item[%d]: %d relationships skipped or failed", i, failed))
+			}
+		}
+	}
+
+	if intentID != "" {
+		_ = client.CompleteIntent(ctx, intentID)
+	}
+
+	return out
+}
+
+// recordBulkIntent records the invalidations and relationships in plan as
+// an IntentBulkLinks intent and returns its ID. It returns an empty ID if
+// there is nothing to record or the intent could not be recorded.
+func recordBulkIntent(ctx context.Context, client Querier, plan []bulkLinks) string {
+	pending := false
+	for _, links := range plan {
+		if links.Invalidates != "" || len(links.Relationships) > 0 {
+			pending = true
+			break
+		}
+	}
+	if !pending {
+		return ""
+	}
+	payload, err := json.Marshal(plan)
+	if err != nil {
+		return ""
+	}
+	intentID, err := client.RecordIntent(ctx, IntentBulkLinks, string(payload))
+	if err != nil {
+		return ""
+	}
+	return intentID
+}
+
 // resolveBatchRefs replaces target_ref index references in relationships with actual IDs
-// from previously stored items in the same batch.
-func resolveBatchRefs(rels any, stored []bulkItem) []any {
+// from previously stored items in the same batch. It also returns how many
+// references were dropped because they point at no stored item.
+func resolveBatchRefs(rels any, stored []bulkItem) ([]any, int) {
 	relSlice, ok := rels.([]any)
 	if !ok {
-		return nil
+		return nil, 0
 	}
+	unresolved := 0
 	resolved := make([]any, 0, len(relSlice))
 	for _, rel := range relSlice {
 		relMap, ok := rel.(map[string]any)
@@ -154,6 +316,7 @@ func resolveBatchRefs(rels any, stored []bulkItem) []any {
 		if refIdx, hasRef := relMap["target_ref"]; hasRef {
 			idx := toInt(refIdx)
 			if idx < 0 || idx >= len(stored) || stored[idx].nodeID == "" {
+				unresolved++
 				continue
 			}
 			// Copy the map and replace target_ref with the resolved target_id.
@@ -161,12 +324,13 @@ func resolveBatchRefs(rels any, stored []bulkItem) []any {
 				"edge":      relMap["edge"],
 				"target_id": stored[idx].nodeID,
 				"role":      relMap["role"],
+				"weight":    relMap["weight"],
 			})
 		} else {
 			resolved = append(resolved, relMap)
 		}
 	}
-	return resolved
+	return resolved, unresolved
 }
 
 // toInt converts a JSON number to int. JSON numbers from map[string]any are float64.
@@ -48,6 +48,32 @@ func TestBulkStore_MixedTypes(t *testing.T) {
 	}
 }
 
+func TestBulkStore_Duplicates(t *testing.T) {
+	mock := &MockQuerier{
+		StoreFactFunc: func(ctx context.Context, req StoreFactRequest) (*Fact, error) {
+			if req.Content == "User likes Go" {
+				return &Fact{ID: "fact:existing", Content: req.Content, DuplicateSimilarity: 1.0}, nil
+			}
+			return &Fact{ID: "fact:new", Content: req.Content}, nil
+		},
+	}
+
+	result, err := BulkStore(context.Background(), mock, map[string]any{
+		"items": []any{
+			map[string]any{"type": "fact", "content": "User likes Go"},
+			map[string]any{"type": "fact", "content": "User uses CozoDB"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("BulkStore() error = %v", err)
+	}
+	for _, check := range []string{"Stored 1 items", "Skipped 1 duplicate facts", "[0] fact [fact:existing] duplicate_of existing", "[1] fact [fact:new]"} {
+		if !strings.Contains(result.Text, check) {
+			t.Errorf("expected %q in output, got: %s", check, result.Text)
+		}
+	}
+}
+
 func TestBulkStore_MissingItems(t *testing.T) {
 	mock := &MockQuerier{}
 	result, _ := BulkStore(context.Background(), mock, map[string]any{})
@@ -353,4 +379,139 @@ func TestBulkStore_AllFiveTypes(t *testing.T) {
 			t.Errorf("expected %q in output, got: %s", typ, result.Text)
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestBulkStore_AtomicRollsBack(t *testing.T) {
+	var fnErr error
+	counted := 0
+	mock := &MockQuerier{
+		StoreFactFunc: func(ctx context.Context, req StoreFactRequest) (*Fact, error) {
+			return nil, fmt.Errorf("storage error")
+		},
+		AtomicFunc: func(ctx context.Context, fn func(ctx context.Context) error) error {
+			fnErr = fn(ctx)
+			return fnErr
+		},
+		IncrementCounterFunc: func(ctx context.Context, key string) error {
+			counted++
+			return nil
+		},
+	}
+
+	result, err := BulkStore(context.Background(), mock, map[string]any{
+		"atomic": true,
+		"items": []any{
+			map[string]any{"type": "entity", "name": "Go", "kind": "technology"},
+			map[string]any{"type": "fact", "content": "will fail"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("BulkStore() error = %v", err)
+	}
+	if fnErr == nil {
+		t.Fatal("the atomic function should fail so the batch is discarded")
+	}
+	if !result.IsError {
+		t.Fatalf("BulkStore() should return an error result, got: %s", result.Text)
+	}
+	if !strings.Contains(result.Text, "Nothing stored") || !strings.Contains(result.Text, "storage error") {
+		t.Errorf("unexpected output: %s", result.Text)
+	}
+	if counted != 0 {
+		t.Errorf("counters incremented %d times for a rolled back batch", counted)
+	}
+}
+
+func TestBulkStore_AtomicUnresolvedRef(t *testing.T) {
+	result, _ := BulkStore(context.Background(), &MockQuerier{}, map[string]any{
+		"atomic": true,
+		"items": []any{
+			map[string]any{
+				"type":    "fact",
+				"content": "User works at Kraklabs",
+				"relationships": []any{
+					map[string]any{"edge": "fact_entity", "target_ref": float64(5)},
+				},
+			},
+		},
+	})
+	if !result.IsError || !strings.Contains(result.Text, "target_ref") {
+		t.Errorf("an unresolved target_ref should roll back an atomic batch, got: %s", result.Text)
+	}
+}
+
+func TestBulkStore_AtomicCommitFailure(t *testing.T) {
+	mock := &MockQuerier{
+		AtomicFunc: func(ctx context.Context, fn func(ctx context.Context) error) error {
+			if err := fn(ctx); err != nil {
+				return err
+			}
+			return fmt.Errorf("disk full")
+		},
+	}
+
+	result, _ := BulkStore(context.Background(), mock, map[string]any{
+		"atomic": true,
+		"items":  []any{map[string]any{"type": "topic", "name": "testing"}},
+	})
+	if !result.IsError || !strings.Contains(result.Text, "disk full") {
+		t.Errorf("a failed commit should be reported, got: %s", result.Text)
+	}
+}
+
+func TestBulkStore_LogsRelationshipIntent(t *testing.T) {
+	var events []string
+	var payload string
+	mock := &MockQuerier{
+		StoreEntityFunc: func(ctx context.Context, req StoreEntityRequest) (*Entity, error) {
+			return &Entity{ID: "ent:ref0001", Name: req.Name, Kind: req.Kind}, nil
+		},
+		RecordIntentFunc: func(ctx context.Context, kind, p string) (string, error) {
+			events = append(events, "record "+kind)
+			payload = p
+			return "int:1", nil
+		},
+		AddRelationshipFunc: func(ctx context.Context, edgeType string, fields map[string]string) error {
+			events = append(events, "relationship")
+			return nil
+		},
+		CompleteIntentFunc: func(ctx context.Context, intentID string) error {
+			events = append(events, "complete "+intentID)
+			return nil
+		},
+	}
+
+	result, _ := BulkStore(context.Background(), mock, map[string]any{
+		"items": []any{
+			map[string]any{"type": "entity", "name": "Kraklabs", "kind": "company"},
+			map[string]any{
+				"type":          "fact",
+				"content":       "User works at Kraklabs",
+				"relationships": []any{map[string]any{"edge": "fact_entity", "target_ref": float64(0)}},
+			},
+		},
+	})
+	if result.IsError {
+		t.Fatalf("BulkStore() returned error: %s", result.Text)
+	}
+	want := []string{"record " + IntentBulkLinks, "relationship", "complete int:1"}
+	if strings.Join(events, ", ") != strings.Join(want, ", ") {
+		t.Errorf("events = %v, want %v", events, want)
+	}
+	// The logged relationships carry resolved IDs, not batch references.
+	if !strings.Contains(payload, `"target_id":"ent:ref0001"`) || strings.Contains(payload, "target_ref") {
+		t.Errorf("payload = %s, want the resolved target_id", payload)
+	}
+}
+
+func TestBulkStore_NoIntentWithoutLinks(t *testing.T) {
+	mock := &MockQuerier{
+		RecordIntentFunc: func(ctx context.Context, kind, payload string) (string, error) {
+			t.Errorf("RecordIntent called for a batch without invalidations or relationships")
+			return "", nil
+		},
+	}
+	_, _ = BulkStore(context.Background(), mock, map[string]any{
+		"items": []any{map[string]any{"type": "topic", "name": "testing"}},
+	})
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import "fmt"
+
+// defaultFactConfidence is the confidence of facts stored without one when
+// their category has no policy setting it.
+const defaultFactConfidence = 0.8
+
+// CategoryPolicy is how facts of one category are stored and kept.
+type CategoryPolicy struct {
+	// RetentionDays, if positive, expires facts of the category that many
+	// days after they are stored, or sooner if they set an earlier expiry.
+	RetentionDays int
+	// DefaultConfidence, if positive, is the confidence of facts of the
+	// category stored without one.
+	DefaultConfidence float64
+}
+
+// Validate checks that the retention is not negative and the default
+// confidence is between 0 and 1.
+func (p CategoryPolicy) Validate() error {
+	if p.RetentionDays < 0 {
+		return fmt.Errorf("retention_days %d must not be negative", p.RetentionDays)
+	}
+	if p.DefaultConfidence < 0 || p.DefaultConfidence > 1 {
+		return fmt.Errorf("default_confidence %v must be between 0 and 1", p.DefaultConfidence)
+	}
+	return nil
+}
+
+// categoryPolicies are the policies set by SetCategoryPolicies.
+var categoryPolicies = map[string]CategoryPolicy{}
+
+// SetCategoryPolicies sets the policies the store tools apply, keyed by
+// fact category. Categories must be accepted by the store tools, so call
+// it after ExtendSchema. If any policy is invalid, nothing is set.
+//
+// SetCategoryPolicies is meant to be called once at startup, before any
+// tool runs; it is not safe for concurrent use with the tools.
+func SetCategoryPolicies(policies map[string]CategoryPolicy) error {
+	for category, p := range policies {
+		if !validFactCategories[category] {
+			return fmt.Errorf("unknown fact category %q", category)
+		}
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("category %q: %w", category, err)
+		}
+	}
+	categoryPolicies = make(map[string]CategoryPolicy, len(policies))
+	for category, p := range policies {
+		categoryPolicies[category] = p
+	}
+	return nil
+}
+
+// defaultConfidence returns the confidence of a fact of category stored
+// without one.
+func defaultConfidence(category string) float64 {
+	if c := categoryPolicies[category].DefaultConfidence; c > 0 {
+		return c
+	}
+	return defaultFactConfidence
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"testing"
+)
+
+// restoreCategoryPolicies undoes SetCategoryPolicies calls made by the test.
+func restoreCategoryPolicies(t *testing.T) {
+	saved := categoryPolicies
+	t.Cleanup(func() { categoryPolicies = saved })
+}
+
+func TestSetCategoryPolicies(t *testing.T) {
+	restoreCategoryPolicies(t)
+
+	tests := []struct {
+		name     string
+		policies map[string]CategoryPolicy
+		wantErr  bool
+	}{
+		{"valid", map[string]CategoryPolicy{"personal": {RetentionDays: 90}, "technical": {DefaultConfidence: 0.9}}, false},
+		{"unknown category", map[string]CategoryPolicy{"secrets": {RetentionDays: 1}}, true},
+		{"negative retention", map[string]CategoryPolicy{"personal": {RetentionDays: -1}}, true},
+		{"confidence above 1", map[string]CategoryPolicy{"personal": {DefaultConfidence: 1.5}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SetCategoryPolicies(tt.policies)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetCategoryPolicies() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	// The failed calls left the valid policies in place.
+	if got := defaultConfidence("technical"); got != 0.9 {
+		t.Errorf("defaultConfidence(technical) = %v, want 0.9", got)
+	}
+}
+
+func TestStore_FactCategoryDefaultConfidence(t *testing.T) {
+	restoreCategoryPolicies(t)
+	if err := SetCategoryPolicies(map[string]CategoryPolicy{"technical": {DefaultConfidence: 0.95}}); err != nil {
+		t.Fatalf("SetCategoryPolicies() error = %v", err)
+	}
+
+	var got []float64
+	mock := &MockQuerier{
+		StoreFactFunc: func(ctx context.Context, req StoreFactRequest) (*Fact, error) {
+			got = append(got, req.Confidence)
+			return &Fact{ID: "fact:test", Content: req.Content, Category: req.Category, Confidence: req.Confidence, Valid: true}, nil
+		},
+	}
+	for _, args := range []map[string]any{
+		{"type": "fact", "content": "Uses Go", "category": "technical"},
+		{"type": "fact", "content": "Uses Rust", "category": "technical", "confidence": 0.5},
+		{"type": "fact", "content": "Likes tea", "category": "preference"},
+	} {
+		if _, err := Store(context.Background(), mock, args); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+	}
+	if len(got) != 3 || got[0] != 0.95 || got[1] != 0.5 || got[2] != 0.8 {
+		t.Errorf("confidences = %v, want [0.95 0.5 0.8]", got)
+	}
+}
@@ -18,12 +18,18 @@ type Querier interface {
 	StoreTopic(ctx context.Context, req StoreTopicRequest) (*Topic, error)
 	InvalidateFact(ctx context.Context, oldFactID, newFactID, reason string) error
 	AddRelationship(ctx context.Context, edgeType string, fields map[string]string) error
+	RemoveRelationship(ctx context.Context, edgeType string, fields map[string]string) error
 
 	// Read operations
 	SemanticSearch(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error)
 	ExactSearch(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error)
+	FullTextSearch(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error)
+	HybridSearch(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error)
+	SimilarNodes(ctx context.Context, nodeID string, nodeTypes []string, limit int) ([]SearchResult, error)
 	GetNodeByID(ctx context.Context, nodeID string) (any, error)
 	ListNodes(ctx context.Context, opts ListOptions) ([]any, int, error)
+	FindEntityByName(ctx context.Context, name string) (*Entity, error)
+	ListPreferences(ctx context.Context) ([]Fact, error)
 
 	// Graph traversal
 	GetRelatedEntities(ctx context.Context, factID string) ([]Entity, error)
@@ -32,19 +38,69 @@ type Querier interface {
 	GetInvalidationChain(ctx context.Context, factID string) ([]Invalidation, error)
 	GetRelatedFacts(ctx context.Context, entityID string) ([]Fact, error)
 	GetEntityDecisions(ctx context.Context, entityID string) ([]Decision, error)
+	GetNodeEdges(ctx context.Context, nodeID string) ([]GraphEdge, error)
 
 	// Update operations
 	UpdateDescription(ctx context.Context, nodeID, newDescription string) error
 	UpdateStatus(ctx context.Context, nodeID, newStatus string) error
+	AddAlias(ctx context.Context, entityID, alias string) error
+	RenameEntity(ctx context.Context, entityID, newName string) error
+	SetArchived(ctx context.Context, nodeID string, archived bool) error
+	SetVerified(ctx context.Context, factID, verifiedBy string, verified bool) error
+	MergeEntities(ctx context.Context, survivorID, duplicateID string) error
+
+	// Atomic calls fn with a context in which node stores, invalidations,
+	// and new relationships are committed together when fn returns nil,
+	// and discarded when it returns an error.
+	Atomic(ctx context.Context, fn func(ctx context.Context) error) error
 
 	// Conflict detection
 	DetectConflicts(ctx context.Context, opts ConflictOptions) ([]Conflict, error)
 	CheckNewFactConflicts(ctx context.Context, content, category string) ([]Conflict, error)
+	FindDuplicates(ctx context.Context, opts DuplicateOptions) ([]DuplicateCluster, error)
 
 	// Stats and export
 	GetStats(ctx context.Context) (*GraphStats, error)
+	GetTopicStats(ctx context.Context) ([]TopicStats, error)
+	SuggestTopics(ctx context.Context, text string, limit int) ([]TopicSuggestion, error)
+	GetEmbeddingStatus(ctx context.Context) (*EmbeddingReport, error)
 	ExportGraph(ctx context.Context, opts ExportOptions) (*ExportData, error)
 
+	// Audit log
+	GetAuditLog(ctx context.Context, opts AuditOptions) ([]AuditEntry, error)
+
+	// Change history
+	GetNodeHistory(ctx context.Context, nodeID string) ([]HistoryEntry, error)
+
+	// Intent log
+	RecordIntent(ctx context.Context, kind, payload string) (string, error)
+	CompleteIntent(ctx context.Context, intentID string) error
+	PendingIntents(ctx context.Context) ([]Intent, error)
+
+	// Snapshots
+	CreateSnapshot(ctx context.Context, label string) (*Snapshot, error)
+	ListSnapshots(ctx context.Context) ([]Snapshot, error)
+	RestoreSnapshot(ctx context.Context, snapshotID string) (*Snapshot, error)
+	DeleteSnapshot(ctx context.Context, snapshotID string) error
+
+	// Saved searches
+	SaveSearch(ctx context.Context, name string, args map[string]any) (*SavedSearch, error)
+	GetSavedSearch(ctx context.Context, name string) (*SavedSearch, error)
+	ListSavedSearches(ctx context.Context) ([]SavedSearch, error)
+	DeleteSavedSearch(ctx context.Context, name string) error
+
+	// Sessions
+	OpenSession(ctx context.Context, title, sourceAgent string) (*Session, error)
+	CloseSession(ctx context.Context, sessionID, summary string) (*Session, error)
+	GetSession(ctx context.Context, sessionID string) (*Session, error)
+	ListSessions(ctx context.Context) ([]Session, error)
+
+	// Attachments
+	Attach(ctx context.Context, nodeID, name, mimeType string, data []byte) (*Attachment, error)
+	ListAttachments(ctx context.Context, nodeID string) ([]Attachment, error)
+	ReadAttachment(ctx context.Context, hash string) (*Attachment, []byte, error)
+	Detach(ctx context.Context, nodeID, hash string) error
+
 	// Metrics
 	IncrementCounter(ctx context.Context, key string) error
 
@@ -61,6 +117,15 @@ type StoreFactRequest struct {
 	Confidence         float64 `json:"confidence"`
 	SourceAgent        string  `json:"source_agent"`
 	SourceConversation string  `json:"source_conversation"`
+	SkipDedup          bool    `json:"skip_dedup,omitempty"` // store even if a near-identical fact exists
+	ExpiresAt          int64   `json:"expires_at,omitempty"` // Unix time after which the fact is invalidated; 0 never
+	// Key names the setting a preference fact records, such as "editor".
+	// Storing a preference with the key of a valid one invalidates the
+	// older value, so each key has one current value.
+	Key string `json:"key,omitempty"`
+	// Negated stores the fact as an anti-fact: Content states what is not
+	// the case, such as "User uses Docker" for "User does not use Docker".
+	Negated bool `json:"negated,omitempty"`
 }
 
 // StoreDecisionRequest contains parameters for storing a decision.
@@ -71,6 +136,8 @@ type StoreDecisionRequest struct {
 	Context            string `json:"context"`
 	SourceAgent        string `json:"source_agent"`
 	SourceConversation string `json:"source_conversation"`
+	// Status is the initial status: "active" (the default) or "proposed".
+	Status string `json:"status,omitempty"`
 }
 
 // StoreEntityRequest contains parameters for storing an entity.
@@ -81,11 +148,13 @@ type StoreEntityRequest struct {
 	SourceAgent string `json:"source_agent"`
 }
 
-// StoreEventRequest contains parameters for storing an event.
+// StoreEventRequest contains parameters for storing an event. EndDate is
+// the last day of an event that spans several days and may be empty.
 type StoreEventRequest struct {
 	Title              string `json:"title"`
 	Description        string `json:"description"`
 	EventDate          string `json:"event_date"`
+	EndDate            string `json:"end_date,omitempty"`
 	SourceAgent        string `json:"source_agent"`
 	SourceConversation string `json:"source_conversation"`
 }
@@ -109,6 +178,31 @@ type Fact struct {
 	Valid              bool    `json:"valid"`
 	CreatedAt          int64   `json:"created_at"`
 	UpdatedAt          int64   `json:"updated_at"`
+	// DuplicateSimilarity is set when StoreFact returned this existing fact
+	// instead of storing a near-identical one (1.0 for an exact match).
+	DuplicateSimilarity float64 `json:"duplicate_similarity,omitempty"`
+	// Verified marks a fact confirmed by a person with SetVerified;
+	// verified facts rank above unverified ones in search.
+	Verified   bool   `json:"verified,omitempty"`
+	VerifiedBy string `json:"verified_by,omitempty"`
+	VerifiedAt int64  `json:"verified_at,omitempty"`
+	// ExpiresAt is the Unix time at which a temporary fact stops being
+	// true; the expiry sweep then invalidates it. Zero means never.
+	ExpiresAt int64 `json:"expires_at,omitempty"`
+	// Key is the setting a preference fact records (see
+	// StoreFactRequest.Key), or empty.
+	Key string `json:"key,omitempty"`
+	// Replaces is set by StoreFact to the IDs of the earlier values of Key
+	// that the new fact invalidated.
+	Replaces []string `json:"replaces,omitempty"`
+	// Negated marks an anti-fact, whose content does not hold (see
+	// StoreFactRequest.Negated).
+	Negated bool `json:"negated,omitempty"`
+	// Edge is set by graph traversals to the edge that reached this fact.
+	Edge *EdgeMeta `json:"edge,omitempty"`
+	// Degree is the number of edges of the fact, set by ListNodes with
+	// IncludeDegree.
+	Degree *int `json:"degree,omitempty"`
 }
 
 // Decision represents a choice with rationale.
@@ -123,6 +217,11 @@ type Decision struct {
 	Status             string `json:"status"`
 	CreatedAt          int64  `json:"created_at"`
 	UpdatedAt          int64  `json:"updated_at"`
+	// Edge is set by graph traversals to the edge that reached this decision.
+	Edge *EdgeMeta `json:"edge,omitempty"`
+	// Degree is the number of edges of the decision, set by ListNodes with
+	// IncludeDegree.
+	Degree *int `json:"degree,omitempty"`
 }
 
 // Entity represents a person, company, project, or technology.
@@ -134,18 +233,37 @@ type Entity struct {
 	SourceAgent string `json:"source_agent"`
 	CreatedAt   int64  `json:"created_at"`
 	UpdatedAt   int64  `json:"updated_at"`
+	// Edge is set by graph traversals to the edge that reached this entity.
+	Edge *EdgeMeta `json:"edge,omitempty"`
+	// Degree is the number of edges of the entity, set by ListNodes with
+	// IncludeDegree.
+	Degree *int `json:"degree,omitempty"`
 }
 
-// Event represents a timestamped occurrence.
+// Event represents a timestamped occurrence. EndDate is set for an event
+// that spans several days and is its last day.
 type Event struct {
 	ID                 string `json:"id"`
 	Title              string `json:"title"`
 	Description        string `json:"description"`
 	EventDate          string `json:"event_date"`
+	EndDate            string `json:"end_date,omitempty"`
 	SourceAgent        string `json:"source_agent"`
 	SourceConversation string `json:"source_conversation"`
 	CreatedAt          int64  `json:"created_at"`
 	UpdatedAt          int64  `json:"updated_at"`
+	// Degree is the number of edges of the event, set by ListNodes with
+	// IncludeDegree.
+	Degree *int `json:"degree,omitempty"`
+}
+
+// Dates returns the event's date, or its first and last day as FROM..TO
+// when it spans several days.
+func (e Event) Dates() string {
+	if e.EndDate == "" || e.EndDate == e.EventDate {
+		return e.EventDate
+	}
+	return e.EventDate + ".." + e.EndDate
 }
 
 // Topic represents a recurring theme.
@@ -155,6 +273,9 @@ type Topic struct {
 	Description string `json:"description"`
 	CreatedAt   int64  `json:"created_at"`
 	UpdatedAt   int64  `json:"updated_at"`
+	// Degree is the number of edges of the topic, set by ListNodes with
+	// IncludeDegree.
+	Degree *int `json:"degree,omitempty"`
 }
 
 // EntityWithRole is an entity with its role in a decision.
@@ -165,23 +286,51 @@ type EntityWithRole struct {
 
 // Invalidation tracks when a fact supersedes another.
 type Invalidation struct {
-	NewFactID  string `json:"new_fact_id"`
-	OldFactID  string `json:"old_fact_id"`
-	Reason     string `json:"reason"`
-	OldContent string `json:"old_content,omitempty"`
-	NewContent string `json:"new_content,omitempty"`
+	NewFactID  string    `json:"new_fact_id"`
+	OldFactID  string    `json:"old_fact_id"`
+	Reason     string    `json:"reason"`
+	OldContent string    `json:"old_content,omitempty"`
+	NewContent string    `json:"new_content,omitempty"`
+	Edge       *EdgeMeta `json:"edge,omitempty"`
+}
+
+// GraphEdge is one relationship in the memory graph, in the direction of
+// its edge table.
+type GraphEdge struct {
+	// Type is the edge type without the "mie_" prefix, e.g. "fact_entity".
+	Type     string `json:"type"`
+	SourceID string `json:"source_id"`
+	TargetID string `json:"target_id"`
+	// Label is the role of a decision_entity edge or the reason of an
+	// invalidates edge, and empty otherwise.
+	Label  string  `json:"label,omitempty"`
+	Weight float64 `json:"weight"`
+}
+
+// EdgeMeta describes a relationship: how strong it is, which agent created
+// it, and when. Edges stored before schema version 5 have weight 1 and no
+// agent or creation time.
+type EdgeMeta struct {
+	Weight      float64 `json:"weight"`
+	SourceAgent string  `json:"source_agent,omitempty"`
+	CreatedAt   int64   `json:"created_at,omitempty"`
 }
 
 // --- Search and query types ---
 
 // SearchResult represents a single result from semantic or exact search.
 type SearchResult struct {
-	NodeType string      `json:"node_type"`
-	ID       string      `json:"id"`
-	Content  string      `json:"content"`
-	Detail   string      `json:"detail"`
-	Distance float64     `json:"distance"`
-	Metadata any `json:"metadata"`
+	NodeType  string   `json:"node_type"`
+	ID        string   `json:"id"`
+	Content   string   `json:"content"`
+	Detail    string   `json:"detail"`
+	Distance  float64  `json:"distance"`
+	Score     float64  `json:"score,omitempty"`      // ranking score: composite (semantic), relevance (fulltext), or fused (hybrid)
+	MatchedBy []string `json:"matched_by,omitempty"` // search modes that found this node (hybrid mode)
+	Metadata  any      `json:"metadata"`
+	// EffectiveConfidence is a fact's confidence after age decay. It is set
+	// only for facts, and only when a decay half-life is configured.
+	EffectiveConfidence float64 `json:"effective_confidence,omitempty"`
 }
 
 // ListOptions configures listing of nodes.
@@ -196,15 +345,50 @@ type ListOptions struct {
 	Offset    int    `json:"offset"`
 	SortBy    string `json:"sort_by"`
 	SortOrder string `json:"sort_order"`
+	// After starts the listing after the node a previous page ended with,
+	// instead of at Offset.
+	After *ListCursor `json:"after,omitempty"`
+	// IncludeArchived also returns nodes hidden with SetArchived.
+	IncludeArchived bool `json:"include_archived"`
+	// SourceAgent keeps nodes written by this agent. Topics record no
+	// agent and ignore it.
+	SourceAgent string `json:"source_agent,omitempty"`
+	// SourceConversation keeps facts, decisions, and events stored in this
+	// conversation or session. Entities and topics record none and ignore it.
+	SourceConversation string `json:"source_conversation,omitempty"`
+	// IncludeDegree sets the Degree of each returned node.
+	IncludeDegree bool `json:"include_degree,omitempty"`
+	TimeRange
 }
 
 // --- Conflict types ---
 
+// Conflict kinds, from the most to the least specific.
+const (
+	// ConflictNumericMismatch: the facts state different numbers.
+	ConflictNumericMismatch = "numeric_mismatch"
+	// ConflictTemporalSupersession: one fact says the other no longer holds,
+	// e.g. "moved to", "no longer", "now".
+	ConflictTemporalSupersession = "temporal_supersession"
+	// ConflictNegation: one fact negates the other, in its words or because
+	// one of them is an anti-fact (Fact.Negated) and the other is not.
+	ConflictNegation = "negation"
+	// ConflictOther: the facts are similar but differ in some other way.
+	ConflictOther = "other"
+)
+
 // Conflict represents two potentially contradicting facts.
 type Conflict struct {
 	FactA      Fact    `json:"fact_a"`
 	FactB      Fact    `json:"fact_b"`
 	Similarity float64 `json:"similarity"`
+	// Kind classifies the difference between the facts; one of the
+	// Conflict* kinds.
+	Kind string `json:"kind,omitempty"`
+	// OnlyInA and OnlyInB are the runs of words of each fact's content that
+	// the other lacks, in order.
+	OnlyInA []string `json:"only_in_a,omitempty"`
+	OnlyInB []string `json:"only_in_b,omitempty"`
 }
 
 // ConflictOptions configures conflict detection.
@@ -214,6 +398,51 @@ type ConflictOptions struct {
 	Limit     int     `json:"limit"`
 }
 
+// DuplicateCluster is a group of entities or topics that likely name the
+// same thing.
+type DuplicateCluster struct {
+	NodeType string `json:"node_type"` // entity or topic
+	// Members are the nodes of the cluster. The first is the suggested
+	// survivor: the member with the most relationships, then the oldest.
+	Members []DuplicateMember `json:"members"`
+	// Pairs are the pairs of members that score at least the threshold,
+	// best first.
+	Pairs []DuplicatePair `json:"pairs"`
+	// Score is the score of the best pair.
+	Score float64 `json:"score"`
+}
+
+// DuplicateMember is a node of a DuplicateCluster.
+type DuplicateMember struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Kind      string   `json:"kind,omitempty"`    // entities only
+	Aliases   []string `json:"aliases,omitempty"` // entities only
+	Edges     int      `json:"edges"`
+	CreatedAt int64    `json:"created_at"`
+}
+
+// DuplicatePair scores two members of a DuplicateCluster.
+type DuplicatePair struct {
+	A string `json:"a"`
+	B string `json:"b"`
+	// NameSimilarity compares the names and aliases of A and B, from 0 to 1.
+	NameSimilarity float64 `json:"name_similarity"`
+	// EmbeddingSimilarity is the cosine similarity of their embeddings, or
+	// 0 without embeddings.
+	EmbeddingSimilarity float64 `json:"embedding_similarity,omitempty"`
+	// Score is the mean of both similarities, or NameSimilarity if that is
+	// higher or there are no embeddings.
+	Score float64 `json:"score"`
+}
+
+// DuplicateOptions configures duplicate detection.
+type DuplicateOptions struct {
+	NodeTypes []string `json:"node_types"` // entity, topic, or both if empty
+	Threshold float64  `json:"threshold"`
+	Limit     int      `json:"limit"` // clusters, 0 for all
+}
+
 // --- Stats and export types ---
 
 // GraphStats contains memory graph statistics.
@@ -232,8 +461,254 @@ type GraphStats struct {
 	LastQueryAt      int64  `json:"last_query_at,omitempty"`
 	LastStoreAt      int64  `json:"last_store_at,omitempty"`
 	SchemaVersion    string `json:"schema_version"`
+	Namespace        string `json:"namespace,omitempty"`
 	StorageEngine    string `json:"storage_engine"`
 	StoragePath      string `json:"storage_path"`
+	// Agents breaks node counts down by source_agent, most active first.
+	Agents []AgentStats `json:"agents,omitempty"`
+	// EmbeddingWarnings describes embedding provider problems, such as a
+	// provider that was unreachable at startup or failed calls.
+	EmbeddingWarnings []string `json:"embedding_warnings,omitempty"`
+	// SearchCache counts semantic searches answered from the result cache.
+	// It is nil when the cache is disabled.
+	SearchCache *SearchCacheStats `json:"search_cache,omitempty"`
+	// EmbeddingQueue counts the nodes waiting for an embedding. It is nil
+	// when embeddings are disabled.
+	EmbeddingQueue *EmbeddingQueueStats `json:"embedding_queue,omitempty"`
+	// Limits compares the size of the graph with its configured limits. It
+	// is nil when no limit is set.
+	Limits *LimitStats `json:"limits,omitempty"`
+}
+
+// LimitStats compares the size of the memory graph with the limits past
+// which stores fail. A zero maximum is unlimited.
+type LimitStats struct {
+	Facts    int `json:"facts"`
+	MaxFacts int `json:"max_facts,omitempty"`
+	// Nodes counts the facts, decisions, entities, events, and topics.
+	Nodes    int `json:"nodes"`
+	MaxNodes int `json:"max_nodes,omitempty"`
+	// StorageBytes is the size of the data directory, shared by all
+	// namespaces.
+	StorageBytes    int64 `json:"storage_bytes"`
+	MaxStorageBytes int64 `json:"max_storage_bytes,omitempty"`
+}
+
+// EmbeddingQueueStats counts the nodes waiting in the persistent embedding
+// queue.
+type EmbeddingQueueStats struct {
+	Pending int `json:"pending"`
+	// Retrying counts the pending nodes whose embedding failed before.
+	Retrying int `json:"retrying"`
+	// OldestQueuedAt is when the longest-waiting node was queued, as Unix
+	// seconds, or 0 when the queue is empty.
+	OldestQueuedAt int64 `json:"oldest_queued_at,omitempty"`
+}
+
+// SearchCacheStats counts lookups in the semantic search result cache
+// since the server started.
+type SearchCacheStats struct {
+	Hits    int64 `json:"hits"`
+	Misses  int64 `json:"misses"`
+	Entries int   `json:"entries"`
+}
+
+// AgentStats counts the nodes one source agent has written.
+type AgentStats struct {
+	Agent     string `json:"agent"`
+	Facts     int    `json:"facts"`
+	Decisions int    `json:"decisions"`
+	Entities  int    `json:"entities"`
+	Events    int    `json:"events"`
+}
+
+// Total returns the number of nodes the agent has written.
+func (a AgentStats) Total() int {
+	return a.Facts + a.Decisions + a.Entities + a.Events
+}
+
+// TopicStats counts the memory linked to one topic. Events count when they
+// are linked to a decision on the topic.
+type TopicStats struct {
+	TopicID   string `json:"topic_id"`
+	Name      string `json:"name"`
+	Facts     int    `json:"facts"`
+	Decisions int    `json:"decisions"`
+	Entities  int    `json:"entities"`
+	Events    int    `json:"events"`
+	// LastActivity is the latest updated_at of the topic and its linked
+	// nodes, as Unix seconds.
+	LastActivity int64 `json:"last_activity"`
+}
+
+// Total returns the number of nodes linked to the topic.
+func (t TopicStats) Total() int {
+	return t.Facts + t.Decisions + t.Entities + t.Events
+}
+
+// TopicSuggestion is an existing topic that fits a text. Score is the
+// cosine similarity of the text and the topic's name and description, or,
+// without embeddings, the share of the topic name's words the text
+// contains.
+type TopicSuggestion struct {
+	TopicID     string  `json:"topic_id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Score       float64 `json:"score"`
+}
+
+// EmbeddingReport describes how much of the memory graph semantic search
+// can find and what keeps the rest from being embedded.
+type EmbeddingReport struct {
+	Enabled  bool   `json:"enabled"`
+	Provider string `json:"provider,omitempty"`
+	Model    string `json:"model,omitempty"`
+	// ConfiguredDimensions is the vector size set in the configuration and
+	// StoredDimensions the size of the embedding tables, or 0 if unknown.
+	// Embeddings cannot be stored while the two differ.
+	ConfiguredDimensions int `json:"configured_dimensions"`
+	StoredDimensions     int `json:"stored_dimensions"`
+	// Index is the vector index semantic search uses: "hnsw", "flat", or
+	// "custom".
+	Index string `json:"index"`
+	// MissingIndexes lists the "table:index" names of absent HNSW indexes.
+	MissingIndexes []string            `json:"missing_indexes,omitempty"`
+	Coverage       []EmbeddingCoverage `json:"coverage"`
+	// LastError is the latest failure to generate or store an embedding,
+	// at LastErrorAt in Unix seconds.
+	LastError   string `json:"last_error,omitempty"`
+	LastErrorAt int64  `json:"last_error_at,omitempty"`
+	// Warnings describes embedding provider problems, as in GraphStats.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// EmbeddingCoverage counts the nodes of one type and how many of them have
+// an embedding.
+type EmbeddingCoverage struct {
+	NodeType string `json:"node_type"`
+	Nodes    int    `json:"nodes"`
+	Embedded int    `json:"embedded"`
+}
+
+// Missing returns the number of nodes without an embedding.
+func (c EmbeddingCoverage) Missing() int {
+	return c.Nodes - c.Embedded
+}
+
+// AuditEntry records one write to the memory graph.
+type AuditEntry struct {
+	ID string `json:"id"`
+	// At is when the write happened, as Unix seconds.
+	At int64 `json:"at"`
+	// Op is the kind of write, such as "created", "invalidated", or "merged".
+	Op string `json:"op"`
+	// Tool is the MCP tool or command that made the write, if known.
+	Tool        string   `json:"tool"`
+	SourceAgent string   `json:"source_agent"`
+	NodeIDs     []string `json:"node_ids"`
+}
+
+// HistoryEntry records one change to a field of a node.
+type HistoryEntry struct {
+	ID     string `json:"id"`
+	NodeID string `json:"node_id"`
+	// At is when the change happened, as Unix seconds.
+	At int64 `json:"at"`
+	// Field is the changed field: "description", "name", "status", or "valid".
+	Field    string `json:"field"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+	// Reason is why the change was made, if given.
+	Reason string `json:"reason,omitempty"`
+	// RelatedID is the node behind the change, such as the fact that
+	// replaced an invalidated one.
+	RelatedID   string `json:"related_id,omitempty"`
+	Tool        string `json:"tool"`
+	SourceAgent string `json:"source_agent"`
+}
+
+// Intent is a multi-step write recorded in the intent log before its steps
+// run, and removed once they all ran. An intent that is still pending was
+// interrupted, for example by a crash.
+type Intent struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+	// Payload is the JSON-encoded work of the intent.
+	Payload   string `json:"payload"`
+	Namespace string `json:"namespace"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// AuditOptions filters the audit log. Empty fields match every entry.
+type AuditOptions struct {
+	Limit       int    `json:"limit"`
+	NodeID      string `json:"node_id"`
+	SourceAgent string `json:"source_agent"`
+	Tool        string `json:"tool"`
+}
+
+// Snapshot is a labeled copy of a namespace's graph, recorded so that the
+// graph can later be reverted to it.
+type Snapshot struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+	// CreatedAt is when the snapshot was taken, as Unix seconds.
+	CreatedAt int64 `json:"created_at"`
+	// Stats counts what the snapshot holds, keyed like ExportData.Stats.
+	Stats map[string]int `json:"stats"`
+}
+
+// SavedSearch is a mie_query search stored under a name so that it can be
+// run again.
+type SavedSearch struct {
+	Name string `json:"name"`
+	// Args are the mie_query arguments of the search, such as query, mode,
+	// and node_types.
+	Args map[string]any `json:"args"`
+	// CreatedAt and UpdatedAt are Unix seconds. Saving under an existing
+	// name replaces the search and moves UpdatedAt.
+	CreatedAt int64 `json:"created_at"`
+	UpdatedAt int64 `json:"updated_at"`
+}
+
+// Session groups the memories stored during one conversation. Facts,
+// decisions, and events stored while a session is open record its ID as
+// their source_conversation.
+type Session struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	SourceAgent string `json:"source_agent,omitempty"`
+	// StartedAt and EndedAt are Unix seconds. EndedAt is 0 while the
+	// session is open.
+	StartedAt int64 `json:"started_at"`
+	EndedAt   int64 `json:"ended_at,omitempty"`
+	// Summary is what the agent recorded about the conversation when it
+	// closed the session.
+	Summary string `json:"summary,omitempty"`
+}
+
+// AttachmentURIPrefix starts the MCP resource URI of an attachment, which
+// ends with its content hash.
+const AttachmentURIPrefix = "mie://attachment/"
+
+// Attachment links a small artifact, such as a diagram or a config
+// snippet, to a node. The content is stored once per hash, however many
+// nodes it is attached to.
+type Attachment struct {
+	// Hash is the hex SHA-256 of the content.
+	Hash     string `json:"hash"`
+	NodeID   string `json:"node_id"`
+	Name     string `json:"name"`
+	MimeType string `json:"mime_type"`
+	Size     int64  `json:"size"`
+	// CreatedAt is when the content was attached to the node, as Unix
+	// seconds.
+	CreatedAt int64 `json:"created_at"`
+}
+
+// URI returns the MCP resource URI that reads the attachment's content.
+func (a Attachment) URI() string {
+	return AttachmentURIPrefix + a.Hash
 }
 
 // ExportOptions configures graph export.
@@ -241,17 +716,36 @@ type ExportOptions struct {
 	Format            string   `json:"format"`
 	IncludeEmbeddings bool     `json:"include_embeddings"`
 	NodeTypes         []string `json:"node_types"`
+	// Since, if set, limits the export to nodes created or updated, facts
+	// verified, and edges, aliases, and archive marks added at or after
+	// this time (Unix seconds).
+	Since int64 `json:"since,omitempty"`
 }
 
 // ExportData contains the full graph export.
 type ExportData struct {
-	Version    string                 `json:"version"`
-	ExportedAt string                 `json:"exported_at"`
-	Stats      map[string]int         `json:"stats"`
-	Facts      []Fact                 `json:"facts,omitempty"`
-	Decisions  []Decision             `json:"decisions,omitempty"`
-	Entities   []Entity               `json:"entities,omitempty"`
-	Events     []Event                `json:"events,omitempty"`
-	Topics     []Topic                `json:"topics,omitempty"`
-	Edges      map[string]any `json:"relationships,omitempty"`
+	Version    string         `json:"version"`
+	ExportedAt string         `json:"exported_at"`
+	Namespace  string         `json:"namespace,omitempty"`
+	Stats      map[string]int `json:"stats"`
+	Facts      []Fact         `json:"facts,omitempty"`
+	Decisions  []Decision     `json:"decisions,omitempty"`
+	Entities   []Entity       `json:"entities,omitempty"`
+	Events     []Event        `json:"events,omitempty"`
+	Topics     []Topic        `json:"topics,omitempty"`
+	// Edges maps an edge type (e.g. "fact_entity", "invalidates") to its
+	// rows, each keyed by edge table column.
+	Edges   map[string][]map[string]string `json:"relationships,omitempty"`
+	Aliases []EntityAlias                  `json:"aliases,omitempty"`
+	// Archived lists the IDs of exported nodes that are archived.
+	Archived []string `json:"archived,omitempty"`
+	// Since is set on incremental exports to the RFC 3339 time they start
+	// from (see ExportOptions.Since).
+	Since string `json:"since,omitempty"`
+}
+
+// EntityAlias is an alternative name that resolves to an entity.
+type EntityAlias struct {
+	Alias    string `json:"alias"`
+	EntityID string `json:"entity_id"`
 }
@@ -38,6 +38,13 @@ func Conflicts(ctx context.Context, client Querier, args map[string]any) (*ToolR
 		return NewError(fmt.Sprintf("Failed to detect conflicts: %v", err)), nil
 	}
 
+	if WantsJSON(args) {
+		if conflicts == nil {
+			conflicts = []Conflict{}
+		}
+		return NewJSONResult(conflictsJSON{Category: category, Threshold: threshold, Conflicts: conflicts}), nil
+	}
+
 	var sb strings.Builder
 
 	if len(conflicts) == 0 {
@@ -54,11 +61,18 @@ func Conflicts(ctx context.Context, client Querier, args map[string]any) (*ToolR
 	sb.WriteString(fmt.Sprintf("## Potential Conflicts Found (%d)\n\n", len(conflicts)))
 
 	for i, c := range conflicts {
-		sb.WriteString(fmt.Sprintf("### Conflict %d (similarity: %.0f%%)\n", i+1, c.Similarity*100))
-		sb.WriteString(fmt.Sprintf("- [%s] %q (%s, confidence: %.1f)\n",
-			c.FactA.ID, Truncate(c.FactA.Content, 80), c.FactA.Category, c.FactA.Confidence))
-		sb.WriteString(fmt.Sprintf("- [%s] %q (%s, confidence: %.1f)\n",
-			c.FactB.ID, Truncate(c.FactB.Content, 80), c.FactB.Category, c.FactB.Confidence))
+		if c.Kind != "" && c.Kind != ConflictOther {
+			sb.WriteString(fmt.Sprintf("### Conflict %d (similarity: %.0f%%, %s)\n", i+1, c.Similarity*100, strings.ReplaceAll(c.Kind, "_", " ")))
+		} else {
+			sb.WriteString(fmt.Sprintf("### Conflict %d (similarity: %.0f%%)\n", i+1, c.Similarity*100))
+		}
+		sb.WriteString(fmt.Sprintf("- [%s] %s (%s, confidence: %.1f)\n",
+			c.FactA.ID, conflictFactContent(c.FactA), c.FactA.Category, c.FactA.Confidence))
+		sb.WriteString(fmt.Sprintf("- [%s] %s (%s, confidence: %.1f)\n",
+			c.FactB.ID, conflictFactContent(c.FactB), c.FactB.Category, c.FactB.Confidence))
+		if len(c.OnlyInA) > 0 || len(c.OnlyInB) > 0 {
+			sb.WriteString(fmt.Sprintf("  Differs: %s vs %s\n", formatConflictSpans(c.OnlyInA), formatConflictSpans(c.OnlyInB)))
+		}
 
 		// Recommendation
 		if c.FactA.CreatedAt < c.FactB.CreatedAt {
@@ -75,4 +89,33 @@ func Conflicts(ctx context.Context, client Querier, args map[string]any) (*ToolR
 	sb.WriteString("To resolve: call mie_update with action=\"invalidate\" on the outdated fact.\n")
 
 	return NewResult(sb.String()), nil
-}
\ No newline at end of file
+}
+
+// conflictFactContent quotes the content of a fact of a conflict, marking
+// an anti-fact with NOT.
+func conflictFactContent(f Fact) string {
+	if f.Negated {
+		return fmt.Sprintf("NOT %q", Truncate(f.Content, 80))
+	}
+	return fmt.Sprintf("%q", Truncate(f.Content, 80))
+}
+
+// formatConflictSpans quotes the differing spans of one fact of a conflict,
+// or returns "(nothing)" if it has none.
+func formatConflictSpans(spans []string) string {
+	if len(spans) == 0 {
+		return "(nothing)"
+	}
+	quoted := make([]string, len(spans))
+	for i, span := range spans {
+		quoted[i] = fmt.Sprintf("%q", Truncate(span, 40))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// conflictsJSON is the JSON response of Conflicts.
+type conflictsJSON struct {
+	Category  string     `json:"category,omitempty"`
+	Threshold float64    `json:"threshold"`
+	Conflicts []Conflict `json:"conflicts"`
+}
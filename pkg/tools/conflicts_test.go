@@ -52,6 +52,27 @@ func TestConflicts_Found(t *testing.T) {
 	}
 }
 
+func TestConflicts_Negated(t *testing.T) {
+	mock := &MockQuerier{
+		DetectConflictsFunc: func(ctx context.Context, opts ConflictOptions) ([]Conflict, error) {
+			return []Conflict{{
+				FactA:      Fact{ID: "fact:a", Content: "User uses Docker", Category: "technical", Confidence: 0.8},
+				FactB:      Fact{ID: "fact:b", Content: "User uses Docker", Category: "technical", Confidence: 0.9, Negated: true},
+				Similarity: 1,
+				Kind:       ConflictNegation,
+			}}, nil
+		},
+		EmbeddingsEnabledFunc: func() bool { return true },
+	}
+
+	result, _ := Conflicts(context.Background(), mock, map[string]any{})
+	for _, check := range []string{"(similarity: 100%, negation)", `- [fact:a] "User uses Docker"`, `- [fact:b] NOT "User uses Docker"`} {
+		if !strings.Contains(result.Text, check) {
+			t.Errorf("Conflicts() output missing %q:\n%s", check, result.Text)
+		}
+	}
+}
+
 func TestConflicts_None(t *testing.T) {
 	mock := &MockQuerier{
 		DetectConflictsFunc: func(ctx context.Context, opts ConflictOptions) ([]Conflict, error) {
@@ -130,4 +151,41 @@ func TestConflicts_Recommendation(t *testing.T) {
 	if !strings.Contains(result.Text, "newer fact") {
 		t.Error("Conflicts() should recommend based on creation time")
 	}
-}
\ No newline at end of file
+}
+
+func TestConflicts_Explanation(t *testing.T) {
+	mock := &MockQuerier{
+		DetectConflictsFunc: func(ctx context.Context, opts ConflictOptions) ([]Conflict, error) {
+			return []Conflict{
+				{
+					FactA:      Fact{ID: "fact:a", Content: "The team has 5 engineers", CreatedAt: 1000},
+					FactB:      Fact{ID: "fact:b", Content: "The team has 7 engineers", CreatedAt: 2000},
+					Similarity: 0.95,
+					Kind:       ConflictNumericMismatch,
+					OnlyInA:    []string{"5"},
+					OnlyInB:    []string{"7"},
+				},
+				{
+					FactA:      Fact{ID: "fact:c", Content: "Deploys are automated"},
+					FactB:      Fact{ID: "fact:d", Content: "Deploys are not automated"},
+					Similarity: 0.9,
+					Kind:       ConflictNegation,
+					OnlyInB:    []string{"not"},
+				},
+			}, nil
+		},
+		EmbeddingsEnabledFunc: func() bool { return true },
+	}
+
+	result, _ := Conflicts(context.Background(), mock, map[string]any{})
+	for _, check := range []string{
+		"### Conflict 1 (similarity: 95%, numeric mismatch)",
+		`  Differs: "5" vs "7"`,
+		"### Conflict 2 (similarity: 90%, negation)",
+		`  Differs: (nothing) vs "not"`,
+	} {
+		if !strings.Contains(result.Text, check) {
+			t.Errorf("Conflicts() output missing %q:\n%s", check, result.Text)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	defaultContextTokens = 1500
+	minContextTokens     = 100
+	maxContextTokens     = 8000
+	contextSearchLimit   = 20
+	contextExpandLimit   = 3
+)
+
+// contextSections lists briefing sections in render order.
+var contextSections = []string{"Facts", "Decisions", "Events", "Entities"}
+
+// briefingItem is one bullet of a context briefing.
+type briefingItem struct {
+	id      string
+	section string
+	line    string
+}
+
+// Context assembles a token-budgeted markdown briefing about a focus. It
+// combines hybrid search hits with the facts and decisions linked to the top
+// matching entities, keeping the highest ranked items that fit the budget.
+func Context(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
+	focus := strings.TrimSpace(GetStringArg(args, "focus", ""))
+	if focus == "" {
+		return NewError("Missing required parameter: focus"), nil
+	}
+	maxTokens := GetIntArg(args, "max_tokens", defaultContextTokens)
+	if maxTokens < minContextTokens {
+		maxTokens = minContextTokens
+	}
+	if maxTokens > maxContextTokens {
+		maxTokens = maxContextTokens
+	}
+
+	results, err := client.HybridSearch(ctx, focus, []string{"fact", "decision", "entity", "event"}, contextSearchLimit)
+	if err != nil {
+		return NewError(fmt.Sprintf("Context search failed: %v", err)), nil
+	}
+
+	var items []briefingItem
+	seen := make(map[string]bool)
+	add := func(item briefingItem) {
+		if item.line == "" || seen[item.id] {
+			return
+		}
+		seen[item.id] = true
+		items = append(items, item)
+	}
+
+	for _, r := range results {
+		add(searchResultItem(r))
+	}
+
+	// Graph expansion: pull in what the top entities are linked to.
+	expanded := 0
+	for _, r := range results {
+		if r.NodeType != "entity" || expanded >= contextExpandLimit {
+			continue
+		}
+		expanded++
+		facts, err := client.GetFactsAboutEntity(ctx, r.ID)
+		if err != nil {
+			return NewError(fmt.Sprintf("Context expansion failed: %v", err)), nil
+		}
+		for _, f := range facts {
+			if f.Valid {
+				add(factItem(f))
+			}
+		}
+		decisions, err := client.GetEntityDecisions(ctx, r.ID)
+		if err != nil {
+			return NewError(fmt.Sprintf("Context expansion failed: %v", err)), nil
+		}
+		for _, d := range decisions {
+			add(decisionItem(d))
+		}
+	}
+
+	header := fmt.Sprintf("## Memory Briefing: %s\n\n", focus)
+	if len(items) == 0 && !WantsJSON(args) {
+		_ = client.IncrementCounter(ctx, "total_queries")
+		return NewResult(header + "_No memories found for this focus._\n"), nil
+	}
+
+	used := estimateTokens(header)
+	kept := make(map[string][]string)
+	var keptItems []briefingItem
+	omitted := 0
+	for _, item := range items {
+		cost := estimateTokens(item.line) + 1
+		if _, ok := kept[item.section]; !ok {
+			cost += estimateTokens("### " + item.section + "\n\n")
+		}
+		if used+cost > maxTokens {
+			omitted++
+			continue
+		}
+		used += cost
+		kept[item.section] = append(kept[item.section], item.line)
+		keptItems = append(keptItems, item)
+	}
+
+	if WantsJSON(args) {
+		out := contextJSON{Focus: focus, Items: []contextItemJSON{}, TokensUsed: used, MaxTokens: maxTokens, Omitted: omitted}
+		for _, item := range keptItems {
+			out.Items = append(out.Items, contextItemJSON{
+				ID:      item.id,
+				Section: strings.ToLower(item.section),
+				Text:    strings.TrimPrefix(item.line, "- "),
+			})
+		}
+		_ = client.IncrementCounter(ctx, "total_queries")
+		return NewJSONResult(out), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(header)
+	for _, section := range contextSections {
+		lines := kept[section]
+		if len(lines) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "### %s\n", section)
+		for _, line := range lines {
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, "_~%d of %d tokens used", used, maxTokens)
+	if omitted > 0 {
+		fmt.Fprintf(&sb, "; %d lower-ranked items omitted", omitted)
+	}
+	sb.WriteString("._\n")
+
+	_ = client.IncrementCounter(ctx, "total_queries")
+	return NewResult(sb.String()), nil
+}
+
+// contextJSON is the JSON response of Context. Items are in rank order.
+type contextJSON struct {
+	Focus      string            `json:"focus"`
+	Items      []contextItemJSON `json:"items"`
+	TokensUsed int               `json:"tokens_used"`
+	MaxTokens  int               `json:"max_tokens"`
+	Omitted    int               `json:"omitted"`
+}
+
+// contextItemJSON is one briefing item of a contextJSON.
+type contextItemJSON struct {
+	ID      string `json:"id"`
+	Section string `json:"section"`
+	Text    string `json:"text"`
+}
+
+// searchResultItem renders a search hit, preferring its typed metadata.
+func searchResultItem(r SearchResult) briefingItem {
+	switch m := r.Metadata.(type) {
+	case *Fact:
+		f := *m
+		if f.Content == "" {
+			f.Content = r.Content
+		}
+		if f.Category == "" {
+			f.Category = r.Detail
+		}
+		return factItem(f)
+	case *Decision:
+		d := *m
+		if d.Title == "" {
+			d.Title = r.Content
+		}
+		return decisionItem(d)
+	case *Event:
+		e := *m
+		if e.Title == "" {
+			e.Title = r.Content
+		}
+		return eventItem(e)
+	case *Entity:
+		e := *m
+		if e.Name == "" {
+			e.Name = r.Content
+		}
+		return entityItem(e)
+	}
+	switch r.NodeType {
+	case "fact":
+		return factItem(Fact{ID: r.ID, Content: r.Content, Category: r.Detail})
+	case "decision":
+		return decisionItem(Decision{ID: r.ID, Title: r.Content, Rationale: r.Detail})
+	case "event":
+		return eventItem(Event{ID: r.ID, Title: r.Content, Description: r.Detail})
+	case "entity":
+		return entityItem(Entity{ID: r.ID, Name: r.Content, Description: r.Detail})
+	}
+	return briefingItem{}
+}
+
+func factItem(f Fact) briefingItem {
+	line := "- " + f.Content
+	if f.Category != "" {
+		line += fmt.Sprintf(" (%s)", f.Category)
+	}
+	if f.Verified {
+		line += " [verified]"
+	}
+	return briefingItem{id: f.ID, section: "Facts", line: line}
+}
+
+func decisionItem(d Decision) briefingItem {
+	line := fmt.Sprintf("- **%s**", d.Title)
+	if d.Status != "" && d.Status != "active" {
+		line += fmt.Sprintf(" [%s]", d.Status)
+	}
+	if d.Rationale != "" {
+		line += ": " + Truncate(d.Rationale, 200)
+	}
+	return briefingItem{id: d.ID, section: "Decisions", line: line}
+}
+
+func eventItem(e Event) briefingItem {
+	line := "- "
+	if e.EventDate != "" {
+		line += e.Dates() + ": "
+	}
+	line += e.Title
+	if e.Description != "" {
+		line += " - " + Truncate(e.Description, 150)
+	}
+	return briefingItem{id: e.ID, section: "Events", line: line}
+}
+
+func entityItem(e Entity) briefingItem {
+	line := "- " + e.Name
+	if e.Kind != "" {
+		line += fmt.Sprintf(" (%s)", e.Kind)
+	}
+	if e.Description != "" {
+		line += ": " + Truncate(e.Description, 150)
+	}
+	return briefingItem{id: e.ID, section: "Entities", line: line}
+}
+
+// estimateTokens approximates the token count of text at four characters per
+// token, which is close enough for budgeting English prose.
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestContext_Briefing(t *testing.T) {
+	var counted bool
+	mock := &MockQuerier{
+		HybridSearchFunc: func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
+			if query != "PostgreSQL" {
+				t.Errorf("query = %q, want PostgreSQL", query)
+			}
+			return []SearchResult{
+				{NodeType: "entity", ID: "ent:pg", Content: "PostgreSQL", Metadata: &Entity{ID: "ent:pg", Name: "PostgreSQL", Kind: "technology"}},
+				{NodeType: "fact", ID: "fact:1", Content: "Primary DB is PostgreSQL 16", Detail: "technical"},
+				{NodeType: "event", ID: "evt:1", Content: "Migrated to PostgreSQL", Metadata: &Event{ID: "evt:1", Title: "Migrated to PostgreSQL", EventDate: "2026-01-15"}},
+			}, nil
+		},
+		GetFactsAboutEntityFunc: func(ctx context.Context, entityID string) ([]Fact, error) {
+			return []Fact{
+				{ID: "fact:1", Content: "Primary DB is PostgreSQL 16", Category: "technical", Valid: true},
+				{ID: "fact:2", Content: "Backups run nightly", Category: "technical", Valid: true},
+				{ID: "fact:old", Content: "Primary DB is MySQL", Valid: false},
+			}, nil
+		},
+		GetEntityDecisionsFunc: func(ctx context.Context, entityID string) ([]Decision, error) {
+			return []Decision{{ID: "dec:1", Title: "Use PostgreSQL", Rationale: "Team expertise", Status: "active"}}, nil
+		},
+		IncrementCounterFunc: func(ctx context.Context, key string) error {
+			counted = key == "total_queries"
+			return nil
+		},
+	}
+
+	result, err := Context(context.Background(), mock, map[string]any{"focus": "PostgreSQL"})
+	if err != nil {
+		t.Fatalf("Context() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Context() returned error: %s", result.Text)
+	}
+	for _, want := range []string{
+		"## Memory Briefing: PostgreSQL",
+		"### Facts\n- Primary DB is PostgreSQL 16 (technical)\n- Backups run nightly (technical)\n",
+		"### Decisions\n- **Use PostgreSQL**: Team expertise\n",
+		"### Events\n- 2026-01-15: Migrated to PostgreSQL\n",
+		"### Entities\n- PostgreSQL (technology)\n",
+	} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("missing %q in:\n%s", want, result.Text)
+		}
+	}
+	if strings.Contains(result.Text, "MySQL") {
+		t.Errorf("invalidated fact should be skipped, got:\n%s", result.Text)
+	}
+	if strings.Count(result.Text, "Primary DB is PostgreSQL 16") != 1 {
+		t.Errorf("duplicate fact should appear once, got:\n%s", result.Text)
+	}
+	if !counted {
+		t.Error("expected total_queries counter to be incremented")
+	}
+}
+
+func TestContext_TokenBudget(t *testing.T) {
+	var results []SearchResult
+	for i := 0; i < 20; i++ {
+		results = append(results, SearchResult{
+			NodeType: "fact",
+			ID:       "fact:" + string(rune('a'+i)),
+			Content:  strings.Repeat("lorem ipsum ", 10) + string(rune('a'+i)),
+		})
+	}
+	mock := &MockQuerier{
+		HybridSearchFunc: func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
+			return results, nil
+		},
+	}
+
+	result, err := Context(context.Background(), mock, map[string]any{"focus": "lorem", "max_tokens": 200})
+	if err != nil {
+		t.Fatalf("Context() error = %v", err)
+	}
+	if estimateTokens(result.Text) > 200+30 {
+		t.Errorf("briefing exceeds budget: ~%d tokens", estimateTokens(result.Text))
+	}
+	if !strings.Contains(result.Text, "lower-ranked items omitted") {
+		t.Errorf("expected omission note, got:\n%s", result.Text)
+	}
+	if !strings.Contains(result.Text, "ipsum a\n") {
+		t.Errorf("highest ranked item should be kept, got:\n%s", result.Text)
+	}
+}
+
+func TestContext_Validation(t *testing.T) {
+	result, err := Context(context.Background(), &MockQuerier{}, map[string]any{})
+	if err != nil {
+		t.Fatalf("Context() error = %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Text, "focus") {
+		t.Errorf("expected missing focus error, got: %s", result.Text)
+	}
+
+	result, _ = Context(context.Background(), &MockQuerier{}, map[string]any{"focus": "nothing"})
+	if result.IsError || !strings.Contains(result.Text, "No memories found") {
+		t.Errorf("expected empty briefing, got: %s", result.Text)
+	}
+}
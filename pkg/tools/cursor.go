@@ -0,0 +1,61 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// ListCursor marks the last node of a ListNodes page, so the next page can
+// start after it even if nodes were added or removed in between.
+type ListCursor struct {
+	NodeType  string `json:"t"`
+	SortBy    string `json:"s"`
+	SortOrder string `json:"o"`
+	// Value is the last node's sort key and ID its node ID, which breaks
+	// ties between nodes with the same sort key.
+	Value any    `json:"v"`
+	ID    string `json:"id"`
+}
+
+// Encode returns the cursor as an opaque URL-safe string.
+func (c ListCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeListCursor parses a cursor returned by ListCursor.Encode.
+func DecodeListCursor(s string) (*ListCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	var c ListCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" || c.SortBy == "" {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	return &c, nil
+}
+
+// listCursorAfter returns the cursor that continues a listing after node,
+// or nil if node has no field named sortBy.
+func listCursorAfter(node any, opts ListOptions) *ListCursor {
+	data, err := json.Marshal(node)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+	id, _ := fields["id"].(string)
+	value, ok := fields[opts.SortBy]
+	if id == "" || !ok {
+		return nil
+	}
+	return &ListCursor{NodeType: opts.NodeType, SortBy: opts.SortBy, SortOrder: opts.SortOrder, Value: value, ID: id}
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// dedupeJSON is the JSON response of Dedupe.
+type dedupeJSON struct {
+	Threshold float64            `json:"threshold"`
+	Clusters  []DuplicateCluster `json:"clusters"`
+}
+
+// Dedupe reports clusters of entities and topics that likely name the same
+// thing, by the similarity of their names, aliases, and embeddings, with
+// the mie_merge calls that would fold each cluster into its survivor.
+func Dedupe(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
+	nodeTypes := GetStringSliceArg(args, "node_types", []string{"entity", "topic"})
+	for _, nt := range nodeTypes {
+		if nt != "entity" && nt != "topic" {
+			return NewError(fmt.Sprintf("Invalid node type %q: mie_dedupe compares entities and topics", nt)), nil
+		}
+	}
+	threshold := GetFloat64Arg(args, "threshold", 0.8)
+	if threshold <= 0 || threshold > 1 {
+		return NewError(fmt.Sprintf("threshold must be greater than 0 and at most 1 (got %v)", threshold)), nil
+	}
+	limit := GetIntArg(args, "limit", 10)
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > 50 {
+		limit = 50
+	}
+	ctx = WithIncludeArchived(ctx, GetBoolArg(args, "include_archived", false))
+
+	clusters, err := client.FindDuplicates(ctx, DuplicateOptions{NodeTypes: nodeTypes, Threshold: threshold, Limit: limit})
+	if err != nil {
+		return NewError(fmt.Sprintf("Failed to find duplicates: %v", err)), nil
+	}
+
+	if WantsJSON(args) {
+		if clusters == nil {
+			clusters = []DuplicateCluster{}
+		}
+		return NewJSONResult(dedupeJSON{Threshold: threshold, Clusters: clusters}), nil
+	}
+
+	var sb strings.Builder
+	if len(clusters) == 0 {
+		sb.WriteString("## Duplicate Report\n\n")
+		fmt.Fprintf(&sb, "_No likely duplicates found among %s (threshold: %.0f%%)._\n", strings.Join(nodeTypes, " and "), threshold*100)
+		return NewResult(sb.String()), nil
+	}
+
+	fmt.Fprintf(&sb, "## Likely Duplicates (%d)\n\n", len(clusters))
+	for i, c := range clusters {
+		fmt.Fprintf(&sb, "### Cluster %d: %s (score: %.0f%%)\n", i+1, c.NodeType, c.Score*100)
+		for j, m := range c.Members {
+			fmt.Fprintf(&sb, "- [%s] %q", m.ID, m.Name)
+			var details []string
+			if m.Kind != "" {
+				details = append(details, m.Kind)
+			}
+			details = append(details, pluralize(m.Edges, "relationship"))
+			if len(m.Aliases) > 0 {
+				details = append(details, "aliases: "+strings.Join(m.Aliases, ", "))
+			}
+			fmt.Fprintf(&sb, " (%s)", strings.Join(details, ", "))
+			if j == 0 {
+				sb.WriteString(" - suggested survivor")
+			}
+			sb.WriteString("\n")
+		}
+		for _, p := range c.Pairs {
+			fmt.Fprintf(&sb, "  [%s] ~ [%s]: %.0f%% (name %.0f%%", p.A, p.B, p.Score*100, p.NameSimilarity*100)
+			if p.EmbeddingSimilarity > 0 {
+				fmt.Fprintf(&sb, ", embedding %.0f%%", p.EmbeddingSimilarity*100)
+			}
+			sb.WriteString(")\n")
+		}
+		survivor := c.Members[0].ID
+		if c.NodeType == "entity" {
+			for _, m := range c.Members[1:] {
+				fmt.Fprintf(&sb, "  Merge: mie_merge survivor_id=%q duplicate_id=%q\n", survivor, m.ID)
+			}
+		} else {
+			fmt.Fprintf(&sb, "  Topics cannot be merged with mie_merge: move the edges of the other topics to [%s] with mie_relate (action=create, then action=delete); mie gc then removes the unused topics.\n", survivor)
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("Review each cluster before merging: similar names can belong to different things.\n")
+	return NewResult(sb.String()), nil
+}
+
+// pluralize returns n and noun, adding an s unless n is 1.
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
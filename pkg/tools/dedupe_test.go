@@ -0,0 +1,91 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDedupe(t *testing.T) {
+	var got DuplicateOptions
+	mock := &MockQuerier{
+		FindDuplicatesFunc: func(ctx context.Context, opts DuplicateOptions) ([]DuplicateCluster, error) {
+			got = opts
+			return []DuplicateCluster{
+				{
+					NodeType: "entity",
+					Members: []DuplicateMember{
+						{ID: "ent:b", Name: "PostgreSQL", Kind: "technology", Edges: 4},
+						{ID: "ent:a", Name: "Postgres", Kind: "technology", Edges: 1, Aliases: []string{"pg"}},
+					},
+					Pairs: []DuplicatePair{{A: "ent:a", B: "ent:b", NameSimilarity: 0.8, EmbeddingSimilarity: 0.96, Score: 0.88}},
+					Score: 0.88,
+				},
+				{
+					NodeType: "topic",
+					Members:  []DuplicateMember{{ID: "top:x", Name: "hiring"}, {ID: "top:y", Name: "Hiring"}},
+					Pairs:    []DuplicatePair{{A: "top:x", B: "top:y", NameSimilarity: 1, Score: 1}},
+					Score:    1,
+				},
+			}, nil
+		},
+	}
+	ctx := context.Background()
+
+	result, _ := Dedupe(ctx, mock, map[string]any{"threshold": 0.85, "limit": 99.0})
+	if result.IsError {
+		t.Fatalf("Dedupe failed: %s", result.Text)
+	}
+	if got.Threshold != 0.85 || got.Limit != 50 || strings.Join(got.NodeTypes, ",") != "entity,topic" {
+		t.Errorf("got options %+v", got)
+	}
+	for _, want := range []string{
+		"## Likely Duplicates (2)",
+		"### Cluster 1: entity (score: 88%)",
+		`- [ent:b] "PostgreSQL" (technology, 4 relationships) - suggested survivor`,
+		`- [ent:a] "Postgres" (technology, 1 relationship, aliases: pg)` + "\n",
+		"[ent:a] ~ [ent:b]: 88% (name 80%, embedding 96%)",
+		`Merge: mie_merge survivor_id="ent:b" duplicate_id="ent:a"`,
+		"[top:x] ~ [top:y]: 100% (name 100%)\n",
+		"Topics cannot be merged with mie_merge",
+	} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("missing %q in:\n%s", want, result.Text)
+		}
+	}
+
+	result, _ = Dedupe(ctx, mock, map[string]any{"node_types": []any{"topic"}, "response_format": "json"})
+	var out dedupeJSON
+	if err := json.Unmarshal([]byte(result.Text), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result.Text)
+	}
+	if out.Threshold != 0.8 || len(out.Clusters) != 2 || out.Clusters[0].Members[0].ID != "ent:b" {
+		t.Errorf("unexpected JSON: %+v", out)
+	}
+	if strings.Join(got.NodeTypes, ",") != "topic" {
+		t.Errorf("node_types not passed: %v", got.NodeTypes)
+	}
+}
+
+func TestDedupe_Errors(t *testing.T) {
+	ctx := context.Background()
+	mock := &MockQuerier{}
+
+	result, _ := Dedupe(ctx, mock, map[string]any{"node_types": []any{"fact"}})
+	if !result.IsError || !strings.Contains(result.Text, "entities and topics") {
+		t.Errorf("fact node type accepted: %s", result.Text)
+	}
+	result, _ = Dedupe(ctx, mock, map[string]any{"threshold": 1.5})
+	if !result.IsError {
+		t.Errorf("threshold above 1 accepted: %s", result.Text)
+	}
+	result, _ = Dedupe(ctx, mock, map[string]any{})
+	if result.IsError || !strings.Contains(result.Text, "_No likely duplicates found among entity and topic (threshold: 80%)._") {
+		t.Errorf("empty report = %s", result.Text)
+	}
+}
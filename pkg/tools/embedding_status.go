@@ -0,0 +1,109 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// EmbeddingStatus reports how many nodes of each type have embeddings, the
+// health of the vector index, the configured and stored vector sizes, and
+// the latest embedding failure, so that semantic search that finds nothing
+// can be explained.
+func EmbeddingStatus(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
+	report, err := client.GetEmbeddingStatus(ctx)
+	if err != nil {
+		return NewError(fmt.Sprintf("Failed to get embedding status: %v", err)), nil
+	}
+	problems := EmbeddingProblems(report)
+	if WantsJSON(args) {
+		if report.Coverage == nil {
+			report.Coverage = []EmbeddingCoverage{}
+		}
+		if problems == nil {
+			problems = []string{}
+		}
+		return NewJSONResult(embeddingStatusJSON{EmbeddingReport: report, Problems: problems}), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Embedding Status\n\n")
+	if report.Enabled {
+		fmt.Fprintf(&sb, "- Embeddings: enabled (%s", orDash(report.Provider))
+		if report.Model != "" {
+			fmt.Fprintf(&sb, ", %s", report.Model)
+		}
+		sb.WriteString(")\n")
+	} else {
+		sb.WriteString("- Embeddings: disabled\n")
+	}
+	fmt.Fprintf(&sb, "- Vector index: %s\n", report.Index)
+	stored := "unknown"
+	if report.StoredDimensions > 0 {
+		stored = fmt.Sprint(report.StoredDimensions)
+	}
+	fmt.Fprintf(&sb, "- Dimensions: %d configured, %s stored\n", report.ConfiguredDimensions, stored)
+
+	sb.WriteString("\n### Coverage\n")
+	sb.WriteString("| Type | Nodes | Embedded | Missing | Coverage |\n")
+	sb.WriteString("|------|-------|----------|---------|----------|\n")
+	for _, c := range report.Coverage {
+		fmt.Fprintf(&sb, "| %s | %d | %d | %d | %s |\n", c.NodeType, c.Nodes, c.Embedded, c.Missing(), coveragePercent(c))
+	}
+
+	sb.WriteString("\n### Problems\n")
+	if len(problems) == 0 {
+		sb.WriteString("_No problems found._\n")
+	}
+	for _, p := range problems {
+		fmt.Fprintf(&sb, "- %s\n", p)
+	}
+	return NewResult(sb.String()), nil
+}
+
+// embeddingStatusJSON is the JSON response of EmbeddingStatus.
+type embeddingStatusJSON struct {
+	*EmbeddingReport
+	Problems []string `json:"problems"`
+}
+
+// EmbeddingProblems explains what in report keeps nodes out of semantic
+// search, with how to fix it. It returns nil when nothing is wrong.
+func EmbeddingProblems(report *EmbeddingReport) []string {
+	var problems []string
+	if !report.Enabled {
+		problems = append(problems, "Embeddings are disabled, so semantic search is unavailable. Set embedding.enabled: true in the config.")
+	}
+	if report.StoredDimensions > 0 && report.StoredDimensions != report.ConfiguredDimensions {
+		problems = append(problems, fmt.Sprintf("The database stores %d-dimensional embeddings but embedding.dimensions is %d, so new embeddings cannot be stored. Set embedding.dimensions: %d and use a model of that size, or switch models with 'mie embed --migrate --model MODEL --dimensions %d'.",
+			report.StoredDimensions, report.ConfiguredDimensions, report.StoredDimensions, report.ConfiguredDimensions))
+	}
+	if len(report.MissingIndexes) > 0 {
+		problems = append(problems, fmt.Sprintf("Missing HNSW indexes: %s. Restart the server with embeddings enabled; opening the database creates them.", strings.Join(report.MissingIndexes, ", ")))
+	}
+	missing := 0
+	for _, c := range report.Coverage {
+		missing += c.Missing()
+	}
+	if missing > 0 && report.Enabled {
+		problems = append(problems, fmt.Sprintf("%d nodes have no embedding and cannot be found by semantic search. Run 'mie embed --backfill' to generate them.", missing))
+	}
+	if report.LastError != "" {
+		problems = append(problems, fmt.Sprintf("Last embedding error at %s: %s",
+			time.Unix(report.LastErrorAt, 0).UTC().Format("2006-01-02 15:04:05"), report.LastError))
+	}
+	return append(problems, report.Warnings...)
+}
+
+// coveragePercent formats the share of nodes of c that have embeddings.
+func coveragePercent(c EmbeddingCoverage) string {
+	if c.Nodes == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d%%", c.Embedded*100/c.Nodes)
+}
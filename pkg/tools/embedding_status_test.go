@@ -0,0 +1,92 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddingStatus(t *testing.T) {
+	mock := &MockQuerier{
+		GetEmbeddingStatusFunc: func(ctx context.Context) (*EmbeddingReport, error) {
+			return &EmbeddingReport{
+				Enabled:              true,
+				Provider:             "ollama",
+				Model:                "nomic-embed-text",
+				ConfiguredDimensions: 1536,
+				StoredDimensions:     768,
+				Index:                "hnsw",
+				Coverage: []EmbeddingCoverage{
+					{NodeType: "fact", Nodes: 10, Embedded: 8},
+					{NodeType: "decision", Nodes: 0, Embedded: 0},
+				},
+				LastError:   "vector length mismatch",
+				LastErrorAt: 1772323200,
+			}, nil
+		},
+	}
+
+	result, err := EmbeddingStatus(context.Background(), mock, map[string]any{})
+	if err != nil || result.IsError {
+		t.Fatalf("EmbeddingStatus() = %v, %v", result, err)
+	}
+	for _, want := range []string{
+		"- Embeddings: enabled (ollama, nomic-embed-text)",
+		"- Dimensions: 1536 configured, 768 stored",
+		"| fact | 10 | 8 | 2 | 80% |",
+		"| decision | 0 | 0 | 0 | - |",
+		"stores 768-dimensional embeddings but embedding.dimensions is 1536",
+		"2 nodes have no embedding",
+		"Last embedding error at 2026-03-01 00:00:00: vector length mismatch",
+	} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("missing %q in:\n%s", want, result.Text)
+		}
+	}
+}
+
+func TestEmbeddingStatus_Healthy(t *testing.T) {
+	mock := &MockQuerier{
+		GetEmbeddingStatusFunc: func(ctx context.Context) (*EmbeddingReport, error) {
+			return &EmbeddingReport{
+				Enabled:              true,
+				ConfiguredDimensions: 768,
+				StoredDimensions:     768,
+				Index:                "flat",
+				Coverage:             []EmbeddingCoverage{{NodeType: "fact", Nodes: 3, Embedded: 3}},
+			}, nil
+		},
+	}
+
+	result, _ := EmbeddingStatus(context.Background(), mock, map[string]any{})
+	if !strings.Contains(result.Text, "_No problems found._") {
+		t.Errorf("expected no problems in:\n%s", result.Text)
+	}
+
+	result, _ = EmbeddingStatus(context.Background(), mock, map[string]any{"response_format": "json"})
+	var out struct {
+		Index    string   `json:"index"`
+		Problems []string `json:"problems"`
+	}
+	if err := json.Unmarshal([]byte(result.Text), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result.Text)
+	}
+	if out.Index != "flat" || out.Problems == nil || len(out.Problems) != 0 {
+		t.Errorf("JSON = %+v, want flat index and no problems", out)
+	}
+}
+
+func TestEmbeddingProblems_Disabled(t *testing.T) {
+	problems := EmbeddingProblems(&EmbeddingReport{
+		ConfiguredDimensions: 768,
+		Coverage:             []EmbeddingCoverage{{NodeType: "fact", Nodes: 5}},
+	})
+	if len(problems) != 1 || !strings.Contains(problems[0], "disabled") {
+		t.Errorf("problems = %v, want only that embeddings are disabled", problems)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	yearPattern    = regexp.MustCompile(`^\d{4}$`)
+	quarterPattern = regexp.MustCompile(`^(?:(\d{4})-?[Qq]([1-4])|[Qq]([1-4])\s+(\d{4}))$`)
+)
+
+// monthLayouts are the month-precision forms ParseEventDate accepts.
+var monthLayouts = []string{"2006-01", "January 2006", "Jan 2006"}
+
+// ParseEventDate normalizes an event date and returns the first and last day
+// of the period it names, both as YYYY-MM-DD. It accepts a day (YYYY-MM-DD or
+// an RFC 3339 timestamp), a month ("2025-06", "June 2025", "Jun 2025"), a
+// quarter ("2025-Q3", "Q3 2025"), or a year ("2025").
+func ParseEventDate(s string) (start, end string, err error) {
+	s = strings.TrimSpace(s)
+	if _, err := time.Parse(time.DateOnly, s); err == nil {
+		return s, s, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		day := t.Format(time.DateOnly)
+		return day, day, nil
+	}
+	for _, layout := range monthLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return period(t, 0, 1)
+		}
+	}
+	if m := quarterPattern.FindStringSubmatch(s); m != nil {
+		year, quarter := m[1]+m[4], m[2]+m[3]
+		y, _ := strconv.Atoi(year)
+		q, _ := strconv.Atoi(quarter)
+		return period(time.Date(y, time.Month(3*q-2), 1, 0, 0, 0, 0, time.UTC), 0, 3)
+	}
+	if yearPattern.MatchString(s) {
+		y, _ := strconv.Atoi(s)
+		return period(time.Date(y, time.January, 1, 0, 0, 0, 0, time.UTC), 1, 0)
+	}
+	return "", "", fmt.Errorf("invalid event date %q: expected YYYY-MM-DD, YYYY-MM, YYYY-Qn, YYYY, or a month such as \"June 2025\"", s)
+}
+
+// period returns the first day of the period starting at t and lasting
+// years and months, and its last day.
+func period(t time.Time, years, months int) (start, end string, err error) {
+	last := t.AddDate(years, months, -1)
+	return t.Format(time.DateOnly), last.Format(time.DateOnly), nil
+}
+
+// ParseEventDates normalizes the event_date and optional end_date of an
+// event. A partial date spans its whole period, so "2025-Q3" alone runs
+// from 2025-07-01 to 2025-09-30; an end_date extends the event to the last
+// day of the period it names.
+func ParseEventDates(eventDate, endDate string) (start, end string, err error) {
+	start, end, err = ParseEventDate(eventDate)
+	if err != nil {
+		return "", "", err
+	}
+	if strings.TrimSpace(endDate) != "" {
+		if _, end, err = ParseEventDate(endDate); err != nil {
+			return "", "", fmt.Errorf("invalid end_date: %w", err)
+		}
+		if end < start {
+			return "", "", fmt.Errorf("end_date %s is before event_date %s", end, start)
+		}
+	}
+	return start, end, nil
+}
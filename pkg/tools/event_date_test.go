@@ -0,0 +1,46 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import "testing"
+
+func TestParseEventDate(t *testing.T) {
+	tests := []struct {
+		in         string
+		start, end string
+		wantErr    bool
+	}{
+		{"2025-06-15", "2025-06-15", "2025-06-15", false},
+		{"2025-06-15T10:00:00Z", "2025-06-15", "2025-06-15", false},
+		{"2024-02", "2024-02-01", "2024-02-29", false},
+		{"June 2025", "2025-06-01", "2025-06-30", false},
+		{"Dec 2025", "2025-12-01", "2025-12-31", false},
+		{"2025-Q3", "2025-07-01", "2025-09-30", false},
+		{"Q4 2025", "2025-10-01", "2025-12-31", false},
+		{"2025", "2025-01-01", "2025-12-31", false},
+		{"2025-Q5", "", "", true},
+		{"June", "", "", true},
+		{"", "", "", true},
+	}
+	for _, tt := range tests {
+		start, end, err := ParseEventDate(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseEventDate(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if start != tt.start || end != tt.end {
+			t.Errorf("ParseEventDate(%q) = %s, %s; want %s, %s", tt.in, start, end, tt.start, tt.end)
+		}
+	}
+}
+
+func TestParseEventDates(t *testing.T) {
+	if start, end, err := ParseEventDates("2025-07-01", "2025-Q3"); err != nil || start != "2025-07-01" || end != "2025-09-30" {
+		t.Errorf("ParseEventDates = %s, %s, %v", start, end, err)
+	}
+	if _, _, err := ParseEventDates("2025-07-01", "2025-06-30"); err == nil {
+		t.Error("ParseEventDates should reject an end before the start")
+	}
+}
@@ -8,23 +8,34 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 )
 
 // Export dumps the complete memory graph for backup or migration.
 func Export(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
 	format := GetStringArg(args, "format", "json")
-	if format != "json" && format != "datalog" {
-		return NewError(fmt.Sprintf("Invalid format %q. Must be json or datalog", format)), nil
+	if format != "json" && format != "datalog" && format != "cypher" {
+		return NewError(fmt.Sprintf("Invalid format %q. Must be json, datalog, or cypher", format)), nil
 	}
 
 	includeEmbeddings := GetBoolArg(args, "include_embeddings", false)
 	nodeTypes := GetStringSliceArg(args, "node_types", []string{"fact", "decision", "entity", "event", "topic"})
 
+	var since int64
+	if s := GetStringArg(args, "since", ""); s != "" {
+		t, err := ParseTimestamp(s)
+		if err != nil {
+			return NewError(fmt.Sprintf("Invalid since: %v", err)), nil
+		}
+		since = t.Unix()
+	}
+
 	data, err := client.ExportGraph(ctx, ExportOptions{
 		Format:            format,
 		IncludeEmbeddings: includeEmbeddings,
 		NodeTypes:         nodeTypes,
+		Since:             since,
 	})
 	if err != nil {
 		return NewError(fmt.Sprintf("Failed to export graph: %v", err)), nil
@@ -34,12 +45,53 @@ func Export(ctx context.Context, client Querier, args map[string]any) (*ToolResu
 	case "json":
 		return exportJSON(data)
 	case "datalog":
-		return exportDatalog(data)
+		result, err := exportDatalog(data)
+		if err != nil || !WantsJSON(args) {
+			return result, err
+		}
+		return NewJSONResult(datalogExportJSON{Format: format, ExportedAt: data.ExportedAt, Script: result.Text}), nil
+	case "cypher":
+		result := exportCypher(data)
+		if !WantsJSON(args) {
+			return result, nil
+		}
+		return NewJSONResult(datalogExportJSON{Format: format, ExportedAt: data.ExportedAt, Script: result.Text}), nil
 	default:
 		return NewError("Unsupported format"), nil
 	}
 }
 
+// exportEdge describes an edge type of an export: the columns holding its
+// endpoint IDs and the node labels of those endpoints.
+type exportEdge struct {
+	fromCol, toCol     string
+	fromLabel, toLabel string
+}
+
+// exportEdges lists the edge types ExportData can hold, keyed without the
+// "mie_" prefix. The Datalog, Cypher, and Markdown exports all read it.
+var exportEdges = map[string]exportEdge{
+	"invalidates":     {"new_fact_id", "old_fact_id", "Fact", "Fact"},
+	"fact_entity":     {"fact_id", "entity_id", "Fact", "Entity"},
+	"fact_topic":      {"fact_id", "topic_id", "Fact", "Topic"},
+	"decision_topic":  {"decision_id", "topic_id", "Decision", "Topic"},
+	"decision_entity": {"decision_id", "entity_id", "Decision", "Entity"},
+	"event_decision":  {"event_id", "decision_id", "Event", "Decision"},
+	"entity_topic":    {"entity_id", "topic_id", "Entity", "Topic"},
+}
+
+// exportNumericColumns are the edge columns written as numbers rather than
+// strings.
+var exportNumericColumns = map[string]bool{"weight": true, "created_at": true}
+
+// datalogExportJSON wraps a Datalog or Cypher export in a JSON response.
+// JSON exports are JSON already.
+type datalogExportJSON struct {
+	Format     string `json:"format"`
+	ExportedAt string `json:"exported_at"`
+	Script     string `json:"script"`
+}
+
 func exportJSON(data *ExportData) (*ToolResult, error) {
 	jsonBytes, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
@@ -59,7 +111,11 @@ func exportJSON(data *ExportData) (*ToolResult, error) {
 func exportDatalog(data *ExportData) (*ToolResult, error) {
 	var sb strings.Builder
 	sb.WriteString("// MIE Memory Export (Datalog format)\n")
-	sb.WriteString(fmt.Sprintf("// Exported: %s\n\n", data.ExportedAt))
+	sb.WriteString(fmt.Sprintf("// Exported: %s\n", data.ExportedAt))
+	if data.Since != "" {
+		sb.WriteString(fmt.Sprintf("// Changes since: %s\n", data.Since))
+	}
+	sb.WriteString("\n")
 
 	// Export facts
 	if data.Facts != nil {
@@ -106,6 +162,40 @@ func exportDatalog(data *ExportData) (*ToolResult, error) {
 		sb.WriteString("\n")
 	}
 
+	// Export relationships, endpoint columns first
+	edgeTypes := make([]string, 0, len(data.Edges))
+	for edgeType := range data.Edges {
+		edgeTypes = append(edgeTypes, edgeType)
+	}
+	sort.Strings(edgeTypes)
+	for _, edgeType := range edgeTypes {
+		edge, ok := exportEdges[edgeType]
+		if !ok {
+			sb.WriteString(fmt.Sprintf("// Skipped unknown relationship type %q\n", edgeType))
+			continue
+		}
+		for _, row := range data.Edges[edgeType] {
+			fields := []string{
+				fmt.Sprintf("%s: %q", edge.fromCol, row[edge.fromCol]),
+				fmt.Sprintf("%s: %q", edge.toCol, row[edge.toCol]),
+			}
+			for _, col := range sortedKeys(row) {
+				if col == edge.fromCol || col == edge.toCol {
+					continue
+				}
+				if exportNumericColumns[col] {
+					if row[col] != "" {
+						fields = append(fields, col+": "+row[col])
+					}
+					continue
+				}
+				fields = append(fields, fmt.Sprintf("%s: %q", col, row[col]))
+			}
+			sb.WriteString(fmt.Sprintf(":put mie_%s { %s }\n", edgeType, strings.Join(fields, ", ")))
+		}
+		sb.WriteString("\n")
+	}
+
 	output := sb.String()
 	if len(output) > 100000 {
 		output = output[:100000] + "\n\n// ... (output truncated)"
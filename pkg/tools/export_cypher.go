@@ -0,0 +1,215 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FormatCypher renders an export as Cypher statements that load the graph
+// into Neo4j or Memgraph: a CREATE per node, labeled by node type, and a
+// MATCH ... CREATE per relationship, typed by the upper-cased edge type.
+// Archived nodes get archived: true and entities carry their aliases. The
+// statements create rather than merge, so the script is meant for an empty
+// database.
+func FormatCypher(data *ExportData) string {
+	var sb strings.Builder
+	sb.WriteString("// MIE Memory Export (Cypher format)\n")
+	sb.WriteString(fmt.Sprintf("// Exported: %s\n", data.ExportedAt))
+	if data.Since != "" {
+		sb.WriteString(fmt.Sprintf("// Changes since: %s\n", data.Since))
+	}
+	sb.WriteString("// Relationships match nodes by label and id; on large graphs, index\n")
+	sb.WriteString("// :Fact(id), :Decision(id), :Entity(id), :Event(id), and :Topic(id) first.\n")
+	sb.WriteString("\n")
+
+	archived := make(map[string]bool, len(data.Archived))
+	for _, id := range data.Archived {
+		archived[id] = true
+	}
+	aliases := map[string][]string{}
+	for _, a := range data.Aliases {
+		aliases[a.EntityID] = append(aliases[a.EntityID], a.Alias)
+	}
+
+	node := func(label, id string, props ...string) {
+		if archived[id] {
+			props = append(props, "archived: true")
+		}
+		sb.WriteString(fmt.Sprintf("CREATE (:%s {id: %s, %s});\n", label, cypherString(id), strings.Join(props, ", ")))
+	}
+
+	for _, f := range data.Facts {
+		props := []string{
+			"content: " + cypherString(f.Content),
+			"category: " + cypherString(f.Category),
+			"confidence: " + cypherFloat(f.Confidence),
+			"source_agent: " + cypherString(f.SourceAgent),
+			"source_conversation: " + cypherString(f.SourceConversation),
+			"valid: " + boolToDatalog(f.Valid),
+			fmt.Sprintf("created_at: %d", f.CreatedAt),
+			fmt.Sprintf("updated_at: %d", f.UpdatedAt),
+		}
+		if f.Verified {
+			props = append(props, "verified: true", "verified_by: "+cypherString(f.VerifiedBy), fmt.Sprintf("verified_at: %d", f.VerifiedAt))
+		}
+		if f.ExpiresAt > 0 {
+			props = append(props, fmt.Sprintf("expires_at: %d", f.ExpiresAt))
+		}
+		node("Fact", f.ID, props...)
+	}
+	for _, d := range data.Decisions {
+		node("Decision", d.ID,
+			"title: "+cypherString(d.Title),
+			"rationale: "+cypherString(d.Rationale),
+			"alternatives: "+cypherString(d.Alternatives),
+			"context: "+cypherString(d.Context),
+			"source_agent: "+cypherString(d.SourceAgent),
+			"source_conversation: "+cypherString(d.SourceConversation),
+			"status: "+cypherString(d.Status),
+			fmt.Sprintf("created_at: %d", d.CreatedAt),
+			fmt.Sprintf("updated_at: %d", d.UpdatedAt))
+	}
+	for _, e := range data.Entities {
+		props := []string{
+			"name: " + cypherString(e.Name),
+			"kind: " + cypherString(e.Kind),
+			"description: " + cypherString(e.Description),
+			"source_agent: " + cypherString(e.SourceAgent),
+			fmt.Sprintf("created_at: %d", e.CreatedAt),
+			fmt.Sprintf("updated_at: %d", e.UpdatedAt),
+		}
+		if names := aliases[e.ID]; len(names) > 0 {
+			quoted := make([]string, len(names))
+			for i, name := range names {
+				quoted[i] = cypherString(name)
+			}
+			props = append(props, "aliases: ["+strings.Join(quoted, ", ")+"]")
+		}
+		node("Entity", e.ID, props...)
+	}
+	for _, ev := range data.Events {
+		props := []string{
+			"title: " + cypherString(ev.Title),
+			"description: " + cypherString(ev.Description),
+			"event_date: " + cypherString(ev.EventDate),
+			"source_agent: " + cypherString(ev.SourceAgent),
+			"source_conversation: " + cypherString(ev.SourceConversation),
+			fmt.Sprintf("created_at: %d", ev.CreatedAt),
+			fmt.Sprintf("updated_at: %d", ev.UpdatedAt),
+		}
+		if ev.EndDate != "" {
+			props = append(props, "end_date: "+cypherString(ev.EndDate))
+		}
+		node("Event", ev.ID, props...)
+	}
+	for _, t := range data.Topics {
+		node("Topic", t.ID,
+			"name: "+cypherString(t.Name),
+			"description: "+cypherString(t.Description),
+			fmt.Sprintf("created_at: %d", t.CreatedAt),
+			fmt.Sprintf("updated_at: %d", t.UpdatedAt))
+	}
+
+	edgeTypes := make([]string, 0, len(data.Edges))
+	for edgeType := range data.Edges {
+		edgeTypes = append(edgeTypes, edgeType)
+	}
+	sort.Strings(edgeTypes)
+	if len(edgeTypes) > 0 {
+		sb.WriteString("\n")
+	}
+	for _, edgeType := range edgeTypes {
+		edge, ok := exportEdges[edgeType]
+		if !ok {
+			sb.WriteString(fmt.Sprintf("// Skipped unknown relationship type %q\n", edgeType))
+			continue
+		}
+		for _, row := range data.Edges[edgeType] {
+			var props []string
+			for _, col := range sortedKeys(row) {
+				if col == edge.fromCol || col == edge.toCol {
+					continue
+				}
+				val := row[col]
+				if !exportNumericColumns[col] {
+					val = cypherString(val)
+				} else if val == "" {
+					continue
+				}
+				props = append(props, col+": "+val)
+			}
+			rel := ":" + strings.ToUpper(edgeType)
+			if len(props) > 0 {
+				rel += " {" + strings.Join(props, ", ") + "}"
+			}
+			sb.WriteString(fmt.Sprintf("MATCH (a:%s {id: %s}), (b:%s {id: %s}) CREATE (a)-[%s]->(b);\n",
+				edge.fromLabel, cypherString(row[edge.fromCol]), edge.toLabel, cypherString(row[edge.toCol]), rel))
+		}
+	}
+
+	return sb.String()
+}
+
+// exportCypher wraps FormatCypher for the mie_export tool, truncating large
+// output like the other formats.
+func exportCypher(data *ExportData) *ToolResult {
+	output := FormatCypher(data)
+	if len(output) > 100000 {
+		output = output[:100000] + "\n\n// ... (output truncated)"
+	}
+	return NewResult(output)
+}
+
+// cypherString quotes s as a single-quoted Cypher string literal.
+func cypherString(s string) string {
+	var sb strings.Builder
+	sb.Grow(len(s) + 2)
+	sb.WriteByte('\'')
+	for _, r := range s {
+		switch r {
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\'':
+			sb.WriteString(`\'`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				sb.WriteString(fmt.Sprintf(`\u%04X`, r))
+				continue
+			}
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('\'')
+	return sb.String()
+}
+
+// cypherFloat formats f as a Cypher float literal.
+func cypherFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.ContainsAny(s, ".eE") {
+		s += ".0"
+	}
+	return s
+}
+
+// sortedKeys returns the keys of m in order, so output is stable.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
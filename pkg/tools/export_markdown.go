@@ -0,0 +1,490 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// vaultPage is the note of an entity, decision, or topic in a markdown
+// vault.
+type vaultPage struct {
+	path  string // slash-separated, relative to the vault, with ".md"
+	title string
+}
+
+// vaultLink is an edge seen from one of its endpoints.
+type vaultLink struct {
+	id   string // the node at the other end
+	role string // the role of a decision_entity edge
+}
+
+// vault holds an export indexed for rendering as markdown notes.
+type vault struct {
+	data          *ExportData
+	pages         map[string]vaultPage
+	facts         map[string]*Fact
+	events        map[string]*Event
+	decisions     map[string]*Decision
+	links         map[string][]vaultLink
+	aliases       map[string][]string
+	archived      map[string]bool
+	invalidatedBy map[string]string
+}
+
+// FormatMarkdownVault renders an export as a folder of markdown notes that
+// Obsidian and similar tools open as a vault. Entities, decisions, and
+// topics get a note each under Entities/, Decisions/, and Topics/, with
+// front matter holding the node ID, type, and timestamps and, for entities,
+// the aliases. Each note lists the facts about its node and links to the
+// notes of the nodes related to it; relationships are listed on the notes
+// at both ends, so every link has its backlink. Facts.md lists all facts
+// by category, Events.md lists events by date, and index.md links to every
+// note. The files are returned keyed by slash-separated path.
+func FormatMarkdownVault(data *ExportData) map[string]string {
+	v := newVault(data)
+	files := map[string]string{
+		"index.md": v.index(),
+		"Facts.md": v.factsNote(),
+	}
+	if len(data.Events) > 0 {
+		files["Events.md"] = v.eventsNote()
+	}
+	for i := range data.Entities {
+		e := &data.Entities[i]
+		files[v.pages[e.ID].path] = v.entityNote(e)
+	}
+	for i := range data.Decisions {
+		d := &data.Decisions[i]
+		files[v.pages[d.ID].path] = v.decisionNote(d)
+	}
+	for i := range data.Topics {
+		t := &data.Topics[i]
+		files[v.pages[t.ID].path] = v.topicNote(t)
+	}
+	return files
+}
+
+func newVault(data *ExportData) *vault {
+	v := &vault{
+		data:          data,
+		pages:         map[string]vaultPage{},
+		facts:         map[string]*Fact{},
+		events:        map[string]*Event{},
+		decisions:     map[string]*Decision{},
+		links:         map[string][]vaultLink{},
+		aliases:       map[string][]string{},
+		archived:      map[string]bool{},
+		invalidatedBy: map[string]string{},
+	}
+	used := map[string]bool{}
+	addPage := func(folder, id, title string) {
+		name := vaultFileName(title)
+		if used[strings.ToLower(folder+"/"+name)] {
+			name += " (" + vaultFileName(id) + ")"
+		}
+		used[strings.ToLower(folder+"/"+name)] = true
+		v.pages[id] = vaultPage{path: folder + "/" + name + ".md", title: title}
+	}
+	for _, e := range data.Entities {
+		addPage("Entities", e.ID, e.Name)
+	}
+	for i, d := range data.Decisions {
+		addPage("Decisions", d.ID, d.Title)
+		v.decisions[d.ID] = &data.Decisions[i]
+	}
+	for _, t := range data.Topics {
+		addPage("Topics", t.ID, t.Name)
+	}
+	for i, f := range data.Facts {
+		v.facts[f.ID] = &data.Facts[i]
+	}
+	for i, ev := range data.Events {
+		v.events[ev.ID] = &data.Events[i]
+	}
+	for _, a := range data.Aliases {
+		v.aliases[a.EntityID] = append(v.aliases[a.EntityID], a.Alias)
+	}
+	for _, id := range data.Archived {
+		v.archived[id] = true
+	}
+	for edgeType, rows := range data.Edges {
+		edge, ok := exportEdges[edgeType]
+		if !ok {
+			continue
+		}
+		for _, row := range rows {
+			from, to := row[edge.fromCol], row[edge.toCol]
+			if edgeType == "invalidates" {
+				v.invalidatedBy[to] = from
+				continue
+			}
+			v.links[from] = append(v.links[from], vaultLink{id: to, role: row["role"]})
+			v.links[to] = append(v.links[to], vaultLink{id: from, role: row["role"]})
+		}
+	}
+	return v
+}
+
+// vaultFileName turns a node name into a file name that every common file
+// system and Obsidian's link syntax accept.
+func vaultFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || strings.ContainsRune(`/\:*?"<>|#^[]`, r) {
+			return '-'
+		}
+		return r
+	}, strings.Join(strings.Fields(name), " "))
+	if runes := []rune(name); len(runes) > 100 {
+		name = string(runes[:100])
+	}
+	name = strings.Trim(name, " .")
+	if name == "" {
+		name = "Untitled"
+	}
+	return name
+}
+
+// wikiLink returns an Obsidian link to the note of node id, shown as its
+// title, or "" if the node has no note.
+func (v *vault) wikiLink(id string) string {
+	page, ok := v.pages[id]
+	if !ok {
+		return ""
+	}
+	title := strings.NewReplacer("|", "-", "[", "(", "]", ")").Replace(oneLine(page.title))
+	return "[[" + strings.TrimSuffix(page.path, ".md") + "|" + title + "]]"
+}
+
+// linked returns the links of node id to nodes whose IDs start with prefix,
+// ordered by the title of their notes.
+func (v *vault) linked(id, prefix string) []vaultLink {
+	var links []vaultLink
+	for _, l := range v.links[id] {
+		if strings.HasPrefix(l.id, prefix) {
+			links = append(links, l)
+		}
+	}
+	return v.byTitle(links)
+}
+
+// byTitle sorts links by the title of the notes they point to.
+func (v *vault) byTitle(links []vaultLink) []vaultLink {
+	slices.SortFunc(links, func(a, b vaultLink) int {
+		return cmp.Or(
+			strings.Compare(strings.ToLower(v.pages[a.id].title), strings.ToLower(v.pages[b.id].title)),
+			strings.Compare(a.id, b.id),
+			strings.Compare(a.role, b.role),
+		)
+	})
+	return links
+}
+
+// factsOf returns the facts linked to node id, oldest first.
+func (v *vault) factsOf(id string) []*Fact {
+	var facts []*Fact
+	for _, l := range v.links[id] {
+		if f, ok := v.facts[l.id]; ok && !slices.Contains(facts, f) {
+			facts = append(facts, f)
+		}
+	}
+	slices.SortFunc(facts, func(a, b *Fact) int {
+		return cmp.Or(cmp.Compare(a.CreatedAt, b.CreatedAt), strings.Compare(a.ID, b.ID))
+	})
+	return facts
+}
+
+// writeFacts writes a list item per fact. Each item links to the other
+// notes the fact is about; from is the note being written.
+func (v *vault) writeFacts(sb *strings.Builder, heading string, facts []*Fact, from string) {
+	if len(facts) == 0 {
+		return
+	}
+	fmt.Fprintf(sb, "\n## %s\n\n", heading)
+	for _, f := range facts {
+		notes := []string{f.Category}
+		if !f.Valid {
+			notes = append(notes, "invalidated")
+			if newer, ok := v.facts[v.invalidatedBy[f.ID]]; ok {
+				notes = append(notes, fmt.Sprintf("replaced by %q", Truncate(oneLine(newer.Content), 80)))
+			}
+		}
+		if f.Verified {
+			notes = append(notes, "verified")
+		}
+		if v.archived[f.ID] {
+			notes = append(notes, "archived")
+		}
+		content := oneLine(f.Content)
+		if !f.Valid {
+			content = "~~" + content + "~~"
+		}
+		fmt.Fprintf(sb, "- %s _(%s)_", content, strings.Join(notes, ", "))
+		var related []string
+		for _, l := range v.links[f.ID] {
+			if link := v.wikiLink(l.id); link != "" && l.id != from && !slices.Contains(related, link) {
+				related = append(related, link)
+			}
+		}
+		slices.Sort(related)
+		if len(related) > 0 {
+			fmt.Fprintf(sb, " · %s", strings.Join(related, ", "))
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// writeLinks writes a list item linking to each node, followed by the
+// label that detail returns for it, if any.
+func (v *vault) writeLinks(sb *strings.Builder, heading string, links []vaultLink, detail func(vaultLink) string) {
+	if len(links) == 0 {
+		return
+	}
+	fmt.Fprintf(sb, "\n## %s\n\n", heading)
+	for _, l := range links {
+		sb.WriteString("- " + v.wikiLink(l.id))
+		if detail != nil {
+			if d := detail(l); d != "" {
+				fmt.Fprintf(sb, " (%s)", d)
+			}
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// decisionDetail labels a link to a decision with its status and the role
+// of the linked entity, if any.
+func (v *vault) decisionDetail(l vaultLink) string {
+	var parts []string
+	if d, ok := v.decisions[l.id]; ok {
+		parts = append(parts, d.Status)
+	}
+	if l.role != "" {
+		parts = append(parts, "role: "+l.role)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (v *vault) entityNote(e *Entity) string {
+	var sb strings.Builder
+	writeFrontMatter(&sb, e.ID, "entity", v.archived[e.ID], e.CreatedAt, e.UpdatedAt,
+		"kind", e.Kind, "source_agent", e.SourceAgent)
+	if aliases := v.aliases[e.ID]; len(aliases) > 0 {
+		sb.WriteString("aliases:\n")
+		for _, a := range aliases {
+			fmt.Fprintf(&sb, "  - %s\n", strconv.Quote(a))
+		}
+	}
+	sb.WriteString("---\n\n")
+	fmt.Fprintf(&sb, "# %s\n\n_%s_\n", oneLine(e.Name), e.Kind)
+	if e.Description != "" {
+		fmt.Fprintf(&sb, "\n%s\n", e.Description)
+	}
+	v.writeFacts(&sb, "Facts", v.factsOf(e.ID), e.ID)
+	v.writeLinks(&sb, "Decisions", v.linked(e.ID, "dec:"), v.decisionDetail)
+	v.writeLinks(&sb, "Topics", v.linked(e.ID, "top:"), nil)
+	return sb.String()
+}
+
+func (v *vault) decisionNote(d *Decision) string {
+	var sb strings.Builder
+	writeFrontMatter(&sb, d.ID, "decision", v.archived[d.ID], d.CreatedAt, d.UpdatedAt,
+		"status", d.Status, "source_agent", d.SourceAgent)
+	sb.WriteString("---\n\n")
+	fmt.Fprintf(&sb, "# %s\n\n**Status:** %s\n", oneLine(d.Title), d.Status)
+	for _, section := range [][2]string{{"Rationale", d.Rationale}, {"Alternatives", d.Alternatives}, {"Context", d.Context}} {
+		if section[1] != "" {
+			fmt.Fprintf(&sb, "\n## %s\n\n%s\n", section[0], section[1])
+		}
+	}
+	v.writeLinks(&sb, "Entities", v.linked(d.ID, "ent:"), func(l vaultLink) string {
+		if l.role == "" {
+			return ""
+		}
+		return "role: " + l.role
+	})
+	v.writeLinks(&sb, "Topics", v.linked(d.ID, "top:"), nil)
+
+	var events []*Event
+	for _, l := range v.links[d.ID] {
+		if ev, ok := v.events[l.id]; ok {
+			events = append(events, ev)
+		}
+	}
+	if len(events) > 0 {
+		sortEvents(events)
+		sb.WriteString("\n## Events\n\n")
+		for _, ev := range events {
+			fmt.Fprintf(&sb, "- %s: %s\n", eventDates(ev), oneLine(ev.Title))
+		}
+	}
+	return sb.String()
+}
+
+func (v *vault) topicNote(t *Topic) string {
+	var sb strings.Builder
+	writeFrontMatter(&sb, t.ID, "topic", v.archived[t.ID], t.CreatedAt, t.UpdatedAt)
+	sb.WriteString("---\n\n")
+	fmt.Fprintf(&sb, "# %s\n", oneLine(t.Name))
+	if t.Description != "" {
+		fmt.Fprintf(&sb, "\n%s\n", t.Description)
+	}
+	v.writeFacts(&sb, "Facts", v.factsOf(t.ID), t.ID)
+	v.writeLinks(&sb, "Decisions", v.linked(t.ID, "dec:"), v.decisionDetail)
+	v.writeLinks(&sb, "Entities", v.linked(t.ID, "ent:"), nil)
+	return sb.String()
+}
+
+func (v *vault) factsNote() string {
+	var sb strings.Builder
+	sb.WriteString("# Facts\n")
+	if len(v.data.Facts) == 0 {
+		sb.WriteString("\n_No facts._\n")
+		return sb.String()
+	}
+	byCategory := map[string][]*Fact{}
+	for _, f := range v.facts {
+		byCategory[f.Category] = append(byCategory[f.Category], f)
+	}
+	categories := make([]string, 0, len(byCategory))
+	for c := range byCategory {
+		categories = append(categories, c)
+	}
+	slices.Sort(categories)
+	for _, c := range categories {
+		facts := byCategory[c]
+		slices.SortFunc(facts, func(a, b *Fact) int {
+			return cmp.Or(cmp.Compare(a.CreatedAt, b.CreatedAt), strings.Compare(a.ID, b.ID))
+		})
+		v.writeFacts(&sb, c, facts, "")
+	}
+	return sb.String()
+}
+
+func (v *vault) eventsNote() string {
+	var sb strings.Builder
+	sb.WriteString("# Events\n\n")
+	events := make([]*Event, 0, len(v.events))
+	for _, ev := range v.events {
+		events = append(events, ev)
+	}
+	sortEvents(events)
+	for _, ev := range events {
+		fmt.Fprintf(&sb, "- %s: %s", eventDates(ev), oneLine(ev.Title))
+		var decisions []string
+		for _, l := range v.links[ev.ID] {
+			if link := v.wikiLink(l.id); link != "" {
+				decisions = append(decisions, link)
+			}
+		}
+		slices.Sort(decisions)
+		if len(decisions) > 0 {
+			fmt.Fprintf(&sb, " · %s", strings.Join(decisions, ", "))
+		}
+		sb.WriteString("\n")
+		if ev.Description != "" {
+			fmt.Fprintf(&sb, "  %s\n", oneLine(ev.Description))
+		}
+	}
+	return sb.String()
+}
+
+func (v *vault) index() string {
+	var sb strings.Builder
+	sb.WriteString("---\ntype: \"index\"\n")
+	fmt.Fprintf(&sb, "exported_at: %s\n", v.data.ExportedAt)
+	if v.data.Namespace != "" {
+		fmt.Fprintf(&sb, "namespace: %s\n", strconv.Quote(v.data.Namespace))
+	}
+	if v.data.Since != "" {
+		fmt.Fprintf(&sb, "since: %s\n", v.data.Since)
+	}
+	sb.WriteString("---\n\n# MIE Memory\n\n")
+	fmt.Fprintf(&sb, "%d facts, %d decisions, %d entities, %d events, and %d topics, exported %s.",
+		len(v.data.Facts), len(v.data.Decisions), len(v.data.Entities), len(v.data.Events), len(v.data.Topics), v.data.ExportedAt)
+	if v.data.Since != "" {
+		fmt.Fprintf(&sb, " Only changes since %s are included.", v.data.Since)
+	}
+	sb.WriteString("\n\n- [[Facts]]\n")
+	if len(v.data.Events) > 0 {
+		sb.WriteString("- [[Events]]\n")
+	}
+
+	if len(v.data.Entities) > 0 {
+		entities := slices.Clone(v.data.Entities)
+		slices.SortFunc(entities, func(a, b Entity) int {
+			return cmp.Or(strings.Compare(a.Kind, b.Kind), strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)), strings.Compare(a.ID, b.ID))
+		})
+		sb.WriteString("\n## Entities\n")
+		kind := ""
+		for i, e := range entities {
+			if i == 0 || e.Kind != kind {
+				kind = e.Kind
+				fmt.Fprintf(&sb, "\n### %s\n\n", kind)
+			}
+			fmt.Fprintf(&sb, "- %s\n", v.wikiLink(e.ID))
+		}
+	}
+	decisions := make([]vaultLink, len(v.data.Decisions))
+	for i, d := range v.data.Decisions {
+		decisions[i].id = d.ID
+	}
+	v.writeLinks(&sb, "Decisions", v.byTitle(decisions), v.decisionDetail)
+	topics := make([]vaultLink, len(v.data.Topics))
+	for i, t := range v.data.Topics {
+		topics[i].id = t.ID
+	}
+	v.writeLinks(&sb, "Topics", v.byTitle(topics), nil)
+	return sb.String()
+}
+
+// writeFrontMatter opens the YAML front matter of a note with the node's
+// ID and type, the non-empty fields given as name and value pairs, its
+// timestamps, and whether it is archived. The caller closes it.
+func writeFrontMatter(sb *strings.Builder, id, nodeType string, archived bool, createdAt, updatedAt int64, fields ...string) {
+	sb.WriteString("---\n")
+	fmt.Fprintf(sb, "id: %s\ntype: %s\n", strconv.Quote(id), strconv.Quote(nodeType))
+	for i := 0; i+1 < len(fields); i += 2 {
+		if fields[i+1] != "" {
+			fmt.Fprintf(sb, "%s: %s\n", fields[i], strconv.Quote(fields[i+1]))
+		}
+	}
+	fmt.Fprintf(sb, "created: %s\nupdated: %s\n", vaultTime(createdAt), vaultTime(updatedAt))
+	if archived {
+		sb.WriteString("archived: true\n")
+	}
+	fmt.Fprintf(sb, "tags:\n  - mie/%s\n", nodeType)
+}
+
+// vaultTime formats a Unix time for front matter.
+func vaultTime(unix int64) string {
+	return time.Unix(unix, 0).UTC().Format(time.RFC3339)
+}
+
+// oneLine joins the lines of s, so it fits in a heading or list item.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// sortEvents orders events by date, then title.
+func sortEvents(events []*Event) {
+	slices.SortFunc(events, func(a, b *Event) int {
+		return cmp.Or(strings.Compare(a.EventDate, b.EventDate), strings.Compare(a.Title, b.Title), strings.Compare(a.ID, b.ID))
+	})
+}
+
+// eventDates formats the date of an event, or its range when it has an
+// end date.
+func eventDates(ev *Event) string {
+	if ev.EndDate != "" && ev.EndDate != ev.EventDate {
+		return ev.EventDate + " to " + ev.EndDate
+	}
+	return ev.EventDate
+}
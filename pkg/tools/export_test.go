@@ -6,6 +6,7 @@ package tools
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"testing"
 )
@@ -72,6 +73,10 @@ func TestExport_Datalog(t *testing.T) {
 				Entities: []Entity{
 					{ID: "ent:abc", Name: "Kraklabs", Kind: "company", SourceAgent: "claude", CreatedAt: 1000, UpdatedAt: 1000},
 				},
+				Edges: map[string][]map[string]string{
+					"fact_entity": {{"fact_id": "fact:abc", "entity_id": "ent:abc", "weight": "0.5", "source_agent": "claude", "created_at": "1000"}},
+					"invalidates": {{"new_fact_id": "fact:new", "old_fact_id": "fact:abc", "reason": "moved", "created_at": ""}},
+				},
 			}, nil
 		},
 	}
@@ -92,6 +97,8 @@ func TestExport_Datalog(t *testing.T) {
 		":put mie_entity",
 		"Kraklabs",
 		"MIE Memory Export",
+		`:put mie_fact_entity { fact_id: "fact:abc", entity_id: "ent:abc", created_at: 1000, source_agent: "claude", weight: 0.5 }`,
+		`:put mie_invalidates { new_fact_id: "fact:new", old_fact_id: "fact:abc", reason: "moved" }`,
 	}
 	for _, check := range checks {
 		if !strings.Contains(result.Text, check) {
@@ -100,6 +107,50 @@ func TestExport_Datalog(t *testing.T) {
 	}
 }
 
+func TestExport_Cypher(t *testing.T) {
+	mock := &MockQuerier{
+		ExportGraphFunc: func(ctx context.Context, opts ExportOptions) (*ExportData, error) {
+			return &ExportData{
+				Version:    "2",
+				ExportedAt: "2026-02-05T20:30:00Z",
+				Facts: []Fact{
+					{ID: "fact:abc", Content: "User's team uses Go\nand Rust", Category: "technical", Confidence: 1, SourceAgent: "claude", Valid: true, CreatedAt: 1000, UpdatedAt: 1000},
+				},
+				Entities: []Entity{
+					{ID: "ent:abc", Name: "Kraklabs", Kind: "company", CreatedAt: 1000, UpdatedAt: 1000},
+				},
+				Edges: map[string][]map[string]string{
+					"fact_entity": {{"fact_id": "fact:abc", "entity_id": "ent:abc", "weight": "0.5", "source_agent": "claude", "created_at": "1000"}},
+				},
+				Aliases:  []EntityAlias{{Alias: "kl", EntityID: "ent:abc"}},
+				Archived: []string{"ent:abc"},
+			}, nil
+		},
+	}
+
+	result, err := Export(context.Background(), mock, map[string]any{"format": "cypher"})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Export() returned error: %s", result.Text)
+	}
+
+	checks := []string{
+		"// MIE Memory Export (Cypher format)",
+		`CREATE (:Fact {id: 'fact:abc', content: 'User\'s team uses Go\nand Rust', category: 'technical', confidence: 1.0,`,
+		"valid: true, created_at: 1000",
+		"CREATE (:Entity {id: 'ent:abc', name: 'Kraklabs'",
+		"aliases: ['kl'], archived: true});",
+		"MATCH (a:Fact {id: 'fact:abc'}), (b:Entity {id: 'ent:abc'}) CREATE (a)-[:FACT_ENTITY {created_at: 1000, source_agent: 'claude', weight: 0.5}]->(b);",
+	}
+	for _, check := range checks {
+		if !strings.Contains(result.Text, check) {
+			t.Errorf("Export() Cypher output missing %q:\n%s", check, result.Text)
+		}
+	}
+}
+
 func TestExport_DefaultFormat(t *testing.T) {
 	var capturedFormat string
 	mock := &MockQuerier{
@@ -153,4 +204,118 @@ func TestExport_IncludeEmbeddings(t *testing.T) {
 	Export(context.Background(), mock, map[string]any{
 		"include_embeddings": true,
 	})
-}
\ No newline at end of file
+}
+
+func TestExport_Since(t *testing.T) {
+	var got ExportOptions
+	mock := &MockQuerier{
+		ExportGraphFunc: func(ctx context.Context, opts ExportOptions) (*ExportData, error) {
+			got = opts
+			return &ExportData{Version: "2", ExportedAt: "2026-02-05T20:30:00Z", Since: "2026-02-01T00:00:00Z"}, nil
+		},
+	}
+
+	result, _ := Export(context.Background(), mock, map[string]any{"format": "datalog", "since": "2026-02-01"})
+	if result.IsError {
+		t.Fatalf("Export() returned error: %s", result.Text)
+	}
+	if got.Since != 1769904000 {
+		t.Errorf("Since = %d, want 1769904000", got.Since)
+	}
+	if !strings.Contains(result.Text, "// Changes since: 2026-02-01T00:00:00Z") {
+		t.Errorf("datalog export should note its start time:\n%s", result.Text)
+	}
+
+	result, _ = Export(context.Background(), mock, map[string]any{"since": "last week"})
+	if !result.IsError || !strings.Contains(result.Text, "Invalid since") {
+		t.Errorf("Export() = %q, want invalid since error", result.Text)
+	}
+}
+
+func TestFormatMarkdownVault(t *testing.T) {
+	data := &ExportData{
+		Version:    "2",
+		ExportedAt: "2026-02-05T20:30:00Z",
+		Namespace:  "default",
+		Facts: []Fact{
+			{ID: "fact:old", Content: "Billing runs on MySQL", Category: "technical", Valid: false, CreatedAt: 1000},
+			{ID: "fact:new", Content: "Billing runs on\nPostgreSQL", Category: "technical", Valid: true, Verified: true, CreatedAt: 2000},
+		},
+		Decisions: []Decision{
+			{ID: "dec:pg", Title: "Move billing to PostgreSQL", Rationale: "Better JSON support", Status: "active", CreatedAt: 1500},
+		},
+		Entities: []Entity{
+			{ID: "ent:pg", Name: "PostgreSQL", Kind: "technology", Description: "Relational database", CreatedAt: 1000},
+			{ID: "ent:pg2", Name: "postgresql", Kind: "technology", CreatedAt: 1000},
+			{ID: "ent:ci", Name: "CI/CD", Kind: "technology", CreatedAt: 1000},
+		},
+		Events: []Event{
+			{ID: "evt:cut", Title: "Billing cutover", EventDate: "2026-03-01", CreatedAt: 1000},
+		},
+		Topics: []Topic{
+			{ID: "top:db", Name: "databases", CreatedAt: 1000},
+		},
+		Edges: map[string][]map[string]string{
+			"fact_entity":     {{"fact_id": "fact:new", "entity_id": "ent:pg"}, {"fact_id": "fact:old", "entity_id": "ent:pg"}},
+			"fact_topic":      {{"fact_id": "fact:new", "topic_id": "top:db"}},
+			"decision_entity": {{"decision_id": "dec:pg", "entity_id": "ent:pg", "role": "chosen"}},
+			"event_decision":  {{"event_id": "evt:cut", "decision_id": "dec:pg"}},
+			"invalidates":     {{"new_fact_id": "fact:new", "old_fact_id": "fact:old", "reason": "migrated"}},
+		},
+		Aliases:  []EntityAlias{{Alias: "Postgres", EntityID: "ent:pg"}},
+		Archived: []string{"ent:ci"},
+	}
+
+	files := FormatMarkdownVault(data)
+	var paths []string
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	want := []string{
+		"Decisions/Move billing to PostgreSQL.md",
+		"Entities/CI-CD.md",
+		"Entities/PostgreSQL.md",
+		"Entities/postgresql (ent-pg2).md",
+		"Events.md",
+		"Facts.md",
+		"Topics/databases.md",
+		"index.md",
+	}
+	if strings.Join(paths, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("FormatMarkdownVault() files = %q, want %q", paths, want)
+	}
+
+	checks := map[string][]string{
+		"Entities/PostgreSQL.md": {
+			"---\nid: \"ent:pg\"\ntype: \"entity\"\nkind: \"technology\"\ncreated: 1970-01-01T00:16:40Z\n",
+			"tags:\n  - mie/entity\naliases:\n  - \"Postgres\"\n---\n\n# PostgreSQL\n\n_technology_\n\nRelational database\n",
+			`- ~~Billing runs on MySQL~~ _(technical, invalidated, replaced by "Billing runs on PostgreSQL")_` + "\n",
+			"- Billing runs on PostgreSQL _(technical, verified)_ · [[Topics/databases|databases]]\n",
+			"## Decisions\n\n- [[Decisions/Move billing to PostgreSQL|Move billing to PostgreSQL]] (active, role: chosen)\n",
+		},
+		"Decisions/Move billing to PostgreSQL.md": {
+			"**Status:** active\n\n## Rationale\n\nBetter JSON support\n",
+			"## Entities\n\n- [[Entities/PostgreSQL|PostgreSQL]] (role: chosen)\n",
+			"## Events\n\n- 2026-03-01: Billing cutover\n",
+		},
+		"Topics/databases.md": {
+			"- Billing runs on PostgreSQL _(technical, verified)_ · [[Entities/PostgreSQL|PostgreSQL]]\n",
+		},
+		"Entities/CI-CD.md": {"archived: true\n", "# CI/CD\n"},
+		"Events.md":         {"- 2026-03-01: Billing cutover · [[Decisions/Move billing to PostgreSQL|Move billing to PostgreSQL]]\n"},
+		"index.md": {
+			"namespace: \"default\"\n",
+			"2 facts, 1 decisions, 3 entities, 1 events, and 1 topics",
+			"### technology\n\n- [[Entities/CI-CD|CI/CD]]\n- [[Entities/PostgreSQL|PostgreSQL]]\n- [[Entities/postgresql (ent-pg2)|postgresql]]\n",
+			"## Topics\n\n- [[Topics/databases|databases]]\n",
+		},
+	}
+	for path, substrings := range checks {
+		for _, s := range substrings {
+			if !strings.Contains(files[path], s) {
+				t.Errorf("%s missing %q:\n%s", path, s, files[path])
+			}
+		}
+	}
+}
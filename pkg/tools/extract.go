@@ -0,0 +1,196 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// maxAnalyzeCandidates caps the candidates Analyze proposes for one call.
+const maxAnalyzeCandidates = 20
+
+// duplicateHintSimilarity is the similarity at or above which a candidate
+// is flagged as a likely duplicate of an existing node.
+const duplicateHintSimilarity = 0.9
+
+// minCandidateWords skips fragments too short to stand on their own, such
+// as "Sounds good." or "Thanks!".
+const minCandidateWords = 4
+
+var (
+	sentenceEnd     = regexp.MustCompile(`[.!?]+(?:\s+|$)|\n+`)
+	decisionPattern = regexp.MustCompile(`(?i)\b(?:decided|decide to|chose|chosen|opted|agreed to|settled on|went with|going with|switched to|will use|moving to|migrate to)\b`)
+	rationaleCue    = regexp.MustCompile(`(?i)\b(?:because|since|so that|as it|due to)\b`)
+	isoDatePattern  = regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}\b`)
+)
+
+// factCategoryCues guesses a fact category from words in the sentence. The
+// first category with a matching cue wins.
+var factCategoryCues = []struct {
+	category string
+	cue      *regexp.Regexp
+}{
+	{"preference", regexp.MustCompile(`(?i)\b(?:prefers?|preferred|likes?|loves?|hates?|dislikes?|favou?rite|rather)\b`)},
+	{"relationship", regexp.MustCompile(`(?i)\b(?:friend|colleague|manager|boss|wife|husband|partner|sister|brother|mother|father|reports to|works with)\b`)},
+	{"professional", regexp.MustCompile(`(?i)\b(?:works? (?:at|for|as)|job|role|team|company|employer|hired|promoted|career)\b`)},
+	{"technical", regexp.MustCompile(`(?i)\b(?:uses?|database|api|server|deploy\w*|framework|library|language|runtime|version|kubernetes|docker|cloud|code|repo\w*)\b`)},
+	{"personal", regexp.MustCompile(`(?i)\b(?:i am|i'm|my|lives? in|born|hobby|hobbies|family)\b`)},
+}
+
+// analyzeCandidate is a node Analyze proposes to store. Item holds the
+// mie_store arguments, including type and relationships to existing nodes,
+// so it can be passed to mie_bulk_store as one of its items.
+type analyzeCandidate struct {
+	Item map[string]any `json:"item"`
+	// Source is the sentence the candidate was extracted from.
+	Source string `json:"source"`
+	// DuplicateOf is the ID of an existing node that says much the same.
+	DuplicateOf         string  `json:"duplicate_of,omitempty"`
+	DuplicateSimilarity float64 `json:"duplicate_similarity,omitempty"`
+}
+
+// extractCandidates splits content into sentences and proposes a fact,
+// decision, or event for each one worth storing. A content type of decision
+// or event makes the whole content one candidate of that type. Candidates
+// are linked to existing entities and topics they name, and flagged as
+// duplicates of existing nodes that are nearly identical; failed lookups
+// only leave those hints out.
+func extractCandidates(ctx context.Context, client Querier, content, contentType string) []analyzeCandidate {
+	var candidates []analyzeCandidate
+	switch contentType {
+	case "decision", "event":
+		text := strings.TrimSpace(content)
+		candidates = append(candidates, newCandidate(contentType, text))
+	default:
+		for _, sentence := range splitSentences(content) {
+			if len(strings.Fields(sentence)) < minCandidateWords || strings.HasSuffix(sentence, "?") {
+				continue
+			}
+			nodeType := "fact"
+			switch {
+			case decisionPattern.MatchString(sentence):
+				nodeType = "decision"
+			case isoDatePattern.MatchString(sentence):
+				nodeType = "event"
+			}
+			candidates = append(candidates, newCandidate(nodeType, sentence))
+			if len(candidates) == maxAnalyzeCandidates {
+				break
+			}
+		}
+	}
+
+	linkExistingNodes(ctx, client, candidates)
+	if client.EmbeddingsEnabled() {
+		for i := range candidates {
+			c := &candidates[i]
+			nodeType := GetStringArg(c.Item, "type", "")
+			results, err := client.SemanticSearch(ctx, c.Source, []string{nodeType}, 1)
+			if err != nil || len(results) == 0 {
+				continue
+			}
+			if similarity := 1 - results[0].Distance; similarity >= duplicateHintSimilarity {
+				c.DuplicateOf = results[0].ID
+				c.DuplicateSimilarity = similarity
+			}
+		}
+	}
+	return candidates
+}
+
+// newCandidate proposes a node of nodeType whose fields are taken from text.
+func newCandidate(nodeType, text string) analyzeCandidate {
+	item := map[string]any{"type": nodeType}
+	switch nodeType {
+	case "decision":
+		item["title"] = Truncate(text, 100)
+		item["rationale"] = text
+		if loc := rationaleCue.FindStringIndex(text); loc != nil {
+			if why := strings.TrimSpace(text[loc[1]:]); why != "" {
+				item["rationale"] = why
+			}
+		}
+	case "event":
+		item["title"] = Truncate(text, 100)
+		item["description"] = text
+		if date := isoDatePattern.FindString(text); date != "" {
+			item["event_date"] = date
+		}
+	default:
+		item["content"] = text
+		item["category"] = "general"
+		for _, c := range factCategoryCues {
+			if c.cue.MatchString(text) {
+				item["category"] = c.category
+				break
+			}
+		}
+	}
+	return analyzeCandidate{Item: item, Source: text}
+}
+
+// linkExistingNodes adds relationships from each candidate to the existing
+// entities and topics its source sentence names.
+func linkExistingNodes(ctx context.Context, client Querier, candidates []analyzeCandidate) {
+	if len(candidates) == 0 {
+		return
+	}
+	for _, t := range []string{"entity", "topic"} {
+		nodes, _, err := client.ListNodes(ctx, ListOptions{NodeType: t, Limit: maxNameCandidates})
+		if err != nil {
+			continue
+		}
+		for _, n := range nodes {
+			var id, name string
+			switch n := n.(type) {
+			case *Entity:
+				id, name = n.ID, n.Name
+			case *Topic:
+				id, name = n.ID, n.Name
+			}
+			if id == "" {
+				continue
+			}
+			for i := range candidates {
+				c := &candidates[i]
+				nodeType := GetStringArg(c.Item, "type", "")
+				edge, ok := partnerEdges(nodeTypePrefixes[nodeType])[t]
+				// Relationships in mie_store run from the stored node.
+				if !ok || edgeEndpoints[edge][0] != nodeTypePrefixes[nodeType] || !mentionsName(c.Source, name) {
+					continue
+				}
+				rels, _ := c.Item["relationships"].([]any)
+				c.Item["relationships"] = append(rels, map[string]any{"edge": edge, "target_id": id})
+			}
+		}
+	}
+}
+
+// splitSentences splits text at sentence punctuation and line breaks,
+// dropping list markers and empty pieces.
+func splitSentences(text string) []string {
+	var sentences []string
+	start := 0
+	for _, loc := range sentenceEnd.FindAllStringIndex(text, -1) {
+		// Keep the punctuation, so questions can be told apart.
+		end := loc[0] + len(strings.TrimRight(text[loc[0]:loc[1]], " \t\r\n"))
+		sentences = appendSentence(sentences, text[start:end])
+		start = loc[1]
+	}
+	return appendSentence(sentences, text[start:])
+}
+
+// appendSentence appends s to sentences after trimming spaces and a leading
+// list marker, unless nothing is left.
+func appendSentence(sentences []string, s string) []string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSpace(strings.TrimLeft(s, "-*•"))
+	if s == "" {
+		return sentences
+	}
+	return append(sentences, s)
+}
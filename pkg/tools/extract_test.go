@@ -0,0 +1,79 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestSplitSentences(t *testing.T) {
+	got := splitSentences("We use Go 1.22 at work. Is that right?\n- Deployed on 2026-03-01!\n\n")
+	want := []string{"We use Go 1.22 at work.", "Is that right?", "Deployed on 2026-03-01!"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("splitSentences = %q, want %q", got, want)
+	}
+}
+
+func TestExtractCandidates(t *testing.T) {
+	mock := &MockQuerier{
+		ListNodesFunc: func(ctx context.Context, opts ListOptions) ([]any, int, error) {
+			if opts.NodeType == "entity" {
+				return []any{&Entity{ID: "ent:pg", Name: "PostgreSQL"}}, 1, nil
+			}
+			return []any{&Topic{ID: "top:db", Name: "databases"}}, 1, nil
+		},
+		EmbeddingsEnabledFunc: func() bool { return true },
+		SemanticSearchFunc: func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
+			if nodeTypes[0] == "fact" {
+				return []SearchResult{{ID: "fact:old", NodeType: "fact", Distance: 0.05}}, nil
+			}
+			return []SearchResult{{ID: "dec:far", NodeType: "decision", Distance: 0.4}}, nil
+		},
+	}
+
+	content := "The user prefers PostgreSQL for databases. We decided to drop MongoDB because it lacked joins. " +
+		"Released v2 on 2026-03-01. Thanks! Should we migrate?"
+	candidates := extractCandidates(context.Background(), mock, content, "conversation")
+	if len(candidates) != 3 {
+		t.Fatalf("candidates = %+v, want 3", candidates)
+	}
+
+	fact := candidates[0]
+	if fact.Item["type"] != "fact" || fact.Item["category"] != "preference" {
+		t.Errorf("fact item = %v, want a preference fact", fact.Item)
+	}
+	wantRels := []any{
+		map[string]any{"edge": "fact_entity", "target_id": "ent:pg"},
+		map[string]any{"edge": "fact_topic", "target_id": "top:db"},
+	}
+	if !reflect.DeepEqual(fact.Item["relationships"], wantRels) {
+		t.Errorf("fact relationships = %v, want %v", fact.Item["relationships"], wantRels)
+	}
+	if fact.DuplicateOf != "fact:old" {
+		t.Errorf("fact DuplicateOf = %q, want fact:old", fact.DuplicateOf)
+	}
+
+	dec := candidates[1]
+	if dec.Item["type"] != "decision" || dec.Item["rationale"] != "it lacked joins." || dec.DuplicateOf != "" {
+		t.Errorf("decision candidate = %+v", dec)
+	}
+
+	evt := candidates[2]
+	if evt.Item["type"] != "event" || evt.Item["event_date"] != "2026-03-01" {
+		t.Errorf("event item = %v", evt.Item)
+	}
+	if _, ok := evt.Item["relationships"]; ok {
+		t.Errorf("event should not link to entities or topics: %v", evt.Item)
+	}
+}
+
+func TestExtractCandidates_ContentType(t *testing.T) {
+	candidates := extractCandidates(context.Background(), &MockQuerier{}, "Use Bun. It starts fast.", "decision")
+	if len(candidates) != 1 || candidates[0].Item["type"] != "decision" || candidates[0].Item["title"] != "Use Bun. It starts fast." {
+		t.Errorf("candidates = %+v, want the whole content as one decision", candidates)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Response formats accepted by the "response_format" tool argument.
+const (
+	FormatMarkdown = "markdown"
+	FormatJSON     = "json"
+)
+
+// ValidateResponseFormat checks the optional "response_format" argument,
+// which must be "markdown" (the default) or "json".
+func ValidateResponseFormat(args map[string]any) error {
+	v, ok := args["response_format"]
+	if !ok || v == nil {
+		return nil
+	}
+	switch s, _ := v.(string); s {
+	case FormatMarkdown, FormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid response_format %v: must be %q or %q", v, FormatMarkdown, FormatJSON)
+	}
+}
+
+// WantsJSON reports whether args ask for a JSON response.
+func WantsJSON(args map[string]any) bool {
+	return GetStringArg(args, "response_format", FormatMarkdown) == FormatJSON
+}
+
+// NewJSONResult creates a successful tool result whose text is v encoded
+// as indented JSON.
+func NewJSONResult(v any) *ToolResult {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return NewError(fmt.Sprintf("Failed to encode JSON response: %v", err))
+	}
+	return NewResult(string(data) + "\n")
+}
+
+// NodeJSON is a node returned by GetNodeByID, tagged with its type, as it
+// appears in JSON responses.
+type NodeJSON struct {
+	Type string `json:"type"`
+	Node any    `json:"node"`
+}
+
+// newNodeJSON tags node with its type.
+func newNodeJSON(node any) NodeJSON {
+	nodeType, _ := nodeLabel(node)
+	return NodeJSON{Type: nodeType, Node: node}
+}
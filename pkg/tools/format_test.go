@@ -0,0 +1,49 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestValidateResponseFormat(t *testing.T) {
+	tests := []struct {
+		args    map[string]any
+		wantErr bool
+	}{
+		{map[string]any{}, false},
+		{map[string]any{"response_format": "markdown"}, false},
+		{map[string]any{"response_format": "json"}, false},
+		{map[string]any{"response_format": "yaml"}, true},
+		{map[string]any{"response_format": 1.0}, true},
+	}
+	for _, tt := range tests {
+		if err := ValidateResponseFormat(tt.args); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateResponseFormat(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+		}
+	}
+}
+
+func TestNewJSONResult(t *testing.T) {
+	result := NewJSONResult(newNodeJSON(&Fact{ID: "fact:1", Content: "Go is fast"}))
+	if result.IsError {
+		t.Fatalf("NewJSONResult() returned error: %s", result.Text)
+	}
+	var got struct {
+		Type string `json:"type"`
+		Node Fact   `json:"node"`
+	}
+	if err := json.Unmarshal([]byte(result.Text), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, result.Text)
+	}
+	if got.Type != "fact" || got.Node.ID != "fact:1" {
+		t.Errorf("NewJSONResult() = %+v", got)
+	}
+
+	if result := NewJSONResult(func() {}); !result.IsError {
+		t.Error("NewJSONResult() should fail on values JSON cannot encode")
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import "sort"
+
+// DefaultRRFK is the standard reciprocal-rank fusion constant. Larger values
+// flatten the advantage of top-ranked results.
+const DefaultRRFK = 60
+
+// RankedList is one ranked result list fed into FuseRanked.
+type RankedList struct {
+	Source  string
+	Results []SearchResult
+}
+
+// FuseRanked merges ranked result lists with reciprocal-rank fusion and
+// deduplicates them by node ID. Each result scores 1/(k+rank) per list it
+// appears in, where rank starts at 1 and is counted within its node type so
+// that lists grouped by type do not favour the first type. The first list
+// containing a node supplies its content and distance; later lists only add
+// to its score and MatchedBy.
+func FuseRanked(k int, lists ...RankedList) []SearchResult {
+	if k <= 0 {
+		k = DefaultRRFK
+	}
+
+	fused := map[string]*SearchResult{}
+	var order []string
+
+	for _, list := range lists {
+		typeRank := map[string]int{}
+		seen := map[string]bool{}
+		for _, r := range list.Results {
+			if seen[r.ID] {
+				continue
+			}
+			seen[r.ID] = true
+			typeRank[r.NodeType]++
+			score := 1.0 / float64(k+typeRank[r.NodeType])
+
+			existing, ok := fused[r.ID]
+			if !ok {
+				merged := r
+				merged.Score = 0
+				merged.MatchedBy = nil
+				fused[r.ID] = &merged
+				order = append(order, r.ID)
+				existing = &merged
+			}
+			existing.Score += score
+			existing.MatchedBy = append(existing.MatchedBy, list.Source)
+		}
+	}
+
+	results := make([]SearchResult, 0, len(order))
+	for _, id := range order {
+		results = append(results, *fused[id])
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results
+}
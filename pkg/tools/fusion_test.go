@@ -0,0 +1,74 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import "testing"
+
+func TestFuseRanked_Deduplicates(t *testing.T) {
+	semantic := []SearchResult{
+		{NodeType: "fact", ID: "fact:a", Content: "A", Distance: 0.1},
+		{NodeType: "fact", ID: "fact:b", Content: "B", Distance: 0.2},
+	}
+	exact := []SearchResult{
+		{NodeType: "fact", ID: "fact:b", Content: "B"},
+		{NodeType: "fact", ID: "fact:c", Content: "C"},
+	}
+
+	results := FuseRanked(60,
+		RankedList{Source: "semantic", Results: semantic},
+		RankedList{Source: "exact", Results: exact},
+	)
+	if len(results) != 3 {
+		t.Fatalf("FuseRanked() returned %d results, want 3", len(results))
+	}
+
+	// fact:b appears in both lists and must rank first.
+	if results[0].ID != "fact:b" {
+		t.Errorf("results[0].ID = %s, want fact:b", results[0].ID)
+	}
+	if len(results[0].MatchedBy) != 2 {
+		t.Errorf("fact:b MatchedBy = %v, want both sources", results[0].MatchedBy)
+	}
+	// The first list supplies the distance.
+	if results[0].Distance != 0.2 {
+		t.Errorf("fact:b Distance = %f, want 0.2 from semantic list", results[0].Distance)
+	}
+	want := 1.0/62 + 1.0/61
+	if diff := results[0].Score - want; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("fact:b Score = %f, want %f", results[0].Score, want)
+	}
+}
+
+func TestFuseRanked_RanksWithinNodeType(t *testing.T) {
+	exact := []SearchResult{
+		{NodeType: "fact", ID: "fact:a"},
+		{NodeType: "fact", ID: "fact:b"},
+		{NodeType: "entity", ID: "ent:a"},
+	}
+	results := FuseRanked(60, RankedList{Source: "exact", Results: exact})
+	scores := map[string]float64{}
+	for _, r := range results {
+		scores[r.ID] = r.Score
+	}
+	if scores["ent:a"] != scores["fact:a"] {
+		t.Errorf("first entity and first fact should score equally, got %f and %f", scores["ent:a"], scores["fact:a"])
+	}
+	if scores["fact:b"] >= scores["fact:a"] {
+		t.Error("second fact should score lower than the first")
+	}
+}
+
+func TestFuseRanked_DefaultK(t *testing.T) {
+	results := FuseRanked(0, RankedList{Source: "exact", Results: []SearchResult{{NodeType: "fact", ID: "fact:a"}}})
+	if len(results) != 1 || results[0].Score != 1.0/float64(DefaultRRFK+1) {
+		t.Errorf("FuseRanked(0) should use DefaultRRFK, got %+v", results)
+	}
+}
+
+func TestFuseRanked_Empty(t *testing.T) {
+	if results := FuseRanked(60); len(results) != 0 {
+		t.Errorf("FuseRanked() with no lists = %v, want empty", results)
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"fmt"
+	"strings"
+)
+
+// similarFactOverlap is the share of words two facts of the same category
+// must have in common to be reported as a conflict when their content
+// differs.
+const similarFactOverlap = 0.6
+
+// GraphMerge is the result of MergeExports.
+type GraphMerge struct {
+	// Data holds the nodes and edges to write into the local graph, in its
+	// namespace and with matched nodes under their local IDs.
+	Data *ExportData `json:"-"`
+	// Added, Updated, and Unchanged count incoming nodes per kind, keyed
+	// like ExportData.Stats: nodes new to the local graph, nodes whose
+	// incoming version is newer, and nodes whose local version is kept.
+	Added     map[string]int `json:"added"`
+	Updated   map[string]int `json:"updated"`
+	Unchanged map[string]int `json:"unchanged"`
+	// Relationships counts the incoming edges, all of which are written.
+	Relationships int `json:"relationships"`
+	// Matched lists incoming nodes matched to a local node with another ID
+	// by their content or name.
+	Matched []GraphMergeMatch `json:"matched"`
+	// Conflicts lists node pairs to resolve by hand.
+	Conflicts []GraphMergeConflict `json:"conflicts"`
+
+	ids map[string]string // incoming ID -> ID in the merged graph
+}
+
+// GraphMergeMatch is an incoming node matched to a local node by content or
+// name.
+type GraphMergeMatch struct {
+	IncomingID string `json:"incoming_id"`
+	LocalID    string `json:"local_id"`
+}
+
+// GraphMergeConflict is a pair of nodes MergeExports could not reconcile
+// on its own.
+type GraphMergeConflict struct {
+	LocalID    string `json:"local_id"`
+	IncomingID string `json:"incoming_id"`
+	Reason     string `json:"reason"`
+}
+
+// mergeSpec describes how MergeExports reconciles one kind of node.
+type mergeSpec[T any] struct {
+	kind string
+	// fields returns the ID and timestamps of a node.
+	fields func(n *T) (id *string, created, updated *int64)
+	// key is the normalized content or name that identifies a node across
+	// graphs, in addition to its ID.
+	key func(n T) string
+	// differ returns why two matched versions need a look, or "".
+	differ func(local, incoming T) string
+	// similar returns why an unmatched incoming node may duplicate or
+	// contradict a local one, or "".
+	similar func(local, incoming T) string
+}
+
+// MergeExports merges incoming, typically an export of the same memory kept
+// on another machine, into local, a full export of the graph to merge into.
+// Nodes match by ID, or else by normalized content (facts), title
+// (decisions), title and date (events), or name (entities, with their
+// kind, and topics). The newer version of a matched node, by updated_at,
+// wins; edges, aliases, and archive marks are unioned. Matched versions that
+// disagree on fact validity, decision status, or entity kind, and unmatched
+// facts that resemble a local one, are reported as conflicts. Incoming IDs
+// are expected to be in local's namespace.
+func MergeExports(local, incoming *ExportData) *GraphMerge {
+	m := &GraphMerge{
+		Added:     map[string]int{},
+		Updated:   map[string]int{},
+		Unchanged: map[string]int{},
+		Matched:   []GraphMergeMatch{},
+		Conflicts: []GraphMergeConflict{},
+		ids:       map[string]string{},
+	}
+	data := &ExportData{Version: incoming.Version, ExportedAt: incoming.ExportedAt, Namespace: local.Namespace}
+
+	data.Facts = mergeNodes(m, local.Facts, incoming.Facts, mergeSpec[Fact]{
+		kind:   "facts",
+		fields: func(f *Fact) (*string, *int64, *int64) { return &f.ID, &f.CreatedAt, &f.UpdatedAt },
+		key:    func(f Fact) string { return normalizeMergeKey(f.Content) },
+		differ: func(l, in Fact) string {
+			if l.Valid != in.Valid {
+				return "valid on one machine and invalidated on the other"
+			}
+			return ""
+		},
+		similar: func(l, in Fact) string {
+			if l.Category != in.Category || wordOverlap(l.Content, in.Content) < similarFactOverlap {
+				return ""
+			}
+			return fmt.Sprintf("similar facts with different content: %q vs %q", Truncate(l.Content, 60), Truncate(in.Content, 60))
+		},
+	})
+	data.Decisions = mergeNodes(m, local.Decisions, incoming.Decisions, mergeSpec[Decision]{
+		kind:   "decisions",
+		fields: func(d *Decision) (*string, *int64, *int64) { return &d.ID, &d.CreatedAt, &d.UpdatedAt },
+		key:    func(d Decision) string { return normalizeMergeKey(d.Title) },
+		differ: func(l, in Decision) string {
+			if l.Status != in.Status {
+				return fmt.Sprintf("status %s here, %s on the other machine", l.Status, in.Status)
+			}
+			return ""
+		},
+	})
+	data.Entities = mergeNodes(m, local.Entities, incoming.Entities, mergeSpec[Entity]{
+		kind:   "entities",
+		fields: func(e *Entity) (*string, *int64, *int64) { return &e.ID, &e.CreatedAt, &e.UpdatedAt },
+		key:    func(e Entity) string { return normalizeMergeKey(e.Name) + "\x00" + e.Kind },
+		similar: func(l, in Entity) string {
+			if normalizeMergeKey(l.Name) != normalizeMergeKey(in.Name) {
+				return ""
+			}
+			return fmt.Sprintf("entity %q is a %s here and a %s on the other machine", l.Name, l.Kind, in.Kind)
+		},
+	})
+	data.Events = mergeNodes(m, local.Events, incoming.Events, mergeSpec[Event]{
+		kind:   "events",
+		fields: func(e *Event) (*string, *int64, *int64) { return &e.ID, &e.CreatedAt, &e.UpdatedAt },
+		key:    func(e Event) string { return normalizeMergeKey(e.Title) + "\x00" + e.EventDate },
+	})
+	data.Topics = mergeNodes(m, local.Topics, incoming.Topics, mergeSpec[Topic]{
+		kind:   "topics",
+		fields: func(t *Topic) (*string, *int64, *int64) { return &t.ID, &t.CreatedAt, &t.UpdatedAt },
+		key:    func(t Topic) string { return normalizeMergeKey(t.Name) },
+	})
+
+	if len(incoming.Edges) > 0 {
+		data.Edges = make(map[string][]map[string]string, len(incoming.Edges))
+	}
+	for edgeType, rows := range incoming.Edges {
+		for _, row := range rows {
+			merged := make(map[string]string, len(row))
+			for col, val := range row {
+				merged[col] = m.id(val)
+			}
+			data.Edges[edgeType] = append(data.Edges[edgeType], merged)
+			m.Relationships++
+		}
+	}
+	for _, a := range incoming.Aliases {
+		data.Aliases = append(data.Aliases, EntityAlias{Alias: a.Alias, EntityID: m.id(a.EntityID)})
+	}
+	for _, id := range incoming.Archived {
+		data.Archived = append(data.Archived, m.id(id))
+	}
+
+	m.Data = data
+	return m
+}
+
+// mergeNodes returns the incoming nodes to write: new ones, and matched
+// ones that are newer than their local version, under the local ID.
+func mergeNodes[T any](m *GraphMerge, local, incoming []T, spec mergeSpec[T]) []T {
+	byID := make(map[string]int, len(local))
+	byKey := make(map[string]int, len(local))
+	for i := range local {
+		id, _, _ := spec.fields(&local[i])
+		byID[*id] = i
+		if k := spec.key(local[i]); k != "" {
+			if _, ok := byKey[k]; !ok {
+				byKey[k] = i
+			}
+		}
+	}
+
+	var out []T
+	for _, n := range incoming {
+		id, created, updated := spec.fields(&n)
+		i, ok := byID[*id]
+		if !ok {
+			if k := spec.key(n); k != "" {
+				i, ok = byKey[k]
+			}
+		}
+		if !ok {
+			m.ids[*id] = *id
+			m.Added[spec.kind]++
+			if spec.similar != nil {
+				for _, l := range local {
+					if reason := spec.similar(l, n); reason != "" {
+						lid, _, _ := spec.fields(&l)
+						m.Conflicts = append(m.Conflicts, GraphMergeConflict{LocalID: *lid, IncomingID: *id, Reason: reason})
+						break
+					}
+				}
+			}
+			out = append(out, n)
+			continue
+		}
+
+		l := local[i]
+		lid, lcreated, lupdated := spec.fields(&l)
+		m.ids[*id] = *lid
+		if *id != *lid {
+			m.Matched = append(m.Matched, GraphMergeMatch{IncomingID: *id, LocalID: *lid})
+		}
+		if spec.differ != nil {
+			if reason := spec.differ(l, n); reason != "" {
+				m.Conflicts = append(m.Conflicts, GraphMergeConflict{LocalID: *lid, IncomingID: *id, Reason: reason})
+			}
+		}
+		if *updated <= *lupdated {
+			m.Unchanged[spec.kind]++
+			continue
+		}
+		*id = *lid
+		if *lcreated > 0 && *lcreated < *created {
+			*created = *lcreated
+		}
+		m.Updated[spec.kind]++
+		out = append(out, n)
+	}
+	return out
+}
+
+// id returns the ID in the merged graph of an incoming node ID, or id
+// itself if it names no incoming node.
+func (m *GraphMerge) id(id string) string {
+	if merged, ok := m.ids[id]; ok {
+		return merged
+	}
+	return id
+}
+
+// normalizeMergeKey lowercases s and collapses its whitespace.
+func normalizeMergeKey(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+// wordOverlap returns the Jaccard similarity of the word sets of a and b.
+func wordOverlap(a, b string) float64 {
+	wa, wb := WordSet(a), WordSet(b)
+	if len(wa) == 0 || len(wb) == 0 {
+		return 0
+	}
+	shared := SharedWords(wa, wb)
+	return float64(shared) / float64(len(wa)+len(wb)-shared)
+}
+
+// ImportDiff is the result of DiffImport.
+type ImportDiff struct {
+	// New, Updated, and Duplicates count incoming nodes per kind, keyed
+	// like ExportData.Stats: nodes whose ID the local graph lacks, which
+	// an import creates, and nodes whose ID it holds, which an import
+	// rewrites in place. Duplicates are the new nodes that match a local
+	// node with another ID by content or name, as in MergeExports; an
+	// import stores them again next to the local node.
+	New        map[string]int `json:"new"`
+	Updated    map[string]int `json:"updated"`
+	Duplicates map[string]int `json:"duplicates"`
+	// Conflicts lists node pairs MergeExports would report as conflicts.
+	Conflicts []GraphMergeConflict `json:"conflicts"`
+}
+
+// DiffImport reports what importing incoming, with its node IDs kept, would
+// change in local, a full export of the graph to import into. Incoming IDs
+// are expected to be in local's namespace.
+func DiffImport(local, incoming *ExportData) *ImportDiff {
+	merge := MergeExports(local, incoming)
+	matched := make(map[string]bool, len(merge.Matched))
+	for _, m := range merge.Matched {
+		matched[m.IncomingID] = true
+	}
+	d := &ImportDiff{
+		New:        map[string]int{},
+		Updated:    map[string]int{},
+		Duplicates: map[string]int{},
+		Conflicts:  merge.Conflicts,
+	}
+	localIDs := exportNodeIDs(local)
+	for kind, ids := range exportNodeIDs(incoming) {
+		for id := range ids {
+			switch {
+			case localIDs[kind][id]:
+				d.Updated[kind]++
+			case matched[id]:
+				d.New[kind]++
+				d.Duplicates[kind]++
+			default:
+				d.New[kind]++
+			}
+		}
+	}
+	return d
+}
+
+// exportNodeIDs returns the node IDs of data per kind, keyed like
+// ExportData.Stats.
+func exportNodeIDs(data *ExportData) map[string]map[string]bool {
+	ids := map[string]map[string]bool{
+		"facts": {}, "decisions": {}, "entities": {}, "events": {}, "topics": {},
+	}
+	for _, f := range data.Facts {
+		ids["facts"][f.ID] = true
+	}
+	for _, d := range data.Decisions {
+		ids["decisions"][d.ID] = true
+	}
+	for _, e := range data.Entities {
+		ids["entities"][e.ID] = true
+	}
+	for _, e := range data.Events {
+		ids["events"][e.ID] = true
+	}
+	for _, t := range data.Topics {
+		ids["topics"][t.ID] = true
+	}
+	return ids
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMergeExports(t *testing.T) {
+	local := &ExportData{
+		Namespace: "default",
+		Facts: []Fact{
+			{ID: "fact:same", Content: "Uses Go", Category: "technical", Valid: true, CreatedAt: 100, UpdatedAt: 100},
+			{ID: "fact:pg", Content: "Billing runs on Postgres 15", Category: "technical", Valid: true, CreatedAt: 100, UpdatedAt: 100},
+		},
+		Decisions: []Decision{
+			{ID: "dec:local", Title: "Use Kafka", Status: "active", CreatedAt: 100, UpdatedAt: 300},
+		},
+		Entities: []Entity{
+			{ID: "ent:alice", Name: "Alice", Kind: "person", CreatedAt: 100, UpdatedAt: 100},
+			{ID: "ent:mercury", Name: "Mercury", Kind: "project", CreatedAt: 100, UpdatedAt: 100},
+		},
+	}
+	incoming := &ExportData{
+		Namespace: "default",
+		Facts: []Fact{
+			{ID: "fact:same", Content: "Uses Go", Category: "technical", Valid: true, CreatedAt: 100, UpdatedAt: 100},
+			{ID: "fact:pg16", Content: "Billing runs on Postgres 16", Category: "technical", Valid: true, CreatedAt: 200, UpdatedAt: 200},
+			{ID: "fact:new", Content: "Deploys on Fridays", Category: "general", Valid: true, CreatedAt: 200, UpdatedAt: 200},
+		},
+		Decisions: []Decision{
+			{ID: "dec:other", Title: "use  kafka", Status: "reversed", CreatedAt: 150, UpdatedAt: 200},
+		},
+		Entities: []Entity{
+			{ID: "ent:alice2", Name: "alice", Kind: "person", Description: "Billing lead", CreatedAt: 50, UpdatedAt: 200},
+			{ID: "ent:mercury2", Name: "Mercury", Kind: "technology", CreatedAt: 100, UpdatedAt: 100},
+		},
+		Edges: map[string][]map[string]string{
+			"fact_entity": {{"fact_id": "fact:new", "entity_id": "ent:alice2"}},
+		},
+		Aliases:  []EntityAlias{{Alias: "ali", EntityID: "ent:alice2"}},
+		Archived: []string{"dec:other"},
+	}
+
+	m := MergeExports(local, incoming)
+
+	if m.Added["facts"] != 2 || m.Unchanged["facts"] != 1 {
+		t.Errorf("facts: added %d, unchanged %d; want 2 and 1", m.Added["facts"], m.Unchanged["facts"])
+	}
+	// The local decision is newer, so it wins over the matched incoming one.
+	if m.Unchanged["decisions"] != 1 || len(m.Data.Decisions) != 0 {
+		t.Errorf("decisions: unchanged %d, written %+v", m.Unchanged["decisions"], m.Data.Decisions)
+	}
+	// The incoming Alice is newer and is written under the local ID with
+	// the earlier creation time.
+	if m.Updated["entities"] != 1 || m.Added["entities"] != 1 {
+		t.Errorf("entities: updated %d, added %d; want 1 and 1", m.Updated["entities"], m.Added["entities"])
+	}
+	var alice *Entity
+	for i := range m.Data.Entities {
+		if m.Data.Entities[i].Name == "alice" {
+			alice = &m.Data.Entities[i]
+		}
+	}
+	if alice == nil || alice.ID != "ent:alice" || alice.Description != "Billing lead" || alice.CreatedAt != 50 {
+		t.Errorf("merged Alice = %+v", alice)
+	}
+
+	if len(m.Matched) != 2 {
+		t.Errorf("matched = %+v, want the decision and Alice", m.Matched)
+	}
+
+	edge := m.Data.Edges["fact_entity"][0]
+	if edge["fact_id"] != "fact:new" || edge["entity_id"] != "ent:alice" || m.Relationships != 1 {
+		t.Errorf("edge = %v, want it remapped to ent:alice", edge)
+	}
+	if m.Data.Aliases[0].EntityID != "ent:alice" || m.Data.Archived[0] != "dec:local" {
+		t.Errorf("aliases %+v, archived %v not remapped", m.Data.Aliases, m.Data.Archived)
+	}
+
+	reasons := map[string]string{}
+	for _, c := range m.Conflicts {
+		reasons[c.LocalID+" "+c.IncomingID] = c.Reason
+	}
+	for pair, want := range map[string]string{
+		"fact:pg fact:pg16":        "similar facts",
+		"dec:local dec:other":      "status active here, reversed",
+		"ent:mercury ent:mercury2": "project here and a technology",
+	} {
+		if !strings.Contains(reasons[pair], want) {
+			t.Errorf("conflict %s = %q, want it to mention %q (all: %+v)", pair, reasons[pair], want, m.Conflicts)
+		}
+	}
+	if len(m.Conflicts) != 3 {
+		t.Errorf("conflicts = %+v, want 3", m.Conflicts)
+	}
+}
+
+func TestDiffImport(t *testing.T) {
+	local := &ExportData{
+		Namespace: "default",
+		Facts: []Fact{
+			{ID: "fact:go", Content: "Uses Go", Category: "technical", Valid: true, UpdatedAt: 100},
+			{ID: "fact:pg", Content: "Billing runs on Postgres 15", Category: "technical", Valid: true, UpdatedAt: 100},
+		},
+		Entities: []Entity{{ID: "ent:alice", Name: "Alice", Kind: "person", UpdatedAt: 100}},
+	}
+	incoming := &ExportData{
+		Namespace: "default",
+		Facts: []Fact{
+			{ID: "fact:go", Content: "Uses Go", Category: "technical", Valid: true, UpdatedAt: 50},
+			{ID: "fact:go2", Content: "uses go", Category: "general", Valid: true, UpdatedAt: 200},
+			{ID: "fact:pg16", Content: "Billing runs on Postgres 16", Category: "technical", Valid: true, UpdatedAt: 200},
+		},
+		Entities: []Entity{{ID: "ent:alice", Name: "Alice", Kind: "person", UpdatedAt: 200}},
+		Topics:   []Topic{{ID: "topic:infra", Name: "infra", UpdatedAt: 200}},
+	}
+
+	d := DiffImport(local, incoming)
+
+	// The import rewrites fact:go even though the local version is newer,
+	// and stores fact:go2 next to it.
+	want := map[string]map[string]int{
+		"new":        {"facts": 2, "topics": 1},
+		"updated":    {"facts": 1, "entities": 1},
+		"duplicates": {"facts": 1},
+	}
+	for name, got := range map[string]map[string]int{"new": d.New, "updated": d.Updated, "duplicates": d.Duplicates} {
+		for _, kind := range []string{"facts", "decisions", "entities", "events", "topics"} {
+			if got[kind] != want[name][kind] {
+				t.Errorf("%s %s = %d, want %d", name, kind, got[kind], want[name][kind])
+			}
+		}
+	}
+	if len(d.Conflicts) != 1 || d.Conflicts[0].IncomingID != "fact:pg16" {
+		t.Errorf("conflicts = %+v, want fact:pg16", d.Conflicts)
+	}
+}
+
+func TestWordOverlap(t *testing.T) {
+	if got := wordOverlap("Billing runs on Postgres 15", "billing runs on postgres 16"); got < 0.6 || got >= 1 {
+		t.Errorf("wordOverlap of near-identical facts = %v", got)
+	}
+	if got := wordOverlap("Uses Go", "Deploys on Fridays"); got != 0 {
+		t.Errorf("wordOverlap of unrelated facts = %v, want 0", got)
+	}
+	if got := wordOverlap("", "anything"); got != 0 {
+		t.Errorf("wordOverlap with empty text = %v, want 0", got)
+	}
+}
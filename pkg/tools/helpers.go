@@ -7,8 +7,21 @@ package tools
 import (
 	"fmt"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
+// DefaultActions maps the tools that take an action argument to the action
+// they run when a call leaves it out. Checks of the action of a call, such
+// as the read-only mode and client policies of the MCP server, must use it
+// too, or a call without action would slip past them.
+var DefaultActions = map[string]string{
+	"mie_attach":   "add",
+	"mie_query":    "search",
+	"mie_relate":   "create",
+	"mie_snapshot": "list",
+}
+
 // GetStringArg extracts a string argument from the args map, returning defaultVal if missing.
 func GetStringArg(args map[string]any, key, defaultVal string) string {
 	v, ok := args[key]
@@ -215,3 +228,60 @@ func EscapeRegex(s string) string {
 func QuoteCozoPattern(pattern string) string {
 	return `___"` + pattern + `"___`
 }
+
+// MentionsWord reports whether text contains word as a whole word, so that
+// neither neighbour is a letter, digit or underscore. With foldCase the
+// match ignores case.
+func MentionsWord(text, word string, foldCase bool) bool {
+	word = strings.TrimSpace(word)
+	if word == "" {
+		return false
+	}
+	if foldCase {
+		text, word = strings.ToLower(text), strings.ToLower(word)
+	}
+	for i := 0; i+len(word) <= len(text); {
+		j := strings.Index(text[i:], word)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(word)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if (start == 0 || !isWordRune(before)) && (end == len(text) || !isWordRune(after)) {
+			return true
+		}
+		_, size := utf8.DecodeRuneInString(text[start:])
+		i = start + size
+	}
+	return false
+}
+
+// isWordRune reports whether r can be part of a word.
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// WordSet returns the lowercased words of text, split at every rune that
+// is not a letter or digit.
+func WordSet(text string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
+
+// SharedWords returns the number of words found in both a and b.
+func SharedWords(a, b map[string]bool) int {
+	shared := 0
+	for w := range a {
+		if b[w] {
+			shared++
+		}
+	}
+	return shared
+}
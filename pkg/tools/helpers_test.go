@@ -192,3 +192,45 @@ func TestEscapeRegex(t *testing.T) {
 		t.Errorf("EscapeRegex = %q, want %q", got, want)
 	}
 }
+
+func TestMentionsWord(t *testing.T) {
+	tests := []struct {
+		text, word string
+		foldCase   bool
+		want       bool
+	}{
+		{"Deployed with Kubernetes today", "kubernetes", true, true},
+		{"Deployed with Kubernetes today", "kubernetes", false, false},
+		{"Deployed with Kubernetes-operator", "Kubernetes", false, true},
+		{"Deployed with Kubernetesy", "Kubernetes", true, false},
+		{"Rewrote it in Go.", "Go", false, true},
+		{"Let's go to lunch", "Go", false, false},
+		{"node_modules", "node", true, false},
+		{"Moved to São Paulo", "são paulo", true, true},
+		{"Gestão de dados", "gest", true, false},
+		{"Über alles", "über", true, true},
+		{"Written in Go—and fast", "go", true, true},
+		{"Ran on kubernetes", "", true, false},
+	}
+	for _, tt := range tests {
+		if got := MentionsWord(tt.text, tt.word, tt.foldCase); got != tt.want {
+			t.Errorf("MentionsWord(%q, %q, %v) = %v, want %v", tt.text, tt.word, tt.foldCase, got, tt.want)
+		}
+	}
+}
+
+func TestWordSet(t *testing.T) {
+	got := WordSet("Billing runs on Postgres-15, billing!")
+	want := map[string]bool{"billing": true, "runs": true, "on": true, "postgres": true, "15": true}
+	if len(got) != len(want) {
+		t.Fatalf("WordSet = %v, want %v", got, want)
+	}
+	for w := range want {
+		if !got[w] {
+			t.Errorf("WordSet is missing %q", w)
+		}
+	}
+	if n := SharedWords(got, WordSet("Postgres runs billing")); n != 3 {
+		t.Errorf("SharedWords = %d, want 3", n)
+	}
+}
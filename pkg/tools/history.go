@@ -0,0 +1,128 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+type changeReasonKey struct{}
+
+// WithChangeReason returns a copy of ctx whose description and status
+// updates are recorded in the node history with reason.
+func WithChangeReason(ctx context.Context, reason string) context.Context {
+	return context.WithValue(ctx, changeReasonKey{}, reason)
+}
+
+// ChangeReasonFromContext returns the reason set by WithChangeReason, or an
+// empty string.
+func ChangeReasonFromContext(ctx context.Context) string {
+	reason, _ := ctx.Value(changeReasonKey{}).(string)
+	return reason
+}
+
+// historyFields are the fields History can be limited to.
+var historyFields = map[string]bool{"description": true, "status": true, "valid": true}
+
+// History returns the change timeline of a node, oldest first: its creation
+// followed by every description change, status change, and invalidation,
+// with the old and new values and the reason given. The field argument
+// limits the changes to one field, such as a decision's status transitions.
+func History(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
+	nodeID := GetStringArg(args, "node_id", "")
+	if nodeID == "" {
+		return NewError("Missing required parameter: node_id"), nil
+	}
+	field := GetStringArg(args, "field", "")
+	if field != "" && !historyFields[field] {
+		return NewError(fmt.Sprintf("Invalid field %q. Must be one of: description, status, valid", field)), nil
+	}
+
+	node, err := client.GetNodeByID(ctx, nodeID)
+	if err != nil {
+		return NewError(fmt.Sprintf("Node [%s] not found: %v", nodeID, err)), nil
+	}
+	entries, err := client.GetNodeHistory(ctx, nodeID)
+	if err != nil {
+		return NewError(fmt.Sprintf("Failed to read history of [%s]: %v", nodeID, err)), nil
+	}
+	if field != "" {
+		entries = slices.DeleteFunc(entries, func(e HistoryEntry) bool { return e.Field != field })
+	}
+	_ = client.IncrementCounter(ctx, "total_queries")
+
+	createdAt, createdBy := nodeCreation(node)
+	if WantsJSON(args) {
+		if entries == nil {
+			entries = []HistoryEntry{}
+		}
+		return NewJSONResult(historyJSON{Node: newNodeJSON(node), CreatedAt: createdAt, Changes: entries}), nil
+	}
+
+	nodeType, label := nodeLabel(node)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## History of [%s]\n\n", nodeID)
+	fmt.Fprintf(&sb, "%s: %s\n\n", nodeType, Truncate(label, 200))
+	sb.WriteString("| Time (UTC) | Change | From | To | Reason | Tool | Agent |\n")
+	sb.WriteString("|------------|--------|------|----|--------|------|-------|\n")
+	fmt.Fprintf(&sb, "| %s | created | - | - | - | - | %s |\n", formatHistoryTime(createdAt), orDash(createdBy))
+	for _, e := range entries {
+		reason := e.Reason
+		if e.RelatedID != "" {
+			reason = strings.TrimSpace(fmt.Sprintf("%s (replaced by [%s])", reason, e.RelatedID))
+		}
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s | %s | %s |\n",
+			formatHistoryTime(e.At), e.Field, historyValue(e.OldValue), historyValue(e.NewValue),
+			historyValue(reason), orDash(e.Tool), orDash(e.SourceAgent))
+	}
+	if len(entries) == 0 {
+		sb.WriteString("\n_No changes since the node was stored._\n")
+	}
+	return NewResult(sb.String()), nil
+}
+
+// historyJSON is the JSON response of History.
+type historyJSON struct {
+	Node      NodeJSON       `json:"node"`
+	CreatedAt int64          `json:"created_at"`
+	Changes   []HistoryEntry `json:"changes"`
+}
+
+// nodeCreation returns when a node returned by GetNodeByID was stored and
+// the agent that stored it.
+func nodeCreation(node any) (at int64, agent string) {
+	switch n := node.(type) {
+	case *Fact:
+		return n.CreatedAt, n.SourceAgent
+	case *Decision:
+		return n.CreatedAt, n.SourceAgent
+	case *Entity:
+		return n.CreatedAt, n.SourceAgent
+	case *Event:
+		return n.CreatedAt, n.SourceAgent
+	case *Topic:
+		return n.CreatedAt, ""
+	default:
+		return 0, ""
+	}
+}
+
+// formatHistoryTime formats Unix seconds for the history table.
+func formatHistoryTime(at int64) string {
+	if at == 0 {
+		return "-"
+	}
+	return time.Unix(at, 0).UTC().Format("2006-01-02 15:04:05")
+}
+
+// historyValue makes v fit in a table cell: one line of at most 80
+// characters, or "-" when empty.
+func historyValue(v string) string {
+	return orDash(Truncate(strings.Join(strings.Fields(v), " "), 80))
+}
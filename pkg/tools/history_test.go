@@ -0,0 +1,134 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestHistory(t *testing.T) {
+	mock := &MockQuerier{
+		GetNodeByIDFunc: func(ctx context.Context, nodeID string) (any, error) {
+			return &Decision{ID: nodeID, Title: "Use PostgreSQL", Status: "reversed", SourceAgent: "claude", CreatedAt: 1772323200}, nil
+		},
+		GetNodeHistoryFunc: func(ctx context.Context, nodeID string) ([]HistoryEntry, error) {
+			return []HistoryEntry{
+				{ID: "hist:1", NodeID: nodeID, At: 1772409600, Field: "status", OldValue: "active", NewValue: "superseded", Tool: "mie_update"},
+				{ID: "hist:2", NodeID: nodeID, At: 1772496000, Field: "status", OldValue: "superseded", NewValue: "reversed", Reason: "Load tests failed", Tool: "mie_update", SourceAgent: "cursor"},
+			}, nil
+		},
+	}
+
+	result, err := History(context.Background(), mock, map[string]any{"node_id": "dec:abc"})
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("History() returned error: %s", result.Text)
+	}
+	for _, want := range []string{
+		"## History of [dec:abc]",
+		"decision: Use PostgreSQL",
+		"| 2026-03-01 00:00:00 | created | - | - | - | - | claude |",
+		"| 2026-03-02 00:00:00 | status | active | superseded | - | mie_update | - |",
+		"| 2026-03-03 00:00:00 | status | superseded | reversed | Load tests failed | mie_update | cursor |",
+	} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("missing %q in:\n%s", want, result.Text)
+		}
+	}
+}
+
+func TestHistory_Field(t *testing.T) {
+	mock := &MockQuerier{
+		GetNodeHistoryFunc: func(ctx context.Context, nodeID string) ([]HistoryEntry, error) {
+			return []HistoryEntry{
+				{ID: "hist:1", NodeID: nodeID, At: 1772409600, Field: "description", OldValue: "Old", NewValue: "New"},
+				{ID: "hist:2", NodeID: nodeID, At: 1772496000, Field: "status", OldValue: "proposed", NewValue: "active", Reason: "Team agreed"},
+			}, nil
+		},
+	}
+
+	result, _ := History(context.Background(), mock, map[string]any{"node_id": "dec:abc", "field": "status"})
+	if !strings.Contains(result.Text, "| status | proposed | active | Team agreed |") {
+		t.Errorf("missing status transition in:\n%s", result.Text)
+	}
+	if strings.Contains(result.Text, "| description |") {
+		t.Errorf("description change should be filtered out:\n%s", result.Text)
+	}
+
+	result, _ = History(context.Background(), mock, map[string]any{"node_id": "dec:abc", "field": "title"})
+	if !result.IsError {
+		t.Error("expected error for invalid field")
+	}
+}
+
+func TestHistory_Invalidation(t *testing.T) {
+	mock := &MockQuerier{
+		GetNodeHistoryFunc: func(ctx context.Context, nodeID string) ([]HistoryEntry, error) {
+			return []HistoryEntry{
+				{ID: "hist:1", NodeID: nodeID, At: 1772409600, Field: "valid", OldValue: "true", NewValue: "false", Reason: "Moved", RelatedID: "fact:new"},
+			}, nil
+		},
+	}
+
+	result, _ := History(context.Background(), mock, map[string]any{"node_id": "fact:abc"})
+	if !strings.Contains(result.Text, "| valid | true | false | Moved (replaced by [fact:new]) |") {
+		t.Errorf("missing invalidation row in:\n%s", result.Text)
+	}
+}
+
+func TestHistory_NoChanges(t *testing.T) {
+	result, _ := History(context.Background(), &MockQuerier{}, map[string]any{"node_id": "fact:abc"})
+	if !strings.Contains(result.Text, "_No changes since the node was stored._") {
+		t.Errorf("missing empty note in:\n%s", result.Text)
+	}
+}
+
+func TestHistory_Errors(t *testing.T) {
+	result, _ := History(context.Background(), &MockQuerier{}, map[string]any{})
+	if !result.IsError {
+		t.Error("History() should require node_id")
+	}
+
+	mock := &MockQuerier{
+		GetNodeByIDFunc: func(ctx context.Context, nodeID string) (any, error) {
+			return nil, fmt.Errorf("node %q not found", nodeID)
+		},
+	}
+	result, _ = History(context.Background(), mock, map[string]any{"node_id": "dec:missing"})
+	if !result.IsError || !strings.Contains(result.Text, "not found") {
+		t.Errorf("History() of a missing node = %+v", result)
+	}
+}
+
+func TestHistory_JSON(t *testing.T) {
+	mock := &MockQuerier{
+		GetNodeByIDFunc: func(ctx context.Context, nodeID string) (any, error) {
+			return &Fact{ID: nodeID, Content: "Uses Go", Valid: true}, nil
+		},
+	}
+	result, err := History(context.Background(), mock, map[string]any{"node_id": "fact:abc", "response_format": "json"})
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	var out struct {
+		Node    NodeJSON       `json:"node"`
+		Changes []HistoryEntry `json:"changes"`
+	}
+	if err := json.Unmarshal([]byte(result.Text), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result.Text)
+	}
+	if out.Node.Type != "fact" {
+		t.Errorf("node type = %q, want fact", out.Node.Type)
+	}
+	if out.Changes == nil || len(out.Changes) != 0 {
+		t.Errorf("changes = %v, want empty list", out.Changes)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// IntentBulkLinks is the intent kind of the invalidations and relationships
+// a bulk store writes after its nodes.
+const IntentBulkLinks = "bulk_links"
+
+// bulkLinks are the invalidation and relationships of one stored bulk item,
+// with batch references already resolved to node IDs.
+type bulkLinks struct {
+	Index         int    `json:"index"`
+	NodeID        string `json:"node_id"`
+	SourceAgent   string `json:"source_agent"`
+	Invalidates   string `json:"invalidates,omitempty"`
+	Relationships []any  `json:"relationships,omitempty"`
+}
+
+// IntentReplay is what ReplayIntents did with one pending intent.
+type IntentReplay struct {
+	Intent Intent
+	// Completed reports whether the intent was finished and removed.
+	Completed bool
+	// Errors lists the steps that failed again, or why the intent could
+	// not be replayed.
+	Errors []string
+}
+
+// applyBulkLinks writes the invalidation and relationships of one bulk item.
+// It returns the invalidation error or message, as handleInvalidation does,
+// and what happened to each relationship. Writing them again is harmless,
+// so an interrupted bulk store can be finished by running it once more.
+func applyBulkLinks(ctx context.Context, client Querier, links bulkLinks) (*ToolResult, string, []relationshipResult) {
+	toolErr, msg := handleInvalidation(ctx, client, map[string]any{"invalidates": links.Invalidates}, links.NodeID)
+	var rels []relationshipResult
+	if links.Relationships != nil {
+		rels = storeRelationships(ctx, client, links.NodeID, links.SourceAgent, links.Relationships)
+	}
+	return toolErr, msg, rels
+}
+
+// ReplayIntents finishes the intents that were recorded but never completed,
+// for example because the process died part way through a bulk store. Each
+// intent runs in its own namespace. Intents that replay are removed even if
+// some of their steps fail again, since retrying would not help; the
+// failures are returned for the caller to report. Intents of an unknown
+// kind are left pending.
+func ReplayIntents(ctx context.Context, client Querier) ([]IntentReplay, error) {
+	pending, err := client.PendingIntents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	replays := make([]IntentReplay, 0, len(pending))
+	for _, intent := range pending {
+		replay := IntentReplay{Intent: intent}
+		nsCtx := WithNamespace(ctx, intent.Namespace)
+		switch intent.Kind {
+		case IntentBulkLinks:
+			var items []bulkLinks
+			if err := json.Unmarshal([]byte(intent.Payload), &items); err != nil {
+				replay.Errors = append(replay.Errors, fmt.Sprintf("decode payload: %v", err))
+				break
+			}
+			for _, links := range items {
+				toolErr, _, rels := applyBulkLinks(nsCtx, client, links)
+				if toolErr != nil {
+					replay.Errors = append(replay.Errors, fmt.Sprintf("item[%d] invalidation: %s", links.Index, toolErr.Text))
+				}
+				for _, r := range rels {
+					if r.Error != "" {
+						replay.Errors = append(replay.Errors, fmt.Sprintf("item[%d] %s -> [%s]: %s", links.Index, r.Edge, r.TargetID, r.Error))
+					}
+				}
+			}
+			if err := client.CompleteIntent(nsCtx, intent.ID); err != nil {
+				replay.Errors = append(replay.Errors, err.Error())
+				break
+			}
+			replay.Completed = true
+		default:
+			replay.Errors = append(replay.Errors, fmt.Sprintf("unknown intent kind %q", intent.Kind))
+		}
+		replays = append(replays, replay)
+	}
+	return replays, nil
+}
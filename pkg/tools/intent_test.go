@@ -0,0 +1,72 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestReplayIntents(t *testing.T) {
+	var completed []string
+	var relNamespaces []string
+	var invalidated []string
+	mock := &MockQuerier{
+		PendingIntentsFunc: func(ctx context.Context) ([]Intent, error) {
+			return []Intent{
+				{ID: "int:1", Kind: IntentBulkLinks, Namespace: "work", Payload: `[
+					{"index": 0, "node_id": "fact:a", "source_agent": "claude", "invalidates": "fact:old"},
+					{"index": 1, "node_id": "fact:b", "source_agent": "claude", "relationships": [
+						{"edge": "fact_entity", "target_id": "ent:x"},
+						{"edge": "fact_entity", "target_id": "ent:gone"}
+					]}
+				]`},
+				{ID: "int:2", Kind: "future_kind", Payload: "{}"},
+			}, nil
+		},
+		InvalidateFactFunc: func(ctx context.Context, oldFactID, newFactID, reason string) error {
+			invalidated = append(invalidated, oldFactID+" by "+newFactID)
+			return nil
+		},
+		AddRelationshipFunc: func(ctx context.Context, edgeType string, fields map[string]string) error {
+			relNamespaces = append(relNamespaces, NamespaceFromContext(ctx))
+			if fields["entity_id"] == "ent:gone" {
+				return fmt.Errorf("target not found")
+			}
+			return nil
+		},
+		CompleteIntentFunc: func(ctx context.Context, intentID string) error {
+			completed = append(completed, intentID)
+			return nil
+		},
+	}
+
+	replays, err := ReplayIntents(context.Background(), mock)
+	if err != nil {
+		t.Fatalf("ReplayIntents: %v", err)
+	}
+	if len(replays) != 2 {
+		t.Fatalf("replays = %+v, want 2", replays)
+	}
+	if len(invalidated) != 1 || invalidated[0] != "fact:old by fact:a" {
+		t.Errorf("invalidated = %v, want fact:old by fact:a", invalidated)
+	}
+	if len(relNamespaces) != 2 || relNamespaces[0] != "work" {
+		t.Errorf("relationships written in namespaces %v, want the intent's namespace", relNamespaces)
+	}
+
+	bulk := replays[0]
+	if !bulk.Completed || len(bulk.Errors) != 1 {
+		t.Errorf("bulk replay = %+v, want completed with the one failed relationship", bulk)
+	}
+	unknown := replays[1]
+	if unknown.Completed || len(unknown.Errors) != 1 {
+		t.Errorf("unknown replay = %+v, want pending with an error", unknown)
+	}
+	if len(completed) != 1 || completed[0] != "int:1" {
+		t.Errorf("completed = %v, want only int:1", completed)
+	}
+}
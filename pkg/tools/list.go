@@ -36,24 +36,87 @@ func List(ctx context.Context, client Querier, args map[string]any) (*ToolResult
 	if offset < 0 {
 		offset = 0
 	}
+	sortBy := GetStringArg(args, "sort_by", "created_at")
+	sortOrder := GetStringArg(args, "sort_order", "desc")
+
+	var after *ListCursor
+	if raw := GetStringArg(args, "cursor", ""); raw != "" {
+		if offset > 0 {
+			return NewError("Pass either cursor or offset, not both"), nil
+		}
+		c, err := DecodeListCursor(raw)
+		if err != nil {
+			return NewError(fmt.Sprintf("Invalid cursor: %v", err)), nil
+		}
+		if c.NodeType != nodeType || c.SortBy != sortBy || c.SortOrder != sortOrder {
+			return NewError("Invalid cursor: it was returned for a different node_type, sort_by, or sort_order"), nil
+		}
+		after = c
+	}
+
+	timeRange, err := ParseTimeRangeArgs(args)
+	if err != nil {
+		return NewError(err.Error()), nil
+	}
+	if timeRange.HasEventDate() && nodeType != "event" {
+		return NewError("event_date_range only applies to node_type=event"), nil
+	}
+	sourceAgent := GetStringArg(args, "source_agent", "")
+	if sourceAgent != "" && nodeType == "topic" {
+		return NewError("source_agent does not apply to node_type=topic"), nil
+	}
+	sourceConversation := GetStringArg(args, "source_conversation", "")
+	if sourceConversation != "" && (nodeType == "entity" || nodeType == "topic") {
+		return NewError(fmt.Sprintf("source_conversation does not apply to node_type=%s", nodeType)), nil
+	}
 
 	opts := ListOptions{
-		NodeType:  nodeType,
-		Category:  GetStringArg(args, "category", ""),
-		Kind:      GetStringArg(args, "kind", ""),
-		Status:    GetStringArg(args, "status", ""),
-		TopicName: GetStringArg(args, "topic", ""),
-		ValidOnly: GetBoolArg(args, "valid_only", true),
-		Limit:     limit,
-		Offset:    offset,
-		SortBy:    GetStringArg(args, "sort_by", "created_at"),
-		SortOrder: GetStringArg(args, "sort_order", "desc"),
+		NodeType:           nodeType,
+		Category:           GetStringArg(args, "category", ""),
+		Kind:               GetStringArg(args, "kind", ""),
+		Status:             GetStringArg(args, "status", ""),
+		TopicName:          GetStringArg(args, "topic", ""),
+		ValidOnly:          GetBoolArg(args, "valid_only", true),
+		Limit:              limit,
+		Offset:             offset,
+		SortBy:             sortBy,
+		SortOrder:          sortOrder,
+		After:              after,
+		TimeRange:          timeRange,
+		IncludeArchived:    GetBoolArg(args, "include_archived", false),
+		SourceAgent:        sourceAgent,
+		SourceConversation: sourceConversation,
+		IncludeDegree:      GetBoolArg(args, "include_degree", false),
 	}
 
+	if after != nil {
+		// Fetch one extra node to learn whether another page follows.
+		opts.Limit++
+	}
 	nodes, total, err := client.ListNodes(ctx, opts)
 	if err != nil {
 		return NewError(fmt.Sprintf("Failed to list nodes: %v", err)), nil
 	}
+	more := total > offset+len(nodes)
+	if after != nil {
+		more = len(nodes) > limit
+		if more {
+			nodes = nodes[:limit]
+		}
+	}
+
+	if WantsJSON(args) {
+		out := listJSON{NodeType: nodeType, Total: total, Offset: offset, Nodes: nodes}
+		if out.Nodes == nil {
+			out.Nodes = []any{}
+		}
+		if more && len(nodes) > 0 {
+			if next := listCursorAfter(nodes[len(nodes)-1], opts); next != nil {
+				out.NextCursor = next.Encode()
+			}
+		}
+		return NewJSONResult(out), nil
+	}
 
 	var sb strings.Builder
 
@@ -62,73 +125,123 @@ func List(ctx context.Context, client Querier, args map[string]any) (*ToolResult
 	}
 	label := typeLabels[nodeType]
 
-	sb.WriteString(fmt.Sprintf("## %s (%d total, showing %d-%d)\n\n", label, total, offset+1, offset+len(nodes)))
+	if after != nil {
+		sb.WriteString(fmt.Sprintf("## %s (%d total, showing %d after cursor)\n\n", label, total, len(nodes)))
+	} else {
+		sb.WriteString(fmt.Sprintf("## %s (%d total, showing %d-%d)\n\n", label, total, offset+1, offset+len(nodes)))
+	}
 
 	if len(nodes) == 0 {
 		sb.WriteString("_No results found._\n")
 		return NewResult(sb.String()), nil
 	}
 
-	formatNodeTable(&sb, nodeType, nodes, offset)
+	formatNodeTable(&sb, nodeType, nodes, offset, opts.IncludeDegree)
 
 	// Pagination info
-	if total > offset+len(nodes) {
-		sb.WriteString(fmt.Sprintf("\nShowing %d of %d results. Use offset=%d for next page.\n", len(nodes), total, offset+limit))
+	if more {
+		if after != nil {
+			sb.WriteString(fmt.Sprintf("\nShowing %d of %d results.\n", len(nodes), total))
+		} else {
+			sb.WriteString(fmt.Sprintf("\nShowing %d of %d results. Use offset=%d for next page.\n", len(nodes), total, offset+limit))
+		}
+		if next := listCursorAfter(nodes[len(nodes)-1], opts); next != nil {
+			sb.WriteString(fmt.Sprintf("next_cursor: %s\nPass it as cursor to get the next page, unaffected by nodes added or removed since.\n", next.Encode()))
+		}
 	}
 
 	return NewResult(sb.String()), nil
 }
 
-func formatNodeTable(sb *strings.Builder, nodeType string, nodes []any, offset int) {
+// listJSON is the JSON response of List. NextCursor is set when another
+// page follows.
+type listJSON struct {
+	NodeType   string `json:"node_type"`
+	Total      int    `json:"total"`
+	Offset     int    `json:"offset"`
+	Nodes      []any  `json:"nodes"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// formatNodeTable writes nodes as a Markdown table. withDegree adds an Edges
+// column with the Degree of each node.
+func formatNodeTable(sb *strings.Builder, nodeType string, nodes []any, offset int, withDegree bool) {
+	header := func(cols, sep string) {
+		if withDegree {
+			cols += " Edges |"
+			sep += "-------|"
+		}
+		sb.WriteString(cols + "\n" + sep + "\n")
+	}
+	row := func(degree *int, format string, a ...any) {
+		line := fmt.Sprintf(format, a...)
+		if withDegree {
+			line += fmt.Sprintf(" %s |", formatDegree(degree))
+		}
+		sb.WriteString(line + "\n")
+	}
+
 	switch nodeType {
 	case "fact":
-		sb.WriteString("| # | ID | Content | Category | Confidence | Created |\n")
-		sb.WriteString("|---|-----|---------|----------|------------|--------|\n")
+		header("| # | ID | Content | Category | Confidence | Verified | Created |",
+			"|---|-----|---------|----------|------------|----------|--------|")
 		for i, node := range nodes {
 			if f, ok := node.(*Fact); ok {
-				fmt.Fprintf(sb, "| %d | %s | %s | %s | %.1f | %d |\n",
-					offset+i+1, f.ID, Truncate(f.Content, 50), f.Category, f.Confidence, f.CreatedAt)
+				verified := ""
+				if f.Verified {
+					verified = f.VerifiedBy
+				}
+				row(f.Degree, "| %d | %s | %s | %s | %.1f | %s | %d |",
+					offset+i+1, f.ID, Truncate(f.Content, 50), f.Category, f.Confidence, verified, f.CreatedAt)
 			}
 		}
 
 	case "decision":
-		sb.WriteString("| # | ID | Title | Status | Created |\n")
-		sb.WriteString("|---|-----|-------|--------|--------|\n")
+		header("| # | ID | Title | Status | Created |",
+			"|---|-----|-------|--------|--------|")
 		for i, node := range nodes {
 			if d, ok := node.(*Decision); ok {
-				fmt.Fprintf(sb, "| %d | %s | %s | %s | %d |\n",
+				row(d.Degree, "| %d | %s | %s | %s | %d |",
 					offset+i+1, d.ID, Truncate(d.Title, 60), d.Status, d.CreatedAt)
 			}
 		}
 
 	case "entity":
-		sb.WriteString("| # | ID | Name | Kind | Description |\n")
-		sb.WriteString("|---|-----|------|------|------------|\n")
+		header("| # | ID | Name | Kind | Description |",
+			"|---|-----|------|------|------------|")
 		for i, node := range nodes {
 			if e, ok := node.(*Entity); ok {
-				fmt.Fprintf(sb, "| %d | %s | %s | %s | %s |\n",
+				row(e.Degree, "| %d | %s | %s | %s | %s |",
 					offset+i+1, e.ID, e.Name, e.Kind, Truncate(e.Description, 40))
 			}
 		}
 
 	case "event":
-		sb.WriteString("| # | ID | Title | Date | Created |\n")
-		sb.WriteString("|---|-----|-------|------|--------|\n")
+		header("| # | ID | Title | Date | Created |",
+			"|---|-----|-------|------|--------|")
 		for i, node := range nodes {
 			if ev, ok := node.(*Event); ok {
-				fmt.Fprintf(sb, "| %d | %s | %s | %s | %d |\n",
-					offset+i+1, ev.ID, Truncate(ev.Title, 60), ev.EventDate, ev.CreatedAt)
+				row(ev.Degree, "| %d | %s | %s | %s | %d |",
+					offset+i+1, ev.ID, Truncate(ev.Title, 60), ev.Dates(), ev.CreatedAt)
 			}
 		}
 
 	case "topic":
-		sb.WriteString("| # | ID | Name | Description |\n")
-		sb.WriteString("|---|-----|------|------------|\n")
+		header("| # | ID | Name | Description |",
+			"|---|-----|------|------------|")
 		for i, node := range nodes {
 			if t, ok := node.(*Topic); ok {
-				fmt.Fprintf(sb, "| %d | %s | %s | %s |\n",
+				row(t.Degree, "| %d | %s | %s | %s |",
 					offset+i+1, t.ID, t.Name, Truncate(t.Description, 60))
 			}
 		}
 	}
-}
\ No newline at end of file
+}
+
+// formatDegree renders a node's edge count, or "-" when it is unknown.
+func formatDegree(degree *int) string {
+	if degree == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d", *degree)
+}
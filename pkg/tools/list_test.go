@@ -6,6 +6,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"strings"
 	"testing"
 )
@@ -93,6 +94,41 @@ func TestList_Entities(t *testing.T) {
 	}
 }
 
+func TestList_IncludeDegree(t *testing.T) {
+	var gotOpts ListOptions
+	mock := &MockQuerier{
+		ListNodesFunc: func(ctx context.Context, opts ListOptions) ([]any, int, error) {
+			gotOpts = opts
+			hub, orphan := 3, 0
+			return []any{
+				&Entity{ID: "ent:abc", Name: "Postgres", Kind: "technology", Degree: &hub},
+				&Entity{ID: "ent:def", Name: "Redis", Kind: "technology", Degree: &orphan},
+			}, 2, nil
+		},
+	}
+
+	result, _ := List(context.Background(), mock, map[string]any{
+		"node_type":      "entity",
+		"include_degree": true,
+	})
+	if result.IsError {
+		t.Fatalf("List() returned error: %s", result.Text)
+	}
+	if !gotOpts.IncludeDegree {
+		t.Error("List() should pass include_degree to ListNodes")
+	}
+	for _, want := range []string{"| Edges |", "| Postgres | technology |  | 3 |", "| Redis | technology |  | 0 |"} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("List() output missing %q:\n%s", want, result.Text)
+		}
+	}
+
+	result, _ = List(context.Background(), mock, map[string]any{"node_type": "entity", "response_format": "json"})
+	if !strings.Contains(result.Text, `"degree": 3`) || !strings.Contains(result.Text, `"degree": 0`) {
+		t.Errorf("JSON output should include degrees:\n%s", result.Text)
+	}
+}
+
 func TestList_Events(t *testing.T) {
 	mock := &MockQuerier{
 		ListNodesFunc: func(ctx context.Context, opts ListOptions) ([]any, int, error) {
@@ -182,6 +218,77 @@ func TestList_Pagination(t *testing.T) {
 	}
 }
 
+func TestList_Cursor(t *testing.T) {
+	facts := []any{
+		&Fact{ID: "fact:c", Content: "Third", Category: "general", CreatedAt: 3000},
+		&Fact{ID: "fact:b", Content: "Second", Category: "general", CreatedAt: 2000},
+		&Fact{ID: "fact:a", Content: "First", Category: "general", CreatedAt: 1000},
+	}
+	mock := &MockQuerier{
+		ListNodesFunc: func(ctx context.Context, opts ListOptions) ([]any, int, error) {
+			start := 0
+			if opts.After != nil {
+				for i, n := range facts {
+					if n.(*Fact).ID == opts.After.ID {
+						start = i + 1
+					}
+				}
+			}
+			end := min(start+opts.Limit, len(facts))
+			return facts[start:end], len(facts), nil
+		},
+	}
+
+	result, _ := List(context.Background(), mock, map[string]any{"node_type": "fact", "limit": float64(1)})
+	_, next, ok := strings.Cut(result.Text, "next_cursor: ")
+	if !ok {
+		t.Fatalf("first page should return next_cursor:\n%s", result.Text)
+	}
+	cursor, _, _ := strings.Cut(next, "\n")
+	c, err := DecodeListCursor(cursor)
+	if err != nil {
+		t.Fatalf("DecodeListCursor() error = %v", err)
+	}
+	if c.ID != "fact:c" || c.Value != float64(3000) {
+		t.Errorf("cursor = %+v, want after fact:c at 3000", c)
+	}
+
+	result, _ = List(context.Background(), mock, map[string]any{"node_type": "fact", "limit": float64(1), "cursor": cursor})
+	if result.IsError || !strings.Contains(result.Text, "fact:b") || strings.Contains(result.Text, "fact:c") {
+		t.Errorf("second page should hold only fact:b:\n%s", result.Text)
+	}
+	if !strings.Contains(result.Text, "next_cursor: ") {
+		t.Errorf("second page should return next_cursor:\n%s", result.Text)
+	}
+
+	result, _ = List(context.Background(), mock, map[string]any{"node_type": "fact", "limit": float64(2), "cursor": cursor})
+	if strings.Contains(result.Text, "next_cursor") {
+		t.Errorf("last page should not return next_cursor:\n%s", result.Text)
+	}
+}
+
+func TestList_CursorErrors(t *testing.T) {
+	cursor := ListCursor{NodeType: "fact", SortBy: "created_at", SortOrder: "desc", Value: float64(1), ID: "fact:a"}.Encode()
+	tests := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"garbage", map[string]any{"node_type": "fact", "cursor": "not a cursor"}, "Invalid cursor"},
+		{"other sort", map[string]any{"node_type": "fact", "cursor": cursor, "sort_order": "asc"}, "different node_type"},
+		{"other type", map[string]any{"node_type": "entity", "cursor": cursor}, "different node_type"},
+		{"with offset", map[string]any{"node_type": "fact", "cursor": cursor, "offset": float64(5)}, "not both"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _ := List(context.Background(), &MockQuerier{}, tt.args)
+			if !result.IsError || !strings.Contains(result.Text, tt.want) {
+				t.Errorf("List() = %q, want error containing %q", result.Text, tt.want)
+			}
+		})
+	}
+}
+
 func TestList_WithFilters(t *testing.T) {
 	mock := &MockQuerier{
 		ListNodesFunc: func(ctx context.Context, opts ListOptions) ([]any, int, error) {
@@ -222,4 +329,133 @@ func TestList_EmptyResults(t *testing.T) {
 	if !strings.Contains(result.Text, "No results found") {
 		t.Error("List() should indicate no results")
 	}
-}
\ No newline at end of file
+}
+
+func TestList_TimeRange(t *testing.T) {
+	var got ListOptions
+	mock := &MockQuerier{
+		ListNodesFunc: func(ctx context.Context, opts ListOptions) ([]any, int, error) {
+			got = opts
+			return nil, 0, nil
+		},
+	}
+
+	result, _ := List(context.Background(), mock, map[string]any{
+		"node_type":        "event",
+		"created_after":    "2026-01-01",
+		"event_date_range": "2026-01-01..2026-01-31",
+	})
+	if result.IsError {
+		t.Fatalf("List() returned error: %s", result.Text)
+	}
+	if got.CreatedAfter == 0 || got.EventDateFrom != "2026-01-01" || got.EventDateTo != "2026-01-31" {
+		t.Errorf("time range not passed to ListNodes: %+v", got.TimeRange)
+	}
+
+	result, _ = List(context.Background(), mock, map[string]any{
+		"node_type":        "fact",
+		"event_date_range": "2026-01-01..2026-01-31",
+	})
+	if !result.IsError {
+		t.Error("List() should reject event_date_range for non-event node types")
+	}
+
+	result, _ = List(context.Background(), mock, map[string]any{
+		"node_type":     "decision",
+		"created_after": "yesterday",
+	})
+	if !result.IsError {
+		t.Error("List() should reject an invalid created_after")
+	}
+}
+
+func TestList_IncludeArchived(t *testing.T) {
+	var got ListOptions
+	mock := &MockQuerier{
+		ListNodesFunc: func(ctx context.Context, opts ListOptions) ([]any, int, error) {
+			got = opts
+			return nil, 0, nil
+		},
+	}
+
+	_, _ = List(context.Background(), mock, map[string]any{"node_type": "topic"})
+	if got.IncludeArchived {
+		t.Error("List() should hide archived nodes by default")
+	}
+
+	_, _ = List(context.Background(), mock, map[string]any{"node_type": "topic", "include_archived": true})
+	if !got.IncludeArchived {
+		t.Error("List() should pass include_archived to ListNodes")
+	}
+}
+
+func TestList_SourceAgent(t *testing.T) {
+	var got ListOptions
+	mock := &MockQuerier{
+		ListNodesFunc: func(ctx context.Context, opts ListOptions) ([]any, int, error) {
+			got = opts
+			return nil, 0, nil
+		},
+	}
+
+	result, _ := List(context.Background(), mock, map[string]any{"node_type": "fact", "source_agent": "claude"})
+	if result.IsError {
+		t.Fatalf("List() returned error: %s", result.Text)
+	}
+	if got.SourceAgent != "claude" {
+		t.Errorf("SourceAgent = %q, want claude", got.SourceAgent)
+	}
+
+	result, _ = List(context.Background(), mock, map[string]any{"node_type": "topic", "source_agent": "claude"})
+	if !result.IsError {
+		t.Error("List() should reject source_agent for topics")
+	}
+}
+
+func TestList_SourceConversation(t *testing.T) {
+	var got ListOptions
+	mock := &MockQuerier{
+		ListNodesFunc: func(ctx context.Context, opts ListOptions) ([]any, int, error) {
+			got = opts
+			return nil, 0, nil
+		},
+	}
+
+	result, _ := List(context.Background(), mock, map[string]any{"node_type": "decision", "source_conversation": "ses:q"})
+	if result.IsError {
+		t.Fatalf("List() returned error: %s", result.Text)
+	}
+	if got.SourceConversation != "ses:q" {
+		t.Errorf("SourceConversation = %q, want ses:q", got.SourceConversation)
+	}
+
+	result, _ = List(context.Background(), mock, map[string]any{"node_type": "entity", "source_conversation": "ses:q"})
+	if !result.IsError {
+		t.Error("List() should reject source_conversation for entities")
+	}
+}
+
+func TestList_JSON(t *testing.T) {
+	mock := &MockQuerier{
+		ListNodesFunc: func(ctx context.Context, opts ListOptions) ([]any, int, error) {
+			return []any{&Fact{ID: "fact:b", Content: "Second", CreatedAt: 2000}}, 2, nil
+		},
+	}
+
+	result, _ := List(context.Background(), mock, map[string]any{"node_type": "fact", "limit": float64(1), "response_format": "json"})
+	var got struct {
+		NodeType   string `json:"node_type"`
+		Total      int    `json:"total"`
+		Nodes      []Fact `json:"nodes"`
+		NextCursor string `json:"next_cursor"`
+	}
+	if err := json.Unmarshal([]byte(result.Text), &got); err != nil {
+		t.Fatalf("List() output is not JSON: %v\n%s", err, result.Text)
+	}
+	if got.NodeType != "fact" || got.Total != 2 || len(got.Nodes) != 1 || got.Nodes[0].ID != "fact:b" {
+		t.Errorf("List() JSON = %+v", got)
+	}
+	if c, err := DecodeListCursor(got.NextCursor); err != nil || c.ID != "fact:b" {
+		t.Errorf("next_cursor = %q (%v), want a cursor after fact:b", got.NextCursor, err)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Merge folds a duplicate entity into a surviving entity. The duplicate's
+// edges move to the survivor and its name becomes an alias of the survivor.
+func Merge(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
+	survivorID := GetStringArg(args, "survivor_id", "")
+	if survivorID == "" {
+		return NewError("Missing required parameter: survivor_id"), nil
+	}
+	duplicateID := GetStringArg(args, "duplicate_id", "")
+	if duplicateID == "" {
+		return NewError("Missing required parameter: duplicate_id"), nil
+	}
+
+	for _, id := range []string{survivorID, duplicateID} {
+		if !strings.HasPrefix(id, "ent:") {
+			return NewError(fmt.Sprintf("mie_merge requires entity IDs (prefix 'ent:'), got %q", id)), nil
+		}
+	}
+	if survivorID == duplicateID {
+		return NewError("survivor_id and duplicate_id must be different entities"), nil
+	}
+
+	names := map[string]string{}
+	for _, id := range []string{survivorID, duplicateID} {
+		node, err := client.GetNodeByID(ctx, id)
+		if err != nil {
+			return NewError(fmt.Sprintf("Node [%s] not found: %v", id, err)), nil
+		}
+		if ent, ok := node.(*Entity); ok {
+			names[id] = ent.Name
+		}
+	}
+
+	if err := client.MergeEntities(ctx, survivorID, duplicateID); err != nil {
+		return NewError(fmt.Sprintf("Failed to merge entities: %v", err)), nil
+	}
+
+	if WantsJSON(args) {
+		return NewJSONResult(mergeJSON{
+			SurvivorID:    survivorID,
+			DuplicateID:   duplicateID,
+			SurvivorName:  names[survivorID],
+			DuplicateName: names[duplicateID],
+		}), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Merged [%s] into [%s]\n", duplicateID, survivorID))
+	sb.WriteString("Fact, decision, and topic relationships now point to the surviving entity.\n")
+	if name := names[duplicateID]; name != "" {
+		sb.WriteString(fmt.Sprintf("%q is now an alias of %q.\n", name, names[survivorID]))
+	}
+	return NewResult(sb.String()), nil
+}
+
+// mergeJSON is the JSON response of Merge. DuplicateName is now an alias of
+// the survivor.
+type mergeJSON struct {
+	SurvivorID    string `json:"survivor_id"`
+	DuplicateID   string `json:"duplicate_id"`
+	SurvivorName  string `json:"survivor_name,omitempty"`
+	DuplicateName string `json:"duplicate_name,omitempty"`
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	var gotSurvivor, gotDuplicate string
+	mock := &MockQuerier{
+		GetNodeByIDFunc: func(ctx context.Context, nodeID string) (any, error) {
+			names := map[string]string{"ent:pg": "PostgreSQL", "ent:postgres": "Postgres"}
+			return &Entity{ID: nodeID, Name: names[nodeID]}, nil
+		},
+		MergeEntitiesFunc: func(ctx context.Context, survivorID, duplicateID string) error {
+			gotSurvivor, gotDuplicate = survivorID, duplicateID
+			return nil
+		},
+	}
+
+	result, err := Merge(context.Background(), mock, map[string]any{
+		"survivor_id":  "ent:pg",
+		"duplicate_id": "ent:postgres",
+	})
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Merge() returned error: %s", result.Text)
+	}
+	if gotSurvivor != "ent:pg" || gotDuplicate != "ent:postgres" {
+		t.Errorf("MergeEntities called with (%q, %q)", gotSurvivor, gotDuplicate)
+	}
+	for _, check := range []string{"Merged [ent:postgres] into [ent:pg]", `"Postgres" is now an alias of "PostgreSQL"`} {
+		if !strings.Contains(result.Text, check) {
+			t.Errorf("Merge() output missing %q, got: %s", check, result.Text)
+		}
+	}
+}
+
+func TestMerge_InvalidInput(t *testing.T) {
+	mock := &MockQuerier{
+		MergeEntitiesFunc: func(ctx context.Context, survivorID, duplicateID string) error {
+			t.Error("MergeEntities should not be called for invalid input")
+			return nil
+		},
+	}
+	tests := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"missing survivor", map[string]any{"duplicate_id": "ent:a"}, "survivor_id"},
+		{"missing duplicate", map[string]any{"survivor_id": "ent:a"}, "duplicate_id"},
+		{"non-entity", map[string]any{"survivor_id": "ent:a", "duplicate_id": "fact:b"}, "entity IDs"},
+		{"same entity", map[string]any{"survivor_id": "ent:a", "duplicate_id": "ent:a"}, "different"},
+	}
+	for _, tt := range tests {
+		result, _ := Merge(context.Background(), mock, tt.args)
+		if !result.IsError {
+			t.Errorf("%s: expected error", tt.name)
+		}
+		if !strings.Contains(result.Text, tt.want) {
+			t.Errorf("%s: expected %q in error, got: %s", tt.name, tt.want, result.Text)
+		}
+	}
+}
+
+func TestMerge_Failure(t *testing.T) {
+	mock := &MockQuerier{
+		GetNodeByIDFunc: func(ctx context.Context, nodeID string) (any, error) {
+			if nodeID == "ent:missing" {
+				return nil, fmt.Errorf("node %q not found", nodeID)
+			}
+			return &Entity{ID: nodeID}, nil
+		},
+		MergeEntitiesFunc: func(ctx context.Context, survivorID, duplicateID string) error {
+			return fmt.Errorf("db error")
+		},
+	}
+
+	result, _ := Merge(context.Background(), mock, map[string]any{"survivor_id": "ent:a", "duplicate_id": "ent:missing"})
+	if !result.IsError || !strings.Contains(result.Text, "ent:missing") {
+		t.Errorf("Merge() should report the missing entity, got: %s", result.Text)
+	}
+
+	result, _ = Merge(context.Background(), mock, map[string]any{"survivor_id": "ent:a", "duplicate_id": "ent:b"})
+	if !result.IsError || !strings.Contains(result.Text, "db error") {
+		t.Errorf("Merge() should surface merge errors, got: %s", result.Text)
+	}
+}
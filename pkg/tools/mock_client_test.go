@@ -4,7 +4,10 @@
 
 package tools
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 // MockQuerier is a mock implementation of the Querier interface for unit testing.
 type MockQuerier struct {
@@ -15,22 +18,60 @@ type MockQuerier struct {
 	StoreTopicFunc           func(ctx context.Context, req StoreTopicRequest) (*Topic, error)
 	InvalidateFactFunc       func(ctx context.Context, oldFactID, newFactID, reason string) error
 	AddRelationshipFunc      func(ctx context.Context, edgeType string, fields map[string]string) error
+	RemoveRelationshipFunc   func(ctx context.Context, edgeType string, fields map[string]string) error
 	SemanticSearchFunc       func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error)
 	ExactSearchFunc          func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error)
+	FullTextSearchFunc       func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error)
+	HybridSearchFunc         func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error)
+	SimilarNodesFunc         func(ctx context.Context, nodeID string, nodeTypes []string, limit int) ([]SearchResult, error)
 	GetNodeByIDFunc          func(ctx context.Context, nodeID string) (any, error)
 	ListNodesFunc            func(ctx context.Context, opts ListOptions) ([]any, int, error)
+	FindEntityByNameFunc     func(ctx context.Context, name string) (*Entity, error)
+	ListPreferencesFunc      func(ctx context.Context) ([]Fact, error)
 	GetRelatedEntitiesFunc   func(ctx context.Context, factID string) ([]Entity, error)
 	GetFactsAboutEntityFunc  func(ctx context.Context, entityID string) ([]Fact, error)
 	GetDecisionEntitiesFunc  func(ctx context.Context, decisionID string) ([]EntityWithRole, error)
 	GetInvalidationChainFunc func(ctx context.Context, factID string) ([]Invalidation, error)
 	GetRelatedFactsFunc      func(ctx context.Context, entityID string) ([]Fact, error)
 	GetEntityDecisionsFunc   func(ctx context.Context, entityID string) ([]Decision, error)
+	GetNodeEdgesFunc         func(ctx context.Context, nodeID string) ([]GraphEdge, error)
 	UpdateDescriptionFunc    func(ctx context.Context, nodeID, newDescription string) error
 	UpdateStatusFunc         func(ctx context.Context, nodeID, newStatus string) error
+	AddAliasFunc             func(ctx context.Context, entityID, alias string) error
+	RenameEntityFunc         func(ctx context.Context, entityID, newName string) error
+	SetArchivedFunc          func(ctx context.Context, nodeID string, archived bool) error
+	SetVerifiedFunc          func(ctx context.Context, factID, verifiedBy string, verified bool) error
+	MergeEntitiesFunc        func(ctx context.Context, survivorID, duplicateID string) error
+	AtomicFunc               func(ctx context.Context, fn func(ctx context.Context) error) error
 	DetectConflictsFunc      func(ctx context.Context, opts ConflictOptions) ([]Conflict, error)
 	CheckNewFactConflictsFunc func(ctx context.Context, content, category string) ([]Conflict, error)
+	FindDuplicatesFunc        func(ctx context.Context, opts DuplicateOptions) ([]DuplicateCluster, error)
 	GetStatsFunc             func(ctx context.Context) (*GraphStats, error)
+	GetTopicStatsFunc        func(ctx context.Context) ([]TopicStats, error)
+	SuggestTopicsFunc        func(ctx context.Context, text string, limit int) ([]TopicSuggestion, error)
+	GetEmbeddingStatusFunc   func(ctx context.Context) (*EmbeddingReport, error)
 	ExportGraphFunc          func(ctx context.Context, opts ExportOptions) (*ExportData, error)
+	GetAuditLogFunc          func(ctx context.Context, opts AuditOptions) ([]AuditEntry, error)
+	GetNodeHistoryFunc       func(ctx context.Context, nodeID string) ([]HistoryEntry, error)
+	RecordIntentFunc         func(ctx context.Context, kind, payload string) (string, error)
+	CompleteIntentFunc       func(ctx context.Context, intentID string) error
+	PendingIntentsFunc       func(ctx context.Context) ([]Intent, error)
+	CreateSnapshotFunc       func(ctx context.Context, label string) (*Snapshot, error)
+	ListSnapshotsFunc        func(ctx context.Context) ([]Snapshot, error)
+	RestoreSnapshotFunc      func(ctx context.Context, snapshotID string) (*Snapshot, error)
+	DeleteSnapshotFunc       func(ctx context.Context, snapshotID string) error
+	SaveSearchFunc           func(ctx context.Context, name string, args map[string]any) (*SavedSearch, error)
+	GetSavedSearchFunc       func(ctx context.Context, name string) (*SavedSearch, error)
+	ListSavedSearchesFunc    func(ctx context.Context) ([]SavedSearch, error)
+	DeleteSavedSearchFunc    func(ctx context.Context, name string) error
+	OpenSessionFunc          func(ctx context.Context, title, sourceAgent string) (*Session, error)
+	CloseSessionFunc         func(ctx context.Context, sessionID, summary string) (*Session, error)
+	GetSessionFunc           func(ctx context.Context, sessionID string) (*Session, error)
+	ListSessionsFunc         func(ctx context.Context) ([]Session, error)
+	AttachFunc               func(ctx context.Context, nodeID, name, mimeType string, data []byte) (*Attachment, error)
+	ListAttachmentsFunc      func(ctx context.Context, nodeID string) ([]Attachment, error)
+	ReadAttachmentFunc       func(ctx context.Context, hash string) (*Attachment, []byte, error)
+	DetachFunc               func(ctx context.Context, nodeID, hash string) error
 	IncrementCounterFunc     func(ctx context.Context, key string) error
 	EmbeddingsEnabledFunc    func() bool
 }
@@ -39,7 +80,7 @@ func (m *MockQuerier) StoreFact(ctx context.Context, req StoreFactRequest) (*Fac
 	if m.StoreFactFunc != nil {
 		return m.StoreFactFunc(ctx, req)
 	}
-	return &Fact{ID: "fact:mock0001", Content: req.Content, Category: req.Category, Confidence: req.Confidence, Valid: true, SourceAgent: req.SourceAgent, CreatedAt: 1000, UpdatedAt: 1000}, nil
+	return &Fact{ID: "fact:mock0001", Content: req.Content, Category: req.Category, Confidence: req.Confidence, Valid: true, SourceAgent: req.SourceAgent, CreatedAt: 1000, UpdatedAt: 1000, ExpiresAt: req.ExpiresAt}, nil
 }
 
 func (m *MockQuerier) StoreDecision(ctx context.Context, req StoreDecisionRequest) (*Decision, error) {
@@ -84,6 +125,13 @@ func (m *MockQuerier) AddRelationship(ctx context.Context, edgeType string, fiel
 	return nil
 }
 
+func (m *MockQuerier) RemoveRelationship(ctx context.Context, edgeType string, fields map[string]string) error {
+	if m.RemoveRelationshipFunc != nil {
+		return m.RemoveRelationshipFunc(ctx, edgeType, fields)
+	}
+	return nil
+}
+
 func (m *MockQuerier) SemanticSearch(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
 	if m.SemanticSearchFunc != nil {
 		return m.SemanticSearchFunc(ctx, query, nodeTypes, limit)
@@ -98,6 +146,27 @@ func (m *MockQuerier) ExactSearch(ctx context.Context, query string, nodeTypes [
 	return []SearchResult{}, nil
 }
 
+func (m *MockQuerier) FullTextSearch(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
+	if m.FullTextSearchFunc != nil {
+		return m.FullTextSearchFunc(ctx, query, nodeTypes, limit)
+	}
+	return []SearchResult{}, nil
+}
+
+func (m *MockQuerier) HybridSearch(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
+	if m.HybridSearchFunc != nil {
+		return m.HybridSearchFunc(ctx, query, nodeTypes, limit)
+	}
+	return []SearchResult{}, nil
+}
+
+func (m *MockQuerier) SimilarNodes(ctx context.Context, nodeID string, nodeTypes []string, limit int) ([]SearchResult, error) {
+	if m.SimilarNodesFunc != nil {
+		return m.SimilarNodesFunc(ctx, nodeID, nodeTypes, limit)
+	}
+	return []SearchResult{}, nil
+}
+
 func (m *MockQuerier) GetNodeByID(ctx context.Context, nodeID string) (any, error) {
 	if m.GetNodeByIDFunc != nil {
 		return m.GetNodeByIDFunc(ctx, nodeID)
@@ -112,6 +181,20 @@ func (m *MockQuerier) ListNodes(ctx context.Context, opts ListOptions) ([]any, i
 	return []any{}, 0, nil
 }
 
+func (m *MockQuerier) FindEntityByName(ctx context.Context, name string) (*Entity, error) {
+	if m.FindEntityByNameFunc != nil {
+		return m.FindEntityByNameFunc(ctx, name)
+	}
+	return nil, nil
+}
+
+func (m *MockQuerier) ListPreferences(ctx context.Context) ([]Fact, error) {
+	if m.ListPreferencesFunc != nil {
+		return m.ListPreferencesFunc(ctx)
+	}
+	return nil, nil
+}
+
 func (m *MockQuerier) GetRelatedEntities(ctx context.Context, factID string) ([]Entity, error) {
 	if m.GetRelatedEntitiesFunc != nil {
 		return m.GetRelatedEntitiesFunc(ctx, factID)
@@ -154,6 +237,13 @@ func (m *MockQuerier) GetEntityDecisions(ctx context.Context, entityID string) (
 	return []Decision{}, nil
 }
 
+func (m *MockQuerier) GetNodeEdges(ctx context.Context, nodeID string) ([]GraphEdge, error) {
+	if m.GetNodeEdgesFunc != nil {
+		return m.GetNodeEdgesFunc(ctx, nodeID)
+	}
+	return nil, nil
+}
+
 func (m *MockQuerier) UpdateDescription(ctx context.Context, nodeID, newDescription string) error {
 	if m.UpdateDescriptionFunc != nil {
 		return m.UpdateDescriptionFunc(ctx, nodeID, newDescription)
@@ -168,6 +258,48 @@ func (m *MockQuerier) UpdateStatus(ctx context.Context, nodeID, newStatus string
 	return nil
 }
 
+func (m *MockQuerier) AddAlias(ctx context.Context, entityID, alias string) error {
+	if m.AddAliasFunc != nil {
+		return m.AddAliasFunc(ctx, entityID, alias)
+	}
+	return nil
+}
+
+func (m *MockQuerier) RenameEntity(ctx context.Context, entityID, newName string) error {
+	if m.RenameEntityFunc != nil {
+		return m.RenameEntityFunc(ctx, entityID, newName)
+	}
+	return nil
+}
+
+func (m *MockQuerier) SetArchived(ctx context.Context, nodeID string, archived bool) error {
+	if m.SetArchivedFunc != nil {
+		return m.SetArchivedFunc(ctx, nodeID, archived)
+	}
+	return nil
+}
+
+func (m *MockQuerier) SetVerified(ctx context.Context, factID, verifiedBy string, verified bool) error {
+	if m.SetVerifiedFunc != nil {
+		return m.SetVerifiedFunc(ctx, factID, verifiedBy, verified)
+	}
+	return nil
+}
+
+func (m *MockQuerier) MergeEntities(ctx context.Context, survivorID, duplicateID string) error {
+	if m.MergeEntitiesFunc != nil {
+		return m.MergeEntitiesFunc(ctx, survivorID, duplicateID)
+	}
+	return nil
+}
+
+func (m *MockQuerier) Atomic(ctx context.Context, fn func(ctx context.Context) error) error {
+	if m.AtomicFunc != nil {
+		return m.AtomicFunc(ctx, fn)
+	}
+	return fn(ctx)
+}
+
 func (m *MockQuerier) DetectConflicts(ctx context.Context, opts ConflictOptions) ([]Conflict, error) {
 	if m.DetectConflictsFunc != nil {
 		return m.DetectConflictsFunc(ctx, opts)
@@ -182,6 +314,13 @@ func (m *MockQuerier) CheckNewFactConflicts(ctx context.Context, content, catego
 	return []Conflict{}, nil
 }
 
+func (m *MockQuerier) FindDuplicates(ctx context.Context, opts DuplicateOptions) ([]DuplicateCluster, error) {
+	if m.FindDuplicatesFunc != nil {
+		return m.FindDuplicatesFunc(ctx, opts)
+	}
+	return []DuplicateCluster{}, nil
+}
+
 func (m *MockQuerier) GetStats(ctx context.Context) (*GraphStats, error) {
 	if m.GetStatsFunc != nil {
 		return m.GetStatsFunc(ctx)
@@ -189,6 +328,27 @@ func (m *MockQuerier) GetStats(ctx context.Context) (*GraphStats, error) {
 	return &GraphStats{}, nil
 }
 
+func (m *MockQuerier) GetTopicStats(ctx context.Context) ([]TopicStats, error) {
+	if m.GetTopicStatsFunc != nil {
+		return m.GetTopicStatsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockQuerier) SuggestTopics(ctx context.Context, text string, limit int) ([]TopicSuggestion, error) {
+	if m.SuggestTopicsFunc != nil {
+		return m.SuggestTopicsFunc(ctx, text, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockQuerier) GetEmbeddingStatus(ctx context.Context) (*EmbeddingReport, error) {
+	if m.GetEmbeddingStatusFunc != nil {
+		return m.GetEmbeddingStatusFunc(ctx)
+	}
+	return &EmbeddingReport{}, nil
+}
+
 func (m *MockQuerier) ExportGraph(ctx context.Context, opts ExportOptions) (*ExportData, error) {
 	if m.ExportGraphFunc != nil {
 		return m.ExportGraphFunc(ctx, opts)
@@ -196,6 +356,153 @@ func (m *MockQuerier) ExportGraph(ctx context.Context, opts ExportOptions) (*Exp
 	return &ExportData{Version: "1", ExportedAt: "2026-02-05T00:00:00Z", Stats: map[string]int{}}, nil
 }
 
+func (m *MockQuerier) GetAuditLog(ctx context.Context, opts AuditOptions) ([]AuditEntry, error) {
+	if m.GetAuditLogFunc != nil {
+		return m.GetAuditLogFunc(ctx, opts)
+	}
+	return nil, nil
+}
+
+func (m *MockQuerier) GetNodeHistory(ctx context.Context, nodeID string) ([]HistoryEntry, error) {
+	if m.GetNodeHistoryFunc != nil {
+		return m.GetNodeHistoryFunc(ctx, nodeID)
+	}
+	return nil, nil
+}
+
+func (m *MockQuerier) RecordIntent(ctx context.Context, kind, payload string) (string, error) {
+	if m.RecordIntentFunc != nil {
+		return m.RecordIntentFunc(ctx, kind, payload)
+	}
+	return "int:mock", nil
+}
+
+func (m *MockQuerier) CompleteIntent(ctx context.Context, intentID string) error {
+	if m.CompleteIntentFunc != nil {
+		return m.CompleteIntentFunc(ctx, intentID)
+	}
+	return nil
+}
+
+func (m *MockQuerier) PendingIntents(ctx context.Context) ([]Intent, error) {
+	if m.PendingIntentsFunc != nil {
+		return m.PendingIntentsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockQuerier) CreateSnapshot(ctx context.Context, label string) (*Snapshot, error) {
+	if m.CreateSnapshotFunc != nil {
+		return m.CreateSnapshotFunc(ctx, label)
+	}
+	return &Snapshot{ID: "snap:mock", Label: label}, nil
+}
+
+func (m *MockQuerier) ListSnapshots(ctx context.Context) ([]Snapshot, error) {
+	if m.ListSnapshotsFunc != nil {
+		return m.ListSnapshotsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockQuerier) RestoreSnapshot(ctx context.Context, snapshotID string) (*Snapshot, error) {
+	if m.RestoreSnapshotFunc != nil {
+		return m.RestoreSnapshotFunc(ctx, snapshotID)
+	}
+	return &Snapshot{ID: "snap:checkpoint"}, nil
+}
+
+func (m *MockQuerier) DeleteSnapshot(ctx context.Context, snapshotID string) error {
+	if m.DeleteSnapshotFunc != nil {
+		return m.DeleteSnapshotFunc(ctx, snapshotID)
+	}
+	return nil
+}
+
+func (m *MockQuerier) SaveSearch(ctx context.Context, name string, args map[string]any) (*SavedSearch, error) {
+	if m.SaveSearchFunc != nil {
+		return m.SaveSearchFunc(ctx, name, args)
+	}
+	return &SavedSearch{Name: name, Args: args}, nil
+}
+
+func (m *MockQuerier) GetSavedSearch(ctx context.Context, name string) (*SavedSearch, error) {
+	if m.GetSavedSearchFunc != nil {
+		return m.GetSavedSearchFunc(ctx, name)
+	}
+	return nil, fmt.Errorf("saved search %q not found", name)
+}
+
+func (m *MockQuerier) ListSavedSearches(ctx context.Context) ([]SavedSearch, error) {
+	if m.ListSavedSearchesFunc != nil {
+		return m.ListSavedSearchesFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockQuerier) DeleteSavedSearch(ctx context.Context, name string) error {
+	if m.DeleteSavedSearchFunc != nil {
+		return m.DeleteSavedSearchFunc(ctx, name)
+	}
+	return nil
+}
+
+func (m *MockQuerier) OpenSession(ctx context.Context, title, sourceAgent string) (*Session, error) {
+	if m.OpenSessionFunc != nil {
+		return m.OpenSessionFunc(ctx, title, sourceAgent)
+	}
+	return &Session{ID: "ses:mock", Title: title, SourceAgent: sourceAgent, StartedAt: 1000}, nil
+}
+
+func (m *MockQuerier) CloseSession(ctx context.Context, sessionID, summary string) (*Session, error) {
+	if m.CloseSessionFunc != nil {
+		return m.CloseSessionFunc(ctx, sessionID, summary)
+	}
+	return &Session{ID: sessionID, StartedAt: 1000, EndedAt: 2000, Summary: summary}, nil
+}
+
+func (m *MockQuerier) GetSession(ctx context.Context, sessionID string) (*Session, error) {
+	if m.GetSessionFunc != nil {
+		return m.GetSessionFunc(ctx, sessionID)
+	}
+	return nil, fmt.Errorf("session %q not found", sessionID)
+}
+
+func (m *MockQuerier) ListSessions(ctx context.Context) ([]Session, error) {
+	if m.ListSessionsFunc != nil {
+		return m.ListSessionsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockQuerier) Attach(ctx context.Context, nodeID, name, mimeType string, data []byte) (*Attachment, error) {
+	if m.AttachFunc != nil {
+		return m.AttachFunc(ctx, nodeID, name, mimeType, data)
+	}
+	return &Attachment{Hash: "abc123", NodeID: nodeID, Name: name, MimeType: mimeType, Size: int64(len(data)), CreatedAt: 1000}, nil
+}
+
+func (m *MockQuerier) ListAttachments(ctx context.Context, nodeID string) ([]Attachment, error) {
+	if m.ListAttachmentsFunc != nil {
+		return m.ListAttachmentsFunc(ctx, nodeID)
+	}
+	return nil, nil
+}
+
+func (m *MockQuerier) ReadAttachment(ctx context.Context, hash string) (*Attachment, []byte, error) {
+	if m.ReadAttachmentFunc != nil {
+		return m.ReadAttachmentFunc(ctx, hash)
+	}
+	return nil, nil, fmt.Errorf("attachment %q not found", hash)
+}
+
+func (m *MockQuerier) Detach(ctx context.Context, nodeID, hash string) error {
+	if m.DetachFunc != nil {
+		return m.DetachFunc(ctx, nodeID, hash)
+	}
+	return nil
+}
+
 func (m *MockQuerier) IncrementCounter(ctx context.Context, key string) error {
 	if m.IncrementCounterFunc != nil {
 		return m.IncrementCounterFunc(ctx, key)
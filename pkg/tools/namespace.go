@@ -0,0 +1,103 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DefaultNamespace is the namespace used when none is configured or requested.
+const DefaultNamespace = "default"
+
+// maxNamespaceLength bounds namespace names so they stay usable as labels.
+const maxNamespaceLength = 64
+
+type namespaceKey struct{}
+
+// WithNamespace returns a copy of ctx that scopes memory operations to the
+// given namespace. An empty namespace returns ctx unchanged.
+func WithNamespace(ctx context.Context, namespace string) context.Context {
+	if namespace == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, namespaceKey{}, namespace)
+}
+
+// NamespaceFromContext returns the namespace carried by ctx, or "" if none was set.
+func NamespaceFromContext(ctx context.Context) string {
+	ns, _ := ctx.Value(namespaceKey{}).(string)
+	return ns
+}
+
+// ValidateNamespace checks that a namespace name only contains lowercase
+// letters, digits, '-', '_' and '.', and is at most 64 characters long.
+func ValidateNamespace(namespace string) error {
+	if namespace == "" {
+		return fmt.Errorf("namespace cannot be empty")
+	}
+	if len(namespace) > maxNamespaceLength {
+		return fmt.Errorf("namespace %q is longer than %d characters", namespace, maxNamespaceLength)
+	}
+	for _, r := range namespace {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+		default:
+			return fmt.Errorf("namespace %q contains invalid character %q; use lowercase letters, digits, '-', '_' or '.'", namespace, r)
+		}
+	}
+	return nil
+}
+
+// ProjectNamespace returns the namespace for a project or directory name:
+// the name lowercased, with each run of characters ValidateNamespace rejects
+// replaced by '-', and cut to 64 characters.
+func ProjectNamespace(project string) (string, error) {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(project) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			sb.WriteRune(r)
+			dash = false
+		case !dash:
+			sb.WriteByte('-')
+			dash = true
+		}
+	}
+	ns := strings.Trim(sb.String(), "-.")
+	if len(ns) > maxNamespaceLength {
+		ns = strings.TrimRight(ns[:maxNamespaceLength], "-.")
+	}
+	if ns == "" {
+		return "", fmt.Errorf("project %q has no letters or digits to name a namespace", project)
+	}
+	return ns, nil
+}
+
+// WithNamespaceArg scopes ctx to the optional "namespace" tool argument, or
+// to the namespace of the optional "project" argument (see ProjectNamespace).
+// When both are absent, ctx is returned unchanged.
+func WithNamespaceArg(ctx context.Context, args map[string]any) (context.Context, error) {
+	ns := GetStringArg(args, "namespace", "")
+	project := GetStringArg(args, "project", "")
+	if ns != "" && project != "" {
+		return ctx, fmt.Errorf("pass either namespace or project, not both")
+	}
+	if project != "" {
+		var err error
+		if ns, err = ProjectNamespace(project); err != nil {
+			return ctx, err
+		}
+	}
+	if ns == "" {
+		return ctx, nil
+	}
+	if err := ValidateNamespace(ns); err != nil {
+		return ctx, err
+	}
+	return WithNamespace(ctx, ns), nil
+}
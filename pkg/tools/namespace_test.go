@@ -0,0 +1,113 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestWithNamespace(t *testing.T) {
+	ctx := context.Background()
+	if got := NamespaceFromContext(ctx); got != "" {
+		t.Errorf("NamespaceFromContext(empty) = %q, want empty", got)
+	}
+
+	ctx = WithNamespace(ctx, "project-a")
+	if got := NamespaceFromContext(ctx); got != "project-a" {
+		t.Errorf("NamespaceFromContext() = %q, want %q", got, "project-a")
+	}
+
+	// An empty namespace must not clear an existing one.
+	ctx = WithNamespace(ctx, "")
+	if got := NamespaceFromContext(ctx); got != "project-a" {
+		t.Errorf("NamespaceFromContext() after empty = %q, want %q", got, "project-a")
+	}
+}
+
+func TestValidateNamespace(t *testing.T) {
+	tests := []struct {
+		ns      string
+		wantErr bool
+	}{
+		{"default", false},
+		{"my-project", false},
+		{"team_a.v2", false},
+		{"", true},
+		{"My-Project", true},
+		{"has space", true},
+		{"quote'd", true},
+		{strings.Repeat("a", 65), true},
+	}
+	for _, tt := range tests {
+		err := ValidateNamespace(tt.ns)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateNamespace(%q) error = %v, wantErr %v", tt.ns, err, tt.wantErr)
+		}
+	}
+}
+
+func TestWithNamespaceArg(t *testing.T) {
+	ctx, err := WithNamespaceArg(context.Background(), map[string]any{"namespace": "work"})
+	if err != nil {
+		t.Fatalf("WithNamespaceArg() error = %v", err)
+	}
+	if got := NamespaceFromContext(ctx); got != "work" {
+		t.Errorf("NamespaceFromContext() = %q, want %q", got, "work")
+	}
+
+	ctx, err = WithNamespaceArg(context.Background(), map[string]any{})
+	if err != nil {
+		t.Fatalf("WithNamespaceArg(missing) error = %v", err)
+	}
+	if got := NamespaceFromContext(ctx); got != "" {
+		t.Errorf("NamespaceFromContext(missing) = %q, want empty", got)
+	}
+
+	if _, err := WithNamespaceArg(context.Background(), map[string]any{"namespace": "Bad Name"}); err == nil {
+		t.Error("WithNamespaceArg() should reject invalid namespace")
+	}
+}
+
+func TestProjectNamespace(t *testing.T) {
+	tests := []struct {
+		project string
+		want    string
+		wantErr bool
+	}{
+		{"mie", "mie", false},
+		{"My Repo", "my-repo", false},
+		{"kraklabs/MIE (fork)", "kraklabs-mie-fork", false},
+		{"api.v2", "api.v2", false},
+		{strings.Repeat("a", 70), strings.Repeat("a", 64), false},
+		{"", "", true},
+		{"***", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ProjectNamespace(tt.project)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ProjectNamespace(%q) error = %v, wantErr %v", tt.project, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ProjectNamespace(%q) = %q, want %q", tt.project, got, tt.want)
+		}
+	}
+}
+
+func TestWithNamespaceArgProject(t *testing.T) {
+	ctx, err := WithNamespaceArg(context.Background(), map[string]any{"project": "My Repo"})
+	if err != nil {
+		t.Fatalf("WithNamespaceArg() error = %v", err)
+	}
+	if got := NamespaceFromContext(ctx); got != "my-repo" {
+		t.Errorf("NamespaceFromContext() = %q, want %q", got, "my-repo")
+	}
+
+	if _, err := WithNamespaceArg(context.Background(), map[string]any{"project": "a", "namespace": "b"}); err == nil {
+		t.Error("WithNamespaceArg() should reject both project and namespace")
+	}
+}
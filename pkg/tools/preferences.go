@@ -0,0 +1,63 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Preferences lists the current value of each preference key, optionally
+// only the one named by the "key" argument.
+func Preferences(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
+	key := strings.ToLower(strings.TrimSpace(GetStringArg(args, "key", "")))
+
+	ctx = WithIncludeArchived(ctx, GetBoolArg(args, "include_archived", false))
+	facts, err := client.ListPreferences(ctx)
+	if err != nil {
+		return NewError(fmt.Sprintf("Failed to list preferences: %v", err)), nil
+	}
+	_ = client.IncrementCounter(ctx, "total_queries")
+
+	// Facts come ordered by key, newest first; the first of each key is
+	// its current value.
+	current := make([]Fact, 0, len(facts))
+	for _, f := range facts {
+		if key != "" && f.Key != key {
+			continue
+		}
+		if n := len(current); n > 0 && current[n-1].Key == f.Key {
+			continue
+		}
+		current = append(current, f)
+	}
+
+	if WantsJSON(args) {
+		return NewJSONResult(preferencesJSON{Preferences: current}), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Preferences\n\n")
+	if len(current) == 0 {
+		if key != "" {
+			fmt.Fprintf(&sb, "_No preference stored for key %q._\n", key)
+		} else {
+			sb.WriteString("_No keyed preferences. Store a preference fact with a key to track its current value._\n")
+		}
+		return NewResult(sb.String()), nil
+	}
+	for _, f := range current {
+		fmt.Fprintf(&sb, "- **%s**: %s [%s] (since %s)\n",
+			f.Key, f.Content, f.ID, time.Unix(f.CreatedAt, 0).UTC().Format("2006-01-02"))
+	}
+	return NewResult(sb.String()), nil
+}
+
+// preferencesJSON is the JSON response of Preferences.
+type preferencesJSON struct {
+	Preferences []Fact `json:"preferences"`
+}
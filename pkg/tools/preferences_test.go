@@ -0,0 +1,54 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPreferences(t *testing.T) {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC).Unix()
+	mock := &MockQuerier{
+		ListPreferencesFunc: func(ctx context.Context) ([]Fact, error) {
+			return []Fact{
+				{ID: "fact:b", Content: "User edits in Helix", Key: "editor", CreatedAt: day + 86400},
+				{ID: "fact:a", Content: "User edits in Vim", Key: "editor", CreatedAt: day},
+				{ID: "fact:c", Content: "User prefers a dark theme", Key: "theme", CreatedAt: day},
+			}, nil
+		},
+	}
+
+	result, err := Preferences(context.Background(), mock, map[string]any{})
+	if err != nil {
+		t.Fatalf("Preferences() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Preferences() returned error: %s", result.Text)
+	}
+	if !strings.Contains(result.Text, "- **editor**: User edits in Helix [fact:b] (since 2026-03-02)") {
+		t.Errorf("missing current editor value:\n%s", result.Text)
+	}
+	if strings.Contains(result.Text, "Vim") {
+		t.Errorf("only the newest value of a key should be listed:\n%s", result.Text)
+	}
+
+	result, _ = Preferences(context.Background(), mock, map[string]any{"key": "Theme", "response_format": "json"})
+	var out preferencesJSON
+	if err := json.Unmarshal([]byte(result.Text), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result.Text)
+	}
+	if len(out.Preferences) != 1 || out.Preferences[0].ID != "fact:c" {
+		t.Errorf("key filter = %+v, want only fact:c", out.Preferences)
+	}
+
+	result, _ = Preferences(context.Background(), mock, map[string]any{"key": "shell"})
+	if !strings.Contains(result.Text, `No preference stored for key "shell"`) {
+		t.Errorf("missing empty note:\n%s", result.Text)
+	}
+}
@@ -8,10 +8,20 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 )
 
-// Query reads from the memory graph. Supports semantic search, exact lookup, and graph traversal.
+// Query reads from the memory graph. Supports semantic search, exact lookup, full-text search, hybrid search, and graph traversal.
+// The actions save, list, run, and delete manage searches saved under a name.
 func Query(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
+	switch action := GetStringArg(args, "action", DefaultActions["mie_query"]); action {
+	case "search":
+	case "save", "list", "run", "delete":
+		return savedSearch(ctx, client, action, args)
+	default:
+		return NewError(fmt.Sprintf("Invalid action %q. Must be one of: search, save, list, run, delete", action)), nil
+	}
+
 	query := GetStringArg(args, "query", "")
 	if query == "" {
 		return NewError("Missing required parameter: query"), nil
@@ -27,17 +37,36 @@ func Query(ctx context.Context, client Querier, args map[string]any) (*ToolResul
 		limit = 50
 	}
 
+	timeRange, err := ParseTimeRangeArgs(args)
+	if err != nil {
+		return NewError(err.Error()), nil
+	}
+	ctx = WithTimeRange(ctx, timeRange)
+	ctx = WithIncludeArchived(ctx, GetBoolArg(args, "include_archived", false))
+	ctx = WithSourceAgent(ctx, GetStringArg(args, "source_agent", ""))
+	if _, ok := args["min_similarity"]; ok {
+		minSimilarity := GetFloat64Arg(args, "min_similarity", -1)
+		if err := ValidateMinSimilarity(minSimilarity); err != nil {
+			return NewError(err.Error()), nil
+		}
+		ctx = WithMinSimilarity(ctx, minSimilarity)
+	}
+
+	asJSON := WantsJSON(args)
 	var result *ToolResult
-	var err error
 	switch mode {
 	case "semantic":
-		result, err = querySemanticMode(ctx, client, query, nodeTypes, limit)
+		result, err = querySemanticMode(ctx, client, query, nodeTypes, limit, asJSON)
 	case "exact":
-		result, err = queryExactMode(ctx, client, query, nodeTypes, limit)
+		result, err = queryExactMode(ctx, client, query, nodeTypes, limit, asJSON)
+	case "fulltext":
+		result, err = queryFullTextMode(ctx, client, query, nodeTypes, limit, asJSON)
+	case "hybrid":
+		result, err = queryHybridMode(ctx, client, query, nodeTypes, limit, asJSON)
 	case "graph":
 		result, err = queryGraphMode(ctx, client, args)
 	default:
-		return NewError(fmt.Sprintf("Invalid mode %q. Must be one of: semantic, exact, graph", mode)), nil
+		return NewError(fmt.Sprintf("Invalid mode %q. Must be one of: semantic, exact, fulltext, hybrid, graph", mode)), nil
 	}
 
 	// Increment usage counter on success (never fail the main operation).
@@ -48,7 +77,22 @@ func Query(ctx context.Context, client Querier, args map[string]any) (*ToolResul
 	return result, err
 }
 
-func querySemanticMode(ctx context.Context, client Querier, query string, nodeTypes []string, limit int) (*ToolResult, error) {
+// searchJSON is the JSON response of Query in a search mode.
+type searchJSON struct {
+	Mode    string         `json:"mode"`
+	Query   string         `json:"query"`
+	Results []SearchResult `json:"results"`
+}
+
+// newSearchJSONResult returns search results as a JSON tool result.
+func newSearchJSONResult(mode, query string, results []SearchResult) *ToolResult {
+	if results == nil {
+		results = []SearchResult{}
+	}
+	return NewJSONResult(searchJSON{Mode: mode, Query: query, Results: results})
+}
+
+func querySemanticMode(ctx context.Context, client Querier, query string, nodeTypes []string, limit int, asJSON bool) (*ToolResult, error) {
 	if !client.EmbeddingsEnabled() {
 		return NewError("Semantic search requires embeddings to be enabled. Enable in config or use mode=exact."), nil
 	}
@@ -57,6 +101,9 @@ func querySemanticMode(ctx context.Context, client Querier, query string, nodeTy
 	if err != nil {
 		return NewError(fmt.Sprintf("Semantic search failed: %v", err)), nil
 	}
+	if asJSON {
+		return newSearchJSONResult("semantic", query, results), nil
+	}
 
 	if len(results) == 0 {
 		return NewResult(fmt.Sprintf("## Memory Search Results for: %q\n\n_No results found._\n", query)), nil
@@ -88,6 +135,14 @@ func querySemanticMode(ctx context.Context, client Querier, query string, nodeTy
 			if item.Detail != "" {
 				sb.WriteString(fmt.Sprintf("   %s\n", item.Detail))
 			}
+			if item.EffectiveConfidence > 0 {
+				sb.WriteString(fmt.Sprintf("   Effective confidence: %.2f\n", item.EffectiveConfidence))
+			}
+			if f, ok := item.Metadata.(*Fact); ok {
+				writeNegation(&sb, f)
+				writeVerification(&sb, f)
+				writeExpiry(&sb, f)
+			}
 		}
 		sb.WriteString("\n")
 	}
@@ -95,11 +150,14 @@ func querySemanticMode(ctx context.Context, client Querier, query string, nodeTy
 	return NewResult(sb.String()), nil
 }
 
-func queryExactMode(ctx context.Context, client Querier, query string, nodeTypes []string, limit int) (*ToolResult, error) {
+func queryExactMode(ctx context.Context, client Querier, query string, nodeTypes []string, limit int, asJSON bool) (*ToolResult, error) {
 	results, err := client.ExactSearch(ctx, query, nodeTypes, limit)
 	if err != nil {
 		return NewError(fmt.Sprintf("Exact search failed: %v", err)), nil
 	}
+	if asJSON {
+		return newSearchJSONResult("exact", query, results), nil
+	}
 
 	if len(results) == 0 {
 		return NewResult(fmt.Sprintf("## Exact Search Results for: %q\n\n_No results found._\n", query)), nil
@@ -128,6 +186,119 @@ func queryExactMode(ctx context.Context, client Querier, query string, nodeTypes
 			if item.Detail != "" {
 				sb.WriteString(fmt.Sprintf("   %s\n", item.Detail))
 			}
+			if item.EffectiveConfidence > 0 {
+				sb.WriteString(fmt.Sprintf("   Effective confidence: %.2f\n", item.EffectiveConfidence))
+			}
+			if f, ok := item.Metadata.(*Fact); ok {
+				writeNegation(&sb, f)
+				writeVerification(&sb, f)
+				writeExpiry(&sb, f)
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	return NewResult(sb.String()), nil
+}
+
+func queryFullTextMode(ctx context.Context, client Querier, query string, nodeTypes []string, limit int, asJSON bool) (*ToolResult, error) {
+	results, err := client.FullTextSearch(ctx, query, nodeTypes, limit)
+	if err != nil {
+		return NewError(fmt.Sprintf("Full-text search failed: %v", err)), nil
+	}
+	if asJSON {
+		return newSearchJSONResult("fulltext", query, results), nil
+	}
+
+	if len(results) == 0 {
+		return NewResult(fmt.Sprintf("## Full-Text Search Results for: %q\n\n_No results found._\n", query)), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("## Full-Text Search Results for: %q\n\n", query))
+
+	grouped := map[string][]SearchResult{}
+	for _, r := range results {
+		grouped[r.NodeType] = append(grouped[r.NodeType], r)
+	}
+
+	typeLabels := map[string]string{
+		"fact": "Facts", "decision": "Decisions", "entity": "Entities", "event": "Events", "topic": "Topics",
+	}
+
+	for _, nt := range nodeTypes {
+		items, ok := grouped[nt]
+		if !ok || len(items) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("### %s (%d results)\n", typeLabels[nt], len(items)))
+		for i, item := range items {
+			sb.WriteString(fmt.Sprintf("%d. [%s] %q (score %.2f)\n", i+1, item.ID, Truncate(item.Content, 100), item.Score))
+			if item.Detail != "" {
+				sb.WriteString(fmt.Sprintf("   %s\n", item.Detail))
+			}
+			if item.EffectiveConfidence > 0 {
+				sb.WriteString(fmt.Sprintf("   Effective confidence: %.2f\n", item.EffectiveConfidence))
+			}
+			if f, ok := item.Metadata.(*Fact); ok {
+				writeNegation(&sb, f)
+				writeVerification(&sb, f)
+				writeExpiry(&sb, f)
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	return NewResult(sb.String()), nil
+}
+
+func queryHybridMode(ctx context.Context, client Querier, query string, nodeTypes []string, limit int, asJSON bool) (*ToolResult, error) {
+	results, err := client.HybridSearch(ctx, query, nodeTypes, limit)
+	if err != nil {
+		return NewError(fmt.Sprintf("Hybrid search failed: %v", err)), nil
+	}
+	if asJSON {
+		return newSearchJSONResult("hybrid", query, results), nil
+	}
+
+	if len(results) == 0 {
+		return NewResult(fmt.Sprintf("## Hybrid Search Results for: %q\n\n_No results found._\n", query)), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("## Hybrid Search Results for: %q\n\n", query))
+	if !client.EmbeddingsEnabled() {
+		sb.WriteString("_Embeddings disabled: showing exact matches only._\n\n")
+	}
+
+	grouped := map[string][]SearchResult{}
+	for _, r := range results {
+		grouped[r.NodeType] = append(grouped[r.NodeType], r)
+	}
+
+	typeLabels := map[string]string{
+		"fact": "Facts", "decision": "Decisions", "entity": "Entities", "event": "Events", "topic": "Topics",
+	}
+
+	for _, nt := range nodeTypes {
+		items, ok := grouped[nt]
+		if !ok || len(items) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("### %s (%d results)\n", typeLabels[nt], len(items)))
+		for i, item := range items {
+			sb.WriteString(fmt.Sprintf("%d. [%s] %q (%s)\n", i+1, item.ID, Truncate(item.Content, 100), hybridMatchLabel(item)))
+			if item.Detail != "" {
+				sb.WriteString(fmt.Sprintf("   %s\n", item.Detail))
+			}
+			if item.EffectiveConfidence > 0 {
+				sb.WriteString(fmt.Sprintf("   Effective confidence: %.2f\n", item.EffectiveConfidence))
+			}
+			if f, ok := item.Metadata.(*Fact); ok {
+				writeNegation(&sb, f)
+				writeVerification(&sb, f)
+				writeExpiry(&sb, f)
+			}
 		}
 		sb.WriteString("\n")
 	}
@@ -135,6 +306,19 @@ func queryExactMode(ctx context.Context, client Querier, query string, nodeTypes
 	return NewResult(sb.String()), nil
 }
 
+// hybridMatchLabel describes which search modes found a hybrid result,
+// including the semantic similarity when available.
+func hybridMatchLabel(item SearchResult) string {
+	label := strings.Join(item.MatchedBy, "+")
+	for _, src := range item.MatchedBy {
+		if src == "semantic" {
+			label += fmt.Sprintf(", %d%% similar", SimilarityPercent(item.Distance))
+			break
+		}
+	}
+	return label
+}
+
 func queryGraphMode(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
 	nodeID := GetStringArg(args, "node_id", "")
 	if nodeID == "" {
@@ -146,21 +330,41 @@ func queryGraphMode(ctx context.Context, client Querier, args map[string]any) (*
 		return NewError("traversal is required for graph mode"), nil
 	}
 
+	var entityName string
+	if name, ok := strings.CutPrefix(nodeID, entityNamePrefix); ok {
+		if !entityTraversals[traversal] {
+			return NewError(fmt.Sprintf("node_id %q names an entity, so traversal must be one of: related_facts, facts_about_entity, entity_decisions", nodeID)), nil
+		}
+		entityName = strings.TrimSpace(name)
+		ent, err := client.FindEntityByName(ctx, entityName)
+		if err != nil {
+			return NewError(fmt.Sprintf("Failed to find entity %q: %v", entityName, err)), nil
+		}
+		if ent == nil {
+			return NewError(fmt.Sprintf("No entity named %q", entityName)), nil
+		}
+		nodeID = ent.ID
+	}
+
 	var sb strings.Builder
 	fmt.Fprintf(&sb, "## Graph Traversal: %s from [%s]\n\n", traversal, nodeID)
+	if entityName != "" {
+		fmt.Fprintf(&sb, "_Resolved %q to entity [%s]._\n\n", entityName, nodeID)
+	}
 
+	var rows any
 	var err error
 	switch traversal {
 	case "related_entities":
-		err = traverseRelatedEntities(ctx, client, &sb, nodeID)
+		rows, err = traverseRelatedEntities(ctx, client, &sb, nodeID)
 	case "related_facts", "facts_about_entity":
-		err = traverseRelatedFacts(ctx, client, &sb, nodeID)
+		rows, err = traverseRelatedFacts(ctx, client, &sb, nodeID)
 	case "invalidation_chain":
-		err = traverseInvalidationChain(ctx, client, &sb, nodeID)
+		rows, err = traverseInvalidationChain(ctx, client, &sb, nodeID)
 	case "decision_entities":
-		err = traverseDecisionEntities(ctx, client, &sb, nodeID)
+		rows, err = traverseDecisionEntities(ctx, client, &sb, nodeID)
 	case "entity_decisions":
-		err = traverseEntityDecisions(ctx, client, &sb, nodeID)
+		rows, err = traverseEntityDecisions(ctx, client, &sb, nodeID)
 	default:
 		return NewError(fmt.Sprintf("Invalid traversal type %q. Must be one of: related_entities, related_facts, invalidation_chain, decision_entities, facts_about_entity, entity_decisions", traversal)), nil
 	}
@@ -169,35 +373,62 @@ func queryGraphMode(ctx context.Context, client Querier, args map[string]any) (*
 		return NewError(fmt.Sprintf("Traversal failed: %v", err)), nil
 	}
 
+	if WantsJSON(args) {
+		return NewJSONResult(graphJSON{Mode: "graph", NodeID: nodeID, EntityName: entityName, Traversal: traversal, Results: rows}), nil
+	}
 	return NewResult(sb.String()), nil
 }
 
-func traverseRelatedEntities(ctx context.Context, client Querier, sb *strings.Builder, nodeID string) error {
+// graphJSON is the JSON response of Query in graph mode. Results holds the
+// rows of the traversal: entities, facts, decisions, or invalidations.
+// EntityName is the name given after "name:", when the entity was looked up
+// by name.
+type graphJSON struct {
+	Mode       string `json:"mode"`
+	NodeID     string `json:"node_id"`
+	EntityName string `json:"entity_name,omitempty"`
+	Traversal  string `json:"traversal"`
+	Results    any    `json:"results"`
+}
+
+// entityNamePrefix marks a graph mode node_id that gives an entity's name
+// or alias instead of its ID.
+const entityNamePrefix = "name:"
+
+// entityTraversals are the graph traversals that start from an entity.
+var entityTraversals = map[string]bool{
+	"related_facts":      true,
+	"facts_about_entity": true,
+	"entity_decisions":   true,
+}
+
+func traverseRelatedEntities(ctx context.Context, client Querier, sb *strings.Builder, nodeID string) (any, error) {
 	entities, err := client.GetRelatedEntities(ctx, nodeID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(entities) == 0 {
 		sb.WriteString("_No related entities found._\n")
-		return nil
+		return []Entity{}, nil
 	}
 	for i, e := range entities {
 		fmt.Fprintf(sb, "%d. [%s] %q (kind: %s)\n", i+1, e.ID, e.Name, e.Kind)
 		if e.Description != "" {
 			fmt.Fprintf(sb, "   %s\n", Truncate(e.Description, 100))
 		}
+		writeEdgeMeta(sb, e.Edge)
 	}
-	return nil
+	return entities, nil
 }
 
-func traverseRelatedFacts(ctx context.Context, client Querier, sb *strings.Builder, nodeID string) error {
+func traverseRelatedFacts(ctx context.Context, client Querier, sb *strings.Builder, nodeID string) (any, error) {
 	facts, err := client.GetFactsAboutEntity(ctx, nodeID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(facts) == 0 {
 		sb.WriteString("_No related facts found._\n")
-		return nil
+		return []Fact{}, nil
 	}
 	for i, f := range facts {
 		validStr := "valid"
@@ -206,18 +437,22 @@ func traverseRelatedFacts(ctx context.Context, client Querier, sb *strings.Build
 		}
 		fmt.Fprintf(sb, "%d. [%s] %q (category: %s, confidence: %.1f, %s)\n",
 			i+1, f.ID, Truncate(f.Content, 100), f.Category, f.Confidence, validStr)
+		writeNegation(sb, &f)
+		writeVerification(sb, &f)
+		writeExpiry(sb, &f)
+		writeEdgeMeta(sb, f.Edge)
 	}
-	return nil
+	return facts, nil
 }
 
-func traverseInvalidationChain(ctx context.Context, client Querier, sb *strings.Builder, nodeID string) error {
+func traverseInvalidationChain(ctx context.Context, client Querier, sb *strings.Builder, nodeID string) (any, error) {
 	chain, err := client.GetInvalidationChain(ctx, nodeID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(chain) == 0 {
 		sb.WriteString("_No invalidation chain found._\n")
-		return nil
+		return []Invalidation{}, nil
 	}
 	for i, inv := range chain {
 		fmt.Fprintf(sb, "%d. [%s] -> [%s]\n", i+1, inv.NewFactID, inv.OldFactID)
@@ -228,38 +463,88 @@ func traverseInvalidationChain(ctx context.Context, client Querier, sb *strings.
 		if inv.NewContent != "" {
 			fmt.Fprintf(sb, "   New: %q\n", Truncate(inv.NewContent, 80))
 		}
+		writeEdgeMeta(sb, inv.Edge)
 	}
-	return nil
+	return chain, nil
 }
 
-func traverseDecisionEntities(ctx context.Context, client Querier, sb *strings.Builder, nodeID string) error {
+func traverseDecisionEntities(ctx context.Context, client Querier, sb *strings.Builder, nodeID string) (any, error) {
 	entities, err := client.GetDecisionEntities(ctx, nodeID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(entities) == 0 {
 		sb.WriteString("_No related entities found for this decision._\n")
-		return nil
+		return []EntityWithRole{}, nil
 	}
 	for i, e := range entities {
 		fmt.Fprintf(sb, "%d. [%s] %q (kind: %s, role: %s)\n",
 			i+1, e.ID, e.Name, e.Kind, e.Role)
+		writeEdgeMeta(sb, e.Edge)
 	}
-	return nil
+	return entities, nil
 }
 
-func traverseEntityDecisions(ctx context.Context, client Querier, sb *strings.Builder, nodeID string) error {
+func traverseEntityDecisions(ctx context.Context, client Querier, sb *strings.Builder, nodeID string) (any, error) {
 	decisions, err := client.GetEntityDecisions(ctx, nodeID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(decisions) == 0 {
 		sb.WriteString("_No related decisions found for this entity._\n")
-		return nil
+		return []Decision{}, nil
 	}
 	for i, d := range decisions {
 		fmt.Fprintf(sb, "%d. [%s] %q (status: %s)\n",
 			i+1, d.ID, Truncate(d.Title, 100), d.Status)
+		writeEdgeMeta(sb, d.Edge)
 	}
-	return nil
-}
\ No newline at end of file
+	return decisions, nil
+}
+
+// writeEdgeMeta writes the weight, creating agent, and creation date of the
+// edge a traversal followed. Edges that predate edge metadata show nothing.
+func writeEdgeMeta(sb *strings.Builder, e *EdgeMeta) {
+	if e == nil || (e.Weight == 1 && e.SourceAgent == "" && e.CreatedAt == 0) {
+		return
+	}
+	fmt.Fprintf(sb, "   Edge: weight %.2f", e.Weight)
+	if e.SourceAgent != "" {
+		fmt.Fprintf(sb, ", by %s", e.SourceAgent)
+	}
+	if e.CreatedAt != 0 {
+		fmt.Fprintf(sb, ", %s", time.Unix(e.CreatedAt, 0).UTC().Format("2006-01-02"))
+	}
+	sb.WriteString("\n")
+}
+
+// writeNegation marks an anti-fact, whose content does not hold.
+func writeNegation(sb *strings.Builder, f *Fact) {
+	if f.Negated {
+		sb.WriteString("   Negated: this does NOT hold\n")
+	}
+}
+
+// writeVerification prints who verified a fact, if anyone did.
+func writeVerification(sb *strings.Builder, f *Fact) {
+	if !f.Verified {
+		return
+	}
+	fmt.Fprintf(sb, "   Verified by %s", f.VerifiedBy)
+	if f.VerifiedAt != 0 {
+		fmt.Fprintf(sb, " on %s", time.Unix(f.VerifiedAt, 0).UTC().Format("2006-01-02"))
+	}
+	sb.WriteString("\n")
+}
+
+// writeExpiry prints when a temporary fact expires or expired.
+func writeExpiry(sb *strings.Builder, f *Fact) {
+	if f.ExpiresAt == 0 {
+		return
+	}
+	verb := "Expires"
+	if f.ExpiresAt <= time.Now().Unix() {
+		verb = "Expired"
+	}
+	fmt.Fprintf(sb, "   %s %s\n", verb, time.Unix(f.ExpiresAt, 0).UTC().Format("2006-01-02 15:04"))
+}
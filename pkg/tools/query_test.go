@@ -6,6 +6,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"strings"
 	"testing"
 )
@@ -117,6 +118,33 @@ func TestQuery_GraphMode(t *testing.T) {
 	}
 }
 
+func TestQuery_GraphMode_EdgeMeta(t *testing.T) {
+	mock := &MockQuerier{
+		GetEntityDecisionsFunc: func(ctx context.Context, entityID string) ([]Decision, error) {
+			return []Decision{
+				{ID: "dec:new", Title: "Use Kafka", Status: "active", Edge: &EdgeMeta{Weight: 0.9, SourceAgent: "claude", CreatedAt: 1772323200}},
+				{ID: "dec:old", Title: "Use RabbitMQ", Status: "superseded", Edge: &EdgeMeta{Weight: 1}},
+			}, nil
+		},
+	}
+
+	result, _ := Query(context.Background(), mock, map[string]any{
+		"query":     "decisions",
+		"mode":      "graph",
+		"node_id":   "ent:queue",
+		"traversal": "entity_decisions",
+	})
+	if result.IsError {
+		t.Fatalf("Query() returned error: %s", result.Text)
+	}
+	if !strings.Contains(result.Text, "Edge: weight 0.90, by claude, 2026-03-01") {
+		t.Errorf("Query() should show edge metadata, got:\n%s", result.Text)
+	}
+	if strings.Count(result.Text, "Edge:") != 1 {
+		t.Errorf("Query() should omit metadata for edges that predate it, got:\n%s", result.Text)
+	}
+}
+
 func TestQuery_GraphMode_InvalidationChain(t *testing.T) {
 	mock := &MockQuerier{
 		GetInvalidationChainFunc: func(ctx context.Context, factID string) ([]Invalidation, error) {
@@ -146,6 +174,64 @@ func TestQuery_GraphMode_InvalidationChain(t *testing.T) {
 	}
 }
 
+func TestQuery_GraphMode_EntityName(t *testing.T) {
+	var lookedUp, traversed string
+	mock := &MockQuerier{
+		FindEntityByNameFunc: func(ctx context.Context, name string) (*Entity, error) {
+			lookedUp = name
+			if name == "Postgres" {
+				return &Entity{ID: "ent:pg", Name: "PostgreSQL", Kind: "technology"}, nil
+			}
+			return nil, nil
+		},
+		GetFactsAboutEntityFunc: func(ctx context.Context, entityID string) ([]Fact, error) {
+			traversed = entityID
+			return []Fact{{ID: "fact:a", Content: "Prod runs on PostgreSQL 16", Category: "technical", Confidence: 0.9, Valid: true}}, nil
+		},
+	}
+
+	result, _ := Query(context.Background(), mock, map[string]any{
+		"query":     "facts",
+		"mode":      "graph",
+		"node_id":   "name: Postgres",
+		"traversal": "facts_about_entity",
+	})
+	if result.IsError {
+		t.Fatalf("Query() returned error: %s", result.Text)
+	}
+	if lookedUp != "Postgres" || traversed != "ent:pg" {
+		t.Errorf("looked up %q and traversed %q, want Postgres and ent:pg", lookedUp, traversed)
+	}
+	if !strings.Contains(result.Text, `Resolved "Postgres" to entity [ent:pg]`) || !strings.Contains(result.Text, "PostgreSQL 16") {
+		t.Errorf("Query() should show the resolved entity and its facts, got:\n%s", result.Text)
+	}
+
+	result, _ = Query(context.Background(), mock, map[string]any{
+		"query":           "facts",
+		"mode":            "graph",
+		"node_id":         "name:Postgres",
+		"traversal":       "related_facts",
+		"response_format": "json",
+	})
+	var resp graphJSON
+	if err := json.Unmarshal([]byte(result.Text), &resp); err != nil {
+		t.Fatalf("response is not JSON: %v\n%s", err, result.Text)
+	}
+	if resp.NodeID != "ent:pg" || resp.EntityName != "Postgres" {
+		t.Errorf("JSON response = %+v, want node_id ent:pg and entity_name Postgres", resp)
+	}
+
+	for _, args := range []map[string]any{
+		{"node_id": "name:MySQL", "traversal": "entity_decisions"},
+		{"node_id": "name:Postgres", "traversal": "related_entities"},
+	} {
+		args["query"], args["mode"] = "traverse", "graph"
+		if result, _ := Query(context.Background(), mock, args); !result.IsError {
+			t.Errorf("Query(%v) should fail, got:\n%s", args, result.Text)
+		}
+	}
+}
+
 func TestQuery_GraphMode_MissingNodeID(t *testing.T) {
 	mock := &MockQuerier{}
 	result, _ := Query(context.Background(), mock, map[string]any{
@@ -187,6 +273,215 @@ func TestQuery_InvalidMode(t *testing.T) {
 	if !result.IsError {
 		t.Error("Query() should return error for invalid mode")
 	}
+	if !strings.Contains(result.Text, "hybrid") {
+		t.Errorf("Invalid mode error should list hybrid, got: %s", result.Text)
+	}
+}
+
+func TestQuery_HybridMode(t *testing.T) {
+	mock := &MockQuerier{
+		HybridSearchFunc: func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
+			return []SearchResult{
+				{NodeType: "fact", ID: "fact:abc", Content: "Uses PostgreSQL", Distance: 0.1, Score: 0.03, MatchedBy: []string{"semantic", "exact"}},
+				{NodeType: "entity", ID: "ent:pg", Content: "PostgreSQL", Score: 0.016, MatchedBy: []string{"exact"}},
+			}, nil
+		},
+		EmbeddingsEnabledFunc: func() bool { return true },
+	}
+
+	result, err := Query(context.Background(), mock, map[string]any{
+		"query": "PostgreSQL",
+		"mode":  "hybrid",
+	})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Query() returned error: %s", result.Text)
+	}
+
+	checks := []string{
+		"Hybrid Search Results",
+		"fact:abc",
+		"semantic+exact",
+		"ent:pg",
+	}
+	for _, check := range checks {
+		if !strings.Contains(result.Text, check) {
+			t.Errorf("Query() output missing %q", check)
+		}
+	}
+}
+
+func TestQuery_FullTextMode(t *testing.T) {
+	mock := &MockQuerier{
+		FullTextSearchFunc: func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
+			if query != "deploying postgres" {
+				t.Errorf("Expected query passed through, got %q", query)
+			}
+			return []SearchResult{
+				{NodeType: "decision", ID: "dec:abc", Content: "Deploy Postgres on RDS", Detail: "Managed backups", Score: 2.5},
+			}, nil
+		},
+	}
+
+	result, err := Query(context.Background(), mock, map[string]any{
+		"query":      "deploying postgres",
+		"mode":       "fulltext",
+		"node_types": []any{"fact", "decision"},
+	})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Query() returned error: %s", result.Text)
+	}
+
+	checks := []string{
+		"Full-Text Search Results",
+		"Decisions (1 results)",
+		"dec:abc",
+		"score 2.50",
+		"Managed backups",
+	}
+	for _, check := range checks {
+		if !strings.Contains(result.Text, check) {
+			t.Errorf("Query() output missing %q", check)
+		}
+	}
+}
+
+func TestQuery_TimeRange(t *testing.T) {
+	var got TimeRange
+	mock := &MockQuerier{
+		ExactSearchFunc: func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
+			got = TimeRangeFromContext(ctx)
+			return nil, nil
+		},
+	}
+
+	result, _ := Query(context.Background(), mock, map[string]any{
+		"query":          "postgres",
+		"mode":           "exact",
+		"created_before": "2026-02-01",
+	})
+	if result.IsError {
+		t.Fatalf("Query() returned error: %s", result.Text)
+	}
+	if got.CreatedBefore == 0 {
+		t.Error("Query() should pass the time range to the search via context")
+	}
+
+	result, _ = Query(context.Background(), mock, map[string]any{
+		"query":            "postgres",
+		"mode":             "exact",
+		"event_date_range": "not-a-range",
+	})
+	if !result.IsError {
+		t.Error("Query() should reject an invalid event_date_range")
+	}
+}
+
+func TestQuery_IncludeArchived(t *testing.T) {
+	var got []bool
+	mock := &MockQuerier{
+		ExactSearchFunc: func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
+			got = append(got, IncludeArchivedFromContext(ctx))
+			return nil, nil
+		},
+	}
+
+	_, _ = Query(context.Background(), mock, map[string]any{"query": "postgres", "mode": "exact"})
+	_, _ = Query(context.Background(), mock, map[string]any{"query": "postgres", "mode": "exact", "include_archived": true})
+	if len(got) != 2 || got[0] || !got[1] {
+		t.Errorf("include_archived in context = %v, want [false true]", got)
+	}
+}
+
+func TestQuery_MinSimilarity(t *testing.T) {
+	var got []float64
+	mock := &MockQuerier{
+		EmbeddingsEnabledFunc: func() bool { return true },
+		SemanticSearchFunc: func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
+			min, ok := MinSimilarityFromContext(ctx)
+			if !ok {
+				min = -1
+			}
+			got = append(got, min)
+			return nil, nil
+		},
+	}
+
+	_, _ = Query(context.Background(), mock, map[string]any{"query": "postgres"})
+	_, _ = Query(context.Background(), mock, map[string]any{"query": "postgres", "min_similarity": 0.7})
+	if len(got) != 2 || got[0] != -1 || got[1] != 0.7 {
+		t.Errorf("min_similarity in context = %v, want [unset 0.7]", got)
+	}
+
+	result, _ := Query(context.Background(), mock, map[string]any{"query": "postgres", "min_similarity": 1.5})
+	if !result.IsError || !strings.Contains(result.Text, "min_similarity") {
+		t.Errorf("Query() should reject min_similarity above 1, got: %s", result.Text)
+	}
+}
+
+func TestQuery_SourceAgent(t *testing.T) {
+	var got []string
+	mock := &MockQuerier{
+		ExactSearchFunc: func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
+			got = append(got, SourceAgentFromContext(ctx))
+			return nil, nil
+		},
+	}
+
+	_, _ = Query(context.Background(), mock, map[string]any{"query": "postgres", "mode": "exact"})
+	_, _ = Query(context.Background(), mock, map[string]any{"query": "postgres", "mode": "exact", "source_agent": "cursor"})
+	if len(got) != 2 || got[0] != "" || got[1] != "cursor" {
+		t.Errorf("source_agent in context = %q, want [\"\" \"cursor\"]", got)
+	}
+}
+
+func TestQuery_EffectiveConfidence(t *testing.T) {
+	mock := &MockQuerier{
+		ExactSearchFunc: func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
+			return []SearchResult{
+				{NodeType: "fact", ID: "fact:old", Content: "Uses Postgres 12", EffectiveConfidence: 0.45},
+				{NodeType: "entity", ID: "ent:pg", Content: "Postgres"},
+			}, nil
+		},
+	}
+
+	result, err := Query(context.Background(), mock, map[string]any{"query": "Postgres", "mode": "exact"})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if strings.Count(result.Text, "Effective confidence") != 1 {
+		t.Errorf("expected one effective confidence line, got:\n%s", result.Text)
+	}
+	if !strings.Contains(result.Text, "Effective confidence: 0.45") {
+		t.Errorf("Query() should show decayed confidence, got:\n%s", result.Text)
+	}
+}
+
+func TestQuery_HybridMode_NoEmbeddings(t *testing.T) {
+	mock := &MockQuerier{
+		HybridSearchFunc: func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
+			return []SearchResult{
+				{NodeType: "fact", ID: "fact:abc", Content: "Uses PostgreSQL", MatchedBy: []string{"exact"}},
+			}, nil
+		},
+		EmbeddingsEnabledFunc: func() bool { return false },
+	}
+
+	result, _ := Query(context.Background(), mock, map[string]any{
+		"query": "PostgreSQL",
+		"mode":  "hybrid",
+	})
+	if result.IsError {
+		t.Fatalf("hybrid mode should work without embeddings, got error: %s", result.Text)
+	}
+	if !strings.Contains(result.Text, "fact:abc") {
+		t.Errorf("Query() output missing exact result, got: %s", result.Text)
+	}
 }
 
 func TestQuery_EmptyResults(t *testing.T) {
@@ -225,4 +520,51 @@ func TestQuery_LimitClamping(t *testing.T) {
 	if capturedLimit != 50 {
 		t.Errorf("Expected limit clamped to 50, got %d", capturedLimit)
 	}
-}
\ No newline at end of file
+}
+
+func TestQuery_JSON(t *testing.T) {
+	mock := &MockQuerier{
+		SemanticSearchFunc: func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
+			return []SearchResult{{NodeType: "fact", ID: "fact:abc", Content: "Go is my primary language", Distance: 0.1, Score: 0.9}}, nil
+		},
+		GetRelatedEntitiesFunc: func(ctx context.Context, factID string) ([]Entity, error) {
+			return []Entity{{ID: "ent:go", Name: "Go", Kind: "technology"}}, nil
+		},
+		EmbeddingsEnabledFunc: func() bool { return true },
+	}
+
+	result, _ := Query(context.Background(), mock, map[string]any{"query": "tech stack", "response_format": "json"})
+	var search struct {
+		Mode    string         `json:"mode"`
+		Query   string         `json:"query"`
+		Results []SearchResult `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(result.Text), &search); err != nil {
+		t.Fatalf("Query() output is not JSON: %v\n%s", err, result.Text)
+	}
+	if search.Mode != "semantic" || search.Query != "tech stack" || len(search.Results) != 1 {
+		t.Fatalf("Query() JSON = %+v", search)
+	}
+	if r := search.Results[0]; r.ID != "fact:abc" || r.Score != 0.9 || r.Distance != 0.1 {
+		t.Errorf("result = %+v, want fact:abc with score and distance", r)
+	}
+
+	result, _ = Query(context.Background(), mock, map[string]any{
+		"query": "fact:abc", "mode": "graph", "node_id": "fact:abc", "traversal": "related_entities", "response_format": "json",
+	})
+	var graph struct {
+		Traversal string   `json:"traversal"`
+		Results   []Entity `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(result.Text), &graph); err != nil {
+		t.Fatalf("Query() graph output is not JSON: %v\n%s", err, result.Text)
+	}
+	if graph.Traversal != "related_entities" || len(graph.Results) != 1 || graph.Results[0].ID != "ent:go" {
+		t.Errorf("graph JSON = %+v", graph)
+	}
+
+	result, _ = Query(context.Background(), &MockQuerier{}, map[string]any{"query": "x", "mode": "exact", "response_format": "json"})
+	if !strings.Contains(result.Text, `"results": []`) {
+		t.Errorf("empty results should be an empty array:\n%s", result.Text)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// edgeEndpoints maps each edge type to the ID prefixes of its source and target nodes.
+var edgeEndpoints = map[string][2]string{
+	"fact_entity":     {"fact:", "ent:"},
+	"fact_topic":      {"fact:", "top:"},
+	"decision_topic":  {"dec:", "top:"},
+	"decision_entity": {"dec:", "ent:"},
+	"event_decision":  {"evt:", "dec:"},
+	"entity_topic":    {"ent:", "top:"},
+}
+
+// Relate creates or deletes a single edge between two existing nodes.
+func Relate(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
+	action := GetStringArg(args, "action", DefaultActions["mie_relate"])
+	if action != "create" && action != "delete" {
+		return NewError(fmt.Sprintf("Invalid action %q. Must be one of: create, delete", action)), nil
+	}
+
+	edgeType := GetStringArg(args, "edge", "")
+	if edgeType == "" {
+		return NewError("Missing required parameter: edge"), nil
+	}
+	sourceID := GetStringArg(args, "source_id", "")
+	if sourceID == "" {
+		return NewError("Missing required parameter: source_id"), nil
+	}
+	targetID := GetStringArg(args, "target_id", "")
+	if targetID == "" {
+		return NewError("Missing required parameter: target_id"), nil
+	}
+
+	if err := validateEdgeEndpoints(edgeType, sourceID, targetID); err != nil {
+		return NewError(err.Error()), nil
+	}
+
+	for _, id := range []string{sourceID, targetID} {
+		if _, err := client.GetNodeByID(ctx, id); err != nil {
+			return NewError(fmt.Sprintf("Node [%s] not found: %v", id, err)), nil
+		}
+	}
+
+	fields := buildEdgeFields(edgeType, sourceID, targetID, args)
+	tableName := "mie_" + edgeType
+
+	if action == "delete" {
+		if err := client.RemoveRelationship(ctx, tableName, fields); err != nil {
+			return NewError(fmt.Sprintf("Failed to delete %s edge: %v", edgeType, err)), nil
+		}
+		if WantsJSON(args) {
+			return NewJSONResult(relateJSON{Action: action, Edge: edgeType, SourceID: sourceID, TargetID: targetID}), nil
+		}
+		return NewResult(fmt.Sprintf("Deleted %s: [%s] -> [%s]", edgeType, sourceID, targetID)), nil
+	}
+
+	fields["source_agent"] = GetStringArg(args, "source_agent", "unknown")
+	if err := client.AddRelationship(ctx, tableName, fields); err != nil {
+		return NewError(fmt.Sprintf("Failed to create %s edge: %v", edgeType, err)), nil
+	}
+
+	if WantsJSON(args) {
+		out := relateJSON{Action: action, Edge: edgeType, SourceID: sourceID, TargetID: targetID, Role: fields["role"]}
+		if w, err := strconv.ParseFloat(fields["weight"], 64); err == nil {
+			out.Weight = &w
+		}
+		return NewJSONResult(out), nil
+	}
+
+	output := fmt.Sprintf("Created %s: [%s] -> [%s]", edgeType, sourceID, targetID)
+	if role := fields["role"]; role != "" {
+		output += fmt.Sprintf("\nRole: %s", role)
+	}
+	if weight := fields["weight"]; weight != "" {
+		output += fmt.Sprintf("\nWeight: %s", weight)
+	}
+	return NewResult(output), nil
+}
+
+// relateJSON is the JSON response of Relate.
+type relateJSON struct {
+	Action   string   `json:"action"`
+	Edge     string   `json:"edge"`
+	SourceID string   `json:"source_id"`
+	TargetID string   `json:"target_id"`
+	Role     string   `json:"role,omitempty"`
+	Weight   *float64 `json:"weight,omitempty"`
+}
+
+// validateEdgeEndpoints checks that the edge type exists and that the source
+// and target IDs carry the node prefixes the edge type connects.
+func validateEdgeEndpoints(edgeType, sourceID, targetID string) error {
+	endpoints, ok := edgeEndpoints[edgeType]
+	if !ok || !validEdgeTypes[edgeType] {
+		return fmt.Errorf("invalid edge type %q. Must be one of: fact_entity, fact_topic, decision_topic, decision_entity, event_decision, entity_topic", edgeType)
+	}
+	if !strings.HasPrefix(sourceID, endpoints[0]) {
+		return fmt.Errorf("edge %s requires a source ID with prefix %q, got %q", edgeType, endpoints[0], sourceID)
+	}
+	if !strings.HasPrefix(targetID, endpoints[1]) {
+		return fmt.Errorf("edge %s requires a target ID with prefix %q, got %q", edgeType, endpoints[1], targetID)
+	}
+	return nil
+}
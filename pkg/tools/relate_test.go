@@ -0,0 +1,193 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRelate_Create(t *testing.T) {
+	var gotTable string
+	var gotFields map[string]string
+	mock := &MockQuerier{
+		AddRelationshipFunc: func(ctx context.Context, edgeType string, fields map[string]string) error {
+			gotTable = edgeType
+			gotFields = fields
+			return nil
+		},
+	}
+
+	result, err := Relate(context.Background(), mock, map[string]any{
+		"edge":      "decision_entity",
+		"source_id": "dec:abc",
+		"target_id": "ent:xyz",
+		"role":      "subject",
+	})
+	if err != nil {
+		t.Fatalf("Relate() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Relate() returned error: %s", result.Text)
+	}
+	if gotTable != "mie_decision_entity" {
+		t.Errorf("Expected table mie_decision_entity, got %s", gotTable)
+	}
+	if gotFields["decision_id"] != "dec:abc" || gotFields["entity_id"] != "ent:xyz" || gotFields["role"] != "subject" {
+		t.Errorf("Unexpected edge fields: %v", gotFields)
+	}
+	if !strings.Contains(result.Text, "Created decision_entity") {
+		t.Errorf("Relate() should confirm creation, got: %s", result.Text)
+	}
+}
+
+func TestRelate_EdgeMetadata(t *testing.T) {
+	var gotFields map[string]string
+	mock := &MockQuerier{
+		AddRelationshipFunc: func(ctx context.Context, edgeType string, fields map[string]string) error {
+			gotFields = fields
+			return nil
+		},
+	}
+
+	result, _ := Relate(context.Background(), mock, map[string]any{
+		"edge":         "fact_entity",
+		"source_id":    "fact:abc",
+		"target_id":    "ent:xyz",
+		"weight":       0.25,
+		"source_agent": "cursor",
+	})
+	if result.IsError {
+		t.Fatalf("Relate() returned error: %s", result.Text)
+	}
+	if gotFields["weight"] != "0.25" || gotFields["source_agent"] != "cursor" {
+		t.Errorf("Unexpected edge fields: %v", gotFields)
+	}
+	if !strings.Contains(result.Text, "Weight: 0.25") {
+		t.Errorf("Relate() should report the weight, got: %s", result.Text)
+	}
+
+	_, _ = Relate(context.Background(), mock, map[string]any{
+		"edge":      "fact_entity",
+		"source_id": "fact:abc",
+		"target_id": "ent:xyz",
+	})
+	if _, ok := gotFields["weight"]; ok || gotFields["source_agent"] != "unknown" {
+		t.Errorf("Relate() without weight sent fields %v", gotFields)
+	}
+}
+
+func TestRelate_Delete(t *testing.T) {
+	removed := false
+	mock := &MockQuerier{
+		AddRelationshipFunc: func(ctx context.Context, edgeType string, fields map[string]string) error {
+			t.Error("AddRelationship should not be called for delete")
+			return nil
+		},
+		RemoveRelationshipFunc: func(ctx context.Context, edgeType string, fields map[string]string) error {
+			removed = true
+			if edgeType != "mie_fact_topic" {
+				t.Errorf("Expected table mie_fact_topic, got %s", edgeType)
+			}
+			return nil
+		},
+	}
+
+	result, err := Relate(context.Background(), mock, map[string]any{
+		"action":    "delete",
+		"edge":      "fact_topic",
+		"source_id": "fact:abc",
+		"target_id": "top:xyz",
+	})
+	if err != nil {
+		t.Fatalf("Relate() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Relate() returned error: %s", result.Text)
+	}
+	if !removed {
+		t.Error("RemoveRelationship was not called")
+	}
+	if !strings.Contains(result.Text, "Deleted fact_topic") {
+		t.Errorf("Relate() should confirm deletion, got: %s", result.Text)
+	}
+}
+
+func TestRelate_PrefixMismatch(t *testing.T) {
+	mock := &MockQuerier{}
+	result, _ := Relate(context.Background(), mock, map[string]any{
+		"edge":      "fact_entity",
+		"source_id": "dec:abc",
+		"target_id": "ent:xyz",
+	})
+	if !result.IsError {
+		t.Error("Relate() should reject a source ID that does not match the edge type")
+	}
+	if !strings.Contains(result.Text, `"fact:"`) {
+		t.Errorf("Error should name the expected prefix, got: %s", result.Text)
+	}
+
+	result, _ = Relate(context.Background(), mock, map[string]any{
+		"edge":      "fact_entity",
+		"source_id": "fact:abc",
+		"target_id": "top:xyz",
+	})
+	if !result.IsError {
+		t.Error("Relate() should reject a target ID that does not match the edge type")
+	}
+}
+
+func TestRelate_InvalidInput(t *testing.T) {
+	mock := &MockQuerier{}
+	tests := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"missing edge", map[string]any{"source_id": "fact:a", "target_id": "ent:b"}, "edge"},
+		{"missing source", map[string]any{"edge": "fact_entity", "target_id": "ent:b"}, "source_id"},
+		{"missing target", map[string]any{"edge": "fact_entity", "source_id": "fact:a"}, "target_id"},
+		{"unknown edge", map[string]any{"edge": "fact_fact", "source_id": "fact:a", "target_id": "fact:b"}, "invalid edge type"},
+		{"bad action", map[string]any{"action": "update", "edge": "fact_entity", "source_id": "fact:a", "target_id": "ent:b"}, "Invalid action"},
+	}
+	for _, tt := range tests {
+		result, _ := Relate(context.Background(), mock, tt.args)
+		if !result.IsError {
+			t.Errorf("%s: expected error", tt.name)
+		}
+		if !strings.Contains(result.Text, tt.want) {
+			t.Errorf("%s: expected %q in error, got: %s", tt.name, tt.want, result.Text)
+		}
+	}
+}
+
+func TestRelate_NodeNotFound(t *testing.T) {
+	mock := &MockQuerier{
+		GetNodeByIDFunc: func(ctx context.Context, nodeID string) (any, error) {
+			if nodeID == "ent:missing" {
+				return nil, fmt.Errorf("node %q not found", nodeID)
+			}
+			return &Fact{ID: nodeID}, nil
+		},
+		AddRelationshipFunc: func(ctx context.Context, edgeType string, fields map[string]string) error {
+			t.Error("AddRelationship should not be called when a node is missing")
+			return nil
+		},
+	}
+
+	result, _ := Relate(context.Background(), mock, map[string]any{
+		"edge":      "fact_entity",
+		"source_id": "fact:abc",
+		"target_id": "ent:missing",
+	})
+	if !result.IsError {
+		t.Error("Relate() should fail when a node does not exist")
+	}
+	if !strings.Contains(result.Text, "ent:missing") {
+		t.Errorf("Error should name the missing node, got: %s", result.Text)
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// reviewScanLimit caps the decisions and entities Review scans for missing
+// rationale, links, and descriptions.
+const reviewScanLimit = 1000
+
+// reviewDecision is an active decision flagged by Review.
+type reviewDecision struct {
+	Decision
+	MissingRationale bool `json:"missing_rationale"`
+	Unlinked         bool `json:"unlinked"`
+}
+
+// Review assembles a memory review digest: valid facts stored in the last
+// days, potential conflicts, active decisions without a rationale or any
+// edges, and entities without a description. It opens with a checklist the
+// agent can work through with the user.
+func Review(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
+	days := GetIntArg(args, "days", 7)
+	if days < 1 {
+		days = 1
+	}
+	if days > 365 {
+		days = 365
+	}
+	limit := GetIntArg(args, "limit", 10)
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > 50 {
+		limit = 50
+	}
+	since := time.Now().AddDate(0, 0, -days).Unix()
+
+	factNodes, factTotal, err := client.ListNodes(ctx, ListOptions{
+		NodeType:  "fact",
+		ValidOnly: true,
+		Limit:     limit,
+		TimeRange: TimeRange{CreatedAfter: since},
+	})
+	if err != nil {
+		return NewError(fmt.Sprintf("Failed to list new facts: %v", err)), nil
+	}
+	var facts []Fact
+	for _, n := range factNodes {
+		if f, ok := n.(*Fact); ok {
+			facts = append(facts, *f)
+		}
+	}
+
+	// Conflict detection needs embeddings; without them the section is skipped.
+	conflictsChecked := client.EmbeddingsEnabled()
+	var conflicts []Conflict
+	if conflictsChecked {
+		conflicts, err = client.DetectConflicts(ctx, ConflictOptions{Threshold: 0.85, Limit: limit})
+		if err != nil {
+			return NewError(fmt.Sprintf("Failed to detect conflicts: %v", err)), nil
+		}
+	}
+
+	decisionNodes, _, err := client.ListNodes(ctx, ListOptions{
+		NodeType:      "decision",
+		Status:        "active",
+		IncludeDegree: true,
+		Limit:         reviewScanLimit,
+	})
+	if err != nil {
+		return NewError(fmt.Sprintf("Failed to list decisions: %v", err)), nil
+	}
+	var decisions []reviewDecision
+	for _, n := range decisionNodes {
+		d, ok := n.(*Decision)
+		if !ok {
+			continue
+		}
+		r := reviewDecision{
+			Decision:         *d,
+			MissingRationale: strings.TrimSpace(d.Rationale) == "",
+			Unlinked:         d.Degree != nil && *d.Degree == 0,
+		}
+		if r.MissingRationale || r.Unlinked {
+			decisions = append(decisions, r)
+		}
+	}
+
+	entityNodes, _, err := client.ListNodes(ctx, ListOptions{NodeType: "entity", Limit: reviewScanLimit})
+	if err != nil {
+		return NewError(fmt.Sprintf("Failed to list entities: %v", err)), nil
+	}
+	var entities []Entity
+	for _, n := range entityNodes {
+		if e, ok := n.(*Entity); ok && strings.TrimSpace(e.Description) == "" {
+			entities = append(entities, *e)
+		}
+	}
+	_ = client.IncrementCounter(ctx, "total_queries")
+
+	decisionTotal, entityTotal := len(decisions), len(entities)
+	decisions = decisions[:min(limit, decisionTotal)]
+	entities = entities[:min(limit, entityTotal)]
+
+	if WantsJSON(args) {
+		if facts == nil {
+			facts = []Fact{}
+		}
+		if conflicts == nil {
+			conflicts = []Conflict{}
+		}
+		if decisions == nil {
+			decisions = []reviewDecision{}
+		}
+		if entities == nil {
+			entities = []Entity{}
+		}
+		return NewJSONResult(reviewJSON{
+			Days:             days,
+			Since:            since,
+			NewFactsTotal:    factTotal,
+			NewFacts:         facts,
+			ConflictsChecked: conflictsChecked,
+			Conflicts:        conflicts,
+			DecisionsTotal:   decisionTotal,
+			Decisions:        decisions,
+			EntitiesTotal:    entityTotal,
+			Entities:         entities,
+		}), nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Memory Review: last %d days\n\n", days)
+
+	sb.WriteString("### Checklist\n")
+	reviewCheck(&sb, factTotal, "Confirm %d new facts with the user: verify the right ones (mie_update action=\"verify\") and invalidate the wrong ones", "No new facts to confirm")
+	if conflictsChecked {
+		reviewCheck(&sb, len(conflicts), "Resolve %d potential conflicts by invalidating the outdated fact (mie_update action=\"invalidate\")", "No potential conflicts")
+	} else {
+		sb.WriteString("- [ ] Conflicts not checked: enable embeddings to detect them\n")
+	}
+	reviewCheck(&sb, decisionTotal, "Fill in %d decisions: ask the user for missing rationale and link them to the entities and topics they affect (mie_relate)", "Every active decision has a rationale and links")
+	reviewCheck(&sb, entityTotal, "Describe %d entities (mie_update action=\"update_description\")", "Every entity has a description")
+
+	if len(facts) > 0 {
+		fmt.Fprintf(&sb, "\n### New Facts (%d)\n", factTotal)
+		for _, f := range facts {
+			fmt.Fprintf(&sb, "- [%s] %q (%s, confidence: %.1f, %s)\n",
+				f.ID, Truncate(f.Content, 100), f.Category, f.Confidence, time.Unix(f.CreatedAt, 0).UTC().Format("2006-01-02"))
+		}
+		reviewMore(&sb, len(facts), factTotal)
+	}
+
+	if len(conflicts) > 0 {
+		fmt.Fprintf(&sb, "\n### Potential Conflicts (%d)\n", len(conflicts))
+		for _, c := range conflicts {
+			fmt.Fprintf(&sb, "- [%s] %q vs [%s] %q (similarity: %.0f%%)\n",
+				c.FactA.ID, Truncate(c.FactA.Content, 60), c.FactB.ID, Truncate(c.FactB.Content, 60), c.Similarity*100)
+		}
+	}
+
+	if len(decisions) > 0 {
+		fmt.Fprintf(&sb, "\n### Decisions Needing Attention (%d)\n", decisionTotal)
+		for _, d := range decisions {
+			var missing []string
+			if d.MissingRationale {
+				missing = append(missing, "no rationale")
+			}
+			if d.Unlinked {
+				missing = append(missing, "no links")
+			}
+			fmt.Fprintf(&sb, "- [%s] %q (%s)\n", d.ID, Truncate(d.Title, 100), strings.Join(missing, ", "))
+		}
+		reviewMore(&sb, len(decisions), decisionTotal)
+	}
+
+	if len(entities) > 0 {
+		fmt.Fprintf(&sb, "\n### Entities Without Description (%d)\n", entityTotal)
+		for _, e := range entities {
+			fmt.Fprintf(&sb, "- [%s] %s (%s)\n", e.ID, e.Name, e.Kind)
+		}
+		reviewMore(&sb, len(entities), entityTotal)
+	}
+
+	return NewResult(sb.String()), nil
+}
+
+// reviewCheck writes a checklist item: todo with n filled in, or done when
+// there is nothing to do.
+func reviewCheck(sb *strings.Builder, n int, todo, done string) {
+	if n == 0 {
+		fmt.Fprintf(sb, "- [x] %s\n", done)
+		return
+	}
+	fmt.Fprintf(sb, "- [ ] "+todo+"\n", n)
+}
+
+// reviewMore notes how many items of a section were left out.
+func reviewMore(sb *strings.Builder, shown, total int) {
+	if total > shown {
+		fmt.Fprintf(sb, "_...and %d more. Raise limit to see them._\n", total-shown)
+	}
+}
+
+// reviewJSON is the JSON response of Review. Each list holds at most limit
+// items; the totals count every item found.
+type reviewJSON struct {
+	Days             int              `json:"days"`
+	Since            int64            `json:"since"`
+	NewFactsTotal    int              `json:"new_facts_total"`
+	NewFacts         []Fact           `json:"new_facts"`
+	ConflictsChecked bool             `json:"conflicts_checked"`
+	Conflicts        []Conflict       `json:"conflicts"`
+	DecisionsTotal   int              `json:"decisions_total"`
+	Decisions        []reviewDecision `json:"decisions"`
+	EntitiesTotal    int              `json:"entities_total"`
+	Entities         []Entity         `json:"entities"`
+}
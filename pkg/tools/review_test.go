@@ -0,0 +1,129 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func reviewMock() *MockQuerier {
+	zero, two := 0, 2
+	return &MockQuerier{
+		ListNodesFunc: func(ctx context.Context, opts ListOptions) ([]any, int, error) {
+			switch opts.NodeType {
+			case "fact":
+				return []any{
+					&Fact{ID: "fact:new", Content: "Billing moved to Stripe", Category: "technical", Confidence: 0.9, CreatedAt: time.Now().Unix()},
+				}, 3, nil
+			case "decision":
+				return []any{
+					&Decision{ID: "dec:ok", Title: "Use Postgres", Rationale: "Team knows it", Degree: &two},
+					&Decision{ID: "dec:bare", Title: "Drop Redis", Degree: &zero},
+					&Decision{ID: "dec:lonely", Title: "Weekly deploys", Rationale: "Less risk", Degree: &zero},
+				}, 3, nil
+			case "entity":
+				return []any{
+					&Entity{ID: "ent:pg", Name: "Postgres", Kind: "technology", Description: "Primary database"},
+					&Entity{ID: "ent:ana", Name: "Ana", Kind: "person"},
+				}, 2, nil
+			}
+			return nil, 0, nil
+		},
+		DetectConflictsFunc: func(ctx context.Context, opts ConflictOptions) ([]Conflict, error) {
+			return []Conflict{{
+				FactA:      Fact{ID: "fact:a", Content: "Deploys on Fridays"},
+				FactB:      Fact{ID: "fact:b", Content: "Never deploy on Fridays"},
+				Similarity: 0.91,
+			}}, nil
+		},
+	}
+}
+
+func TestReview(t *testing.T) {
+	var factOpts ListOptions
+	mock := reviewMock()
+	list := mock.ListNodesFunc
+	mock.ListNodesFunc = func(ctx context.Context, opts ListOptions) ([]any, int, error) {
+		if opts.NodeType == "fact" {
+			factOpts = opts
+		}
+		return list(ctx, opts)
+	}
+
+	result, err := Review(context.Background(), mock, map[string]any{"days": float64(14), "limit": float64(1)})
+	if err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Review() returned error: %s", result.Text)
+	}
+
+	want := time.Now().AddDate(0, 0, -14).Unix()
+	if d := factOpts.CreatedAfter - want; d < -5 || d > 5 || !factOpts.ValidOnly {
+		t.Errorf("fact listing options = %+v, want valid facts created after %d", factOpts, want)
+	}
+
+	for _, s := range []string{
+		"## Memory Review: last 14 days",
+		"- [ ] Confirm 3 new facts",
+		"- [ ] Resolve 1 potential conflicts",
+		"- [ ] Fill in 2 decisions",
+		"- [ ] Describe 1 entities",
+		`- [fact:new] "Billing moved to Stripe"`,
+		"_...and 2 more. Raise limit to see them._",
+		`[fact:a] "Deploys on Fridays" vs [fact:b] "Never deploy on Fridays" (similarity: 91%)`,
+		`- [dec:bare] "Drop Redis" (no rationale, no links)`,
+		"- [ent:ana] Ana (person)",
+	} {
+		if !strings.Contains(result.Text, s) {
+			t.Errorf("missing %q in:\n%s", s, result.Text)
+		}
+	}
+	for _, s := range []string{"dec:ok", "ent:pg", "dec:lonely"} {
+		if strings.Contains(result.Text, s) {
+			t.Errorf("unexpected %s in:\n%s", s, result.Text)
+		}
+	}
+}
+
+func TestReview_Clean(t *testing.T) {
+	mock := &MockQuerier{EmbeddingsEnabledFunc: func() bool { return false }}
+	result, _ := Review(context.Background(), mock, map[string]any{})
+	if result.IsError {
+		t.Fatalf("Review() returned error: %s", result.Text)
+	}
+	for _, s := range []string{
+		"last 7 days",
+		"- [x] No new facts to confirm",
+		"- [ ] Conflicts not checked",
+		"- [x] Every active decision has a rationale and links",
+		"- [x] Every entity has a description",
+	} {
+		if !strings.Contains(result.Text, s) {
+			t.Errorf("missing %q in:\n%s", s, result.Text)
+		}
+	}
+}
+
+func TestReview_JSON(t *testing.T) {
+	result, _ := Review(context.Background(), reviewMock(), map[string]any{"response_format": FormatJSON})
+	var out reviewJSON
+	if err := json.Unmarshal([]byte(result.Text), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result.Text)
+	}
+	if out.Days != 7 || out.NewFactsTotal != 3 || len(out.NewFacts) != 1 || !out.ConflictsChecked || len(out.Conflicts) != 1 {
+		t.Errorf("unexpected facts or conflicts: %+v", out)
+	}
+	if out.DecisionsTotal != 2 || !out.Decisions[0].MissingRationale || !out.Decisions[1].Unlinked || out.Decisions[1].MissingRationale {
+		t.Errorf("unexpected decisions: %+v", out.Decisions)
+	}
+	if out.EntitiesTotal != 1 || out.Entities[0].ID != "ent:ana" {
+		t.Errorf("unexpected entities: %+v", out.Entities)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// savedSearchKeys are the mie_query arguments recorded by action=save. A
+// run may override any of them.
+var savedSearchKeys = []string{
+	"query", "mode", "node_types", "limit", "category", "kind", "valid_only",
+	"created_after", "created_before", "event_date_range", "include_archived",
+	"source_agent", "node_id", "traversal", "min_similarity",
+}
+
+// savedSearch handles the mie_query actions that save, list, run, and
+// delete named searches.
+func savedSearch(ctx context.Context, client Querier, action string, args map[string]any) (*ToolResult, error) {
+	if action == "list" {
+		return listSavedSearches(ctx, client, args)
+	}
+
+	name := strings.TrimSpace(GetStringArg(args, "name", ""))
+	if name == "" {
+		return NewError(fmt.Sprintf("Missing required parameter: name (action=%s)", action)), nil
+	}
+
+	switch action {
+	case "save":
+		if GetStringArg(args, "query", "") == "" {
+			return NewError("Missing required parameter: query"), nil
+		}
+		mode := GetStringArg(args, "mode", "semantic")
+		switch mode {
+		case "semantic", "exact", "fulltext", "hybrid", "graph":
+		default:
+			return NewError(fmt.Sprintf("Invalid mode %q. Must be one of: semantic, exact, fulltext, hybrid, graph", mode)), nil
+		}
+		if _, err := ParseTimeRangeArgs(args); err != nil {
+			return NewError(err.Error()), nil
+		}
+
+		saved := make(map[string]any)
+		for _, key := range savedSearchKeys {
+			if v, ok := args[key]; ok && v != nil {
+				saved[key] = v
+			}
+		}
+		search, err := client.SaveSearch(ctx, name, saved)
+		if err != nil {
+			return NewError(fmt.Sprintf("Failed to save search: %v", err)), nil
+		}
+		if WantsJSON(args) {
+			return NewJSONResult(search), nil
+		}
+		return NewResult(fmt.Sprintf("Saved search %q: %s\nRun it with action=\"run\", name=%q.",
+			search.Name, describeSavedSearch(search.Args), search.Name)), nil
+
+	case "run":
+		search, err := client.GetSavedSearch(ctx, name)
+		if err != nil {
+			return NewError(fmt.Sprintf("Failed to load saved search: %v", err)), nil
+		}
+		return Query(ctx, client, SavedSearchArgs(search, args))
+
+	case "delete":
+		if err := client.DeleteSavedSearch(ctx, name); err != nil {
+			return NewError(fmt.Sprintf("Failed to delete saved search: %v", err)), nil
+		}
+		if WantsJSON(args) {
+			return NewJSONResult(map[string]string{"deleted": name}), nil
+		}
+		return NewResult(fmt.Sprintf("Deleted saved search %q", name)), nil
+	}
+	return NewError(fmt.Sprintf("Invalid action %q", action)), nil
+}
+
+// SavedSearchArgs returns the mie_query arguments that run search. Search
+// arguments present in overrides replace the saved ones, and its
+// response_format is kept.
+func SavedSearchArgs(search *SavedSearch, overrides map[string]any) map[string]any {
+	args := make(map[string]any, len(search.Args)+1)
+	for k, v := range search.Args {
+		args[k] = v
+	}
+	for _, key := range savedSearchKeys {
+		if v, ok := overrides[key]; ok && v != nil {
+			args[key] = v
+		}
+	}
+	if v, ok := overrides["response_format"]; ok {
+		args["response_format"] = v
+	}
+	return args
+}
+
+func listSavedSearches(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
+	searches, err := client.ListSavedSearches(ctx)
+	if err != nil {
+		return NewError(fmt.Sprintf("Failed to list saved searches: %v", err)), nil
+	}
+	_ = client.IncrementCounter(ctx, "total_queries")
+	if WantsJSON(args) {
+		if searches == nil {
+			searches = []SavedSearch{}
+		}
+		return NewJSONResult(map[string]any{"saved_searches": searches}), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Saved Searches\n\n")
+	if len(searches) == 0 {
+		sb.WriteString("_No saved searches. Save one with action=\"save\" and a name._\n")
+		return NewResult(sb.String()), nil
+	}
+	sb.WriteString(FormatSavedSearchTable(searches))
+	return NewResult(sb.String()), nil
+}
+
+// FormatSavedSearchTable renders saved searches as a markdown table.
+func FormatSavedSearchTable(searches []SavedSearch) string {
+	var sb strings.Builder
+	sb.WriteString("| Name | Search | Updated (UTC) |\n")
+	sb.WriteString("|------|--------|---------------|\n")
+	for _, s := range searches {
+		fmt.Fprintf(&sb, "| %s | %s | %s |\n",
+			s.Name, describeSavedSearch(s.Args), time.Unix(s.UpdatedAt, 0).UTC().Format("2006-01-02 15:04:05"))
+	}
+	return sb.String()
+}
+
+// describeSavedSearch summarizes saved mie_query arguments, for example
+// `semantic "auth flow" node_types=fact,decision limit=5`.
+func describeSavedSearch(args map[string]any) string {
+	mode := GetStringArg(args, "mode", "semantic")
+	parts := []string{fmt.Sprintf("%s %q", mode, GetStringArg(args, "query", ""))}
+
+	var keys []string
+	for k := range args {
+		if k != "mode" && k != "query" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := args[k]
+		if list := GetStringSliceArg(args, k, nil); list != nil {
+			v = strings.Join(list, ",")
+		}
+		parts = append(parts, fmt.Sprintf("%s=%v", k, v))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestQuerySaveSearch(t *testing.T) {
+	var gotName string
+	var gotArgs map[string]any
+	mock := &MockQuerier{
+		SaveSearchFunc: func(ctx context.Context, name string, args map[string]any) (*SavedSearch, error) {
+			gotName, gotArgs = name, args
+			return &SavedSearch{Name: name, Args: args}, nil
+		},
+	}
+	result, err := Query(context.Background(), mock, map[string]any{
+		"action":          "save",
+		"name":            " auth ",
+		"query":           "authentication flow",
+		"mode":            "hybrid",
+		"node_types":      []any{"fact", "decision"},
+		"limit":           float64(5),
+		"namespace":       "billing",
+		"response_format": "markdown",
+	})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Query() returned error: %s", result.Text)
+	}
+	if gotName != "auth" {
+		t.Errorf("name = %q, want trimmed name", gotName)
+	}
+	want := map[string]any{
+		"query":      "authentication flow",
+		"mode":       "hybrid",
+		"node_types": []any{"fact", "decision"},
+		"limit":      float64(5),
+	}
+	if !reflect.DeepEqual(gotArgs, want) {
+		t.Errorf("saved args = %v, want %v", gotArgs, want)
+	}
+	if !strings.Contains(result.Text, `hybrid "authentication flow" limit=5 node_types=fact,decision`) {
+		t.Errorf("output should describe the search:\n%s", result.Text)
+	}
+}
+
+func TestQueryRunSavedSearch(t *testing.T) {
+	var gotQuery string
+	var gotLimit int
+	mock := &MockQuerier{
+		GetSavedSearchFunc: func(ctx context.Context, name string) (*SavedSearch, error) {
+			return &SavedSearch{Name: name, Args: map[string]any{
+				"query": "postgres", "mode": "exact", "limit": float64(3),
+			}}, nil
+		},
+		ExactSearchFunc: func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
+			gotQuery, gotLimit = query, limit
+			return []SearchResult{{ID: "fact:1", NodeType: "fact", Content: "We use PostgreSQL"}}, nil
+		},
+	}
+
+	result, err := Query(context.Background(), mock, map[string]any{"action": "run", "name": "db"})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if result.IsError || gotQuery != "postgres" || gotLimit != 3 {
+		t.Fatalf("ran query %q limit %d: %s", gotQuery, gotLimit, result.Text)
+	}
+
+	// Arguments given with the run override the saved ones.
+	result, _ = Query(context.Background(), mock, map[string]any{
+		"action": "run", "name": "db", "limit": float64(20), "response_format": "json",
+	})
+	if gotLimit != 20 {
+		t.Errorf("limit = %d, want the override", gotLimit)
+	}
+	var out searchJSON
+	if err := json.Unmarshal([]byte(result.Text), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result.Text)
+	}
+	if out.Mode != "exact" || len(out.Results) != 1 {
+		t.Errorf("JSON result = %+v", out)
+	}
+
+	result, _ = Query(context.Background(), &MockQuerier{}, map[string]any{"action": "run", "name": "missing"})
+	if !result.IsError || !strings.Contains(result.Text, "not found") {
+		t.Errorf("running an unknown search should fail, got: %s", result.Text)
+	}
+}
+
+func TestQueryListSavedSearches(t *testing.T) {
+	mock := &MockQuerier{
+		ListSavedSearchesFunc: func(ctx context.Context) ([]SavedSearch, error) {
+			return []SavedSearch{{
+				Name:      "db",
+				Args:      map[string]any{"query": "postgres", "mode": "exact"},
+				UpdatedAt: 1767225600,
+			}}, nil
+		},
+	}
+	result, err := Query(context.Background(), mock, map[string]any{"action": "list"})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if !strings.Contains(result.Text, `| db | exact "postgres" | 2026-01-01 00:00:00 |`) {
+		t.Errorf("output missing saved search row:\n%s", result.Text)
+	}
+
+	result, _ = Query(context.Background(), &MockQuerier{}, map[string]any{"action": "list"})
+	if !strings.Contains(result.Text, "No saved searches") {
+		t.Errorf("empty list output:\n%s", result.Text)
+	}
+}
+
+func TestQuerySavedSearchErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"invalid action", map[string]any{"action": "rerun"}, "Invalid action"},
+		{"save without name", map[string]any{"action": "save", "query": "x"}, "name"},
+		{"save without query", map[string]any{"action": "save", "name": "x"}, "query"},
+		{"save with invalid mode", map[string]any{"action": "save", "name": "x", "query": "x", "mode": "fuzzy"}, "Invalid mode"},
+		{"run without name", map[string]any{"action": "run"}, "name"},
+		{"delete without name", map[string]any{"action": "delete"}, "name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Query(context.Background(), &MockQuerier{}, tt.args)
+			if err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			if !result.IsError || !strings.Contains(result.Text, tt.want) {
+				t.Errorf("got %q, want error containing %q", result.Text, tt.want)
+			}
+		})
+	}
+}
+
+func TestBulkQueryRejectsSavedSearchWrites(t *testing.T) {
+	result, err := BulkQuery(context.Background(), &MockQuerier{}, map[string]any{
+		"queries": []any{map[string]any{"action": "save", "name": "x", "query": "x"}},
+	})
+	if err != nil {
+		t.Fatalf("BulkQuery() error = %v", err)
+	}
+	if !strings.Contains(result.Text, `action "save" is not supported`) {
+		t.Errorf("bulk save should be rejected, got:\n%s", result.Text)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"fmt"
+	"slices"
+)
+
+// maxSchemaNameLength is the longest custom entity kind or fact category.
+const maxSchemaNameLength = 32
+
+// ValidateSchemaName checks that a custom entity kind or fact category only
+// contains lowercase letters, digits, '-' and '_', and is at most 32
+// characters long.
+func ValidateSchemaName(name string) error {
+	if name == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	if len(name) > maxSchemaNameLength {
+		return fmt.Errorf("name %q is longer than %d characters", name, maxSchemaNameLength)
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+		default:
+			return fmt.Errorf("name %q contains invalid character %q; use lowercase letters, digits, '-' or '_'", name, r)
+		}
+	}
+	return nil
+}
+
+// ExtendSchema adds entity kinds and fact categories to the values the
+// store tools accept, after the built-in ones. Names that are already
+// allowed are ignored. If any name is invalid, nothing is added.
+//
+// ExtendSchema is meant to be called once at startup, before any tool runs;
+// it is not safe for concurrent use with the tools.
+func ExtendSchema(kinds, categories []string) error {
+	for _, name := range slices.Concat(kinds, categories) {
+		if err := ValidateSchemaName(name); err != nil {
+			return err
+		}
+	}
+	for _, k := range kinds {
+		if !validEntityKinds[k] {
+			validEntityKinds[k] = true
+			entityKinds = append(entityKinds, k)
+		}
+	}
+	for _, c := range categories {
+		if !validFactCategories[c] {
+			validFactCategories[c] = true
+			factCategories = append(factCategories, c)
+		}
+	}
+	return nil
+}
+
+// EntityKinds returns the entity kinds the store tools accept.
+func EntityKinds() []string {
+	return slices.Clone(entityKinds)
+}
+
+// FactCategories returns the fact categories the store tools accept.
+func FactCategories() []string {
+	return slices.Clone(factCategories)
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
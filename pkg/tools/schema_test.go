@@ -0,0 +1,89 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// restoreSchema undoes ExtendSchema calls made by the test.
+func restoreSchema(t *testing.T) {
+	kinds, categories := slices.Clone(entityKinds), slices.Clone(factCategories)
+	validKinds, validCategories := maps.Clone(validEntityKinds), maps.Clone(validFactCategories)
+	t.Cleanup(func() {
+		entityKinds, factCategories = kinds, categories
+		validEntityKinds, validFactCategories = validKinds, validCategories
+	})
+}
+
+func TestValidateSchemaName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"service", false},
+		{"bug-report", false},
+		{"team_2", false},
+		{"", true},
+		{"Service", true},
+		{"has space", true},
+		{strings.Repeat("a", 33), true},
+	}
+	for _, tt := range tests {
+		err := ValidateSchemaName(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateSchemaName(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestExtendSchema(t *testing.T) {
+	restoreSchema(t)
+
+	if err := ExtendSchema([]string{"service", "person"}, []string{"incident"}); err != nil {
+		t.Fatalf("ExtendSchema() error = %v", err)
+	}
+	kinds := EntityKinds()
+	if kinds[len(kinds)-1] != "service" || slices.Index(kinds, "person") != 0 {
+		t.Errorf("EntityKinds() = %v, want built-ins followed by service", kinds)
+	}
+	if !slices.Contains(FactCategories(), "incident") {
+		t.Errorf("FactCategories() = %v, want incident", FactCategories())
+	}
+
+	var stored StoreEntityRequest
+	mock := &MockQuerier{
+		StoreEntityFunc: func(ctx context.Context, req StoreEntityRequest) (*Entity, error) {
+			stored = req
+			return &Entity{ID: "ent:1", Name: req.Name, Kind: req.Kind}, nil
+		},
+	}
+	result, _ := Store(context.Background(), mock, map[string]any{
+		"type": "entity",
+		"name": "billing-api",
+		"kind": "service",
+	})
+	if result.IsError {
+		t.Fatalf("Store() error: %s", result.Text)
+	}
+	if stored.Kind != "service" {
+		t.Errorf("stored kind = %q, want service", stored.Kind)
+	}
+}
+
+func TestExtendSchemaInvalid(t *testing.T) {
+	restoreSchema(t)
+
+	if err := ExtendSchema([]string{"service"}, []string{"Bad Name"}); err == nil {
+		t.Fatal("ExtendSchema() should reject an invalid name")
+	}
+	if slices.Contains(EntityKinds(), "service") {
+		t.Error("ExtendSchema() should add nothing when a name is invalid")
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	defaultSessionNodeLimit = 50
+	maxSessionNodeLimit     = 100
+)
+
+// sessionNodeTypes are the node types that record the conversation they
+// were stored in.
+var sessionNodeTypes = []string{"fact", "decision", "event"}
+
+type sessionKey struct{}
+
+// WithSession returns a copy of ctx in which stores that name no
+// source_conversation record sessionID instead. An empty sessionID returns
+// ctx unchanged.
+func WithSession(ctx context.Context, sessionID string) context.Context {
+	if sessionID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, sessionKey{}, sessionID)
+}
+
+// SessionFromContext returns the open session of ctx, or "" if there is none.
+func SessionFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(sessionKey{}).(string)
+	return id
+}
+
+// sessionJSON is the JSON response of the open, close, and show actions.
+type sessionJSON struct {
+	Session *Session `json:"session"`
+	// Closed is the session that open closed because it was still active.
+	Closed *Session         `json:"closed,omitempty"`
+	Nodes  map[string][]any `json:"nodes,omitempty"`
+	Totals map[string]int   `json:"totals,omitempty"`
+}
+
+// Sessions runs a mie_session action and returns the session that is open
+// afterwards: the new session for action=open, "" after closing the open
+// session, and otherwise the open session of ctx. The caller keeps it and
+// passes it back with WithSession.
+//
+// Opening a session while another is open closes the other one first.
+func Sessions(ctx context.Context, client Querier, args map[string]any) (*ToolResult, string, error) {
+	active := SessionFromContext(ctx)
+	action := GetStringArg(args, "action", "")
+
+	switch action {
+	case "open":
+		var closed *Session
+		if active != "" {
+			// The active session may have been closed by session_id.
+			if prev, err := client.CloseSession(ctx, active, ""); err == nil {
+				closed = prev
+			}
+		}
+		session, err := client.OpenSession(ctx, strings.TrimSpace(GetStringArg(args, "title", "")), GetStringArg(args, "source_agent", ""))
+		if err != nil {
+			return NewError(fmt.Sprintf("Failed to open session: %v", err)), "", nil
+		}
+		if WantsJSON(args) {
+			return NewJSONResult(sessionJSON{Session: session, Closed: closed}), session.ID, nil
+		}
+		var sb strings.Builder
+		if closed != nil {
+			fmt.Fprintf(&sb, "Closed session [%s]\n", closed.ID)
+		}
+		fmt.Fprintf(&sb, "Opened session [%s]", session.ID)
+		if session.Title != "" {
+			fmt.Fprintf(&sb, " %q", session.Title)
+		}
+		sb.WriteString("\nFacts, decisions, and events stored from now on record it as their source_conversation.")
+		return NewResult(sb.String()), session.ID, nil
+
+	case "close":
+		id := GetStringArg(args, "session_id", active)
+		if id == "" {
+			return NewError("No open session. Pass session_id to close another one."), active, nil
+		}
+		session, err := client.CloseSession(ctx, id, strings.TrimSpace(GetStringArg(args, "summary", "")))
+		if err != nil {
+			return NewError(fmt.Sprintf("Failed to close session: %v", err)), active, nil
+		}
+		if id == active {
+			active = ""
+		}
+		if WantsJSON(args) {
+			return NewJSONResult(sessionJSON{Session: session}), active, nil
+		}
+		return NewResult(fmt.Sprintf("Closed session [%s] after %s", session.ID,
+			time.Duration(session.EndedAt-session.StartedAt)*time.Second)), active, nil
+
+	case "list":
+		result, err := listSessions(ctx, client, args, active)
+		return result, active, err
+
+	case "show":
+		id := GetStringArg(args, "session_id", active)
+		if id == "" {
+			return NewError("No open session. Pass session_id to show another one."), active, nil
+		}
+		result, err := showSession(ctx, client, args, id)
+		return result, active, err
+	}
+	return NewError(fmt.Sprintf("Invalid action %q. Must be one of: open, close, list, show", action)), active, nil
+}
+
+func listSessions(ctx context.Context, client Querier, args map[string]any, active string) (*ToolResult, error) {
+	sessions, err := client.ListSessions(ctx)
+	if err != nil {
+		return NewError(fmt.Sprintf("Failed to list sessions: %v", err)), nil
+	}
+	_ = client.IncrementCounter(ctx, "total_queries")
+	if WantsJSON(args) {
+		if sessions == nil {
+			sessions = []Session{}
+		}
+		return NewJSONResult(map[string]any{"sessions": sessions, "active": active}), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Sessions\n\n")
+	if len(sessions) == 0 {
+		sb.WriteString("_No sessions. Open one with action=\"open\"._\n")
+		return NewResult(sb.String()), nil
+	}
+	sb.WriteString("| ID | Title | Agent | Started (UTC) | Ended (UTC) |\n")
+	sb.WriteString("|----|-------|-------|---------------|-------------|\n")
+	for _, s := range sessions {
+		ended := "open"
+		if s.EndedAt != 0 {
+			ended = formatSessionTime(s.EndedAt)
+		}
+		if s.ID == active {
+			ended += " (this conversation)"
+		}
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s |\n",
+			s.ID, orDash(s.Title), orDash(s.SourceAgent), formatSessionTime(s.StartedAt), ended)
+	}
+	return NewResult(sb.String()), nil
+}
+
+// formatSessionTime formats Unix seconds for session listings.
+func formatSessionTime(at int64) string {
+	return time.Unix(at, 0).UTC().Format("2006-01-02 15:04:05")
+}
+
+// showSession lists the facts, decisions, and events stored in a session.
+func showSession(ctx context.Context, client Querier, args map[string]any, id string) (*ToolResult, error) {
+	session, err := client.GetSession(ctx, id)
+	if err != nil {
+		return NewError(fmt.Sprintf("Failed to load session: %v", err)), nil
+	}
+	limit := GetIntArg(args, "limit", defaultSessionNodeLimit)
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > maxSessionNodeLimit {
+		limit = maxSessionNodeLimit
+	}
+
+	out := sessionJSON{Session: session, Nodes: make(map[string][]any), Totals: make(map[string]int)}
+	for _, nt := range sessionNodeTypes {
+		nodes, total, err := client.ListNodes(ctx, ListOptions{
+			NodeType:           nt,
+			SourceConversation: id,
+			Limit:              limit,
+			SortBy:             "created_at",
+			SortOrder:          "asc",
+		})
+		if err != nil {
+			return NewError(fmt.Sprintf("Failed to list session %ss: %v", nt, err)), nil
+		}
+		if nodes == nil {
+			nodes = []any{}
+		}
+		out.Nodes[nt], out.Totals[nt] = nodes, total
+	}
+	_ = client.IncrementCounter(ctx, "total_queries")
+	if WantsJSON(args) {
+		return NewJSONResult(out), nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Session [%s]", session.ID)
+	if session.Title != "" {
+		fmt.Fprintf(&sb, " %s", session.Title)
+	}
+	fmt.Fprintf(&sb, "\n\nStarted %s", formatSessionTime(session.StartedAt))
+	if session.SourceAgent != "" {
+		fmt.Fprintf(&sb, " by %s", session.SourceAgent)
+	}
+	if session.EndedAt != 0 {
+		fmt.Fprintf(&sb, ", ended %s", formatSessionTime(session.EndedAt))
+	} else {
+		sb.WriteString(", still open")
+	}
+	sb.WriteString("\n")
+	if session.Summary != "" {
+		fmt.Fprintf(&sb, "Summary: %s\n", session.Summary)
+	}
+
+	labels := map[string]string{"fact": "Facts", "decision": "Decisions", "event": "Events"}
+	empty := true
+	for _, nt := range sessionNodeTypes {
+		nodes := out.Nodes[nt]
+		if len(nodes) == 0 {
+			continue
+		}
+		empty = false
+		fmt.Fprintf(&sb, "\n### %s (%d)\n\n", labels[nt], out.Totals[nt])
+		formatNodeTable(&sb, nt, nodes, 0, false)
+		if more := out.Totals[nt] - len(nodes); more > 0 {
+			fmt.Fprintf(&sb, "\n_%d more; list them with mie_list source_conversation=%q._\n", more, id)
+		}
+	}
+	if empty {
+		sb.WriteString("\n_Nothing was stored in this session._\n")
+	}
+	return NewResult(sb.String()), nil
+}
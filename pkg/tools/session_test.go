@@ -0,0 +1,134 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSessions_OpenClose(t *testing.T) {
+	var closedID string
+	mock := &MockQuerier{
+		CloseSessionFunc: func(ctx context.Context, sessionID, summary string) (*Session, error) {
+			closedID = sessionID
+			return &Session{ID: sessionID, StartedAt: 1000, EndedAt: 1090, Summary: summary}, nil
+		},
+	}
+	ctx := context.Background()
+
+	result, active, err := Sessions(ctx, mock, map[string]any{"action": "open", "title": " Queue choice ", "source_agent": "claude"})
+	if err != nil || result.IsError {
+		t.Fatalf("open failed: %v %s", err, result.Text)
+	}
+	if active != "ses:mock" || !strings.Contains(result.Text, `Opened session [ses:mock] "Queue choice"`) {
+		t.Errorf("open: active = %q, text:\n%s", active, result.Text)
+	}
+
+	// Opening another session closes the active one.
+	result, active, _ = Sessions(WithSession(ctx, "ses:old"), mock, map[string]any{"action": "open"})
+	if closedID != "ses:old" || active != "ses:mock" || !strings.Contains(result.Text, "Closed session [ses:old]") {
+		t.Errorf("reopen: closed %q, active %q, text:\n%s", closedID, active, result.Text)
+	}
+
+	result, active, _ = Sessions(WithSession(ctx, "ses:mock"), mock, map[string]any{"action": "close", "summary": "Chose NATS"})
+	if result.IsError || active != "" || closedID != "ses:mock" {
+		t.Fatalf("close: active %q, closed %q, text:\n%s", active, closedID, result.Text)
+	}
+	if !strings.Contains(result.Text, "Closed session [ses:mock] after 1m30s") {
+		t.Errorf("unexpected close result:\n%s", result.Text)
+	}
+
+	// Closing another session keeps the active one.
+	_, active, _ = Sessions(WithSession(ctx, "ses:mock"), mock, map[string]any{"action": "close", "session_id": "ses:other"})
+	if active != "ses:mock" || closedID != "ses:other" {
+		t.Errorf("closing another session: active %q, closed %q", active, closedID)
+	}
+
+	result, _, _ = Sessions(ctx, mock, map[string]any{"action": "close"})
+	if !result.IsError {
+		t.Error("expected an error closing without an open session")
+	}
+	result, _, _ = Sessions(ctx, mock, map[string]any{"action": "pause"})
+	if !result.IsError {
+		t.Error("expected an error for an invalid action")
+	}
+}
+
+func TestSessions_Show(t *testing.T) {
+	var got []ListOptions
+	mock := &MockQuerier{
+		GetSessionFunc: func(ctx context.Context, sessionID string) (*Session, error) {
+			return &Session{ID: sessionID, Title: "Queue choice", SourceAgent: "claude", StartedAt: 1000, Summary: "Chose NATS"}, nil
+		},
+		ListNodesFunc: func(ctx context.Context, opts ListOptions) ([]any, int, error) {
+			got = append(got, opts)
+			if opts.NodeType == "decision" {
+				return []any{&Decision{ID: "dec:nats", Title: "Use NATS", Status: "active"}}, 1, nil
+			}
+			return nil, 0, nil
+		},
+	}
+
+	result, _, _ := Sessions(WithSession(context.Background(), "ses:q"), mock, map[string]any{"action": "show"})
+	if result.IsError {
+		t.Fatalf("show failed: %s", result.Text)
+	}
+	for _, want := range []string{"## Session [ses:q] Queue choice", "by claude, still open", "Summary: Chose NATS", "### Decisions (1)", "Use NATS"} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("missing %q in:\n%s", want, result.Text)
+		}
+	}
+	if len(got) != 3 || got[0].SourceConversation != "ses:q" || got[0].NodeType != "fact" {
+		t.Errorf("unexpected list options: %+v", got)
+	}
+
+	result, _, _ = Sessions(context.Background(), mock, map[string]any{"action": "show", "session_id": "ses:q", "response_format": "json"})
+	var out sessionJSON
+	if err := json.Unmarshal([]byte(result.Text), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result.Text)
+	}
+	if out.Session.ID != "ses:q" || out.Totals["decision"] != 1 || len(out.Nodes["fact"]) != 0 {
+		t.Errorf("unexpected JSON: %+v", out)
+	}
+}
+
+func TestSessions_List(t *testing.T) {
+	mock := &MockQuerier{
+		ListSessionsFunc: func(ctx context.Context) ([]Session, error) {
+			return []Session{
+				{ID: "ses:new", Title: "Queue choice", StartedAt: 2000},
+				{ID: "ses:old", StartedAt: 1000, EndedAt: 1500},
+			}, nil
+		},
+	}
+	result, active, _ := Sessions(WithSession(context.Background(), "ses:new"), mock, map[string]any{"action": "list"})
+	if active != "ses:new" {
+		t.Errorf("list changed the active session to %q", active)
+	}
+	for _, want := range []string{"| ses:new | Queue choice | - | 1970-01-01 00:33:20 | open (this conversation) |", "| ses:old | - | - |"} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("missing %q in:\n%s", want, result.Text)
+		}
+	}
+}
+
+func TestStore_SessionSourceConversation(t *testing.T) {
+	var got []string
+	mock := &MockQuerier{
+		StoreFactFunc: func(ctx context.Context, req StoreFactRequest) (*Fact, error) {
+			got = append(got, req.SourceConversation)
+			return &Fact{ID: "fact:x", Content: req.Content}, nil
+		},
+	}
+	ctx := WithSession(context.Background(), "ses:q")
+	_, _ = Store(ctx, mock, map[string]any{"type": "fact", "content": "Jobs retry 3 times", "category": "technical"})
+	_, _ = Store(ctx, mock, map[string]any{"type": "fact", "content": "Jobs retry 3 times", "category": "technical", "source_conversation": "chat-7"})
+	if len(got) != 2 || got[0] != "ses:q" || got[1] != "chat-7" {
+		t.Errorf("source conversations = %v, want [ses:q chat-7]", got)
+	}
+}
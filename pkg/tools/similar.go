@@ -0,0 +1,89 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// duplicateSimilarityPercent is the similarity from which Similar flags a
+// node as a likely duplicate.
+const duplicateSimilarityPercent = 95
+
+// similarJSON is the JSON response of Similar.
+type similarJSON struct {
+	NodeID  string         `json:"node_id"`
+	Results []SearchResult `json:"results"`
+}
+
+// Similar finds the nodes nearest to an existing node by its stored
+// embedding, across node types unless node_types narrows them, so agents can
+// spot duplicates and related memories without writing a query.
+func Similar(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
+	nodeID := strings.TrimSpace(GetStringArg(args, "node_id", ""))
+	if nodeID == "" {
+		return NewError("Missing required parameter: node_id"), nil
+	}
+	if !client.EmbeddingsEnabled() {
+		return NewError("Finding similar nodes requires embeddings to be enabled. Enable in config or use mie_query with mode=exact."), nil
+	}
+
+	nodeTypes := GetStringSliceArg(args, "node_types", []string{"fact", "decision", "entity", "event"})
+	limit := GetIntArg(args, "limit", 10)
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > 50 {
+		limit = 50
+	}
+	ctx = WithIncludeArchived(ctx, GetBoolArg(args, "include_archived", false))
+	ctx = WithSourceAgent(ctx, GetStringArg(args, "source_agent", ""))
+	if _, ok := args["min_similarity"]; ok {
+		minSimilarity := GetFloat64Arg(args, "min_similarity", -1)
+		if err := ValidateMinSimilarity(minSimilarity); err != nil {
+			return NewError(err.Error()), nil
+		}
+		ctx = WithMinSimilarity(ctx, minSimilarity)
+	}
+
+	results, err := client.SimilarNodes(ctx, nodeID, nodeTypes, limit)
+	if err != nil {
+		return NewError(fmt.Sprintf("Failed to find nodes similar to [%s]: %v", nodeID, err)), nil
+	}
+	_ = client.IncrementCounter(ctx, "total_queries")
+
+	if WantsJSON(args) {
+		if results == nil {
+			results = []SearchResult{}
+		}
+		return NewJSONResult(similarJSON{NodeID: nodeID, Results: results}), nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Nodes Similar to [%s]\n\n", nodeID)
+	if len(results) == 0 {
+		sb.WriteString("_No similar nodes found._\n")
+		return NewResult(sb.String()), nil
+	}
+	duplicates := 0
+	for i, item := range results {
+		pct := SimilarityPercent(item.Distance)
+		fmt.Fprintf(&sb, "%d. %s %d%% %s [%s] %q", i+1, SimilarityIndicator(item.Distance), pct, item.NodeType, item.ID, Truncate(item.Content, 100))
+		if pct >= duplicateSimilarityPercent {
+			sb.WriteString(" (likely duplicate)")
+			duplicates++
+		}
+		sb.WriteString("\n")
+		if item.Detail != "" {
+			fmt.Fprintf(&sb, "   %s\n", item.Detail)
+		}
+	}
+	if duplicates > 0 {
+		sb.WriteString("\nMerge duplicate entities with mie_merge, invalidate duplicate facts with mie_update, or link related nodes with mie_relate.\n")
+	}
+	return NewResult(sb.String()), nil
+}
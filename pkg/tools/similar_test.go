@@ -0,0 +1,79 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSimilar(t *testing.T) {
+	var gotTypes []string
+	var gotLimit int
+	mock := &MockQuerier{
+		SimilarNodesFunc: func(ctx context.Context, nodeID string, nodeTypes []string, limit int) ([]SearchResult, error) {
+			gotTypes, gotLimit = nodeTypes, limit
+			if v, ok := MinSimilarityFromContext(ctx); !ok || v != 0.7 {
+				t.Errorf("min_similarity not passed: %v %v", v, ok)
+			}
+			return []SearchResult{
+				{NodeType: "fact", ID: "fact:b", Content: "Billing runs on Postgres 16", Detail: "technical", Distance: 0.02},
+				{NodeType: "entity", ID: "ent:pg", Content: "Postgres", Distance: 0.3},
+			}, nil
+		},
+	}
+	ctx := context.Background()
+
+	result, _ := Similar(ctx, mock, map[string]any{"node_id": "fact:a", "node_types": []any{"fact", "entity"}, "limit": 99.0, "min_similarity": 0.7})
+	if result.IsError {
+		t.Fatalf("Similar failed: %s", result.Text)
+	}
+	if gotLimit != 50 || strings.Join(gotTypes, ",") != "fact,entity" {
+		t.Errorf("got types %v, limit %d", gotTypes, gotLimit)
+	}
+	for _, want := range []string{
+		"## Nodes Similar to [fact:a]",
+		`1. 🟢 98% fact [fact:b] "Billing runs on Postgres 16" (likely duplicate)`,
+		`2. 🟡 70% entity [ent:pg] "Postgres"` + "\n",
+		"invalidate duplicate facts with mie_update",
+	} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("missing %q in:\n%s", want, result.Text)
+		}
+	}
+
+	result, _ = Similar(ctx, mock, map[string]any{"node_id": "fact:a", "min_similarity": 0.7, "response_format": "json"})
+	var out similarJSON
+	if err := json.Unmarshal([]byte(result.Text), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result.Text)
+	}
+	if out.NodeID != "fact:a" || len(out.Results) != 2 {
+		t.Errorf("unexpected JSON: %+v", out)
+	}
+}
+
+func TestSimilar_Errors(t *testing.T) {
+	ctx := context.Background()
+	mock := &MockQuerier{}
+
+	for _, args := range []map[string]any{
+		{},
+		{"node_id": "fact:a", "min_similarity": 1.5},
+	} {
+		if result, _ := Similar(ctx, mock, args); !result.IsError {
+			t.Errorf("expected an error for %v", args)
+		}
+	}
+	if result, _ := Similar(ctx, &MockQuerier{EmbeddingsEnabledFunc: func() bool { return false }}, map[string]any{"node_id": "fact:a"}); !result.IsError || !strings.Contains(result.Text, "embeddings") {
+		t.Errorf("expected an embeddings error, got %q", result.Text)
+	}
+
+	result, _ := Similar(ctx, mock, map[string]any{"node_id": "fact:a"})
+	if !strings.Contains(result.Text, "_No similar nodes found._") {
+		t.Errorf("unexpected empty result:\n%s", result.Text)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+)
+
+type minSimilarityKey struct{}
+
+// WithMinSimilarity returns a copy of ctx whose semantic searches drop
+// results less similar than min (0-1), overriding the configured default.
+func WithMinSimilarity(ctx context.Context, min float64) context.Context {
+	return context.WithValue(ctx, minSimilarityKey{}, min)
+}
+
+// MinSimilarityFromContext returns the minimum similarity set with
+// WithMinSimilarity, and whether one was set.
+func MinSimilarityFromContext(ctx context.Context) (float64, bool) {
+	min, ok := ctx.Value(minSimilarityKey{}).(float64)
+	return min, ok
+}
+
+// ValidateMinSimilarity checks that min is a similarity between 0 and 1.
+func ValidateMinSimilarity(min float64) error {
+	if min < 0 || min > 1 {
+		return fmt.Errorf("min_similarity must be between 0 and 1 (got %v)", min)
+	}
+	return nil
+}
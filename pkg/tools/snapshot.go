@@ -0,0 +1,131 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// snapshotStatKinds is the order in which snapshot contents are reported.
+var snapshotStatKinds = []string{"facts", "decisions", "entities", "events", "topics", "relationships"}
+
+// Snapshots creates, lists, restores, or deletes snapshots of the memory
+// graph, so agents can checkpoint before large imports and roll back.
+func Snapshots(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
+	action := GetStringArg(args, "action", DefaultActions["mie_snapshot"])
+
+	switch action {
+	case "create":
+		label := strings.TrimSpace(GetStringArg(args, "label", ""))
+		if label == "" {
+			return NewError("Missing required parameter: label"), nil
+		}
+		snap, err := client.CreateSnapshot(ctx, label)
+		if err != nil {
+			return NewError(fmt.Sprintf("Failed to create snapshot: %v", err)), nil
+		}
+		if WantsJSON(args) {
+			return NewJSONResult(snapshotJSON{Action: action, Snapshot: snap}), nil
+		}
+		return NewResult(fmt.Sprintf("Created snapshot [%s] %q (%s)\nRestore it with action=\"restore\", snapshot_id=%q.",
+			snap.ID, snap.Label, FormatSnapshotStats(snap.Stats), snap.ID)), nil
+
+	case "list":
+		snapshots, err := client.ListSnapshots(ctx)
+		if err != nil {
+			return NewError(fmt.Sprintf("Failed to list snapshots: %v", err)), nil
+		}
+		_ = client.IncrementCounter(ctx, "total_queries")
+		if WantsJSON(args) {
+			if snapshots == nil {
+				snapshots = []Snapshot{}
+			}
+			return NewJSONResult(snapshotJSON{Action: action, Snapshots: snapshots}), nil
+		}
+		var sb strings.Builder
+		sb.WriteString("## Snapshots\n\n")
+		if len(snapshots) == 0 {
+			sb.WriteString("_No snapshots. Create one with action=\"create\"._\n")
+			return NewResult(sb.String()), nil
+		}
+		sb.WriteString(FormatSnapshotTable(snapshots))
+		return NewResult(sb.String()), nil
+
+	case "restore":
+		id := GetStringArg(args, "snapshot_id", "")
+		if id == "" {
+			return NewError("Missing required parameter: snapshot_id"), nil
+		}
+		checkpoint, err := client.RestoreSnapshot(ctx, id)
+		if err != nil {
+			msg := fmt.Sprintf("Failed to restore snapshot [%s]: %v", id, err)
+			if checkpoint != nil {
+				msg += fmt.Sprintf("\nThe graph before the restore was saved as [%s].", checkpoint.ID)
+			}
+			return NewError(msg), nil
+		}
+		if WantsJSON(args) {
+			return NewJSONResult(snapshotJSON{Action: action, SnapshotID: id, Checkpoint: checkpoint}), nil
+		}
+		return NewResult(fmt.Sprintf("Restored snapshot [%s].\nThe graph before the restore was saved as [%s]; restore it to undo.",
+			id, checkpoint.ID)), nil
+
+	case "delete":
+		id := GetStringArg(args, "snapshot_id", "")
+		if id == "" {
+			return NewError("Missing required parameter: snapshot_id"), nil
+		}
+		if err := client.DeleteSnapshot(ctx, id); err != nil {
+			return NewError(fmt.Sprintf("Failed to delete snapshot [%s]: %v", id, err)), nil
+		}
+		if WantsJSON(args) {
+			return NewJSONResult(snapshotJSON{Action: action, SnapshotID: id}), nil
+		}
+		return NewResult(fmt.Sprintf("Deleted snapshot [%s]", id)), nil
+
+	default:
+		return NewError(fmt.Sprintf("Invalid action %q. Must be one of: create, list, restore, delete", action)), nil
+	}
+}
+
+// snapshotJSON is the JSON response of Snapshots. Which fields are set
+// depends on the action.
+type snapshotJSON struct {
+	Action     string     `json:"action"`
+	Snapshot   *Snapshot  `json:"snapshot,omitempty"`
+	Snapshots  []Snapshot `json:"snapshots,omitempty"`
+	SnapshotID string     `json:"snapshot_id,omitempty"`
+	// Checkpoint is the snapshot of the graph taken before a restore.
+	Checkpoint *Snapshot `json:"checkpoint,omitempty"`
+}
+
+// FormatSnapshotTable renders snapshots as a markdown table.
+func FormatSnapshotTable(snapshots []Snapshot) string {
+	var sb strings.Builder
+	sb.WriteString("| ID | Label | Created (UTC) | Contents |\n")
+	sb.WriteString("|----|-------|---------------|----------|\n")
+	for _, s := range snapshots {
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s |\n",
+			s.ID, s.Label, time.Unix(s.CreatedAt, 0).UTC().Format("2006-01-02 15:04:05"), FormatSnapshotStats(s.Stats))
+	}
+	return sb.String()
+}
+
+// FormatSnapshotStats renders snapshot contents as "3 facts, 2 entities".
+func FormatSnapshotStats(stats map[string]int) string {
+	var parts []string
+	for _, kind := range snapshotStatKinds {
+		if n := stats[kind]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, kind))
+		}
+	}
+	if len(parts) == 0 {
+		return "empty"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSnapshotCreate(t *testing.T) {
+	var gotLabel string
+	mock := &MockQuerier{
+		CreateSnapshotFunc: func(ctx context.Context, label string) (*Snapshot, error) {
+			gotLabel = label
+			return &Snapshot{ID: "snap:1", Label: label, Stats: map[string]int{"facts": 3, "relationships": 2}}, nil
+		},
+	}
+	result, err := Snapshots(context.Background(), mock, map[string]any{"action": "create", "label": " before import "})
+	if err != nil {
+		t.Fatalf("Snapshots() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Snapshots() returned error: %s", result.Text)
+	}
+	if gotLabel != "before import" {
+		t.Errorf("label = %q, want trimmed label", gotLabel)
+	}
+	for _, want := range []string{"[snap:1]", `"before import"`, "3 facts, 2 relationships"} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("output missing %q:\n%s", want, result.Text)
+		}
+	}
+}
+
+func TestSnapshotList(t *testing.T) {
+	mock := &MockQuerier{
+		ListSnapshotsFunc: func(ctx context.Context) ([]Snapshot, error) {
+			return []Snapshot{
+				{ID: "snap:2", Label: "after import", CreatedAt: 1767225600, Stats: map[string]int{"facts": 5}},
+				{ID: "snap:1", Label: "before import", CreatedAt: 1767222000, Stats: map[string]int{}},
+			}, nil
+		},
+	}
+	result, err := Snapshots(context.Background(), mock, map[string]any{})
+	if err != nil {
+		t.Fatalf("Snapshots() error = %v", err)
+	}
+	for _, want := range []string{
+		"| snap:2 | after import | 2026-01-01 00:00:00 | 5 facts |",
+		"| snap:1 | before import | 2025-12-31 23:00:00 | empty |",
+	} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("output missing %q:\n%s", want, result.Text)
+		}
+	}
+
+	result, err = Snapshots(context.Background(), &MockQuerier{}, map[string]any{"action": "list", "response_format": "json"})
+	if err != nil {
+		t.Fatalf("Snapshots() error = %v", err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal([]byte(result.Text), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result.Text)
+	}
+	if out["action"] != "list" {
+		t.Errorf("action = %v, want list", out["action"])
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	var gotID string
+	mock := &MockQuerier{
+		RestoreSnapshotFunc: func(ctx context.Context, snapshotID string) (*Snapshot, error) {
+			gotID = snapshotID
+			return &Snapshot{ID: "snap:checkpoint"}, nil
+		},
+	}
+	result, err := Snapshots(context.Background(), mock, map[string]any{"action": "restore", "snapshot_id": "snap:1"})
+	if err != nil {
+		t.Fatalf("Snapshots() error = %v", err)
+	}
+	if result.IsError || gotID != "snap:1" {
+		t.Fatalf("restore of %q: %s", gotID, result.Text)
+	}
+	if !strings.Contains(result.Text, "[snap:checkpoint]") {
+		t.Errorf("output should name the checkpoint:\n%s", result.Text)
+	}
+
+	mock.RestoreSnapshotFunc = func(ctx context.Context, snapshotID string) (*Snapshot, error) {
+		return &Snapshot{ID: "snap:checkpoint"}, errors.New("import failed")
+	}
+	result, _ = Snapshots(context.Background(), mock, map[string]any{"action": "restore", "snapshot_id": "snap:1"})
+	if !result.IsError || !strings.Contains(result.Text, "import failed") || !strings.Contains(result.Text, "[snap:checkpoint]") {
+		t.Errorf("failed restore should report the error and checkpoint, got: %s", result.Text)
+	}
+}
+
+func TestSnapshotErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"invalid action", map[string]any{"action": "rollback"}, "Invalid action"},
+		{"create without label", map[string]any{"action": "create"}, "label"},
+		{"restore without id", map[string]any{"action": "restore"}, "snapshot_id"},
+		{"delete without id", map[string]any{"action": "delete"}, "snapshot_id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Snapshots(context.Background(), &MockQuerier{}, tt.args)
+			if err != nil {
+				t.Fatalf("Snapshots() error = %v", err)
+			}
+			if !result.IsError || !strings.Contains(result.Text, tt.want) {
+				t.Errorf("got %q, want error containing %q", result.Text, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import "context"
+
+type sourceAgentKey struct{}
+
+// WithSourceAgent returns a copy of ctx whose searches only return nodes
+// written by agent. Topics record no agent, so they are skipped. An empty
+// agent returns ctx unchanged.
+func WithSourceAgent(ctx context.Context, agent string) context.Context {
+	if agent == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, sourceAgentKey{}, agent)
+}
+
+// SourceAgentFromContext returns the agent searches under ctx are restricted
+// to, or "" for all agents.
+func SourceAgentFromContext(ctx context.Context) string {
+	agent, _ := ctx.Value(sourceAgentKey{}).(string)
+	return agent
+}
@@ -10,12 +10,21 @@ import (
 	"time"
 )
 
+// statusJSON is the JSON response of Status.
+type statusJSON struct {
+	*GraphStats
+	EmbeddingsEnabled bool `json:"embeddings_enabled"`
+}
+
 // Status returns memory graph health and statistics.
 func Status(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
 	stats, err := client.GetStats(ctx)
 	if err != nil {
 		return NewError(fmt.Sprintf("Failed to get graph stats: %v", err)), nil
 	}
+	if WantsJSON(args) {
+		return NewJSONResult(statusJSON{GraphStats: stats, EmbeddingsEnabled: client.EmbeddingsEnabled()}), nil
+	}
 
 	var sb string
 	sb += "## MIE Memory Status\n\n"
@@ -29,6 +38,14 @@ func Status(ctx context.Context, client Querier, args map[string]any) (*ToolResu
 	sb += fmt.Sprintf("- Topics: %d\n", stats.TotalTopics)
 	sb += fmt.Sprintf("- Relationships: %d edges total\n", stats.TotalEdges)
 
+	if len(stats.Agents) > 0 {
+		sb += "\n### Sources by Agent\n"
+		for _, a := range stats.Agents {
+			sb += fmt.Sprintf("- %s: %d nodes (%d facts, %d decisions, %d entities, %d events)\n",
+				a.Agent, a.Total(), a.Facts, a.Decisions, a.Entities, a.Events)
+		}
+	}
+
 	// Configuration
 	sb += "\n### Configuration\n"
 	if stats.StorageEngine != "" {
@@ -43,6 +60,9 @@ func Status(ctx context.Context, client Querier, args map[string]any) (*ToolResu
 	} else {
 		sb += "- Embeddings: disabled\n"
 	}
+	if stats.Namespace != "" {
+		sb += fmt.Sprintf("- Namespace: %s\n", stats.Namespace)
+	}
 	if stats.SchemaVersion != "" {
 		sb += fmt.Sprintf("- Schema version: %s\n", stats.SchemaVersion)
 	}
@@ -57,12 +77,39 @@ func Status(ctx context.Context, client Querier, args map[string]any) (*ToolResu
 	}
 	if client.EmbeddingsEnabled() {
 		sb += "- Embeddings enabled\n"
+		if q := stats.EmbeddingQueue; q != nil {
+			if q.Pending == 0 {
+				sb += "- Embedding queue: empty\n"
+			} else {
+				sb += fmt.Sprintf("- Embedding queue: %d pending (%d retrying, oldest queued %s)\n",
+					q.Pending, q.Retrying, time.Unix(q.OldestQueuedAt, 0).UTC().Format("2006-01-02 15:04:05"))
+			}
+		}
 	} else {
 		sb += "- Embeddings disabled (semantic search unavailable)\n"
 	}
+	for _, w := range stats.EmbeddingWarnings {
+		sb += fmt.Sprintf("- Warning: %s\n", w)
+	}
+
+	if l := stats.Limits; l != nil {
+		sb += "\n### Limits\n"
+		if l.MaxFacts > 0 {
+			sb += fmt.Sprintf("- Facts: %d of %d%s\n", l.Facts, l.MaxFacts, limitUsage(int64(l.Facts), int64(l.MaxFacts)))
+		}
+		if l.MaxNodes > 0 {
+			sb += fmt.Sprintf("- Nodes: %d of %d%s\n", l.Nodes, l.MaxNodes, limitUsage(int64(l.Nodes), int64(l.MaxNodes)))
+		}
+		if l.MaxStorageBytes > 0 {
+			sb += fmt.Sprintf("- Storage: %s of %s%s\n", formatAttachmentSize(l.StorageBytes), formatAttachmentSize(l.MaxStorageBytes),
+				limitUsage(l.StorageBytes, l.MaxStorageBytes))
+		}
+	}
 
 	// Usage metrics
-	if stats.TotalQueries > 0 || stats.TotalStores > 0 {
+	cache := stats.SearchCache
+	cacheUsed := cache != nil && cache.Hits+cache.Misses > 0
+	if stats.TotalQueries > 0 || stats.TotalStores > 0 || cacheUsed {
 		sb += "\n### Usage\n"
 		sb += fmt.Sprintf("- Total queries: %d\n", stats.TotalQueries)
 		sb += fmt.Sprintf("- Total stores: %d\n", stats.TotalStores)
@@ -72,7 +119,25 @@ func Status(ctx context.Context, client Querier, args map[string]any) (*ToolResu
 		if stats.LastStoreAt > 0 {
 			sb += fmt.Sprintf("- Last store: %s\n", time.Unix(stats.LastStoreAt, 0).UTC().Format("2006-01-02 15:04:05"))
 		}
+		if cacheUsed {
+			sb += fmt.Sprintf("- Search cache: %d hits, %d misses (%.0f%% hit rate), %d searches cached\n",
+				cache.Hits, cache.Misses, 100*float64(cache.Hits)/float64(cache.Hits+cache.Misses), cache.Entries)
+		}
 	}
 
 	return NewResult(sb), nil
 }
+
+// limitUsage describes how much of a limit is used, warning when it is
+// nearly or fully reached.
+func limitUsage(usage, limit int64) string {
+	pct := 100 * usage / limit
+	switch {
+	case usage >= limit:
+		return fmt.Sprintf(" (%d%%, full: stores fail until memories are pruned or the limit is raised)", pct)
+	case pct >= 90:
+		return fmt.Sprintf(" (%d%%, nearly full)", pct)
+	default:
+		return fmt.Sprintf(" (%d%%)", pct)
+	}
+}
@@ -30,6 +30,10 @@ func TestStatus_PopulatedGraph(t *testing.T) {
 				SchemaVersion:    "1",
 				StorageEngine:    "sqlite",
 				StoragePath:      "~/.mie/data/default/index.db",
+				Agents: []AgentStats{
+					{Agent: "claude", Facts: 30, Decisions: 8, Entities: 15, Events: 5},
+				},
+				SearchCache: &SearchCacheStats{Hits: 3, Misses: 1, Entries: 1},
 			}, nil
 		},
 		EmbeddingsEnabledFunc: func() bool { return true },
@@ -51,6 +55,8 @@ func TestStatus_PopulatedGraph(t *testing.T) {
 		"Events: 8",
 		"Topics: 5",
 		"89 edges",
+		"### Sources by Agent",
+		"claude: 58 nodes (30 facts, 8 decisions, 15 entities, 5 events)",
 		"sqlite",
 		"Embeddings: enabled",
 		"Schema version: 1",
@@ -59,6 +65,7 @@ func TestStatus_PopulatedGraph(t *testing.T) {
 		"Total stores: 15",
 		"Last query:",
 		"Last store:",
+		"Search cache: 3 hits, 1 misses (75% hit rate), 1 searches cached",
 	}
 	for _, check := range checks {
 		if !strings.Contains(result.Text, check) {
@@ -92,4 +99,56 @@ func TestStatus_EmptyGraph(t *testing.T) {
 	if strings.Contains(result.Text, "### Usage") {
 		t.Error("Status() should not show Usage section when counters are zero")
 	}
-}
\ No newline at end of file
+}
+
+func TestStatus_EmbeddingWarnings(t *testing.T) {
+	mock := &MockQuerier{
+		GetStatsFunc: func(ctx context.Context) (*GraphStats, error) {
+			return &GraphStats{
+				TotalFacts:        1,
+				EmbeddingWarnings: []string{"embedding provider ollama failed 2 call(s)"},
+				EmbeddingQueue:    &EmbeddingQueueStats{Pending: 3, Retrying: 1, OldestQueuedAt: 2000},
+			}, nil
+		},
+		EmbeddingsEnabledFunc: func() bool { return true },
+	}
+
+	result, err := Status(context.Background(), mock, map[string]any{})
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if !strings.Contains(result.Text, "- Warning: embedding provider ollama failed 2 call(s)") {
+		t.Errorf("Status() should list embedding warnings:\n%s", result.Text)
+	}
+	if !strings.Contains(result.Text, "- Embedding queue: 3 pending (1 retrying, oldest queued 1970-01-01 00:33:20)") {
+		t.Errorf("Status() should show the embedding queue:\n%s", result.Text)
+	}
+}
+
+func TestStatus_Limits(t *testing.T) {
+	mock := &MockQuerier{
+		GetStatsFunc: func(ctx context.Context) (*GraphStats, error) {
+			return &GraphStats{
+				TotalFacts: 950,
+				Limits:     &LimitStats{Facts: 950, MaxFacts: 1000, Nodes: 1000, MaxNodes: 1000, StorageBytes: 3 << 20},
+			}, nil
+		},
+	}
+
+	result, err := Status(context.Background(), mock, map[string]any{})
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	for _, want := range []string{
+		"### Limits\n",
+		"- Facts: 950 of 1000 (95%, nearly full)\n",
+		"- Nodes: 1000 of 1000 (100%, full: stores fail",
+	} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("Status() missing %q:\n%s", want, result.Text)
+		}
+	}
+	if strings.Contains(result.Text, "- Storage:") {
+		t.Errorf("Status() should not show an unset limit:\n%s", result.Text)
+	}
+}
@@ -7,21 +7,31 @@ package tools
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 )
 
-// validFactCategories enumerates allowed fact categories.
-var validFactCategories = map[string]bool{
-	"personal": true, "professional": true, "preference": true,
-	"technical": true, "relationship": true, "general": true,
+// factCategories lists allowed fact categories in the order tool schemas
+// show them. ExtendSchema appends configured categories.
+var factCategories = []string{
+	"personal", "professional", "preference",
+	"technical", "relationship", "general",
 }
 
-// validEntityKinds enumerates allowed entity kinds.
-var validEntityKinds = map[string]bool{
-	"person": true, "company": true, "project": true, "product": true,
-	"technology": true, "place": true, "other": true,
+// entityKinds lists allowed entity kinds in the order tool schemas show
+// them. ExtendSchema appends configured kinds.
+var entityKinds = []string{
+	"person", "company", "project", "product",
+	"technology", "place", "other",
 }
 
+// validFactCategories enumerates allowed fact categories.
+var validFactCategories = stringSet(factCategories)
+
+// validEntityKinds enumerates allowed entity kinds.
+var validEntityKinds = stringSet(entityKinds)
+
 // validEdgeTypes enumerates allowed relationship edge types.
 var validEdgeTypes = map[string]bool{
 	"fact_entity": true, "fact_topic": true, "decision_topic": true,
@@ -35,13 +45,14 @@ func Store(ctx context.Context, client Querier, args map[string]any) (*ToolResul
 		return NewError("Missing required parameter: type"), nil
 	}
 
-	nodeID, summary, err := storeNode(ctx, client, args, nodeType)
+	stored, err := storeNode(ctx, client, args, nodeType)
 	if err != nil {
 		return NewError(fmt.Sprintf("Failed to store %s: %v", nodeType, err)), nil
 	}
-	if nodeID == "" {
+	if stored.ID == "" {
 		return NewError(fmt.Sprintf("Invalid type %q. Must be one of: fact, decision, entity, event, topic", nodeType)), nil
 	}
+	nodeID := stored.ID
 
 	// Handle invalidation
 	toolErr, invalidationMsg := handleInvalidation(ctx, client, args, nodeID)
@@ -50,16 +61,35 @@ func Store(ctx context.Context, client Querier, args map[string]any) (*ToolResul
 	}
 
 	// Handle relationships
-	var relMsg string
-	if rels, ok := args["relationships"]; ok && rels != nil {
-		relMsg = storeRelationships(ctx, client, nodeID, rels)
+	var rels []relationshipResult
+	if raw, ok := args["relationships"]; ok && raw != nil {
+		rels = storeRelationships(ctx, client, nodeID, GetStringArg(args, "source_agent", "unknown"), raw)
 	}
 
 	// Increment usage counter (never fail the main operation).
-	_ = client.IncrementCounter(ctx, "total_stores")
+	if !stored.Duplicate {
+		_ = client.IncrementCounter(ctx, "total_stores")
+	}
+
+	if WantsJSON(args) {
+		out := storeJSON{
+			ID:            nodeID,
+			NodeType:      nodeType,
+			Duplicate:     stored.Duplicate,
+			Node:          stored.Node,
+			Relationships: rels,
+		}
+		if invalidationMsg != "" {
+			out.Invalidated = GetStringArg(args, "invalidates", "")
+		}
+		return NewJSONResult(out), nil
+	}
 
-	output := fmt.Sprintf("Stored %s [%s]\n%s", nodeType, nodeID, summary)
-	if relMsg != "" {
+	output := fmt.Sprintf("Stored %s [%s]\n%s", nodeType, nodeID, stored.Summary)
+	if stored.Duplicate {
+		output = fmt.Sprintf("Not stored: duplicate of existing %s [%s]\n%s", nodeType, nodeID, stored.Summary)
+	}
+	if relMsg := relationshipLines(rels); relMsg != "" {
 		output += "\n\nRelationships created:\n" + relMsg
 	}
 	if invalidationMsg != "" {
@@ -69,60 +99,101 @@ func Store(ctx context.Context, client Querier, args map[string]any) (*ToolResul
 	return NewResult(output), nil
 }
 
-func storeNode(ctx context.Context, client Querier, args map[string]any, nodeType string) (string, string, error) {
+// storeJSON is the JSON response of Store.
+type storeJSON struct {
+	ID            string               `json:"id"`
+	NodeType      string               `json:"node_type"`
+	Duplicate     bool                 `json:"duplicate"`
+	Node          any                  `json:"node"`
+	Relationships []relationshipResult `json:"relationships,omitempty"`
+	Invalidated   string               `json:"invalidated,omitempty"`
+}
+
+// storedNode is the outcome of storing one node. ID is empty if the node
+// type is unknown.
+type storedNode struct {
+	ID      string
+	Summary string
+	// Duplicate is set when an existing duplicate fact was returned
+	// instead of storing a new one.
+	Duplicate bool
+	Node      any
+}
+
+// storeNode stores one node of the given type.
+func storeNode(ctx context.Context, client Querier, args map[string]any, nodeType string) (storedNode, error) {
 	sourceAgent := GetStringArg(args, "source_agent", "unknown")
-	sourceConversation := GetStringArg(args, "source_conversation", "")
+	sourceConversation := GetStringArg(args, "source_conversation", SessionFromContext(ctx))
 
 	switch nodeType {
 	case "fact":
 		result, err := storeFact(ctx, client, args, sourceAgent, sourceConversation)
 		if err != nil {
-			return "", "", err
+			return storedNode{}, err
+		}
+		summary := fmt.Sprintf("Content: %q\nCategory: %s | Confidence: %.1f | Source: %s",
+			Truncate(result.Content, 100), result.Category, result.Confidence, result.SourceAgent)
+		if result.Negated {
+			summary += "\nNegated: this does NOT hold"
+		}
+		if result.Key != "" {
+			summary += fmt.Sprintf("\nKey: %s", result.Key)
 		}
-		return result.ID, fmt.Sprintf("Content: %q\nCategory: %s | Confidence: %.1f | Source: %s",
-			Truncate(result.Content, 100), result.Category, result.Confidence, result.SourceAgent), nil
+		if len(result.Replaces) > 0 {
+			summary += fmt.Sprintf("\nReplaced: [%s]", strings.Join(result.Replaces, "], ["))
+		}
+		if result.ExpiresAt != 0 {
+			summary += fmt.Sprintf("\nExpires: %s (then invalidated)", time.Unix(result.ExpiresAt, 0).UTC().Format(time.RFC3339))
+		}
+		if result.DuplicateSimilarity > 0 {
+			summary += fmt.Sprintf("\nduplicate_of: %s (%.0f%% similar)", result.ID, result.DuplicateSimilarity*100)
+			return storedNode{ID: result.ID, Summary: summary, Duplicate: true, Node: result}, nil
+		}
+		return storedNode{ID: result.ID, Summary: summary, Node: result}, nil
 
 	case "decision":
 		result, err := storeDecision(ctx, client, args, sourceAgent, sourceConversation)
 		if err != nil {
-			return "", "", err
+			return storedNode{}, err
 		}
-		return result.ID, fmt.Sprintf("Title: %q\nRationale: %s\nStatus: %s | Source: %s",
-			Truncate(result.Title, 100), Truncate(result.Rationale, 100), result.Status, result.SourceAgent), nil
+		summary := fmt.Sprintf("Title: %q\nRationale: %s\nStatus: %s | Source: %s",
+			Truncate(result.Title, 100), Truncate(result.Rationale, 100), result.Status, result.SourceAgent)
+		return storedNode{ID: result.ID, Summary: summary, Node: result}, nil
 
 	case "entity":
 		result, err := storeEntity(ctx, client, args, sourceAgent)
 		if err != nil {
-			return "", "", err
+			return storedNode{}, err
 		}
 		summary := fmt.Sprintf("Name: %q\nKind: %s | Source: %s",
 			result.Name, result.Kind, result.SourceAgent)
 		if result.Description != "" {
 			summary += fmt.Sprintf("\nDescription: %s", Truncate(result.Description, 100))
 		}
-		return result.ID, summary, nil
+		return storedNode{ID: result.ID, Summary: summary, Node: result}, nil
 
 	case "event":
 		result, err := storeEvent(ctx, client, args, sourceAgent, sourceConversation)
 		if err != nil {
-			return "", "", err
+			return storedNode{}, err
 		}
-		return result.ID, fmt.Sprintf("Title: %q\nDate: %s | Source: %s",
-			Truncate(result.Title, 100), result.EventDate, result.SourceAgent), nil
+		summary := fmt.Sprintf("Title: %q\nDate: %s | Source: %s",
+			Truncate(result.Title, 100), result.Dates(), result.SourceAgent)
+		return storedNode{ID: result.ID, Summary: summary, Node: result}, nil
 
 	case "topic":
 		result, err := storeTopic(ctx, client, args)
 		if err != nil {
-			return "", "", err
+			return storedNode{}, err
 		}
 		summary := fmt.Sprintf("Name: %q", result.Name)
 		if result.Description != "" {
 			summary += fmt.Sprintf("\nDescription: %s", Truncate(result.Description, 100))
 		}
-		return result.ID, summary, nil
+		return storedNode{ID: result.ID, Summary: summary, Node: result}, nil
 
 	default:
-		return "", "", nil
+		return storedNode{}, nil
 	}
 }
 
@@ -146,13 +217,30 @@ func storeFact(ctx context.Context, client Querier, args map[string]any, sourceA
 	if content == "" {
 		return nil, fmt.Errorf("content is required for fact type")
 	}
-	category := GetStringArg(args, "category", "general")
+	// A key marks a preference; the client rejects it on other categories.
+	key := strings.TrimSpace(GetStringArg(args, "key", ""))
+	defaultCategory := "general"
+	if key != "" {
+		defaultCategory = "preference"
+	}
+	category := GetStringArg(args, "category", defaultCategory)
 	if !validFactCategories[category] {
 		category = "general"
 	}
-	confidence := GetFloat64Arg(args, "confidence", 0.8)
+	confidence := GetFloat64Arg(args, "confidence", defaultConfidence(category))
 	if confidence <= 0 || confidence > 1.0 {
-		confidence = 0.8
+		confidence = defaultConfidence(category)
+	}
+	var expiresAt int64
+	if raw := GetStringArg(args, "expires_at", ""); raw != "" {
+		t, err := ParseTimestamp(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expires_at: %w", err)
+		}
+		if !t.After(time.Now()) {
+			return nil, fmt.Errorf("expires_at %s is not in the future", raw)
+		}
+		expiresAt = t.Unix()
 	}
 	return client.StoreFact(ctx, StoreFactRequest{
 		Content:            content,
@@ -160,6 +248,11 @@ func storeFact(ctx context.Context, client Querier, args map[string]any, sourceA
 		Confidence:         confidence,
 		SourceAgent:        sourceAgent,
 		SourceConversation: sourceConversation,
+		// A replacement is expected to resemble the fact it invalidates.
+		SkipDedup: GetStringArg(args, "invalidates", "") != "",
+		ExpiresAt: expiresAt,
+		Key:       key,
+		Negated:   GetBoolArg(args, "negated", false),
 	})
 }
 
@@ -179,6 +272,7 @@ func storeDecision(ctx context.Context, client Querier, args map[string]any, sou
 		Context:            GetStringArg(args, "context", ""),
 		SourceAgent:        sourceAgent,
 		SourceConversation: sourceConversation,
+		Status:             GetStringArg(args, "status", ""),
 	})
 }
 
@@ -192,7 +286,7 @@ func storeEntity(ctx context.Context, client Querier, args map[string]any, sourc
 		return nil, fmt.Errorf("kind is required for entity type")
 	}
 	if !validEntityKinds[kind] {
-		return nil, fmt.Errorf("invalid entity kind %q. Must be one of: person, company, project, product, technology, place, other", kind)
+		return nil, fmt.Errorf("invalid entity kind %q. Must be one of: %s", kind, strings.Join(entityKinds, ", "))
 	}
 	return client.StoreEntity(ctx, StoreEntityRequest{
 		Name:        name,
@@ -211,10 +305,18 @@ func storeEvent(ctx context.Context, client Querier, args map[string]any, source
 	if eventDate == "" {
 		return nil, fmt.Errorf("event_date is required for event type")
 	}
+	start, end, err := ParseEventDates(eventDate, GetStringArg(args, "end_date", ""))
+	if err != nil {
+		return nil, err
+	}
+	if end == start {
+		end = ""
+	}
 	return client.StoreEvent(ctx, StoreEventRequest{
 		Title:              title,
 		Description:        GetStringArg(args, "description", ""),
-		EventDate:          eventDate,
+		EventDate:          start,
+		EndDate:            end,
 		SourceAgent:        sourceAgent,
 		SourceConversation: sourceConversation,
 	})
@@ -231,12 +333,55 @@ func storeTopic(ctx context.Context, client Querier, args map[string]any) (*Topi
 	})
 }
 
-func storeRelationships(ctx context.Context, client Querier, sourceNodeID string, rels any) string {
+// relationshipResult is the outcome of creating one relationship.
+type relationshipResult struct {
+	Edge     string   `json:"edge"`
+	TargetID string   `json:"target_id"`
+	Weight   *float64 `json:"weight,omitempty"`
+	// Error says why the edge was skipped or failed; empty if it was created.
+	Error string `json:"error,omitempty"`
+}
+
+// errInvalidEdgeType is the relationshipResult error of an unknown edge type.
+const errInvalidEdgeType = "invalid edge type"
+
+// relationshipLines formats one line per relationship result.
+func relationshipLines(rels []relationshipResult) string {
+	var sb strings.Builder
+	for _, r := range rels {
+		switch {
+		case r.Error == errInvalidEdgeType:
+			sb.WriteString(fmt.Sprintf("- Skipped invalid edge type: %s\n", r.Edge))
+		case r.Error != "":
+			sb.WriteString(fmt.Sprintf("- Failed %s -> [%s]: %s\n", r.Edge, r.TargetID, r.Error))
+		case r.Weight != nil:
+			sb.WriteString(fmt.Sprintf("- %s -> [%s] (weight %s)\n", r.Edge, r.TargetID, strconv.FormatFloat(*r.Weight, 'g', -1, 64)))
+		default:
+			sb.WriteString(fmt.Sprintf("- %s -> [%s]\n", r.Edge, r.TargetID))
+		}
+	}
+	return sb.String()
+}
+
+// relationshipFailures returns how many relationships were skipped or failed.
+func relationshipFailures(rels []relationshipResult) int {
+	failed := 0
+	for _, r := range rels {
+		if r.Error != "" {
+			failed++
+		}
+	}
+	return failed
+}
+
+// storeRelationships creates the edges in rels from sourceNodeID, attributed
+// to sourceAgent, and returns what happened to each.
+func storeRelationships(ctx context.Context, client Querier, sourceNodeID, sourceAgent string, rels any) []relationshipResult {
 	relSlice, ok := rels.([]any)
 	if !ok {
-		return ""
+		return nil
 	}
-	var sb strings.Builder
+	var results []relationshipResult
 	for _, rel := range relSlice {
 		relMap, ok := rel.(map[string]any)
 		if !ok {
@@ -247,20 +392,25 @@ func storeRelationships(ctx context.Context, client Querier, sourceNodeID string
 		if edgeType == "" || targetID == "" {
 			continue
 		}
+		r := relationshipResult{Edge: edgeType, TargetID: targetID}
 		if !validEdgeTypes[edgeType] {
-			sb.WriteString(fmt.Sprintf("- Skipped invalid edge type: %s\n", edgeType))
+			r.Error = errInvalidEdgeType
+			results = append(results, r)
 			continue
 		}
 
 		fields := buildEdgeFields(edgeType, sourceNodeID, targetID, relMap)
+		fields["source_agent"] = sourceAgent
 		tableName := "mie_" + edgeType
 		if err := client.AddRelationship(ctx, tableName, fields); err != nil {
-			sb.WriteString(fmt.Sprintf("- Failed %s -> [%s]: %v\n", edgeType, targetID, err))
-		} else {
-			sb.WriteString(fmt.Sprintf("- %s -> [%s]\n", edgeType, targetID))
+			r.Error = err.Error()
+		} else if w := fields["weight"]; w != "" {
+			weight, _ := strconv.ParseFloat(w, 64)
+			r.Weight = &weight
 		}
+		results = append(results, r)
 	}
-	return sb.String()
+	return results
 }
 
 func buildEdgeFields(edgeType, sourceNodeID, targetID string, relMap map[string]any) map[string]string {
@@ -288,5 +438,8 @@ func buildEdgeFields(edgeType, sourceNodeID, targetID string, relMap map[string]
 		fields["entity_id"] = sourceNodeID
 		fields["topic_id"] = targetID
 	}
+	if w, ok := relMap["weight"]; ok && w != nil {
+		fields["weight"] = strconv.FormatFloat(GetFloat64Arg(relMap, "weight", 1), 'g', -1, 64)
+	}
 	return fields
 }
\ No newline at end of file
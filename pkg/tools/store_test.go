@@ -6,6 +6,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
@@ -37,6 +38,150 @@ func TestStore_Fact(t *testing.T) {
 	}
 }
 
+func TestStore_FactDuplicate(t *testing.T) {
+	stores := 0
+	mock := &MockQuerier{
+		StoreFactFunc: func(ctx context.Context, req StoreFactRequest) (*Fact, error) {
+			if req.SkipDedup {
+				t.Error("SkipDedup should only be set when invalidating")
+			}
+			return &Fact{ID: "fact:existing", Content: "User works at Kraklabs", Category: "professional", DuplicateSimilarity: 0.97}, nil
+		},
+		IncrementCounterFunc: func(ctx context.Context, key string) error {
+			stores++
+			return nil
+		},
+	}
+	result, err := Store(context.Background(), mock, map[string]any{
+		"type":    "fact",
+		"content": "The user works at Kraklabs",
+	})
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Store() returned error: %s", result.Text)
+	}
+	for _, check := range []string{"Not stored: duplicate of existing fact [fact:existing]", "duplicate_of: fact:existing (97% similar)"} {
+		if !strings.Contains(result.Text, check) {
+			t.Errorf("Store() output missing %q, got: %s", check, result.Text)
+		}
+	}
+	if stores != 0 {
+		t.Errorf("duplicates should not increment total_stores, got %d increments", stores)
+	}
+}
+
+func TestStore_FactInvalidationSkipsDedup(t *testing.T) {
+	mock := &MockQuerier{
+		StoreFactFunc: func(ctx context.Context, req StoreFactRequest) (*Fact, error) {
+			if !req.SkipDedup {
+				t.Error("Expected SkipDedup when the fact invalidates another")
+			}
+			return &Fact{ID: "fact:new", Content: req.Content}, nil
+		},
+	}
+	result, _ := Store(context.Background(), mock, map[string]any{
+		"type":        "fact",
+		"content":     "User lives in Lyon",
+		"invalidates": "fact:old",
+	})
+	if result.IsError {
+		t.Fatalf("Store() returned error: %s", result.Text)
+	}
+}
+
+func TestStore_FactExpiresAt(t *testing.T) {
+	var got StoreFactRequest
+	mock := &MockQuerier{
+		StoreFactFunc: func(ctx context.Context, req StoreFactRequest) (*Fact, error) {
+			got = req
+			return &Fact{ID: "fact:mock0001", Content: req.Content, ExpiresAt: req.ExpiresAt}, nil
+		},
+	}
+	result, err := Store(context.Background(), mock, map[string]any{
+		"type":       "fact",
+		"content":    "User is traveling in Japan",
+		"expires_at": "2099-03-01",
+	})
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Store() returned error: %s", result.Text)
+	}
+	if got.ExpiresAt != 4076006400 {
+		t.Errorf("ExpiresAt = %d, want 2099-03-01 UTC", got.ExpiresAt)
+	}
+	if !strings.Contains(result.Text, "Expires: 2099-03-01T00:00:00Z") {
+		t.Errorf("Store() should show the expiry:\n%s", result.Text)
+	}
+
+	for _, expiresAt := range []string{"next week", "2020-01-01"} {
+		result, _ = Store(context.Background(), &MockQuerier{}, map[string]any{
+			"type":       "fact",
+			"content":    "User is traveling",
+			"expires_at": expiresAt,
+		})
+		if !result.IsError || !strings.Contains(result.Text, "expires_at") {
+			t.Errorf("expires_at %q should be rejected, got: %s", expiresAt, result.Text)
+		}
+	}
+}
+
+func TestStore_FactKey(t *testing.T) {
+	var got StoreFactRequest
+	mock := &MockQuerier{
+		StoreFactFunc: func(ctx context.Context, req StoreFactRequest) (*Fact, error) {
+			got = req
+			return &Fact{ID: "fact:mock0002", Content: req.Content, Category: req.Category, Key: "editor", Replaces: []string{"fact:mock0001"}}, nil
+		},
+	}
+	result, err := Store(context.Background(), mock, map[string]any{
+		"type":    "fact",
+		"content": "User edits in Helix",
+		"key":     " editor ",
+	})
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Store() returned error: %s", result.Text)
+	}
+	if got.Key != "editor" || got.Category != "preference" {
+		t.Errorf("request key %q category %q, want editor preference", got.Key, got.Category)
+	}
+	for _, want := range []string{"Key: editor", "Replaced: [fact:mock0001]"} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("Store() should contain %q:\n%s", want, result.Text)
+		}
+	}
+}
+
+func TestStore_FactNegated(t *testing.T) {
+	var got StoreFactRequest
+	mock := &MockQuerier{
+		StoreFactFunc: func(ctx context.Context, req StoreFactRequest) (*Fact, error) {
+			got = req
+			return &Fact{ID: "fact:mock0003", Content: req.Content, Negated: req.Negated}, nil
+		},
+	}
+	result, err := Store(context.Background(), mock, map[string]any{
+		"type":    "fact",
+		"content": "User uses Docker",
+		"negated": true,
+	})
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if !got.Negated {
+		t.Error("request should be negated")
+	}
+	if !strings.Contains(result.Text, "Negated: this does NOT hold") {
+		t.Errorf("Store() should mark the anti-fact:\n%s", result.Text)
+	}
+}
+
 func TestStore_Decision(t *testing.T) {
 	mock := &MockQuerier{}
 	result, err := Store(context.Background(), mock, map[string]any{
@@ -91,6 +236,40 @@ func TestStore_Event(t *testing.T) {
 	}
 }
 
+func TestStore_EventPartialDate(t *testing.T) {
+	var got StoreEventRequest
+	mock := &MockQuerier{
+		StoreEventFunc: func(_ context.Context, req StoreEventRequest) (*Event, error) {
+			got = req
+			return &Event{ID: "evt:mock0001", Title: req.Title, EventDate: req.EventDate, EndDate: req.EndDate}, nil
+		},
+	}
+	result, err := Store(context.Background(), mock, map[string]any{
+		"type":       "event",
+		"title":      "Billing migration",
+		"event_date": "2025-Q3",
+		"end_date":   "Oct 2025",
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("Store() = %v, %v", result, err)
+	}
+	if got.EventDate != "2025-07-01" || got.EndDate != "2025-10-31" {
+		t.Errorf("stored dates %s..%s, want 2025-07-01..2025-10-31", got.EventDate, got.EndDate)
+	}
+	if !strings.Contains(result.Text, "2025-07-01..2025-10-31") {
+		t.Errorf("Store() should show the date range, got %s", result.Text)
+	}
+
+	result, _ = Store(context.Background(), mock, map[string]any{
+		"type":       "event",
+		"title":      "Billing migration",
+		"event_date": "sometime",
+	})
+	if !result.IsError {
+		t.Error("Store() should reject an unparseable event_date")
+	}
+}
+
 func TestStore_Topic(t *testing.T) {
 	mock := &MockQuerier{}
 	result, err := Store(context.Background(), mock, map[string]any{
@@ -330,4 +509,49 @@ func TestStore_CounterErrorDoesNotFailStore(t *testing.T) {
 	if result.IsError {
 		t.Error("Store() should succeed even when counter increment fails")
 	}
-}
\ No newline at end of file
+}
+
+func TestStore_JSON(t *testing.T) {
+	mock := &MockQuerier{}
+	result, err := Store(context.Background(), mock, map[string]any{
+		"type":            "fact",
+		"content":         "User works at Kraklabs",
+		"invalidates":     "fact:old",
+		"relationships":   []any{map[string]any{"edge": "fact_entity", "target_id": "ent:kraklabs", "weight": 0.5}, map[string]any{"edge": "bogus", "target_id": "ent:x"}},
+		"response_format": "json",
+	})
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	var got struct {
+		ID            string `json:"id"`
+		NodeType      string `json:"node_type"`
+		Duplicate     bool   `json:"duplicate"`
+		Node          Fact   `json:"node"`
+		Invalidated   string `json:"invalidated"`
+		Relationships []struct {
+			Edge     string   `json:"edge"`
+			TargetID string   `json:"target_id"`
+			Weight   *float64 `json:"weight"`
+			Error    string   `json:"error"`
+		} `json:"relationships"`
+	}
+	if err := json.Unmarshal([]byte(result.Text), &got); err != nil {
+		t.Fatalf("Store() output is not JSON: %v\n%s", err, result.Text)
+	}
+	if got.ID != "fact:mock0001" || got.NodeType != "fact" || got.Duplicate || got.Node.Content != "User works at Kraklabs" {
+		t.Errorf("Store() JSON = %+v", got)
+	}
+	if got.Invalidated != "fact:old" {
+		t.Errorf("invalidated = %q, want fact:old", got.Invalidated)
+	}
+	if len(got.Relationships) != 2 {
+		t.Fatalf("relationships = %+v, want 2", got.Relationships)
+	}
+	if r := got.Relationships[0]; r.Error != "" || r.Weight == nil || *r.Weight != 0.5 {
+		t.Errorf("relationships[0] = %+v, want created with weight 0.5", r)
+	}
+	if r := got.Relationships[1]; r.Error != "invalid edge type" {
+		t.Errorf("relationships[1] = %+v, want invalid edge type", r)
+	}
+}
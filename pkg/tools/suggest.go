@@ -0,0 +1,266 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// nameMatchConfidence is the confidence a suggestion gets when one node's
+// text mentions the other node's name as a whole word.
+const nameMatchConfidence = 0.9
+
+// maxNameCandidates caps the entities and topics whose names are matched
+// against a node's text, and the nodes searched for a node's name.
+const maxNameCandidates = 1000
+
+// nodeTypePrefixes maps node types to the prefixes of their IDs.
+var nodeTypePrefixes = map[string]string{
+	"fact":     "fact:",
+	"decision": "dec:",
+	"entity":   "ent:",
+	"event":    "evt:",
+	"topic":    "top:",
+}
+
+// SuggestRelationships proposes edges between a node and nodes it is not yet
+// linked to, scored by embedding similarity and by name matching. The agent
+// confirms a suggestion by passing its edge, source_id, and target_id to
+// mie_relate.
+func SuggestRelationships(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
+	nodeID := GetStringArg(args, "node_id", "")
+	if nodeID == "" {
+		return NewError("Missing required parameter: node_id"), nil
+	}
+	limit := GetIntArg(args, "limit", 10)
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > 50 {
+		limit = 50
+	}
+	minConfidence := GetFloat64Arg(args, "min_confidence", 0.5)
+	if minConfidence < 0 || minConfidence > 1.0 {
+		minConfidence = 0.5
+	}
+
+	node, err := client.GetNodeByID(ctx, nodeID)
+	if err != nil {
+		return NewError(fmt.Sprintf("Node [%s] not found: %v", nodeID, err)), nil
+	}
+	_ = client.IncrementCounter(ctx, "total_queries")
+
+	existing, err := client.GetNodeEdges(ctx, nodeID)
+	if err != nil {
+		return NewError(fmt.Sprintf("Failed to get edges of [%s]: %v", nodeID, err)), nil
+	}
+	linked := map[string]bool{nodeID: true}
+	for _, e := range existing {
+		linked[e.SourceID] = true
+		linked[e.TargetID] = true
+	}
+
+	partners := partnerEdges(nodeID)
+	suggestions := map[string]*relationshipSuggestion{}
+	suggest := func(nodeType, id, label string, confidence float64, reason string) {
+		edge, ok := partners[nodeType]
+		if !ok || linked[id] {
+			return
+		}
+		s := suggestions[id]
+		if s == nil {
+			s = &relationshipSuggestion{Edge: edge, SourceID: nodeID, TargetID: id, NodeType: nodeType, Label: label}
+			if edgeEndpoints[edge][0] == nodeTypePrefixes[nodeType] {
+				s.SourceID, s.TargetID = id, nodeID
+			}
+			suggestions[id] = s
+		}
+		if slices.Contains(s.Reasons, reason) {
+			return
+		}
+		confidence = math.Max(0, math.Min(confidence, 1))
+		s.Confidence = 1 - (1-s.Confidence)*(1-confidence)
+		s.Reasons = append(s.Reasons, reason)
+	}
+
+	nodeType, _ := nodeLabel(node)
+	text := suggestionText(node)
+
+	embeddings := client.EmbeddingsEnabled()
+	if embeddings {
+		var types []string
+		for _, t := range allSearchableNodeTypes {
+			if _, ok := partners[t]; ok {
+				types = append(types, t)
+			}
+		}
+		if len(types) > 0 {
+			results, err := client.SemanticSearch(ctx, text, types, limit*3)
+			if err != nil {
+				return NewError(fmt.Sprintf("Semantic search failed: %v", err)), nil
+			}
+			for _, r := range results {
+				suggest(r.NodeType, r.ID, r.Content, 1-r.Distance,
+					fmt.Sprintf("embedding similarity %d%%", SimilarityPercent(r.Distance)))
+			}
+		}
+	}
+
+	// Entities and topics named in the node's text.
+	for _, t := range []string{"entity", "topic"} {
+		if _, ok := partners[t]; !ok {
+			continue
+		}
+		nodes, _, err := client.ListNodes(ctx, ListOptions{NodeType: t, Limit: maxNameCandidates})
+		if err != nil {
+			return NewError(fmt.Sprintf("Failed to list %s nodes: %v", t, err)), nil
+		}
+		for _, n := range nodes {
+			var id, name string
+			switch n := n.(type) {
+			case *Entity:
+				id, name = n.ID, n.Name
+			case *Topic:
+				id, name = n.ID, n.Name
+			}
+			if id != "" && mentionsName(text, name) {
+				suggest(t, id, name, nameMatchConfidence, fmt.Sprintf("name match %q", name))
+			}
+		}
+	}
+
+	// Nodes whose text names this entity or topic.
+	if nodeType == "entity" || nodeType == "topic" {
+		_, name := nodeLabel(node)
+		var types []string
+		for _, t := range []string{"fact", "decision", "entity"} {
+			if _, ok := partners[t]; ok {
+				types = append(types, t)
+			}
+		}
+		results, err := client.ExactSearch(ctx, name, types, maxNameCandidates)
+		if err != nil {
+			return NewError(fmt.Sprintf("Search for %q failed: %v", name, err)), nil
+		}
+		for _, r := range results {
+			if mentionsName(r.Content+"\n"+r.Detail, name) {
+				suggest(r.NodeType, r.ID, r.Content, nameMatchConfidence, fmt.Sprintf("name match %q", name))
+			}
+		}
+	}
+
+	var out []relationshipSuggestion
+	for _, s := range suggestions {
+		if s.Confidence >= minConfidence {
+			out = append(out, *s)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Confidence != out[j].Confidence {
+			return out[i].Confidence > out[j].Confidence
+		}
+		return out[i].SourceID+out[i].TargetID < out[j].SourceID+out[j].TargetID
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+
+	if WantsJSON(args) {
+		if out == nil {
+			out = []relationshipSuggestion{}
+		}
+		return NewJSONResult(suggestJSON{NodeID: nodeID, NodeType: nodeType, MinConfidence: minConfidence, Suggestions: out}), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("## Relationship Suggestions for [%s] (%d)\n\n", nodeID, len(out)))
+	if !embeddings {
+		sb.WriteString("_Embeddings are disabled; suggestions come from name matching only._\n\n")
+	}
+	if len(out) == 0 {
+		sb.WriteString(fmt.Sprintf("_No unlinked nodes found at %.0f%% confidence or above._\n", minConfidence*100))
+		return NewResult(sb.String()), nil
+	}
+
+	sb.WriteString("| Confidence | Edge | Source | Target | Node | Why |\n")
+	sb.WriteString("|------------|------|--------|--------|------|-----|\n")
+	for _, s := range out {
+		sb.WriteString(fmt.Sprintf("| %.0f%% | %s | %s | %s | %s | %s |\n",
+			s.Confidence*100, s.Edge, s.SourceID, s.TargetID, Truncate(s.Label, 50), strings.Join(s.Reasons, "; ")))
+	}
+	sb.WriteString("\nTo confirm: call mie_relate with the edge, source_id, and target_id of a suggestion.\n")
+
+	return NewResult(sb.String()), nil
+}
+
+// relationshipSuggestion is an edge proposed by SuggestRelationships. Its
+// Edge, SourceID, and TargetID are the arguments mie_relate takes to create
+// it; NodeType and Label describe the other end of the edge.
+type relationshipSuggestion struct {
+	Edge       string   `json:"edge"`
+	SourceID   string   `json:"source_id"`
+	TargetID   string   `json:"target_id"`
+	NodeType   string   `json:"node_type"`
+	Label      string   `json:"label"`
+	Confidence float64  `json:"confidence"`
+	Reasons    []string `json:"reasons"`
+}
+
+// suggestJSON is the JSON response of SuggestRelationships.
+type suggestJSON struct {
+	NodeID        string                   `json:"node_id"`
+	NodeType      string                   `json:"node_type"`
+	MinConfidence float64                  `json:"min_confidence"`
+	Suggestions   []relationshipSuggestion `json:"suggestions"`
+}
+
+// partnerEdges maps the types of nodes that nodeID can be linked to onto the
+// edge type linking them, in either direction.
+func partnerEdges(nodeID string) map[string]string {
+	types := make(map[string]string, len(nodeTypePrefixes))
+	for t, prefix := range nodeTypePrefixes {
+		types[prefix] = t
+	}
+	partners := map[string]string{}
+	for edge, ends := range edgeEndpoints {
+		switch {
+		case strings.HasPrefix(nodeID, ends[0]):
+			partners[types[ends[1]]] = edge
+		case strings.HasPrefix(nodeID, ends[1]):
+			partners[types[ends[0]]] = edge
+		}
+	}
+	return partners
+}
+
+// suggestionText returns the text of node that is compared with other nodes.
+func suggestionText(node any) string {
+	var parts []string
+	switch n := node.(type) {
+	case *Fact:
+		parts = []string{n.Content}
+	case *Decision:
+		parts = []string{n.Title, n.Rationale}
+	case *Entity:
+		parts = []string{n.Name, n.Description}
+	case *Event:
+		parts = []string{n.Title, n.Description}
+	case *Topic:
+		parts = []string{n.Name, n.Description}
+	}
+	return strings.TrimSpace(strings.Join(parts, "\n"))
+}
+
+// mentionsName reports whether text contains name as a whole word, ignoring
+// case. Names shorter than two characters never match.
+func mentionsName(text, name string) bool {
+	return utf8.RuneCountInString(strings.TrimSpace(name)) >= 2 && MentionsWord(text, name, true)
+}
@@ -0,0 +1,197 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// suggestMock serves a fact mentioning two entities, one of them already
+// linked, a decision mentioning one of them, and a topic.
+func suggestMock() *MockQuerier {
+	nodes := map[string]any{
+		"fact:1":       &Fact{ID: "fact:1", Content: "The billing service runs on Kubernetes and stores invoices in Postgres."},
+		"dec:1":        &Decision{ID: "dec:1", Title: "Move billing to Kubernetes", Rationale: "Autoscaling"},
+		"ent:k8s":      &Entity{ID: "ent:k8s", Name: "Kubernetes"},
+		"ent:postgres": &Entity{ID: "ent:postgres", Name: "Postgres"},
+		"ent:go":       &Entity{ID: "ent:go", Name: "Go"},
+		"top:billing":  &Topic{ID: "top:billing", Name: "Billing"},
+	}
+	edges := []GraphEdge{
+		{Type: "fact_entity", SourceID: "fact:1", TargetID: "ent:postgres", Weight: 1},
+	}
+	return &MockQuerier{
+		GetNodeByIDFunc: func(ctx context.Context, nodeID string) (any, error) {
+			if n, ok := nodes[nodeID]; ok {
+				return n, nil
+			}
+			return nil, fmt.Errorf("node %q not found", nodeID)
+		},
+		GetNodeEdgesFunc: func(ctx context.Context, nodeID string) ([]GraphEdge, error) {
+			var out []GraphEdge
+			for _, e := range edges {
+				if e.SourceID == nodeID || e.TargetID == nodeID {
+					out = append(out, e)
+				}
+			}
+			return out, nil
+		},
+		ListNodesFunc: func(ctx context.Context, opts ListOptions) ([]any, int, error) {
+			switch opts.NodeType {
+			case "entity":
+				return []any{nodes["ent:k8s"], nodes["ent:postgres"], nodes["ent:go"]}, 3, nil
+			case "topic":
+				return []any{nodes["top:billing"]}, 1, nil
+			}
+			return nil, 0, nil
+		},
+		ExactSearchFunc: func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
+			var out []SearchResult
+			if strings.Contains(nodes["fact:1"].(*Fact).Content, query) {
+				out = append(out, SearchResult{NodeType: "fact", ID: "fact:1", Content: nodes["fact:1"].(*Fact).Content})
+			}
+			if strings.Contains("Move billing to Kubernetes", query) {
+				out = append(out, SearchResult{NodeType: "decision", ID: "dec:1", Content: "Move billing to Kubernetes", Detail: "Autoscaling"})
+			}
+			return out, nil
+		},
+		EmbeddingsEnabledFunc: func() bool { return false },
+	}
+}
+
+func TestSuggestRelationshipsByName(t *testing.T) {
+	result, err := SuggestRelationships(context.Background(), suggestMock(), map[string]any{"node_id": "fact:1"})
+	if err != nil {
+		t.Fatalf("SuggestRelationships() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("SuggestRelationships() returned error: %s", result.Text)
+	}
+	for _, want := range []string{
+		"| 90% | fact_entity | fact:1 | ent:k8s | Kubernetes |",
+		"| 90% | fact_topic | fact:1 | top:billing | Billing |",
+		"Embeddings are disabled",
+		"mie_relate",
+	} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("output missing %q:\n%s", want, result.Text)
+		}
+	}
+	// Postgres is already linked, and "Go" only appears inside other words.
+	for _, unwanted := range []string{"ent:postgres", "ent:go"} {
+		if strings.Contains(result.Text, unwanted) {
+			t.Errorf("output should not suggest %s:\n%s", unwanted, result.Text)
+		}
+	}
+}
+
+func TestSuggestRelationshipsForEntity(t *testing.T) {
+	result, err := SuggestRelationships(context.Background(), suggestMock(), map[string]any{
+		"node_id":         "ent:k8s",
+		"response_format": "json",
+	})
+	if err != nil {
+		t.Fatalf("SuggestRelationships() error = %v", err)
+	}
+	var out suggestJSON
+	if err := json.Unmarshal([]byte(result.Text), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result.Text)
+	}
+	got := map[string]relationshipSuggestion{}
+	for _, s := range out.Suggestions {
+		got[s.Edge] = s
+	}
+	if s := got["fact_entity"]; s.SourceID != "fact:1" || s.TargetID != "ent:k8s" {
+		t.Errorf("fact_entity suggestion = %+v, want fact:1 -> ent:k8s", s)
+	}
+	if s := got["decision_entity"]; s.SourceID != "dec:1" || s.TargetID != "ent:k8s" {
+		t.Errorf("decision_entity suggestion = %+v, want dec:1 -> ent:k8s", s)
+	}
+}
+
+func TestSuggestRelationshipsCombinesSignals(t *testing.T) {
+	mock := suggestMock()
+	mock.EmbeddingsEnabledFunc = func() bool { return true }
+	mock.SemanticSearchFunc = func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
+		for _, nt := range nodeTypes {
+			if nt == "topic" {
+				t.Errorf("SemanticSearch() got node type topic, which has no embeddings")
+			}
+		}
+		return []SearchResult{
+			{NodeType: "entity", ID: "ent:k8s", Content: "Kubernetes", Distance: 0.4},
+			{NodeType: "entity", ID: "ent:go", Content: "Go", Distance: 0.7},
+		}, nil
+	}
+
+	result, err := SuggestRelationships(context.Background(), mock, map[string]any{
+		"node_id":         "fact:1",
+		"response_format": "json",
+	})
+	if err != nil {
+		t.Fatalf("SuggestRelationships() error = %v", err)
+	}
+	var out suggestJSON
+	if err := json.Unmarshal([]byte(result.Text), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result.Text)
+	}
+	if len(out.Suggestions) != 2 {
+		t.Fatalf("got %d suggestions, want 2 (Go is below min_confidence): %+v", len(out.Suggestions), out.Suggestions)
+	}
+	first := out.Suggestions[0]
+	if first.TargetID != "ent:k8s" || len(first.Reasons) != 2 {
+		t.Errorf("first suggestion = %+v, want ent:k8s with two reasons", first)
+	}
+	// 1 - (1-0.9)*(1-0.6)
+	if first.Confidence < 0.959 || first.Confidence > 0.961 {
+		t.Errorf("combined confidence = %v, want 0.96", first.Confidence)
+	}
+}
+
+func TestSuggestRelationshipsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"missing node_id", map[string]any{}, "Missing required parameter: node_id"},
+		{"unknown node", map[string]any{"node_id": "fact:nope"}, "not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := SuggestRelationships(context.Background(), suggestMock(), tt.args)
+			if err != nil {
+				t.Fatalf("SuggestRelationships() error = %v", err)
+			}
+			if !result.IsError || !strings.Contains(result.Text, tt.want) {
+				t.Errorf("got %q, want error containing %q", result.Text, tt.want)
+			}
+		})
+	}
+}
+
+func TestMentionsName(t *testing.T) {
+	tests := []struct {
+		text, name string
+		want       bool
+	}{
+		{"Runs on Kubernetes.", "kubernetes", true},
+		{"Uses Go 1.24", "Go", true},
+		{"Good governance", "Go", false},
+		{"C++ and C", "C", false},
+		{"node_modules", "node", false},
+		{"Café au lait", "café", true},
+		{"", "Go", false},
+	}
+	for _, tt := range tests {
+		if got := mentionsName(tt.text, tt.name); got != tt.want {
+			t.Errorf("mentionsName(%q, %q) = %v, want %v", tt.text, tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeRange restricts reads to nodes created within a window and, for
+// events, to an event_date window. Zero values leave a bound open.
+type TimeRange struct {
+	// CreatedAfter keeps nodes with created_at >= CreatedAfter (Unix seconds).
+	CreatedAfter int64 `json:"created_after,omitempty"`
+	// CreatedBefore keeps nodes with created_at < CreatedBefore (Unix seconds).
+	CreatedBefore int64 `json:"created_before,omitempty"`
+	// EventDateFrom and EventDateTo (YYYY-MM-DD) keep the events that
+	// overlap them: those starting on or before EventDateTo and ending on
+	// or after EventDateFrom.
+	EventDateFrom string `json:"event_date_from,omitempty"`
+	EventDateTo   string `json:"event_date_to,omitempty"`
+}
+
+// IsZero reports whether the range has no bounds.
+func (t TimeRange) IsZero() bool {
+	return t == TimeRange{}
+}
+
+// HasEventDate reports whether the range bounds event_date.
+func (t TimeRange) HasEventDate() bool {
+	return t.EventDateFrom != "" || t.EventDateTo != ""
+}
+
+type timeRangeKey struct{}
+
+// WithTimeRange returns a copy of ctx that restricts searches to tr.
+// A zero range returns ctx unchanged.
+func WithTimeRange(ctx context.Context, tr TimeRange) context.Context {
+	if tr.IsZero() {
+		return ctx
+	}
+	return context.WithValue(ctx, timeRangeKey{}, tr)
+}
+
+// TimeRangeFromContext returns the time range carried by ctx, or a zero range.
+func TimeRangeFromContext(ctx context.Context) TimeRange {
+	tr, _ := ctx.Value(timeRangeKey{}).(TimeRange)
+	return tr
+}
+
+// ParseTimeRangeArgs reads the created_after, created_before, and
+// event_date_range tool arguments. Timestamps accept RFC 3339 or YYYY-MM-DD
+// (midnight UTC). event_date_range is "FROM..TO" with either side optional,
+// or a single date meaning that day or period; the dates take the forms of
+// ParseEventDate.
+func ParseTimeRangeArgs(args map[string]any) (TimeRange, error) {
+	var tr TimeRange
+
+	if s := GetStringArg(args, "created_after", ""); s != "" {
+		t, err := ParseTimestamp(s)
+		if err != nil {
+			return tr, fmt.Errorf("invalid created_after: %w", err)
+		}
+		tr.CreatedAfter = t.Unix()
+	}
+	if s := GetStringArg(args, "created_before", ""); s != "" {
+		t, err := ParseTimestamp(s)
+		if err != nil {
+			return tr, fmt.Errorf("invalid created_before: %w", err)
+		}
+		tr.CreatedBefore = t.Unix()
+	}
+	if tr.CreatedAfter != 0 && tr.CreatedBefore != 0 && tr.CreatedAfter >= tr.CreatedBefore {
+		return tr, fmt.Errorf("created_after must be earlier than created_before")
+	}
+
+	if s := strings.TrimSpace(GetStringArg(args, "event_date_range", "")); s != "" {
+		from, to := s, s
+		if i := strings.Index(s, ".."); i >= 0 {
+			from, to = strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+2:])
+		}
+		if from == "" && to == "" {
+			return tr, fmt.Errorf("invalid event_date_range %q: expected FROM..TO", s)
+		}
+		// A partial date covers its whole period: "2025-Q3" starts on
+		// July 1 as FROM and ends on September 30 as TO.
+		var err error
+		if from != "" {
+			if from, _, err = ParseEventDate(from); err != nil {
+				return tr, fmt.Errorf("invalid event_date_range %q: %w", s, err)
+			}
+		}
+		if to != "" {
+			if _, to, err = ParseEventDate(to); err != nil {
+				return tr, fmt.Errorf("invalid event_date_range %q: %w", s, err)
+			}
+		}
+		if from != "" && to != "" && from > to {
+			return tr, fmt.Errorf("invalid event_date_range %q: start is after end", s)
+		}
+		tr.EventDateFrom, tr.EventDateTo = from, to
+	}
+
+	return tr, nil
+}
+
+// ParseTimestamp parses an RFC 3339 timestamp or a YYYY-MM-DD date (midnight
+// UTC), the forms accepted by time arguments such as created_after.
+func ParseTimestamp(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not an RFC 3339 timestamp or YYYY-MM-DD date", s)
+	}
+	return t, nil
+}
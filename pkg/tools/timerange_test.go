@@ -0,0 +1,61 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseTimeRangeArgs(t *testing.T) {
+	jan1 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	feb1 := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC).Unix()
+
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    TimeRange
+		wantErr bool
+	}{
+		{"empty", map[string]any{}, TimeRange{}, false},
+		{"dates", map[string]any{"created_after": "2026-01-01", "created_before": "2026-02-01"}, TimeRange{CreatedAfter: jan1, CreatedBefore: feb1}, false},
+		{"rfc3339", map[string]any{"created_after": "2026-01-01T00:00:00Z"}, TimeRange{CreatedAfter: jan1}, false},
+		{"event range", map[string]any{"event_date_range": "2026-01-01..2026-01-31"}, TimeRange{EventDateFrom: "2026-01-01", EventDateTo: "2026-01-31"}, false},
+		{"open start", map[string]any{"event_date_range": "..2026-01-31"}, TimeRange{EventDateTo: "2026-01-31"}, false},
+		{"open end", map[string]any{"event_date_range": "2026-01-01.."}, TimeRange{EventDateFrom: "2026-01-01"}, false},
+		{"single day", map[string]any{"event_date_range": "2026-01-15"}, TimeRange{EventDateFrom: "2026-01-15", EventDateTo: "2026-01-15"}, false},
+		{"quarter", map[string]any{"event_date_range": "2025-Q3"}, TimeRange{EventDateFrom: "2025-07-01", EventDateTo: "2025-09-30"}, false},
+		{"months", map[string]any{"event_date_range": "June 2025..2025-08"}, TimeRange{EventDateFrom: "2025-06-01", EventDateTo: "2025-08-31"}, false},
+		{"bad timestamp", map[string]any{"created_after": "last month"}, TimeRange{}, true},
+		{"inverted created", map[string]any{"created_after": "2026-02-01", "created_before": "2026-01-01"}, TimeRange{}, true},
+		{"bad event date", map[string]any{"event_date_range": "January..February"}, TimeRange{}, true},
+		{"inverted event range", map[string]any{"event_date_range": "2026-02-01..2026-01-01"}, TimeRange{}, true},
+		{"empty event range", map[string]any{"event_date_range": ".."}, TimeRange{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTimeRangeArgs(tt.args)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWithTimeRange(t *testing.T) {
+	ctx := context.Background()
+	if !TimeRangeFromContext(ctx).IsZero() {
+		t.Error("TimeRangeFromContext(empty) should be zero")
+	}
+
+	tr := TimeRange{CreatedAfter: 100}
+	ctx = WithTimeRange(ctx, tr)
+	if got := TimeRangeFromContext(ctx); got != tr {
+		t.Errorf("TimeRangeFromContext() = %+v, want %+v", got, tr)
+	}
+}
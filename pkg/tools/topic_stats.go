@@ -0,0 +1,134 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// sparseTopicThreshold is the number of linked nodes below which a topic is
+// reported as sparsely covered.
+const sparseTopicThreshold = 3
+
+// StatsByTopic breaks the memory graph down per topic so callers can see
+// which areas are well covered and which are sparse.
+func StatsByTopic(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
+	sortBy := GetStringArg(args, "sort_by", "coverage")
+	switch sortBy {
+	case "coverage", "activity", "name":
+	default:
+		return NewError(fmt.Sprintf("Invalid sort_by %q. Must be one of: coverage, activity, name", sortBy)), nil
+	}
+	limit := GetIntArg(args, "limit", 25)
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	ctx = WithIncludeArchived(ctx, GetBoolArg(args, "include_archived", false))
+	stats, err := client.GetTopicStats(ctx)
+	if err != nil {
+		return NewError(fmt.Sprintf("Failed to get topic stats: %v", err)), nil
+	}
+	_ = client.IncrementCounter(ctx, "total_queries")
+
+	if WantsJSON(args) {
+		return topicStatsJSONResult(stats, sortBy, limit), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Memory Coverage by Topic\n\n")
+	if len(stats) == 0 {
+		sb.WriteString("_No topics found. Store topics and link memory to them with mie_relate to see coverage._\n")
+		return NewResult(sb.String()), nil
+	}
+
+	sortTopicStats(stats, sortBy)
+
+	var sparse []string
+	for _, t := range stats {
+		if t.Total() < sparseTopicThreshold {
+			sparse = append(sparse, t.Name)
+		}
+	}
+	fmt.Fprintf(&sb, "%d topics, %d with fewer than %d linked nodes.\n\n", len(stats), len(sparse), sparseTopicThreshold)
+
+	shown := stats
+	if len(shown) > limit {
+		shown = shown[:limit]
+	}
+	sb.WriteString("| Topic | ID | Facts | Decisions | Events | Entities | Total | Last activity |\n")
+	sb.WriteString("|-------|-----|-------|-----------|--------|----------|-------|---------------|\n")
+	for _, t := range shown {
+		lastActivity := "-"
+		if t.LastActivity > 0 {
+			lastActivity = time.Unix(t.LastActivity, 0).UTC().Format("2006-01-02")
+		}
+		fmt.Fprintf(&sb, "| %s | %s | %d | %d | %d | %d | %d | %s |\n",
+			t.Name, t.TopicID, t.Facts, t.Decisions, t.Events, t.Entities, t.Total(), lastActivity)
+	}
+	if len(stats) > len(shown) {
+		fmt.Fprintf(&sb, "\nShowing %d of %d topics. Raise limit to see more.\n", len(shown), len(stats))
+	}
+
+	if len(sparse) > 0 {
+		fmt.Fprintf(&sb, "\n### Sparse Topics\n%s\n", strings.Join(sparse, ", "))
+	}
+
+	return NewResult(sb.String()), nil
+}
+
+// topicStatsJSON is the JSON response of StatsByTopic. Topics holds at
+// most limit topics; Sparse names every sparse topic.
+type topicStatsJSON struct {
+	TotalTopics     int          `json:"total_topics"`
+	SparseThreshold int          `json:"sparse_threshold"`
+	Sparse          []string     `json:"sparse"`
+	Topics          []TopicStats `json:"topics"`
+}
+
+// topicStatsJSONResult sorts stats by sortBy and returns them as JSON.
+func topicStatsJSONResult(stats []TopicStats, sortBy string, limit int) *ToolResult {
+	sortTopicStats(stats, sortBy)
+	out := topicStatsJSON{TotalTopics: len(stats), SparseThreshold: sparseTopicThreshold, Sparse: []string{}, Topics: stats}
+	for _, t := range stats {
+		if t.Total() < sparseTopicThreshold {
+			out.Sparse = append(out.Sparse, t.Name)
+		}
+	}
+	if len(out.Topics) > limit {
+		out.Topics = out.Topics[:limit]
+	}
+	if out.Topics == nil {
+		out.Topics = []TopicStats{}
+	}
+	return NewJSONResult(out)
+}
+
+// sortTopicStats orders stats by sortBy: "coverage" puts the most linked
+// nodes first, "activity" the most recently active, and "name" sorts
+// alphabetically. Ties fall back to the topic name.
+func sortTopicStats(stats []TopicStats, sortBy string) {
+	sort.SliceStable(stats, func(i, j int) bool {
+		a, b := stats[i], stats[j]
+		switch sortBy {
+		case "coverage":
+			if a.Total() != b.Total() {
+				return a.Total() > b.Total()
+			}
+		case "activity":
+			if a.LastActivity != b.LastActivity {
+				return a.LastActivity > b.LastActivity
+			}
+		}
+		return a.Name < b.Name
+	})
+}
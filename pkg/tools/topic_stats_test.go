@@ -0,0 +1,70 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatsByTopic(t *testing.T) {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC).Unix()
+	mock := &MockQuerier{
+		GetTopicStatsFunc: func(ctx context.Context) ([]TopicStats, error) {
+			return []TopicStats{
+				{TopicID: "top:a", Name: "billing", Facts: 1, LastActivity: day + 86400},
+				{TopicID: "top:b", Name: "infra", Facts: 4, Decisions: 2, Events: 1, Entities: 1, LastActivity: day},
+				{TopicID: "top:c", Name: "hiring"},
+			}, nil
+		},
+	}
+
+	result, err := StatsByTopic(context.Background(), mock, map[string]any{})
+	if err != nil {
+		t.Fatalf("StatsByTopic() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("StatsByTopic() returned error: %s", result.Text)
+	}
+	if !strings.Contains(result.Text, "| infra | top:b | 4 | 2 | 1 | 1 | 8 | 2026-03-01 |") {
+		t.Errorf("missing infra row:\n%s", result.Text)
+	}
+	if strings.Index(result.Text, "| infra") > strings.Index(result.Text, "| billing") {
+		t.Error("coverage sort should list infra before billing")
+	}
+	if !strings.Contains(result.Text, "3 topics, 2 with fewer than 3 linked nodes") {
+		t.Errorf("missing summary:\n%s", result.Text)
+	}
+	if !strings.Contains(result.Text, "### Sparse Topics\nbilling, hiring") {
+		t.Errorf("missing sparse topics:\n%s", result.Text)
+	}
+	if !strings.Contains(result.Text, "| hiring | top:c | 0 | 0 | 0 | 0 | 0 | - |") {
+		t.Errorf("topic without activity should show '-':\n%s", result.Text)
+	}
+
+	result, _ = StatsByTopic(context.Background(), mock, map[string]any{"sort_by": "activity", "limit": float64(1)})
+	if !strings.Contains(result.Text, "| billing |") || strings.Contains(result.Text, "| infra |") {
+		t.Errorf("activity sort with limit 1 should show only billing:\n%s", result.Text)
+	}
+	if !strings.Contains(result.Text, "Showing 1 of 3 topics") {
+		t.Errorf("missing truncation note:\n%s", result.Text)
+	}
+}
+
+func TestStatsByTopic_Empty(t *testing.T) {
+	result, _ := StatsByTopic(context.Background(), &MockQuerier{}, map[string]any{})
+	if result.IsError || !strings.Contains(result.Text, "No topics found") {
+		t.Errorf("unexpected result: %s", result.Text)
+	}
+}
+
+func TestStatsByTopic_InvalidSort(t *testing.T) {
+	result, _ := StatsByTopic(context.Background(), &MockQuerier{}, map[string]any{"sort_by": "size"})
+	if !result.IsError {
+		t.Error("StatsByTopic() should reject an unknown sort_by")
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// defaultTopicMinScore is the score below which a topic is not suggested
+// for a text.
+const defaultTopicMinScore = 0.5
+
+// Topics lists the existing topics with the number of nodes linked to each
+// and, given a text, suggests the topics that best fit it, so agents reuse
+// topics instead of inventing near-duplicate names.
+func Topics(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
+	text := strings.TrimSpace(GetStringArg(args, "text", ""))
+	limit := GetIntArg(args, "limit", 25)
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	minScore := GetFloat64Arg(args, "min_score", defaultTopicMinScore)
+	if minScore < 0 || minScore > 1 {
+		return NewError(fmt.Sprintf("Invalid min_score %v. Must be between 0 and 1", minScore)), nil
+	}
+
+	ctx = WithIncludeArchived(ctx, GetBoolArg(args, "include_archived", false))
+	stats, err := client.GetTopicStats(ctx)
+	if err != nil {
+		return NewError(fmt.Sprintf("Failed to list topics: %v", err)), nil
+	}
+	_ = client.IncrementCounter(ctx, "total_queries")
+	linked := make(map[string]int, len(stats))
+	for _, t := range stats {
+		linked[t.TopicID] = t.Total()
+	}
+
+	if text == "" {
+		return listTopics(stats, limit, WantsJSON(args)), nil
+	}
+
+	suggestions, err := client.SuggestTopics(ctx, text, limit)
+	if err != nil {
+		return NewError(fmt.Sprintf("Failed to suggest topics: %v", err)), nil
+	}
+	var best float64
+	var fits []TopicSuggestion
+	for _, s := range suggestions {
+		best = max(best, s.Score)
+		if s.Score >= minScore {
+			fits = append(fits, s)
+		}
+	}
+
+	if WantsJSON(args) {
+		out := topicSuggestionsJSON{TotalTopics: len(stats), Suggestions: []topicSuggestionJSON{}}
+		for _, s := range fits {
+			out.Suggestions = append(out.Suggestions, topicSuggestionJSON{TopicSuggestion: s, Linked: linked[s.TopicID]})
+		}
+		return NewJSONResult(out), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Suggested Topics\n\n")
+	if len(fits) == 0 {
+		if len(stats) == 0 {
+			sb.WriteString("_No topics exist yet. Store one with mie_store (type=topic) if this subject will come up again._\n")
+		} else {
+			fmt.Fprintf(&sb, "_None of the %d topics fits this text (best score %.2f). Store a new topic only if this subject will come up again._\n", len(stats), best)
+		}
+		return NewResult(sb.String()), nil
+	}
+	sb.WriteString("| Topic | ID | Score | Linked nodes | Description |\n")
+	sb.WriteString("|-------|-----|-------|--------------|-------------|\n")
+	for _, s := range fits {
+		fmt.Fprintf(&sb, "| %s | %s | %.2f | %d | %s |\n",
+			s.Name, s.TopicID, s.Score, linked[s.TopicID], Truncate(s.Description, 80))
+	}
+	sb.WriteString("\nLink memory to these topics with mie_relate (fact_topic, decision_topic, entity_topic) rather than creating new ones.\n")
+	return NewResult(sb.String()), nil
+}
+
+// listTopics renders the topics, most linked first.
+func listTopics(stats []TopicStats, limit int, asJSON bool) *ToolResult {
+	sortTopicStats(stats, "coverage")
+	shown := stats
+	if len(shown) > limit {
+		shown = shown[:limit]
+	}
+
+	if asJSON {
+		out := topicListJSON{TotalTopics: len(stats), Topics: []topicUsageJSON{}}
+		for _, t := range shown {
+			out.Topics = append(out.Topics, topicUsageJSON{TopicID: t.TopicID, Name: t.Name, Linked: t.Total(), LastActivity: t.LastActivity})
+		}
+		return NewJSONResult(out)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Topics\n\n")
+	if len(stats) == 0 {
+		sb.WriteString("_No topics found. Store one with mie_store (type=topic)._\n")
+		return NewResult(sb.String())
+	}
+	sb.WriteString("| Topic | ID | Linked nodes | Last activity |\n")
+	sb.WriteString("|-------|-----|--------------|---------------|\n")
+	for _, t := range shown {
+		lastActivity := "-"
+		if t.LastActivity > 0 {
+			lastActivity = time.Unix(t.LastActivity, 0).UTC().Format("2006-01-02")
+		}
+		fmt.Fprintf(&sb, "| %s | %s | %d | %s |\n", t.Name, t.TopicID, t.Total(), lastActivity)
+	}
+	if len(stats) > len(shown) {
+		fmt.Fprintf(&sb, "\nShowing %d of %d topics. Raise limit to see more.\n", len(shown), len(stats))
+	}
+	sb.WriteString("\nPass text to get the topics that best fit a piece of content.\n")
+	return NewResult(sb.String())
+}
+
+// topicListJSON is the JSON response of Topics without text.
+type topicListJSON struct {
+	TotalTopics int              `json:"total_topics"`
+	Topics      []topicUsageJSON `json:"topics"`
+}
+
+type topicUsageJSON struct {
+	TopicID      string `json:"topic_id"`
+	Name         string `json:"name"`
+	Linked       int    `json:"linked"`
+	LastActivity int64  `json:"last_activity"`
+}
+
+// topicSuggestionsJSON is the JSON response of Topics with text. It holds
+// the suggestions that reach min_score.
+type topicSuggestionsJSON struct {
+	TotalTopics int                   `json:"total_topics"`
+	Suggestions []topicSuggestionJSON `json:"suggestions"`
+}
+
+type topicSuggestionJSON struct {
+	TopicSuggestion
+	Linked int `json:"linked"`
+}
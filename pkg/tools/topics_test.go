@@ -0,0 +1,99 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func topicsMock() *MockQuerier {
+	return &MockQuerier{
+		GetTopicStatsFunc: func(ctx context.Context) ([]TopicStats, error) {
+			return []TopicStats{
+				{TopicID: "top:a", Name: "billing", Facts: 5, Decisions: 1},
+				{TopicID: "top:b", Name: "hiring", Facts: 1},
+				{TopicID: "top:c", Name: "infra", Facts: 2},
+			}, nil
+		},
+		SuggestTopicsFunc: func(ctx context.Context, text string, limit int) ([]TopicSuggestion, error) {
+			return []TopicSuggestion{
+				{TopicID: "top:a", Name: "billing", Description: "Invoices and payments", Score: 0.82},
+				{TopicID: "top:c", Name: "infra", Score: 0.31},
+			}, nil
+		},
+	}
+}
+
+func TestTopics_List(t *testing.T) {
+	result, err := Topics(context.Background(), topicsMock(), map[string]any{"limit": float64(2)})
+	if err != nil {
+		t.Fatalf("Topics() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Topics() returned error: %s", result.Text)
+	}
+	if !strings.Contains(result.Text, "| billing | top:a | 6 | - |") {
+		t.Errorf("missing billing row:\n%s", result.Text)
+	}
+	if strings.Index(result.Text, "| billing") > strings.Index(result.Text, "| infra") {
+		t.Error("topics should be listed most linked first")
+	}
+	if strings.Contains(result.Text, "| hiring |") || !strings.Contains(result.Text, "Showing 2 of 3 topics") {
+		t.Errorf("limit 2 should drop hiring:\n%s", result.Text)
+	}
+}
+
+func TestTopics_Suggest(t *testing.T) {
+	mock := topicsMock()
+	var gotText string
+	suggest := mock.SuggestTopicsFunc
+	mock.SuggestTopicsFunc = func(ctx context.Context, text string, limit int) ([]TopicSuggestion, error) {
+		gotText = text
+		return suggest(ctx, text, limit)
+	}
+
+	result, _ := Topics(context.Background(), mock, map[string]any{"text": " Invoice retries "})
+	if result.IsError {
+		t.Fatalf("Topics() returned error: %s", result.Text)
+	}
+	if gotText != "Invoice retries" {
+		t.Errorf("expected trimmed text, got %q", gotText)
+	}
+	if !strings.Contains(result.Text, "| billing | top:a | 0.82 | 6 | Invoices and payments |") {
+		t.Errorf("missing billing suggestion:\n%s", result.Text)
+	}
+	if strings.Contains(result.Text, "| infra |") {
+		t.Errorf("infra is below min_score and should be left out:\n%s", result.Text)
+	}
+
+	result, _ = Topics(context.Background(), mock, map[string]any{"text": "Invoice retries", "min_score": 0.9})
+	if !strings.Contains(result.Text, "None of the 3 topics fits this text (best score 0.82)") {
+		t.Errorf("expected no-fit note:\n%s", result.Text)
+	}
+}
+
+func TestTopics_SuggestJSON(t *testing.T) {
+	result, _ := Topics(context.Background(), topicsMock(), map[string]any{"text": "Invoice retries", "min_score": 0.3, "response_format": "json"})
+	var out topicSuggestionsJSON
+	if err := json.Unmarshal([]byte(result.Text), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result.Text)
+	}
+	if out.TotalTopics != 3 || len(out.Suggestions) != 2 {
+		t.Fatalf("expected 3 topics and 2 suggestions, got %+v", out)
+	}
+	if out.Suggestions[0].TopicID != "top:a" || out.Suggestions[0].Linked != 6 {
+		t.Errorf("expected billing with 6 linked nodes first, got %+v", out.Suggestions[0])
+	}
+}
+
+func TestTopics_InvalidMinScore(t *testing.T) {
+	result, _ := Topics(context.Background(), topicsMock(), map[string]any{"text": "x", "min_score": 1.5})
+	if !result.IsError {
+		t.Error("expected error for min_score above 1")
+	}
+}
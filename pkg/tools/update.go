@@ -10,9 +10,10 @@ import (
 	"strings"
 )
 
-// validDecisionStatuses enumerates allowed decision status transitions.
+// validDecisionStatuses enumerates the decision statuses. Which changes
+// between them are allowed is checked when the status is written.
 var validDecisionStatuses = map[string]bool{
-	"active": true, "superseded": true, "reversed": true,
+	"proposed": true, "active": true, "superseded": true, "reversed": true,
 }
 
 // Update modifies existing nodes or invalidates facts.
@@ -27,16 +28,52 @@ func Update(ctx context.Context, client Querier, args map[string]any) (*ToolResu
 		return NewError("Missing required parameter: action"), nil
 	}
 
+	var result *ToolResult
+	var err error
 	switch action {
 	case "invalidate":
-		return updateInvalidate(ctx, client, nodeID, args)
+		result, err = updateInvalidate(ctx, client, nodeID, args)
 	case "update_description":
-		return updateDescription(ctx, client, nodeID, args)
+		result, err = updateDescription(ctx, client, nodeID, args)
 	case "update_status":
-		return updateStatus(ctx, client, nodeID, args)
+		result, err = updateStatus(ctx, client, nodeID, args)
+	case "alias":
+		result, err = updateAlias(ctx, client, nodeID, args)
+	case "rename":
+		result, err = updateRename(ctx, client, nodeID, args)
+	case "archive":
+		result, err = updateArchived(ctx, client, nodeID, true)
+	case "unarchive":
+		result, err = updateArchived(ctx, client, nodeID, false)
+	case "verify":
+		result, err = updateVerified(ctx, client, nodeID, args, true)
+	case "unverify":
+		result, err = updateVerified(ctx, client, nodeID, args, false)
 	default:
-		return NewError(fmt.Sprintf("Invalid action %q. Must be one of: invalidate, update_description, update_status", action)), nil
+		return NewError(fmt.Sprintf("Invalid action %q. Must be one of: invalidate, update_description, update_status, alias, rename, archive, unarchive, verify, unverify", action)), nil
 	}
+	if err != nil || result.IsError || !WantsJSON(args) {
+		return result, err
+	}
+	return NewJSONResult(updateJSON{
+		Action:        action,
+		NodeID:        nodeID,
+		Reason:        GetStringArg(args, "reason", ""),
+		ReplacementID: GetStringArg(args, "replacement_id", ""),
+		NewValue:      GetStringArg(args, "new_value", ""),
+		VerifiedBy:    GetStringArg(args, "verified_by", ""),
+	}), nil
+}
+
+// updateJSON is the JSON response of a successful Update, echoing the
+// arguments the action used.
+type updateJSON struct {
+	Action        string `json:"action"`
+	NodeID        string `json:"node_id"`
+	Reason        string `json:"reason,omitempty"`
+	ReplacementID string `json:"replacement_id,omitempty"`
+	NewValue      string `json:"new_value,omitempty"`
+	VerifiedBy    string `json:"verified_by,omitempty"`
 }
 
 func updateInvalidate(ctx context.Context, client Querier, nodeID string, args map[string]any) (*ToolResult, error) {
@@ -73,7 +110,7 @@ func updateDescription(ctx context.Context, client Querier, nodeID string, args
 		return NewError("new_value is required for update_description action"), nil
 	}
 
-	err := client.UpdateDescription(ctx, nodeID, newValue)
+	err := client.UpdateDescription(withUpdateReason(ctx, args), nodeID, newValue)
 	if err != nil {
 		return NewError(fmt.Sprintf("Failed to update description: %v", err)), nil
 	}
@@ -92,13 +129,101 @@ func updateStatus(ctx context.Context, client Querier, nodeID string, args map[s
 	}
 
 	if !validDecisionStatuses[newValue] {
-		return NewError(fmt.Sprintf("Invalid status %q. Must be one of: active, superseded, reversed", newValue)), nil
+		return NewError(fmt.Sprintf("Invalid status %q. Must be one of: proposed, active, superseded, reversed", newValue)), nil
 	}
 
-	err := client.UpdateStatus(ctx, nodeID, newValue)
+	err := client.UpdateStatus(withUpdateReason(ctx, args), nodeID, newValue)
 	if err != nil {
 		return NewError(fmt.Sprintf("Failed to update status: %v", err)), nil
 	}
 
-	return NewResult(fmt.Sprintf("Updated status for [%s]\nNew status: %s", nodeID, newValue)), nil
-}
\ No newline at end of file
+	output := fmt.Sprintf("Updated status for [%s]\nNew status: %s", nodeID, newValue)
+	if reason := strings.TrimSpace(GetStringArg(args, "reason", "")); reason != "" {
+		output += "\nReason: " + reason
+	}
+	return NewResult(output), nil
+}
+
+// withUpdateReason returns ctx with the optional reason argument attached,
+// so that the node history records why a value changed.
+func withUpdateReason(ctx context.Context, args map[string]any) context.Context {
+	if reason := strings.TrimSpace(GetStringArg(args, "reason", "")); reason != "" {
+		return WithChangeReason(ctx, reason)
+	}
+	return ctx
+}
+
+func updateAlias(ctx context.Context, client Querier, nodeID string, args map[string]any) (*ToolResult, error) {
+	if !strings.HasPrefix(nodeID, "ent:") {
+		return NewError(fmt.Sprintf("alias action requires an entity ID (prefix 'ent:'), got %q", nodeID)), nil
+	}
+
+	alias := strings.TrimSpace(GetStringArg(args, "new_value", ""))
+	if alias == "" {
+		return NewError("new_value is required for alias action"), nil
+	}
+
+	err := client.AddAlias(ctx, nodeID, alias)
+	if err != nil {
+		return NewError(fmt.Sprintf("Failed to add alias: %v", err)), nil
+	}
+
+	return NewResult(fmt.Sprintf("Added alias %q for [%s]\nLookups and new entities named %q now resolve to this entity.", alias, nodeID, alias)), nil
+}
+
+func updateRename(ctx context.Context, client Querier, nodeID string, args map[string]any) (*ToolResult, error) {
+	if !strings.HasPrefix(nodeID, "ent:") {
+		return NewError(fmt.Sprintf("rename action requires an entity ID (prefix 'ent:'), got %q", nodeID)), nil
+	}
+
+	newName := strings.TrimSpace(GetStringArg(args, "new_value", ""))
+	if newName == "" {
+		return NewError("new_value is required for rename action"), nil
+	}
+
+	err := client.RenameEntity(withUpdateReason(ctx, args), nodeID, newName)
+	if err != nil {
+		return NewError(fmt.Sprintf("Failed to rename entity: %v", err)), nil
+	}
+
+	return NewResult(fmt.Sprintf("Renamed [%s] to %q\nThe previous name is kept as an alias, so lookups by it still resolve to this entity.", nodeID, newName)), nil
+}
+
+func updateArchived(ctx context.Context, client Querier, nodeID string, archived bool) (*ToolResult, error) {
+	err := client.SetArchived(ctx, nodeID, archived)
+	if err != nil {
+		if archived {
+			return NewError(fmt.Sprintf("Failed to archive node: %v", err)), nil
+		}
+		return NewError(fmt.Sprintf("Failed to unarchive node: %v", err)), nil
+	}
+
+	if archived {
+		return NewResult(fmt.Sprintf("Archived [%s]\nIt is hidden from search and list results unless include_archived is set.", nodeID)), nil
+	}
+	return NewResult(fmt.Sprintf("Unarchived [%s]", nodeID)), nil
+}
+
+func updateVerified(ctx context.Context, client Querier, nodeID string, args map[string]any, verified bool) (*ToolResult, error) {
+	action := "verify"
+	if !verified {
+		action = "unverify"
+	}
+	if !strings.HasPrefix(nodeID, "fact:") {
+		return NewError(fmt.Sprintf("%s action requires a fact ID (prefix 'fact:'), got %q", action, nodeID)), nil
+	}
+
+	verifiedBy := strings.TrimSpace(GetStringArg(args, "verified_by", ""))
+	if verified && verifiedBy == "" {
+		return NewError("verified_by is required for verify action"), nil
+	}
+
+	if err := client.SetVerified(ctx, nodeID, verifiedBy, verified); err != nil {
+		return NewError(fmt.Sprintf("Failed to %s fact: %v", action, err)), nil
+	}
+
+	if verified {
+		return NewResult(fmt.Sprintf("Verified [%s] (by %s)\nIt now ranks above unverified facts in search.", nodeID, verifiedBy)), nil
+	}
+	return NewResult(fmt.Sprintf("Unverified [%s]", nodeID)), nil
+}
@@ -154,6 +154,32 @@ func TestUpdate_UpdateStatus(t *testing.T) {
 	}
 }
 
+func TestUpdate_UpdateStatusReason(t *testing.T) {
+	var reason string
+	mock := &MockQuerier{
+		UpdateStatusFunc: func(ctx context.Context, nodeID, newStatus string) error {
+			reason = ChangeReasonFromContext(ctx)
+			return nil
+		},
+	}
+
+	result, _ := Update(context.Background(), mock, map[string]any{
+		"node_id":   "dec:abc123",
+		"action":    "update_status",
+		"new_value": "reversed",
+		"reason":    "  Load tests failed  ",
+	})
+	if result.IsError {
+		t.Fatalf("Update() returned error: %s", result.Text)
+	}
+	if reason != "Load tests failed" {
+		t.Errorf("change reason = %q, want %q", reason, "Load tests failed")
+	}
+	if !strings.Contains(result.Text, "Reason: Load tests failed") {
+		t.Errorf("expected the reason in the result, got:\n%s", result.Text)
+	}
+}
+
 func TestUpdate_UpdateStatusNonDecision(t *testing.T) {
 	mock := &MockQuerier{}
 	result, _ := Update(context.Background(), mock, map[string]any{
@@ -178,6 +204,166 @@ func TestUpdate_UpdateStatusInvalidValue(t *testing.T) {
 	}
 }
 
+func TestUpdate_Alias(t *testing.T) {
+	var gotID, gotAlias string
+	mock := &MockQuerier{
+		AddAliasFunc: func(ctx context.Context, entityID, alias string) error {
+			gotID, gotAlias = entityID, alias
+			return nil
+		},
+	}
+
+	result, err := Update(context.Background(), mock, map[string]any{
+		"node_id":   "ent:pg",
+		"action":    "alias",
+		"new_value": "Postgres",
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Update() returned error: %s", result.Text)
+	}
+	if gotID != "ent:pg" || gotAlias != "Postgres" {
+		t.Errorf("AddAlias called with (%q, %q)", gotID, gotAlias)
+	}
+	if !strings.Contains(result.Text, "Added alias") {
+		t.Error("Update() should confirm the alias")
+	}
+}
+
+func TestUpdate_AliasInvalid(t *testing.T) {
+	mock := &MockQuerier{
+		AddAliasFunc: func(ctx context.Context, entityID, alias string) error {
+			return fmt.Errorf("alias %q already refers to ent:other", alias)
+		},
+	}
+	tests := []struct {
+		name string
+		args map[string]any
+	}{
+		{"non-entity", map[string]any{"node_id": "fact:abc", "action": "alias", "new_value": "x"}},
+		{"missing value", map[string]any{"node_id": "ent:pg", "action": "alias"}},
+		{"conflict", map[string]any{"node_id": "ent:pg", "action": "alias", "new_value": "Postgres"}},
+	}
+	for _, tt := range tests {
+		result, _ := Update(context.Background(), mock, tt.args)
+		if !result.IsError {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
+
+func TestUpdate_Rename(t *testing.T) {
+	var gotID, gotName, gotReason string
+	mock := &MockQuerier{
+		RenameEntityFunc: func(ctx context.Context, entityID, newName string) error {
+			gotID, gotName, gotReason = entityID, newName, ChangeReasonFromContext(ctx)
+			return nil
+		},
+	}
+
+	result, _ := Update(context.Background(), mock, map[string]any{
+		"node_id":   "ent:pg",
+		"action":    "rename",
+		"new_value": " PostgreSQL ",
+		"reason":    "official name",
+	})
+	if result.IsError {
+		t.Fatalf("Update() returned error: %s", result.Text)
+	}
+	if gotID != "ent:pg" || gotName != "PostgreSQL" || gotReason != "official name" {
+		t.Errorf("RenameEntity called with (%q, %q), reason %q", gotID, gotName, gotReason)
+	}
+	if !strings.Contains(result.Text, `Renamed [ent:pg] to "PostgreSQL"`) {
+		t.Errorf("unexpected result: %s", result.Text)
+	}
+
+	for _, args := range []map[string]any{
+		{"node_id": "dec:pg", "action": "rename", "new_value": "x"},
+		{"node_id": "ent:pg", "action": "rename", "new_value": " "},
+	} {
+		if result, _ := Update(context.Background(), mock, args); !result.IsError {
+			t.Errorf("expected an error for %v", args)
+		}
+	}
+}
+
+func TestUpdate_Archive(t *testing.T) {
+	var calls []string
+	mock := &MockQuerier{
+		SetArchivedFunc: func(ctx context.Context, nodeID string, archived bool) error {
+			calls = append(calls, fmt.Sprintf("%s=%t", nodeID, archived))
+			return nil
+		},
+	}
+
+	result, err := Update(context.Background(), mock, map[string]any{"node_id": "top:old", "action": "archive"})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if result.IsError || !strings.Contains(result.Text, "Archived [top:old]") {
+		t.Errorf("unexpected archive result: %s", result.Text)
+	}
+
+	result, _ = Update(context.Background(), mock, map[string]any{"node_id": "top:old", "action": "unarchive"})
+	if result.IsError || !strings.Contains(result.Text, "Unarchived [top:old]") {
+		t.Errorf("unexpected unarchive result: %s", result.Text)
+	}
+
+	if strings.Join(calls, ",") != "top:old=true,top:old=false" {
+		t.Errorf("SetArchived calls = %v", calls)
+	}
+}
+
+func TestUpdate_ArchiveNotFound(t *testing.T) {
+	mock := &MockQuerier{
+		SetArchivedFunc: func(ctx context.Context, nodeID string, archived bool) error {
+			return fmt.Errorf("node %q not found", nodeID)
+		},
+	}
+	result, _ := Update(context.Background(), mock, map[string]any{"node_id": "ent:missing", "action": "archive"})
+	if !result.IsError || !strings.Contains(result.Text, "not found") {
+		t.Errorf("expected not found error, got: %s", result.Text)
+	}
+}
+
+func TestUpdate_Verify(t *testing.T) {
+	var calls []string
+	mock := &MockQuerier{
+		SetVerifiedFunc: func(ctx context.Context, factID, verifiedBy string, verified bool) error {
+			calls = append(calls, fmt.Sprintf("%s=%t:%s", factID, verified, verifiedBy))
+			return nil
+		},
+	}
+
+	result, err := Update(context.Background(), mock, map[string]any{"node_id": "fact:abc", "action": "verify", "verified_by": "alice"})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if result.IsError || !strings.Contains(result.Text, "Verified [fact:abc] (by alice)") {
+		t.Errorf("unexpected verify result: %s", result.Text)
+	}
+
+	result, _ = Update(context.Background(), mock, map[string]any{"node_id": "fact:abc", "action": "unverify"})
+	if result.IsError || !strings.Contains(result.Text, "Unverified [fact:abc]") {
+		t.Errorf("unexpected unverify result: %s", result.Text)
+	}
+
+	if strings.Join(calls, ",") != "fact:abc=true:alice,fact:abc=false:" {
+		t.Errorf("SetVerified calls = %v", calls)
+	}
+
+	result, _ = Update(context.Background(), mock, map[string]any{"node_id": "fact:abc", "action": "verify"})
+	if !result.IsError || !strings.Contains(result.Text, "verified_by is required") {
+		t.Errorf("expected missing verified_by error, got: %s", result.Text)
+	}
+	result, _ = Update(context.Background(), mock, map[string]any{"node_id": "ent:abc", "action": "verify", "verified_by": "alice"})
+	if !result.IsError || !strings.Contains(result.Text, "requires a fact ID") {
+		t.Errorf("expected fact ID error, got: %s", result.Text)
+	}
+}
+
 func TestUpdate_MissingNodeID(t *testing.T) {
 	mock := &MockQuerier{}
 	result, _ := Update(context.Background(), mock, map[string]any{
@@ -0,0 +1,195 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ArgumentError is an invalid argument of a tool call.
+type ArgumentError struct {
+	// Field is the path of the argument, such as "confidence" or
+	// "items[2].relationships[0].weight".
+	Field   string
+	Message string
+}
+
+// ArgumentErrors lists the invalid arguments of a tool call: missing
+// required ones first, then the others by name.
+type ArgumentErrors []ArgumentError
+
+func (e ArgumentErrors) Error() string {
+	parts := make([]string, len(e))
+	for i, ae := range e {
+		parts[i] = ae.Field + ": " + ae.Message
+	}
+	return strings.Join(parts, "; ")
+}
+
+// ValidateArgs checks the arguments of a tool call against the tool's JSON
+// input schema: required properties, types, enums, and numeric bounds
+// (minimum, maximum, exclusiveMinimum, exclusiveMaximum), down through array
+// items and nested objects. Properties the schema does not declare are not
+// checked. It returns nil or ArgumentErrors naming every invalid field.
+//
+// The tools themselves replace invalid values with defaults; ValidateArgs
+// is for callers that would rather reject them.
+func ValidateArgs(schema, args map[string]any) error {
+	var errs ArgumentErrors
+	validateObject(schema, args, "", &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// validateObject checks the properties of obj against schema, an object
+// schema, reporting fields under the path prefix.
+func validateObject(schema, obj map[string]any, prefix string, errs *ArgumentErrors) {
+	for _, name := range schemaStrings(schema["required"]) {
+		if v, ok := obj[name]; !ok || v == nil {
+			*errs = append(*errs, ArgumentError{Field: prefix + name, Message: "is required"})
+		}
+	}
+	props, _ := schema["properties"].(map[string]any)
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		v, ok := obj[name]
+		if !ok || v == nil {
+			continue
+		}
+		if prop, ok := props[name].(map[string]any); ok {
+			validateValue(prop, v, prefix+name, errs)
+		}
+	}
+}
+
+// validateValue checks v against the property schema prop.
+func validateValue(prop map[string]any, v any, field string, errs *ArgumentErrors) {
+	fail := func(format string, a ...any) {
+		*errs = append(*errs, ArgumentError{Field: field, Message: fmt.Sprintf(format, a...)})
+	}
+
+	switch typ, _ := prop["type"].(string); typ {
+	case "string":
+		s, ok := v.(string)
+		if !ok {
+			fail("must be a string, got %s", describeArg(v))
+			return
+		}
+		if enum := schemaStrings(prop["enum"]); len(enum) > 0 && !slices.Contains(enum, s) {
+			fail("must be one of %s, got %q", strings.Join(enum, ", "), s)
+		}
+	case "number", "integer":
+		n, ok := numberArg(v)
+		if !ok {
+			fail("must be a number, got %s", describeArg(v))
+			return
+		}
+		if typ == "integer" && n != math.Trunc(n) {
+			fail("must be an integer, got %s", formatArgNumber(n))
+			return
+		}
+		if lo, ok := numberArg(prop["minimum"]); ok && n < lo {
+			fail("must be at least %s, got %s", formatArgNumber(lo), formatArgNumber(n))
+		}
+		if lo, ok := numberArg(prop["exclusiveMinimum"]); ok && n <= lo {
+			fail("must be greater than %s, got %s", formatArgNumber(lo), formatArgNumber(n))
+		}
+		if hi, ok := numberArg(prop["maximum"]); ok && n > hi {
+			fail("must be at most %s, got %s", formatArgNumber(hi), formatArgNumber(n))
+		}
+		if hi, ok := numberArg(prop["exclusiveMaximum"]); ok && n >= hi {
+			fail("must be less than %s, got %s", formatArgNumber(hi), formatArgNumber(n))
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			fail("must be true or false, got %s", describeArg(v))
+		}
+	case "array":
+		items, ok := v.([]any)
+		if !ok {
+			fail("must be an array, got %s", describeArg(v))
+			return
+		}
+		if itemSchema, ok := prop["items"].(map[string]any); ok {
+			for i, item := range items {
+				validateValue(itemSchema, item, fmt.Sprintf("%s[%d]", field, i), errs)
+			}
+		}
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			fail("must be an object, got %s", describeArg(v))
+			return
+		}
+		validateObject(prop, obj, field+".", errs)
+	}
+}
+
+// schemaStrings returns a schema keyword holding a list of strings, such as
+// "enum" or "required".
+func schemaStrings(v any) []string {
+	switch list := v.(type) {
+	case []string:
+		return list
+	case []any:
+		out := make([]string, 0, len(list))
+		for _, s := range list {
+			if s, ok := s.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// numberArg returns v as a float64 if it is a number.
+func numberArg(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// formatArgNumber formats n without trailing zeros, as in 1, 0.5, or 1e+06.
+func formatArgNumber(n float64) string {
+	return strconv.FormatFloat(n, 'g', -1, 64)
+}
+
+// describeArg names the JSON type of v for an error message, with the
+// value itself when it is short.
+func describeArg(v any) string {
+	switch v := v.(type) {
+	case string:
+		return fmt.Sprintf("string %q", Truncate(v, 40))
+	case bool:
+		return fmt.Sprintf("boolean %t", v)
+	case []any:
+		return "an array"
+	case map[string]any:
+		return "an object"
+	}
+	if n, ok := numberArg(v); ok {
+		return "number " + formatArgNumber(n)
+	}
+	return fmt.Sprintf("%T", v)
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateArgs(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"type":       map[string]any{"type": "string", "enum": []string{"fact", "entity"}},
+			"confidence": map[string]any{"type": "number", "minimum": 0, "maximum": 1},
+			"limit":      map[string]any{"type": "integer", "minimum": 1, "maximum": 50},
+			"verified":   map[string]any{"type": "boolean"},
+			"relationships": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"edge":   map[string]any{"type": "string"},
+						"weight": map[string]any{"type": "number", "exclusiveMinimum": 0, "maximum": 1},
+					},
+					"required": []string{"edge"},
+				},
+			},
+		},
+		"required": []string{"type"},
+	}
+
+	valid := map[string]any{
+		"type": "fact", "confidence": 0.9, "limit": float64(10), "verified": true, "extra": "ignored",
+		"relationships": []any{map[string]any{"edge": "fact_entity", "weight": 0.5}},
+	}
+	if err := ValidateArgs(schema, valid); err != nil {
+		t.Errorf("valid arguments rejected: %v", err)
+	}
+
+	err := ValidateArgs(schema, map[string]any{
+		"confidence": 1.5, "limit": 2.5, "verified": "yes",
+		"relationships": []any{map[string]any{"weight": float64(0)}, "x"},
+	})
+	var argErrs ArgumentErrors
+	if !errors.As(err, &argErrs) {
+		t.Fatalf("expected ArgumentErrors, got %v", err)
+	}
+	want := []ArgumentError{
+		{"type", "is required"},
+		{"confidence", "must be at most 1, got 1.5"},
+		{"limit", "must be an integer, got 2.5"},
+		{"relationships[0].edge", "is required"},
+		{"relationships[0].weight", "must be greater than 0, got 0"},
+		{"relationships[1]", `must be an object, got string "x"`},
+		{"verified", `must be true or false, got string "yes"`},
+	}
+	if len(argErrs) != len(want) {
+		t.Fatalf("got %d errors, want %d: %v", len(argErrs), len(want), err)
+	}
+	for i := range want {
+		if argErrs[i] != want[i] {
+			t.Errorf("error %d = %+v, want %+v", i, argErrs[i], want[i])
+		}
+	}
+
+	err = ValidateArgs(schema, map[string]any{"type": "event", "limit": "10"})
+	if err == nil || err.Error() != `limit: must be a number, got string "10"; type: must be one of fact, entity, got "event"` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
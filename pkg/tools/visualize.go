@@ -0,0 +1,249 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// maxMermaidLabel is the number of characters of a node label shown in a
+// Mermaid diagram.
+const maxMermaidLabel = 40
+
+// mermaidShapes holds the opening and closing brackets of each node type's
+// Mermaid shape.
+var mermaidShapes = map[string][2]string{
+	"fact":     {"[", "]"},
+	"decision": {"{{", "}}"},
+	"entity":   {"(", ")"},
+	"event":    {"[/", "/]"},
+	"topic":    {"((", "))"},
+}
+
+// mermaidClassDefs styles nodes by type, in the order they are emitted.
+var mermaidClassDefs = []struct{ nodeType, style string }{
+	{"fact", "fill:#e8f1fb,stroke:#3b82f6"},
+	{"decision", "fill:#fdf1e3,stroke:#f59e0b"},
+	{"entity", "fill:#e9f7ef,stroke:#10b981"},
+	{"event", "fill:#f5ecfb,stroke:#8b5cf6"},
+	{"topic", "fill:#f3f4f6,stroke:#6b7280"},
+}
+
+// Visualize renders the neighborhood of a node or topic as a Mermaid
+// flowchart, walking edges breadth-first up to depth hops.
+func Visualize(ctx context.Context, client Querier, args map[string]any) (*ToolResult, error) {
+	nodeID := strings.TrimSpace(GetStringArg(args, "node_id", ""))
+	topic := strings.TrimSpace(GetStringArg(args, "topic", ""))
+	if nodeID == "" && topic == "" {
+		return NewError("Missing required parameter: node_id or topic"), nil
+	}
+	if nodeID != "" && topic != "" {
+		return NewError("Pass either node_id or topic, not both"), nil
+	}
+	depth := GetIntArg(args, "depth", 1)
+	if depth < 1 {
+		depth = 1
+	}
+	if depth > 3 {
+		depth = 3
+	}
+	maxNodes := GetIntArg(args, "max_nodes", 30)
+	if maxNodes < 2 {
+		maxNodes = 2
+	}
+	if maxNodes > 100 {
+		maxNodes = 100
+	}
+	ctx = WithIncludeArchived(ctx, GetBoolArg(args, "include_archived", false))
+
+	if topic != "" {
+		id, err := findTopicID(ctx, client, topic)
+		if err != nil {
+			return NewError(fmt.Sprintf("Failed to find topic: %v", err)), nil
+		}
+		if id == "" {
+			return NewError(fmt.Sprintf("Topic not found: %s", topic)), nil
+		}
+		nodeID = id
+	}
+
+	root, err := client.GetNodeByID(ctx, nodeID)
+	if err != nil {
+		return NewError(fmt.Sprintf("Node not found: %v", err)), nil
+	}
+
+	nodes := map[string]any{nodeID: root}
+	order := []string{nodeID}
+	var edges []GraphEdge
+	seenEdges := make(map[GraphEdge]bool)
+	truncated := false
+
+	frontier := []string{nodeID}
+	for d := 0; d < depth && len(frontier) > 0; d++ {
+		var next []string
+		for _, id := range frontier {
+			nodeEdges, err := client.GetNodeEdges(ctx, id)
+			if err != nil {
+				return NewError(fmt.Sprintf("Failed to get edges of %s: %v", id, err)), nil
+			}
+			for _, e := range nodeEdges {
+				other := e.TargetID
+				if other == id {
+					other = e.SourceID
+				}
+				if _, ok := nodes[other]; !ok {
+					if len(order) >= maxNodes {
+						truncated = true
+						continue
+					}
+					node, err := client.GetNodeByID(ctx, other)
+					if err != nil {
+						// Dangling edge: the other node no longer exists.
+						continue
+					}
+					nodes[other] = node
+					order = append(order, other)
+					next = append(next, other)
+				}
+				if !seenEdges[e] {
+					seenEdges[e] = true
+					edges = append(edges, e)
+				}
+			}
+		}
+		frontier = next
+	}
+	_ = client.IncrementCounter(ctx, "total_queries")
+
+	if WantsJSON(args) {
+		out := visualizeJSON{
+			RootID:    nodeID,
+			Depth:     depth,
+			Nodes:     make([]NodeJSON, len(order)),
+			Edges:     edges,
+			Truncated: truncated,
+			Mermaid:   mermaidFlowchart(order, nodes, edges),
+		}
+		for i, id := range order {
+			out.Nodes[i] = newNodeJSON(nodes[id])
+		}
+		if out.Edges == nil {
+			out.Edges = []GraphEdge{}
+		}
+		return NewJSONResult(out), nil
+	}
+
+	_, rootText := nodeLabel(root)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Memory Map: %s\n\n", Truncate(rootText, 80))
+	fmt.Fprintf(&sb, "%d nodes, %d edges within %d hop(s) of %s.\n\n", len(order), len(edges), depth, nodeID)
+	sb.WriteString("```mermaid\n")
+	sb.WriteString(mermaidFlowchart(order, nodes, edges))
+	sb.WriteString("```\n")
+	if truncated {
+		fmt.Fprintf(&sb, "\n_Stopped at %d nodes. Raise max_nodes or lower depth to see more._\n", maxNodes)
+	}
+	return NewResult(sb.String()), nil
+}
+
+// visualizeJSON is the JSON response of Visualize. Nodes are in the order
+// the walk reached them, starting with the root.
+type visualizeJSON struct {
+	RootID    string      `json:"root_id"`
+	Depth     int         `json:"depth"`
+	Nodes     []NodeJSON  `json:"nodes"`
+	Edges     []GraphEdge `json:"edges"`
+	Truncated bool        `json:"truncated"`
+	Mermaid   string      `json:"mermaid"`
+}
+
+// findTopicID returns the ID of the topic named name, ignoring case, or of
+// the first topic matching it if none has that exact name.
+func findTopicID(ctx context.Context, client Querier, name string) (string, error) {
+	results, err := client.ExactSearch(ctx, name, []string{"topic"}, 20)
+	if err != nil {
+		return "", err
+	}
+	for _, r := range results {
+		if strings.EqualFold(r.Content, name) {
+			return r.ID, nil
+		}
+	}
+	if len(results) > 0 {
+		return results[0].ID, nil
+	}
+	return "", nil
+}
+
+// mermaidFlowchart renders nodes, in order, and the edges between them.
+// The first node is drawn with a thicker border.
+func mermaidFlowchart(order []string, nodes map[string]any, edges []GraphEdge) string {
+	ids := make(map[string]string, len(order))
+	classes := make(map[string][]string)
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	for i, id := range order {
+		ref := fmt.Sprintf("n%d", i)
+		ids[id] = ref
+		nodeType, text := nodeLabel(nodes[id])
+		shape, ok := mermaidShapes[nodeType]
+		if !ok {
+			shape = mermaidShapes["fact"]
+		}
+		label := mermaidText(fmt.Sprintf("%s: %s", nodeType, text), maxMermaidLabel)
+		fmt.Fprintf(&sb, "    %s%s\"%s\"%s\n", ref, shape[0], label, shape[1])
+		classes[nodeType] = append(classes[nodeType], ref)
+	}
+	for _, e := range edges {
+		label := e.Type
+		if e.Label != "" {
+			label += ": " + e.Label
+		}
+		fmt.Fprintf(&sb, "    %s -->|\"%s\"| %s\n", ids[e.SourceID], mermaidText(label, maxMermaidLabel), ids[e.TargetID])
+	}
+	for _, def := range mermaidClassDefs {
+		refs := classes[def.nodeType]
+		if len(refs) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "    classDef %s %s\n", def.nodeType, def.style)
+		fmt.Fprintf(&sb, "    class %s %s\n", strings.Join(refs, ","), def.nodeType)
+	}
+	if len(order) > 0 {
+		sb.WriteString("    style n0 stroke-width:3px\n")
+	}
+	return sb.String()
+}
+
+// nodeLabel returns the type of a node returned by GetNodeByID and the text
+// that names it.
+func nodeLabel(node any) (nodeType, text string) {
+	switch n := node.(type) {
+	case *Fact:
+		return "fact", n.Content
+	case *Decision:
+		return "decision", n.Title
+	case *Entity:
+		return "entity", n.Name
+	case *Event:
+		return "event", n.Title
+	case *Topic:
+		return "topic", n.Name
+	default:
+		return "node", fmt.Sprintf("%v", node)
+	}
+}
+
+// mermaidText makes s safe inside a quoted Mermaid label: one line, at most
+// maxLen characters, with quotes and angle brackets as entity codes.
+func mermaidText(s string, maxLen int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > maxLen {
+		s = string(r[:maxLen]) + "..."
+	}
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;").Replace(s)
+}
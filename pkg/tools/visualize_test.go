@@ -0,0 +1,140 @@
+// Copyright (C) 2025-2026 Kraklabs. All rights reserved.
+// Use of this source code is governed by the AGPL-3.0
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// visualizeMock serves a small graph: a topic with a fact and a decision,
+// the decision involving an entity.
+func visualizeMock() *MockQuerier {
+	nodes := map[string]any{
+		"top:billing": &Topic{ID: "top:billing", Name: "billing"},
+		"fact:1":      &Fact{ID: "fact:1", Content: `Invoices use "net 30" terms`},
+		"dec:1":       &Decision{ID: "dec:1", Title: "Use Stripe"},
+		"ent:stripe":  &Entity{ID: "ent:stripe", Name: "Stripe"},
+	}
+	edges := []GraphEdge{
+		{Type: "fact_topic", SourceID: "fact:1", TargetID: "top:billing", Weight: 1},
+		{Type: "decision_topic", SourceID: "dec:1", TargetID: "top:billing", Weight: 1},
+		{Type: "decision_entity", SourceID: "dec:1", TargetID: "ent:stripe", Label: "subject", Weight: 1},
+	}
+	return &MockQuerier{
+		GetNodeByIDFunc: func(ctx context.Context, nodeID string) (any, error) {
+			if n, ok := nodes[nodeID]; ok {
+				return n, nil
+			}
+			return nil, fmt.Errorf("node %q not found", nodeID)
+		},
+		GetNodeEdgesFunc: func(ctx context.Context, nodeID string) ([]GraphEdge, error) {
+			var out []GraphEdge
+			for _, e := range edges {
+				if e.SourceID == nodeID || e.TargetID == nodeID {
+					out = append(out, e)
+				}
+			}
+			return out, nil
+		},
+		ExactSearchFunc: func(ctx context.Context, query string, nodeTypes []string, limit int) ([]SearchResult, error) {
+			if strings.Contains("billing", strings.ToLower(query)) {
+				return []SearchResult{{NodeType: "topic", ID: "top:billing", Content: "billing"}}, nil
+			}
+			return nil, nil
+		},
+	}
+}
+
+func TestVisualizeTopic(t *testing.T) {
+	result, err := Visualize(context.Background(), visualizeMock(), map[string]any{"topic": "Billing"})
+	if err != nil {
+		t.Fatalf("Visualize() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Visualize() returned error: %s", result.Text)
+	}
+
+	for _, want := range []string{
+		"## Memory Map: billing",
+		"3 nodes, 2 edges within 1 hop(s) of top:billing.",
+		"```mermaid\nflowchart LR\n",
+		`    n0(("topic: billing"))`,
+		`    n1["fact: Invoices use #quot;net 30#quot; terms"]`,
+		`    n2{{"decision: Use Stripe"}}`,
+		`    n1 -->|"fact_topic"| n0`,
+		`    n2 -->|"decision_topic"| n0`,
+		"    class n2 decision",
+		"    style n0 stroke-width:3px",
+	} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("output missing %q:\n%s", want, result.Text)
+		}
+	}
+	if strings.Contains(result.Text, "entity: Stripe") {
+		t.Errorf("depth 1 should not reach the entity:\n%s", result.Text)
+	}
+}
+
+func TestVisualizeDepthAndLimit(t *testing.T) {
+	result, _ := Visualize(context.Background(), visualizeMock(), map[string]any{"node_id": "fact:1", "depth": float64(3)})
+	if !strings.Contains(result.Text, "4 nodes, 3 edges") {
+		t.Errorf("depth 3 should reach the whole graph:\n%s", result.Text)
+	}
+	if !strings.Contains(result.Text, `-->|"decision_entity: subject"|`) {
+		t.Errorf("missing labelled edge:\n%s", result.Text)
+	}
+
+	result, _ = Visualize(context.Background(), visualizeMock(), map[string]any{"node_id": "top:billing", "max_nodes": float64(2)})
+	if !strings.Contains(result.Text, "2 nodes, 1 edges") || !strings.Contains(result.Text, "Stopped at 2 nodes") {
+		t.Errorf("max_nodes should cut the walk:\n%s", result.Text)
+	}
+}
+
+func TestVisualizeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"missing", map[string]any{}, "Missing required parameter"},
+		{"both", map[string]any{"node_id": "fact:1", "topic": "billing"}, "not both"},
+		{"unknown topic", map[string]any{"topic": "hiring"}, "Topic not found"},
+		{"unknown node", map[string]any{"node_id": "fact:404"}, "Node not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _ := Visualize(context.Background(), visualizeMock(), tt.args)
+			if !result.IsError || !strings.Contains(result.Text, tt.want) {
+				t.Errorf("Visualize() = %q, want error containing %q", result.Text, tt.want)
+			}
+		})
+	}
+}
+
+func TestVisualizeJSON(t *testing.T) {
+	result, _ := Visualize(context.Background(), visualizeMock(), map[string]any{"node_id": "dec:1", "response_format": "json"})
+	var got struct {
+		RootID  string      `json:"root_id"`
+		Nodes   []NodeJSON  `json:"nodes"`
+		Edges   []GraphEdge `json:"edges"`
+		Mermaid string      `json:"mermaid"`
+	}
+	if err := json.Unmarshal([]byte(result.Text), &got); err != nil {
+		t.Fatalf("Visualize() output is not JSON: %v\n%s", err, result.Text)
+	}
+	if got.RootID != "dec:1" || len(got.Nodes) != 3 || len(got.Edges) != 2 {
+		t.Fatalf("Visualize() JSON = %+v", got)
+	}
+	if got.Nodes[0].Type != "decision" {
+		t.Errorf("first node type = %q, want decision", got.Nodes[0].Type)
+	}
+	if !strings.HasPrefix(got.Mermaid, "flowchart LR\n") {
+		t.Errorf("mermaid = %q", got.Mermaid)
+	}
+}